@@ -0,0 +1,878 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"frp-cli-ui/internal/installer"
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// cliCommand 命令行子命令
+type cliCommand struct {
+	name    string
+	usage   string
+	summary string
+	run     func(args []string) error
+}
+
+// cliCommands 返回所有子命令
+func cliCommands() []cliCommand {
+	return []cliCommand{
+		{"start", i18n.T("start server|client [-c 配置文件]"), i18n.T("在前台启动服务端或客户端，Ctrl+C 停止"), runStart},
+		{"stop", "stop server|client", i18n.T("停止由本工具启动的服务端或客户端"), runStop},
+		{"status", "status [--json]", i18n.T("查看安装与运行状态"), runStatus},
+		{"proxy", i18n.T("proxy list [--target 名称] [--api 地址] [--user 用户] [--password 密码] [--json]"), i18n.T("从 frps Dashboard API 列出代理"), runProxy},
+		{"config", i18n.T("config validate [-c 配置文件] [--live] [--json]"), i18n.T("校验配置文件，--live 同时检查端口占用，--json 输出 JSON；退出码 0 通过、1 有警告、2 有错误"), runConfig},
+		{"install", i18n.T("install [--version 版本|latest] [--channel stable|prerelease] [--dir 目录 | --system | --use-existing] [--mirror 镜像] [--proxy 代理] [--archive 安装包] [--skip-verify]"), i18n.T("下载并安装 FRP"), runInstall},
+		{"versions", i18n.T("versions [list | use <版本> | rollback | pin server|client <版本|current> | remove <版本>]"), i18n.T("管理 ~/.frp-manager 中并存的 FRP 版本"), runVersions},
+		{"self-update", "self-update [--check] [--skip-verify]", i18n.T("检查并安装 frp-cli-ui 自身的新版本"), runSelfUpdate},
+		{"version", "version", i18n.T("显示版本信息"), runVersion},
+	}
+}
+
+// isCLIInvocation 判断命令行参数是否为子命令调用
+func isCLIInvocation(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "help", "-h", "--help":
+		return true
+	}
+	for _, cmd := range cliCommands() {
+		if cmd.name == args[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// runCLI 执行子命令并返回退出码
+func runCLI(args []string) int {
+	for _, cmd := range cliCommands() {
+		if cmd.name == args[0] {
+			if err := cmd.run(args[1:]); err != nil {
+				var exit *exitCodeError
+				if !errors.As(err, &exit) {
+					fmt.Fprintf(os.Stderr, i18n.T("错误: %v\n"), err)
+					return 1
+				}
+				if exit.err != nil {
+					fmt.Fprintf(os.Stderr, i18n.T("错误: %v\n"), exit.err)
+				}
+				return exit.code
+			}
+			return 0
+		}
+	}
+
+	printUsage(os.Stdout)
+	return 0
+}
+
+// printUsage 打印帮助信息
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "%s %s\n\n", config.AppName, config.AppVersion)
+	fmt.Fprintln(w, i18n.T("用法:"))
+	fmt.Fprintln(w, i18n.T("  frp-cli-ui              启动终端界面"))
+	fmt.Fprintln(w, i18n.T("  frp-cli-ui --headless [--listen 地址] [--server] [--client]   无界面运行，提供只读的 HTTP 状态页"))
+	fmt.Fprintln(w, i18n.T("  frp-cli-ui <命令> [参数]"))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, i18n.T("命令:"))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, cmd := range cliCommands() {
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.usage, cmd.summary)
+	}
+	tw.Flush()
+}
+
+// parseService 解析 server/client 参数
+func parseService(args []string) (string, []string, error) {
+	if len(args) == 0 || (args[0] != "server" && args[0] != "client") {
+		return "", nil, i18n.Errorf("请指定 server 或 client")
+	}
+	return args[0], args[1:], nil
+}
+
+// defaultConfigPath 返回应用设置中服务对应的配置文件
+func defaultConfigPath(svc string) string {
+	settings, _ := config.LoadAppSettings()
+	if svc == "server" {
+		return settings.ServerConfigPath
+	}
+	return settings.ClientConfigPath
+}
+
+// runStart 在前台启动服务并输出日志，收到中断信号后停止
+func runStart(args []string) error {
+	svc, rest, err := parseService(args)
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("start", flag.ContinueOnError)
+	configPath := fs.String("c", defaultConfigPath(svc), i18n.T("配置文件路径"))
+	if err := fs.Parse(rest); err != nil {
+		return err
+	}
+
+	manager := newHookedManager()
+	defer manager.Close()
+	if svc == "server" {
+		err = manager.StartServer(*configPath)
+	} else {
+		err = manager.StartClient(*configPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	logChan, unsubscribe := manager.SubscribeLogs()
+	defer unsubscribe()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case logMsg := <-logChan:
+			fmt.Printf("[%s] [%s] [%s] %s\n",
+				logMsg.Timestamp.Format("15:04:05"), logMsg.Level, logMsg.Source, logMsg.Message)
+		case <-signals:
+			return stopService(manager, svc)
+		case <-ticker.C:
+			// 进程自行退出时结束前台运行
+			status := manager.GetServerStatus()
+			if svc == "client" {
+				status = manager.GetClientStatus()
+			}
+			if !status.IsRunning {
+				return i18n.Errorf("%s 进程已退出", svc)
+			}
+		}
+	}
+}
+
+// runStop 停止服务
+func runStop(args []string) error {
+	svc, _, err := parseService(args)
+	if err != nil {
+		return err
+	}
+	manager := newHookedManager()
+	defer manager.Close()
+	return stopService(manager, svc)
+}
+
+// newHookedManager 创建进程管理器，并应用设置中的服务生命周期钩子
+func newHookedManager() *service.Manager {
+	settings, _ := config.LoadAppSettings()
+	manager := service.NewManager()
+	manager.SetHooks("server", service.HooksFromSettings(settings.ServerHooks))
+	manager.SetHooks("client", service.HooksFromSettings(settings.ClientHooks))
+	return manager
+}
+
+// stopService 停止指定服务
+func stopService(manager *service.Manager, svc string) error {
+	if svc == "server" {
+		return manager.StopServer()
+	}
+	return manager.StopClient()
+}
+
+// cliProcessStatus 状态输出中的进程信息
+type cliProcessStatus struct {
+	Running    bool       `json:"running"`
+	PID        int        `json:"pid,omitempty"`
+	ConfigPath string     `json:"configPath,omitempty"`
+	StartTime  *time.Time `json:"startTime,omitempty"`
+}
+
+// cliArchMismatch 状态输出中与本机系统或架构不符的程序
+type cliArchMismatch struct {
+	Name      string   `json:"name"`
+	Path      string   `json:"path"`
+	Platforms []string `json:"platforms"` // 程序适用的平台，如 linux/amd64
+}
+
+// cliStatus 状态输出
+type cliStatus struct {
+	Installed  bool              `json:"installed"`
+	Version    string            `json:"version,omitempty"`
+	InstallDir string            `json:"installDir"`
+	FrpsPath   string            `json:"frpsPath,omitempty"`
+	FrpcPath   string            `json:"frpcPath,omitempty"`
+	External   bool              `json:"external,omitempty"`
+	Origin     string            `json:"origin,omitempty"` // 程序来源，如 managed、brew、scoop、system、path
+	Mismatches []cliArchMismatch `json:"archMismatches,omitempty"`
+	Server     cliProcessStatus  `json:"server"`
+	Client     cliProcessStatus  `json:"client"`
+}
+
+// runStatus 输出安装与运行状态
+func runStatus(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, i18n.T("以 JSON 格式输出"))
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	status := cliStatus{}
+	inst := installer.NewInstaller("")
+	status.InstallDir = inst.GetInstallDir()
+	if installStatus, err := inst.CheckInstallation(); err == nil {
+		status.Installed = installStatus.IsInstalled
+		status.Version = installStatus.Version
+		status.FrpsPath = installStatus.FrpsPath
+		status.FrpcPath = installStatus.FrpcPath
+		status.External = installStatus.External
+		if installStatus.IsInstalled {
+			status.Origin = installStatus.Origin
+		}
+		for _, mismatch := range installStatus.Mismatches {
+			item := cliArchMismatch{Name: mismatch.Name, Path: mismatch.Path}
+			for _, p := range mismatch.Platforms {
+				item.Platforms = append(item.Platforms, p.String())
+			}
+			status.Mismatches = append(status.Mismatches, item)
+		}
+	}
+
+	manager := service.NewManager()
+	defer manager.Close()
+	status.Server = toCLIProcessStatus(manager.GetServerStatus(), manager.GetProcessState("server"))
+	status.Client = toCLIProcessStatus(manager.GetClientStatus(), manager.GetProcessState("client"))
+
+	if *asJSON {
+		return printJSON(status)
+	}
+
+	if status.External {
+		fmt.Printf(i18n.T("FRP: 使用 %s 中的程序 (版本: %s, frps: %s, frpc: %s)\n"),
+			installer.OriginLabel(status.Origin), status.Version, status.FrpsPath, status.FrpcPath)
+	} else if installer.IsPackageOrigin(status.Origin) {
+		fmt.Printf(i18n.T("FRP: 使用 %s 安装的程序 (版本: %s, 目录: %s)\n"), installer.OriginLabel(status.Origin), status.Version, status.InstallDir)
+	} else if status.Installed {
+		fmt.Printf(i18n.T("FRP: 已安装 (版本: %s, 目录: %s)\n"), status.Version, status.InstallDir)
+	} else {
+		fmt.Printf(i18n.T("FRP: 未安装 (目录: %s)\n"), status.InstallDir)
+	}
+	for _, mismatch := range status.Mismatches {
+		fmt.Printf(i18n.T("⚠️ %s (%s) 适用于 %s，本机为 %s/%s，无法运行；执行 frp-cli-ui install 重新安装\n"),
+			mismatch.Name, mismatch.Path, strings.Join(mismatch.Platforms, ", "), runtime.GOOS, runtime.GOARCH)
+	}
+	printProcessStatus(i18n.T("服务端"), status.Server)
+	printProcessStatus(i18n.T("客户端"), status.Client)
+	return nil
+}
+
+// toCLIProcessStatus 转换进程状态
+func toCLIProcessStatus(status service.ProcessStatus, state *service.ProcessState) cliProcessStatus {
+	result := cliProcessStatus{
+		Running: status.IsRunning,
+		PID:     status.PID,
+	}
+	if !status.StartTime.IsZero() {
+		result.StartTime = &status.StartTime
+	}
+	if state != nil {
+		result.ConfigPath = state.ConfigPath
+	}
+	return result
+}
+
+// printProcessStatus 打印进程状态
+func printProcessStatus(label string, status cliProcessStatus) {
+	if !status.Running {
+		fmt.Printf(i18n.T("%s: 未运行\n"), label)
+		return
+	}
+	startTime := i18n.T("未知")
+	if status.StartTime != nil {
+		startTime = status.StartTime.Format(time.DateTime)
+	}
+	fmt.Printf(i18n.T("%s: 运行中 (PID: %d, 配置: %s, 启动于: %s)\n"),
+		label, status.PID, status.ConfigPath, startTime)
+}
+
+// runProxy 代理相关命令
+func runProxy(args []string) error {
+	if len(args) == 0 || args[0] != "list" {
+		return i18n.Errorf("用法: proxy list [--target 名称] [--api 地址] [--user 用户] [--password 密码] [--json]")
+	}
+
+	settings, _ := config.LoadAppSettings()
+	fs := flag.NewFlagSet("proxy list", flag.ContinueOnError)
+	targetName := fs.String("target", settings.ActiveDashboardTarget().Name, i18n.T("应用设置中的 Dashboard 目标名称"))
+	apiURL := fs.String("api", "", i18n.T("frps Dashboard API 地址，覆盖目标中的地址"))
+	user := fs.String("user", "", i18n.T("Dashboard 用户名，覆盖目标中的用户名"))
+	password := fs.String("password", "", i18n.T("Dashboard 密码，覆盖目标中的密码"))
+	asJSON := fs.Bool("json", false, i18n.T("以 JSON 格式输出"))
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	target, err := findDashboardTarget(settings, *targetName)
+	if err != nil {
+		return err
+	}
+	if *apiURL != "" {
+		target.URL = *apiURL
+	}
+	if *user != "" {
+		target.User, target.Token = *user, ""
+	}
+	if *password != "" {
+		target.Password = *password
+	}
+
+	client := service.NewAPIClient(target.URL, target.User, target.Password)
+	if err := client.SetTarget(target); err != nil {
+		return err
+	}
+	if !client.IsServerReachable() {
+		return i18n.Errorf("无法连接 frps Dashboard API: %s", target.URL)
+	}
+
+	proxies, err := client.GetProxyList()
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(proxies)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, i18n.T("名称\t类型\t状态\t远程端口\t连接数\t今日上行\t今日下行"))
+	for _, proxy := range proxies {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%s\t%s\n",
+			proxy.Name, proxy.Conf.Type, proxy.Status, proxy.Conf.RemotePort, proxy.CurConns,
+			service.FormatTraffic(proxy.TodayTrafficIn), service.FormatTraffic(proxy.TodayTrafficOut))
+	}
+	return tw.Flush()
+}
+
+// findDashboardTarget 按名称查找应用设置中的 Dashboard 目标
+func findDashboardTarget(settings *config.AppSettings, name string) (config.DashboardTarget, error) {
+	names := make([]string, 0, len(settings.DashboardTargets)+1)
+	for _, target := range settings.DashboardTargetList() {
+		if target.Name == name {
+			return target, nil
+		}
+		names = append(names, target.Name)
+	}
+	return config.DashboardTarget{}, i18n.Errorf("未找到 Dashboard 目标 %s，可选: %s", name, strings.Join(names, " / "))
+}
+
+// 配置校验的退出码，便于在 CI 中区分只有警告和存在错误
+const (
+	exitValidateWarning = 1
+	exitValidateError   = 2
+)
+
+// exitCodeError 要求以指定退出码结束的错误，err 为空时不再输出错误信息
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	if e.err == nil {
+		return ""
+	}
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// 校验问题的级别与来源
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+
+	findingValidate = "validate" // 字段取值校验
+	findingPort     = "port"     // 本机端口占用检查
+	findingSchema   = "schema"   // frp 配置结构检查
+)
+
+// validationFinding 配置校验发现的一个问题，path 为字段路径，如 proxies[web].localPort，无法定位时为空
+type validationFinding struct {
+	Severity string `json:"severity"`
+	Source   string `json:"source"`
+	Path     string `json:"path"`
+	Message  string `json:"message"`
+}
+
+// validationReport config validate --json 输出的校验结果
+type validationReport struct {
+	Config   string              `json:"config"`
+	Valid    bool                `json:"valid"`
+	Errors   int                 `json:"errors"`
+	Warnings int                 `json:"warnings"`
+	Findings []validationFinding `json:"findings"`
+}
+
+// add 记录一个问题并累计数量
+func (r *validationReport) add(severity, source, path, message string) {
+	r.Findings = append(r.Findings, validationFinding{Severity: severity, Source: source, Path: path, Message: message})
+	if severity == severityError {
+		r.Errors++
+		r.Valid = false
+	} else {
+		r.Warnings++
+	}
+}
+
+// exitCode 返回校验结果对应的退出码：0 通过，1 只有警告，2 存在错误
+func (r *validationReport) exitCode() int {
+	switch {
+	case r.Errors > 0:
+		return exitValidateError
+	case r.Warnings > 0:
+		return exitValidateWarning
+	}
+	return 0
+}
+
+// runConfig 校验配置文件，退出码 0 表示通过，1 表示只有警告，2 表示存在错误
+func runConfig(args []string) error {
+	if len(args) == 0 || args[0] != "validate" {
+		return i18n.Errorf("用法: config validate [-c 配置文件] [--live] [--json]")
+	}
+
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	configPath := fs.String("c", defaultConfigPath("client"), i18n.T("配置文件路径"))
+	live := fs.Bool("live", false, i18n.T("检查本机端口占用"))
+	asJSON := fs.Bool("json", false, i18n.T("以 JSON 输出校验结果，供 CI 使用"))
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	report := validateConfigFile(*configPath, *live)
+	code := report.exitCode()
+	if *asJSON {
+		if err := printJSON(report); err != nil {
+			return err
+		}
+		if code != 0 {
+			return &exitCodeError{code: code}
+		}
+		return nil
+	}
+
+	for _, f := range report.Findings {
+		switch f.Source {
+		case findingSchema:
+			fmt.Printf("📐 %s: %s\n", f.Path, f.Message)
+		case findingPort:
+			fmt.Printf("⚠️ %s\n", f.Message)
+		default:
+			fmt.Printf("❌ %s\n", f.Message)
+		}
+	}
+
+	switch code {
+	case exitValidateError:
+		return &exitCodeError{code: code, err: i18n.Errorf("配置文件 %s 校验未通过，共 %d 个错误", *configPath, report.Errors)}
+	case exitValidateWarning:
+		fmt.Printf(i18n.T("✅ 配置文件 %s 校验通过，有 %d 个警告\n"), *configPath, report.Warnings)
+		return &exitCodeError{code: code}
+	}
+	fmt.Printf(i18n.T("✅ 配置文件 %s 校验通过\n"), *configPath)
+	return nil
+}
+
+// validateConfigFile 校验配置文件并汇总所有问题，配置无法加载时记为一个错误
+func validateConfigFile(configPath string, live bool) validationReport {
+	report := validationReport{Config: configPath, Valid: true, Findings: []validationFinding{}}
+
+	cfg, err := config.NewLoader(configPath).Load()
+	if err != nil {
+		report.add(severityError, findingValidate, "", err.Error())
+		return report
+	}
+
+	validator := config.NewValidator()
+	validator.SetLiveCheck(live)
+	for _, issue := range validator.ValidateConfigIssues(cfg) {
+		report.add(severityError, findingValidate, issue.Field, issue.Message)
+	}
+	for _, issue := range validator.CheckPortAvailabilityIssues(cfg) {
+		report.add(severityWarning, findingPort, issue.Field, issue.Message)
+	}
+
+	// 按 frp 的配置结构检查文件中的字段名和类型，frp 会静默忽略拼错的字段
+	if data, err := os.ReadFile(configPath); err == nil {
+		issues, err := config.CheckSchema(data, config.DetectFormat(configPath), cfg.IsServerConfig())
+		if err != nil {
+			report.add(severityError, findingSchema, "", err.Error())
+		}
+		for _, issue := range issues {
+			report.add(severityWarning, findingSchema, issue.Path, issue.Message)
+		}
+	}
+	return report
+}
+
+// runInstall 安装 FRP
+func runInstall(args []string) error {
+	fs := flag.NewFlagSet("install", flag.ContinueOnError)
+	version := fs.String("version", "", i18n.T("要安装的 FRP 版本，latest 表示更新通道中的最新版本"))
+	channel := fs.String("channel", "", i18n.T("更新通道 stable 或 prerelease，默认使用已保存的设置；prerelease 且未指定版本时安装最新的预发布版本"))
+	dir := fs.String("dir", "", i18n.T("安装目录，默认使用设置中记录的目录或 ~/.frp-manager"))
+	system := fs.Bool("system", false, i18n.T("系统范围安装到 /usr/local/bin，需要时通过 sudo 提权"))
+	mirror := fs.String("mirror", "", i18n.T("下载镜像，默认使用已保存的设置"))
+	proxy := fs.String("proxy", "", i18n.T("下载代理 (http/https/socks5)，默认使用已保存的设置"))
+	skipVerify := fs.Bool("skip-verify", false, i18n.T("跳过 SHA256 校验（不推荐）"))
+	useExisting := fs.Bool("use-existing", false, i18n.T("直接使用包管理器或手动安装的 frps/frpc，不下载"))
+	archive := fs.String("archive", "", i18n.T("从本地的 frp_*.tar.gz/zip 安装，不访问网络；校验文件需放在同一目录"))
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *archive != "" && (*version != "" || *channel != "") {
+		return i18n.Errorf("--archive 安装压缩包中的版本，不能与 --version 或 --channel 同时使用")
+	}
+
+	if *useExisting {
+		return useExistingFRP()
+	}
+	if *system && *dir != "" {
+		return i18n.Errorf("--system 与 --dir 不能同时使用")
+	}
+	installDir := *dir
+	if *system {
+		if runtime.GOOS == "windows" {
+			return i18n.Errorf("Windows 不支持 --system，请用 --dir 指定安装目录")
+		}
+		installDir = installer.SystemInstallDir
+	}
+	if installDir != "" {
+		abs, err := filepath.Abs(installDir)
+		if err != nil {
+			return err
+		}
+		installDir = abs
+	}
+
+	inst := installer.NewInstaller(installDir)
+	switch *channel {
+	case "":
+	case config.ReleaseChannelStable, config.ReleaseChannelPrerelease:
+		inst.SetPrerelease(*channel == config.ReleaseChannelPrerelease)
+		if *version == "" && inst.IncludesPrereleases() {
+			*version = "latest"
+		}
+	default:
+		return i18n.Errorf("不支持的更新通道: %s，可选: %s", *channel, strings.Join(config.ReleaseChannels, " / "))
+	}
+	if *mirror != "" {
+		if err := installer.ValidateMirror(*mirror); err != nil {
+			return err
+		}
+		inst.SetDownloadMirror(*mirror)
+	}
+	if *proxy != "" {
+		if err := inst.SetDownloadProxy(*proxy); err != nil {
+			return err
+		}
+	}
+	inst.SetSkipVerify(*skipVerify)
+
+	if *archive != "" {
+		return installArchive(inst, *archive, installDir)
+	}
+
+	// 下载镜像和代理设置好之后再获取版本列表
+	if *version == "latest" {
+		release, err := inst.LatestRelease()
+		if err != nil {
+			return err
+		}
+		*version = release.Version()
+	}
+	if *version != "" {
+		inst.SetVersion(*version)
+	}
+	if installer.IsPrerelease(inst.GetVersion()) {
+		fmt.Printf(i18n.T("⚠️ %s 是预发布版本，可能不稳定\n"), inst.GetVersion())
+	}
+
+	install := inst.InstallFRP
+	if status, err := inst.CheckInstallation(); err == nil {
+		if status.CanAdopt() {
+			fmt.Printf(i18n.T("提示: 检测到 %s 安装的 FRP %s (%s)，可使用 install --use-existing 直接使用，不必重复下载\n"),
+				installer.OriginLabel(status.Origin), status.Version, filepath.Dir(status.FrpsPath))
+		}
+		// 已有的程序与本机架构不符时重新下载，不直接切换到同一版本
+		for _, mismatch := range status.Mismatches {
+			fmt.Println("⚠️ " + mismatch.Error())
+			install = inst.ReinstallFRP
+		}
+	}
+
+	fmt.Printf(i18n.T("正在安装 FRP %s 到 %s ...\n"), inst.GetVersion(), inst.GetInstallDir())
+	if inst.NeedsElevation() {
+		if err := installElevated(inst, inst.StageFRP); err != nil {
+			return err
+		}
+	} else if err := install(); err != nil {
+		return err
+	}
+	return finishInstall(inst, installDir)
+}
+
+// installArchive 从本地压缩包安装 FRP，用于无法访问 GitHub 的离线环境
+func installArchive(inst *installer.Installer, path, installDir string) error {
+	archive, err := installer.ParseArchive(path)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(i18n.T("正在从 %s 安装 FRP %s 到 %s ...\n"), archive.Path, archive.Version, inst.GetInstallDir())
+	if inst.NeedsElevation() {
+		stage := func() (string, error) { return inst.StageArchive(archive.Path) }
+		if err := installElevated(inst, stage); err != nil {
+			return err
+		}
+	} else if err := inst.InstallFromArchive(archive.Path); err != nil {
+		return err
+	}
+	return finishInstall(inst, installDir)
+}
+
+// finishInstall 记录安装位置，之后启动 frps/frpc 和检查安装状态时直接使用
+func finishInstall(inst *installer.Installer, installDir string) error {
+	if installDir != "" {
+		if inst.IsDefaultDir() {
+			installDir = ""
+		}
+		if err := saveInstallDir(installDir); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(i18n.T("✅ FRP 安装成功"))
+	return nil
+}
+
+// useExistingFRP 把 PATH 或包管理器目录中检测到的 frps/frpc 所在目录记为安装目录
+func useExistingFRP() error {
+	status, err := installer.NewInstaller("").CheckInstallation()
+	if err != nil {
+		return err
+	}
+	if !status.CanAdopt() {
+		if status.IsInstalled && !status.External {
+			fmt.Printf(i18n.T("FRP 已在使用 %s 中的程序\n"), status.InstallDir)
+			return nil
+		}
+		return i18n.Errorf("没有在 PATH 或包管理器目录中找到位于同一目录的 frps 和 frpc")
+	}
+
+	dir := filepath.Dir(status.FrpsPath)
+	if err := saveInstallDir(dir); err != nil {
+		return err
+	}
+	fmt.Printf(i18n.T("✅ 已改用 %s 安装的 FRP %s: %s\n"), installer.OriginLabel(status.Origin), status.Version, dir)
+	return nil
+}
+
+// saveInstallDir 把安装目录记录到应用设置，为空时恢复默认目录
+func saveInstallDir(dir string) error {
+	settings, err := config.LoadAppSettings()
+	if err != nil {
+		return err
+	}
+	settings.InstallDir = dir
+	return config.SaveAppSettings(settings)
+}
+
+// installElevated 由 stage 下载或解压到临时目录后，通过 sudo 安装到当前用户无权写入的目录
+func installElevated(inst *installer.Installer, stage func() (string, error)) error {
+	if err := inst.CheckNotPackageManaged(); err != nil {
+		return err
+	}
+	staged, err := stage()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staged)
+
+	fmt.Printf(i18n.T("没有写入 %s 的权限，将通过 sudo 安装\n"), inst.GetInstallDir())
+	cmd := inst.ElevatedInstallCommand(staged)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return i18n.Errorf("sudo 安装失败: %w", err)
+	}
+	return nil
+}
+
+// runVersions 列出、切换、回滚和删除默认安装目录中并存的 FRP 版本
+func runVersions(args []string) error {
+	usage := i18n.Errorf("用法: versions [list | use <版本> | rollback | pin server|client <版本|current> | remove <版本>]")
+	inst := installer.NewInstaller("")
+	if !inst.ManagesVersions() {
+		return i18n.Errorf("安装目录 %s 不支持多版本，只有安装在 %s 时才会保留多个版本", inst.GetInstallDir(), installer.DefaultInstallDir())
+	}
+
+	action := "list"
+	if len(args) > 0 {
+		action, args = args[0], args[1:]
+	}
+	switch {
+	case action == "list" && len(args) == 0:
+		return listVersions(inst)
+	case action == "use" && len(args) == 1:
+		if err := inst.UseVersion(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf(i18n.T("✅ 已切换到 FRP %s，正在运行的 frps/frpc 重启后生效\n"), args[0])
+	case action == "rollback" && len(args) == 0:
+		version, err := inst.Rollback()
+		if err != nil {
+			return err
+		}
+		fmt.Printf(i18n.T("↩ 已回滚到 FRP %s，正在运行的 frps/frpc 重启后生效\n"), version)
+	case action == "pin" && len(args) == 2:
+		return pinVersion(inst, args[0], args[1])
+	case action == "remove" && len(args) == 1:
+		if err := inst.RemoveVersion(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf(i18n.T("🗑 已删除 FRP %s\n"), args[0])
+	default:
+		return usage
+	}
+	return nil
+}
+
+// listVersions 列出已安装的版本并标出当前、上一个和固定的版本
+func listVersions(inst *installer.Installer) error {
+	versions, err := inst.InstalledVersions()
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		fmt.Println(i18n.T("没有按版本安装的 FRP，使用 install 安装后会保留在 versions 目录中"))
+		return nil
+	}
+
+	settings, _ := config.LoadAppSettings()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, v := range versions {
+		var marks []string
+		if v.Current {
+			marks = append(marks, i18n.T("当前"))
+		}
+		if v.Previous {
+			marks = append(marks, i18n.T("上一个"))
+		}
+		if v.Version == settings.ServerVersion {
+			marks = append(marks, i18n.T("frps 固定"))
+		}
+		if v.Version == settings.ClientVersion {
+			marks = append(marks, i18n.T("frpc 固定"))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", v.Version, strings.Join(marks, ", "), v.Dir)
+	}
+	return tw.Flush()
+}
+
+// pinVersion 让 frps 或 frpc 固定使用已安装的版本，current 表示跟随当前版本
+func pinVersion(inst *installer.Installer, svc, version string) error {
+	if svc != "server" && svc != "client" {
+		return i18n.Errorf("请指定 server 或 client")
+	}
+	if version == "current" {
+		version = ""
+	} else if !inst.HasVersion(version) {
+		return i18n.Errorf("版本 %s 未安装", version)
+	}
+
+	settings, err := config.LoadAppSettings()
+	if err != nil {
+		return err
+	}
+	if svc == "server" {
+		settings.ServerVersion = version
+	} else {
+		settings.ClientVersion = version
+	}
+	if err := config.SaveAppSettings(settings); err != nil {
+		return err
+	}
+
+	if version == "" {
+		fmt.Printf(i18n.T("✅ %s 改为使用当前版本\n"), svc)
+	} else {
+		fmt.Printf(i18n.T("✅ %s 固定使用 FRP %s，重启后生效\n"), svc, version)
+	}
+	return nil
+}
+
+// runSelfUpdate 检查并安装 frp-cli-ui 的新版本，替换当前程序后下次运行生效
+func runSelfUpdate(args []string) error {
+	fs := flag.NewFlagSet("self-update", flag.ContinueOnError)
+	checkOnly := fs.Bool("check", false, i18n.T("只检查是否有新版本，不安装"))
+	skipVerify := fs.Bool("skip-verify", false, i18n.T("跳过 SHA256 校验（不推荐）"))
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	inst := installer.NewInstaller("")
+	inst.SetSkipVerify(*skipVerify)
+	updater := installer.NewSelfUpdater(inst)
+	update, err := updater.Check(true)
+	if err != nil {
+		return err
+	}
+	if !update.Available() {
+		fmt.Printf(i18n.T("✨ %s %s 已是最新版本\n"), config.AppName, config.AppVersion)
+		return nil
+	}
+
+	fmt.Printf(i18n.T("发现新版本: %s (当前: %s)\n"), update.Version(), update.Current)
+	if *checkOnly {
+		return nil
+	}
+	fmt.Printf(i18n.T("正在下载 %s ...\n"), update.Asset.Name)
+	exe, err := updater.Apply(update)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(i18n.T("✅ 已更新到 %s: %s，重新运行即可使用新版本\n"), update.Version(), exe)
+	return nil
+}
+
+// runVersion 输出版本信息
+func runVersion(args []string) error {
+	fmt.Printf("%s %s\n", config.AppName, config.AppVersion)
+	return nil
+}
+
+// printJSON 以缩进 JSON 输出
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// isHeadlessInvocation 判断是否以无界面模式运行
+func isHeadlessInvocation(args []string) bool {
+	return slices.Contains(args, "--headless") || slices.Contains(args, "-headless")
+}
+
+// runHeadless 不启动终端界面，在前台运行进程管理、健康监控、自动启动和 Webhook，
+// 并提供只读的 HTTP 状态页，收到中断信号后退出
+func runHeadless(args []string) error {
+	fs := flag.NewFlagSet("headless", flag.ContinueOnError)
+	fs.Bool("headless", true, i18n.T("以无界面模式运行"))
+	listen := fs.String("listen", service.DefaultStatusAddr, i18n.T("状态页监听地址，如 0.0.0.0:7600"))
+	startServer := fs.Bool("server", false, i18n.T("启动时运行服务端"))
+	startClient := fs.Bool("client", false, i18n.T("启动时运行客户端"))
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	settings, _ := config.LoadAppSettings()
+	if err := config.InitializeWorkspace(); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.Sprintf("初始化工作空间失败: %v", err))
+	}
+
+	events := service.NewEventBus()
+	manager := service.NewManager()
+	defer manager.Close()
+	manager.SetEventBus(events)
+	manager.SetRestartPolicy("server", service.RestartPolicyFromSettings(settings, settings.AutoRestartServer))
+	manager.SetRestartPolicy("client", service.RestartPolicyFromSettings(settings, settings.AutoRestartClient))
+	manager.SetHooks("server", service.HooksFromSettings(settings.ServerHooks))
+	manager.SetHooks("client", service.HooksFromSettings(settings.ClientHooks))
+	manager.SetLogFile("server", service.LogFileFromSettings(settings.ServerLogFile, settings.ServerConfigPath))
+	manager.SetLogFile("client", service.LogFileFromSettings(settings.ClientLogFile, settings.ClientConfigPath))
+	apiClient := service.NewAPIClient(settings.DashboardURL, settings.DashboardUser, settings.DashboardPassword)
+	if err := apiClient.SetTarget(settings.ActiveDashboardTarget()); err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("错误: %v\n"), err)
+	}
+
+	latency := service.NewLatencyMonitor(manager, apiClient, service.LatencyOptionsFromSettings(settings))
+	monitor := service.NewHealthMonitor(manager, apiClient, service.MonitorOptionsFromSettings(settings))
+	monitor.SetEventBus(events)
+	monitor.SetLatencyMonitor(latency)
+	webhooks := service.NewWebhookDispatcher()
+	webhooks.SetWebhooks(settings.Webhooks)
+	scheduler := service.NewScheduler(manager)
+	scheduler.SetSettings(settings)
+	resources := service.NewResourceMonitor(manager)
+
+	status := service.NewStatusServer(manager, apiClient, monitor, latency)
+	addr, err := status.Start(*listen)
+	if err != nil {
+		return err
+	}
+
+	if settings.LatencyInterval > 0 {
+		latency.Start()
+	}
+	if settings.MonitorInterval > 0 {
+		monitor.Start()
+	}
+	webhooks.Start(events)
+	scheduler.Start()
+	resources.Start()
+
+	if *startServer {
+		if err := manager.StartServer(settings.ServerConfigPath); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("错误: %v\n"), err)
+		}
+	}
+	if *startClient {
+		if err := manager.StartClient(settings.ClientConfigPath); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("错误: %v\n"), err)
+		}
+	}
+
+	fmt.Printf(i18n.T("无界面模式已启动，状态页: http://%s/ ，按 Ctrl+C 退出\n"), addr)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	logChan, unsubscribe := manager.SubscribeLogs()
+	defer unsubscribe()
+
+	for {
+		select {
+		case logMsg := <-logChan:
+			fmt.Printf("[%s] [%s] [%s] %s\n",
+				logMsg.Timestamp.Format("15:04:05"), logMsg.Level, logMsg.Source, logMsg.Message)
+		case alert := <-monitor.Alerts():
+			if alert.Resolved {
+				fmt.Printf("✅ %s\n", alert.Title)
+			} else {
+				fmt.Printf("⚠️ %s: %s\n", alert.Title, alert.Message)
+			}
+		case event := <-manager.RestartEvents():
+			fmt.Println(event.Message())
+		case event := <-scheduler.Events():
+			fmt.Println(event.Message())
+		case err := <-webhooks.Errors():
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		case <-signals:
+			status.Stop()
+			monitor.Stop()
+			latency.Stop()
+			scheduler.Stop()
+			webhooks.Stop()
+			resources.Stop()
+			if !settings.StopOnExit {
+				return nil
+			}
+			return manager.Shutdown(settings.ShutdownDuration(), func(step string) { fmt.Println(step) })
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 
 	"frp-cli-ui/internal/installer"
 	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
 	"frp-cli-ui/pkg/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,12 +15,33 @@ import (
 )
 
 func main() {
+	// 命令行模式和终端界面都按应用设置中的语言输出
+	settings, _ := config.LoadAppSettings()
+	i18n.SetLanguage(settings.Language)
+
+	// 删除自更新时留下的旧程序
+	installer.CleanupSelfUpdate()
+
+	// 带子命令时以命令行模式运行，不启动终端界面
+	if isCLIInvocation(os.Args[1:]) {
+		os.Exit(runCLI(os.Args[1:]))
+	}
+
+	// 无界面模式在前台运行监控并提供状态页
+	if isHeadlessInvocation(os.Args[1:]) {
+		if err := runHeadless(os.Args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("错误: %v\n"), err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// 设置字符宽度计算
 	runewidth.DefaultCondition.EastAsianWidth = false
 
 	// 初始化工作空间和配置文件
 	if err := config.InitializeWorkspace(); err != nil {
-		log.Printf("初始化工作空间失败: %v", err)
+		log.Printf(i18n.T("初始化工作空间失败: %v"), err)
 		// 不退出程序，继续运行
 	}
 
@@ -28,16 +51,43 @@ func main() {
 
 	// 使用新架构创建主控制面板
 	initialModel := ui.NewMainDashboard()
+	guard := ui.NewCrashGuard(initialModel)
 
 	// 初始化 TUI 程序，Bubble Tea 默认已支持 Ctrl+Z 挂起和信号处理
 	p := tea.NewProgram(
-		initialModel,
+		guard,
 		tea.WithAltScreen(),
 	)
 
-	// 启动 TUI
-	if _, err := p.Run(); err != nil {
-		log.Printf("FRP CLI UI 启动失败: %v", err)
+	// 启动 TUI，界面 panic 时 Bubble Tea 会恢复终端后返回
+	_, runErr := p.Run()
+
+	// 先生成崩溃报告，再停止进程，避免报告中缺少进程状态
+	if guard.Crashed() {
+		if path, err := guard.WriteReport(); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.Sprintf("生成崩溃报告失败: %v", err))
+		} else {
+			fmt.Fprintln(os.Stderr, i18n.Sprintf("界面发生异常，调试报告已保存到 %s，提交问题时请附上该目录", path))
+		}
+	}
+
+	// 界面被信号中断或跳过关闭等待时，在这里完成进程清理
+	if err := initialModel.Shutdown(func(step string) { fmt.Println(step) }); err != nil {
+		log.Printf(i18n.T("退出时停止进程失败: %v"), err)
+	}
+
+	if runErr != nil {
+		if !guard.Crashed() {
+			log.Printf(i18n.T("FRP CLI UI 启动失败: %v"), runErr)
+		}
 		os.Exit(1)
 	}
+
+	// 自更新后选择立即重启时，用新程序替换当前进程
+	if initialModel.RestartRequested() {
+		if err := restartSelf(); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.Sprintf("重启失败，请手动运行新版本: %v", err))
+			os.Exit(1)
+		}
+	}
 }
@@ -0,0 +1,19 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"syscall"
+
+	"frp-cli-ui/internal/installer"
+)
+
+// restartSelf 以相同的参数和环境执行更新后的程序，替换当前进程
+func restartSelf() error {
+	exe, err := installer.SelfExecutable()
+	if err != nil {
+		return err
+	}
+	return syscall.Exec(exe, os.Args, os.Environ())
+}
@@ -0,0 +1,30 @@
+//go:build windows
+
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+
+	"frp-cli-ui/internal/installer"
+)
+
+// restartSelf Windows 不能替换当前进程，以相同的参数启动更新后的程序并等待它退出
+func restartSelf() error {
+	exe, err := installer.SelfExecutable()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.ExitCode())
+	}
+	if err == nil {
+		os.Exit(0)
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+// plugin_example 子进程扩展标签页示例：从标准输入逐行读取 JSON 消息，
+// 向标准输出逐行写入 JSON 消息。在应用设置中添加:
+//
+//	tabPlugins:
+//	  - name: 示例插件
+//	    path: /path/to/plugin_example
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// request 管理工具发送的消息
+type request struct {
+	Type             string `json:"type"`
+	Width            int    `json:"width"`
+	Height           int    `json:"height"`
+	Focused          bool   `json:"focused"`
+	Key              string `json:"key"`
+	ClientConfigPath string `json:"clientConfigPath"`
+	Event            *struct {
+		Type  string    `json:"type"`
+		Title string    `json:"title"`
+		Time  time.Time `json:"time"`
+	} `json:"event"`
+}
+
+// response 发送给管理工具的消息
+type response struct {
+	Type    string `json:"type"`
+	Content string `json:"content,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Text    string `json:"text,omitempty"`
+	Error   bool   `json:"error,omitempty"`
+	Input   bool   `json:"input,omitempty"`
+}
+
+func main() {
+	out := json.NewEncoder(os.Stdout)
+	var (
+		width, height int
+		presses       int
+		clientConfig  string
+		events        []string
+	)
+
+	render := func() {
+		var b strings.Builder
+		fmt.Fprintf(&b, "🧩 示例插件 (%dx%d)\n\n", width, height)
+		fmt.Fprintf(&b, "客户端配置: %s\n", clientConfig)
+		fmt.Fprintf(&b, "按键次数: %d（按 r 清零，按 n 发送通知）\n\n", presses)
+		b.WriteString("最近的事件:\n")
+		for _, event := range events {
+			b.WriteString("  " + event + "\n")
+		}
+		out.Encode(response{Type: "view", Content: b.String()})
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var req request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			fmt.Fprintln(os.Stderr, "无法解析消息:", err)
+			continue
+		}
+
+		switch req.Type {
+		case "init":
+			clientConfig = req.ClientConfigPath
+			out.Encode(response{Type: "title", Title: "示例"})
+		case "resize":
+			width, height = req.Width, req.Height
+		case "key":
+			presses++
+			switch req.Key {
+			case "r":
+				presses = 0
+			case "n":
+				out.Encode(response{Type: "status", Text: "来自示例插件的通知"})
+			}
+		case "event":
+			if req.Event != nil {
+				events = append(events, req.Event.Time.Format("15:04:05")+" "+req.Event.Type+" "+req.Event.Title)
+				if len(events) > 10 {
+					events = events[1:]
+				}
+			}
+		}
+		render()
+	}
+}
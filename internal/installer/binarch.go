@@ -0,0 +1,173 @@
+package installer
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"os"
+	"runtime"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// Platform 可执行文件适用的系统和架构，命名与 GOOS/GOARCH 一致
+type Platform struct {
+	OS   string
+	Arch string
+}
+
+// String 返回 系统/架构，如 linux/amd64
+func (p Platform) String() string {
+	return p.OS + "/" + p.Arch
+}
+
+// runsHere 该平台的程序能否在本机运行。64 位 Linux/Windows 可以运行 386 程序，
+// Apple 芯片的 macOS 通过 Rosetta 2 运行 amd64 程序
+func (p Platform) runsHere() bool {
+	osOK := p.OS == runtime.GOOS || (p.OS == "linux" && runtime.GOOS == "android")
+	archOK := p.Arch == runtime.GOARCH ||
+		(p.Arch == "386" && runtime.GOARCH == "amd64" && runtime.GOOS != "darwin") ||
+		(p.Arch == "amd64" && runtime.GOARCH == "arm64" && runtime.GOOS == "darwin")
+	return osOK && archOK
+}
+
+// ArchMismatch 与本机系统或架构不符、无法运行的程序，通常是从其他机器拷贝过来的
+type ArchMismatch struct {
+	Name      string // frps 或 frpc
+	Path      string
+	Platforms []Platform
+}
+
+// Error 实现 error 接口
+func (m *ArchMismatch) Error() string {
+	platform := m.Platforms[0].String()
+	for _, p := range m.Platforms[1:] {
+		platform += ", " + p.String()
+	}
+	return i18n.Sprintf("%s 是适用于 %s 的程序，本机为 %s/%s，无法运行，请重新安装适用于本机的版本", m.Path, platform, runtime.GOOS, runtime.GOARCH)
+}
+
+// BinaryPlatforms 读取 ELF、Mach-O 或 PE 文件头，返回可执行文件适用的平台。
+// Mach-O 通用二进制包含多个架构；不是这三种格式（如脚本）时返回错误
+func BinaryPlatforms(path string) ([]Platform, error) {
+	if f, err := elf.Open(path); err == nil {
+		defer f.Close()
+		return []Platform{{OS: elfOS(f), Arch: elfArch(f)}}, nil
+	}
+	if f, err := macho.Open(path); err == nil {
+		defer f.Close()
+		return []Platform{{OS: "darwin", Arch: machoArch(f.Cpu)}}, nil
+	}
+	if f, err := macho.OpenFat(path); err == nil {
+		defer f.Close()
+		platforms := make([]Platform, 0, len(f.Arches))
+		for _, arch := range f.Arches {
+			platforms = append(platforms, Platform{OS: "darwin", Arch: machoArch(arch.Cpu)})
+		}
+		return platforms, nil
+	}
+	if f, err := pe.Open(path); err == nil {
+		defer f.Close()
+		return []Platform{{OS: "windows", Arch: peArch(f.Machine)}}, nil
+	}
+	return nil, i18n.Errorf("无法识别 %s 的可执行文件格式", path)
+}
+
+// DetectArchMismatch 检查 frps 或 frpc 能否在本机运行，不符时返回不符的平台，否则返回 nil。
+// 文件不存在或格式无法识别时不报告，由执行时的错误说明
+func DetectArchMismatch(name, path string) *ArchMismatch {
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	platforms, err := BinaryPlatforms(path)
+	if err != nil {
+		return nil
+	}
+	for _, p := range platforms {
+		if p.runsHere() {
+			return nil
+		}
+	}
+	return &ArchMismatch{Name: name, Path: path, Platforms: platforms}
+}
+
+// elfOS 根据 ELF 的 OS/ABI 判断系统，未标明时按 Linux 处理
+func elfOS(f *elf.File) string {
+	switch f.OSABI {
+	case elf.ELFOSABI_FREEBSD:
+		return "freebsd"
+	case elf.ELFOSABI_NETBSD:
+		return "netbsd"
+	case elf.ELFOSABI_OPENBSD:
+		return "openbsd"
+	}
+	return "linux"
+}
+
+// elfArch 把 ELF 的机器类型转换为 GOARCH 名称
+func elfArch(f *elf.File) string {
+	little := f.ByteOrder == binary.LittleEndian
+	is64 := f.Class == elf.ELFCLASS64
+	switch f.Machine {
+	case elf.EM_X86_64:
+		return "amd64"
+	case elf.EM_386:
+		return "386"
+	case elf.EM_AARCH64:
+		return "arm64"
+	case elf.EM_ARM:
+		return "arm"
+	case elf.EM_RISCV:
+		return "riscv64"
+	case elf.EM_LOONGARCH:
+		return "loong64"
+	case elf.EM_S390:
+		return "s390x"
+	case elf.EM_PPC64:
+		if little {
+			return "ppc64le"
+		}
+		return "ppc64"
+	case elf.EM_MIPS:
+		arch := "mips"
+		if is64 {
+			arch = "mips64"
+		}
+		if little {
+			arch += "le"
+		}
+		return arch
+	}
+	return f.Machine.String()
+}
+
+// machoArch 把 Mach-O 的 CPU 类型转换为 GOARCH 名称
+func machoArch(cpu macho.Cpu) string {
+	switch cpu {
+	case macho.CpuAmd64:
+		return "amd64"
+	case macho.CpuArm64:
+		return "arm64"
+	case macho.Cpu386:
+		return "386"
+	case macho.CpuArm:
+		return "arm"
+	}
+	return cpu.String()
+}
+
+// peArch 把 PE 的机器类型转换为 GOARCH 名称
+func peArch(machine uint16) string {
+	switch machine {
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		return "amd64"
+	case pe.IMAGE_FILE_MACHINE_I386:
+		return "386"
+	case pe.IMAGE_FILE_MACHINE_ARM64:
+		return "arm64"
+	case pe.IMAGE_FILE_MACHINE_ARMNT:
+		return "arm"
+	}
+	return i18n.Sprintf("未知 (0x%x)", machine)
+}
@@ -0,0 +1,129 @@
+package installer
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// checksumsFilename frp 每个发布附带的 SHA256 校验文件
+const checksumsFilename = "frp_sha256_checksums.txt"
+
+// ChecksumError 下载文件校验失败
+type ChecksumError struct {
+	File     string
+	Expected string
+	Actual   string
+	Removed  bool // 下载的文件已删除，下次重新下载
+}
+
+// Error 实现 error 接口
+func (e *ChecksumError) Error() string {
+	return i18n.Sprintf("%s SHA256 校验失败: 期望 %s，实际 %s", e.File, e.Expected, e.Actual)
+}
+
+// getChecksumsURL 获取当前版本校验文件的下载链接
+func (i *Installer) getChecksumsURL() string {
+	return fmt.Sprintf("%s/v%s/%s", i.baseURL, i.version, checksumsFilename)
+}
+
+// fetchChecksums 下载并解析校验文件，返回 文件名 -> SHA256
+func (i *Installer) fetchChecksums() (map[string]string, error) {
+	client := i.newHTTPClient(30 * time.Second)
+
+	resp, err := client.Get(i.mirrorURL(i.getChecksumsURL(), checksumsFilename))
+	if err != nil {
+		return nil, i18n.Errorf("请求校验文件失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, i18n.Errorf("获取校验文件失败，状态码: %d", resp.StatusCode)
+	}
+
+	return parseChecksums(resp.Body)
+}
+
+// parseChecksums 解析 sha256sum 格式的校验文件
+func parseChecksums(r io.Reader) (map[string]string, error) {
+	checksums := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		// sha256sum 二进制模式会在文件名前加 *
+		checksums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, i18n.Errorf("读取校验文件失败: %w", err)
+	}
+
+	if len(checksums) == 0 {
+		return nil, i18n.Errorf("校验文件为空")
+	}
+	return checksums, nil
+}
+
+// fileSHA256 计算文件的 SHA256
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// verifyChecksum 对照发布的校验文件验证下载的压缩包
+func (i *Installer) verifyChecksum(path, filename string) error {
+	checksums, err := i.fetchChecksums()
+	if err != nil {
+		return err
+	}
+
+	if err := matchChecksum(checksums, path, filename); err != nil {
+		var checksumErr *ChecksumError
+		if errors.As(err, &checksumErr) {
+			// 文件已损坏或被篡改，删除后下次重新下载
+			os.Remove(path)
+			checksumErr.Removed = true
+		}
+		return err
+	}
+	return nil
+}
+
+// matchChecksum 计算文件的 SHA256 并与校验文件中 filename 的记录比较
+func matchChecksum(checksums map[string]string, path, filename string) error {
+	expected, ok := checksums[filename]
+	if !ok {
+		return i18n.Errorf("校验文件中没有 %s", filename)
+	}
+
+	actual, err := fileSHA256(path)
+	if err != nil {
+		return i18n.Errorf("计算 SHA256 失败: %w", err)
+	}
+
+	if actual != expected {
+		return &ChecksumError{File: filename, Expected: expected, Actual: actual}
+	}
+	return nil
+}
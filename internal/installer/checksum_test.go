@@ -0,0 +1,97 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseChecksums(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "sha256sum text mode",
+			input: "aaa111  frp_0.52.3_linux_amd64.tar.gz\n" +
+				"bbb222  frp_0.52.3_windows_amd64.zip\n",
+			want: map[string]string{
+				"frp_0.52.3_linux_amd64.tar.gz": "aaa111",
+				"frp_0.52.3_windows_amd64.zip":  "bbb222",
+			},
+		},
+		{
+			name:  "binary mode prefix",
+			input: "ccc333 *frp_0.52.3_darwin_arm64.tar.gz\n",
+			want:  map[string]string{"frp_0.52.3_darwin_arm64.tar.gz": "ccc333"},
+		},
+		{
+			name:  "uppercase hash",
+			input: "ABCDEF  frp.tar.gz\n",
+			want:  map[string]string{"frp.tar.gz": "abcdef"},
+		},
+		{
+			name:  "skips malformed lines",
+			input: "# comment line here\n\nonlyhash\naaa111  frp.tar.gz\n",
+			want:  map[string]string{"frp.tar.gz": "aaa111"},
+		},
+		{name: "empty", input: "", wantErr: true},
+		{name: "no valid lines", input: "not a checksum file\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseChecksums(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseChecksums error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("parseChecksums = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frp.tar.gz")
+	content := []byte("frp release archive")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(content)
+	actual := hex.EncodeToString(sum[:])
+	wrong := strings.Repeat("0", len(actual))
+
+	tests := []struct {
+		name         string
+		checksums    map[string]string
+		wantErr      bool
+		wantMismatch bool
+	}{
+		{name: "match", checksums: map[string]string{"frp.tar.gz": actual}},
+		{name: "missing entry", checksums: map[string]string{"other.tar.gz": actual}, wantErr: true},
+		{name: "mismatch", checksums: map[string]string{"frp.tar.gz": wrong}, wantErr: true, wantMismatch: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := matchChecksum(tt.checksums, path, "frp.tar.gz")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("matchChecksum error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var checksumErr *ChecksumError
+			if errors.As(err, &checksumErr) != tt.wantMismatch {
+				t.Fatalf("matchChecksum error = %v, want ChecksumError %v", err, tt.wantMismatch)
+			}
+			if tt.wantMismatch && (checksumErr.Expected != wrong || checksumErr.Actual != actual) {
+				t.Errorf("ChecksumError = %+v, want expected %s actual %s", checksumErr, wrong, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 已安装程序的来源
+const (
+	OriginManaged = "managed" // 本工具的安装目录
+	OriginBrew    = "brew"    // Homebrew / Linuxbrew
+	OriginScoop   = "scoop"
+	OriginChoco   = "choco" // Chocolatey
+	OriginSnap    = "snap"
+	OriginSystem  = "system" // 系统软件包，如 apt、dnf 安装到 /usr/bin
+	OriginPath    = "path"   // PATH 或其他目录中的程序，来源未知
+)
+
+// IsPackageOrigin 是否由包管理器管理，这类程序应通过对应的包管理器更新和卸载
+func IsPackageOrigin(origin string) bool {
+	switch origin {
+	case OriginBrew, OriginScoop, OriginChoco, OriginSnap, OriginSystem:
+		return true
+	}
+	return false
+}
+
+// OriginLabel 返回来源的显示名称
+func OriginLabel(origin string) string {
+	switch origin {
+	case OriginManaged:
+		return i18n.T("本工具安装")
+	case OriginBrew:
+		return "Homebrew"
+	case OriginScoop:
+		return "Scoop"
+	case OriginChoco:
+		return "Chocolatey"
+	case OriginSnap:
+		return "Snap"
+	case OriginSystem:
+		return i18n.T("系统软件包 (apt/dnf 等)")
+	}
+	return "PATH"
+}
+
+// CommonDirs 返回包管理器和手动安装常用的程序目录，程序不在 PATH 中时依次查找
+func CommonDirs() []string {
+	if runtime.GOOS == "windows" {
+		var dirs []string
+		if home, err := os.UserHomeDir(); err == nil {
+			dirs = append(dirs, filepath.Join(home, "scoop", "shims"))
+		}
+		if programData := os.Getenv("ProgramData"); programData != "" {
+			dirs = append(dirs, filepath.Join(programData, "chocolatey", "bin"))
+		}
+		return dirs
+	}
+	return []string{
+		"/opt/homebrew/bin",
+		"/home/linuxbrew/.linuxbrew/bin",
+		"/usr/local/bin",
+		"/usr/bin",
+		"/snap/bin",
+		"/opt/frp",
+	}
+}
+
+// DetectOrigin 根据程序路径及其链接的实际位置判断来源，包管理器的目录优先于安装目录
+func DetectOrigin(path, installDir string) string {
+	if origin := packageOrigin(path); origin != "" {
+		return origin
+	}
+	// Homebrew 在 /usr/local/bin 等目录中放的是指向 Cellar 的链接
+	if resolved, err := filepath.EvalSymlinks(path); err == nil && resolved != path {
+		if origin := packageOrigin(resolved); origin != "" {
+			return origin
+		}
+	}
+	if runtime.GOOS != "windows" {
+		switch filepath.Dir(path) {
+		case "/usr/bin", "/usr/sbin", "/bin", "/sbin":
+			return OriginSystem
+		}
+	}
+	if samePath(filepath.Dir(path), installDir) {
+		return OriginManaged
+	}
+	return OriginPath
+}
+
+// packageOrigin 根据路径中包管理器特有的目录判断来源，无法判断时返回空
+func packageOrigin(path string) string {
+	p := strings.ToLower(filepath.ToSlash(path))
+	switch {
+	case strings.Contains(p, "/cellar/"), strings.HasPrefix(p, "/opt/homebrew/"), strings.Contains(p, "/.linuxbrew/"):
+		return OriginBrew
+	case strings.Contains(p, "/scoop/"):
+		return OriginScoop
+	case strings.Contains(p, "/chocolatey/"):
+		return OriginChoco
+	case strings.HasPrefix(p, "/snap/"):
+		return OriginSnap
+	}
+	return ""
+}
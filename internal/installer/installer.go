@@ -4,21 +4,40 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
 )
 
+// versionPattern 匹配 frps --version 输出中的版本号
+var versionPattern = regexp.MustCompile(`\d+\.\d+\.\d+(-[0-9A-Za-z.]+)?`)
+
 // Installer FRP 安装管理器
 type Installer struct {
-	installDir string
-	version    string
-	baseURL    string
+	installDir    string
+	explicitDir   bool   // 安装目录由调用方指定，不跟随设置
+	serverVersion string // frps 固定使用的版本，为空时使用 current
+	clientVersion string // frpc 固定使用的版本，为空时使用 current
+	prerelease    bool   // 更新通道包含预发布版本
+	version       string
+	baseURL       string
+	releases      *ReleaseClient
+	progress      chan<- DownloadProgress
+	skipVerify    bool
+	mirror        string
+	proxyURL      *url.URL
 }
 
 // InstallStatus 安装状态
@@ -30,102 +49,185 @@ type InstallStatus struct {
 	InstallDir    string
 	NeedsUpdate   bool
 	LatestVersion string
+	FrpsVersion   string             // frps --version 的输出
+	FrpcVersion   string             // frpc --version 的输出
+	External      bool               // 程序来自 PATH 而不是安装目录
+	Origin        string             // frps 的来源，如 brew、scoop，见 Origin* 常量
+	Versions      []InstalledVersion // 默认安装目录中并存的版本，从新到旧排列
+	Mismatches    []*ArchMismatch    // 与本机系统或架构不符、无法运行的程序
+}
+
+// CanAdopt 是否检测到安装目录之外、位于同一目录且能在本机运行的 frps 和 frpc，可以直接使用而不必重复下载
+func (s *InstallStatus) CanAdopt() bool {
+	return s.IsInstalled && s.External && filepath.Dir(s.FrpsPath) == filepath.Dir(s.FrpcPath) && len(s.Mismatches) == 0
 }
 
-// NewInstaller 创建新的安装管理器
+// PackageManaged 程序是否由包管理器管理，这时不能由本工具更新或卸载
+func (s *InstallStatus) PackageManaged() bool {
+	return IsPackageOrigin(s.Origin)
+}
+
+// NewInstaller 创建新的安装管理器，installDir 为空时使用设置中记录的安装目录，
+// 未记录时安装到用户目录下的 .frp-manager 文件夹，与配置目录保持一致
 func NewInstaller(installDir string) *Installer {
-	if installDir == "" {
-		// 默认安装到用户目录下的 .frp-manager 文件夹，与配置目录保持一致
-		homeDir, _ := os.UserHomeDir()
-		if homeDir != "" {
-			installDir = filepath.Join(homeDir, ".frp-manager")
-		} else {
-			installDir = ".frp-manager"
-		}
+	inst := &Installer{
+		installDir:  installDir,
+		explicitDir: installDir != "",
+		version:     "0.52.3", // 当前稳定版本
+		baseURL:     "https://github.com/fatedier/frp/releases/download",
+		releases:    NewReleaseClient(filepath.Join(config.GetDefaultWorkDir(), "releases-cache.json")),
 	}
 
-	return &Installer{
-		installDir: installDir,
-		version:    "0.52.3", // 当前稳定版本
-		baseURL:    "https://github.com/fatedier/frp/releases/download",
+	// 应用已保存的安装目录、镜像和代理设置，读取失败时直接从 GitHub 下载
+	if settings, err := config.LoadAppSettings(); err == nil {
+		inst.ApplySettings(settings)
 	}
+	if inst.installDir == "" {
+		inst.installDir = DefaultInstallDir()
+	}
+
+	return inst
 }
 
-// CheckInstallation 检查 FRP 安装状态
+// CheckInstallation 检查 FRP 安装状态，安装目录中缺少的程序会继续在 PATH 中查找
 func (i *Installer) CheckInstallation() (*InstallStatus, error) {
 	status := &InstallStatus{
 		InstallDir: i.installDir,
 	}
 
-	// 检查安装目录是否存在
-	if _, err := os.Stat(i.installDir); os.IsNotExist(err) {
+	frpsPath := i.findExecutable("frps")
+	frpcPath := i.findExecutable("frpc")
+	if frpsPath == "" || frpcPath == "" {
 		return status, nil
 	}
 
-	// 检查 frps 和 frpc 是否存在
-	frpsPath := filepath.Join(i.installDir, "frps")
-	frpcPath := filepath.Join(i.installDir, "frpc")
+	status.IsInstalled = true
+	status.FrpsPath = frpsPath
+	status.FrpcPath = frpcPath
+	status.External = !i.isInInstallDir(frpsPath) || !i.isInInstallDir(frpcPath)
+	status.Origin = DetectOrigin(frpsPath, i.installDir)
+	status.Versions, _ = i.InstalledVersions()
+	// 从其他机器拷贝来的程序无法运行，执行时只有难以理解的 exec format error
+	if mismatch := DetectArchMismatch("frps", frpsPath); mismatch != nil {
+		status.Mismatches = append(status.Mismatches, mismatch)
+	}
+	if mismatch := DetectArchMismatch("frpc", frpcPath); mismatch != nil {
+		status.Mismatches = append(status.Mismatches, mismatch)
+	}
 
-	if runtime.GOOS == "windows" {
-		frpsPath += ".exe"
-		frpcPath += ".exe"
+	// 获取实际运行的程序版本
+	if version, err := i.getInstalledVersion(frpsPath); err == nil {
+		status.FrpsVersion = version
+	}
+	if version, err := i.getInstalledVersion(frpcPath); err == nil {
+		status.FrpcVersion = version
 	}
 
-	frpsExists := i.fileExists(frpsPath)
-	frpcExists := i.fileExists(frpcPath)
+	status.LatestVersion = i.latestKnownVersion()
+	switch {
+	case status.FrpsVersion != "":
+		status.Version = status.FrpsVersion
+	case status.FrpcVersion != "":
+		status.Version = status.FrpcVersion
+	default:
+		status.Version = i18n.T("未知")
+		return status, nil
+	}
+
+	// 检查是否需要更新
+	status.NeedsUpdate = i.needsUpdate(status.Version)
+
+	return status, nil
+}
 
-	if frpsExists && frpcExists {
-		status.IsInstalled = true
-		status.FrpsPath = frpsPath
-		status.FrpcPath = frpcPath
+// findExecutable 查找可执行文件，优先使用安装目录中固定或当前的版本，其次查找 PATH，最后查找包管理器的常用目录
+func (i *Installer) findExecutable(name string) string {
+	if version := i.pinnedVersion(name); version != "" {
+		if path := ExecutablePath(VersionDir(i.installDir, version), name); i.fileExists(path) {
+			return path
+		}
+	}
+	for _, dir := range []string{BinDir(i.installDir), i.installDir} {
+		if path := ExecutablePath(dir, name); i.fileExists(path) {
+			return path
+		}
+	}
 
-		// 尝试获取版本信息
-		if version, err := i.getInstalledVersion(frpsPath); err == nil {
-			status.Version = version
-			// 检查是否需要更新
-			status.NeedsUpdate = i.needsUpdate(version)
-			status.LatestVersion = i.version
+	if path, err := exec.LookPath(ExecutablePath("", name)); err == nil {
+		if abs, err := filepath.Abs(path); err == nil {
+			return abs
 		}
+		return path
 	}
 
-	return status, nil
+	for _, dir := range CommonDirs() {
+		if path := ExecutablePath(dir, name); i.fileExists(path) {
+			return path
+		}
+	}
+	return ""
 }
 
-// InstallFRP 安装 FRP
-func (i *Installer) InstallFRP() error {
-	// 创建安装目录
-	if err := os.MkdirAll(i.installDir, 0755); err != nil {
-		return fmt.Errorf("创建安装目录失败: %w", err)
+// CheckNotPackageManaged 安装目录中的程序由包管理器管理时返回错误，避免覆盖或删除包管理器的文件；
+// 通过 sudo 安装时需要在下载前调用
+func (i *Installer) CheckNotPackageManaged() error {
+	path := ExecutablePath(i.installDir, "frps")
+	if !i.fileExists(path) {
+		return nil
+	}
+	if origin := DetectOrigin(path, i.installDir); IsPackageOrigin(origin) {
+		return i18n.Errorf("%s 中的 FRP 由 %s 管理，请通过包管理器更新或卸载", i.installDir, OriginLabel(origin))
 	}
+	return nil
+}
 
-	// 获取下载 URL
-	downloadURL, filename, err := i.getDownloadURL()
+// isInInstallDir 判断程序是否位于安装目录或其中的版本目录中
+func (i *Installer) isInInstallDir(path string) bool {
+	dir, err := filepath.Abs(i.installDir)
+	if err != nil {
+		return false
+	}
+	absPath, err := filepath.Abs(path)
 	if err != nil {
-		return fmt.Errorf("获取下载链接失败: %w", err)
+		return false
 	}
+	parent := filepath.Dir(absPath)
+	return parent == dir || filepath.Dir(parent) == filepath.Join(dir, versionsDirName)
+}
 
-	// 下载文件
-	tempFile := filepath.Join(os.TempDir(), filename)
-	if err := i.downloadFile(downloadURL, tempFile); err != nil {
-		return fmt.Errorf("下载文件失败: %w", err)
+// pinnedVersion 返回 frps 或 frpc 固定使用的版本
+func (i *Installer) pinnedVersion(name string) string {
+	if name == "frps" {
+		return i.serverVersion
 	}
-	defer os.Remove(tempFile)
+	return i.clientVersion
+}
 
-	// 解压文件
-	if err := i.extractFile(tempFile, i.installDir); err != nil {
-		return fmt.Errorf("解压文件失败: %w", err)
+// InstallFRP 安装 FRP，先在临时目录中下载、校验并解压，再替换安装目录中的程序。
+// 默认目录中各版本并存，目标版本已安装时直接切换，不重新下载
+func (i *Installer) InstallFRP() error {
+	if err := i.CheckNotPackageManaged(); err != nil {
+		return err
+	}
+	if i.ManagesVersions() && i.HasVersion(i.version) {
+		return i.UseVersion(i.version)
 	}
 
-	// 设置执行权限 (Unix 系统)
-	if runtime.GOOS != "windows" {
-		frpsPath := filepath.Join(i.installDir, "frps")
-		frpcPath := filepath.Join(i.installDir, "frpc")
+	// 创建安装目录
+	if err := os.MkdirAll(i.installDir, 0755); err != nil {
+		return i18n.Errorf("创建安装目录失败: %w", err)
+	}
 
-		os.Chmod(frpsPath, 0755)
-		os.Chmod(frpcPath, 0755)
+	staged, err := i.StageFRP()
+	if err != nil {
+		return err
 	}
+	defer os.RemoveAll(staged)
 
-	return nil
+	if i.ManagesVersions() {
+		return i.installVersion(staged)
+	}
+	return i.installStaged(staged)
 }
 
 // getDownloadURL 获取下载链接
@@ -144,7 +246,7 @@ func (i *Installer) getDownloadURL() (string, string, error) {
 		osName = "windows"
 		ext = "zip"
 	default:
-		return "", "", fmt.Errorf("不支持的操作系统: %s", runtime.GOOS)
+		return "", "", i18n.Errorf("不支持的操作系统: %s", runtime.GOOS)
 	}
 
 	// 确定架构
@@ -158,7 +260,7 @@ func (i *Installer) getDownloadURL() (string, string, error) {
 	case "arm":
 		arch = "arm"
 	default:
-		return "", "", fmt.Errorf("不支持的架构: %s", runtime.GOARCH)
+		return "", "", i18n.Errorf("不支持的架构: %s", runtime.GOARCH)
 	}
 
 	filename := fmt.Sprintf("frp_%s_%s_%s.%s", i.version, osName, arch, ext)
@@ -167,35 +269,76 @@ func (i *Installer) getDownloadURL() (string, string, error) {
 	return url, filename, nil
 }
 
-// downloadFile 下载文件
+// downloadFile 下载文件，先写入 .part 临时文件，已有部分时通过 HTTP Range 续传
 func (i *Installer) downloadFile(url, filepath string) error {
+	partPath := filepath + ".part"
+
 	// 创建 HTTP 客户端
-	client := &http.Client{
-		Timeout: 30 * time.Minute, // 30分钟超时
+	client := i.newHTTPClient(30 * time.Minute) // 30分钟超时
+
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return i18n.Errorf("创建请求失败: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
 	}
 
 	// 发送请求
-	resp, err := client.Get(url)
+	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("请求失败: %w", err)
+		return i18n.Errorf("请求失败: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("下载失败，状态码: %d", resp.StatusCode)
+	flags := os.O_CREATE | os.O_WRONLY
+	var total int64
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+		if resp.ContentLength >= 0 {
+			total = offset + resp.ContentLength
+		}
+	case http.StatusOK:
+		// 服务器不支持续传，从头开始下载
+		flags |= os.O_TRUNC
+		offset = 0
+		if resp.ContentLength >= 0 {
+			total = resp.ContentLength
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// 已有部分无效或已超出文件大小，删除后重新下载
+		os.Remove(partPath)
+		return i18n.Errorf("续传位置无效，请重试")
+	default:
+		return i18n.Errorf("下载失败，状态码: %d", resp.StatusCode)
 	}
 
 	// 创建文件
-	out, err := os.Create(filepath)
+	out, err := os.OpenFile(partPath, flags, 0644)
 	if err != nil {
-		return fmt.Errorf("创建文件失败: %w", err)
+		return i18n.Errorf("创建文件失败: %w", err)
 	}
-	defer out.Close()
 
 	// 复制数据
-	_, err = io.Copy(out, resp.Body)
+	writer := newProgressWriter(out, i.progress, offset, total)
+	_, err = io.Copy(writer, resp.Body)
+	closeErr := out.Close()
 	if err != nil {
-		return fmt.Errorf("写入文件失败: %w", err)
+		return i18n.Errorf("写入文件失败: %w", err)
+	}
+	if closeErr != nil {
+		return i18n.Errorf("写入文件失败: %w", closeErr)
+	}
+	writer.finish()
+
+	if err := os.Rename(partPath, filepath); err != nil {
+		return i18n.Errorf("保存文件失败: %w", err)
 	}
 
 	return nil
@@ -208,7 +351,7 @@ func (i *Installer) extractFile(src, dest string) error {
 	} else if strings.HasSuffix(src, ".zip") {
 		return i.extractZip(src, dest)
 	}
-	return fmt.Errorf("不支持的文件格式")
+	return i18n.Errorf("不支持的文件格式")
 }
 
 // extractTarGz 解压 tar.gz 文件
@@ -307,17 +450,62 @@ func (i *Installer) fileExists(filename string) bool {
 	return !info.IsDir()
 }
 
-// getInstalledVersion 获取已安装的版本
+// getInstalledVersion 通过执行 frps --version 获取已安装的版本
 func (i *Installer) getInstalledVersion(execPath string) (string, error) {
-	// 这里可以通过执行 frps --version 来获取版本
-	// 为了简化，我们返回配置的版本
-	return i.version, nil
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, execPath, "--version").Output()
+	if err != nil {
+		return "", i18n.Errorf("获取版本失败: %w", err)
+	}
+
+	version := versionPattern.FindString(string(output))
+	if version == "" {
+		return "", i18n.Errorf("无法识别版本输出: %s", strings.TrimSpace(string(output)))
+	}
+	return version, nil
 }
 
-// needsUpdate 检查是否需要更新
+// needsUpdate 检查是否有更新的正式版本
 func (i *Installer) needsUpdate(currentVersion string) bool {
-	// 简单的版本比较，实际应该使用语义化版本比较
-	return currentVersion != i.version
+	return CompareVersions(currentVersion, i.latestKnownVersion()) < 0
+}
+
+// latestKnownVersion 返回更新通道中已知的最新版本，只读取本地缓存，没有缓存时使用默认版本
+func (i *Installer) latestKnownVersion() string {
+	latest := i.version
+	if cache, err := i.releases.loadCache(); err == nil {
+		if releases := FilterChannel(cache.Releases, i.prerelease); len(releases) > 0 {
+			if CompareVersions(releases[0].Version(), latest) > 0 {
+				latest = releases[0].Version()
+			}
+		}
+	}
+	return latest
+}
+
+// LatestRelease 返回更新通道中最新的版本
+func (i *Installer) LatestRelease() (*Release, error) {
+	return i.releases.Latest(i.prerelease)
+}
+
+// SetPrerelease 设置更新通道是否包含预发布版本
+func (i *Installer) SetPrerelease(include bool) {
+	i.prerelease = include
+}
+
+// IncludesPrereleases 更新通道是否包含预发布版本
+func (i *Installer) IncludesPrereleases() bool {
+	return i.prerelease
+}
+
+// ListReleases 获取可安装的版本列表，forceRefresh 为 true 时忽略缓存
+func (i *Installer) ListReleases(forceRefresh bool) ([]Release, error) {
+	if forceRefresh {
+		return i.releases.Refresh()
+	}
+	return i.releases.ListReleases()
 }
 
 // GetInstallDir 获取安装目录
@@ -330,37 +518,64 @@ func (i *Installer) SetVersion(version string) {
 	i.version = version
 }
 
+// SetProgressChannel 设置下载进度通道，为空时不上报进度
+func (i *Installer) SetProgressChannel(ch chan<- DownloadProgress) {
+	i.progress = ch
+}
+
+// SetSkipVerify 设置是否跳过 SHA256 校验
+func (i *Installer) SetSkipVerify(skip bool) {
+	i.skipVerify = skip
+}
+
 // GetVersion 获取当前设置的版本
 func (i *Installer) GetVersion() string {
 	return i.version
 }
 
-// Uninstall 卸载 FRP
+// Uninstall 卸载 FRP。默认目录整体删除，自定义目录（如 /usr/local/bin）只删除 frps 和 frpc
 func (i *Installer) Uninstall() error {
 	if _, err := os.Stat(i.installDir); os.IsNotExist(err) {
 		return nil // 已经不存在
 	}
 
-	return os.RemoveAll(i.installDir)
+	if i.IsDefaultDir() {
+		return os.RemoveAll(i.installDir)
+	}
+	if err := i.CheckNotPackageManaged(); err != nil {
+		return err
+	}
+	if i.NeedsElevation() {
+		return i18n.Errorf("没有删除 %s 中程序的权限，请手动执行: sudo rm %s %s", i.installDir,
+			ExecutablePath(i.installDir, "frps"), ExecutablePath(i.installDir, "frpc"))
+	}
+	for _, name := range []string{"frps", "frpc"} {
+		if err := os.Remove(ExecutablePath(i.installDir, name)); err != nil && !os.IsNotExist(err) {
+			return i18n.Errorf("删除 %s 失败: %w", name, err)
+		}
+	}
+	return nil
 }
 
-// UpdateFRP 更新 FRP
-func (i *Installer) UpdateFRP() error {
-	// 备份当前安装
-	backupDir := i.installDir + ".backup"
-	if err := os.Rename(i.installDir, backupDir); err != nil {
-		return fmt.Errorf("备份失败: %w", err)
+// ReinstallFRP 重新下载适用于本机的程序，替换与本机系统或架构不符的程序。
+// 默认目录中同一版本的程序不能运行时先删除该版本，避免安装时直接切换过去
+func (i *Installer) ReinstallFRP() error {
+	if i.ManagesVersions() && i.HasVersion(i.version) {
+		dir := VersionDir(i.installDir, i.version)
+		if DetectArchMismatch("frps", ExecutablePath(dir, "frps")) != nil || DetectArchMismatch("frpc", ExecutablePath(dir, "frpc")) != nil {
+			if err := os.RemoveAll(dir); err != nil {
+				return i18n.Errorf("删除版本 %s 失败: %w", i.version, err)
+			}
+		}
 	}
+	return i.InstallFRP()
+}
 
-	// 尝试安装新版本
+// UpdateFRP 更新 FRP。默认目录中新版本安装成功后才切换 current，旧版本保留以便回滚；
+// 自定义目录下载校验成功后才会逐个替换程序
+func (i *Installer) UpdateFRP() error {
 	if err := i.InstallFRP(); err != nil {
-		// 安装失败，恢复备份
-		os.RemoveAll(i.installDir)
-		os.Rename(backupDir, i.installDir)
-		return fmt.Errorf("更新失败: %w", err)
+		return i18n.Errorf("更新失败: %w", err)
 	}
-
-	// 删除备份
-	os.RemoveAll(backupDir)
 	return nil
 }
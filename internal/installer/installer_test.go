@@ -0,0 +1,96 @@
+package installer
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testArchive = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// newArchiveServer 返回提供 testArchive 的服务器，honorRange 为 false 时忽略 Range 请求头
+func newArchiveServer(t *testing.T, honorRange bool, gotRange *string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*gotRange = r.Header.Get("Range")
+		var offset int
+		if honorRange && *gotRange != "" {
+			if _, err := fmt.Sscanf(*gotRange, "bytes=%d-", &offset); err != nil || offset > len(testArchive) {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(testArchive)-1, len(testArchive)))
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		w.Write([]byte(testArchive[offset:]))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDownloadFileResume(t *testing.T) {
+	tests := []struct {
+		name       string
+		partial    string
+		honorRange bool
+		wantRange  string
+	}{
+		{name: "fresh download", honorRange: true},
+		{name: "resumes from part file", partial: testArchive[:10], honorRange: true, wantRange: "bytes=10-"},
+		{name: "server ignores range", partial: "stale data", honorRange: false, wantRange: "bytes=10-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRange string
+			srv := newArchiveServer(t, tt.honorRange, &gotRange)
+
+			path := filepath.Join(t.TempDir(), "frp.tar.gz")
+			if tt.partial != "" {
+				if err := os.WriteFile(path+".part", []byte(tt.partial), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			inst := &Installer{}
+			if err := inst.downloadFile(srv.URL, path); err != nil {
+				t.Fatalf("downloadFile: %v", err)
+			}
+			if gotRange != tt.wantRange {
+				t.Errorf("Range header = %q, want %q", gotRange, tt.wantRange)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != testArchive {
+				t.Errorf("downloaded %q, want %q", got, testArchive)
+			}
+			if _, err := os.Stat(path + ".part"); !os.IsNotExist(err) {
+				t.Errorf("part file left behind: %v", err)
+			}
+		})
+	}
+}
+
+func TestDownloadFileInvalidRange(t *testing.T) {
+	var gotRange string
+	srv := newArchiveServer(t, true, &gotRange)
+
+	path := filepath.Join(t.TempDir(), "frp.tar.gz")
+	if err := os.WriteFile(path+".part", []byte(strings.Repeat("x", len(testArchive)+5)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	inst := &Installer{}
+	if err := inst.downloadFile(srv.URL, path); err == nil {
+		t.Fatal("downloadFile succeeded with a part file larger than the archive")
+	}
+	if _, err := os.Stat(path + ".part"); !os.IsNotExist(err) {
+		t.Errorf("invalid part file not removed: %v", err)
+	}
+}
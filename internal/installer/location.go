@@ -0,0 +1,200 @@
+package installer
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// SystemInstallDir 系统范围安装的目录，所有用户都可以在 PATH 中找到，写入需要管理员权限
+const SystemInstallDir = "/usr/local/bin"
+
+// DefaultInstallDir 返回默认安装目录，即与配置目录相同的 ~/.frp-manager
+func DefaultInstallDir() string {
+	homeDir, _ := os.UserHomeDir()
+	if homeDir == "" {
+		return ".frp-manager"
+	}
+	return filepath.Join(homeDir, ".frp-manager")
+}
+
+// InstallDirFromSettings 返回设置中记录的安装目录，未设置时返回默认安装目录
+func InstallDirFromSettings(settings *config.AppSettings) string {
+	if settings == nil || settings.InstallDir == "" {
+		return DefaultInstallDir()
+	}
+	return settings.InstallDir
+}
+
+// ExecutablePath 返回目录中 frps/frpc 程序的路径，Windows 下带 .exe 后缀
+func ExecutablePath(dir, name string) string {
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return filepath.Join(dir, name)
+}
+
+// SetInstallDir 指定安装目录，之后不再跟随设置中的安装目录
+func (i *Installer) SetInstallDir(dir string) {
+	i.installDir = dir
+	i.explicitDir = true
+}
+
+// IsDefaultDir 是否安装在默认目录，默认目录由本工具独占，卸载和更新时可以整体删除或备份
+func (i *Installer) IsDefaultDir() bool {
+	return samePath(i.installDir, DefaultInstallDir())
+}
+
+// NeedsElevation 当前用户是否无法写入安装目录，需要通过 sudo 安装。Windows 下总是返回 false
+func (i *Installer) NeedsElevation() bool {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		return false
+	}
+	return !dirWritable(i.installDir)
+}
+
+// dirWritable 判断当前用户能否在目录中创建文件，目录不存在时检查最近的已存在上级目录
+func dirWritable(dir string) bool {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	for {
+		if info, err := os.Stat(dir); err == nil {
+			if !info.IsDir() {
+				return false
+			}
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+
+	f, err := os.CreateTemp(dir, ".frp-write-test-*")
+	if err != nil {
+		return false
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return true
+}
+
+// StageFRP 下载、校验并解压 FRP 到新建的临时目录，返回该目录，调用方负责删除
+func (i *Installer) StageFRP() (string, error) {
+	downloadURL, filename, err := i.getDownloadURL()
+	if err != nil {
+		return "", i18n.Errorf("获取下载链接失败: %w", err)
+	}
+
+	// 下载文件，失败时保留未完成的部分以便下次续传
+	tempFile := filepath.Join(os.TempDir(), filename)
+	if err := i.downloadFile(i.mirrorURL(downloadURL, filename), tempFile); err != nil {
+		return "", i18n.Errorf("下载文件失败: %w", err)
+	}
+
+	// 校验文件，避免安装被篡改或损坏的程序
+	if !i.skipVerify {
+		if err := i.verifyChecksum(tempFile, filename); err != nil {
+			return "", i18n.Errorf("校验文件失败: %w", err)
+		}
+	}
+
+	// 文件可能已损坏，解压失败时同样删除，下次重新下载
+	staged, err := i.extractStaged(tempFile)
+	os.Remove(tempFile)
+	return staged, err
+}
+
+// extractStaged 把压缩包解压到新建的临时目录并确认其中有 frps 和 frpc，返回该目录，调用方负责删除
+func (i *Installer) extractStaged(archive string) (string, error) {
+	staged, err := os.MkdirTemp("", "frp-install-")
+	if err != nil {
+		return "", i18n.Errorf("创建临时目录失败: %w", err)
+	}
+	if err := i.extractFile(archive, staged); err != nil {
+		os.RemoveAll(staged)
+		return "", i18n.Errorf("解压文件失败: %w", err)
+	}
+
+	for _, name := range []string{"frps", "frpc"} {
+		path := ExecutablePath(staged, name)
+		if !i.fileExists(path) {
+			os.RemoveAll(staged)
+			return "", i18n.Errorf("安装包中缺少 %s", filepath.Base(path))
+		}
+		// 设置执行权限 (Unix 系统)
+		if runtime.GOOS != "windows" {
+			os.Chmod(path, 0755)
+		}
+	}
+	return staged, nil
+}
+
+// installStaged 把临时目录中的程序逐个替换到安装目录
+func (i *Installer) installStaged(staged string) error {
+	for _, name := range []string{"frps", "frpc"} {
+		if err := replaceFile(ExecutablePath(staged, name), ExecutablePath(i.installDir, name)); err != nil {
+			return i18n.Errorf("安装 %s 失败: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ElevatedInstallCommand 返回通过 sudo 把临时目录中的程序安装到安装目录的命令，
+// sudo 会在终端中提示输入密码，调用方需要让出终端后再执行
+func (i *Installer) ElevatedInstallCommand(staged string) *exec.Cmd {
+	prompt := i18n.Sprintf("[sudo] 安装 FRP 到 %s 需要管理员密码: ", i.installDir)
+	// sh -c 之后的第一个参数为 $0，即安装目录，其余为要安装的程序
+	args := []string{"-p", prompt, "sh", "-c", `mkdir -p "$0" && install -m 0755 "$@" "$0"`, i.installDir}
+	for _, name := range []string{"frps", "frpc"} {
+		args = append(args, ExecutablePath(staged, name))
+	}
+	return exec.Command("sudo", args...)
+}
+
+// replaceFile 先复制到目标旁的临时文件再重命名，安装中途失败时不会留下损坏的程序
+func replaceFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp := dst + ".new"
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// samePath 判断两个路径是否指向同一位置
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return absA == absB
+}
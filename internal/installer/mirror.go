@@ -0,0 +1,119 @@
+package installer
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// SetDownloadMirror 设置下载镜像。模板中可使用 {url}、{version}、{filename} 占位符，
+// 不含占位符时作为前缀拼接在原始链接前（ghproxy 风格），为空时直接从 GitHub 下载
+func (i *Installer) SetDownloadMirror(mirror string) {
+	i.mirror = strings.TrimSpace(mirror)
+}
+
+// SetDownloadProxy 设置下载代理，支持 http://、https://、socks5:// 地址，为空时使用环境变量中的代理
+func (i *Installer) SetDownloadProxy(proxy string) error {
+	proxy = strings.TrimSpace(proxy)
+	if proxy == "" {
+		i.proxyURL = nil
+	} else {
+		proxyURL, err := ParseProxyURL(proxy)
+		if err != nil {
+			return err
+		}
+		i.proxyURL = proxyURL
+	}
+
+	// 获取版本列表同样走代理
+	if i.releases != nil {
+		i.releases.httpClient = i.newHTTPClient(15 * time.Second)
+	}
+	return nil
+}
+
+// ApplySettings 应用安装目录、固定版本、更新通道、下载镜像和代理设置，调用方指定了安装目录时保持不变
+func (i *Installer) ApplySettings(settings *config.AppSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if !i.explicitDir {
+		i.installDir = InstallDirFromSettings(settings)
+	}
+	i.serverVersion, i.clientVersion = settings.ServerVersion, settings.ClientVersion
+	i.prerelease = settings.IncludesPrereleases()
+	i.SetDownloadMirror(settings.DownloadMirror)
+	return i.SetDownloadProxy(settings.DownloadProxy)
+}
+
+// ParseProxyURL 解析并校验代理地址
+func ParseProxyURL(proxy string) (*url.URL, error) {
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		return nil, i18n.Errorf("无效的代理地址: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, i18n.Errorf("不支持的代理协议: %q，仅支持 http、https、socks5", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, i18n.Errorf("代理地址缺少主机: %s", proxy)
+	}
+	return proxyURL, nil
+}
+
+// ValidateMirror 校验镜像模板
+func ValidateMirror(mirror string) error {
+	mirror = strings.TrimSpace(mirror)
+	if mirror == "" {
+		return nil
+	}
+
+	sample := strings.NewReplacer("{url}", "https://github.com/x", "{version}", "0.0.0", "{filename}", "x").Replace(mirror)
+	parsed, err := url.Parse(sample)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return i18n.Errorf("无效的镜像地址: %s", mirror)
+	}
+	return nil
+}
+
+// mirrorURL 按镜像设置改写 FRP 的下载链接
+func (i *Installer) mirrorURL(rawURL, filename string) string {
+	return i.mirrorURLFor(rawURL, i.version, filename)
+}
+
+// mirrorURLFor 按镜像设置改写下载链接，version 用于替换模板中的 {version}
+func (i *Installer) mirrorURLFor(rawURL, version, filename string) string {
+	if i.mirror == "" {
+		return rawURL
+	}
+
+	if strings.Contains(i.mirror, "{") {
+		return strings.NewReplacer(
+			"{url}", rawURL,
+			"{version}", version,
+			"{filename}", filename,
+		).Replace(i.mirror)
+	}
+
+	return strings.TrimRight(i.mirror, "/") + "/" + rawURL
+}
+
+// newHTTPClient 创建使用代理设置的 HTTP 客户端
+func (i *Installer) newHTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if i.proxyURL != nil {
+		transport.Proxy = http.ProxyURL(i.proxyURL)
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
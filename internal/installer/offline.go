@@ -0,0 +1,101 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// archivePattern 匹配 frp 发布的压缩包名，如 frp_0.52.3_linux_amd64.tar.gz
+var archivePattern = regexp.MustCompile(`^frp_(\d+\.\d+\.\d+(?:-[0-9A-Za-z.]+)?)_([0-9a-z]+)_([0-9a-z]+)\.(tar\.gz|zip)$`)
+
+// ArchiveInfo 从本地压缩包文件名中识别出的版本和平台
+type ArchiveInfo struct {
+	Path    string
+	Version string
+	OS      string
+	Arch    string
+}
+
+// ParseArchive 按 frp 发布的命名规则识别本地压缩包，并确认它适用于当前平台
+func ParseArchive(path string) (*ArchiveInfo, error) {
+	name := filepath.Base(path)
+	m := archivePattern.FindStringSubmatch(name)
+	if m == nil {
+		return nil, i18n.Errorf("无法识别的安装包 %s，文件名应为 frp_<版本>_<系统>_<架构>.tar.gz 或 .zip", name)
+	}
+	info := &ArchiveInfo{Path: path, Version: m[1], OS: m[2], Arch: m[3]}
+
+	if info.OS != runtime.GOOS || info.Arch != runtime.GOARCH {
+		return nil, i18n.Errorf("安装包 %s 适用于 %s/%s，当前平台为 %s/%s", name, info.OS, info.Arch, runtime.GOOS, runtime.GOARCH)
+	}
+	// frp 的 Windows 版本只发布 zip，其他平台只发布 tar.gz
+	if (m[4] == "zip") != (runtime.GOOS == "windows") {
+		return nil, i18n.Errorf("安装包 %s 的格式与当前平台不符", name)
+	}
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return nil, i18n.Errorf("安装包 %s 不存在", path)
+	}
+	return info, nil
+}
+
+// verifyArchive 对照压缩包所在目录中的 frp_sha256_checksums.txt 校验本地压缩包，
+// 离线环境无法下载校验文件，需要与压缩包一起拷贝过来
+func (i *Installer) verifyArchive(archive *ArchiveInfo) error {
+	checksumsPath := filepath.Join(filepath.Dir(archive.Path), checksumsFilename)
+	file, err := os.Open(checksumsPath)
+	if os.IsNotExist(err) {
+		return i18n.Errorf("未找到校验文件 %s，请把发布附带的校验文件放在安装包所在目录", checksumsPath)
+	}
+	if err != nil {
+		return i18n.Errorf("读取校验文件失败: %w", err)
+	}
+	defer file.Close()
+
+	checksums, err := parseChecksums(file)
+	if err != nil {
+		return err
+	}
+	return matchChecksum(checksums, archive.Path, filepath.Base(archive.Path))
+}
+
+// StageArchive 校验并解压本地压缩包到新建的临时目录，返回该目录，调用方负责删除。
+// 压缩包本身保持不变，之后安装的版本为压缩包的版本
+func (i *Installer) StageArchive(path string) (string, error) {
+	archive, err := ParseArchive(path)
+	if err != nil {
+		return "", err
+	}
+	if !i.skipVerify {
+		if err := i.verifyArchive(archive); err != nil {
+			return "", i18n.Errorf("校验文件失败: %w", err)
+		}
+	}
+	i.version = archive.Version
+	return i.extractStaged(archive.Path)
+}
+
+// InstallFromArchive 从本地压缩包安装 FRP，用于无法访问 GitHub 的离线环境。
+// 与在线安装一样，默认目录中按版本并存，其他目录直接替换程序
+func (i *Installer) InstallFromArchive(path string) error {
+	if err := i.CheckNotPackageManaged(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(i.installDir, 0755); err != nil {
+		return i18n.Errorf("创建安装目录失败: %w", err)
+	}
+
+	staged, err := i.StageArchive(path)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staged)
+
+	if i.ManagesVersions() {
+		return i.installVersion(staged)
+	}
+	return i.installStaged(staged)
+}
@@ -0,0 +1,93 @@
+package installer
+
+import (
+	"io"
+	"time"
+)
+
+// progressInterval 进度上报的最小间隔
+const progressInterval = 200 * time.Millisecond
+
+// DownloadProgress 下载进度
+type DownloadProgress struct {
+	Downloaded int64         // 已下载字节数（含续传前已有部分）
+	Total      int64         // 总字节数，未知时为 0
+	Speed      float64       // 当前速度，字节/秒
+	ETA        time.Duration // 预计剩余时间，未知时为 0
+	Resumed    bool          // 是否为断点续传
+	Done       bool          // 下载是否完成
+}
+
+// Percent 返回完成比例 0~1，总大小未知时返回 0
+func (p DownloadProgress) Percent() float64 {
+	if p.Total <= 0 {
+		return 0
+	}
+	return float64(p.Downloaded) / float64(p.Total)
+}
+
+// progressWriter 统计写入字节数并按间隔上报进度
+type progressWriter struct {
+	w          io.Writer
+	ch         chan<- DownloadProgress
+	progress   DownloadProgress
+	startBytes int64
+	startTime  time.Time
+	lastReport time.Time
+}
+
+// newProgressWriter 创建进度统计写入器，ch 为空时不上报
+func newProgressWriter(w io.Writer, ch chan<- DownloadProgress, offset, total int64) *progressWriter {
+	return &progressWriter{
+		w:  w,
+		ch: ch,
+		progress: DownloadProgress{
+			Downloaded: offset,
+			Total:      total,
+			Resumed:    offset > 0,
+		},
+		startBytes: offset,
+		startTime:  time.Now(),
+	}
+}
+
+// Write 写入数据并更新进度
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.progress.Downloaded += int64(n)
+
+	if time.Since(pw.lastReport) >= progressInterval {
+		pw.report()
+	}
+	return n, err
+}
+
+// finish 上报下载完成
+func (pw *progressWriter) finish() {
+	pw.progress.Done = true
+	pw.progress.ETA = 0
+	pw.report()
+}
+
+// report 计算速度与剩余时间并非阻塞地发送进度
+func (pw *progressWriter) report() {
+	pw.lastReport = time.Now()
+	if pw.ch == nil {
+		return
+	}
+
+	elapsed := time.Since(pw.startTime).Seconds()
+	if elapsed > 0 {
+		pw.progress.Speed = float64(pw.progress.Downloaded-pw.startBytes) / elapsed
+	}
+	if pw.progress.Speed > 0 && pw.progress.Total > pw.progress.Downloaded {
+		remaining := float64(pw.progress.Total-pw.progress.Downloaded) / pw.progress.Speed
+		pw.progress.ETA = time.Duration(remaining * float64(time.Second))
+	}
+
+	// 界面来不及消费时丢弃本次进度，避免阻塞下载
+	select {
+	case pw.ch <- pw.progress:
+	default:
+	}
+}
@@ -0,0 +1,215 @@
+package installer
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+const (
+	// defaultReleasesURL frp 发布列表 API
+	defaultReleasesURL = "https://api.github.com/repos/fatedier/frp/releases?per_page=30"
+	// releaseCacheTTL 缓存有效期，期内不再请求 GitHub
+	releaseCacheTTL = 6 * time.Hour
+)
+
+// Release GitHub 发布信息
+type Release struct {
+	TagName     string         `json:"tag_name"`
+	Name        string         `json:"name"`
+	Prerelease  bool           `json:"prerelease"`
+	Draft       bool           `json:"draft"`
+	PublishedAt time.Time      `json:"published_at"`
+	Assets      []ReleaseAsset `json:"assets"`
+}
+
+// ReleaseAsset 发布附件
+type ReleaseAsset struct {
+	Name               string `json:"name"`
+	Size               int64  `json:"size"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// Version 返回去掉 v 前缀的版本号
+func (r Release) Version() string {
+	if v, err := ParseVersion(r.TagName); err == nil {
+		return v.String()
+	}
+	return r.TagName
+}
+
+// releaseCache 发布列表缓存文件内容
+type releaseCache struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Releases  []Release `json:"releases"`
+}
+
+// ReleaseClient GitHub 发布列表客户端，带本地缓存以便离线使用
+type ReleaseClient struct {
+	apiURL     string
+	cachePath  string
+	httpClient *http.Client
+}
+
+// NewReleaseClient 创建 frp 发布列表客户端
+func NewReleaseClient(cachePath string) *ReleaseClient {
+	return newReleaseClient(defaultReleasesURL, cachePath)
+}
+
+// newReleaseClient 创建指定仓库发布列表 API 的客户端
+func newReleaseClient(apiURL, cachePath string) *ReleaseClient {
+	return &ReleaseClient{
+		apiURL:    apiURL,
+		cachePath: cachePath,
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+	}
+}
+
+// ListReleases 获取可用版本列表（按版本号从新到旧），缓存未过期时直接使用缓存，
+// 请求失败时回退到过期缓存
+func (c *ReleaseClient) ListReleases() ([]Release, error) {
+	cache, cacheErr := c.loadCache()
+	if cacheErr == nil && time.Since(cache.FetchedAt) < releaseCacheTTL {
+		return cache.Releases, nil
+	}
+
+	releases, err := c.fetch()
+	if err != nil {
+		if cacheErr == nil {
+			return cache.Releases, nil
+		}
+		return nil, err
+	}
+
+	c.saveCache(releases)
+	return releases, nil
+}
+
+// Refresh 忽略缓存重新获取版本列表
+func (c *ReleaseClient) Refresh() ([]Release, error) {
+	releases, err := c.fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.saveCache(releases)
+	return releases, nil
+}
+
+// Latest 返回最新的版本，includePrerelease 为 false 时只考虑正式版本
+func (c *ReleaseClient) Latest(includePrerelease bool) (*Release, error) {
+	releases, err := c.ListReleases()
+	if err != nil {
+		return nil, err
+	}
+	if releases = FilterChannel(releases, includePrerelease); len(releases) > 0 {
+		return &releases[0], nil
+	}
+	if includePrerelease {
+		return nil, i18n.Errorf("没有可用的版本")
+	}
+	return nil, i18n.Errorf("没有可用的正式版本")
+}
+
+// FilterChannel 按更新通道筛选版本，includePrerelease 为 false 时去掉预发布版本
+func FilterChannel(releases []Release, includePrerelease bool) []Release {
+	if includePrerelease {
+		return releases
+	}
+	stable := make([]Release, 0, len(releases))
+	for _, release := range releases {
+		if !release.Prerelease {
+			stable = append(stable, release)
+		}
+	}
+	return stable
+}
+
+// fetch 从 GitHub 获取发布列表
+func (c *ReleaseClient) fetch() ([]Release, error) {
+	req, err := http.NewRequest("GET", c.apiURL, nil)
+	if err != nil {
+		return nil, i18n.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, i18n.Errorf("获取版本列表失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, i18n.Errorf("获取版本列表失败，状态码: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, i18n.Errorf("读取版本列表失败: %w", err)
+	}
+
+	var all []Release
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, i18n.Errorf("解析版本列表失败: %w", err)
+	}
+
+	releases := make([]Release, 0, len(all))
+	for _, release := range all {
+		if release.Draft {
+			continue
+		}
+		if _, err := ParseVersion(release.TagName); err != nil {
+			continue
+		}
+		releases = append(releases, release)
+	}
+
+	sort.SliceStable(releases, func(a, b int) bool {
+		return CompareVersions(releases[a].TagName, releases[b].TagName) > 0
+	})
+
+	return releases, nil
+}
+
+// loadCache 读取缓存
+func (c *ReleaseClient) loadCache() (*releaseCache, error) {
+	if c.cachePath == "" {
+		return nil, i18n.Errorf("未设置缓存路径")
+	}
+
+	data, err := os.ReadFile(c.cachePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var cache releaseCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, err
+	}
+	return &cache, nil
+}
+
+// saveCache 写入缓存，失败时忽略
+func (c *ReleaseClient) saveCache(releases []Release) {
+	if c.cachePath == "" {
+		return
+	}
+
+	data, err := json.MarshalIndent(releaseCache{FetchedAt: time.Now(), Releases: releases}, "", "  ")
+	if err != nil {
+		return
+	}
+
+	os.MkdirAll(filepath.Dir(c.cachePath), 0755)
+	os.WriteFile(c.cachePath, data, 0644)
+}
@@ -0,0 +1,215 @@
+package installer
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+const (
+	// selfReleasesURL frp-cli-ui 自身的发布列表 API
+	selfReleasesURL = "https://api.github.com/repos/konbluesky/frp-cli-ui/releases?per_page=10"
+	// selfChecksumsAsset 每个发布附带的 SHA256 校验文件，sha256sum 格式
+	selfChecksumsAsset = "checksums.txt"
+)
+
+// SelfAssetName 返回当前平台的发布附件名，如 frp-cli-ui_linux_amd64，Windows 下带 .exe 后缀
+func SelfAssetName() string {
+	name := "frp-cli-ui_" + runtime.GOOS + "_" + runtime.GOARCH
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// SelfUpdate frp-cli-ui 自身的更新检查结果
+type SelfUpdate struct {
+	Current   string        // 正在运行的版本
+	Latest    *Release      // 最新的正式版本，没有发布时为空
+	Asset     *ReleaseAsset // 当前平台的程序，已是最新版本时为空
+	Checksums *ReleaseAsset // 发布附带的校验文件
+}
+
+// Available 是否有可以安装的新版本
+func (u *SelfUpdate) Available() bool {
+	return u != nil && u.Asset != nil
+}
+
+// Version 返回新版本号，没有新版本时返回空
+func (u *SelfUpdate) Version() string {
+	if !u.Available() {
+		return ""
+	}
+	return u.Latest.Version()
+}
+
+// SelfUpdater 检查并安装 frp-cli-ui 自身的新版本，下载使用安装器的镜像、代理和进度设置
+type SelfUpdater struct {
+	inst     *Installer
+	releases *ReleaseClient
+}
+
+// NewSelfUpdater 创建自更新器
+func NewSelfUpdater(inst *Installer) *SelfUpdater {
+	releases := newReleaseClient(selfReleasesURL, filepath.Join(config.GetDefaultWorkDir(), "self-releases-cache.json"))
+	releases.httpClient = inst.newHTTPClient(15 * time.Second)
+	return &SelfUpdater{inst: inst, releases: releases}
+}
+
+// Check 检查是否有新的正式版本，forceRefresh 为 true 时忽略缓存
+func (u *SelfUpdater) Check(forceRefresh bool) (*SelfUpdate, error) {
+	u.releases.httpClient = u.inst.newHTTPClient(15 * time.Second)
+	list := u.releases.ListReleases
+	if forceRefresh {
+		list = u.releases.Refresh
+	}
+	releases, err := list()
+	if err != nil {
+		return nil, err
+	}
+
+	update := &SelfUpdate{Current: config.AppVersion}
+	stable := FilterChannel(releases, false)
+	if len(stable) == 0 {
+		return update, nil
+	}
+	update.Latest = &stable[0]
+	if CompareVersions(update.Latest.Version(), config.AppVersion) <= 0 {
+		return update, nil
+	}
+
+	for i, asset := range update.Latest.Assets {
+		switch asset.Name {
+		case SelfAssetName():
+			update.Asset = &update.Latest.Assets[i]
+		case selfChecksumsAsset:
+			update.Checksums = &update.Latest.Assets[i]
+		}
+	}
+	if update.Asset == nil {
+		return nil, i18n.Errorf("版本 %s 没有提供 %s/%s 平台的程序", update.Latest.Version(), runtime.GOOS, runtime.GOARCH)
+	}
+	return update, nil
+}
+
+// Apply 下载并校验新版本，确认能够运行后原子替换正在运行的程序，返回程序路径。
+// 替换后需要重启才会使用新版本
+func (u *SelfUpdater) Apply(update *SelfUpdate) (string, error) {
+	if !update.Available() {
+		return "", i18n.Errorf("已是最新版本")
+	}
+	exe, err := SelfExecutable()
+	if err != nil {
+		return "", err
+	}
+	if runtime.GOOS != "windows" && os.Geteuid() != 0 && !dirWritable(filepath.Dir(exe)) {
+		return "", i18n.Errorf("没有写入 %s 的权限，请执行 sudo %s self-update", filepath.Dir(exe), exe)
+	}
+
+	// 下载到程序旁边，替换时只需重命名
+	version, asset := update.Version(), update.Asset
+	staged := exe + ".new"
+	if err := u.inst.downloadFile(u.inst.mirrorURLFor(asset.BrowserDownloadURL, version, asset.Name), staged); err != nil {
+		return "", i18n.Errorf("下载文件失败: %w", err)
+	}
+	defer os.Remove(staged)
+
+	if !u.inst.skipVerify {
+		if err := u.verify(update, staged); err != nil {
+			return "", i18n.Errorf("校验文件失败: %w", err)
+		}
+	}
+	if err := os.Chmod(staged, 0755); err != nil {
+		return "", err
+	}
+	if err := checkRunnable(staged); err != nil {
+		return "", err
+	}
+
+	if err := swapExecutable(staged, exe); err != nil {
+		return "", i18n.Errorf("替换程序失败: %w", err)
+	}
+	return exe, nil
+}
+
+// verify 对照发布附带的校验文件验证下载的程序
+func (u *SelfUpdater) verify(update *SelfUpdate, path string) error {
+	if update.Checksums == nil {
+		return i18n.Errorf("版本 %s 没有提供校验文件 %s", update.Version(), selfChecksumsAsset)
+	}
+
+	client := u.inst.newHTTPClient(30 * time.Second)
+	resp, err := client.Get(u.inst.mirrorURLFor(update.Checksums.BrowserDownloadURL, update.Version(), selfChecksumsAsset))
+	if err != nil {
+		return i18n.Errorf("请求校验文件失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return i18n.Errorf("获取校验文件失败，状态码: %d", resp.StatusCode)
+	}
+	checksums, err := parseChecksums(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return matchChecksum(checksums, path, update.Asset.Name)
+}
+
+// SelfExecutable 返回正在运行的程序的实际路径
+func SelfExecutable() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", i18n.Errorf("获取程序路径失败: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	return exe, nil
+}
+
+// CleanupSelfUpdate 删除 Windows 上替换程序时留下的旧程序，启动时调用
+func CleanupSelfUpdate() {
+	if exe, err := SelfExecutable(); err == nil {
+		os.Remove(exe + ".old")
+	}
+}
+
+// checkRunnable 运行新程序的 version 子命令，避免替换成无法在本机运行的程序
+func checkRunnable(path string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := exec.CommandContext(ctx, path, "version").Run(); err != nil {
+		return i18n.Errorf("新版本无法在本机运行: %w", err)
+	}
+	return nil
+}
+
+// swapExecutable 用新程序替换正在运行的程序。Unix 上直接重命名覆盖，正在运行的进程不受影响；
+// Windows 不能覆盖正在运行的程序，先把它改名为 .old，下次启动时删除
+func swapExecutable(staged, exe string) error {
+	if runtime.GOOS != "windows" {
+		return os.Rename(staged, exe)
+	}
+	return swapWithBackup(staged, exe)
+}
+
+// swapWithBackup 先把 exe 改名为 .old 再放入新程序，放入失败时恢复原程序
+func swapWithBackup(staged, exe string) error {
+	old := exe + ".old"
+	os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		return err
+	}
+	if err := os.Rename(staged, exe); err != nil {
+		os.Rename(old, exe)
+		return err
+	}
+	return nil
+}
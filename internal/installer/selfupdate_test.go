@@ -0,0 +1,69 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeExecutables 在临时目录中写入正在运行的程序和下载好的新程序
+func writeExecutables(t *testing.T) (staged, exe string) {
+	t.Helper()
+	dir := t.TempDir()
+	exe = filepath.Join(dir, "frp-cli-ui")
+	staged = exe + ".new"
+	if err := os.WriteFile(exe, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(staged, []byte("new"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return staged, exe
+}
+
+// assertContent 检查文件内容
+func assertContent(t *testing.T, path, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("%s = %q, want %q", filepath.Base(path), got, want)
+	}
+}
+
+func TestSwapExecutable(t *testing.T) {
+	staged, exe := writeExecutables(t)
+	if err := swapExecutable(staged, exe); err != nil {
+		t.Fatalf("swapExecutable: %v", err)
+	}
+	assertContent(t, exe, "new")
+	if _, err := os.Stat(staged); !os.IsNotExist(err) {
+		t.Errorf("staged file left behind: %v", err)
+	}
+}
+
+func TestSwapWithBackup(t *testing.T) {
+	staged, exe := writeExecutables(t)
+	if err := swapWithBackup(staged, exe); err != nil {
+		t.Fatalf("swapWithBackup: %v", err)
+	}
+	assertContent(t, exe, "new")
+	assertContent(t, exe+".old", "old")
+}
+
+func TestSwapWithBackupRestoresOnFailure(t *testing.T) {
+	staged, exe := writeExecutables(t)
+	if err := os.Remove(staged); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := swapWithBackup(staged, exe); err == nil {
+		t.Fatal("swapWithBackup succeeded without a staged file")
+	}
+	assertContent(t, exe, "old")
+	if _, err := os.Stat(exe + ".old"); !os.IsNotExist(err) {
+		t.Errorf("backup left behind after restore: %v", err)
+	}
+}
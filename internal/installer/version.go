@@ -0,0 +1,156 @@
+package installer
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// Version 语义化版本号
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+// ParseVersion 解析版本号，支持 v 前缀和 -rc1 等预发布后缀
+func ParseVersion(s string) (Version, error) {
+	var v Version
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" {
+		return v, i18n.Errorf("版本号为空")
+	}
+
+	// 去掉构建元数据
+	if idx := strings.Index(s, "+"); idx >= 0 {
+		s = s[:idx]
+	}
+	if idx := strings.Index(s, "-"); idx >= 0 {
+		v.Prerelease = s[idx+1:]
+		s = s[:idx]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return v, i18n.Errorf("无效的版本号: %s", s)
+	}
+
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, i18n.Errorf("无效的版本号: %s", s)
+		}
+		*numbers[i] = n
+	}
+
+	return v, nil
+}
+
+// String 返回不带 v 前缀的版本号
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Compare 比较两个版本，返回 -1、0 或 1
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]int{
+		{v.Major, other.Major},
+		{v.Minor, other.Minor},
+		{v.Patch, other.Patch},
+	} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// 正式版本高于同号的预发布版本
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	default:
+		return comparePrerelease(v.Prerelease, other.Prerelease)
+	}
+}
+
+// comparePrerelease 按语义化版本规则逐段比较预发布后缀：纯数字段按数值比较且低于其他段，
+// 段数多的高于前缀相同的；rc10 这类字母后接数字的段按字母和数字分别比较，使 rc10 高于 rc2
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := comparePrereleaseIdent(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(as), len(bs))
+}
+
+// comparePrereleaseIdent 比较预发布后缀中的一段
+func comparePrereleaseIdent(a, b string) int {
+	an, aNumeric := prereleaseNumber(a)
+	bn, bNumeric := prereleaseNumber(b)
+	switch {
+	case aNumeric && bNumeric:
+		return cmp.Compare(an, bn)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	}
+
+	aPrefix, aSuffix := splitTrailingDigits(a)
+	bPrefix, bSuffix := splitTrailingDigits(b)
+	if aPrefix != bPrefix || aSuffix == "" || bSuffix == "" {
+		return strings.Compare(a, b)
+	}
+	an, _ = prereleaseNumber(aSuffix)
+	bn, _ = prereleaseNumber(bSuffix)
+	return cmp.Compare(an, bn)
+}
+
+// prereleaseNumber 解析纯数字的段
+func prereleaseNumber(s string) (uint64, bool) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	return n, err == nil
+}
+
+// splitTrailingDigits 拆出末尾的数字，如 rc10 拆为 rc 和 10
+func splitTrailingDigits(s string) (string, string) {
+	i := len(s)
+	for i > 0 && s[i-1] >= '0' && s[i-1] <= '9' {
+		i--
+	}
+	return s[:i], s[i:]
+}
+
+// IsPrerelease 判断版本号是否带有 -rc1 等预发布后缀
+func IsPrerelease(version string) bool {
+	v, err := ParseVersion(version)
+	return err == nil && v.Prerelease != ""
+}
+
+// CompareVersions 比较两个版本字符串，无法解析时按字符串比较
+func CompareVersions(a, b string) int {
+	va, errA := ParseVersion(a)
+	vb, errB := ParseVersion(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return va.Compare(vb)
+}
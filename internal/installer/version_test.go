@@ -0,0 +1,40 @@
+package installer
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"0.52.3", "0.52.3", 0},
+		{"v0.52.3", "0.52.3", 0},
+		{"0.52.3", "0.53.0", -1},
+		{"1.0.0", "0.99.9", 1},
+		{"0.53.0", "0.53.0-rc1", 1},
+		{"0.53.0-rc2", "0.53.0-rc10", -1},
+		{"0.53.0-rc10", "0.53.0-rc2", 1},
+		{"0.53.0-alpha", "0.53.0-beta", -1},
+		{"0.53.0-beta.2", "0.53.0-beta.11", -1},
+		{"0.53.0-1", "0.53.0-alpha", -1},
+		{"0.53.0-alpha", "0.53.0-alpha.1", -1},
+		{"0.53.0-rc1+build5", "0.53.0-rc1", 0},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSortPrereleases(t *testing.T) {
+	versions := []string{"0.53.0-rc10", "0.53.0", "0.53.0-rc2", "0.52.3", "0.53.0-rc1"}
+	slices.SortFunc(versions, CompareVersions)
+	want := []string{"0.52.3", "0.53.0-rc1", "0.53.0-rc2", "0.53.0-rc10", "0.53.0"}
+	if !slices.Equal(versions, want) {
+		t.Errorf("sorted = %v, want %v", versions, want)
+	}
+}
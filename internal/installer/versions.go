@@ -0,0 +1,275 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 默认安装目录中并存的各个版本位于 versions/<版本>/，current 和 previous 指向当前和上一个使用的版本
+const (
+	versionsDirName = "versions"
+	currentMarker   = "current"
+	previousMarker  = "previous"
+)
+
+// InstalledVersion 默认安装目录中已安装的一个版本
+type InstalledVersion struct {
+	Version  string
+	Dir      string
+	Current  bool // current 指向该版本
+	Previous bool // 切换前使用的版本，回滚时切换回来
+}
+
+// VersionsDir 返回并存版本所在的目录
+func VersionsDir(installDir string) string {
+	return filepath.Join(installDir, versionsDirName)
+}
+
+// VersionDir 返回某个版本的安装目录
+func VersionDir(installDir, version string) string {
+	return filepath.Join(VersionsDir(installDir), version)
+}
+
+// CurrentVersion 返回 current 指向的版本，尚未按版本安装时返回空
+func CurrentVersion(installDir string) string {
+	return readMarker(filepath.Join(VersionsDir(installDir), currentMarker))
+}
+
+// PreviousVersion 返回上一个使用的版本，没有时返回空
+func PreviousVersion(installDir string) string {
+	return readMarker(filepath.Join(VersionsDir(installDir), previousMarker))
+}
+
+// BinDir 返回安装目录中当前使用的程序所在目录：current 指向的版本目录，
+// 没有按版本安装时（旧版本安装或自定义目录）为安装目录本身。current 是符号链接时返回链接本身，
+// 系统服务等记录了程序路径的地方在切换版本并重启后同样使用新版本
+func BinDir(installDir string) string {
+	current := filepath.Join(VersionsDir(installDir), currentMarker)
+	if info, err := os.Lstat(current); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		return current
+	}
+	if version := CurrentVersion(installDir); version != "" {
+		return VersionDir(installDir, version)
+	}
+	return installDir
+}
+
+// PinnedVersion 返回设置中 frps 或 frpc 固定使用的版本，为空表示跟随 current
+func PinnedVersion(settings *config.AppSettings, name string) string {
+	if settings == nil {
+		return ""
+	}
+	if name == "frps" {
+		return settings.ServerVersion
+	}
+	return settings.ClientVersion
+}
+
+// ServiceBinDir 返回 frps 或 frpc 使用的程序所在目录，固定的版本已删除时回到 current
+func ServiceBinDir(settings *config.AppSettings, name string) string {
+	installDir := InstallDirFromSettings(settings)
+	if version := PinnedVersion(settings, name); version != "" {
+		dir := VersionDir(installDir, version)
+		if info, err := os.Stat(ExecutablePath(dir, name)); err == nil && !info.IsDir() {
+			return dir
+		}
+	}
+	return BinDir(installDir)
+}
+
+// ListInstalledVersions 列出默认安装目录中已安装的版本，从新到旧排列
+func ListInstalledVersions(installDir string) ([]InstalledVersion, error) {
+	entries, err := os.ReadDir(VersionsDir(installDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取已安装版本失败: %w", err)
+	}
+
+	current, previous := CurrentVersion(installDir), PreviousVersion(installDir)
+	var versions []InstalledVersion
+	for _, entry := range entries {
+		name := entry.Name()
+		// current 和 previous 是指向版本目录的符号链接或记录版本号的文件
+		if !entry.IsDir() || name == currentMarker || name == previousMarker {
+			continue
+		}
+		versions = append(versions, InstalledVersion{
+			Version:  name,
+			Dir:      VersionDir(installDir, name),
+			Current:  name == current,
+			Previous: name == previous,
+		})
+	}
+	sort.Slice(versions, func(a, b int) bool {
+		return CompareVersions(versions[a].Version, versions[b].Version) > 0
+	})
+	return versions, nil
+}
+
+// readMarker 读取 current 或 previous 指向的版本。Windows 上没有创建符号链接的权限时，
+// 标记是一个记录版本号的普通文件
+func readMarker(path string) string {
+	if target, err := os.Readlink(path); err == nil {
+		return filepath.Base(target)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// writeMarker 让 current 或 previous 指向 version。先在旁边创建再重命名覆盖，
+// 中途失败时原来的标记保持不变
+func writeMarker(path, version string) error {
+	tmp := path + ".new"
+	os.Remove(tmp)
+	if err := os.Symlink(version, tmp); err != nil {
+		if err := os.WriteFile(tmp, []byte(version+"\n"), 0644); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// ManagesVersions 是否按版本并存安装：只有本工具独占的默认目录才保留多个版本，
+// 自定义目录（如 /usr/local/bin）直接替换其中的程序
+func (i *Installer) ManagesVersions() bool {
+	return i.IsDefaultDir()
+}
+
+// HasVersion 默认安装目录中是否已安装该版本，已安装时切换过去不需要重新下载
+func (i *Installer) HasVersion(version string) bool {
+	dir := VersionDir(i.installDir, version)
+	return version != "" && i.fileExists(ExecutablePath(dir, "frps")) && i.fileExists(ExecutablePath(dir, "frpc"))
+}
+
+// InstalledVersions 列出默认安装目录中已安装的版本，从新到旧排列
+func (i *Installer) InstalledVersions() ([]InstalledVersion, error) {
+	if !i.ManagesVersions() {
+		return nil, nil
+	}
+	return ListInstalledVersions(i.installDir)
+}
+
+// UseVersion 把 current 切换到已安装的版本，原来的版本记为 previous 以便回滚。
+// 正在运行的 frps/frpc 重启后才会使用新版本
+func (i *Installer) UseVersion(version string) error {
+	if !i.ManagesVersions() {
+		return i18n.Errorf("只有安装在 %s 时才能切换版本", DefaultInstallDir())
+	}
+	if !i.HasVersion(version) {
+		return i18n.Errorf("版本 %s 未安装", version)
+	}
+	return i.setCurrent(version)
+}
+
+// Rollback 切换回上一个使用的版本，不需要重新下载，返回切换到的版本
+func (i *Installer) Rollback() (string, error) {
+	previous := PreviousVersion(i.installDir)
+	if previous == "" || !i.HasVersion(previous) {
+		return "", i18n.Errorf("没有可回滚的版本")
+	}
+	if err := i.UseVersion(previous); err != nil {
+		return "", err
+	}
+	return previous, nil
+}
+
+// RemoveVersion 删除一个已安装的版本，当前使用或被 frps/frpc 固定使用的版本不能删除
+func (i *Installer) RemoveVersion(version string) error {
+	if !i.ManagesVersions() || !i.HasVersion(version) {
+		return i18n.Errorf("版本 %s 未安装", version)
+	}
+	if version == CurrentVersion(i.installDir) {
+		return i18n.Errorf("版本 %s 正在使用，请先切换到其他版本", version)
+	}
+	if version == i.serverVersion || version == i.clientVersion {
+		return i18n.Errorf("版本 %s 已被 frps 或 frpc 固定使用，请先取消固定", version)
+	}
+	if err := os.RemoveAll(VersionDir(i.installDir, version)); err != nil {
+		return i18n.Errorf("删除版本 %s 失败: %w", version, err)
+	}
+	if version == PreviousVersion(i.installDir) {
+		os.Remove(filepath.Join(VersionsDir(i.installDir), previousMarker))
+	}
+	return nil
+}
+
+// setCurrent 让 current 指向 version，原来的版本记为 previous
+func (i *Installer) setCurrent(version string) error {
+	dir := VersionsDir(i.installDir)
+	current := CurrentVersion(i.installDir)
+	if current == version {
+		return nil
+	}
+	if current != "" {
+		if err := writeMarker(filepath.Join(dir, previousMarker), current); err != nil {
+			return i18n.Errorf("记录上一个版本失败: %w", err)
+		}
+	}
+	if err := writeMarker(filepath.Join(dir, currentMarker), version); err != nil {
+		return i18n.Errorf("切换版本失败: %w", err)
+	}
+	return nil
+}
+
+// installVersion 把临时目录中的程序安装到对应的版本目录并切换 current，
+// 其他版本保持不变，新版本有问题时可以回滚
+func (i *Installer) installVersion(staged string) error {
+	i.migrateLegacy()
+
+	dir := VersionDir(i.installDir, i.version)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return i18n.Errorf("创建版本目录失败: %w", err)
+	}
+	for _, name := range []string{"frps", "frpc"} {
+		if err := replaceFile(ExecutablePath(staged, name), ExecutablePath(dir, name)); err != nil {
+			return i18n.Errorf("安装 %s 失败: %w", name, err)
+		}
+	}
+	return i.setCurrent(i.version)
+}
+
+// migrateLegacy 把按版本安装之前直接放在安装目录中的程序移到对应的版本目录，
+// 之后可以回滚到该版本。无法识别版本时保留原样，不影响新版本的安装
+func (i *Installer) migrateLegacy() {
+	if CurrentVersion(i.installDir) != "" {
+		return
+	}
+	frps := ExecutablePath(i.installDir, "frps")
+	if !i.fileExists(frps) || !i.fileExists(ExecutablePath(i.installDir, "frpc")) {
+		return
+	}
+	version, err := i.getInstalledVersion(frps)
+	if err != nil {
+		return
+	}
+
+	dir := VersionDir(i.installDir, version)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+	for _, name := range []string{"frps", "frpc"} {
+		src, dst := ExecutablePath(i.installDir, name), ExecutablePath(dir, name)
+		if i.fileExists(dst) {
+			os.Remove(src)
+		} else if err := os.Rename(src, dst); err != nil {
+			return
+		}
+	}
+	if i.HasVersion(version) {
+		writeMarker(filepath.Join(VersionsDir(i.installDir), currentMarker), version)
+	}
+}
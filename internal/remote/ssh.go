@@ -0,0 +1,355 @@
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// dialTimeout SSH 连接超时
+const dialTimeout = 15 * time.Second
+
+// UnknownHostError 远程主机公钥尚未被信任
+type UnknownHostError struct {
+	Host        string
+	Fingerprint string
+	key         ssh.PublicKey
+}
+
+func (e *UnknownHostError) Error() string {
+	return i18n.Sprintf("未知主机 %s，公钥指纹 %s", e.Host, e.Fingerprint)
+}
+
+// Client 远程服务器 SSH 客户端
+type Client struct {
+	profile config.RemoteProfile
+	conn    *ssh.Client
+}
+
+// Dial 连接远程服务器，主机公钥需在 ~/.ssh/known_hosts 或应用自己的 known_hosts 中
+func Dial(profile config.RemoteProfile) (*Client, error) {
+	auth, err := authMethods(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := hostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := ssh.Dial("tcp", profile.Address(), &ssh.ClientConfig{
+		User:            profile.User,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         dialTimeout,
+	})
+	if err != nil {
+		var unknown *UnknownHostError
+		if errors.As(err, &unknown) {
+			return nil, unknown
+		}
+		return nil, i18n.Errorf("连接 %s 失败: %w", profile.Address(), err)
+	}
+
+	return &Client{profile: profile, conn: conn}, nil
+}
+
+// Close 关闭连接
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// authMethods 根据配置生成认证方式
+func authMethods(profile config.RemoteProfile) ([]ssh.AuthMethod, error) {
+	switch profile.AuthMethod {
+	case config.RemoteAuthPassword:
+		return []ssh.AuthMethod{ssh.Password(profile.Password)}, nil
+
+	case config.RemoteAuthAgent:
+		socket := os.Getenv("SSH_AUTH_SOCK")
+		if socket == "" {
+			return nil, i18n.Errorf("未检测到 ssh-agent (SSH_AUTH_SOCK 为空)")
+		}
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			return nil, i18n.Errorf("连接 ssh-agent 失败: %w", err)
+		}
+		return []ssh.AuthMethod{ssh.PublicKeysCallback(agent.NewClient(conn).Signers)}, nil
+
+	default:
+		signer, err := loadPrivateKey(profile.KeyPath, profile.Password)
+		if err != nil {
+			return nil, err
+		}
+		return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil
+	}
+}
+
+// loadPrivateKey 读取私钥，未指定路径时依次尝试常见的默认私钥
+func loadPrivateKey(keyPath, passphrase string) (ssh.Signer, error) {
+	candidates := []string{keyPath}
+	if keyPath == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, i18n.Errorf("获取用户主目录失败: %w", err)
+		}
+		candidates = []string{
+			filepath.Join(homeDir, ".ssh", "id_ed25519"),
+			filepath.Join(homeDir, ".ssh", "id_ecdsa"),
+			filepath.Join(homeDir, ".ssh", "id_rsa"),
+		}
+	}
+
+	for _, candidate := range candidates {
+		data, err := os.ReadFile(candidate)
+		if err != nil {
+			continue
+		}
+		if passphrase != "" {
+			signer, err := ssh.ParsePrivateKeyWithPassphrase(data, []byte(passphrase))
+			if err != nil {
+				return nil, i18n.Errorf("解析私钥 %s 失败: %w", candidate, err)
+			}
+			return signer, nil
+		}
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			var missing *ssh.PassphraseMissingError
+			if errors.As(err, &missing) {
+				return nil, i18n.Errorf("私钥 %s 有口令保护，请在密码中填写口令", candidate)
+			}
+			return nil, i18n.Errorf("解析私钥 %s 失败: %w", candidate, err)
+		}
+		return signer, nil
+	}
+
+	return nil, i18n.Errorf("找不到可用的私钥: %s", strings.Join(candidates, ", "))
+}
+
+// hostKeyCallback 依次检查用户和本应用的 known_hosts，主机未知时返回 UnknownHostError
+func hostKeyCallback() (ssh.HostKeyCallback, error) {
+	var files []string
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		files = append(files, filepath.Join(homeDir, ".ssh", "known_hosts"))
+	}
+	files = append(files, config.GetRemoteKnownHostsPath())
+
+	var existing []string
+	for _, file := range files {
+		if _, err := os.Stat(file); err == nil {
+			existing = append(existing, file)
+		}
+	}
+
+	var checker ssh.HostKeyCallback
+	if len(existing) > 0 {
+		var err error
+		checker, err = knownhosts.New(existing...)
+		if err != nil {
+			return nil, i18n.Errorf("读取 known_hosts 失败: %w", err)
+		}
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if checker != nil {
+			err := checker(hostname, remote, key)
+			var keyErr *knownhosts.KeyError
+			if err == nil || !errors.As(err, &keyErr) {
+				return err
+			}
+			// 记录存在但公钥不同，可能遭到中间人攻击，不允许继续
+			if len(keyErr.Want) > 0 {
+				return i18n.Errorf("主机 %s 的公钥与已记录的不一致，可能存在中间人攻击", hostname)
+			}
+		}
+		return &UnknownHostError{
+			Host:        hostname,
+			Fingerprint: ssh.FingerprintSHA256(key),
+			key:         key,
+		}
+	}, nil
+}
+
+// TrustHost 将主机公钥加入本应用的 known_hosts
+func TrustHost(err *UnknownHostError) error {
+	path := config.GetRemoteKnownHostsPath()
+	if mkErr := os.MkdirAll(filepath.Dir(path), 0755); mkErr != nil {
+		return i18n.Errorf("创建配置目录失败: %w", mkErr)
+	}
+
+	f, openErr := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if openErr != nil {
+		return i18n.Errorf("写入 known_hosts 失败: %w", openErr)
+	}
+	defer f.Close()
+
+	line := knownhosts.Line([]string{knownhosts.Normalize(err.Host)}, err.key)
+	if _, writeErr := f.WriteString(line + "\n"); writeErr != nil {
+		return i18n.Errorf("写入 known_hosts 失败: %w", writeErr)
+	}
+	return nil
+}
+
+// run 执行远程命令并返回合并后的输出
+func (c *Client) run(command string, stdin []byte) (string, error) {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return "", i18n.Errorf("创建 SSH 会话失败: %w", err)
+	}
+	defer session.Close()
+
+	var output bytes.Buffer
+	session.Stdout = &output
+	session.Stderr = &output
+	if stdin != nil {
+		session.Stdin = bytes.NewReader(stdin)
+	}
+
+	err = session.Run(command)
+	return strings.TrimSpace(output.String()), err
+}
+
+// privileged 按配置为命令加上 sudo，-n 避免等待密码输入
+func (c *Client) privileged(command string) string {
+	if c.profile.UseSudo {
+		return "sudo -n sh -c " + shellQuote(command)
+	}
+	return command
+}
+
+// UploadConfig 上传配置文件，先写入临时文件并备份旧配置再替换
+func (c *Client) UploadConfig(data []byte) error {
+	target := c.profile.RemoteConfigPath
+	tmp := target + ".uploading"
+	script := fmt.Sprintf("mkdir -p %s && cat > %s && { [ ! -f %s ] || cp -p %s %s; } && mv %s %s",
+		shellQuote(path.Dir(target)),
+		shellQuote(tmp),
+		shellQuote(target), shellQuote(target), shellQuote(target+".bak"),
+		shellQuote(tmp), shellQuote(target))
+
+	if output, err := c.run(c.privileged(script), data); err != nil {
+		return i18n.Errorf("上传配置到 %s 失败: %s", target, commandError(output, err))
+	}
+	return nil
+}
+
+// ReadConfig 读取远程配置文件，只取标准输出，避免 sudo 等命令的提示混入配置内容
+func (c *Client) ReadConfig() ([]byte, error) {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return nil, i18n.Errorf("创建 SSH 会话失败: %w", err)
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+
+	target := c.profile.RemoteConfigPath
+	if err := session.Run(c.privileged("cat " + shellQuote(target))); err != nil {
+		return nil, i18n.Errorf("读取 %s 失败: %s", target, commandError(strings.TrimSpace(stderr.String()), err))
+	}
+	return stdout.Bytes(), nil
+}
+
+// RestartService 重启远程 frps/frpc 服务并确认已运行
+func (c *Client) RestartService() (string, error) {
+	service := shellQuote(c.profile.ServiceName)
+	if output, err := c.run(c.privileged("systemctl restart "+service), nil); err != nil {
+		return "", i18n.Errorf("重启 %s 失败: %s", c.profile.ServiceName, commandError(output, err))
+	}
+
+	output, err := c.run("systemctl is-active "+service, nil)
+	if err != nil {
+		return output, i18n.Errorf("%s 重启后未处于运行状态: %s", c.profile.ServiceName, commandError(output, err))
+	}
+	return output, nil
+}
+
+// ServiceStatus 获取远程服务状态
+func (c *Client) ServiceStatus() (string, error) {
+	output, err := c.run("systemctl is-active "+shellQuote(c.profile.ServiceName), nil)
+	if output == "" && err != nil {
+		return "", i18n.Errorf("获取服务状态失败: %w", err)
+	}
+	return output, nil
+}
+
+// TailLogs 持续读取远程日志，每行发送到 lines，ctx 取消或连接断开时返回
+func (c *Client) TailLogs(ctx context.Context, lines chan<- string) error {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return i18n.Errorf("创建 SSH 会话失败: %w", err)
+	}
+	defer session.Close()
+
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return i18n.Errorf("读取远程输出失败: %w", err)
+	}
+	session.Stderr = session.Stdout
+
+	command := "journalctl -u " + shellQuote(c.profile.ServiceName) + " -n 100 -f --no-pager"
+	if c.profile.LogPath != "" {
+		command = "tail -n 100 -F " + shellQuote(c.profile.LogPath)
+	}
+	if c.profile.UseSudo {
+		command = c.privileged(command)
+	}
+
+	if err := session.Start(command); err != nil {
+		return i18n.Errorf("启动远程日志命令失败: %w", err)
+	}
+
+	// ctx 取消时关闭会话以结束远程命令
+	go func() {
+		<-ctx.Done()
+		session.Close()
+	}()
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		select {
+		case lines <- scanner.Text():
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err := session.Wait(); err != nil {
+		return i18n.Errorf("远程日志命令已退出: %w", err)
+	}
+	return nil
+}
+
+// shellQuote 为 sh 命令参数加单引号
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// commandError 优先使用命令输出作为错误说明
+func commandError(output string, err error) string {
+	if output != "" {
+		return output
+	}
+	return err.Error()
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// apiCacheTTL API 响应的缓存时间，仪表盘、健康监控和状态页在此时间内共用同一份响应，
+// 过期后仍使用 ETag/Last-Modified 条件请求
+const apiCacheTTL = time.Second
+
+// apiCacheEntry 缓存的 API 响应及其校验信息
+type apiCacheEntry struct {
+	body         []byte
+	etag         string
+	lastModified string
+	fetchedAt    time.Time
+}
+
+// InvalidateCache 清空缓存的 API 响应，关闭代理、断开客户端等操作后调用
+func (c *APIClient) InvalidateCache() {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	c.cache = make(map[string]*apiCacheEntry)
+}
+
+// cachedResponse 返回未过期的缓存响应
+func (c *APIClient) cachedResponse(key string) ([]byte, bool) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	entry := c.cache[key]
+	if entry == nil || time.Since(entry.fetchedAt) >= apiCacheTTL {
+		return nil, false
+	}
+	return entry.body, true
+}
+
+// fetchCached 发送 GET 请求，带上上次响应的 ETag/Last-Modified，frps 返回 304 时复用缓存内容
+func (c *APIClient) fetchCached(req *http.Request, httpClient *http.Client) ([]byte, error) {
+	key := req.URL.String()
+
+	c.cacheMu.Lock()
+	entry := c.cache[key]
+	c.cacheMu.Unlock()
+	if entry != nil {
+		if entry.etag != "" {
+			req.Header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, i18n.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		c.storeResponse(key, &apiCacheEntry{
+			body:         entry.body,
+			etag:         entry.etag,
+			lastModified: entry.lastModified,
+			fetchedAt:    time.Now(),
+		})
+		return entry.body, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIStatusError{StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, i18n.Errorf("读取响应失败: %w", err)
+	}
+	c.storeResponse(key, &apiCacheEntry{
+		body:         body,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		fetchedAt:    time.Now(),
+	})
+	return body, nil
+}
+
+// storeResponse 保存响应，切换目标后才返回的旧请求结果以旧地址为键，不会被新目标使用
+func (c *APIClient) storeResponse(key string, entry *apiCacheEntry) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	c.cache[key] = entry
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// ApplyProgressFunc 应用配置的进度回调
+type ApplyProgressFunc func(step string)
+
+// ApplyClientConfig 校验并写入客户端配置，然后让运行中的 frpc 生效：
+// 优先通过管理接口热重载，不可用时回退为重启客户端进程
+func (m *Manager) ApplyClientConfig(cfg *config.Config, configPath string, progress ApplyProgressFunc) (string, error) {
+	report := func(step string) {
+		if progress != nil {
+			progress(step)
+		}
+	}
+
+	report(i18n.T("正在校验配置..."))
+	if err := config.NewValidator().ValidateConfig(cfg); err != nil {
+		return "", i18n.Errorf("配置校验失败: %w", err)
+	}
+
+	report(i18n.T("正在写入配置..."))
+	if err := config.NewLoader(configPath).Save(cfg); err != nil {
+		return "", err
+	}
+	m.events.Publish(ConfigSavedEvent("client", configPath))
+
+	if !m.GetClientStatus().IsRunning {
+		return i18n.T("配置已保存（客户端未运行）"), nil
+	}
+
+	// 运行中的客户端使用其他配置文件时，重载不会加载刚写入的内容
+	if state := m.GetProcessState("client"); state != nil && !samePath(state.ConfigPath, configPath) {
+		return i18n.Sprintf("配置已保存，运行中的客户端使用 %s，未重载", state.ConfigPath), nil
+	}
+
+	report(i18n.T("正在热重载客户端..."))
+	client, err := NewClientAPIClientFromConfig(cfg)
+	if err == nil {
+		if err = client.Reload(); err == nil {
+			m.sendLog("INFO", i18n.T("客户端配置已通过管理接口热重载"), "client")
+			return i18n.T("配置已保存并热重载"), nil
+		}
+	}
+
+	m.sendLog("WARN", i18n.Sprintf("热重载不可用，改为重启客户端: %v", err), "client")
+	report(i18n.T("热重载不可用，正在重启客户端..."))
+	if err := m.Restart("client", configPath); err != nil {
+		return "", i18n.Errorf("重启客户端失败: %w", err)
+	}
+
+	return i18n.T("配置已保存，客户端已重启"), nil
+}
+
+// SetProxyDisabled 在客户端配置中停用或启用代理，然后让运行中的 frpc 生效。
+// name 可以是 frps 上带 "user." 前缀的代理名，代理已处于目标状态时返回空说明
+func (m *Manager) SetProxyDisabled(configPath, name string, disabled bool) (string, error) {
+	cfg, err := config.NewLoader(configPath).Load()
+	if err != nil {
+		return "", err
+	}
+
+	index := FindClientProxy(cfg, name)
+	if index < 0 {
+		return "", i18n.Errorf("代理 %s 不在本机客户端配置 %s 中", name, configPath)
+	}
+	if cfg.Proxies[index].Disabled == disabled {
+		return "", nil
+	}
+	cfg.Proxies[index].Disabled = disabled
+	return m.ApplyClientConfig(cfg, configPath, nil)
+}
+
+// FindClientProxy 按 frps 上的代理名查找客户端配置中的代理，未找到时返回 -1。
+// frpc 设置了 user 时，frps 上的代理名带有 "user." 前缀
+func FindClientProxy(cfg *config.Config, name string) int {
+	return slices.IndexFunc(cfg.Proxies, func(proxy config.ProxyConfig) bool {
+		return proxy.Name == name || strings.HasSuffix(name, "."+proxy.Name)
+	})
+}
+
+// samePath 判断两个路径是否指向同一文件
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return absA == absB
+}
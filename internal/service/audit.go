@@ -0,0 +1,187 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// AuditEntry 操作记录中的一条，按 JSON Lines 追加到审计文件
+type AuditEntry struct {
+	Time    time.Time `json:"time"`
+	Session string    `json:"session"` // 记录该操作的程序实例，同一次运行的操作属于同一会话
+	User    string    `json:"user"`
+	Host    string    `json:"host"`
+	Type    string    `json:"type"`
+	Action  string    `json:"action"`
+	Summary string    `json:"summary"`
+	Detail  string    `json:"detail,omitempty"`
+}
+
+// GetAuditLogPath 获取操作记录文件路径
+func GetAuditLogPath() string {
+	return filepath.Join(config.GetDefaultWorkDir(), "audit.log")
+}
+
+// AuditRecorder 订阅事件总线，开启后把用户操作和配置保存事件记录到审计文件，
+// 多人共用一台跳板机时可以查到谁在什么时候改了配置
+type AuditRecorder struct {
+	path    string
+	session string
+	user    string
+	errors  chan error
+
+	mu      sync.Mutex
+	enabled bool
+	cancel  func()
+}
+
+// NewAuditRecorder 创建操作记录器，默认关闭
+func NewAuditRecorder(path string) *AuditRecorder {
+	return &AuditRecorder{
+		path:    path,
+		session: time.Now().Format("20060102-150405") + "-" + strconv.Itoa(os.Getpid()),
+		user:    config.CurrentUser(),
+		errors:  make(chan error, 20),
+	}
+}
+
+// Path 审计文件路径
+func (r *AuditRecorder) Path() string {
+	return r.path
+}
+
+// Session 当前会话标识
+func (r *AuditRecorder) Session() string {
+	return r.session
+}
+
+// SetEnabled 开启或关闭记录，对之后的事件生效
+func (r *AuditRecorder) SetEnabled(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.enabled = enabled
+}
+
+// Errors 写入失败的错误通道，界面从中读取并提示
+func (r *AuditRecorder) Errors() <-chan error {
+	return r.errors
+}
+
+// Start 订阅事件总线并在后台记录，重复调用时忽略
+func (r *AuditRecorder) Start(bus *EventBus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return
+	}
+
+	events, cancel := bus.Subscribe(100)
+	r.cancel = cancel
+	go func() {
+		for event := range events {
+			if err := r.Record(event); err != nil {
+				select {
+				case r.errors <- err:
+				default:
+				}
+			}
+		}
+	}()
+}
+
+// Stop 取消订阅，停止记录
+func (r *AuditRecorder) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+	}
+}
+
+// Record 记录一个事件，只记录用户操作和配置保存，关闭时忽略
+func (r *AuditRecorder) Record(event Event) error {
+	if event.Type != EventUserAction && event.Type != EventConfigSaved {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.enabled {
+		return nil
+	}
+
+	entry := AuditEntry{
+		Time:    event.Time,
+		Session: r.session,
+		User:    r.user,
+		Host:    event.Host,
+		Type:    event.Type,
+		Action:  event.Source,
+		Summary: event.Title,
+		Detail:  event.Message,
+	}
+	if event.Type == EventConfigSaved {
+		entry.Action = "config.save"
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return i18n.Errorf("写入操作记录失败: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return i18n.Errorf("写入操作记录失败: %w", err)
+	}
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return i18n.Errorf("写入操作记录失败: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return i18n.Errorf("写入操作记录失败: %w", err)
+	}
+	return nil
+}
+
+// ReadAuditLog 读取审计文件，按记录顺序返回，跳过无法解析的行，文件不存在时返回空列表
+func ReadAuditLog(path string) ([]AuditEntry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取操作记录失败: %w", err)
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, i18n.Errorf("读取操作记录失败: %w", err)
+	}
+	return entries, nil
+}
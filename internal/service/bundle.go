@@ -0,0 +1,338 @@
+package service
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// bundleInstallDir Linux/macOS 部署包的安装目录
+const bundleInstallDir = "/opt/frp"
+
+// BundleTargets 支持导出部署包的目标系统
+var BundleTargets = []string{"linux", "darwin", "windows"}
+
+// BundleSpec 部署包导出参数
+type BundleSpec struct {
+	Name          string // "frps" 或 "frpc"
+	ConfigName    string // 部署包中的配置文件名，如 frpc.toml
+	ConfigData    []byte
+	TargetOS      string // linux / darwin / windows
+	IncludeBinary bool   // 是否打包本机的 frp 程序，仅目标系统与本机一致时可用
+	OutputPath    string // 为空时输出到当前目录
+}
+
+// BundleResult 部署包导出结果
+type BundleResult struct {
+	Path  string
+	Files []string
+}
+
+// bundleFile 部署包中的单个文件
+type bundleFile struct {
+	name string
+	data []byte
+	mode int64
+}
+
+// DefaultBundlePath 返回部署包的默认文件名，Windows 使用 zip，其余使用 tar.gz
+func DefaultBundlePath(dir, name, targetOS string) string {
+	ext := ".tar.gz"
+	if targetOS == "windows" {
+		ext = ".zip"
+	}
+	return filepath.Join(dir, name+"-"+targetOS+"-bundle"+ext)
+}
+
+// ExportBundle 将配置、启动脚本、服务定义和可选的 frp 程序打包成一个压缩包
+func ExportBundle(spec BundleSpec) (*BundleResult, error) {
+	if spec.Name != "frps" && spec.Name != "frpc" {
+		return nil, i18n.Errorf("不支持的服务: %s", spec.Name)
+	}
+	if len(spec.ConfigData) == 0 {
+		return nil, i18n.Errorf("配置内容为空")
+	}
+	if spec.ConfigName == "" {
+		spec.ConfigName = spec.Name + ".toml"
+	}
+	if spec.TargetOS == "" {
+		spec.TargetOS = runtime.GOOS
+	}
+	if spec.OutputPath == "" {
+		spec.OutputPath = DefaultBundlePath(".", spec.Name, spec.TargetOS)
+	}
+
+	files := []bundleFile{{name: spec.ConfigName, data: spec.ConfigData, mode: 0644}}
+
+	binaryName := spec.Name
+	if spec.TargetOS == "windows" {
+		binaryName += ".exe"
+	}
+	if spec.IncludeBinary {
+		if spec.TargetOS != runtime.GOOS {
+			return nil, i18n.Errorf("本机的 %s 只能运行在 %s 上，无法打包到 %s 部署包", spec.Name, runtime.GOOS, spec.TargetOS)
+		}
+		binaryPath, err := findFRPExecutable(spec.Name)
+		if err != nil {
+			return nil, i18n.Errorf("找不到 %s 可执行文件: %w", spec.Name, err)
+		}
+		data, err := os.ReadFile(binaryPath)
+		if err != nil {
+			return nil, i18n.Errorf("读取 %s 失败: %w", binaryPath, err)
+		}
+		files = append(files, bundleFile{name: binaryName, data: data, mode: 0755})
+	}
+
+	scripts, err := bundleScripts(spec, binaryName)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, scripts...)
+
+	if err := os.MkdirAll(filepath.Dir(spec.OutputPath), 0755); err != nil {
+		return nil, i18n.Errorf("创建输出目录失败: %w", err)
+	}
+	out, err := os.Create(spec.OutputPath)
+	if err != nil {
+		return nil, i18n.Errorf("创建部署包失败: %w", err)
+	}
+	defer out.Close()
+
+	root := spec.Name + "-bundle"
+	if spec.TargetOS == "windows" {
+		err = writeZipBundle(out, root, files)
+	} else {
+		err = writeTarGzBundle(out, root, files)
+	}
+	if err != nil {
+		os.Remove(spec.OutputPath)
+		return nil, i18n.Errorf("写入部署包失败: %w", err)
+	}
+
+	result := &BundleResult{Path: spec.OutputPath}
+	for _, file := range files {
+		result.Files = append(result.Files, file.name)
+	}
+	return result, nil
+}
+
+// bundleScripts 生成目标系统的启动脚本与服务定义
+func bundleScripts(spec BundleSpec, binaryName string) ([]bundleFile, error) {
+	serviceName := (&Daemonizer{goos: spec.TargetOS}).ServiceName(spec.Name)
+	data := map[string]string{
+		"Name":        spec.Name,
+		"Binary":      binaryName,
+		"Config":      spec.ConfigName,
+		"ServiceName": serviceName,
+		"InstallDir":  bundleInstallDir,
+		"Description": serviceDescription(spec.Name),
+	}
+
+	var files []bundleFile
+	render := func(name string, tmpl *template.Template, values map[string]string, mode int64) error {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, values); err != nil {
+			return i18n.Errorf("生成 %s 失败: %w", name, err)
+		}
+		content := buf.Bytes()
+		if spec.TargetOS == "windows" {
+			content = []byte(strings.ReplaceAll(buf.String(), "\n", "\r\n"))
+		}
+		files = append(files, bundleFile{name: name, data: content, mode: mode})
+		return nil
+	}
+
+	switch spec.TargetOS {
+	case "linux":
+		if err := render("start.sh", bundleStartShTemplate, data, 0755); err != nil {
+			return nil, err
+		}
+		if err := render(serviceName+".service", systemdUnitTemplate, map[string]string{
+			"Description": data["Description"],
+			"BinaryPath":  bundleInstallDir + "/" + binaryName,
+			"ConfigPath":  bundleInstallDir + "/" + spec.ConfigName,
+			"WantedBy":    "multi-user.target",
+		}, 0644); err != nil {
+			return nil, err
+		}
+		data["UnitFile"] = serviceName + ".service"
+		if err := render("install.sh", bundleInstallSystemdTemplate, data, 0755); err != nil {
+			return nil, err
+		}
+	case "darwin":
+		if err := render("start.sh", bundleStartShTemplate, data, 0755); err != nil {
+			return nil, err
+		}
+		if err := render(serviceName+".plist", launchdPlistTemplate, map[string]string{
+			"Label":      serviceName,
+			"BinaryPath": bundleInstallDir + "/" + binaryName,
+			"ConfigPath": bundleInstallDir + "/" + spec.ConfigName,
+			"RunAtLoad":  "true",
+			"LogPath":    bundleInstallDir + "/" + spec.Name + ".service.log",
+		}, 0644); err != nil {
+			return nil, err
+		}
+		data["UnitFile"] = serviceName + ".plist"
+		if err := render("install.sh", bundleInstallLaunchdTemplate, data, 0755); err != nil {
+			return nil, err
+		}
+	case "windows":
+		if err := render("start.bat", bundleStartBatTemplate, data, 0644); err != nil {
+			return nil, err
+		}
+		if err := render("install-service.bat", bundleInstallWindowsTemplate, data, 0644); err != nil {
+			return nil, err
+		}
+		if err := render("uninstall-service.bat", bundleUninstallWindowsTemplate, data, 0644); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, i18n.Errorf("不支持的操作系统: %s", spec.TargetOS)
+	}
+
+	return files, nil
+}
+
+// writeTarGzBundle 写入 tar.gz 部署包，保留脚本和程序的可执行权限
+func writeTarGzBundle(w io.Writer, root string, files []bundleFile) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	for _, file := range files {
+		header := &tar.Header{
+			Name:    root + "/" + file.name,
+			Mode:    file.mode,
+			Size:    int64(len(file.data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// writeZipBundle 写入 zip 部署包
+func writeZipBundle(w io.Writer, root string, files []bundleFile) error {
+	zw := zip.NewWriter(w)
+	now := time.Now()
+
+	for _, file := range files {
+		header := &zip.FileHeader{
+			Name:     root + "/" + file.name,
+			Method:   zip.Deflate,
+			Modified: now,
+		}
+		header.SetMode(os.FileMode(file.mode))
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(file.data); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// bundleStartShTemplate Linux/macOS 前台启动脚本，部署包中没有程序时使用 PATH 中的版本
+var bundleStartShTemplate = template.Must(template.New("start.sh").Parse(`#!/bin/sh
+# 在部署包目录中前台启动 {{.Name}}
+cd "$(dirname "$0")" || exit 1
+BIN=./{{.Binary}}
+[ -x "$BIN" ] || BIN={{.Binary}}
+exec "$BIN" -c ./{{.Config}}
+`))
+
+// bundleInstallSystemdTemplate 安装为 systemd 服务的脚本
+var bundleInstallSystemdTemplate = template.Must(template.New("install.sh").Parse(`#!/bin/sh
+# 将 {{.Name}} 安装到 {{.InstallDir}} 并注册为 systemd 服务，需要 root 权限
+set -e
+cd "$(dirname "$0")"
+mkdir -p {{.InstallDir}}
+cp ./{{.Config}} {{.InstallDir}}/{{.Config}}
+if [ -f ./{{.Binary}} ]; then
+	install -m 0755 ./{{.Binary}} {{.InstallDir}}/{{.Binary}}
+elif command -v {{.Binary}} >/dev/null 2>&1; then
+	ln -sf "$(command -v {{.Binary}})" {{.InstallDir}}/{{.Binary}}
+else
+	echo "{{.Binary}} not found, copy it to {{.InstallDir}}/{{.Binary}} first" >&2
+	exit 1
+fi
+cp ./{{.UnitFile}} /etc/systemd/system/{{.UnitFile}}
+systemctl daemon-reload
+systemctl enable --now {{.UnitFile}}
+systemctl --no-pager status {{.UnitFile}}
+`))
+
+// bundleInstallLaunchdTemplate 安装为 launchd 服务的脚本
+var bundleInstallLaunchdTemplate = template.Must(template.New("install.sh").Parse(`#!/bin/sh
+# 将 {{.Name}} 安装到 {{.InstallDir}} 并注册为 launchd 服务，需要 root 权限
+set -e
+cd "$(dirname "$0")"
+mkdir -p {{.InstallDir}}
+cp ./{{.Config}} {{.InstallDir}}/{{.Config}}
+if [ -f ./{{.Binary}} ]; then
+	install -m 0755 ./{{.Binary}} {{.InstallDir}}/{{.Binary}}
+elif command -v {{.Binary}} >/dev/null 2>&1; then
+	ln -sf "$(command -v {{.Binary}})" {{.InstallDir}}/{{.Binary}}
+else
+	echo "{{.Binary}} not found, copy it to {{.InstallDir}}/{{.Binary}} first" >&2
+	exit 1
+fi
+cp ./{{.UnitFile}} /Library/LaunchDaemons/{{.UnitFile}}
+launchctl unload /Library/LaunchDaemons/{{.UnitFile}} 2>/dev/null || true
+launchctl load -w /Library/LaunchDaemons/{{.UnitFile}}
+`))
+
+// bundleStartBatTemplate Windows 前台启动脚本
+var bundleStartBatTemplate = template.Must(template.New("start.bat").Parse(`@echo off
+rem 在部署包目录中前台启动 {{.Name}}
+cd /d "%~dp0"
+if exist "{{.Binary}}" (
+	"{{.Binary}}" -c "{{.Config}}"
+) else (
+	{{.Binary}} -c "{{.Config}}"
+)
+`))
+
+// bundleInstallWindowsTemplate 注册为 Windows 服务的脚本，存在 nssm 时优先用它包装
+var bundleInstallWindowsTemplate = template.Must(template.New("install-service.bat").Parse(`@echo off
+rem 以部署包所在目录注册 {{.ServiceName}} 服务，需以管理员身份运行
+cd /d "%~dp0"
+where nssm >nul 2>nul
+if %errorlevel% == 0 (
+	nssm install {{.ServiceName}} "%~dp0{{.Binary}}" -c "%~dp0{{.Config}}"
+	nssm set {{.ServiceName}} DisplayName "FRP {{.Description}}"
+) else (
+	sc create {{.ServiceName}} binPath= "\"%~dp0{{.Binary}}\" -c \"%~dp0{{.Config}}\"" DisplayName= "FRP {{.Description}}"
+)
+sc config {{.ServiceName}} start= auto
+sc start {{.ServiceName}}
+`))
+
+// bundleUninstallWindowsTemplate 移除 Windows 服务的脚本
+var bundleUninstallWindowsTemplate = template.Must(template.New("uninstall-service.bat").Parse(`@echo off
+rem 停止并删除 {{.ServiceName}} 服务，需以管理员身份运行
+sc stop {{.ServiceName}}
+sc delete {{.ServiceName}}
+`))
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"crypto/md5"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/yamux"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// frp 控制消息类型，与 frp 的 msg 包保持一致
+const (
+	frpMsgTypeLogin     byte = 'o'
+	frpMsgTypeLoginResp byte = '1'
+
+	// frpMaxMsgLength 单条控制消息的最大长度
+	frpMaxMsgLength = 10240
+	// frpTestClientVersion 测试登录时上报的客户端版本
+	frpTestClientVersion = "0.52.3"
+)
+
+// ConnectionTestStage 连接测试失败所处的阶段
+type ConnectionTestStage string
+
+const (
+	StageDial  ConnectionTestStage = "dial"
+	StageTLS   ConnectionTestStage = "tls"
+	StageMux   ConnectionTestStage = "mux"
+	StageLogin ConnectionTestStage = "login"
+	StageOK    ConnectionTestStage = "ok"
+)
+
+// ConnectionTestOptions 连接测试参数
+type ConnectionTestOptions struct {
+	Timeout    time.Duration
+	TLSEnable  bool
+	TLSConfig  *tls.Config // 为空时不校验服务端证书，与 frpc 默认行为一致
+	TCPMux     bool
+	ClientUser string
+}
+
+// DefaultConnectionTestOptions 返回与 frpc 默认配置一致的测试参数
+func DefaultConnectionTestOptions() ConnectionTestOptions {
+	return ConnectionTestOptions{
+		Timeout:   5 * time.Second,
+		TLSEnable: true,
+		TCPMux:    true,
+	}
+}
+
+// ConnectionTestOptionsFor 按客户端配置的传输设置生成测试参数
+func ConnectionTestOptionsFor(cfg *config.Config) ConnectionTestOptions {
+	opts := DefaultConnectionTestOptions()
+	if cfg != nil {
+		opts.TLSEnable = cfg.Transport.TLS.Enabled()
+		if cfg.Transport.DialServerTimeout > 0 {
+			opts.Timeout = time.Duration(cfg.Transport.DialServerTimeout) * time.Second
+		}
+	}
+	return opts
+}
+
+// ConnectionTestResult 连接测试结果
+type ConnectionTestResult struct {
+	Address       string
+	Stage         ConnectionTestStage
+	Latency       time.Duration
+	ServerVersion string
+	Err           error
+}
+
+// Success 测试是否成功
+func (r *ConnectionTestResult) Success() bool {
+	return r.Stage == StageOK
+}
+
+// Summary 返回适合展示的一句话结果
+func (r *ConnectionTestResult) Summary() string {
+	switch r.Stage {
+	case StageOK:
+		return i18n.Sprintf("连接 %s 成功，认证通过 (frps %s, 耗时 %dms)", r.Address, r.ServerVersion, r.Latency.Milliseconds())
+	case StageDial:
+		return i18n.Sprintf("无法连接 %s: %v", r.Address, r.Err)
+	case StageTLS:
+		return i18n.Sprintf("TLS 握手失败: %v", r.Err)
+	case StageMux:
+		return i18n.Sprintf("建立多路复用失败: %v", r.Err)
+	default:
+		return i18n.Sprintf("登录失败: %v", r.Err)
+	}
+}
+
+// frpLogin frp 登录消息
+type frpLogin struct {
+	Version      string `json:"version,omitempty"`
+	Hostname     string `json:"hostname,omitempty"`
+	Os           string `json:"os,omitempty"`
+	Arch         string `json:"arch,omitempty"`
+	User         string `json:"user,omitempty"`
+	PrivilegeKey string `json:"privilege_key,omitempty"`
+	Timestamp    int64  `json:"timestamp,omitempty"`
+	PoolCount    int    `json:"pool_count,omitempty"`
+}
+
+// frpLoginResp frp 登录响应
+type frpLoginResp struct {
+	Version string `json:"version,omitempty"`
+	RunID   string `json:"run_id,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// TestConnection 按客户端配置连接 frps 并完成一次登录握手，用于检查网络、TLS 与 token
+func (m *Manager) TestConnection(cfg *config.Config, opts ConnectionTestOptions) *ConnectionTestResult {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	serverAddr := "127.0.0.1"
+	serverPort := 7000
+	if cfg != nil && cfg.ServerAddr != "" {
+		serverAddr = cfg.ServerAddr
+	}
+	if cfg != nil && cfg.ServerPort > 0 {
+		serverPort = cfg.ServerPort
+	}
+
+	result := &ConnectionTestResult{
+		Address: net.JoinHostPort(serverAddr, strconv.Itoa(serverPort)),
+		Stage:   StageDial,
+	}
+	start := time.Now()
+
+	if cfg != nil && cfg.Transport.Protocol != "" && cfg.Transport.Protocol != "tcp" {
+		result.Err = i18n.Errorf("连接测试暂不支持 %s 协议", cfg.Transport.Protocol)
+		return result
+	}
+
+	conn, err := net.DialTimeout("tcp", result.Address, opts.Timeout)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer conn.Close()
+	conn.SetDeadline(start.Add(opts.Timeout))
+
+	var transport net.Conn = conn
+	if opts.TLSEnable {
+		result.Stage = StageTLS
+		tlsConfig := opts.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig, err = clientTLSConfig(cfg, serverAddr)
+			if err != nil {
+				result.Err = err
+				return result
+			}
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			result.Err = err
+			return result
+		}
+		transport = tlsConn
+	}
+
+	stream := transport
+	if opts.TCPMux {
+		result.Stage = StageMux
+		muxConfig := yamux.DefaultConfig()
+		muxConfig.LogOutput = io.Discard
+		muxConfig.EnableKeepAlive = false
+
+		session, err := yamux.Client(transport, muxConfig)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		defer session.Close()
+
+		muxStream, err := session.OpenStream()
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		muxStream.SetDeadline(start.Add(opts.Timeout))
+		stream = muxStream
+	}
+
+	result.Stage = StageLogin
+	token := ""
+	if cfg != nil {
+		token = cfg.Auth.Token
+	}
+	hostname, _ := os.Hostname()
+	timestamp := time.Now().Unix()
+	login := frpLogin{
+		Version:      frpTestClientVersion,
+		Hostname:     hostname,
+		Os:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		User:         opts.ClientUser,
+		PrivilegeKey: frpAuthKey(token, timestamp),
+		Timestamp:    timestamp,
+		PoolCount:    1,
+	}
+
+	if err := writeFRPMsg(stream, frpMsgTypeLogin, login); err != nil {
+		result.Err = i18n.Errorf("发送登录消息失败: %w", err)
+		return result
+	}
+
+	var resp frpLoginResp
+	if err := readFRPMsg(stream, frpMsgTypeLoginResp, &resp); err != nil {
+		result.Err = i18n.Errorf("读取登录响应失败: %w", err)
+		return result
+	}
+
+	if resp.Error != "" {
+		result.Err = fmt.Errorf("%s", resp.Error)
+		return result
+	}
+
+	result.Stage = StageOK
+	result.ServerVersion = resp.Version
+	result.Latency = time.Since(start)
+	return result
+}
+
+// clientTLSConfig 按客户端 TLS 配置加载证书；未配置 CA 时不校验服务端证书，与 frpc 默认行为一致
+func clientTLSConfig(cfg *config.Config, serverAddr string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+	if cfg == nil {
+		return tlsConfig, nil
+	}
+
+	settings := cfg.Transport.TLS
+	if settings.CertFile != "" && settings.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(settings.CertFile, settings.KeyFile)
+		if err != nil {
+			return nil, i18n.Errorf("加载客户端证书失败: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if settings.TrustedCaFile != "" {
+		caPEM, err := os.ReadFile(settings.TrustedCaFile)
+		if err != nil {
+			return nil, i18n.Errorf("读取 CA 文件失败: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, i18n.Errorf("CA 文件中没有有效的证书: %s", settings.TrustedCaFile)
+		}
+		tlsConfig.InsecureSkipVerify = false
+		tlsConfig.RootCAs = pool
+		tlsConfig.ServerName = serverAddr
+		if settings.ServerName != "" {
+			tlsConfig.ServerName = settings.ServerName
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// frpAuthKey 计算 token 认证的 privilege_key
+func frpAuthKey(token string, timestamp int64) string {
+	sum := md5.Sum([]byte(token + strconv.FormatInt(timestamp, 10)))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeFRPMsg 按 frp 控制消息格式写入：类型(1字节) + 长度(int64 大端) + JSON
+func writeFRPMsg(w io.Writer, msgType byte, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 0, 9+len(body))
+	buf = append(buf, msgType)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(len(body)))
+	buf = append(buf, body...)
+
+	_, err = w.Write(buf)
+	return err
+}
+
+// readFRPMsg 读取一条 frp 控制消息并解析到 v
+func readFRPMsg(r io.Reader, expectType byte, v interface{}) error {
+	header := make([]byte, 9)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return err
+	}
+
+	if header[0] != expectType {
+		return i18n.Errorf("意外的消息类型: %q", header[0])
+	}
+
+	length := int64(binary.BigEndian.Uint64(header[1:]))
+	if length < 0 || length > frpMaxMsgLength {
+		return i18n.Errorf("消息长度异常: %d", length)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,424 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// SystemServiceSpec 系统服务安装参数
+type SystemServiceSpec struct {
+	Name         string // "frps" 或 "frpc"
+	BinaryPath   string // 为空时自动查找
+	ConfigPath   string
+	EnableOnBoot bool
+}
+
+// SystemServiceStatus 系统服务状态
+type SystemServiceStatus struct {
+	Installed bool
+	Enabled   bool
+	Active    bool
+	UnitPath  string // systemd unit / launchd plist 路径，Windows 下为服务名
+	Backend   string // systemd / launchd / windows
+}
+
+// Daemonizer 将 frps/frpc 安装为操作系统服务，脱离 TUI 独立运行
+type Daemonizer struct {
+	goos     string
+	userMode bool // 非 root 用户安装为用户级服务
+	runner   func(name string, args ...string) (string, error)
+}
+
+// NewDaemonizer 创建系统服务管理器
+func NewDaemonizer() *Daemonizer {
+	return &Daemonizer{
+		goos:     runtime.GOOS,
+		userMode: runtime.GOOS != "windows" && os.Geteuid() != 0,
+		runner:   runCommand,
+	}
+}
+
+// runCommand 执行命令并返回合并后的输出
+func runCommand(name string, args ...string) (string, error) {
+	output, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
+
+// Backend 返回当前平台使用的服务管理后端
+func (d *Daemonizer) Backend() string {
+	switch d.goos {
+	case "linux":
+		return "systemd"
+	case "darwin":
+		return "launchd"
+	case "windows":
+		return "windows"
+	default:
+		return ""
+	}
+}
+
+// ServiceName 返回 FRP 程序对应的系统服务名
+func (d *Daemonizer) ServiceName(name string) string {
+	if d.goos == "darwin" {
+		return "com.frp-manager." + name
+	}
+	return "frp-manager-" + name
+}
+
+// unitPath 返回服务定义文件路径
+func (d *Daemonizer) unitPath(name string) string {
+	serviceName := d.ServiceName(name)
+
+	switch d.goos {
+	case "linux":
+		if d.userMode {
+			homeDir, _ := os.UserHomeDir()
+			return filepath.Join(homeDir, ".config", "systemd", "user", serviceName+".service")
+		}
+		return filepath.Join("/etc/systemd/system", serviceName+".service")
+	case "darwin":
+		if d.userMode {
+			homeDir, _ := os.UserHomeDir()
+			return filepath.Join(homeDir, "Library", "LaunchAgents", serviceName+".plist")
+		}
+		return filepath.Join("/Library/LaunchDaemons", serviceName+".plist")
+	default:
+		return serviceName
+	}
+}
+
+// Install 安装系统服务并立即启动
+func (d *Daemonizer) Install(spec SystemServiceSpec) error {
+	if spec.Name != "frps" && spec.Name != "frpc" {
+		return i18n.Errorf("不支持的服务: %s", spec.Name)
+	}
+
+	if spec.BinaryPath == "" {
+		binaryPath, err := findFRPExecutable(spec.Name)
+		if err != nil {
+			return err
+		}
+		spec.BinaryPath = binaryPath
+	}
+
+	// 服务运行时的工作目录不确定，必须使用绝对路径
+	var err error
+	if spec.BinaryPath, err = filepath.Abs(spec.BinaryPath); err != nil {
+		return i18n.Errorf("解析程序路径失败: %w", err)
+	}
+	if spec.ConfigPath, err = filepath.Abs(spec.ConfigPath); err != nil {
+		return i18n.Errorf("解析配置路径失败: %w", err)
+	}
+	if _, err := os.Stat(spec.ConfigPath); err != nil {
+		return i18n.Errorf("配置文件不存在: %w", err)
+	}
+
+	switch d.goos {
+	case "linux":
+		return d.installSystemd(spec)
+	case "darwin":
+		return d.installLaunchd(spec)
+	case "windows":
+		return d.installWindows(spec)
+	default:
+		return i18n.Errorf("不支持的操作系统: %s", d.goos)
+	}
+}
+
+// Uninstall 停止并移除系统服务
+func (d *Daemonizer) Uninstall(name string) error {
+	serviceName := d.ServiceName(name)
+	path := d.unitPath(name)
+
+	switch d.goos {
+	case "linux":
+		// 服务可能已经停止，忽略停止失败
+		d.systemctl("disable", "--now", serviceName+".service")
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return i18n.Errorf("删除服务文件失败: %w", err)
+		}
+		_, err := d.systemctl("daemon-reload")
+		return err
+	case "darwin":
+		d.runner("launchctl", "unload", "-w", path)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return i18n.Errorf("删除服务文件失败: %w", err)
+		}
+		return nil
+	case "windows":
+		d.runner("sc", "stop", serviceName)
+		if _, err := d.runner("sc", "delete", serviceName); err != nil {
+			return i18n.Errorf("删除 Windows 服务失败: %w", err)
+		}
+		return nil
+	default:
+		return i18n.Errorf("不支持的操作系统: %s", d.goos)
+	}
+}
+
+// SetEnabled 设置服务是否开机自启
+func (d *Daemonizer) SetEnabled(name string, enabled bool) error {
+	serviceName := d.ServiceName(name)
+
+	switch d.goos {
+	case "linux":
+		action := "disable"
+		if enabled {
+			action = "enable"
+		}
+		_, err := d.systemctl(action, serviceName+".service")
+		return err
+	case "darwin":
+		// launchd 的开机自启由 plist 的 RunAtLoad 决定，重新写入后重新加载
+		path := d.unitPath(name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return i18n.Errorf("读取服务文件失败: %w", err)
+		}
+		from, to := "<key>RunAtLoad</key>\n\t<true/>", "<key>RunAtLoad</key>\n\t<false/>"
+		if enabled {
+			from, to = to, from
+		}
+		if err := os.WriteFile(path, []byte(strings.Replace(string(data), from, to, 1)), 0644); err != nil {
+			return i18n.Errorf("写入服务文件失败: %w", err)
+		}
+		d.runner("launchctl", "unload", path)
+		_, err = d.runner("launchctl", "load", "-w", path)
+		return err
+	case "windows":
+		startType := "demand"
+		if enabled {
+			startType = "auto"
+		}
+		_, err := d.runner("sc", "config", serviceName, "start=", startType)
+		return err
+	default:
+		return i18n.Errorf("不支持的操作系统: %s", d.goos)
+	}
+}
+
+// Status 查询系统服务状态
+func (d *Daemonizer) Status(name string) (*SystemServiceStatus, error) {
+	serviceName := d.ServiceName(name)
+	status := &SystemServiceStatus{
+		UnitPath: d.unitPath(name),
+		Backend:  d.Backend(),
+	}
+
+	switch d.goos {
+	case "linux":
+		if _, err := os.Stat(status.UnitPath); err != nil {
+			return status, nil
+		}
+		status.Installed = true
+		output, _ := d.systemctl("is-enabled", serviceName+".service")
+		status.Enabled = strings.TrimSpace(output) == "enabled"
+		output, _ = d.systemctl("is-active", serviceName+".service")
+		status.Active = strings.TrimSpace(output) == "active"
+	case "darwin":
+		data, err := os.ReadFile(status.UnitPath)
+		if err != nil {
+			return status, nil
+		}
+		status.Installed = true
+		status.Enabled = strings.Contains(string(data), "<key>RunAtLoad</key>\n\t<true/>")
+		output, err := d.runner("launchctl", "list", serviceName)
+		status.Active = err == nil && strings.Contains(output, "\"PID\"")
+	case "windows":
+		output, err := d.runner("sc", "qc", serviceName)
+		if err != nil {
+			return status, nil
+		}
+		status.Installed = true
+		status.Enabled = strings.Contains(output, "AUTO_START")
+		output, _ = d.runner("sc", "query", serviceName)
+		status.Active = strings.Contains(output, "RUNNING")
+	default:
+		return nil, i18n.Errorf("不支持的操作系统: %s", d.goos)
+	}
+
+	return status, nil
+}
+
+// systemctl 执行 systemctl，用户级服务自动追加 --user
+func (d *Daemonizer) systemctl(args ...string) (string, error) {
+	if d.userMode {
+		args = append([]string{"--user"}, args...)
+	}
+	return d.runner("systemctl", args...)
+}
+
+// systemdUnitTemplate systemd unit 模板
+var systemdUnitTemplate = template.Must(template.New("systemd").Parse(`[Unit]
+Description=FRP {{.Description}} (managed by frp-cli-ui)
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+Type=simple
+ExecStart="{{.BinaryPath}}" -c "{{.ConfigPath}}"
+Restart=on-failure
+RestartSec=5s
+LimitNOFILE=1048576
+
+[Install]
+WantedBy={{.WantedBy}}
+`))
+
+// installSystemd 安装 systemd 服务
+func (d *Daemonizer) installSystemd(spec SystemServiceSpec) error {
+	wantedBy := "multi-user.target"
+	if d.userMode {
+		// 用户级服务只在用户登录后启动，如需开机即启动需执行 loginctl enable-linger
+		wantedBy = "default.target"
+	}
+
+	var buf bytes.Buffer
+	if err := systemdUnitTemplate.Execute(&buf, map[string]string{
+		"Description": serviceDescription(spec.Name),
+		"BinaryPath":  spec.BinaryPath,
+		"ConfigPath":  spec.ConfigPath,
+		"WantedBy":    wantedBy,
+	}); err != nil {
+		return i18n.Errorf("生成 systemd 服务文件失败: %w", err)
+	}
+
+	path := d.unitPath(spec.Name)
+	if err := writeServiceFile(path, buf.Bytes()); err != nil {
+		return err
+	}
+
+	if _, err := d.systemctl("daemon-reload"); err != nil {
+		return err
+	}
+
+	unit := d.ServiceName(spec.Name) + ".service"
+	if spec.EnableOnBoot {
+		_, err := d.systemctl("enable", "--now", unit)
+		return err
+	}
+	_, err := d.systemctl("start", unit)
+	return err
+}
+
+// launchdPlistTemplate launchd plist 模板
+var launchdPlistTemplate = template.Must(template.New("launchd").Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>{{.Label}}</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>{{.BinaryPath}}</string>
+		<string>-c</string>
+		<string>{{.ConfigPath}}</string>
+	</array>
+	<key>RunAtLoad</key>
+	<{{.RunAtLoad}}/>
+	<key>KeepAlive</key>
+	<dict>
+		<key>SuccessfulExit</key>
+		<false/>
+	</dict>
+	<key>StandardOutPath</key>
+	<string>{{.LogPath}}</string>
+	<key>StandardErrorPath</key>
+	<string>{{.LogPath}}</string>
+</dict>
+</plist>
+`))
+
+// installLaunchd 安装 launchd 服务
+func (d *Daemonizer) installLaunchd(spec SystemServiceSpec) error {
+	runAtLoad := "false"
+	if spec.EnableOnBoot {
+		runAtLoad = "true"
+	}
+
+	var buf bytes.Buffer
+	if err := launchdPlistTemplate.Execute(&buf, map[string]string{
+		"Label":      d.ServiceName(spec.Name),
+		"BinaryPath": spec.BinaryPath,
+		"ConfigPath": spec.ConfigPath,
+		"RunAtLoad":  runAtLoad,
+		"LogPath":    filepath.Join(filepath.Dir(spec.ConfigPath), spec.Name+".service.log"),
+	}); err != nil {
+		return i18n.Errorf("生成 launchd 服务文件失败: %w", err)
+	}
+
+	path := d.unitPath(spec.Name)
+	if err := writeServiceFile(path, buf.Bytes()); err != nil {
+		return err
+	}
+
+	// 先卸载可能存在的旧定义，再加载并启动
+	d.runner("launchctl", "unload", path)
+	if _, err := d.runner("launchctl", "load", "-w", path); err != nil {
+		return err
+	}
+	_, err := d.runner("launchctl", "start", d.ServiceName(spec.Name))
+	return err
+}
+
+// installWindows 安装 Windows 服务
+// frp 本身不实现 Windows 服务控制协议，存在 nssm 时优先用它包装，否则直接使用 sc 注册
+func (d *Daemonizer) installWindows(spec SystemServiceSpec) error {
+	serviceName := d.ServiceName(spec.Name)
+	startType := "demand"
+	if spec.EnableOnBoot {
+		startType = "auto"
+	}
+
+	if nssm, err := exec.LookPath("nssm"); err == nil {
+		if _, err := d.runner(nssm, "install", serviceName, spec.BinaryPath, "-c", spec.ConfigPath); err != nil {
+			return i18n.Errorf("注册 Windows 服务失败: %w", err)
+		}
+		d.runner(nssm, "set", serviceName, "DisplayName", "FRP "+serviceDescription(spec.Name))
+	} else {
+		binPath := fmt.Sprintf("\"%s\" -c \"%s\"", spec.BinaryPath, spec.ConfigPath)
+		if _, err := d.runner("sc", "create", serviceName,
+			"binPath=", binPath,
+			"DisplayName=", "FRP "+serviceDescription(spec.Name),
+		); err != nil {
+			return i18n.Errorf("注册 Windows 服务失败: %w", err)
+		}
+	}
+
+	if _, err := d.runner("sc", "config", serviceName, "start=", startType); err != nil {
+		return err
+	}
+	_, err := d.runner("sc", "start", serviceName)
+	return err
+}
+
+// writeServiceFile 写入服务定义文件
+func writeServiceFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return i18n.Errorf("创建服务目录失败: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return i18n.Errorf("写入服务文件失败: %w", err)
+	}
+	return nil
+}
+
+// serviceDescription 返回服务描述
+func serviceDescription(name string) string {
+	if name == "frps" {
+		return "Server"
+	}
+	return "Client"
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 扫描参数
+const (
+	discoverDialTimeout   = 300 * time.Millisecond // 单个端口的连接超时
+	discoverBannerTimeout = 500 * time.Millisecond // 等待服务主动发送欢迎信息的时间
+	discoverConcurrency   = 128                    // 同时进行的连接数，避免超出 macOS 默认的文件描述符上限
+	discoverMaxLANHosts   = 1024                   // 局域网扫描的主机数上限，每个网段最多扫描本机所在的 /24
+	discoverBannerMax     = 60                     // 保留的欢迎信息长度
+)
+
+// knownPort 常见服务的默认端口
+type knownPort struct {
+	name string
+	http bool // 是否为 HTTP 服务，建议的代理名以 web 开头
+}
+
+// knownPorts 按端口识别常见服务，局域网和 Windows 上只扫描这些端口
+var knownPorts = map[int]knownPort{
+	21: {name: "ftp"}, 22: {name: "ssh"}, 23: {name: "telnet"}, 25: {name: "smtp"},
+	80: {name: "http", http: true}, 139: {name: "netbios"}, 443: {name: "https"}, 445: {name: "smb"},
+	548: {name: "afp"}, 631: {name: "ipp", http: true}, 873: {name: "rsync"}, 1433: {name: "mssql"},
+	1883: {name: "mqtt"}, 2049: {name: "nfs"}, 2375: {name: "docker"}, 3000: {name: "web", http: true},
+	3306: {name: "mysql"}, 3389: {name: "rdp"}, 5000: {name: "web", http: true}, 5001: {name: "web-tls"},
+	5173: {name: "vite", http: true}, 5432: {name: "postgres"}, 5900: {name: "vnc"}, 6379: {name: "redis"},
+	7000: {name: "frps"}, 7400: {name: "frpc-admin", http: true}, 7500: {name: "frps-dashboard", http: true},
+	8000: {name: "web", http: true}, 8080: {name: "web", http: true}, 8096: {name: "jellyfin", http: true},
+	8123: {name: "homeassistant", http: true}, 8443: {name: "web-tls"}, 8888: {name: "web", http: true},
+	9000: {name: "web", http: true}, 9090: {name: "prometheus", http: true}, 9443: {name: "web-tls"},
+	11434: {name: "ollama", http: true}, 25565: {name: "minecraft"}, 27017: {name: "mongodb"},
+	32400: {name: "plex", http: true},
+}
+
+// DiscoverOptions 本机服务发现的参数
+type DiscoverOptions struct {
+	LAN bool // 同时扫描本机所在局域网网段的常见端口
+}
+
+// DiscoveredService 发现的监听端口
+type DiscoveredService struct {
+	Host    string // 127.0.0.1 或局域网地址
+	Port    int
+	Service string // 识别出的服务，如 ssh、http、mysql，无法识别时为空
+	Banner  string // 服务的欢迎信息或 HTTP Server 头
+}
+
+// Local 是否为本机服务
+func (s DiscoveredService) Local() bool {
+	return s.Host == "127.0.0.1"
+}
+
+// SuggestedName 建议的代理名，如 ssh、ssh-2222、web-8080，局域网服务加上主机地址的最后一段
+func (s DiscoveredService) SuggestedName() string {
+	name := s.Service
+	switch name {
+	case "":
+		name = "tcp-" + strconv.Itoa(s.Port)
+	case "http", "https", "tls", "web", "web-tls":
+		name = "web-" + strconv.Itoa(s.Port)
+	default:
+		// 不在默认端口上的服务加上端口，如 ssh-2222
+		if knownPorts[s.Port].name != name {
+			name += "-" + strconv.Itoa(s.Port)
+		}
+	}
+	if !s.Local() {
+		if ip := net.ParseIP(s.Host).To4(); ip != nil {
+			name += "-" + strconv.Itoa(int(ip[3]))
+		}
+	}
+	return name
+}
+
+// DiscoverServices 扫描本机（Unix 上为全部 TCP 端口，Windows 上连接被拒绝很慢，只扫描常见端口）
+// 和可选的局域网常见端口，返回正在监听的端口并尽量识别服务。ctx 取消时返回已发现的部分和 ctx 的错误
+func DiscoverServices(ctx context.Context, opts DiscoverOptions) ([]DiscoveredService, error) {
+	common := make([]int, 0, len(knownPorts))
+	for port := range knownPorts {
+		common = append(common, port)
+	}
+	slices.Sort(common)
+
+	localPorts := common
+	if runtime.GOOS != "windows" {
+		localPorts = make([]int, 65535)
+		for i := range localPorts {
+			localPorts[i] = i + 1
+		}
+	}
+
+	type target struct {
+		host string
+		port int
+	}
+	targets := make(chan target)
+	go func() {
+		defer close(targets)
+		send := func(host string, ports []int) bool {
+			for _, port := range ports {
+				select {
+				case targets <- target{host, port}:
+				case <-ctx.Done():
+					return false
+				}
+			}
+			return true
+		}
+		if !send("127.0.0.1", localPorts) || !opts.LAN {
+			return
+		}
+		for _, host := range lanHosts() {
+			if !send(host, common) {
+				return
+			}
+		}
+	}()
+
+	var (
+		mu    sync.Mutex
+		found []DiscoveredService
+		wg    sync.WaitGroup
+	)
+	for range discoverConcurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dialer := net.Dialer{Timeout: discoverDialTimeout}
+			for t := range targets {
+				conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(t.host, strconv.Itoa(t.port)))
+				if err != nil {
+					continue
+				}
+				svc := identifyService(conn, t.port)
+				conn.Close()
+				svc.Host = t.host
+				mu.Lock()
+				found = append(found, svc)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	slices.SortFunc(found, func(a, b DiscoveredService) int {
+		// 本机在前，局域网按地址排序
+		switch {
+		case a.Host == b.Host:
+			return a.Port - b.Port
+		case a.Local():
+			return -1
+		case b.Local():
+			return 1
+		}
+		return bytes.Compare(net.ParseIP(a.Host).To16(), net.ParseIP(b.Host).To16())
+	})
+	return found, ctx.Err()
+}
+
+// identifyService 根据欢迎信息、HTTP 探测和端口识别服务
+func identifyService(conn net.Conn, port int) DiscoveredService {
+	svc := DiscoveredService{Port: port}
+	if known, ok := knownPorts[port]; ok {
+		svc.Service = known.name
+	}
+
+	buf := make([]byte, 512)
+	_ = conn.SetReadDeadline(time.Now().Add(discoverBannerTimeout))
+	n, _ := conn.Read(buf)
+	if n == 0 {
+		// 没有欢迎信息的服务，发送 HTTP 请求试探
+		_ = conn.SetDeadline(time.Now().Add(discoverBannerTimeout))
+		if _, err := conn.Write([]byte("HEAD / HTTP/1.0\r\nHost: localhost\r\n\r\n")); err == nil {
+			n, _ = conn.Read(buf)
+		}
+	}
+	data := buf[:n]
+
+	switch {
+	case n == 0:
+	case bytes.HasPrefix(data, []byte("SSH-")):
+		svc.Service, svc.Banner = "ssh", firstLine(data)
+	case bytes.HasPrefix(data, []byte("RFB ")):
+		svc.Service, svc.Banner = "vnc", firstLine(data)
+	case bytes.HasPrefix(data, []byte("220")):
+		line := firstLine(data)
+		switch upper := strings.ToUpper(line); {
+		case strings.Contains(upper, "FTP"):
+			svc.Service = "ftp"
+		case strings.Contains(upper, "SMTP"):
+			svc.Service = "smtp"
+		}
+		svc.Banner = line
+	case bytes.HasPrefix(data, []byte("HTTP/")):
+		if known, ok := knownPorts[port]; !ok || !known.http {
+			svc.Service = "http"
+		}
+		svc.Banner = httpServerHeader(data)
+	case isMySQLGreeting(data, port):
+		svc.Service = "mysql"
+		if end := bytes.IndexByte(data[5:], 0); end > 0 {
+			svc.Banner = "MySQL " + string(data[5:5+end])
+		}
+	case data[0] == 0x15 && n > 1 && data[1] == 0x03:
+		// 对 HTTP 请求返回 TLS 告警，说明是 TLS 服务
+		if svc.Service == "" {
+			svc.Service = "tls"
+		}
+	default:
+		svc.Banner = firstLine(data)
+	}
+	return svc
+}
+
+// isMySQLGreeting 是否为 MySQL 握手包：3 字节长度、1 字节序号、协议版本 10、以 0 结尾的版本号
+func isMySQLGreeting(data []byte, port int) bool {
+	if len(data) <= 5 || data[4] != 10 {
+		return false
+	}
+	return port == 3306 || bytes.Contains(data, []byte("mysql_native_password")) || bytes.Contains(data, []byte("caching_sha2_password"))
+}
+
+// firstLine 返回第一行可打印文本，过长时截断
+func firstLine(data []byte) string {
+	line, _, _ := strings.Cut(string(data), "\n")
+	line = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == 0xfffd {
+			return -1
+		}
+		return r
+	}, line)
+	if runes := []rune(strings.TrimSpace(line)); len(runes) > discoverBannerMax {
+		return string(runes[:discoverBannerMax]) + "…"
+	}
+	return strings.TrimSpace(line)
+}
+
+// httpServerHeader 返回 HTTP 响应的 Server 头，没有时返回状态行
+func httpServerHeader(data []byte) string {
+	for _, line := range strings.Split(string(data), "\r\n") {
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(name), "server") {
+			return firstLine([]byte(strings.TrimSpace(value)))
+		}
+	}
+	return firstLine(data)
+}
+
+// lanHosts 返回本机所在 IPv4 私有网段中除本机外的地址，网段大于 /24 时只取本机所在的 /24
+func lanHosts() []string {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil
+	}
+
+	var hosts []string
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip := ipNet.IP.To4()
+		if ip == nil || !ip.IsPrivate() {
+			continue
+		}
+		ones, _ := ipNet.Mask.Size()
+		mask := ipNet.Mask
+		if ones < 24 {
+			mask = net.CIDRMask(24, 32)
+		}
+		network := ip.Mask(mask)
+		size := 1 << (32 - max(ones, 24))
+		for i := 1; i < size-1 && len(hosts) < discoverMaxLANHosts; i++ {
+			host := make(net.IP, 4)
+			copy(host, network)
+			host[2] += byte(i >> 8)
+			host[3] += byte(i)
+			if host.Equal(ip) || seen[host.String()] {
+				continue
+			}
+			seen[host.String()] = true
+			hosts = append(hosts, host.String())
+		}
+	}
+	return hosts
+}
+
+// DiscoverSummary 返回扫描范围的说明
+func DiscoverSummary(opts DiscoverOptions) string {
+	local := i18n.T("本机全部端口")
+	if runtime.GOOS == "windows" {
+		local = i18n.Sprintf("本机 %d 个常见端口", len(knownPorts))
+	}
+	if !opts.LAN {
+		return local
+	}
+	return local + i18n.Sprintf(" + 局域网 %d 台主机的 %d 个常见端口", len(lanHosts()), len(knownPorts))
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// dnsCheckTimeout 检查全部域名的超时时间
+const dnsCheckTimeout = 8 * time.Second
+
+// dnsWildcardProbe 检查泛域名时代替 * 的主机名
+const dnsWildcardProbe = "frp-dns-check"
+
+// DomainCheckStatus 域名检查结果
+type DomainCheckStatus string
+
+const (
+	DomainOK         DomainCheckStatus = "ok"         // 解析到 frps 服务器
+	DomainMismatch   DomainCheckStatus = "mismatch"   // 解析到其他地址
+	DomainUnresolved DomainCheckStatus = "unresolved" // 无法解析
+	DomainUnknown    DomainCheckStatus = "unknown"    // frps 地址无法解析，无法比较
+)
+
+// DomainCheck 一个域名的 DNS 检查结果
+type DomainCheck struct {
+	Proxy   string
+	Domain  string
+	CNAME   string   // 域名的 CNAME 目标，没有 CNAME 时为空
+	Addrs   []string // 域名解析到的地址
+	Status  DomainCheckStatus
+	Message string // 问题说明和修改建议，检查通过时为解析结果
+}
+
+// domainTarget 需要检查的代理域名
+type domainTarget struct {
+	proxy  string
+	domain string
+}
+
+// proxyDomains 收集已启用的 HTTP/HTTPS 代理的域名，subdomain 与 subDomainHost 拼接，subDomainHost 为空时跳过
+func proxyDomains(cfg *config.Config, subDomainHost string) []domainTarget {
+	var targets []domainTarget
+	for _, proxy := range cfg.Proxies {
+		if proxy.Disabled || (proxy.Type != "http" && proxy.Type != "https") {
+			continue
+		}
+		for _, domain := range proxy.CustomDomains {
+			if domain = strings.TrimSpace(domain); domain != "" {
+				targets = append(targets, domainTarget{proxy: proxy.Name, domain: domain})
+			}
+		}
+		if proxy.Subdomain != "" && subDomainHost != "" {
+			targets = append(targets, domainTarget{proxy: proxy.Name, domain: proxy.Subdomain + "." + subDomainHost})
+		}
+	}
+	return targets
+}
+
+// HasProxyDomains 配置中是否有需要检查 DNS 的域名
+func HasProxyDomains(cfg *config.Config, subDomainHost string) bool {
+	return cfg != nil && len(proxyDomains(cfg, subDomainHost)) > 0
+}
+
+// CheckProxyDomains 解析客户端配置中 HTTP/HTTPS 代理的自定义域名和子域名，
+// 检查 A/AAAA 记录或 CNAME 目标是否指向客户端连接的 frps 服务器（serverAddr）。
+// subDomainHost 为服务端配置的子域名主域名，未知时传空字符串，只设置了 subdomain 的代理不检查
+func CheckProxyDomains(cfg *config.Config, subDomainHost string) []DomainCheck {
+	targets := proxyDomains(cfg, subDomainHost)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dnsCheckTimeout)
+	defer cancel()
+
+	serverAddr := strings.TrimSuffix(strings.ToLower(cfg.ServerAddr), ".")
+	serverIPs, serverErr := lookupHostIPs(ctx, serverAddr)
+
+	checks := make([]DomainCheck, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checks[i] = checkDomain(ctx, target, serverAddr, serverIPs, serverErr)
+		}()
+	}
+	wg.Wait()
+	return checks
+}
+
+// lookupHostIPs 解析 frps 地址，地址本身是 IP 时直接返回
+func lookupHostIPs(ctx context.Context, host string) ([]string, error) {
+	if host == "" {
+		return nil, i18n.Errorf("客户端配置未设置 serverAddr")
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return []string{ip.String()}, nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ips := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP.String())
+	}
+	return ips, nil
+}
+
+// checkDomain 解析一个域名并与 frps 地址比较，生成可操作的提示
+func checkDomain(ctx context.Context, target domainTarget, serverAddr string, serverIPs []string, serverErr error) DomainCheck {
+	check := DomainCheck{Proxy: target.proxy, Domain: target.domain}
+	host := target.domain
+	if strings.HasPrefix(host, "*.") {
+		host = dnsWildcardProbe + host[1:]
+	}
+
+	if cname, err := net.DefaultResolver.LookupCNAME(ctx, host); err == nil {
+		cname = strings.TrimSuffix(strings.ToLower(cname), ".")
+		if cname != strings.ToLower(host) {
+			check.CNAME = cname
+		}
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		check.Status = DomainUnresolved
+		check.Message = i18n.Sprintf("无法解析: %v。请在 DNS 中为 %s 添加指向 %s 的 A/AAAA 记录",
+			dnsErrorText(err), target.domain, serverTargetText(serverAddr, serverIPs))
+		if serverAddr != "" && net.ParseIP(serverAddr) == nil {
+			check.Message += i18n.Sprintf("，或添加指向 %s 的 CNAME 记录", serverAddr)
+		}
+		return check
+	}
+	for _, addr := range addrs {
+		check.Addrs = append(check.Addrs, addr.IP.String())
+	}
+	resolved := strings.Join(check.Addrs, ", ")
+	if check.CNAME != "" {
+		resolved = check.CNAME + " → " + resolved
+	}
+
+	if serverErr != nil {
+		check.Status = DomainUnknown
+		check.Message = i18n.Sprintf("解析到 %s，但无法解析 frps 地址 %s: %v，无法比较", resolved, serverAddr, dnsErrorText(serverErr))
+		return check
+	}
+
+	if check.CNAME == serverAddr || slices.ContainsFunc(check.Addrs, func(ip string) bool { return slices.Contains(serverIPs, ip) }) {
+		check.Status = DomainOK
+		check.Message = i18n.Sprintf("解析到 %s", resolved)
+		return check
+	}
+
+	check.Status = DomainMismatch
+	check.Message = i18n.Sprintf("解析到 %s，但 frps 服务器是 %s。请把 %s 的 A/AAAA 记录改为 %s",
+		resolved, serverTargetText(serverAddr, serverIPs), target.domain, strings.Join(serverIPs, " / "))
+	if !isPublicIPs(serverIPs) {
+		check.Message += i18n.T("；serverAddr 是内网地址，如果 frps 通过 NAT 或端口映射对外提供服务，请确认域名指向的公网地址转发到了 frps")
+	} else if check.CNAME != "" {
+		check.Message += i18n.Sprintf("，或把 CNAME 改为 %s；如果使用了 CDN，请确认 CDN 回源地址是 frps", serverAddr)
+	}
+	return check
+}
+
+// serverTargetText 返回 frps 地址的说明，如 frp.example.com (1.2.3.4)
+func serverTargetText(serverAddr string, serverIPs []string) string {
+	ips := strings.Join(serverIPs, ", ")
+	if ips == "" || ips == serverAddr {
+		return serverAddr
+	}
+	return serverAddr + " (" + ips + ")"
+}
+
+// dnsErrorText 简化 DNS 错误，域名不存在时返回更易懂的说明
+func dnsErrorText(err error) string {
+	if dnsErr, ok := err.(*net.DNSError); ok {
+		switch {
+		case dnsErr.IsNotFound:
+			return i18n.T("域名没有解析记录")
+		case dnsErr.IsTimeout:
+			return i18n.T("DNS 查询超时")
+		}
+	}
+	return err.Error()
+}
+
+// isPublicIPs 地址中是否至少有一个公网地址
+func isPublicIPs(ips []string) bool {
+	for _, s := range ips {
+		ip := net.ParseIP(s)
+		if ip != nil && !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsUnspecified() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 生命周期事件类型，格式为 "<分类>.<动作>"，订阅时可以只写分类
+const (
+	EventProcessStarted  = "process.started"
+	EventProcessStopped  = "process.stopped"
+	EventProcessCrashed  = "process.crashed"
+	EventProxyOnline     = "proxy.online"
+	EventProxyOffline    = "proxy.offline"
+	EventQuotaWarning    = "quota.warning"
+	EventQuotaExceeded   = "quota.exceeded"
+	EventConfigSaved     = "config.saved"
+	EventUpdateAvailable = "update.available"
+	EventUserAction      = "user.action"
+)
+
+// EventTypes 所有生命周期事件类型
+var EventTypes = []string{
+	EventProcessStarted,
+	EventProcessStopped,
+	EventProcessCrashed,
+	EventProxyOnline,
+	EventProxyOffline,
+	EventQuotaWarning,
+	EventQuotaExceeded,
+	EventConfigSaved,
+	EventUpdateAvailable,
+	EventUserAction,
+}
+
+// 用户操作，作为 user.action 事件的 Source
+const (
+	ActionProcessStart   = "process.start"
+	ActionProcessStop    = "process.stop"
+	ActionProcessRestart = "process.restart"
+	ActionConfigEdit     = "config.edit"
+	ActionConfigUndo     = "config.undo"
+	ActionTemplateApply  = "template.apply"
+	ActionFrpInstall     = "frp.install"
+	ActionSystemService  = "system.service"
+	ActionTokenRotate    = "token.rotate"
+)
+
+// Event 生命周期事件
+type Event struct {
+	Type    string            `json:"type"`
+	Source  string            `json:"source"` // 事件来源，如 server、client、代理名或配置文件路径
+	Title   string            `json:"title"`
+	Message string            `json:"message,omitempty"`
+	Data    map[string]string `json:"data,omitempty"`
+	Host    string            `json:"host"`
+	Time    time.Time         `json:"time"`
+}
+
+// Text 返回适合推送到聊天工具的单行描述
+func (e Event) Text() string {
+	text := e.Title
+	if e.Message != "" {
+		text += ": " + e.Message
+	}
+	if e.Host != "" {
+		text = "[" + e.Host + "] " + text
+	}
+	return text
+}
+
+// MatchEvent 判断事件是否符合订阅列表，列表为空或包含 "*" 时匹配全部，
+// 只写分类（如 process）时匹配该分类下的所有事件。
+// 用户操作事件较频繁，列表为空时不匹配，需要显式订阅
+func MatchEvent(subscriptions []string, eventType string) bool {
+	category, _, _ := strings.Cut(eventType, ".")
+	if len(subscriptions) == 0 {
+		return category != "user"
+	}
+	for _, subscription := range subscriptions {
+		if subscription == "*" || subscription == eventType || subscription == category {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfigSavedEvent 创建配置文件已保存事件，role 为 server 或 client
+func ConfigSavedEvent(role, path string) Event {
+	title := i18n.T("客户端配置已保存")
+	if role == "server" {
+		title = i18n.T("服务端配置已保存")
+	}
+	return Event{
+		Type:    EventConfigSaved,
+		Source:  role,
+		Title:   title,
+		Message: path,
+		Data:    map[string]string{"path": path},
+	}
+}
+
+// UserActionEvent 创建用户操作事件，action 为 Action 常量，detail 描述操作对象，如进程、文件或修改内容
+func UserActionEvent(action, detail string) Event {
+	return Event{
+		Type:   EventUserAction,
+		Source: action,
+		Title:  detail,
+		Data:   map[string]string{"user": config.CurrentUser()},
+	}
+}
+
+// EventBus 进程内的事件总线，发布者不会被慢订阅者阻塞
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[int]chan Event
+	nextID      int
+	host        string
+}
+
+// NewEventBus 创建事件总线
+func NewEventBus() *EventBus {
+	host, _ := os.Hostname()
+	return &EventBus{
+		subscribers: make(map[int]chan Event),
+		host:        host,
+	}
+}
+
+// Subscribe 订阅所有事件，返回事件通道和取消订阅的函数。
+// 通道缓冲区满时新事件会被丢弃
+func (b *EventBus) Subscribe(buffer int) (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan Event, buffer)
+	b.subscribers[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers, id)
+			close(ch)
+		})
+	}
+}
+
+// Publish 非阻塞地向所有订阅者发送事件，总线为 nil 时忽略
+func (b *EventBus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if event.Host == "" {
+		event.Host = b.host
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
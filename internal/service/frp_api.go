@@ -5,15 +5,41 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
 )
 
 // APIClient FRP API 客户端
 type APIClient struct {
+	mu         sync.RWMutex
+	name       string // Dashboard 目标名称
 	baseURL    string
 	username   string
 	password   string
+	token      string
 	httpClient *http.Client
+
+	// 响应缓存和并发请求合并，以完整请求地址为键
+	cacheMu  sync.Mutex
+	cache    map[string]*apiCacheEntry
+	inflight singleflight.Group
+}
+
+// APIStatusError frps API 返回非 200 状态码，404 通常表示当前 frps 版本不提供该接口
+type APIStatusError struct {
+	StatusCode int
+}
+
+// Error 实现 error 接口
+func (e *APIStatusError) Error() string {
+	return i18n.Sprintf("API 请求失败，状态码: %d", e.StatusCode)
 }
 
 // ProxyInfo 代理信息（匹配FRP实际API响应）
@@ -34,14 +60,28 @@ type ProxyConf struct {
 	Name       string `json:"name"`
 	Type       string `json:"type"`
 	LocalIP    string `json:"localIP"`
+	LocalPort  int    `json:"localPort"`
 	RemotePort int    `json:"remotePort"`
+	// http/https 代理的域名
+	CustomDomains     []string `json:"customDomains"`
+	SubDomain         string   `json:"subdomain"`
+	Locations         []string `json:"locations"`
+	HostHeaderRewrite string   `json:"hostHeaderRewrite"`
+	Multiplexer       string   `json:"multiplexer"`
 	// FRP API中有很多额外字段，但我们主要需要这些
-	Transport    map[string]string      `json:"transport"`
+	Transport    ProxyConfTransport     `json:"transport"`
 	LoadBalancer map[string]string      `json:"loadBalancer"`
 	HealthCheck  map[string]interface{} `json:"healthCheck"`
 	Plugin       map[string]interface{} `json:"plugin"`
 }
 
+// ProxyConfTransport 代理的加密、压缩与限速配置，frps 返回的开关是布尔值
+type ProxyConfTransport struct {
+	UseEncryption  bool   `json:"useEncryption"`
+	UseCompression bool   `json:"useCompression"`
+	BandwidthLimit string `json:"bandwidthLimit"`
+}
+
 // ServerInfo 服务器信息
 type ServerInfo struct {
 	Version               string         `json:"version"`
@@ -86,6 +126,13 @@ type TrafficInfo struct {
 	TrafficOut int64  `json:"traffic_out"`
 }
 
+// ProxyTrafficHistory 代理流量历史（frps 按天统计，索引 0 为今天）
+type ProxyTrafficHistory struct {
+	Name       string  `json:"name"`
+	TrafficIn  []int64 `json:"trafficIn"`
+	TrafficOut []int64 `json:"trafficOut"`
+}
+
 // NewAPIClient 创建新的 API 客户端
 func NewAPIClient(baseURL, username, password string) *APIClient {
 	return &APIClient{
@@ -95,51 +142,120 @@ func NewAPIClient(baseURL, username, password string) *APIClient {
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		cache: make(map[string]*apiCacheEntry),
 	}
 }
 
-// makeRequest 发送 HTTP 请求
-func (c *APIClient) makeRequest(endpoint string) ([]byte, error) {
-	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
+// SetTarget 切换到指定的 Dashboard 目标，包括认证方式和 TLS 设置，用于应用设置变更后复用同一客户端。
+// CA 证书无法加载时仍切换地址，但使用系统默认的证书校验
+func (c *APIClient) SetTarget(target config.DashboardTarget) error {
+	tlsConfig, err := target.TLSConfig()
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	if tlsConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		httpClient.Transport = transport
+	}
+
+	c.InvalidateCache()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.name = target.Name
+	c.baseURL = strings.TrimRight(target.URL, "/")
+	c.username = target.User
+	c.password = target.Password
+	c.token = target.Token
+	c.httpClient = httpClient
+	return err
+}
+
+// TargetName 返回当前 Dashboard 目标名称，未通过 SetTarget 设置时为空
+func (c *APIClient) TargetName() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.name
+}
+
+// BaseURL 返回当前 API 地址
+func (c *APIClient) BaseURL() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.baseURL
+}
 
-	req, err := http.NewRequest("GET", url, nil)
+// newRequest 创建带认证信息的请求，设置了令牌时使用 Bearer 认证，否则使用 Basic 认证
+func (c *APIClient) newRequest(method, endpoint string) (*http.Request, *http.Client, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	req, err := http.NewRequest(method, c.baseURL+endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
+		return nil, nil, i18n.Errorf("创建请求失败: %w", err)
 	}
 
-	// 添加基本认证
-	if c.username != "" && c.password != "" {
+	switch {
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	case c.username != "" && c.password != "":
 		req.SetBasicAuth(c.username, c.password)
 	}
+	return req, c.httpClient, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+// makeRequest 发送 GET 请求，缓存未过期时直接返回缓存，同一地址的并发请求合并为一次
+func (c *APIClient) makeRequest(endpoint string) ([]byte, error) {
+	req, httpClient, err := c.newRequest(http.MethodGet, endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("请求失败: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API 请求失败，状态码: %d", resp.StatusCode)
+	key := req.URL.String()
+	if body, ok := c.cachedResponse(key); ok {
+		return body, nil
+	}
+	body, err, _ := c.inflight.Do(key, func() (any, error) {
+		return c.fetchCached(req, httpClient)
+	})
+	if err != nil {
+		return nil, err
 	}
+	return body.([]byte), nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// Ping 不经过缓存请求一次服务器信息，返回响应耗时，用于测量 Dashboard 延迟
+func (c *APIClient) Ping() (time.Duration, error) {
+	req, httpClient, err := c.newRequest(http.MethodGet, "/api/serverinfo")
 	if err != nil {
-		return nil, fmt.Errorf("读取响应失败: %w", err)
+		return 0, err
 	}
 
-	return body, nil
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, i18n.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return 0, i18n.Errorf("读取响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, &APIStatusError{StatusCode: resp.StatusCode}
+	}
+	return time.Since(start), nil
 }
 
 // GetServerInfo 获取服务器信息
 func (c *APIClient) GetServerInfo() (*ServerInfo, error) {
 	data, err := c.makeRequest("/api/serverinfo")
 	if err != nil {
-		return nil, fmt.Errorf("获取服务器信息失败: %w", err)
+		return nil, i18n.Errorf("获取服务器信息失败: %w", err)
 	}
 
 	var serverInfo ServerInfo
 	if err := json.Unmarshal(data, &serverInfo); err != nil {
-		return nil, fmt.Errorf("解析服务器信息失败: %w", err)
+		return nil, i18n.Errorf("解析服务器信息失败: %w", err)
 	}
 
 	return &serverInfo, nil
@@ -149,17 +265,24 @@ func (c *APIClient) GetServerInfo() (*ServerInfo, error) {
 func (c *APIClient) GetProxyList() ([]ProxyInfo, error) {
 	// FRP API需要按类型分别查询，常见的代理类型包括：
 	proxyTypes := []string{"tcp", "http", "https", "stcp", "sudp", "udp", "xtcp"}
-	var allProxies []ProxyInfo
 
-	for _, proxyType := range proxyTypes {
-		proxies, err := c.getProxyListByType(proxyType)
-		if err != nil {
-			// 如果某个类型查询失败，记录但不中断整个查询
-			continue
-		}
-		allProxies = append(allProxies, proxies...)
+	// 各类型并发查询，慢速链路上总耗时接近单次请求
+	results := make([][]ProxyInfo, len(proxyTypes))
+	var wg sync.WaitGroup
+	for i, proxyType := range proxyTypes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 如果某个类型查询失败，忽略该类型但不中断整个查询
+			results[i], _ = c.getProxyListByType(proxyType)
+		}()
 	}
+	wg.Wait()
 
+	var allProxies []ProxyInfo
+	for _, proxies := range results {
+		allProxies = append(allProxies, proxies...)
+	}
 	return allProxies, nil
 }
 
@@ -168,30 +291,30 @@ func (c *APIClient) getProxyListByType(proxyType string) ([]ProxyInfo, error) {
 	endpoint := fmt.Sprintf("/api/proxy/%s", proxyType)
 	data, err := c.makeRequest(endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("获取%s类型代理失败: %w", proxyType, err)
+		return nil, i18n.Errorf("获取%s类型代理失败: %w", proxyType, err)
 	}
 
 	var response struct {
 		Proxies []ProxyInfo `json:"proxies"`
 	}
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("解析%s类型代理失败: %w", proxyType, err)
+		return nil, i18n.Errorf("解析%s类型代理失败: %w", proxyType, err)
 	}
 
 	return response.Proxies, nil
 }
 
-// GetProxyInfo 获取特定代理信息
-func (c *APIClient) GetProxyInfo(name string) (*ProxyInfo, error) {
-	endpoint := fmt.Sprintf("/api/proxy/%s", name)
+// GetProxyInfo 获取特定代理信息，frps 按类型和名称定位代理
+func (c *APIClient) GetProxyInfo(proxyType, name string) (*ProxyInfo, error) {
+	endpoint := fmt.Sprintf("/api/proxy/%s/%s", url.PathEscape(proxyType), url.PathEscape(name))
 	data, err := c.makeRequest(endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("获取代理信息失败: %w", err)
+		return nil, i18n.Errorf("获取代理信息失败: %w", err)
 	}
 
 	var proxyInfo ProxyInfo
 	if err := json.Unmarshal(data, &proxyInfo); err != nil {
-		return nil, fmt.Errorf("解析代理信息失败: %w", err)
+		return nil, i18n.Errorf("解析代理信息失败: %w", err)
 	}
 
 	return &proxyInfo, nil
@@ -201,7 +324,7 @@ func (c *APIClient) GetProxyInfo(name string) (*ProxyInfo, error) {
 func (c *APIClient) GetClientList() ([]ClientInfo, error) {
 	data, err := c.makeRequest("/api/client")
 	if err != nil {
-		return nil, fmt.Errorf("获取客户端列表失败: %w", err)
+		return nil, i18n.Errorf("获取客户端列表失败: %w", err)
 	}
 
 	var response struct {
@@ -209,17 +332,38 @@ func (c *APIClient) GetClientList() ([]ClientInfo, error) {
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("解析客户端列表失败: %w", err)
+		return nil, i18n.Errorf("解析客户端列表失败: %w", err)
 	}
 
 	return response.Clients, nil
 }
 
+// KickClient 按 run ID 断开已连接的 frpc 客户端
+func (c *APIClient) KickClient(runID string) error {
+	req, httpClient, err := c.newRequest(http.MethodDelete, "/api/client/"+url.PathEscape(runID))
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return i18n.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return i18n.Errorf("断开客户端失败: %w", &APIStatusError{StatusCode: resp.StatusCode})
+	}
+
+	c.InvalidateCache()
+	return nil
+}
+
 // GetTrafficInfo 获取流量信息
 func (c *APIClient) GetTrafficInfo() ([]TrafficInfo, error) {
 	data, err := c.makeRequest("/api/traffic")
 	if err != nil {
-		return nil, fmt.Errorf("获取流量信息失败: %w", err)
+		return nil, i18n.Errorf("获取流量信息失败: %w", err)
 	}
 
 	var response struct {
@@ -227,63 +371,66 @@ func (c *APIClient) GetTrafficInfo() ([]TrafficInfo, error) {
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("解析流量信息失败: %w", err)
+		return nil, i18n.Errorf("解析流量信息失败: %w", err)
 	}
 
 	return response.Traffic, nil
 }
 
-// CloseProxy 关闭代理
-func (c *APIClient) CloseProxy(name string) error {
-	url := fmt.Sprintf("%s/api/proxy/%s", c.baseURL, name)
-
-	req, err := http.NewRequest("DELETE", url, nil)
+// GetProxyTraffic 获取指定代理的流量历史
+func (c *APIClient) GetProxyTraffic(name string) (*ProxyTrafficHistory, error) {
+	data, err := c.makeRequest("/api/traffic/" + url.PathEscape(name))
 	if err != nil {
-		return fmt.Errorf("创建请求失败: %w", err)
+		return nil, i18n.Errorf("获取代理流量历史失败: %w", err)
 	}
 
-	// 添加基本认证
-	if c.username != "" && c.password != "" {
-		req.SetBasicAuth(c.username, c.password)
+	var history ProxyTrafficHistory
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, i18n.Errorf("解析代理流量历史失败: %w", err)
+	}
+
+	return &history, nil
+}
+
+// CloseProxy 让 frps 关闭代理及其当前连接，frpc 会按配置重新注册代理
+func (c *APIClient) CloseProxy(name string) error {
+	req, httpClient, err := c.newRequest(http.MethodDelete, "/api/proxy/"+url.PathEscape(name))
+	if err != nil {
+		return err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("请求失败: %w", err)
+		return i18n.Errorf("请求失败: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("关闭代理失败，状态码: %d", resp.StatusCode)
+		return i18n.Errorf("关闭代理失败: %w", &APIStatusError{StatusCode: resp.StatusCode})
 	}
 
+	c.InvalidateCache()
 	return nil
 }
 
 // ReloadConfig 重新加载配置
 func (c *APIClient) ReloadConfig() error {
-	url := fmt.Sprintf("%s/api/reload", c.baseURL)
-
-	req, err := http.NewRequest("POST", url, nil)
+	req, httpClient, err := c.newRequest(http.MethodPost, "/api/reload")
 	if err != nil {
-		return fmt.Errorf("创建请求失败: %w", err)
-	}
-
-	// 添加基本认证
-	if c.username != "" && c.password != "" {
-		req.SetBasicAuth(c.username, c.password)
+		return err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("请求失败: %w", err)
+		return i18n.Errorf("请求失败: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("重新加载配置失败，状态码: %d", resp.StatusCode)
+		return i18n.Errorf("重新加载配置失败，状态码: %d", resp.StatusCode)
 	}
 
+	c.InvalidateCache()
 	return nil
 }
 
@@ -297,12 +444,12 @@ func (c *APIClient) IsServerReachable() bool {
 func (c *APIClient) GetConnectionStats() (map[string]interface{}, error) {
 	data, err := c.makeRequest("/api/status")
 	if err != nil {
-		return nil, fmt.Errorf("获取连接统计失败: %w", err)
+		return nil, i18n.Errorf("获取连接统计失败: %w", err)
 	}
 
 	var stats map[string]interface{}
 	if err := json.Unmarshal(data, &stats); err != nil {
-		return nil, fmt.Errorf("解析连接统计失败: %w", err)
+		return nil, i18n.Errorf("解析连接统计失败: %w", err)
 	}
 
 	return stats, nil
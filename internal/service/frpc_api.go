@@ -0,0 +1,146 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// ClientAPIClient frpc 管理接口客户端（webServer 配置项开启的 admin API）
+type ClientAPIClient struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// ClientProxyStatus frpc 上报的代理状态
+type ClientProxyStatus struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	Err        string `json:"err"`
+	LocalAddr  string `json:"local_addr"`
+	Plugin     string `json:"plugin"`
+	RemoteAddr string `json:"remote_addr"`
+}
+
+// NewClientAPIClient 创建 frpc 管理接口客户端
+func NewClientAPIClient(baseURL, username, password string) *ClientAPIClient {
+	return &ClientAPIClient{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		username: username,
+		password: password,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// NewClientAPIClientFromConfig 根据客户端配置中的 webServer 创建管理接口客户端
+func NewClientAPIClientFromConfig(cfg *config.Config) (*ClientAPIClient, error) {
+	if cfg == nil || cfg.WebServer.Port <= 0 {
+		return nil, i18n.Errorf("客户端未配置 webServer.port，无法使用管理接口")
+	}
+
+	addr := cfg.WebServer.Addr
+	if addr == "" || addr == "0.0.0.0" {
+		addr = "127.0.0.1"
+	}
+
+	baseURL := fmt.Sprintf("http://%s:%d", addr, cfg.WebServer.Port)
+	return NewClientAPIClient(baseURL, cfg.WebServer.User, cfg.WebServer.Password), nil
+}
+
+// doRequest 发送 HTTP 请求
+func (c *ClientAPIClient) doRequest(method, endpoint string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequest(method, c.baseURL+endpoint, body)
+	if err != nil {
+		return nil, i18n.Errorf("创建请求失败: %w", err)
+	}
+
+	// 添加基本认证
+	if c.username != "" && c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, i18n.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, i18n.Errorf("读取响应失败: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if msg := strings.TrimSpace(string(data)); msg != "" {
+			return nil, i18n.Errorf("API 请求失败，状态码: %d, %s", resp.StatusCode, msg)
+		}
+		return nil, i18n.Errorf("API 请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	return data, nil
+}
+
+// GetStatus 获取客户端各代理状态，按代理类型分组
+func (c *ClientAPIClient) GetStatus() (map[string][]ClientProxyStatus, error) {
+	data, err := c.doRequest(http.MethodGet, "/api/status", nil)
+	if err != nil {
+		return nil, i18n.Errorf("获取客户端状态失败: %w", err)
+	}
+
+	var status map[string][]ClientProxyStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, i18n.Errorf("解析客户端状态失败: %w", err)
+	}
+
+	return status, nil
+}
+
+// GetConfig 获取 frpc 当前使用的配置文件内容
+func (c *ClientAPIClient) GetConfig() (string, error) {
+	data, err := c.doRequest(http.MethodGet, "/api/config", nil)
+	if err != nil {
+		return "", i18n.Errorf("获取客户端配置失败: %w", err)
+	}
+	return string(data), nil
+}
+
+// PutConfig 覆盖 frpc 配置文件内容，需要再调用 Reload 才会生效
+func (c *ClientAPIClient) PutConfig(content string) error {
+	if _, err := c.doRequest(http.MethodPut, "/api/config", strings.NewReader(content)); err != nil {
+		return i18n.Errorf("上传客户端配置失败: %w", err)
+	}
+	return nil
+}
+
+// Reload 让 frpc 重新加载配置文件，不会中断进程
+func (c *ClientAPIClient) Reload() error {
+	if _, err := c.doRequest(http.MethodGet, "/api/reload", nil); err != nil {
+		return i18n.Errorf("热重载客户端配置失败: %w", err)
+	}
+	return nil
+}
+
+// Stop 请求 frpc 优雅退出
+func (c *ClientAPIClient) Stop() error {
+	if _, err := c.doRequest(http.MethodPost, "/api/stop", nil); err != nil {
+		return i18n.Errorf("停止客户端失败: %w", err)
+	}
+	return nil
+}
+
+// IsReachable 检查管理接口是否可达
+func (c *ClientAPIClient) IsReachable() bool {
+	_, err := c.GetStatus()
+	return err == nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 生命周期钩子名称，同时作为日志前缀和 FRP_HOOK 环境变量的值
+const (
+	HookPreStart  = "pre-start"
+	HookPostStart = "post-start"
+	HookPreStop   = "pre-stop"
+	HookPostStop  = "post-stop"
+	HookOnCrash   = "on-crash"
+)
+
+// hookWaitDelay 钩子被结束后等待其输出关闭的时间，避免后台子进程占用输出管道导致一直等待
+const hookWaitDelay = 2 * time.Second
+
+// Hooks 服务生命周期钩子
+type Hooks struct {
+	Commands map[string]string // 钩子名称 -> shell 命令
+	Timeout  time.Duration     // 单个钩子的最长运行时间
+}
+
+// HooksFromSettings 根据应用设置生成服务的生命周期钩子
+func HooksFromSettings(hooks config.ServiceHooks) Hooks {
+	timeout := hooks.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultHookTimeout
+	}
+	return Hooks{
+		Commands: map[string]string{
+			HookPreStart:  hooks.PreStart,
+			HookPostStart: hooks.PostStart,
+			HookPreStop:   hooks.PreStop,
+			HookPostStop:  hooks.PostStop,
+			HookOnCrash:   hooks.OnCrash,
+		},
+		Timeout: time.Duration(timeout) * time.Second,
+	}
+}
+
+// hookEnv 钩子运行时的上下文，通过 FRP_ 开头的环境变量传给命令
+type hookEnv struct {
+	pid        int
+	configPath string
+	reason     string // 异常退出原因，仅 on-crash
+}
+
+// SetHooks 设置服务的生命周期钩子
+func (m *Manager) SetHooks(service string, hooks Hooks) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks[service] = hooks
+}
+
+// runHook 执行钩子并等待结束，输出逐行写入服务日志。未配置时直接返回 nil，超时后强制结束钩子及其子进程
+func (m *Manager) runHook(service, hook string, env hookEnv) error {
+	m.mu.RLock()
+	hooks := m.hooks[service]
+	m.mu.RUnlock()
+
+	command := strings.TrimSpace(hooks.Commands[hook])
+	if command == "" {
+		return nil
+	}
+	timeout := hooks.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultHookTimeout * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, command)
+	setProcessGroup(cmd)
+	cmd.Cancel = func() error { return killProcessGroup(cmd.Process.Pid) }
+	cmd.WaitDelay = hookWaitDelay
+	cmd.Env = append(os.Environ(),
+		"FRP_SERVICE="+service,
+		"FRP_HOOK="+hook,
+		"FRP_CONFIG="+env.configPath,
+		"FRP_REASON="+env.reason,
+	)
+	if env.pid > 0 {
+		cmd.Env = append(cmd.Env, "FRP_PID="+strconv.Itoa(env.pid))
+	}
+	output := &hookLogWriter{m: m, service: service, prefix: "[" + hook + "] "}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	m.sendLog("INFO", i18n.Sprintf("执行 %s 钩子: %s", hook, command), service)
+	start := time.Now()
+	err := cmd.Run()
+	output.flush()
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		err = i18n.Errorf("%s 钩子超过 %s 未结束，已强制停止", hook, timeout)
+	case err != nil:
+		err = i18n.Errorf("%s 钩子执行失败: %w", hook, err)
+	}
+	if err != nil {
+		m.sendLog("ERROR", err.Error(), service)
+		return err
+	}
+	m.sendLog("INFO", i18n.Sprintf("%s 钩子执行完成，用时 %s", hook, time.Since(start).Round(time.Millisecond)), service)
+	return nil
+}
+
+// runHookAsync 在后台执行钩子，不阻塞进程的启停，失败只写入日志
+func (m *Manager) runHookAsync(service, hook string, env hookEnv) {
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+		_ = m.runHook(service, hook, env)
+	}()
+}
+
+// shellCommand 使用系统 shell 执行命令，Unix 为 sh -c，Windows 为 cmd /C
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// hookLogWriter 把钩子输出按行写入服务日志
+type hookLogWriter struct {
+	m       *Manager
+	service string
+	prefix  string
+
+	mu   sync.Mutex
+	part string
+}
+
+// Write 实现 io.Writer
+func (w *hookLogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	lines := strings.Split(w.part+string(p), "\n")
+	w.part = lines[len(lines)-1]
+	for _, line := range lines[:len(lines)-1] {
+		w.send(line)
+	}
+	return len(p), nil
+}
+
+// flush 写出最后一行没有换行符的输出
+func (w *hookLogWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.send(w.part)
+	w.part = ""
+}
+
+// send 写入一行非空输出，调用方需持有锁
+func (w *hookLogWriter) send(line string) {
+	if line = strings.TrimSpace(line); line != "" {
+		w.m.sendLog("INFO", w.prefix+line, w.service)
+	}
+}
+
+// runPreStartHook 服务未运行时执行 pre-start 钩子，钩子失败时取消启动
+func (m *Manager) runPreStartHook(service, configPath string) error {
+	status := m.GetServerStatus()
+	if service == "client" {
+		status = m.GetClientStatus()
+	}
+	if status.IsRunning {
+		// 交给启动流程报告服务已在运行
+		return nil
+	}
+	if err := m.runHook(service, HookPreStart, hookEnv{configPath: configPath}); err != nil {
+		return i18n.Errorf("已取消启动 %s: %w", serviceDisplayName(service), err)
+	}
+	return nil
+}
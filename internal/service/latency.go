@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// latencyHistorySize 保留的延迟采样数量
+const latencyHistorySize = 120
+
+// latencyWarnSamples 连续超过阈值多少次才进入告警状态，避免偶发抖动
+const latencyWarnSamples = 3
+
+// LatencySample 一次延迟采样，失败或未测量的项为 -1
+type LatencySample struct {
+	Time      time.Time
+	Connect   time.Duration // TCP 连接 serverAddr:serverPort 的耗时
+	Dashboard time.Duration // frps Dashboard API 的响应耗时
+}
+
+// LatencyOptions 延迟监控选项
+type LatencyOptions struct {
+	Interval         time.Duration // 采样间隔，0 表示关闭
+	Threshold        time.Duration // 告警阈值，0 表示不告警
+	ClientConfigPath string        // 客户端配置，从中读取 serverAddr 和 serverPort
+}
+
+// LatencyOptionsFromSettings 根据应用设置生成延迟监控选项
+func LatencyOptionsFromSettings(settings *config.AppSettings) LatencyOptions {
+	return LatencyOptions{
+		Interval:         settings.LatencyDuration(),
+		Threshold:        settings.LatencyThreshold(),
+		ClientConfigPath: settings.ClientConfigPath,
+	}
+}
+
+// LatencyMonitor 在后台定期测量到 frps 的 TCP 连接延迟和 Dashboard 响应时间
+type LatencyMonitor struct {
+	manager   *Manager
+	apiClient *APIClient
+
+	mu      sync.Mutex
+	options LatencyOptions
+	target  string
+	samples []LatencySample
+
+	cancel       context.CancelFunc
+	optionsReady chan struct{}
+}
+
+// NewLatencyMonitor 创建延迟监控
+func NewLatencyMonitor(manager *Manager, apiClient *APIClient, options LatencyOptions) *LatencyMonitor {
+	return &LatencyMonitor{
+		manager:      manager,
+		apiClient:    apiClient,
+		options:      options,
+		optionsReady: make(chan struct{}, 1),
+	}
+}
+
+// SetOptions 更新监控选项，下一轮采样生效
+func (lm *LatencyMonitor) SetOptions(options LatencyOptions) {
+	lm.mu.Lock()
+	lm.options = options
+	lm.mu.Unlock()
+
+	select {
+	case lm.optionsReady <- struct{}{}:
+	default:
+	}
+}
+
+// Options 返回当前监控选项
+func (lm *LatencyMonitor) Options() LatencyOptions {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	return lm.options
+}
+
+// Start 启动采样 goroutine，立即进行第一次采样
+func (lm *LatencyMonitor) Start() {
+	if lm.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lm.cancel = cancel
+	go lm.run(ctx)
+}
+
+// Stop 停止采样并清空历史
+func (lm *LatencyMonitor) Stop() {
+	if lm.cancel != nil {
+		lm.cancel()
+		lm.cancel = nil
+	}
+
+	lm.mu.Lock()
+	lm.samples = nil
+	lm.mu.Unlock()
+}
+
+// run 采样循环
+func (lm *LatencyMonitor) run(ctx context.Context) {
+	for {
+		lm.Sample()
+
+		interval := lm.Options().Interval
+		if interval <= 0 {
+			interval = 5 * time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-lm.optionsReady:
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Sample 进行一次采样并加入历史
+func (lm *LatencyMonitor) Sample() LatencySample {
+	options := lm.Options()
+	target := lm.serverTarget(options)
+	timeout := options.Interval
+	if timeout <= 0 || timeout > 5*time.Second {
+		timeout = 5 * time.Second
+	}
+
+	sample := LatencySample{Time: time.Now(), Connect: -1, Dashboard: -1}
+	if target != "" {
+		start := time.Now()
+		if conn, err := net.DialTimeout("tcp", target, timeout); err == nil {
+			sample.Connect = time.Since(start)
+			conn.Close()
+		}
+	}
+	if lm.apiClient != nil {
+		// 不使用缓存，否则测到的是缓存命中的耗时
+		if elapsed, err := lm.apiClient.Ping(); err == nil {
+			sample.Dashboard = elapsed
+		}
+	}
+
+	lm.mu.Lock()
+	lm.target = target
+	lm.samples = append(lm.samples, sample)
+	if len(lm.samples) > latencyHistorySize {
+		lm.samples = lm.samples[len(lm.samples)-latencyHistorySize:]
+	}
+	lm.mu.Unlock()
+	return sample
+}
+
+// serverTarget 从客户端配置读取 frps 地址，正在运行的 frpc 使用其启动时的配置
+func (lm *LatencyMonitor) serverTarget(options LatencyOptions) string {
+	configPath := options.ClientConfigPath
+	if lm.manager != nil {
+		if state := lm.manager.GetProcessState("client"); state != nil && state.ConfigPath != "" {
+			configPath = state.ConfigPath
+		}
+	}
+	if configPath == "" {
+		return ""
+	}
+
+	cfg, err := config.NewLoader(configPath).Load()
+	if err != nil || cfg.ServerAddr == "" {
+		return ""
+	}
+	port := cfg.ServerPort
+	if port <= 0 {
+		port = 7000
+	}
+	return net.JoinHostPort(cfg.ServerAddr, strconv.Itoa(port))
+}
+
+// Samples 返回采样历史，按时间从旧到新
+func (lm *LatencyMonitor) Samples() []LatencySample {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	return append([]LatencySample(nil), lm.samples...)
+}
+
+// Target 返回最近一次测量的 frps 地址，未配置客户端时为空
+func (lm *LatencyMonitor) Target() string {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	return lm.target
+}
+
+// Warning 最近连续几次连接延迟或 Dashboard 响应时间超过阈值时返回告警说明，否则返回空字符串
+func (lm *LatencyMonitor) Warning() string {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	threshold := lm.options.Threshold
+	if threshold <= 0 || len(lm.samples) < latencyWarnSamples {
+		return ""
+	}
+
+	recent := lm.samples[len(lm.samples)-latencyWarnSamples:]
+	exceeded := func(value func(LatencySample) time.Duration) (time.Duration, bool) {
+		var worst time.Duration
+		for _, sample := range recent {
+			if value(sample) <= threshold {
+				return 0, false
+			}
+			worst = max(worst, value(sample))
+		}
+		return worst, true
+	}
+
+	if worst, ok := exceeded(func(s LatencySample) time.Duration { return s.Connect }); ok {
+		return i18n.Sprintf("最近 %d 次连接 %s 的延迟超过 %dms，最高 %dms",
+			latencyWarnSamples, lm.target, threshold.Milliseconds(), worst.Milliseconds())
+	}
+	if worst, ok := exceeded(func(s LatencySample) time.Duration { return s.Dashboard }); ok {
+		return i18n.Sprintf("最近 %d 次 frps Dashboard 响应时间超过 %dms，最高 %dms",
+			latencyWarnSamples, threshold.Milliseconds(), worst.Milliseconds())
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package service
+
+import "sync"
+
+// logHistorySize 保留的最近日志条数，新订阅者订阅时先收到这些日志
+const logHistorySize = 500
+
+// logSubscriberBuffer 每个订阅者的通道缓冲区，需大于 logHistorySize 以容纳回放的历史
+const logSubscriberBuffer = 1000
+
+// logBroadcaster 将日志广播给所有订阅者，每个订阅者有独立的通道，
+// 慢订阅者缓冲区满时只丢弃它自己的新日志，不影响其他订阅者和进程输出的读取
+type logBroadcaster struct {
+	mu          sync.Mutex
+	subscribers map[int]chan LogMessage
+	nextID      int
+	history     *LogStore // 最近的日志，新订阅者订阅时回放
+	closed      bool
+}
+
+// newLogBroadcaster 创建日志广播器
+func newLogBroadcaster() *logBroadcaster {
+	return &logBroadcaster{
+		subscribers: make(map[int]chan LogMessage),
+		history:     NewLogStore(logHistorySize),
+	}
+}
+
+// publish 记录日志并非阻塞地发送给所有订阅者，关闭后忽略
+func (b *logBroadcaster) publish(msg LogMessage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	b.history.Append(msg)
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+// subscribe 订阅日志，通道中先是历史日志，之后是新日志。返回取消订阅的函数
+func (b *logBroadcaster) subscribe() (<-chan LogMessage, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan LogMessage, logSubscriberBuffer)
+	// 在锁内回放历史，保证与之后的新日志之间没有遗漏或重复
+	for _, msg := range b.history.All() {
+		ch <- msg
+	}
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := b.subscribers[id]; ok {
+				delete(b.subscribers, id)
+				close(ch)
+			}
+		})
+	}
+}
+
+// close 关闭所有订阅者的通道，之后的日志被忽略
+func (b *logBroadcaster) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for id, ch := range b.subscribers {
+		delete(b.subscribers, id)
+		close(ch)
+	}
+}
+
+// SubscribeLogs 订阅 frps/frpc 日志，返回独立的日志通道和取消订阅的函数。
+// 通道中先收到最近的历史日志；消费过慢导致缓冲区满时，该订阅者会丢失新日志。
+// Manager 关闭后通道被关闭
+func (m *Manager) SubscribeLogs() (<-chan LogMessage, func()) {
+	return m.logs.subscribe()
+}
+
+// RecentLogs 返回最近的日志，最多 500 条
+func (m *Manager) RecentLogs() []LogMessage {
+	return m.logs.history.All()
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevels 日志级别，按严重程度从高到低排列
+var LogLevels = []string{"ERROR", "WARN", "INFO", "DEBUG", "TRACE"}
+
+// frpLevelPattern 匹配 frp 自身输出中的级别标记，包括短格式 [E] [W] [I] [D] [T] 和完整的级别名
+var frpLevelPattern = regexp.MustCompile(`\[(E|W|I|D|T|ERROR|WARNING|WARN|INFO|DEBUG|TRACE)\]`)
+
+// ParseLogLevel 把 frp 日志中的级别标记（短格式或完整名称，不含方括号）转换为 LogLevels 中的级别，无法识别时返回空
+func ParseLogLevel(token string) string {
+	switch strings.ToUpper(token) {
+	case "E", "ERROR":
+		return "ERROR"
+	case "W", "WARN", "WARNING":
+		return "WARN"
+	case "I", "INFO":
+		return "INFO"
+	case "D", "DEBUG":
+		return "DEBUG"
+	case "T", "TRACE":
+		return "TRACE"
+	}
+	return ""
+}
+
+// EffectiveLevel 获取日志的实际级别，优先使用 frp 输出中的级别标记。
+// frp 的日志默认写到标准输出，只按输出管道判断会把警告和错误都当作 INFO
+func (l LogMessage) EffectiveLevel() string {
+	if match := frpLevelPattern.FindStringSubmatch(l.Message); match != nil {
+		return ParseLogLevel(match[1])
+	}
+	return l.Level
+}
+
+// LogLevelRank 返回级别的严重程度，数值越小越严重，未知级别排在最后
+func LogLevelRank(level string) int {
+	for i, l := range LogLevels {
+		if l == level {
+			return i
+		}
+	}
+	return len(LogLevels)
+}
+
+// LogQuery 日志查询条件，零值表示返回全部日志
+type LogQuery struct {
+	Since    time.Time             // 只返回此时间及之后的日志
+	Until    time.Time             // 只返回此时间之前的日志
+	MinLevel string                // 只返回该级别及更严重的日志
+	Source   string                // 只返回该来源 (server/client) 的日志
+	Match    func(LogMessage) bool // 其他过滤条件，如关键字搜索
+	Limit    int                   // 只返回满足条件的最新 N 条
+}
+
+// matches 判断日志是否满足查询条件
+func (q LogQuery) matches(entry LogMessage) bool {
+	if !q.Since.IsZero() && entry.Timestamp.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !entry.Timestamp.Before(q.Until) {
+		return false
+	}
+	if q.MinLevel != "" && LogLevelRank(entry.EffectiveLevel()) > LogLevelRank(q.MinLevel) {
+		return false
+	}
+	if q.Source != "" && entry.Source != q.Source {
+		return false
+	}
+	return q.Match == nil || q.Match(entry)
+}
+
+// LogStore 固定容量的环形日志存储，追加为 O(1)，写满后覆盖最旧的日志，可在多个 goroutine 中使用
+type LogStore struct {
+	mu      sync.RWMutex
+	entries []LogMessage
+	start   int // 最旧日志的位置
+	size    int
+}
+
+// NewLogStore 创建容量为 capacity 的日志存储，capacity 小于 1 时按 1 处理
+func NewLogStore(capacity int) *LogStore {
+	return &LogStore{entries: make([]LogMessage, max(capacity, 1))}
+}
+
+// Append 追加日志，超出容量时丢弃最旧的日志
+func (s *LogStore) Append(entries ...LogMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	capacity := len(s.entries)
+	for _, entry := range entries {
+		if s.size < capacity {
+			s.entries[(s.start+s.size)%capacity] = entry
+			s.size++
+			continue
+		}
+		s.entries[s.start] = entry
+		s.start = (s.start + 1) % capacity
+	}
+}
+
+// Len 返回当前保存的日志条数
+func (s *LogStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.size
+}
+
+// Capacity 返回最多保存的日志条数
+func (s *LogStore) Capacity() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+// SetCapacity 修改容量，缩小时只保留最新的日志
+func (s *LogStore) SetCapacity(capacity int) {
+	capacity = max(capacity, 1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if capacity == len(s.entries) {
+		return
+	}
+
+	kept := s.snapshotLocked()
+	if len(kept) > capacity {
+		kept = kept[len(kept)-capacity:]
+	}
+	s.entries = make([]LogMessage, capacity)
+	copy(s.entries, kept)
+	s.start, s.size = 0, len(kept)
+}
+
+// Clear 清空日志
+func (s *LogStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.entries)
+	s.start, s.size = 0, 0
+}
+
+// All 按时间顺序返回全部日志的副本
+func (s *LogStore) All() []LogMessage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snapshotLocked()
+}
+
+// Query 按时间顺序返回满足条件的日志。设置 Limit 时从最新的日志向前查找，找到足够数量即停止
+func (s *LogStore) Query(q LogQuery) []LogMessage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	capacity := len(s.entries)
+	if q.Limit <= 0 {
+		var result []LogMessage
+		for i := 0; i < s.size; i++ {
+			if entry := s.entries[(s.start+i)%capacity]; q.matches(entry) {
+				result = append(result, entry)
+			}
+		}
+		return result
+	}
+
+	result := make([]LogMessage, 0, min(q.Limit, s.size))
+	for i := s.size - 1; i >= 0 && len(result) < q.Limit; i-- {
+		entry := s.entries[(s.start+i)%capacity]
+		if !q.Since.IsZero() && entry.Timestamp.Before(q.Since) {
+			// 日志按时间追加，更早的日志都不满足条件
+			break
+		}
+		if q.matches(entry) {
+			result = append(result, entry)
+		}
+	}
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
+
+// snapshotLocked 按时间顺序复制全部日志，调用方需持有锁
+func (s *LogStore) snapshotLocked() []LogMessage {
+	result := make([]LogMessage, s.size)
+	for i := range result {
+		result[i] = s.entries[(s.start+i)%len(s.entries)]
+	}
+	return result
+}
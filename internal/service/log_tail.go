@@ -0,0 +1,241 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// logTailInterval 检查日志文件新内容的间隔
+const logTailInterval = 500 * time.Millisecond
+
+// logTailMaxLine 单行日志的最大长度，超过时按已读到的内容输出，避免缓存无限增长
+const logTailMaxLine = 64 * 1024
+
+// logTail 正在跟踪的日志文件
+type logTail struct {
+	path   string
+	cancel context.CancelFunc
+}
+
+// LogFileFromSettings 返回服务要跟踪的日志文件：优先使用应用设置中的路径，
+// 否则使用 frp 配置中 log.to 指定的文件；输出到控制台或配置无法读取时返回空
+func LogFileFromSettings(logFile, configPath string) string {
+	if logFile = strings.TrimSpace(logFile); logFile != "" {
+		return logFile
+	}
+	if configPath == "" || !fileExists(configPath) {
+		return ""
+	}
+	cfg, err := config.NewLoader(configPath).Load()
+	if err != nil || cfg.Log.To == "" || cfg.Log.To == "console" {
+		return ""
+	}
+	return cfg.Log.To
+}
+
+// SetLogFile 跟踪服务的日志文件，新写入的每一行与进程输出一样进入日志，
+// 用于 systemd 等外部启动、没有输出管道可读的 frps/frpc。path 为空时停止跟踪
+func (m *Manager) SetLogFile(service, path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if current := m.tails[service]; current != nil {
+		if current.path == path {
+			return
+		}
+		current.cancel()
+		delete(m.tails, service)
+	}
+	if path == "" || m.closed {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.tails[service] = &logTail{path: path, cancel: cancel}
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+		m.tailLogFile(ctx, service, path)
+	}()
+}
+
+// LogFile 返回服务正在跟踪的日志文件，未跟踪时返回空
+func (m *Manager) LogFile(service string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if tail := m.tails[service]; tail != nil {
+		return tail.path
+	}
+	return ""
+}
+
+// fileTailer 按行读取日志文件的新内容，识别按重命名和按截断两种轮转方式
+type fileTailer struct {
+	path    string
+	file    *os.File
+	info    os.FileInfo // 打开的文件，用于判断路径是否已指向轮转后的新文件
+	offset  int64
+	partial []byte // 还没有换行符的最后一行
+}
+
+// open 打开日志文件。fromEnd 为 true 时从末尾开始，只读取之后写入的内容
+func (t *fileTailer) open(fromEnd bool) error {
+	file, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	t.file, t.info, t.offset, t.partial = file, info, 0, nil
+	if fromEnd {
+		t.offset, err = file.Seek(0, io.SeekEnd)
+		if err != nil {
+			t.close()
+			return err
+		}
+	}
+	return nil
+}
+
+// close 关闭当前文件
+func (t *fileTailer) close() {
+	if t.file != nil {
+		t.file.Close()
+		t.file = nil
+	}
+}
+
+// rotated 路径是否已指向另一个文件（旧文件被重命名或删除后重新创建）
+func (t *fileTailer) rotated() bool {
+	info, err := os.Stat(t.path)
+	return err != nil || !os.SameFile(info, t.info)
+}
+
+// read 读取当前文件的新内容，返回完整的行。文件被截断时从头读取
+func (t *fileTailer) read() ([]string, error) {
+	info, err := t.file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < t.offset {
+		if _, err := t.file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		t.offset, t.partial = 0, nil
+	}
+
+	data, err := io.ReadAll(t.file)
+	t.offset += int64(len(data))
+	if err != nil {
+		return nil, err
+	}
+
+	t.partial = append(t.partial, data...)
+	var lines []string
+	for {
+		i := bytes.IndexByte(t.partial, '\n')
+		if i < 0 {
+			break
+		}
+		lines = append(lines, string(t.partial[:i]))
+		t.partial = t.partial[i+1:]
+	}
+	if len(t.partial) > logTailMaxLine {
+		lines = append(lines, string(t.partial))
+		t.partial = nil
+	}
+	return lines, nil
+}
+
+// flush 返回轮转前旧文件中没有换行符的最后一行
+func (t *fileTailer) flush() []string {
+	if len(t.partial) == 0 {
+		return nil
+	}
+	line := string(t.partial)
+	t.partial = nil
+	return []string{line}
+}
+
+// tailLogFile 跟踪日志文件直到 ctx 取消。启动时从文件末尾开始，历史内容不重复读取；
+// 文件轮转后从新文件的开头读取，文件暂时不存在时等待其创建
+func (m *Manager) tailLogFile(ctx context.Context, service, path string) {
+	tailer := &fileTailer{path: path}
+	defer tailer.close()
+
+	ticker := time.NewTicker(logTailInterval)
+	defer ticker.Stop()
+
+	fromEnd := true
+	waiting := false
+	for {
+		if tailer.file == nil {
+			// 等待中创建的文件从头读取，不遗漏最早写入的内容
+			if err := tailer.open(fromEnd && !waiting); err != nil {
+				if !waiting {
+					m.sendLog("WARN", i18n.Sprintf("无法读取日志文件 %s，等待文件创建: %v", path, err), service)
+					waiting = true
+				}
+			} else {
+				if waiting {
+					m.sendLog("INFO", i18n.Sprintf("日志文件 %s 已创建，继续跟踪", path), service)
+				} else if fromEnd {
+					m.sendLog("INFO", i18n.Sprintf("正在跟踪日志文件 %s", path), service)
+				}
+				fromEnd, waiting = false, false
+			}
+		}
+
+		if tailer.file != nil && !m.publishTailed(tailer, service) {
+			// 读取失败后重新打开时从末尾开始，避免重复已发送的内容
+			fromEnd = true
+		}
+		if tailer.file != nil {
+			// 读完旧文件剩余的内容后再切换到轮转后的新文件
+			if tailer.rotated() {
+				m.publishLines(tailer.flush(), service)
+				tailer.close()
+				fromEnd = false
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishTailed 读取并发送日志文件的新内容。读取失败时关闭文件并返回 false，下次重新打开
+func (m *Manager) publishTailed(tailer *fileTailer, service string) bool {
+	lines, err := tailer.read()
+	m.publishLines(lines, service)
+	if err != nil {
+		m.sendLog("ERROR", i18n.Sprintf("读取日志文件 %s 失败: %v", tailer.path, err), service)
+		tailer.close()
+		return false
+	}
+	return true
+}
+
+// publishLines 与进程输出一样发送日志行，级别由 frp 日志中的标记决定
+func (m *Manager) publishLines(lines []string, service string) {
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			m.sendLog("INFO", line, service)
+		}
+	}
+}
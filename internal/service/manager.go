@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"frp-cli-ui/internal/installer"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
 	"io"
 	"os"
 	"os/exec"
@@ -13,7 +15,6 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 )
 
@@ -24,8 +25,26 @@ type Manager struct {
 	clientCmd    *exec.Cmd
 	serverCancel context.CancelFunc
 	clientCancel context.CancelFunc
-	logChan      chan LogMessage
+	logs         *logBroadcaster
 	isRunning    bool
+	serverState  *ProcessState // 服务端进程状态（自己启动或重新接管的）
+	clientState  *ProcessState // 客户端进程状态（自己启动或重新接管的）
+	statePath    string
+	stoppedAt    map[string]time.Time      // 用户主动停止服务的时间，用于区分异常退出
+	usage        map[string]ResourceSample // 最近一次资源采样，由 ResourceMonitor 写入
+
+	restartPolicies map[string]RestartPolicy
+	restartHistory  map[string][]time.Time // 最近的自动重启时间
+	restartEvents   chan RestartEvent
+	events          *EventBus
+	hooks           map[string]Hooks    // 生命周期钩子
+	tails           map[string]*logTail // 正在跟踪的外部日志文件
+
+	ctx       context.Context // 管理器的生命周期，Close 后取消等待中的自动重启
+	cancel    context.CancelFunc
+	closed    bool // 已关闭，不再接受启动请求
+	closeOnce sync.Once
+	workers   sync.WaitGroup // 日志收集、进程监控和自动重启协程
 }
 
 // LogMessage 日志消息
@@ -41,234 +60,303 @@ type ProcessStatus struct {
 	IsRunning bool
 	PID       int
 	StartTime time.Time
-	CPU       float64
-	Memory    uint64
+	CPU       float64 // CPU 占用百分比，未启用资源监控时为 0
+	Memory    uint64  // 常驻内存，单位字节，未启用资源监控时为 0
 }
 
 // NewManager 创建新的进程管理器
 func NewManager() *Manager {
-	return &Manager{
-		logChan: make(chan LogMessage, 1000),
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Manager{
+		ctx:       ctx,
+		cancel:    cancel,
+		logs:      newLogBroadcaster(),
+		statePath: GetStateFilePath(),
+		stoppedAt: make(map[string]time.Time),
+		usage:     make(map[string]ResourceSample),
+
+		restartPolicies: make(map[string]RestartPolicy),
+		restartHistory:  make(map[string][]time.Time),
+		restartEvents:   make(chan RestartEvent, 20),
+		hooks:           make(map[string]Hooks),
+		tails:           make(map[string]*logTail),
+	}
+	m.reconcile()
+	return m
+}
+
+// reconcile 读取上次运行留下的状态文件，重新接管仍在运行的进程
+func (m *Manager) reconcile() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, err := loadProcessState(m.statePath)
+	if err != nil {
+		m.sendLog("ERROR", err.Error(), "server")
+		return
+	}
+
+	changed := false
+
+	if state.Server != nil {
+		if isFRPProcessAlive(state.Server.PID, "frps") {
+			m.serverState = state.Server
+			m.isRunning = true
+			m.sendLog("INFO", i18n.Sprintf("已重新接管 FRP 服务端 (PID: %d, 配置: %s)", state.Server.PID, state.Server.ConfigPath), "server")
+		} else {
+			changed = true
+		}
+	}
+
+	if state.Client != nil {
+		if isFRPProcessAlive(state.Client.PID, "frpc") {
+			m.clientState = state.Client
+			m.sendLog("INFO", i18n.Sprintf("已重新接管 FRP 客户端 (PID: %d, 配置: %s)", state.Client.PID, state.Client.ConfigPath), "client")
+		} else {
+			changed = true
+		}
+	}
+
+	// 清理已经退出的进程记录
+	if changed {
+		m.persistStateLocked()
+	}
+}
+
+// persistStateLocked 将当前进程状态写入状态文件，调用方需持有锁
+func (m *Manager) persistStateLocked() {
+	state := &processStateFile{
+		Server: m.serverState,
+		Client: m.clientState,
+	}
+	if err := saveProcessState(m.statePath, state); err != nil {
+		m.sendLog("ERROR", err.Error(), "server")
 	}
 }
 
-// StartServer 启动 FRP 服务端
+// sendLog 非阻塞地广播一条日志
+func (m *Manager) sendLog(level, message, source string) {
+	m.logs.publish(LogMessage{
+		Timestamp: time.Now(),
+		Level:     level,
+		Message:   message,
+		Source:    source,
+	})
+}
+
+// StartServer 启动 FRP 服务端，启动前执行 pre-start 钩子，启动后在后台执行 post-start 钩子
 func (m *Manager) StartServer(configPath string) error {
+	if err := m.runPreStartHook("server", configPath); err != nil {
+		return err
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if m.closed {
+		return i18n.Errorf("进程管理器已关闭")
+	}
 	if m.serverCmd != nil && m.serverCmd.Process != nil {
-		return fmt.Errorf("FRP 服务端已在运行")
+		return i18n.Errorf("FRP 服务端已在运行")
+	}
+
+	if m.serverState != nil && isFRPProcessAlive(m.serverState.PID, "frps") {
+		return i18n.Errorf("FRP 服务端已在运行 (PID: %d)", m.serverState.PID)
 	}
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return fmt.Errorf("配置文件不存在: %s", configPath)
+		return i18n.Errorf("配置文件不存在: %s", configPath)
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	m.serverCancel = cancel
 
-	frpsPath, err := m.findFRPExecutable("frps")
+	frpsPath, err := findFRPExecutable("frps")
 	if err != nil {
-		return fmt.Errorf("找不到 frps 可执行文件: %w", err)
+		return i18n.Errorf("找不到 frps 可执行文件: %w", err)
+	}
+	if mismatch := installer.DetectArchMismatch("frps", frpsPath); mismatch != nil {
+		return mismatch
 	}
 
 	m.serverCmd = exec.CommandContext(ctx, frpsPath, "-c", configPath)
+	setProcessGroup(m.serverCmd)
 
 	stdout, err := m.serverCmd.StdoutPipe()
 	if err != nil {
-		return fmt.Errorf("创建输出管道失败: %w", err)
+		return i18n.Errorf("创建输出管道失败: %w", err)
 	}
 
 	stderr, err := m.serverCmd.StderrPipe()
 	if err != nil {
-		return fmt.Errorf("创建错误管道失败: %w", err)
+		return i18n.Errorf("创建错误管道失败: %w", err)
 	}
 
 	if err := m.serverCmd.Start(); err != nil {
-		return fmt.Errorf("启动 FRP 服务端失败: %w", err)
+		return i18n.Errorf("启动 FRP 服务端失败: %w", err)
 	}
 
-	go m.collectLogs(stdout, "server", "INFO")
-	go m.collectLogs(stderr, "server", "ERROR")
-	go m.monitorProcess(m.serverCmd, "server")
+	m.startWorkersLocked(m.serverCmd, stdout, stderr, "server")
 
 	m.isRunning = true
-	m.logChan <- LogMessage{
-		Timestamp: time.Now(),
-		Level:     "INFO",
-		Message:   fmt.Sprintf("FRP 服务端启动成功 (PID: %d)", m.serverCmd.Process.Pid),
-		Source:    "server",
+	m.serverState = &ProcessState{
+		PID:        m.serverCmd.Process.Pid,
+		ConfigPath: configPath,
+		StartTime:  time.Now(),
 	}
+	m.persistStateLocked()
+	m.sendLog("INFO", i18n.Sprintf("FRP 服务端启动成功 (PID: %d)", m.serverCmd.Process.Pid), "server")
+	m.publishProcessEvent(EventProcessStarted, "server", m.serverState.PID, configPath, "")
+	m.runHookAsync("server", HookPostStart, hookEnv{pid: m.serverState.PID, configPath: configPath})
 
 	return nil
 }
 
-// StartClient 启动 FRP 客户端
+// StartClient 启动 FRP 客户端，启动前执行 pre-start 钩子，启动后在后台执行 post-start 钩子
 func (m *Manager) StartClient(configPath string) error {
+	if err := m.runPreStartHook("client", configPath); err != nil {
+		return err
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if m.closed {
+		return i18n.Errorf("进程管理器已关闭")
+	}
 	if m.clientCmd != nil && m.clientCmd.Process != nil {
-		return fmt.Errorf("FRP 客户端已在运行")
+		return i18n.Errorf("FRP 客户端已在运行")
+	}
+
+	if m.clientState != nil && isFRPProcessAlive(m.clientState.PID, "frpc") {
+		return i18n.Errorf("FRP 客户端已在运行 (PID: %d)", m.clientState.PID)
 	}
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return fmt.Errorf("配置文件不存在: %s", configPath)
+		return i18n.Errorf("配置文件不存在: %s", configPath)
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	m.clientCancel = cancel
 
-	frpcPath, err := m.findFRPExecutable("frpc")
+	frpcPath, err := findFRPExecutable("frpc")
 	if err != nil {
-		return fmt.Errorf("找不到 frpc 可执行文件: %w", err)
+		return i18n.Errorf("找不到 frpc 可执行文件: %w", err)
+	}
+	if mismatch := installer.DetectArchMismatch("frpc", frpcPath); mismatch != nil {
+		return mismatch
 	}
 
 	m.clientCmd = exec.CommandContext(ctx, frpcPath, "-c", configPath)
+	setProcessGroup(m.clientCmd)
 
 	stdout, err := m.clientCmd.StdoutPipe()
 	if err != nil {
-		return fmt.Errorf("创建输出管道失败: %w", err)
+		return i18n.Errorf("创建输出管道失败: %w", err)
 	}
 
 	stderr, err := m.clientCmd.StderrPipe()
 	if err != nil {
-		return fmt.Errorf("创建错误管道失败: %w", err)
+		return i18n.Errorf("创建错误管道失败: %w", err)
 	}
 
 	if err := m.clientCmd.Start(); err != nil {
-		return fmt.Errorf("启动 FRP 客户端失败: %w", err)
+		return i18n.Errorf("启动 FRP 客户端失败: %w", err)
 	}
 
-	go m.collectLogs(stdout, "client", "INFO")
-	go m.collectLogs(stderr, "client", "ERROR")
-	go m.monitorProcess(m.clientCmd, "client")
+	m.startWorkersLocked(m.clientCmd, stdout, stderr, "client")
 
-	m.logChan <- LogMessage{
-		Timestamp: time.Now(),
-		Level:     "INFO",
-		Message:   fmt.Sprintf("FRP 客户端启动成功 (PID: %d)", m.clientCmd.Process.Pid),
-		Source:    "client",
+	m.clientState = &ProcessState{
+		PID:        m.clientCmd.Process.Pid,
+		ConfigPath: configPath,
+		StartTime:  time.Now(),
 	}
+	m.persistStateLocked()
+
+	m.sendLog("INFO", i18n.Sprintf("FRP 客户端启动成功 (PID: %d)", m.clientCmd.Process.Pid), "client")
+	m.publishProcessEvent(EventProcessStarted, "client", m.clientState.PID, configPath, "")
+	m.runHookAsync("client", HookPostStart, hookEnv{pid: m.clientState.PID, configPath: configPath})
 
 	return nil
 }
 
-// StopServer 停止 FRP 服务端 - 支持停止外部启动的进程
+// StopServer 停止 FRP 服务端 - 支持停止外部启动的进程。停止前后分别执行 pre-stop 和 post-stop 钩子
 func (m *Manager) StopServer() error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	m.stoppedAt["server"] = time.Now()
+	proc, managed := m.takeProcessLocked("server")
+	m.mu.Unlock()
 
 	var stoppedPID int
-
-	if m.serverCmd != nil && m.serverCmd.Process != nil {
-		process := m.serverCmd.Process
-		stoppedPID = process.Pid
-
-		if m.serverCancel != nil {
-			m.serverCancel()
-			m.serverCancel = nil
-		}
-
-		if err := process.Signal(syscall.SIGTERM); err != nil {
-			if killErr := process.Kill(); killErr != nil {
-				return fmt.Errorf("强制停止进程失败: %w", killErr)
-			}
+	var configPath string
+
+	if managed {
+		// 停止本次启动或重新接管的进程，等待期间不持有锁，避免阻塞状态查询
+		stoppedPID, configPath = proc.pid, proc.configPath
+		_ = m.runHook("server", HookPreStop, hookEnv{pid: proc.pid, configPath: proc.configPath})
+		if _, err := m.terminate(proc, stopTimeout); err != nil {
+			return i18n.Errorf("停止FRP服务端失败: %w", err)
 		}
-
-		m.serverCmd.Wait()
-		m.serverCmd = nil
-		m.isRunning = false
 	} else {
 		if pid := m.findFRPProcess("frps"); pid > 0 {
 			stoppedPID = pid
+			_ = m.runHook("server", HookPreStop, hookEnv{pid: pid})
 			if err := m.killProcessByPID(pid); err != nil {
-				return fmt.Errorf("停止外部FRP服务端失败: %w", err)
+				return i18n.Errorf("停止外部FRP服务端失败: %w", err)
 			}
 		}
 	}
 
 	if stoppedPID > 0 {
-		m.logChan <- LogMessage{
-			Timestamp: time.Now(),
-			Level:     "INFO",
-			Message:   fmt.Sprintf("FRP 服务端已停止 (PID: %d)", stoppedPID),
-			Source:    "server",
-		}
+		m.sendLog("INFO", i18n.Sprintf("FRP 服务端已停止 (PID: %d)", stoppedPID), "server")
+		m.publishProcessEvent(EventProcessStopped, "server", stoppedPID, "", "")
+		_ = m.runHook("server", HookPostStop, hookEnv{pid: stoppedPID, configPath: configPath})
 	}
 
 	return nil
 }
 
-// StopClient 停止 FRP 客户端 - 支持停止外部启动的进程
+// StopClient 停止 FRP 客户端 - 支持停止外部启动的进程。停止前后分别执行 pre-stop 和 post-stop 钩子
 func (m *Manager) StopClient() error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	// 首先尝试停止自己管理的进程
-	if m.clientCmd != nil && m.clientCmd.Process != nil {
-		process := m.clientCmd.Process
-		cmd := m.clientCmd
-
-		// 取消上下文
-		if m.clientCancel != nil {
-			m.clientCancel()
-			m.clientCancel = nil
-		}
-
-		// 优雅关闭
-		if err := process.Signal(syscall.SIGTERM); err != nil {
-			// 如果优雅关闭失败，强制杀死进程
-			if killErr := process.Kill(); killErr != nil {
-				return fmt.Errorf("强制停止进程失败: %w", killErr)
-			}
+	m.stoppedAt["client"] = time.Now()
+	proc, managed := m.takeProcessLocked("client")
+	m.mu.Unlock()
+
+	// 首先尝试停止自己管理（含重新接管）的进程
+	if managed {
+		env := hookEnv{pid: proc.pid, configPath: proc.configPath}
+		_ = m.runHook("client", HookPreStop, env)
+		if _, err := m.terminate(proc, stopTimeout); err != nil {
+			return i18n.Errorf("停止 FRP 客户端进程失败: %w", err)
 		}
-
-		// 清理引用
-		m.clientCmd = nil
-
-		// 在后台等待进程结束，但不阻塞当前操作
-		go func() {
-			cmd.Wait()
-		}()
-
-		m.logChan <- LogMessage{
-			Timestamp: time.Now(),
-			Level:     "INFO",
-			Message:   "FRP 客户端已停止",
-			Source:    "client",
-		}
-
+		m.sendLog("INFO", i18n.Sprintf("FRP 客户端已停止 (PID: %d)", proc.pid), "client")
+		m.publishProcessEvent(EventProcessStopped, "client", proc.pid, "", "")
+		_ = m.runHook("client", HookPostStop, env)
 		return nil
 	}
 
 	// 如果没有自己管理的进程，尝试查找并停止外部进程
 	if pid := m.findFRPProcess("frpc"); pid > 0 {
+		_ = m.runHook("client", HookPreStop, hookEnv{pid: pid})
 		if err := m.killProcessByPID(pid); err != nil {
-			return fmt.Errorf("停止外部 FRP 客户端进程失败: %w", err)
+			return i18n.Errorf("停止外部 FRP 客户端进程失败: %w", err)
 		}
-
-		m.logChan <- LogMessage{
-			Timestamp: time.Now(),
-			Level:     "INFO",
-			Message:   fmt.Sprintf("外部 FRP 客户端进程已停止 (PID: %d)", pid),
-			Source:    "client",
-		}
-
+		m.sendLog("INFO", i18n.Sprintf("外部 FRP 客户端进程已停止 (PID: %d)", pid), "client")
+		m.publishProcessEvent(EventProcessStopped, "client", pid, "", "")
+		_ = m.runHook("client", HookPostStop, hookEnv{pid: pid})
 		return nil
 	}
 
-	return fmt.Errorf("没有找到运行中的 FRP 客户端进程")
+	return i18n.Errorf("没有找到运行中的 FRP 客户端进程")
 }
 
 // killProcessByPID 根据PID停止进程
 func (m *Manager) killProcessByPID(pid int) error {
 	switch runtime.GOOS {
 	case "windows":
-		// Windows 使用 taskkill
-		cmd := exec.Command("taskkill", "/F", "/PID", fmt.Sprintf("%d", pid))
-		return cmd.Run()
+		// Windows 使用 taskkill，同时结束子进程
+		return killProcessTree(pid)
 	case "darwin", "linux":
 		// macOS 和 Linux 使用 kill
 		cmd := exec.Command("kill", "-TERM", fmt.Sprintf("%d", pid))
@@ -279,60 +367,139 @@ func (m *Manager) killProcessByPID(pid int) error {
 		}
 		return nil
 	default:
-		return fmt.Errorf("不支持的操作系统: %s", runtime.GOOS)
+		return i18n.Errorf("不支持的操作系统: %s", runtime.GOOS)
 	}
 }
 
-// GetServerStatus 获取服务端状态 - 仅检查自己管理的进程
+// GetServerStatus 获取服务端状态 - 仅检查自己管理（含重新接管）的进程
 func (m *Manager) GetServerStatus() ProcessStatus {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	return m.withUsageLocked("server", m.processStatusLocked(m.serverCmd, m.serverState, "frps"))
+}
+
+// GetClientStatus 获取客户端状态 - 仅检查自己管理（含重新接管）的进程
+func (m *Manager) GetClientStatus() ProcessStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.withUsageLocked("client", m.processStatusLocked(m.clientCmd, m.clientState, "frpc"))
+}
+
+// withUsageLocked 填入同一进程最近一次的资源采样，调用方需持有锁
+func (m *Manager) withUsageLocked(service string, status ProcessStatus) ProcessStatus {
+	if usage, ok := m.usage[service]; ok && status.IsRunning && usage.PID == status.PID {
+		status.CPU = usage.CPU
+		status.Memory = usage.Memory
+	}
+	return status
+}
+
+// setResourceUsage 记录进程最近一次的资源采样，PID 为 0 表示进程已停止
+func (m *Manager) setResourceUsage(service string, sample ResourceSample) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if sample.PID == 0 {
+		delete(m.usage, service)
+		return
+	}
+	m.usage[service] = sample
+}
+
+// processStatusLocked 根据进程句柄和持久化状态计算进程状态，调用方需持有锁
+func (m *Manager) processStatusLocked(cmd *exec.Cmd, state *ProcessState, processName string) ProcessStatus {
 	// 只检查自己管理的进程，避免受外部进程干扰
-	if m.serverCmd != nil && m.serverCmd.Process != nil {
+	if cmd != nil && cmd.Process != nil {
+		status := ProcessStatus{
+			IsRunning: true,
+			PID:       cmd.Process.Pid,
+		}
+		if state != nil {
+			status.StartTime = state.StartTime
+		}
+		return status
+	}
+
+	// 重新接管的进程没有 exec.Cmd，需要实时确认其仍然存活
+	if state != nil && isFRPProcessAlive(state.PID, processName) {
 		return ProcessStatus{
 			IsRunning: true,
-			PID:       m.serverCmd.Process.Pid,
-			StartTime: time.Now(), // 这里应该记录实际启动时间
+			PID:       state.PID,
+			StartTime: state.StartTime,
 		}
 	}
 
 	return ProcessStatus{IsRunning: false}
 }
 
-// GetClientStatus 获取客户端状态 - 仅检查自己管理的进程
-func (m *Manager) GetClientStatus() ProcessStatus {
+// GetProcessState 获取服务的持久化进程状态，未运行时返回 nil
+func (m *Manager) GetProcessState(service string) *ProcessState {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	// 只检查自己管理的进程，避免受外部进程干扰
-	if m.clientCmd != nil && m.clientCmd.Process != nil {
-		return ProcessStatus{
-			IsRunning: true,
-			PID:       m.clientCmd.Process.Pid,
-			StartTime: time.Now(), // 这里应该记录实际启动时间
-		}
+	var state *ProcessState
+	switch service {
+	case "server":
+		state = m.serverState
+	case "client":
+		state = m.clientState
+	}
+	if state == nil {
+		return nil
 	}
 
-	return ProcessStatus{IsRunning: false}
+	stateCopy := *state
+	return &stateCopy
+}
+
+// StoppedAt 返回用户最近一次主动停止服务的时间，从未停止时返回零值
+func (m *Manager) StoppedAt(service string) time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.stoppedAt[service]
+}
+
+// SetEventBus 设置事件总线，进程启动、停止和崩溃时发布事件，需在启动服务前调用
+func (m *Manager) SetEventBus(bus *EventBus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = bus
 }
 
-// GetLogChannel 获取日志通道
-func (m *Manager) GetLogChannel() <-chan LogMessage {
-	return m.logChan
+// publishProcessEvent 发布进程生命周期事件，reason 为崩溃原因
+func (m *Manager) publishProcessEvent(eventType, service string, pid int, configPath, reason string) {
+	name := serviceDisplayName(service)
+	event := Event{
+		Type:    eventType,
+		Source:  service,
+		Message: fmt.Sprintf("PID: %d", pid),
+		Data:    map[string]string{"pid": strconv.Itoa(pid)},
+	}
+	switch eventType {
+	case EventProcessStarted:
+		event.Title = i18n.Sprintf("%s 已启动", name)
+	case EventProcessCrashed:
+		event.Title = i18n.Sprintf("%s 异常退出", name)
+		event.Message = reason
+		event.Data["reason"] = reason
+	default:
+		event.Title = i18n.Sprintf("%s 已停止", name)
+	}
+	if configPath != "" {
+		event.Data["config"] = configPath
+	}
+	m.events.Publish(event)
 }
 
 // findFRPExecutable 查找 FRP 可执行文件
-func (m *Manager) findFRPExecutable(name string) (string, error) {
-	// 首先尝试使用安装器查找
-	inst := installer.NewInstaller("")
-	status, err := inst.CheckInstallation()
-	if err == nil && status.IsInstalled {
-		if name == "frps" && status.FrpsPath != "" {
-			return status.FrpsPath, nil
-		}
-		if name == "frpc" && status.FrpcPath != "" {
-			return status.FrpcPath, nil
+func findFRPExecutable(name string) (string, error) {
+	// 首先使用设置中记录的安装目录，未记录时为默认安装目录；其中按版本安装时使用固定的版本或 current 指向的版本
+	settings, _ := config.LoadAppSettings()
+	installDir := installer.InstallDirFromSettings(settings)
+	for _, dir := range []string{installer.ServiceBinDir(settings, name), installDir} {
+		if path := installer.ExecutablePath(dir, name); fileExists(path) {
+			return path, nil
 		}
 	}
 
@@ -351,80 +518,61 @@ func (m *Manager) findFRPExecutable(name string) (string, error) {
 		return localPath, nil
 	}
 
-	// 在常见位置查找
-	commonPaths := []string{
-		"/usr/local/bin/" + name,
-		"/usr/bin/" + name,
-		"/opt/frp/" + name,
-		"./bin/" + name,
-	}
-
-	if runtime.GOOS == "windows" {
-		for i, path := range commonPaths {
-			commonPaths[i] = path + ".exe"
-		}
-	}
-
-	for _, path := range commonPaths {
-		if _, err := os.Stat(path); err == nil {
+	// 在包管理器和手动安装的常见位置查找
+	for _, dir := range append(installer.CommonDirs(), "./bin") {
+		if path := installer.ExecutablePath(dir, name); fileExists(path) {
 			return path, nil
 		}
 	}
 
-	return "", fmt.Errorf("找不到 %s 可执行文件", name)
+	return "", i18n.Errorf("找不到 %s 可执行文件", name)
 }
 
-// collectLogs 收集进程日志
-func (m *Manager) collectLogs(reader io.Reader, source, level string) {
-	// 只有INFO级别的日志收集器在结束时发送停止消息，避免重复
-	defer func() {
-		if level == "INFO" {
-			// 当日志收集结束时，发送一条信息
-			select {
-			case m.logChan <- LogMessage{
-				Timestamp: time.Now(),
-				Level:     "DEBUG",
-				Message:   fmt.Sprintf("%s 日志收集已停止", source),
-				Source:    source,
-			}:
-			default:
-				// 如果通道已关闭，忽略错误
-			}
-		}
-	}()
+// fileExists 判断路径是否为已存在的普通文件
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
 
+// collectLogs 收集进程日志，一直读到输出结束，避免进程因管道写满而阻塞
+func (m *Manager) collectLogs(reader io.Reader, source, level string) {
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line != "" {
-			// 尝试发送日志到通道，如果通道已关闭则退出
-			select {
-			case m.logChan <- LogMessage{
-				Timestamp: time.Now(),
-				Level:     level,
-				Message:   line,
-				Source:    source,
-			}:
-			default:
-				// 通道可能已满或关闭，退出
-				return
-			}
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			m.sendLog(level, line, source)
 		}
 	}
 
-	// 检查scanner错误
 	if err := scanner.Err(); err != nil && err != io.EOF {
-		select {
-		case m.logChan <- LogMessage{
-			Timestamp: time.Now(),
-			Level:     "ERROR",
-			Message:   fmt.Sprintf("日志扫描错误: %v", err),
-			Source:    source,
-		}:
-		default:
-			// 如果通道已关闭，忽略错误
-		}
+		m.sendLog("ERROR", i18n.Sprintf("日志扫描错误: %v", err), source)
+	}
+	// 只有 INFO 级别的日志收集器在结束时发送停止消息，避免重复
+	if level == "INFO" {
+		m.sendLog("DEBUG", i18n.Sprintf("%s 日志收集已停止", source), source)
+	}
+}
+
+// startWorkersLocked 启动进程的日志收集和监控协程。监控协程等日志读完后再回收进程，
+// 既符合 exec.Cmd 的要求，也保证退出信息排在进程的最后一条输出之后。调用方需持有锁
+func (m *Manager) startWorkersLocked(cmd *exec.Cmd, stdout, stderr io.Reader, source string) {
+	var collectors sync.WaitGroup
+	collectors.Add(2)
+	m.workers.Add(3)
+	for _, output := range []struct {
+		reader io.Reader
+		level  string
+	}{{stdout, "INFO"}, {stderr, "ERROR"}} {
+		go func() {
+			defer m.workers.Done()
+			defer collectors.Done()
+			m.collectLogs(output.reader, source, output.level)
+		}()
 	}
+	go func() {
+		defer m.workers.Done()
+		collectors.Wait()
+		m.monitorProcess(cmd, source)
+	}()
 }
 
 // monitorProcess 监控进程状态
@@ -436,41 +584,54 @@ func (m *Manager) monitorProcess(cmd *exec.Cmd, source string) {
 
 	// 检查命令是否还存在（可能已被清理）
 	var shouldLog bool
+	var configPath string
+	pid := cmd.Process.Pid
 	if source == "server" && m.serverCmd == cmd {
+		if m.serverState != nil {
+			configPath = m.serverState.ConfigPath
+		}
 		m.serverCmd = nil
+		m.serverState = nil
 		shouldLog = true
 	} else if source == "client" && m.clientCmd == cmd {
+		if m.clientState != nil {
+			configPath = m.clientState.ConfigPath
+		}
 		m.clientCmd = nil
+		m.clientState = nil
 		shouldLog = true
 	}
 
+	if shouldLog {
+		m.persistStateLocked()
+	}
+
 	// 只有当命令仍然有效时才记录退出信息
 	if shouldLog {
 		if err != nil {
 			// 检查是否是被取消的上下文（正常停止）
 			if strings.Contains(err.Error(), "signal: terminated") ||
 				strings.Contains(err.Error(), "context canceled") {
-				m.logChan <- LogMessage{
-					Timestamp: time.Now(),
-					Level:     "INFO",
-					Message:   fmt.Sprintf("%s 进程已正常停止", source),
-					Source:    source,
-				}
+				m.sendLog("INFO", i18n.Sprintf("%s 进程已正常停止", source), source)
+				m.publishProcessEvent(EventProcessStopped, source, pid, configPath, "")
+				m.runHookAsync(source, HookPostStop, hookEnv{pid: pid, configPath: configPath})
 			} else {
-				m.logChan <- LogMessage{
-					Timestamp: time.Now(),
-					Level:     "ERROR",
-					Message:   fmt.Sprintf("进程异常退出: %v", err),
-					Source:    source,
-				}
+				m.sendLog("ERROR", i18n.Sprintf("进程异常退出: %v", err), source)
+				m.publishProcessEvent(EventProcessCrashed, source, pid, configPath, err.Error())
+				// 主动停止时进程句柄已被清理，走到这里说明是崩溃，执行 on-crash 钩子后按策略自动重启
+				exitedAt := time.Now()
+				reason := err.Error()
+				m.workers.Add(1)
+				go func() {
+					defer m.workers.Done()
+					_ = m.runHook(source, HookOnCrash, hookEnv{pid: pid, configPath: configPath, reason: reason})
+					m.autoRestart(source, configPath, exitedAt)
+				}()
 			}
 		} else {
-			m.logChan <- LogMessage{
-				Timestamp: time.Now(),
-				Level:     "INFO",
-				Message:   fmt.Sprintf("%s 进程正常退出", source),
-				Source:    source,
-			}
+			m.sendLog("INFO", i18n.Sprintf("%s 进程正常退出", source), source)
+			m.publishProcessEvent(EventProcessStopped, source, pid, configPath, "")
+			m.runHookAsync(source, HookPostStop, hookEnv{pid: pid, configPath: configPath})
 		}
 	}
 }
@@ -480,41 +641,51 @@ func (m *Manager) Restart(service, configPath string) error {
 	switch service {
 	case "server":
 		if err := m.StopServer(); err != nil {
-			return fmt.Errorf("停止服务端失败: %w", err)
+			return i18n.Errorf("停止服务端失败: %w", err)
 		}
 		// 等待进程完全停止
 		time.Sleep(2 * time.Second)
 		return m.StartServer(configPath)
 	case "client":
 		if err := m.StopClient(); err != nil {
-			return fmt.Errorf("停止客户端失败: %w", err)
+			return i18n.Errorf("停止客户端失败: %w", err)
 		}
 		// 等待进程完全停止
 		time.Sleep(2 * time.Second)
 		return m.StartClient(configPath)
 	default:
-		return fmt.Errorf("未知的服务类型: %s", service)
+		return i18n.Errorf("未知的服务类型: %s", service)
 	}
 }
 
-// Close 关闭管理器
-func (m *Manager) Close() error {
-	var errs []error
-
-	if err := m.StopServer(); err != nil {
-		errs = append(errs, err)
-	}
-
-	if err := m.StopClient(); err != nil {
-		errs = append(errs, err)
-	}
-
-	close(m.logChan)
-
-	if len(errs) > 0 {
-		return fmt.Errorf("关闭时发生错误: %v", errs)
-	}
+// closeDrainTimeout 关闭管理器时等待日志收集和监控协程结束的最长时间
+const closeDrainTimeout = 3 * time.Second
 
+// Close 结束管理器的生命周期：取消等待中的自动重启、拒绝新的启动请求，
+// 等已退出进程的日志收集协程读完剩余输出后关闭所有日志订阅。可以重复调用，始终返回 nil。
+// Close 不会停止进程，退出时需要停止本工具启动的 frps/frpc 应先调用 Shutdown；
+// 仍在运行的进程的输出不会结束，此时不等待其协程
+func (m *Manager) Close() error {
+	m.closeOnce.Do(func() {
+		m.mu.Lock()
+		m.closed = true
+		m.cancel()
+		running := m.serverCmd != nil || m.clientCmd != nil
+		m.mu.Unlock()
+
+		if !running {
+			done := make(chan struct{})
+			go func() {
+				m.workers.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(closeDrainTimeout):
+			}
+		}
+		m.logs.close()
+	})
 	return nil
 }
 
@@ -0,0 +1,498 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 告警级别
+const (
+	AlertCritical = "critical" // 进程退出等需要立即处理的故障
+	AlertWarning  = "warning"  // 代理离线、接口不可达等
+)
+
+// Alert 健康检查告警，Resolved 为 true 表示之前的告警已恢复
+type Alert struct {
+	Key      string    `json:"key"`
+	Severity string    `json:"severity"`
+	Title    string    `json:"title"`
+	Message  string    `json:"message"`
+	Time     time.Time `json:"time"`
+	Resolved bool      `json:"resolved"`
+}
+
+// MonitorOptions 健康监控选项
+type MonitorOptions struct {
+	Interval         time.Duration // 检查间隔
+	DesktopNotify    bool          // 是否发送桌面通知
+	WebhookURL       string        // 告警 Webhook 地址，留空不发送
+	ClientConfigPath string        // 客户端配置，用于访问 frpc 管理接口
+}
+
+// MonitorOptionsFromSettings 根据应用设置生成健康监控选项
+func MonitorOptionsFromSettings(settings *config.AppSettings) MonitorOptions {
+	return MonitorOptions{
+		Interval:         settings.MonitorDuration(),
+		DesktopNotify:    settings.DesktopNotify,
+		WebhookURL:       settings.AlertWebhookURL,
+		ClientConfigPath: settings.ClientConfigPath,
+	}
+}
+
+// problem 一次检查发现的问题
+type problem struct {
+	severity string
+	title    string
+	message  string
+}
+
+// HealthMonitor 在后台定期检查进程存活、frps 代理状态和 frpc 代理连接，
+// 状态变化时发出告警和恢复通知
+type HealthMonitor struct {
+	manager   *Manager
+	apiClient *APIClient
+	notifier  *Notifier
+	alerts    chan Alert
+	events    *EventBus
+	latency   *LatencyMonitor
+	quotas    *QuotaTracker
+
+	mu        sync.Mutex
+	options   MonitorOptions
+	active    map[string]Alert
+	dismissed []string
+
+	// 以下状态只在监控 goroutine 中访问
+	lastRunning  map[string]time.Time
+	proxyOnline  map[string]bool
+	proxyStatus  map[string]string // 上一轮看到的代理状态，用于发布上下线事件
+	apiReachable bool
+	proxyTarget  string               // 上一轮检查的 Dashboard 目标，切换目标后重新记录代理状态
+	latencyMuted bool                 // 已忽略延迟告警，延迟恢复正常前不再告警
+	quotaLevel   map[string]int       // 各配额上一轮的告警级别，级别升高时发布事件
+	quotaMuted   map[string]int       // 已忽略的配额告警级别，用量升到更高级别或进入新周期前不再告警
+	quotaStopped map[string]time.Time // 已自动停止的配额及其周期开始时间
+	cancel       context.CancelFunc
+	optionsReady chan struct{}
+}
+
+// NewHealthMonitor 创建健康监控
+func NewHealthMonitor(manager *Manager, apiClient *APIClient, options MonitorOptions) *HealthMonitor {
+	return &HealthMonitor{
+		manager:      manager,
+		apiClient:    apiClient,
+		notifier:     NewNotifier(),
+		alerts:       make(chan Alert, 100),
+		options:      options,
+		active:       make(map[string]Alert),
+		lastRunning:  make(map[string]time.Time),
+		proxyOnline:  make(map[string]bool),
+		quotaLevel:   make(map[string]int),
+		quotaMuted:   make(map[string]int),
+		quotaStopped: make(map[string]time.Time),
+		optionsReady: make(chan struct{}, 1),
+	}
+}
+
+// SetOptions 更新监控选项，下一轮检查生效
+func (hm *HealthMonitor) SetOptions(options MonitorOptions) {
+	hm.mu.Lock()
+	hm.options = options
+	hm.mu.Unlock()
+
+	// 唤醒监控 goroutine 以使用新的检查间隔
+	select {
+	case hm.optionsReady <- struct{}{}:
+	default:
+	}
+}
+
+// currentOptions 返回当前监控选项
+func (hm *HealthMonitor) currentOptions() MonitorOptions {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	return hm.options
+}
+
+// SetEventBus 设置事件总线，代理上线、离线时发布事件，需在 Start 之前调用
+func (hm *HealthMonitor) SetEventBus(bus *EventBus) {
+	hm.events = bus
+}
+
+// SetLatencyMonitor 设置延迟监控，延迟持续超过阈值时发出告警，需在 Start 之前调用
+func (hm *HealthMonitor) SetLatencyMonitor(latency *LatencyMonitor) {
+	hm.latency = latency
+}
+
+// Alerts 告警通道，界面从中读取告警并显示横幅
+func (hm *HealthMonitor) Alerts() <-chan Alert {
+	return hm.alerts
+}
+
+// ActiveAlerts 返回尚未恢复的告警，按时间排序
+func (hm *HealthMonitor) ActiveAlerts() []Alert {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	alerts := make([]Alert, 0, len(hm.active))
+	for _, alert := range hm.active {
+		alerts = append(alerts, alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Time.Before(alerts[j].Time) })
+	return alerts
+}
+
+// Dismiss 忽略当前所有告警：已退出的进程和已离线的代理不再视为故障，直到它们再次运行或上线
+func (hm *HealthMonitor) Dismiss() {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	for key := range hm.active {
+		hm.dismissed = append(hm.dismissed, key)
+	}
+	hm.active = make(map[string]Alert)
+}
+
+// applyDismissed 清除被忽略告警对应的基线状态
+func (hm *HealthMonitor) applyDismissed() {
+	hm.mu.Lock()
+	dismissed := hm.dismissed
+	hm.dismissed = nil
+	hm.mu.Unlock()
+
+	for _, key := range dismissed {
+		kind, name, _ := strings.Cut(key, ":")
+		switch kind {
+		case "process":
+			delete(hm.lastRunning, name)
+		case "proxy":
+			delete(hm.proxyOnline, name)
+		case "server":
+			hm.apiReachable = false
+		case "latency":
+			hm.latencyMuted = true
+		case "quota":
+			hm.muteQuota(name)
+		}
+	}
+}
+
+// Start 启动监控 goroutine
+func (hm *HealthMonitor) Start() {
+	if hm.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	hm.cancel = cancel
+	go hm.run(ctx)
+}
+
+// Stop 停止监控
+func (hm *HealthMonitor) Stop() {
+	if hm.cancel != nil {
+		hm.cancel()
+		hm.cancel = nil
+	}
+}
+
+// run 监控循环
+func (hm *HealthMonitor) run(ctx context.Context) {
+	for {
+		interval := hm.currentOptions().Interval
+		if interval <= 0 {
+			interval = 15 * time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-hm.optionsReady:
+			continue
+		case <-time.After(interval):
+		}
+
+		hm.Check()
+	}
+}
+
+// Check 执行一轮健康检查，并根据与上一轮的差异发出告警或恢复通知
+func (hm *HealthMonitor) Check() {
+	hm.applyDismissed()
+
+	options := hm.currentOptions()
+	problems := make(map[string]problem)
+
+	hm.checkProcess("server", "frps", hm.manager.GetServerStatus(), problems)
+	hm.checkProcess("client", "frpc", hm.manager.GetClientStatus(), problems)
+	hm.checkServerProxies(problems)
+	hm.checkLatency(problems)
+	hm.checkQuotas(options, problems)
+	if hm.manager.GetClientStatus().IsRunning {
+		hm.checkClientProxies(options, problems)
+	}
+
+	hm.reconcile(problems, options)
+}
+
+// checkProcess 进程之前在运行、现在不在且不是用户主动停止时视为异常退出，
+// 告警一直保持到进程重新运行
+func (hm *HealthMonitor) checkProcess(source, name string, status ProcessStatus, problems map[string]problem) {
+	if status.IsRunning {
+		hm.lastRunning[source] = time.Now()
+		return
+	}
+
+	lastRunning, seen := hm.lastRunning[source]
+	if !seen {
+		return
+	}
+	if hm.manager.StoppedAt(source).After(lastRunning) {
+		delete(hm.lastRunning, source)
+		return
+	}
+
+	problems["process:"+source] = problem{
+		severity: AlertCritical,
+		title:    i18n.Sprintf("%s 进程已退出", name),
+		message:  i18n.Sprintf("%s 已意外停止运行，隧道不可用", name),
+	}
+}
+
+// checkServerProxies 检查 frps 上的代理，之前在线的代理离线或消失时告警
+func (hm *HealthMonitor) checkServerProxies(problems map[string]problem) {
+	// 切换到另一台 frps 时，旧服务器上的代理不应被当作离线
+	if target := hm.apiClient.TargetName(); target != hm.proxyTarget {
+		hm.proxyTarget = target
+		hm.proxyOnline = make(map[string]bool)
+		hm.proxyStatus = nil
+		hm.apiReachable = false
+	}
+
+	if _, err := hm.apiClient.GetServerInfo(); err != nil {
+		// 只有之前能访问的 Dashboard 变得不可达才告警，frps 未运行或未配置时不打扰
+		if hm.apiReachable {
+			problems["server:api"] = problem{
+				severity: AlertWarning,
+				title:    i18n.T("frps Dashboard 不可达"),
+				message:  err.Error(),
+			}
+		}
+		// 无法确认代理状态，保持已有的代理告警
+		hm.keepActive("proxy:", problems)
+		return
+	}
+	hm.apiReachable = true
+
+	proxies, err := hm.apiClient.GetProxyList()
+	if err != nil {
+		return
+	}
+
+	hm.publishProxyEvents(proxies)
+
+	seen := make(map[string]bool, len(proxies))
+	for _, proxy := range proxies {
+		seen[proxy.Name] = true
+		online := proxy.Status == "online"
+		if online {
+			hm.proxyOnline[proxy.Name] = true
+			continue
+		}
+		if hm.proxyOnline[proxy.Name] {
+			problems["proxy:"+proxy.Name] = problem{
+				severity: AlertWarning,
+				title:    i18n.Sprintf("代理 %s 已离线", proxy.Name),
+				message:  i18n.Sprintf("frps 报告代理状态为 %s", proxy.Status),
+			}
+		}
+	}
+
+	for name := range hm.proxyOnline {
+		if !seen[name] {
+			problems["proxy:"+name] = problem{
+				severity: AlertWarning,
+				title:    i18n.Sprintf("代理 %s 已离线", name),
+				message:  i18n.T("代理已从 frps 上消失，客户端可能已断开"),
+			}
+		}
+	}
+}
+
+// publishProxyEvents 对比上一轮的代理状态，发布上线和离线事件。
+// 第一轮只记录状态，避免启动时把所有在线代理都当作新上线
+func (hm *HealthMonitor) publishProxyEvents(proxies []ProxyInfo) {
+	if hm.events == nil {
+		return
+	}
+
+	first := hm.proxyStatus == nil
+	current := make(map[string]string, len(proxies))
+	for _, proxy := range proxies {
+		current[proxy.Name] = proxy.Status
+		if first {
+			continue
+		}
+
+		previous, existed := hm.proxyStatus[proxy.Name]
+		online := proxy.Status == "online"
+		switch {
+		case online && (!existed || previous != "online"):
+			hm.events.Publish(Event{
+				Type:    EventProxyOnline,
+				Source:  proxy.Name,
+				Title:   i18n.Sprintf("代理 %s 已上线", proxy.Name),
+				Message: i18n.Sprintf("类型: %s", proxy.Conf.Type),
+				Data:    map[string]string{"proxy": proxy.Name, "type": proxy.Conf.Type, "status": proxy.Status},
+			})
+		case !online && existed && previous == "online":
+			hm.events.Publish(Event{
+				Type:    EventProxyOffline,
+				Source:  proxy.Name,
+				Title:   i18n.Sprintf("代理 %s 已离线", proxy.Name),
+				Message: i18n.Sprintf("frps 报告代理状态为 %s", proxy.Status),
+				Data:    map[string]string{"proxy": proxy.Name, "type": proxy.Conf.Type, "status": proxy.Status},
+			})
+		}
+	}
+
+	for name, previous := range hm.proxyStatus {
+		if _, ok := current[name]; !ok && previous == "online" {
+			hm.events.Publish(Event{
+				Type:    EventProxyOffline,
+				Source:  name,
+				Title:   i18n.Sprintf("代理 %s 已离线", name),
+				Message: i18n.T("代理已从 frps 上消失，客户端可能已断开"),
+				Data:    map[string]string{"proxy": name},
+			})
+		}
+	}
+	hm.proxyStatus = current
+}
+
+// checkLatency 延迟监控处于告警状态时告警，延迟恢复正常后发出恢复通知
+func (hm *HealthMonitor) checkLatency(problems map[string]problem) {
+	if hm.latency == nil {
+		return
+	}
+
+	warning := hm.latency.Warning()
+	if warning == "" {
+		hm.latencyMuted = false
+		return
+	}
+	if !hm.latencyMuted {
+		problems["latency:server"] = problem{
+			severity: AlertWarning,
+			title:    i18n.T("到 frps 的延迟过高"),
+			message:  warning,
+		}
+	}
+}
+
+// checkClientProxies 通过 frpc 管理接口检查客户端连接和各代理状态
+func (hm *HealthMonitor) checkClientProxies(options MonitorOptions, problems map[string]problem) {
+	configPath := options.ClientConfigPath
+	if state := hm.manager.GetProcessState("client"); state != nil && state.ConfigPath != "" {
+		configPath = state.ConfigPath
+	}
+	if configPath == "" {
+		return
+	}
+
+	cfg, err := config.NewLoader(configPath).Load()
+	if err != nil {
+		return
+	}
+	client, err := NewClientAPIClientFromConfig(cfg)
+	if err != nil {
+		// 未开启管理接口时无法获取代理状态
+		return
+	}
+
+	status, err := client.GetStatus()
+	if err != nil {
+		problems["client:api"] = problem{
+			severity: AlertWarning,
+			title:    i18n.T("frpc 管理接口不可达"),
+			message:  err.Error(),
+		}
+		hm.keepActive("client-proxy:", problems)
+		return
+	}
+
+	for _, proxies := range status {
+		for _, proxy := range proxies {
+			if proxy.Status == "running" {
+				continue
+			}
+			message := i18n.Sprintf("状态: %s", proxy.Status)
+			if proxy.Err != "" {
+				message += i18n.T("，错误: ") + proxy.Err
+			}
+			problems["client-proxy:"+proxy.Name] = problem{
+				severity: AlertWarning,
+				title:    i18n.Sprintf("客户端代理 %s 未连接", proxy.Name),
+				message:  message,
+			}
+		}
+	}
+}
+
+// keepActive 本轮无法检查时，保留指定前缀的活跃告警，避免误报恢复
+func (hm *HealthMonitor) keepActive(prefix string, problems map[string]problem) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	for key, alert := range hm.active {
+		if strings.HasPrefix(key, prefix) {
+			problems[key] = problem{severity: alert.Severity, title: alert.Title, message: alert.Message}
+		}
+	}
+}
+
+// reconcile 比较本轮问题与活跃告警，新问题发告警，消失的问题发恢复通知
+func (hm *HealthMonitor) reconcile(problems map[string]problem, options MonitorOptions) {
+	now := time.Now()
+	var events []Alert
+
+	hm.mu.Lock()
+	for key, p := range problems {
+		if _, exists := hm.active[key]; exists {
+			continue
+		}
+		alert := Alert{Key: key, Severity: p.severity, Title: p.title, Message: p.message, Time: now}
+		hm.active[key] = alert
+		events = append(events, alert)
+	}
+	for key, alert := range hm.active {
+		if _, still := problems[key]; still {
+			continue
+		}
+		delete(hm.active, key)
+		events = append(events, Alert{
+			Key:      key,
+			Severity: alert.Severity,
+			Title:    alert.Title + i18n.T("，已恢复"),
+			Time:     now,
+			Resolved: true,
+		})
+	}
+	hm.mu.Unlock()
+
+	for _, alert := range events {
+		select {
+		case hm.alerts <- alert:
+		default:
+		}
+		if err := hm.notifier.Notify(alert, options); err != nil {
+			hm.manager.sendLog("WARN", err.Error(), "server")
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"strconv"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// DefaultSTUNServers NAT 检测使用的 STUN 服务器，第一个是 frpc natHoleStunServer 的默认值
+var DefaultSTUNServers = []string{
+	"stun.easyvoip.com:3478",
+	"stun.l.google.com:19302",
+	"stun.cloudflare.com:3478",
+}
+
+// STUN 协议常量（RFC 5389）
+const (
+	stunBindingRequest  = 0x0001
+	stunBindingResponse = 0x0101
+	stunMagicCookie     = 0x2112A442
+	stunAttrMapped      = 0x0001
+	stunAttrXorMapped   = 0x0020
+	stunHeaderSize      = 20
+	stunRequestTimeout  = 2 * time.Second
+)
+
+// NATType 本机所在网络的 NAT 类型，按映射行为划分
+type NATType string
+
+const (
+	NATNone      NATType = "none"      // 本机直接拥有公网地址
+	NATCone      NATType = "cone"      // 与目标无关的映射（锥形 NAT），同一本地端口对所有目标使用同一个公网端口
+	NATSymmetric NATType = "symmetric" // 与目标相关的映射（对称型 NAT），每个目标分配不同的公网端口
+	NATUnknown   NATType = "unknown"   // 可用的 STUN 服务器不足两个，无法判断
+)
+
+// STUNResult 一个 STUN 服务器的检测结果
+type STUNResult struct {
+	Server string
+	Mapped string // 服务器看到的公网地址
+	Err    error
+}
+
+// NATReport NAT 检测结果
+type NATReport struct {
+	LocalAddr string
+	Results   []STUNResult
+	PublicIP  string
+	Type      NATType
+	PortDelta int  // 对称型 NAT 时两次映射的端口差，较小时端口分配可预测
+	Preserved bool // 公网端口与本地端口相同
+}
+
+// Likelihood 返回 xtcp 打洞成功可能性的说明和建议
+func (r *NATReport) Likelihood() string {
+	switch r.Type {
+	case NATNone:
+		return i18n.T("本机有公网地址，对端只要能发出 UDP 就能直连，打洞成功率很高")
+	case NATCone:
+		return i18n.T("锥形 NAT：与锥形 NAT 的对端打洞成功率高，对端为对称型 NAT 时也有较大机会成功")
+	case NATSymmetric:
+		if r.PortDelta != 0 && abs(r.PortDelta) <= 10 {
+			return i18n.T("对称型 NAT，但端口按顺序分配：对端为锥形 NAT 时 frp 可以预测端口，有一定机会成功；两端都是对称型时基本无法打洞，建议在访问者中设置 fallbackTo 回退到 stcp")
+		}
+		return i18n.T("对称型 NAT，端口随机分配：只有对端为锥形 NAT 或公网地址时才可能成功，两端都是对称型时无法打洞，建议在访问者中设置 fallbackTo 回退到 stcp")
+	default:
+		return i18n.T("可用的 STUN 服务器不足两个，无法判断 NAT 类型，请检查 UDP 出站是否被防火墙拦截")
+	}
+}
+
+// DetectNAT 从同一个本地 UDP 端口依次向多个 STUN 服务器发送绑定请求，
+// 比较各服务器看到的公网地址判断 NAT 的映射行为，与 frpc nathole discover 的方法相同
+func DetectNAT(ctx context.Context, servers []string) (*NATReport, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return nil, i18n.Errorf("创建 UDP 套接字失败: %w", err)
+	}
+	defer conn.Close()
+
+	report := &NATReport{Type: NATUnknown}
+	localPort := conn.LocalAddr().(*net.UDPAddr).Port
+	var mapped []*net.UDPAddr
+	for _, server := range servers {
+		if ctx.Err() != nil {
+			break
+		}
+		addr, err := stunBinding(ctx, conn, server)
+		result := STUNResult{Server: server, Err: err}
+		if err == nil {
+			result.Mapped = addr.String()
+			mapped = append(mapped, addr)
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	// 本机出口地址，用于判断是否在 NAT 之后
+	if probe, err := net.Dial("udp4", "192.0.2.1:9"); err == nil {
+		report.LocalAddr = net.JoinHostPort(probe.LocalAddr().(*net.UDPAddr).IP.String(), strconv.Itoa(localPort))
+		probe.Close()
+	}
+
+	if len(mapped) == 0 {
+		return report, i18n.Errorf("所有 STUN 服务器都没有响应，UDP 出站可能被防火墙拦截")
+	}
+	report.PublicIP = mapped[0].IP.String()
+	report.Preserved = mapped[0].Port == localPort
+
+	localIP, _, _ := net.SplitHostPort(report.LocalAddr)
+	switch {
+	case localIP == report.PublicIP:
+		report.Type = NATNone
+	case len(mapped) < 2:
+		report.Type = NATUnknown
+	default:
+		report.Type = NATCone
+		for _, addr := range mapped[1:] {
+			if !addr.IP.Equal(mapped[0].IP) || addr.Port != mapped[0].Port {
+				report.Type = NATSymmetric
+				report.PortDelta = addr.Port - mapped[0].Port
+				break
+			}
+		}
+	}
+	return report, nil
+}
+
+// stunBinding 发送一次 STUN 绑定请求并返回映射地址
+func stunBinding(ctx context.Context, conn *net.UDPConn, server string) (*net.UDPAddr, error) {
+	raddr, err := net.ResolveUDPAddr("udp4", server)
+	if err != nil {
+		return nil, err
+	}
+
+	request := make([]byte, stunHeaderSize)
+	binary.BigEndian.PutUint16(request[0:], stunBindingRequest)
+	binary.BigEndian.PutUint32(request[4:], stunMagicCookie)
+	txID := request[8:20]
+	if _, err := rand.Read(txID); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(stunRequestTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+	defer conn.SetDeadline(time.Time{})
+
+	if _, err := conn.WriteToUDP(request, raddr); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 1024)
+	for {
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			return nil, err
+		}
+		// 忽略其他服务器迟到的响应
+		if !from.IP.Equal(raddr.IP) || n < stunHeaderSize || string(buf[8:20]) != string(txID) {
+			continue
+		}
+		if binary.BigEndian.Uint16(buf[0:]) != stunBindingResponse {
+			return nil, i18n.Errorf("STUN 服务器返回错误响应")
+		}
+		return parseSTUNMappedAddress(buf[stunHeaderSize:n])
+	}
+}
+
+// parseSTUNMappedAddress 从属性中解析 XOR-MAPPED-ADDRESS，旧服务器只返回 MAPPED-ADDRESS
+func parseSTUNMappedAddress(attrs []byte) (*net.UDPAddr, error) {
+	var fallback *net.UDPAddr
+	for len(attrs) >= 4 {
+		typ := binary.BigEndian.Uint16(attrs[0:])
+		length := int(binary.BigEndian.Uint16(attrs[2:]))
+		if len(attrs) < 4+length {
+			break
+		}
+		value := attrs[4 : 4+length]
+		if length >= 8 && value[1] == 0x01 {
+			port := int(binary.BigEndian.Uint16(value[2:]))
+			ip := net.IPv4(value[4], value[5], value[6], value[7])
+			switch typ {
+			case stunAttrXorMapped:
+				port ^= stunMagicCookie >> 16
+				cookie := make([]byte, 4)
+				binary.BigEndian.PutUint32(cookie, stunMagicCookie)
+				ip = net.IPv4(value[4]^cookie[0], value[5]^cookie[1], value[6]^cookie[2], value[7]^cookie[3])
+				return &net.UDPAddr{IP: ip, Port: port}, nil
+			case stunAttrMapped:
+				fallback = &net.UDPAddr{IP: ip, Port: port}
+			}
+		}
+		// 属性按 4 字节对齐
+		attrs = attrs[min(4+(length+3)&^3, len(attrs)):]
+	}
+	if fallback != nil {
+		return fallback, nil
+	}
+	return nil, i18n.Errorf("STUN 响应中没有映射地址")
+}
+
+// abs 返回整数的绝对值
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// Notifier 发送桌面通知和 Webhook 告警
+type Notifier struct {
+	httpClient *http.Client
+}
+
+// NewNotifier 创建通知器
+func NewNotifier() *Notifier {
+	return &Notifier{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Notify 按选项发送告警，发送失败只返回错误，不影响监控
+func (n *Notifier) Notify(alert Alert, options MonitorOptions) error {
+	var errs []string
+
+	if options.DesktopNotify {
+		if err := n.sendDesktop(alert); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if options.WebhookURL != "" {
+		if err := n.SendWebhook(options.WebhookURL, alert); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return i18n.Errorf("发送告警失败: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// sendDesktop 发送桌面通知，Linux 使用 notify-send，macOS 使用 osascript
+func (n *Notifier) sendDesktop(alert Alert) error {
+	title := i18n.T("FRP 管理工具: ") + alert.Title
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		urgency := "normal"
+		if alert.Severity == AlertCritical && !alert.Resolved {
+			urgency = "critical"
+		}
+		cmd = exec.Command("notify-send", "-u", urgency, title, alert.Message)
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptQuote(alert.Message), appleScriptQuote(title))
+		cmd = exec.Command("osascript", "-e", script)
+	default:
+		return i18n.Errorf("当前系统不支持桌面通知: %s", runtime.GOOS)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return i18n.Errorf("桌面通知失败: %v %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// SendWebhook 以 JSON 格式 POST 告警到 Webhook 地址
+func (n *Notifier) SendWebhook(webhookURL string, alert Alert) error {
+	payload := struct {
+		Alert
+		Text string `json:"text"` // 方便直接接入只读取 text 字段的聊天机器人
+	}{
+		Alert: alert,
+		Text:  alert.Title,
+	}
+	if alert.Message != "" {
+		payload.Text += ": " + alert.Message
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return i18n.Errorf("序列化告警失败: %w", err)
+	}
+
+	resp, err := n.httpClient.Post(webhookURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return i18n.Errorf("发送 Webhook 失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return i18n.Errorf("Webhook 返回状态码 %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// appleScriptQuote 为 AppleScript 字符串加引号
+func appleScriptQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
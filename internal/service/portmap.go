@@ -0,0 +1,420 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 端口映射参数
+const (
+	ssdpAddr           = "239.255.255.250:1900"
+	ssdpTimeout        = 2 * time.Second
+	natPMPPort         = 5351
+	natPMPTimeout      = time.Second
+	portMapHTTPTimeout = 5 * time.Second
+)
+
+// upnpSearchTargets SSDP 搜索的设备类型
+var upnpSearchTargets = []string{
+	"urn:schemas-upnp-org:device:InternetGatewayDevice:1",
+	"urn:schemas-upnp-org:device:InternetGatewayDevice:2",
+	"urn:schemas-upnp-org:service:WANIPConnection:1",
+}
+
+// PortMapper 路由器端口映射协议（UPnP IGD 或 NAT-PMP）
+type PortMapper interface {
+	// Protocol 协议名称
+	Protocol() string
+	// Gateway 路由器地址
+	Gateway() string
+	// ExternalIP 路由器 WAN 口的地址
+	ExternalIP(ctx context.Context) (string, error)
+	// AddMapping 把路由器的外部端口映射到本机端口，返回路由器实际分配的外部端口
+	AddMapping(ctx context.Context, protocol string, internalPort, externalPort int, lifetime time.Duration) (int, error)
+	// DeleteMapping 删除端口映射
+	DeleteMapping(ctx context.Context, protocol string, internalPort, externalPort int) error
+}
+
+// DiscoverPortMapper 依次尝试 UPnP 和 NAT-PMP，返回第一个可用的端口映射协议
+func DiscoverPortMapper(ctx context.Context) (PortMapper, error) {
+	upnp, upnpErr := discoverUPnP(ctx)
+	if upnpErr == nil {
+		return upnp, nil
+	}
+	pmp, pmpErr := discoverNATPMP(ctx)
+	if pmpErr == nil {
+		return pmp, nil
+	}
+	return nil, i18n.Errorf("路由器不支持或未开启 UPnP (%v) 和 NAT-PMP (%v)", upnpErr, pmpErr)
+}
+
+// DoubleNATWarning 比较路由器 WAN 口地址和 STUN 检测到的公网地址，路由器不是最外层 NAT 时返回提示
+func DoubleNATWarning(externalIP, publicIP string) string {
+	ip := net.ParseIP(externalIP)
+	if ip == nil {
+		return ""
+	}
+	_, cgnat, _ := net.ParseCIDR("100.64.0.0/10")
+	switch {
+	case cgnat.Contains(ip):
+		return i18n.Sprintf("路由器 WAN 口地址 %s 属于运营商级 NAT (CGNAT)，路由器上的端口映射对公网无效，打洞取决于运营商 NAT 的类型", externalIP)
+	case ip.IsPrivate():
+		return i18n.Sprintf("路由器 WAN 口地址 %s 是内网地址，存在多层 NAT，需要在上级路由器（如光猫）上也设置映射或开启桥接", externalIP)
+	case publicIP != "" && externalIP != publicIP:
+		return i18n.Sprintf("路由器 WAN 口地址 %s 与公网地址 %s 不同，路由器外还有一层 NAT", externalIP, publicIP)
+	}
+	return ""
+}
+
+// upnpMapper UPnP IGD 的 WANIPConnection/WANPPPConnection 服务
+type upnpMapper struct {
+	gateway     string
+	serviceType string
+	controlURL  string
+	localIP     string
+}
+
+// upnpService 设备描述中的服务
+type upnpService struct {
+	ServiceType string `xml:"serviceType"`
+	ControlURL  string `xml:"controlURL"`
+}
+
+// upnpDevice 设备描述中的设备，网关的 WAN 连接服务位于嵌套的子设备中
+type upnpDevice struct {
+	Services []upnpService `xml:"serviceList>service"`
+	Devices  []upnpDevice  `xml:"deviceList>device"`
+}
+
+// upnpRoot 设备描述文档
+type upnpRoot struct {
+	URLBase string     `xml:"URLBase"`
+	Device  upnpDevice `xml:"device"`
+}
+
+// findService 递归查找 WAN 连接服务
+func (d upnpDevice) findService() (upnpService, bool) {
+	for _, svc := range d.Services {
+		if strings.Contains(svc.ServiceType, "WANIPConnection") || strings.Contains(svc.ServiceType, "WANPPPConnection") {
+			return svc, true
+		}
+	}
+	for _, child := range d.Devices {
+		if svc, ok := child.findService(); ok {
+			return svc, true
+		}
+	}
+	return upnpService{}, false
+}
+
+// discoverUPnP 通过 SSDP 组播查找支持 UPnP 的网关并读取设备描述
+func discoverUPnP(ctx context.Context) (*upnpMapper, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	dst, _ := net.ResolveUDPAddr("udp4", ssdpAddr)
+	for _, target := range upnpSearchTargets {
+		request := "M-SEARCH * HTTP/1.1\r\nHOST: " + ssdpAddr + "\r\nMAN: \"ssdp:discover\"\r\nMX: 2\r\nST: " + target + "\r\n\r\n"
+		if _, err := conn.WriteToUDP([]byte(request), dst); err != nil {
+			return nil, err
+		}
+	}
+
+	deadline := time.Now().Add(ssdpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetReadDeadline(deadline)
+
+	buf := make([]byte, 2048)
+	tried := make(map[string]bool)
+	lastErr := i18n.Errorf("没有收到 UPnP 设备的响应")
+	for {
+		n, _, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			return nil, lastErr
+		}
+		resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil)
+		if err != nil {
+			continue
+		}
+		location := resp.Header.Get("Location")
+		if location == "" || tried[location] {
+			continue
+		}
+		tried[location] = true
+		mapper, err := newUPnPMapper(ctx, location)
+		if err == nil {
+			return mapper, nil
+		}
+		lastErr = err
+	}
+}
+
+// newUPnPMapper 读取设备描述，找到 WAN 连接服务的控制地址
+func newUPnPMapper(ctx context.Context, location string) (*upnpMapper, error) {
+	base, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, portMapHTTPTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var root upnpRoot
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&root); err != nil {
+		return nil, i18n.Errorf("解析 UPnP 设备描述失败: %w", err)
+	}
+	svc, ok := root.Device.findService()
+	if !ok {
+		return nil, i18n.Errorf("%s 不是互联网网关设备", base.Host)
+	}
+	if root.URLBase != "" {
+		if u, err := url.Parse(root.URLBase); err == nil {
+			base = u
+		}
+	}
+	control, err := base.Parse(svc.ControlURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// 映射的内部地址是本机连接网关时使用的地址
+	probe, err := net.Dial("udp4", base.Host)
+	if err != nil {
+		return nil, err
+	}
+	localIP := probe.LocalAddr().(*net.UDPAddr).IP.String()
+	probe.Close()
+
+	return &upnpMapper{
+		gateway:     base.Hostname(),
+		serviceType: svc.ServiceType,
+		controlURL:  control.String(),
+		localIP:     localIP,
+	}, nil
+}
+
+// Protocol 协议名称
+func (m *upnpMapper) Protocol() string { return "UPnP" }
+
+// Gateway 路由器地址
+func (m *upnpMapper) Gateway() string { return m.gateway }
+
+// ExternalIP 路由器 WAN 口的地址
+func (m *upnpMapper) ExternalIP(ctx context.Context) (string, error) {
+	body, err := m.soap(ctx, "GetExternalIPAddress", "")
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		IP string `xml:"Body>GetExternalIPAddressResponse>NewExternalIPAddress"`
+	}
+	if err := xml.Unmarshal(body, &resp); err != nil {
+		return "", err
+	}
+	return resp.IP, nil
+}
+
+// AddMapping UPnP 不会另行分配端口，成功时外部端口即请求的端口
+func (m *upnpMapper) AddMapping(ctx context.Context, protocol string, internalPort, externalPort int, lifetime time.Duration) (int, error) {
+	args := fmt.Sprintf("<NewRemoteHost></NewRemoteHost><NewExternalPort>%d</NewExternalPort><NewProtocol>%s</NewProtocol>"+
+		"<NewInternalPort>%d</NewInternalPort><NewInternalClient>%s</NewInternalClient><NewEnabled>1</NewEnabled>"+
+		"<NewPortMappingDescription>frp-cli-ui</NewPortMappingDescription><NewLeaseDuration>%d</NewLeaseDuration>",
+		externalPort, strings.ToUpper(protocol), internalPort, m.localIP, int(lifetime.Seconds()))
+	if _, err := m.soap(ctx, "AddPortMapping", args); err != nil {
+		return 0, err
+	}
+	return externalPort, nil
+}
+
+// DeleteMapping 删除端口映射
+func (m *upnpMapper) DeleteMapping(ctx context.Context, protocol string, internalPort, externalPort int) error {
+	args := fmt.Sprintf("<NewRemoteHost></NewRemoteHost><NewExternalPort>%d</NewExternalPort><NewProtocol>%s</NewProtocol>",
+		externalPort, strings.ToUpper(protocol))
+	_, err := m.soap(ctx, "DeletePortMapping", args)
+	return err
+}
+
+// soap 调用 WAN 连接服务的 SOAP 动作，返回响应内容
+func (m *upnpMapper) soap(ctx context.Context, action, args string) ([]byte, error) {
+	envelope := `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" ` +
+		`s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>` +
+		`<u:` + action + ` xmlns:u="` + m.serviceType + `">` + args + `</u:` + action + `></s:Body></s:Envelope>`
+
+	ctx, cancel := context.WithTimeout(ctx, portMapHTTPTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.controlURL, strings.NewReader(envelope))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	req.Header.Set("SOAPAction", `"`+m.serviceType+"#"+action+`"`)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var fault struct {
+			Code        int    `xml:"Body>Fault>detail>UPnPError>errorCode"`
+			Description string `xml:"Body>Fault>detail>UPnPError>errorDescription"`
+		}
+		if xml.Unmarshal(body, &fault) == nil && fault.Code != 0 {
+			return nil, i18n.Errorf("路由器拒绝 %s: %d %s", action, fault.Code, fault.Description)
+		}
+		return nil, i18n.Errorf("路由器拒绝 %s: HTTP %d", action, resp.StatusCode)
+	}
+	return body, nil
+}
+
+// natPMPMapper NAT-PMP 协议（RFC 6886），Apple 路由器和部分开源固件支持
+type natPMPMapper struct {
+	gateway string
+}
+
+// discoverNATPMP 向默认网关查询外部地址，确认其支持 NAT-PMP
+func discoverNATPMP(ctx context.Context) (*natPMPMapper, error) {
+	gateway := defaultGateway()
+	if gateway == "" {
+		return nil, i18n.Errorf("找不到默认网关")
+	}
+	m := &natPMPMapper{gateway: gateway}
+	if _, err := m.ExternalIP(ctx); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Protocol 协议名称
+func (m *natPMPMapper) Protocol() string { return "NAT-PMP" }
+
+// Gateway 路由器地址
+func (m *natPMPMapper) Gateway() string { return m.gateway }
+
+// ExternalIP 路由器 WAN 口的地址
+func (m *natPMPMapper) ExternalIP(ctx context.Context) (string, error) {
+	resp, err := m.request(ctx, []byte{0, 0}, 12)
+	if err != nil {
+		return "", err
+	}
+	return net.IP(resp[8:12]).String(), nil
+}
+
+// AddMapping 路由器可能分配与请求不同的外部端口
+func (m *natPMPMapper) AddMapping(ctx context.Context, protocol string, internalPort, externalPort int, lifetime time.Duration) (int, error) {
+	resp, err := m.request(ctx, natPMPMapRequest(protocol, internalPort, externalPort, uint32(lifetime.Seconds())), 16)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(resp[10:12])), nil
+}
+
+// DeleteMapping 生存时间为 0 的映射请求即删除
+func (m *natPMPMapper) DeleteMapping(ctx context.Context, protocol string, internalPort, _ int) error {
+	_, err := m.request(ctx, natPMPMapRequest(protocol, internalPort, 0, 0), 16)
+	return err
+}
+
+// natPMPMapRequest 构造映射请求，操作码 1 为 UDP，2 为 TCP
+func natPMPMapRequest(protocol string, internalPort, externalPort int, lifetime uint32) []byte {
+	request := make([]byte, 12)
+	request[1] = 1
+	if strings.EqualFold(protocol, "tcp") {
+		request[1] = 2
+	}
+	binary.BigEndian.PutUint16(request[4:], uint16(internalPort))
+	binary.BigEndian.PutUint16(request[6:], uint16(externalPort))
+	binary.BigEndian.PutUint32(request[8:], lifetime)
+	return request
+}
+
+// request 发送 NAT-PMP 请求并检查响应的操作码和结果码，超时重试一次
+func (m *natPMPMapper) request(ctx context.Context, request []byte, size int) ([]byte, error) {
+	conn, err := net.Dial("udp4", net.JoinHostPort(m.gateway, strconv.Itoa(natPMPPort)))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	resp := make([]byte, 16)
+	for attempt := 0; attempt < 2; attempt++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if _, err = conn.Write(request); err != nil {
+			return nil, err
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(natPMPTimeout << attempt))
+		var n int
+		n, err = conn.Read(resp)
+		if err != nil {
+			continue
+		}
+		if n < size || resp[1] != request[1]+128 {
+			return nil, i18n.Errorf("NAT-PMP 响应格式错误")
+		}
+		if code := binary.BigEndian.Uint16(resp[2:4]); code != 0 {
+			return nil, i18n.Errorf("路由器拒绝 NAT-PMP 请求，结果码 %d", code)
+		}
+		return resp[:n], nil
+	}
+	return nil, i18n.Errorf("网关 %s 没有响应 NAT-PMP 请求: %w", m.gateway, err)
+}
+
+// defaultGateway 返回默认网关地址。Linux 上读取路由表，其他系统假定网关是本机所在网段的 .1
+func defaultGateway() string {
+	if data, err := os.ReadFile("/proc/net/route"); err == nil {
+		for _, line := range strings.Split(string(data), "\n")[1:] {
+			fields := strings.Fields(line)
+			if len(fields) < 3 || fields[1] != "00000000" {
+				continue
+			}
+			// 网关以小端十六进制存储
+			if raw, err := hex.DecodeString(fields[2]); err == nil && len(raw) == 4 {
+				return net.IPv4(raw[3], raw[2], raw[1], raw[0]).String()
+			}
+		}
+	}
+
+	probe, err := net.Dial("udp4", "192.0.2.1:9")
+	if err != nil {
+		return ""
+	}
+	defer probe.Close()
+	ip := probe.LocalAddr().(*net.UDPAddr).IP.To4()
+	if ip == nil || !ip.IsPrivate() {
+		return ""
+	}
+	return net.IPv4(ip[0], ip[1], ip[2], 1).String()
+}
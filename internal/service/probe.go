@@ -0,0 +1,150 @@
+package service
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// probeBannerWait TCP 探测连接建立后等待服务主动发送数据的时间
+const probeBannerWait = 1500 * time.Millisecond
+
+// frpNotFoundMarker frps 在域名没有对应代理或本地服务无响应时返回的 404 页面中的标记
+const frpNotFoundMarker = "Faithfully yours, frp."
+
+// ProxyProbeTarget 端到端探测的目标
+type ProxyProbeTarget struct {
+	Type string // tcp、http 或 https
+	Addr string // host:port，tcp 为远程端口，http/https 为 frps 的虚拟主机端口
+	Host string // http 的 Host 头和 https 的 SNI
+}
+
+// ProxyProbeResult 端到端探测结果
+type ProxyProbeResult struct {
+	Target  ProxyProbeTarget
+	Latency time.Duration // 建立连接或收到响应的耗时，不含等待服务发送数据的时间
+	Detail  string        // 成功时的补充说明，例如 HTTP 状态码
+	Err     error
+}
+
+// Success 隧道是否端到端可用
+func (r *ProxyProbeResult) Success() bool {
+	return r.Err == nil
+}
+
+// Summary 返回适合展示的一句话结果
+func (r *ProxyProbeResult) Summary() string {
+	addr := r.Target.Addr
+	if r.Target.Host != "" {
+		addr = r.Target.Host + " (" + r.Target.Addr + ")"
+	}
+	if r.Err != nil {
+		return i18n.Sprintf("探测 %s 失败: %v", addr, r.Err)
+	}
+	return i18n.Sprintf("探测 %s 成功，%s (耗时 %dms)", addr, r.Detail, r.Latency.Milliseconds())
+}
+
+// ProbeProxy 从外部经 frps 连接代理，检查隧道是否能到达本地服务。
+// frps 总会接受远程端口上的连接，因此 TCP 还会等待一小段时间：连接被立即关闭说明 frpc 连不上本地服务
+func ProbeProxy(target ProxyProbeTarget, timeout time.Duration) *ProxyProbeResult {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	result := &ProxyProbeResult{Target: target}
+	switch target.Type {
+	case "tcp":
+		probeTCP(result, timeout)
+	case "http":
+		probeHTTP(result, timeout)
+	case "https":
+		probeHTTPS(result, timeout)
+	default:
+		result.Err = i18n.Errorf("暂不支持探测 %s 代理", target.Type)
+	}
+	return result
+}
+
+// probeTCP 连接远程端口，收到数据或连接保持打开都视为可用
+func probeTCP(result *ProxyProbeResult, timeout time.Duration) {
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", result.Target.Addr, timeout)
+	if err != nil {
+		result.Err = i18n.Errorf("无法连接远程端口: %w", err)
+		return
+	}
+	defer conn.Close()
+	result.Latency = time.Since(start)
+
+	conn.SetReadDeadline(time.Now().Add(min(timeout, probeBannerWait)))
+	buf := make([]byte, 1)
+	_, err = conn.Read(buf)
+	switch {
+	case err == nil:
+		result.Latency = time.Since(start)
+		result.Detail = i18n.T("服务已响应")
+	case errors.Is(err, os.ErrDeadlineExceeded):
+		result.Detail = i18n.T("连接保持打开，服务未主动发送数据")
+	case errors.Is(err, io.EOF):
+		result.Err = i18n.Errorf("隧道已建立但连接被关闭，本地服务可能未运行")
+	default:
+		result.Err = i18n.Errorf("读取响应失败: %w", err)
+	}
+}
+
+// probeHTTP 带 Host 头请求 frps 的虚拟主机端口，frps 自己的 404 页面视为失败
+func probeHTTP(result *ProxyProbeResult, timeout time.Duration) {
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{DisableKeepAlives: true},
+		// 重定向说明后端已响应，不需要跟随
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+result.Target.Addr+"/", nil)
+	if err != nil {
+		result.Err = err
+		return
+	}
+	req.Host = result.Target.Host
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Err = i18n.Errorf("请求失败: %w", err)
+		return
+	}
+	defer resp.Body.Close()
+	result.Latency = time.Since(start)
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode == http.StatusNotFound && strings.Contains(string(body), frpNotFoundMarker) {
+		result.Err = i18n.Errorf("frps 返回了 404 页面，域名没有对应的代理或本地服务无响应")
+		return
+	}
+	result.Detail = "HTTP " + resp.Status
+}
+
+// probeHTTPS 以域名作为 SNI 与 frps 的 HTTPS 虚拟主机端口握手，frps 找不到代理或本地服务不可用时会直接断开
+func probeHTTPS(result *ProxyProbeResult, timeout time.Duration) {
+	start := time.Now()
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", result.Target.Addr, &tls.Config{
+		ServerName:         result.Target.Host,
+		InsecureSkipVerify: true, // 只检查隧道是否可用，证书由浏览器校验
+	})
+	if err != nil {
+		result.Err = i18n.Errorf("TLS 握手失败: %w", err)
+		return
+	}
+	defer conn.Close()
+	result.Latency = time.Since(start)
+	result.Detail = i18n.Sprintf("TLS 握手成功 (%s)", tls.VersionName(conn.ConnectionState().Version))
+}
@@ -0,0 +1,25 @@
+//go:build unix
+
+package service
+
+import (
+	"os/exec"
+	"syscall"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// setProcessGroup 让 frp 进程使用独立的进程组，挂起或中断终端界面时不会连带影响 frp 进程
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// sendCtrlBreak 仅 Windows 使用
+func sendCtrlBreak(pid int) error {
+	return i18n.NewError("仅 Windows 支持发送 CTRL_BREAK 事件")
+}
+
+// killProcessGroup 强制结束 setProcessGroup 启动的进程及其所在进程组中的子进程
+func killProcessGroup(pid int) error {
+	return syscall.Kill(-pid, syscall.SIGKILL)
+}
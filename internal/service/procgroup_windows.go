@@ -0,0 +1,35 @@
+//go:build windows
+
+package service
+
+import (
+	"os/exec"
+	"syscall"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// ctrlBreakEvent GenerateConsoleCtrlEvent 的 CTRL_BREAK_EVENT
+const ctrlBreakEvent = 1
+
+var procGenerateConsoleCtrlEvent = syscall.NewLazyDLL("kernel32.dll").NewProc("GenerateConsoleCtrlEvent")
+
+// setProcessGroup 以新进程组启动 frp，之后可以只向它发送 CTRL_BREAK 事件，不影响终端界面本身
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// sendCtrlBreak 向以新进程组启动的进程发送 CTRL_BREAK 事件，frp 会将其作为中断信号正常退出。
+// pid 必须是 setProcessGroup 启动的进程组组长，否则事件会发给整个控制台
+func sendCtrlBreak(pid int) error {
+	ret, _, err := procGenerateConsoleCtrlEvent.Call(ctrlBreakEvent, uintptr(pid))
+	if ret == 0 {
+		return i18n.Errorf("发送 CTRL_BREAK 失败: %w", err)
+	}
+	return nil
+}
+
+// killProcessGroup 强制结束进程及其子进程
+func killProcessGroup(pid int) error {
+	return killProcessTree(pid)
+}
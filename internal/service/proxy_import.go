@@ -0,0 +1,115 @@
+package service
+
+import (
+	"encoding/json"
+	"sort"
+
+	"frp-cli-ui/pkg/config"
+)
+
+// ProxyImport 从 frps 查询到的一个可导入代理
+type ProxyImport struct {
+	Proxy  config.ProxyConfig
+	Status string // frps 上的状态，online/offline
+
+	// Exists 本地配置中已有同名代理，导入时跳过
+	Exists bool
+	// NeedsSecret stcp/xtcp/sudp 代理的 secretKey 不会通过 API 返回，导入后需要补填
+	NeedsSecret bool
+}
+
+// Importable 是否可以导入：离线代理在 frps 上没有配置内容
+func (p ProxyImport) Importable() bool {
+	return p.Status == "online" && p.Proxy.Type != "" && !p.Exists
+}
+
+// ProxyImportsFromServer 把 frps 上已注册的代理转换为本地代理配置，按名称排序；
+// cfg 为当前客户端配置，用于标记已存在的同名代理
+func ProxyImportsFromServer(proxies []ProxyInfo, cfg *config.Config) []ProxyImport {
+	existing := make(map[string]bool)
+	if cfg != nil {
+		for _, proxy := range cfg.Proxies {
+			existing[proxy.Name] = true
+		}
+	}
+
+	imports := make([]ProxyImport, 0, len(proxies))
+	for _, info := range proxies {
+		proxy := ProxyConfigFromServer(info)
+		item := ProxyImport{Proxy: proxy, Status: info.Status, Exists: existing[proxy.Name]}
+		switch proxy.Type {
+		case "stcp", "sudp", "xtcp":
+			item.NeedsSecret = true
+		}
+		imports = append(imports, item)
+	}
+	sort.SliceStable(imports, func(i, j int) bool {
+		return imports[i].Proxy.Name < imports[j].Proxy.Name
+	})
+	return imports
+}
+
+// ProxyConfigFromServer 把 frps API 返回的代理配置还原为 frpc 的代理配置，
+// secretKey、httpPassword 等敏感字段不会通过 API 返回
+func ProxyConfigFromServer(info ProxyInfo) config.ProxyConfig {
+	conf := info.Conf
+	name := info.Name
+	if name == "" {
+		name = conf.Name
+	}
+
+	proxy := config.ProxyConfig{
+		Name:              name,
+		Type:              conf.Type,
+		LocalIP:           conf.LocalIP,
+		LocalPort:         conf.LocalPort,
+		RemotePort:        conf.RemotePort,
+		CustomDomains:     conf.CustomDomains,
+		Subdomain:         conf.SubDomain,
+		Locations:         conf.Locations,
+		HostHeaderRewrite: conf.HostHeaderRewrite,
+		LoadBalancer: config.LoadBalancerConfig{
+			Group:    conf.LoadBalancer["group"],
+			GroupKey: conf.LoadBalancer["groupKey"],
+		},
+		Transport: config.ProxyTransport{
+			UseEncryption:  conf.Transport.UseEncryption,
+			UseCompression: conf.Transport.UseCompression,
+			BandwidthLimit: conf.Transport.BandwidthLimit,
+		},
+		HealthCheck: healthCheckFromServer(conf.HealthCheck),
+	}
+
+	// 插件字段名与本地配置一致，按 JSON 字段名不区分大小写解码
+	if len(conf.Plugin) > 0 {
+		if data, err := json.Marshal(conf.Plugin); err == nil {
+			_ = json.Unmarshal(data, &proxy.Plugin)
+		}
+		if proxy.Plugin.Type != "" {
+			proxy.LocalIP, proxy.LocalPort = "", 0
+		}
+	}
+	return proxy
+}
+
+// healthCheckFromServer 还原健康检查配置，frps 对未配置健康检查的代理也会返回类型为空的对象
+func healthCheckFromServer(values map[string]interface{}) config.HealthCheckConfig {
+	str := func(key string) string {
+		s, _ := values[key].(string)
+		return s
+	}
+	num := func(key string) int {
+		n, _ := values[key].(float64)
+		return int(n)
+	}
+
+	check := config.HealthCheckConfig{Type: str("type")}
+	if check.Type == "" {
+		return check
+	}
+	check.TimeoutS = num("timeoutSeconds")
+	check.MaxFailed = num("maxFailed")
+	check.IntervalS = num("intervalSeconds")
+	check.Path = str("path")
+	return check
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// QuotaUsage 一个流量配额在当前周期的用量
+type QuotaUsage struct {
+	Quota config.TrafficQuota
+	Used  int64
+	Limit int64
+	Start time.Time // 当前周期开始时间
+	End   time.Time // 当前周期结束时间，即配额重置时间
+}
+
+// Ratio 返回用量占配额的比例
+func (u QuotaUsage) Ratio() float64 {
+	if u.Limit <= 0 {
+		return 0
+	}
+	return float64(u.Used) / float64(u.Limit)
+}
+
+// Exceeded 是否已超出配额
+func (u QuotaUsage) Exceeded() bool {
+	return u.Limit > 0 && u.Used >= u.Limit
+}
+
+// Warning 是否达到预警阈值
+func (u QuotaUsage) Warning() bool {
+	return u.Limit > 0 && u.Ratio() >= u.Quota.WarnRatio()
+}
+
+// Key 配额的唯一标识，由代理、周期和方向组成
+func (u QuotaUsage) Key() string {
+	direction := u.Quota.Direction
+	if direction == "" {
+		direction = config.QuotaTotal
+	}
+	return u.Quota.Proxy + "/" + u.Quota.Period + "/" + direction
+}
+
+// quotaDay 一个序列一天的流量合计
+type quotaDay struct {
+	in  int64
+	out int64
+}
+
+// QuotaTracker 按天汇总流量历史，计算各配额在当前周期的用量。
+// 第一次查询时从流量存储载入周期内的历史，之后由采样方通过 Add 增量累加
+type QuotaTracker struct {
+	mu         sync.Mutex
+	store      *TrafficStore
+	quotas     []config.TrafficQuota
+	days       map[string]map[string]quotaDay // 序列名 → 日期 → 合计
+	loadedFrom time.Time                      // 已载入历史的起始时间，零值表示尚未载入
+}
+
+// NewQuotaTracker 创建配额统计
+func NewQuotaTracker(store *TrafficStore) *QuotaTracker {
+	return &QuotaTracker{store: store}
+}
+
+// SetQuotas 更新配额，需要更早的历史时在下次查询时重新载入
+func (t *QuotaTracker) SetQuotas(quotas []config.TrafficQuota) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.quotas = append([]config.TrafficQuota(nil), quotas...)
+}
+
+// HasQuotas 是否设置了配额
+func (t *QuotaTracker) HasQuotas() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.quotas) > 0
+}
+
+// Add 累加新写入流量存储的采样，尚未载入历史时忽略，载入时会从存储读到这些记录
+func (t *QuotaTracker) Add(records []TrafficRecord) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.loadedFrom.IsZero() {
+		return
+	}
+	for _, record := range records {
+		if !record.Time.Before(t.loadedFrom) {
+			t.addLocked(record)
+		}
+	}
+}
+
+// addLocked 把一条记录计入所在的日期，调用方需持有锁
+func (t *QuotaTracker) addLocked(record TrafficRecord) {
+	byDay, ok := t.days[record.Name]
+	if !ok {
+		byDay = make(map[string]quotaDay)
+		t.days[record.Name] = byDay
+	}
+	key := record.Time.Local().Format(trafficFileLayout)
+	day := byDay[key]
+	day.in += record.In
+	day.out += record.Out
+	byDay[key] = day
+}
+
+// Usage 返回各配额在当前周期的用量，顺序与配额设置一致
+func (t *QuotaTracker) Usage(now time.Time) ([]QuotaUsage, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.quotas) == 0 {
+		return nil, nil
+	}
+
+	earliest := now
+	for _, q := range t.quotas {
+		if start := q.PeriodStart(now); start.Before(earliest) {
+			earliest = start
+		}
+	}
+	if t.loadedFrom.IsZero() || earliest.Before(t.loadedFrom) {
+		if err := t.loadLocked(earliest); err != nil {
+			return nil, err
+		}
+	}
+	// 丢弃已进入上一周期的日期
+	earliestDay := earliest.Format(trafficFileLayout)
+	for _, byDay := range t.days {
+		for key := range byDay {
+			if key < earliestDay {
+				delete(byDay, key)
+			}
+		}
+	}
+	if earliest.After(t.loadedFrom) {
+		t.loadedFrom = earliest
+	}
+
+	usages := make([]QuotaUsage, 0, len(t.quotas))
+	for _, q := range t.quotas {
+		name := q.Proxy
+		if name == "" {
+			name = ServerTrafficSeries
+		}
+		usage := QuotaUsage{Quota: q, Limit: q.LimitBytes(), Start: q.PeriodStart(now), End: q.PeriodEnd(now)}
+		startDay := usage.Start.Format(trafficFileLayout)
+		for key, day := range t.days[name] {
+			if key < startDay {
+				continue
+			}
+			switch q.Direction {
+			case config.QuotaIn:
+				usage.Used += day.in
+			case config.QuotaOut:
+				usage.Used += day.out
+			default:
+				usage.Used += day.in + day.out
+			}
+		}
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+// loadLocked 从流量存储重新载入 since 之后的历史，调用方需持有锁
+func (t *QuotaTracker) loadLocked(since time.Time) error {
+	records, err := t.store.Load(since)
+	if err != nil {
+		return err
+	}
+	t.days = make(map[string]map[string]quotaDay)
+	for _, record := range records {
+		t.addLocked(record)
+	}
+	t.loadedFrom = since
+	return nil
+}
+
+// 配额告警级别
+const (
+	quotaLevelNone = iota
+	quotaLevelWarning
+	quotaLevelExceeded
+)
+
+// SetQuotaTracker 设置流量配额统计，用量达到预警阈值或超出配额时告警，需在 Start 之前调用
+func (hm *HealthMonitor) SetQuotaTracker(tracker *QuotaTracker) {
+	hm.quotas = tracker
+}
+
+// checkQuotas 检查流量配额，超出时按配置停用代理或停止 frps。每个周期只自动停止一次，
+// 用户在周期内手动恢复后不会再次停止
+func (hm *HealthMonitor) checkQuotas(options MonitorOptions, problems map[string]problem) {
+	if hm.quotas == nil {
+		return
+	}
+	usages, err := hm.quotas.Usage(time.Now())
+	if err != nil {
+		hm.manager.sendLog("WARN", i18n.Sprintf("统计流量配额失败: %v", err), "server")
+		return
+	}
+
+	for _, usage := range usages {
+		id := usage.Key()
+		level := quotaLevelNone
+		switch {
+		case usage.Exceeded():
+			level = quotaLevelExceeded
+		case usage.Warning():
+			level = quotaLevelWarning
+		}
+
+		previous := hm.quotaLevel[id]
+		hm.quotaLevel[id] = level
+		if level == quotaLevelNone {
+			// 新周期开始，恢复被忽略的告警
+			delete(hm.quotaMuted, id)
+			continue
+		}
+		if level > previous {
+			hm.publishQuotaEvent(usage, level)
+		}
+
+		name := usage.Quota.DisplayName()
+		message := i18n.Sprintf("已用 %s / %s，%s 重置", FormatTraffic(usage.Used), FormatTraffic(usage.Limit), usage.End.Format("01-02 15:04"))
+		if level == quotaLevelExceeded && usage.Quota.AutoStop && !hm.quotaStopped[id].Equal(usage.Start) {
+			hm.quotaStopped[id] = usage.Start
+			note, err := hm.enforceQuota(usage.Quota, options)
+			switch {
+			case err != nil:
+				message += i18n.Sprintf("，自动停止失败: %v", err)
+				hm.manager.sendLog("ERROR", i18n.Sprintf("%s 已用尽，自动停止失败: %v", name, err), "client")
+			case note != "":
+				message += "，" + note
+				hm.manager.sendLog("WARN", i18n.Sprintf("%s 已用尽，%s", name, note), "client")
+			}
+		}
+
+		if hm.quotaMuted[id] < quotaLevelWarning {
+			problems["quota:"+id+":warning"] = problem{
+				severity: AlertWarning,
+				title:    i18n.Sprintf("%s 已用 %.0f%%", name, usage.Ratio()*100),
+				message:  message,
+			}
+		}
+		if level == quotaLevelExceeded && hm.quotaMuted[id] < quotaLevelExceeded {
+			problems["quota:"+id+":exceeded"] = problem{
+				severity: AlertCritical,
+				title:    i18n.Sprintf("%s 已用尽", name),
+				message:  message,
+			}
+		}
+	}
+}
+
+// muteQuota 忽略配额告警，直到用量升到更高级别或进入新周期
+func (hm *HealthMonitor) muteQuota(name string) {
+	id, levelName, _ := cutLast(name, ":")
+	level := quotaLevelWarning
+	if levelName == "exceeded" {
+		level = quotaLevelExceeded
+	}
+	hm.quotaMuted[id] = max(hm.quotaMuted[id], level)
+}
+
+// cutLast 在最后一个 sep 处切分字符串
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// publishQuotaEvent 发布配额预警或超出事件
+func (hm *HealthMonitor) publishQuotaEvent(usage QuotaUsage, level int) {
+	if hm.events == nil {
+		return
+	}
+	eventType, title := EventQuotaWarning, i18n.Sprintf("%s 已用 %.0f%%", usage.Quota.DisplayName(), usage.Ratio()*100)
+	if level == quotaLevelExceeded {
+		eventType, title = EventQuotaExceeded, i18n.Sprintf("%s 已用尽", usage.Quota.DisplayName())
+	}
+	source := usage.Quota.Proxy
+	if source == "" {
+		source = "server"
+	}
+	hm.events.Publish(Event{
+		Type:    eventType,
+		Source:  source,
+		Title:   title,
+		Message: i18n.Sprintf("已用 %s / %s", FormatTraffic(usage.Used), FormatTraffic(usage.Limit)),
+		Data: map[string]string{
+			"proxy":  usage.Quota.Proxy,
+			"period": usage.Quota.Period,
+			"used":   strconv.FormatInt(usage.Used, 10),
+			"limit":  strconv.FormatInt(usage.Limit, 10),
+		},
+	})
+}
+
+// enforceQuota 停用超出配额的代理并重载 frpc，服务端配额则停止 frps，返回执行结果说明
+func (hm *HealthMonitor) enforceQuota(q config.TrafficQuota, options MonitorOptions) (string, error) {
+	if q.Proxy == "" {
+		if !hm.manager.GetServerStatus().IsRunning {
+			return "", nil
+		}
+		if err := hm.manager.StopServer(); err != nil {
+			return "", err
+		}
+		return i18n.T("已停止 frps"), nil
+	}
+
+	configPath := options.ClientConfigPath
+	if state := hm.manager.GetProcessState("client"); state != nil && state.ConfigPath != "" {
+		configPath = state.ConfigPath
+	}
+	result, err := hm.manager.SetProxyDisabled(configPath, q.Proxy, true)
+	if err != nil || result == "" {
+		return "", err
+	}
+	return i18n.Sprintf("已停用代理 %s（%s）", q.Proxy, result), nil
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// resourceSampleInterval 进程资源采样间隔
+const resourceSampleInterval = 2 * time.Second
+
+// resourceHistorySize 每个进程保留的资源采样数量
+const resourceHistorySize = 90
+
+// processUsage 从操作系统读取的进程累计 CPU 时间和常驻内存
+type processUsage struct {
+	cpuTime time.Duration
+	rss     uint64
+}
+
+// ResourceSample 一次进程资源采样
+type ResourceSample struct {
+	Time      time.Time
+	PID       int
+	StartTime time.Time // 进程启动时间，用于计算运行时长
+	CPU       float64   // 两次采样之间的 CPU 占用百分比，100 表示占满一个核心
+	Memory    uint64    // 常驻内存 (RSS)，单位字节
+}
+
+// resourceSeries 单个服务的采样历史
+type resourceSeries struct {
+	pid     int
+	last    processUsage
+	lastAt  time.Time
+	samples []ResourceSample
+}
+
+// ResourceMonitor 在后台定期采样 frps/frpc 进程的 CPU 和内存占用，
+// 最新结果同时写入 Manager，通过 GetServerStatus/GetClientStatus 的 CPU 和 Memory 字段返回
+type ResourceMonitor struct {
+	manager *Manager
+
+	mu     sync.Mutex
+	series map[string]*resourceSeries
+
+	cancel context.CancelFunc
+}
+
+// NewResourceMonitor 创建进程资源监控
+func NewResourceMonitor(manager *Manager) *ResourceMonitor {
+	return &ResourceMonitor{
+		manager: manager,
+		series:  make(map[string]*resourceSeries),
+	}
+}
+
+// Start 启动采样 goroutine
+func (rm *ResourceMonitor) Start() {
+	if rm.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rm.cancel = cancel
+	go rm.run(ctx)
+}
+
+// Stop 停止采样
+func (rm *ResourceMonitor) Stop() {
+	if rm.cancel != nil {
+		rm.cancel()
+		rm.cancel = nil
+	}
+}
+
+// run 采样循环
+func (rm *ResourceMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(resourceSampleInterval)
+	defer ticker.Stop()
+
+	for {
+		rm.Sample()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sample 对服务端和客户端各采样一次，进程未运行时清空其历史
+func (rm *ResourceMonitor) Sample() {
+	for _, service := range []string{"server", "client"} {
+		var status ProcessStatus
+		if service == "server" {
+			status = rm.manager.GetServerStatus()
+		} else {
+			status = rm.manager.GetClientStatus()
+		}
+		if !status.IsRunning {
+			rm.reset(service)
+			continue
+		}
+
+		usage, err := readProcessUsage(status.PID)
+		if err != nil {
+			rm.reset(service)
+			continue
+		}
+		if sample, ok := rm.record(service, status, usage, time.Now()); ok {
+			rm.manager.setResourceUsage(service, sample)
+		}
+	}
+}
+
+// record 记录一次读数，第一次读数只作为计算 CPU 占用的起点
+func (rm *ResourceMonitor) record(service string, status ProcessStatus, usage processUsage, now time.Time) (ResourceSample, bool) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	series := rm.series[service]
+	if series == nil || series.pid != status.PID {
+		// 进程重启后 PID 和累计 CPU 时间都会变化，重新开始统计
+		rm.series[service] = &resourceSeries{pid: status.PID, last: usage, lastAt: now}
+		return ResourceSample{}, false
+	}
+
+	sample := ResourceSample{Time: now, PID: status.PID, StartTime: status.StartTime, Memory: usage.rss}
+	if elapsed := now.Sub(series.lastAt); elapsed > 0 && usage.cpuTime >= series.last.cpuTime {
+		sample.CPU = float64(usage.cpuTime-series.last.cpuTime) / float64(elapsed) * 100
+	}
+	series.last, series.lastAt = usage, now
+	series.samples = append(series.samples, sample)
+	if len(series.samples) > resourceHistorySize {
+		series.samples = series.samples[len(series.samples)-resourceHistorySize:]
+	}
+	return sample, true
+}
+
+// reset 清空服务的采样历史
+func (rm *ResourceMonitor) reset(service string) {
+	rm.mu.Lock()
+	delete(rm.series, service)
+	rm.mu.Unlock()
+	rm.manager.setResourceUsage(service, ResourceSample{})
+}
+
+// Samples 返回服务 (server/client) 的采样历史副本
+func (rm *ResourceMonitor) Samples(service string) []ResourceSample {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	series := rm.series[service]
+	if series == nil {
+		return nil
+	}
+	return append([]ResourceSample(nil), series.samples...)
+}
@@ -0,0 +1,64 @@
+//go:build darwin
+
+package service
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// readProcessUsage 通过 ps 读取 CPU 时间和常驻内存
+func readProcessUsage(pid int) (processUsage, error) {
+	output, err := exec.Command("ps", "-o", "rss=,time=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return processUsage{}, err
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) < 2 {
+		return processUsage{}, i18n.Errorf("无法解析进程 %d 的状态", pid)
+	}
+
+	rss, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return processUsage{}, i18n.Errorf("无法解析进程 %d 的内存", pid)
+	}
+	cpuTime, err := parsePSTime(fields[1])
+	if err != nil {
+		return processUsage{}, i18n.Errorf("无法解析进程 %d 的状态", pid)
+	}
+	return processUsage{cpuTime: cpuTime, rss: rss * 1024}, nil
+}
+
+// parsePSTime 解析 ps 输出的 CPU 时间，格式为 [[dd-]hh:]mm:ss.ss
+func parsePSTime(value string) (time.Duration, error) {
+	var days int
+	if before, after, ok := strings.Cut(value, "-"); ok {
+		d, err := strconv.Atoi(before)
+		if err != nil {
+			return 0, err
+		}
+		days, value = d, after
+	}
+
+	var total time.Duration
+	parts := strings.Split(value, ":")
+	for i, part := range parts {
+		unit := time.Second
+		switch len(parts) - 1 - i {
+		case 1:
+			unit = time.Minute
+		case 2:
+			unit = time.Hour
+		}
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, err
+		}
+		total += time.Duration(v * float64(unit))
+	}
+	return total + time.Duration(days)*24*time.Hour, nil
+}
@@ -0,0 +1,53 @@
+//go:build linux
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// clockTicks /proc 中 CPU 时间的单位，Linux 用户态固定为 100
+const clockTicks = 100
+
+// readProcessUsage 从 /proc/<pid>/stat 和 /proc/<pid>/statm 读取 CPU 时间和常驻内存
+func readProcessUsage(pid int) (processUsage, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return processUsage{}, err
+	}
+	// 进程名可能包含空格，从最后一个右括号之后开始按空格切分，第一个字段为进程状态
+	stat := string(data)
+	fields := strings.Fields(stat[strings.LastIndexByte(stat, ')')+1:])
+	if len(fields) < 13 {
+		return processUsage{}, i18n.Errorf("无法解析进程 %d 的状态", pid)
+	}
+	utime, err1 := strconv.ParseUint(fields[11], 10, 64)
+	stime, err2 := strconv.ParseUint(fields[12], 10, 64)
+	if err1 != nil || err2 != nil {
+		return processUsage{}, i18n.Errorf("无法解析进程 %d 的状态", pid)
+	}
+
+	data, err = os.ReadFile(fmt.Sprintf("/proc/%d/statm", pid))
+	if err != nil {
+		return processUsage{}, err
+	}
+	statm := strings.Fields(string(data))
+	if len(statm) < 2 {
+		return processUsage{}, i18n.Errorf("无法解析进程 %d 的内存", pid)
+	}
+	pages, err := strconv.ParseUint(statm[1], 10, 64)
+	if err != nil {
+		return processUsage{}, i18n.Errorf("无法解析进程 %d 的内存", pid)
+	}
+
+	return processUsage{
+		cpuTime: time.Duration(utime+stime) * time.Second / clockTicks,
+		rss:     pages * uint64(os.Getpagesize()),
+	}, nil
+}
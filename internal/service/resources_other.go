@@ -0,0 +1,14 @@
+//go:build !linux && !darwin && !windows
+
+package service
+
+import (
+	"runtime"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// readProcessUsage 其他系统暂不支持读取进程资源
+func readProcessUsage(pid int) (processUsage, error) {
+	return processUsage{}, i18n.Errorf("不支持的操作系统: %s", runtime.GOOS)
+}
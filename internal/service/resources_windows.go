@@ -0,0 +1,58 @@
+//go:build windows
+
+package service
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// processQueryLimitedInformation OpenProcess 的 PROCESS_QUERY_LIMITED_INFORMATION 权限
+const processQueryLimitedInformation = 0x1000
+
+var procGetProcessMemoryInfo = syscall.NewLazyDLL("kernel32.dll").NewProc("K32GetProcessMemoryInfo")
+
+// processMemoryCounters PROCESS_MEMORY_COUNTERS 结构
+type processMemoryCounters struct {
+	cb                         uint32
+	pageFaultCount             uint32
+	peakWorkingSetSize         uintptr
+	workingSetSize             uintptr
+	quotaPeakPagedPoolUsage    uintptr
+	quotaPagedPoolUsage        uintptr
+	quotaPeakNonPagedPoolUsage uintptr
+	quotaNonPagedPoolUsage     uintptr
+	pagefileUsage              uintptr
+	peakPagefileUsage          uintptr
+}
+
+// readProcessUsage 通过 GetProcessTimes 和 GetProcessMemoryInfo 读取 CPU 时间和工作集内存
+func readProcessUsage(pid int) (processUsage, error) {
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return processUsage{}, err
+	}
+	defer syscall.CloseHandle(handle)
+
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return processUsage{}, err
+	}
+
+	var counters processMemoryCounters
+	counters.cb = uint32(unsafe.Sizeof(counters))
+	if ret, _, err := procGetProcessMemoryInfo.Call(uintptr(handle), uintptr(unsafe.Pointer(&counters)), uintptr(counters.cb)); ret == 0 {
+		return processUsage{}, err
+	}
+
+	return processUsage{
+		cpuTime: filetimeDuration(kernel) + filetimeDuration(user),
+		rss:     uint64(counters.workingSetSize),
+	}, nil
+}
+
+// filetimeDuration 将以 100 纳秒为单位的 FILETIME 转换为时长
+func filetimeDuration(ft syscall.Filetime) time.Duration {
+	return time.Duration(uint64(ft.HighDateTime)<<32|uint64(ft.LowDateTime)) * 100
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// maxRestartBackoff 自动重启退避的上限
+const maxRestartBackoff = time.Minute
+
+// RestartPolicy 进程异常退出后的自动重启策略
+type RestartPolicy struct {
+	Enabled    bool
+	MaxRetries int           // 时间窗口内最多重启次数，0 表示不限
+	Backoff    time.Duration // 第一次重启前的等待时间，之后每次翻倍
+	Window     time.Duration // 统计重启次数的时间窗口，超出窗口的重启不再计数
+}
+
+// RestartPolicyFromSettings 根据应用设置生成自动重启策略
+func RestartPolicyFromSettings(settings *config.AppSettings, enabled bool) RestartPolicy {
+	return RestartPolicy{
+		Enabled:    enabled,
+		MaxRetries: settings.RestartMaxRetries,
+		Backoff:    time.Duration(settings.RestartBackoff) * time.Second,
+		Window:     time.Duration(settings.RestartWindow) * time.Second,
+	}
+}
+
+// RestartEvent 自动重启事件
+type RestartEvent struct {
+	Service   string // "server" 或 "client"
+	Attempt   int
+	Delay     time.Duration
+	Restarted bool  // 已重新启动
+	GaveUp    bool  // 达到最大重启次数，放弃重启
+	Err       error // 重启失败的原因
+	Time      time.Time
+}
+
+// Message 返回适合显示在状态栏的描述
+func (e RestartEvent) Message() string {
+	name := serviceDisplayName(e.Service)
+	switch {
+	case e.GaveUp:
+		return i18n.Sprintf("%s 频繁崩溃，已重启 %d 次，停止自动重启", name, e.Attempt)
+	case e.Err != nil:
+		return i18n.Sprintf("%s 第 %d 次自动重启失败: %v", name, e.Attempt, e.Err)
+	case e.Restarted:
+		return i18n.Sprintf("%s 已自动重启（第 %d 次）", name, e.Attempt)
+	default:
+		return i18n.Sprintf("%s 异常退出，%s 后第 %d 次自动重启", name, e.Delay, e.Attempt)
+	}
+}
+
+// serviceDisplayName 返回服务的显示名称
+func serviceDisplayName(service string) string {
+	if service == "server" {
+		return i18n.T("FRP 服务端")
+	}
+	return i18n.T("FRP 客户端")
+}
+
+// SetRestartPolicy 设置服务的自动重启策略
+func (m *Manager) SetRestartPolicy(service string, policy RestartPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.restartPolicies[service] = policy
+}
+
+// RestartEvents 自动重启事件通道
+func (m *Manager) RestartEvents() <-chan RestartEvent {
+	return m.restartEvents
+}
+
+// emitRestartEvent 非阻塞地发送重启事件并写入日志
+func (m *Manager) emitRestartEvent(event RestartEvent) {
+	event.Time = time.Now()
+
+	level := "WARN"
+	if event.Restarted {
+		level = "INFO"
+	} else if event.GaveUp || event.Err != nil {
+		level = "ERROR"
+	}
+	m.sendLog(level, event.Message(), event.Service)
+
+	select {
+	case m.restartEvents <- event:
+	default:
+	}
+}
+
+// autoRestart 按策略在退避后重新启动异常退出的进程，期间用户主动停止或手动启动时放弃
+func (m *Manager) autoRestart(service, configPath string, exitedAt time.Time) {
+	m.mu.Lock()
+	policy := m.restartPolicies[service]
+	if !policy.Enabled {
+		m.mu.Unlock()
+		return
+	}
+
+	// 只统计时间窗口内的重启次数
+	now := time.Now()
+	var recent []time.Time
+	for _, at := range m.restartHistory[service] {
+		if policy.Window <= 0 || now.Sub(at) < policy.Window {
+			recent = append(recent, at)
+		}
+	}
+
+	if policy.MaxRetries > 0 && len(recent) >= policy.MaxRetries {
+		m.restartHistory[service] = recent
+		m.mu.Unlock()
+		m.emitRestartEvent(RestartEvent{Service: service, Attempt: len(recent), GaveUp: true})
+		return
+	}
+
+	attempt := len(recent) + 1
+	m.restartHistory[service] = append(recent, now)
+	m.mu.Unlock()
+
+	delay := policy.Backoff
+	for i := 1; i < attempt && delay < maxRestartBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRestartBackoff {
+		delay = maxRestartBackoff
+	}
+
+	m.emitRestartEvent(RestartEvent{Service: service, Attempt: attempt, Delay: delay})
+	select {
+	case <-time.After(delay):
+	case <-m.ctx.Done():
+		// 管理器已关闭，程序正在退出
+		return
+	}
+
+	if m.StoppedAt(service).After(exitedAt) {
+		m.sendLog("INFO", i18n.Sprintf("%s 已被手动停止，取消自动重启", serviceDisplayName(service)), service)
+		return
+	}
+
+	var err error
+	switch service {
+	case "server":
+		if m.GetServerStatus().IsRunning {
+			return
+		}
+		err = m.StartServer(configPath)
+	case "client":
+		if m.GetClientStatus().IsRunning {
+			return
+		}
+		err = m.StartClient(configPath)
+	}
+
+	m.emitRestartEvent(RestartEvent{Service: service, Attempt: attempt, Restarted: err == nil, Err: err})
+	if err != nil {
+		// 启动失败同样计入重启次数，继续按策略重试
+		m.autoRestart(service, configPath, exitedAt)
+	}
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// scheduleInterval 检查时间窗口的间隔
+const scheduleInterval = 15 * time.Second
+
+// ScheduleEvent 自动启动或按时间窗口停止服务的事件
+type ScheduleEvent struct {
+	Profile string
+	Service string // "server" 或 "client"
+	Started bool   // 进入时间窗口或应用启动时已启动服务
+	Stopped bool   // 离开时间窗口后已停止服务
+	Err     error
+	Time    time.Time
+}
+
+// Message 返回适合显示在状态栏的描述
+func (e ScheduleEvent) Message() string {
+	name := serviceDisplayName(e.Service)
+	switch {
+	case e.Err != nil:
+		return i18n.Sprintf("自动启动 %s 失败: %v", e.Profile, e.Err)
+	case e.Stopped:
+		return i18n.Sprintf("%s 已离开时间窗口，%s 已停止", e.Profile, name)
+	default:
+		return i18n.Sprintf("%s 已自动启动 %s", e.Profile, name)
+	}
+}
+
+// ScheduleStatus 自动启动配置的当前状态，用于界面显示
+type ScheduleStatus struct {
+	Profile    config.AutostartProfile
+	ConfigPath string
+	InWindow   bool // 当前在时间窗口内
+	Running    bool // 对应服务正在使用该配置文件运行
+	Scheduled  bool // 服务由调度器启动
+}
+
+// Scheduler 按自动启动配置在应用启动和进入时间窗口时启动服务，
+// 离开时间窗口时停止由它启动的服务
+type Scheduler struct {
+	manager *Manager
+	events  chan ScheduleEvent
+
+	mu       sync.Mutex
+	settings *config.AppSettings
+	active   map[string]bool   // 上一轮各配置是否处于启用且在窗口内
+	started  map[string]string // 服务 -> 启动它的配置名
+	cancel   context.CancelFunc
+	reload   chan struct{}
+}
+
+// NewScheduler 创建调度器
+func NewScheduler(manager *Manager) *Scheduler {
+	return &Scheduler{
+		manager: manager,
+		events:  make(chan ScheduleEvent, 20),
+		active:  make(map[string]bool),
+		started: make(map[string]string),
+		reload:  make(chan struct{}, 1),
+	}
+}
+
+// SetSettings 更新自动启动配置，调度器运行时立即重新检查
+func (s *Scheduler) SetSettings(settings *config.AppSettings) {
+	s.mu.Lock()
+	s.settings = settings
+	s.mu.Unlock()
+
+	select {
+	case s.reload <- struct{}{}:
+	default:
+	}
+}
+
+// Events 调度事件通道
+func (s *Scheduler) Events() <-chan ScheduleEvent {
+	return s.events
+}
+
+// Start 在后台运行调度，启动时立即检查一次，重复调用时忽略
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go s.run(ctx)
+}
+
+// Stop 停止调度，已启动的服务保持运行
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
+
+// run 定期检查时间窗口，设置变化时立即检查
+func (s *Scheduler) run(ctx context.Context) {
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+
+	s.Evaluate(time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.reload:
+			s.Evaluate(time.Now())
+		case now := <-ticker.C:
+			s.Evaluate(now)
+		}
+	}
+}
+
+// Evaluate 对比上一轮的状态：配置刚变为启用且在窗口内时启动服务，
+// 离开时间窗口时停止由调度器启动的服务。用户手动停止的服务要等下一个窗口才会再次启动
+func (s *Scheduler) Evaluate(now time.Time) {
+	s.mu.Lock()
+	settings := s.settings
+	s.mu.Unlock()
+	if settings == nil {
+		return
+	}
+
+	for _, profile := range settings.Autostart {
+		inWindow := profile.InWindow(now)
+		active := profile.Enabled && inWindow
+
+		s.mu.Lock()
+		wasActive := s.active[profile.Name]
+		s.active[profile.Name] = active
+		startedBy := s.started[profile.Service]
+		s.mu.Unlock()
+
+		configPath := settings.AutostartConfigPath(profile)
+		switch {
+		case active && !wasActive:
+			s.start(profile, configPath)
+		case profile.Enabled && !inWindow && startedBy == profile.Name:
+			s.stop(profile, configPath)
+		}
+	}
+}
+
+// start 启动配置对应的服务，服务已在运行时跳过
+func (s *Scheduler) start(profile config.AutostartProfile, configPath string) {
+	if s.serviceStatus(profile.Service).IsRunning {
+		return
+	}
+
+	var err error
+	if profile.Service == "server" {
+		err = s.manager.StartServer(configPath)
+	} else {
+		err = s.manager.StartClient(configPath)
+	}
+	if err == nil {
+		s.mu.Lock()
+		s.started[profile.Service] = profile.Name
+		s.mu.Unlock()
+	}
+	s.emit(ScheduleEvent{Profile: profile.Name, Service: profile.Service, Started: err == nil, Err: err})
+}
+
+// stop 停止调度器启动的服务，用户已手动停止或改用其他配置文件运行时只清除记录
+func (s *Scheduler) stop(profile config.AutostartProfile, configPath string) {
+	s.mu.Lock()
+	delete(s.started, profile.Service)
+	s.mu.Unlock()
+
+	state := s.manager.GetProcessState(profile.Service)
+	if state == nil || !samePath(state.ConfigPath, configPath) {
+		return
+	}
+
+	var err error
+	if profile.Service == "server" {
+		err = s.manager.StopServer()
+	} else {
+		err = s.manager.StopClient()
+	}
+	s.emit(ScheduleEvent{Profile: profile.Name, Service: profile.Service, Stopped: err == nil, Err: err})
+}
+
+// serviceStatus 返回服务的运行状态
+func (s *Scheduler) serviceStatus(service string) ProcessStatus {
+	if service == "server" {
+		return s.manager.GetServerStatus()
+	}
+	return s.manager.GetClientStatus()
+}
+
+// emit 非阻塞地发送调度事件并写入日志
+func (s *Scheduler) emit(event ScheduleEvent) {
+	event.Time = time.Now()
+
+	level := "INFO"
+	if event.Err != nil {
+		level = "ERROR"
+	}
+	s.manager.sendLog(level, event.Message(), event.Service)
+
+	select {
+	case s.events <- event:
+	default:
+	}
+}
+
+// Statuses 返回各自动启动配置的当前状态
+func (s *Scheduler) Statuses(now time.Time) []ScheduleStatus {
+	s.mu.Lock()
+	settings := s.settings
+	started := make(map[string]string, len(s.started))
+	for service, name := range s.started {
+		started[service] = name
+	}
+	s.mu.Unlock()
+	if settings == nil {
+		return nil
+	}
+
+	statuses := make([]ScheduleStatus, 0, len(settings.Autostart))
+	for _, profile := range settings.Autostart {
+		status := ScheduleStatus{
+			Profile:    profile,
+			ConfigPath: settings.AutostartConfigPath(profile),
+			InWindow:   profile.InWindow(now),
+			Scheduled:  started[profile.Service] == profile.Name,
+		}
+		if state := s.manager.GetProcessState(profile.Service); state != nil {
+			status.Running = samePath(state.ConfigPath, status.ConfigPath)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
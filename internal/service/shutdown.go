@@ -0,0 +1,68 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// ShutdownProgressFunc 关闭进度回调
+type ShutdownProgressFunc func(step string)
+
+// HasManagedProcesses 是否有本工具启动或重新接管、仍在运行的进程
+func (m *Manager) HasManagedProcesses() bool {
+	return m.GetServerStatus().IsRunning || m.GetClientStatus().IsRunning
+}
+
+// Shutdown 停止本工具管理的服务端和客户端进程，不会影响外部启动的进程。
+// 先请求进程正常退出，超过 timeout 仍未退出时强制结束
+func (m *Manager) Shutdown(timeout time.Duration, progress ShutdownProgressFunc) error {
+	report := func(step string) {
+		if progress != nil {
+			progress(step)
+		}
+	}
+
+	var errs []string
+	for _, proc := range m.takeManagedProcesses() {
+		label := serviceDisplayName(proc.service)
+		report(i18n.Sprintf("正在停止 %s (PID: %d)...", label, proc.pid))
+
+		env := hookEnv{pid: proc.pid, configPath: proc.configPath}
+		_ = m.runHook(proc.service, HookPreStop, env)
+		forced, err := m.terminate(proc, timeout)
+		if err == nil {
+			_ = m.runHook(proc.service, HookPostStop, env)
+		}
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Sprintf("%s: %v", label, err))
+			report(i18n.Sprintf("❌ 停止 %s 失败: %v", label, err))
+		case forced:
+			report(i18n.Sprintf("⚠️ %s 未在 %s 内退出，已强制结束", label, timeout))
+		default:
+			report(i18n.Sprintf("✅ %s 已停止", label))
+		}
+	}
+
+	if len(errs) > 0 {
+		return i18n.Errorf("停止进程失败: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// takeManagedProcesses 取出仍在运行的受管进程并清理记录，避免监控协程把退出当成崩溃重启
+func (m *Manager) takeManagedProcesses() []managedProcess {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var procs []managedProcess
+	for _, service := range []string{"server", "client"} {
+		if proc, ok := m.takeProcessLocked(service); ok {
+			procs = append(procs, proc)
+		}
+	}
+	return procs
+}
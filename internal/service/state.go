@@ -0,0 +1,107 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// ProcessState 持久化的进程状态，用于应用重启后重新接管进程
+type ProcessState struct {
+	PID        int       `json:"pid"`
+	ConfigPath string    `json:"configPath"`
+	StartTime  time.Time `json:"startTime"`
+}
+
+// processStateFile 状态文件内容
+type processStateFile struct {
+	Server *ProcessState `json:"server,omitempty"`
+	Client *ProcessState `json:"client,omitempty"`
+}
+
+// GetStateFilePath 获取进程状态文件路径
+func GetStateFilePath() string {
+	return filepath.Join(config.GetDefaultWorkDir(), "state.json")
+}
+
+// loadProcessState 读取进程状态文件，文件不存在时返回空状态
+func loadProcessState(path string) (*processStateFile, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &processStateFile{}, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取状态文件失败: %w", err)
+	}
+
+	var state processStateFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, i18n.Errorf("解析状态文件失败: %w", err)
+	}
+
+	return &state, nil
+}
+
+// saveProcessState 写入进程状态文件，先写临时文件再重命名以避免写坏
+func saveProcessState(path string, state *processStateFile) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return i18n.Errorf("创建状态目录失败: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return i18n.Errorf("序列化状态失败: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return i18n.Errorf("写入状态文件失败: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return i18n.Errorf("替换状态文件失败: %w", err)
+	}
+
+	return nil
+}
+
+// isFRPProcessAlive 检查 PID 对应的进程是否仍在运行且确实是指定的 FRP 程序，
+// 通过进程名校验避免 PID 被其他进程复用时误判
+func isFRPProcessAlive(pid int, processName string) bool {
+	if pid <= 0 {
+		return false
+	}
+
+	switch runtime.GOOS {
+	case "linux":
+		data, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+		if err != nil {
+			return false
+		}
+		return strings.Contains(strings.TrimSpace(string(data)), processName)
+	case "darwin":
+		output, err := exec.Command("ps", "-p", strconv.Itoa(pid), "-o", "comm=").Output()
+		if err != nil {
+			return false
+		}
+		return strings.Contains(filepath.Base(strings.TrimSpace(string(output))), processName)
+	case "windows":
+		output, err := exec.Command("tasklist", "/FI", fmt.Sprintf("PID eq %d", pid), "/FO", "CSV", "/NH").Output()
+		if err != nil {
+			return false
+		}
+		return strings.Contains(string(output), processName)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// DefaultStatusAddr 无界面模式状态页的默认监听地址，只允许本机访问
+const DefaultStatusAddr = "127.0.0.1:7600"
+
+// statusCacheTTL 状态快照的缓存时间，避免频繁刷新时反复请求 frps Dashboard API
+const statusCacheTTL = 2 * time.Second
+
+// StatusProcess 状态页中的进程信息
+type StatusProcess struct {
+	Running    bool       `json:"running"`
+	PID        int        `json:"pid,omitempty"`
+	ConfigPath string     `json:"configPath,omitempty"`
+	StartTime  *time.Time `json:"startTime,omitempty"`
+	CPUPercent float64    `json:"cpuPercent,omitempty"`  // 启用资源监控时的 CPU 占用
+	MemoryRSS  int64      `json:"memoryBytes,omitempty"` // 启用资源监控时的常驻内存
+}
+
+// StatusProxy 状态页中的代理信息
+type StatusProxy struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	RemotePort int    `json:"remotePort,omitempty"`
+	CurConns   int    `json:"curConns"`
+	TrafficIn  int64  `json:"todayTrafficIn"`
+	TrafficOut int64  `json:"todayTrafficOut"`
+}
+
+// StatusLatency 状态页中最近一次延迟采样，单位毫秒，测量失败为 -1
+type StatusLatency struct {
+	Target      string  `json:"target"`
+	ConnectMs   float64 `json:"connectMs"`
+	DashboardMs float64 `json:"dashboardMs"`
+	Warning     string  `json:"warning,omitempty"`
+}
+
+// StatusSnapshot 无界面模式状态页的内容
+type StatusSnapshot struct {
+	App             string         `json:"app"`
+	Version         string         `json:"version"`
+	Time            time.Time      `json:"time"`
+	Server          StatusProcess  `json:"server"`
+	Client          StatusProcess  `json:"client"`
+	DashboardURL    string         `json:"dashboardURL"`
+	DashboardTarget string         `json:"dashboardTarget,omitempty"`
+	DashboardError  string         `json:"dashboardError,omitempty"`
+	FrpsVersion     string         `json:"frpsVersion,omitempty"`
+	Proxies         []StatusProxy  `json:"proxies"`
+	Alerts          []Alert        `json:"alerts"`
+	Latency         *StatusLatency `json:"latency,omitempty"`
+}
+
+// StatusServer 只读的 HTTP 状态页，/ 返回 HTML，/api/status 返回 JSON
+type StatusServer struct {
+	manager   *Manager
+	apiClient *APIClient
+	monitor   *HealthMonitor
+	latency   *LatencyMonitor
+
+	mu       sync.Mutex
+	cached   *StatusSnapshot
+	cachedAt time.Time
+	server   *http.Server
+}
+
+// NewStatusServer 创建状态页，monitor 和 latency 可以为 nil
+func NewStatusServer(manager *Manager, apiClient *APIClient, monitor *HealthMonitor, latency *LatencyMonitor) *StatusServer {
+	return &StatusServer{
+		manager:   manager,
+		apiClient: apiClient,
+		monitor:   monitor,
+		latency:   latency,
+	}
+}
+
+// Start 监听地址并在后台提供状态页，监听失败时直接返回错误
+func (s *StatusServer) Start(addr string) (string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", i18n.Errorf("监听状态页地址 %s 失败: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleIndex)
+	mux.HandleFunc("/api/status", s.handleJSON)
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.manager.sendLog("ERROR", i18n.Sprintf("状态页异常退出: %v", err), "status")
+		}
+	}()
+	return listener.Addr().String(), nil
+}
+
+// Stop 关闭状态页
+func (s *StatusServer) Stop() {
+	if s.server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = s.server.Shutdown(ctx)
+	s.server = nil
+}
+
+// Snapshot 收集当前状态，短时间内的重复请求使用缓存
+func (s *StatusServer) Snapshot() *StatusSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cached != nil && time.Since(s.cachedAt) < statusCacheTTL {
+		return s.cached
+	}
+
+	snapshot := &StatusSnapshot{
+		App:     config.AppName,
+		Version: config.AppVersion,
+		Time:    time.Now(),
+		Server:  statusProcess(s.manager.GetServerStatus(), s.manager.GetProcessState("server")),
+		Client:  statusProcess(s.manager.GetClientStatus(), s.manager.GetProcessState("client")),
+		Proxies: []StatusProxy{},
+		Alerts:  []Alert{},
+	}
+
+	if s.apiClient != nil {
+		snapshot.DashboardURL = s.apiClient.BaseURL()
+		snapshot.DashboardTarget = s.apiClient.TargetName()
+		if info, err := s.apiClient.GetServerInfo(); err != nil {
+			snapshot.DashboardError = err.Error()
+		} else {
+			snapshot.FrpsVersion = info.Version
+			if proxies, err := s.apiClient.GetProxyList(); err != nil {
+				snapshot.DashboardError = err.Error()
+			} else {
+				for _, proxy := range proxies {
+					snapshot.Proxies = append(snapshot.Proxies, StatusProxy{
+						Name:       proxy.Name,
+						Type:       proxy.Conf.Type,
+						Status:     proxy.Status,
+						RemotePort: proxy.Conf.RemotePort,
+						CurConns:   proxy.CurConns,
+						TrafficIn:  proxy.TodayTrafficIn,
+						TrafficOut: proxy.TodayTrafficOut,
+					})
+				}
+			}
+		}
+	}
+
+	if s.monitor != nil {
+		snapshot.Alerts = append(snapshot.Alerts, s.monitor.ActiveAlerts()...)
+	}
+	if s.latency != nil {
+		if samples := s.latency.Samples(); len(samples) > 0 {
+			last := samples[len(samples)-1]
+			snapshot.Latency = &StatusLatency{
+				Target:      s.latency.Target(),
+				ConnectMs:   durationMs(last.Connect),
+				DashboardMs: durationMs(last.Dashboard),
+				Warning:     s.latency.Warning(),
+			}
+		}
+	}
+
+	s.cached, s.cachedAt = snapshot, time.Now()
+	return snapshot
+}
+
+// statusProcess 转换进程状态
+func statusProcess(status ProcessStatus, state *ProcessState) StatusProcess {
+	result := StatusProcess{
+		Running:    status.IsRunning,
+		PID:        status.PID,
+		CPUPercent: status.CPU,
+		MemoryRSS:  int64(status.Memory),
+	}
+	if !status.StartTime.IsZero() {
+		result.StartTime = &status.StartTime
+	}
+	if state != nil {
+		result.ConfigPath = state.ConfigPath
+	}
+	return result
+}
+
+// durationMs 将延迟转换为毫秒，测量失败时保持 -1
+func durationMs(d time.Duration) float64 {
+	if d < 0 {
+		return -1
+	}
+	return float64(d.Microseconds()) / 1000
+}
+
+// allowReadOnly 状态页只读，拒绝 GET/HEAD 以外的请求
+func allowReadOnly(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+	w.Header().Set("Allow", "GET, HEAD")
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	return false
+}
+
+// handleJSON 返回 JSON 格式的状态
+func (s *StatusServer) handleJSON(w http.ResponseWriter, r *http.Request) {
+	if !allowReadOnly(w, r) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(s.Snapshot())
+}
+
+// handleIndex 返回 HTML 状态页，每 10 秒自动刷新
+func (s *StatusServer) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if !allowReadOnly(w, r) {
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = statusPageTemplate.Execute(w, map[string]any{
+		"S": s.Snapshot(),
+		"L": statusPageLabels(),
+	})
+}
+
+// statusPageLabels 状态页中的文字，按当前语言翻译
+func statusPageLabels() map[string]string {
+	return map[string]string{
+		"Title":     i18n.T("FRP 状态"),
+		"Updated":   i18n.T("更新于"),
+		"Server":    i18n.T("服务端"),
+		"Client":    i18n.T("客户端"),
+		"Running":   i18n.T("运行中"),
+		"Stopped":   i18n.T("未运行"),
+		"Config":    i18n.T("配置"),
+		"Alerts":    i18n.T("告警"),
+		"NoAlerts":  i18n.T("没有告警"),
+		"Latency":   i18n.T("到 frps 的延迟"),
+		"Connect":   i18n.T("连接"),
+		"Dashboard": i18n.T("面板"),
+		"Proxies":   i18n.T("代理"),
+		"NoProxies": i18n.T("没有代理"),
+		"Name":      i18n.T("名称"),
+		"Type":      i18n.T("类型"),
+		"Status":    i18n.T("状态"),
+		"Port":      i18n.T("远程端口"),
+		"Conns":     i18n.T("连接数"),
+		"In":        i18n.T("今日上行"),
+		"Out":       i18n.T("今日下行"),
+		"Failed":    i18n.T("失败"),
+	}
+}
+
+// statusPageTemplate HTML 状态页模板，不依赖外部资源
+var statusPageTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"traffic": FormatTraffic,
+	"datetime": func(t time.Time) string {
+		return t.Format(time.DateTime)
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="10">
+<title>{{.L.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 10px; text-align: left; }
+th { background: #f3f3f3; }
+.ok { color: #1a7f37; } .bad { color: #cf222e; } .warn { color: #9a6700; } .muted { color: #777; }
+</style>
+</head>
+<body>
+<h1>{{.L.Title}}</h1>
+<p class="muted">{{.S.App}} {{.S.Version}} · {{.L.Updated}} {{datetime .S.Time}} · <a href="/api/status">JSON</a></p>
+
+<table>
+<tr><th>{{.L.Server}}</th>{{with .S.Server}}<td class="{{if .Running}}ok{{else}}muted{{end}}">{{if .Running}}{{$.L.Running}} (PID {{.PID}}{{if .MemoryRSS}} · CPU {{printf "%.1f" .CPUPercent}}% · RSS {{traffic .MemoryRSS}}{{end}}){{else}}{{$.L.Stopped}}{{end}}</td><td>{{.ConfigPath}}</td>{{end}}</tr>
+<tr><th>{{.L.Client}}</th>{{with .S.Client}}<td class="{{if .Running}}ok{{else}}muted{{end}}">{{if .Running}}{{$.L.Running}} (PID {{.PID}}{{if .MemoryRSS}} · CPU {{printf "%.1f" .CPUPercent}}% · RSS {{traffic .MemoryRSS}}{{end}}){{else}}{{$.L.Stopped}}{{end}}</td><td>{{.ConfigPath}}</td>{{end}}</tr>
+<tr><th>Dashboard</th><td colspan="2">{{with .S.DashboardTarget}}{{.}} · {{end}}{{.S.DashboardURL}} {{if .S.DashboardError}}<span class="bad">{{.S.DashboardError}}</span>{{else}}<span class="ok">frps {{.S.FrpsVersion}}</span>{{end}}</td></tr>
+</table>
+
+<h2>{{.L.Alerts}}</h2>
+{{if .S.Alerts}}<ul>{{range .S.Alerts}}<li class="{{if eq .Severity "critical"}}bad{{else}}warn{{end}}"><b>{{.Title}}</b> {{.Message}} <span class="muted">{{datetime .Time}}</span></li>{{end}}</ul>
+{{else}}<p class="ok">{{.L.NoAlerts}}</p>{{end}}
+
+{{with .S.Latency}}<h2>{{$.L.Latency}}</h2>
+<p>{{.Target}} · {{$.L.Connect}} {{if lt .ConnectMs 0.0}}<span class="bad">{{$.L.Failed}}</span>{{else}}{{printf "%.1f" .ConnectMs}}ms{{end}} · {{$.L.Dashboard}} {{if lt .DashboardMs 0.0}}<span class="bad">{{$.L.Failed}}</span>{{else}}{{printf "%.1f" .DashboardMs}}ms{{end}}</p>
+{{if .Warning}}<p class="warn">{{.Warning}}</p>{{end}}{{end}}
+
+<h2>{{.L.Proxies}}</h2>
+{{if .S.Proxies}}<table>
+<tr><th>{{.L.Name}}</th><th>{{.L.Type}}</th><th>{{.L.Status}}</th><th>{{.L.Port}}</th><th>{{.L.Conns}}</th><th>{{.L.In}}</th><th>{{.L.Out}}</th></tr>
+{{range .S.Proxies}}<tr><td>{{.Name}}</td><td>{{.Type}}</td><td class="{{if eq .Status "online"}}ok{{else}}bad{{end}}">{{.Status}}</td><td>{{if .RemotePort}}{{.RemotePort}}{{end}}</td><td>{{.CurConns}}</td><td>{{traffic .TrafficIn}}</td><td>{{traffic .TrafficOut}}</td></tr>
+{{end}}</table>
+{{else}}<p class="muted">{{.L.NoProxies}}</p>{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"syscall"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// stopTimeout 手动停止服务时等待进程正常退出的时间
+const stopTimeout = 5 * time.Second
+
+// managedProcess 需要停止的受管进程
+type managedProcess struct {
+	service    string
+	name       string
+	pid        int
+	configPath string
+	process    *os.Process // 本次启动的进程，重新接管的进程为 nil
+	cancel     func()
+}
+
+// takeProcessLocked 取出服务仍在运行的受管进程并清理记录，记录停止时间以免被当成崩溃重启，调用方需持有锁
+func (m *Manager) takeProcessLocked(service string) (managedProcess, bool) {
+	proc := managedProcess{service: service, name: "frps"}
+	cmd, state, cancel := m.serverCmd, m.serverState, m.serverCancel
+	if service == "client" {
+		proc.name = "frpc"
+		cmd, state, cancel = m.clientCmd, m.clientState, m.clientCancel
+	}
+
+	status := m.processStatusLocked(cmd, state, proc.name)
+	if !status.IsRunning {
+		return proc, false
+	}
+
+	proc.pid = status.PID
+	proc.cancel = cancel
+	if cmd != nil {
+		proc.process = cmd.Process
+	}
+	if state != nil {
+		proc.configPath = state.ConfigPath
+	}
+
+	if service == "server" {
+		m.serverCmd, m.serverState, m.serverCancel = nil, nil, nil
+		m.isRunning = false
+	} else {
+		m.clientCmd, m.clientState, m.clientCancel = nil, nil, nil
+	}
+	m.stoppedAt[service] = time.Now()
+	m.persistStateLocked()
+
+	return proc, true
+}
+
+// terminate 请求进程正常退出并等待，超时或无法正常停止时强制结束进程及其子进程，forced 表示使用了强制结束
+func (m *Manager) terminate(proc managedProcess, timeout time.Duration) (forced bool, err error) {
+	defer m.releaseContext(proc)
+
+	if err := m.requestStop(proc); err != nil {
+		m.sendLog("WARN", i18n.Sprintf("无法正常停止 %s，将强制结束: %v", proc.name, err), proc.service)
+		return true, forceKill(proc)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if !isFRPProcessAlive(proc.pid, proc.name) {
+			return false, nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	return true, forceKill(proc)
+}
+
+// requestStop 请求进程正常退出。Unix 发送 SIGTERM；Windows 没有 SIGTERM，
+// frpc 优先调用管理接口 /api/stop，本次启动的进程发送 CTRL_BREAK 事件
+func (m *Manager) requestStop(proc managedProcess) error {
+	if runtime.GOOS != "windows" {
+		process := proc.process
+		if process == nil {
+			var err error
+			if process, err = os.FindProcess(proc.pid); err != nil {
+				return err
+			}
+		}
+		return process.Signal(syscall.SIGTERM)
+	}
+
+	if proc.service == "client" && proc.configPath != "" {
+		if err := stopClientViaAdmin(proc.configPath); err == nil {
+			return nil
+		}
+	}
+	if proc.process != nil {
+		return sendCtrlBreak(proc.pid)
+	}
+	return i18n.NewError("上次运行时启动的进程无法接收 CTRL_BREAK 事件")
+}
+
+// stopClientViaAdmin 通过 frpc 管理接口请求客户端退出
+func stopClientViaAdmin(configPath string) error {
+	cfg, err := config.NewLoader(configPath).Load()
+	if err != nil {
+		return err
+	}
+	client, err := NewClientAPIClientFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+	return client.Stop()
+}
+
+// forceKill 强制结束进程，Windows 上同时结束其子进程，避免残留 frpc.exe
+func forceKill(proc managedProcess) error {
+	if runtime.GOOS == "windows" {
+		if err := killProcessTree(proc.pid); err == nil || !isFRPProcessAlive(proc.pid, proc.name) {
+			return nil
+		}
+	}
+
+	process := proc.process
+	if process == nil {
+		var err error
+		if process, err = os.FindProcess(proc.pid); err != nil {
+			return err
+		}
+	}
+	if err := process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return i18n.Errorf("强制停止进程失败: %w", err)
+	}
+	return nil
+}
+
+// killProcessTree 使用 taskkill 结束进程及其子进程
+func killProcessTree(pid int) error {
+	output, err := exec.Command("taskkill", "/F", "/T", "/PID", strconv.Itoa(pid)).CombinedOutput()
+	if err != nil {
+		return i18n.Errorf("taskkill 失败: %v %s", err, output)
+	}
+	return nil
+}
+
+// releaseContext 进程退出后释放其上下文
+func (m *Manager) releaseContext(proc managedProcess) {
+	if proc.cancel != nil {
+		proc.cancel()
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"frp-cli-ui/internal/remote"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// RotationTarget 令牌轮换涉及的一份配置，位于本机或通过 SSH 管理的远程服务器
+type RotationTarget struct {
+	Name       string
+	Server     bool                  // 是否为 frps 配置
+	ConfigPath string                // 本机配置路径，远程目标为空
+	Remote     *config.RemoteProfile // 远程服务器，本机目标为空
+}
+
+// LocalRotationTarget 创建本机配置的轮换目标
+func LocalRotationTarget(server bool, configPath string) RotationTarget {
+	name := i18n.T("本机客户端")
+	if server {
+		name = i18n.T("本机服务端")
+	}
+	return RotationTarget{Name: name, Server: server, ConfigPath: configPath}
+}
+
+// RemoteRotationTarget 创建远程服务器的轮换目标
+func RemoteRotationTarget(profile config.RemoteProfile) RotationTarget {
+	return RotationTarget{Name: profile.Name, Server: !profile.IsClient(), Remote: &profile}
+}
+
+// Location 返回配置所在的位置，用于清单显示
+func (t RotationTarget) Location() string {
+	if t.Remote != nil {
+		return t.Remote.Host + ":" + t.Remote.RemoteConfigPath
+	}
+	return t.ConfigPath
+}
+
+// ReadToken 读取目标配置当前的认证令牌
+func (t RotationTarget) ReadToken() (string, error) {
+	if t.Remote == nil {
+		cfg, err := config.NewLoader(t.ConfigPath).Load()
+		if err != nil {
+			return "", err
+		}
+		return cfg.Auth.Token, nil
+	}
+
+	client, err := remote.Dial(*t.Remote)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	cfg, _, err := readRemoteConfig(client, *t.Remote)
+	if err != nil {
+		return "", err
+	}
+	return cfg.Auth.Token, nil
+}
+
+// readRemoteConfig 读取并解析远程配置，同时返回原始内容，写回时用于保留注释和未识别的字段
+func readRemoteConfig(client *remote.Client, profile config.RemoteProfile) (*config.Config, []byte, error) {
+	data, err := client.ReadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg, err := config.UnmarshalConfig(data, config.DetectFormat(profile.RemoteConfigPath))
+	if err != nil {
+		return nil, nil, i18n.Errorf("解析 %s 失败: %w", profile.RemoteConfigPath, err)
+	}
+	return cfg, data, nil
+}
+
+// RotateToken 将目标配置的认证令牌改为 token 并重启对应的服务使其生效，返回执行结果说明。
+// frpc 重新登录服务端时才会使用新令牌，热重载不会生效，因此客户端同样需要重启
+func (m *Manager) RotateToken(target RotationTarget, token string) (string, error) {
+	if target.Remote != nil {
+		return rotateRemoteToken(*target.Remote, token)
+	}
+
+	loader := config.NewLoader(target.ConfigPath)
+	cfg, err := loader.Load()
+	if err != nil {
+		return "", err
+	}
+	cfg.Auth.Token = token
+	if err := loader.Save(cfg); err != nil {
+		return "", err
+	}
+
+	role, status := "client", m.GetClientStatus()
+	if target.Server {
+		role, status = "server", m.GetServerStatus()
+	}
+	m.events.Publish(ConfigSavedEvent(role, target.ConfigPath))
+
+	if !status.IsRunning {
+		return i18n.T("配置已保存（未运行）"), nil
+	}
+	if state := m.GetProcessState(role); state != nil && !samePath(state.ConfigPath, target.ConfigPath) {
+		return i18n.Sprintf("配置已保存，运行中的进程使用 %s，未重启", state.ConfigPath), nil
+	}
+	if err := m.Restart(role, target.ConfigPath); err != nil {
+		return "", i18n.Errorf("配置已保存，但重启失败: %w", err)
+	}
+	return i18n.T("配置已保存并重启"), nil
+}
+
+// rotateRemoteToken 在远程服务器上改写认证令牌并重启服务
+func rotateRemoteToken(profile config.RemoteProfile, token string) (string, error) {
+	client, err := remote.Dial(profile)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	cfg, data, err := readRemoteConfig(client, profile)
+	if err != nil {
+		return "", err
+	}
+	cfg.Auth.Token = token
+
+	updated, err := config.MarshalConfigPreserving(data, cfg, config.DetectFormat(profile.RemoteConfigPath))
+	if err != nil {
+		return "", err
+	}
+	if err := client.UploadConfig(updated); err != nil {
+		return "", err
+	}
+	if _, err := client.RestartService(); err != nil {
+		return "", i18n.Errorf("配置已上传，但重启失败: %w", err)
+	}
+	return i18n.Sprintf("配置已上传，%s 已重启", profile.ServiceName), nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"frp-cli-ui/pkg/config"
+)
+
+func TestRotateTokenRewritesAuthToken(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	path := filepath.Join(t.TempDir(), "frps.toml")
+	original := `bindPort = 7000
+
+[auth]
+# shared with every frpc
+token = "old"
+`
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	target := LocalRotationTarget(true, path)
+	token, err := target.ReadToken()
+	if err != nil {
+		t.Fatalf("ReadToken: %v", err)
+	}
+	if token != "old" {
+		t.Fatalf("ReadToken = %q, want %q", token, "old")
+	}
+
+	manager := NewManager()
+	defer manager.Close()
+	if _, err := manager.RotateToken(target, "new"); err != nil {
+		t.Fatalf("RotateToken: %v", err)
+	}
+
+	token, err = target.ReadToken()
+	if err != nil {
+		t.Fatalf("ReadToken after rotation: %v", err)
+	}
+	if token != "new" {
+		t.Errorf("ReadToken after rotation = %q, want %q", token, "new")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if strings.Contains(content, "old") {
+		t.Errorf("old token still present:\n%s", content)
+	}
+	if n := strings.Count(content, "token ="); n != 1 {
+		t.Errorf("found %d token keys, want 1:\n%s", n, content)
+	}
+	if !strings.Contains(content, "# shared with every frpc") {
+		t.Errorf("comment in [auth] was not preserved:\n%s", content)
+	}
+	assertNoSchemaIssues(t, data)
+}
+
+func TestRotateTokenMovesLegacyToken(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	path := filepath.Join(t.TempDir(), "frps.toml")
+	if err := os.WriteFile(path, []byte("bindPort = 7000\ntoken = \"old\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	target := LocalRotationTarget(true, path)
+	if token, err := target.ReadToken(); err != nil || token != "old" {
+		t.Fatalf("ReadToken = %q, %v, want %q", token, err, "old")
+	}
+
+	manager := NewManager()
+	defer manager.Close()
+	if _, err := manager.RotateToken(target, "new"); err != nil {
+		t.Fatalf("RotateToken: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "old") || !strings.Contains(string(data), "[auth]") {
+		t.Errorf("legacy token was not moved to [auth]:\n%s", data)
+	}
+	if token, err := target.ReadToken(); err != nil || token != "new" {
+		t.Errorf("ReadToken after rotation = %q, %v, want %q", token, err, "new")
+	}
+	assertNoSchemaIssues(t, data)
+}
+
+// assertNoSchemaIssues 检查保存后的 frps 配置中没有 frp 不认识的字段
+func assertNoSchemaIssues(t *testing.T, data []byte) {
+	t.Helper()
+	issues, err := config.CheckSchema(config.StripFileMeta(data), config.FormatTOML, true)
+	if err != nil {
+		t.Fatalf("CheckSchema: %v", err)
+	}
+	for _, issue := range issues {
+		t.Errorf("unexpected schema issue: %s", issue)
+	}
+}
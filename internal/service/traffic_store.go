@@ -0,0 +1,245 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// ServerTrafficSeries 服务端总流量在存储中使用的名称
+const ServerTrafficSeries = "@server"
+
+// trafficFileLayout 流量文件按天存储，文件名中的日期格式
+const trafficFileLayout = "20060102"
+
+// TrafficRecord 一次流量采样，In/Out 为与上次采样的增量，TotalIn/TotalOut 为 frps 计数器的原始值，
+// 应用重启后用它作为基线继续计算增量
+type TrafficRecord struct {
+	Time     time.Time `json:"t"`
+	Name     string    `json:"name"`
+	In       int64     `json:"in"`
+	Out      int64     `json:"out"`
+	TotalIn  int64     `json:"totalIn"`
+	TotalOut int64     `json:"totalOut"`
+}
+
+// TrafficStore 追加写入的流量时间序列存储，每天一个 JSON Lines 文件
+type TrafficStore struct {
+	mu        sync.Mutex
+	dir       string
+	retention time.Duration
+	prunedDay string
+}
+
+// GetTrafficDir 获取流量历史目录
+func GetTrafficDir() string {
+	return filepath.Join(config.GetDefaultWorkDir(), "traffic")
+}
+
+// NewTrafficStore 创建流量存储，retention 为 0 时不清理旧数据
+func NewTrafficStore(dir string, retention time.Duration) *TrafficStore {
+	return &TrafficStore{dir: dir, retention: retention}
+}
+
+// SetRetention 更新保留时长
+func (s *TrafficStore) SetRetention(retention time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retention = retention
+	s.prunedDay = ""
+}
+
+// dayFile 返回某天对应的文件路径
+func (s *TrafficStore) dayFile(day time.Time) string {
+	return filepath.Join(s.dir, "traffic-"+day.Format(trafficFileLayout)+".jsonl")
+}
+
+// Append 追加采样记录，每天第一次写入时顺便清理过期文件
+func (s *TrafficStore) Append(records []TrafficRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return i18n.Errorf("创建流量历史目录失败: %w", err)
+	}
+
+	// 按天分组，跨零点的一批采样写入各自的文件
+	byFile := make(map[string][]TrafficRecord)
+	for _, record := range records {
+		path := s.dayFile(record.Time)
+		byFile[path] = append(byFile[path], record)
+	}
+
+	for path, group := range byFile {
+		if err := appendTrafficRecords(path, group); err != nil {
+			return err
+		}
+	}
+
+	today := time.Now().Format(trafficFileLayout)
+	if s.prunedDay != today {
+		s.prunedDay = today
+		return s.prune(time.Now())
+	}
+	return nil
+}
+
+// appendTrafficRecords 将记录追加到文件
+func appendTrafficRecords(path string, records []TrafficRecord) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return i18n.Errorf("打开流量历史文件失败: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return i18n.Errorf("序列化流量记录失败: %w", err)
+		}
+		w.Write(data)
+		w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		return i18n.Errorf("写入流量历史失败: %w", err)
+	}
+	return nil
+}
+
+// Load 读取 since 之后的所有记录，按时间升序返回；写坏的行会被跳过
+func (s *TrafficStore) Load(since time.Time) ([]TrafficRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := s.listFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	sinceDay := since.Format(trafficFileLayout)
+	var records []TrafficRecord
+	for _, file := range files {
+		if file.day < sinceDay {
+			continue
+		}
+
+		f, err := os.Open(file.path)
+		if err != nil {
+			return nil, i18n.Errorf("读取流量历史失败: %w", err)
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var record TrafficRecord
+			if json.Unmarshal(scanner.Bytes(), &record) != nil {
+				continue
+			}
+			if !record.Time.Before(since) {
+				records = append(records, record)
+			}
+		}
+		f.Close()
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+	return records, nil
+}
+
+// DailyTotals 按天汇总某个序列最近 days 天的流量，索引 0 为今天，与 frps 的流量历史格式一致
+func (s *TrafficStore) DailyTotals(name string, days int, now time.Time) (*ProxyTrafficHistory, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	records, err := s.Load(today.AddDate(0, 0, -(days - 1)))
+	if err != nil {
+		return nil, err
+	}
+
+	history := &ProxyTrafficHistory{
+		Name:       name,
+		TrafficIn:  make([]int64, days),
+		TrafficOut: make([]int64, days),
+	}
+	for _, record := range records {
+		if record.Name != name {
+			continue
+		}
+		t := record.Time.In(now.Location())
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
+		// 夏令时切换的日子不是 24 小时，加半天后再取整
+		index := int((today.Sub(day) + 12*time.Hour) / (24 * time.Hour))
+		if index >= 0 && index < days {
+			history.TrafficIn[index] += record.In
+			history.TrafficOut[index] += record.Out
+		}
+	}
+	return history, nil
+}
+
+// prune 删除超过保留时长的文件，调用方需持有锁
+func (s *TrafficStore) prune(now time.Time) error {
+	if s.retention <= 0 {
+		return nil
+	}
+
+	files, err := s.listFiles()
+	if err != nil {
+		return err
+	}
+
+	// 文件保存一整天的数据，只有当天全部过期时才删除
+	cutoff := now.Add(-s.retention).Format(trafficFileLayout)
+	for _, file := range files {
+		if file.day < cutoff {
+			if err := os.Remove(file.path); err != nil && !os.IsNotExist(err) {
+				return i18n.Errorf("删除过期流量历史失败: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// trafficFile 流量历史文件
+type trafficFile struct {
+	path string
+	day  string
+}
+
+// listFiles 列出所有流量历史文件，按日期升序
+func (s *TrafficStore) listFiles() ([]trafficFile, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取流量历史目录失败: %w", err)
+	}
+
+	var files []trafficFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "traffic-") || !strings.HasSuffix(name, ".jsonl") {
+			continue
+		}
+		day := strings.TrimSuffix(strings.TrimPrefix(name, "traffic-"), ".jsonl")
+		if _, err := time.Parse(trafficFileLayout, day); err != nil {
+			continue
+		}
+		files = append(files, trafficFile{path: filepath.Join(s.dir, name), day: day})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].day < files[j].day })
+	return files, nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// verifyTimeout verify 命令的超时时间
+const verifyTimeout = 10 * time.Second
+
+// VerifyResult frp 自带 verify 命令的检查结果
+type VerifyResult struct {
+	Service    string // "server" 或 "client"
+	ConfigPath string
+	Binary     string // 执行检查的 frps/frpc
+	Output     string
+	Passed     bool
+}
+
+// VerifyConfigFile 使用已安装的 frps/frpc 执行 verify -c 检查配置文件，
+// 可以发现本工具校验器尚未覆盖的字段。verify 未通过时 Passed 为 false，
+// 找不到可执行文件、配置文件不存在或命令无法运行时返回错误
+func VerifyConfigFile(service, configPath string) (*VerifyResult, error) {
+	name := "frpc"
+	if service == "server" {
+		name = "frps"
+	}
+
+	if _, err := os.Stat(configPath); err != nil {
+		return nil, i18n.Errorf("配置文件不存在: %s", configPath)
+	}
+	binary, err := findFRPExecutable(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+
+	// 在配置文件所在目录执行，配置中的相对路径（证书等）与实际启动时一致
+	cmd := exec.CommandContext(ctx, binary, "verify", "-c", configPath)
+	cmd.Dir = filepath.Dir(configPath)
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, i18n.Errorf("%s verify 超时", name)
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, i18n.Errorf("运行 %s verify 失败: %w", name, err)
+	}
+
+	return &VerifyResult{
+		Service:    service,
+		ConfigPath: configPath,
+		Binary:     binary,
+		Output:     strings.TrimSpace(string(output)),
+		Passed:     err == nil,
+	}, nil
+}
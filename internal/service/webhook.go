@@ -0,0 +1,142 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// WebhookDispatcher 订阅事件总线，把生命周期事件按模板 POST 到用户配置的 Webhook
+type WebhookDispatcher struct {
+	httpClient *http.Client
+	errors     chan error
+
+	mu       sync.RWMutex
+	webhooks []config.WebhookConfig
+	cancel   func()
+}
+
+// NewWebhookDispatcher 创建 Webhook 分发器
+func NewWebhookDispatcher() *WebhookDispatcher {
+	return &WebhookDispatcher{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		errors:     make(chan error, 20),
+	}
+}
+
+// SetWebhooks 更新 Webhook 列表，对之后的事件生效
+func (d *WebhookDispatcher) SetWebhooks(webhooks []config.WebhookConfig) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.webhooks = append([]config.WebhookConfig(nil), webhooks...)
+}
+
+// Errors 推送失败的错误通道，界面从中读取并提示
+func (d *WebhookDispatcher) Errors() <-chan error {
+	return d.errors
+}
+
+// Start 订阅事件总线并在后台推送，重复调用时忽略
+func (d *WebhookDispatcher) Start(bus *EventBus) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancel != nil {
+		return
+	}
+
+	events, cancel := bus.Subscribe(100)
+	d.cancel = cancel
+	go func() {
+		for event := range events {
+			d.Dispatch(event)
+		}
+	}()
+}
+
+// Stop 取消订阅，停止推送
+func (d *WebhookDispatcher) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancel != nil {
+		d.cancel()
+		d.cancel = nil
+	}
+}
+
+// Dispatch 把事件推送到所有订阅了该事件的 Webhook，失败时发送到错误通道
+func (d *WebhookDispatcher) Dispatch(event Event) {
+	d.mu.RLock()
+	webhooks := d.webhooks
+	d.mu.RUnlock()
+
+	for _, webhook := range webhooks {
+		if !MatchEvent(webhook.Events, event.Type) {
+			continue
+		}
+		if err := d.Send(webhook, event); err != nil {
+			select {
+			case d.errors <- i18n.Errorf("推送事件到 %s 失败: %w", webhook.DisplayName(), err):
+			default:
+			}
+		}
+	}
+}
+
+// Send 按 Webhook 的模板推送一个事件
+func (d *WebhookDispatcher) Send(webhook config.WebhookConfig, event Event) error {
+	data, err := WebhookPayload(webhook.Template, event)
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.httpClient.Post(webhook.URL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return i18n.Errorf("发送 Webhook 失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return i18n.Errorf("Webhook 返回状态码 %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// WebhookPayload 按模板生成请求体：generic 为事件 JSON 加 text 字段，
+// slack、dingtalk、feishu 为各自机器人要求的文本消息格式
+func WebhookPayload(template string, event Event) ([]byte, error) {
+	text := event.Text()
+
+	var payload any
+	switch template {
+	case config.WebhookTemplateSlack:
+		payload = map[string]string{"text": text}
+	case config.WebhookTemplateDingTalk:
+		payload = map[string]any{
+			"msgtype": "text",
+			"text":    map[string]string{"content": text},
+		}
+	case config.WebhookTemplateFeishu:
+		payload = map[string]any{
+			"msg_type": "text",
+			"content":  map[string]string{"text": text},
+		}
+	case config.WebhookTemplateGeneric, "":
+		payload = struct {
+			Event
+			Text string `json:"text"`
+		}{Event: event, Text: text}
+	default:
+		return nil, i18n.Errorf("不支持的 Webhook 模板: %s", template)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, i18n.Errorf("序列化事件失败: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// backupTimeLayout 备份文件名中的时间格式
+const backupTimeLayout = "20060102-150405.000"
+
+// BackupInfo 配置备份信息
+type BackupInfo struct {
+	Path       string    // 备份文件路径
+	ConfigPath string    // 对应的配置文件
+	CreatedAt  time.Time // 备份时间
+	Size       int64
+}
+
+// BackupPolicy 备份保留策略
+type BackupPolicy struct {
+	MaxCount int           // 每个配置文件最多保留的备份数，0 表示不限
+	MaxAge   time.Duration // 备份最长保留时间，0 表示不限
+}
+
+// GetBackupDir 获取备份根目录
+func GetBackupDir() string {
+	return filepath.Join(GetDefaultWorkDir(), "backups")
+}
+
+// backupDirFor 返回配置文件对应的备份目录，用路径哈希区分不同目录下的同名文件
+func backupDirFor(configPath string) string {
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		absPath = configPath
+	}
+	sum := sha1.Sum([]byte(absPath))
+	return filepath.Join(GetBackupDir(), filepath.Base(absPath)+"-"+hex.EncodeToString(sum[:4]))
+}
+
+// currentBackupPolicy 从应用设置读取备份保留策略
+func currentBackupPolicy() BackupPolicy {
+	settings, _ := LoadAppSettings()
+	return BackupPolicy{
+		MaxCount: settings.BackupKeep,
+		MaxAge:   time.Duration(settings.BackupMaxDays) * 24 * time.Hour,
+	}
+}
+
+// BackupConfigFile 将配置文件当前内容备份到备份目录，文件不存在时不做任何事，
+// newData 与当前内容相同时同样跳过，避免产生无意义的备份
+func BackupConfigFile(configPath string, newData []byte) (*BackupInfo, error) {
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取原配置文件失败: %w", err)
+	}
+	if newData != nil && bytes.Equal(data, newData) {
+		return nil, nil
+	}
+
+	dir := backupDirFor(configPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, i18n.Errorf("创建备份目录失败: %w", err)
+	}
+
+	now := time.Now()
+	backupPath := filepath.Join(dir, now.Format(backupTimeLayout)+filepath.Ext(configPath))
+	if err := os.WriteFile(backupPath, data, 0600); err != nil {
+		return nil, i18n.Errorf("创建备份文件失败: %w", err)
+	}
+
+	PruneBackups(configPath, currentBackupPolicy())
+
+	return &BackupInfo{
+		Path:       backupPath,
+		ConfigPath: configPath,
+		CreatedAt:  now,
+		Size:       int64(len(data)),
+	}, nil
+}
+
+// ListBackups 列出配置文件的所有备份，按时间从新到旧排列
+func ListBackups(configPath string) ([]BackupInfo, error) {
+	entries, err := os.ReadDir(backupDirFor(configPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取备份目录失败: %w", err)
+	}
+
+	var backups []BackupInfo
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		createdAt, err := time.ParseInLocation(backupTimeLayout, strings.TrimSuffix(name, filepath.Ext(name)), time.Local)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, BackupInfo{
+			Path:       filepath.Join(backupDirFor(configPath), name),
+			ConfigPath: configPath,
+			CreatedAt:  createdAt,
+			Size:       info.Size(),
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	return backups, nil
+}
+
+// PruneBackups 按保留策略删除多余或过期的备份，始终保留最新的一份
+func PruneBackups(configPath string, policy BackupPolicy) {
+	backups, err := ListBackups(configPath)
+	if err != nil {
+		return
+	}
+
+	for i, backup := range backups {
+		if i == 0 {
+			continue
+		}
+		tooMany := policy.MaxCount > 0 && i >= policy.MaxCount
+		tooOld := policy.MaxAge > 0 && time.Since(backup.CreatedAt) > policy.MaxAge
+		if tooMany || tooOld {
+			os.Remove(backup.Path)
+		}
+	}
+}
+
+// RestoreBackup 用备份内容覆盖配置文件，覆盖前会先备份当前内容以便撤销
+func RestoreBackup(backup BackupInfo) error {
+	data, err := os.ReadFile(backup.Path)
+	if err != nil {
+		return i18n.Errorf("读取备份文件失败: %w", err)
+	}
+
+	if _, err := BackupConfigFile(backup.ConfigPath, data); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(backup.ConfigPath), 0755); err != nil {
+		return i18n.Errorf("创建配置目录失败: %w", err)
+	}
+	if err := os.WriteFile(backup.ConfigPath, data, 0644); err != nil {
+		return i18n.Errorf("恢复配置文件失败: %w", err)
+	}
+	return nil
+}
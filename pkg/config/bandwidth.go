@@ -0,0 +1,32 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// ParseBandwidthLimit 解析 "1MB"、"500KB" 形式的带宽限制，返回每秒字节数，空字符串返回 0
+func ParseBandwidthLimit(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	var base float64
+	switch {
+	case strings.HasSuffix(value, "MB"):
+		base = 1024 * 1024
+	case strings.HasSuffix(value, "KB"):
+		base = 1024
+	default:
+		return 0, i18n.Errorf("带宽限制 %s 缺少单位，应以 KB 或 MB 结尾", value)
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(value[:len(value)-2]), 64)
+	if err != nil || number <= 0 {
+		return 0, i18n.Errorf("带宽限制 %s 必须是正数加单位，如 1MB", value)
+	}
+	return int64(number * base), nil
+}
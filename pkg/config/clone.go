@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// Clone 深拷贝配置，修改副本不会影响原配置
+func (c *Config) Clone() *Config {
+	if c == nil {
+		return nil
+	}
+
+	clone := *c
+	if c.AllowPorts != nil {
+		clone.AllowPorts = append([]PortRange(nil), c.AllowPorts...)
+	}
+	if c.Transport.TLS.Enable != nil {
+		enable := *c.Transport.TLS.Enable
+		clone.Transport.TLS.Enable = &enable
+	}
+	clone.Includes = cloneStrings(c.Includes)
+	clone.includeFiles = cloneStrings(c.includeFiles)
+	if c.Proxies != nil {
+		clone.Proxies = make([]ProxyConfig, len(c.Proxies))
+		for i, proxy := range c.Proxies {
+			clone.Proxies[i] = proxy.Clone()
+		}
+	}
+	if c.Visitors != nil {
+		clone.Visitors = append([]VisitorConfig(nil), c.Visitors...)
+	}
+	return &clone
+}
+
+// Clone 深拷贝代理配置
+func (p ProxyConfig) Clone() ProxyConfig {
+	clone := p
+	clone.CustomDomains = cloneStrings(p.CustomDomains)
+	clone.Locations = cloneStrings(p.Locations)
+	if p.HealthCheck.HTTPHeaders != nil {
+		clone.HealthCheck.HTTPHeaders = append([]HTTPHeader(nil), p.HealthCheck.HTTPHeaders...)
+	}
+	clone.RequestHeaders.Set = cloneStringMap(p.RequestHeaders.Set)
+	clone.Plugin.RequestHeaders.Set = cloneStringMap(p.Plugin.RequestHeaders.Set)
+	return clone
+}
+
+// cloneStringMap 复制字符串映射，保留 nil
+func cloneStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	clone := make(map[string]string, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
+
+// cloneStrings 复制字符串切片，保留 nil
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
+
+// DuplicateProxy 复制客户端配置中的代理，副本使用自动递增的名称（ssh → ssh-2），
+// 设置了远程端口时改用下一个未被其他代理占用的端口。副本总是启用，也不会自动加入配置
+func DuplicateProxy(cfg *Config, index int) (ProxyConfig, error) {
+	if cfg == nil || index < 0 || index >= len(cfg.Proxies) {
+		return ProxyConfig{}, i18n.Errorf("代理不存在")
+	}
+
+	names := make(map[string]bool, len(cfg.Proxies))
+	ports := make(map[int]bool, len(cfg.Proxies))
+	for _, proxy := range cfg.Proxies {
+		names[proxy.Name] = true
+		ports[proxy.RemotePort] = true
+	}
+
+	clone := cfg.Proxies[index].Clone()
+	clone.Disabled = false
+	base, n := splitNameSuffix(clone.Name)
+	for {
+		n++
+		clone.Name = fmt.Sprintf("%s-%d", base, n)
+		if !names[clone.Name] {
+			break
+		}
+	}
+
+	if clone.RemotePort > 0 {
+		for ports[clone.RemotePort] && clone.RemotePort < 65535 {
+			clone.RemotePort++
+		}
+	}
+	return clone, nil
+}
+
+// splitNameSuffix 拆分名称末尾的 "-数字" 序号，没有序号时视为 1
+func splitNameSuffix(name string) (string, int) {
+	if i := strings.LastIndex(name, "-"); i > 0 {
+		if n, err := strconv.Atoi(name[i+1:]); err == nil && n > 0 {
+			return name[:i], n
+		}
+	}
+	return name, 1
+}
@@ -5,10 +5,12 @@ import (
 	"path/filepath"
 )
 
-const (
-	AppName    = "FRP Manager"
-	AppVersion = "1.0.0"
-)
+// AppName 应用名称
+const AppName = "FRP Manager"
+
+// AppVersion 应用版本，发布构建时通过 -ldflags "-X frp-cli-ui/pkg/config.AppVersion=1.1.0" 写入，
+// 自更新按它判断是否有新版本
+var AppVersion = "1.0.0"
 
 // GetDefaultWorkDir 获取默认工作目录
 func GetDefaultWorkDir() string {
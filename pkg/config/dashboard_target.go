@@ -0,0 +1,98 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"os"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// DefaultDashboardTarget 由 dashboardURL/dashboardUser/dashboardPassword 组成的默认目标名称
+const DefaultDashboardTarget = "default"
+
+// DashboardTarget 命名的 frps Dashboard API 目标，用于在一个界面中切换监控多台 frps
+type DashboardTarget struct {
+	Name               string `yaml:"name"`                         // 显示名称，切换目标时使用
+	URL                string `yaml:"url"`                          // Dashboard API 地址，支持 https
+	User               string `yaml:"user,omitempty"`               // Basic 认证用户名
+	Password           string `yaml:"password,omitempty"`           // Basic 认证密码
+	Token              string `yaml:"token,omitempty"`              // 以 Bearer 方式发送的令牌，适用于放在反向代理后的 Dashboard
+	CAFile             string `yaml:"caFile,omitempty"`             // 校验服务端证书使用的 CA 证书 (PEM)
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify,omitempty"` // 不校验服务端证书，仅用于测试
+}
+
+// TLSConfig 根据 CA 证书和校验选项生成 TLS 配置，未设置时返回 nil 表示使用系统默认
+func (t DashboardTarget) TLSConfig() (*tls.Config, error) {
+	if t.CAFile == "" && !t.InsecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: t.InsecureSkipVerify}
+	if t.CAFile != "" {
+		data, err := os.ReadFile(expandHome(t.CAFile))
+		if err != nil {
+			return nil, i18n.Errorf("读取 CA 证书失败: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, i18n.Errorf("CA 证书 %s 中没有有效的 PEM 证书", t.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// DashboardTargetList 返回所有 Dashboard 目标，第一个为默认目标
+func (s *AppSettings) DashboardTargetList() []DashboardTarget {
+	targets := []DashboardTarget{{
+		Name:     DefaultDashboardTarget,
+		URL:      s.DashboardURL,
+		User:     s.DashboardUser,
+		Password: s.DashboardPassword,
+	}}
+	return append(targets, s.DashboardTargets...)
+}
+
+// ActiveDashboardTarget 返回当前使用的 Dashboard 目标，找不到时使用默认目标
+func (s *AppSettings) ActiveDashboardTarget() DashboardTarget {
+	targets := s.DashboardTargetList()
+	for _, target := range targets {
+		if target.Name == s.ActiveDashboard {
+			return target
+		}
+	}
+	return targets[0]
+}
+
+// validateDashboardTargets 校验 Dashboard 目标的名称、地址和证书
+func validateDashboardTargets(targets []DashboardTarget, active string) error {
+	names := map[string]bool{DefaultDashboardTarget: true}
+	for _, target := range targets {
+		name := strings.TrimSpace(target.Name)
+		if name == "" {
+			return i18n.Errorf("Dashboard 目标名称不能为空")
+		}
+		if names[name] {
+			return i18n.Errorf("Dashboard 目标名称重复: %s", name)
+		}
+		names[name] = true
+
+		parsed, err := url.Parse(target.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return i18n.Errorf("Dashboard 目标 %s 的地址无效: %s", name, target.URL)
+		}
+		if target.Token != "" && target.User != "" {
+			return i18n.Errorf("Dashboard 目标 %s 不能同时设置用户名和令牌", name)
+		}
+		if _, err := target.TLSConfig(); err != nil {
+			return i18n.Errorf("Dashboard 目标 %s: %w", name, err)
+		}
+	}
+	if active != "" && !names[active] {
+		return i18n.Errorf("当前 Dashboard 目标不存在: %s", active)
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 诊断问题的严重程度
+const (
+	DiagnosticError   = "error"
+	DiagnosticWarning = "warning"
+)
+
+// frp 默认的服务端端口
+const defaultFRPServerPort = 7000
+
+// ConfigProfile 参与诊断的配置文件
+type ConfigProfile struct {
+	Path   string
+	Role   string // server、client 或 unknown
+	Config *Config
+	Err    error // 读取或解析失败时的错误
+}
+
+// Name 返回用于提示的配置名称
+func (p ConfigProfile) Name() string {
+	return filepath.Base(p.Path)
+}
+
+// Diagnostic 一条诊断结果
+type Diagnostic struct {
+	Severity string
+	Message  string
+}
+
+// KnownConfigPaths 返回本工具知道的所有配置文件：应用设置中的服务端/客户端配置、
+// 自动启动和远程服务器使用的配置，以及工作目录 configs 下的配置文件，按路径去重
+func KnownConfigPaths(settings *AppSettings) []string {
+	if settings == nil {
+		settings = DefaultAppSettings()
+	}
+
+	candidates := []string{settings.ServerConfigPath, settings.ClientConfigPath}
+	for _, profile := range settings.Autostart {
+		candidates = append(candidates, settings.AutostartConfigPath(profile))
+	}
+	if remotes, err := LoadRemoteProfiles(); err == nil {
+		for _, remote := range remotes {
+			candidates = append(candidates, remote.LocalConfigPath)
+		}
+	}
+	for _, pattern := range []string{"*.toml", "*.yaml", "*.yml"} {
+		matches, _ := filepath.Glob(filepath.Join(GetDefaultWorkDir(), "configs", pattern))
+		candidates = append(candidates, matches...)
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, path := range candidates {
+		if path == "" {
+			continue
+		}
+		key := path
+		if abs, err := filepath.Abs(path); err == nil {
+			key = abs
+		}
+		if !seen[key] {
+			seen[key] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// LoadConfigProfile 读取配置文件，失败时记录在 Err 中
+func LoadConfigProfile(path string) ConfigProfile {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ConfigProfile{Path: path, Role: "unknown", Err: i18n.Errorf("配置文件不存在")}
+		}
+		return ConfigProfile{Path: path, Role: "unknown", Err: i18n.Errorf("读取配置文件失败: %w", err)}
+	}
+
+	cfg, err := UnmarshalConfig(content, DetectFormat(path))
+	if err != nil {
+		return ConfigProfile{Path: path, Role: "unknown", Err: i18n.Errorf("解析配置文件失败: %w", err)}
+	}
+	return NewConfigProfile(path, cfg)
+}
+
+// NewConfigProfile 用已加载的配置创建诊断条目，用于包含尚未保存的修改
+func NewConfigProfile(path string, cfg *Config) ConfigProfile {
+	return ConfigProfile{Path: path, Role: DetectConfigType(cfg), Config: cfg}
+}
+
+// serverPort 返回客户端连接的服务端端口
+func (p ConfigProfile) serverPort() int {
+	if p.Config.ServerPort > 0 {
+		return p.Config.ServerPort
+	}
+	return defaultFRPServerPort
+}
+
+// serverKey 返回客户端连接的服务端，连接同一服务端的客户端共享远程端口和代理名称
+func (p ConfigProfile) serverKey() string {
+	addr := p.Config.ServerAddr
+	if isLocalServer(addr) {
+		addr = "localhost"
+	}
+	return fmt.Sprintf("%s:%d", addr, p.serverPort())
+}
+
+// bindPort 返回服务端监听客户端连接的端口
+func (p ConfigProfile) bindPort() int {
+	if p.Config.BindPort > 0 {
+		return p.Config.BindPort
+	}
+	return defaultFRPServerPort
+}
+
+// proxyOwner 占用远程端口或代理名称的代理
+type proxyOwner struct {
+	profile ConfigProfile
+	proxy   ProxyConfig
+}
+
+// String 返回 "代理名 (配置文件)"
+func (o proxyOwner) String() string {
+	return fmt.Sprintf("%s (%s)", o.proxy.Name, o.profile.Name())
+}
+
+// AnalyzeProfiles 在 GetValidationSummary 的基础上对多个配置做交叉检查：
+// 连接同一服务端的客户端之间的远程端口冲突和代理重名，远程端口与服务端自身端口冲突、
+// 不在服务端 allowPorts 范围内以及超过 maxPortsPerClient。
+// frps 可能部署在远程服务器上，客户端按端口与服务端配置对应
+func (v *Validator) AnalyzeProfiles(profiles []ConfigProfile) []Diagnostic {
+	var diagnostics []Diagnostic
+	report := func(severity, message string) {
+		diagnostics = append(diagnostics, Diagnostic{Severity: severity, Message: message})
+	}
+
+	var servers []ConfigProfile
+	groups := make(map[string][]ConfigProfile)
+	var groupKeys []string
+	for _, profile := range profiles {
+		if profile.Err != nil {
+			report(DiagnosticWarning, fmt.Sprintf("%s: %v", profile.Name(), profile.Err))
+			continue
+		}
+
+		summary := v.GetValidationSummary(profile.Config)
+		for _, section := range []string{"server", "client", "proxies", "visitors"} {
+			for _, message := range summary[section] {
+				report(DiagnosticError, fmt.Sprintf("%s: %s", profile.Name(), message))
+			}
+		}
+
+		switch profile.Role {
+		case "server":
+			servers = append(servers, profile)
+		case "client":
+			key := profile.serverKey()
+			if _, exists := groups[key]; !exists {
+				groupKeys = append(groupKeys, key)
+			}
+			groups[key] = append(groups[key], profile)
+		}
+	}
+
+	for _, key := range groupKeys {
+		clients := groups[key]
+		ports := make(map[string][]proxyOwner)
+		names := make(map[string][]proxyOwner)
+		var portKeys, nameKeys []string
+
+		for _, client := range clients {
+			for _, proxy := range client.Config.Proxies {
+				if proxy.Disabled {
+					continue
+				}
+				owner := proxyOwner{profile: client, proxy: proxy}
+				if _, exists := names[proxy.Name]; !exists {
+					nameKeys = append(nameKeys, proxy.Name)
+				}
+				names[proxy.Name] = append(names[proxy.Name], owner)
+
+				if proxy.RemotePort > 0 {
+					portKey := fmt.Sprintf("%d/%s", proxy.RemotePort, proxyNetwork(proxy))
+					if _, exists := ports[portKey]; !exists {
+						portKeys = append(portKeys, portKey)
+					}
+					ports[portKey] = append(ports[portKey], owner)
+				}
+			}
+		}
+
+		for _, portKey := range portKeys {
+			if owners := ports[portKey]; len(owners) > 1 {
+				report(DiagnosticError, i18n.Sprintf("服务端 %s 的远程端口 %s 被多个代理占用: %s", key, portKey, joinOwners(owners)))
+			}
+		}
+		for _, name := range nameKeys {
+			// 同一配置内的重名已由 GetValidationSummary 报告
+			if owners := names[name]; len(owners) > 1 && !sameProfile(owners) {
+				report(DiagnosticError, i18n.Sprintf("服务端 %s 上的代理名称 %s 在多个配置中重复: %s", key, name, joinOwners(owners)))
+			}
+		}
+
+		for _, client := range clients {
+			for _, server := range servers {
+				if server.bindPort() == client.serverPort() {
+					diagnostics = append(diagnostics, checkServerPorts(server, client)...)
+				}
+			}
+		}
+	}
+
+	// 错误排在警告前面，同级保持发现的顺序
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Severity == DiagnosticError && diagnostics[j].Severity != DiagnosticError
+	})
+	return diagnostics
+}
+
+// checkServerPorts 检查客户端的远程端口是否与服务端自身端口冲突、是否在 allowPorts 范围内
+func checkServerPorts(server, client ConfigProfile) []Diagnostic {
+	var diagnostics []Diagnostic
+	report := func(severity, message string) {
+		diagnostics = append(diagnostics, Diagnostic{Severity: severity, Message: message})
+	}
+
+	cfg := server.Config
+	serverPorts := []struct {
+		name    string
+		network string
+		port    int
+	}{
+		{i18n.T("绑定端口"), "tcp", server.bindPort()},
+		{i18n.T("UDP端口"), "udp", cfg.BindUDPPort},
+		{i18n.T("KCP端口"), "udp", cfg.KCPBindPort},
+		{i18n.T("Web服务器端口"), "tcp", cfg.WebServer.Port},
+		{i18n.T("HTTP虚拟主机端口"), "tcp", cfg.VhostHTTPPort},
+		{i18n.T("HTTPS虚拟主机端口"), "tcp", cfg.VhostHTTPSPort},
+		{i18n.T("tcpmux HTTP CONNECT 端口"), "tcp", cfg.TCPMuxHTTPConnectPort},
+		{i18n.T("SSH 隧道网关端口"), "tcp", cfg.SSHTunnelGateway.BindPort},
+	}
+
+	used := 0
+	for _, proxy := range client.Config.Proxies {
+		if proxy.Disabled || proxy.RemotePort <= 0 {
+			continue
+		}
+		used++
+		owner := proxyOwner{profile: client, proxy: proxy}
+
+		for _, serverPort := range serverPorts {
+			if serverPort.port == proxy.RemotePort && serverPort.network == proxyNetwork(proxy) {
+				report(DiagnosticError, i18n.Sprintf("%s 的远程端口 %d 与服务端 %s 的%s冲突",
+					owner, proxy.RemotePort, server.Name(), serverPort.name))
+			}
+		}
+
+		if len(cfg.AllowPorts) > 0 && !portAllowed(cfg.AllowPorts, proxy.RemotePort) {
+			report(DiagnosticError, i18n.Sprintf("%s 的远程端口 %d 不在服务端 %s 允许的端口范围 %s 内",
+				owner, proxy.RemotePort, server.Name(), FormatPortRanges(cfg.AllowPorts)))
+		}
+	}
+
+	if cfg.MaxPortsPerClient > 0 && used > cfg.MaxPortsPerClient {
+		report(DiagnosticWarning, i18n.Sprintf("%s 使用了 %d 个远程端口，超过服务端 %s 的每客户端上限 %d",
+			client.Name(), used, server.Name(), cfg.MaxPortsPerClient))
+	}
+	return diagnostics
+}
+
+// proxyNetwork 返回代理远程端口使用的网络类型
+func proxyNetwork(proxy ProxyConfig) string {
+	if proxy.Type == "udp" {
+		return "udp"
+	}
+	return "tcp"
+}
+
+// portAllowed 判断端口是否在任一范围内
+func portAllowed(ranges []PortRange, port int) bool {
+	for _, r := range ranges {
+		if r.Contains(port) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameProfile 判断代理是否都来自同一个配置文件
+func sameProfile(owners []proxyOwner) bool {
+	for _, owner := range owners[1:] {
+		if owner.profile.Path != owners[0].profile.Path {
+			return false
+		}
+	}
+	return true
+}
+
+// joinOwners 拼接占用者列表
+func joinOwners(owners []proxyOwner) string {
+	names := make([]string, len(owners))
+	for i, owner := range owners {
+		names[i] = owner.String()
+	}
+	return strings.Join(names, ", ")
+}
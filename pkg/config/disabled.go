@@ -0,0 +1,112 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// disabledProxyPrefix 已停用代理所在注释行的前缀，frpc 会把这些行当作注释忽略
+const disabledProxyPrefix = "#~"
+
+// disabledProxyList 已停用代理在注释块中的结构
+type disabledProxyList struct {
+	Proxies []ProxyConfig `yaml:"disabledProxies" toml:"disabledProxies"`
+}
+
+// EnabledProxyCount 返回未停用的代理数量
+func (c *Config) EnabledProxyCount() int {
+	count := 0
+	for _, proxy := range c.Proxies {
+		if !proxy.Disabled {
+			count++
+		}
+	}
+	return count
+}
+
+// splitDisabledProxies 拆出已停用的代理，没有停用的代理时原样返回配置
+func splitDisabledProxies(config *Config) (*Config, []ProxyConfig) {
+	if config == nil || config.EnabledProxyCount() == len(config.Proxies) {
+		return config, nil
+	}
+
+	active := *config
+	active.Proxies = nil
+	var disabled []ProxyConfig
+	for _, proxy := range config.Proxies {
+		if proxy.Disabled {
+			// 注释块本身就表示停用，不再重复写入 disabled 字段
+			proxy.Disabled = false
+			disabled = append(disabled, proxy)
+		} else {
+			active.Proxies = append(active.Proxies, proxy)
+		}
+	}
+	return &active, disabled
+}
+
+// appendDisabledProxies 将已停用的代理按相同格式序列化后以注释形式追加到配置末尾，
+// frpc 不会加载这些代理，重新读取配置时可以还原
+func appendDisabledProxies(data []byte, disabled []ProxyConfig, format ConfigFormat) ([]byte, error) {
+	if len(disabled) == 0 {
+		return data, nil
+	}
+
+	var block []byte
+	var err error
+	list := disabledProxyList{Proxies: disabled}
+	if format == FormatTOML {
+		block, err = toml.Marshal(list)
+	} else {
+		block, err = yaml.Marshal(list)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(data)
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("\n# " + i18n.T("以下代理已停用，frpc 不会加载，可在配置管理的代理列表中重新启用") + "\n")
+	for _, line := range strings.Split(strings.TrimRight(string(block), "\n"), "\n") {
+		buf.WriteString(strings.TrimRight(disabledProxyPrefix+" "+line, " ") + "\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// parseDisabledProxies 从配置末尾的注释块中还原已停用的代理
+func parseDisabledProxies(data []byte, format ConfigFormat) ([]ProxyConfig, error) {
+	var block strings.Builder
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if rest, ok := strings.CutPrefix(line, disabledProxyPrefix); ok {
+			block.WriteString(strings.TrimPrefix(rest, " ") + "\n")
+		}
+	}
+	if block.Len() == 0 {
+		return nil, nil
+	}
+
+	var list disabledProxyList
+	var err error
+	if format == FormatTOML {
+		err = toml.Unmarshal([]byte(block.String()), &list)
+	} else {
+		err = yaml.Unmarshal([]byte(block.String()), &list)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("解析已停用的代理失败: %w", err)
+	}
+
+	for i := range list.Proxies {
+		list.Proxies[i].Disabled = true
+	}
+	return list.Proxies, nil
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// FieldIssue 表单提交前发现的问题，Field 为出错的配置字段名，用于定位到表单中对应的分组
+type FieldIssue struct {
+	Field   string
+	Message string
+}
+
+// ProxyFieldIssues 检查单个代理中字段之间的组合，如 http 代理设置了远程端口、缺少本地端口等
+func (v *Validator) ProxyFieldIssues(proxy ProxyConfig) []FieldIssue {
+	var issues []FieldIssue
+	add := func(field string, err error) {
+		if err != nil {
+			issues = append(issues, FieldIssue{Field: field, Message: err.Error()})
+		}
+	}
+
+	add("name", v.validateProxyName(proxy.Name))
+	if err := v.validateProxyType(proxy.Type); err != nil {
+		add("type", err)
+		return issues
+	}
+
+	if field, err := v.validateTypeFields(proxy); err != nil {
+		add(field, err)
+	}
+	if proxy.Plugin.Type == "" && proxy.LocalPort == 0 {
+		add("localPort", i18n.Errorf("未使用插件时需要设置本地端口"))
+	}
+	if proxy.LocalPort != 0 {
+		add("localPort", v.validatePort(proxy.LocalPort))
+	}
+
+	switch proxy.Type {
+	case "tcp", "udp":
+		add("remotePort", v.validateTCPUDPProxy(proxy))
+	case "http", "https":
+		add("customDomains", v.validateHTTPDomains(proxy))
+		add("locations", v.validateHTTPOptions(proxy))
+	case "stcp", "sudp", "xtcp":
+		add("secretKey", v.validateSecretKey(proxy.SecretKey))
+	}
+
+	add("plugin", v.validatePlugin(proxy))
+	add("group", v.validateLoadBalancing(proxy))
+	add("healthCheck", v.validateHealthCheck(proxy.HealthCheck))
+	if _, err := ParseBandwidthLimit(proxy.Transport.BandwidthLimit); err != nil {
+		add("bandwidthLimit", i18n.Errorf("带宽限制无效: %w", err))
+	}
+	return issues
+}
+
+// VisitorFieldIssues 检查单个访问者的类型、密钥与绑定端口
+func (v *Validator) VisitorFieldIssues(visitor VisitorConfig) []FieldIssue {
+	var issues []FieldIssue
+	add := func(field string, err error) {
+		if err != nil {
+			issues = append(issues, FieldIssue{Field: field, Message: err.Error()})
+		}
+	}
+
+	if visitor.Name == "" {
+		add("name", i18n.Errorf("访问者名称不能为空"))
+	}
+	add("type", v.validateVisitorType(visitor.Type))
+	if visitor.ServerName == "" {
+		add("serverName", i18n.Errorf("需要填写要访问的代理名称"))
+	}
+	add("secretKey", v.validateSecretKey(visitor.SecretKey))
+	// xtcp 访问者可以只打洞不监听本地端口，其余类型必须绑定端口
+	if visitor.BindPort != 0 || visitor.Type != "xtcp" {
+		add("bindPort", v.validatePort(visitor.BindPort))
+	}
+	return issues
+}
+
+// ServerFieldIssues 检查服务端表单中的端口冲突、子域名主域名与允许端口范围
+func (v *Validator) ServerFieldIssues(config *Config) []FieldIssue {
+	var issues []FieldIssue
+	add := func(field string, err error) {
+		if err != nil {
+			issues = append(issues, FieldIssue{Field: field, Message: err.Error()})
+		}
+	}
+
+	add("bindPort", v.validatePort(config.BindPort))
+	if config.SubDomainHost != "" {
+		add("subDomainHost", v.validateDomain(config.SubDomainHost))
+	}
+	add("allowPorts", ValidatePortRanges(config.AllowPorts))
+	return append(issues, serverPortConflicts(config)...)
+}
+
+// ClientFieldIssues 检查客户端表单的服务器端口与传输设置
+func (v *Validator) ClientFieldIssues(config *Config) []FieldIssue {
+	var issues []FieldIssue
+	add := func(field string, err error) {
+		if err != nil {
+			issues = append(issues, FieldIssue{Field: field, Message: err.Error()})
+		}
+	}
+
+	// 地址格式由表单逐项校验，localhost 等单段主机名同样可用
+	if strings.TrimSpace(config.ServerAddr) == "" {
+		add("serverAddr", i18n.Errorf("服务器地址不能为空"))
+	}
+	add("serverPort", v.validatePort(config.ServerPort))
+	add("transport", v.validateClientTransport(config.Transport))
+	return issues
+}
+
+// serverPortConflicts 检查服务端监听的 TCP 端口是否重复，UDP 与 KCP 端口不参与比较
+func serverPortConflicts(config *Config) []FieldIssue {
+	ports := []struct {
+		field string
+		port  int
+	}{
+		{"bindPort", config.BindPort},
+		{"webServer.port", config.WebServer.Port},
+		{"vhostHTTPPort", config.VhostHTTPPort},
+		{"vhostHTTPSPort", config.VhostHTTPSPort},
+		{"tcpmuxHTTPConnectPort", config.TCPMuxHTTPConnectPort},
+		{"sshTunnelGateway.bindPort", config.SSHTunnelGateway.BindPort},
+	}
+
+	var issues []FieldIssue
+	for i, p := range ports {
+		if p.port == 0 {
+			continue
+		}
+		for _, prev := range ports[:i] {
+			if prev.port == p.port {
+				issues = append(issues, FieldIssue{
+					Field:   p.field,
+					Message: i18n.Sprintf("%s 与 %s 使用了同一端口 %d", p.field, prev.field, p.port),
+				})
+				break
+			}
+		}
+	}
+	return issues
+}
+
+// itemPath 返回列表项的字段路径，有名称时用名称定位，如 proxies[web]，否则用序号
+func itemPath(list string, index int, name string) string {
+	if name == "" {
+		return fmt.Sprintf("%s[%d]", list, index)
+	}
+	return list + "[" + name + "]"
+}
+
+// issueMessages 取出问题说明，丢弃字段路径
+func issueMessages(issues []FieldIssue) []string {
+	if len(issues) == 0 {
+		return nil
+	}
+	messages := make([]string, len(issues))
+	for i, issue := range issues {
+		messages[i] = issue.Message
+	}
+	return messages
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// fileMetaPrefix 配置文件头部元数据注释行的前缀，YAML 和 TOML 都使用 # 注释
+const fileMetaPrefix = "# @"
+
+// 元数据注释中的键，按写入顺序排列
+const (
+	fileMetaGeneratedBy = "generated-by"
+	fileMetaVersion     = "app-version"
+	fileMetaUser        = "modified-by"
+	fileMetaHost        = "hostname"
+	fileMetaTime        = "modified-at"
+)
+
+// FileMeta 保存配置时写在文件头部的元数据，记录最后由谁在哪台机器上修改
+type FileMeta struct {
+	GeneratedBy string
+	Version     string
+	User        string
+	Host        string
+	Time        time.Time
+}
+
+// NewFileMeta 以当前用户、主机和时间创建元数据
+func NewFileMeta() FileMeta {
+	host, _ := os.Hostname()
+	return FileMeta{
+		GeneratedBy: AppName,
+		Version:     AppVersion,
+		User:        CurrentUser(),
+		Host:        host,
+		Time:        time.Now(),
+	}
+}
+
+// Header 生成头部注释，每个字段一行
+func (m FileMeta) Header() string {
+	var b strings.Builder
+	line := func(key, value string) {
+		if value != "" {
+			b.WriteString(fileMetaPrefix + key + ": " + value + "\n")
+		}
+	}
+	line(fileMetaGeneratedBy, m.GeneratedBy)
+	line(fileMetaVersion, m.Version)
+	line(fileMetaUser, m.User)
+	line(fileMetaHost, m.Host)
+	if !m.Time.IsZero() {
+		line(fileMetaTime, m.Time.Format(time.RFC3339))
+	}
+	return b.String()
+}
+
+// Summary 返回“最后由 X 于 Y 修改”的单行描述
+func (m FileMeta) Summary() string {
+	who := m.User
+	if m.Host != "" {
+		who += "@" + m.Host
+	}
+	text := i18n.Sprintf("最后由 %s 于 %s 修改", who, m.Time.Local().Format("2006-01-02 15:04"))
+	if m.GeneratedBy != "" {
+		text += " (" + strings.TrimSpace(m.GeneratedBy+" "+m.Version) + ")"
+	}
+	return text
+}
+
+// ParseFileMeta 解析文件开头注释中的元数据，没有元数据时返回 false
+func ParseFileMeta(data []byte) (FileMeta, bool) {
+	var meta FileMeta
+	found := false
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, fileMetaPrefix), ":")
+		if !strings.HasPrefix(line, fileMetaPrefix) || !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case fileMetaGeneratedBy:
+			meta.GeneratedBy = value
+		case fileMetaVersion:
+			meta.Version = value
+		case fileMetaUser:
+			meta.User = value
+		case fileMetaHost:
+			meta.Host = value
+		case fileMetaTime:
+			meta.Time, _ = time.Parse(time.RFC3339, value)
+		default:
+			continue
+		}
+		found = true
+	}
+	return meta, found
+}
+
+// ReadFileMeta 读取配置文件头部的元数据，文件不存在或没有元数据时返回 nil
+func ReadFileMeta(path string) *FileMeta {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	meta, ok := ParseFileMeta(data)
+	if !ok {
+		return nil
+	}
+	return &meta
+}
+
+// StripFileMeta 去掉文件开头的元数据注释及其后的空行，其他注释保留
+func StripFileMeta(data []byte) []byte {
+	rest := data
+	stripped := false
+	for len(rest) > 0 {
+		line, next, _ := bytes.Cut(rest, []byte("\n"))
+		if !bytes.HasPrefix(line, []byte(fileMetaPrefix)) {
+			break
+		}
+		rest, stripped = next, true
+	}
+	if !stripped {
+		return data
+	}
+	if line, next, _ := bytes.Cut(rest, []byte("\n")); len(bytes.TrimSpace(line)) == 0 {
+		rest = next
+	}
+	return rest
+}
+
+// WithFileMeta 把元数据写到内容开头，与正文之间空一行，替换已有的元数据
+func WithFileMeta(data []byte, meta FileMeta) []byte {
+	return append([]byte(meta.Header()+"\n"), StripFileMeta(data)...)
+}
+
+// CurrentUser 返回执行操作的用户名，通过 sudo 运行时返回原用户
+func CurrentUser() string {
+	if name := os.Getenv("SUDO_USER"); name != "" {
+		return name
+	}
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// ConfigFormat 配置文件格式
+type ConfigFormat string
+
+const (
+	FormatYAML ConfigFormat = "yaml"
+	FormatTOML ConfigFormat = "toml"
+	FormatINI  ConfigFormat = "ini"
+)
+
+// DetectFormat 根据文件扩展名判断配置格式，无法识别时按 YAML 处理
+func DetectFormat(path string) ConfigFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return FormatTOML
+	case ".ini":
+		return FormatINI
+	default:
+		return FormatYAML
+	}
+}
+
+// Extension 返回格式对应的文件扩展名
+func (f ConfigFormat) Extension() string {
+	return "." + string(f)
+}
+
+// MarshalConfig 按指定格式序列化配置，已停用的代理以注释形式写在末尾
+func MarshalConfig(config *Config, format ConfigFormat) ([]byte, error) {
+	active, disabled := splitDisabledProxies(config)
+
+	var data []byte
+	var err error
+	switch format {
+	case FormatTOML:
+		data, err = toml.Marshal(active)
+	case FormatYAML:
+		data, err = yaml.Marshal(active)
+	default:
+		return nil, i18n.Errorf("不支持写入 %s 格式", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return appendDisabledProxies(data, disabled, format)
+}
+
+// UnmarshalConfig 按指定格式解析配置，同时还原注释中已停用的代理，
+// 早期版本写法的字段移到 frp v1 的位置
+func UnmarshalConfig(data []byte, format ConfigFormat) (*Config, error) {
+	config, err := unmarshalConfigRaw(data, format)
+	if err != nil {
+		return nil, err
+	}
+	config.migrateLegacyFields()
+	return config, nil
+}
+
+// unmarshalConfigRaw 解析配置但保留早期写法的字段，合并保存时据此删除原文件中的旧字段
+func unmarshalConfigRaw(data []byte, format ConfigFormat) (*Config, error) {
+	var config Config
+
+	switch format {
+	case FormatTOML:
+		if err := toml.Unmarshal(data, &config); err != nil {
+			return nil, err
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, err
+		}
+	case FormatINI:
+		result, err := ConvertINI(data)
+		if err != nil {
+			return nil, err
+		}
+		return result.Config, nil
+	default:
+		return nil, i18n.Errorf("不支持的配置格式: %s", format)
+	}
+
+	disabled, err := parseDisabledProxies(data, format)
+	if err != nil {
+		return nil, err
+	}
+	config.Proxies = append(config.Proxies, disabled...)
+	return &config, nil
+}
+
+// migrateLegacyFields 把早期版本写法的字段移到 frp v1 的位置，新位置已有值时以新位置为准
+func (c *Config) migrateLegacyFields() {
+	if c.Auth.Token == "" {
+		c.Auth.Token = c.LegacyToken
+	}
+	c.LegacyToken = ""
+	if c.Log.MaxDays == 0 {
+		c.Log.MaxDays = c.Log.LegacyMaxLogFile
+	}
+	c.Log.LegacyMaxLogFile = 0
+	for i := range c.Proxies {
+		c.Proxies[i].migrateLegacyFields()
+	}
+}
+
+// migrateLegacyFields 把代理中早期版本写法的字段移到 frp v1 的位置
+func (p *ProxyConfig) migrateLegacyFields() {
+	if p.HTTPPassword == "" {
+		p.HTTPPassword = p.LegacyHTTPPwd
+	}
+	if p.LoadBalancer.Group == "" {
+		p.LoadBalancer.Group = p.LegacyGroup
+	}
+	if p.LoadBalancer.GroupKey == "" {
+		p.LoadBalancer.GroupKey = p.LegacyGroupKey
+	}
+	p.LegacyHTTPPwd, p.LegacyGroup, p.LegacyGroupKey = "", "", ""
+
+	check := &p.HealthCheck
+	if check.TimeoutS == 0 {
+		check.TimeoutS = check.LegacyTimeoutS
+	}
+	if check.IntervalS == 0 {
+		check.IntervalS = check.LegacyIntervalS
+	}
+	check.LegacyTimeoutS, check.LegacyIntervalS = 0, 0
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// HeaderOperations HTTP 请求头改写配置
+type HeaderOperations struct {
+	Set map[string]string `yaml:"set,omitempty" toml:"set,omitempty"` // 转发前设置的请求头
+}
+
+// ParseHeaders 解析 "X-From-Where=frp,X-Env=prod" 形式的请求头列表，空字符串返回 nil
+func ParseHeaders(value string) (map[string]string, error) {
+	var headers map[string]string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, headerValue, found := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, i18n.Errorf("请求头 %s 格式无效，应为 名称=值", part)
+		}
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers[name] = strings.TrimSpace(headerValue)
+	}
+	return headers, nil
+}
+
+// FormatHeaders 将请求头按名称排序后格式化为逗号分隔的文本
+func FormatHeaders(headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + headers[name]
+	}
+	return strings.Join(parts, ",")
+}
+
+// ValidateHeaders 校验请求头名称只包含 HTTP token 允许的字符
+func ValidateHeaders(headers map[string]string) error {
+	for name := range headers {
+		if name == "" || strings.ContainsFunc(name, func(r rune) bool {
+			return r <= ' ' || r >= 0x7f || strings.ContainsRune(`()<>@,;:\"/[]?={}`, r)
+		}) {
+			return i18n.Errorf("请求头名称 %q 无效", name)
+		}
+	}
+	return nil
+}
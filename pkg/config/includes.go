@@ -0,0 +1,308 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// IncludeDir 拆分代理时默认使用的子目录，与主配置放在同一目录下
+const IncludeDir = "confd"
+
+// disabledIncludeSuffix 整个文件停用时追加的后缀，frpc 的 includes 通配符不会匹配这类文件
+const disabledIncludeSuffix = ".disabled"
+
+// includeNameReplacer 代理名中不适合出现在文件名里的字符
+var includeNameReplacer = regexp.MustCompile(`[^\w.-]+`)
+
+// resolveInclude 将 includes 中的相对路径解析为相对主配置所在目录的路径
+func resolveInclude(configPath, pattern string) string {
+	if filepath.IsAbs(pattern) {
+		return filepath.Clean(pattern)
+	}
+	return filepath.Join(filepath.Dir(configPath), pattern)
+}
+
+// loadIncludes 按 includes 通配符读取拆分出去的代理与访问者，
+// 带 .disabled 后缀的文件中的代理全部视为已停用
+func loadIncludes(config *Config, configPath string) error {
+	config.includeFiles = nil
+	seen := make(map[string]bool)
+
+	for _, pattern := range config.Includes {
+		resolved := resolveInclude(configPath, pattern)
+		enabled, err := filepath.Glob(resolved)
+		if err != nil {
+			return i18n.Errorf("includes 路径 %s 无效: %w", pattern, err)
+		}
+		disabled, _ := filepath.Glob(resolved + disabledIncludeSuffix)
+
+		files := append(enabled, disabled...)
+		sort.Strings(files)
+		for _, file := range files {
+			source := strings.TrimSuffix(file, disabledIncludeSuffix)
+			if seen[source] {
+				continue
+			}
+			seen[source] = true
+
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return i18n.Errorf("读取包含文件 %s 失败: %w", file, err)
+			}
+			part, err := UnmarshalConfig(data, DetectFormat(source))
+			if err != nil {
+				return i18n.Errorf("解析包含文件 %s 失败: %w", file, err)
+			}
+
+			fileDisabled := file != source
+			for _, proxy := range part.Proxies {
+				proxy.Source = source
+				proxy.Disabled = proxy.Disabled || fileDisabled
+				config.Proxies = append(config.Proxies, proxy)
+			}
+			for _, visitor := range part.Visitors {
+				visitor.Source = source
+				config.Visitors = append(config.Visitors, visitor)
+			}
+			config.includeFiles = append(config.includeFiles, source)
+		}
+	}
+	return nil
+}
+
+// IncludeFilePath 为代理生成拆分文件的路径，位于主配置旁的 confd 目录，
+// 与已有拆分文件重名时追加序号
+func IncludeFilePath(config *Config, configPath, proxyName string) string {
+	base := strings.Trim(includeNameReplacer.ReplaceAllString(proxyName, "_"), "._")
+	if base == "" {
+		base = "proxy"
+	}
+
+	used := make(map[string]bool)
+	for _, proxy := range config.Proxies {
+		if proxy.Source != "" {
+			used[proxy.Source] = true
+		}
+	}
+
+	dir := filepath.Join(filepath.Dir(configPath), IncludeDir)
+	ext := DetectFormat(configPath).Extension()
+	path := filepath.Join(dir, base+ext)
+	for n := 2; used[path]; n++ {
+		path = filepath.Join(dir, base+"-"+strconv.Itoa(n)+ext)
+	}
+	return path
+}
+
+// includeFile 一个拆分文件中的内容
+type includeFile struct {
+	proxies  []ProxyConfig
+	visitors []VisitorConfig
+}
+
+// disabled 返回文件是否整体停用：只含代理且全部代理都已停用
+func (f *includeFile) disabled() bool {
+	if len(f.proxies) == 0 || len(f.visitors) > 0 {
+		return false
+	}
+	for _, proxy := range f.proxies {
+		if !proxy.Disabled {
+			return false
+		}
+	}
+	return true
+}
+
+// splitIncludes 拆出带来源文件的代理与访问者，返回只含内联部分的主配置，
+// 并在 includes 中补全能匹配到这些文件的通配符
+func splitIncludes(config *Config, configPath string) (*Config, map[string]*includeFile) {
+	files := make(map[string]*includeFile)
+	main := *config
+	main.Proxies = nil
+	main.Visitors = nil
+
+	fileFor := func(source string) *includeFile {
+		f, ok := files[source]
+		if !ok {
+			f = &includeFile{}
+			files[source] = f
+		}
+		return f
+	}
+	for _, proxy := range config.Proxies {
+		if proxy.Source == "" {
+			main.Proxies = append(main.Proxies, proxy)
+			continue
+		}
+		f := fileFor(proxy.Source)
+		f.proxies = append(f.proxies, proxy)
+	}
+	for _, visitor := range config.Visitors {
+		if visitor.Source == "" {
+			main.Visitors = append(main.Visitors, visitor)
+			continue
+		}
+		f := fileFor(visitor.Source)
+		f.visitors = append(f.visitors, visitor)
+	}
+	if len(files) == 0 {
+		return config, nil
+	}
+
+	main.Includes = append([]string(nil), config.Includes...)
+	for source := range files {
+		if !includeMatches(main.Includes, configPath, source) {
+			main.Includes = append(main.Includes, includePattern(configPath, source))
+		}
+	}
+	sort.Strings(main.Includes[len(config.Includes):])
+	return &main, files
+}
+
+// includeMatches 判断文件是否已被某个 includes 通配符覆盖
+func includeMatches(includes []string, configPath, source string) bool {
+	for _, pattern := range includes {
+		if ok, _ := filepath.Match(resolveInclude(configPath, pattern), source); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// includePattern 生成匹配拆分文件所在目录下同类文件的通配符，尽量使用相对主配置的路径
+func includePattern(configPath, source string) string {
+	pattern := filepath.Join(filepath.Dir(source), "*"+filepath.Ext(source))
+	if rel, err := filepath.Rel(filepath.Dir(configPath), pattern); err == nil && !strings.HasPrefix(rel, "..") {
+		return "./" + filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(pattern)
+}
+
+// renderIncludes 生成各拆分文件将要写入的内容，整体停用的文件使用 .disabled 文件名
+func renderIncludes(files map[string]*includeFile) ([]PendingFile, error) {
+	pending := make([]PendingFile, 0, len(files))
+	for _, source := range sortedKeys(files) {
+		f := files[source]
+		part := &Config{Proxies: f.proxies, Visitors: f.visitors}
+		target, other := source, source+disabledIncludeSuffix
+		if f.disabled() {
+			// 文件名后缀已表示停用，内容中按启用写入，重新启用时只需去掉后缀
+			part.Proxies = make([]ProxyConfig, len(f.proxies))
+			for i, proxy := range f.proxies {
+				proxy.Disabled = false
+				part.Proxies[i] = proxy
+			}
+			target, other = other, target
+		}
+
+		original, err := os.ReadFile(target)
+		if err != nil {
+			original, _ = os.ReadFile(other)
+		}
+		data, err := MarshalConfigPreserving(original, part, DetectFormat(source))
+		if err != nil {
+			return nil, i18n.Errorf("序列化包含文件 %s 失败: %w", source, err)
+		}
+		pending = append(pending, PendingFile{Path: target, Data: data})
+	}
+	return pending, nil
+}
+
+// saveIncludes 写入拆分文件并删除同一文件的另一种启用状态，
+// 已不再包含任何代理的旧拆分文件会被删除
+func saveIncludes(config *Config, files map[string]*includeFile, pending []PendingFile) error {
+	for _, file := range pending {
+		if err := writeIncludeFile(file.Path, file.Data); err != nil {
+			return err
+		}
+		other := file.Path + disabledIncludeSuffix
+		if source, ok := strings.CutSuffix(file.Path, disabledIncludeSuffix); ok {
+			other = source
+		}
+		if err := removeIncludeFile(other); err != nil {
+			return err
+		}
+	}
+
+	for _, source := range config.includeFiles {
+		if files[source] != nil {
+			continue
+		}
+		if err := removeIncludeFile(source); err != nil {
+			return err
+		}
+		if err := removeIncludeFile(source + disabledIncludeSuffix); err != nil {
+			return err
+		}
+	}
+	config.includeFiles = sortedKeys(files)
+	return nil
+}
+
+// writeIncludeFile 内容有变化时备份并写入拆分文件
+func writeIncludeFile(path string, data []byte) error {
+	if old, err := os.ReadFile(path); err == nil && bytes.Equal(old, data) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return i18n.Errorf("创建配置目录失败: %w", err)
+	}
+	if _, err := BackupConfigFile(path, data); err != nil {
+		return i18n.Errorf("备份配置失败: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return i18n.Errorf("写入包含文件 %s 失败: %w", path, err)
+	}
+	return nil
+}
+
+// removeIncludeFile 备份后删除拆分文件，文件不存在时忽略
+func removeIncludeFile(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	if _, err := BackupConfigFile(path, nil); err != nil {
+		return i18n.Errorf("备份配置失败: %w", err)
+	}
+	if err := os.Remove(path); err != nil {
+		return i18n.Errorf("删除包含文件 %s 失败: %w", path, err)
+	}
+	return nil
+}
+
+// IncludedProxyCount 返回保存在拆分文件中的代理数量
+func (c *Config) IncludedProxyCount() int {
+	count := 0
+	for _, proxy := range c.Proxies {
+		if proxy.Source != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// Flatten 返回把拆分文件中的代理与访问者合并回主配置的副本，
+// 用于预览、复制和导出到其他机器等不会携带拆分文件的场景
+func (c *Config) Flatten() *Config {
+	if c == nil || len(c.Includes) == 0 {
+		return c
+	}
+
+	flat := c.Clone()
+	flat.Includes = nil
+	flat.includeFiles = nil
+	for i := range flat.Proxies {
+		flat.Proxies[i].Source = ""
+	}
+	for i := range flat.Visitors {
+		flat.Visitors[i].Source = ""
+	}
+	return flat
+}
@@ -0,0 +1,516 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// INISection INI 配置段
+type INISection struct {
+	Name   string
+	Keys   map[string]string
+	Order  []string // 保留键的原始顺序，便于生成稳定的提示信息
+	LineNo int
+}
+
+// INIImportResult INI 导入结果
+type INIImportResult struct {
+	Config     *Config
+	ConfigType string   // "server" 或 "client"
+	Warnings   []string // 无法迁移或已被忽略的配置项
+}
+
+// ParseINI 解析旧版 frp 使用的 INI 配置
+func ParseINI(content []byte) ([]*INISection, error) {
+	var sections []*INISection
+	var current *INISection
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, i18n.Errorf("第 %d 行: 配置段格式无效: %s", lineNo, line)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, i18n.Errorf("第 %d 行: 配置段名称不能为空", lineNo)
+			}
+			current = &INISection{Name: name, Keys: make(map[string]string), LineNo: lineNo}
+			sections = append(sections, current)
+			continue
+		}
+
+		if current == nil {
+			return nil, i18n.Errorf("第 %d 行: 配置项必须位于配置段内", lineNo)
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, i18n.Errorf("第 %d 行: 缺少 '=': %s", lineNo, line)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if _, exists := current.Keys[key]; !exists {
+			current.Order = append(current.Order, key)
+		}
+		current.Keys[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, i18n.Errorf("读取 INI 内容失败: %w", err)
+	}
+
+	return sections, nil
+}
+
+// ImportINIFile 读取 INI 文件并转换为新版配置
+func ImportINIFile(path string) (*INIImportResult, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取 INI 文件失败: %w", err)
+	}
+
+	result, err := ConvertINI(content)
+	if err != nil {
+		return nil, i18n.Errorf("转换 INI 文件失败: %w", err)
+	}
+
+	return result, nil
+}
+
+// ConvertINI 将 INI 内容转换为新版配置结构
+func ConvertINI(content []byte) (*INIImportResult, error) {
+	sections, err := ParseINI(content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &INIImportResult{Config: &Config{}}
+	hasCommon := false
+
+	for _, section := range sections {
+		if section.Name == "common" {
+			hasCommon = true
+			result.Warnings = append(result.Warnings, convertINICommon(section, result.Config)...)
+			continue
+		}
+
+		if strings.HasPrefix(section.Name, "range:") {
+			proxies, warnings, err := convertINIRangeProxy(section)
+			if err != nil {
+				return nil, err
+			}
+			result.Config.Proxies = append(result.Config.Proxies, proxies...)
+			result.Warnings = append(result.Warnings, warnings...)
+			continue
+		}
+
+		if section.Keys["role"] == "visitor" {
+			visitor, warnings, err := convertINIVisitor(section)
+			if err != nil {
+				return nil, err
+			}
+			result.Config.Visitors = append(result.Config.Visitors, visitor)
+			result.Warnings = append(result.Warnings, warnings...)
+			continue
+		}
+
+		proxy, warnings, err := convertINIProxy(section.Name, section)
+		if err != nil {
+			return nil, err
+		}
+		result.Config.Proxies = append(result.Config.Proxies, proxy)
+		result.Warnings = append(result.Warnings, warnings...)
+	}
+
+	if !hasCommon {
+		result.Warnings = append(result.Warnings, i18n.T("未找到 [common] 配置段，仅迁移了代理配置"))
+	}
+
+	result.ConfigType = DetectConfigType(result.Config)
+	if result.ConfigType == "unknown" {
+		return nil, i18n.Errorf("无法识别配置类型，请确认这是 frps.ini 或 frpc.ini")
+	}
+
+	return result, nil
+}
+
+// INITargetPath 根据 INI 文件路径生成迁移后的目标路径
+func INITargetPath(iniPath string, format ConfigFormat) string {
+	base := strings.TrimSuffix(iniPath, filepath.Ext(iniPath))
+	return base + format.Extension()
+}
+
+// convertINICommon 转换 [common] 配置段
+func convertINICommon(section *INISection, config *Config) []string {
+	var warnings []string
+
+	// 服务端 dashboard_* 与客户端 admin_* 在新版中都对应 webServer
+	for _, key := range section.Order {
+		value := section.Keys[key]
+		var err error
+
+		switch key {
+		case "server_addr":
+			config.ServerAddr = value
+		case "server_port":
+			config.ServerPort, err = parseINIPort(value)
+		case "token":
+			config.Auth.Token = value
+		case "bind_port":
+			config.BindPort, err = parseINIPort(value)
+		case "bind_udp_port":
+			config.BindUDPPort, err = parseINIPort(value)
+		case "kcp_bind_port":
+			config.KCPBindPort, err = parseINIPort(value)
+		case "proxy_bind_addr":
+			config.ProxyBindAddr = value
+		case "allow_ports":
+			config.AllowPorts, err = ParsePortRanges(value)
+		case "max_ports_per_client":
+			config.MaxPortsPerClient, err = strconv.Atoi(value)
+		case "vhost_http_port":
+			config.VhostHTTPPort, err = parseINIPort(value)
+		case "vhost_https_port":
+			config.VhostHTTPSPort, err = parseINIPort(value)
+		case "vhost_http_timeout":
+			config.VhostHTTPTimeout, err = strconv.Atoi(value)
+		case "subdomain_host":
+			config.SubDomainHost = value
+		case "custom_404_page":
+			config.Custom404Page = value
+		case "tcpmux_httpconnect_port":
+			config.TCPMuxHTTPConnectPort, err = parseINIPort(value)
+		case "tcpmux_passthrough":
+			config.TCPMuxPassthrough, err = strconv.ParseBool(value)
+		case "heartbeat_interval":
+			config.Transport.HeartbeatInterval, err = strconv.Atoi(value)
+		case "heartbeat_timeout":
+			config.Transport.HeartbeatTimeout, err = strconv.Atoi(value)
+		case "protocol":
+			config.Transport.Protocol = value
+		case "pool_count":
+			config.Transport.PoolCount, err = strconv.Atoi(value)
+		case "dial_server_timeout":
+			config.Transport.DialServerTimeout, err = strconv.Atoi(value)
+		case "http_proxy":
+			config.Transport.ProxyURL = value
+		case "tls_enable":
+			var enable bool
+			if enable, err = strconv.ParseBool(value); err == nil {
+				config.Transport.TLS.Enable = &enable
+			}
+		case "tls_cert_file":
+			config.Transport.TLS.CertFile = value
+		case "tls_key_file":
+			config.Transport.TLS.KeyFile = value
+		case "tls_trusted_ca_file":
+			config.Transport.TLS.TrustedCaFile = value
+		case "tls_server_name":
+			config.Transport.TLS.ServerName = value
+		case "dashboard_addr", "admin_addr":
+			config.WebServer.Addr = value
+		case "dashboard_port", "admin_port":
+			config.WebServer.Port, err = parseINIPort(value)
+		case "dashboard_user", "admin_user":
+			config.WebServer.User = value
+		case "dashboard_pwd", "admin_pwd":
+			config.WebServer.Password = value
+		case "assets_dir":
+			config.WebServer.AssetsDir = value
+		case "log_file":
+			config.Log.To = value
+		case "log_level":
+			config.Log.Level = value
+		case "log_max_days":
+			config.Log.MaxDays, err = strconv.Atoi(value)
+		case "disable_log_color":
+			config.Log.DisablePrintColor, err = strconv.ParseBool(value)
+		case "authentication_method", "bind_addr", "log_way":
+			// 新版默认行为一致，无需迁移
+		default:
+			warnings = append(warnings, i18n.Sprintf("[common] %s 暂不支持迁移，已忽略", key))
+		}
+
+		if err != nil {
+			warnings = append(warnings, i18n.Sprintf("[common] %s 的值 '%s' 无效: %v", key, value, err))
+		}
+	}
+
+	return warnings
+}
+
+// convertINIProxy 转换单个代理配置段
+func convertINIProxy(name string, section *INISection) (ProxyConfig, []string, error) {
+	proxy := ProxyConfig{Name: name, Type: "tcp"}
+	var warnings []string
+	pluginParams := make(map[string]string)
+
+	for _, key := range section.Order {
+		value := section.Keys[key]
+		var err error
+
+		switch {
+		case key == "type":
+			proxy.Type = value
+		case key == "local_ip":
+			proxy.LocalIP = value
+		case key == "local_port":
+			proxy.LocalPort, err = parseINIPort(value)
+		case key == "remote_port":
+			proxy.RemotePort, err = parseINIPort(value)
+		case key == "custom_domains":
+			proxy.CustomDomains = splitINIList(value)
+		case key == "subdomain":
+			proxy.Subdomain = value
+		case key == "locations":
+			proxy.Locations = splitINIList(value)
+		case key == "http_user":
+			proxy.HTTPUser = value
+		case key == "http_pwd":
+			proxy.HTTPPassword = value
+		case key == "host_header_rewrite":
+			proxy.HostHeaderRewrite = value
+		case key == "sk":
+			proxy.SecretKey = value
+		case key == "role":
+			proxy.Role = value
+		case key == "server_name":
+			proxy.ServerName = value
+		case key == "plugin":
+			proxy.Plugin.Type = value
+		case strings.HasPrefix(key, "plugin_"):
+			pluginParams[strings.TrimPrefix(key, "plugin_")] = value
+		case strings.HasPrefix(key, "header_"):
+			if proxy.RequestHeaders.Set == nil {
+				proxy.RequestHeaders.Set = make(map[string]string)
+			}
+			proxy.RequestHeaders.Set[strings.TrimPrefix(key, "header_")] = value
+		case key == "group":
+			proxy.LoadBalancer.Group = value
+		case key == "group_key":
+			proxy.LoadBalancer.GroupKey = value
+		case key == "health_check_type":
+			proxy.HealthCheck.Type = value
+		case key == "health_check_timeout_s":
+			proxy.HealthCheck.TimeoutS, err = strconv.Atoi(value)
+		case key == "health_check_max_failed":
+			proxy.HealthCheck.MaxFailed, err = strconv.Atoi(value)
+		case key == "health_check_interval_s":
+			proxy.HealthCheck.IntervalS, err = strconv.Atoi(value)
+		case key == "health_check_url":
+			proxy.HealthCheck.Path = value
+		case key == "bandwidth_limit":
+			proxy.Transport.BandwidthLimit = value
+		case key == "use_encryption":
+			proxy.Transport.UseEncryption, err = strconv.ParseBool(value)
+		case key == "use_compression":
+			proxy.Transport.UseCompression, err = strconv.ParseBool(value)
+		default:
+			warnings = append(warnings, i18n.Sprintf("[%s] %s 暂不支持迁移，已忽略", section.Name, key))
+		}
+
+		if err != nil {
+			return proxy, nil, i18n.Errorf("[%s] %s 的值 '%s' 无效: %w", section.Name, key, value, err)
+		}
+	}
+
+	warnings = append(warnings, convertINIPluginParams(section.Name, &proxy.Plugin, pluginParams)...)
+
+	return proxy, warnings, nil
+}
+
+// convertINIPluginParams 将 plugin_* 参数转换为插件配置字段
+func convertINIPluginParams(sectionName string, plugin *PluginConfig, params map[string]string) []string {
+	var warnings []string
+	var user, password string
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := params[key]
+		switch {
+		case key == "unix_path":
+			plugin.UnixPath = value
+		case key == "http_user", key == "user":
+			user = value
+		case key == "http_passwd", key == "passwd":
+			password = value
+		case key == "local_path":
+			plugin.LocalPath = value
+		case key == "strip_prefix":
+			plugin.StripPrefix = value
+		case key == "local_addr":
+			plugin.LocalAddr = value
+		case key == "crt_path":
+			plugin.CrtPath = value
+		case key == "key_path":
+			plugin.KeyPath = value
+		case key == "host_header_rewrite":
+			plugin.HostHeaderRewrite = value
+		case strings.HasPrefix(key, "header_"):
+			if plugin.RequestHeaders.Set == nil {
+				plugin.RequestHeaders.Set = make(map[string]string)
+			}
+			plugin.RequestHeaders.Set[strings.TrimPrefix(key, "header_")] = value
+		default:
+			warnings = append(warnings, i18n.Sprintf("[%s] plugin_%s 暂不支持迁移，已忽略", sectionName, key))
+		}
+	}
+
+	if user != "" || password != "" {
+		plugin.SetAuth(user, password)
+	}
+	return warnings
+}
+
+// convertINIVisitor 转换访问者配置段
+func convertINIVisitor(section *INISection) (VisitorConfig, []string, error) {
+	visitor := VisitorConfig{Name: section.Name}
+	var warnings []string
+
+	for _, key := range section.Order {
+		value := section.Keys[key]
+		var err error
+
+		switch key {
+		case "type":
+			visitor.Type = value
+		case "server_name":
+			visitor.ServerName = value
+		case "sk":
+			visitor.SecretKey = value
+		case "bind_addr":
+			visitor.BindAddr = value
+		case "bind_port":
+			visitor.BindPort, err = parseINIPort(value)
+		case "role":
+			// 已通过 role 判断为访问者
+		default:
+			warnings = append(warnings, i18n.Sprintf("[%s] %s 暂不支持迁移，已忽略", section.Name, key))
+		}
+
+		if err != nil {
+			return visitor, nil, i18n.Errorf("[%s] %s 的值 '%s' 无效: %w", section.Name, key, value, err)
+		}
+	}
+
+	return visitor, warnings, nil
+}
+
+// convertINIRangeProxy 展开 [range:name] 批量端口配置段
+func convertINIRangeProxy(section *INISection) ([]ProxyConfig, []string, error) {
+	prefix := strings.TrimPrefix(section.Name, "range:")
+
+	localPorts, err := parseINIPortRanges(section.Keys["local_port"])
+	if err != nil {
+		return nil, nil, i18n.Errorf("[%s] local_port 无效: %w", section.Name, err)
+	}
+	remotePorts, err := parseINIPortRanges(section.Keys["remote_port"])
+	if err != nil {
+		return nil, nil, i18n.Errorf("[%s] remote_port 无效: %w", section.Name, err)
+	}
+	if len(localPorts) != len(remotePorts) {
+		return nil, nil, i18n.Errorf("[%s] local_port 与 remote_port 数量不一致", section.Name)
+	}
+
+	// 端口字段由展开逻辑单独处理，其余字段复用普通代理的转换
+	base := &INISection{Name: section.Name, Keys: make(map[string]string), LineNo: section.LineNo}
+	for _, key := range section.Order {
+		if key == "local_port" || key == "remote_port" {
+			continue
+		}
+		base.Order = append(base.Order, key)
+		base.Keys[key] = section.Keys[key]
+	}
+
+	template, warnings, err := convertINIProxy(prefix, base)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proxies := make([]ProxyConfig, len(localPorts))
+	for i := range localPorts {
+		proxy := template
+		proxy.Name = fmt.Sprintf("%s_%d", prefix, i)
+		proxy.LocalPort = localPorts[i]
+		proxy.RemotePort = remotePorts[i]
+		proxies[i] = proxy
+	}
+
+	return proxies, warnings, nil
+}
+
+// parseINIPort 解析端口号
+func parseINIPort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, i18n.Errorf("端口必须是数字")
+	}
+	return port, nil
+}
+
+// parseINIPortRanges 解析 "6000-6005,6007" 形式的端口范围
+func parseINIPortRanges(value string) ([]int, error) {
+	var ports []int
+	for _, part := range splitINIList(value) {
+		if start, end, found := strings.Cut(part, "-"); found {
+			startPort, err := parseINIPort(strings.TrimSpace(start))
+			if err != nil {
+				return nil, err
+			}
+			endPort, err := parseINIPort(strings.TrimSpace(end))
+			if err != nil {
+				return nil, err
+			}
+			if endPort < startPort {
+				return nil, i18n.Errorf("端口范围 %s 无效", part)
+			}
+			for port := startPort; port <= endPort; port++ {
+				ports = append(ports, port)
+			}
+			continue
+		}
+
+		port, err := parseINIPort(part)
+		if err != nil {
+			return nil, err
+		}
+		ports = append(ports, port)
+	}
+
+	if len(ports) == 0 {
+		return nil, i18n.Errorf("端口不能为空")
+	}
+	return ports, nil
+}
+
+// splitINIList 拆分逗号分隔的列表
+func splitINIList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,98 @@
+package config
+
+import "testing"
+
+func TestConvertINIPassesSchemaCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		ini    string
+		server bool
+		check  func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "server",
+			ini: `[common]
+bind_port = 7000
+token = secret
+log_file = /var/log/frps.log
+log_max_days = 7
+`,
+			server: true,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Log.MaxDays != 7 {
+					t.Errorf("Log.MaxDays = %d, want 7", cfg.Log.MaxDays)
+				}
+				if cfg.Auth.Token != "secret" {
+					t.Errorf("Auth.Token = %q, want %q", cfg.Auth.Token, "secret")
+				}
+			},
+		},
+		{
+			name: "client",
+			ini: `[common]
+server_addr = example.com
+server_port = 7000
+token = secret
+log_max_days = 3
+
+[web]
+type = http
+local_port = 8080
+custom_domains = web.example.com
+http_user = admin
+http_pwd = pass
+
+[ssh]
+type = tcp
+local_port = 22
+remote_port = 6000
+group = ssh
+group_key = key
+`,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Log.MaxDays != 3 {
+					t.Errorf("Log.MaxDays = %d, want 3", cfg.Log.MaxDays)
+				}
+				if len(cfg.Proxies) != 2 {
+					t.Fatalf("got %d proxies, want 2", len(cfg.Proxies))
+				}
+				for _, proxy := range cfg.Proxies {
+					switch proxy.Name {
+					case "web":
+						if proxy.HTTPPassword != "pass" {
+							t.Errorf("web HTTPPassword = %q, want %q", proxy.HTTPPassword, "pass")
+						}
+					case "ssh":
+						if proxy.LoadBalancer != (LoadBalancerConfig{Group: "ssh", GroupKey: "key"}) {
+							t.Errorf("ssh LoadBalancer = %+v", proxy.LoadBalancer)
+						}
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ConvertINI([]byte(tt.ini))
+			if err != nil {
+				t.Fatalf("ConvertINI: %v", err)
+			}
+			tt.check(t, result.Config)
+
+			for _, format := range []ConfigFormat{FormatYAML, FormatTOML} {
+				data, err := MarshalConfig(result.Config, format)
+				if err != nil {
+					t.Fatalf("MarshalConfig(%s): %v", format, err)
+				}
+				issues, err := CheckSchema(data, format, tt.server)
+				if err != nil {
+					t.Fatalf("CheckSchema(%s): %v", format, err)
+				}
+				for _, issue := range issues {
+					t.Errorf("%s: unexpected schema issue: %s\n%s", format, issue, data)
+				}
+			}
+		})
+	}
+}
@@ -1,119 +1,225 @@
 package config
 
 import (
-	"fmt"
+	"bytes"
 	"io"
 	"os"
 	"path/filepath"
-	"time"
 
-	"gopkg.in/yaml.v3"
+	"frp-cli-ui/pkg/i18n"
 )
 
 // Config FRP 配置结构
 type Config struct {
 	// 通用配置
-	ServerAddr string `yaml:"serverAddr,omitempty"`
-	ServerPort int    `yaml:"serverPort,omitempty"`
-	Token      string `yaml:"token,omitempty"`
+	ServerAddr string `yaml:"serverAddr,omitempty" toml:"serverAddr,omitempty"`
+	ServerPort int    `yaml:"serverPort,omitempty" toml:"serverPort,omitempty"`
+
+	// 身份验证配置
+	Auth AuthConfig `yaml:"auth,omitempty" toml:"auth,omitempty"`
+
+	// LegacyToken 早期版本写在顶层的 token，frp v1 不认识，加载时移到 auth.token，保存时不再写出
+	LegacyToken string `yaml:"token,omitempty" toml:"token,omitempty"`
 
 	// 服务端配置
-	BindPort      int    `yaml:"bindPort,omitempty"`
-	BindUDPPort   int    `yaml:"bindUDPPort,omitempty"`
-	KCPBindPort   int    `yaml:"kcpBindPort,omitempty"`
-	ProxyBindAddr string `yaml:"proxyBindAddr,omitempty"`
+	BindPort      int    `yaml:"bindPort,omitempty" toml:"bindPort,omitempty"`
+	BindUDPPort   int    `yaml:"bindUDPPort,omitempty" toml:"bindUDPPort,omitempty"`
+	KCPBindPort   int    `yaml:"kcpBindPort,omitempty" toml:"kcpBindPort,omitempty"`
+	ProxyBindAddr string `yaml:"proxyBindAddr,omitempty" toml:"proxyBindAddr,omitempty"`
+
+	// 服务端端口与虚拟主机配置
+	AllowPorts            []PortRange `yaml:"allowPorts,omitempty" toml:"allowPorts,omitempty"`                       // 允许客户端使用的远程端口范围
+	MaxPortsPerClient     int         `yaml:"maxPortsPerClient,omitempty" toml:"maxPortsPerClient,omitempty"`         // 每个客户端最多可用的端口数，0 表示不限
+	VhostHTTPPort         int         `yaml:"vhostHTTPPort,omitempty" toml:"vhostHTTPPort,omitempty"`                 // HTTP 虚拟主机端口
+	VhostHTTPSPort        int         `yaml:"vhostHTTPSPort,omitempty" toml:"vhostHTTPSPort,omitempty"`               // HTTPS 虚拟主机端口
+	VhostHTTPTimeout      int         `yaml:"vhostHTTPTimeout,omitempty" toml:"vhostHTTPTimeout,omitempty"`           // HTTP 代理的响应头超时，单位秒，frps 默认 60
+	SubDomainHost         string      `yaml:"subDomainHost,omitempty" toml:"subDomainHost,omitempty"`                 // 子域名的主域名
+	Custom404Page         string      `yaml:"custom404Page,omitempty" toml:"custom404Page,omitempty"`                 // 找不到 HTTP 代理时返回的页面文件
+	TCPMuxHTTPConnectPort int         `yaml:"tcpmuxHTTPConnectPort,omitempty" toml:"tcpmuxHTTPConnectPort,omitempty"` // tcpmux 的 HTTP CONNECT 端口
+	TCPMuxPassthrough     bool        `yaml:"tcpmuxPassthrough,omitempty" toml:"tcpmuxPassthrough,omitempty"`         // 把 CONNECT 请求原样转发给客户端
+
+	// SSH 隧道网关，frp v0.53 起支持，未运行 frpc 的机器可直接用 ssh -R 创建代理
+	SSHTunnelGateway SSHTunnelGatewayConfig `yaml:"sshTunnelGateway,omitempty" toml:"sshTunnelGateway,omitempty"`
+
+	// 传输层配置
+	Transport TransportConfig `yaml:"transport,omitempty" toml:"transport,omitempty"`
 
 	// Web 服务器配置
-	WebServer WebServerConfig `yaml:"webServer,omitempty"`
+	WebServer WebServerConfig `yaml:"webServer,omitempty" toml:"webServer,omitempty"`
 
 	// 日志配置
-	Log LogConfig `yaml:"log,omitempty"`
+	Log LogConfig `yaml:"log,omitempty" toml:"log,omitempty"`
+
+	// 从其他文件加载的代理与访问者，支持通配符，相对路径以主配置所在目录为准
+	Includes []string `yaml:"includes,omitempty" toml:"includes,omitempty"`
 
 	// 客户端代理配置
-	Proxies []ProxyConfig `yaml:"proxies,omitempty"`
+	Proxies []ProxyConfig `yaml:"proxies,omitempty" toml:"proxies,omitempty"`
 
 	// 访问者配置
-	Visitors []VisitorConfig `yaml:"visitors,omitempty"`
+	Visitors []VisitorConfig `yaml:"visitors,omitempty" toml:"visitors,omitempty"`
+
+	// 加载时读取到的拆分文件，保存时据此删除已不再使用的文件
+	includeFiles []string
 }
 
 // WebServerConfig Web 服务器配置
 type WebServerConfig struct {
-	Addr        string `yaml:"addr,omitempty"`
-	Port        int    `yaml:"port,omitempty"`
-	User        string `yaml:"user,omitempty"`
-	Password    string `yaml:"password,omitempty"`
-	AssetsDir   string `yaml:"assetsDir,omitempty"`
-	PProfEnable bool   `yaml:"pprofEnable,omitempty"`
+	Addr        string `yaml:"addr,omitempty" toml:"addr,omitempty"`
+	Port        int    `yaml:"port,omitempty" toml:"port,omitempty"`
+	User        string `yaml:"user,omitempty" toml:"user,omitempty"`
+	Password    string `yaml:"password,omitempty" toml:"password,omitempty"`
+	AssetsDir   string `yaml:"assetsDir,omitempty" toml:"assetsDir,omitempty"`
+	PProfEnable bool   `yaml:"pprofEnable,omitempty" toml:"pprofEnable,omitempty"`
+}
+
+// SSHTunnelGatewayConfig 服务端 SSH 隧道网关配置
+type SSHTunnelGatewayConfig struct {
+	BindPort              int    `yaml:"bindPort,omitempty" toml:"bindPort,omitempty"`                           // SSH 监听端口，0 表示不启用
+	PrivateKeyFile        string `yaml:"privateKeyFile,omitempty" toml:"privateKeyFile,omitempty"`               // SSH 主机私钥
+	AutoGenPrivateKeyPath string `yaml:"autoGenPrivateKeyPath,omitempty" toml:"autoGenPrivateKeyPath,omitempty"` // 未指定私钥时自动生成的私钥保存路径
+	AuthorizedKeysFile    string `yaml:"authorizedKeysFile,omitempty" toml:"authorizedKeysFile,omitempty"`       // 允许连接的公钥列表，留空时不校验客户端公钥
+}
+
+// TransportConfig 传输层配置
+type TransportConfig struct {
+	HeartbeatInterval int `yaml:"heartbeatInterval,omitempty" toml:"heartbeatInterval,omitempty"` // 客户端心跳间隔，单位秒，-1 表示关闭
+	HeartbeatTimeout  int `yaml:"heartbeatTimeout,omitempty" toml:"heartbeatTimeout,omitempty"`   // 心跳超时，单位秒，-1 表示关闭
+
+	// 客户端传输配置
+	Protocol          string    `yaml:"protocol,omitempty" toml:"protocol,omitempty"`                   // 与服务端通信的协议：tcp/kcp/quic/websocket/wss
+	PoolCount         int       `yaml:"poolCount,omitempty" toml:"poolCount,omitempty"`                 // 预先建立的连接数
+	DialServerTimeout int       `yaml:"dialServerTimeout,omitempty" toml:"dialServerTimeout,omitempty"` // 连接服务端超时，单位秒
+	ProxyURL          string    `yaml:"proxyURL,omitempty" toml:"proxyURL,omitempty"`                   // 通过 http/socks5 代理连接服务端
+	TLS               TLSConfig `yaml:"tls,omitempty" toml:"tls,omitempty"`
+}
+
+// TransportProtocols frpc 支持的传输协议
+var TransportProtocols = []string{"tcp", "kcp", "quic", "websocket", "wss"}
+
+// TLSConfig 客户端 TLS 配置
+type TLSConfig struct {
+	Enable        *bool  `yaml:"enable,omitempty" toml:"enable,omitempty"`               // 为空时使用 frpc 默认值（开启）
+	CertFile      string `yaml:"certFile,omitempty" toml:"certFile,omitempty"`           // 客户端证书
+	KeyFile       string `yaml:"keyFile,omitempty" toml:"keyFile,omitempty"`             // 客户端私钥
+	TrustedCaFile string `yaml:"trustedCaFile,omitempty" toml:"trustedCaFile,omitempty"` // 用于校验服务端证书的 CA
+	ServerName    string `yaml:"serverName,omitempty" toml:"serverName,omitempty"`       // 校验证书时使用的服务端名称
+}
+
+// Enabled 返回是否启用 TLS，未设置时与 frpc 默认一致为开启
+func (t TLSConfig) Enabled() bool {
+	return t.Enable == nil || *t.Enable
+}
+
+// AuthConfig 身份验证配置
+type AuthConfig struct {
+	Token string `yaml:"token,omitempty" toml:"token,omitempty"` // 服务端与客户端必须一致
 }
 
 // LogConfig 日志配置
 type LogConfig struct {
-	To                string `yaml:"to,omitempty"`
-	Level             string `yaml:"level,omitempty"`
-	MaxLogFile        int    `yaml:"maxLogFile,omitempty"`
-	DisablePrintColor bool   `yaml:"disablePrintColor,omitempty"`
+	To                string `yaml:"to,omitempty" toml:"to,omitempty"`
+	Level             string `yaml:"level,omitempty" toml:"level,omitempty"`
+	MaxDays           int    `yaml:"maxDays,omitempty" toml:"maxDays,omitempty"` // 日志文件保留天数
+	DisablePrintColor bool   `yaml:"disablePrintColor,omitempty" toml:"disablePrintColor,omitempty"`
+
+	// LegacyMaxLogFile 早期版本使用的 maxLogFile，加载时移到 maxDays，保存时不再写出
+	LegacyMaxLogFile int `yaml:"maxLogFile,omitempty" toml:"maxLogFile,omitempty"`
 }
 
 // ProxyConfig 代理配置
 type ProxyConfig struct {
-	Name      string `yaml:"name"`
-	Type      string `yaml:"type"`
-	LocalIP   string `yaml:"localIP,omitempty"`
-	LocalPort int    `yaml:"localPort,omitempty"`
+	Name      string `yaml:"name" toml:"name"`
+	Type      string `yaml:"type" toml:"type"`
+	LocalIP   string `yaml:"localIP,omitempty" toml:"localIP,omitempty"`
+	LocalPort int    `yaml:"localPort,omitempty" toml:"localPort,omitempty"`
+
+	// 已停用的代理保留配置但不会被 frpc 加载，保存时写在配置末尾的注释中
+	Disabled bool `yaml:"disabled,omitempty" toml:"disabled,omitempty"`
+
+	// 代理所在的拆分文件，为空表示写在主配置中
+	Source string `yaml:"-" toml:"-"`
 
 	// TCP/UDP 代理配置
-	RemotePort int `yaml:"remotePort,omitempty"`
+	RemotePort int `yaml:"remotePort,omitempty" toml:"remotePort,omitempty"`
 
 	// HTTP/HTTPS 代理配置
-	CustomDomains     []string `yaml:"customDomains,omitempty"`
-	Subdomain         string   `yaml:"subdomain,omitempty"`
-	Locations         []string `yaml:"locations,omitempty"`
-	HTTPUser          string   `yaml:"httpUser,omitempty"`
-	HTTPPwd           string   `yaml:"httpPwd,omitempty"`
-	HostHeaderRewrite string   `yaml:"hostHeaderRewrite,omitempty"`
+	CustomDomains     []string `yaml:"customDomains,omitempty" toml:"customDomains,omitempty"`
+	Subdomain         string   `yaml:"subdomain,omitempty" toml:"subdomain,omitempty"`
+	Locations         []string `yaml:"locations,omitempty" toml:"locations,omitempty"`
+	HTTPUser          string   `yaml:"httpUser,omitempty" toml:"httpUser,omitempty"`
+	HTTPPassword      string   `yaml:"httpPassword,omitempty" toml:"httpPassword,omitempty"`
+	HostHeaderRewrite string   `yaml:"hostHeaderRewrite,omitempty" toml:"hostHeaderRewrite,omitempty"`
+
+	RequestHeaders HeaderOperations `yaml:"requestHeaders,omitempty" toml:"requestHeaders,omitempty"`
 
 	// STCP/SUDP/XTCP 代理配置
-	SecretKey  string `yaml:"secretKey,omitempty"`
-	Role       string `yaml:"role,omitempty"`
-	ServerName string `yaml:"serverName,omitempty"`
+	SecretKey  string `yaml:"secretKey,omitempty" toml:"secretKey,omitempty"`
+	Role       string `yaml:"role,omitempty" toml:"role,omitempty"`
+	ServerName string `yaml:"serverName,omitempty" toml:"serverName,omitempty"`
 
 	// 插件配置
-	Plugin       string            `yaml:"plugin,omitempty"`
-	PluginParams map[string]string `yaml:"pluginParams,omitempty"`
+	Plugin PluginConfig `yaml:"plugin,omitempty" toml:"plugin,omitempty"`
 
 	// 负载均衡配置
-	Group    string `yaml:"group,omitempty"`
-	GroupKey string `yaml:"groupKey,omitempty"`
+	LoadBalancer LoadBalancerConfig `yaml:"loadBalancer,omitempty" toml:"loadBalancer,omitempty"`
 
 	// 健康检查配置
-	HealthCheck HealthCheckConfig `yaml:"healthCheck,omitempty"`
+	HealthCheck HealthCheckConfig `yaml:"healthCheck,omitempty" toml:"healthCheck,omitempty"`
+
+	// 加密、压缩与带宽限制
+	Transport ProxyTransport `yaml:"transport,omitempty" toml:"transport,omitempty"`
+
+	// 早期版本写在代理顶层的 httpPwd、group、groupKey，加载时移到 frp v1 的位置，保存时不再写出
+	LegacyHTTPPwd  string `yaml:"httpPwd,omitempty" toml:"httpPwd,omitempty"`
+	LegacyGroup    string `yaml:"group,omitempty" toml:"group,omitempty"`
+	LegacyGroupKey string `yaml:"groupKey,omitempty" toml:"groupKey,omitempty"`
+}
 
-	// 带宽限制
-	BandwidthLimit string `yaml:"bandwidthLimit,omitempty"`
+// LoadBalancerConfig 负载均衡配置，同一分组的代理共用远程端口或域名
+type LoadBalancerConfig struct {
+	Group    string `yaml:"group,omitempty" toml:"group,omitempty"`
+	GroupKey string `yaml:"groupKey,omitempty" toml:"groupKey,omitempty"` // 加入分组时验证，同一分组必须一致
+}
 
-	// 其他配置
-	UseEncryption  bool `yaml:"useEncryption,omitempty"`
-	UseCompression bool `yaml:"useCompression,omitempty"`
+// ProxyTransport 代理传输配置
+type ProxyTransport struct {
+	UseEncryption  bool   `yaml:"useEncryption,omitempty" toml:"useEncryption,omitempty"`
+	UseCompression bool   `yaml:"useCompression,omitempty" toml:"useCompression,omitempty"`
+	BandwidthLimit string `yaml:"bandwidthLimit,omitempty" toml:"bandwidthLimit,omitempty"` // 如 1MB、500KB，留空不限速
 }
 
 // VisitorConfig 访问者配置
 type VisitorConfig struct {
-	Name       string `yaml:"name"`
-	Type       string `yaml:"type"`
-	ServerName string `yaml:"serverName"`
-	SecretKey  string `yaml:"secretKey"`
-	BindAddr   string `yaml:"bindAddr,omitempty"`
-	BindPort   int    `yaml:"bindPort"`
+	Name       string `yaml:"name" toml:"name"`
+	Type       string `yaml:"type" toml:"type"`
+	ServerName string `yaml:"serverName" toml:"serverName"`
+	SecretKey  string `yaml:"secretKey" toml:"secretKey"`
+	BindAddr   string `yaml:"bindAddr,omitempty" toml:"bindAddr,omitempty"`
+	BindPort   int    `yaml:"bindPort" toml:"bindPort"`
+
+	// 访问者所在的拆分文件，为空表示写在主配置中
+	Source string `yaml:"-" toml:"-"`
 }
 
 // HealthCheckConfig 健康检查配置
 type HealthCheckConfig struct {
-	Type        string   `yaml:"type,omitempty"`
-	TimeoutS    int      `yaml:"timeoutS,omitempty"`
-	MaxFailed   int      `yaml:"maxFailed,omitempty"`
-	IntervalS   int      `yaml:"intervalS,omitempty"`
-	Path        string   `yaml:"path,omitempty"`
-	HTTPHeaders []string `yaml:"httpHeaders,omitempty"`
+	Type        string       `yaml:"type,omitempty" toml:"type,omitempty"`
+	TimeoutS    int          `yaml:"timeoutSeconds,omitempty" toml:"timeoutSeconds,omitempty"`
+	MaxFailed   int          `yaml:"maxFailed,omitempty" toml:"maxFailed,omitempty"`
+	IntervalS   int          `yaml:"intervalSeconds,omitempty" toml:"intervalSeconds,omitempty"`
+	Path        string       `yaml:"path,omitempty" toml:"path,omitempty"`
+	HTTPHeaders []HTTPHeader `yaml:"httpHeaders,omitempty" toml:"httpHeaders,omitempty"`
+
+	// 早期版本使用的 timeoutS、intervalS，加载时移到 timeoutSeconds、intervalSeconds，保存时不再写出
+	LegacyTimeoutS  int `yaml:"timeoutS,omitempty" toml:"timeoutS,omitempty"`
+	LegacyIntervalS int `yaml:"intervalS,omitempty" toml:"intervalS,omitempty"`
+}
+
+// HTTPHeader HTTP 健康检查请求中附带的请求头
+type HTTPHeader struct {
+	Name  string `yaml:"name" toml:"name"`
+	Value string `yaml:"value" toml:"value"`
 }
 
 // Loader 配置加载器
@@ -133,29 +239,32 @@ func NewLoader(configPath string) *Loader {
 func (l *Loader) Load() (*Config, error) {
 	// 检查文件是否存在
 	if _, err := os.Stat(l.configPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("配置文件不存在: %s", l.configPath)
+		return nil, i18n.Errorf("配置文件不存在: %s", l.configPath)
 	}
 
 	// 读取文件内容
 	file, err := os.Open(l.configPath)
 	if err != nil {
-		return nil, fmt.Errorf("打开配置文件失败: %w", err)
+		return nil, i18n.Errorf("打开配置文件失败: %w", err)
 	}
 	defer file.Close()
 
 	content, err := io.ReadAll(file)
 	if err != nil {
-		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+		return nil, i18n.Errorf("读取配置文件失败: %w", err)
 	}
 
-	// 解析 YAML
-	var config Config
-	if err := yaml.Unmarshal(content, &config); err != nil {
-		return nil, fmt.Errorf("解析配置文件失败: %w", err)
+	// 按扩展名解析 YAML/TOML
+	config, err := UnmarshalConfig(content, DetectFormat(l.configPath))
+	if err != nil {
+		return nil, i18n.Errorf("解析配置文件失败: %w", err)
+	}
+	if err := loadIncludes(config, l.configPath); err != nil {
+		return nil, err
 	}
 
-	l.config = &config
-	return &config, nil
+	l.config = config
+	return config, nil
 }
 
 // Save 保存配置文件
@@ -163,24 +272,71 @@ func (l *Loader) Save(config *Config) error {
 	// 创建目录（如果不存在）
 	dir := filepath.Dir(l.configPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("创建配置目录失败: %w", err)
+		return i18n.Errorf("创建配置目录失败: %w", err)
 	}
 
-	// 序列化为 YAML
-	data, err := yaml.Marshal(config)
+	main, includes, pending, err := l.render(config)
 	if err != nil {
-		return fmt.Errorf("序列化配置失败: %w", err)
+		return err
+	}
+	data := pending[0].Data
+
+	// 覆盖前自动备份旧内容，备份失败时不写入以免丢失原配置
+	if _, err := BackupConfigFile(l.configPath, data); err != nil {
+		return i18n.Errorf("备份配置失败: %w", err)
 	}
 
 	// 写入文件
 	if err := os.WriteFile(l.configPath, data, 0644); err != nil {
-		return fmt.Errorf("写入配置文件失败: %w", err)
+		return i18n.Errorf("写入配置文件失败: %w", err)
 	}
+	if err := saveIncludes(config, includes, pending[1:]); err != nil {
+		return err
+	}
+	config.Includes = main.Includes
 
 	l.config = config
 	return nil
 }
 
+// PendingFile 保存配置时将要写入的文件
+type PendingFile struct {
+	Path string
+	Data []byte
+}
+
+// DryRun 生成保存时将写入的主配置和拆分文件内容，不修改磁盘，可用于预览和结构检查
+func (l *Loader) DryRun(config *Config) ([]PendingFile, error) {
+	_, _, pending, err := l.render(config)
+	return pending, err
+}
+
+// render 生成主配置和各拆分文件的内容，第一个文件总是主配置
+func (l *Loader) render(config *Config) (*Config, map[string]*includeFile, []PendingFile, error) {
+	// 拆分到 confd 等目录的代理单独写入各自的文件，主配置只保留 includes
+	main, includes := splitIncludes(config, l.configPath)
+
+	// 按扩展名序列化为 YAML/TOML，在原文件基础上合并以保留注释和键的顺序
+	original, _ := os.ReadFile(l.configPath)
+	body := StripFileMeta(original)
+	data, err := MarshalConfigPreserving(body, main, DetectFormat(l.configPath))
+	if err != nil {
+		return nil, nil, nil, i18n.Errorf("序列化配置失败: %w", err)
+	}
+	// 内容有变化时在头部记录修改人，未变化时保留原文件和原来的修改记录
+	if original != nil && bytes.Equal(data, body) {
+		data = original
+	} else {
+		data = WithFileMeta(data, NewFileMeta())
+	}
+
+	files, err := renderIncludes(includes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return main, includes, append([]PendingFile{{Path: l.configPath, Data: data}}, files...), nil
+}
+
 // GetConfig 获取当前配置
 func (l *Loader) GetConfig() *Config {
 	return l.config
@@ -226,12 +382,12 @@ func CreateDefaultClientConfig() *Config {
 // AddProxy 添加代理配置
 func (l *Loader) AddProxy(proxy ProxyConfig) error {
 	if l.config == nil {
-		return fmt.Errorf("配置尚未加载")
+		return i18n.Errorf("配置尚未加载")
 	}
 
 	for _, existingProxy := range l.config.Proxies {
 		if existingProxy.Name == proxy.Name {
-			return fmt.Errorf("代理名称 '%s' 已存在", proxy.Name)
+			return i18n.Errorf("代理名称 '%s' 已存在", proxy.Name)
 		}
 	}
 
@@ -242,7 +398,7 @@ func (l *Loader) AddProxy(proxy ProxyConfig) error {
 // RemoveProxy 移除代理配置
 func (l *Loader) RemoveProxy(name string) error {
 	if l.config == nil {
-		return fmt.Errorf("配置尚未加载")
+		return i18n.Errorf("配置尚未加载")
 	}
 
 	for i, proxy := range l.config.Proxies {
@@ -252,30 +408,40 @@ func (l *Loader) RemoveProxy(name string) error {
 		}
 	}
 
-	return fmt.Errorf("未找到名称为 '%s' 的代理", name)
+	return i18n.Errorf("未找到名称为 '%s' 的代理", name)
 }
 
-// UpdateProxy 更新代理配置
+// UpdateProxy 更新代理配置，newProxy 名称为空时沿用原名称，改名时不能与其他代理重名
 func (l *Loader) UpdateProxy(name string, newProxy ProxyConfig) error {
 	if l.config == nil {
-		return fmt.Errorf("配置尚未加载")
+		return i18n.Errorf("配置尚未加载")
+	}
+
+	if newProxy.Name == "" {
+		newProxy.Name = name
+	}
+	if newProxy.Name != name {
+		for _, proxy := range l.config.Proxies {
+			if proxy.Name == newProxy.Name {
+				return i18n.Errorf("代理名称 '%s' 已存在", newProxy.Name)
+			}
+		}
 	}
 
 	for i, proxy := range l.config.Proxies {
 		if proxy.Name == name {
-			newProxy.Name = name
 			l.config.Proxies[i] = newProxy
 			return nil
 		}
 	}
 
-	return fmt.Errorf("未找到名称为 '%s' 的代理", name)
+	return i18n.Errorf("未找到名称为 '%s' 的代理", name)
 }
 
 // GetProxy 获取代理配置
 func (l *Loader) GetProxy(name string) (*ProxyConfig, error) {
 	if l.config == nil {
-		return nil, fmt.Errorf("配置尚未加载")
+		return nil, i18n.Errorf("配置尚未加载")
 	}
 
 	for _, proxy := range l.config.Proxies {
@@ -284,7 +450,7 @@ func (l *Loader) GetProxy(name string) (*ProxyConfig, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("未找到名称为 '%s' 的代理", name)
+	return nil, i18n.Errorf("未找到名称为 '%s' 的代理", name)
 }
 
 // ListProxies 列出所有代理
@@ -295,42 +461,28 @@ func (l *Loader) ListProxies() []ProxyConfig {
 	return l.config.Proxies
 }
 
-// Backup 备份配置文件
+// Backup 备份配置文件到备份目录
 func (l *Loader) Backup() error {
-	backupPath := l.configPath + ".backup." + time.Now().Format("20060102_150405")
-
-	originalData, err := os.ReadFile(l.configPath)
-	if err != nil {
-		return fmt.Errorf("读取原配置文件失败: %w", err)
+	if _, err := os.Stat(l.configPath); err != nil {
+		return i18n.Errorf("读取原配置文件失败: %w", err)
 	}
-
-	if err := os.WriteFile(backupPath, originalData, 0644); err != nil {
-		return fmt.Errorf("创建备份文件失败: %w", err)
-	}
-
-	return nil
+	_, err := BackupConfigFile(l.configPath, nil)
+	return err
 }
 
-// Restore 恢复配置文件
+// Restore 从最近一次备份恢复配置文件
 func (l *Loader) Restore() error {
-	backupPattern := l.configPath + ".backup.*"
-	matches, err := filepath.Glob(backupPattern)
+	backups, err := ListBackups(l.configPath)
 	if err != nil {
-		return fmt.Errorf("查找备份文件失败: %w", err)
+		return i18n.Errorf("查找备份文件失败: %w", err)
 	}
 
-	if len(matches) == 0 {
-		return fmt.Errorf("未找到备份文件")
-	}
-
-	latestBackup := matches[len(matches)-1]
-	backupData, err := os.ReadFile(latestBackup)
-	if err != nil {
-		return fmt.Errorf("读取备份文件失败: %w", err)
+	if len(backups) == 0 {
+		return i18n.Errorf("未找到备份文件")
 	}
 
-	if err := os.WriteFile(l.configPath, backupData, 0644); err != nil {
-		return fmt.Errorf("恢复配置文件失败: %w", err)
+	if err := RestoreBackup(backups[0]); err != nil {
+		return err
 	}
 
 	_, err = l.Load()
@@ -352,25 +504,22 @@ func (l *Loader) ExportToFile(config *Config, filePath string) error {
 	// 创建目录（如果不存在）
 	dir := filepath.Dir(filePath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("创建导出目录失败: %w", err)
+		return i18n.Errorf("创建导出目录失败: %w", err)
 	}
 
-	// 序列化为 YAML
-	data, err := yaml.Marshal(config)
+	// 按扩展名序列化为 YAML/TOML
+	data, err := MarshalConfig(config.Flatten(), DetectFormat(filePath))
 	if err != nil {
-		return fmt.Errorf("序列化配置失败: %w", err)
+		return i18n.Errorf("序列化配置失败: %w", err)
 	}
 
-	// 添加配置文件头部注释
-	header := fmt.Sprintf("# FRP 配置文件\n# 导出时间: %s\n# 配置类型: %s\n\n",
-		time.Now().Format("2006-01-02 15:04:05"),
-		detectConfigType(config))
-
+	// 添加配置文件头部注释，元数据导入时可以解析回来
+	header := NewFileMeta().Header() + i18n.Sprintf("# 配置类型: %s\n\n", DetectConfigType(config))
 	finalData := append([]byte(header), data...)
 
 	// 写入文件
 	if err := os.WriteFile(filePath, finalData, 0644); err != nil {
-		return fmt.Errorf("写入配置文件失败: %w", err)
+		return i18n.Errorf("写入配置文件失败: %w", err)
 	}
 
 	return nil
@@ -380,22 +529,22 @@ func (l *Loader) ExportToFile(config *Config, filePath string) error {
 func (l *Loader) ImportFromFile(filePath string) (*Config, error) {
 	// 检查文件是否存在
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("导入文件不存在: %s", filePath)
+		return nil, i18n.Errorf("导入文件不存在: %s", filePath)
 	}
 
 	// 读取文件内容
 	content, err := os.ReadFile(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("读取导入文件失败: %w", err)
+		return nil, i18n.Errorf("读取导入文件失败: %w", err)
 	}
 
-	// 解析 YAML
-	var config Config
-	if err := yaml.Unmarshal(content, &config); err != nil {
-		return nil, fmt.Errorf("解析导入文件失败: %w", err)
+	// 按扩展名解析 YAML/TOML/INI
+	config, err := UnmarshalConfig(content, DetectFormat(filePath))
+	if err != nil {
+		return nil, i18n.Errorf("解析导入文件失败: %w", err)
 	}
 
-	return &config, nil
+	return config, nil
 }
 
 // MergeConfig 合并两个配置
@@ -415,8 +564,8 @@ func (l *Loader) MergeConfig(target *Config, source *Config) *Config {
 	if source.ServerPort != 0 {
 		merged.ServerPort = source.ServerPort
 	}
-	if source.Token != "" {
-		merged.Token = source.Token
+	if source.Auth.Token != "" {
+		merged.Auth.Token = source.Auth.Token
 	}
 
 	if source.BindPort != 0 {
@@ -428,6 +577,70 @@ func (l *Loader) MergeConfig(target *Config, source *Config) *Config {
 	if source.KCPBindPort != 0 {
 		merged.KCPBindPort = source.KCPBindPort
 	}
+	if len(source.AllowPorts) > 0 {
+		merged.AllowPorts = append([]PortRange(nil), source.AllowPorts...)
+	}
+	if source.MaxPortsPerClient != 0 {
+		merged.MaxPortsPerClient = source.MaxPortsPerClient
+	}
+	if source.VhostHTTPPort != 0 {
+		merged.VhostHTTPPort = source.VhostHTTPPort
+	}
+	if source.VhostHTTPSPort != 0 {
+		merged.VhostHTTPSPort = source.VhostHTTPSPort
+	}
+	if source.VhostHTTPTimeout != 0 {
+		merged.VhostHTTPTimeout = source.VhostHTTPTimeout
+	}
+	if source.SubDomainHost != "" {
+		merged.SubDomainHost = source.SubDomainHost
+	}
+	if source.Custom404Page != "" {
+		merged.Custom404Page = source.Custom404Page
+	}
+	if source.TCPMuxHTTPConnectPort != 0 {
+		merged.TCPMuxHTTPConnectPort = source.TCPMuxHTTPConnectPort
+	}
+	if source.TCPMuxPassthrough {
+		merged.TCPMuxPassthrough = true
+	}
+	if source.SSHTunnelGateway != (SSHTunnelGatewayConfig{}) {
+		merged.SSHTunnelGateway = source.SSHTunnelGateway
+	}
+	if source.Transport.HeartbeatInterval != 0 {
+		merged.Transport.HeartbeatInterval = source.Transport.HeartbeatInterval
+	}
+	if source.Transport.HeartbeatTimeout != 0 {
+		merged.Transport.HeartbeatTimeout = source.Transport.HeartbeatTimeout
+	}
+	if source.Transport.Protocol != "" {
+		merged.Transport.Protocol = source.Transport.Protocol
+	}
+	if source.Transport.PoolCount != 0 {
+		merged.Transport.PoolCount = source.Transport.PoolCount
+	}
+	if source.Transport.DialServerTimeout != 0 {
+		merged.Transport.DialServerTimeout = source.Transport.DialServerTimeout
+	}
+	if source.Transport.ProxyURL != "" {
+		merged.Transport.ProxyURL = source.Transport.ProxyURL
+	}
+	if source.Transport.TLS.Enable != nil {
+		enable := *source.Transport.TLS.Enable
+		merged.Transport.TLS.Enable = &enable
+	}
+	if source.Transport.TLS.CertFile != "" {
+		merged.Transport.TLS.CertFile = source.Transport.TLS.CertFile
+	}
+	if source.Transport.TLS.KeyFile != "" {
+		merged.Transport.TLS.KeyFile = source.Transport.TLS.KeyFile
+	}
+	if source.Transport.TLS.TrustedCaFile != "" {
+		merged.Transport.TLS.TrustedCaFile = source.Transport.TLS.TrustedCaFile
+	}
+	if source.Transport.TLS.ServerName != "" {
+		merged.Transport.TLS.ServerName = source.Transport.TLS.ServerName
+	}
 
 	if source.WebServer.Port != 0 {
 		merged.WebServer.Port = source.WebServer.Port
@@ -471,14 +684,18 @@ func (l *Loader) MergeConfig(target *Config, source *Config) *Config {
 	return &merged
 }
 
-// detectConfigType 检测配置类型
-func detectConfigType(config *Config) string {
-	if config.BindPort > 0 || config.WebServer.Port > 0 {
+// DetectConfigType 根据字段检测配置类型，返回 server、client 或 unknown
+func DetectConfigType(config *Config) string {
+	if config.BindPort > 0 {
 		return "server"
 	}
-	if config.ServerAddr != "" || len(config.Proxies) > 0 {
+	// 客户端的管理接口同样使用 webServer，需先判断客户端特征
+	if config.ServerAddr != "" || len(config.Proxies) > 0 || len(config.Visitors) > 0 {
 		return "client"
 	}
+	if config.WebServer.Port > 0 {
+		return "server"
+	}
 	return "unknown"
 }
 
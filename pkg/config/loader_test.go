@@ -0,0 +1,220 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// clientWithAuth 带 token、HTTP 认证、负载均衡分组和日志保留天数的客户端配置
+func clientWithAuth() *Config {
+	return &Config{
+		ServerAddr: "example.com",
+		ServerPort: 7000,
+		Auth:       AuthConfig{Token: "secret"},
+		Log:        LogConfig{To: "/var/log/frpc.log", MaxDays: 7},
+		Proxies: []ProxyConfig{{
+			Name:          "web",
+			Type:          "http",
+			LocalPort:     8080,
+			CustomDomains: []string{"web.example.com"},
+			HTTPUser:      "admin",
+			HTTPPassword:  "pass",
+			LoadBalancer:  LoadBalancerConfig{Group: "web", GroupKey: "key"},
+		}},
+	}
+}
+
+func TestDryRunPassesSchemaCheck(t *testing.T) {
+	for _, ext := range []string{".yaml", ".toml"} {
+		t.Run(ext, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "frpc"+ext)
+			pending, err := NewLoader(path).DryRun(clientWithAuth())
+			if err != nil {
+				t.Fatalf("DryRun: %v", err)
+			}
+
+			issues, err := CheckSchema(pending[0].Data, DetectFormat(path), false)
+			if err != nil {
+				t.Fatalf("CheckSchema: %v", err)
+			}
+			for _, issue := range issues {
+				t.Errorf("unexpected schema issue: %s", issue)
+			}
+		})
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	for _, ext := range []string{".yaml", ".toml"} {
+		t.Run(ext, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "frpc"+ext)
+			if err := NewLoader(path).Save(clientWithAuth()); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+
+			cfg, err := NewLoader(path).Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if cfg.Auth.Token != "secret" {
+				t.Errorf("Auth.Token = %q, want %q", cfg.Auth.Token, "secret")
+			}
+			if cfg.Log.MaxDays != 7 {
+				t.Errorf("Log.MaxDays = %d, want 7", cfg.Log.MaxDays)
+			}
+			proxy := cfg.Proxies[0]
+			if proxy.HTTPPassword != "pass" {
+				t.Errorf("HTTPPassword = %q, want %q", proxy.HTTPPassword, "pass")
+			}
+			if proxy.LoadBalancer != (LoadBalancerConfig{Group: "web", GroupKey: "key"}) {
+				t.Errorf("LoadBalancer = %+v", proxy.LoadBalancer)
+			}
+		})
+	}
+}
+
+func TestLegacyKeysMigrateOnSave(t *testing.T) {
+	tests := []struct {
+		ext     string
+		content string
+	}{
+		{".yaml", `serverAddr: example.com
+token: secret # shared with frps
+log:
+  maxLogFile: 7
+proxies:
+  - name: web
+    type: http
+    localPort: 8080
+    customDomains: [web.example.com]
+    httpUser: admin
+    httpPwd: pass
+    group: web
+    groupKey: key
+    healthCheck:
+      type: http
+      path: /health
+      timeoutS: 3
+      intervalS: 10
+`},
+		{".toml", `serverAddr = "example.com"
+token = "secret"
+
+[log]
+maxLogFile = 7
+
+[[proxies]]
+name = "web"
+type = "http"
+localPort = 8080
+customDomains = ["web.example.com"]
+httpUser = "admin"
+httpPwd = "pass"
+group = "web"
+groupKey = "key"
+
+[proxies.healthCheck]
+type = "http"
+path = "/health"
+timeoutS = 3
+intervalS = 10
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ext, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "frpc"+tt.ext)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			loader := NewLoader(path)
+			cfg, err := loader.Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if cfg.Auth.Token != "secret" || cfg.Log.MaxDays != 7 {
+				t.Errorf("legacy fields not migrated: token %q, maxDays %d", cfg.Auth.Token, cfg.Log.MaxDays)
+			}
+			proxy := cfg.Proxies[0]
+			if proxy.HTTPPassword != "pass" || proxy.LoadBalancer.Group != "web" || proxy.LoadBalancer.GroupKey != "key" {
+				t.Errorf("legacy proxy fields not migrated: %+v", proxy)
+			}
+			if proxy.HealthCheck.TimeoutS != 3 || proxy.HealthCheck.IntervalS != 10 {
+				t.Errorf("legacy health check fields not migrated: %+v", proxy.HealthCheck)
+			}
+
+			if err := loader.Save(cfg); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			issues, err := CheckSchema(StripFileMeta(data), DetectFormat(path), false)
+			if err != nil {
+				t.Fatalf("CheckSchema: %v", err)
+			}
+			for _, issue := range issues {
+				t.Errorf("unexpected schema issue after save: %s\n%s", issue, data)
+			}
+			for _, legacy := range []string{"maxLogFile", "httpPwd", "timeoutS", "intervalS"} {
+				if strings.Contains(string(data), legacy+":") || strings.Contains(string(data), legacy+" =") {
+					t.Errorf("legacy key %s still present after save:\n%s", legacy, data)
+				}
+			}
+
+			reloaded, err := NewLoader(path).Load()
+			if err != nil {
+				t.Fatalf("reload: %v", err)
+			}
+			if check := reloaded.Proxies[0].HealthCheck; check.TimeoutS != 3 || check.IntervalS != 10 {
+				t.Errorf("health check lost after save: %+v", check)
+			}
+		})
+	}
+}
+
+func TestHealthCheckHTTPHeadersRoundTrip(t *testing.T) {
+	headers := []HTTPHeader{{Name: "Host", Value: "web.example.com"}, {Name: "X-Probe", Value: "frp"}}
+	cfg := &Config{
+		ServerAddr: "example.com",
+		Proxies: []ProxyConfig{{
+			Name:        "web",
+			Type:        "http",
+			LocalPort:   8080,
+			HealthCheck: HealthCheckConfig{Type: "http", Path: "/health", HTTPHeaders: headers},
+		}},
+	}
+
+	for _, ext := range []string{".yaml", ".toml"} {
+		t.Run(ext, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "frpc"+ext)
+			if err := NewLoader(path).Save(cfg); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			issues, err := CheckSchema(StripFileMeta(data), DetectFormat(path), false)
+			if err != nil {
+				t.Fatalf("CheckSchema: %v", err)
+			}
+			for _, issue := range issues {
+				t.Errorf("unexpected schema issue: %s\n%s", issue, data)
+			}
+
+			loaded, err := NewLoader(path).Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if got := loaded.Proxies[0].HealthCheck.HTTPHeaders; !reflect.DeepEqual(got, headers) {
+				t.Errorf("HTTPHeaders = %+v, want %+v", got, headers)
+			}
+		})
+	}
+}
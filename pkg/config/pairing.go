@@ -0,0 +1,192 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// PairingTypes 需要访问者配对的代理类型
+var PairingTypes = []string{"stcp", "xtcp", "sudp"}
+
+// pairingCommentPrefix 访问者片段中记录密钥指纹的注释前缀
+const pairingCommentPrefix = "# frp-cli-ui pairing "
+
+// Pairing 一组互相匹配的代理和访问者，代理在提供服务的机器上运行，访问者在另一台机器上运行
+type Pairing struct {
+	Proxy   ProxyConfig
+	Visitor VisitorConfig
+}
+
+// NewPairing 生成 stcp/xtcp/sudp 代理和对应的访问者，两边使用相同的随机 secretKey，
+// 访问者的 serverName 指向代理名称，在另一台机器的 bindPort 上提供访问
+func NewPairing(proxyType, name string, localPort, bindPort, keyLength int) (*Pairing, error) {
+	if !slices.Contains(PairingTypes, proxyType) {
+		return nil, i18n.Errorf("%s 代理不需要访问者配对", proxyType)
+	}
+	if name == "" {
+		return nil, i18n.Errorf("代理名称不能为空")
+	}
+
+	secretKey, err := GenerateToken(keyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pairing{
+		Proxy: ProxyConfig{
+			Name:      name,
+			Type:      proxyType,
+			LocalIP:   "127.0.0.1",
+			LocalPort: localPort,
+			SecretKey: secretKey,
+		},
+		Visitor: VisitorConfig{
+			Name:       name + "-visitor",
+			Type:       proxyType,
+			ServerName: name,
+			SecretKey:  secretKey,
+			BindAddr:   "127.0.0.1",
+			BindPort:   bindPort,
+		},
+	}, nil
+}
+
+// CheckPairing 校验访问者能否连接代理：类型、serverName 和 secretKey 都必须一致
+func CheckPairing(proxy ProxyConfig, visitor VisitorConfig) error {
+	switch {
+	case visitor.ServerName != proxy.Name:
+		return i18n.Errorf("访问者 %s 的 serverName 为 %s，与代理 %s 不一致", visitor.Name, visitor.ServerName, proxy.Name)
+	case visitor.Type != proxy.Type:
+		return i18n.Errorf("访问者 %s 的类型为 %s，代理 %s 的类型为 %s", visitor.Name, visitor.Type, proxy.Name, proxy.Type)
+	case visitor.SecretKey != proxy.SecretKey:
+		return i18n.Errorf("访问者 %s 与代理 %s 的 secretKey 不一致", visitor.Name, proxy.Name)
+	}
+	return nil
+}
+
+// pairingFingerprint 返回访问者密钥的指纹，用于导入时发现复制过程中被截断或改动的密钥
+func pairingFingerprint(visitor VisitorConfig) string {
+	sum := sha256.Sum256([]byte(visitor.Type + "\x00" + visitor.ServerName + "\x00" + visitor.SecretKey))
+	return hex.EncodeToString(sum[:6])
+}
+
+// VisitorSnippet 生成另一台机器使用的 frpc 配置：沿用客户端的服务端连接信息，只包含访问者，
+// 开头的注释记录密钥指纹，导入时用于校验
+func (p *Pairing) VisitorSnippet(client *Config, format ConfigFormat) ([]byte, error) {
+	snippet := &Config{Visitors: []VisitorConfig{p.Visitor}}
+	if client != nil {
+		snippet.ServerAddr = client.ServerAddr
+		snippet.ServerPort = client.ServerPort
+		snippet.Auth.Token = client.Auth.Token
+	}
+
+	data, err := MarshalConfig(snippet, format)
+	if err != nil {
+		return nil, err
+	}
+
+	header := fmt.Sprintf("# %s\n%s%s %s\n",
+		i18n.Sprintf("%s 的访问者配置，在另一台机器上与 frpc 一起使用", p.Proxy.Name),
+		pairingCommentPrefix, p.Visitor.Name, pairingFingerprint(p.Visitor))
+	return append([]byte(header), data...), nil
+}
+
+// ExportVisitorSnippet 将访问者配置写入文件，格式由扩展名决定
+func (p *Pairing) ExportVisitorSnippet(client *Config, path string) error {
+	data, err := p.VisitorSnippet(client, DetectFormat(path))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return i18n.Errorf("写入访问者配置失败: %w", err)
+	}
+	return nil
+}
+
+// ImportVisitorFile 读取访问者配置文件并合并到客户端配置，格式由扩展名决定
+func ImportVisitorFile(target *Config, path string) (*VisitorImport, error) {
+	path = expandHome(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取访问者配置失败: %w", err)
+	}
+	return ImportVisitorSnippet(target, data, DetectFormat(path))
+}
+
+// VisitorImport 导入访问者配置的结果
+type VisitorImport struct {
+	Added    []string
+	Replaced []string
+	Warnings []string
+}
+
+// ImportVisitorSnippet 解析访问者配置并合并到客户端配置，同名访问者会被替换。
+// 密钥指纹不一致，或本机存在 serverName 对应的代理但密钥、类型不匹配时拒绝导入
+func ImportVisitorSnippet(target *Config, data []byte, format ConfigFormat) (*VisitorImport, error) {
+	snippet, err := UnmarshalConfig(data, format)
+	if err != nil {
+		return nil, i18n.Errorf("解析访问者配置失败: %w", err)
+	}
+	if len(snippet.Visitors) == 0 {
+		return nil, i18n.Errorf("配置中没有访问者")
+	}
+
+	fingerprints := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), pairingCommentPrefix); ok {
+			if fields := strings.Fields(rest); len(fields) == 2 {
+				fingerprints[fields[0]] = fields[1]
+			}
+		}
+	}
+
+	for _, visitor := range snippet.Visitors {
+		if !slices.Contains(PairingTypes, visitor.Type) {
+			return nil, i18n.Errorf("访问者 %s 的类型 %s 无效", visitor.Name, visitor.Type)
+		}
+		if visitor.SecretKey == "" {
+			return nil, i18n.Errorf("访问者 %s 缺少 secretKey", visitor.Name)
+		}
+		if expected, ok := fingerprints[visitor.Name]; ok && expected != pairingFingerprint(visitor) {
+			return nil, i18n.Errorf("访问者 %s 的密钥与导出时不一致，可能在复制时被截断或改动", visitor.Name)
+		}
+		for _, proxy := range target.Proxies {
+			if proxy.Name == visitor.ServerName {
+				if err := CheckPairing(proxy, visitor); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	result := &VisitorImport{}
+	if snippet.ServerAddr != "" {
+		switch {
+		case target.ServerAddr == "":
+			target.ServerAddr = snippet.ServerAddr
+			target.ServerPort = snippet.ServerPort
+			target.Auth.Token = snippet.Auth.Token
+		case target.ServerAddr != snippet.ServerAddr || target.ServerPort != snippet.ServerPort:
+			result.Warnings = append(result.Warnings, i18n.Sprintf("访问者需要连接代理所在的服务端 %s:%d，当前客户端连接的是 %s:%d",
+				snippet.ServerAddr, snippet.ServerPort, target.ServerAddr, target.ServerPort))
+		}
+	}
+
+	for _, visitor := range snippet.Visitors {
+		index := slices.IndexFunc(target.Visitors, func(v VisitorConfig) bool { return v.Name == visitor.Name })
+		if index >= 0 {
+			target.Visitors[index] = visitor
+			result.Replaced = append(result.Replaced, visitor.Name)
+		} else {
+			target.Visitors = append(target.Visitors, visitor)
+			result.Added = append(result.Added, visitor.Name)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package config
+
+// PluginTypes 支持的 frpc 客户端插件
+var PluginTypes = []string{"unix_domain_socket", "http_proxy", "socks5", "static_file", "https2http"}
+
+// PluginConfig 客户端插件配置，按插件类型使用不同字段，与 frpc 的 plugin 配置块一致
+type PluginConfig struct {
+	Type string `yaml:"type,omitempty" toml:"type,omitempty"`
+
+	// unix_domain_socket
+	UnixPath string `yaml:"unixPath,omitempty" toml:"unixPath,omitempty"` // 要转发的 Unix 域套接字路径
+
+	// http_proxy / static_file
+	HTTPUser     string `yaml:"httpUser,omitempty" toml:"httpUser,omitempty"`
+	HTTPPassword string `yaml:"httpPassword,omitempty" toml:"httpPassword,omitempty"`
+
+	// socks5
+	Username string `yaml:"username,omitempty" toml:"username,omitempty"`
+	Password string `yaml:"password,omitempty" toml:"password,omitempty"`
+
+	// static_file
+	LocalPath   string `yaml:"localPath,omitempty" toml:"localPath,omitempty"`     // 对外提供的本地目录
+	StripPrefix string `yaml:"stripPrefix,omitempty" toml:"stripPrefix,omitempty"` // 访问路径中去掉的前缀
+
+	// https2http
+	LocalAddr         string           `yaml:"localAddr,omitempty" toml:"localAddr,omitempty"` // 本地 HTTP 服务地址
+	CrtPath           string           `yaml:"crtPath,omitempty" toml:"crtPath,omitempty"`
+	KeyPath           string           `yaml:"keyPath,omitempty" toml:"keyPath,omitempty"`
+	HostHeaderRewrite string           `yaml:"hostHeaderRewrite,omitempty" toml:"hostHeaderRewrite,omitempty"`
+	RequestHeaders    HeaderOperations `yaml:"requestHeaders,omitempty" toml:"requestHeaders,omitempty"`
+}
+
+// UsesAuth 插件是否支持用户名密码认证
+func (p PluginConfig) UsesAuth() bool {
+	switch p.Type {
+	case "http_proxy", "socks5", "static_file":
+		return true
+	}
+	return false
+}
+
+// SetAuth 按插件类型写入认证信息，socks5 使用 username/password，其余使用 httpUser/httpPassword
+func (p *PluginConfig) SetAuth(user, password string) {
+	p.HTTPUser, p.HTTPPassword, p.Username, p.Password = "", "", "", ""
+	switch p.Type {
+	case "socks5":
+		p.Username, p.Password = user, password
+	case "http_proxy", "static_file":
+		p.HTTPUser, p.HTTPPassword = user, password
+	}
+}
+
+// Auth 返回插件的认证信息
+func (p PluginConfig) Auth() (user, password string) {
+	if p.Type == "socks5" {
+		return p.Username, p.Password
+	}
+	return p.HTTPUser, p.HTTPPassword
+}
+
+// hasParams 是否设置了任意插件参数
+func (p PluginConfig) hasParams() bool {
+	for _, value := range []string{
+		p.UnixPath, p.HTTPUser, p.HTTPPassword, p.Username, p.Password,
+		p.LocalPath, p.StripPrefix, p.LocalAddr, p.CrtPath, p.KeyPath, p.HostHeaderRewrite,
+	} {
+		if value != "" {
+			return true
+		}
+	}
+	return len(p.RequestHeaders.Set) > 0
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"net"
+	"strconv"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// portProbe 需要探测的端口
+type portProbe struct {
+	field   string // 字段路径，格式与 CheckSchema 一致
+	label   string
+	network string // "tcp" 或 "udp"
+	addr    string
+	port    int
+}
+
+// SetLiveCheck 设置是否开启实时端口占用检查
+func (v *Validator) SetLiveCheck(enabled bool) {
+	v.liveCheck = enabled
+}
+
+// LiveCheckEnabled 是否开启了实时端口占用检查
+func (v *Validator) LiveCheckEnabled() bool {
+	return v.liveCheck
+}
+
+// CheckPortAvailability 探测配置中需要在本机监听的端口是否已被占用，返回警告列表；
+// 未开启实时检查时返回 nil
+func (v *Validator) CheckPortAvailability(config *Config) []string {
+	return issueMessages(v.CheckPortAvailabilityIssues(config))
+}
+
+// CheckPortAvailabilityIssues 与 CheckPortAvailability 相同，同时返回被占用端口的字段路径
+func (v *Validator) CheckPortAvailabilityIssues(config *Config) []FieldIssue {
+	if !v.liveCheck || config == nil {
+		return nil
+	}
+
+	var issues []FieldIssue
+	for _, probe := range collectPortProbes(config) {
+		if err := CheckPortAvailable(probe.network, probe.addr, probe.port); err != nil {
+			issues = append(issues, FieldIssue{
+				Field:   probe.field,
+				Message: i18n.Sprintf("%s %d/%s 已被占用: %v", probe.label, probe.port, probe.network, err),
+			})
+		}
+	}
+
+	return issues
+}
+
+// CheckPortAvailable 通过尝试监听判断端口是否可用
+func CheckPortAvailable(network, addr string, port int) error {
+	address := net.JoinHostPort(addr, strconv.Itoa(port))
+
+	if network == "udp" {
+		conn, err := net.ListenPacket("udp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return listener.Close()
+}
+
+// collectPortProbes 收集配置中需要在本机监听的端口
+func collectPortProbes(config *Config) []portProbe {
+	var probes []portProbe
+	add := func(field, label, network, addr string, port int) {
+		if port > 0 {
+			probes = append(probes, portProbe{field: field, label: label, network: network, addr: addr, port: port})
+		}
+	}
+
+	add("bindPort", i18n.T("绑定端口"), "tcp", "", config.BindPort)
+	add("bindUDPPort", i18n.T("UDP端口"), "udp", "", config.BindUDPPort)
+	add("kcpBindPort", i18n.T("KCP端口"), "udp", "", config.KCPBindPort)
+	add("webServer.port", i18n.T("Web服务器端口"), "tcp", config.WebServer.Addr, config.WebServer.Port)
+	add("vhostHTTPPort", i18n.T("HTTP虚拟主机端口"), "tcp", config.ProxyBindAddr, config.VhostHTTPPort)
+	add("vhostHTTPSPort", i18n.T("HTTPS虚拟主机端口"), "tcp", config.ProxyBindAddr, config.VhostHTTPSPort)
+	add("tcpmuxHTTPConnectPort", i18n.T("tcpmux HTTP CONNECT 端口"), "tcp", config.ProxyBindAddr, config.TCPMuxHTTPConnectPort)
+	add("sshTunnelGateway.bindPort", i18n.T("SSH 隧道网关端口"), "tcp", "", config.SSHTunnelGateway.BindPort)
+
+	// 远程端口由 frps 监听，只有服务端就在本机时探测才有意义
+	if isLocalServer(config.ServerAddr) {
+		for i, proxy := range config.Proxies {
+			if proxy.Disabled {
+				continue
+			}
+			network := "tcp"
+			if proxy.Type == "udp" {
+				network = "udp"
+			}
+			add(itemPath("proxies", i, proxy.Name)+".remotePort", i18n.Sprintf("代理 '%s' 远程端口", proxy.Name), network, "", proxy.RemotePort)
+		}
+	}
+
+	for i, visitor := range config.Visitors {
+		network := "tcp"
+		if visitor.Type == "sudp" {
+			network = "udp"
+		}
+		add(itemPath("visitors", i, visitor.Name)+".bindPort", i18n.Sprintf("访问者 '%s' 绑定端口", visitor.Name), network, visitor.BindAddr, visitor.BindPort)
+	}
+
+	return probes
+}
+
+// isLocalServer 判断服务端地址是否指向本机
+func isLocalServer(addr string) bool {
+	switch addr {
+	case "", "localhost", "127.0.0.1", "::1", "0.0.0.0":
+		return true
+	}
+	return false
+}
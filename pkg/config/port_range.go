@@ -0,0 +1,106 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// PortRange 端口范围，Single 表示单个端口，否则为 Start-End 闭区间
+type PortRange struct {
+	Start  int `yaml:"start,omitempty" toml:"start,omitempty"`
+	End    int `yaml:"end,omitempty" toml:"end,omitempty"`
+	Single int `yaml:"single,omitempty" toml:"single,omitempty"`
+}
+
+// Bounds 返回范围的起止端口
+func (r PortRange) Bounds() (int, int) {
+	if r.Single != 0 {
+		return r.Single, r.Single
+	}
+	return r.Start, r.End
+}
+
+// Contains 判断端口是否在范围内
+func (r PortRange) Contains(port int) bool {
+	start, end := r.Bounds()
+	return port >= start && port <= end
+}
+
+// String 返回 "2000-3000" 或 "3001" 形式的文本
+func (r PortRange) String() string {
+	start, end := r.Bounds()
+	if start == end {
+		return strconv.Itoa(start)
+	}
+	return strconv.Itoa(start) + "-" + strconv.Itoa(end)
+}
+
+// ParsePortRanges 解析 "2000-3000,3001,5000-6000" 形式的端口范围列表，空字符串返回 nil
+func ParsePortRanges(value string) ([]PortRange, error) {
+	var ranges []PortRange
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if start, end, found := strings.Cut(part, "-"); found {
+			startPort, err1 := strconv.Atoi(strings.TrimSpace(start))
+			endPort, err2 := strconv.Atoi(strings.TrimSpace(end))
+			if err1 != nil || err2 != nil {
+				return nil, i18n.Errorf("端口范围 %s 无效", part)
+			}
+			ranges = append(ranges, PortRange{Start: startPort, End: endPort})
+			continue
+		}
+
+		port, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, i18n.Errorf("端口范围 %s 无效", part)
+		}
+		ranges = append(ranges, PortRange{Single: port})
+	}
+	return ranges, nil
+}
+
+// FormatPortRanges 将端口范围列表格式化为逗号分隔的文本
+func FormatPortRanges(ranges []PortRange) string {
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// ValidatePortRanges 校验端口范围：每项只能是单个端口或起止端口，端口有效、起始不大于结束且各项不重叠
+func ValidatePortRanges(ranges []PortRange) error {
+	for _, r := range ranges {
+		if r.Single != 0 && (r.Start != 0 || r.End != 0) {
+			return i18n.Errorf("端口范围 %s 不能同时设置 single 和 start/end", r)
+		}
+		start, end := r.Bounds()
+		if start < 1 || end > 65535 {
+			return i18n.Errorf("端口范围 %s 无效: %w", r, i18n.NewError("端口必须在 1-65535 范围内"))
+		}
+		if start > end {
+			return i18n.Errorf("端口范围 %s 的起始端口大于结束端口", r)
+		}
+	}
+
+	sorted := append([]PortRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool {
+		si, _ := sorted[i].Bounds()
+		sj, _ := sorted[j].Bounds()
+		return si < sj
+	})
+	for i := 1; i < len(sorted); i++ {
+		_, prevEnd := sorted[i-1].Bounds()
+		if start, _ := sorted[i].Bounds(); start <= prevEnd {
+			return i18n.Errorf("端口范围 %s 与 %s 重叠", sorted[i-1], sorted[i])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// ProxyPreset 常见服务的代理预设
+type ProxyPreset struct {
+	Key         string // 预设标识，同时作为默认代理名称
+	Name        string
+	Description string
+	Type        string
+	LocalPort   int
+	RemotePort  int    // 建议的远程端口，仅 tcp/udp 使用
+	Warning     string // 暴露到公网时的安全提示
+}
+
+// ProxyPresets 返回内置的代理预设
+func ProxyPresets() []ProxyPreset {
+	return []ProxyPreset{
+		{Key: "ssh", Name: i18n.T("SSH 远程登录"), Description: i18n.T("通过公网端口访问本机 SSH"), Type: "tcp", LocalPort: 22, RemotePort: 6022,
+			Warning: i18n.T("建议禁用密码登录，仅使用密钥认证")},
+		{Key: "ssh-secure", Name: i18n.T("SSH (私密访问)"), Description: i18n.T("不开放公网端口，仅持有密钥的访问者可连接"), Type: "stcp", LocalPort: 22},
+		{Key: "web", Name: i18n.T("HTTP 网站 / Web 应用"), Description: i18n.T("通过域名访问本地 Web 服务"), Type: "http", LocalPort: 8080},
+		{Key: "web-https", Name: i18n.T("HTTPS 网站"), Description: i18n.T("本地服务自行处理 TLS，按域名转发"), Type: "https", LocalPort: 443},
+		{Key: "rdp", Name: i18n.T("Windows 远程桌面"), Description: i18n.T("通过公网端口访问 RDP"), Type: "tcp", LocalPort: 3389, RemotePort: 13389,
+			Warning: i18n.T("RDP 常被扫描爆破，请使用强密码并考虑改用私密访问")},
+		{Key: "vnc", Name: i18n.T("VNC 远程桌面"), Description: i18n.T("通过公网端口访问 VNC"), Type: "tcp", LocalPort: 5900, RemotePort: 15900,
+			Warning: i18n.T("VNC 默认不加密，请设置强密码")},
+		{Key: "mysql", Name: "MySQL", Description: i18n.T("通过公网端口访问 MySQL 数据库"), Type: "tcp", LocalPort: 3306, RemotePort: 13306,
+			Warning: i18n.T("数据库直接暴露在公网风险较高，请限制账号权限")},
+		{Key: "postgres", Name: "PostgreSQL", Description: i18n.T("通过公网端口访问 PostgreSQL 数据库"), Type: "tcp", LocalPort: 5432, RemotePort: 15432,
+			Warning: i18n.T("数据库直接暴露在公网风险较高，请限制账号权限")},
+		{Key: "redis", Name: "Redis", Description: i18n.T("通过公网端口访问 Redis"), Type: "tcp", LocalPort: 6379, RemotePort: 16379,
+			Warning: i18n.T("Redis 默认无密码，务必设置 requirepass")},
+		{Key: "minecraft", Name: i18n.T("Minecraft Java 版"), Description: i18n.T("让朋友加入本机 Minecraft 服务器"), Type: "tcp", LocalPort: 25565, RemotePort: 25565},
+		{Key: "minecraft-bedrock", Name: i18n.T("Minecraft 基岩版"), Description: i18n.T("基岩版服务器使用 UDP"), Type: "udp", LocalPort: 19132, RemotePort: 19132},
+		{Key: "tcp", Name: i18n.T("自定义 TCP 端口"), Description: i18n.T("转发任意 TCP 服务"), Type: "tcp", LocalPort: 8080, RemotePort: 6000},
+		{Key: "udp", Name: i18n.T("自定义 UDP 端口"), Description: i18n.T("转发任意 UDP 服务"), Type: "udp", LocalPort: 5000, RemotePort: 6000},
+	}
+}
+
+// GetProxyPreset 根据标识获取预设
+func GetProxyPreset(key string) (ProxyPreset, error) {
+	for _, preset := range ProxyPresets() {
+		if preset.Key == key {
+			return preset, nil
+		}
+	}
+	return ProxyPreset{}, i18n.Errorf("预设不存在: %s", key)
+}
+
+// UniqueProxyName 返回在配置中不重复的代理名称
+func UniqueProxyName(cfg *Config, base string) string {
+	used := make(map[string]bool)
+	if cfg != nil {
+		for _, proxy := range cfg.Proxies {
+			used[proxy.Name] = true
+		}
+	}
+
+	if !used[base] {
+		return base
+	}
+	for i := 2; ; i++ {
+		name := fmt.Sprintf("%s-%d", base, i)
+		if !used[name] {
+			return name
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// proxyMetaSuffix 代理标签文件的后缀，与客户端配置放在同一目录，frpc 不会读取
+const proxyMetaSuffix = ".meta.yaml"
+
+// ProxyMeta 代理的标签和备注，只在本工具中使用
+type ProxyMeta struct {
+	Labels []string `yaml:"labels,omitempty"`
+	Note   string   `yaml:"note,omitempty"`
+}
+
+// IsEmpty 是否没有任何标签和备注
+func (m ProxyMeta) IsEmpty() bool {
+	return len(m.Labels) == 0 && m.Note == ""
+}
+
+// ProxyMetadata 一份客户端配置中各代理的标签和备注，按代理名称索引
+type ProxyMetadata struct {
+	Proxies map[string]ProxyMeta `yaml:"proxies,omitempty"`
+}
+
+// ProxyMetaPath 返回客户端配置对应的标签文件路径，如 frpc.toml 对应 frpc.meta.yaml
+func ProxyMetaPath(configPath string) string {
+	return strings.TrimSuffix(configPath, filepath.Ext(configPath)) + proxyMetaSuffix
+}
+
+// LoadProxyMetadata 读取客户端配置对应的标签文件，文件不存在时返回空的标签集合
+func LoadProxyMetadata(configPath string) (*ProxyMetadata, error) {
+	meta := &ProxyMetadata{Proxies: make(map[string]ProxyMeta)}
+	data, err := os.ReadFile(ProxyMetaPath(configPath))
+	if os.IsNotExist(err) {
+		return meta, nil
+	}
+	if err != nil {
+		return meta, i18n.Errorf("读取代理标签失败: %w", err)
+	}
+	if err := yaml.Unmarshal(data, meta); err != nil {
+		return meta, i18n.Errorf("解析代理标签失败: %w", err)
+	}
+	if meta.Proxies == nil {
+		meta.Proxies = make(map[string]ProxyMeta)
+	}
+	return meta, nil
+}
+
+// Save 写入客户端配置对应的标签文件，没有任何标签时删除文件
+func (m *ProxyMetadata) Save(configPath string) error {
+	path := ProxyMetaPath(configPath)
+	if len(m.Proxies) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return i18n.Errorf("删除代理标签文件失败: %w", err)
+		}
+		return nil
+	}
+
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return i18n.Errorf("序列化代理标签失败: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return i18n.Errorf("创建配置目录失败: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return i18n.Errorf("写入代理标签失败: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return i18n.Errorf("替换代理标签文件失败: %w", err)
+	}
+	return nil
+}
+
+// Get 返回代理的标签和备注
+func (m *ProxyMetadata) Get(name string) ProxyMeta {
+	if m == nil {
+		return ProxyMeta{}
+	}
+	return m.Proxies[name]
+}
+
+// Set 设置代理的标签和备注，为空时删除该代理的记录
+func (m *ProxyMetadata) Set(name string, meta ProxyMeta) {
+	if meta.IsEmpty() {
+		delete(m.Proxies, name)
+		return
+	}
+	m.Proxies[name] = meta
+}
+
+// Labels 返回用到的所有标签，按名称排序
+func (m *ProxyMetadata) Labels() []string {
+	if m == nil {
+		return nil
+	}
+	var labels []string
+	for _, meta := range m.Proxies {
+		for _, label := range meta.Labels {
+			if !slices.Contains(labels, label) {
+				labels = append(labels, label)
+			}
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// ParseLabels 解析逗号或空格分隔的标签，去掉重复项
+func ParseLabels(value string) []string {
+	var labels []string
+	for _, label := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '，' || r == ' '
+	}) {
+		if !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
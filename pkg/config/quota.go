@@ -0,0 +1,138 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 流量配额周期
+const (
+	QuotaDaily   = "daily"
+	QuotaMonthly = "monthly"
+)
+
+// 流量配额统计的方向
+const (
+	QuotaTotal = "total" // 入站 + 出站
+	QuotaIn    = "in"
+	QuotaOut   = "out"
+)
+
+// DefaultQuotaWarnPercent 未设置时用量达到配额的百分比后发出预警
+const DefaultQuotaWarnPercent = 80
+
+// TrafficQuota 流量配额，按本工具持久化的 frps 流量采样统计
+type TrafficQuota struct {
+	Proxy       string `yaml:"proxy,omitempty"`       // 代理名，为空表示 frps 服务端总流量
+	Period      string `yaml:"period"`                // daily 或 monthly
+	Limit       string `yaml:"limit"`                 // 配额，如 "1TB"、"500GB"
+	Direction   string `yaml:"direction,omitempty"`   // total、in 或 out，为空表示 total
+	ResetDay    int    `yaml:"resetDay,omitempty"`    // 月配额每月重置的日期（1-28），与 VPS 账单日对齐，0 表示 1 号
+	WarnPercent int    `yaml:"warnPercent,omitempty"` // 预警百分比，0 表示使用 DefaultQuotaWarnPercent
+	AutoStop    bool   `yaml:"autoStop,omitempty"`    // 超出配额后停用本机客户端配置中的代理，服务端配额则停止 frps
+}
+
+// DisplayName 返回配额的显示名称，如 "web 月配额"
+func (q TrafficQuota) DisplayName() string {
+	target := q.Proxy
+	if target == "" {
+		target = i18n.T("服务端总流量")
+	}
+	if q.Period == QuotaDaily {
+		return i18n.Sprintf("%s 日配额", target)
+	}
+	return i18n.Sprintf("%s 月配额", target)
+}
+
+// LimitBytes 返回配额的字节数，格式错误时返回 0
+func (q TrafficQuota) LimitBytes() int64 {
+	limit, _ := ParseTrafficSize(q.Limit)
+	return limit
+}
+
+// WarnRatio 返回预警阈值占配额的比例
+func (q TrafficQuota) WarnRatio() float64 {
+	if q.WarnPercent <= 0 {
+		return DefaultQuotaWarnPercent / 100.0
+	}
+	return float64(q.WarnPercent) / 100
+}
+
+// PeriodStart 返回 now 所在统计周期的开始时间（本地时间）
+func (q TrafficQuota) PeriodStart(now time.Time) time.Time {
+	if q.Period == QuotaDaily {
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	}
+	day := max(q.ResetDay, 1)
+	start := time.Date(now.Year(), now.Month(), day, 0, 0, 0, 0, now.Location())
+	if now.Before(start) {
+		start = start.AddDate(0, -1, 0)
+	}
+	return start
+}
+
+// PeriodEnd 返回 now 所在统计周期的结束时间，即下一周期的开始
+func (q TrafficQuota) PeriodEnd(now time.Time) time.Time {
+	start := q.PeriodStart(now)
+	if q.Period == QuotaDaily {
+		return start.AddDate(0, 0, 1)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// ParseTrafficSize 解析 "1TB"、"500GB"、"1.5 GB" 形式的流量，按 1024 进制换算为字节数
+func ParseTrafficSize(value string) (int64, error) {
+	text := strings.ToUpper(strings.TrimSpace(value))
+	units := []struct {
+		suffix string
+		size   float64
+	}{
+		{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+	}
+	for _, unit := range units {
+		if !strings.HasSuffix(text, unit.suffix) {
+			continue
+		}
+		number, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(text, unit.suffix)), 64)
+		if err != nil || number <= 0 {
+			break
+		}
+		return int64(number * unit.size), nil
+	}
+	return 0, i18n.Errorf("无效的流量配额 %q，应为正数加单位，如 500GB、1TB", value)
+}
+
+// validateTrafficQuotas 校验流量配额，同一代理、周期和方向只能有一个配额
+func validateTrafficQuotas(quotas []TrafficQuota) error {
+	seen := make(map[string]bool, len(quotas))
+	for _, q := range quotas {
+		if q.Period != QuotaDaily && q.Period != QuotaMonthly {
+			return i18n.Errorf("%s: 周期必须是 daily 或 monthly", q.DisplayName())
+		}
+		if _, err := ParseTrafficSize(q.Limit); err != nil {
+			return i18n.Errorf("%s: %w", q.DisplayName(), err)
+		}
+		if q.Direction != "" && q.Direction != QuotaTotal && q.Direction != QuotaIn && q.Direction != QuotaOut {
+			return i18n.Errorf("%s: 方向必须是 total、in 或 out", q.DisplayName())
+		}
+		if q.ResetDay < 0 || q.ResetDay > 28 {
+			return i18n.Errorf("%s: 重置日期必须在 1-28 之间", q.DisplayName())
+		}
+		if q.WarnPercent < 0 || q.WarnPercent > 100 {
+			return i18n.Errorf("%s: 预警百分比必须在 0-100 之间", q.DisplayName())
+		}
+
+		key := q.Proxy + "|" + q.Period + "|" + q.Direction
+		if q.Direction == QuotaTotal {
+			key = q.Proxy + "|" + q.Period + "|"
+		}
+		if seen[key] {
+			return i18n.Errorf("%s 重复", q.DisplayName())
+		}
+		seen[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 远程服务器认证方式
+const (
+	RemoteAuthKey      = "key"      // 私钥文件
+	RemoteAuthPassword = "password" // 密码
+	RemoteAuthAgent    = "agent"    // ssh-agent
+)
+
+// 远程服务器上运行的服务
+const (
+	RemoteRoleServer = "frps"
+	RemoteRoleClient = "frpc"
+)
+
+// RemoteProfile 运行 frps 或 frpc 的远程服务器连接配置
+type RemoteProfile struct {
+	Name             string `yaml:"name"`
+	Role             string `yaml:"role,omitempty"` // frps / frpc，默认 frps
+	Host             string `yaml:"host"`
+	Port             int    `yaml:"port"`
+	User             string `yaml:"user"`
+	AuthMethod       string `yaml:"authMethod"`                // key / password / agent
+	KeyPath          string `yaml:"keyPath,omitempty"`         // 私钥路径，默认 ~/.ssh/id_ed25519 或 id_rsa
+	Password         string `yaml:"password,omitempty"`        // 密码或私钥口令
+	RemoteConfigPath string `yaml:"remoteConfigPath"`          // 远程 frps/frpc 配置文件路径
+	ServiceName      string `yaml:"serviceName"`               // systemd 服务名
+	LogPath          string `yaml:"logPath,omitempty"`         // 远程日志文件，留空时使用 journalctl
+	UseSudo          bool   `yaml:"useSudo,omitempty"`         // 写配置和重启服务时使用 sudo -n
+	LocalConfigPath  string `yaml:"localConfigPath,omitempty"` // 要上传的本地配置，留空时使用应用设置中的服务端或客户端配置
+}
+
+// remoteProfilesFile 远程服务器配置文件内容
+type remoteProfilesFile struct {
+	Profiles []RemoteProfile `yaml:"profiles"`
+}
+
+// GetRemoteProfilesPath 获取远程服务器配置文件路径
+func GetRemoteProfilesPath() string {
+	return filepath.Join(GetDefaultWorkDir(), "remotes.yaml")
+}
+
+// GetRemoteKnownHostsPath 获取本应用信任的远程主机公钥文件
+func GetRemoteKnownHostsPath() string {
+	return filepath.Join(GetDefaultWorkDir(), "known_hosts")
+}
+
+// LoadRemoteProfiles 读取远程服务器配置，文件不存在时返回空列表
+func LoadRemoteProfiles() ([]RemoteProfile, error) {
+	data, err := os.ReadFile(GetRemoteProfilesPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取远程服务器配置失败: %w", err)
+	}
+
+	var file remoteProfilesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, i18n.Errorf("解析远程服务器配置失败: %w", err)
+	}
+	for i := range file.Profiles {
+		file.Profiles[i].Normalize()
+	}
+	return file.Profiles, nil
+}
+
+// SaveRemoteProfiles 保存远程服务器配置，文件中可能包含密码，仅允许当前用户读写
+func SaveRemoteProfiles(profiles []RemoteProfile) error {
+	path := GetRemoteProfilesPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return i18n.Errorf("创建配置目录失败: %w", err)
+	}
+
+	data, err := yaml.Marshal(remoteProfilesFile{Profiles: profiles})
+	if err != nil {
+		return i18n.Errorf("序列化远程服务器配置失败: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return i18n.Errorf("写入远程服务器配置失败: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return i18n.Errorf("替换远程服务器配置失败: %w", err)
+	}
+	return nil
+}
+
+// NewRemoteProfile 创建带默认值的远程服务器配置
+func NewRemoteProfile() RemoteProfile {
+	profile := RemoteProfile{}
+	profile.Normalize()
+	return profile
+}
+
+// Normalize 为缺失的字段填充默认值，并展开路径开头的 ~
+func (p *RemoteProfile) Normalize() {
+	if p.Port == 0 {
+		p.Port = 22
+	}
+	if p.User == "" {
+		p.User = "root"
+	}
+	if p.AuthMethod == "" {
+		p.AuthMethod = RemoteAuthKey
+	}
+	if p.Role == "" {
+		p.Role = RemoteRoleServer
+	}
+	if p.RemoteConfigPath == "" {
+		p.RemoteConfigPath = "/etc/frp/" + p.Role + ".toml"
+	}
+	if p.ServiceName == "" {
+		p.ServiceName = p.Role
+	}
+	p.KeyPath = expandHome(p.KeyPath)
+	p.LocalConfigPath = expandHome(p.LocalConfigPath)
+}
+
+// IsClient 远程服务器上运行的是否为 frpc
+func (p *RemoteProfile) IsClient() bool {
+	return p.Role == RemoteRoleClient
+}
+
+// Address 返回 host:port 形式的连接地址
+func (p *RemoteProfile) Address() string {
+	return fmt.Sprintf("%s:%d", p.Host, p.Port)
+}
+
+// Validate 校验远程服务器配置
+func (p *RemoteProfile) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return i18n.Errorf("名称不能为空")
+	}
+	if strings.TrimSpace(p.Host) == "" {
+		return i18n.Errorf("主机地址不能为空")
+	}
+	if p.Role != RemoteRoleServer && p.Role != RemoteRoleClient {
+		return i18n.Errorf("未知服务类型 %q，可选: frps / frpc", p.Role)
+	}
+	if p.Port < 1 || p.Port > 65535 {
+		return i18n.Errorf("SSH 端口必须在 1-65535 范围内")
+	}
+	if p.User == "" {
+		return i18n.Errorf("用户名不能为空")
+	}
+	switch p.AuthMethod {
+	case RemoteAuthKey, RemoteAuthAgent:
+	case RemoteAuthPassword:
+		if p.Password == "" {
+			return i18n.Errorf("密码认证需要填写密码")
+		}
+	default:
+		return i18n.Errorf("未知认证方式 %q，可选: key / password / agent", p.AuthMethod)
+	}
+	if !strings.HasPrefix(p.RemoteConfigPath, "/") {
+		return i18n.Errorf("远程配置路径必须是绝对路径")
+	}
+	if p.ServiceName == "" {
+		return i18n.Errorf("服务名不能为空")
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package config
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// MarshalConfigPreserving 在原文件内容的基础上写入配置，保留注释、键的顺序以及本工具不认识的字段，
+// 只改动与原内容不同的字段；原内容为空或无法按原结构合并时退回 MarshalConfig 重新生成
+func MarshalConfigPreserving(original []byte, config *Config, format ConfigFormat) ([]byte, error) {
+	base := stripDisabledBlock(original)
+	if len(bytes.TrimSpace(base)) == 0 || (format != FormatYAML && format != FormatTOML) {
+		return MarshalConfig(config, format)
+	}
+
+	// 原内容按当前模型解析后的结果，用来区分“被界面删除的字段”和“本工具不认识的字段”；
+	// 保留早期写法的字段，使其在新配置中缺失而被删除，由 frp v1 位置上的新字段代替
+	known, err := unmarshalConfigRaw(base, format)
+	if err != nil {
+		return MarshalConfig(config, format)
+	}
+
+	active, disabled := splitDisabledProxies(config)
+	var data []byte
+	if format == FormatTOML {
+		data, err = mergeTOML(base, known, active)
+	} else {
+		data, err = mergeYAML(base, known, active)
+	}
+	if err != nil {
+		return MarshalConfig(config, format)
+	}
+	return appendDisabledProxies(data, disabled, format)
+}
+
+// stripDisabledBlock 去掉末尾已停用代理的注释块及其说明行，保存时会按当前状态重新生成
+func stripDisabledBlock(data []byte) []byte {
+	lines := strings.Split(string(data), "\n")
+	start := -1
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimRight(line, "\r"), disabledProxyPrefix) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return data
+	}
+
+	// 注释块前一行是生成的说明，再往前是分隔用的空行
+	end := start
+	if start > 0 && strings.HasPrefix(lines[start-1], "# ") {
+		start--
+	}
+	if start > 0 && strings.TrimSpace(lines[start-1]) == "" {
+		start--
+	}
+
+	kept := append([]string(nil), lines[:start]...)
+	for _, line := range lines[end:] {
+		if !strings.HasPrefix(strings.TrimRight(line, "\r"), disabledProxyPrefix) {
+			kept = append(kept, line)
+		}
+	}
+	return []byte(strings.TrimRight(strings.Join(kept, "\n"), "\n") + "\n")
+}
+
+// mergeYAML 在原 YAML 文档的节点树上合并新配置，原有节点连同注释和顺序尽量保留
+func mergeYAML(base []byte, known, active *Config) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(base, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, i18n.Errorf("配置结构无法合并")
+	}
+
+	var knownNode, wantNode yaml.Node
+	if err := knownNode.Encode(known); err != nil {
+		return nil, err
+	}
+	if err := wantNode.Encode(active); err != nil {
+		return nil, err
+	}
+	doc.Content[0] = mergeYAMLNode(doc.Content[0], &knownNode, &wantNode)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(detectYAMLIndent(base))
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mergeYAMLNode 合并单个节点：old 为原文件节点，known 为原内容经模型解析后的节点（可能为空），want 为新配置节点
+func mergeYAMLNode(old, known, want *yaml.Node) *yaml.Node {
+	if old.Kind != want.Kind {
+		return withComments(want, old)
+	}
+
+	switch old.Kind {
+	case yaml.MappingNode:
+		var content []*yaml.Node
+		seen := make(map[string]bool)
+		for i := 0; i+1 < len(old.Content); i += 2 {
+			key, value := old.Content[i], old.Content[i+1]
+			seen[key.Value] = true
+			wantValue := yamlMapValue(want, key.Value)
+			knownValue := yamlMapValue(known, key.Value)
+			switch {
+			case wantValue != nil:
+				content = append(content, key, mergeYAMLNode(value, knownValue, wantValue))
+			case knownValue != nil:
+				// 模型认识这个字段但新配置中已没有，说明在界面中被清空或删除
+			default:
+				content = append(content, key, value)
+			}
+		}
+		for i := 0; i+1 < len(want.Content); i += 2 {
+			if !seen[want.Content[i].Value] {
+				content = append(content, want.Content[i], want.Content[i+1])
+			}
+		}
+		old.Content = content
+		return old
+
+	case yaml.SequenceNode:
+		if !yamlHasMappings(old) && !yamlHasMappings(want) {
+			if yamlScalarsEqual(old, want) {
+				return old
+			}
+			merged := withComments(want, old)
+			merged.Style = old.Style
+			return merged
+		}
+
+		wantItems := yamlItemsByID(want)
+		knownItems := yamlItemsByID(known)
+		seen := make(map[string]bool)
+		var content []*yaml.Node
+		for _, item := range yamlItemIDs(old) {
+			seen[item.id] = true
+			switch {
+			case wantItems[item.id] != nil:
+				content = append(content, mergeYAMLNode(item.node, knownItems[item.id], wantItems[item.id]))
+			case knownItems[item.id] != nil:
+				// 已在界面中删除的代理等列表项
+			default:
+				content = append(content, item.node)
+			}
+		}
+		for _, item := range yamlItemIDs(want) {
+			if !seen[item.id] {
+				content = append(content, item.node)
+			}
+		}
+		old.Content = content
+		return old
+
+	case yaml.ScalarNode:
+		if old.Value == want.Value && old.ShortTag() == want.ShortTag() {
+			return old
+		}
+		merged := withComments(want, old)
+		if old.ShortTag() == want.ShortTag() {
+			merged.Style = old.Style
+		}
+		return merged
+	}
+	return old
+}
+
+// withComments 把原节点上的注释搬到新节点
+func withComments(node, from *yaml.Node) *yaml.Node {
+	merged := *node
+	merged.HeadComment = from.HeadComment
+	merged.LineComment = from.LineComment
+	merged.FootComment = from.FootComment
+	return &merged
+}
+
+// yamlMapValue 取映射节点中指定键的值，节点为空或不是映射时返回 nil
+func yamlMapValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlHasMappings 判断序列中是否有映射类型的元素
+func yamlHasMappings(node *yaml.Node) bool {
+	for _, item := range node.Content {
+		if item.Kind == yaml.MappingNode {
+			return true
+		}
+	}
+	return false
+}
+
+// yamlScalarsEqual 判断两个纯标量序列的值是否一致
+func yamlScalarsEqual(a, b *yaml.Node) bool {
+	if len(a.Content) != len(b.Content) {
+		return false
+	}
+	for i := range a.Content {
+		if a.Content[i].Value != b.Content[i].Value || a.Content[i].ShortTag() != b.Content[i].ShortTag() {
+			return false
+		}
+	}
+	return true
+}
+
+// itemID 带 name 字段的列表元素标识，重名时附带出现次数
+func itemID(name string, n int) string {
+	return "name=" + name + "/" + strconv.Itoa(n)
+}
+
+// itemIndex 没有 name 字段的列表元素按下标标识
+func itemIndex(i int) string {
+	return strconv.Itoa(i)
+}
+
+// yamlItem 带标识的序列元素
+type yamlItem struct {
+	id   string
+	node *yaml.Node
+}
+
+// yamlItemIDs 为序列元素生成标识：有 name 字段时按名称及其出现次数，否则按下标
+func yamlItemIDs(node *yaml.Node) []yamlItem {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	items := make([]yamlItem, len(node.Content))
+	names := make(map[string]int)
+	for i, item := range node.Content {
+		if name := yamlMapValue(item, "name"); name != nil && name.Kind == yaml.ScalarNode {
+			items[i] = yamlItem{id: itemID(name.Value, names[name.Value]), node: item}
+			names[name.Value]++
+		} else {
+			items[i] = yamlItem{id: itemIndex(i), node: item}
+		}
+	}
+	return items
+}
+
+// yamlItemsByID 按标识索引序列元素
+func yamlItemsByID(node *yaml.Node) map[string]*yaml.Node {
+	items := make(map[string]*yaml.Node)
+	for _, item := range yamlItemIDs(node) {
+		items[item.id] = item.node
+	}
+	return items
+}
+
+// detectYAMLIndent 按原文件第一处缩进推断缩进宽度，无法判断时使用默认的 4 个空格
+func detectYAMLIndent(data []byte) int {
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if indent := len(line) - len(trimmed); indent > 0 {
+			if indent > 8 {
+				return 4
+			}
+			return indent
+		}
+	}
+	return 4
+}
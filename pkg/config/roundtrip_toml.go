@@ -0,0 +1,590 @@
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// tomlEntry TOML 文件中的一个表头或键值，连同它前面的注释和空行
+type tomlEntry struct {
+	comments []string // 前置注释与空行
+	lines    []string // 表头或键值本身，多行数组或字符串占多行
+	header   bool
+	path     []string // 表头为表路径，键值为完整路径；列表元素用 "#标识" 表示
+	section  []string // 键值所在的表路径，表头与 path 相同
+	prefix   string   // 键值行等号及其后空白之前的内容，替换值时沿用
+	comment  string   // 单行键值末尾的注释
+	deleted  bool
+}
+
+// tomlDoc 按条目拆分的 TOML 文件
+type tomlDoc struct {
+	entries []*tomlEntry
+	tail    []string // 末尾的注释与空行
+}
+
+// parseTOMLDoc 逐行拆分 TOML，并借助解析后的数据为 [[数组表]] 的元素生成与 YAML 一致的标识
+func parseTOMLDoc(data []byte, tree map[string]any) (*tomlDoc, error) {
+	doc := &tomlDoc{}
+	var pending []string
+	var section []string
+	arrays := newTOMLArrayTracker(tree)
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if n := len(lines); n > 0 && lines[n-1] == "" {
+		lines = lines[:n-1]
+	}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			pending = append(pending, line)
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "[") {
+			array := strings.HasPrefix(trimmed, "[[")
+			name, ok := tomlHeaderName(trimmed, array)
+			if !ok {
+				return nil, i18n.Errorf("无法识别的表头: %s", trimmed)
+			}
+			section = arrays.resolve(splitTOMLKey(name), array)
+			doc.entries = append(doc.entries, &tomlEntry{
+				comments: pending, lines: []string{line}, header: true, path: section, section: section,
+			})
+			pending = nil
+			continue
+		}
+
+		eq := tomlIndexOutsideStrings(line, '=')
+		if eq < 0 {
+			return nil, i18n.Errorf("无法识别的配置行: %s", trimmed)
+		}
+		valueStart := eq + 1
+		for valueStart < len(line) && (line[valueStart] == ' ' || line[valueStart] == '\t') {
+			valueStart++
+		}
+
+		entry := &tomlEntry{
+			comments: pending,
+			lines:    []string{line},
+			path:     append(append([]string(nil), section...), splitTOMLKey(strings.TrimSpace(line[:eq]))...),
+			section:  section,
+			prefix:   line[:valueStart],
+		}
+		pending = nil
+
+		value := line[valueStart:]
+		for !tomlValueComplete(value) && i+1 < len(lines) {
+			i++
+			entry.lines = append(entry.lines, lines[i])
+			value += "\n" + lines[i]
+		}
+		if len(entry.lines) == 1 {
+			if hash := tomlIndexOutsideStrings(value, '#'); hash >= 0 {
+				entry.comment = strings.TrimSpace(value[hash:])
+			}
+		}
+		doc.entries = append(doc.entries, entry)
+	}
+	doc.tail = pending
+	return doc, nil
+}
+
+// tomlArrayTracker 记录解析过程中每个数组表当前所在的元素
+type tomlArrayTracker struct {
+	tree    map[string]any
+	counts  map[string]int
+	current map[string]string
+}
+
+func newTOMLArrayTracker(tree map[string]any) *tomlArrayTracker {
+	return &tomlArrayTracker{tree: tree, counts: make(map[string]int), current: make(map[string]string)}
+}
+
+// resolve 将表头路径解析为带元素标识的完整路径，[[x]] 会开始 x 的下一个元素，
+// [x.y] 中的 x 若是数组表则指向它最近的元素
+func (t *tomlArrayTracker) resolve(names []string, array bool) []string {
+	var path []string
+	for i, name := range names {
+		path = append(path, name)
+		key := tomlPathKey(path)
+		if i == len(names)-1 && array {
+			index := t.counts[key]
+			t.counts[key]++
+			items, _ := tomlLookup(t.tree, path)
+			id := "#" + tomlElementID(items, index)
+			t.current[key] = id
+			path = append(path, id)
+			continue
+		}
+		if id, ok := t.current[key]; ok {
+			path = append(path, id)
+		}
+	}
+	return path
+}
+
+// mergeTOML 在原 TOML 文本上逐行合并新配置：未改动的行原样保留，改动的值就地替换，
+// 删除的字段移除对应行，新字段插入到所属的表中
+func mergeTOML(base []byte, known, active *Config) ([]byte, error) {
+	var oldTree map[string]any
+	if err := toml.Unmarshal(base, &oldTree); err != nil {
+		return nil, err
+	}
+	knownTree, err := tomlTree(known)
+	if err != nil {
+		return nil, err
+	}
+	wantData, err := toml.Marshal(active)
+	if err != nil {
+		return nil, err
+	}
+	var wantTree map[string]any
+	if err := toml.Unmarshal(wantData, &wantTree); err != nil {
+		return nil, err
+	}
+
+	doc, err := parseTOMLDoc(base, oldTree)
+	if err != nil {
+		return nil, err
+	}
+	wantDoc, err := parseTOMLDoc(wantData, wantTree)
+	if err != nil {
+		return nil, err
+	}
+
+	// 界面中删除的数组元素（如代理）连同其子表和注释整体移除
+	var removed [][]string
+	for _, entry := range doc.entries {
+		if entry.header && strings.HasPrefix(entry.path[len(entry.path)-1], "#") &&
+			tomlRemoved(knownTree, wantTree, entry.path) {
+			removed = append(removed, entry.path)
+		}
+	}
+
+	// 逐个处理原有条目，covered 记录新配置中已由原有行表示的路径
+	covered := make(map[string]bool)
+	for _, entry := range doc.entries {
+		if tomlUnderAny(entry.section, removed) {
+			entry.deleted = true
+			continue
+		}
+		if entry.header {
+			entry.deleted = tomlRemoved(knownTree, wantTree, entry.path)
+			continue
+		}
+
+		want, ok := tomlLookup(wantTree, entry.path)
+		if !ok {
+			entry.deleted = tomlRemoved(knownTree, wantTree, entry.path)
+			continue
+		}
+		covered[tomlPathKey(entry.path)] = true
+		if old, _ := tomlLookup(oldTree, entry.path); reflect.DeepEqual(old, want) {
+			continue
+		}
+		value, err := renderTOMLValue(want)
+		if err != nil {
+			return nil, err
+		}
+		line := entry.prefix + value
+		if entry.comment != "" {
+			line += " " + entry.comment
+		}
+		entry.lines = []string{line}
+	}
+
+	// 表中仍有本工具不认识的字段时保留表头
+	for i, entry := range doc.entries {
+		if !entry.header || !entry.deleted || tomlUnderAny(entry.section, removed) {
+			continue
+		}
+		for _, next := range doc.entries[i+1:] {
+			if next.header {
+				break
+			}
+			if !next.deleted {
+				entry.deleted = false
+				break
+			}
+		}
+	}
+
+	// 同一位置上先写入已有表中的新键，再写入新表，避免新键落到新表头之下
+	inserts := make(map[int][]string)
+	tables := make(map[int][]string)
+	var appended []string
+	for _, block := range tomlBlocks(wantDoc) {
+		var missing []*tomlEntry
+		for _, entry := range block.entries {
+			if !tomlCovered(covered, entry.path) {
+				missing = append(missing, entry)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		// 原文件中已有同一张表时插入到表内最后一个键之后
+		if anchor, ok := tomlSectionEnd(doc, block.path); ok {
+			for _, entry := range missing {
+				inserts[anchor] = append(inserts[anchor], entry.lines...)
+			}
+			continue
+		}
+
+		// 原文件用点号键（如 webServer.port = 7500）定义了这张表时，沿用同样的写法
+		if anchor, prefix, ok := tomlDottedEnd(doc, block.path); ok {
+			for _, entry := range missing {
+				for _, line := range entry.lines {
+					inserts[anchor] = append(inserts[anchor], prefix+line)
+				}
+			}
+			continue
+		}
+
+		// 否则连同表头作为新表写入：数组元素中的子表放在该元素之后，其余追加到末尾
+		lines := []string{""}
+		if block.header != nil {
+			lines = append(lines, block.header.lines...)
+		}
+		for _, entry := range missing {
+			lines = append(lines, entry.lines...)
+		}
+		if anchor, ok := tomlElementEnd(doc, block.path); ok {
+			tables[anchor] = append(tables[anchor], lines...)
+		} else {
+			appended = append(appended, lines...)
+		}
+	}
+
+	var buf bytes.Buffer
+	write := func(lines []string) {
+		for _, line := range lines {
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+		}
+	}
+	write(inserts[-1])
+	for i, entry := range doc.entries {
+		if !entry.deleted {
+			write(entry.comments)
+			write(entry.lines)
+		}
+		write(inserts[i])
+		write(tables[i])
+	}
+	write(appended)
+	write(doc.tail)
+	return buf.Bytes(), nil
+}
+
+// tomlBlock 新配置中的一张表：表头（根表为空）及其中的键值
+type tomlBlock struct {
+	header  *tomlEntry
+	path    []string
+	entries []*tomlEntry
+}
+
+// tomlBlocks 将条目按表分组
+func tomlBlocks(doc *tomlDoc) []*tomlBlock {
+	blocks := []*tomlBlock{{}}
+	for _, entry := range doc.entries {
+		if entry.header {
+			blocks = append(blocks, &tomlBlock{header: entry, path: entry.path})
+			continue
+		}
+		last := blocks[len(blocks)-1]
+		last.entries = append(last.entries, entry)
+	}
+	return blocks
+}
+
+// tomlSectionEnd 返回原文件中指定表最后一个条目的位置，根表没有键时返回 -1 表示文件开头
+func tomlSectionEnd(doc *tomlDoc, path []string) (int, bool) {
+	end, found := -1, len(path) == 0
+	for i, entry := range doc.entries {
+		if entry.deleted {
+			continue
+		}
+		if tomlPathKey(entry.section) == tomlPathKey(path) {
+			end, found = i, true
+		}
+	}
+	return end, found
+}
+
+// tomlElementEnd 新表位于某个数组元素中时，返回原文件中该元素最后一个条目的位置
+func tomlElementEnd(doc *tomlDoc, path []string) (int, bool) {
+	element := -1
+	for i, seg := range path {
+		if strings.HasPrefix(seg, "#") {
+			element = i
+		}
+	}
+	if element < 0 {
+		return 0, false
+	}
+
+	end, found := 0, false
+	for i, entry := range doc.entries {
+		if !entry.deleted && tomlHasPrefix(entry.section, path[:element+1]) {
+			end, found = i, true
+		}
+	}
+	if found {
+		return end, true
+	}
+
+	// 整个元素都是新增的，跟在同一数组的最后一个元素之后
+	for i, entry := range doc.entries {
+		if !entry.deleted && len(entry.section) > element && tomlHasPrefix(entry.section, path[:element]) {
+			end, found = i, true
+		}
+	}
+	return end, found
+}
+
+// tomlDottedEnd 查找原文件中以点号键定义指定表的最后一行，返回插入位置和新键需要的前缀
+func tomlDottedEnd(doc *tomlDoc, path []string) (int, string, bool) {
+	end, prefix, found := 0, "", false
+	for i, entry := range doc.entries {
+		if entry.deleted || entry.header || len(entry.section) >= len(path) ||
+			len(entry.path) <= len(path) || !tomlHasPrefix(entry.path, path) {
+			continue
+		}
+		relative := path[len(entry.section):]
+		if !tomlHasPrefix(path, entry.section) || strings.HasPrefix(strings.Join(relative, "."), "#") ||
+			strings.Contains(strings.Join(relative, "."), ".#") {
+			continue
+		}
+		end, prefix, found = i, strings.Join(relative, ".")+".", true
+	}
+	return end, prefix, found
+}
+
+// tomlRemoved 判断路径在原内容中能被模型识别、但新配置中已经没有，即在界面中被删除或清空
+func tomlRemoved(known, want map[string]any, path []string) bool {
+	if _, ok := tomlLookup(want, path); ok {
+		return false
+	}
+	_, ok := tomlLookup(known, path)
+	return ok
+}
+
+// tomlUnderAny 判断路径是否位于任一给定路径之下
+func tomlUnderAny(path []string, prefixes [][]string) bool {
+	for _, prefix := range prefixes {
+		if tomlHasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// tomlCovered 判断路径或其上级是否已由原文件中的某一行表示
+func tomlCovered(covered map[string]bool, path []string) bool {
+	for i := len(path); i > 0; i-- {
+		if covered[tomlPathKey(path[:i])] {
+			return true
+		}
+	}
+	return false
+}
+
+// tomlTree 将配置按 TOML 序列化后再解析为通用结构，与原文件的解析结果可以直接比较
+func tomlTree(config *Config) (map[string]any, error) {
+	data, err := toml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]any
+	if err := toml.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// tomlLookup 按路径取值，"#标识" 段用于定位数组元素
+func tomlLookup(tree map[string]any, path []string) (any, bool) {
+	var current any = tree
+	for _, seg := range path {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[seg]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []any:
+			if !strings.HasPrefix(seg, "#") {
+				return nil, false
+			}
+			index := -1
+			for i := range node {
+				if tomlElementID(node, i) == seg[1:] {
+					index = i
+					break
+				}
+			}
+			if index < 0 {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// tomlElementID 为数组元素生成标识，与 YAML 列表元素的规则相同
+func tomlElementID(items any, index int) string {
+	list, _ := items.([]any)
+	if index >= len(list) {
+		return itemIndex(index)
+	}
+	item, _ := list[index].(map[string]any)
+	name, ok := item["name"].(string)
+	if !ok {
+		return itemIndex(index)
+	}
+	n := 0
+	for _, other := range list[:index] {
+		if m, _ := other.(map[string]any); m != nil && m["name"] == name {
+			n++
+		}
+	}
+	return itemID(name, n)
+}
+
+// renderTOMLValue 将值渲染为 TOML 行内写法
+func renderTOMLValue(value any) (string, error) {
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.SetTablesInline(true)
+	if err := enc.Encode(map[string]any{"v": value}); err != nil {
+		return "", err
+	}
+	line := strings.TrimSpace(buf.String())
+	rendered, ok := strings.CutPrefix(line, "v = ")
+	if !ok {
+		return "", i18n.Errorf("配置结构无法合并")
+	}
+	return rendered, nil
+}
+
+// tomlHeaderName 取出 [x] 或 [[x]] 中的表名，忽略行尾注释
+func tomlHeaderName(line string, array bool) (string, bool) {
+	open, close := "[", "]"
+	if array {
+		open, close = "[[", "]]"
+	}
+	rest := strings.TrimPrefix(line, open)
+	end := strings.Index(rest, close)
+	if end < 0 {
+		return "", false
+	}
+	return strings.TrimSpace(rest[:end]), true
+}
+
+// splitTOMLKey 按点拆分键名，引号中的点不拆分
+func splitTOMLKey(key string) []string {
+	var parts []string
+	var current strings.Builder
+	var quote byte
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				current.WriteByte(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '.':
+			parts = append(parts, strings.TrimSpace(current.String()))
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	return append(parts, strings.TrimSpace(current.String()))
+}
+
+// tomlIndexOutsideStrings 返回字符串之外第一次出现 c 的位置
+func tomlIndexOutsideStrings(s string, c byte) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == '\\' && quote == '"' {
+				i++
+			} else if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == c:
+			return i
+		}
+	}
+	return -1
+}
+
+// tomlValueComplete 判断值是否已经结束，用于识别跨行的数组、行内表和多行字符串
+func tomlValueComplete(value string) bool {
+	depth := 0
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case strings.HasPrefix(value[i:], `"""`), strings.HasPrefix(value[i:], `'''`):
+			delim := value[i : i+3]
+			end := strings.Index(value[i+3:], delim)
+			if end < 0 {
+				return false
+			}
+			i += end + 5
+		case c == '"' || c == '\'':
+			for i++; i < len(value) && value[i] != c && value[i] != '\n'; i++ {
+				if c == '"' && value[i] == '\\' {
+					i++
+				}
+			}
+		case c == '#':
+			for i < len(value) && value[i] != '\n' {
+				i++
+			}
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// tomlPathKey 将路径拼接为可比较的字符串
+func tomlPathKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
+
+// tomlHasPrefix 判断路径是否以 prefix 开头
+func tomlHasPrefix(path, prefix []string) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// AutostartProfile 自动启动配置，应用启动时以及进入时间窗口时启动对应服务，离开时间窗口时停止
+type AutostartProfile struct {
+	Name       string   `yaml:"name"`
+	Service    string   `yaml:"service"`              // server 或 client
+	ConfigPath string   `yaml:"configPath,omitempty"` // 为空时使用应用设置中的配置文件
+	Enabled    bool     `yaml:"enabled"`
+	Windows    []string `yaml:"windows,omitempty"` // 时间窗口，如 "09:00-18:00"、"mon-fri 09:00-18:00"，为空表示全天
+}
+
+// InWindow 判断当前时间是否在任一时间窗口内，没有配置窗口时始终为 true
+func (p AutostartProfile) InWindow(now time.Time) bool {
+	if len(p.Windows) == 0 {
+		return true
+	}
+	for _, text := range p.Windows {
+		if window, err := ParseTimeWindow(text); err == nil && window.Contains(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// AutostartConfigPath 返回自动启动配置使用的配置文件
+func (s *AppSettings) AutostartConfigPath(p AutostartProfile) string {
+	switch {
+	case p.ConfigPath != "":
+		return p.ConfigPath
+	case p.Service == "server":
+		return s.ServerConfigPath
+	default:
+		return s.ClientConfigPath
+	}
+}
+
+// validateAutostart 校验自动启动配置
+func validateAutostart(profiles []AutostartProfile) error {
+	names := make(map[string]bool, len(profiles))
+	for _, p := range profiles {
+		if p.Name == "" {
+			return i18n.Errorf("自动启动配置的名称不能为空")
+		}
+		if names[p.Name] {
+			return i18n.Errorf("自动启动配置名称重复: %s", p.Name)
+		}
+		names[p.Name] = true
+
+		if p.Service != "server" && p.Service != "client" {
+			return i18n.Errorf("自动启动配置 %s 的服务必须是 server 或 client", p.Name)
+		}
+		for _, text := range p.Windows {
+			if _, err := ParseTimeWindow(text); err != nil {
+				return i18n.Errorf("自动启动配置 %s: %w", p.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// weekdayNames 星期缩写，按 time.Weekday 排列
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// TimeWindow 按本地时间判断的时间窗口
+type TimeWindow struct {
+	Days  [7]bool // 按 time.Weekday 索引
+	Start int     // 开始时刻，距零点的分钟数
+	End   int     // 结束时刻，小于开始时刻表示跨过零点，与开始相同表示全天
+}
+
+// ParseTimeWindow 解析时间窗口，格式为 "[星期] [HH:MM-HH:MM]"。
+// 星期支持 mon-fri、sat,sun、daily，省略表示每天；省略时刻表示全天
+func ParseTimeWindow(text string) (TimeWindow, error) {
+	var window TimeWindow
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 || len(fields) > 2 {
+		return window, i18n.Errorf("无效的时间窗口: %q", text)
+	}
+
+	days, clock := "", ""
+	for _, field := range fields {
+		if strings.Contains(field, ":") {
+			clock = field
+		} else {
+			days = field
+		}
+	}
+	if len(fields) == 2 && (days == "" || clock == "") {
+		return window, i18n.Errorf("无效的时间窗口: %q", text)
+	}
+
+	if err := window.parseDays(days); err != nil {
+		return window, i18n.Errorf("无效的时间窗口 %q: %w", text, err)
+	}
+	if clock != "" {
+		start, end, ok := strings.Cut(clock, "-")
+		if !ok {
+			return window, i18n.Errorf("无效的时间窗口 %q: 时刻应为 HH:MM-HH:MM", text)
+		}
+		var err error
+		if window.Start, err = parseClock(start); err != nil {
+			return window, i18n.Errorf("无效的时间窗口 %q: %w", text, err)
+		}
+		if window.End, err = parseClock(end); err != nil {
+			return window, i18n.Errorf("无效的时间窗口 %q: %w", text, err)
+		}
+	}
+	return window, nil
+}
+
+// parseDays 解析星期，支持逗号分隔和范围，范围可以跨周末，如 fri-mon
+func (w *TimeWindow) parseDays(text string) error {
+	if text == "" || text == "daily" || text == "*" {
+		for i := range w.Days {
+			w.Days[i] = true
+		}
+		return nil
+	}
+
+	for _, part := range strings.Split(text, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		start := weekdayIndex(from)
+		if start < 0 {
+			return i18n.Errorf("未知的星期: %s", from)
+		}
+		end := start
+		if isRange {
+			if end = weekdayIndex(to); end < 0 {
+				return i18n.Errorf("未知的星期: %s", to)
+			}
+		}
+		for day := start; ; day = (day + 1) % 7 {
+			w.Days[day] = true
+			if day == end {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// weekdayIndex 返回星期缩写对应的 time.Weekday，未知时返回 -1
+func weekdayIndex(name string) int {
+	for i, weekday := range weekdayNames {
+		if name == weekday {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseClock 解析 HH:MM，24:00 表示当天结束
+func parseClock(text string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(text, "%d:%d", &hour, &minute); err != nil ||
+		hour < 0 || hour > 24 || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, i18n.Errorf("无效的时刻: %s", text)
+	}
+	return hour*60 + minute, nil
+}
+
+// Contains 判断时间是否在窗口内，跨零点的窗口零点之后属于前一天
+func (w TimeWindow) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	day := int(t.Weekday())
+
+	switch {
+	case w.Start == w.End:
+		return w.Days[day]
+	case w.Start < w.End:
+		return w.Days[day] && minute >= w.Start && minute < w.End
+	case minute >= w.Start:
+		return w.Days[day]
+	default:
+		return minute < w.End && w.Days[(day+6)%7]
+	}
+}
@@ -0,0 +1,622 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 以下结构对照 frp v0.52 的 pkg/config/v1 整理，并补充了后续版本新增的 annotations、responseHeaders 等字段，
+// 只保留字段名和类型，用于检查配置中的字段拼写和值类型。
+// frp 解析配置时会静默忽略不认识的字段，字段名写错后配置看似生效、实际没有作用
+
+type frpsSchema struct {
+	Auth struct {
+		Method           string   `json:"method"`
+		AdditionalScopes []string `json:"additionalScopes"`
+		Token            string   `json:"token"`
+		OIDC             struct {
+			Issuer          string `json:"issuer"`
+			Audience        string `json:"audience"`
+			SkipExpiryCheck bool   `json:"skipExpiryCheck"`
+			SkipIssuerCheck bool   `json:"skipIssuerCheck"`
+		} `json:"oidc"`
+	} `json:"auth"`
+	BindAddr              string          `json:"bindAddr"`
+	BindPort              int             `json:"bindPort"`
+	KCPBindPort           int             `json:"kcpBindPort"`
+	QUICBindPort          int             `json:"quicBindPort"`
+	ProxyBindAddr         string          `json:"proxyBindAddr"`
+	VhostHTTPPort         int             `json:"vhostHTTPPort"`
+	VhostHTTPTimeout      int             `json:"vhostHTTPTimeout"`
+	VhostHTTPSPort        int             `json:"vhostHTTPSPort"`
+	TCPMuxHTTPConnectPort int             `json:"tcpmuxHTTPConnectPort"`
+	TCPMuxPassthrough     bool            `json:"tcpmuxPassthrough"`
+	SubDomainHost         string          `json:"subDomainHost"`
+	Custom404Page         string          `json:"custom404Page"`
+	WebServer             schemaWebServer `json:"webServer"`
+	EnablePrometheus      bool            `json:"enablePrometheus"`
+	Log                   schemaLog       `json:"log"`
+	Transport             struct {
+		TCPMux                  bool       `json:"tcpMux"`
+		TCPMuxKeepaliveInterval int        `json:"tcpMuxKeepaliveInterval"`
+		TCPKeepAlive            int        `json:"tcpKeepalive"`
+		MaxPoolCount            int        `json:"maxPoolCount"`
+		HeartbeatTimeout        int        `json:"heartbeatTimeout"`
+		QUIC                    schemaQUIC `json:"quic"`
+		TLS                     struct {
+			Force         bool   `json:"force"`
+			CertFile      string `json:"certFile"`
+			KeyFile       string `json:"keyFile"`
+			TrustedCaFile string `json:"trustedCaFile"`
+		} `json:"tls"`
+	} `json:"transport"`
+	DetailedErrorsToClient          bool `json:"detailedErrorsToClient"`
+	MaxPortsPerClient               int  `json:"maxPortsPerClient"`
+	UserConnTimeout                 int  `json:"userConnTimeout"`
+	UDPPacketSize                   int  `json:"udpPacketSize"`
+	NatHoleAnalysisDataReserveHours int  `json:"natholeAnalysisDataReserveHours"`
+	AllowPorts                      []struct {
+		Start  int `json:"start"`
+		End    int `json:"end"`
+		Single int `json:"single"`
+	} `json:"allowPorts"`
+	HTTPPlugins []struct {
+		Name      string   `json:"name"`
+		Addr      string   `json:"addr"`
+		Path      string   `json:"path"`
+		Ops       []string `json:"ops"`
+		TLSVerify bool     `json:"tlsVerify"`
+	} `json:"httpPlugins"`
+	SSHTunnelGateway struct {
+		BindPort              int    `json:"bindPort"`
+		PrivateKeyFile        string `json:"privateKeyFile"`
+		AutoGenPrivateKeyPath string `json:"autoGenPrivateKeyPath"`
+		AuthorizedKeysFile    string `json:"authorizedKeysFile"`
+	} `json:"sshTunnelGateway"`
+}
+
+type frpcSchema struct {
+	Auth struct {
+		Method           string   `json:"method"`
+		AdditionalScopes []string `json:"additionalScopes"`
+		Token            string   `json:"token"`
+		OIDC             struct {
+			ClientID                 string            `json:"clientID"`
+			ClientSecret             string            `json:"clientSecret"`
+			Audience                 string            `json:"audience"`
+			Scope                    string            `json:"scope"`
+			TokenEndpointURL         string            `json:"tokenEndpointURL"`
+			AdditionalEndpointParams map[string]string `json:"additionalEndpointParams"`
+		} `json:"oidc"`
+	} `json:"auth"`
+	User              string          `json:"user"`
+	ServerAddr        string          `json:"serverAddr"`
+	ServerPort        int             `json:"serverPort"`
+	NatHoleSTUNServer string          `json:"natHoleStunServer"`
+	DNSServer         string          `json:"dnsServer"`
+	LoginFailExit     bool            `json:"loginFailExit"`
+	Start             []string        `json:"start"`
+	Log               schemaLog       `json:"log"`
+	WebServer         schemaWebServer `json:"webServer"`
+	Transport         struct {
+		Protocol                string     `json:"protocol"`
+		DialServerTimeout       int        `json:"dialServerTimeout"`
+		DialServerKeepAlive     int        `json:"dialServerKeepalive"`
+		ConnectServerLocalIP    string     `json:"connectServerLocalIP"`
+		ProxyURL                string     `json:"proxyURL"`
+		PoolCount               int        `json:"poolCount"`
+		TCPMux                  bool       `json:"tcpMux"`
+		TCPMuxKeepaliveInterval int        `json:"tcpMuxKeepaliveInterval"`
+		QUIC                    schemaQUIC `json:"quic"`
+		HeartbeatInterval       int        `json:"heartbeatInterval"`
+		HeartbeatTimeout        int        `json:"heartbeatTimeout"`
+		TLS                     struct {
+			Enable                    bool   `json:"enable"`
+			DisableCustomTLSFirstByte bool   `json:"disableCustomTLSFirstByte"`
+			CertFile                  string `json:"certFile"`
+			KeyFile                   string `json:"keyFile"`
+			TrustedCaFile             string `json:"trustedCaFile"`
+			ServerName                string `json:"serverName"`
+		} `json:"tls"`
+	} `json:"transport"`
+	UDPPacketSize int               `json:"udpPacketSize"`
+	Metadatas     map[string]string `json:"metadatas"`
+	Includes      []string          `json:"includes"`
+	Proxies       []schemaProxy     `json:"proxies"`
+	Visitors      []schemaVisitor   `json:"visitors"`
+}
+
+type schemaLog struct {
+	To                string `json:"to"`
+	Level             string `json:"level"`
+	MaxDays           int    `json:"maxDays"`
+	DisablePrintColor bool   `json:"disablePrintColor"`
+}
+
+type schemaWebServer struct {
+	Addr        string `json:"addr"`
+	Port        int    `json:"port"`
+	User        string `json:"user"`
+	Password    string `json:"password"`
+	AssetsDir   string `json:"assetsDir"`
+	PprofEnable bool   `json:"pprofEnable"`
+	TLS         struct {
+		CertFile      string `json:"certFile"`
+		KeyFile       string `json:"keyFile"`
+		TrustedCaFile string `json:"trustedCaFile"`
+		ServerName    string `json:"serverName"`
+	} `json:"tls"`
+}
+
+type schemaQUIC struct {
+	KeepalivePeriod    int `json:"keepalivePeriod"`
+	MaxIdleTimeout     int `json:"maxIdleTimeout"`
+	MaxIncomingStreams int `json:"maxIncomingStreams"`
+}
+
+type schemaHeaders struct {
+	Set map[string]string `json:"set"`
+}
+
+// schemaProxy 与 schemaVisitor、schemaPlugin 按 type 字段选择具体结构
+type (
+	schemaProxy   struct{}
+	schemaVisitor struct{}
+	schemaPlugin  struct{}
+)
+
+type schemaProxyBase struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Transport struct {
+		UseEncryption        bool   `json:"useEncryption"`
+		UseCompression       bool   `json:"useCompression"`
+		BandwidthLimit       string `json:"bandwidthLimit"`
+		BandwidthLimitMode   string `json:"bandwidthLimitMode"`
+		ProxyProtocolVersion string `json:"proxyProtocolVersion"`
+	} `json:"transport"`
+	Metadatas    map[string]string `json:"metadatas"`
+	Annotations  map[string]string `json:"annotations"`
+	LoadBalancer struct {
+		Group    string `json:"group"`
+		GroupKey string `json:"groupKey"`
+	} `json:"loadBalancer"`
+	HealthCheck struct {
+		Type            string `json:"type"`
+		TimeoutSeconds  int    `json:"timeoutSeconds"`
+		MaxFailed       int    `json:"maxFailed"`
+		IntervalSeconds int    `json:"intervalSeconds"`
+		Path            string `json:"path"`
+		HTTPHeaders     []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"httpHeaders"`
+	} `json:"healthCheck"`
+	LocalIP   string       `json:"localIP"`
+	LocalPort int          `json:"localPort"`
+	Plugin    schemaPlugin `json:"plugin"`
+}
+
+type schemaDomain struct {
+	CustomDomains []string `json:"customDomains"`
+	SubDomain     string   `json:"subdomain"`
+}
+
+type schemaRemotePortProxy struct {
+	schemaProxyBase
+	RemotePort int `json:"remotePort"`
+}
+
+type schemaHTTPProxy struct {
+	schemaProxyBase
+	schemaDomain
+	Locations         []string      `json:"locations"`
+	HTTPUser          string        `json:"httpUser"`
+	HTTPPassword      string        `json:"httpPassword"`
+	HostHeaderRewrite string        `json:"hostHeaderRewrite"`
+	RequestHeaders    schemaHeaders `json:"requestHeaders"`
+	ResponseHeaders   schemaHeaders `json:"responseHeaders"`
+	RouteByHTTPUser   string        `json:"routeByHTTPUser"`
+}
+
+type schemaHTTPSProxy struct {
+	schemaProxyBase
+	schemaDomain
+}
+
+type schemaTCPMuxProxy struct {
+	schemaProxyBase
+	schemaDomain
+	HTTPUser        string `json:"httpUser"`
+	HTTPPassword    string `json:"httpPassword"`
+	RouteByHTTPUser string `json:"routeByHTTPUser"`
+	Multiplexer     string `json:"multiplexer"`
+}
+
+type schemaSecretProxy struct {
+	schemaProxyBase
+	SecretKey  string   `json:"secretKey"`
+	AllowUsers []string `json:"allowUsers"`
+}
+
+type schemaXTCPProxy struct {
+	schemaSecretProxy
+	NatTraversal struct {
+		DisableAssistedAddrs bool `json:"disableAssistedAddrs"`
+	} `json:"natTraversal"`
+}
+
+type schemaVisitorBase struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Transport struct {
+		UseEncryption  bool `json:"useEncryption"`
+		UseCompression bool `json:"useCompression"`
+	} `json:"transport"`
+	SecretKey  string `json:"secretKey"`
+	ServerUser string `json:"serverUser"`
+	ServerName string `json:"serverName"`
+	BindAddr   string `json:"bindAddr"`
+	BindPort   int    `json:"bindPort"`
+}
+
+type schemaXTCPVisitor struct {
+	schemaVisitorBase
+	Protocol          string `json:"protocol"`
+	KeepTunnelOpen    bool   `json:"keepTunnelOpen"`
+	MaxRetriesAnHour  int    `json:"maxRetriesAnHour"`
+	MinRetryInterval  int    `json:"minRetryInterval"`
+	FallbackTo        string `json:"fallbackTo"`
+	FallbackTimeoutMs int    `json:"fallbackTimeoutMs"`
+}
+
+type schemaPluginBase struct {
+	Type string `json:"type"`
+}
+
+type schemaHTTPSPlugin struct {
+	schemaPluginBase
+	LocalAddr         string        `json:"localAddr"`
+	HostHeaderRewrite string        `json:"hostHeaderRewrite"`
+	RequestHeaders    schemaHeaders `json:"requestHeaders"`
+	CrtPath           string        `json:"crtPath"`
+	KeyPath           string        `json:"keyPath"`
+}
+
+type schemaHTTP2HTTPSPlugin struct {
+	schemaPluginBase
+	LocalAddr         string        `json:"localAddr"`
+	HostHeaderRewrite string        `json:"hostHeaderRewrite"`
+	RequestHeaders    schemaHeaders `json:"requestHeaders"`
+}
+
+type schemaHTTPProxyPlugin struct {
+	schemaPluginBase
+	HTTPUser     string `json:"httpUser"`
+	HTTPPassword string `json:"httpPassword"`
+}
+
+type schemaSocks5Plugin struct {
+	schemaPluginBase
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type schemaStaticFilePlugin struct {
+	schemaPluginBase
+	LocalPath    string `json:"localPath"`
+	StripPrefix  string `json:"stripPrefix"`
+	HTTPUser     string `json:"httpUser"`
+	HTTPPassword string `json:"httpPassword"`
+}
+
+type schemaUnixSocketPlugin struct {
+	schemaPluginBase
+	UnixPath string `json:"unixPath"`
+}
+
+// schemaVariants 按 type 字段区分的结构
+var schemaVariants = map[reflect.Type]map[string]reflect.Type{
+	reflect.TypeOf(schemaProxy{}): {
+		"tcp":    reflect.TypeOf(schemaRemotePortProxy{}),
+		"udp":    reflect.TypeOf(schemaRemotePortProxy{}),
+		"http":   reflect.TypeOf(schemaHTTPProxy{}),
+		"https":  reflect.TypeOf(schemaHTTPSProxy{}),
+		"tcpmux": reflect.TypeOf(schemaTCPMuxProxy{}),
+		"stcp":   reflect.TypeOf(schemaSecretProxy{}),
+		"xtcp":   reflect.TypeOf(schemaXTCPProxy{}),
+		"sudp":   reflect.TypeOf(schemaSecretProxy{}),
+	},
+	reflect.TypeOf(schemaVisitor{}): {
+		"stcp": reflect.TypeOf(schemaVisitorBase{}),
+		"sudp": reflect.TypeOf(schemaVisitorBase{}),
+		"xtcp": reflect.TypeOf(schemaXTCPVisitor{}),
+	},
+	reflect.TypeOf(schemaPlugin{}): {
+		"http2https":         reflect.TypeOf(schemaHTTP2HTTPSPlugin{}),
+		"http_proxy":         reflect.TypeOf(schemaHTTPProxyPlugin{}),
+		"https2http":         reflect.TypeOf(schemaHTTPSPlugin{}),
+		"https2https":        reflect.TypeOf(schemaHTTPSPlugin{}),
+		"socks5":             reflect.TypeOf(schemaSocks5Plugin{}),
+		"static_file":        reflect.TypeOf(schemaStaticFilePlugin{}),
+		"unix_domain_socket": reflect.TypeOf(schemaUnixSocketPlugin{}),
+	},
+}
+
+// schemaRenamed 旧版配置中常见的字段在 frp v1 中的位置
+var schemaRenamed = map[string]string{
+	"token":      "auth.token",
+	"group":      "loadBalancer.group",
+	"groupKey":   "loadBalancer.groupKey",
+	"httpPwd":    "httpPassword",
+	"maxLogFile": "maxDays",
+}
+
+// SchemaIssue 配置与 frp 配置结构不一致的地方
+type SchemaIssue struct {
+	Path    string // 字段路径，如 proxies[web].localPort
+	Message string
+}
+
+// String 返回带字段路径的说明
+func (i SchemaIssue) String() string {
+	return i.Path + ": " + i.Message
+}
+
+// CheckSchema 按 frp v1 的配置结构检查配置内容，找出 frp 不认识的字段（附带可能的正确拼写）和类型不符的值；
+// server 为 true 时按 frps 检查，否则按 frpc 检查。INI 配置不做检查
+func CheckSchema(data []byte, format ConfigFormat, server bool) ([]SchemaIssue, error) {
+	var doc map[string]any
+	switch format {
+	case FormatTOML:
+		if err := toml.Unmarshal(data, &doc); err != nil {
+			return nil, i18n.Errorf("解析配置文件失败: %w", err)
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, i18n.Errorf("解析配置文件失败: %w", err)
+		}
+	default:
+		return nil, nil
+	}
+
+	root := reflect.TypeOf(frpcSchema{})
+	if server {
+		root = reflect.TypeOf(frpsSchema{})
+	}
+	var issues []SchemaIssue
+	checkSchemaValue(doc, root, "", &issues)
+	return issues, nil
+}
+
+// IsServerConfig 根据字段判断配置是否属于服务端
+func (c *Config) IsServerConfig() bool {
+	return DetectConfigType(c) == "server"
+}
+
+// checkSchemaValue 递归检查值是否符合结构定义
+func checkSchemaValue(value any, t reflect.Type, path string, issues *[]SchemaIssue) {
+	// 含模板的值由 frp 在加载时渲染，这里无法判断类型
+	if s, ok := value.(string); ok && strings.Contains(s, "{{") {
+		return
+	}
+	report := func(message string) {
+		*issues = append(*issues, SchemaIssue{Path: path, Message: message})
+	}
+
+	if variants, ok := schemaVariants[t]; ok {
+		fields, ok := value.(map[string]any)
+		if !ok {
+			report(i18n.Sprintf("类型应为%s，实际为%s", schemaKindName(reflect.Struct), schemaValueName(value)))
+			return
+		}
+		kind, _ := fields["type"].(string)
+		if kind == "" && t == reflect.TypeOf(schemaProxy{}) {
+			kind = "tcp"
+		}
+		variant, ok := variants[kind]
+		if !ok {
+			report(i18n.Sprintf("frp 不支持类型 %s，可选: %s", kind, strings.Join(sortedKeys(variants), "/")))
+			return
+		}
+		t = variant
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		fields, ok := value.(map[string]any)
+		if !ok {
+			report(i18n.Sprintf("类型应为%s，实际为%s", schemaKindName(reflect.Struct), schemaValueName(value)))
+			return
+		}
+		known := schemaFields(t)
+		for _, key := range sortedKeys(fields) {
+			childPath := joinSchemaPath(path, key)
+			field, ok := lookupSchemaField(known, key)
+			if !ok {
+				message := i18n.T("frp 不认识这个字段，加载时会被忽略")
+				if renamed, ok := schemaRenamed[key]; ok {
+					message += i18n.Sprintf("（frp v1 中应写作 %s）", renamed)
+				} else if suggestion := suggestSchemaField(known, key); suggestion != "" {
+					message += i18n.Sprintf("（是否想写 %s？）", suggestion)
+				}
+				*issues = append(*issues, SchemaIssue{Path: childPath, Message: message})
+				continue
+			}
+			checkSchemaValue(fields[key], field, childPath, issues)
+		}
+
+	case reflect.Slice:
+		items, ok := value.([]any)
+		if !ok {
+			report(i18n.Sprintf("类型应为%s，实际为%s", schemaKindName(reflect.Slice), schemaValueName(value)))
+			return
+		}
+		for i, item := range items {
+			label := fmt.Sprint(i)
+			if m, ok := item.(map[string]any); ok {
+				if name, ok := m["name"].(string); ok && name != "" {
+					label = name
+				}
+			}
+			checkSchemaValue(item, t.Elem(), fmt.Sprintf("%s[%s]", path, label), issues)
+		}
+
+	case reflect.Map:
+		entries, ok := value.(map[string]any)
+		if !ok {
+			report(i18n.Sprintf("类型应为%s，实际为%s", schemaKindName(reflect.Map), schemaValueName(value)))
+			return
+		}
+		for _, key := range sortedKeys(entries) {
+			checkSchemaValue(entries[key], t.Elem(), joinSchemaPath(path, key), issues)
+		}
+
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			report(i18n.Sprintf("类型应为%s，实际为%s", schemaKindName(reflect.String), schemaValueName(value)))
+		}
+
+	case reflect.Int:
+		switch v := value.(type) {
+		case int, int64, uint64:
+		case float64:
+			if v != float64(int64(v)) {
+				report(i18n.Sprintf("类型应为%s，实际为%s", schemaKindName(reflect.Int), schemaValueName(value)))
+			}
+		default:
+			report(i18n.Sprintf("类型应为%s，实际为%s", schemaKindName(reflect.Int), schemaValueName(value)))
+		}
+
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			report(i18n.Sprintf("类型应为%s，实际为%s", schemaKindName(reflect.Bool), schemaValueName(value)))
+		}
+	}
+}
+
+// schemaFields 返回结构的 json 字段名及其类型，匿名嵌入的结构会展开
+func schemaFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			for name, ft := range schemaFields(field.Type) {
+				fields[name] = ft
+			}
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = strings.ToLower(field.Name[:1]) + field.Name[1:]
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// lookupSchemaField 按 frp 解析 JSON 的规则不区分大小写查找字段
+func lookupSchemaField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if t, ok := fields[key]; ok {
+		return t, true
+	}
+	for name, t := range fields {
+		if strings.EqualFold(name, key) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// suggestSchemaField 找出与拼错的字段最接近的已知字段，差异过大时返回空
+func suggestSchemaField(fields map[string]reflect.Type, key string) string {
+	best, bestDistance := "", len(key)/3+1
+	for _, name := range sortedKeys(fields) {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(key)); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// editDistance 计算两个字符串的编辑距离
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// schemaKindName 返回结构类型的中文名称
+func schemaKindName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return i18n.T("字符串")
+	case reflect.Int:
+		return i18n.T("整数")
+	case reflect.Bool:
+		return i18n.T("布尔值")
+	case reflect.Slice:
+		return i18n.T("列表")
+	default:
+		return i18n.T("对象")
+	}
+}
+
+// schemaValueName 返回配置中实际值类型的中文名称
+func schemaValueName(value any) string {
+	switch v := value.(type) {
+	case string:
+		return i18n.T("字符串") + fmt.Sprintf(" %q", v)
+	case int, int64, uint64:
+		return i18n.T("整数")
+	case float64:
+		return i18n.T("小数")
+	case bool:
+		return i18n.T("布尔值")
+	case []any:
+		return i18n.T("列表")
+	case nil:
+		return i18n.T("空值")
+	default:
+		return i18n.T("对象")
+	}
+}
+
+// joinSchemaPath 拼接字段路径
+func joinSchemaPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// sortedKeys 返回排序后的映射键，保证检查结果顺序稳定
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SearchScope 搜索条目所属的位置
+type SearchScope string
+
+const (
+	SearchScopeServer  SearchScope = "server"
+	SearchScopeClient  SearchScope = "client"
+	SearchScopeProxy   SearchScope = "proxy"
+	SearchScopeVisitor SearchScope = "visitor"
+)
+
+// SearchEntry 可搜索的一个配置字段，密钥和密码不参与搜索
+type SearchEntry struct {
+	Scope SearchScope
+	Index int    // 代理或访问者在配置中的下标
+	Owner string // 代理或访问者名称
+	Field string // 配置文件中的字段名，如 remotePort、plugin.localPath
+	Value string
+}
+
+// SearchHit 一条匹配结果，Positions 为 Value 中匹配到的字符下标（按 rune 计）
+type SearchHit struct {
+	SearchEntry
+	Positions []int
+	Score     int
+}
+
+// SearchEntries 列出服务端与客户端配置中可搜索的字段，配置为空时跳过
+func SearchEntries(server, client *Config) []SearchEntry {
+	var entries []SearchEntry
+	add := func(scope SearchScope, index int, owner, field, value string) {
+		if value != "" {
+			entries = append(entries, SearchEntry{Scope: scope, Index: index, Owner: owner, Field: field, Value: value})
+		}
+	}
+	port := func(port int) string {
+		if port == 0 {
+			return ""
+		}
+		return strconv.Itoa(port)
+	}
+
+	if server != nil {
+		add(SearchScopeServer, -1, "", "bindPort", port(server.BindPort))
+		add(SearchScopeServer, -1, "", "bindUDPPort", port(server.BindUDPPort))
+		add(SearchScopeServer, -1, "", "kcpBindPort", port(server.KCPBindPort))
+		add(SearchScopeServer, -1, "", "vhostHTTPPort", port(server.VhostHTTPPort))
+		add(SearchScopeServer, -1, "", "vhostHTTPSPort", port(server.VhostHTTPSPort))
+		add(SearchScopeServer, -1, "", "tcpmuxHTTPConnectPort", port(server.TCPMuxHTTPConnectPort))
+		add(SearchScopeServer, -1, "", "subDomainHost", server.SubDomainHost)
+		add(SearchScopeServer, -1, "", "sshTunnelGateway.bindPort", port(server.SSHTunnelGateway.BindPort))
+		add(SearchScopeServer, -1, "", "webServer.port", port(server.WebServer.Port))
+	}
+
+	if client == nil {
+		return entries
+	}
+	add(SearchScopeClient, -1, "", "serverAddr", client.ServerAddr)
+	add(SearchScopeClient, -1, "", "serverPort", port(client.ServerPort))
+	add(SearchScopeClient, -1, "", "webServer.port", port(client.WebServer.Port))
+
+	for i, proxy := range client.Proxies {
+		scope, name := SearchScopeProxy, proxy.Name
+		add(scope, i, name, "name", proxy.Name)
+		add(scope, i, name, "localIP", proxy.LocalIP)
+		add(scope, i, name, "localPort", port(proxy.LocalPort))
+		add(scope, i, name, "remotePort", port(proxy.RemotePort))
+		for _, domain := range proxy.CustomDomains {
+			add(scope, i, name, "customDomains", domain)
+		}
+		add(scope, i, name, "subdomain", proxy.Subdomain)
+		for _, location := range proxy.Locations {
+			add(scope, i, name, "locations", location)
+		}
+		add(scope, i, name, "hostHeaderRewrite", proxy.HostHeaderRewrite)
+		add(scope, i, name, "serverName", proxy.ServerName)
+		add(scope, i, name, "group", proxy.LoadBalancer.Group)
+		add(scope, i, name, "healthCheck.path", proxy.HealthCheck.Path)
+
+		plugin := proxy.Plugin
+		add(scope, i, name, "plugin.type", plugin.Type)
+		add(scope, i, name, "plugin.unixPath", plugin.UnixPath)
+		add(scope, i, name, "plugin.localPath", plugin.LocalPath)
+		add(scope, i, name, "plugin.stripPrefix", plugin.StripPrefix)
+		add(scope, i, name, "plugin.localAddr", plugin.LocalAddr)
+		add(scope, i, name, "plugin.crtPath", plugin.CrtPath)
+		add(scope, i, name, "plugin.keyPath", plugin.KeyPath)
+		add(scope, i, name, "plugin.hostHeaderRewrite", plugin.HostHeaderRewrite)
+	}
+
+	for i, visitor := range client.Visitors {
+		scope, name := SearchScopeVisitor, visitor.Name
+		add(scope, i, name, "name", visitor.Name)
+		add(scope, i, name, "serverName", visitor.ServerName)
+		add(scope, i, name, "bindAddr", visitor.BindAddr)
+		add(scope, i, name, "bindPort", port(visitor.BindPort))
+	}
+	return entries
+}
+
+// Search 在条目中查找匹配 query 的字段，按匹配程度从高到低排序，同分时保持配置中的顺序
+func Search(entries []SearchEntry, query string) []SearchHit {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+
+	var hits []SearchHit
+	for _, entry := range entries {
+		if score, positions, ok := FuzzyMatch(query, entry.Value); ok {
+			hits = append(hits, SearchHit{SearchEntry: entry, Positions: positions, Score: score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	return hits
+}
+
+// FuzzyMatch 忽略大小写匹配 query：完全相同、前缀、包含的得分依次降低，
+// 其次是按顺序出现 query 中全部字符的模糊匹配。纯数字的 query 只做包含匹配，避免查端口时匹配到无关端口
+func FuzzyMatch(query, text string) (int, []int, bool) {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(text))
+	if len(q) == 0 || len(q) > len(t) {
+		return 0, nil, false
+	}
+
+	if start := runeIndex(t, q); start >= 0 {
+		positions := make([]int, len(q))
+		for i := range q {
+			positions[i] = start + i
+		}
+		switch {
+		case len(q) == len(t):
+			return 3000, positions, true
+		case start == 0:
+			return 2000 - len(t), positions, true
+		default:
+			return 1000 - start, positions, true
+		}
+	}
+
+	if isDigits(q) {
+		return 0, nil, false
+	}
+	var positions []int
+	for i, j := 0, 0; i < len(t) && j < len(q); i++ {
+		if t[i] == q[j] {
+			positions = append(positions, i)
+			j++
+		}
+	}
+	if len(positions) < len(q) {
+		return 0, nil, false
+	}
+	// 匹配字符越集中得分越高
+	span := positions[len(positions)-1] - positions[0] + 1
+	return max(1, 500-(span-len(q))*10-positions[0]), positions, true
+}
+
+// runeIndex 返回 sub 在 s 中首次出现的位置，不存在时返回 -1
+func runeIndex(s, sub []rune) int {
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if string(s[i:i+len(sub)]) == string(sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isDigits 是否全部为数字
+func isDigits(r []rune) bool {
+	for _, c := range r {
+		if !unicode.IsDigit(c) {
+			return false
+		}
+	}
+	return true
+}
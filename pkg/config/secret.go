@@ -0,0 +1,36 @@
+package config
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// tokenAlphabet 生成令牌使用的字符，只含字母和数字，写入 YAML/TOML/INI 都无需转义
+const tokenAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// 令牌长度范围
+const (
+	MinTokenLength     = 16
+	MaxTokenLength     = 128
+	DefaultTokenLength = 32
+)
+
+// GenerateToken 使用加密安全的随机数生成指定长度的令牌，可用于 token 和 secretKey
+func GenerateToken(length int) (string, error) {
+	if length < MinTokenLength || length > MaxTokenLength {
+		return "", i18n.Errorf("令牌长度必须在 %d-%d 之间", MinTokenLength, MaxTokenLength)
+	}
+
+	limit := big.NewInt(int64(len(tokenAlphabet)))
+	token := make([]byte, length)
+	for i := range token {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", i18n.Errorf("生成随机数失败: %w", err)
+		}
+		token[i] = tokenAlphabet[n.Int64()]
+	}
+	return string(token), nil
+}
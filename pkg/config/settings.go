@@ -0,0 +1,533 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// AppSettings 应用自身的设置（区别于 frp 配置文件）
+type AppSettings struct {
+	DashboardURL       string `yaml:"dashboardURL"`                 // frps Dashboard API 地址
+	DashboardUser      string `yaml:"dashboardUser"`                // Dashboard 用户名
+	DashboardPassword  string `yaml:"dashboardPassword"`            // Dashboard 密码
+	RefreshInterval    int    `yaml:"refreshInterval"`              // frps/frpc 进程状态刷新间隔，单位秒
+	APIPollInterval    int    `yaml:"apiPollInterval"`              // 仪表盘轮询 frps 代理列表和服务器信息的间隔，单位秒
+	TrafficInterval    int    `yaml:"trafficInterval"`              // 流量统计采样间隔，单位秒
+	LogInterval        int    `yaml:"logInterval"`                  // 日志刷新到界面的间隔，单位秒
+	LogCapacity        int    `yaml:"logCapacity"`                  // 日志页保留的日志条数
+	Theme              string `yaml:"theme"`                        // 界面主题
+	Language           string `yaml:"language"`                     // 界面语言，zh 或 en
+	ServerConfigPath   string `yaml:"serverConfigPath"`             // 服务端配置文件
+	ClientConfigPath   string `yaml:"clientConfigPath"`             // 客户端配置文件
+	ServerLogFile      string `yaml:"serverLogFile,omitempty"`      // 跟踪的 frps 日志文件，为空时使用服务端配置中 log.to 指定的文件
+	ClientLogFile      string `yaml:"clientLogFile,omitempty"`      // 跟踪的 frpc 日志文件，为空时使用客户端配置中 log.to 指定的文件
+	DownloadMirror     string `yaml:"downloadMirror,omitempty"`     // 下载镜像，支持 {url} 占位符或作为前缀
+	DownloadProxy      string `yaml:"downloadProxy,omitempty"`      // 下载代理，支持 http/https/socks5
+	InstallDir         string `yaml:"installDir,omitempty"`         // FRP 安装目录，为空时使用 ~/.frp-manager
+	ServerVersion      string `yaml:"serverVersion,omitempty"`      // frps 固定使用的已安装版本，为空时使用 current 指向的版本
+	ClientVersion      string `yaml:"clientVersion,omitempty"`      // frpc 固定使用的已安装版本，为空时使用 current 指向的版本
+	ReleaseChannel     string `yaml:"releaseChannel,omitempty"`     // FRP 更新通道，stable 或 prerelease，为空时为 stable
+	BackupKeep         int    `yaml:"backupKeep"`                   // 每个配置文件保留的备份数，0 表示不限
+	BackupMaxDays      int    `yaml:"backupMaxDays"`                // 备份保留天数，0 表示不限
+	TemplateCatalogURL string `yaml:"templateCatalogURL,omitempty"` // 在线模板目录地址
+	TrafficKeepDays    int    `yaml:"trafficKeepDays"`              // 流量历史保留天数，0 表示不限
+	MonitorInterval    int    `yaml:"monitorInterval"`              // 健康检查间隔，单位秒，0 表示关闭
+	LatencyInterval    int    `yaml:"latencyInterval"`              // 到 frps 的延迟采样间隔，单位秒，0 表示关闭
+	LatencyWarnMs      int    `yaml:"latencyWarnMs"`                // 延迟告警阈值，单位毫秒，0 表示不告警
+	DesktopNotify      bool   `yaml:"desktopNotify"`                // 告警时发送桌面通知
+	AlertWebhookURL    string `yaml:"alertWebhookURL,omitempty"`    // 告警 Webhook 地址
+	AutoRestartServer  bool   `yaml:"autoRestartServer"`            // frps 崩溃后自动重启
+	AutoRestartClient  bool   `yaml:"autoRestartClient"`            // frpc 崩溃后自动重启
+	RestartMaxRetries  int    `yaml:"restartMaxRetries"`            // 重启窗口内最多重启次数，0 表示不限
+	RestartBackoff     int    `yaml:"restartBackoff"`               // 第一次重启前等待的秒数，之后每次翻倍
+	RestartWindow      int    `yaml:"restartWindow"`                // 统计重启次数的时间窗口，单位秒
+	StopOnExit         bool   `yaml:"stopOnExit"`                   // 退出时停止本工具启动的 frps/frpc
+	ShutdownTimeout    int    `yaml:"shutdownTimeout"`              // 退出时等待进程停止的秒数，超时后强制结束
+	TokenLength        int    `yaml:"tokenLength"`                  // 生成 token 和 secretKey 时的长度
+	AuditLog           bool   `yaml:"auditLog"`                     // 把启停、配置修改等用户操作记录到审计文件
+
+	// DashboardTargets 其他 frps 的 Dashboard API，可在仪表盘中切换
+	DashboardTargets []DashboardTarget `yaml:"dashboardTargets,omitempty"`
+
+	// ActiveDashboard 当前使用的 Dashboard 目标名称，为空或 default 时使用上面的 Dashboard 地址
+	ActiveDashboard string `yaml:"activeDashboard,omitempty"`
+
+	// Autostart 自动启动配置，应用启动时和进入时间窗口时启动对应服务
+	Autostart []AutostartProfile `yaml:"autostart,omitempty"`
+
+	// Webhooks 生命周期事件 Webhook，进程启停、代理上下线等事件发生时推送
+	Webhooks []WebhookConfig `yaml:"webhooks,omitempty"`
+
+	// ServerHooks frps 生命周期钩子
+	ServerHooks ServiceHooks `yaml:"serverHooks,omitempty"`
+
+	// ClientHooks frpc 生命周期钩子
+	ClientHooks ServiceHooks `yaml:"clientHooks,omitempty"`
+
+	// TrafficQuotas 按天或按月的流量配额，接近或超出时告警，可选自动停用代理
+	TrafficQuotas []TrafficQuota `yaml:"trafficQuotas,omitempty"`
+
+	// TabPlugins 额外加载的扩展标签页，可以是 Go 插件（.so）或通过标准输入输出通信的可执行程序
+	TabPlugins []TabPluginConfig `yaml:"tabPlugins,omitempty"`
+
+	// KeyBindings 自定义快捷键，键为 "<分组>.<操作>"，值为逗号分隔的按键，如 global.quit: "q,ctrl+c"
+	KeyBindings map[string]string `yaml:"keyBindings,omitempty"`
+
+	// LogColors 按日志级别覆盖主题中的日志颜色，值为 0-255 的终端颜色编号或 #RRGGBB，如 WARN: "214"
+	LogColors map[string]string `yaml:"logColors,omitempty"`
+
+	// FileBookmarks 文件选择器中额外的书签目录，~/.frp-manager 和 /etc/frp 总是显示
+	FileBookmarks []string `yaml:"fileBookmarks,omitempty"`
+
+	// RecentFiles 最近在文件选择器中选择或保存的文件，最新的在前
+	RecentFiles []string `yaml:"recentFiles,omitempty"`
+}
+
+// FRP 更新通道
+const (
+	ReleaseChannelStable     = "stable"     // 只提供正式版本
+	ReleaseChannelPrerelease = "prerelease" // 同时提供 RC 等预发布版本
+)
+
+// ReleaseChannels 支持的更新通道
+var ReleaseChannels = []string{ReleaseChannelStable, ReleaseChannelPrerelease}
+
+// Webhook 消息模板
+const (
+	WebhookTemplateGeneric  = "generic"  // 原样推送事件 JSON
+	WebhookTemplateSlack    = "slack"    // Slack Incoming Webhook
+	WebhookTemplateDingTalk = "dingtalk" // 钉钉自定义机器人
+	WebhookTemplateFeishu   = "feishu"   // 飞书自定义机器人
+)
+
+// WebhookTemplates 支持的 Webhook 消息模板
+var WebhookTemplates = []string{WebhookTemplateGeneric, WebhookTemplateSlack, WebhookTemplateDingTalk, WebhookTemplateFeishu}
+
+// WebhookConfig 事件 Webhook 配置
+type WebhookConfig struct {
+	Name     string   `yaml:"name,omitempty"`     // 显示名称，用于错误提示
+	URL      string   `yaml:"url"`                // 推送地址
+	Template string   `yaml:"template,omitempty"` // 消息模板，为空时使用 generic
+	Events   []string `yaml:"events,omitempty"`   // 订阅的事件，如 process.crashed 或 process，为空表示全部
+}
+
+// DisplayName 返回 Webhook 的显示名称，未设置名称时使用地址
+func (w WebhookConfig) DisplayName() string {
+	if w.Name != "" {
+		return w.Name
+	}
+	return w.URL
+}
+
+// DefaultHookTimeout 未设置时单个钩子的最长运行秒数
+const DefaultHookTimeout = 30
+
+// ServiceHooks 服务生命周期钩子，每项是一条 shell 命令（Windows 上由 cmd /C 执行），为空时跳过
+type ServiceHooks struct {
+	PreStart  string `yaml:"preStart,omitempty"`  // 启动前执行，失败时取消启动
+	PostStart string `yaml:"postStart,omitempty"` // 启动成功后执行
+	PreStop   string `yaml:"preStop,omitempty"`   // 停止前执行，失败时仍继续停止
+	PostStop  string `yaml:"postStop,omitempty"`  // 停止或正常退出后执行
+	OnCrash   string `yaml:"onCrash,omitempty"`   // 异常退出后、自动重启前执行
+	Timeout   int    `yaml:"timeout,omitempty"`   // 单个钩子的最长运行秒数，0 表示使用 DefaultHookTimeout
+}
+
+// TabPluginConfig 扩展标签页插件配置
+type TabPluginConfig struct {
+	Name     string   `yaml:"name"`               // 标签页标题，插件可以在运行时修改
+	Path     string   `yaml:"path"`               // 以 .so 结尾时作为 Go 插件加载，否则作为子进程启动
+	Args     []string `yaml:"args,omitempty"`     // 子进程插件的命令行参数
+	Disabled bool     `yaml:"disabled,omitempty"` // 暂不加载
+}
+
+// IsGoPlugin 是否为 Go 插件
+func (p TabPluginConfig) IsGoPlugin() bool {
+	return strings.HasSuffix(p.Path, ".so")
+}
+
+// DefaultAppSettings 返回默认应用设置
+func DefaultAppSettings() *AppSettings {
+	return &AppSettings{
+		DashboardURL:      "http://127.0.0.1:7500",
+		DashboardUser:     "admin",
+		DashboardPassword: "admin",
+		RefreshInterval:   3,
+		APIPollInterval:   3,
+		TrafficInterval:   5,
+		LogInterval:       1,
+		LogCapacity:       5000,
+		Theme:             "default",
+		Language:          i18n.Chinese,
+		ServerConfigPath:  GetDefaultServerConfigPath(),
+		ClientConfigPath:  GetDefaultClientConfigPath(),
+		BackupKeep:        20,
+		BackupMaxDays:     30,
+		TrafficKeepDays:   7,
+		MonitorInterval:   15,
+		LatencyInterval:   5,
+		LatencyWarnMs:     300,
+		DesktopNotify:     true,
+		AutoRestartServer: true,
+		AutoRestartClient: true,
+		RestartMaxRetries: 5,
+		RestartBackoff:    2,
+		RestartWindow:     300,
+		StopOnExit:        true,
+		ShutdownTimeout:   10,
+		TokenLength:       DefaultTokenLength,
+	}
+}
+
+// GetAppSettingsPath 获取应用设置文件路径
+func GetAppSettingsPath() string {
+	return filepath.Join(GetDefaultWorkDir(), "settings.yaml")
+}
+
+// LoadAppSettings 读取应用设置，文件不存在时返回默认设置，缺失的字段使用默认值
+func LoadAppSettings() (*AppSettings, error) {
+	settings := DefaultAppSettings()
+
+	data, err := os.ReadFile(GetAppSettingsPath())
+	if os.IsNotExist(err) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, i18n.Errorf("读取应用设置失败: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, settings); err != nil {
+		return DefaultAppSettings(), i18n.Errorf("解析应用设置失败: %w", err)
+	}
+	settings.fillDefaults()
+	return settings, nil
+}
+
+// SaveAppSettings 保存应用设置，先写临时文件再重命名以避免写坏
+func SaveAppSettings(settings *AppSettings) error {
+	path := GetAppSettingsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return i18n.Errorf("创建设置目录失败: %w", err)
+	}
+
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return i18n.Errorf("序列化应用设置失败: %w", err)
+	}
+
+	// 文件中包含 Dashboard 密码，仅允许当前用户读写
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return i18n.Errorf("写入应用设置失败: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return i18n.Errorf("替换应用设置失败: %w", err)
+	}
+	return nil
+}
+
+// Validate 校验应用设置
+func (s *AppSettings) Validate() error {
+	parsed, err := url.Parse(s.DashboardURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return i18n.Errorf("无效的 Dashboard 地址: %s", s.DashboardURL)
+	}
+	if s.RefreshInterval < 1 || s.RefreshInterval > 3600 {
+		return i18n.Errorf("进程状态刷新间隔必须在 1-3600 秒之间")
+	}
+	if s.APIPollInterval < 1 || s.APIPollInterval > 3600 {
+		return i18n.Errorf("API 轮询间隔必须在 1-3600 秒之间")
+	}
+	if s.TrafficInterval < 5 || s.TrafficInterval > 3600 {
+		return i18n.Errorf("流量采样间隔必须在 5-3600 秒之间")
+	}
+	if s.LogInterval < 1 || s.LogInterval > 60 {
+		return i18n.Errorf("日志刷新间隔必须在 1-60 秒之间")
+	}
+	if s.LogCapacity < 100 || s.LogCapacity > 100000 {
+		return i18n.Errorf("日志保留条数必须在 100-100000 之间")
+	}
+	if i18n.Normalize(s.Language) == "" {
+		return i18n.Errorf("不支持的语言: %s，可选: %s", s.Language, strings.Join(i18n.Languages(), " / "))
+	}
+	if s.ServerConfigPath == "" || s.ClientConfigPath == "" {
+		return i18n.Errorf("配置文件路径不能为空")
+	}
+	if s.InstallDir != "" && !filepath.IsAbs(s.InstallDir) {
+		return i18n.Errorf("安装目录必须是绝对路径: %s", s.InstallDir)
+	}
+	if s.ReleaseChannel != "" && !slices.Contains(ReleaseChannels, s.ReleaseChannel) {
+		return i18n.Errorf("不支持的更新通道: %s，可选: %s", s.ReleaseChannel, strings.Join(ReleaseChannels, " / "))
+	}
+	for _, version := range []string{s.ServerVersion, s.ClientVersion} {
+		if strings.ContainsAny(version, `/\`) || version == "." || version == ".." {
+			return i18n.Errorf("无效的 FRP 版本: %s", version)
+		}
+	}
+	if s.BackupKeep < 0 || s.BackupMaxDays < 0 {
+		return i18n.Errorf("备份保留数量和天数不能为负数")
+	}
+	if s.TrafficKeepDays < 0 {
+		return i18n.Errorf("流量历史保留天数不能为负数")
+	}
+	if s.MonitorInterval < 0 || s.MonitorInterval > 3600 {
+		return i18n.Errorf("健康检查间隔必须在 0-3600 秒之间")
+	}
+	if s.LatencyInterval < 0 || s.LatencyInterval > 3600 {
+		return i18n.Errorf("延迟采样间隔必须在 0-3600 秒之间")
+	}
+	if s.LatencyWarnMs < 0 {
+		return i18n.Errorf("延迟告警阈值不能为负数")
+	}
+	if s.RestartMaxRetries < 0 || s.RestartBackoff < 0 || s.RestartWindow < 0 {
+		return i18n.Errorf("自动重启次数、退避和窗口不能为负数")
+	}
+	if s.ShutdownTimeout < 1 || s.ShutdownTimeout > 300 {
+		return i18n.Errorf("退出等待时间必须在 1-300 秒之间")
+	}
+	if s.TokenLength < MinTokenLength || s.TokenLength > MaxTokenLength {
+		return i18n.Errorf("令牌长度必须在 %d-%d 之间", MinTokenLength, MaxTokenLength)
+	}
+	if s.AlertWebhookURL != "" {
+		parsed, err := url.Parse(s.AlertWebhookURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return i18n.Errorf("无效的告警 Webhook 地址: %s", s.AlertWebhookURL)
+		}
+	}
+	if err := validateDashboardTargets(s.DashboardTargets, s.ActiveDashboard); err != nil {
+		return err
+	}
+	if err := validateAutostart(s.Autostart); err != nil {
+		return err
+	}
+	for _, webhook := range s.Webhooks {
+		parsed, err := url.Parse(webhook.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return i18n.Errorf("无效的 Webhook 地址: %s", webhook.URL)
+		}
+		if webhook.Template != "" && !slices.Contains(WebhookTemplates, webhook.Template) {
+			return i18n.Errorf("不支持的 Webhook 模板: %s，可选: %s", webhook.Template, strings.Join(WebhookTemplates, " / "))
+		}
+	}
+	if err := validateTrafficQuotas(s.TrafficQuotas); err != nil {
+		return err
+	}
+	if s.ServerHooks.Timeout < 0 || s.ClientHooks.Timeout < 0 {
+		return i18n.Errorf("钩子超时时间不能为负数")
+	}
+	for _, plugin := range s.TabPlugins {
+		if plugin.Name == "" || plugin.Path == "" {
+			return i18n.Errorf("扩展标签页插件必须设置 name 和 path")
+		}
+	}
+	if s.TemplateCatalogURL != "" {
+		parsed, err := url.Parse(s.TemplateCatalogURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return i18n.Errorf("无效的模板目录地址: %s", s.TemplateCatalogURL)
+		}
+	}
+	return nil
+}
+
+// IncludesPrereleases 更新通道是否包含预发布版本
+func (s *AppSettings) IncludesPrereleases() bool {
+	return s.ReleaseChannel == ReleaseChannelPrerelease
+}
+
+// RefreshDuration 返回 frps/frpc 进程状态刷新间隔
+func (s *AppSettings) RefreshDuration() time.Duration {
+	if s.RefreshInterval <= 0 {
+		return 3 * time.Second
+	}
+	return time.Duration(s.RefreshInterval) * time.Second
+}
+
+// APIPollDuration 返回仪表盘轮询 frps API 的间隔
+func (s *AppSettings) APIPollDuration() time.Duration {
+	if s.APIPollInterval <= 0 {
+		return 3 * time.Second
+	}
+	return time.Duration(s.APIPollInterval) * time.Second
+}
+
+// TrafficDuration 返回流量统计采样间隔，采样依赖 API 轮询结果，实际间隔不小于 API 轮询间隔
+func (s *AppSettings) TrafficDuration() time.Duration {
+	if s.TrafficInterval <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(s.TrafficInterval) * time.Second
+}
+
+// LogDuration 返回日志刷新到界面的间隔
+func (s *AppSettings) LogDuration() time.Duration {
+	if s.LogInterval <= 0 {
+		return time.Second
+	}
+	return time.Duration(s.LogInterval) * time.Second
+}
+
+// MonitorDuration 返回健康检查间隔，0 表示关闭
+func (s *AppSettings) MonitorDuration() time.Duration {
+	return time.Duration(s.MonitorInterval) * time.Second
+}
+
+// LatencyDuration 返回延迟采样间隔，0 表示关闭
+func (s *AppSettings) LatencyDuration() time.Duration {
+	return time.Duration(s.LatencyInterval) * time.Second
+}
+
+// LatencyThreshold 返回延迟告警阈值，0 表示不告警
+func (s *AppSettings) LatencyThreshold() time.Duration {
+	return time.Duration(s.LatencyWarnMs) * time.Millisecond
+}
+
+// ShutdownDuration 返回退出时等待进程停止的时长
+func (s *AppSettings) ShutdownDuration() time.Duration {
+	if s.ShutdownTimeout <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(s.ShutdownTimeout) * time.Second
+}
+
+// TrafficRetention 返回流量历史保留时长，0 表示不限。设置了月配额时至少保留一个完整的月周期
+func (s *AppSettings) TrafficRetention() time.Duration {
+	days := s.TrafficKeepDays
+	if days > 0 && slices.ContainsFunc(s.TrafficQuotas, func(q TrafficQuota) bool { return q.Period == QuotaMonthly }) {
+		days = max(days, 32)
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// Redacted 返回去掉密码和 Webhook 令牌的副本，用于写入崩溃报告等需要分享的场合
+func (s *AppSettings) Redacted() *AppSettings {
+	redacted := *s
+	if redacted.DashboardPassword != "" {
+		redacted.DashboardPassword = redactedValue
+	}
+	redacted.DashboardURL = redactURL(s.DashboardURL)
+	redacted.DownloadProxy = redactURL(s.DownloadProxy)
+	redacted.AlertWebhookURL = redactURL(s.AlertWebhookURL)
+	redacted.DashboardTargets = make([]DashboardTarget, len(s.DashboardTargets))
+	for i, target := range s.DashboardTargets {
+		if target.Password != "" {
+			target.Password = redactedValue
+		}
+		if target.Token != "" {
+			target.Token = redactedValue
+		}
+		redacted.DashboardTargets[i] = target
+	}
+	redacted.Webhooks = make([]WebhookConfig, len(s.Webhooks))
+	for i, webhook := range s.Webhooks {
+		webhook.URL = redactURL(webhook.URL)
+		redacted.Webhooks[i] = webhook
+	}
+	return &redacted
+}
+
+// redactedValue 替换敏感内容的占位符
+const redactedValue = "<redacted>"
+
+// redactURL 去掉地址中的用户信息、路径和查询参数，Webhook 的令牌通常放在这些位置
+func redactURL(raw string) string {
+	if raw == "" {
+		return raw
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return redactedValue
+	}
+	redacted := parsed.Scheme + "://" + parsed.Host
+	if parsed.User != nil || (parsed.Path != "" && parsed.Path != "/") || parsed.RawQuery != "" {
+		redacted += "/" + redactedValue
+	}
+	return redacted
+}
+
+// fillDefaults 为缺失的字段填充默认值
+func (s *AppSettings) fillDefaults() {
+	defaults := DefaultAppSettings()
+	if s.DashboardURL == "" {
+		s.DashboardURL = defaults.DashboardURL
+	}
+	if s.RefreshInterval <= 0 {
+		s.RefreshInterval = defaults.RefreshInterval
+	}
+	if s.APIPollInterval <= 0 {
+		s.APIPollInterval = defaults.APIPollInterval
+	}
+	if s.TrafficInterval <= 0 {
+		s.TrafficInterval = defaults.TrafficInterval
+	}
+	if s.LogInterval <= 0 {
+		s.LogInterval = defaults.LogInterval
+	}
+	if s.LogCapacity <= 0 {
+		s.LogCapacity = defaults.LogCapacity
+	}
+	if s.ShutdownTimeout <= 0 {
+		s.ShutdownTimeout = defaults.ShutdownTimeout
+	}
+	if s.TokenLength <= 0 {
+		s.TokenLength = defaults.TokenLength
+	}
+	if s.Theme == "" {
+		s.Theme = defaults.Theme
+	}
+	if s.Language == "" {
+		s.Language = defaults.Language
+	}
+	if s.ServerConfigPath == "" {
+		s.ServerConfigPath = defaults.ServerConfigPath
+	}
+	if s.ClientConfigPath == "" {
+		s.ClientConfigPath = defaults.ClientConfigPath
+	}
+	s.ServerConfigPath = expandHome(s.ServerConfigPath)
+	s.ClientConfigPath = expandHome(s.ClientConfigPath)
+	s.ServerLogFile = expandHome(s.ServerLogFile)
+	s.ClientLogFile = expandHome(s.ClientLogFile)
+	s.InstallDir = expandHome(s.InstallDir)
+	for i := range s.TabPlugins {
+		s.TabPlugins[i].Path = expandHome(s.TabPlugins[i].Path)
+	}
+}
+
+// MaxRecentFiles 最多保留的最近文件数
+const MaxRecentFiles = 10
+
+// AddRecentFile 把文件移到最近文件的最前面，超出 MaxRecentFiles 时丢弃最早的记录
+func (s *AppSettings) AddRecentFile(path string) {
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+
+	recent := []string{path}
+	for _, p := range s.RecentFiles {
+		if p != path && len(recent) < MaxRecentFiles {
+			recent = append(recent, p)
+		}
+	}
+	s.RecentFiles = recent
+}
+
+// expandHome 展开路径开头的 ~
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// ShareCodePrefix 分享码前缀，导入时可省略
+const ShareCodePrefix = "frp://"
+
+// shareCodeVersion 分享码格式版本
+const shareCodeVersion = 1
+
+// SharedConfig 分享码中携带的最小客户端配置：服务端连接信息和一个代理
+type SharedConfig struct {
+	ServerAddr string
+	ServerPort int
+	Token      string
+	Proxy      ProxyConfig
+}
+
+// shareWire 分享码的序列化结构，使用短字段名压缩长度
+type shareWire struct {
+	Version    int      `json:"v"`
+	ServerAddr string   `json:"s"`
+	ServerPort int      `json:"p"`
+	Token      string   `json:"t,omitempty"`
+	Name       string   `json:"n"`
+	Type       string   `json:"y"`
+	LocalIP    string   `json:"li,omitempty"`
+	LocalPort  int      `json:"lp,omitempty"`
+	RemotePort int      `json:"rp,omitempty"`
+	Domains    []string `json:"d,omitempty"`
+	Subdomain  string   `json:"sd,omitempty"`
+	SecretKey  string   `json:"sk,omitempty"`
+}
+
+// NewSharedConfig 从客户端配置中取出服务端连接信息和指定代理
+func NewSharedConfig(cfg *Config, proxyName string) (*SharedConfig, error) {
+	if cfg == nil || cfg.ServerAddr == "" {
+		return nil, i18n.Errorf("客户端配置缺少服务端地址")
+	}
+	for _, proxy := range cfg.Proxies {
+		if proxy.Name == proxyName {
+			return &SharedConfig{
+				ServerAddr: cfg.ServerAddr,
+				ServerPort: cfg.ServerPort,
+				Token:      cfg.Auth.Token,
+				Proxy:      proxy,
+			}, nil
+		}
+	}
+	return nil, i18n.Errorf("代理 '%s' 不存在", proxyName)
+}
+
+// Encode 生成分享码：JSON 经 deflate 压缩后做 URL 安全的 base64 编码
+func (s *SharedConfig) Encode() (string, error) {
+	wire := shareWire{
+		Version:    shareCodeVersion,
+		ServerAddr: s.ServerAddr,
+		ServerPort: s.ServerPort,
+		Token:      s.Token,
+		Name:       s.Proxy.Name,
+		Type:       s.Proxy.Type,
+		LocalIP:    s.Proxy.LocalIP,
+		LocalPort:  s.Proxy.LocalPort,
+		RemotePort: s.Proxy.RemotePort,
+		Domains:    s.Proxy.CustomDomains,
+		Subdomain:  s.Proxy.Subdomain,
+		SecretKey:  s.Proxy.SecretKey,
+	}
+	data, err := json.Marshal(wire)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	fw, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := fw.Write(data); err != nil {
+		return "", err
+	}
+	if err := fw.Close(); err != nil {
+		return "", err
+	}
+
+	return ShareCodePrefix + base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// DecodeShareCode 解析分享码，忽略首尾空白和可选的 frp:// 前缀
+func DecodeShareCode(code string) (*SharedConfig, error) {
+	code = strings.TrimPrefix(strings.TrimSpace(code), ShareCodePrefix)
+	if code == "" {
+		return nil, i18n.Errorf("分享码为空")
+	}
+
+	compressed, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(code, "="))
+	if err != nil {
+		return nil, i18n.Errorf("分享码格式无效: %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(compressed)), 64*1024))
+	if err != nil {
+		return nil, i18n.Errorf("分享码格式无效: %w", err)
+	}
+
+	var wire shareWire
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return nil, i18n.Errorf("分享码格式无效: %w", err)
+	}
+	if wire.Version != shareCodeVersion {
+		return nil, i18n.Errorf("不支持的分享码版本: %d", wire.Version)
+	}
+	if wire.ServerAddr == "" {
+		return nil, i18n.Errorf("分享码缺少服务端地址")
+	}
+	if wire.ServerPort < 1 || wire.ServerPort > 65535 {
+		return nil, i18n.Errorf("分享码中的服务端端口无效: %d", wire.ServerPort)
+	}
+	if wire.Name == "" || wire.Type == "" {
+		return nil, i18n.Errorf("分享码缺少代理名称或类型")
+	}
+
+	return &SharedConfig{
+		ServerAddr: wire.ServerAddr,
+		ServerPort: wire.ServerPort,
+		Token:      wire.Token,
+		Proxy: ProxyConfig{
+			Name:          wire.Name,
+			Type:          wire.Type,
+			LocalIP:       wire.LocalIP,
+			LocalPort:     wire.LocalPort,
+			RemotePort:    wire.RemotePort,
+			CustomDomains: wire.Domains,
+			Subdomain:     wire.Subdomain,
+			SecretKey:     wire.SecretKey,
+		},
+	}, nil
+}
+
+// ApplyTo 将分享的连接信息写入客户端配置，同名代理会被替换，返回是否发生了替换。
+// 分享码不含令牌时保留客户端原有的令牌
+func (s *SharedConfig) ApplyTo(cfg *Config) bool {
+	cfg.ServerAddr = s.ServerAddr
+	cfg.ServerPort = s.ServerPort
+	if s.Token != "" {
+		cfg.Auth.Token = s.Token
+	}
+
+	for i, proxy := range cfg.Proxies {
+		if proxy.Name == s.Proxy.Name {
+			cfg.Proxies[i] = s.Proxy.Clone()
+			return true
+		}
+	}
+	cfg.Proxies = append(cfg.Proxies, s.Proxy.Clone())
+	return false
+}
@@ -0,0 +1,51 @@
+package config
+
+import "testing"
+
+func TestSharedConfigApplyTo(t *testing.T) {
+	tests := []struct {
+		name        string
+		shareToken  string
+		wantToken   string
+		proxyName   string
+		wantReplace bool
+	}{
+		{name: "keeps existing token when share has none", wantToken: "local", proxyName: "web"},
+		{name: "uses shared token", shareToken: "shared", wantToken: "shared", proxyName: "web"},
+		{name: "replaces proxy with same name", wantToken: "local", proxyName: "ssh", wantReplace: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{ServerAddr: "old.example.com", ServerPort: 7000}
+			cfg.Auth.Token = "local"
+			cfg.Proxies = []ProxyConfig{{Name: "ssh", Type: "tcp", LocalPort: 22}}
+
+			shared := &SharedConfig{
+				ServerAddr: "new.example.com",
+				ServerPort: 7001,
+				Token:      tt.shareToken,
+				Proxy:      ProxyConfig{Name: tt.proxyName, Type: "tcp", LocalPort: 8080},
+			}
+			if replaced := shared.ApplyTo(cfg); replaced != tt.wantReplace {
+				t.Errorf("ApplyTo replaced = %v, want %v", replaced, tt.wantReplace)
+			}
+
+			if cfg.Auth.Token != tt.wantToken {
+				t.Errorf("Auth.Token = %q, want %q", cfg.Auth.Token, tt.wantToken)
+			}
+			if cfg.ServerAddr != "new.example.com" || cfg.ServerPort != 7001 {
+				t.Errorf("server = %s:%d, want new.example.com:7001", cfg.ServerAddr, cfg.ServerPort)
+			}
+			wantProxies := 2
+			if tt.wantReplace {
+				wantProxies = 1
+			}
+			if len(cfg.Proxies) != wantProxies {
+				t.Fatalf("%d proxies, want %d", len(cfg.Proxies), wantProxies)
+			}
+			if got := cfg.Proxies[len(cfg.Proxies)-1]; got.Name != tt.proxyName || got.LocalPort != 8080 {
+				t.Errorf("applied proxy = %s:%d, want %s:8080", got.Name, got.LocalPort, tt.proxyName)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// SSHTunnelTypes frps SSH 隧道网关支持的代理类型
+var SSHTunnelTypes = []string{"tcp", "http", "https", "tcpmux", "stcp"}
+
+// DefaultSSHTunnelPort frp 文档中 SSH 隧道网关的示例端口，服务端配置未启用网关时作为默认值
+const DefaultSSHTunnelPort = 2200
+
+// sshSafeArg 不需要加引号的命令行参数
+var sshSafeArg = regexp.MustCompile(`^[\w@%+=:,./-]+$`)
+
+// SSHTunnel 通过 frps SSH 隧道网关创建代理的参数，连接方不需要运行 frpc
+type SSHTunnel struct {
+	ServerAddr string
+	SSHPort    int
+
+	Type      string
+	Name      string
+	LocalIP   string
+	LocalPort int
+
+	RemotePort   int    // tcp，0 表示由 frps 分配
+	CustomDomain string // http/https/tcpmux
+	Subdomain    string // http/https/tcpmux
+	SecretKey    string // stcp
+}
+
+// NewSSHTunnel 按服务端与客户端配置填好连接参数，配置为空时使用默认值
+func NewSSHTunnel(server, client *Config) SSHTunnel {
+	tunnel := SSHTunnel{Type: "tcp", LocalIP: "127.0.0.1", SSHPort: DefaultSSHTunnelPort}
+	if server != nil && server.SSHTunnelGateway.BindPort > 0 {
+		tunnel.SSHPort = server.SSHTunnelGateway.BindPort
+	}
+	if client != nil {
+		tunnel.ServerAddr = client.ServerAddr
+	}
+	return tunnel
+}
+
+// Validate 检查生成命令所需的参数
+func (t SSHTunnel) Validate() error {
+	if !slices.Contains(SSHTunnelTypes, t.Type) {
+		return i18n.Errorf("SSH 隧道网关不支持 %s 代理", t.Type)
+	}
+	if strings.TrimSpace(t.ServerAddr) == "" {
+		return i18n.Errorf("服务器地址不能为空")
+	}
+	if t.SSHPort < 1 || t.SSHPort > 65535 {
+		return i18n.Errorf("SSH 隧道网关端口必须在 1-65535 范围内")
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		return i18n.Errorf("代理名称不能为空")
+	}
+	if t.LocalPort < 1 || t.LocalPort > 65535 {
+		return i18n.Errorf("本地端口必须在 1-65535 范围内")
+	}
+
+	switch t.Type {
+	case "tcp":
+		if t.RemotePort < 0 || t.RemotePort > 65535 {
+			return i18n.Errorf("远程端口必须在 0-65535 范围内")
+		}
+	case "http", "https", "tcpmux":
+		if t.CustomDomain == "" && t.Subdomain == "" {
+			return i18n.Errorf("%s 代理需要自定义域名或子域名", t.Type)
+		}
+	case "stcp":
+		if t.SecretKey == "" {
+			return i18n.Errorf("stcp 代理需要 secretKey")
+		}
+	}
+	return nil
+}
+
+// Command 生成 ssh -R 命令行。-R 中的远程端口只是占位，实际监听端口和域名由命令后面的参数决定
+func (t SSHTunnel) Command() (string, error) {
+	if err := t.Validate(); err != nil {
+		return "", err
+	}
+
+	localIP := t.LocalIP
+	if localIP == "" {
+		localIP = "127.0.0.1"
+	}
+	if strings.Contains(localIP, ":") {
+		localIP = "[" + localIP + "]"
+	}
+
+	args := []string{
+		"ssh", "-R", fmt.Sprintf(":80:%s:%d", localIP, t.LocalPort),
+		"v0@" + t.ServerAddr, "-p", strconv.Itoa(t.SSHPort),
+		t.Type, "--proxy_name", t.Name,
+	}
+	switch t.Type {
+	case "tcp":
+		if t.RemotePort > 0 {
+			args = append(args, "--remote_port", strconv.Itoa(t.RemotePort))
+		}
+	case "http", "https", "tcpmux":
+		if t.CustomDomain != "" {
+			args = append(args, "--custom_domain", t.CustomDomain)
+		}
+		if t.Subdomain != "" {
+			args = append(args, "--sd", t.Subdomain)
+		}
+	case "stcp":
+		args = append(args, "--sk", t.SecretKey)
+	}
+
+	for i, arg := range args {
+		if !sshSafeArg.MatchString(arg) {
+			args[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(args, " "), nil
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// catalogCacheTTL 模板目录缓存有效期，过期前不重复请求网络
+const catalogCacheTTL = time.Hour
+
+// catalogMaxSize 模板目录最大字节数
+const catalogMaxSize = 4 << 20
+
+// TemplateCatalog 在线模板目录，YAML 或 JSON 格式
+type TemplateCatalog struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description,omitempty"`
+	Templates   []*ConfigTemplate `yaml:"templates"`
+}
+
+// CatalogResult 模板目录获取结果
+type CatalogResult struct {
+	Catalog   *TemplateCatalog
+	FromCache bool      // 是否使用了本地缓存
+	CachedAt  time.Time // 缓存写入时间
+	FetchErr  error     // 网络获取失败的原因，使用离线缓存时不为空
+	Skipped   int       // 格式不完整而被忽略的模板数量
+}
+
+// GetCatalogCacheDir 获取模板目录缓存目录
+func GetCatalogCacheDir() string {
+	return filepath.Join(GetDefaultWorkDir(), "cache", "catalogs")
+}
+
+// catalogCachePath 返回目录地址对应的缓存文件
+func catalogCachePath(catalogURL string) string {
+	sum := sha1.Sum([]byte(catalogURL))
+	return filepath.Join(GetCatalogCacheDir(), hex.EncodeToString(sum[:8])+".yaml")
+}
+
+// FetchTemplateCatalog 获取在线模板目录。缓存未过期且未强制刷新时直接使用缓存，
+// 网络不可用时回退到过期的缓存
+func FetchTemplateCatalog(catalogURL string, forceRefresh bool) (*CatalogResult, error) {
+	if catalogURL == "" {
+		return nil, i18n.Errorf("未设置模板目录地址")
+	}
+
+	cachePath := catalogCachePath(catalogURL)
+	cacheInfo, cacheErr := os.Stat(cachePath)
+
+	if !forceRefresh && cacheErr == nil && time.Since(cacheInfo.ModTime()) < catalogCacheTTL {
+		if result, err := loadCatalogFile(cachePath); err == nil {
+			result.FromCache = true
+			result.CachedAt = cacheInfo.ModTime()
+			return result, nil
+		}
+	}
+
+	data, fetchErr := downloadCatalog(catalogURL)
+	if fetchErr == nil {
+		result, err := parseCatalog(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(GetCatalogCacheDir(), 0755); err == nil {
+			_ = os.WriteFile(cachePath, data, 0644)
+		}
+		return result, nil
+	}
+
+	// 离线回退
+	if cacheErr == nil {
+		if result, err := loadCatalogFile(cachePath); err == nil {
+			result.FromCache = true
+			result.CachedAt = cacheInfo.ModTime()
+			result.FetchErr = fetchErr
+			return result, nil
+		}
+	}
+	return nil, fetchErr
+}
+
+// downloadCatalog 下载模板目录
+func downloadCatalog(catalogURL string) ([]byte, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Get(catalogURL)
+	if err != nil {
+		return nil, i18n.Errorf("获取模板目录失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, i18n.Errorf("获取模板目录失败，HTTP状态码: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, catalogMaxSize+1))
+	if err != nil {
+		return nil, i18n.Errorf("读取模板目录失败: %w", err)
+	}
+	if len(data) > catalogMaxSize {
+		return nil, i18n.Errorf("模板目录超过 %d MB 限制", catalogMaxSize>>20)
+	}
+	return data, nil
+}
+
+// loadCatalogFile 读取缓存的模板目录
+func loadCatalogFile(path string) (*CatalogResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取模板目录缓存失败: %w", err)
+	}
+	return parseCatalog(data)
+}
+
+// parseCatalog 解析模板目录并过滤格式不完整的模板
+func parseCatalog(data []byte) (*CatalogResult, error) {
+	var catalog TemplateCatalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, i18n.Errorf("解析模板目录失败: %w", err)
+	}
+
+	result := &CatalogResult{Catalog: &catalog}
+	valid := catalog.Templates[:0]
+	for _, template := range catalog.Templates {
+		if template == nil || template.Name == "" || template.Config == nil ||
+			(template.Type != "server" && template.Type != "client") {
+			result.Skipped++
+			continue
+		}
+		valid = append(valid, template)
+	}
+	catalog.Templates = valid
+
+	if len(catalog.Templates) == 0 {
+		return nil, i18n.Errorf("模板目录中没有可用的模板")
+	}
+	return result, nil
+}
+
+// ImportCatalogTemplate 将目录中的模板导入为用户模板，重名时自动追加序号，返回最终名称
+func (tm *TemplateManager) ImportCatalogTemplate(template *ConfigTemplate) (string, error) {
+	name := template.Name
+	for i := 2; ; i++ {
+		if _, exists := tm.templates[name]; !exists {
+			break
+		}
+		name = fmt.Sprintf("%s-%d", template.Name, i)
+	}
+
+	imported := &ConfigTemplate{
+		Name:        name,
+		Description: template.Description,
+		Type:        template.Type,
+		Config:      template.Config.Clone(),
+		CreatedAt:   time.Now(),
+	}
+	if err := tm.AddTemplate(imported); err != nil {
+		return "", err
+	}
+	return name, nil
+}
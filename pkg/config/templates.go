@@ -1,43 +1,136 @@
 package config
 
 import (
-	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"frp-cli-ui/pkg/i18n"
 )
 
 // ConfigTemplate 配置模板
 type ConfigTemplate struct {
-	Name        string
-	Description string
-	Type        string // "server" or "client"
-	Config      *Config
-	CreatedAt   time.Time
+	Name        string    `yaml:"name"`
+	Description string    `yaml:"description,omitempty"`
+	Type        string    `yaml:"type"` // "server" or "client"
+	Config      *Config   `yaml:"config"`
+	CreatedAt   time.Time `yaml:"createdAt"`
+	Builtin     bool      `yaml:"-"` // 内置模板不可修改或删除
 }
 
 // TemplateManager 模板管理器
 type TemplateManager struct {
 	templates map[string]*ConfigTemplate
+	dir       string
+}
+
+// GetTemplateDir 获取用户模板目录
+func GetTemplateDir() string {
+	return filepath.Join(GetDefaultWorkDir(), "templates")
 }
 
-// NewTemplateManager 创建新的模板管理器
+// NewTemplateManager 创建新的模板管理器，并加载用户模板目录中的模板
 func NewTemplateManager() *TemplateManager {
 	tm := &TemplateManager{
 		templates: make(map[string]*ConfigTemplate),
+		dir:       GetTemplateDir(),
 	}
 
+	_ = tm.Reload()
+	return tm
+}
+
+// Reload 重新加载内置模板和用户模板，单个模板文件损坏不影响其他模板
+func (tm *TemplateManager) Reload() error {
+	tm.templates = make(map[string]*ConfigTemplate)
 	for _, template := range getBuiltinTemplates() {
+		template.Builtin = true
 		tm.templates[template.Name] = template
 	}
 
-	return tm
+	matches, err := filepath.Glob(filepath.Join(tm.dir, "*.yaml"))
+	if err != nil {
+		return i18n.Errorf("查找用户模板失败: %w", err)
+	}
+
+	var failed []string
+	for _, path := range matches {
+		template, err := loadTemplateFile(path)
+		if err != nil {
+			failed = append(failed, filepath.Base(path))
+			continue
+		}
+		// 与内置模板重名时保留内置模板
+		if existing, ok := tm.templates[template.Name]; ok && existing.Builtin {
+			failed = append(failed, filepath.Base(path))
+			continue
+		}
+		tm.templates[template.Name] = template
+	}
+
+	if len(failed) > 0 {
+		return i18n.Errorf("以下模板文件无法加载: %s", strings.Join(failed, ", "))
+	}
+	return nil
 }
 
-// GetTemplates 获取所有模板
+// loadTemplateFile 读取单个模板文件
+func loadTemplateFile(path string) (*ConfigTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取模板文件失败: %w", err)
+	}
+
+	var template ConfigTemplate
+	if err := yaml.Unmarshal(data, &template); err != nil {
+		return nil, i18n.Errorf("解析模板文件失败: %w", err)
+	}
+	if template.Name == "" || template.Config == nil {
+		return nil, i18n.Errorf("模板文件不完整: %s", path)
+	}
+	template.Config.migrateLegacyFields()
+	return &template, nil
+}
+
+// templatePath 返回用户模板的文件路径，名称中不能用于文件名的字符替换为下划线
+func (tm *TemplateManager) templatePath(name string) string {
+	fileName := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < 32 {
+			return '_'
+		}
+		return r
+	}, name)
+	return filepath.Join(tm.dir, fileName+".yaml")
+}
+
+// writeTemplate 将用户模板写入模板目录
+func (tm *TemplateManager) writeTemplate(template *ConfigTemplate) error {
+	if err := os.MkdirAll(tm.dir, 0755); err != nil {
+		return i18n.Errorf("创建模板目录失败: %w", err)
+	}
+
+	data, err := yaml.Marshal(template)
+	if err != nil {
+		return i18n.Errorf("序列化模板失败: %w", err)
+	}
+
+	if err := os.WriteFile(tm.templatePath(template.Name), data, 0644); err != nil {
+		return i18n.Errorf("写入模板文件失败: %w", err)
+	}
+	return nil
+}
+
+// GetTemplates 获取所有模板，内置模板在前，其余按名称排序
 func (tm *TemplateManager) GetTemplates() []*ConfigTemplate {
 	templates := make([]*ConfigTemplate, 0, len(tm.templates))
 	for _, template := range tm.templates {
 		templates = append(templates, template)
 	}
+	sortTemplates(templates)
 	return templates
 }
 
@@ -49,24 +142,48 @@ func (tm *TemplateManager) GetTemplatesByType(configType string) []*ConfigTempla
 			templates = append(templates, template)
 		}
 	}
+	sortTemplates(templates)
 	return templates
 }
 
+// sortTemplates 排序模板列表
+func sortTemplates(templates []*ConfigTemplate) {
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Builtin != templates[j].Builtin {
+			return templates[i].Builtin
+		}
+		if templates[i].Type != templates[j].Type {
+			return templates[i].Type > templates[j].Type
+		}
+		return templates[i].Name < templates[j].Name
+	})
+}
+
 // GetTemplate 根据名称获取模板
 func (tm *TemplateManager) GetTemplate(name string) (*ConfigTemplate, error) {
 	template, exists := tm.templates[name]
 	if !exists {
-		return nil, fmt.Errorf("模板不存在: %s", name)
+		return nil, i18n.Errorf("模板不存在: %s", name)
 	}
 	return template, nil
 }
 
-// AddTemplate 添加自定义模板
+// AddTemplate 添加自定义模板并保存到模板目录
 func (tm *TemplateManager) AddTemplate(template *ConfigTemplate) error {
-	if template.Name == "" {
-		return fmt.Errorf("模板名称不能为空")
+	name := strings.TrimSpace(template.Name)
+	if name == "" {
+		return i18n.Errorf("模板名称不能为空")
+	}
+	if existing, ok := tm.templates[name]; ok && existing.Builtin {
+		return i18n.Errorf("不能覆盖内置模板: %s", name)
 	}
-	tm.templates[template.Name] = template
+
+	template.Name = name
+	template.Builtin = false
+	if err := tm.writeTemplate(template); err != nil {
+		return err
+	}
+	tm.templates[name] = template
 	return nil
 }
 
@@ -74,8 +191,8 @@ func (tm *TemplateManager) AddTemplate(template *ConfigTemplate) error {
 func getBuiltinTemplates() []*ConfigTemplate {
 	return []*ConfigTemplate{
 		{
-			Name:        "基础服务端",
-			Description: "基本的FRP服务端配置",
+			Name:        i18n.T("基础服务端"),
+			Description: i18n.T("基本的FRP服务端配置"),
 			Type:        "server",
 			Config: &Config{
 				BindPort: 7000,
@@ -92,12 +209,12 @@ func getBuiltinTemplates() []*ConfigTemplate {
 			CreatedAt: time.Now(),
 		},
 		{
-			Name:        "安全服务端",
-			Description: "带认证的安全服务端配置",
+			Name:        i18n.T("安全服务端"),
+			Description: i18n.T("带认证的安全服务端配置"),
 			Type:        "server",
 			Config: &Config{
 				BindPort: 7000,
-				Token:    "your_token_here",
+				Auth:     AuthConfig{Token: "your_token_here"},
 				WebServer: WebServerConfig{
 					Port:     7500,
 					User:     "admin",
@@ -111,8 +228,8 @@ func getBuiltinTemplates() []*ConfigTemplate {
 			CreatedAt: time.Now(),
 		},
 		{
-			Name:        "SSH隧道客户端",
-			Description: "SSH端口转发客户端配置",
+			Name:        i18n.T("SSH隧道客户端"),
+			Description: i18n.T("SSH端口转发客户端配置"),
 			Type:        "client",
 			Config: &Config{
 				ServerAddr: "your_server_ip",
@@ -134,8 +251,8 @@ func getBuiltinTemplates() []*ConfigTemplate {
 			CreatedAt: time.Now(),
 		},
 		{
-			Name:        "Web服务客户端",
-			Description: "HTTP/HTTPS服务客户端配置",
+			Name:        i18n.T("Web服务客户端"),
+			Description: i18n.T("HTTP/HTTPS服务客户端配置"),
 			Type:        "client",
 			Config: &Config{
 				ServerAddr: "your_server_ip",
@@ -157,8 +274,8 @@ func getBuiltinTemplates() []*ConfigTemplate {
 			CreatedAt: time.Now(),
 		},
 		{
-			Name:        "远程桌面客户端",
-			Description: "RDP/VNC远程桌面客户端配置",
+			Name:        i18n.T("远程桌面客户端"),
+			Description: i18n.T("RDP/VNC远程桌面客户端配置"),
 			Type:        "client",
 			Config: &Config{
 				ServerAddr: "your_server_ip",
@@ -180,8 +297,8 @@ func getBuiltinTemplates() []*ConfigTemplate {
 			CreatedAt: time.Now(),
 		},
 		{
-			Name:        "数据库客户端",
-			Description: "数据库端口转发客户端配置",
+			Name:        i18n.T("数据库客户端"),
+			Description: i18n.T("数据库端口转发客户端配置"),
 			Type:        "client",
 			Config: &Config{
 				ServerAddr: "your_server_ip",
@@ -210,8 +327,8 @@ func getBuiltinTemplates() []*ConfigTemplate {
 			CreatedAt: time.Now(),
 		},
 		{
-			Name:        "游戏服务器客户端",
-			Description: "游戏服务器端口转发配置",
+			Name:        i18n.T("游戏服务器客户端"),
+			Description: i18n.T("游戏服务器端口转发配置"),
 			Type:        "client",
 			Config: &Config{
 				ServerAddr: "your_server_ip",
@@ -240,13 +357,13 @@ func getBuiltinTemplates() []*ConfigTemplate {
 			CreatedAt: time.Now(),
 		},
 		{
-			Name:        "安全内网穿透",
-			Description: "使用STCP的安全内网穿透配置",
+			Name:        i18n.T("安全内网穿透"),
+			Description: i18n.T("使用STCP的安全内网穿透配置"),
 			Type:        "client",
 			Config: &Config{
 				ServerAddr: "your_server_ip",
 				ServerPort: 7000,
-				Token:      "your_token_here",
+				Auth:       AuthConfig{Token: "your_token_here"},
 				Log: LogConfig{
 					To:    "console",
 					Level: "info",
@@ -283,20 +400,10 @@ func (tm *TemplateManager) ApplyTemplate(templateName string) (*Config, error) {
 		return nil, err
 	}
 
-	config := *template.Config
-
-	proxies := make([]ProxyConfig, len(template.Config.Proxies))
-	copy(proxies, template.Config.Proxies)
-	config.Proxies = proxies
-
-	visitors := make([]VisitorConfig, len(template.Config.Visitors))
-	copy(visitors, template.Config.Visitors)
-	config.Visitors = visitors
-
-	return &config, nil
+	return template.Config.Clone(), nil
 }
 
-// MergeTemplate 合并模板到现有配置
+// MergeTemplate 合并模板到现有配置，已有的字段和同名代理/访问者保持不变
 func (tm *TemplateManager) MergeTemplate(target *Config, templateName string) (*Config, error) {
 	template, err := tm.GetTemplate(templateName)
 	if err != nil {
@@ -307,7 +414,7 @@ func (tm *TemplateManager) MergeTemplate(target *Config, templateName string) (*
 		return tm.ApplyTemplate(templateName)
 	}
 
-	merged := *target
+	merged := target.Clone()
 
 	if merged.ServerAddr == "" && template.Config.ServerAddr != "" {
 		merged.ServerAddr = template.Config.ServerAddr
@@ -315,12 +422,24 @@ func (tm *TemplateManager) MergeTemplate(target *Config, templateName string) (*
 	if merged.ServerPort == 0 && template.Config.ServerPort != 0 {
 		merged.ServerPort = template.Config.ServerPort
 	}
-	if merged.Token == "" && template.Config.Token != "" {
-		merged.Token = template.Config.Token
+	if merged.Auth.Token == "" && template.Config.Auth.Token != "" {
+		merged.Auth.Token = template.Config.Auth.Token
 	}
 	if merged.BindPort == 0 && template.Config.BindPort != 0 {
 		merged.BindPort = template.Config.BindPort
 	}
+	if len(merged.AllowPorts) == 0 && len(template.Config.AllowPorts) > 0 {
+		merged.AllowPorts = append([]PortRange(nil), template.Config.AllowPorts...)
+	}
+	if merged.VhostHTTPPort == 0 && template.Config.VhostHTTPPort != 0 {
+		merged.VhostHTTPPort = template.Config.VhostHTTPPort
+	}
+	if merged.VhostHTTPSPort == 0 && template.Config.VhostHTTPSPort != 0 {
+		merged.VhostHTTPSPort = template.Config.VhostHTTPSPort
+	}
+	if merged.SubDomainHost == "" && template.Config.SubDomainHost != "" {
+		merged.SubDomainHost = template.Config.SubDomainHost
+	}
 
 	if merged.WebServer.Port == 0 && template.Config.WebServer.Port != 0 {
 		merged.WebServer = template.Config.WebServer
@@ -337,7 +456,7 @@ func (tm *TemplateManager) MergeTemplate(target *Config, templateName string) (*
 
 	for _, proxy := range template.Config.Proxies {
 		if !proxyNames[proxy.Name] {
-			merged.Proxies = append(merged.Proxies, proxy)
+			merged.Proxies = append(merged.Proxies, proxy.Clone())
 		}
 	}
 
@@ -352,33 +471,76 @@ func (tm *TemplateManager) MergeTemplate(target *Config, templateName string) (*
 		}
 	}
 
-	return &merged, nil
+	return merged, nil
 }
 
 // SaveTemplate 保存当前配置为模板
 func (tm *TemplateManager) SaveTemplate(name, description, configType string, config *Config) error {
 	if name == "" {
-		return fmt.Errorf("模板名称不能为空")
+		return i18n.Errorf("模板名称不能为空")
 	}
 	if config == nil {
-		return fmt.Errorf("配置不能为空")
+		return i18n.Errorf("配置不能为空")
 	}
 
 	template := &ConfigTemplate{
 		Name:        name,
 		Description: description,
 		Type:        configType,
-		Config:      config,
+		Config:      config.Clone(),
 		CreatedAt:   time.Now(),
 	}
 
 	return tm.AddTemplate(template)
 }
 
-// DeleteTemplate 删除模板
+// RenameTemplate 重命名用户模板
+func (tm *TemplateManager) RenameTemplate(oldName, newName string) error {
+	template, err := tm.GetTemplate(oldName)
+	if err != nil {
+		return err
+	}
+	if template.Builtin {
+		return i18n.Errorf("不能重命名内置模板: %s", oldName)
+	}
+
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return i18n.Errorf("模板名称不能为空")
+	}
+	if newName == oldName {
+		return nil
+	}
+	if _, exists := tm.templates[newName]; exists {
+		return i18n.Errorf("模板已存在: %s", newName)
+	}
+
+	renamed := *template
+	renamed.Name = newName
+	if err := tm.writeTemplate(&renamed); err != nil {
+		return err
+	}
+	if err := os.Remove(tm.templatePath(oldName)); err != nil && !os.IsNotExist(err) {
+		return i18n.Errorf("删除旧模板文件失败: %w", err)
+	}
+
+	delete(tm.templates, oldName)
+	tm.templates[newName] = &renamed
+	return nil
+}
+
+// DeleteTemplate 删除用户模板及其文件
 func (tm *TemplateManager) DeleteTemplate(name string) error {
-	if _, exists := tm.templates[name]; !exists {
-		return fmt.Errorf("模板不存在: %s", name)
+	template, exists := tm.templates[name]
+	if !exists {
+		return i18n.Errorf("模板不存在: %s", name)
+	}
+	if template.Builtin {
+		return i18n.Errorf("不能删除内置模板: %s", name)
+	}
+
+	if err := os.Remove(tm.templatePath(name)); err != nil && !os.IsNotExist(err) {
+		return i18n.Errorf("删除模板文件失败: %w", err)
 	}
 	delete(tm.templates, name)
 	return nil
@@ -403,7 +565,12 @@ func (tm *TemplateManager) ImportTemplate(name, description, filePath string) er
 		return err
 	}
 
-	configType := detectConfigType(config)
+	// 未填写描述时使用导出文件头部记录的来源
+	if meta := ReadFileMeta(filePath); description == "" && meta != nil {
+		description = meta.Summary()
+	}
+
+	configType := DetectConfigType(config)
 	return tm.SaveTemplate(name, description, configType, config)
 }
 
@@ -433,8 +600,36 @@ log.level = "info"
 # 允许的端口范围 (可选)
 # allowPorts = [
 #   { start = 2000, end = 3000 },
-#   { start = 3001, end = 3500 }
+#   { single = 3001 }
 # ]
+
+# 每个客户端最多可用的端口数 (可选，0 表示不限)
+# maxPortsPerClient = 0
+
+# HTTP/HTTPS 虚拟主机端口 (可选)
+# vhostHTTPPort = 80
+# vhostHTTPSPort = 443
+
+# HTTP 代理等待响应头的超时，单位秒 (可选，默认 60)
+# vhostHTTPTimeout = 60
+
+# 找不到对应 HTTP 代理时返回的页面 (可选)
+# custom404Page = "/etc/frp/404.html"
+
+# 子域名的主域名 (可选)
+# subDomainHost = "frps.example.com"
+
+# tcpmux 的 HTTP CONNECT 端口 (可选)
+# tcpmuxHTTPConnectPort = 1337
+# tcpmuxPassthrough = false
+
+# SSH 隧道网关 (可选，frp v0.53+)，未运行 frpc 的机器可用 ssh -R 创建代理
+# sshTunnelGateway.bindPort = 2200
+# sshTunnelGateway.autoGenPrivateKeyPath = "./.autogen_ssh_key"
+# sshTunnelGateway.authorizedKeysFile = "/home/frp/.ssh/authorized_keys"
+
+# 心跳超时，单位秒 (可选，-1 表示关闭)
+# transport.heartbeatTimeout = 90
 `
 
 // DefaultClientConfigTemplate 默认客户端配置模板
@@ -454,6 +649,19 @@ serverPort = 7000
 log.to = "console"
 log.level = "info"
 
+# 传输配置 (可选)
+# transport.protocol = "tcp"            # tcp / kcp / quic / websocket / wss
+# transport.poolCount = 5
+# transport.dialServerTimeout = 10
+# transport.proxyURL = "socks5://127.0.0.1:1080"
+
+# TLS 配置 (可选，默认开启)
+# transport.tls.enable = true
+# transport.tls.certFile = "/etc/frp/client.crt"
+# transport.tls.keyFile = "/etc/frp/client.key"
+# transport.tls.trustedCaFile = "/etc/frp/ca.crt"
+# transport.tls.serverName = "frps.example.com"
+
 # 代理配置示例
 # [[proxies]]
 # name = "web"
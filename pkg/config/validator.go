@@ -1,14 +1,20 @@
 package config
 
 import (
-	"fmt"
 	"net"
+	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+
+	"frp-cli-ui/pkg/i18n"
 )
 
 // Validator 配置验证器
-type Validator struct{}
+type Validator struct {
+	liveCheck bool // 是否实时探测本机端口占用
+}
 
 // NewValidator 创建新的验证器
 func NewValidator() *Validator {
@@ -18,27 +24,27 @@ func NewValidator() *Validator {
 // ValidateConfig 验证完整配置
 func (v *Validator) ValidateConfig(config *Config) error {
 	if config == nil {
-		return fmt.Errorf("配置不能为空")
+		return i18n.Errorf("配置不能为空")
 	}
 
 	// 验证服务端配置
 	if err := v.validateServerConfig(config); err != nil {
-		return fmt.Errorf("服务端配置错误: %w", err)
+		return i18n.Errorf("服务端配置错误: %w", err)
 	}
 
 	// 验证客户端配置
 	if err := v.validateClientConfig(config); err != nil {
-		return fmt.Errorf("客户端配置错误: %w", err)
+		return i18n.Errorf("客户端配置错误: %w", err)
 	}
 
 	// 验证代理配置
 	if err := v.validateProxies(config.Proxies); err != nil {
-		return fmt.Errorf("代理配置错误: %w", err)
+		return i18n.Errorf("代理配置错误: %w", err)
 	}
 
 	// 验证访问者配置
 	if err := v.validateVisitors(config.Visitors); err != nil {
-		return fmt.Errorf("访问者配置错误: %w", err)
+		return i18n.Errorf("访问者配置错误: %w", err)
 	}
 
 	return nil
@@ -46,107 +52,186 @@ func (v *Validator) ValidateConfig(config *Config) error {
 
 // ValidateConfigDetailed 详细验证配置，返回所有错误
 func (v *Validator) ValidateConfigDetailed(config *Config) []string {
+	return issueMessages(v.ValidateConfigIssues(config))
+}
+
+// ValidateConfigIssues 详细验证配置，返回所有错误及其字段路径，路径格式与 CheckSchema 一致
+func (v *Validator) ValidateConfigIssues(config *Config) []FieldIssue {
 	if config == nil {
-		return []string{"配置不能为空"}
+		return []FieldIssue{{Message: i18n.T("配置不能为空")}}
 	}
 
-	var errors []string
-	errors = append(errors, v.validateServerConfigDetailed(config)...)
-	errors = append(errors, v.validateClientConfigDetailed(config)...)
-	errors = append(errors, v.validateProxiesDetailed(config.Proxies)...)
-	errors = append(errors, v.validateVisitorsDetailed(config.Visitors)...)
+	var issues []FieldIssue
+	issues = append(issues, v.validateServerConfigDetailed(config)...)
+	issues = append(issues, v.validateClientConfigDetailed(config)...)
+	issues = append(issues, v.validateProxiesDetailed(config.Proxies)...)
+	issues = append(issues, v.validateVisitorsDetailed(config.Visitors)...)
 
-	return errors
+	return issues
 }
 
 // validateServerConfig 验证服务端配置
 func (v *Validator) validateServerConfig(config *Config) error {
 	ports := map[string]int{
-		"绑定端口":     config.BindPort,
-		"UDP端口":    config.BindUDPPort,
-		"KCP端口":    config.KCPBindPort,
-		"Web服务器端口": config.WebServer.Port,
+		i18n.T("绑定端口"):                   config.BindPort,
+		i18n.T("UDP端口"):                  config.BindUDPPort,
+		i18n.T("KCP端口"):                  config.KCPBindPort,
+		i18n.T("Web服务器端口"):               config.WebServer.Port,
+		i18n.T("HTTP虚拟主机端口"):             config.VhostHTTPPort,
+		i18n.T("HTTPS虚拟主机端口"):            config.VhostHTTPSPort,
+		i18n.T("tcpmux HTTP CONNECT 端口"): config.TCPMuxHTTPConnectPort,
+		i18n.T("SSH 隧道网关端口"):             config.SSHTunnelGateway.BindPort,
 	}
 
 	for name, port := range ports {
 		if port != 0 {
 			if err := v.validatePort(port); err != nil {
-				return fmt.Errorf("%s无效: %w", name, err)
+				return i18n.Errorf("%s无效: %w", name, err)
 			}
 		}
 	}
 
 	if config.WebServer.Addr != "" {
 		if err := v.validateAddress(config.WebServer.Addr); err != nil {
-			return fmt.Errorf("Web服务器地址无效: %w", err)
+			return i18n.Errorf("Web服务器地址无效: %w", err)
 		}
 	}
 
-	return nil
-}
+	if err := ValidatePortRanges(config.AllowPorts); err != nil {
+		return i18n.Errorf("允许端口范围无效: %w", err)
+	}
 
-// validateServerConfigDetailed 详细验证服务端配置
-func (v *Validator) validateServerConfigDetailed(config *Config) []string {
-	var errors []string
+	if config.MaxPortsPerClient < 0 {
+		return i18n.Errorf("每个客户端的最大端口数不能为负数")
+	}
 
-	ports := map[string]int{
-		"绑定端口":     config.BindPort,
-		"UDP端口":    config.BindUDPPort,
-		"KCP端口":    config.KCPBindPort,
-		"Web服务器端口": config.WebServer.Port,
+	if config.SubDomainHost != "" {
+		if err := v.validateDomain(config.SubDomainHost); err != nil {
+			return i18n.Errorf("子域名主域名无效: %w", err)
+		}
 	}
 
-	for name, port := range ports {
-		if port != 0 {
-			if err := v.validatePort(port); err != nil {
-				errors = append(errors, fmt.Sprintf("%s无效: %v", name, err))
+	if config.VhostHTTPTimeout < 0 {
+		return i18n.Errorf("HTTP 响应超时不能为负数")
+	}
+
+	if err := v.validateTransport(config.Transport); err != nil {
+		return i18n.Errorf("心跳配置无效: %w", err)
+	}
+
+	return nil
+}
+
+// validateServerConfigDetailed 详细验证服务端配置
+func (v *Validator) validateServerConfigDetailed(config *Config) []FieldIssue {
+	var issues []FieldIssue
+	add := func(field, message string) {
+		issues = append(issues, FieldIssue{Field: field, Message: message})
+	}
+
+	ports := []struct {
+		field string
+		name  string
+		port  int
+	}{
+		{"bindPort", i18n.T("绑定端口"), config.BindPort},
+		{"bindUDPPort", i18n.T("UDP端口"), config.BindUDPPort},
+		{"kcpBindPort", i18n.T("KCP端口"), config.KCPBindPort},
+		{"webServer.port", i18n.T("Web服务器端口"), config.WebServer.Port},
+		{"vhostHTTPPort", i18n.T("HTTP虚拟主机端口"), config.VhostHTTPPort},
+		{"vhostHTTPSPort", i18n.T("HTTPS虚拟主机端口"), config.VhostHTTPSPort},
+		{"tcpmuxHTTPConnectPort", i18n.T("tcpmux HTTP CONNECT 端口"), config.TCPMuxHTTPConnectPort},
+		{"sshTunnelGateway.bindPort", i18n.T("SSH 隧道网关端口"), config.SSHTunnelGateway.BindPort},
+	}
+
+	for _, p := range ports {
+		if p.port != 0 {
+			if err := v.validatePort(p.port); err != nil {
+				add(p.field, i18n.Sprintf("%s无效: %v", p.name, err))
 			}
 		}
 	}
 
 	if config.WebServer.Addr != "" {
 		if err := v.validateAddress(config.WebServer.Addr); err != nil {
-			errors = append(errors, fmt.Sprintf("Web服务器地址无效: %v", err))
+			add("webServer.addr", i18n.Sprintf("Web服务器地址无效: %v", err))
+		}
+	}
+
+	if err := ValidatePortRanges(config.AllowPorts); err != nil {
+		add("allowPorts", i18n.Sprintf("允许端口范围无效: %v", err))
+	}
+
+	if config.MaxPortsPerClient < 0 {
+		add("maxPortsPerClient", i18n.T("每个客户端的最大端口数不能为负数"))
+	}
+
+	if config.SubDomainHost != "" {
+		if err := v.validateDomain(config.SubDomainHost); err != nil {
+			add("subDomainHost", i18n.Sprintf("子域名主域名无效: %v", err))
 		}
 	}
 
-	return errors
+	if config.VhostHTTPTimeout < 0 {
+		add("vhostHTTPTimeout", i18n.T("HTTP 响应超时不能为负数"))
+	}
+
+	// 相对路径以 frps 的工作目录为准，无法判断，只在实时检查时确认绝对路径存在
+	if v.liveCheck && filepath.IsAbs(config.Custom404Page) {
+		if _, err := os.Stat(config.Custom404Page); err != nil {
+			add("custom404Page", i18n.Sprintf("自定义 404 页面不可用: %v", err))
+		}
+	}
+
+	if err := v.validateTransport(config.Transport); err != nil {
+		add("transport", i18n.Sprintf("心跳配置无效: %v", err))
+	}
+
+	return append(issues, serverPortConflicts(config)...)
 }
 
 // validateClientConfig 验证客户端配置
 func (v *Validator) validateClientConfig(config *Config) error {
 	if config.ServerAddr != "" {
 		if err := v.validateAddress(config.ServerAddr); err != nil {
-			return fmt.Errorf("服务器地址无效: %w", err)
+			return i18n.Errorf("服务器地址无效: %w", err)
 		}
 	}
 
 	if config.ServerPort != 0 {
 		if err := v.validatePort(config.ServerPort); err != nil {
-			return fmt.Errorf("服务器端口无效: %w", err)
+			return i18n.Errorf("服务器端口无效: %w", err)
 		}
 	}
 
+	if err := v.validateClientTransport(config.Transport); err != nil {
+		return i18n.Errorf("传输配置无效: %w", err)
+	}
+
 	return nil
 }
 
 // validateClientConfigDetailed 详细验证客户端配置
-func (v *Validator) validateClientConfigDetailed(config *Config) []string {
-	var errors []string
+func (v *Validator) validateClientConfigDetailed(config *Config) []FieldIssue {
+	var issues []FieldIssue
 
 	if config.ServerAddr != "" {
 		if err := v.validateAddress(config.ServerAddr); err != nil {
-			errors = append(errors, fmt.Sprintf("服务器地址无效: %v", err))
+			issues = append(issues, FieldIssue{Field: "serverAddr", Message: i18n.Sprintf("服务器地址无效: %v", err)})
 		}
 	}
 
 	if config.ServerPort != 0 {
 		if err := v.validatePort(config.ServerPort); err != nil {
-			errors = append(errors, fmt.Sprintf("服务器端口无效: %v", err))
+			issues = append(issues, FieldIssue{Field: "serverPort", Message: i18n.Sprintf("服务器端口无效: %v", err)})
 		}
 	}
 
-	return errors
+	if err := v.validateClientTransport(config.Transport); err != nil {
+		issues = append(issues, FieldIssue{Field: "transport", Message: i18n.Sprintf("传输配置无效: %v", err)})
+	}
+
+	return issues
 }
 
 // validateProxies 验证代理配置
@@ -155,32 +240,48 @@ func (v *Validator) validateProxies(proxies []ProxyConfig) error {
 
 	for i, proxy := range proxies {
 		if err := v.validateProxyName(proxy.Name); err != nil {
-			return fmt.Errorf("代理 %d 名称无效: %w", i+1, err)
+			return i18n.Errorf("代理 %d 名称无效: %w", i+1, err)
 		}
 
 		if names[proxy.Name] {
-			return fmt.Errorf("代理名称 '%s' 重复", proxy.Name)
+			return i18n.Errorf("代理名称 '%s' 重复", proxy.Name)
 		}
 		names[proxy.Name] = true
 
 		if err := v.validateProxyType(proxy.Type); err != nil {
-			return fmt.Errorf("代理 '%s' 类型无效: %w", proxy.Name, err)
+			return i18n.Errorf("代理 '%s' 类型无效: %w", proxy.Name, err)
 		}
 
 		if proxy.LocalIP != "" {
 			if err := v.validateAddress(proxy.LocalIP); err != nil {
-				return fmt.Errorf("代理 '%s' 本地地址无效: %w", proxy.Name, err)
+				return i18n.Errorf("代理 '%s' 本地地址无效: %w", proxy.Name, err)
 			}
 		}
 
 		if proxy.LocalPort != 0 {
 			if err := v.validatePort(proxy.LocalPort); err != nil {
-				return fmt.Errorf("代理 '%s' 本地端口无效: %w", proxy.Name, err)
+				return i18n.Errorf("代理 '%s' 本地端口无效: %w", proxy.Name, err)
 			}
 		}
 
 		if err := v.validateProxyByType(proxy); err != nil {
-			return fmt.Errorf("代理 '%s' 配置错误: %w", proxy.Name, err)
+			return i18n.Errorf("代理 '%s' 配置错误: %w", proxy.Name, err)
+		}
+
+		if err := v.validateLoadBalancing(proxy); err != nil {
+			return i18n.Errorf("代理 '%s' 负载均衡配置错误: %w", proxy.Name, err)
+		}
+
+		if err := v.validateHealthCheck(proxy.HealthCheck); err != nil {
+			return i18n.Errorf("代理 '%s' 健康检查配置错误: %w", proxy.Name, err)
+		}
+
+		if err := v.validatePlugin(proxy); err != nil {
+			return i18n.Errorf("代理 '%s' 插件配置错误: %w", proxy.Name, err)
+		}
+
+		if _, err := ParseBandwidthLimit(proxy.Transport.BandwidthLimit); err != nil {
+			return i18n.Errorf("代理 '%s' 带宽限制无效: %w", proxy.Name, err)
 		}
 	}
 
@@ -188,42 +289,65 @@ func (v *Validator) validateProxies(proxies []ProxyConfig) error {
 }
 
 // validateProxiesDetailed 详细验证代理配置
-func (v *Validator) validateProxiesDetailed(proxies []ProxyConfig) []string {
-	var errors []string
+func (v *Validator) validateProxiesDetailed(proxies []ProxyConfig) []FieldIssue {
+	var issues []FieldIssue
 	names := make(map[string]bool)
 
 	for i, proxy := range proxies {
+		path := itemPath("proxies", i, proxy.Name)
+		add := func(field, message string) {
+			issues = append(issues, FieldIssue{Field: joinSchemaPath(path, field), Message: message})
+		}
+
 		if err := v.validateProxyName(proxy.Name); err != nil {
-			errors = append(errors, fmt.Sprintf("代理 %d 名称无效: %v", i+1, err))
+			add("name", i18n.Sprintf("代理 %d 名称无效: %v", i+1, err))
 		}
 
 		if names[proxy.Name] {
-			errors = append(errors, fmt.Sprintf("代理名称 '%s' 重复", proxy.Name))
+			add("name", i18n.Sprintf("代理名称 '%s' 重复", proxy.Name))
 		}
 		names[proxy.Name] = true
 
 		if err := v.validateProxyType(proxy.Type); err != nil {
-			errors = append(errors, fmt.Sprintf("代理 '%s' 类型无效: %v", proxy.Name, err))
+			add("type", i18n.Sprintf("代理 '%s' 类型无效: %v", proxy.Name, err))
 		}
 
 		if proxy.LocalIP != "" {
 			if err := v.validateAddress(proxy.LocalIP); err != nil {
-				errors = append(errors, fmt.Sprintf("代理 '%s' 本地地址无效: %v", proxy.Name, err))
+				add("localIP", i18n.Sprintf("代理 '%s' 本地地址无效: %v", proxy.Name, err))
 			}
 		}
 
 		if proxy.LocalPort != 0 {
 			if err := v.validatePort(proxy.LocalPort); err != nil {
-				errors = append(errors, fmt.Sprintf("代理 '%s' 本地端口无效: %v", proxy.Name, err))
+				add("localPort", i18n.Sprintf("代理 '%s' 本地端口无效: %v", proxy.Name, err))
 			}
 		}
 
-		if err := v.validateProxyByType(proxy); err != nil {
-			errors = append(errors, fmt.Sprintf("代理 '%s' 配置错误: %v", proxy.Name, err))
+		if field, err := v.validateTypeFields(proxy); err != nil {
+			add(field, i18n.Sprintf("代理 '%s' 配置错误: %v", proxy.Name, err))
+		} else if err := v.validateProxyByType(proxy); err != nil {
+			add("", i18n.Sprintf("代理 '%s' 配置错误: %v", proxy.Name, err))
+		}
+
+		if err := v.validateLoadBalancing(proxy); err != nil {
+			add("group", i18n.Sprintf("代理 '%s' 负载均衡配置错误: %v", proxy.Name, err))
+		}
+
+		if err := v.validateHealthCheck(proxy.HealthCheck); err != nil {
+			add("healthCheck", i18n.Sprintf("代理 '%s' 健康检查配置错误: %v", proxy.Name, err))
+		}
+
+		if err := v.validatePlugin(proxy); err != nil {
+			add("plugin", i18n.Sprintf("代理 '%s' 插件配置错误: %v", proxy.Name, err))
+		}
+
+		if _, err := ParseBandwidthLimit(proxy.Transport.BandwidthLimit); err != nil {
+			add("transport.bandwidthLimit", i18n.Sprintf("代理 '%s' 带宽限制无效: %v", proxy.Name, err))
 		}
 	}
 
-	return errors
+	return issues
 }
 
 // validateVisitors 验证访问者配置
@@ -232,21 +356,21 @@ func (v *Validator) validateVisitors(visitors []VisitorConfig) error {
 
 	for i, visitor := range visitors {
 		if visitor.Name == "" {
-			return fmt.Errorf("访问者 %d 名称不能为空", i+1)
+			return i18n.Errorf("访问者 %d 名称不能为空", i+1)
 		}
 
 		if names[visitor.Name] {
-			return fmt.Errorf("访问者名称 '%s' 重复", visitor.Name)
+			return i18n.Errorf("访问者名称 '%s' 重复", visitor.Name)
 		}
 		names[visitor.Name] = true
 
 		if err := v.validateVisitorType(visitor.Type); err != nil {
-			return fmt.Errorf("访问者 '%s' 类型无效: %w", visitor.Name, err)
+			return i18n.Errorf("访问者 '%s' 类型无效: %w", visitor.Name, err)
 		}
 
 		if visitor.BindPort != 0 {
 			if err := v.validatePort(visitor.BindPort); err != nil {
-				return fmt.Errorf("访问者 '%s' 绑定端口无效: %w", visitor.Name, err)
+				return i18n.Errorf("访问者 '%s' 绑定端口无效: %w", visitor.Name, err)
 			}
 		}
 	}
@@ -255,36 +379,45 @@ func (v *Validator) validateVisitors(visitors []VisitorConfig) error {
 }
 
 // validateVisitorsDetailed 详细验证访问者配置
-func (v *Validator) validateVisitorsDetailed(visitors []VisitorConfig) []string {
-	var errors []string
+func (v *Validator) validateVisitorsDetailed(visitors []VisitorConfig) []FieldIssue {
+	var issues []FieldIssue
 	names := make(map[string]bool)
 
 	for i, visitor := range visitors {
+		path := itemPath("visitors", i, visitor.Name)
+		add := func(field, message string) {
+			issues = append(issues, FieldIssue{Field: joinSchemaPath(path, field), Message: message})
+		}
+
 		if visitor.Name == "" {
-			errors = append(errors, fmt.Sprintf("访问者 %d 名称不能为空", i+1))
+			add("name", i18n.Sprintf("访问者 %d 名称不能为空", i+1))
 		}
 
 		if names[visitor.Name] {
-			errors = append(errors, fmt.Sprintf("访问者名称 '%s' 重复", visitor.Name))
+			add("name", i18n.Sprintf("访问者名称 '%s' 重复", visitor.Name))
 		}
 		names[visitor.Name] = true
 
 		if err := v.validateVisitorType(visitor.Type); err != nil {
-			errors = append(errors, fmt.Sprintf("访问者 '%s' 类型无效: %v", visitor.Name, err))
+			add("type", i18n.Sprintf("访问者 '%s' 类型无效: %v", visitor.Name, err))
 		}
 
 		if visitor.BindPort != 0 {
 			if err := v.validatePort(visitor.BindPort); err != nil {
-				errors = append(errors, fmt.Sprintf("访问者 '%s' 绑定端口无效: %v", visitor.Name, err))
+				add("bindPort", i18n.Sprintf("访问者 '%s' 绑定端口无效: %v", visitor.Name, err))
 			}
 		}
 	}
 
-	return errors
+	return issues
 }
 
 // validateProxyByType 根据类型验证代理配置
 func (v *Validator) validateProxyByType(proxy ProxyConfig) error {
+	if _, err := v.validateTypeFields(proxy); err != nil {
+		return err
+	}
+
 	switch proxy.Type {
 	case "tcp", "udp":
 		return v.validateTCPUDPProxy(proxy)
@@ -296,42 +429,205 @@ func (v *Validator) validateProxyByType(proxy ProxyConfig) error {
 	return nil
 }
 
+// validateTypeFields 检查代理类型用不到的字段并返回字段名，frpc 严格解析配置时会因这些字段启动失败
+func (v *Validator) validateTypeFields(proxy ProxyConfig) (string, error) {
+	if proxy.RemotePort != 0 && proxy.Type != "tcp" && proxy.Type != "udp" {
+		return "remotePort", i18n.Errorf("%s 代理不使用远程端口", proxy.Type)
+	}
+	if (len(proxy.CustomDomains) > 0 || proxy.Subdomain != "") && proxy.Type != "http" && proxy.Type != "https" {
+		return "customDomains", i18n.Errorf("%s 代理不使用自定义域名和子域名", proxy.Type)
+	}
+	if proxy.SecretKey != "" && proxy.Type != "stcp" && proxy.Type != "sudp" && proxy.Type != "xtcp" {
+		return "secretKey", i18n.Errorf("%s 代理不使用密钥，只有 stcp/sudp/xtcp 代理需要", proxy.Type)
+	}
+	if proxy.Role != "" && proxy.Role != "server" {
+		return "role", i18n.Errorf("代理的 role 只能为 server，访问端请添加访问者")
+	}
+	return "", nil
+}
+
 // validateTCPUDPProxy 验证 TCP/UDP 代理
 func (v *Validator) validateTCPUDPProxy(proxy ProxyConfig) error {
 	if proxy.RemotePort == 0 {
-		return fmt.Errorf("远程端口不能为空")
+		return i18n.Errorf("远程端口不能为空")
 	}
 	return v.validatePort(proxy.RemotePort)
 }
 
 // validateHTTPProxy 验证 HTTP 代理
 func (v *Validator) validateHTTPProxy(proxy ProxyConfig) error {
+	if err := v.validateHTTPDomains(proxy); err != nil {
+		return err
+	}
+	return v.validateHTTPOptions(proxy)
+}
+
+// validateHTTPDomains 验证 HTTP 代理的自定义域名与子域名
+func (v *Validator) validateHTTPDomains(proxy ProxyConfig) error {
 	if len(proxy.CustomDomains) == 0 && proxy.Subdomain == "" {
-		return fmt.Errorf("HTTP代理必须设置自定义域名或子域名")
+		return i18n.Errorf("HTTP代理必须设置自定义域名或子域名")
 	}
 
 	for _, domain := range proxy.CustomDomains {
 		if err := v.validateDomain(domain); err != nil {
-			return fmt.Errorf("自定义域名无效: %w", err)
+			return i18n.Errorf("自定义域名无效: %w", err)
 		}
 	}
 
 	if proxy.Subdomain != "" {
 		if err := v.validateSubdomain(proxy.Subdomain); err != nil {
-			return fmt.Errorf("子域名无效: %w", err)
+			return i18n.Errorf("子域名无效: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateHTTPOptions 验证路由、认证与请求头改写，这些选项仅 HTTP 代理支持
+func (v *Validator) validateHTTPOptions(proxy ProxyConfig) error {
+	hasOptions := len(proxy.Locations) > 0 || proxy.HTTPUser != "" || proxy.HTTPPassword != "" ||
+		proxy.HostHeaderRewrite != "" || len(proxy.RequestHeaders.Set) > 0
+	if !hasOptions {
+		return nil
+	}
+	if proxy.Type != "http" {
+		return i18n.Errorf("路由路径、HTTP 认证和请求头改写仅适用于 HTTP 代理")
+	}
+
+	for _, location := range proxy.Locations {
+		if !strings.HasPrefix(location, "/") {
+			return i18n.Errorf("路由路径 %s 必须以 / 开头", location)
+		}
+	}
+	if proxy.HTTPPassword != "" && proxy.HTTPUser == "" {
+		return i18n.Errorf("设置了 HTTP 认证密码但未设置用户名")
+	}
+	if strings.ContainsAny(proxy.HostHeaderRewrite, " /") {
+		return i18n.Errorf("Host 头改写应为主机名: %s", proxy.HostHeaderRewrite)
+	}
+	if err := ValidateHeaders(proxy.RequestHeaders.Set); err != nil {
+		return i18n.Errorf("请求头无效: %w", err)
+	}
+	return nil
+}
+
+// validateLoadBalancing 验证负载均衡分组，frp 只支持 tcp、http 和 tcpmux 代理分组
+func (v *Validator) validateLoadBalancing(proxy ProxyConfig) error {
+	if proxy.LoadBalancer.Group == "" {
+		if proxy.LoadBalancer.GroupKey != "" {
+			return i18n.Errorf("设置了分组密钥但未设置分组名称")
 		}
+		return nil
 	}
 
+	switch proxy.Type {
+	case "tcp", "http", "tcpmux":
+	default:
+		return i18n.Errorf("%s 代理不支持负载均衡分组", proxy.Type)
+	}
+	if strings.ContainsAny(proxy.LoadBalancer.Group, " \t") {
+		return i18n.Errorf("分组名称不能包含空白字符")
+	}
+	// frps 用分组密钥验证加入分组的代理，未设置时任何知道分组名的客户端都能加入并分走流量
+	if proxy.LoadBalancer.GroupKey == "" {
+		return i18n.Errorf("分组 %s 未设置分组密钥", proxy.LoadBalancer.Group)
+	}
+	return nil
+}
+
+// validateHealthCheck 验证健康检查，0 表示使用 frp 默认值（超时 3 秒、间隔 10 秒、失败 1 次）
+func (v *Validator) validateHealthCheck(check HealthCheckConfig) error {
+	if check.Type == "" {
+		if check.Path != "" || check.TimeoutS != 0 || check.IntervalS != 0 || check.MaxFailed != 0 {
+			return i18n.Errorf("设置了健康检查参数但未选择检查类型")
+		}
+		return nil
+	}
+
+	switch check.Type {
+	case "tcp":
+	case "http":
+		if !strings.HasPrefix(check.Path, "/") {
+			return i18n.Errorf("HTTP 健康检查路径必须以 / 开头")
+		}
+	default:
+		return i18n.Errorf("不支持的健康检查类型: %s", check.Type)
+	}
+
+	if check.TimeoutS < 0 || check.IntervalS < 0 || check.MaxFailed < 0 {
+		return i18n.Errorf("健康检查的超时、间隔和失败次数不能为负数")
+	}
+
+	timeout, interval := check.TimeoutS, check.IntervalS
+	if timeout == 0 {
+		timeout = 3
+	}
+	if interval == 0 {
+		interval = 10
+	}
+	if timeout >= interval {
+		return i18n.Errorf("健康检查超时(%d 秒)必须小于检查间隔(%d 秒)", timeout, interval)
+	}
+	return nil
+}
+
+// validatePlugin 验证客户端插件类型与必填参数
+func (v *Validator) validatePlugin(proxy ProxyConfig) error {
+	plugin := proxy.Plugin
+	if plugin.Type == "" {
+		if plugin.hasParams() {
+			return i18n.Errorf("设置了插件参数但未选择插件")
+		}
+		return nil
+	}
+
+	switch plugin.Type {
+	case "unix_domain_socket":
+		if plugin.UnixPath == "" {
+			return i18n.Errorf("unix_domain_socket 插件需要设置套接字路径")
+		}
+	case "http_proxy", "socks5":
+	case "static_file":
+		if plugin.LocalPath == "" {
+			return i18n.Errorf("static_file 插件需要设置本地目录")
+		}
+	case "https2http":
+		if proxy.Type != "https" {
+			return i18n.Errorf("https2http 插件只能用于 HTTPS 代理")
+		}
+		if plugin.LocalAddr == "" {
+			return i18n.Errorf("https2http 插件需要设置本地服务地址")
+		}
+		if _, _, err := net.SplitHostPort(plugin.LocalAddr); err != nil {
+			return i18n.Errorf("本地服务地址应为 host:port 形式: %s", plugin.LocalAddr)
+		}
+		if (plugin.CrtPath == "") != (plugin.KeyPath == "") {
+			return i18n.Errorf("证书和私钥必须同时配置")
+		}
+		if err := ValidateHeaders(plugin.RequestHeaders.Set); err != nil {
+			return i18n.Errorf("请求头无效: %w", err)
+		}
+	default:
+		return i18n.Errorf("不支持的插件: %s，可选: %s", plugin.Type, strings.Join(PluginTypes, ", "))
+	}
+
+	if user, password := plugin.Auth(); password != "" && user == "" {
+		return i18n.Errorf("设置了插件认证密码但未设置用户名")
+	}
 	return nil
 }
 
 // validateSecretProxy 验证加密代理
 func (v *Validator) validateSecretProxy(proxy ProxyConfig) error {
-	if proxy.SecretKey == "" {
-		return fmt.Errorf("密钥不能为空")
+	return v.validateSecretKey(proxy.SecretKey)
+}
+
+// validateSecretKey 验证 stcp/sudp/xtcp 代理与访问者共用的密钥
+func (v *Validator) validateSecretKey(secretKey string) error {
+	if secretKey == "" {
+		return i18n.Errorf("密钥不能为空")
 	}
-	if len(proxy.SecretKey) < 8 {
-		return fmt.Errorf("密钥长度不能少于8位")
+	if len(secretKey) < 8 {
+		return i18n.Errorf("密钥长度不能少于8位")
 	}
 	return nil
 }
@@ -339,15 +635,70 @@ func (v *Validator) validateSecretProxy(proxy ProxyConfig) error {
 // validatePort 验证端口号
 func (v *Validator) validatePort(port int) error {
 	if port < 1 || port > 65535 {
-		return fmt.Errorf("端口必须在 1-65535 范围内")
+		return i18n.Errorf("端口必须在 1-65535 范围内")
+	}
+	return nil
+}
+
+// validateTransport 验证心跳设置，-1 表示关闭，间隔需小于超时
+func (v *Validator) validateTransport(transport TransportConfig) error {
+	if transport.HeartbeatInterval < -1 || transport.HeartbeatTimeout < -1 {
+		return i18n.Errorf("心跳间隔和超时不能小于 -1")
+	}
+	if transport.HeartbeatInterval > 0 && transport.HeartbeatTimeout > 0 &&
+		transport.HeartbeatInterval >= transport.HeartbeatTimeout {
+		return i18n.Errorf("心跳间隔必须小于心跳超时")
 	}
 	return nil
 }
 
+// validateClientTransport 验证客户端传输协议、连接池、代理与 TLS 设置
+func (v *Validator) validateClientTransport(transport TransportConfig) error {
+	if transport.Protocol != "" && !isValidTransportProtocol(transport.Protocol) {
+		return i18n.Errorf("不支持的传输协议: %s，可选: %s", transport.Protocol, strings.Join(TransportProtocols, ", "))
+	}
+	if transport.PoolCount < 0 {
+		return i18n.Errorf("连接池数量不能为负数")
+	}
+	if transport.DialServerTimeout < 0 {
+		return i18n.Errorf("连接超时不能为负数")
+	}
+	if transport.ProxyURL != "" {
+		proxyURL, err := url.Parse(transport.ProxyURL)
+		if err != nil || proxyURL.Host == "" {
+			return i18n.Errorf("代理地址格式无效: %s", transport.ProxyURL)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return i18n.Errorf("代理地址仅支持 http、https 或 socks5")
+		}
+	}
+
+	tlsConfig := transport.TLS
+	if (tlsConfig.CertFile == "") != (tlsConfig.KeyFile == "") {
+		return i18n.Errorf("TLS 证书和私钥必须同时配置")
+	}
+	if !tlsConfig.Enabled() && (tlsConfig.CertFile != "" || tlsConfig.TrustedCaFile != "") {
+		return i18n.Errorf("已关闭 TLS，但仍配置了证书文件")
+	}
+	return nil
+}
+
+// isValidTransportProtocol 检查传输协议是否受支持
+func isValidTransportProtocol(protocol string) bool {
+	for _, p := range TransportProtocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
 // validateAddress 验证地址
 func (v *Validator) validateAddress(addr string) error {
 	if addr == "" {
-		return fmt.Errorf("地址不能为空")
+		return i18n.Errorf("地址不能为空")
 	}
 
 	if net.ParseIP(addr) != nil {
@@ -355,17 +706,17 @@ func (v *Validator) validateAddress(addr string) error {
 	}
 
 	if matched, _ := regexp.MatchString(`^[a-zA-Z0-9.-]+$`, addr); !matched {
-		return fmt.Errorf("地址格式无效")
+		return i18n.Errorf("地址格式无效")
 	}
 
 	parts := strings.Split(addr, ".")
 	if len(parts) < 2 {
-		return fmt.Errorf("域名格式无效")
+		return i18n.Errorf("域名格式无效")
 	}
 
 	for _, part := range parts {
 		if len(part) == 0 || len(part) > 63 {
-			return fmt.Errorf("域名部分长度无效")
+			return i18n.Errorf("域名部分长度无效")
 		}
 	}
 
@@ -375,17 +726,17 @@ func (v *Validator) validateAddress(addr string) error {
 // validateDomain 验证域名
 func (v *Validator) validateDomain(domain string) error {
 	if domain == "" {
-		return fmt.Errorf("域名不能为空")
+		return i18n.Errorf("域名不能为空")
 	}
 
 	if matched, _ := regexp.MatchString(`^[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`, domain); !matched {
-		return fmt.Errorf("域名格式无效")
+		return i18n.Errorf("域名格式无效")
 	}
 
 	parts := strings.Split(domain, ".")
 	for _, part := range parts {
 		if len(part) == 0 || len(part) > 63 {
-			return fmt.Errorf("域名部分长度无效")
+			return i18n.Errorf("域名部分长度无效")
 		}
 	}
 
@@ -395,15 +746,15 @@ func (v *Validator) validateDomain(domain string) error {
 // validateSubdomain 验证子域名
 func (v *Validator) validateSubdomain(subdomain string) error {
 	if subdomain == "" {
-		return fmt.Errorf("子域名不能为空")
+		return i18n.Errorf("子域名不能为空")
 	}
 
 	if matched, _ := regexp.MatchString(`^[a-zA-Z0-9-]+$`, subdomain); !matched {
-		return fmt.Errorf("子域名只能包含字母、数字和连字符")
+		return i18n.Errorf("子域名只能包含字母、数字和连字符")
 	}
 
 	if len(subdomain) > 63 {
-		return fmt.Errorf("子域名长度不能超过63个字符")
+		return i18n.Errorf("子域名长度不能超过63个字符")
 	}
 
 	return nil
@@ -412,15 +763,15 @@ func (v *Validator) validateSubdomain(subdomain string) error {
 // validateProxyName 验证代理名称
 func (v *Validator) validateProxyName(name string) error {
 	if name == "" {
-		return fmt.Errorf("代理名称不能为空")
+		return i18n.Errorf("代理名称不能为空")
 	}
 
 	if matched, _ := regexp.MatchString(`^[a-zA-Z0-9_-]+$`, name); !matched {
-		return fmt.Errorf("代理名称只能包含字母、数字、下划线和连字符")
+		return i18n.Errorf("代理名称只能包含字母、数字、下划线和连字符")
 	}
 
 	if len(name) > 50 {
-		return fmt.Errorf("代理名称长度不能超过50个字符")
+		return i18n.Errorf("代理名称长度不能超过50个字符")
 	}
 
 	return nil
@@ -434,7 +785,7 @@ func (v *Validator) validateProxyType(proxyType string) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("无效的代理类型: %s", proxyType)
+	return i18n.Errorf("无效的代理类型: %s", proxyType)
 }
 
 // validateVisitorType 验证访问者类型
@@ -445,7 +796,7 @@ func (v *Validator) validateVisitorType(visitorType string) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("无效的访问者类型: %s", visitorType)
+	return i18n.Errorf("无效的访问者类型: %s", visitorType)
 }
 
 // ValidateProxyConfig 验证单个代理配置
@@ -460,13 +811,13 @@ func (v *Validator) ValidateProxyConfig(proxy ProxyConfig) error {
 
 	if proxy.LocalIP != "" {
 		if err := v.validateAddress(proxy.LocalIP); err != nil {
-			return fmt.Errorf("本地地址无效: %w", err)
+			return i18n.Errorf("本地地址无效: %w", err)
 		}
 	}
 
 	if proxy.LocalPort != 0 {
 		if err := v.validatePort(proxy.LocalPort); err != nil {
-			return fmt.Errorf("本地端口无效: %w", err)
+			return i18n.Errorf("本地端口无效: %w", err)
 		}
 	}
 
@@ -478,24 +829,24 @@ func (v *Validator) GetValidationSummary(config *Config) map[string][]string {
 	summary := make(map[string][]string)
 
 	if config == nil {
-		summary["error"] = []string{"配置不能为空"}
+		summary["error"] = []string{i18n.T("配置不能为空")}
 		return summary
 	}
 
 	if serverErrors := v.validateServerConfigDetailed(config); len(serverErrors) > 0 {
-		summary["server"] = serverErrors
+		summary["server"] = issueMessages(serverErrors)
 	}
 
 	if clientErrors := v.validateClientConfigDetailed(config); len(clientErrors) > 0 {
-		summary["client"] = clientErrors
+		summary["client"] = issueMessages(clientErrors)
 	}
 
 	if proxyErrors := v.validateProxiesDetailed(config.Proxies); len(proxyErrors) > 0 {
-		summary["proxies"] = proxyErrors
+		summary["proxies"] = issueMessages(proxyErrors)
 	}
 
 	if visitorErrors := v.validateVisitorsDetailed(config.Visitors); len(visitorErrors) > 0 {
-		summary["visitors"] = visitorErrors
+		summary["visitors"] = issueMessages(visitorErrors)
 	}
 
 	return summary
@@ -506,28 +857,33 @@ func (v *Validator) CompareConfigs(config1, config2 *Config) map[string][]string
 	differences := make(map[string][]string)
 
 	if config1 == nil || config2 == nil {
-		differences["error"] = []string{"配置不能为空"}
+		differences["error"] = []string{i18n.T("配置不能为空")}
 		return differences
 	}
 
 	if config1.ServerAddr != config2.ServerAddr {
 		differences["server"] = append(differences["server"],
-			fmt.Sprintf("服务器地址: %s -> %s", config1.ServerAddr, config2.ServerAddr))
+			i18n.Sprintf("服务器地址: %s -> %s", config1.ServerAddr, config2.ServerAddr))
 	}
 
 	if config1.ServerPort != config2.ServerPort {
 		differences["server"] = append(differences["server"],
-			fmt.Sprintf("服务器端口: %d -> %d", config1.ServerPort, config2.ServerPort))
+			i18n.Sprintf("服务器端口: %d -> %d", config1.ServerPort, config2.ServerPort))
 	}
 
 	if config1.BindPort != config2.BindPort {
 		differences["server"] = append(differences["server"],
-			fmt.Sprintf("绑定端口: %d -> %d", config1.BindPort, config2.BindPort))
+			i18n.Sprintf("绑定端口: %d -> %d", config1.BindPort, config2.BindPort))
+	}
+
+	if ranges1, ranges2 := FormatPortRanges(config1.AllowPorts), FormatPortRanges(config2.AllowPorts); ranges1 != ranges2 {
+		differences["server"] = append(differences["server"],
+			i18n.Sprintf("允许端口: %s -> %s", ranges1, ranges2))
 	}
 
 	if len(config1.Proxies) != len(config2.Proxies) {
 		differences["proxies"] = append(differences["proxies"],
-			fmt.Sprintf("代理数量: %d -> %d", len(config1.Proxies), len(config2.Proxies)))
+			i18n.Sprintf("代理数量: %d -> %d", len(config1.Proxies), len(config2.Proxies)))
 	}
 
 	proxyMap1 := make(map[string]ProxyConfig)
@@ -539,15 +895,15 @@ func (v *Validator) CompareConfigs(config1, config2 *Config) map[string][]string
 		if proxy1, exists := proxyMap1[proxy2.Name]; exists {
 			if proxy1.Type != proxy2.Type {
 				differences["proxies"] = append(differences["proxies"],
-					fmt.Sprintf("代理 %s 类型: %s -> %s", proxy2.Name, proxy1.Type, proxy2.Type))
+					i18n.Sprintf("代理 %s 类型: %s -> %s", proxy2.Name, proxy1.Type, proxy2.Type))
 			}
 			if proxy1.LocalPort != proxy2.LocalPort {
 				differences["proxies"] = append(differences["proxies"],
-					fmt.Sprintf("代理 %s 本地端口: %d -> %d", proxy2.Name, proxy1.LocalPort, proxy2.LocalPort))
+					i18n.Sprintf("代理 %s 本地端口: %d -> %d", proxy2.Name, proxy1.LocalPort, proxy2.LocalPort))
 			}
 		} else {
 			differences["proxies"] = append(differences["proxies"],
-				fmt.Sprintf("新增代理: %s", proxy2.Name))
+				i18n.Sprintf("新增代理: %s", proxy2.Name))
 		}
 	}
 
@@ -559,7 +915,7 @@ func (v *Validator) GetConfigSummary(config *Config) map[string]interface{} {
 	summary := make(map[string]interface{})
 
 	if config == nil {
-		summary["error"] = "配置为空"
+		summary["error"] = i18n.T("配置为空")
 		return summary
 	}
 
@@ -0,0 +1,62 @@
+package config
+
+import "testing"
+
+func TestValidateLoadBalancing(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxy   ProxyConfig
+		wantErr bool
+	}{
+		{"no group", ProxyConfig{Type: "tcp"}, false},
+		{"group with key", ProxyConfig{Type: "tcp", LoadBalancer: LoadBalancerConfig{Group: "web", GroupKey: "key"}}, false},
+		{"group without key", ProxyConfig{Type: "tcp", LoadBalancer: LoadBalancerConfig{Group: "web"}}, true},
+		{"key without group", ProxyConfig{Type: "tcp", LoadBalancer: LoadBalancerConfig{GroupKey: "key"}}, true},
+		{"unsupported type", ProxyConfig{Type: "udp", LoadBalancer: LoadBalancerConfig{Group: "web", GroupKey: "key"}}, true},
+		{"whitespace in group", ProxyConfig{Type: "http", LoadBalancer: LoadBalancerConfig{Group: "a b", GroupKey: "key"}}, true},
+	}
+
+	v := NewValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.validateLoadBalancing(tt.proxy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateLoadBalancing() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerSavedUnderLoadBalancer(t *testing.T) {
+	cfg := &Config{
+		ServerAddr: "example.com",
+		Proxies: []ProxyConfig{{
+			Name:         "ssh",
+			Type:         "tcp",
+			LocalPort:    22,
+			RemotePort:   6000,
+			LoadBalancer: LoadBalancerConfig{Group: "ssh", GroupKey: "key"},
+		}},
+	}
+	for _, format := range []ConfigFormat{FormatYAML, FormatTOML} {
+		data, err := MarshalConfig(cfg, format)
+		if err != nil {
+			t.Fatalf("MarshalConfig(%s): %v", format, err)
+		}
+		issues, err := CheckSchema(data, format, false)
+		if err != nil {
+			t.Fatalf("CheckSchema(%s): %v", format, err)
+		}
+		for _, issue := range issues {
+			t.Errorf("%s: unexpected schema issue: %s", format, issue)
+		}
+
+		loaded, err := UnmarshalConfig(data, format)
+		if err != nil {
+			t.Fatalf("UnmarshalConfig(%s): %v", format, err)
+		}
+		if got := loaded.Proxies[0].LoadBalancer; got != cfg.Proxies[0].LoadBalancer {
+			t.Errorf("%s: LoadBalancer = %+v, want %+v", format, got, cfg.Proxies[0].LoadBalancer)
+		}
+	}
+}
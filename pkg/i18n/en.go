@@ -0,0 +1,2446 @@
+package i18n
+
+// english 英文消息目录，键为代码中的中文原文
+var english = map[string]string{
+	// cmd/frp-cli-ui/cli.go
+	"start server|client [-c 配置文件]":                                            "start server|client [-c config file]",
+	"在前台启动服务端或客户端，Ctrl+C 停止":                                                   "Start the server or client in the foreground, Ctrl+C to stop",
+	"停止由本工具启动的服务端或客户端":                                                         "Stop the server or client started by this tool",
+	"查看安装与运行状态":                                                                "Show installation and running status",
+	"proxy list [--target 名称] [--api 地址] [--user 用户] [--password 密码] [--json]": "proxy list [--target name] [--api address] [--user user] [--password password] [--json]",
+	"从 frps Dashboard API 列出代理":                                                "List proxies from the frps Dashboard API",
+	"config validate [-c 配置文件] [--live] [--json]":                              "config validate [-c config_file] [--live] [--json]",
+	"校验配置文件，--live 同时检查端口占用，--json 输出 JSON；退出码 0 通过、1 有警告、2 有错误":                                                                                                    "Validate a config file; --live also checks port usage, --json prints JSON; exit code 0 ok, 1 warnings, 2 errors",
+	"install [--version 版本|latest] [--channel stable|prerelease] [--dir 目录 | --system | --use-existing] [--mirror 镜像] [--proxy 代理] [--archive 安装包] [--skip-verify]": "install [--version VERSION|latest] [--channel stable|prerelease] [--dir DIR | --system | --use-existing] [--mirror MIRROR] [--proxy PROXY] [--archive ARCHIVE] [--skip-verify]",
+	"下载并安装 FRP": "Download and install FRP",
+	"versions [list | use <版本> | rollback | pin server|client <版本|current> | remove <版本>]": "versions [list | use <version> | rollback | pin server|client <version|current> | remove <version>]",
+	"管理 ~/.frp-manager 中并存的 FRP 版本":                                                        "Manage side-by-side FRP versions in ~/.frp-manager",
+	"检查并安装 frp-cli-ui 自身的新版本":                                                              "Check for and install a new version of frp-cli-ui itself",
+	"显示版本信息":   "Show version information",
+	"错误: %v\n": "Error: %v\n",
+	"用法:":      "Usage:",
+	"  frp-cli-ui              启动终端界面": "  frp-cli-ui              Start the terminal UI",
+	"  frp-cli-ui --headless [--listen 地址] [--server] [--client]   无界面运行，提供只读的 HTTP 状态页": "  frp-cli-ui --headless [--listen addr] [--server] [--client]   run without the TUI and serve a read-only HTTP status page",
+	"  frp-cli-ui <命令> [参数]": "  frp-cli-ui <command> [options]",
+	"命令:":                    "Commands:",
+	"请指定 server 或 client":    "Please specify server or client",
+	"配置文件路径":                 "Config file path",
+	"%s 进程已退出":               "%s process exited",
+	"以 JSON 格式输出":            "Output as JSON",
+	"FRP: 使用 %s 中的程序 (版本: %s, frps: %s, frpc: %s)\n":                "FRP: using programs from %s (version: %s, frps: %s, frpc: %s)\n",
+	"FRP: 使用 %s 安装的程序 (版本: %s, 目录: %s)\n":                           "FRP: using programs installed by %s (version: %s, dir: %s)\n",
+	"FRP: 已安装 (版本: %s, 目录: %s)\n":                                   "FRP: installed (version: %s, directory: %s)\n",
+	"FRP: 未安装 (目录: %s)\n":                                           "FRP: not installed (directory: %s)\n",
+	"⚠️ %s (%s) 适用于 %s，本机为 %s/%s，无法运行；执行 frp-cli-ui install 重新安装\n": "⚠️ %s (%s) is built for %s, this machine is %s/%s, it cannot run; run frp-cli-ui install to reinstall\n",
+	"服务端":       "Server",
+	"客户端":       "Client",
+	"%s: 未运行\n": "%s: not running\n",
+	"未知":        "Unknown",
+	"%s: 运行中 (PID: %d, 配置: %s, 启动于: %s)\n":                                         "%s: running (PID: %d, config: %s, started at: %s)\n",
+	"用法: proxy list [--target 名称] [--api 地址] [--user 用户] [--password 密码] [--json]": "Usage: proxy list [--target name] [--api address] [--user user] [--password password] [--json]",
+	"应用设置中的 Dashboard 目标名称":                                                        "Dashboard target name from app settings",
+	"frps Dashboard API 地址，覆盖目标中的地址":                                               "frps Dashboard API address, overrides the target address",
+	"Dashboard 用户名，覆盖目标中的用户名":                                                      "Dashboard user, overrides the target user",
+	"Dashboard 密码，覆盖目标中的密码":                                                        "Dashboard password, overrides the target password",
+	"无法连接 frps Dashboard API: %s":                                                  "Cannot connect to the frps Dashboard API: %s",
+	"名称\t类型\t状态\t远程端口\t连接数\t今日上行\t今日下行":                                            "Name\tType\tStatus\tRemote Port\tConnections\tToday Out\tToday In",
+	"未找到 Dashboard 目标 %s，可选: %s":                                                   "Dashboard target %s not found, available: %s",
+	"用法: config validate [-c 配置文件] [--live] [--json]":                              "usage: config validate [-c config_file] [--live] [--json]",
+	"检查本机端口占用":                                                                     "Check local port usage",
+	"以 JSON 输出校验结果，供 CI 使用":                                                        "Print validation results as JSON for CI",
+	"配置文件 %s 校验未通过，共 %d 个错误":                                                       "Config file %s failed validation with %d error(s)",
+	"✅ 配置文件 %s 校验通过，有 %d 个警告\n":                                                    "✅ Config file %s passed validation with %d warning(s)\n",
+	"✅ 配置文件 %s 校验通过\n":                                                             "✅ Config file %s is valid\n",
+	"要安装的 FRP 版本，latest 表示更新通道中的最新版本":                                              "FRP version to install; latest means the newest version in the release channel",
+	"更新通道 stable 或 prerelease，默认使用已保存的设置；prerelease 且未指定版本时安装最新的预发布版本":    "release channel, stable or prerelease (defaults to the saved settings); prerelease without --version installs the newest pre-release",
+	"安装目录，默认使用设置中记录的目录或 ~/.frp-manager":                                   "Install directory, defaults to the one recorded in settings or ~/.frp-manager",
+	"系统范围安装到 /usr/local/bin，需要时通过 sudo 提权":                                "Install system-wide to /usr/local/bin, escalating with sudo when needed",
+	"下载镜像，默认使用已保存的设置":                                                     "Download mirror, defaults to the saved setting",
+	"下载代理 (http/https/socks5)，默认使用已保存的设置":                                 "Download proxy (http/https/socks5), defaults to the saved setting",
+	"跳过 SHA256 校验（不推荐）":                                                   "Skip SHA256 verification (not recommended)",
+	"直接使用包管理器或手动安装的 frps/frpc，不下载":                                        "Use frps/frpc already installed by a package manager or by hand instead of downloading",
+	"从本地的 frp_*.tar.gz/zip 安装，不访问网络；校验文件需放在同一目录":                          "Install from a local frp_*.tar.gz/zip without network access; the checksum file must be in the same directory",
+	"--archive 安装压缩包中的版本，不能与 --version 或 --channel 同时使用":                  "--archive installs the version in the archive and cannot be combined with --version or --channel",
+	"--system 与 --dir 不能同时使用":                                             "--system and --dir cannot be used together",
+	"Windows 不支持 --system，请用 --dir 指定安装目录":                                "--system is not supported on Windows, use --dir to choose the install directory",
+	"不支持的更新通道: %s，可选: %s":                                                 "unsupported release channel: %s, options: %s",
+	"⚠️ %s 是预发布版本，可能不稳定\n":                                                "⚠️ %s is a pre-release and may be unstable\n",
+	"提示: 检测到 %s 安装的 FRP %s (%s)，可使用 install --use-existing 直接使用，不必重复下载\n": "Hint: found FRP %[2]s installed by %[1]s (%[3]s); use install --use-existing to use it without downloading another copy\n",
+	"正在安装 FRP %s 到 %s ...\n":                                              "Installing FRP %s to %s ...\n",
+	"正在从 %s 安装 FRP %s 到 %s ...\n":                                         "Installing FRP %[2]s from %[1]s to %[3]s ...\n",
+	"✅ FRP 安装成功":         "✅ FRP installed successfully",
+	"FRP 已在使用 %s 中的程序\n": "FRP already uses the programs in %s\n",
+	"没有在 PATH 或包管理器目录中找到位于同一目录的 frps 和 frpc":                                                   "No frps and frpc found in the same directory in PATH or package manager directories",
+	"✅ 已改用 %s 安装的 FRP %s: %s\n":                                                                "✅ Now using FRP %[2]s installed by %[1]s: %[3]s\n",
+	"没有写入 %s 的权限，将通过 sudo 安装\n":                                                                "No permission to write to %s, installing via sudo\n",
+	"sudo 安装失败: %w":                                                                            "sudo install failed: %w",
+	"用法: versions [list | use <版本> | rollback | pin server|client <版本|current> | remove <版本>]": "usage: versions [list | use <version> | rollback | pin server|client <version|current> | remove <version>]",
+	"安装目录 %s 不支持多版本，只有安装在 %s 时才会保留多个版本":                                                        "install directory %s does not keep multiple versions; only installs in %s do",
+	"✅ 已切换到 FRP %s，正在运行的 frps/frpc 重启后生效\n":                                                    "✅ Switched to FRP %s; running frps/frpc use it after a restart\n",
+	"↩ 已回滚到 FRP %s，正在运行的 frps/frpc 重启后生效\n":                                                    "↩ Rolled back to FRP %s; running frps/frpc use it after a restart\n",
+	"🗑 已删除 FRP %s\n":                                                                           "🗑 Removed FRP %s\n",
+	"没有按版本安装的 FRP，使用 install 安装后会保留在 versions 目录中":                                             "No versioned FRP installs yet; versions installed with install are kept in the versions directory",
+	"当前":              "current",
+	"上一个":             "previous",
+	"frps 固定":         "pinned by frps",
+	"frpc 固定":         "pinned by frpc",
+	"版本 %s 未安装":       "version %s is not installed",
+	"✅ %s 改为使用当前版本\n": "✅ %s now follows the current version\n",
+	"✅ %s 固定使用 FRP %s，重启后生效\n":    "✅ %s pinned to FRP %s; takes effect after a restart\n",
+	"只检查是否有新版本，不安装":               "Only check for a new version, do not install",
+	"✨ %s %s 已是最新版本\n":            "✨ %s %s is already the latest version\n",
+	"发现新版本: %s (当前: %s)\n":        "New version found: %s (current: %s)\n",
+	"正在下载 %s ...\n":               "Downloading %s ...\n",
+	"✅ 已更新到 %s: %s，重新运行即可使用新版本\n": "✅ Updated to %s: %s, run it again to use the new version\n",
+
+	// cmd/frp-cli-ui/headless.go
+	"以无界面模式运行":                                "run in headless mode",
+	"状态页监听地址，如 0.0.0.0:7600":                  "status page listen address, e.g. 0.0.0.0:7600",
+	"启动时运行服务端":                                "start the server on launch",
+	"启动时运行客户端":                                "start the client on launch",
+	"初始化工作空间失败: %v":                           "Failed to initialize workspace: %v",
+	"无界面模式已启动，状态页: http://%s/ ，按 Ctrl+C 退出\n": "Headless mode started, status page: http://%s/ , press Ctrl+C to exit\n",
+
+	// cmd/frp-cli-ui/main.go
+	"生成崩溃报告失败: %v": "Failed to write crash report: %v",
+	"界面发生异常，调试报告已保存到 %s，提交问题时请附上该目录": "The interface crashed. A debug report was saved to %s, please attach this directory when filing an issue",
+	"退出时停止进程失败: %v":       "Failed to stop processes on exit: %v",
+	"FRP CLI UI 启动失败: %v": "FRP CLI UI failed to start: %v",
+	"重启失败，请手动运行新版本: %v":   "Restart failed, please run the new version manually: %v",
+
+	// internal/installer/binarch.go
+	"%s 是适用于 %s 的程序，本机为 %s/%s，无法运行，请重新安装适用于本机的版本": "%s is built for %s, this machine is %s/%s, it cannot run; please reinstall the version for this machine",
+	"无法识别 %s 的可执行文件格式": "Unrecognized executable format of %s",
+	"未知 (0x%x)": "unknown (0x%x)",
+
+	// internal/installer/checksum.go
+	"%s SHA256 校验失败: 期望 %s，实际 %s": "%s failed SHA256 verification: expected %s, got %s",
+	"请求校验文件失败: %w":                "Failed to request checksum file: %w",
+	"获取校验文件失败，状态码: %d":            "Failed to fetch checksum file, status code: %d",
+	"读取校验文件失败: %w":                "Failed to read checksum file: %w",
+	"校验文件为空":                      "Checksum file is empty",
+	"校验文件中没有 %s":                  "Checksum file does not contain %s",
+	"计算 SHA256 失败: %w":            "Failed to compute SHA256: %w",
+
+	// internal/installer/detect.go
+	"本工具安装":             "installed by this tool",
+	"系统软件包 (apt/dnf 等)": "system package (apt/dnf etc.)",
+
+	// internal/installer/installer.go
+	"%s 中的 FRP 由 %s 管理，请通过包管理器更新或卸载":      "FRP in %s is managed by %s, please update or uninstall it with the package manager",
+	"创建安装目录失败: %w":                        "Failed to create install directory: %w",
+	"不支持的操作系统: %s":                        "Unsupported operating system: %s",
+	"不支持的架构: %s":                          "Unsupported architecture: %s",
+	"创建请求失败: %w":                          "Failed to create request: %w",
+	"请求失败: %w":                            "Request failed: %w",
+	"续传位置无效，请重试":                          "Invalid resume position, please try again",
+	"下载失败，状态码: %d":                        "Download failed, status code: %d",
+	"创建文件失败: %w":                          "Failed to create file: %w",
+	"写入文件失败: %w":                          "Failed to write file: %w",
+	"保存文件失败: %w":                          "Failed to save file: %w",
+	"不支持的文件格式":                            "Unsupported file format",
+	"获取版本失败: %w":                          "Failed to get version: %w",
+	"无法识别版本输出: %s":                        "Unrecognized version output: %s",
+	"没有删除 %s 中程序的权限，请手动执行: sudo rm %s %s": "No permission to remove the programs in %s, please run: sudo rm %s %s",
+	"删除 %s 失败: %w":                        "Failed to remove %s: %w",
+	"删除版本 %s 失败: %w":                      "failed to remove version %s: %w",
+	"更新失败: %w":                            "Update failed: %w",
+
+	// internal/installer/location.go
+	"获取下载链接失败: %w":                 "Failed to get download URL: %w",
+	"下载文件失败: %w":                   "Failed to download file: %w",
+	"校验文件失败: %w":                   "Failed to verify file: %w",
+	"创建临时目录失败: %w":                 "Failed to create temporary directory: %w",
+	"解压文件失败: %w":                   "Failed to extract file: %w",
+	"安装包中缺少 %s":                    "The package does not contain %s",
+	"安装 %s 失败: %w":                 "Failed to install %s: %w",
+	"[sudo] 安装 FRP 到 %s 需要管理员密码: ": "[sudo] administrator password required to install FRP to %s: ",
+
+	// internal/installer/mirror.go
+	"无效的代理地址: %w":                        "Invalid proxy URL: %w",
+	"不支持的代理协议: %q，仅支持 http、https、socks5": "Unsupported proxy scheme: %q, only http, https and socks5 are supported",
+	"代理地址缺少主机: %s":                       "Proxy URL is missing a host: %s",
+	"无效的镜像地址: %s":                        "Invalid mirror URL: %s",
+
+	// internal/installer/offline.go
+	"无法识别的安装包 %s，文件名应为 frp_<版本>_<系统>_<架构>.tar.gz 或 .zip": "Unrecognized archive %s, the file name should be frp_<version>_<os>_<arch>.tar.gz or .zip",
+	"安装包 %s 适用于 %s/%s，当前平台为 %s/%s":                       "Archive %s is for %s/%s, the current platform is %s/%s",
+	"安装包 %s 的格式与当前平台不符":                                  "The format of archive %s does not match the current platform",
+	"安装包 %s 不存在":                                         "Archive %s does not exist",
+	"未找到校验文件 %s，请把发布附带的校验文件放在安装包所在目录":                    "Checksum file %s not found, please put the checksum file from the release next to the archive",
+
+	// internal/installer/releases.go
+	"没有可用的版本":          "no versions available",
+	"没有可用的正式版本":        "No stable release available",
+	"获取版本列表失败: %w":     "Failed to get release list: %w",
+	"获取版本列表失败，状态码: %d": "Failed to get release list, status code: %d",
+	"读取版本列表失败: %w":     "Failed to read release list: %w",
+	"解析版本列表失败: %w":     "Failed to parse release list: %w",
+	"未设置缓存路径":          "Cache path is not set",
+
+	// internal/installer/selfupdate.go
+	"版本 %s 没有提供 %s/%s 平台的程序":              "Version %s provides no binary for %s/%s",
+	"已是最新版本":                              "Already the latest version",
+	"没有写入 %s 的权限，请执行 sudo %s self-update": "No permission to write %s, please run sudo %s self-update",
+	"替换程序失败: %w":                          "Failed to replace executable: %w",
+	"版本 %s 没有提供校验文件 %s":                   "Version %s provides no checksum file %s",
+	"获取程序路径失败: %w":                        "Failed to get executable path: %w",
+	"新版本无法在本机运行: %w":                      "The new version cannot run on this machine: %w",
+
+	// internal/installer/version.go
+	"版本号为空":      "Version is empty",
+	"无效的版本号: %s": "Invalid version: %s",
+
+	// internal/installer/versions.go
+	"读取已安装版本失败: %w":                    "failed to read installed versions: %w",
+	"只有安装在 %s 时才能切换版本":                 "versions can only be switched for installs in %s",
+	"没有可回滚的版本":                         "no previous version to roll back to",
+	"版本 %s 正在使用，请先切换到其他版本":             "version %s is in use; switch to another version first",
+	"版本 %s 已被 frps 或 frpc 固定使用，请先取消固定": "version %s is pinned by frps or frpc; unpin it first",
+	"记录上一个版本失败: %w":                    "failed to record the previous version: %w",
+	"切换版本失败: %w":                       "failed to switch version: %w",
+	"创建版本目录失败: %w":                     "failed to create version directory: %w",
+
+	// internal/remote/ssh.go
+	"未知主机 %s，公钥指纹 %s":                   "Unknown host %s, key fingerprint %s",
+	"连接 %s 失败: %w":                      "Failed to connect to %s: %w",
+	"未检测到 ssh-agent (SSH_AUTH_SOCK 为空)": "ssh-agent not detected (SSH_AUTH_SOCK is empty)",
+	"连接 ssh-agent 失败: %w":               "Failed to connect to ssh-agent: %w",
+	"获取用户主目录失败: %w":                     "Failed to get user home directory: %w",
+	"解析私钥 %s 失败: %w":                    "Failed to parse private key %s: %w",
+	"私钥 %s 有口令保护，请在密码中填写口令":             "Private key %s is passphrase-protected, enter the passphrase as the password",
+	"找不到可用的私钥: %s":                      "No usable private key found: %s",
+	"读取 known_hosts 失败: %w":             "Failed to read known_hosts: %w",
+	"主机 %s 的公钥与已记录的不一致，可能存在中间人攻击":       "Host key for %s does not match the recorded one, possible man-in-the-middle attack",
+	"创建配置目录失败: %w":                      "Failed to create config directory: %w",
+	"写入 known_hosts 失败: %w":             "Failed to write known_hosts: %w",
+	"创建 SSH 会话失败: %w":                   "Failed to create SSH session: %w",
+	"上传配置到 %s 失败: %s":                   "Failed to upload config to %s: %s",
+	"读取 %s 失败: %s":                      "failed to read %s: %s",
+	"重启 %s 失败: %s":                      "Failed to restart %s: %s",
+	"%s 重启后未处于运行状态: %s":                 "%s is not running after restart: %s",
+	"获取服务状态失败: %w":                      "Failed to get service status: %w",
+	"读取远程输出失败: %w":                      "Failed to read remote output: %w",
+	"启动远程日志命令失败: %w":                    "Failed to start remote log command: %w",
+	"远程日志命令已退出: %w":                     "Remote log command exited: %w",
+
+	// internal/service/api_cache.go
+	"读取响应失败: %w": "Failed to read response: %w",
+
+	// internal/service/apply.go
+	"正在校验配置...":              "Validating config...",
+	"配置校验失败: %w":             "Config validation failed: %w",
+	"正在写入配置...":              "Writing config...",
+	"配置已保存（客户端未运行）":          "Config saved (client not running)",
+	"配置已保存，运行中的客户端使用 %s，未重载": "Config saved; the running client uses %s and was not reloaded",
+	"正在热重载客户端...":            "Hot-reloading client...",
+	"客户端配置已通过管理接口热重载":        "Client config hot-reloaded via the admin API",
+	"配置已保存并热重载":              "Config saved and hot-reloaded",
+	"热重载不可用，改为重启客户端: %v":     "Hot reload unavailable, restarting client instead: %v",
+	"热重载不可用，正在重启客户端...":      "Hot reload unavailable, restarting client...",
+	"重启客户端失败: %w":            "Failed to restart client: %w",
+	"配置已保存，客户端已重启":           "Config saved, client restarted",
+	"代理 %s 不在本机客户端配置 %s 中":   "proxy %s is not in the local client config %s",
+
+	// internal/service/audit.go
+	"写入操作记录失败: %w": "failed to write audit log: %w",
+	"读取操作记录失败: %w": "failed to read audit log: %w",
+
+	// internal/service/bundle.go
+	"不支持的服务: %s": "Unsupported service: %s",
+	"配置内容为空":     "Config content is empty",
+	"本机的 %s 只能运行在 %s 上，无法打包到 %s 部署包": "The local %s only runs on %s and cannot be packaged into a %s bundle",
+	"找不到 %s 可执行文件: %w":               "Cannot find %s executable: %w",
+	"读取 %s 失败: %w":                   "Failed to read %s: %w",
+	"创建输出目录失败: %w":                   "Failed to create output directory: %w",
+	"创建部署包失败: %w":                    "Failed to create bundle: %w",
+	"写入部署包失败: %w":                    "Failed to write bundle: %w",
+	"生成 %s 失败: %w":                   "Failed to generate %s: %w",
+
+	// internal/service/conntest.go
+	"连接 %s 成功，认证通过 (frps %s, 耗时 %dms)": "Connected to %s, authentication passed (frps %s, took %dms)",
+	"无法连接 %s: %v":       "Cannot connect to %s: %v",
+	"TLS 握手失败: %v":      "TLS handshake failed: %v",
+	"建立多路复用失败: %v":      "Failed to set up multiplexing: %v",
+	"登录失败: %v":          "Login failed: %v",
+	"连接测试暂不支持 %s 协议":    "connection test does not support the %s protocol yet",
+	"发送登录消息失败: %w":      "Failed to send login message: %w",
+	"读取登录响应失败: %w":      "Failed to read login response: %w",
+	"加载客户端证书失败: %w":     "failed to load client certificate: %w",
+	"读取 CA 文件失败: %w":    "failed to read CA file: %w",
+	"CA 文件中没有有效的证书: %s": "no valid certificate found in CA file: %s",
+	"意外的消息类型: %q":       "Unexpected message type: %q",
+	"消息长度异常: %d":        "Invalid message length: %d",
+
+	// internal/service/daemonizer.go
+	"解析程序路径失败: %w":          "Failed to resolve binary path: %w",
+	"解析配置路径失败: %w":          "Failed to resolve config path: %w",
+	"配置文件不存在: %w":           "Config file does not exist: %w",
+	"删除服务文件失败: %w":          "Failed to remove service file: %w",
+	"删除 Windows 服务失败: %w":   "Failed to remove Windows service: %w",
+	"读取服务文件失败: %w":          "Failed to read service file: %w",
+	"写入服务文件失败: %w":          "Failed to write service file: %w",
+	"生成 systemd 服务文件失败: %w": "Failed to generate systemd unit: %w",
+	"生成 launchd 服务文件失败: %w": "Failed to generate launchd plist: %w",
+	"注册 Windows 服务失败: %w":   "Failed to register Windows service: %w",
+	"创建服务目录失败: %w":          "Failed to create service directory: %w",
+
+	// internal/service/discover.go
+	"本机全部端口":                  "all local ports",
+	"本机 %d 个常见端口":             "%d common local ports",
+	" + 局域网 %d 台主机的 %d 个常见端口": " + %[2]d common ports on %[1]d LAN hosts",
+
+	// internal/service/dns_check.go
+	"客户端配置未设置 serverAddr":                       "the client config does not set serverAddr",
+	"无法解析: %v。请在 DNS 中为 %s 添加指向 %s 的 A/AAAA 记录": "Cannot resolve: %v. Add an A/AAAA record for %s pointing at %s",
+	"，或添加指向 %s 的 CNAME 记录":                      ", or a CNAME record pointing at %s",
+	"解析到 %s，但无法解析 frps 地址 %s: %v，无法比较":          "Resolves to %s, but the frps address %s cannot be resolved: %v, so they cannot be compared",
+	"解析到 %s": "Resolves to %s",
+	"解析到 %s，但 frps 服务器是 %s。请把 %s 的 A/AAAA 记录改为 %s":                       "Resolves to %s, but the frps server is %s. Change the A/AAAA record of %s to %s",
+	"；serverAddr 是内网地址，如果 frps 通过 NAT 或端口映射对外提供服务，请确认域名指向的公网地址转发到了 frps": "; serverAddr is a private address, so if frps is exposed through NAT or port forwarding, make sure the public address the domain points at forwards to frps",
+	"，或把 CNAME 改为 %s；如果使用了 CDN，请确认 CDN 回源地址是 frps":                       ", or change the CNAME to %s; if you use a CDN, make sure its origin is frps",
+	"域名没有解析记录": "the domain has no DNS records",
+	"DNS 查询超时": "DNS query timed out",
+
+	// internal/service/events.go
+	"客户端配置已保存": "Client config saved",
+	"服务端配置已保存": "Server config saved",
+
+	// internal/service/frp_api.go
+	"API 请求失败，状态码: %d": "API request failed, status code: %d",
+	"获取服务器信息失败: %w":    "Failed to get server info: %w",
+	"解析服务器信息失败: %w":    "Failed to parse server info: %w",
+	"获取%s类型代理失败: %w":   "Failed to get %s proxies: %w",
+	"解析%s类型代理失败: %w":   "Failed to parse %s proxies: %w",
+	"获取代理信息失败: %w":     "Failed to get proxy info: %w",
+	"解析代理信息失败: %w":     "Failed to parse proxy info: %w",
+	"获取客户端列表失败: %w":    "Failed to get client list: %w",
+	"解析客户端列表失败: %w":    "Failed to parse client list: %w",
+	"断开客户端失败: %w":      "failed to disconnect client: %w",
+	"获取流量信息失败: %w":     "Failed to get traffic info: %w",
+	"解析流量信息失败: %w":     "Failed to parse traffic info: %w",
+	"获取代理流量历史失败: %w":   "Failed to get proxy traffic history: %w",
+	"解析代理流量历史失败: %w":   "Failed to parse proxy traffic history: %w",
+	"关闭代理失败: %w":       "failed to close proxy: %w",
+	"重新加载配置失败，状态码: %d": "Failed to reload config, status code: %d",
+	"获取连接统计失败: %w":     "Failed to get connection stats: %w",
+	"解析连接统计失败: %w":     "Failed to parse connection stats: %w",
+
+	// internal/service/frpc_api.go
+	"客户端未配置 webServer.port，无法使用管理接口": "Client has no webServer.port configured, the admin API is unavailable",
+	"API 请求失败，状态码: %d, %s":           "API request failed, status code: %d, %s",
+	"获取客户端状态失败: %w":                  "Failed to get client status: %w",
+	"解析客户端状态失败: %w":                  "Failed to parse client status: %w",
+	"获取客户端配置失败: %w":                  "Failed to get client config: %w",
+	"上传客户端配置失败: %w":                  "Failed to upload client config: %w",
+	"热重载客户端配置失败: %w":                 "Failed to hot-reload client config: %w",
+	"停止客户端失败: %w":                    "Failed to stop client: %w",
+
+	// internal/service/hooks.go
+	"执行 %s 钩子: %s":         "Running %s hook: %s",
+	"%s 钩子超过 %s 未结束，已强制停止": "%s hook did not finish within %s and was killed",
+	"%s 钩子执行失败: %w":        "%s hook failed: %w",
+	"%s 钩子执行完成，用时 %s":      "%s hook finished in %s",
+	"已取消启动 %s: %w":         "Start of %s cancelled: %w",
+
+	// internal/service/latency.go
+	"最近 %d 次连接 %s 的延迟超过 %dms，最高 %dms":            "Connecting to %[2]s took longer than %[3]dms for the last %[1]d samples, up to %[4]dms",
+	"最近 %d 次 frps Dashboard 响应时间超过 %dms，最高 %dms": "frps Dashboard responses took longer than %[2]dms for the last %[1]d samples, up to %[3]dms",
+
+	// internal/service/log_tail.go
+	"无法读取日志文件 %s，等待文件创建: %v": "Cannot read log file %s, waiting for it to be created: %v",
+	"日志文件 %s 已创建，继续跟踪":       "Log file %s was created, resuming tail",
+	"正在跟踪日志文件 %s":            "Tailing log file %s",
+	"读取日志文件 %s 失败: %v":       "Failed to read log file %s: %v",
+
+	// internal/service/manager.go
+	"已重新接管 FRP 服务端 (PID: %d, 配置: %s)": "Re-attached FRP server (PID: %d, config: %s)",
+	"已重新接管 FRP 客户端 (PID: %d, 配置: %s)": "Re-attached FRP client (PID: %d, config: %s)",
+	"进程管理器已关闭":                        "Process manager is closed",
+	"FRP 服务端已在运行":                     "FRP server is already running",
+	"FRP 服务端已在运行 (PID: %d)":           "FRP server is already running (PID: %d)",
+	"配置文件不存在: %s":                     "Config file does not exist: %s",
+	"找不到 frps 可执行文件: %w":              "frps executable not found: %w",
+	"创建输出管道失败: %w":                    "Failed to create stdout pipe: %w",
+	"创建错误管道失败: %w":                    "Failed to create stderr pipe: %w",
+	"启动 FRP 服务端失败: %w":                "Failed to start FRP server: %w",
+	"FRP 服务端启动成功 (PID: %d)":           "FRP server started (PID: %d)",
+	"FRP 客户端已在运行":                     "FRP client is already running",
+	"FRP 客户端已在运行 (PID: %d)":           "FRP client is already running (PID: %d)",
+	"找不到 frpc 可执行文件: %w":              "frpc executable not found: %w",
+	"启动 FRP 客户端失败: %w":                "Failed to start FRP client: %w",
+	"FRP 客户端启动成功 (PID: %d)":           "FRP client started (PID: %d)",
+	"停止FRP服务端失败: %w":                  "Failed to stop FRP server: %w",
+	"停止外部FRP服务端失败: %w":                "Failed to stop external FRP server: %w",
+	"FRP 服务端已停止 (PID: %d)":            "FRP server stopped (PID: %d)",
+	"停止 FRP 客户端进程失败: %w":              "Failed to stop FRP client process: %w",
+	"FRP 客户端已停止 (PID: %d)":            "FRP client stopped (PID: %d)",
+	"停止外部 FRP 客户端进程失败: %w":            "Failed to stop external FRP client process: %w",
+	"外部 FRP 客户端进程已停止 (PID: %d)":       "External FRP client process stopped (PID: %d)",
+	"没有找到运行中的 FRP 客户端进程":              "No running FRP client process found",
+	"%s 已启动":                          "%s started",
+	"%s 异常退出":                         "%s exited unexpectedly",
+	"%s 已停止":                          "%s stopped",
+	"找不到 %s 可执行文件":                    "%s executable not found",
+	"日志扫描错误: %v":                      "Log scan error: %v",
+	"%s 日志收集已停止":                      "%s log collection stopped",
+	"%s 进程已正常停止":                      "%s process stopped normally",
+	"进程异常退出: %v":                      "Process exited abnormally: %v",
+	"%s 进程正常退出":                       "%s process exited normally",
+	"停止服务端失败: %w":                     "Failed to stop server: %w",
+	"未知的服务类型: %s":                     "Unknown service type: %s",
+
+	// internal/service/monitor.go
+	"%s 已意外停止运行，隧道不可用":       "%s stopped unexpectedly, tunnels are unavailable",
+	"frps Dashboard 不可达":     "frps Dashboard unreachable",
+	"代理 %s 已离线":              "Proxy %s is offline",
+	"frps 报告代理状态为 %s":        "frps reports proxy status %s",
+	"代理已从 frps 上消失，客户端可能已断开": "Proxy disappeared from frps, the client may have disconnected",
+	"代理 %s 已上线":              "Proxy %s is online",
+	"类型: %s":                 "Type: %s",
+	"到 frps 的延迟过高":           "High latency to frps",
+	"frpc 管理接口不可达":           "frpc admin API unreachable",
+	"状态: %s":                 "Status: %s",
+	"，错误: ":                  ", error: ",
+	"客户端代理 %s 未连接":           "Client proxy %s is not connected",
+	"，已恢复":                   ", recovered",
+
+	// internal/service/nat.go
+	"本机有公网地址，对端只要能发出 UDP 就能直连，打洞成功率很高":                                                             "This machine has a public address; any peer that can send UDP can connect directly, so hole punching is very likely to succeed",
+	"锥形 NAT：与锥形 NAT 的对端打洞成功率高，对端为对称型 NAT 时也有较大机会成功":                                                "Cone NAT: hole punching with a cone NAT peer is very likely to succeed, and has a good chance even when the peer is behind a symmetric NAT",
+	"对称型 NAT，但端口按顺序分配：对端为锥形 NAT 时 frp 可以预测端口，有一定机会成功；两端都是对称型时基本无法打洞，建议在访问者中设置 fallbackTo 回退到 stcp": "Symmetric NAT with sequential port allocation: frp can predict ports when the peer is behind a cone NAT, so it may succeed; with symmetric NAT on both ends hole punching almost never works, set fallbackTo on the visitor to fall back to stcp",
+	"对称型 NAT，端口随机分配：只有对端为锥形 NAT 或公网地址时才可能成功，两端都是对称型时无法打洞，建议在访问者中设置 fallbackTo 回退到 stcp":            "Symmetric NAT with random port allocation: only works if the peer is behind a cone NAT or has a public address; with symmetric NAT on both ends hole punching fails, set fallbackTo on the visitor to fall back to stcp",
+	"可用的 STUN 服务器不足两个，无法判断 NAT 类型，请检查 UDP 出站是否被防火墙拦截":                                              "Fewer than two STUN servers responded, so the NAT type cannot be determined; check whether outbound UDP is blocked by a firewall",
+	"创建 UDP 套接字失败: %w":                "failed to create UDP socket: %w",
+	"所有 STUN 服务器都没有响应，UDP 出站可能被防火墙拦截": "no STUN server responded, outbound UDP may be blocked by a firewall",
+	"STUN 服务器返回错误响应":                  "STUN server returned an error response",
+	"STUN 响应中没有映射地址":                  "STUN response contains no mapped address",
+
+	// internal/service/notify.go
+	"发送告警失败: %s":        "Failed to send alert: %s",
+	"FRP 管理工具: ":        "FRP Manager: ",
+	"当前系统不支持桌面通知: %s":   "Desktop notifications are not supported on this system: %s",
+	"桌面通知失败: %v %s":     "Desktop notification failed: %v %s",
+	"序列化告警失败: %w":       "Failed to serialize alert: %w",
+	"发送 Webhook 失败: %w": "Failed to send webhook: %w",
+	"Webhook 返回状态码 %d":  "Webhook returned status code %d",
+
+	// internal/service/portmap.go
+	"路由器不支持或未开启 UPnP (%v) 和 NAT-PMP (%v)":                               "router does not support or has not enabled UPnP (%v) or NAT-PMP (%v)",
+	"路由器 WAN 口地址 %s 属于运营商级 NAT (CGNAT)，路由器上的端口映射对公网无效，打洞取决于运营商 NAT 的类型": "Router WAN address %s is behind carrier-grade NAT (CGNAT); port mappings on the router are not reachable from the internet and hole punching depends on the carrier's NAT type",
+	"路由器 WAN 口地址 %s 是内网地址，存在多层 NAT，需要在上级路由器（如光猫）上也设置映射或开启桥接":            "Router WAN address %s is a private address (double NAT); add the mapping on the upstream router (e.g. the ISP modem) too, or switch it to bridge mode",
+	"路由器 WAN 口地址 %s 与公网地址 %s 不同，路由器外还有一层 NAT":                           "Router WAN address %s differs from the public address %s; there is another NAT beyond the router",
+	"没有收到 UPnP 设备的响应":           "no response from any UPnP device",
+	"解析 UPnP 设备描述失败: %w":        "failed to parse UPnP device description: %w",
+	"%s 不是互联网网关设备":              "%s is not an internet gateway device",
+	"路由器拒绝 %s: %d %s":           "router rejected %s: %d %s",
+	"路由器拒绝 %s: HTTP %d":         "router rejected %s: HTTP %d",
+	"找不到默认网关":                   "default gateway not found",
+	"NAT-PMP 响应格式错误":            "malformed NAT-PMP response",
+	"路由器拒绝 NAT-PMP 请求，结果码 %d":   "router rejected the NAT-PMP request, result code %d",
+	"网关 %s 没有响应 NAT-PMP 请求: %w": "gateway %s did not respond to NAT-PMP: %w",
+
+	// internal/service/probe.go
+	"探测 %s 失败: %v":          "Probe of %s failed: %v",
+	"探测 %s 成功，%s (耗时 %dms)": "Probe of %s succeeded, %s (took %dms)",
+	"暂不支持探测 %s 代理":          "probing %s proxies is not supported yet",
+	"无法连接远程端口: %w":          "cannot connect to the remote port: %w",
+	"服务已响应":                 "service responded",
+	"连接保持打开，服务未主动发送数据":      "connection stays open, the service did not send data first",
+	"隧道已建立但连接被关闭，本地服务可能未运行": "the tunnel accepted the connection but closed it; the local service may not be running",
+	"frps 返回了 404 页面，域名没有对应的代理或本地服务无响应": "frps returned its 404 page; no proxy matches the domain or the local service did not respond",
+	"TLS 握手失败: %w":  "TLS handshake failed: %w",
+	"TLS 握手成功 (%s)": "TLS handshake succeeded (%s)",
+
+	// internal/service/procgroup_unix.go
+	"仅 Windows 支持发送 CTRL_BREAK 事件": "Sending CTRL_BREAK events is only supported on Windows",
+
+	// internal/service/procgroup_windows.go
+	"发送 CTRL_BREAK 失败: %w": "Failed to send CTRL_BREAK: %w",
+
+	// internal/service/quota.go
+	"统计流量配额失败: %v":      "Failed to compute traffic quotas: %v",
+	"已用 %s / %s，%s 重置":  "%s of %s used, resets %s",
+	"，自动停止失败: %v":       ", auto-stop failed: %v",
+	"%s 已用尽，自动停止失败: %v": "%s exhausted, auto-stop failed: %v",
+	"%s 已用尽，%s":         "%s exhausted, %s",
+	"%s 已用 %.0f%%":      "%s at %.0f%%",
+	"%s 已用尽":            "%s exhausted",
+	"已用 %s / %s":        "%s of %s used",
+	"已停止 frps":          "stopped frps",
+	"已停用代理 %s（%s）":      "disabled proxy %s (%s)",
+
+	// internal/service/resources_darwin.go
+	"无法解析进程 %d 的状态": "cannot parse status of process %d",
+	"无法解析进程 %d 的内存": "cannot parse memory of process %d",
+
+	// internal/service/restart.go
+	"%s 频繁崩溃，已重启 %d 次，停止自动重启": "%s keeps crashing, restarted %d times, auto-restart stopped",
+	"%s 第 %d 次自动重启失败: %v":     "%s auto-restart #%d failed: %v",
+	"%s 已自动重启（第 %d 次）":        "%s auto-restarted (attempt %d)",
+	"%s 异常退出，%s 后第 %d 次自动重启":  "%s exited abnormally, auto-restart in %s (attempt %d)",
+	"FRP 服务端": "FRP server",
+	"FRP 客户端": "FRP client",
+	"%s 已被手动停止，取消自动重启": "%s was stopped manually, auto-restart cancelled",
+
+	// internal/service/scheduler.go
+	"自动启动 %s 失败: %v":    "Autostart %s failed: %v",
+	"%s 已离开时间窗口，%s 已停止": "%s left its time window, %s stopped",
+	"%s 已自动启动 %s":       "%s auto-started %s",
+
+	// internal/service/shutdown.go
+	"正在停止 %s (PID: %d)...":  "Stopping %s (PID: %d)...",
+	"❌ 停止 %s 失败: %v":        "❌ Failed to stop %s: %v",
+	"⚠️ %s 未在 %s 内退出，已强制结束": "⚠️ %s did not exit within %s and was killed",
+	"✅ %s 已停止":              "✅ %s stopped",
+	"停止进程失败: %s":            "Failed to stop processes: %s",
+
+	// internal/service/state.go
+	"读取状态文件失败: %w": "Failed to read state file: %w",
+	"解析状态文件失败: %w": "Failed to parse state file: %w",
+	"创建状态目录失败: %w": "Failed to create state directory: %w",
+	"序列化状态失败: %w":  "Failed to serialize state: %w",
+	"写入状态文件失败: %w": "Failed to write state file: %w",
+	"替换状态文件失败: %w": "Failed to replace state file: %w",
+
+	// internal/service/status_server.go
+	"监听状态页地址 %s 失败: %w": "failed to listen on status page address %s: %w",
+	"状态页异常退出: %v":       "Status page stopped unexpectedly: %v",
+	"FRP 状态":            "FRP Status",
+	"更新于":               "Updated",
+	"运行中":               "Running",
+	"未运行":               "Not running",
+	"配置":                "Config",
+	"告警":                "Alerts",
+	"没有告警":              "No alerts",
+	"到 frps 的延迟":        "Latency to frps",
+	"连接":                "Connect",
+	"面板":                "Dashboard",
+	"代理":                "Proxies",
+	"没有代理":              "No proxies",
+	"名称":                "Name",
+	"类型":                "Type",
+	"状态":                "Status",
+	"远程端口":              "Remote port",
+	"连接数":               "Connections",
+	"今日上行":              "Today Out",
+	"今日下行":              "Today In",
+	"失败":                "failed",
+
+	// internal/service/terminate.go
+	"无法正常停止 %s，将强制结束: %v":          "Cannot stop %s gracefully, killing it: %v",
+	"上次运行时启动的进程无法接收 CTRL_BREAK 事件": "Processes started in a previous run cannot receive CTRL_BREAK events",
+	"强制停止进程失败: %w":                 "Failed to kill process: %w",
+	"taskkill 失败: %v %s":           "taskkill failed: %v %s",
+
+	// internal/service/token_rotation.go
+	"本机客户端":        "Local client",
+	"本机服务端":        "Local server",
+	"解析 %s 失败: %w": "failed to parse %s: %w",
+	"配置已保存（未运行）":   "config saved (not running)",
+	"配置已保存，运行中的进程使用 %s，未重启": "config saved, the running process uses %s and was not restarted",
+	"配置已保存，但重启失败: %w":       "config saved, but restart failed: %w",
+	"配置已保存并重启":              "config saved and restarted",
+	"配置已上传，但重启失败: %w":       "config uploaded, but restart failed: %w",
+	"配置已上传，%s 已重启":          "config uploaded, %s restarted",
+
+	// internal/service/traffic_store.go
+	"创建流量历史目录失败: %w": "Failed to create traffic history directory: %w",
+	"打开流量历史文件失败: %w": "Failed to open traffic history file: %w",
+	"序列化流量记录失败: %w":  "Failed to serialize traffic record: %w",
+	"写入流量历史失败: %w":   "Failed to write traffic history: %w",
+	"读取流量历史失败: %w":   "Failed to read traffic history: %w",
+	"删除过期流量历史失败: %w": "Failed to delete expired traffic history: %w",
+	"读取流量历史目录失败: %w": "Failed to read traffic history directory: %w",
+
+	// internal/service/verify.go
+	"%s verify 超时":        "%s verify timed out",
+	"运行 %s verify 失败: %w": "Failed to run %s verify: %w",
+
+	// internal/service/webhook.go
+	"推送事件到 %s 失败: %w":     "Failed to push event to %s: %w",
+	"不支持的 Webhook 模板: %s": "Unsupported webhook template: %s",
+	"序列化事件失败: %w":         "Failed to serialize event: %w",
+
+	// pkg/config/backup.go
+	"读取原配置文件失败: %w": "Failed to read original config file: %w",
+	"创建备份目录失败: %w":  "Failed to create backup directory: %w",
+	"创建备份文件失败: %w":  "Failed to create backup file: %w",
+	"读取备份目录失败: %w":  "Failed to read backup directory: %w",
+	"读取备份文件失败: %w":  "Failed to read backup file: %w",
+	"恢复配置文件失败: %w":  "Failed to restore config file: %w",
+
+	// pkg/config/bandwidth.go
+	"带宽限制 %s 缺少单位，应以 KB 或 MB 结尾": "bandwidth limit %s has no unit, it must end with KB or MB",
+	"带宽限制 %s 必须是正数加单位，如 1MB":     "bandwidth limit %s must be a positive number with a unit, e.g. 1MB",
+
+	// pkg/config/clone.go
+	"代理不存在": "Proxy does not exist",
+
+	// pkg/config/dashboard_target.go
+	"读取 CA 证书失败: %w":               "failed to read CA certificate: %w",
+	"CA 证书 %s 中没有有效的 PEM 证书":       "no valid PEM certificate in CA file %s",
+	"Dashboard 目标名称不能为空":           "Dashboard target name cannot be empty",
+	"Dashboard 目标名称重复: %s":         "duplicate Dashboard target name: %s",
+	"Dashboard 目标 %s 的地址无效: %s":    "invalid address for Dashboard target %s: %s",
+	"Dashboard 目标 %s 不能同时设置用户名和令牌": "Dashboard target %s cannot set both user and token",
+	"Dashboard 目标 %s: %w":          "Dashboard target %s: %w",
+	"当前 Dashboard 目标不存在: %s":       "active Dashboard target does not exist: %s",
+
+	// pkg/config/diagnose.go
+	"配置文件不存在":                       "config file does not exist",
+	"读取配置文件失败: %w":                  "Failed to read config file: %w",
+	"解析配置文件失败: %w":                  "Failed to parse config file: %w",
+	"服务端 %s 的远程端口 %s 被多个代理占用: %s":   "Remote port %[2]s on server %[1]s is used by multiple proxies: %[3]s",
+	"服务端 %s 上的代理名称 %s 在多个配置中重复: %s": "Proxy name %[2]s on server %[1]s is duplicated across configs: %[3]s",
+	"绑定端口":                   "Bind port",
+	"UDP端口":                  "UDP port",
+	"KCP端口":                  "KCP port",
+	"Web服务器端口":               "Web server port",
+	"HTTP虚拟主机端口":             "HTTP vhost port",
+	"HTTPS虚拟主机端口":            "HTTPS vhost port",
+	"tcpmux HTTP CONNECT 端口": "tcpmux HTTP CONNECT port",
+	"SSH 隧道网关端口":             "SSH tunnel gateway port",
+	"%s 的远程端口 %d 与服务端 %s 的%s冲突":           "Remote port %[2]d of %[1]s conflicts with the %[4]s of server %[3]s",
+	"%s 的远程端口 %d 不在服务端 %s 允许的端口范围 %s 内":   "Remote port %[2]d of %[1]s is outside the allowed port range %[4]s of server %[3]s",
+	"%s 使用了 %d 个远程端口，超过服务端 %s 的每客户端上限 %d": "%s uses %d remote ports, exceeding the per-client limit of server %s (%d)",
+
+	// pkg/config/disabled.go
+	"以下代理已停用，frpc 不会加载，可在配置管理的代理列表中重新启用": "The following proxies are disabled and will not be loaded by frpc; re-enable them from the proxy list in the config tab",
+	"解析已停用的代理失败: %w": "failed to parse disabled proxies: %w",
+
+	// pkg/config/field_issues.go
+	"未使用插件时需要设置本地端口":     "Local port is required when no plugin is used",
+	"带宽限制无效: %w":         "invalid bandwidth limit: %w",
+	"访问者名称不能为空":          "Visitor name cannot be empty",
+	"需要填写要访问的代理名称":       "The name of the proxy to visit is required",
+	"服务器地址不能为空":          "Server address cannot be empty",
+	"%s 与 %s 使用了同一端口 %d": "%s and %s use the same port %d",
+
+	// pkg/config/file_meta.go
+	"最后由 %s 于 %s 修改": "Last modified by %s on %s",
+
+	// pkg/config/format.go
+	"不支持写入 %s 格式":  "Writing %s format is not supported",
+	"不支持的配置格式: %s": "Unsupported config format: %s",
+
+	// pkg/config/headers.go
+	"请求头 %s 格式无效，应为 名称=值": "invalid request header %s, expected name=value",
+	"请求头名称 %q 无效":         "invalid request header name %q",
+
+	// pkg/config/includes.go
+	"includes 路径 %s 无效: %w": "invalid includes path %s: %w",
+	"读取包含文件 %s 失败: %w":      "failed to read include file %s: %w",
+	"解析包含文件 %s 失败: %w":      "failed to parse include file %s: %w",
+	"序列化包含文件 %s 失败: %w":     "failed to serialize include file %s: %w",
+	"备份配置失败: %w":            "Failed to back up config: %w",
+	"写入包含文件 %s 失败: %w":      "failed to write include file %s: %w",
+	"删除包含文件 %s 失败: %w":      "failed to delete include file %s: %w",
+
+	// pkg/config/ini.go
+	"第 %d 行: 配置段格式无效: %s":                 "Line %d: invalid section header: %s",
+	"第 %d 行: 配置段名称不能为空":                   "Line %d: section name cannot be empty",
+	"第 %d 行: 配置项必须位于配置段内":                 "Line %d: entries must be inside a section",
+	"第 %d 行: 缺少 '=': %s":                  "Line %d: missing '=': %s",
+	"读取 INI 内容失败: %w":                     "Failed to read INI content: %w",
+	"读取 INI 文件失败: %w":                     "Failed to read INI file: %w",
+	"转换 INI 文件失败: %w":                     "Failed to convert INI file: %w",
+	"未找到 [common] 配置段，仅迁移了代理配置":           "No [common] section found, only proxies were migrated",
+	"无法识别配置类型，请确认这是 frps.ini 或 frpc.ini":  "Cannot detect the config type, make sure this is frps.ini or frpc.ini",
+	"[common] %s 暂不支持迁移，已忽略":              "[common] %s is not supported for migration yet, ignored",
+	"[common] %s 的值 '%s' 无效: %v":          "[common] invalid value '%[2]s' for %[1]s: %[3]v",
+	"[%s] %s 暂不支持迁移，已忽略":                  "[%s] %s is not supported for migration yet, ignored",
+	"[%s] %s 的值 '%s' 无效: %w":              "[%s] invalid value '%[3]s' for %[2]s: %[4]w",
+	"[%s] plugin_%s 暂不支持迁移，已忽略":           "[%s] plugin_%s cannot be migrated yet and was ignored",
+	"[%s] local_port 无效: %w":              "[%s] invalid local_port: %w",
+	"[%s] remote_port 无效: %w":             "[%s] invalid remote_port: %w",
+	"[%s] local_port 与 remote_port 数量不一致": "[%s] local_port and remote_port counts do not match",
+	"端口必须是数字":                             "Port must be a number",
+	"端口范围 %s 无效":                          "Invalid port range %s",
+	"端口不能为空":                              "Port cannot be empty",
+
+	// pkg/config/init.go
+	"创建工作目录失败: %w":      "Failed to create work directory: %w",
+	"创建日志目录失败: %w":      "Failed to create log directory: %w",
+	"创建默认服务端配置文件失败: %w": "Failed to create default server config file: %w",
+	"创建默认客户端配置文件失败: %w": "Failed to create default client config file: %w",
+
+	// pkg/config/loader.go
+	"打开配置文件失败: %w":    "Failed to open config file: %w",
+	"写入配置文件失败: %w":    "Failed to write config file: %w",
+	"序列化配置失败: %w":     "Failed to serialize config: %w",
+	"配置尚未加载":          "Config has not been loaded",
+	"代理名称 '%s' 已存在":   "Proxy name '%s' already exists",
+	"未找到名称为 '%s' 的代理": "No proxy named '%s' found",
+	"查找备份文件失败: %w":    "Failed to find backup files: %w",
+	"未找到备份文件":         "No backup file found",
+	"创建导出目录失败: %w":    "Failed to create export directory: %w",
+	"# 配置类型: %s\n\n":  "# Config type: %s\n\n",
+	"导入文件不存在: %s":     "Import file does not exist: %s",
+	"读取导入文件失败: %w":    "Failed to read import file: %w",
+	"解析导入文件失败: %w":    "Failed to parse import file: %w",
+
+	// pkg/config/pairing.go
+	"%s 代理不需要访问者配对":                         "%s proxies do not need visitor pairing",
+	"代理名称不能为空":                              "Proxy name cannot be empty",
+	"访问者 %s 的 serverName 为 %s，与代理 %s 不一致":   "Visitor %s has serverName %s, which does not match proxy %s",
+	"访问者 %s 的类型为 %s，代理 %s 的类型为 %s":          "Visitor %s has type %s, but proxy %s has type %s",
+	"访问者 %s 与代理 %s 的 secretKey 不一致":         "Visitor %s and proxy %s have different secretKeys",
+	"%s 的访问者配置，在另一台机器上与 frpc 一起使用":          "Visitor config for %s, use it with frpc on the other machine",
+	"写入访问者配置失败: %w":                         "failed to write visitor config: %w",
+	"读取访问者配置失败: %w":                         "failed to read visitor config: %w",
+	"解析访问者配置失败: %w":                         "failed to parse visitor config: %w",
+	"配置中没有访问者":                              "no visitors in the config",
+	"访问者 %s 的类型 %s 无效":                      "visitor %s has invalid type %s",
+	"访问者 %s 缺少 secretKey":                   "visitor %s is missing secretKey",
+	"访问者 %s 的密钥与导出时不一致，可能在复制时被截断或改动":        "the key of visitor %s differs from the exported one; it may have been truncated or modified while copying",
+	"访问者需要连接代理所在的服务端 %s:%d，当前客户端连接的是 %s:%d": "The visitor must connect to the proxy's server %s:%d, but this client connects to %s:%d",
+
+	// pkg/config/port_check.go
+	"%s %d/%s 已被占用: %v": "%s %d/%s is already in use: %v",
+	"代理 '%s' 远程端口":      "Proxy '%s' remote port",
+	"访问者 '%s' 绑定端口":     "Visitor '%s' bind port",
+
+	// pkg/config/port_range.go
+	"端口范围 %s 不能同时设置 single 和 start/end": "Port range %s cannot set both single and start/end",
+	"端口范围 %s 无效: %w":                    "Invalid port range %s: %w",
+	"端口必须在 1-65535 范围内":                 "Port must be between 1 and 65535",
+	"端口范围 %s 的起始端口大于结束端口":               "Port range %s starts after it ends",
+	"端口范围 %s 与 %s 重叠":                   "Port ranges %s and %s overlap",
+
+	// pkg/config/presets.go
+	"SSH 远程登录":                     "SSH remote login",
+	"通过公网端口访问本机 SSH":               "Access this machine's SSH through a public port",
+	"建议禁用密码登录，仅使用密钥认证":             "Disable password login and use key authentication only",
+	"SSH (私密访问)":                   "SSH (private access)",
+	"不开放公网端口，仅持有密钥的访问者可连接":         "No public port; only visitors holding the secret key can connect",
+	"HTTP 网站 / Web 应用":             "HTTP website / web app",
+	"通过域名访问本地 Web 服务":              "Access a local web service by domain",
+	"HTTPS 网站":                     "HTTPS website",
+	"本地服务自行处理 TLS，按域名转发":           "The local service handles TLS; traffic is routed by domain",
+	"Windows 远程桌面":                 "Windows Remote Desktop",
+	"通过公网端口访问 RDP":                 "Access RDP through a public port",
+	"RDP 常被扫描爆破，请使用强密码并考虑改用私密访问":   "RDP is a frequent brute-force target; use a strong password and consider private access",
+	"VNC 远程桌面":                     "VNC remote desktop",
+	"通过公网端口访问 VNC":                 "Access VNC through a public port",
+	"VNC 默认不加密，请设置强密码":             "VNC is unencrypted by default; set a strong password",
+	"通过公网端口访问 MySQL 数据库":           "Access a MySQL database through a public port",
+	"数据库直接暴露在公网风险较高，请限制账号权限":       "Exposing a database to the internet is risky; restrict account privileges",
+	"通过公网端口访问 PostgreSQL 数据库":      "Access a PostgreSQL database through a public port",
+	"通过公网端口访问 Redis":               "Access Redis through a public port",
+	"Redis 默认无密码，务必设置 requirepass": "Redis has no password by default; be sure to set requirepass",
+	"Minecraft Java 版":             "Minecraft Java Edition",
+	"让朋友加入本机 Minecraft 服务器":        "Let friends join the Minecraft server on this machine",
+	"Minecraft 基岩版":                "Minecraft Bedrock Edition",
+	"基岩版服务器使用 UDP":                 "Bedrock servers use UDP",
+	"自定义 TCP 端口":                   "Custom TCP port",
+	"转发任意 TCP 服务":                  "Forward any TCP service",
+	"自定义 UDP 端口":                   "Custom UDP port",
+	"转发任意 UDP 服务":                  "Forward any UDP service",
+	"预设不存在: %s":                    "Preset not found: %s",
+
+	// pkg/config/proxy_meta.go
+	"读取代理标签失败: %w":   "failed to read proxy labels: %w",
+	"解析代理标签失败: %w":   "failed to parse proxy labels: %w",
+	"删除代理标签文件失败: %w": "failed to remove proxy label file: %w",
+	"序列化代理标签失败: %w":  "failed to serialize proxy labels: %w",
+	"写入代理标签失败: %w":   "failed to write proxy labels: %w",
+	"替换代理标签文件失败: %w": "failed to replace proxy label file: %w",
+
+	// pkg/config/quota.go
+	"服务端总流量": "Server total traffic",
+	"%s 日配额": "%s daily quota",
+	"%s 月配额": "%s monthly quota",
+	"无效的流量配额 %q，应为正数加单位，如 500GB、1TB": "invalid traffic quota %q, expected a positive number with a unit such as 500GB or 1TB",
+	"%s: 周期必须是 daily 或 monthly":      "%s: period must be daily or monthly",
+	"%s: %w":                         "%s: %w",
+	"%s: 方向必须是 total、in 或 out":       "%s: direction must be total, in or out",
+	"%s: 重置日期必须在 1-28 之间":            "%s: reset day must be between 1 and 28",
+	"%s: 预警百分比必须在 0-100 之间":          "%s: warning percent must be between 0 and 100",
+	"%s 重复": "duplicate %s",
+
+	// pkg/config/remote.go
+	"读取远程服务器配置失败: %w":                      "Failed to read remote server config: %w",
+	"解析远程服务器配置失败: %w":                      "Failed to parse remote server config: %w",
+	"序列化远程服务器配置失败: %w":                     "Failed to serialize remote server config: %w",
+	"写入远程服务器配置失败: %w":                      "Failed to write remote server config: %w",
+	"替换远程服务器配置失败: %w":                      "Failed to replace remote server config: %w",
+	"名称不能为空":                               "Name cannot be empty",
+	"主机地址不能为空":                             "Host cannot be empty",
+	"未知服务类型 %q，可选: frps / frpc":            "unknown service type %q, options: frps / frpc",
+	"SSH 端口必须在 1-65535 范围内":                "SSH port must be between 1 and 65535",
+	"用户名不能为空":                              "Username cannot be empty",
+	"密码认证需要填写密码":                           "Password authentication requires a password",
+	"未知认证方式 %q，可选: key / password / agent": "Unknown auth method %q, options: key / password / agent",
+	"远程配置路径必须是绝对路径":                        "Remote config path must be absolute",
+	"服务名不能为空":                              "Service name cannot be empty",
+
+	// pkg/config/roundtrip.go
+	"配置结构无法合并": "config structure cannot be merged",
+
+	// pkg/config/roundtrip_toml.go
+	"无法识别的表头: %s":  "unrecognized table header: %s",
+	"无法识别的配置行: %s": "unrecognized config line: %s",
+
+	// pkg/config/schedule.go
+	"自动启动配置的名称不能为空":                    "Autostart profile name cannot be empty",
+	"自动启动配置名称重复: %s":                   "Duplicate autostart profile name: %s",
+	"自动启动配置 %s 的服务必须是 server 或 client": "Service of autostart profile %s must be server or client",
+	"自动启动配置 %s: %w":                    "Autostart profile %s: %w",
+	"无效的时间窗口: %q":                      "Invalid time window: %q",
+	"无效的时间窗口 %q: %w":                   "Invalid time window %q: %w",
+	"无效的时间窗口 %q: 时刻应为 HH:MM-HH:MM":     "Invalid time window %q: time should be HH:MM-HH:MM",
+	"未知的星期: %s":                        "Unknown weekday: %s",
+	"无效的时刻: %s":                        "Invalid time of day: %s",
+
+	// pkg/config/schema.go
+	"类型应为%s，实际为%s":        "should be %s, got %s",
+	"frp 不支持类型 %s，可选: %s": "frp does not support type %s, options: %s",
+	"frp 不认识这个字段，加载时会被忽略": "unknown to frp, will be ignored when loading",
+	"（frp v1 中应写作 %s）":    " (frp v1 expects %s)",
+	"（是否想写 %s？）":          " (did you mean %s?)",
+	"字符串":                 "string",
+	"整数":                  "integer",
+	"布尔值":                 "boolean",
+	"列表":                  "list",
+	"对象":                  "object",
+	"小数":                  "decimal",
+	"空值":                  "null",
+
+	// pkg/config/secret.go
+	"令牌长度必须在 %d-%d 之间": "Token length must be between %d and %d",
+	"生成随机数失败: %w":      "Failed to generate random data: %w",
+
+	// pkg/config/settings.go
+	"读取应用设置失败: %w":               "Failed to read app settings: %w",
+	"解析应用设置失败: %w":               "Failed to parse app settings: %w",
+	"创建设置目录失败: %w":               "Failed to create settings directory: %w",
+	"序列化应用设置失败: %w":              "Failed to serialize app settings: %w",
+	"写入应用设置失败: %w":               "Failed to write app settings: %w",
+	"替换应用设置失败: %w":               "Failed to replace app settings: %w",
+	"无效的 Dashboard 地址: %s":       "Invalid Dashboard URL: %s",
+	"进程状态刷新间隔必须在 1-3600 秒之间":     "Process status refresh interval must be between 1 and 3600 seconds",
+	"API 轮询间隔必须在 1-3600 秒之间":     "API poll interval must be between 1 and 3600 seconds",
+	"流量采样间隔必须在 5-3600 秒之间":       "Traffic sampling interval must be between 5 and 3600 seconds",
+	"日志刷新间隔必须在 1-60 秒之间":         "Log refresh interval must be between 1 and 60 seconds",
+	"日志保留条数必须在 100-100000 之间":    "Log capacity must be between 100 and 100000",
+	"不支持的语言: %s，可选: %s":          "Unsupported language: %s, options: %s",
+	"配置文件路径不能为空":                 "Config file paths cannot be empty",
+	"安装目录必须是绝对路径: %s":            "Install directory must be an absolute path: %s",
+	"无效的 FRP 版本: %s":             "invalid FRP version: %s",
+	"备份保留数量和天数不能为负数":             "Backup count and days cannot be negative",
+	"流量历史保留天数不能为负数":              "Traffic history retention days cannot be negative",
+	"健康检查间隔必须在 0-3600 秒之间":       "Health check interval must be between 0 and 3600 seconds",
+	"延迟采样间隔必须在 0-3600 秒之间":       "latency sampling interval must be between 0 and 3600 seconds",
+	"延迟告警阈值不能为负数":                "latency warning threshold cannot be negative",
+	"自动重启次数、退避和窗口不能为负数":          "Auto-restart retries, backoff and window cannot be negative",
+	"退出等待时间必须在 1-300 秒之间":        "Exit wait time must be between 1 and 300 seconds",
+	"无效的告警 Webhook 地址: %s":       "Invalid alert webhook URL: %s",
+	"无效的 Webhook 地址: %s":         "Invalid webhook URL: %s",
+	"不支持的 Webhook 模板: %s，可选: %s": "Unsupported webhook template: %s, options: %s",
+	"钩子超时时间不能为负数":                "hook timeout cannot be negative",
+	"扩展标签页插件必须设置 name 和 path":    "extension tab plugins must set name and path",
+	"无效的模板目录地址: %s":              "Invalid template catalog URL: %s",
+
+	// pkg/config/share.go
+	"客户端配置缺少服务端地址":     "Client config is missing the server address",
+	"代理 '%s' 不存在":      "Proxy '%s' does not exist",
+	"分享码为空":            "Share code is empty",
+	"分享码格式无效: %w":      "Invalid share code: %w",
+	"不支持的分享码版本: %d":    "Unsupported share code version: %d",
+	"分享码缺少服务端地址":       "Share code is missing the server address",
+	"分享码中的服务端端口无效: %d": "Invalid server port in share code: %d",
+	"分享码缺少代理名称或类型":     "Share code is missing the proxy name or type",
+
+	// pkg/config/ssh_tunnel.go
+	"SSH 隧道网关不支持 %s 代理":         "The SSH tunnel gateway does not support %s proxies",
+	"SSH 隧道网关端口必须在 1-65535 范围内": "SSH tunnel gateway port must be between 1 and 65535",
+	"本地端口必须在 1-65535 范围内":       "Local port must be between 1 and 65535",
+	"远程端口必须在 0-65535 范围内":       "Remote port must be between 0 and 65535",
+	"%s 代理需要自定义域名或子域名":          "%s proxies need a custom domain or subdomain",
+	"stcp 代理需要 secretKey":       "stcp proxies need a secretKey",
+
+	// pkg/config/template_catalog.go
+	"未设置模板目录地址":            "Template catalog URL is not set",
+	"获取模板目录失败: %w":         "Failed to fetch template catalog: %w",
+	"获取模板目录失败，HTTP状态码: %d": "Failed to fetch template catalog, HTTP status code: %d",
+	"读取模板目录失败: %w":         "Failed to read template catalog: %w",
+	"模板目录超过 %d MB 限制":      "Template catalog exceeds the %d MB limit",
+	"读取模板目录缓存失败: %w":       "Failed to read template catalog cache: %w",
+	"解析模板目录失败: %w":         "Failed to parse template catalog: %w",
+	"模板目录中没有可用的模板":         "No usable templates in the catalog",
+
+	// pkg/config/templates.go
+	"查找用户模板失败: %w":      "Failed to find user templates: %w",
+	"以下模板文件无法加载: %s":    "The following template files could not be loaded: %s",
+	"读取模板文件失败: %w":      "Failed to read template file: %w",
+	"解析模板文件失败: %w":      "Failed to parse template file: %w",
+	"模板文件不完整: %s":       "Incomplete template file: %s",
+	"创建模板目录失败: %w":      "Failed to create template directory: %w",
+	"序列化模板失败: %w":       "Failed to serialize template: %w",
+	"写入模板文件失败: %w":      "Failed to write template file: %w",
+	"模板不存在: %s":         "Template not found: %s",
+	"模板名称不能为空":          "Template name cannot be empty",
+	"不能覆盖内置模板: %s":      "Cannot overwrite built-in template: %s",
+	"基础服务端":             "Basic server",
+	"基本的FRP服务端配置":       "Basic FRP server config",
+	"安全服务端":             "Secure server",
+	"带认证的安全服务端配置":       "Secure server config with authentication",
+	"SSH隧道客户端":          "SSH tunnel client",
+	"SSH端口转发客户端配置":      "SSH port forwarding client config",
+	"Web服务客户端":          "Web service client",
+	"HTTP/HTTPS服务客户端配置": "HTTP/HTTPS service client config",
+	"远程桌面客户端":           "Remote desktop client",
+	"RDP/VNC远程桌面客户端配置":  "RDP/VNC remote desktop client config",
+	"数据库客户端":            "Database client",
+	"数据库端口转发客户端配置":      "Database port forwarding client config",
+	"游戏服务器客户端":          "Game server client",
+	"游戏服务器端口转发配置":       "Game server port forwarding config",
+	"安全内网穿透":            "Secure intranet tunnel",
+	"使用STCP的安全内网穿透配置":   "Secure intranet tunnel config using STCP",
+	"配置不能为空":            "Config cannot be empty",
+	"不能重命名内置模板: %s":     "Cannot rename built-in template: %s",
+	"模板已存在: %s":         "Template already exists: %s",
+	"删除旧模板文件失败: %w":     "Failed to remove old template file: %w",
+	"不能删除内置模板: %s":      "Cannot delete built-in template: %s",
+	"删除模板文件失败: %w":      "Failed to delete template file: %w",
+
+	// pkg/config/validator.go
+	"服务端配置错误: %w":                       "Server config error: %w",
+	"客户端配置错误: %w":                       "Client config error: %w",
+	"代理配置错误: %w":                        "Proxy config error: %w",
+	"访问者配置错误: %w":                       "Visitor config error: %w",
+	"%s无效: %w":                          "Invalid %s: %w",
+	"Web服务器地址无效: %w":                    "Invalid web server address: %w",
+	"允许端口范围无效: %w":                      "Invalid allowed port ranges: %w",
+	"每个客户端的最大端口数不能为负数":                  "Max ports per client cannot be negative",
+	"子域名主域名无效: %w":                      "Invalid subdomain host: %w",
+	"HTTP 响应超时不能为负数":                    "HTTP response timeout cannot be negative",
+	"心跳配置无效: %w":                        "Invalid heartbeat settings: %w",
+	"%s无效: %v":                          "Invalid %s: %v",
+	"Web服务器地址无效: %v":                    "Invalid web server address: %v",
+	"允许端口范围无效: %v":                      "Invalid allowed port ranges: %v",
+	"子域名主域名无效: %v":                      "Invalid subdomain host: %v",
+	"自定义 404 页面不可用: %v":                 "Custom 404 page is unavailable: %v",
+	"心跳配置无效: %v":                        "Invalid heartbeat settings: %v",
+	"服务器地址无效: %w":                       "Invalid server address: %w",
+	"服务器端口无效: %w":                       "Invalid server port: %w",
+	"传输配置无效: %w":                        "invalid transport settings: %w",
+	"服务器地址无效: %v":                       "Invalid server address: %v",
+	"服务器端口无效: %v":                       "Invalid server port: %v",
+	"传输配置无效: %v":                        "invalid transport settings: %v",
+	"代理 %d 名称无效: %w":                    "Invalid name for proxy %d: %w",
+	"代理名称 '%s' 重复":                      "Duplicate proxy name '%s'",
+	"代理 '%s' 类型无效: %w":                  "Invalid type for proxy '%s': %w",
+	"代理 '%s' 本地地址无效: %w":                "Invalid local address for proxy '%s': %w",
+	"代理 '%s' 本地端口无效: %w":                "Invalid local port for proxy '%s': %w",
+	"代理 '%s' 配置错误: %w":                  "Proxy '%s' config error: %w",
+	"代理 '%s' 负载均衡配置错误: %w":              "proxy '%s' load balancing error: %w",
+	"代理 '%s' 健康检查配置错误: %w":              "proxy '%s' health check error: %w",
+	"代理 '%s' 插件配置错误: %w":                "proxy '%s' plugin error: %w",
+	"代理 '%s' 带宽限制无效: %w":                "proxy '%s' has an invalid bandwidth limit: %w",
+	"代理 %d 名称无效: %v":                    "Invalid name for proxy %d: %v",
+	"代理 '%s' 类型无效: %v":                  "Invalid type for proxy '%s': %v",
+	"代理 '%s' 本地地址无效: %v":                "Invalid local address for proxy '%s': %v",
+	"代理 '%s' 本地端口无效: %v":                "Invalid local port for proxy '%s': %v",
+	"代理 '%s' 配置错误: %v":                  "Proxy '%s' config error: %v",
+	"代理 '%s' 负载均衡配置错误: %v":              "proxy '%s' load balancing error: %v",
+	"代理 '%s' 健康检查配置错误: %v":              "proxy '%s' health check error: %v",
+	"代理 '%s' 插件配置错误: %v":                "proxy '%s' plugin error: %v",
+	"代理 '%s' 带宽限制无效: %v":                "proxy '%s' has an invalid bandwidth limit: %v",
+	"访问者 %d 名称不能为空":                     "Name of visitor %d cannot be empty",
+	"访问者名称 '%s' 重复":                     "Duplicate visitor name '%s'",
+	"访问者 '%s' 类型无效: %w":                 "Invalid type for visitor '%s': %w",
+	"访问者 '%s' 绑定端口无效: %w":               "Invalid bind port for visitor '%s': %w",
+	"访问者 '%s' 类型无效: %v":                 "Invalid type for visitor '%s': %v",
+	"访问者 '%s' 绑定端口无效: %v":               "Invalid bind port for visitor '%s': %v",
+	"%s 代理不使用远程端口":                      "%s proxies do not use a remote port",
+	"%s 代理不使用自定义域名和子域名":                 "%s proxies do not use custom domains or subdomains",
+	"%s 代理不使用密钥，只有 stcp/sudp/xtcp 代理需要": "%s proxies do not use a secret key; only stcp/sudp/xtcp proxies need one",
+	"代理的 role 只能为 server，访问端请添加访问者":     "A proxy's role can only be server; add a visitor for the visiting side",
+	"远程端口不能为空":                          "Remote port cannot be empty",
+	"HTTP代理必须设置自定义域名或子域名":               "HTTP proxies must set custom domains or a subdomain",
+	"自定义域名无效: %w":                       "Invalid custom domain: %w",
+	"子域名无效: %w":                         "Invalid subdomain: %w",
+	"路由路径、HTTP 认证和请求头改写仅适用于 HTTP 代理":    "locations, HTTP auth and header rewrites only apply to HTTP proxies",
+	"路由路径 %s 必须以 / 开头":                  "location %s must start with /",
+	"设置了 HTTP 认证密码但未设置用户名":              "HTTP auth password is set without a user name",
+	"Host 头改写应为主机名: %s":                 "host header rewrite must be a host name: %s",
+	"请求头无效: %w":                         "invalid request headers: %w",
+	"设置了分组密钥但未设置分组名称":                   "group key is set without a group name",
+	"%s 代理不支持负载均衡分组":                    "%s proxies do not support load balancing groups",
+	"分组名称不能包含空白字符":                      "group name cannot contain whitespace",
+	"分组 %s 未设置分组密钥":                     "Group %s has no group key",
+	"设置了健康检查参数但未选择检查类型":                 "health check options are set but no check type is selected",
+	"HTTP 健康检查路径必须以 / 开头":               "HTTP health check path must start with /",
+	"不支持的健康检查类型: %s":                    "unsupported health check type: %s",
+	"健康检查的超时、间隔和失败次数不能为负数":              "health check timeout, interval and max failures cannot be negative",
+	"健康检查超时(%d 秒)必须小于检查间隔(%d 秒)":        "health check timeout (%d s) must be less than the interval (%d s)",
+	"设置了插件参数但未选择插件":                     "plugin options are set but no plugin is selected",
+	"unix_domain_socket 插件需要设置套接字路径":    "the unix_domain_socket plugin needs a socket path",
+	"static_file 插件需要设置本地目录":            "the static_file plugin needs a local directory",
+	"https2http 插件只能用于 HTTPS 代理":        "the https2http plugin can only be used with HTTPS proxies",
+	"https2http 插件需要设置本地服务地址":           "the https2http plugin needs a local address",
+	"本地服务地址应为 host:port 形式: %s":         "local address must be host:port: %s",
+	"证书和私钥必须同时配置":                       "certificate and key must be set together",
+	"不支持的插件: %s，可选: %s":                 "unsupported plugin: %s, choose from: %s",
+	"设置了插件认证密码但未设置用户名":                  "plugin password is set without a user name",
+	"密钥不能为空":                            "Secret key cannot be empty",
+	"密钥长度不能少于8位":                        "Secret key must be at least 8 characters",
+	"心跳间隔和超时不能小于 -1":                    "Heartbeat interval and timeout cannot be less than -1",
+	"心跳间隔必须小于心跳超时":                      "Heartbeat interval must be less than the heartbeat timeout",
+	"不支持的传输协议: %s，可选: %s":               "unsupported transport protocol: %s, choose from: %s",
+	"连接池数量不能为负数":                        "pool count cannot be negative",
+	"连接超时不能为负数":                         "dial timeout cannot be negative",
+	"代理地址格式无效: %s":                      "invalid proxy URL: %s",
+	"代理地址仅支持 http、https 或 socks5":       "proxy URL must use http, https or socks5",
+	"TLS 证书和私钥必须同时配置":                   "TLS certificate and key must be set together",
+	"已关闭 TLS，但仍配置了证书文件":                 "TLS is disabled but certificate files are still configured",
+	"地址不能为空":                            "Address cannot be empty",
+	"地址格式无效":                            "Invalid address format",
+	"域名格式无效":                            "Invalid domain format",
+	"域名部分长度无效":                          "Invalid domain label length",
+	"域名不能为空":                            "Domain cannot be empty",
+	"子域名不能为空":                           "Subdomain cannot be empty",
+	"子域名只能包含字母、数字和连字符":                  "Subdomain may only contain letters, digits and hyphens",
+	"子域名长度不能超过63个字符":                    "Subdomain cannot exceed 63 characters",
+	"代理名称只能包含字母、数字、下划线和连字符":             "Proxy name may only contain letters, digits, underscores and hyphens",
+	"代理名称长度不能超过50个字符":                   "Proxy name cannot exceed 50 characters",
+	"无效的代理类型: %s":                       "Invalid proxy type: %s",
+	"无效的访问者类型: %s":                      "Invalid visitor type: %s",
+	"本地地址无效: %w":                        "Invalid local address: %w",
+	"本地端口无效: %w":                        "Invalid local port: %w",
+	"服务器地址: %s -> %s":                   "Server address: %s -> %s",
+	"服务器端口: %d -> %d":                   "Server port: %d -> %d",
+	"绑定端口: %d -> %d":                    "Bind port: %d -> %d",
+	"允许端口: %s -> %s":                    "Allowed ports: %s -> %s",
+	"代理数量: %d -> %d":                    "Proxy count: %d -> %d",
+	"代理 %s 类型: %s -> %s":                "Proxy %s type: %s -> %s",
+	"代理 %s 本地端口: %d -> %d":              "Proxy %s local port: %d -> %d",
+	"新增代理: %s":                          "New proxy: %s",
+	"配置为空":                              "Config is empty",
+
+	// pkg/ui/app_layout.go
+	"正在加载...": "Loading...",
+
+	// pkg/ui/app_layout_scroll.go
+	"↕ 第 %d-%d 行，共 %d 行": "↕ Lines %d-%d of %d",
+
+	// pkg/ui/app_settings_form.go
+	"Dashboard 地址:":                "Dashboard URL:",
+	"Dashboard 用户:":                "Dashboard user:",
+	"Dashboard 密码:":                "Dashboard password:",
+	"进程状态(秒):":                     "Process status (s):",
+	"3，刷新 frps/frpc 运行状态的间隔":       "3, interval for refreshing frps/frpc status",
+	"API 轮询(秒):":                   "API poll (s):",
+	"3，仪表盘请求 frps 代理列表的间隔":         "3, interval for fetching the frps proxy list",
+	"流量采样(秒):":                     "Traffic sampling (s):",
+	"5，最小 5，记录流量统计的间隔":             "5, minimum 5, interval for recording traffic stats",
+	"日志刷新(秒):":                     "Log refresh (s):",
+	"1，新日志显示到界面的间隔 (1-60)":         "1, interval for showing new logs (1-60)",
+	"日志保留条数:":                      "Log capacity:",
+	"5000，日志页最多保留的条数 (100-100000)": "5000, maximum entries kept on the Logs tab (100-100000)",
+	"主题:":      "Theme:",
+	"界面语言:":    "Language:",
+	"服务端配置:":   "Server config:",
+	"客户端配置:":   "Client config:",
+	"服务端日志文件:": "Server log file:",
+	"/var/log/frps.log，留空时使用配置中的 log.to": "/var/log/frps.log; empty uses log.to from the config",
+	"客户端日志文件:":                           "Client log file:",
+	"/var/log/frpc.log，留空时使用配置中的 log.to": "/var/log/frpc.log; empty uses log.to from the config",
+	"下载镜像:": "Download mirror:",
+	"https://ghproxy.com/ 或含 {url}/{version}/{filename} 的模板": "https://ghproxy.com/ or a template containing {url}/{version}/{filename}",
+	"下载代理:": "Download proxy:",
+	"http://127.0.0.1:7890 或 socks5://127.0.0.1:1080": "http://127.0.0.1:7890 or socks5://127.0.0.1:1080",
+	"安装目录:":         "Install dir:",
+	"%s，系统范围安装填 %s": "%s, or %s for a system-wide install",
+	"更新通道:":         "Release channel:",
+	"stable / prerelease，prerelease 包含 RC 等预发布版本": "stable / prerelease; prerelease includes RCs and other pre-releases",
+	"备份保留数量:":                             "Backups to keep:",
+	"20，0 表示不限":                           "20, 0 means unlimited",
+	"备份保留天数:":                             "Backup retention (days):",
+	"30，0 表示不限":                           "30, 0 means unlimited",
+	"模板目录地址:":                             "Template catalog URL:",
+	"流量保留天数:":                             "Traffic retention (days):",
+	"7，0 表示不限":                            "7, 0 means unlimited",
+	"健康检查(秒):":                            "Health check (s):",
+	"15，0 表示关闭":                           "15, 0 disables it",
+	"延迟采样(秒):":                            "Latency probe (s):",
+	"5，0 表示关闭":                            "5, 0 disables",
+	"延迟告警(毫秒):":                           "Latency warning (ms):",
+	"300，0 表示不告警":                         "300, 0 disables warnings",
+	"桌面通知:":                               "Desktop notifications:",
+	"告警 Webhook:":                         "Alert webhook:",
+	"https://example.com/hook，留空不发送":      "https://example.com/hook, leave empty to disable",
+	"服务端自动重启:":                            "Auto-restart server:",
+	"客户端自动重启:":                            "Auto-restart client:",
+	"最大重启次数:":                             "Max restarts:",
+	"5，0 表示不限":                            "5, 0 means unlimited",
+	"重启退避(秒):":                            "Restart backoff (s):",
+	"2，之后每次翻倍，最长 60 秒":                    "2, doubles each time, at most 60 seconds",
+	"重启窗口(秒):":                            "Restart window (s):",
+	"300，窗口内超过最大次数后停止重启":                  "300, restarts stop after the max count within the window",
+	"退出时停止进程:":                            "Stop processes on exit:",
+	"yes / no，仅停止本工具启动的 frps/frpc":        "yes / no, only stops frps/frpc started by this tool",
+	"退出等待(秒):":                            "Exit wait (s):",
+	"10，超时后强制结束":                          "10, processes are killed after the timeout",
+	"令牌长度:":                               "Token length:",
+	"32，生成 token/secretKey 的字符数 (16-128)": "32, characters in generated token/secretKey (16-128)",
+	"操作记录:":                               "Audit log:",
+	"yes / no，把启停、配置修改等操作写入 ~/.frp-manager/audit.log": "yes / no, write start/stop, config edits and other actions to ~/.frp-manager/audit.log",
+	"进程状态刷新间隔必须是整数":                                   "Process status refresh interval must be an integer",
+	"API 轮询间隔必须是整数":                                   "API poll interval must be an integer",
+	"流量采样间隔必须是整数":                                     "Traffic sampling interval must be an integer",
+	"日志刷新间隔必须是整数":                                     "Log refresh interval must be an integer",
+	"日志保留条数必须是整数":                                     "Log capacity must be an integer",
+	"备份保留数量必须是整数":                                     "Backups to keep must be an integer",
+	"备份保留天数必须是整数":                                     "Backup retention days must be an integer",
+	"流量保留天数必须是整数":                                     "Traffic retention days must be an integer",
+	"健康检查间隔必须是整数":                                     "Health check interval must be an integer",
+	"延迟采样间隔必须是整数":                                     "latency sampling interval must be an integer",
+	"延迟告警阈值必须是整数":                                     "latency warning threshold must be an integer",
+	"桌面通知%w":                                          "Desktop notifications: %w",
+	"服务端自动重启%w":                                       "Auto-restart server: %w",
+	"客户端自动重启%w":                                       "Auto-restart client: %w",
+	"最大重启次数必须是整数":                                     "Max restarts must be an integer",
+	"重启退避必须是整数":                                       "Restart backoff must be an integer",
+	"重启窗口必须是整数":                                       "Restart window must be an integer",
+	"退出时停止进程%w":                                       "Stop processes on exit: %w",
+	"退出等待时间必须是整数":                                     "Exit wait time must be an integer",
+	"令牌长度必须是整数":                                       "Token length must be an integer",
+	"操作记录%w":                                          "audit log %w",
+	"未知主题 %q，可选: %s":                                  "Unknown theme %q, options: %s",
+	"⚙️ 应用设置":                                         "⚙️ App Settings",
+	"镜像支持 {url}、{version}、{filename} 占位符，不含占位符时作为前缀；留空表示直连": "The mirror supports {url}, {version} and {filename} placeholders and is used as a prefix otherwise; leave empty to connect directly",
+	"保存到 %s • Tab/↑↓: 切换 • Enter: 保存 • Esc: 取消":             "Saved to %s • Tab/↑↓: switch • Enter: save • Esc: cancel",
+	"请填写 yes 或 no": "please enter yes or no",
+
+	// pkg/ui/audit_viewer.go
+	"用户、操作或内容": "user, action or content",
+	"📜 操作记录":   "📜 Audit Log",
+	"已暂停":      "paused",
+	"播放中":      "playing",
+	"回放会话 %s（第 %d/%d 步，%s）":                 "Replaying session %s (step %d/%d, %s)",
+	"⚠️  操作记录未开启，在应用设置中把「操作记录」设为 yes 后开始记录": "⚠️  Audit log is off; set \"Audit log\" to yes in app settings to start recording",
+	"筛选: ":        "Filter: ",
+	"正在读取操作记录...": "Reading audit log...",
+	"还没有操作记录":     "No recorded actions yet",
+	"没有符合条件的记录":   "No matching entries",
+	"共 %d 条":      "%d entries",
+	"时间: %s":      "Time: %s",
+	"用户: %s@%s":   "User: %s@%s",
+	"会话: %s":      "Session: %s",
+	"操作: %s":      "Action: %s",
+	"内容: %s":      "Summary: %s",
+	"详情: %s":      "Detail: %s",
+	"输入筛选条件 | Enter 确认 | ESC 清除":                              "Type to filter | Enter confirm | ESC clear",
+	"%s/%s 逐步查看 | %s 播放/暂停 | %s 退出回放":                         "%s/%s step | %s play/pause | %s exit replay",
+	"%s/%s 选择 | %s 筛选 | %s 回放该会话 | %s 自动回放 | %s 重新读取 | %s 返回": "%s/%s select | %s filter | %s replay session | %s auto replay | %s reload | %s back",
+
+	// pkg/ui/backup_browser.go
+	"✅ 已将 %s 恢复到 %s 的备份，恢复前的内容也已备份": "✅ Restored %s from the backup taken at %s; the previous content was backed up too",
+	"🕘 从备份恢复": "🕘 Restore from Backup",
+	"备份目录: ":  "Backup directory: ",
+	"暂无备份，保存配置时会自动备份旧内容\n\n": "No backups yet; old content is backed up automatically when saving\n\n",
+	"按 ESC 返回菜单":                     "Press ESC to return to the menu",
+	"共 %d 个备份":                       "%d backup(s)",
+	"📝 恢复后的变更":                       "📝 Changes after restoring",
+	"  … 还有 %d 行":                    "  … %d more line(s)",
+	"↑/↓ 选择备份 | Enter/y 恢复 | ESC 返回": "↑/↓ select backup | Enter/y restore | ESC back",
+
+	// pkg/ui/bundle_export.go
+	"尚未加载或创建%s配置":         "No %s config has been loaded or created yet",
+	"生成配置失败: %w":          "Failed to generate config: %w",
+	"📦 导出部署包":             "📦 Export Bundle",
+	"配置: ":                "Config: ",
+	"目标系统: ":              "Target OS: ",
+	"不包含，目标机器需自行安装":       "Not included, install it on the target machine",
+	"包含本机程序":              "Include local binary",
+	" (本机为 %s，无法打包到其他系统)": " (this machine is %s, cannot package for another OS)",
+	"frp 程序: ":            "frp binary: ",
+	"⏳ 正在打包...":           "⏳ Packaging...",
+	"✅ 已导出 %s":            "✅ Exported %s",
+	"按 %s 复制部署包路径":        "Press %s to copy the bundle path",
+	"%s 切换服务端/客户端 | %s 切换目标系统 | %s 是否包含程序 | Enter 导出 | ESC 返回":                        "%s server/client | %s target OS | %s include binary | Enter export | ESC back",
+	"zip 包含配置、start.bat 以及注册 Windows 服务的 install-service.bat / uninstall-service.bat": "zip with config, start.bat and install-service.bat / uninstall-service.bat for the Windows service",
+	"tar.gz 包含配置、start.sh、launchd plist 以及安装到 /opt/frp 的 install.sh":                  "tar.gz with config, start.sh, launchd plist and install.sh that installs to /opt/frp",
+	"tar.gz 包含配置、start.sh、systemd unit 以及安装到 /opt/frp 的 install.sh":                   "tar.gz with config, start.sh, systemd unit and install.sh that installs to /opt/frp",
+
+	// pkg/ui/clients_tab.go
+	"主机名":              "Hostname",
+	"版本":               "Version",
+	"系统/架构":            "OS/Arch",
+	"代理数":              "Proxies",
+	"用户":               "User",
+	"连接时间":             "Connected",
+	"当前 frps 不支持断开客户端": "This frps does not support disconnecting clients",
+	"✅ 已断开客户端 %s (%s)": "✅ Disconnected client %s (%s)",
+	"正在断开客户端 %s":       "Disconnecting client %s",
+	"🖥️ 已连接的客户端 (%d)":  "🖥️ Connected clients (%d)",
+	"当前 frps 未提供客户端列表接口 (/api/client)，请升级 frps 或检查应用设置中的 Dashboard 地址": "This frps does not provide the client list API (/api/client). Upgrade frps or check the Dashboard address in the app settings",
+	"暂无已连接的客户端\n\n启动服务端并在应用设置中配置 Dashboard 地址后，frpc 连接时会显示在这里":         "No connected clients\n\nStart the server and set the Dashboard address in the app settings; connected frpc clients will appear here",
+	"客户端地址: %s\n":        "Client address: %s\n",
+	"连接池: %d | 代理: %d\n": "Pool: %d | Proxies: %d\n",
+	"连接时间: %s":           "Connected: %s",
+	" | 上次断开: %s":        " | Last disconnected: %s",
+	"确定断开客户端 %s (%s) 吗？frpc 会按配置自动重连 (y/N)": "Disconnect client %s (%s)? frpc will reconnect according to its config (y/N)",
+	"❌ 刷新失败: ": "❌ Refresh failed: ",
+
+	// pkg/ui/clipboard.go
+	"系统剪贴板":               "system clipboard",
+	"📋 已复制 %d 行到剪贴板 (%s)": "📋 Copied %d lines to the clipboard (%s)",
+	"📋 已复制到剪贴板 (%s): %s":  "📋 Copied to the clipboard (%s): %s",
+
+	// pkg/ui/config_diagnose.go
+	"🩺 已重新诊断":       "🩺 Diagnosis refreshed",
+	"🩺 配置诊断":        "🩺 Config diagnosis",
+	"📂 已检查的配置 (%d)": "📂 Checked configs (%d)",
+	"无法读取":          "unreadable",
+	"客户端，%d 个代理":    "client, %d proxies",
+	"未知类型":          "unknown type",
+	"✅ 未发现端口冲突、代理重名或 allowPorts 问题":     "✅ No port conflicts, duplicate proxy names or allowPorts problems found",
+	"🔍 发现 %d 个错误，%d 个警告":                "🔍 Found %d errors, %d warnings",
+	"第 %d-%d 条，共 %d 条":                  "Items %d-%d of %d",
+	"客户端按服务端端口与服务端配置对应；当前编辑的配置包含未保存的修改": "Clients are matched to server configs by server port; the configs being edited include unsaved changes",
+	"↑/↓ 滚动 | %s 重新诊断 | ESC 返回菜单":       "↑/↓ scroll | %s re-run | ESC back to menu",
+
+	// pkg/ui/config_file_choice.go
+	"🔍 自动识别类型":           "🔍 Detect type automatically",
+	"🎯 作为服务端配置打开":        "🎯 Open as server config",
+	"💻 作为客户端配置打开":        "💻 Open as client config",
+	"选择配置文件":             "Select config file",
+	"选择服务端配置文件":          "Select server config file",
+	"选择客户端配置文件":          "Select client config file",
+	"❌ 读取配置文件失败: %v":     "❌ Failed to read config file: %v",
+	"按识别结果作为%s打开":        "Open as %s (detected)",
+	"作为%s打开":             "Open as %s",
+	"打开服务端配置 ":           "Open server config ",
+	"打开客户端配置 ":           "Open client config ",
+	"📄 %s 尚不存在，保存%s时会创建": "📄 %s does not exist yet and will be created when the %s is saved",
+	"📁 已将 %s 作为%s打开":     "📁 Opened %s as %s",
+	"服务端配置":              "Server config",
+	"客户端配置":              "Client config",
+	"📁 选择配置文件":           "📁 Select Config File",
+	"选择文件的用途，自动识别时按 bindPort、serverAddr、代理等字段判断": "Choose what the file is for; automatic detection looks at bindPort, serverAddr, proxies and similar fields",
+	"文件: %s": "File: %s",
+	"⚠️  新文件无法识别类型，请选择用途":            "⚠️  A new file has no type to detect, choose what it is for",
+	"⚠️  无法识别配置类型，请选择用途":             "⚠️  Could not detect the config type, choose what it is for",
+	"⚠️  文件看起来是%s，与选择的%s不符":          "⚠️  The file looks like a %s, which does not match the selected %s",
+	"↑/↓ 选择 | Enter 选择文件 | ESC 返回菜单": "↑/↓ select | Enter pick file | ESC back to menu",
+	"↑/↓ 选择 | Enter 确认 | ESC 取消":     "↑/↓ select | Enter confirm | ESC cancel",
+
+	// pkg/ui/config_form.go
+	"服务端监听端口": "Server bind port",
+	"FRP 服务端监听端口，客户端通过此端口连接": "Port the FRP server listens on; clients connect through it",
+	"认证令牌 (可选)": "Auth token (optional)",
+	"客户端连接时使用的认证令牌，留空表示不需要认证": "Token clients use to authenticate; leave empty to disable authentication",
+	"Web 管理界面地址":    "Web dashboard address",
+	"Web 管理界面监听地址":  "Address the web dashboard listens on",
+	"Web 管理界面端口":    "Web dashboard port",
+	"Web 管理界面监听端口":  "Port the web dashboard listens on",
+	"Web 管理用户名":     "Web dashboard username",
+	"Web 管理界面登录用户名": "Username for the web dashboard login",
+	"Web 管理密码":      "Web dashboard password",
+	"Web 管理界面登录密码":  "Password for the web dashboard login",
+	"日志级别":          "Log level",
+	"选择日志记录级别":      "Choose the log level",
+	"📄 日志配置":        "📄 Log Settings",
+	"允许的端口范围":       "Allowed port ranges",
+	"客户端可使用的远程端口，逗号分隔，如 2000-3000,3001；留空表示不限": "Remote ports clients may use, comma-separated, e.g. 2000-3000,3001; leave empty for no limit",
+	"每个客户端最大端口数":                                 "Max ports per client",
+	"单个客户端最多可占用的端口数，留空或 0 表示不限":                  "Maximum number of ports a single client may use; empty or 0 means unlimited",
+	"HTTP 虚拟主机端口":                                "HTTP vhost port",
+	"HTTP 类型代理共用的监听端口，留空表示不启用":                   "Port shared by HTTP proxies; leave empty to disable",
+	"HTTPS 虚拟主机端口":                               "HTTPS vhost port",
+	"HTTPS 类型代理共用的监听端口，留空表示不启用":                  "Port shared by HTTPS proxies; leave empty to disable",
+	"HTTP 响应超时(秒)":                               "HTTP response timeout (seconds)",
+	"HTTP 代理等待后端返回响应头的时间，留空使用默认值 60":             "How long HTTP proxies wait for the backend's response headers; leave empty for the default of 60",
+	"子域名主域名":                                     "Subdomain host",
+	"设置后代理可使用 subdomain，访问地址为 <subdomain>.<主域名>": "When set, proxies can use subdomain and are reachable at <subdomain>.<host>",
+	"自定义 404 页面":                                 "Custom 404 page",
+	"访问的域名没有对应的 HTTP 代理时返回的 HTML 文件，留空使用 frps 自带页面": "HTML file returned when no HTTP proxy matches the requested domain; leave empty to use the built-in frps page",
+	"tcpmux 类型代理使用的 HTTP CONNECT 端口，留空表示不启用":        "HTTP CONNECT port used by tcpmux proxies; leave empty to disable",
+	"tcpmux 透传": "tcpmux passthrough",
+	"开启后 frps 不处理 CONNECT 请求，原样转发给客户端": "When enabled, frps forwards CONNECT requests to the client unchanged",
+	"关闭":      "Off",
+	"开启":      "On",
+	"心跳超时(秒)": "Heartbeat timeout (s)",
+	"超过该时间未收到客户端心跳即断开，留空使用默认值 90，-1 表示关闭": "Clients are disconnected when no heartbeat arrives within this time; empty uses the default 90, -1 disables it",
+	"🌐 端口与虚拟主机": "🌐 Ports and Virtual Hosts",
+	"frp v0.53+ 支持，未运行 frpc 的机器可用 ssh -R 创建代理，留空表示不启用": "Supported by frp v0.53+; machines without frpc can create proxies with ssh -R. Leave empty to disable",
+	"SSH 主机私钥 (可选)":                                          "SSH host private key (optional)",
+	"frps 作为 SSH 服务端使用的私钥文件":                                 "Private key file frps uses as the SSH server",
+	"自动生成私钥路径 (可选)":                                          "Auto-generated private key path (optional)",
+	"未指定主机私钥时 frps 自动生成私钥并保存到此路径":                            "When no host key is set, frps generates one and saves it here",
+	"授权公钥文件 (可选)":                                            "Authorized keys file (optional)",
+	"格式同 authorized_keys，留空时不校验连接者的公钥，任何人都能创建代理":             "Same format as authorized_keys; when empty, public keys are not checked and anyone can create proxies",
+	"🔑 SSH 隧道网关":                                             "🔑 SSH tunnel gateway",
+	"服务器地址":                                                  "Server address",
+	"FRP 服务端的 IP 地址或域名":                                      "IP address or domain of the FRP server",
+	"如: 123.456.789.123 或 your-server.com (本地测试填 127.0.0.1)": "e.g. 123.456.789.123 or your-server.com (use 127.0.0.1 for local testing)",
+	"服务器地址不能包含空格":                                            "Server address cannot contain spaces",
+	"服务器端口":                                                  "Server port",
+	"FRP 服务端监听端口 (默认: 7000)":                                 "Port the FRP server listens on (default: 7000)",
+	"服务端设置的认证令牌，需与服务端一致。如果服务端未设置可留空": "Auth token configured on the server; must match it. Leave empty if the server has none",
+	"留空表示无认证":   "Leave empty for no authentication",
+	"🔧 服务器连接配置": "🔧 Server Connection",
+	"传输协议":      "Transport protocol",
+	"与服务端通信使用的协议，kcp/quic 需服务端开启对应端口": "Protocol used to talk to the server; kcp/quic require the matching port on the server",
+	"连接池数量": "Pool count",
+	"预先建立的连接数，留空或 0 表示不预建": "Connections to open in advance; empty or 0 disables the pool",
+	"连接超时(秒)": "Dial timeout (s)",
+	"连接服务端的超时时间，留空使用默认值 10": "Timeout when connecting to the server; empty uses the default of 10",
+	"代理地址": "Proxy URL",
+	"通过 http/socks5 代理连接服务端，留空表示直连": "Connect to the server through an http/socks5 proxy; empty means direct",
+	"启用 TLS": "Enable TLS",
+	"frpc 默认开启，关闭后与服务端的通信不加密": "On by default in frpc; turning it off leaves traffic to the server unencrypted",
+	"客户端证书": "Client certificate",
+	"双向认证时使用的证书文件路径，需与私钥同时填写": "Certificate file for mutual TLS; must be set together with the key",
+	"客户端私钥": "Client key",
+	"与客户端证书配对的私钥文件路径": "Private key file matching the client certificate",
+	"受信任的 CA": "Trusted CA",
+	"用于校验服务端证书的 CA 文件，留空表示不校验": "CA file used to verify the server certificate; empty skips verification",
+	"TLS 服务端名称": "TLS server name",
+	"校验服务端证书时使用的名称，留空使用服务器地址": "Name used to verify the server certificate; empty uses the server address",
+	"🔒 传输与 TLS":    "🔒 Transport & TLS",
+	"不使用插件，转发本地端口": "No plugin, forward a local port",
+	"代理名称":         "Proxy name",
+	"代理的唯一标识名称 (建议使用有意义的名称，如: web-server, ssh-tunnel)": "Unique name of the proxy (use something meaningful, e.g. web-server, ssh-tunnel)",
+	"代理名称不能包含空格，建议使用连字符":                               "Proxy name cannot contain spaces; use hyphens instead",
+	"代理名称至少需要2个字符":                                     "Proxy name must be at least 2 characters",
+	"代理类型":                                             "Proxy type",
+	"选择代理协议类型 (TCP最常用，HTTP用于网站)":                       "Choose the proxy protocol (TCP is most common, HTTP is for websites)",
+	"TCP - 通用端口转发 (推荐)":                                "TCP - generic port forwarding (recommended)",
+	"HTTP - 网站代理":                                      "HTTP - website proxy",
+	"HTTPS - 安全网站代理":                                   "HTTPS - secure website proxy",
+	"UDP - UDP协议转发":                                    "UDP - UDP forwarding",
+	"STCP - 安全TCP (需要密钥)":                              "STCP - secure TCP (secret key required)",
+	"SUDP - 安全UDP (需要密钥)":                              "SUDP - secure UDP (secret key required)",
+	"XTCP - 点对点TCP (需要密钥)":                             "XTCP - peer-to-peer TCP (secret key required)",
+	"客户端插件":                                            "Client plugin",
+	"使用插件时由 frpc 直接提供服务，无需填写本地地址和端口": "With a plugin frpc serves requests itself, so no local address or port is needed",
+	"本地 IP 地址":        "Local IP address",
+	"要代理的本地服务的 IP 地址": "IP address of the local service to expose",
+	"本地端口":            "Local port",
+	"要代理的本地服务端口 (如: 22=SSH, 80=HTTP, 3389=RDP, 8080=Web服务)": "Port of the local service to expose (e.g. 22=SSH, 80=HTTP, 3389=RDP, 8080=web)",
+	"本地端口不能为空": "Local port cannot be empty",
+	"远程桌面":     "Remote desktop",
+	"Web服务":    "Web service",
+	"🔧 基本代理配置": "🔧 Basic Proxy Settings",
+	"服务端监听的公网端口 (仅TCP/UDP类型需要)": "Public port the server listens on (TCP/UDP only)",
+	"TCP/UDP 代理需要设置远程端口":        "TCP/UDP proxies require a remote port",
+	"🌐 TCP/UDP 配置":              "🌐 TCP/UDP Settings",
+	"自定义域名":                     "Custom domains",
+	"绑定的域名，多个域名用逗号分隔 (仅HTTP/HTTPS类型需要)": "Domains to bind, separated by commas (HTTP/HTTPS only)",
+	"HTTP/HTTPS 代理需要设置自定义域名":            "HTTP/HTTPS proxies require custom domains",
+	"🌐 HTTP/HTTPS 配置":                   "🌐 HTTP/HTTPS Settings",
+	"路由路径":                              "Locations",
+	"只转发匹配这些 URL 前缀的请求，多个用逗号分隔，留空表示全部": "Only forward requests matching these URL prefixes, comma separated; empty forwards everything",
+	"HTTP 认证用户名":             "HTTP auth user",
+	"访问时要求 Basic 认证，留空表示不需要": "Require Basic auth for visitors; empty disables it",
+	"HTTP 认证密码":              "HTTP auth password",
+	"Basic 认证密码，需同时设置用户名":    "Basic auth password; requires a user name",
+	"Host 头改写":               "Host header rewrite",
+	"转发到本地服务前将 Host 头替换为该值，留空表示不改写": "Replace the Host header with this value before forwarding; empty keeps it",
+	"请求头": "Request headers",
+	"转发前设置的请求头，格式 名称=值，多个用逗号分隔": "Headers set before forwarding, as name=value, comma separated",
+	"🧭 HTTP 路由与认证": "🧭 HTTP routing & auth",
+	"密钥":           "Secret key",
+	"用于安全连接的密钥 (仅STCP/SUDP/XTCP类型需要)":                "Secret key for secure connections (STCP/SUDP/XTCP only)",
+	"加密代理需要设置密钥":                                     "Encrypted proxies require a secret key",
+	"🔒 加密代理配置":                                       "🔒 Encrypted Proxy Settings",
+	"套接字路径":                                          "Socket path",
+	"要转发的 Unix 域套接字，如 Docker 的 /var/run/docker.sock": "Unix domain socket to forward, e.g. Docker's /var/run/docker.sock",
+	"🔌 Unix 域套接字":                                    "🔌 Unix domain socket",
+	"本地目录":                                           "Local directory",
+	"通过 HTTP 对外提供的本地目录":                              "Local directory served over HTTP",
+	"去除路径前缀":                                         "Strip prefix",
+	"访问 URL 中需要去掉的前缀，如 static":                       "Prefix removed from request URLs, e.g. static",
+	"🔌 静态文件":                                         "🔌 Static files",
+	"本地服务地址":                                         "Local address",
+	"接收解密后 HTTP 请求的本地服务，host:port 形式":                "Local service receiving the decrypted HTTP requests, as host:port",
+	"证书文件":                                           "Certificate file",
+	"对外提供 HTTPS 使用的证书，留空时 frpc 自动生成自签名证书": "Certificate used to serve HTTPS; empty lets frpc generate a self-signed one",
+	"私钥文件": "Key file",
+	"与证书配对的私钥，需与证书同时填写": "Private key matching the certificate; set both or neither",
+	"🔌 HTTPS 转 HTTP": "🔌 HTTPS to HTTP",
+	"插件用户名":          "Plugin user",
+	"访问插件时需要认证，留空表示不需要": "Require authentication to use the plugin; empty disables it",
+	"插件密码":   "Plugin password",
+	"🔐 插件认证": "🔐 Plugin auth",
+	"负载均衡分组": "Load balancing group",
+	"同组代理共享远程端口或域名并轮流处理请求，仅 TCP/HTTP 支持，留空表示不分组": "Proxies in the same group share a remote port or domain and take turns; TCP/HTTP only, empty means no group",
+	"分组密钥": "Group key",
+	"同组代理需使用相同的密钥，设置了分组时必填": "Proxies in the same group must use the same key; required when a group is set",
+	"健康检查": "Health check",
+	"检查失败时 frpc 会暂时下线该代理，恢复后重新注册": "frpc takes the proxy offline while checks fail and registers it again once healthy",
+	"不启用":                   "Disabled",
+	"TCP - 检查端口能否连接":        "TCP - check that the port accepts connections",
+	"HTTP - 请求路径并检查 2xx 响应": "HTTP - request a path and expect a 2xx response",
+	"检查路径":                  "Check path",
+	"HTTP 健康检查请求的路径":        "Path requested by the HTTP health check",
+	"检查间隔(秒)":               "Check interval (s)",
+	"留空使用默认值 10":            "Empty uses the default of 10",
+	"检查超时(秒)":               "Check timeout (s)",
+	"需小于检查间隔，留空使用默认值 3":     "Must be less than the interval; empty uses the default of 3",
+	"最大失败次数":                "Max failures",
+	"连续失败达到该次数后下线代理，留空使用默认值 1": "Take the proxy offline after this many consecutive failures; empty uses the default of 1",
+	"带宽限制": "Bandwidth limit",
+	"单个代理的最大带宽，单位 KB 或 MB，如 1MB，留空表示不限速": "Maximum bandwidth for this proxy in KB or MB, e.g. 1MB; empty means unlimited",
+	"加密传输": "Encryption",
+	"在 frpc 与 frps 之间加密该代理的流量": "Encrypt this proxy's traffic between frpc and frps",
+	"压缩传输": "Compression",
+	"压缩该代理的流量，适合文本类数据，会增加 CPU 占用": "Compress this proxy's traffic; good for text data, uses more CPU",
+	"⚙️ 高级":                "⚙️ Advanced",
+	"访问者名称":                "Visitor name",
+	"访问者的唯一标识名称":           "Unique name of the visitor",
+	"访问者类型":                "Visitor type",
+	"选择访问者类型":              "Choose the visitor type",
+	"服务器名称":                "Server name",
+	"要访问的代理服务器名称":          "Name of the proxy to access",
+	"服务器名称不能为空":            "Server name cannot be empty",
+	"与代理服务器相同的密钥":          "Same secret key as the proxy",
+	"🔧 基本访问者配置":            "🔧 Basic Visitor Settings",
+	"绑定地址":                 "Bind address",
+	"本地绑定的 IP 地址":          "Local IP address to bind",
+	"本地监听端口":               "Local listening port",
+	"绑定端口不能为空":             "Bind port cannot be empty",
+	"🌐 连接配置":               "🌐 Connection Settings",
+	"服务端配置已完成":             "Server config completed",
+	"客户端配置已完成":             "Client config completed",
+	"代理配置已完成":              "Proxy config completed",
+	"访问者配置已完成":             "Visitor config completed",
+	"\n✅ %s\n\n按 ESC 返回\n": "\n✅ %s\n\nPress ESC to return\n",
+	"必须是整数":                "must be an integer",
+	"不能小于 %d":              "cannot be less than %d",
+	"日志输出位置":               "Log output",
+	"console 输出到控制台，或填写日志文件路径": "console writes to the terminal; or enter a log file path",
+
+	// pkg/ui/config_form_check.go
+	"❌ 有 %d 处配置需要修改，已跳转到第一处所在的分组": "❌ %d settings need changes; jumped to the group of the first one",
+
+	// pkg/ui/config_history.go
+	"没有可撤销的修改": "Nothing to undo",
+	"撤销 ":      "Undo ",
+	"↩️ 已撤销: %s（尚未保存到文件）":        "↩️ Undone: %s (not saved to file yet)",
+	"没有可重做的修改":                   "Nothing to redo",
+	"重做 ":                        "Redo ",
+	"↪️ 已重做: %s（尚未保存到文件）":        "↪️ Redone: %s (not saved to file yet)",
+	"回到历史状态 ":                    "Restore history state ",
+	"🕘 已回到 %s 的状态: %s（尚未保存到文件）":  "🕘 Reverted to the state at %s: %s (not saved to file yet)",
+	"📜 修改历史":                     "📜 Edit History",
+	"● 当前状态 | 可撤销 %d 步，可重做 %d 步": "● Current state | %d step(s) to undo, %d step(s) to redo",
+	"%s/%s 选择 | Enter 回到该状态 | %s 撤销 | %s 重做 | ESC 返回": "%s/%s select | Enter revert to this state | %s undo | %s redo | ESC back",
+
+	// pkg/ui/config_preview.go
+	"🎯 服务端配置文件内容:":         "🎯 Server config file content:",
+	"服务端配置为空":              "Server config is empty",
+	"💻 客户端配置文件内容:":         "💻 Client config file content:",
+	"客户端配置为空":              "Client config is empty",
+	"错误: ":                 "Error: ",
+	"跟随配置文件":               "Same as config file",
+	"格式: %s | 已滚动 %3.0f%%": "Format: %s | Scrolled %3.0f%%",
+	"↑/↓ PgUp/PgDn 滚动 | Home/End 首尾 | %s 行号 | %s 切换格式 | %s 复制客户端配置 | %s 复制服务端配置 | %s frp verify | ESC 返回菜单": "↑/↓ PgUp/PgDn scroll | Home/End top/bottom | %s line numbers | %s switch format | %s copy client config | %s copy server config | %s frp verify | ESC back to menu",
+
+	// pkg/ui/config_search.go
+	"代理名、域名、端口、插件参数、访问者名": "proxy name, domain, port, plugin option, visitor name",
+	"代理 ":    "Proxy ",
+	"访问者 ":   "Visitor ",
+	"🔍 搜索配置": "🔍 Search Config",
+	"在 %d 个字段中搜索，纯数字只匹配包含该数字的端口和值": "Searching %d fields; digit-only queries only match values containing that number",
+	"没有匹配的配置项": "No matching config items",
+	"共 %d 条结果": "%d results",
+	"输入关键字搜索 | ↑/↓ 选择结果 | Enter 跳转 | ESC 返回菜单": "Type to search | ↑/↓ select result | Enter jump | ESC back to menu",
+
+	// pkg/ui/config_tab.go
+	"配置管理":                  "Config",
+	"🎯 服务端配置":               "🎯 Server Config",
+	"💻 客户端配置":               "💻 Client Config",
+	"🔗 添加代理":                "🔗 Add Proxy",
+	"👥 添加访问者":               "👥 Add Visitor",
+	"👀 预览配置":                "👀 Preview Config",
+	"💾 保存配置":                "💾 Save Config",
+	"📥 导入INI配置":             "📥 Import INI Config",
+	"🔄 应用并重载客户端":            "🔄 Apply and Reload Client",
+	"🧙 代理向导":                "🧙 Proxy Wizard",
+	"📋 配置模板":                "📋 Config Templates",
+	"📱 分享/导入配置":             "📱 Share/Import Config",
+	"🧪 验证(frp verify)":      "🧪 Verify (frp verify)",
+	"📑 代理列表":                "📑 Proxy list",
+	"🔐 STCP/XTCP 配对":        "🔐 STCP/XTCP Pairing",
+	"🌐 从服务端导入代理":            "🌐 Import proxies from server",
+	"🔑 SSH 隧道命令":            "🔑 SSH tunnel command",
+	"🔍 发现本机服务":              "🔍 Discover local services",
+	"🕳️ XTCP 打洞诊断":          "🕳️ XTCP Hole Punching Check",
+	"🔁 轮换认证令牌":              "🔁 Rotate auth token",
+	"初始状态":                  "Initial state",
+	"编辑服务端配置":               "Edit server config",
+	"编辑客户端配置":               "Edit client config",
+	"编辑代理 ":                 "Edit proxy ",
+	"编辑访问者 ":                "Edit visitor ",
+	"向导添加代理 ":               "Add proxy via wizard ",
+	"代理 %s 已添加，但保存配置失败: %v": "Proxy %s was added, but saving the config failed: %v",
+	"✅ 已添加代理 %s 并保存到 %s，按 r 应用并重载客户端": "✅ Added proxy %s and saved to %s; press r to apply and reload the client",
+	"❌ 保存%s失败: %v":            "❌ Failed to save %s: %v",
+	"✅ %s已保存到 %s":             "✅ %s saved to %s",
+	"⚠️ 没有已加载的配置可保存":          "⚠️ No loaded config to save",
+	"❌ 尚未编辑客户端配置":             "❌ Client config has not been edited yet",
+	"❌ 进程管理器不可用":              "❌ Process manager is unavailable",
+	"正在测试连接 %s:%d":            "Testing connection to %s:%d",
+	"加载配置文件":                  "Load config files",
+	"📁 配置类型":                  "📁 Config Types",
+	"当前配置文件:":                 "Current config files:",
+	"📄 服务端: %s\n":             "📄 Server: %s\n",
+	"❌ 服务端: %s (不存在)\n":       "❌ Server: %s (missing)\n",
+	"📄 客户端: %s\n":             "📄 Client: %s\n",
+	"❌ 客户端: %s (不存在)\n":       "❌ Client: %s (missing)\n",
+	"配置状态:":                   "Config status:",
+	"✓ 服务端: 端口 %d\n":          "✓ Server: port %d\n",
+	"✗ 服务端: 未加载\n":            "✗ Server: not loaded\n",
+	"✓ 客户端: %s:%d\n":          "✓ Client: %s:%d\n",
+	"  └ 代理: %d个\n":           "  └ Proxies: %d\n",
+	"✗ 客户端: 未加载\n":            "✗ Client: not loaded\n",
+	"操作提示:":                   "Tips:",
+	" 选择菜单\n":                 " select item\n",
+	" 确认选择\n":                 " confirm\n",
+	"Tab 激活表单\n":              "Tab activate form\n",
+	"ESC 退出表单\n":              "ESC leave form\n",
+	"%s 搜索代理、端口、域名\n":         "%s search proxies, ports, domains\n",
+	"%s 撤销 (%d) | %s 重做 (%d)": "%s undo (%d) | %s redo (%d)",
+	"Enter 下一步 | ESC 取消向导":    "Enter next | ESC cancel wizard",
+	"🎯 服务端":                   "🎯 Server",
+	"💻 客户端":                   "💻 Client",
+	"🔗 代理":                    "🔗 Proxy",
+	"👥 访问者":                   "👥 Visitor",
+	"👁️ 配置预览":                 "👁️ Config Preview",
+	"表单操作: Tab/Shift+Tab 切换字段 | ESC 退出编辑 | Ctrl+Tab 回到菜单": "Form: Tab/Shift+Tab switch fields | ESC stop editing | Ctrl+Tab back to menu",
+	"%s 在令牌/密钥输入框中生成随机值":                                  "%s generates a random value in token/secret key fields",
+	"按 Tab 键激活表单编辑":                                       "Press Tab to edit the form",
+	"📋 FRP 配置管理":                                          "📋 FRP Config Management",
+	"📊 配置状态":                                              "📊 Config Status",
+	"✓ 服务端: 端口 %d":                                        "✓ Server: port %d",
+	" (已设置认证)":                                            " (auth enabled)",
+	"○ 服务端: 未配置\n":                                        "○ Server: not configured\n",
+	"✓ 客户端: %s:%d":                                        "✓ Client: %s:%d",
+	" (%d个代理)":                                            " (%d proxies)",
+	"○ 客户端: 未配置\n":                                        "○ Client: not configured\n",
+	"📚 功能说明":                                              "📚 Features",
+	"• 🎯 服务端配置: 配置FRP服务端参数\n":                             "• 🎯 Server Config: configure FRP server parameters\n",
+	"• 💻 客户端配置: 配置客户端连接信息\n":                              "• 💻 Client Config: configure the client connection\n",
+	"• 🔗 添加代理: 添加端口转发规则\n":                                "• 🔗 Add Proxy: add port forwarding rules\n",
+	"• 👥 添加访问者: 添加P2P连接配置\n":                              "• 👥 Add Visitor: add P2P connection settings\n",
+	"• 📁 选择配置文件: 选择不同的配置文件\n":                             "• 📁 Select Config File: switch to another config file\n",
+	"• 👀 预览配置: 带语法高亮和行号滚动查看配置内容，可切换 YAML/TOML\n":                                         "• 👀 Preview config: scroll through the config with syntax highlighting and line numbers, switchable between YAML/TOML\n",
+	"• 💾 保存配置: 保存当前配置到文件\n":                                                              "• 💾 Save Config: save the current config to file\n",
+	"• 📥 导入INI配置: 将旧版 frpc.ini/frps.ini 迁移为新格式\n":                                        "• 📥 Import INI Config: migrate legacy frpc.ini/frps.ini to the new format\n",
+	"• 🔄 应用并重载客户端: 校验并保存客户端配置后热重载 frpc (快捷键 %s)\n":                                       "• 🔄 Apply and Reload Client: validate and save the client config, then hot-reload frpc (shortcut %s)\n",
+	"• 🔌 测试连接: 按客户端配置连接服务端并验证 token (快捷键 %s)\n":                                          "• 🔌 Test Connection: connect to the server with the client config and verify the token (shortcut %s)\n",
+	"• 🧙 代理向导: 选择 SSH、网站、远程桌面、数据库等常见服务，自动填好端口 (快捷键 %s)\n":                                "• 🧙 Proxy Wizard: pick common services like SSH, websites, remote desktop or databases with ports pre-filled (shortcut %s)\n",
+	"• 🕘 从备份恢复: 每次保存都会自动备份旧配置，可预览差异后恢复 (快捷键 %s)\n":                                       "• 🕘 Restore from Backup: every save backs up the old config; preview the diff and restore (shortcut %s)\n",
+	"• 📜 修改历史: 查看每次修改的时间和内容，%s 撤销、%s 重做 (快捷键 %s)\n":                                      "• 📜 Edit History: see when and what changed, %s to undo, %s to redo (shortcut %s)\n",
+	"• 📋 配置模板: 应用或合并内置/自定义模板，可将当前配置保存为模板 (快捷键 %s)\n":                                     "• 📋 Config Templates: apply or merge built-in/custom templates, save the current config as a template (shortcut %s)\n",
+	"• 📦 导出部署包: 将配置、启动脚本、系统服务定义和可选的 frp 程序打包，复制到目标机器即可部署\n":                              "• 📦 Export Bundle: package the config, start scripts, service definition and optionally the frp binary to copy to the target machine\n",
+	"• 📱 分享/导入配置: 将服务端地址、token 和一个代理编码为分享码和终端二维码，或粘贴分享码导入\n":                             "• 📱 Share/Import Config: encode the server address, token and one proxy as a share code and terminal QR code, or paste a share code to import it\n",
+	"• 🧪 验证(frp verify): 用已安装的 frps/frpc 检查配置文件，发现本工具尚未校验的字段 (快捷键 %s)\n":                 "• 🧪 Verify (frp verify): check config files with the installed frps/frpc to catch fields this tool does not validate yet (shortcut %s)\n",
+	"• 📑 代理列表: 临时停用/重新启用代理而不丢失配置，或以已有代理为基础新建代理 (快捷键 %s)\n":                               "• 📑 Proxy list: temporarily disable/re-enable proxies without losing their config, or create a proxy based on an existing one (shortcut %s)\n",
+	"• 🩺 配置诊断: 交叉检查服务端和所有客户端配置，发现远程端口冲突、代理重名和 allowPorts 问题 (快捷键 %s)\n":                  "• 🩺 Config diagnosis: cross-check the server and all client configs for remote port conflicts, duplicate proxy names and allowPorts problems (shortcut %s)\n",
+	"• 🔐 STCP/XTCP 配对: 一次生成密钥相同的代理和访问者，导出访问者配置给另一台机器，导入时校验密钥 (快捷键 %s)\n":                 "• 🔐 STCP/XTCP pairing: generate a proxy and visitor sharing one key, export the visitor for the other machine, and verify the key on import (shortcut %s)\n",
+	"• 🔍 发现本机服务: 扫描本机（可选局域网）正在监听的端口并识别常见服务，Enter 为选中的服务预填代理 (快捷键 %s)\n":                  "• 🔍 Discover local services: scan listening ports on this machine (optionally the LAN), identify common services and press Enter to prefill a proxy (shortcut %s)\n",
+	"• 🕳️ XTCP 打洞诊断: 通过 STUN 检测 NAT 类型和打洞成功的可能性，可通过 UPnP/NAT-PMP 在路由器上添加端口映射 (快捷键 %s)\n": "• 🕳️ XTCP hole punching check: detect the NAT type and hole punching likelihood via STUN, and add router port mappings via UPnP/NAT-PMP (shortcut %s)\n",
+	"• 🔁 轮换认证令牌: 生成新令牌，先更新并重启服务端，再更新仍使用旧令牌的客户端，清单逐台显示进度 (快捷键 %s)\n\n":                    "• 🔁 Rotate auth token: generate a new token, update and restart the server first, then the clients still using the old token, with per-machine progress in a checklist (shortcut %s)\n\n",
+	"💡 操作提示": "💡 Tips",
+	"• 修改配置后需要手动保存，保存前会自动备份\n":                       "• Changes must be saved manually; the old file is backed up before saving\n",
+	"• 代理配置属于客户端配置的一部分\n":                            "• Proxies are part of the client config\n",
+	"• 可以同时配置多个代理规则\n":                               "• Multiple proxy rules can be configured at once\n",
+	"• 代理较多时按 %s 搜索代理名、域名、端口、插件参数和访问者名，Enter 跳转到匹配项": "• With many proxies, press %s to search proxy names, domains, ports, plugin options and visitor names; Enter jumps to the match",
+	"🔍 启动前检查:": "🔍 Pre-start checks:",
+	"✅ 配置有效，端口均可用，字段均符合 frp 配置结构": "✅ Config is valid, ports are available and all fields match the frp config schema",
+
+	// pkg/ui/crash_report.go
+	"界面没有发生崩溃":       "the interface did not crash",
+	"创建崩溃报告目录失败: %w": "failed to create crash report directory: %w",
+	"写入崩溃报告失败: %w":   "failed to write crash report: %w",
+
+	// pkg/ui/dashboard_autostart.go
+	"✅ 已停用自动启动 %s": "✅ Autostart %s disabled",
+	"✅ 已启用自动启动 %s": "✅ Autostart %s enabled",
+	"⏰ 自动启动":       "⏰ Autostart",
+	"全天":           "All day",
+	"● 运行中":        "● Running",
+	"已停用":          "Disabled",
+	"○ 未运行":        "○ Not running",
+	"等待时间窗口":       "Waiting for time window",
+
+	// pkg/ui/dashboard_cards.go
+	"📈 流量":     "📈 Traffic",
+	"⏰ 运行时间":   "⏰ Uptime",
+	"状态: ":     "Status: ",
+	"检测中":      "Checking",
+	"端口: ":     "Port: ",
+	"API 不可达":  "API unreachable",
+	"端口: %d":   "Port: %d",
+	"版本: ":     "Version: ",
+	"代理: ":     "Proxies: ",
+	"代理: %d 个": "Proxies: %d",
+	"(%d 在线)":  "(%d online)",
+	"不可达":      "Unreachable",
+	"上行: ":     "Up: ",
+	"下行: ":     "Down: ",
+	"服务端: ":    "Server: ",
+	"客户端: ":    "Client: ",
+
+	// pkg/ui/dashboard_labels.go
+	"❌ 还没有代理标签，可在配置管理的代理列表中按 %s 添加": "❌ No proxy labels yet; press %s in the Config tab's proxy list to add some",
+	"按标签分组": "Group by label",
+
+	// pkg/ui/dashboard_latency.go
+	"未配置客户端":              "no client configured",
+	"📶 到 frps 的延迟":        "📶 Latency to frps",
+	"%s • 每 %d 秒采样":       "%s • sampled every %d s",
+	"无法连接":                "unreachable",
+	"当前 %s  平均 %s  最高 %s": "now %s  avg %s  max %s",
+
+	// pkg/ui/dashboard_poll.go
+	"⏸ 已暂停自动刷新": "⏸ Auto refresh paused",
+	"▶ 已恢复自动刷新": "▶ Auto refresh resumed",
+
+	// pkg/ui/dashboard_tab.go
+	"本地地址":     "Local Address",
+	"启动时间":     "Started",
+	"端到端":      "End-to-end",
+	"标签":       "Labels",
+	"仪表盘":      "Dashboard",
+	"📋 代理状态详情": "📋 Proxy Status",
+	"暂无活跃代理\n\n请在配置管理中添加代理配置，或启动 FRP 客户端": "No active proxies\n\nAdd proxies in Config or start the FRP client",
+
+	// pkg/ui/dashboard_target.go
+	"❌ 没有其他 Dashboard 目标，可在 settings.yaml 的 dashboardTargets 中添加": "❌ No other Dashboard targets, add them under dashboardTargets in settings.yaml",
+	"✅ 已切换到 Dashboard 目标 %s (%s)":                                 "✅ Switched to Dashboard target %s (%s)",
+	"🌐 切换 Dashboard 目标":                                           "🌐 Switch Dashboard Target",
+	"令牌":                                                          "token",
+	"不校验证书":                                                       "skip verify",
+	"%s 切换":                                                       "%s switch",
+
+	// pkg/ui/dns_check.go
+	"🌐 域名解析:": "🌐 Domain DNS:",
+	"⏳ 正在检查 HTTP/HTTPS 代理的域名是否指向 frps...":       "⏳ Checking whether HTTP/HTTPS proxy domains point at frps...",
+	"%d 个代理只设置了子域名，服务端配置未设置 subDomainHost，无法检查": "%d proxies only set a subdomain and the server config has no subDomainHost, so they cannot be checked",
+
+	// pkg/ui/extension.go
+	"加载扩展标签页 %s 失败: %w": "failed to load extension tab %s: %w",
+
+	// pkg/ui/extension_goplugin.go
+	"打开 Go 插件失败: %w":                                       "failed to open Go plugin: %w",
+	"Go 插件没有导出 NewTab: %w":                                 "Go plugin does not export NewTab: %w",
+	"Go 插件的 NewTab 签名应为 func(ui.Services) (ui.Tab, error)": "the Go plugin's NewTab must have the signature func(ui.Services) (ui.Tab, error)",
+
+	// pkg/ui/extension_goplugin_stub.go
+	"当前程序编译时未启用 Go 插件支持，请使用 go build -tags goplugin 重新编译，或改用子进程插件": "this build has no Go plugin support; rebuild with go build -tags goplugin, or use a subprocess plugin instead",
+
+	// pkg/ui/extension_process.go
+	"启动插件失败: %w":      "failed to start plugin: %w",
+	"❌ 插件 %s 已退出: %v": "❌ Plugin %s exited: %v",
+	"⚠️ 插件 %s 已退出":    "⚠️ Plugin %s exited",
+	"正在等待插件 %s 输出...": "Waiting for output from plugin %s...",
+
+	// pkg/ui/file_picker.go
+	"文件名: ": "File name: ",
+	"⚠️ 文件已存在，保存时将覆盖":                                            "⚠️ File exists and will be overwritten",
+	"输入路径 | Tab 补全 | Enter 打开目录/选择文件 | ESC 返回列表":                 "Type a path | Tab complete | Enter open dir/select file | ESC back to list",
+	"↑/↓ 导航 | Enter 进入书签/选择最近文件 | ESC 返回列表":                      "↑/↓ navigate | Enter open bookmark/select recent file | ESC back to list",
+	"输入新文件名 | Tab 补全 | Enter 新建 | ESC 返回列表":                      "Type a new file name | Tab complete | Enter create | ESC back to list",
+	"↑/↓ 导航 | Enter 选择文件/进入目录 | ESC 取消":                          "↑/↓ navigate | Enter select file/open directory | ESC cancel",
+	"↑/↓ 导航 | Enter 进入目录 | %s 选择当前目录 | ESC 取消":                   "↑/↓ navigate | Enter open directory | %s select current directory | ESC cancel",
+	"↑/↓ 导航 | Enter 选择/进入 | %s 选择当前目录 | ESC 取消":                  "↑/↓ navigate | Enter select/open | %s select current directory | ESC cancel",
+	"↑/↓ 导航 | Tab 补全/进入目录/使用已有文件名 | Enter 保存 | ESC 取消 | %s 隐藏文件": "↑/↓ navigate | Tab complete/enter dir/use existing name | Enter save | ESC cancel | %s hidden files",
+	" | %s 复制路径 | %s 显示隐藏文件 | %s 回到主目录":                          " | %s copy path | %s show hidden files | %s go to home directory",
+	"%s 输入路径 | %s 书签/最近文件":                                       "%s type path | %s bookmarks/recent files",
+	"%s 新建文件": "%s new file",
+
+	// pkg/ui/file_picker_places.go
+	"❌ 目录不存在: %s": "❌ Directory does not exist: %s",
+	"❌ 路径不存在: %s": "❌ Path does not exist: %s",
+	"📌 书签":        "📌 Bookmarks",
+	"🕘 最近文件":      "🕘 Recent files",
+	"还没有最近文件，选择或保存文件后会显示在这里": "No recent files yet; files you select or save will show up here",
+
+	// pkg/ui/frp_arch.go
+	"重新安装适用于 %s/%s 的 FRP":             "Reinstall FRP for %s/%s",
+	"✅ 已重新安装适用于 %s/%s 的 FRP":          "✅ Reinstalled FRP for %s/%s",
+	"正在下载适用于 %s/%s 的 FRP...":          "Downloading FRP for %s/%s...",
+	"🔧 按 %s 重新下载适用于 %s/%s 的 FRP %s\n": "🔧 Press %s to download FRP %[4]s for %[2]s/%[3]s again\n",
+
+	// pkg/ui/frp_verify.go
+	"🧪 frp verify:": "🧪 frp verify:",
+	"⏳ 正在使用 frps/frpc 检查配置文件...":      "⏳ Checking config files with frps/frpc...",
+	"检查的是磁盘上的配置文件，未保存的修改请先保存；%s 重新检查": "Checks the config files on disk, save unsaved changes first; %s to check again",
+
+	// pkg/ui/frp_versions.go
+	"切换 FRP 版本到 %s":          "Switch FRP version to %s",
+	"✅ 已切换到 FRP %s":          "✅ Switched to FRP %s",
+	"回滚 FRP 到 %s":            "Roll back FRP to %s",
+	"↩ 已回滚到 FRP %s":          "↩ Rolled back to FRP %s",
+	"🗑 已删除 FRP %s":           "🗑 Removed FRP %s",
+	"📌 %s 固定使用 FRP %s":       "📌 %s pinned to FRP %s",
+	"📌 %s 改为使用当前版本":          "📌 %s now follows the current version",
+	"，重启 frps/frpc 后生效":      "; restart frps/frpc to apply",
+	" (当前)":                  " (current)",
+	"🗂 已安装版本: %s\n":          "🗂 Installed versions: %s\n",
+	"📌 frps 固定使用: %s\n":      "📌 frps pinned to: %s\n",
+	"📌 frpc 固定使用: %s\n":      "📌 frpc pinned to: %s\n",
+	"↩ 按 %s 回滚到 %s，无需重新下载\n": "↩ Press %s to roll back to %s without re-downloading\n",
+	"🗂 已安装版本":                "🗂 Installed versions",
+	"没有按版本安装的 FRP，安装或更新后会保留在这里\n": "No versioned FRP installs yet; installed and updated versions are kept here\n",
+	" ✓ 当前":   " ✓ current",
+	" ↩ 上一个":  " ↩ previous",
+	" 📌 frps": " 📌 frps",
+	" 📌 frpc": " 📌 frpc",
+	"↑/↓ 选择 • %s 设为当前 • %s 固定给 %s • %s 切换 frps/frpc • %s 删除 • ESC 关闭": "↑/↓ select • %s make current • %s pin for %s • %s switch frps/frpc • %s remove • ESC close",
+
+	// pkg/ui/health_alerts.go
+	"... 另有 %d 条告警\n": "... %d more alert(s)\n",
+	"Ctrl+A: 忽略告警":    "Ctrl+A: dismiss alerts",
+
+	// pkg/ui/help_overlay.go
+	"（当前）":      " (current)",
+	"⌨️  快捷键帮助": "⌨️  Keyboard Shortcuts",
+	"表单、确认框和弹窗中使用 Enter 确认、ESC 取消、y 确认操作，输入框中 Tab/↑/↓ 切换焦点和补全，这些按键不可自定义。":               "Forms, confirmations and dialogs use Enter to confirm, ESC to cancel and y to accept, and Tab/↑/↓ move focus and complete in input fields; these keys cannot be customized.",
+	"在 settings.yaml 的 keyBindings 中按 \"<分组>.<操作>\" 自定义快捷键，如 global.quit: \"q,ctrl+c\"": "Customize shortcuts in keyBindings of settings.yaml as \"<group>.<action>\", e.g. global.quit: \"q,ctrl+c\"",
+	"/ESC: 关闭帮助": "/ESC: close help",
+
+	// pkg/ui/ini_migration.go
+	"没有可写入的配置":        "Nothing to write",
+	"✅ 已写入 %s":        "✅ Wrote %s",
+	"选择旧版 INI 配置文件":   "Select a legacy INI config file",
+	"导入 INI 配置 ":      "Import INI config ",
+	"📥 INI 配置迁移":      "📥 INI Config Migration",
+	"源文件: %s\n":       "Source file: %s\n",
+	"目标文件: %s (%s)\n": "Target file: %s (%s)\n",
+	"配置类型: %s | 代理: %d 个 | 访问者: %d 个\n\n": "Config type: %s | Proxies: %d | Visitors: %d\n\n",
+	"⚠️ 迁移提示": "⚠️ Migration Notes",
+	"📝 变更预览":  "📝 Change Preview",
+	"Enter/y 写入文件 | %s 切换 YAML/TOML | ESC 取消": "Enter/y write file | %s toggle YAML/TOML | ESC cancel",
+	"  (无变更)": "  (no changes)",
+
+	// pkg/ui/install_elevated.go
+	"正在下载 FRP，完成后需要通过 sudo 安装到 %s...": "Downloading FRP, it will then be installed to %s via sudo...",
+	"🔐 请在终端中输入 sudo 密码，安装到 %s":        "🔐 Enter your sudo password in the terminal to install to %s",
+
+	// pkg/ui/install_offline.go
+	"📦 选择 FRP 安装包 (frp_<版本>_<系统>_<架构>.tar.gz/zip，校验文件放在同一目录)": "📦 Select FRP archive (frp_<version>_<os>_<arch>.tar.gz/zip, with the checksum file in the same directory)",
+	"从 %s 离线安装 FRP":                  "Install FRP offline from %s",
+	"✅ 已从安装包安装 FRP %s":               "✅ Installed FRP %s from archive",
+	"正在解压 %s，完成后需要通过 sudo 安装到 %s...": "Extracting %s, it then needs to be installed to %s via sudo...",
+	"正在从 %s 安装 FRP %s...":            "Installing FRP %[2]s from %[1]s...",
+
+	// pkg/ui/keymap.go
+	"退出":              "quit",
+	"下一个标签页":          "next tab",
+	"上一个标签页":          "previous tab",
+	"启动服务端":           "start server",
+	"停止服务端":           "stop server",
+	"启动客户端":           "start client",
+	"停止客户端":           "stop client",
+	"忽略告警":            "dismiss alerts",
+	"暂停/恢复刷新":         "Pause/resume refresh",
+	"挂起程序":            "suspend",
+	"快捷键帮助":           "shortcut help",
+	"超出屏幕的内容向上翻页":     "Page up through content taller than the screen",
+	"超出屏幕的内容向下翻页":     "Page down through content taller than the screen",
+	"上移":              "up",
+	"下移":              "down",
+	"查看详情":            "details",
+	"关闭详情":            "close details",
+	"复制访问地址":          "copy address",
+	"端到端探测":           "probe end-to-end",
+	"自动启动":            "Autostart",
+	"启用/停用自动启动":       "Enable/disable autostart",
+	"切换 Dashboard 目标": "switch dashboard target",
+	"按标签筛选":           "Filter by label",
+	"关闭代理连接":          "close proxy connections",
+	"暂停/恢复代理":         "pause/resume proxy",
+	"上一个代理":           "previous proxy",
+	"下一个代理":           "next proxy",
+	"切换时间窗口":          "switch time window",
+	"刷新历史":            "refresh history",
+	"上一个客户端":          "Previous client",
+	"下一个客户端":          "Next client",
+	"刷新列表":            "Refresh list",
+	"断开客户端":           "Disconnect client",
+	"确认选择":            "confirm",
+	"应用并重载客户端":        "apply and reload client",
+	"测试连接":            "test connection",
+	"代理向导":            "proxy wizard",
+	"从备份恢复":           "restore from backup",
+	"撤销":              "undo",
+	"重做":              "redo",
+	"修改历史":            "edit history",
+	"配置模板":            "config templates",
+	"生成令牌/密钥":         "generate token/secret",
+	"同步令牌到客户端":        "sync token to client",
+	"复制客户端配置":         "copy client config",
+	"复制服务端配置":         "copy server config",
+	"显示/隐藏行号":         "Show/hide line numbers",
+	"切换预览格式":          "Switch preview format",
+	"frp verify 验证":   "Verify with frp",
+	"代理列表":            "Proxy list",
+	"复制代理":            "Duplicate proxy",
+	"配置诊断":            "Config diagnosis",
+	"STCP/XTCP 配对":    "STCP/XTCP pairing",
+	"拆分到独立文件/合并回主配置": "Split into own file / merge back",
+	"全部拆分/全部合并":      "Split all / merge all",
+	"从服务端导入代理":       "Import proxies from server",
+	"SSH 隧道命令":       "SSH tunnel command",
+	"发现本机服务":         "Discover local services",
+	"XTCP 打洞诊断":      "XTCP hole punching check",
+	"轮换认证令牌":         "rotate auth token",
+	"编辑标签/备注":        "Edit labels/note",
+	"搜索配置":           "Search config",
+	"删除代理":           "Delete proxy",
+	"启用/停用或勾选":       "Enable/disable or select",
+	"全部启用/停用或全部勾选":   "Enable/disable all or select all",
+	"应用模板(替换)":       "Apply template (replace)",
+	"合并到当前配置":        "Merge into current config",
+	"保存服务端配置为模板":     "Save server config as template",
+	"保存客户端配置为模板":     "Save client config as template",
+	"重命名模板":          "Rename template",
+	"删除模板":           "Delete template",
+	"在线模板":           "Online templates",
+	"刷新模板目录":         "Refresh template catalog",
+	"导入到本地":          "Import locally",
+	"切换服务端/客户端":      "Switch server/client",
+	"切换目标系统":         "Switch target OS",
+	"是否包含程序":         "Include binary",
+	"复制部署包路径":        "Copy bundle path",
+	"复制分享码":          "Copy share code",
+	"导入分享码":          "Import share code",
+	"复制访问者配置":        "Copy visitor config",
+	"写入访问者配置文件":      "Write visitor config file",
+	"切换 YAML/TOML":   "Toggle YAML/TOML",
+	"跳到开头":           "jump to top",
+	"跳到末尾":           "jump to bottom",
+	"切换是否扫描局域网":      "Toggle LAN scan",
+	"添加端口映射":         "Add port mapping",
+	"删除本次添加的映射":      "Delete mappings added this session",
+	"复制新令牌":          "Copy new token",
+	"复制 SSH 命令":      "Copy SSH command",
+	"上一个结果":          "Previous result",
+	"下一个结果":          "Next result",
+	"安装FRP":          "install FRP",
+	"更新FRP":          "update FRP",
+	"卸载FRP":          "uninstall FRP",
+	"选择版本":           "select version",
+	"镜像/代理":          "mirror/proxy",
+	"应用设置":           "app settings",
+	"刷新状态":           "refresh status",
+	"热重载客户端":         "hot-reload client",
+	"切换服务目标":         "switch service target",
+	"切换自动重启":         "toggle auto-restart",
+	"安装为系统服务":        "install as system service",
+	"切换开机自启":         "toggle start on boot",
+	"移除系统服务":         "remove system service",
+	"操作记录":           "Audit log",
+	"使用已安装的 FRP":     "Use installed FRP",
+	"已安装版本":          "installed versions",
+	"回滚版本":           "roll back version",
+	"更新 frp-cli-ui":  "Update frp-cli-ui",
+	"离线安装":           "Offline install",
+	"刷新版本列表":         "Refresh version list",
+	"切换正式版/预发布通道":    "Switch stable/pre-release channel",
+	"关闭列表":           "Close list",
+	"设为当前版本":         "Make current version",
+	"固定/取消固定版本":      "Pin/unpin version",
+	"删除版本":           "Remove version",
+	"返回/退出回放":        "Back/exit replay",
+	"筛选记录":           "Filter entries",
+	"回放该会话":          "Replay session",
+	"自动回放/暂停":        "Auto replay/pause",
+	"重新读取":           "Reload",
+	"跳到第一条":          "Jump to first",
+	"跳到最后一条":         "Jump to last",
+	"添加":             "add",
+	"编辑":             "edit",
+	"删除":             "delete",
+	"测试连接并查看服务状态":    "test connection and show service status",
+	"上传配置":           "upload config",
+	"重启远程服务":         "restart remote service",
+	"查看/停止远程日志":      "view/stop remote logs",
+	"搜索":             "search",
+	"跳转时间":           "jump to time",
+	"清除搜索":           "clear search",
+	"级别":             "level",
+	"来源":             "source",
+	"跟随/暂停":          "follow/pause",
+	"清空":             "clear",
+	"复制日志行":          "copy log line",
+	"导出日志":           "export logs",
+	"选择文件/进入目录":      "Select file/open directory",
+	"选择当前目录":         "Select current directory",
+	"复制路径":           "Copy path",
+	"显示/隐藏隐藏文件":      "Show/hide hidden files",
+	"回到主目录":          "Go to home directory",
+	"输入路径":           "Type path",
+	"书签/最近文件":        "Bookmarks/recent files",
+	"新建文件":           "New file",
+	"全局":             "Global",
+	"流量":             "Traffic",
+	"代理列表/从服务端导入代理":  "Proxy list / import from server",
+	"导出部署包":          "Export bundle",
+	"分享码":            "Share code",
+	"INI 配置迁移":       "INI migration",
+	"配置预览":           "Config preview",
+	"设置":             "Settings",
+	"远程服务器":          "Remote Servers",
+	"日志":             "Logs",
+	"文件选择":           "File picker",
+	"未知的快捷键设置项: %s":  "Unknown key binding: %s",
+	"快捷键 %s 不能为空":    "Key binding %s cannot be empty",
+	"快捷键冲突: %s 同时用于 %s 和 %s": "Key binding conflict: %s is used by both %s and %s",
+
+	// pkg/ui/local_discovery.go
+	"🔍 已预填 %s:%d，请填写远程端口或域名后保存": "🔍 Prefilled %s:%d; fill in the remote port or domain and save",
+	"扫描范围: %s":             "Scanning: %s",
+	"⏳ 正在扫描监听中的 TCP 端口...": "⏳ Scanning for listening TCP ports...",
+	"❌ 扫描失败: %v":           "❌ Scan failed: %v",
+	"没有发现监听中的端口":           "No listening ports found",
+	"(已由代理 %s 转发)":         "(already exposed by proxy %s)",
+	"共 %d 个端口，用时 %s":       "%d ports, took %s",
+	"%s 同时扫描局域网":           "%s also scan the LAN",
+	"%s 只扫描本机":             "%s scan this machine only",
+	"↑/↓ 选择 | Enter 为该服务添加代理 | %s | %s 重新扫描 | ESC 返回菜单": "↑/↓ select | Enter add a proxy for it | %s | %s rescan | ESC back to menu",
+
+	// pkg/ui/log_colors.go
+	"未知的日志级别 %q，可选: %s":                           "Unknown log level %q, options: %s",
+	"日志级别 %s 的颜色 %q 无效，应为 0-255 的终端颜色编号或 #RRGGBB": "Invalid color %[2]q for log level %[1]s, expected a terminal color number 0-255 or #RRGGBB",
+
+	// pkg/ui/log_export.go
+	"❌ 没有可导出的日志":                           "❌ No logs to export",
+	"📤 导出日志 (扩展名 .txt 或 .json，再加 .gz 可压缩)": "📤 Export Logs (.txt or .json, add .gz to compress)",
+	"✅ 已导出 %d 条日志到 %s":                     "✅ Exported %d log lines to %s",
+	"写入日志文件失败: %w":                         "failed to write log file: %w",
+	"序列化日志失败: %w":                          "failed to serialize logs: %w",
+	"压缩日志失败: %w":                           "failed to compress logs: %w",
+
+	// pkg/ui/logs_tab.go
+	"搜索 (支持正则): ":             "Search (regex): ",
+	"跳转到时间 (HH:MM[:SS]): ":    "Jump to time (HH:MM[:SS]): ",
+	"正则无效，已按普通文本搜索":           "Invalid regex, searching as plain text",
+	"时间格式无效: %s":              "Invalid time format: %s",
+	"已跳转到 %s":                 "Jumped to %s",
+	"没有 %s 之后的日志":             "No logs after %s",
+	"暂无匹配的日志":                 "No matching logs",
+	"❌ 没有可复制的日志":              "❌ No log to copy",
+	"全部":                      "All",
+	" 及以上":                    " and above",
+	"⏸ 已暂停":                   "⏸ Paused",
+	"▶ 跟随中":                   "▶ Following",
+	"无":                       "None",
+	"📋 日志查看器":                 "📋 Log Viewer",
+	"级别:":                     "Level:",
+	"来源:":                     "Source:",
+	"搜索:":                     "Search:",
+	"(%d/%d 条)":               "(%d/%d lines)",
+	" • ↑/↓ PgUp/PgDn: 滚动 • ": " • ↑/↓ PgUp/PgDn: scroll • ",
+
+	// pkg/ui/main_dashboard.go
+	"，已使用默认快捷键":                ", using the default key bindings",
+	"启动服务端 ":                   "Start server ",
+	"正在启动服务端":                  "Starting server",
+	"✅ 服务端已启动":                 "✅ Server started",
+	"正在停止服务端":                  "Stopping server",
+	"✅ 服务端已停止":                 "✅ Server stopped",
+	"启动客户端 ":                   "Start client ",
+	"正在启动客户端":                  "Starting client",
+	"✅ 客户端已启动":                 "✅ Client started",
+	"正在停止客户端":                  "Stopping client",
+	"✅ 客户端已停止":                 "✅ Client stopped",
+	"Windows 不支持挂起，请使用 ":       "Suspending is not supported on Windows, use ",
+	" 退出":                      " to quit",
+	"✅ 应用设置已保存":                "✅ App settings saved",
+	"正在初始化...\n\n按 Ctrl+C 退出":  "Initializing...\n\nPress Ctrl+C to quit",
+	"正在运行的 frps/frpc 将继续在后台运行": "Running frps/frpc will keep running in the background",
+	"退出时将停止本工具启动的 frps/frpc":   "frps/frpc started by this tool will be stopped on exit",
+	"确认退出\n\n您确定要退出 FRP 管理工具吗？\n%s\n\n[Y] 是的，退出  [N] 取消\n\n按 Y 或 Enter 确认退出，按 N 或 ESC 取消": "Confirm Exit\n\nAre you sure you want to quit FRP Manager?\n%s\n\n[Y] Yes, quit  [N] Cancel\n\nPress Y or Enter to quit, N or ESC to cancel",
+	"⏸ 刷新已暂停 (%s 恢复)": "⏸ Refresh paused (%s to resume)",
+	"%s/%s 翻页":        "%s/%s to scroll",
+
+	// pkg/ui/nat_check.go
+	"格式应为 \"udp 40000\" 或 \"tcp 6000:16000\"": "expected format \"udp 40000\" or \"tcp 6000:16000\"",
+	"端口必须是 1-65535 之间的数字":                     "ports must be numbers between 1 and 65535",
+	"无 NAT（公网地址）":                             "No NAT (public address)",
+	"锥形 NAT (Cone)":                           "Cone NAT",
+	"对称型 NAT (Symmetric)":                     "Symmetric NAT",
+	"客户端配置中的 xtcp 代理/访问者: %s":                 "xtcp proxies/visitors in the client config: %s",
+	"当前客户端配置中没有 xtcp 代理或访问者，检测结果仍可用于判断本网络能否打洞":        "The client config has no xtcp proxies or visitors; the result still tells whether hole punching can work on this network",
+	"⏳ 正在通过 STUN 检测 NAT 类型并查找支持 UPnP/NAT-PMP 的路由器...": "⏳ Detecting the NAT type via STUN and looking for a UPnP/NAT-PMP router...",
+	"ESC 返回菜单":                    "ESC back to menu",
+	"📡 NAT 类型":                    "📡 NAT type",
+	"✓ %s 看到的地址: %s":              "✓ %s sees: %s",
+	"本机地址: %s":                    "Local address: %s",
+	"，端口差 %+d":                    ", port delta %+d",
+	"，保持本地端口":                     ", local port preserved",
+	"🔀 路由器端口映射":                   "🔀 Router port mapping",
+	"✓ 网关 %s 支持 %s":               "✓ Gateway %s supports %s",
+	"获取 WAN 口地址失败: %v":            "Failed to get WAN address: %v",
+	"WAN 口地址: %s":                 "WAN address: %s",
+	"✓ 已映射 %s %d → 本机 %d（%s 后过期）": "✓ Mapped %s %d → local %d (expires in %s)",
+	"⏳ 正在与路由器通信...":               "⏳ Talking to the router...",
+	"输入要映射的端口，如 udp 40000 或 tcp 6000:16000（本机端口:外部端口）": "Enter the port to map, e.g. udp 40000 or tcp 6000:16000 (local port:external port)",
+	"Enter 添加映射 | ESC 取消": "Enter add mapping | ESC cancel",
+	"提示: frpc 打洞时使用随机端口，固定的端口映射不能保证 xtcp 成功；映射适合让对端直接连接本机的固定端口，或用于确认路由器是否开启了 UPnP/NAT-PMP": "Note: frpc punches holes from random ports, so a fixed port mapping does not guarantee xtcp success; mappings are useful for letting the peer connect directly to a fixed local port, or for checking whether UPnP/NAT-PMP is enabled on the router",
+	"重新检测":         "recheck",
+	"%s 添加映射":      "%s add mapping",
+	"%s 删除本次添加的映射": "%s delete mappings added this session",
+
+	// pkg/ui/operations.go
+	" 等 %d 个操作": " (%d operations)",
+
+	// pkg/ui/pairing.go
+	"要做什么？": "What would you like to do?",
+	"stcp/xtcp/sudp 需要两台机器上的代理和访问者使用相同的 secretKey 和 serverName": "stcp/xtcp/sudp require the proxy and visitor on two machines to share the same secretKey and serverName",
+	"生成代理和访问者 (本机提供服务)":                                         "Generate proxy and visitor (this machine provides the service)",
+	"导入访问者配置 (本机访问另一台机器的服务)":                                    "Import visitor config (this machine accesses a service on another machine)",
+	"stcp - 流量经 frps 中转，最稳定":                                    "stcp - traffic relayed through frps, most reliable",
+	"xtcp - 点对点直连，打洞失败时无法连接":                                    "xtcp - peer-to-peer, fails if hole punching fails",
+	"sudp - UDP 服务，流量经 frps 中转":                                 "sudp - UDP service, traffic relayed through frps",
+	"访问者的 serverName 会设置为这个名称":                                  "The visitor's serverName will be set to this name",
+	"本机上该服务监听的端口":                                               "Port the service listens on locally",
+	"访问者端口":                                                     "Visitor Port",
+	"另一台机器上访问者监听的端口，通过 127.0.0.1:端口 访问本机服务":                     "Port the visitor listens on at the other machine; reach this machine's service via 127.0.0.1:port",
+	"🔐 生成代理和访问者":                                                "🔐 Generate Proxy and Visitor",
+	"访问者配置文件":                                                   "Visitor Config File",
+	"另一台机器上配对助手导出的 .toml/.yaml 文件，导入时会校验密钥":                     "The .toml/.yaml file exported by the pairing assistant on the other machine; the key is verified on import",
+	"文件路径不能为空":                                                  "file path cannot be empty",
+	"📥 导入访问者配置":                                                 "📥 Import Visitor Config",
+	"代理名称 %s 已存在":                                               "Proxy name %s already exists",
+	"添加配对代理 ":                                                   "Add paired proxy ",
+	"✅ 已写入访问者配置 %s，复制到另一台机器后用配对助手导入":                            "✅ Wrote visitor config %s; copy it to the other machine and import it with the pairing assistant",
+	"导入访问者 ":                                                    "Import visitors ",
+	"Enter 下一步 | ESC 返回菜单":                                      "Enter next | ESC back to menu",
+	"✅ 已添加访问者: %s":                                              "✅ Added visitors: %s",
+	"✅ 已替换同名访问者: %s":                                            "✅ Replaced visitors with the same name: %s",
+	"密钥校验通过；导入的内容尚未保存，请使用 💾 保存配置 写入文件":                          "Key verified; the import is not saved yet, use 💾 Save Config to write it to file",
+	"✅ 已添加代理 %s (%s)，保存配置后生效":                                   "✅ Added proxy %s (%s), takes effect after saving",
+	"另一台机器使用下面的访问者配置，访问 %s:%d 即可连接本机的 %d 端口":                    "Use the visitor config below on the other machine; connecting to %s:%d reaches port %d on this machine",
+	"⚠️ xtcp 依赖 NAT 打洞，两端网络不支持时无法连接，可改用 stcp":                   "⚠️ xtcp relies on NAT hole punching and fails when either network does not support it; use stcp instead",
+	"⚠️ 访问者配置包含 secretKey 和 token，请通过可信渠道发送":                    "⚠️ The visitor config contains the secretKey and token, send it over a trusted channel",
+	"%s 复制访问者配置 | %s 写入 %s | ESC 返回菜单":                          "%s copy visitor config | %s write %s | ESC back to menu",
+
+	// pkg/ui/process_resources.go
+	"PID %d • 运行 %s": "PID %d • up %s",
+	"内存":             "Mem",
+	"🧮 进程资源":         "🧮 Process Resources",
+	"CPU 以单核为 100%":  "CPU 100% = one core",
+	"CPU %s %.1f%% • 内存 %s %s • 运行 %s": "CPU %s %.1f%% • Mem %s %s • up %s",
+
+	// pkg/ui/proxy_actions.go
+	"❌ 代理 %s 正在执行其他操作":        "❌ another operation is running on proxy %s",
+	"正在关闭代理 %s":               "Closing proxy %s",
+	"❌ 代理详情尚未加载":              "❌ Proxy details are not loaded yet",
+	"❌ 未设置客户端配置，无法暂停或恢复代理":    "❌ no client config set, cannot pause or resume proxies",
+	"正在暂停代理 %s":               "Pausing proxy %s",
+	"正在恢复代理 %s":               "Resuming proxy %s",
+	"当前 frps 不支持关闭代理":         "this frps does not support closing proxies",
+	"✅ 已关闭代理 %s，frpc 会重新注册代理": "✅ Closed proxy %s, frpc will register it again",
+	"✅ 已暂停代理 %s":              "✅ Paused proxy %s",
+	"✅ 已恢复代理 %s":              "✅ Resumed proxy %s",
+	"确定关闭代理 %s 吗？当前连接会被断开，frpc 会重新注册代理 (y/N)": "Close proxy %s? Current connections will be dropped and frpc will register the proxy again (y/N)",
+	"⏳ 正在关闭代理...": "⏳ Closing proxy...",
+	"⏳ 正在暂停代理...": "⏳ Pausing proxy...",
+	"⏳ 正在恢复代理...": "⏳ Resuming proxy...",
+
+	// pkg/ui/proxy_detail.go
+	"仅限访问者连接":                    "Visitors only",
+	"❌ 该代理仅限访问者连接，没有访问地址":        "❌ This proxy is only reachable through visitors and has no address",
+	"❌ 该代理没有可复制的访问地址":            "❌ This proxy has no address to copy",
+	"❌ 请按 %s 打开详情后复制 %s 代理的访问地址": "❌ Press %s to open details before copying the address of a %s proxy",
+	"🔍 代理详情: %s":                 "🔍 Proxy Details: %s",
+	"访问地址":                       "Access address",
+	"当前连接":                       "Current connections",
+	"客户端版本":                      "Client version",
+	"最近启动":                       "Last started",
+	"最近关闭":                       "Last closed",
+	"更新于 %s • Esc: 返回列表":         "Updated at %s • Esc: back to list",
+
+	// pkg/ui/proxy_labels.go
+	"逗号分隔，如 prod, homelab；仪表盘可按标签筛选和分组": "Comma separated, e.g. prod, homelab; the dashboard can filter and group by label",
+	"备注":           "Note",
+	"🏷️ 代理 %s 的标签": "🏷️ Labels for proxy %s",
+	"🏷️ 已更新代理 %s 的标签，保存在 %s": "🏷️ Updated labels for proxy %s, saved to %s",
+
+	// pkg/ui/proxy_list.go
+	"第 %d/%d 页": "Page %d/%d",
+	"❌ 客户端配置中还没有代理，请先添加代理": "❌ The client config has no proxies yet, add one first",
+	"停用代理 ": "Disable proxy ",
+	"⏸️ 已停用代理 %s，保存配置后生效": "⏸️ Disabled proxy %s, takes effect after saving",
+	"启用代理 ": "Enable proxy ",
+	"▶️ 已启用代理 %s，保存配置后生效": "▶️ Enabled proxy %s, takes effect after saving",
+	"停用全部代理": "Disable all proxies",
+	"⏸️ 已停用全部代理，保存配置后生效": "⏸️ Disabled all proxies, takes effect after saving",
+	"启用全部代理": "Enable all proxies",
+	"▶️ 已启用全部代理，保存配置后生效":                   "▶️ Enabled all proxies, takes effect after saving",
+	"❌ INI 配置不支持 includes，请先迁移到 TOML/YAML": "❌ INI configs do not support includes, migrate to TOML/YAML first",
+	"合并代理 ": "Merge proxy ",
+	"📥 代理 %s 将合并回主配置，保存配置后生效": "📥 Proxy %s will be merged back into the main config, takes effect after saving",
+	"拆分代理 ": "Split proxy ",
+	"📤 代理 %s 将保存到 %s，保存配置后生效": "📤 Proxy %s will be saved to %s, takes effect after saving",
+	"拆分全部代理": "Split all proxies",
+	"📤 全部代理将分别保存到 %s 目录，主配置中生成 includes，保存配置后生效": "📤 All proxies will be saved as separate files under %s with an includes entry in the main config, takes effect after saving",
+	"合并全部代理": "Merge all proxies",
+	"📥 全部代理将合并回主配置，保存配置后生效":       "📥 All proxies will be merged back into the main config, takes effect after saving",
+	"📑 已复制代理 %s 为 %s，修改后提交表单即可添加": "📑 Duplicated proxy %s as %s, submit the form to add it",
+	"❌ 代理 %s 已不在客户端配置中":           "❌ Proxy %s is no longer in the client config",
+	"代理 %s 已更新，但保存配置失败: %v":       "Proxy %s updated, but saving the config failed: %v",
+	"✅ 已更新代理 %s 并保存到 %s":          "✅ Updated proxy %s and saved to %s",
+	"✏️ 已更新代理 %s，保存配置后生效":         "✏️ Updated proxy %s; takes effect after saving the config",
+	"删除代理 ": "Delete proxy ",
+	"代理 %s 已删除，但保存配置失败: %v":                   "Proxy %s deleted, but saving the config failed: %v",
+	"🗑️ 已删除代理 %s 并保存到 %s":                     "🗑️ Deleted proxy %s and saved to %s",
+	"🗑️ 已删除代理 %s，保存配置后生效":                     "🗑️ Deleted proxy %s; takes effect after saving the config",
+	"Enter 保存 | ESC 取消":                       "Enter save | ESC cancel",
+	"确定删除代理 %s 吗？已写入配置文件的代理会同时从文件中删除 (y/N)":   "Delete proxy %s? If it is already in the config file it is removed from the file too (y/N)",
+	"共 %d 个代理，%d 个已停用；停用的代理保存在配置文件末尾的注释中":     "%d proxies, %d disabled; disabled proxies are kept as comments at the end of the config file",
+	"%d 个代理保存在独立文件中，文件内代理全部停用时重命名为 .disabled": "%d proxies are stored in separate files; a file is renamed to .disabled when all its proxies are disabled",
+	"↑/↓ 选择代理 | Enter 编辑 | %s 删除 | %s 启用/停用 | %s 全部启用/停用 | %s 复制并编辑 | %s 拆分/合并 | %s 全部拆分/合并 | %s 标签/备注 | ESC 返回菜单": "↑/↓ select proxy | Enter edit | %s delete | %s enable/disable | %s enable/disable all | %s duplicate and edit | %s split/merge | %s split/merge all | %s labels/note | ESC back to menu",
+
+	// pkg/ui/proxy_probe.go
+	"❌ 该代理仅限访问者连接，无法从外部探测":    "❌ This proxy only accepts visitors and cannot be probed from outside",
+	"❌ UDP 没有连接握手，无法判断隧道是否可用": "❌ UDP has no connection handshake, so the tunnel cannot be checked",
+	"正在探测代理 %s":          "Probing proxy %s",
+	"代理没有远程端口":           "the proxy has no remote port",
+	"代理没有可用的域名":          "the proxy has no usable domain",
+	"frps 未开启 %s 虚拟主机端口": "frps has no %s vhost port enabled",
+	"⏳ 探测中":              "⏳ probing",
+	"❌ 不通":               "❌ down",
+
+	// pkg/ui/proxy_wizard.go
+	"要共享什么服务？":           "What service do you want to share?",
+	"选择后会自动填好端口和推荐的代理类型": "Ports and the recommended proxy type are filled in automatically",
+	"🧙 新建代理向导":           "🧙 New Proxy Wizard",
+	"类型: %s，本地端口: %d":    "Type: %s, local port: %d",
+	"公网端口":               "Public port",
+	"通过 服务器地址:公网端口 访问，需在 frps 允许的端口范围内": "Access via server-address:public-port; must be within the port range allowed by frps",
+	"访问域名": "Access domain",
+	"需要将域名解析到 frps 服务器": "Point the domain at the frps server",
+	"访问密钥": "Access key",
+	"访问者需要使用相同的密钥，已自动生成": "Visitors must use the same key; one was generated automatically",
+	"密钥长度至少6个字符":         "Secret key must be at least 6 characters",
+	"⚙️ 可选设置":            "⚙️ Optional settings",
+	"🔧 确认代理信息":           "🔧 Confirm Proxy",
+	"公网端口 %d 已被其他代理使用":   "Public port %d is already used by another proxy",
+	"\n✅ 已添加代理 %s\n":     "\n✅ Added proxy %s\n",
+
+	// pkg/ui/remote_profile_form.go
+	"名称:         ":                    "Name:         ",
+	"服务类型:     ":                      "Service:      ",
+	"主机:         ":                    "Host:         ",
+	"1.2.3.4 或 frp.example.com":       "1.2.3.4 or frp.example.com",
+	"SSH 端口:     ":                    "SSH port:     ",
+	"用户:         ":                    "User:         ",
+	"认证方式:     ":                      "Auth method:  ",
+	"私钥路径:     ":                      "Private key:  ",
+	"留空自动查找 ~/.ssh/id_ed25519、id_rsa": "Leave empty to look for ~/.ssh/id_ed25519, id_rsa",
+	"密码/口令:    ":                      "Password:     ",
+	"密码认证的密码或私钥口令":                    "Password for password auth, or the private key passphrase",
+	"远程配置:     ":                      "Remote config:",
+	"服务名:       ":                     "Service:      ",
+	"日志文件:     ":                      "Log file:     ",
+	"留空使用 journalctl":                 "Leave empty to use journalctl",
+	"使用 sudo:    ":                    "Use sudo:     ",
+	"本地配置:     ":                      "Local config: ",
+	"留空使用应用设置中的服务端或客户端配置": "empty uses the server or client config from app settings",
+	"SSH 端口必须是数字":         "SSH port must be a number",
+	"使用 sudo %w":          "Use sudo: %w",
+
+	// pkg/ui/remote_tab.go
+	"⏹️ 已停止查看 %s 的日志":            "⏹️ Stopped viewing logs of %s",
+	"已取消连接 ":                     "Cancelled connection to ",
+	"🔑 已信任 %s (%s)":              "🔑 Trusted %s (%s)",
+	"➕ 添加远程服务器":                  "➕ Add Remote Server",
+	"✏️ 编辑远程服务器":                 "✏️ Edit Remote Server",
+	"名称 %s 已存在":                  "Name %s already exists",
+	"✅ 已保存远程服务器 %s":              "✅ Saved remote server %s",
+	"🗑️ 已删除远程服务器 %s":             "🗑️ Deleted remote server %s",
+	"%s 连接成功，%s 服务状态: %s":        "Connected to %s, %s service status: %s",
+	"%s 上的 %s 已重启":               "%s on %s restarted",
+	"❌ 本地配置校验失败: %v":             "❌ Local config validation failed: %v",
+	"❌ 读取本地配置失败: %v":             "❌ Failed to read local config: %v",
+	"已上传 %s 到 %s:%s，按 %s 重启服务生效": "Uploaded %s to %s:%s; press %s to restart the service",
+	"🖥️ 远程服务器":                   "🖥️ Remote Servers",
+	"暂无远程服务器，按 ":                 "No remote servers yet, press ",
+	" 添加\n":                      " to add one\n",
+	"主机: %s@%s (%s 认证)\n":        "Host: %s@%s (%s auth)\n",
+	"本地配置: %s\n":                 "Local config: %s\n",
+	"远程配置: %s\n":                 "Remote config: %s\n",
+	"服务: %s":                     "Service: %s",
+	"日志: %s\n":                   "Logs: %s\n",
+	"⚠️ 首次连接 %s\n公钥指纹: %s\n请确认指纹与服务器一致，按 y 信任并继续，其他键取消": "⚠️ First connection to %s\nKey fingerprint: %s\nMake sure the fingerprint matches the server; press y to trust and continue, any other key to cancel",
+	"将 %s 上传到 %s:%s，远程旧配置会备份为 .bak，确认上传？(y/N)":          "Upload %s to %s:%s? The old remote config will be backed up as .bak (y/N)",
+	"确定删除远程服务器 %s 吗？(y/N)":                              "Delete remote server %s? (y/N)",
+	"⏳ 正在执行远程操作...\n\n":                                 "⏳ Running remote operation...\n\n",
+	"📋 操作记录":                                            "📋 Activity",
+	"📜 远程日志: ":                                          "📜 Remote logs: ",
+	" (实时，按 ":                                           " (live, press ",
+	" 停止)":                                              " to stop)",
+	"按 ":                                                "Press ",
+	" 查看远程 frps 日志":                                     " to view remote frps logs",
+
+	// pkg/ui/self_update.go
+	"❌ 检查 frp-cli-ui 更新失败: %v":              "❌ Failed to check for frp-cli-ui updates: %v",
+	"🆕 frp-cli-ui 有新版本 %s，再按 %s 安装":         "🆕 frp-cli-ui %s is available, press %s again to install",
+	"✨ frp-cli-ui %s 已是最新版本":                "✨ frp-cli-ui %s is already the latest version",
+	"正在下载 frp-cli-ui %s...":                 "Downloading frp-cli-ui %s...",
+	"操作失败: %v":                              "Operation failed: %v",
+	"✅ frp-cli-ui 已更新到 %s":                  "✅ frp-cli-ui updated to %s",
+	"frp-cli-ui %s 将在下次启动时生效":               "frp-cli-ui %s will take effect on next start",
+	"🔁 frp-cli-ui 已更新到 %s，立即重启以使用新版本？(y/N)": "🔁 frp-cli-ui updated to %s, restart now to use the new version? (y/N)",
+	"🆕 frp-cli-ui 有新版本 %s（当前 %s），按 %s 更新\n": "🆕 frp-cli-ui %s is available (current %s), press %s to update\n",
+
+	// pkg/ui/server_import.go
+	"❌ 未配置 Dashboard 地址，无法查询服务端代理":              "❌ No dashboard address configured, cannot query server proxies",
+	"❌ 请先按 %s 勾选要导入的代理":                         "❌ Select the proxies to import with %s first",
+	"从服务端导入 %d 个代理":                             "Import %d proxies from server",
+	"已导入 %d 个代理，但保存配置失败: %v":                    "Imported %d proxies, but saving the config failed: %v",
+	"✅ 已从服务端导入 %s 并保存到 %s，代理处于停用状态，确认后在代理列表中启用": "✅ Imported %s from the server and saved to %s; the proxies are disabled, enable them in the proxy list once confirmed",
+	"；%d 个 stcp/xtcp/sudp 代理需要补填 secretKey":     "; %d stcp/xtcp/sudp proxies need a secretKey",
+	"Dashboard 目标: %s":   "Dashboard target: %s",
+	"⏳ 正在查询服务端已注册的代理...": "⏳ Querying proxies registered on the server...",
+	"❌ 查询服务端代理失败: %v":    "❌ Failed to query server proxies: %v",
+	"服务端上没有已注册的代理":       "No proxies are registered on the server",
+	"(本地已有同名代理)":         "(a local proxy has the same name)",
+	"(离线，服务端没有配置内容)":     "(offline, the server has no config for it)",
+	"(需补填 secretKey)":    "(secretKey required)",
+	"共 %d 个代理，%d 个可导入，已勾选 %d 个；密钥和密码不会通过 API 返回，导入后需要补填":          "%d proxies, %d importable, %d selected; keys and passwords are not returned by the API and must be filled in after import",
+	"↑/↓ 选择代理 | %s 勾选 | %s 全选/取消 | Enter 导入 | %s 重新查询 | ESC 返回菜单": "↑/↓ select proxy | %s toggle | %s select all/none | Enter import | %s re-query | ESC back to menu",
+
+	// pkg/ui/settings_tab.go
+	"FRP 有新版本可用: %s": "New FRP version available: %s",
+	"当前版本: %s":       "Current version: %s",
+	"检查安装状态失败: %v":   "Failed to check installation: %v",
+	"❌ 安装已中止: %s 未通过 SHA256 校验，文件可能已损坏或被篡改，已删除下载文件\n期望: %s\n实际: %s": "❌ Installation aborted: %s failed SHA256 verification; the file may be corrupted or tampered with and has been deleted\nExpected: %s\nActual: %s",
+	"❌ 安装已中止: %s 未通过 SHA256 校验，文件可能已损坏或被篡改\n期望: %s\n实际: %s":         "❌ Installation aborted: %s failed SHA256 verification, the file may be corrupted or tampered with\nExpected: %s\nActual: %s",
+	"查询系统服务失败: %v":         "Failed to query system service: %v",
+	"📋 实时日志":               "📋 Live Logs",
+	"🎯 服务端日志:":             "🎯 Server logs:",
+	"💻 客户端日志:":             "💻 Client logs:",
+	"🔧 FRP 安装状态":           "🔧 FRP Installation",
+	"正在检查安装状态...":          "Checking installation...",
+	"✅ 已安装 (版本: %s)\n":     "✅ Installed (version: %s)\n",
+	"⚠️ 当前使用的是预发布版本，可能不稳定": "⚠️ This is a pre-release version and may be unstable",
+	"📦 检测到 %s 安装的程序，按 %s 直接使用，无需重复下载到 %s\n": "📦 Found programs installed by %s, press %s to use them instead of downloading another copy to %s\n",
+	"📍 使用 %s 中的程序（未由本工具安装）\n":               "📍 Using programs from %s (not installed by this tool)\n",
+	"📁 安装目录: %s（由 %s 管理，请通过包管理器更新或卸载）\n":    "📁 Install dir: %s (managed by %s, update or uninstall with the package manager)\n",
+	"📁 安装目录: %s\n":                   "📁 Install directory: %s\n",
+	"🎯 服务端: %s (%s)\n":               "🎯 Server: %s (%s)\n",
+	"💻 客户端: %s (%s)\n":               "💻 Client: %s (%s)\n",
+	"⚠️ frps 与 frpc 版本不一致":           "⚠️ frps and frpc versions differ",
+	"🔄 有新版本可用: %s\n":                 "🔄 New version available: %s\n",
+	"✨ 已是最新版本\n":                     "✨ Up to date\n",
+	"🎯 已选择版本: %s\n":                  "🎯 Selected version: %s\n",
+	"❌ 未安装\n":                        "❌ Not installed\n",
+	"📁 将安装到: %s\n":                   "📁 Will install to: %s\n",
+	"📦 将安装版本: %s\n":                  "📦 Will install version: %s\n",
+	"📡 更新通道: 预发布（包含 RC 等测试版本）":       "📡 Release channel: pre-release (includes RCs and other test builds)",
+	"🌐 下载镜像: %s\n":                   "🌐 Download mirror: %s\n",
+	"🔌 下载代理: %s\n":                   "🔌 Download proxy: %s\n",
+	"🚀 FRP 服务控制":                     "🚀 FRP Service Control",
+	"🎯 服务端状态: %s\n":                  "🎯 Server status: %s\n",
+	"💻 客户端状态: %s\n":                  "💻 Client status: %s\n",
+	"开":                              "on",
+	"关":                              "off",
+	"不限次数":                           "unlimited",
+	"%d 秒内最多 %d 次":                   "at most %[2]d times in %[1]d seconds",
+	"🔁 自动重启: 服务端 %s / 客户端 %s (%s)\n": "🔁 Auto-restart: server %s / client %s (%s)\n",
+	"⚡ 状态刷新: %d秒":                    "⚡ Status refresh: %ds",
+	"启动服务端失败: %v":                    "Failed to start server: %v",
+	"停止服务端失败: %v":                    "Failed to stop server: %v",
+	"启动客户端失败: %v":                    "Failed to start client: %v",
+	"停止客户端失败: %v":                    "Failed to stop client: %v",
+	"热重载客户端配置 ":                      "Hot-reload client config ",
+	"正在热重载客户端配置":                     "Reloading client config",
+	"✅ 客户端配置已热重载":                    "✅ Client config hot-reloaded",
+	"正在测试连接...":                      "Testing connection...",
+	"正在测试连接":                         "Testing connection",
+	"安装 FRP":                         "Install FRP",
+	"✅ FRP 安装成功！":                    "✅ FRP installed successfully!",
+	"正在下载 FRP...":                    "Downloading FRP...",
+	"更新 FRP":                         "Update FRP",
+	"✅ FRP 更新成功！":                    "✅ FRP updated successfully!",
+	"正在更新 FRP...":                    "Updating FRP...",
+	"下载完成，正在校验并解压...":                "Download complete, verifying and extracting...",
+	"已下载 %s":                         "Downloaded %s",
+	"  剩余 %s":                        "  %s left",
+	"  (断点续传)":                       "  (resumed)",
+	"卸载 FRP":                         "Uninstall FRP",
+	"正在卸载 FRP...":                    "Uninstalling FRP...",
+	"✅ FRP 卸载成功！":                    "✅ FRP uninstalled successfully!",
+	"暂无日志 (状态: ":                     "No logs (status: ",
+	"正在安装 %s 系统服务...":                "Installing %s system service...",
+	"安装 %s 系统服务":                     "Install %s system service",
+	"✅ %s 已注册为系统服务":                  "✅ %s registered as a system service",
+	"开启 %s 开机自启":                     "Enable %s on boot",
+	"关闭 %s 开机自启":                     "Disable %s on boot",
+	"✅ %s 已开启开机自启":                   "✅ Enabled start on boot for %s",
+	"✅ %s 已关闭开机自启":                   "✅ Disabled start on boot for %s",
+	"正在移除 %s 系统服务...":                "Removing %s system service...",
+	"移除 %s 系统服务":                     "Remove %s system service",
+	"✅ %s 系统服务已移除":                   "✅ %s system service removed",
+	"🛠 系统服务":                         "🛠 System Service",
+	"当前系统不支持安装系统服务":                  "Installing system services is not supported on this system",
+	"检查中...":                         "Checking...",
+	"未安装":                            "Not installed",
+	"开机自启: 关":                        "Start on boot: off",
+	"开机自启: 开":                        "Start on boot: on",
+	"版本未知":                           "Unknown version",
+	"✅ 已改用 %s 安装的 FRP: %s":           "✅ Now using FRP installed by %s: %s",
+	"📡 已切换到预发布通道，版本列表包含 RC 等测试版本": "📡 Switched to the pre-release channel; the version list includes RCs and other test builds",
+	"📡 已切换到正式版通道":                 "📡 Switched to the stable channel",
+	" (预发布)":                      " (pre-release)",
+	"📦 选择版本（正式版通道）":               "📦 Select version (stable channel)",
+	"📦 选择版本（预发布通道）":               "📦 Select version (pre-release channel)",
+	"获取版本列表失败: ":                  "Failed to get release list: ",
+	"当前通道没有可用的版本，按 %s 切换通道\n":     "No versions in this channel; press %s to switch channels\n",
+	"正在获取版本列表...\n":               "Fetching release list...\n",
+	" ⚠ 预发布":                      " ⚠ pre-release",
+	" ✓ 已安装":                      " ✓ installed",
+	" (已下载，切换无需重新下载)":             " (downloaded, switching needs no download)",
+	"↑/↓ 选择 • Enter 确认 • %s 切换正式版/预发布通道 • %s 刷新 • %s 取消": "↑/↓ select • Enter confirm • %s switch stable/pre-release channel • %s refresh • %s cancel",
+	"已关闭":        "disabled",
+	"已开启":        "enabled",
+	"🔁 %s自动重启%s": "🔁 %s auto-restart %s",
+
+	// pkg/ui/share_code.go
+	"分享码: ":                    "Share code: ",
+	"生成分享码失败: %w":              "Failed to generate share code: %w",
+	"生成二维码失败: %w":              "Failed to generate QR code: %w",
+	"✅ 已导入 %s:%d，替换了同名代理 '%s'": "✅ Imported %s:%d and replaced proxy '%s'",
+	"✅ 已导入 %s:%d 和代理 '%s'":     "✅ Imported %s:%d and proxy '%s'",
+	"导入分享码 ":                   "Import share code ",
+	"📱 分享/导入客户端配置":             "📱 Share/Import Client Config",
+	"粘贴同事发来的分享码，服务端地址、端口以及分享码中的 token 将覆盖当前客户端配置，代理按名称添加或替换": "Paste a share code from a teammate. Server address, port and any token in the code overwrite the current client config; the proxy is added or replaced by name",
+	"导入的内容尚未保存，请使用 💾 保存配置 写入文件":                              "Imported content is not saved yet, use 💾 Save Config to write it to file",
+	"Enter 导入 | ESC 返回":       "Enter import | ESC back",
+	"代理: %s (%s)  [%d/%d]\n":  "Proxy: %s (%s)  [%d/%d]\n",
+	"服务端: %s:%d\n\n":          "Server: %s:%d\n\n",
+	"⚠️ 分享码包含 token，请只发给可信的人": "⚠️ The share code contains the token, only send it to people you trust",
+	"←/→ 切换代理 | %s 复制分享码 | %s 导入分享码 | ESC 返回": "←/→ switch proxy | %s copy share code | %s import share code | ESC back",
+
+	// pkg/ui/shutdown.go
+	"部分进程未能停止，请手动检查":                     "Some processes could not be stopped, please check manually",
+	"关闭完成，正在退出...":                       "Shutdown complete, exiting...",
+	"正在关闭\n\n正在停止本工具启动的 FRP 进程，请稍候...\n": "Shutting down\n\nStopping FRP processes started by this tool, please wait...\n",
+	"\n\n按 Ctrl+C 跳过等待，剩余进程将在退出后继续停止":    "\n\nPress Ctrl+C to skip waiting; remaining processes will keep stopping after exit",
+
+	// pkg/ui/ssh_tunnel.go
+	"frps 所在机器的地址":                        "Address of the machine running frps",
+	"服务端配置中 sshTunnelGateway.bindPort 的值": "Value of sshTunnelGateway.bindPort in the server config",
+	"本地 IP": "Local IP",
+	"运行 ssh 命令的机器上要暴露的服务地址": "Address of the service to expose on the machine running ssh",
+	"远程端口 (tcp)": "Remote port (tcp)",
+	"frps 上对外监听的端口，留空由 frps 分配": "Port frps listens on publicly; leave empty to let frps assign one",
+	"与子域名至少填写一项":                "Fill in at least this or the subdomain",
+	"子域名":                       "Subdomain",
+	"需要服务端配置 subDomainHost":     "Requires subDomainHost in the server config",
+	"访问密钥 (secretKey)":          "Secret key (secretKey)",
+	"访问者需要使用相同的 secretKey":      "Visitors must use the same secretKey",
+	"在要暴露服务的机器上运行下面的命令，连接保持期间代理一直有效，不需要安装 frpc：":   "Run the command below on the machine whose service you want to expose; the proxy stays up while the connection is open and frpc is not needed:",
+	"⚠️ 当前服务端配置未启用 SSH 隧道网关，请在服务端配置中填写 SSH 隧道网关端口": "⚠️ The current server config does not enable the SSH tunnel gateway; set the SSH tunnel gateway port in the server config",
+	"⚠️ 服务端未配置授权公钥文件，任何能连接该端口的人都可以创建代理":            "⚠️ No authorized keys file is configured on the server; anyone who can reach the port can create proxies",
+	"远程端口由 frps 分配，连接成功后 ssh 会输出实际端口":              "frps assigns the remote port; ssh prints the actual port after connecting",
+	"SSH 隧道网关需要 frps v0.53 及以上版本；用户名 v0 为固定写法":     "The SSH tunnel gateway requires frps v0.53 or later; the user name v0 is fixed",
+	"%s 复制命令 | ESC 返回菜单": "%s copy command | ESC back to menu",
+
+	// pkg/ui/template_browser.go
+	"未设置模板目录地址，请在设置页按 g 填写「模板目录地址」": "Template catalog URL is not set; press g on the Settings tab to fill in \"Template catalog URL\"",
+	"📥 已导入模板 %s":              "📥 Imported template %s",
+	"尚未编辑服务端配置":               "Server config has not been edited yet",
+	"尚未编辑客户端配置":               "Client config has not been edited yet",
+	"内置模板不能重命名":               "Built-in templates cannot be renamed",
+	"内置模板不能删除":                "Built-in templates cannot be deleted",
+	"🗑️ 已删除模板 %s":             "🗑️ Deleted template %s",
+	"从当前服务端配置创建":              "Created from the current server config",
+	"✅ 已将当前服务端配置保存为模板 %s":     "✅ Saved the current server config as template %s",
+	"从当前客户端配置创建":              "Created from the current client config",
+	"✅ 已将当前客户端配置保存为模板 %s":     "✅ Saved the current client config as template %s",
+	"✅ 模板已重命名为 %s":            "✅ Template renamed to %s",
+	"应用模板 ":                   "Apply template ",
+	"合并模板 ":                   "Merge template ",
+	"✅ 已%s，可按 %s 撤销，确认后请保存配置": "✅ Done: %s. Press %s to undo; save the config once you are happy with it",
+	"用户模板目录: ":                "User template directory: ",
+	"自定义":                     "Custom",
+	"内置":                      "Built-in",
+	"👀 模板内容":                  "👀 Template Content",
+	"新模板名称: ":                 "New template name: ",
+	"重命名为: ":                  "Rename to: ",
+	"Enter 确认 | ESC 取消":       "Enter confirm | ESC cancel",
+	"确定删除模板 %s 吗？(y/N)":       "Delete template %s? (y/N)",
+	"%s 应用(替换) | %s 合并到当前配置 | %s/%s 保存当前服务端/客户端配置为模板 | %s 重命名 | %s 删除 | %s 在线模板 | ESC 返回": "%s apply (replace) | %s merge into current config | %s/%s save current server/client config as template | %s rename | %s delete | %s online templates | ESC back",
+	"🌐 在线模板":              "🌐 Online Templates",
+	"目录地址: ":              "Catalog URL: ",
+	"\n⏳ 正在获取模板目录...\n":   "\n⏳ Fetching template catalog...\n",
+	"使用 %s 的缓存":           "Using cache from %s",
+	"📴 离线模式，":             "📴 Offline mode, ",
+	"，按 %s 刷新":            ", press %s to refresh",
+	"⚠️ 已忽略 %d 个格式不完整的模板": "⚠️ Ignored %d incomplete template(s)",
+	" (本地已有同名模板)":         " (a local template with this name exists)",
+	"↑/↓ 选择 | %s 导入到本地 | %s 刷新 | ESC 返回本地模板": "↑/↓ select | %s import locally | %s refresh | ESC back to local templates",
+	"生成预览失败: ": "Failed to generate preview: ",
+
+	// pkg/ui/token_generator.go
+	"请先将焦点移到认证令牌或密钥输入框":          "Move focus to the auth token or secret key field first",
+	"🔑 已生成 %d 位密钥并复制到剪贴板 (%s)":   "🔑 Generated a %d-character secret key and copied it to the clipboard (%s)",
+	"🔑 已生成 %d 位认证令牌并复制到剪贴板 (%s)": "🔑 Generated a %d-character auth token and copied it to the clipboard (%s)",
+	"，按 %s 同步到客户端配置":             ", press %s to sync it to the client config",
+	"尚未加载或创建客户端配置":               "No client config has been loaded or created yet",
+	"认证令牌为空":                     "Auth token is empty",
+	"同步认证令牌到客户端配置":               "Sync auth token to client config",
+	"✅ 已同步认证令牌到客户端配置，保存后生效":      "✅ Auth token synced to the client config, save to apply",
+	"密钥为空": "Secret key is empty",
+	"客户端配置中没有 serverName 为 '%s' 的访问者": "No visitor with serverName '%s' in the client config",
+	"同步密钥到访问者 ":                       "Sync secret key to visitor ",
+	"✅ 已同步密钥到 %d 个访问者，保存后生效":          "✅ Secret key synced to %d visitor(s), save to apply",
+	"当前表单没有可同步的令牌":                    "The current form has no token to sync",
+
+	// pkg/ui/token_rotation.go
+	"令牌与服务端不同，未关联":      "token differs from the server, not linked",
+	"轮换认证令牌: %s":        "Rotate auth token: %s",
+	"服务端未完成轮换，未修改":      "server rotation did not complete, not modified",
+	"❌ 令牌轮换完成，%d 项失败":   "❌ Token rotation finished, %d failed",
+	"✅ 令牌轮换完成":          "✅ Token rotation finished",
+	"📋 已复制新令牌到剪贴板 (%s)": "📋 New token copied to clipboard (%s)",
+	"没有可轮换的服务端：本机服务端配置不存在，也没有添加 frps 远程服务器":      "No server to rotate: the local server config does not exist and no frps remote server has been added",
+	"选择要轮换令牌的服务端，使用相同令牌的本机客户端和 frpc 远程服务器会一并更新:": "Choose the server whose token to rotate; the local client and frpc remote servers using the same token are updated too:",
+	"↑↓: 选择 • Enter: 读取令牌 • ESC: 返回菜单":           "↑↓: select • Enter: read tokens • ESC: back to menu",
+	"⏳ 正在读取服务端和客户端当前的令牌...":                      "⏳ Reading the current server and client tokens...",
+	"ESC: 重新选择服务端": "ESC: choose another server",
+	"新令牌: %s":      "New token: %s",
+	"将依次写入新令牌并重启：先服务端，再各客户端。确认开始？(y/N)": "The new token will be written and services restarted: the server first, then each client. Start? (y/N)",
+	"⏳ 正在轮换，完成前无法关闭":                    "⏳ Rotating, cannot close until finished",
+	"%s: 复制新令牌 • ESC: 返回菜单":             "%s: copy new token • ESC: back to menu",
+	"%v，请先在远程服务器页测试连接并信任该主机":            "%v, test the connection on the remote servers tab and trust the host first",
+
+	// pkg/ui/traffic_tab.go
+	"🖥 服务端总计": "🖥 Server Totals",
+	"%d 小时":   "%d h",
+	"%d 分钟":   "%d min",
+	"📡 代理":    "📡 Proxies",
+	"暂无代理数据\n请确认服务端已运行": "No proxy data\nMake sure the server is running",
+	"📈 流量图表":               "📈 Traffic Chart",
+	"暂无数据":                 "No data",
+	"📈 %s - 最近 %s":         "📈 %s - last %s",
+	"入站 ↓":                 "Inbound ↓",
+	"       峰值 %s / 合计 %s": "       peak %s / total %s",
+	"出站 ↑":                 "Outbound ↑",
+	"📊 近 7 天流量":            "📊 Last 7 Days",
+	"加载中...":               "Loading...",
+	"⚠️ 流量历史: ":            "⚠️ Traffic history: ",
+	"📏 流量配额":               "📏 Traffic quotas",
+	"%s 重置":                "resets %s",
+
+	// 运行状态
+	"已停止": "Stopped",
+	"未连接": "Not connected",
+	"已连接": "Connected",
+	"连接中": "Connecting",
+	"启动中": "Starting",
+}
@@ -0,0 +1,95 @@
+// Package i18n 界面文本的多语言支持。
+//
+// 消息 ID 即中文原文：代码中直接书写中文并用 T、Sprintf、Errorf 包裹，
+// 切换到其他语言时从对应的消息目录中查找译文，找不到时回退为中文原文
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// 支持的语言
+const (
+	Chinese = "zh"
+	English = "en"
+)
+
+// catalogs 各语言的消息目录，键为中文原文
+var catalogs = map[string]map[string]string{
+	English: english,
+}
+
+// current 当前语言，界面和后台 goroutine 会并发读取
+var current atomic.Value
+
+func init() {
+	current.Store(Chinese)
+}
+
+// Languages 返回支持的语言代码
+func Languages() []string {
+	languages := []string{Chinese}
+	for lang := range catalogs {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages[1:])
+	return languages
+}
+
+// Normalize 规范化语言代码，如 en_US.UTF-8、en-GB 规范为 en，不支持的语言返回空字符串
+func Normalize(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "_-."); i >= 0 {
+		lang = lang[:i]
+	}
+	for _, supported := range Languages() {
+		if lang == supported {
+			return lang
+		}
+	}
+	return ""
+}
+
+// SetLanguage 切换当前语言，不支持的语言回退为中文
+func SetLanguage(lang string) {
+	if lang = Normalize(lang); lang == "" {
+		lang = Chinese
+	}
+	current.Store(lang)
+}
+
+// Language 返回当前语言
+func Language() string {
+	return current.Load().(string)
+}
+
+// T 翻译一条消息
+func T(msg string) string {
+	catalog, ok := catalogs[Language()]
+	if !ok {
+		return msg
+	}
+	if translated, ok := catalog[msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Sprintf 翻译格式串后格式化
+func Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Errorf 翻译格式串后创建错误，支持 %w
+func Errorf(format string, args ...any) error {
+	return fmt.Errorf(T(format), args...)
+}
+
+// NewError 创建翻译后的错误
+func NewError(msg string) error {
+	return errors.New(T(msg))
+}
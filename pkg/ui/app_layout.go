@@ -4,6 +4,9 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"frp-cli-ui/pkg/i18n"
 )
 
 // AppLayoutConfig 应用布局配置
@@ -21,19 +24,38 @@ type AppLayoutConfig struct {
 	ShowBottomBar bool // 是否显示底部栏（包含帮助和状态信息）
 
 	// 自定义内容
-	Title       string   // 应用标题
-	Tabs        []string // 标签页列表
-	ActiveTab   int      // 当前活跃标签
-	StatusText  string   // 状态栏文本（显示在底部右侧）
-	HelpText    string   // 帮助文本（显示在底部左侧）
-	MainContent string   // 主内容区域
+	Title          string          // 应用标题
+	Tabs           []string        // 标签页列表
+	ActiveTab      int             // 当前活跃标签
+	StatusText     string          // 状态栏文本（显示在底部右侧）
+	StatusSegments []StatusSegment // 分段的状态栏文本，设置后代替 StatusText，宽度不足时先省略优先级低的段
+	HelpText       string          // 帮助文本（显示在底部左侧）
+	MainContent    string          // 主内容区域
+	Toasts         []Toast         // 操作结果通知（显示在底部栏上方右侧）
+	ScrollHint     string          // 主内容超出可见高度时在滚动位置后显示的按键提示
+}
+
+// StatusSegment 状态栏中的一段文本，Priority 越大越重要
+type StatusSegment struct {
+	Text     string
+	Priority int
 }
 
+// narrowLayoutWidth 终端宽度低于该值时底部栏的帮助和状态上下排列，标签页使用紧凑样式
+const narrowLayoutWidth = 100
+
+// statusSeparator 状态栏各段之间的分隔符
+const statusSeparator = " | "
+
+// shortLayoutHeight 终端高度低于该值时标题栏不再上下留白
+const shortLayoutHeight = 30
+
 // AppLayout 通用应用布局渲染器
 type AppLayout struct {
 	width  int
 	height int
 	config AppLayoutConfig
+	scroll layoutScroll // 各标签页主内容的滚动位置
 }
 
 // NewAppLayout 创建新的应用布局
@@ -54,6 +76,42 @@ func NewAppLayout(width, height int) *AppLayout {
 	}
 }
 
+// layoutTheme 主题配色
+type layoutTheme struct {
+	primary   string
+	secondary string
+	border    string
+	muted     string
+	logColors map[string]string // 各日志级别的颜色，未列出的级别使用 defaultLogColors
+}
+
+// layoutThemes 内置主题
+var layoutThemes = map[string]layoutTheme{
+	"default": {primary: "#7D56F4", secondary: "#57", border: "240", muted: "240"},
+	"ocean":   {primary: "#1F6FEB", secondary: "45", border: "24", muted: "244", logColors: map[string]string{"INFO": "45"}},
+	"forest":  {primary: "#2E7D32", secondary: "114", border: "22", muted: "244", logColors: map[string]string{"INFO": "114"}},
+	"mono": {primary: "245", secondary: "255", border: "240", muted: "242",
+		logColors: map[string]string{"ERROR": "255", "WARN": "252", "INFO": "248", "DEBUG": "242", "TRACE": "239"}},
+}
+
+// ThemeNames 返回内置主题名称，默认主题排在最前
+func ThemeNames() []string {
+	return []string{"default", "ocean", "forest", "mono"}
+}
+
+// ApplyTheme 应用主题配色，未知主题使用默认配色
+func (al *AppLayout) ApplyTheme(name string) {
+	theme, ok := layoutThemes[name]
+	if !ok {
+		theme = layoutThemes["default"]
+	}
+	al.config.PrimaryColor = theme.primary
+	al.config.SecondaryColor = theme.secondary
+	al.config.BorderColor = theme.border
+	al.config.HelpColor = theme.muted
+	al.config.StatusColor = theme.muted
+}
+
 // SetSize 设置布局尺寸
 func (al *AppLayout) SetSize(width, height int) {
 	al.width = width
@@ -68,7 +126,7 @@ func (al *AppLayout) UpdateConfig(updater func(*AppLayoutConfig)) {
 // Render 渲染完整的应用布局
 func (al *AppLayout) Render() string {
 	if al.width == 0 {
-		return "正在加载..."
+		return i18n.T("正在加载...")
 	}
 
 	// 创建样式
@@ -89,6 +147,15 @@ func (al *AppLayout) Render() string {
 		components = append(components, tabsRow, "")
 	}
 
+	// 底部栏先渲染，主内容使用剩余的高度
+	var bottomBar string
+	if al.config.ShowBottomBar && (al.config.HelpText != "" || al.statusText() != "" || len(al.config.Toasts) > 0) {
+		bottomBar = al.renderBottomBar(styles)
+		if len(al.config.Toasts) > 0 {
+			bottomBar = lipgloss.JoinVertical(lipgloss.Left, renderToasts(al.config.Toasts, al.width-4), bottomBar)
+		}
+	}
+
 	// 主内容
 	if al.config.MainContent != "" {
 		// 为主内容添加边框
@@ -98,7 +165,19 @@ func (al *AppLayout) Render() string {
 			Padding(1).
 			Width(al.width - 8) // 减去外层边框和内边距
 
-		styledContent := contentStyle.Render(al.config.MainContent)
+		// 外层和主内容各自的边框与内边距共占 8 行
+		headerHeight := 0
+		if len(components) > 0 {
+			headerHeight = lipgloss.Height(lipgloss.JoinVertical(lipgloss.Left, components...))
+		}
+		bottomHeight := 0
+		if bottomBar != "" {
+			bottomHeight = lipgloss.Height(bottomBar)
+		}
+		bodyHeight := al.height - 8 - headerHeight - bottomHeight
+
+		body := al.scroll.clip(al.config.ActiveTab, al.config.MainContent, al.width-10, bodyHeight, al.config.ScrollHint)
+		styledContent := contentStyle.Render(body)
 		components = append(components, styledContent)
 	}
 
@@ -107,8 +186,7 @@ func (al *AppLayout) Render() string {
 
 	// 处理底部栏和对齐
 	var finalContent string
-	if al.config.ShowBottomBar && (al.config.HelpText != "" || al.config.StatusText != "") {
-		bottomBar := al.renderBottomBar(styles)
+	if bottomBar != "" {
 		finalContent = al.alignBottomBarToBottom(innerContent, bottomBar)
 	} else {
 		finalContent = innerContent
@@ -118,27 +196,41 @@ func (al *AppLayout) Render() string {
 	return styles.appBorder.Render(finalContent)
 }
 
+// narrow 终端宽度是否低于 narrowLayoutWidth
+func (al *AppLayout) narrow() bool {
+	return al.width < narrowLayoutWidth
+}
+
 // createStyles 创建所有样式
 func (al *AppLayout) createStyles() appStyles {
+	tabPadding := 5
+	if al.narrow() {
+		tabPadding = 1
+	}
+	titlePadding := 1
+	if al.height < shortLayoutHeight {
+		titlePadding = 0
+	}
+
 	return appStyles{
 		title: lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("#FAFAFA")).
 			Background(lipgloss.Color(al.config.PrimaryColor)).
-			Padding(1, 1).
+			Padding(titlePadding, 1).
 			Width(al.width - 4).
 			Align(lipgloss.Center),
 
 		tab: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(lipgloss.Color(al.config.BorderColor)).
-			Padding(0, 5),
+			Padding(0, tabPadding),
 
 		activeTab: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(lipgloss.Color(al.config.SecondaryColor)).
 			Foreground(lipgloss.Color(al.config.SecondaryColor)).
-			Padding(0, 5),
+			Padding(0, tabPadding),
 
 		help: lipgloss.NewStyle().
 			Foreground(lipgloss.Color(al.config.HelpColor)),
@@ -163,34 +255,114 @@ type appStyles struct {
 	appBorder lipgloss.Style
 }
 
-// renderTabs 渲染标签页
+// renderTabs 渲染标签页，放不下时以当前标签为中心只显示能容纳的部分，两端用 … 表示被省略的标签
 func (al *AppLayout) renderTabs(styles appStyles) string {
-	var tabs []string
+	rendered := make([]string, len(al.config.Tabs))
 	for i, tab := range al.config.Tabs {
 		if i == al.config.ActiveTab {
-			tabs = append(tabs, styles.activeTab.Render(tab))
+			rendered[i] = styles.activeTab.Render(tab)
 		} else {
-			tabs = append(tabs, styles.tab.Render(tab))
+			rendered[i] = styles.tab.Render(tab)
 		}
 	}
-	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+
+	availableWidth := al.width - 4
+	if lipgloss.Width(lipgloss.JoinHorizontal(lipgloss.Top, rendered...)) <= availableWidth {
+		return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+	}
+
+	// 预留两端省略号的宽度，从当前标签向两侧扩展
+	active := min(max(al.config.ActiveTab, 0), len(rendered)-1)
+	start, end := active, active+1
+	used := lipgloss.Width(rendered[active])
+	for {
+		grown := false
+		if end < len(rendered) && used+lipgloss.Width(rendered[end]) <= availableWidth-4 {
+			used += lipgloss.Width(rendered[end])
+			end++
+			grown = true
+		}
+		if start > 0 && used+lipgloss.Width(rendered[start-1]) <= availableWidth-4 {
+			start--
+			used += lipgloss.Width(rendered[start])
+			grown = true
+		}
+		if !grown {
+			break
+		}
+	}
+
+	more := lipgloss.NewStyle().Foreground(lipgloss.Color(al.config.BorderColor)).Render("\n …")
+	visible := rendered[start:end]
+	if start > 0 {
+		visible = append([]string{more}, visible...)
+	}
+	if end < len(rendered) {
+		visible = append(visible, more)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, visible...)
+}
+
+// statusSegments 返回状态栏的各段，未设置 StatusSegments 时 StatusText 作为一整段
+func (al *AppLayout) statusSegments() []StatusSegment {
+	if len(al.config.StatusSegments) > 0 {
+		return al.config.StatusSegments
+	}
+	if al.config.StatusText == "" {
+		return nil
+	}
+	return []StatusSegment{{Text: al.config.StatusText}}
 }
 
-// renderBottomBar 渲染底部栏（左侧帮助信息，右侧状态信息）
+// statusText 返回完整的状态栏文本
+func (al *AppLayout) statusText() string {
+	return fitStatusSegments(al.statusSegments(), -1)
+}
+
+// renderBottomBar 渲染底部栏：宽终端左侧帮助信息、右侧状态信息，
+// 窄终端帮助和状态上下排列；放不下时先省略优先级低的状态段，再截断并以 … 结尾，保证不会折行
 func (al *AppLayout) renderBottomBar(styles appStyles) string {
 	// 计算可用宽度（减去边框和内边距）
 	availableWidth := al.width - 4
+	segments := al.statusSegments()
 
-	var leftContent, rightContent string
+	if al.narrow() {
+		var lines []string
+		if al.config.HelpText != "" {
+			lines = append(lines, styles.help.Render(ansi.Truncate(al.config.HelpText, availableWidth, "…")))
+		}
+		if status := fitStatusSegments(segments, availableWidth); status != "" {
+			lines = append(lines, styles.status.Render(status))
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
 
-	// 左侧帮助信息
+	// 状态信息优先，帮助信息使用剩余宽度，两者之间至少留两个空格
+	rightContent := fitStatusSegments(segments, availableWidth)
+	rightWidth := lipgloss.Width(rightContent)
+	var leftContent string
 	if al.config.HelpText != "" {
-		leftContent = styles.help.Render(al.config.HelpText)
+		helpWidth := availableWidth - rightWidth
+		if rightWidth > 0 {
+			helpWidth -= 2
+		}
+		if lipgloss.Width(al.config.HelpText) > helpWidth && rightContent != "" {
+			// 帮助信息放不下时压缩状态段给帮助留出空间，状态段至少保留一半宽度
+			statusWidth := max(availableWidth-lipgloss.Width(al.config.HelpText)-2, availableWidth/2)
+			rightContent = fitStatusSegments(segments, statusWidth)
+			rightWidth = lipgloss.Width(rightContent)
+			helpWidth = availableWidth - rightWidth - 2
+		}
+		if helpWidth > 0 {
+			leftContent = ansi.Truncate(al.config.HelpText, helpWidth, "…")
+		}
 	}
 
-	// 右侧状态信息
-	if al.config.StatusText != "" {
-		rightContent = styles.status.Render(al.config.StatusText)
+	if leftContent != "" {
+		leftContent = styles.help.Render(leftContent)
+	}
+	if rightContent != "" {
+		rightContent = styles.status.Render(rightContent)
 	}
 
 	// 如果只有一侧有内容，直接返回
@@ -204,21 +376,51 @@ func (al *AppLayout) renderBottomBar(styles appStyles) string {
 		return lipgloss.NewStyle().Width(availableWidth).Align(lipgloss.Left).Render(leftContent)
 	}
 
-	// 计算左右内容的实际宽度
-	leftWidth := lipgloss.Width(leftContent)
-	rightWidth := lipgloss.Width(rightContent)
-
 	// 计算中间需要的空白宽度
-	middleWidth := availableWidth - leftWidth - rightWidth
+	middleWidth := availableWidth - lipgloss.Width(leftContent) - lipgloss.Width(rightContent)
 	if middleWidth < 0 {
 		middleWidth = 0
 	}
 
-	// 创建中间的空白填充
-	middle := strings.Repeat(" ", middleWidth)
-
 	// 组合左侧、中间空白、右侧
-	return leftContent + middle + rightContent
+	return leftContent + strings.Repeat(" ", middleWidth) + rightContent
+}
+
+// fitStatusSegments 把状态段拼接到 width 以内：先按优先级从低到高省略（同优先级先省略靠后的），
+// 只剩一段仍放不下时截断并以 … 结尾，width 为负数时不限制宽度
+func fitStatusSegments(segments []StatusSegment, width int) string {
+	kept := make([]int, len(segments))
+	for i := range segments {
+		kept[i] = i
+	}
+
+	join := func() string {
+		texts := make([]string, len(kept))
+		for i, idx := range kept {
+			texts[i] = segments[idx].Text
+		}
+		return strings.Join(texts, statusSeparator)
+	}
+
+	text := join()
+	if width < 0 {
+		return text
+	}
+	for len(kept) > 1 && lipgloss.Width(text) > width {
+		// 找出优先级最低、位置最靠后的段
+		drop := 0
+		for i, idx := range kept {
+			if segments[idx].Priority <= segments[kept[drop]].Priority {
+				drop = i
+			}
+		}
+		kept = append(kept[:drop], kept[drop+1:]...)
+		text = join()
+	}
+	if lipgloss.Width(text) > width {
+		text = ansi.Truncate(text, max(width, 0), "…")
+	}
+	return text
 }
 
 // alignBottomBarToBottom 将底部栏对齐到底部
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// minBodyHeight 主内容区域至少显示的行数，终端再矮也不会更少
+const minBodyHeight = 3
+
+// layoutScroll 记录各标签页主内容的滚动位置，以及上次渲染时的可见行数和最大滚动位置
+type layoutScroll struct {
+	offsets   map[int]int
+	tab       int // 上次渲染的标签页
+	page      int // 上次渲染时可见的行数
+	maxOffset int // 上次渲染时的最大滚动位置，0 表示内容没有超出
+}
+
+// clip 按标签页的滚动位置截取主内容。内容按 width 折行后超出 height 行时，
+// 只显示其中一屏，最后一行显示滚动位置和 hint；未超出时原样返回
+func (s *layoutScroll) clip(tab int, content string, width, height int, hint string) string {
+	s.tab = tab
+	height = max(height, minBodyHeight)
+	lines := strings.Split(lipgloss.NewStyle().Width(width).Render(content), "\n")
+	if len(lines) <= height {
+		s.page, s.maxOffset = height, 0
+		return content
+	}
+
+	// 留出最后一行显示滚动位置
+	s.page = height - 1
+	s.maxOffset = len(lines) - s.page
+	if s.offsets == nil {
+		s.offsets = make(map[int]int)
+	}
+	offset := min(max(s.offsets[tab], 0), s.maxOffset)
+	s.offsets[tab] = offset
+
+	indicator := i18n.Sprintf("↕ 第 %d-%d 行，共 %d 行", offset+1, offset+s.page, len(lines))
+	if hint != "" {
+		indicator += " · " + hint
+	}
+	indicator = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Width(width).
+		Align(lipgloss.Right).
+		Render(truncateString(indicator, width))
+
+	return strings.Join(lines[offset:offset+s.page], "\n") + "\n" + indicator
+}
+
+// Scrollable 上次渲染的标签页主内容是否超出可见高度
+func (al *AppLayout) Scrollable() bool {
+	return al.scroll.maxOffset > 0
+}
+
+// ScrollPage 当前标签页主内容翻页，pages 为负数时向上，超出范围时停在两端
+func (al *AppLayout) ScrollPage(pages int) {
+	s := &al.scroll
+	if s.maxOffset == 0 {
+		return
+	}
+	if s.offsets == nil {
+		s.offsets = make(map[int]int)
+	}
+	s.offsets[s.tab] = min(max(s.offsets[s.tab]+pages*s.page, 0), s.maxOffset)
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// dashboardStatusSegments 与仪表盘运行时相同的状态段
+func dashboardStatusSegments() []StatusSegment {
+	return []StatusSegment{
+		{Text: "⏸ 已暂停", Priority: 90},
+		{Text: "Server: 运行中", Priority: 60},
+		{Text: "Client: 已停止", Priority: 60},
+		{Text: "Active Proxies: 12", Priority: 40},
+		{Text: "Total Traffic: 1.2 GB", Priority: 30},
+		{Text: "Last Update: 2024-01-02 15:04:05", Priority: 10},
+	}
+}
+
+func TestRenderBottomBarGolden(t *testing.T) {
+	for _, width := range []int{80, 100, 120} {
+		t.Run(fmt.Sprint(width), func(t *testing.T) {
+			al := NewAppLayout(width, 40)
+			al.UpdateConfig(func(config *AppLayoutConfig) {
+				config.HelpText = "Tab: 切换标签页 • ?: 帮助 • q: 退出 • r: 刷新 • s: 启动/停止服务"
+				config.StatusSegments = dashboardStatusSegments()
+			})
+
+			got := ansi.Strip(al.renderBottomBar(al.createStyles()))
+			for _, line := range strings.Split(got, "\n") {
+				if w := lipgloss.Width(line); w > width-4 {
+					t.Errorf("line is %d columns wide, available %d: %q", w, width-4, line)
+				}
+			}
+
+			path := filepath.Join("testdata", fmt.Sprintf("bottom_bar_%d.golden", width))
+			if *updateGolden {
+				if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read golden file (run with -update to create it): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("bottom bar at %d columns does not match %s:\ngot:\n%s\nwant:\n%s", width, path, got, want)
+			}
+		})
+	}
+}
+
+func TestFitStatusSegments(t *testing.T) {
+	segments := []StatusSegment{
+		{Text: "A", Priority: 50},
+		{Text: "B", Priority: 10},
+		{Text: "C", Priority: 30},
+		{Text: "D", Priority: 10},
+	}
+
+	tests := []struct {
+		name  string
+		width int
+		want  string
+	}{
+		{"unlimited", -1, "A | B | C | D"},
+		{"fits", 13, "A | B | C | D"},
+		{"drops later of lowest priority first", 12, "A | B | C"},
+		{"drops remaining lowest priority", 8, "A | C"},
+		{"keeps highest priority", 4, "A"},
+		{"truncates last segment", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fitStatusSegments(segments, tt.width); got != tt.want {
+				t.Errorf("fitStatusSegments(width %d) = %q, want %q", tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitStatusSegmentsTruncatesWithEllipsis(t *testing.T) {
+	segments := []StatusSegment{
+		{Text: "Server: running", Priority: 60},
+		{Text: "Last Update: 15:04:05", Priority: 10},
+	}
+	got := fitStatusSegments(segments, 10)
+	if got != "Server: r…" {
+		t.Errorf("fitStatusSegments = %q, want %q", got, "Server: r…")
+	}
+	if w := lipgloss.Width(got); w > 10 {
+		t.Errorf("result is %d columns wide, want at most 10", w)
+	}
+}
+
+func TestFitStatusSegmentsEmpty(t *testing.T) {
+	if got := fitStatusSegments(nil, 80); got != "" {
+		t.Errorf("fitStatusSegments(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,313 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"frp-cli-ui/internal/installer"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 应用设置表单字段顺序
+const (
+	settingsFieldDashboardURL = iota
+	settingsFieldDashboardUser
+	settingsFieldDashboardPassword
+	settingsFieldRefreshInterval
+	settingsFieldAPIPollInterval
+	settingsFieldTrafficInterval
+	settingsFieldLogInterval
+	settingsFieldLogCapacity
+	settingsFieldTheme
+	settingsFieldLanguage
+	settingsFieldServerConfig
+	settingsFieldClientConfig
+	settingsFieldServerLogFile
+	settingsFieldClientLogFile
+	settingsFieldMirror
+	settingsFieldProxy
+	settingsFieldInstallDir
+	settingsFieldReleaseChannel
+	settingsFieldBackupKeep
+	settingsFieldBackupMaxDays
+	settingsFieldCatalogURL
+	settingsFieldTrafficKeepDays
+	settingsFieldMonitorInterval
+	settingsFieldLatencyInterval
+	settingsFieldLatencyWarn
+	settingsFieldDesktopNotify
+	settingsFieldWebhookURL
+	settingsFieldAutoRestartServer
+	settingsFieldAutoRestartClient
+	settingsFieldRestartMaxRetries
+	settingsFieldRestartBackoff
+	settingsFieldRestartWindow
+	settingsFieldStopOnExit
+	settingsFieldShutdownTimeout
+	settingsFieldTokenLength
+	settingsFieldAuditLog
+)
+
+// appSettingsForm 应用设置编辑表单
+type appSettingsForm struct {
+	inputs []textinput.Model
+	focus  int
+	err    error
+}
+
+// newAppSettingsForm 创建应用设置表单，focus 为初始聚焦的字段
+func newAppSettingsForm(settings *config.AppSettings, focus int) *appSettingsForm {
+	fields := []struct {
+		prompt      string
+		placeholder string
+		value       string
+	}{
+		{i18n.T("Dashboard 地址:"), "http://127.0.0.1:7500", settings.DashboardURL},
+		{i18n.T("Dashboard 用户:"), "admin", settings.DashboardUser},
+		{i18n.T("Dashboard 密码:"), "admin", settings.DashboardPassword},
+		{i18n.T("进程状态(秒):"), i18n.T("3，刷新 frps/frpc 运行状态的间隔"), strconv.Itoa(settings.RefreshInterval)},
+		{i18n.T("API 轮询(秒):"), i18n.T("3，仪表盘请求 frps 代理列表的间隔"), strconv.Itoa(settings.APIPollInterval)},
+		{i18n.T("流量采样(秒):"), i18n.T("5，最小 5，记录流量统计的间隔"), strconv.Itoa(settings.TrafficInterval)},
+		{i18n.T("日志刷新(秒):"), i18n.T("1，新日志显示到界面的间隔 (1-60)"), strconv.Itoa(settings.LogInterval)},
+		{i18n.T("日志保留条数:"), i18n.T("5000，日志页最多保留的条数 (100-100000)"), strconv.Itoa(settings.LogCapacity)},
+		{i18n.T("主题:"), strings.Join(ThemeNames(), " / "), settings.Theme},
+		{i18n.T("界面语言:"), strings.Join(i18n.Languages(), " / "), settings.Language},
+		{i18n.T("服务端配置:"), config.GetDefaultServerConfigPath(), settings.ServerConfigPath},
+		{i18n.T("客户端配置:"), config.GetDefaultClientConfigPath(), settings.ClientConfigPath},
+		{i18n.T("服务端日志文件:"), i18n.T("/var/log/frps.log，留空时使用配置中的 log.to"), settings.ServerLogFile},
+		{i18n.T("客户端日志文件:"), i18n.T("/var/log/frpc.log，留空时使用配置中的 log.to"), settings.ClientLogFile},
+		{i18n.T("下载镜像:"), i18n.T("https://ghproxy.com/ 或含 {url}/{version}/{filename} 的模板"), settings.DownloadMirror},
+		{i18n.T("下载代理:"), i18n.T("http://127.0.0.1:7890 或 socks5://127.0.0.1:1080"), settings.DownloadProxy},
+		{i18n.T("安装目录:"), i18n.Sprintf("%s，系统范围安装填 %s", installer.DefaultInstallDir(), installer.SystemInstallDir), settings.InstallDir},
+		{i18n.T("更新通道:"), i18n.T("stable / prerelease，prerelease 包含 RC 等预发布版本"), settings.ReleaseChannel},
+		{i18n.T("备份保留数量:"), i18n.T("20，0 表示不限"), strconv.Itoa(settings.BackupKeep)},
+		{i18n.T("备份保留天数:"), i18n.T("30，0 表示不限"), strconv.Itoa(settings.BackupMaxDays)},
+		{i18n.T("模板目录地址:"), "https://example.com/frp-templates.yaml", settings.TemplateCatalogURL},
+		{i18n.T("流量保留天数:"), i18n.T("7，0 表示不限"), strconv.Itoa(settings.TrafficKeepDays)},
+		{i18n.T("健康检查(秒):"), i18n.T("15，0 表示关闭"), strconv.Itoa(settings.MonitorInterval)},
+		{i18n.T("延迟采样(秒):"), i18n.T("5，0 表示关闭"), strconv.Itoa(settings.LatencyInterval)},
+		{i18n.T("延迟告警(毫秒):"), i18n.T("300，0 表示不告警"), strconv.Itoa(settings.LatencyWarnMs)},
+		{i18n.T("桌面通知:"), "yes / no", yesNo(settings.DesktopNotify)},
+		{i18n.T("告警 Webhook:"), i18n.T("https://example.com/hook，留空不发送"), settings.AlertWebhookURL},
+		{i18n.T("服务端自动重启:"), "yes / no", yesNo(settings.AutoRestartServer)},
+		{i18n.T("客户端自动重启:"), "yes / no", yesNo(settings.AutoRestartClient)},
+		{i18n.T("最大重启次数:"), i18n.T("5，0 表示不限"), strconv.Itoa(settings.RestartMaxRetries)},
+		{i18n.T("重启退避(秒):"), i18n.T("2，之后每次翻倍，最长 60 秒"), strconv.Itoa(settings.RestartBackoff)},
+		{i18n.T("重启窗口(秒):"), i18n.T("300，窗口内超过最大次数后停止重启"), strconv.Itoa(settings.RestartWindow)},
+		{i18n.T("退出时停止进程:"), i18n.T("yes / no，仅停止本工具启动的 frps/frpc"), yesNo(settings.StopOnExit)},
+		{i18n.T("退出等待(秒):"), i18n.T("10，超时后强制结束"), strconv.Itoa(settings.ShutdownTimeout)},
+		{i18n.T("令牌长度:"), i18n.T("32，生成 token/secretKey 的字符数 (16-128)"), strconv.Itoa(settings.TokenLength)},
+		{i18n.T("操作记录:"), i18n.T("yes / no，把启停、配置修改等操作写入 ~/.frp-manager/audit.log"), yesNo(settings.AuditLog)},
+	}
+
+	// 按当前语言下最长的提示文字对齐
+	promptWidth := 0
+	for _, field := range fields {
+		promptWidth = max(promptWidth, runewidth.StringWidth(field.prompt))
+	}
+
+	form := &appSettingsForm{focus: focus}
+	for i, field := range fields {
+		input := textinput.New()
+		input.Prompt = runewidth.FillRight(field.prompt, promptWidth+1)
+		input.Placeholder = field.placeholder
+		input.CharLimit = 256
+		input.Width = 50
+		input.SetValue(field.value)
+		if i == settingsFieldDashboardPassword {
+			input.EchoMode = textinput.EchoPassword
+		}
+		form.inputs = append(form.inputs, input)
+	}
+	form.inputs[form.focus].Focus()
+
+	return form
+}
+
+// Update 处理按键，保存成功时返回新的设置，取消时 closed 为 true
+func (f *appSettingsForm) Update(msg tea.KeyMsg, current *config.AppSettings) (cmd tea.Cmd, saved *config.AppSettings, closed bool) {
+	switch msg.String() {
+	case "esc":
+		return nil, nil, true
+	case "tab", "down":
+		return f.moveFocus(1), nil, false
+	case "shift+tab", "up":
+		return f.moveFocus(-1), nil, false
+	case "enter":
+		settings, err := f.settings(current)
+		if err == nil {
+			err = config.SaveAppSettings(settings)
+		}
+		f.err = err
+		if err != nil {
+			return nil, nil, false
+		}
+		return nil, settings, true
+	}
+
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	return cmd, nil, false
+}
+
+// moveFocus 移动输入焦点
+func (f *appSettingsForm) moveFocus(delta int) tea.Cmd {
+	f.inputs[f.focus].Blur()
+	f.focus = (f.focus + delta + len(f.inputs)) % len(f.inputs)
+	return f.inputs[f.focus].Focus()
+}
+
+// settings 根据表单内容生成并校验新设置
+func (f *appSettingsForm) settings(current *config.AppSettings) (*config.AppSettings, error) {
+	settings := *current
+	value := func(field int) string {
+		return strings.TrimSpace(f.inputs[field].Value())
+	}
+
+	settings.DashboardURL = strings.TrimRight(value(settingsFieldDashboardURL), "/")
+	settings.DashboardUser = value(settingsFieldDashboardUser)
+	settings.DashboardPassword = f.inputs[settingsFieldDashboardPassword].Value()
+	settings.Theme = value(settingsFieldTheme)
+	settings.Language = i18n.Normalize(value(settingsFieldLanguage))
+	if settings.Language == "" {
+		settings.Language = value(settingsFieldLanguage)
+	}
+	settings.ServerConfigPath = value(settingsFieldServerConfig)
+	settings.ClientConfigPath = value(settingsFieldClientConfig)
+	settings.ServerLogFile = value(settingsFieldServerLogFile)
+	settings.ClientLogFile = value(settingsFieldClientLogFile)
+	settings.DownloadMirror = value(settingsFieldMirror)
+	settings.DownloadProxy = value(settingsFieldProxy)
+	settings.InstallDir = value(settingsFieldInstallDir)
+	settings.ReleaseChannel = value(settingsFieldReleaseChannel)
+	if settings.ReleaseChannel == config.ReleaseChannelStable {
+		settings.ReleaseChannel = ""
+	}
+	settings.TemplateCatalogURL = value(settingsFieldCatalogURL)
+
+	interval, err := strconv.Atoi(value(settingsFieldRefreshInterval))
+	if err != nil {
+		return nil, i18n.Errorf("进程状态刷新间隔必须是整数")
+	}
+	settings.RefreshInterval = interval
+	if settings.APIPollInterval, err = strconv.Atoi(value(settingsFieldAPIPollInterval)); err != nil {
+		return nil, i18n.Errorf("API 轮询间隔必须是整数")
+	}
+	if settings.TrafficInterval, err = strconv.Atoi(value(settingsFieldTrafficInterval)); err != nil {
+		return nil, i18n.Errorf("流量采样间隔必须是整数")
+	}
+	if settings.LogInterval, err = strconv.Atoi(value(settingsFieldLogInterval)); err != nil {
+		return nil, i18n.Errorf("日志刷新间隔必须是整数")
+	}
+	if settings.LogCapacity, err = strconv.Atoi(value(settingsFieldLogCapacity)); err != nil {
+		return nil, i18n.Errorf("日志保留条数必须是整数")
+	}
+
+	if settings.BackupKeep, err = strconv.Atoi(value(settingsFieldBackupKeep)); err != nil {
+		return nil, i18n.Errorf("备份保留数量必须是整数")
+	}
+	if settings.BackupMaxDays, err = strconv.Atoi(value(settingsFieldBackupMaxDays)); err != nil {
+		return nil, i18n.Errorf("备份保留天数必须是整数")
+	}
+	if settings.TrafficKeepDays, err = strconv.Atoi(value(settingsFieldTrafficKeepDays)); err != nil {
+		return nil, i18n.Errorf("流量保留天数必须是整数")
+	}
+	if settings.MonitorInterval, err = strconv.Atoi(value(settingsFieldMonitorInterval)); err != nil {
+		return nil, i18n.Errorf("健康检查间隔必须是整数")
+	}
+	if settings.LatencyInterval, err = strconv.Atoi(value(settingsFieldLatencyInterval)); err != nil {
+		return nil, i18n.Errorf("延迟采样间隔必须是整数")
+	}
+	if settings.LatencyWarnMs, err = strconv.Atoi(value(settingsFieldLatencyWarn)); err != nil {
+		return nil, i18n.Errorf("延迟告警阈值必须是整数")
+	}
+	if settings.DesktopNotify, err = parseYesNo(value(settingsFieldDesktopNotify)); err != nil {
+		return nil, i18n.Errorf("桌面通知%w", err)
+	}
+	settings.AlertWebhookURL = value(settingsFieldWebhookURL)
+	if settings.AutoRestartServer, err = parseYesNo(value(settingsFieldAutoRestartServer)); err != nil {
+		return nil, i18n.Errorf("服务端自动重启%w", err)
+	}
+	if settings.AutoRestartClient, err = parseYesNo(value(settingsFieldAutoRestartClient)); err != nil {
+		return nil, i18n.Errorf("客户端自动重启%w", err)
+	}
+	if settings.RestartMaxRetries, err = strconv.Atoi(value(settingsFieldRestartMaxRetries)); err != nil {
+		return nil, i18n.Errorf("最大重启次数必须是整数")
+	}
+	if settings.RestartBackoff, err = strconv.Atoi(value(settingsFieldRestartBackoff)); err != nil {
+		return nil, i18n.Errorf("重启退避必须是整数")
+	}
+	if settings.RestartWindow, err = strconv.Atoi(value(settingsFieldRestartWindow)); err != nil {
+		return nil, i18n.Errorf("重启窗口必须是整数")
+	}
+	if settings.StopOnExit, err = parseYesNo(value(settingsFieldStopOnExit)); err != nil {
+		return nil, i18n.Errorf("退出时停止进程%w", err)
+	}
+	if settings.ShutdownTimeout, err = strconv.Atoi(value(settingsFieldShutdownTimeout)); err != nil {
+		return nil, i18n.Errorf("退出等待时间必须是整数")
+	}
+	if settings.TokenLength, err = strconv.Atoi(value(settingsFieldTokenLength)); err != nil {
+		return nil, i18n.Errorf("令牌长度必须是整数")
+	}
+	if settings.AuditLog, err = parseYesNo(value(settingsFieldAuditLog)); err != nil {
+		return nil, i18n.Errorf("操作记录%w", err)
+	}
+
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	if _, ok := layoutThemes[settings.Theme]; !ok {
+		return nil, i18n.Errorf("未知主题 %q，可选: %s", settings.Theme, strings.Join(ThemeNames(), ", "))
+	}
+	if err := installer.ValidateMirror(settings.DownloadMirror); err != nil {
+		return nil, err
+	}
+	if settings.DownloadProxy != "" {
+		if _, err := installer.ParseProxyURL(settings.DownloadProxy); err != nil {
+			return nil, err
+		}
+	}
+
+	return &settings, nil
+}
+
+// View 渲染表单
+func (f *appSettingsForm) View() string {
+	content := lipgloss.NewStyle().Bold(true).Render(i18n.T("⚙️ 应用设置")) + "\n"
+	for _, input := range f.inputs {
+		content += input.View() + "\n"
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	content += hintStyle.Render(i18n.T("镜像支持 {url}、{version}、{filename} 占位符，不含占位符时作为前缀；留空表示直连")) + "\n"
+	if f.err != nil {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+f.err.Error()) + "\n"
+	}
+	content += hintStyle.Render(i18n.Sprintf("保存到 %s • Tab/↑↓: 切换 • Enter: 保存 • Esc: 取消", config.GetAppSettingsPath()))
+	return content
+}
+
+// yesNo 将布尔值显示为 yes / no
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+// parseYesNo 解析 yes / no 输入，留空视为 no
+func parseYesNo(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes", "y", "true":
+		return true, nil
+	case "no", "n", "false", "":
+		return false, nil
+	default:
+		return false, i18n.Errorf("请填写 yes 或 no")
+	}
+}
@@ -0,0 +1,329 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// auditReplayInterval 自动回放时每一步停留的时间
+const auditReplayInterval = 1500 * time.Millisecond
+
+// auditLogMsg 操作记录读取结果
+type auditLogMsg struct {
+	entries []service.AuditEntry
+	err     error
+}
+
+// auditReplayTickMsg 自动回放前进一步
+type auditReplayTickMsg struct {
+	id int
+}
+
+// auditViewer 操作记录浏览器：默认最新的在前，可以筛选；
+// 回放时按时间顺序逐条显示某个会话中的操作
+type auditViewer struct {
+	path      string
+	entries   []service.AuditEntry // 文件中的顺序，即时间顺序
+	visible   []int                // 当前显示的记录在 entries 中的下标
+	cursor    int
+	filter    textinput.Model
+	filtering bool
+	session   string // 正在回放的会话，为空表示浏览全部
+	playing   bool   // 正在自动回放
+	playID    int    // 每次开始自动回放时递增，丢弃之前的计时
+	loading   bool
+	err       error
+}
+
+// newAuditViewer 创建操作记录浏览器并在后台读取审计文件
+func newAuditViewer(path string) (*auditViewer, tea.Cmd) {
+	filter := textinput.New()
+	filter.Placeholder = i18n.T("用户、操作或内容")
+	filter.CharLimit = 64
+	filter.Width = 30
+
+	v := &auditViewer{path: path, filter: filter, loading: true}
+	return v, loadAuditLog(path)
+}
+
+// loadAuditLog 在后台读取审计文件
+func loadAuditLog(path string) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := service.ReadAuditLog(path)
+		return auditLogMsg{entries: entries, err: err}
+	}
+}
+
+// setEntries 更新读取到的记录
+func (v *auditViewer) setEntries(msg auditLogMsg) {
+	v.loading = false
+	v.entries, v.err = msg.entries, msg.err
+	v.refresh()
+}
+
+// refresh 按筛选条件或回放的会话重新计算显示的记录
+func (v *auditViewer) refresh() {
+	v.visible = v.visible[:0]
+	if v.session != "" {
+		for i, entry := range v.entries {
+			if entry.Session == v.session {
+				v.visible = append(v.visible, i)
+			}
+		}
+	} else {
+		query := strings.ToLower(strings.TrimSpace(v.filter.Value()))
+		for i := len(v.entries) - 1; i >= 0; i-- {
+			if query == "" || strings.Contains(auditSearchText(v.entries[i]), query) {
+				v.visible = append(v.visible, i)
+			}
+		}
+	}
+	v.cursor = min(v.cursor, max(len(v.visible)-1, 0))
+}
+
+// auditSearchText 返回筛选时匹配的文本
+func auditSearchText(entry service.AuditEntry) string {
+	return strings.ToLower(strings.Join([]string{
+		entry.User, entry.Host, entry.Action, entry.Summary, entry.Detail, entry.Session,
+	}, " "))
+}
+
+// current 返回光标所在的记录
+func (v *auditViewer) current() *service.AuditEntry {
+	if v.cursor < 0 || v.cursor >= len(v.visible) {
+		return nil
+	}
+	return &v.entries[v.visible[v.cursor]]
+}
+
+// startReplay 按时间顺序回放选中记录所在的会话，光标停在该记录上
+func (v *auditViewer) startReplay() {
+	entry := v.current()
+	if entry == nil {
+		return
+	}
+	selected := v.visible[v.cursor]
+	v.session = entry.Session
+	v.refresh()
+	for i, index := range v.visible {
+		if index == selected {
+			v.cursor = i
+		}
+	}
+}
+
+// stopReplay 退出回放，回到浏览全部记录
+func (v *auditViewer) stopReplay() {
+	selected := -1
+	if v.cursor < len(v.visible) {
+		selected = v.visible[v.cursor]
+	}
+	v.session, v.playing = "", false
+	v.refresh()
+	for i, index := range v.visible {
+		if index == selected {
+			v.cursor = i
+		}
+	}
+}
+
+// togglePlay 开始或暂停自动回放，已经在最后一步时从头开始
+func (v *auditViewer) togglePlay() tea.Cmd {
+	if v.playing {
+		v.playing = false
+		return nil
+	}
+	if len(v.visible) == 0 {
+		return nil
+	}
+	if v.cursor >= len(v.visible)-1 {
+		v.cursor = 0
+	}
+	v.playing = true
+	v.playID++
+	return v.tick()
+}
+
+// tick 等待下一步自动回放
+func (v *auditViewer) tick() tea.Cmd {
+	id := v.playID
+	return tea.Tick(auditReplayInterval, func(time.Time) tea.Msg {
+		return auditReplayTickMsg{id: id}
+	})
+}
+
+// advance 自动回放前进一步，到最后一步时停止
+func (v *auditViewer) advance(msg auditReplayTickMsg) tea.Cmd {
+	if !v.playing || msg.id != v.playID {
+		return nil
+	}
+	if v.cursor >= len(v.visible)-1 {
+		v.playing = false
+		return nil
+	}
+	v.cursor++
+	return v.tick()
+}
+
+// update 处理按键，返回浏览器是否需要关闭
+func (v *auditViewer) update(msg tea.KeyMsg, keys SettingsKeyMap) (tea.Cmd, bool) {
+	if v.filtering {
+		switch msg.String() {
+		case "esc":
+			v.filter.SetValue("")
+			fallthrough
+		case "enter":
+			v.filtering = false
+			v.filter.Blur()
+			v.refresh()
+			return nil, false
+		}
+		var cmd tea.Cmd
+		v.filter, cmd = v.filter.Update(msg)
+		v.cursor = 0
+		v.refresh()
+		return cmd, false
+	}
+
+	switch {
+	case key.Matches(msg, keys.CloseAudit):
+		if v.session != "" {
+			v.stopReplay()
+			return nil, false
+		}
+		return nil, true
+	case key.Matches(msg, keys.Up):
+		v.playing = false
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case key.Matches(msg, keys.Down):
+		v.playing = false
+		if v.cursor < len(v.visible)-1 {
+			v.cursor++
+		}
+	case key.Matches(msg, keys.AuditTop):
+		v.cursor = 0
+	case key.Matches(msg, keys.AuditBottom):
+		v.cursor = max(len(v.visible)-1, 0)
+	case key.Matches(msg, keys.AuditFilter):
+		if v.session == "" {
+			v.filtering = true
+			return v.filter.Focus(), false
+		}
+	case key.Matches(msg, keys.AuditReplay):
+		if v.session == "" {
+			v.startReplay()
+		}
+	case key.Matches(msg, keys.AuditPlay):
+		if v.session == "" {
+			v.startReplay()
+		}
+		return v.togglePlay(), false
+	case key.Matches(msg, keys.AuditReload):
+		v.loading = true
+		return loadAuditLog(v.path), false
+	}
+	return nil, false
+}
+
+// view 渲染操作记录列表和选中记录的详情
+func (v *auditViewer) view(width, height int, enabled bool, keys SettingsKeyMap) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color("#FAFAFA"))
+	actionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+
+	title := i18n.T("📜 操作记录")
+	if v.session != "" {
+		state := i18n.T("已暂停")
+		if v.playing {
+			state = i18n.T("播放中")
+		}
+		title += " · " + i18n.Sprintf("回放会话 %s（第 %d/%d 步，%s）", v.session, v.cursor+1, len(v.visible), state)
+	}
+	content := titleStyle.Render(title) + "\n"
+	content += hintStyle.Render(v.path) + "\n"
+	if !enabled {
+		content += warnStyle.Render(i18n.T("⚠️  操作记录未开启，在应用设置中把「操作记录」设为 yes 后开始记录")) + "\n"
+	}
+	if v.filtering || v.filter.Value() != "" {
+		content += i18n.T("筛选: ") + v.filter.View() + "\n"
+	}
+	content += "\n"
+
+	switch {
+	case v.loading:
+		return content + i18n.T("正在读取操作记录...")
+	case v.err != nil:
+		content += errorStyle.Render(v.err.Error()) + "\n"
+	}
+	if len(v.visible) == 0 {
+		if len(v.entries) == 0 {
+			content += hintStyle.Render(i18n.T("还没有操作记录")) + "\n"
+		} else {
+			content += hintStyle.Render(i18n.T("没有符合条件的记录")) + "\n"
+		}
+	}
+
+	// 只显示光标附近的记录，留出详情和提示的位置
+	rows := max(height-16, 5)
+	start := max(min(v.cursor-rows/2, len(v.visible)-rows), 0)
+	end := min(start+rows, len(v.visible))
+	for i := start; i < end; i++ {
+		entry := v.entries[v.visible[i]]
+		prefix := entry.Time.Local().Format("2006-01-02 15:04:05") + "  " + truncateString(entry.User+"@"+entry.Host, 20) + "  "
+		rest := truncateString(entry.Action+"  "+entry.Summary, max(width-lipgloss.Width(prefix)-4, 10))
+		if i == v.cursor {
+			content += selectedStyle.Render("▶ "+prefix+rest) + "\n"
+			continue
+		}
+		action, summary, _ := strings.Cut(rest, "  ")
+		content += "  " + prefix + actionStyle.Render(action) + "  " + summary + "\n"
+	}
+	if len(v.visible) > rows {
+		content += hintStyle.Render(i18n.Sprintf("共 %d 条", len(v.visible))) + "\n"
+	}
+
+	if entry := v.current(); entry != nil {
+		detail := i18n.Sprintf("时间: %s", entry.Time.Local().Format("2006-01-02 15:04:05")) + "\n" +
+			i18n.Sprintf("用户: %s@%s", entry.User, entry.Host) + "\n" +
+			i18n.Sprintf("会话: %s", entry.Session) + "\n" +
+			i18n.Sprintf("操作: %s", entry.Action) + "\n" +
+			i18n.Sprintf("内容: %s", entry.Summary)
+		if entry.Detail != "" {
+			detail += "\n" + i18n.Sprintf("详情: %s", entry.Detail)
+		}
+		content += "\n" + lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), true, false, false, false).
+			BorderForeground(lipgloss.Color("240")).
+			Width(max(width-2, 20)).
+			Render(detail) + "\n"
+	}
+
+	var hint string
+	switch {
+	case v.filtering:
+		hint = i18n.T("输入筛选条件 | Enter 确认 | ESC 清除")
+	case v.session != "":
+		hint = i18n.Sprintf("%s/%s 逐步查看 | %s 播放/暂停 | %s 退出回放",
+			keys.Up.Help().Key, keys.Down.Help().Key, keys.AuditPlay.Help().Key, keys.CloseAudit.Help().Key)
+	default:
+		hint = i18n.Sprintf("%s/%s 选择 | %s 筛选 | %s 回放该会话 | %s 自动回放 | %s 重新读取 | %s 返回",
+			keys.Up.Help().Key, keys.Down.Help().Key, keys.AuditFilter.Help().Key, keys.AuditReplay.Help().Key,
+			keys.AuditPlay.Help().Key, keys.AuditReload.Help().Key, keys.CloseAudit.Help().Key)
+	}
+	return content + "\n" + hintStyle.Render(hint)
+}
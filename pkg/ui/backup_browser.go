@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// backupPreviewMaxLines 备份预览最多显示的差异行数
+const backupPreviewMaxLines = 30
+
+// backupBrowser 备份恢复浏览器状态
+type backupBrowser struct {
+	backups  []config.BackupInfo
+	selected int
+	diff     []config.DiffLine
+	err      error
+}
+
+// newBackupBrowser 列出服务端和客户端配置的所有备份
+func newBackupBrowser(serverPath, clientPath string) *backupBrowser {
+	b := &backupBrowser{}
+
+	for _, path := range []string{serverPath, clientPath} {
+		backups, err := config.ListBackups(path)
+		if err != nil {
+			b.err = err
+			continue
+		}
+		b.backups = append(b.backups, backups...)
+	}
+
+	b.refresh()
+	return b
+}
+
+// current 返回当前选中的备份
+func (b *backupBrowser) current() *config.BackupInfo {
+	if b.selected < 0 || b.selected >= len(b.backups) {
+		return nil
+	}
+	return &b.backups[b.selected]
+}
+
+// move 移动选中项并刷新预览
+func (b *backupBrowser) move(delta int) {
+	if len(b.backups) == 0 {
+		return
+	}
+	b.selected = (b.selected + delta + len(b.backups)) % len(b.backups)
+	b.refresh()
+}
+
+// refresh 计算选中备份相对当前配置文件的差异
+func (b *backupBrowser) refresh() {
+	b.diff = nil
+	backup := b.current()
+	if backup == nil {
+		return
+	}
+
+	backupData, err := os.ReadFile(backup.Path)
+	if err != nil {
+		b.err = i18n.Errorf("读取备份文件失败: %w", err)
+		return
+	}
+
+	// 当前文件不存在时整份备份均为新增
+	var currentData []byte
+	if data, err := os.ReadFile(backup.ConfigPath); err == nil {
+		currentData = data
+	}
+	b.diff = config.DiffText(string(currentData), string(backupData))
+}
+
+// handleRestoreBackup 打开备份恢复浏览器
+func (ct *ConfigTab) handleRestoreBackup() (Tab, tea.Cmd) {
+	ct.backups = newBackupBrowser(ct.serverConfigPath, ct.clientConfigPath)
+	ct.state = ConfigTabBackups
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	return ct, nil
+}
+
+// updateBackupBrowser 处理备份浏览器中的按键
+func (ct *ConfigTab) updateBackupBrowser(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	b := ct.backups
+
+	switch {
+	case msg.String() == "esc":
+		ct.backups = nil
+		ct.state = ConfigTabMenu
+	case key.Matches(msg, ct.keys.Config.Up):
+		b.move(-1)
+	case key.Matches(msg, ct.keys.Config.Down):
+		b.move(1)
+	case msg.String() == "enter" || msg.String() == "y":
+		backup := b.current()
+		if backup == nil {
+			return ct, nil
+		}
+		if err := config.RestoreBackup(*backup); err != nil {
+			b.err = err
+			return ct, nil
+		}
+
+		ct.backups = nil
+		ct.state = ConfigTabMenu
+		ct.loadConfigFile()
+		return ct, showStatusMessage(i18n.Sprintf("✅ 已将 %s 恢复到 %s 的备份，恢复前的内容也已备份",
+			backup.ConfigPath, backup.CreatedAt.Format("2006-01-02 15:04:05")), false)
+	}
+
+	return ct, nil
+}
+
+// renderBackupBrowser 渲染备份列表和差异预览
+func (ct *ConfigTab) renderBackupBrowser(width int) string {
+	b := ct.backups
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color("#FAFAFA"))
+
+	content := titleStyle.Render(i18n.T("🕘 从备份恢复")) + "\n\n"
+	content += hintStyle.Render(i18n.T("备份目录: ")+config.GetBackupDir()) + "\n\n"
+
+	if len(b.backups) == 0 {
+		content += i18n.T("暂无备份，保存配置时会自动备份旧内容\n\n")
+		if b.err != nil {
+			content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+b.err.Error()) + "\n\n"
+		}
+		content += hintStyle.Render(i18n.T("按 ESC 返回菜单"))
+		return content
+	}
+
+	// 只显示选中项附近的备份，避免列表过长
+	start := b.selected - 4
+	if start < 0 {
+		start = 0
+	}
+	end := start + 8
+	if end > len(b.backups) {
+		end = len(b.backups)
+	}
+
+	for i := start; i < end; i++ {
+		backup := b.backups[i]
+		line := fmt.Sprintf("%s  %-12s %s", backup.CreatedAt.Format("2006-01-02 15:04:05"),
+			filepath.Base(backup.ConfigPath), formatTraffic(backup.Size))
+		if i == b.selected {
+			content += "▶ " + selectedStyle.Render(line) + "\n"
+		} else {
+			content += "  " + line + "\n"
+		}
+	}
+	content += hintStyle.Render(i18n.Sprintf("共 %d 个备份", len(b.backups))) + "\n\n"
+
+	content += lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render(i18n.T("📝 恢复后的变更")) + "\n"
+	diff := b.diff
+	truncated := false
+	if len(diff) > backupPreviewMaxLines {
+		diff = diff[:backupPreviewMaxLines]
+		truncated = true
+	}
+	content += renderDiff(diff, width)
+	if truncated {
+		content += hintStyle.Render(i18n.Sprintf("  … 还有 %d 行", len(b.diff)-backupPreviewMaxLines)) + "\n"
+	}
+	content += "\n"
+
+	if b.err != nil {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+b.err.Error()) + "\n"
+	}
+	content += hintStyle.Render(i18n.T("↑/↓ 选择备份 | Enter/y 恢复 | ESC 返回"))
+
+	return content
+}
@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// bundleExport 部署包导出面板状态
+type bundleExport struct {
+	name          string // "frps" 或 "frpc"
+	targetOS      string
+	includeBinary bool
+	exporting     bool
+	result        *service.BundleResult
+	err           error
+}
+
+// bundleExportMsg 部署包导出结果
+type bundleExportMsg struct {
+	result *service.BundleResult
+	err    error
+}
+
+// handleExportBundle 打开部署包导出面板，默认导出客户端配置
+func (ct *ConfigTab) handleExportBundle() (Tab, tea.Cmd) {
+	name := "frpc"
+	if ct.clientConfig == nil && ct.serverConfig != nil {
+		name = "frps"
+	}
+	ct.bundle = &bundleExport{name: name, targetOS: runtime.GOOS}
+	ct.state = ConfigTabExport
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	return ct, nil
+}
+
+// bundleSpec 根据当前配置生成导出参数，未保存的修改也会包含在内
+func (ct *ConfigTab) bundleSpec() (service.BundleSpec, error) {
+	b := ct.bundle
+	cfg, path := ct.clientConfig, ct.clientConfigPath
+	if b.name == "frps" {
+		cfg, path = ct.serverConfig, ct.serverConfigPath
+	}
+	if cfg == nil {
+		return service.BundleSpec{}, i18n.Errorf("尚未加载或创建%s配置", bundleLabel(b.name))
+	}
+
+	// INI 配置按 YAML 导出，与迁移后的新格式保持一致
+	format := config.DetectFormat(path)
+	if format == config.FormatINI {
+		format = config.FormatYAML
+	}
+	data, err := config.MarshalConfig(cfg.Flatten(), format)
+	if err != nil {
+		return service.BundleSpec{}, i18n.Errorf("生成配置失败: %w", err)
+	}
+	data = config.WithFileMeta(data, config.NewFileMeta())
+
+	return service.BundleSpec{
+		Name:          b.name,
+		ConfigName:    b.name + format.Extension(),
+		ConfigData:    data,
+		TargetOS:      b.targetOS,
+		IncludeBinary: b.includeBinary,
+		OutputPath:    service.DefaultBundlePath(filepath.Dir(path), b.name, b.targetOS),
+	}, nil
+}
+
+// updateBundleExport 处理部署包导出面板中的按键
+func (ct *ConfigTab) updateBundleExport(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	b := ct.bundle
+	if b.exporting {
+		return ct, nil
+	}
+
+	keys := ct.keys.Config
+	switch {
+	case msg.String() == "esc":
+		ct.bundle = nil
+		ct.state = ConfigTabMenu
+	case key.Matches(msg, keys.BundleService):
+		if b.name == "frpc" {
+			b.name = "frps"
+		} else {
+			b.name = "frpc"
+		}
+		b.result, b.err = nil, nil
+	case key.Matches(msg, keys.BundleTarget):
+		for i, target := range service.BundleTargets {
+			if target == b.targetOS {
+				b.targetOS = service.BundleTargets[(i+1)%len(service.BundleTargets)]
+				break
+			}
+		}
+		b.result, b.err = nil, nil
+	case key.Matches(msg, keys.BundleBinary):
+		b.includeBinary = !b.includeBinary
+		b.result, b.err = nil, nil
+	case key.Matches(msg, keys.CopyBundlePath):
+		if b.result != nil {
+			return ct, copyCmd(b.result.Path)
+		}
+	case msg.String() == "enter":
+		spec, err := ct.bundleSpec()
+		if err != nil {
+			b.err = err
+			return ct, nil
+		}
+		b.exporting = true
+		b.result, b.err = nil, nil
+		return ct, func() tea.Msg {
+			result, err := service.ExportBundle(spec)
+			return bundleExportMsg{result: result, err: err}
+		}
+	}
+
+	return ct, nil
+}
+
+// renderBundleExport 渲染部署包导出面板
+func (ct *ConfigTab) renderBundleExport(width int) string {
+	b, keys := ct.bundle, ct.keys.Config
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	content := titleStyle.Render(i18n.T("📦 导出部署包")) + "\n\n"
+	content += labelStyle.Render(i18n.T("配置: ")) + bundleLabel(b.name) + " (" + b.name + ")\n"
+	content += labelStyle.Render(i18n.T("目标系统: ")) + b.targetOS + "\n"
+
+	binary := i18n.T("不包含，目标机器需自行安装")
+	if b.includeBinary {
+		binary = i18n.T("包含本机程序")
+		if b.targetOS != runtime.GOOS {
+			binary += lipgloss.NewStyle().Foreground(lipgloss.Color("226")).
+				Render(i18n.Sprintf(" (本机为 %s，无法打包到其他系统)", runtime.GOOS))
+		}
+	}
+	content += labelStyle.Render(i18n.T("frp 程序: ")) + binary + "\n\n"
+
+	content += hintStyle.Render(bundleContents(b.targetOS)) + "\n\n"
+
+	switch {
+	case b.exporting:
+		content += i18n.T("⏳ 正在打包...") + "\n\n"
+	case b.err != nil:
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+b.err.Error()) + "\n\n"
+	case b.result != nil:
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render(i18n.Sprintf("✅ 已导出 %s", b.result.Path)) + "\n"
+		content += truncateString(strings.Join(b.result.Files, ", "), width) + "\n"
+		content += hintStyle.Render(i18n.Sprintf("按 %s 复制部署包路径", keys.CopyBundlePath.Help().Key)) + "\n\n"
+	}
+
+	content += hintStyle.Render(i18n.Sprintf("%s 切换服务端/客户端 | %s 切换目标系统 | %s 是否包含程序 | Enter 导出 | ESC 返回",
+		keys.BundleService.Help().Key, keys.BundleTarget.Help().Key, keys.BundleBinary.Help().Key))
+	return content
+}
+
+// bundleLabel 返回服务的显示名称
+func bundleLabel(name string) string {
+	if name == "frps" {
+		return i18n.T("服务端")
+	}
+	return i18n.T("客户端")
+}
+
+// bundleContents 描述目标系统部署包中的脚本
+func bundleContents(targetOS string) string {
+	switch targetOS {
+	case "windows":
+		return i18n.T("zip 包含配置、start.bat 以及注册 Windows 服务的 install-service.bat / uninstall-service.bat")
+	case "darwin":
+		return i18n.T("tar.gz 包含配置、start.sh、launchd plist 以及安装到 /opt/frp 的 install.sh")
+	default:
+		return i18n.T("tar.gz 包含配置、start.sh、systemd unit 以及安装到 /opt/frp 的 install.sh")
+	}
+}
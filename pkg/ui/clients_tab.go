@@ -0,0 +1,320 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// clientListMsg frps 客户端列表结果
+type clientListMsg struct {
+	clients []service.ClientInfo
+	err     error
+}
+
+// clientKickMsg 断开客户端的结果
+type clientKickMsg struct {
+	client service.ClientInfo
+	err    error
+}
+
+// ClientsTab 已连接到 frps 的 frpc 客户端列表
+type ClientsTab struct {
+	BaseTab
+	table       table.Model
+	apiClient   *service.APIClient
+	appSettings *config.AppSettings
+	keys        *KeyMap
+	clients     []service.ClientInfo
+	fetchedAt   time.Time
+	err         error
+	confirmKick bool
+}
+
+// clientsColumns 客户端列表的表头，按当前语言显示
+func clientsColumns() []table.Column {
+	return []table.Column{
+		{Title: i18n.T("主机名"), Width: 16},
+		{Title: i18n.T("版本"), Width: 8},
+		{Title: i18n.T("系统/架构"), Width: 14},
+		{Title: i18n.T("代理数"), Width: 6},
+		{Title: i18n.T("用户"), Width: 10},
+		{Title: "Run ID", Width: 18},
+		{Title: i18n.T("连接时间"), Width: 16},
+	}
+}
+
+// NewClientsTab 创建客户端标签页
+func NewClientsTab(apiClient *service.APIClient) *ClientsTab {
+	t := table.New(
+		table.WithColumns(clientsColumns()),
+		table.WithRows([]table.Row{}),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	baseTab := NewBaseTab("客户端")
+	baseTab.focusable = true
+
+	ct := &ClientsTab{
+		BaseTab:   baseTab,
+		table:     t,
+		apiClient: apiClient,
+	}
+	ct.SetKeyMap(DefaultKeyMap())
+	return ct
+}
+
+// Init 初始化
+func (ct *ClientsTab) Init() tea.Cmd {
+	return nil
+}
+
+// SetAppSettings 设置应用配置，用于确定刷新间隔，并按当前语言更新表头
+func (ct *ClientsTab) SetAppSettings(settings *config.AppSettings) {
+	ct.appSettings = settings
+	ct.table.SetColumns(clientsColumns())
+}
+
+// SetKeyMap 设置快捷键，表格的上下移动同样使用自定义按键
+func (ct *ClientsTab) SetKeyMap(keys *KeyMap) {
+	ct.keys = keys
+	ct.table.KeyMap.LineUp = keys.Clients.Up
+	ct.table.KeyMap.LineDown = keys.Clients.Down
+}
+
+// IsInInputMode 等待确认断开时独占键盘
+func (ct *ClientsTab) IsInInputMode() bool {
+	return ct.confirmKick
+}
+
+// selectedClient 返回当前选中的客户端
+func (ct *ClientsTab) selectedClient() *service.ClientInfo {
+	index := ct.table.Cursor()
+	if index < 0 || index >= len(ct.clients) {
+		return nil
+	}
+	return &ct.clients[index]
+}
+
+// Update 更新状态
+func (ct *ClientsTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !ct.focused {
+			return ct, nil
+		}
+
+		if ct.confirmKick {
+			ct.confirmKick = false
+			if msg.String() == "y" {
+				return ct, ct.kickSelected()
+			}
+			return ct, nil
+		}
+
+		switch {
+		case key.Matches(msg, ct.keys.Clients.Refresh):
+			return ct, ct.fetchClients()
+		case key.Matches(msg, ct.keys.Clients.Kick):
+			if ct.selectedClient() != nil {
+				ct.confirmKick = true
+			}
+			return ct, nil
+		}
+
+	case dashboardTickMsg:
+		if time.Since(ct.fetchedAt) >= ct.refreshInterval() {
+			return ct, ct.fetchClients()
+		}
+		return ct, nil
+
+	case clientListMsg:
+		ct.err = msg.err
+		if msg.err == nil {
+			ct.setClients(msg.clients)
+		}
+		return ct, nil
+
+	case clientKickMsg:
+		if msg.err != nil {
+			return ct, showStatusMessage("❌ "+clientsErrorText(msg.err, i18n.T("当前 frps 不支持断开客户端")), true)
+		}
+		return ct, tea.Batch(
+			showStatusMessage(i18n.Sprintf("✅ 已断开客户端 %s (%s)", msg.client.Hostname, msg.client.RunID), false),
+			ct.fetchClients(),
+		)
+	}
+
+	ct.table, cmd = ct.table.Update(msg)
+	return ct, cmd
+}
+
+// refreshInterval 返回列表刷新间隔
+func (ct *ClientsTab) refreshInterval() time.Duration {
+	if ct.appSettings == nil {
+		return 3 * time.Second
+	}
+	return ct.appSettings.APIPollDuration()
+}
+
+// fetchClients 从 frps API 获取客户端列表
+func (ct *ClientsTab) fetchClients() tea.Cmd {
+	if ct.apiClient == nil {
+		return nil
+	}
+
+	// 在发出请求时记录时间，切换标签页导致结果丢失时下次仍会重新获取
+	ct.fetchedAt = time.Now()
+	apiClient := ct.apiClient
+	return func() tea.Msg {
+		clients, err := apiClient.GetClientList()
+		return clientListMsg{clients: clients, err: err}
+	}
+}
+
+// kickSelected 断开选中的客户端
+func (ct *ClientsTab) kickSelected() tea.Cmd {
+	client := ct.selectedClient()
+	if client == nil || ct.apiClient == nil {
+		return nil
+	}
+
+	target, apiClient := *client, ct.apiClient
+	return runOperation(i18n.Sprintf("正在断开客户端 %s", target.Hostname), func() tea.Msg {
+		return clientKickMsg{client: target, err: apiClient.KickClient(target.RunID)}
+	})
+}
+
+// setClients 更新客户端列表，保持选中行不越界
+func (ct *ClientsTab) setClients(clients []service.ClientInfo) {
+	ct.clients = clients
+
+	rows := make([]table.Row, len(clients))
+	for i, client := range clients {
+		platform := client.OS
+		if client.Arch != "" {
+			platform += "/" + client.Arch
+		}
+		rows[i] = table.Row{
+			client.Hostname,
+			client.Version,
+			platform,
+			fmt.Sprintf("%d", client.ProxyNum),
+			client.User,
+			client.RunID,
+			formatTime(client.LastStartTime),
+		}
+	}
+
+	ct.table.SetRows(rows)
+	if ct.table.Cursor() >= len(rows) && len(rows) > 0 {
+		ct.table.SetCursor(len(rows) - 1)
+	}
+}
+
+// clientsErrorText 返回错误说明，frps 没有对应接口时返回 unsupported
+func clientsErrorText(err error, unsupported string) string {
+	var statusErr *service.APIStatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return unsupported
+	}
+	return err.Error()
+}
+
+// View 渲染视图
+func (ct *ClientsTab) View(width int, height int) string {
+	tableWidth := width - 20
+	if tableWidth < 100 {
+		tableWidth = 100
+	}
+	ct.table.SetWidth(tableWidth)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(1)
+
+	keys := ct.keys.Clients
+	title := titleStyle.Render(i18n.Sprintf("🖥️ 已连接的客户端 (%d)", len(ct.clients))) +
+		hintStyle.Render("  "+helpLine(" • ", keys.Refresh, keys.Kick))
+
+	var body string
+	switch {
+	case ct.err != nil && len(ct.clients) == 0:
+		text := clientsErrorText(ct.err, i18n.T("当前 frps 未提供客户端列表接口 (/api/client)，请升级 frps 或检查应用设置中的 Dashboard 地址"))
+		body = containerStyle.Render(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Width(width - 24).Render("❌ " + text))
+	case len(ct.clients) == 0:
+		body = containerStyle.Render(lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Italic(true).
+			Align(lipgloss.Center).
+			Width(width - 20).
+			Padding(2).
+			Render(i18n.T("暂无已连接的客户端\n\n启动服务端并在应用设置中配置 Dashboard 地址后，frpc 连接时会显示在这里")))
+	default:
+		body = containerStyle.Render(ct.table.View())
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, body, ct.renderSelected())
+}
+
+// renderSelected 渲染选中客户端的详细信息和断开确认
+func (ct *ClientsTab) renderSelected() string {
+	client := ct.selectedClient()
+	if client == nil {
+		return ""
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+	content := "\n" + labelStyle.Render(client.Hostname) + "\n"
+	content += "Run ID: " + client.RunID + "\n"
+	if client.ConnectServerLocalIP != "" {
+		content += i18n.Sprintf("客户端地址: %s\n", client.ConnectServerLocalIP)
+	}
+	content += i18n.Sprintf("连接池: %d | 代理: %d\n", client.PoolCount, client.ProxyNum)
+	content += i18n.Sprintf("连接时间: %s", formatTime(client.LastStartTime))
+	if client.LastCloseTime != "" {
+		content += i18n.Sprintf(" | 上次断开: %s", formatTime(client.LastCloseTime))
+	}
+	content += "\n"
+
+	if ct.confirmKick {
+		content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("226")).
+			Render(i18n.Sprintf("确定断开客户端 %s (%s) 吗？frpc 会按配置自动重连 (y/N)", client.Hostname, client.RunID))
+	}
+	if ct.err != nil {
+		content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(i18n.T("❌ 刷新失败: ")+ct.err.Error())
+	}
+
+	return content
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// copyToClipboard 复制文本并返回使用的方式。本机会话优先使用系统剪贴板（pbcopy、xclip/xsel/wl-copy、Windows 剪贴板），
+// SSH 会话或系统剪贴板不可用时通过 OSC52 交给本地终端
+func copyToClipboard(text string) string {
+	if os.Getenv("SSH_TTY") == "" && os.Getenv("SSH_CONNECTION") == "" {
+		if err := clipboard.WriteAll(text); err == nil {
+			return i18n.T("系统剪贴板")
+		}
+	}
+
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	// bubbletea 占用标准输出，写到标准错误避免干扰界面渲染
+	seq.WriteTo(os.Stderr)
+	return "OSC52"
+}
+
+// copyCmd 在后台复制文本，完成后在状态栏提示复制的内容
+func copyCmd(text string) tea.Cmd {
+	return func() tea.Msg {
+		method := copyToClipboard(text)
+		if lines := strings.Count(strings.TrimRight(text, "\n"), "\n") + 1; lines > 1 {
+			return statusMessageMsg{text: i18n.Sprintf("📋 已复制 %d 行到剪贴板 (%s)", lines, method)}
+		}
+		return statusMessageMsg{text: i18n.Sprintf("📋 已复制到剪贴板 (%s): %s", method, truncateString(text, 60))}
+	}
+}
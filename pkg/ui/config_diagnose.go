@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// diagnosisMaxLines 诊断结果每屏最多显示的行数
+const diagnosisMaxLines = 16
+
+// configDiagnosis 跨配置诊断面板状态
+type configDiagnosis struct {
+	profiles    []config.ConfigProfile
+	diagnostics []config.Diagnostic
+	offset      int
+}
+
+// handleDiagnose 读取所有已知配置并打开诊断面板
+func (ct *ConfigTab) handleDiagnose() (Tab, tea.Cmd) {
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	ct.diagnosis = ct.runDiagnosis()
+	ct.state = ConfigTabDiagnose
+	return ct, nil
+}
+
+// runDiagnosis 读取所有已知配置做交叉检查，当前编辑的配置使用内存中尚未保存的版本
+func (ct *ConfigTab) runDiagnosis() *configDiagnosis {
+	paths := append([]string{ct.serverConfigPath, ct.clientConfigPath}, config.KnownConfigPaths(ct.appSettings)...)
+
+	var profiles []config.ConfigProfile
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			abs = path
+		}
+		if path == "" || seen[abs] {
+			continue
+		}
+		seen[abs] = true
+
+		switch {
+		case path == ct.serverConfigPath && ct.serverConfig != nil:
+			profiles = append(profiles, config.NewConfigProfile(path, ct.serverConfig))
+		case path == ct.clientConfigPath && ct.clientConfig != nil:
+			profiles = append(profiles, config.NewConfigProfile(path, ct.clientConfig))
+		default:
+			profiles = append(profiles, config.LoadConfigProfile(path))
+		}
+	}
+
+	return &configDiagnosis{
+		profiles:    profiles,
+		diagnostics: config.NewValidator().AnalyzeProfiles(profiles),
+	}
+}
+
+// updateDiagnosis 处理诊断面板中的按键
+func (ct *ConfigTab) updateDiagnosis(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	d := ct.diagnosis
+	keys := ct.keys.Config
+
+	switch {
+	case msg.String() == "esc":
+		ct.diagnosis = nil
+		ct.state = ConfigTabMenu
+	case key.Matches(msg, keys.Up):
+		if d.offset > 0 {
+			d.offset--
+		}
+	case key.Matches(msg, keys.Down):
+		if d.offset < len(d.diagnostics)-1 {
+			d.offset++
+		}
+	case key.Matches(msg, keys.Diagnose):
+		ct.diagnosis = ct.runDiagnosis()
+		return ct, showStatusMessage(i18n.T("🩺 已重新诊断"), false)
+	}
+	return ct, nil
+}
+
+// renderDiagnosis 渲染诊断面板
+func (ct *ConfigTab) renderDiagnosis(width int) string {
+	d := ct.diagnosis
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	lineStyle := lipgloss.NewStyle().Width(width - 4)
+
+	content := titleStyle.Render(i18n.T("🩺 配置诊断")) + "\n\n"
+
+	content += sectionStyle.Render(i18n.Sprintf("📂 已检查的配置 (%d)", len(d.profiles))) + "\n"
+	for _, profile := range d.profiles {
+		var role string
+		switch {
+		case profile.Err != nil:
+			role = i18n.T("无法读取")
+		case profile.Role == "server":
+			role = i18n.T("服务端")
+		case profile.Role == "client":
+			role = i18n.Sprintf("客户端，%d 个代理", len(profile.Config.Proxies))
+		default:
+			role = i18n.T("未知类型")
+		}
+		content += "  " + profile.Path + "  " + hintStyle.Render(role) + "\n"
+	}
+	content += "\n"
+
+	errors, warnings := 0, 0
+	for _, diagnostic := range d.diagnostics {
+		if diagnostic.Severity == config.DiagnosticError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+
+	if len(d.diagnostics) == 0 {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render(i18n.T("✅ 未发现端口冲突、代理重名或 allowPorts 问题")) + "\n\n"
+	} else {
+		content += sectionStyle.Render(i18n.Sprintf("🔍 发现 %d 个错误，%d 个警告", errors, warnings)) + "\n"
+
+		end := d.offset + diagnosisMaxLines
+		if end > len(d.diagnostics) {
+			end = len(d.diagnostics)
+		}
+		for _, diagnostic := range d.diagnostics[d.offset:end] {
+			if diagnostic.Severity == config.DiagnosticError {
+				content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Inherit(lineStyle).Render("❌ "+diagnostic.Message) + "\n"
+			} else {
+				content += lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Inherit(lineStyle).Render("⚠️ "+diagnostic.Message) + "\n"
+			}
+		}
+		if d.offset > 0 || end < len(d.diagnostics) {
+			content += hintStyle.Render(i18n.Sprintf("第 %d-%d 条，共 %d 条", d.offset+1, end, len(d.diagnostics))) + "\n"
+		}
+		content += "\n"
+	}
+
+	content += hintStyle.Render(strings.Join([]string{
+		i18n.T("客户端按服务端端口与服务端配置对应；当前编辑的配置包含未保存的修改"),
+		i18n.Sprintf("↑/↓ 滚动 | %s 重新诊断 | ESC 返回菜单", ct.keys.Config.Diagnose.Help().Key),
+	}, "\n"))
+	return content
+}
@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// configFileOption 选择配置文件时的一个选项，role 为空表示按识别结果自动分配
+type configFileOption struct {
+	label string
+	role  string
+}
+
+// configFileChoice 选择配置文件的子菜单：先选择作为服务端还是客户端配置打开，
+// 选择文件后按 DetectConfigType 识别类型，与所选不符或无法识别时再询问分配方式
+type configFileChoice struct {
+	options  []configFileOption
+	cursor   int
+	role     string         // 子菜单中选择的类型，为空表示自动识别
+	path     string         // 已选择、等待确认分配方式的文件
+	cfg      *config.Config // 已读取的配置，新文件为 nil
+	detected string         // 识别出的配置类型
+}
+
+// handleChangeConfigFile 打开选择配置文件的子菜单
+func (ct *ConfigTab) handleChangeConfigFile() (Tab, tea.Cmd) {
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	ct.fileChoice = &configFileChoice{
+		options: []configFileOption{
+			{label: i18n.T("🔍 自动识别类型")},
+			{label: i18n.T("🎯 作为服务端配置打开"), role: "server"},
+			{label: i18n.T("💻 作为客户端配置打开"), role: "client"},
+		},
+	}
+	ct.state = ConfigTabFileChoice
+	return ct, nil
+}
+
+// updateFileChoice 处理子菜单中的按键，Enter 打开文件选择器或确认分配方式
+func (ct *ConfigTab) updateFileChoice(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	c := ct.fileChoice
+	switch {
+	case msg.String() == "esc":
+		ct.fileChoice = nil
+		ct.state = ConfigTabMenu
+	case key.Matches(msg, ct.keys.Config.Up):
+		if c.cursor > 0 {
+			c.cursor--
+		}
+	case key.Matches(msg, ct.keys.Config.Down):
+		if c.cursor < len(c.options)-1 {
+			c.cursor++
+		}
+	case msg.String() == "enter":
+		option := c.options[c.cursor]
+		if c.path != "" {
+			return ct.assignConfigFile(option.role, c.path, c.cfg)
+		}
+		c.role = option.role
+		return ct, ct.openConfigFilePicker()
+	}
+	return ct, nil
+}
+
+// openConfigFilePicker 打开文件选择器，可以选择已有文件或新建文件
+func (ct *ConfigTab) openConfigFilePicker() tea.Cmd {
+	title := i18n.T("选择配置文件")
+	switch ct.fileChoice.role {
+	case "server":
+		title = i18n.T("选择服务端配置文件")
+	case "client":
+		title = i18n.T("选择客户端配置文件")
+	}
+	ct.filePicker = NewFilePicker(title, FilePickerModeFile)
+	ct.filePicker.SetKeyMap(ct.keys)
+	ct.filePicker.SetExtensions([]string{".yaml", ".yml", ".toml", ".ini"})
+	ct.filePicker.SetAllowCreate(true)
+	ct.filePicker.SetStartPath(config.GetDefaultWorkDir())
+	ct.filePicker.SetSize(ct.width, ct.height)
+	return ct.filePicker.Show()
+}
+
+// handleConfigFileChosen 读取选择的文件并识别类型，与所选类型一致时直接分配，否则询问分配方式。
+// 在文件选择器中取消时不会调用，停留在子菜单中可以重新选择用途
+func (ct *ConfigTab) handleConfigFileChosen(result FilePickerResult) (Tab, tea.Cmd) {
+	c := ct.fileChoice
+	var cfg *config.Config
+	detected := "unknown"
+	if !result.New {
+		loaded, err := config.NewLoader(result.Path).Load()
+		if err != nil {
+			return ct, showStatusMessage(i18n.Sprintf("❌ 读取配置文件失败: %v", err), true)
+		}
+		cfg = loaded
+		detected = config.DetectConfigType(cfg)
+	}
+
+	switch {
+	case c.role == "" && detected != "unknown":
+		return ct.assignConfigFile(detected, result.Path, cfg)
+	case c.role != "" && (detected == c.role || detected == "unknown"):
+		return ct.assignConfigFile(c.role, result.Path, cfg)
+	}
+
+	// 识别结果与所选不符或无法识别，把可选的分配方式列出来，识别结果排在最前
+	c.path, c.cfg, c.detected = result.Path, cfg, detected
+	c.options = nil
+	if detected != "unknown" {
+		c.options = append(c.options, configFileOption{label: i18n.Sprintf("按识别结果作为%s打开", configRoleLabel(detected)), role: detected})
+	}
+	for _, role := range []string{"server", "client"} {
+		if role != detected {
+			c.options = append(c.options, configFileOption{label: i18n.Sprintf("作为%s打开", configRoleLabel(role)), role: role})
+		}
+	}
+	c.cursor = 0
+	return ct, nil
+}
+
+// assignConfigFile 把文件作为服务端或客户端配置打开，新文件保留当前配置，保存时写入该文件
+func (ct *ConfigTab) assignConfigFile(role, path string, cfg *config.Config) (Tab, tea.Cmd) {
+	ct.fileChoice = nil
+	ct.state = ConfigTabMenu
+
+	if role == "server" {
+		ct.serverConfigPath = path
+		if cfg != nil {
+			ct.serverConfig = cfg
+		}
+		ct.history.reset(i18n.T("打开服务端配置 ")+filepath.Base(path), ct.serverConfig, ct.clientConfig)
+	} else {
+		ct.clientConfigPath = path
+		if cfg != nil {
+			ct.clientConfig = cfg
+		}
+		ct.history.reset(i18n.T("打开客户端配置 ")+filepath.Base(path), ct.serverConfig, ct.clientConfig)
+	}
+
+	if cfg == nil {
+		return ct, showStatusMessage(i18n.Sprintf("📄 %s 尚不存在，保存%s时会创建", path, configRoleLabel(role)), false)
+	}
+	text := i18n.Sprintf("📁 已将 %s 作为%s打开", filepath.Base(path), configRoleLabel(role))
+	if meta := config.ReadFileMeta(path); meta != nil {
+		text += "，" + meta.Summary()
+	}
+	return ct, showStatusMessage(text, false)
+}
+
+// configRoleLabel 返回配置类型的显示名称
+func configRoleLabel(role string) string {
+	if role == "server" {
+		return i18n.T("服务端配置")
+	}
+	return i18n.T("客户端配置")
+}
+
+// fileMetaCache 缓存配置文件头部的元数据，文件修改时间变化时重新读取
+type fileMetaCache struct {
+	path    string
+	modTime time.Time
+	meta    *config.FileMeta
+}
+
+// get 返回文件头部的元数据，没有元数据时返回 nil
+func (c *fileMetaCache) get(path string, info os.FileInfo) *config.FileMeta {
+	if c.path != path || !c.modTime.Equal(info.ModTime()) {
+		c.path, c.modTime = path, info.ModTime()
+		c.meta = config.ReadFileMeta(path)
+	}
+	return c.meta
+}
+
+// renderFileChoice 渲染选择配置文件的子菜单或分配方式确认
+func (ct *ConfigTab) renderFileChoice() string {
+	c := ct.fileChoice
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Padding(0, 1)
+	normalStyle := lipgloss.NewStyle().Padding(0, 1)
+
+	content := titleStyle.Render(i18n.T("📁 选择配置文件")) + "\n"
+	if c.path == "" {
+		content += hintStyle.Render(i18n.T("选择文件的用途，自动识别时按 bindPort、serverAddr、代理等字段判断")) + "\n\n"
+	} else {
+		content += i18n.Sprintf("文件: %s", c.path) + "\n"
+		switch {
+		case c.cfg == nil:
+			content += warnStyle.Render(i18n.T("⚠️  新文件无法识别类型，请选择用途")) + "\n\n"
+		case c.detected == "unknown":
+			content += warnStyle.Render(i18n.T("⚠️  无法识别配置类型，请选择用途")) + "\n\n"
+		default:
+			content += warnStyle.Render(i18n.Sprintf("⚠️  文件看起来是%s，与选择的%s不符", configRoleLabel(c.detected), configRoleLabel(c.role))) + "\n\n"
+		}
+	}
+
+	for i, option := range c.options {
+		if i == c.cursor {
+			content += selectedStyle.Render("▶ "+option.label) + "\n"
+		} else {
+			content += normalStyle.Render("  "+option.label) + "\n"
+		}
+	}
+
+	hint := i18n.T("↑/↓ 选择 | Enter 选择文件 | ESC 返回菜单")
+	if c.path != "" {
+		hint = i18n.T("↑/↓ 选择 | Enter 确认 | ESC 取消")
+	}
+	content += "\n" + hintStyle.Render(hint)
+	return content
+}
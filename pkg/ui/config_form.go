@@ -1,7 +1,7 @@
 package ui
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
 	"strings"
 
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/huh"
 
 	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
 )
 
 // ConfigFormType 配置表单类型
@@ -32,6 +33,10 @@ type ConfigFormModel struct {
 	err           error
 	// 添加表单数据绑定字段
 	formData map[string]*string
+	// 提交时跨字段校验发现的问题，修改后重新提交
+	issues []config.FieldIssue
+	// 代理表单中不能使用的名称，即客户端配置中其他代理的名称
+	takenNames []string
 }
 
 // NewServerConfigForm 创建服务端配置表单
@@ -41,15 +46,12 @@ func NewServerConfigForm(cfg *config.Config) *ConfigFormModel {
 	}
 
 	// 创建表单数据绑定
-	formData := make(map[string]*string)
-	formData["bindPort"] = new(string)
-	formData["webPort"] = new(string)
-	formData["webAddr"] = new(string)
-	formData["webUser"] = new(string)
-	formData["webPassword"] = new(string)
-	formData["logTo"] = new(string)
-	formData["logLevel"] = new(string)
-	formData["token"] = new(string)
+	formData := newFormData(
+		"bindPort", "token", "webAddr", "webPort", "webUser", "webPassword", "logTo", "logLevel",
+		"allowPorts", "maxPortsPerClient", "vhostHTTPPort", "vhostHTTPSPort", "vhostHTTPTimeout",
+		"subDomainHost", "custom404Page", "tcpmuxHTTPConnectPort", "tcpmuxPassthrough", "heartbeatTimeout",
+		"sshBindPort", "sshPrivateKeyFile", "sshAutoGenKeyPath", "sshAuthorizedKeys",
+	)
 
 	// 初始化表单数据
 	if cfg.BindPort > 0 {
@@ -63,32 +65,49 @@ func NewServerConfigForm(cfg *config.Config) *ConfigFormModel {
 	*formData["webPassword"] = cfg.WebServer.Password
 	*formData["logTo"] = cfg.Log.To
 	*formData["logLevel"] = cfg.Log.Level
-	*formData["token"] = cfg.Token
+	*formData["token"] = cfg.Auth.Token
+	*formData["allowPorts"] = config.FormatPortRanges(cfg.AllowPorts)
+	*formData["maxPortsPerClient"] = formatOptionalInt(cfg.MaxPortsPerClient)
+	*formData["vhostHTTPPort"] = formatOptionalInt(cfg.VhostHTTPPort)
+	*formData["vhostHTTPSPort"] = formatOptionalInt(cfg.VhostHTTPSPort)
+	*formData["vhostHTTPTimeout"] = formatOptionalInt(cfg.VhostHTTPTimeout)
+	*formData["subDomainHost"] = cfg.SubDomainHost
+	*formData["custom404Page"] = cfg.Custom404Page
+	*formData["tcpmuxHTTPConnectPort"] = formatOptionalInt(cfg.TCPMuxHTTPConnectPort)
+	*formData["tcpmuxPassthrough"] = yesNo(cfg.TCPMuxPassthrough)
+	*formData["heartbeatTimeout"] = formatOptionalInt(cfg.Transport.HeartbeatTimeout)
+	*formData["sshBindPort"] = formatOptionalInt(cfg.SSHTunnelGateway.BindPort)
+	*formData["sshPrivateKeyFile"] = cfg.SSHTunnelGateway.PrivateKeyFile
+	*formData["sshAutoGenKeyPath"] = cfg.SSHTunnelGateway.AutoGenPrivateKeyPath
+	*formData["sshAuthorizedKeys"] = cfg.SSHTunnelGateway.AuthorizedKeysFile
 
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewInput().
-				Title("服务端监听端口").
-				Description("FRP 服务端监听端口，客户端通过此端口连接").
+				Title(i18n.T("服务端监听端口")).
+				Description(i18n.T("FRP 服务端监听端口，客户端通过此端口连接")).
 				Placeholder("7000").
+				Key("bindPort").
 				Value(formData["bindPort"]),
 
 			huh.NewInput().
-				Title("认证令牌 (可选)").
-				Description("客户端连接时使用的认证令牌，留空表示不需要认证").
+				Title(i18n.T("认证令牌 (可选)")).
+				Description(i18n.T("客户端连接时使用的认证令牌，留空表示不需要认证")).
 				Placeholder("your_secure_token_here").
+				Key("token").
 				Value(formData["token"]),
 
 			huh.NewInput().
-				Title("Web 管理界面地址").
-				Description("Web 管理界面监听地址").
+				Title(i18n.T("Web 管理界面地址")).
+				Description(i18n.T("Web 管理界面监听地址")).
 				Placeholder("127.0.0.1").
 				Value(formData["webAddr"]),
 
 			huh.NewInput().
-				Title("Web 管理界面端口").
-				Description("Web 管理界面监听端口").
+				Title(i18n.T("Web 管理界面端口")).
+				Description(i18n.T("Web 管理界面监听端口")).
 				Placeholder("7500").
+				Key("webPort").
 				Value(formData["webPort"]).
 				Validate(func(str string) error {
 					if str == "" {
@@ -96,38 +115,31 @@ func NewServerConfigForm(cfg *config.Config) *ConfigFormModel {
 					}
 					port, err := strconv.Atoi(str)
 					if err != nil {
-						return fmt.Errorf("端口必须是数字")
+						return i18n.Errorf("端口必须是数字")
 					}
 					if port < 1 || port > 65535 {
-						return fmt.Errorf("端口必须在 1-65535 范围内")
+						return i18n.Errorf("端口必须在 1-65535 范围内")
 					}
 					return nil
 				}),
 
 			huh.NewInput().
-				Title("Web 管理用户名").
-				Description("Web 管理界面登录用户名").
+				Title(i18n.T("Web 管理用户名")).
+				Description(i18n.T("Web 管理界面登录用户名")).
 				Placeholder("admin").
 				Value(formData["webUser"]),
 
 			huh.NewInput().
-				Title("Web 管理密码").
-				Description("Web 管理界面登录密码").
+				Title(i18n.T("Web 管理密码")).
+				Description(i18n.T("Web 管理界面登录密码")).
 				Placeholder("admin").
 				Value(formData["webPassword"]).
 				EchoMode(huh.EchoModePassword),
-			huh.NewSelect[string]().
-				Title("日志输出位置").
-				Description("选择日志输出的位置").
-				Options(
-					huh.NewOption("控制台", "console"),
-					huh.NewOption("文件", "file"),
-				).
-				Value(formData["logTo"]),
+			newLogToInput(formData["logTo"]),
 
 			huh.NewSelect[string]().
-				Title("日志级别").
-				Description("选择日志记录级别").
+				Title(i18n.T("日志级别")).
+				Description(i18n.T("选择日志记录级别")).
 				Options(
 					huh.NewOption("Trace", "trace"),
 					huh.NewOption("Debug", "debug"),
@@ -136,7 +148,118 @@ func NewServerConfigForm(cfg *config.Config) *ConfigFormModel {
 					huh.NewOption("Error", "error"),
 				).
 				Value(formData["logLevel"]),
-		).Title("📄 日志配置"),
+		).Title(i18n.T("📄 日志配置")),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("允许的端口范围")).
+				Description(i18n.T("客户端可使用的远程端口，逗号分隔，如 2000-3000,3001；留空表示不限")).
+				Placeholder("2000-3000,3001").
+				Key("allowPorts").
+				Value(formData["allowPorts"]).
+				Validate(func(str string) error {
+					ranges, err := config.ParsePortRanges(str)
+					if err != nil {
+						return err
+					}
+					return config.ValidatePortRanges(ranges)
+				}),
+
+			huh.NewInput().
+				Title(i18n.T("每个客户端最大端口数")).
+				Description(i18n.T("单个客户端最多可占用的端口数，留空或 0 表示不限")).
+				Placeholder("0").
+				Value(formData["maxPortsPerClient"]).
+				Validate(validateOptionalNumber(0)),
+
+			huh.NewInput().
+				Title(i18n.T("HTTP 虚拟主机端口")).
+				Description(i18n.T("HTTP 类型代理共用的监听端口，留空表示不启用")).
+				Placeholder("80").
+				Key("vhostHTTPPort").
+				Value(formData["vhostHTTPPort"]).
+				Validate(validateOptionalPort),
+
+			huh.NewInput().
+				Title(i18n.T("HTTPS 虚拟主机端口")).
+				Description(i18n.T("HTTPS 类型代理共用的监听端口，留空表示不启用")).
+				Placeholder("443").
+				Key("vhostHTTPSPort").
+				Value(formData["vhostHTTPSPort"]).
+				Validate(validateOptionalPort),
+
+			huh.NewInput().
+				Title(i18n.T("HTTP 响应超时(秒)")).
+				Description(i18n.T("HTTP 代理等待后端返回响应头的时间，留空使用默认值 60")).
+				Placeholder("60").
+				Value(formData["vhostHTTPTimeout"]).
+				Validate(validateOptionalNumber(0)),
+
+			huh.NewInput().
+				Title(i18n.T("子域名主域名")).
+				Description(i18n.T("设置后代理可使用 subdomain，访问地址为 <subdomain>.<主域名>")).
+				Placeholder("frps.example.com").
+				Key("subDomainHost").
+				Value(formData["subDomainHost"]),
+
+			huh.NewInput().
+				Title(i18n.T("自定义 404 页面")).
+				Description(i18n.T("访问的域名没有对应的 HTTP 代理时返回的 HTML 文件，留空使用 frps 自带页面")).
+				Placeholder("/etc/frp/404.html").
+				Value(formData["custom404Page"]),
+
+			huh.NewInput().
+				Title(i18n.T("tcpmux HTTP CONNECT 端口")).
+				Description(i18n.T("tcpmux 类型代理使用的 HTTP CONNECT 端口，留空表示不启用")).
+				Placeholder("1337").
+				Key("tcpmuxHTTPConnectPort").
+				Value(formData["tcpmuxHTTPConnectPort"]).
+				Validate(validateOptionalPort),
+
+			huh.NewSelect[string]().
+				Title(i18n.T("tcpmux 透传")).
+				Description(i18n.T("开启后 frps 不处理 CONNECT 请求，原样转发给客户端")).
+				Options(
+					huh.NewOption(i18n.T("关闭"), "no"),
+					huh.NewOption(i18n.T("开启"), "yes"),
+				).
+				Value(formData["tcpmuxPassthrough"]),
+
+			huh.NewInput().
+				Title(i18n.T("心跳超时(秒)")).
+				Description(i18n.T("超过该时间未收到客户端心跳即断开，留空使用默认值 90，-1 表示关闭")).
+				Placeholder("90").
+				Value(formData["heartbeatTimeout"]).
+				Validate(validateOptionalNumber(-1)),
+		).Title(i18n.T("🌐 端口与虚拟主机")),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("SSH 隧道网关端口")).
+				Description(i18n.T("frp v0.53+ 支持，未运行 frpc 的机器可用 ssh -R 创建代理，留空表示不启用")).
+				Placeholder("2200").
+				Key("sshBindPort").
+				Value(formData["sshBindPort"]).
+				Validate(validateOptionalPort),
+
+			huh.NewInput().
+				Title(i18n.T("SSH 主机私钥 (可选)")).
+				Description(i18n.T("frps 作为 SSH 服务端使用的私钥文件")).
+				Placeholder("/home/frp/.ssh/id_rsa").
+				Value(formData["sshPrivateKeyFile"]),
+
+			huh.NewInput().
+				Title(i18n.T("自动生成私钥路径 (可选)")).
+				Description(i18n.T("未指定主机私钥时 frps 自动生成私钥并保存到此路径")).
+				Placeholder("./.autogen_ssh_key").
+				Value(formData["sshAutoGenKeyPath"]),
+
+			huh.NewInput().
+				Title(i18n.T("授权公钥文件 (可选)")).
+				Description(i18n.T("格式同 authorized_keys，留空时不校验连接者的公钥，任何人都能创建代理")).
+				Placeholder("/home/frp/.ssh/authorized_keys").
+				Value(formData["sshAuthorizedKeys"]),
+		).Title(i18n.T("🔑 SSH 隧道网关")),
 	)
 
 	// 表单创建完成，配置更新在 Update 方法中处理
@@ -155,27 +278,48 @@ func NewClientConfigForm(cfg *config.Config) *ConfigFormModel {
 		cfg = config.CreateDefaultClientConfig()
 	}
 
-	var serverAddr, serverPort, token string
-	var logTo, logLevel string
+	// 创建表单数据绑定
+	formData := newFormData(
+		"serverAddr", "serverPort", "token", "logTo", "logLevel",
+		"protocol", "poolCount", "dialServerTimeout", "proxyURL",
+		"tlsEnable", "tlsCertFile", "tlsKeyFile", "tlsTrustedCaFile", "tlsServerName",
+	)
 
-	serverAddr = cfg.ServerAddr
-	if cfg.ServerPort > 0 {
-		serverPort = strconv.Itoa(cfg.ServerPort)
+	// 初始化表单数据
+	*formData["serverAddr"] = cfg.ServerAddr
+	*formData["serverPort"] = formatOptionalInt(cfg.ServerPort)
+	*formData["token"] = cfg.Auth.Token
+	*formData["logTo"] = cfg.Log.To
+	*formData["logLevel"] = cfg.Log.Level
+	*formData["protocol"] = cfg.Transport.Protocol
+	if *formData["protocol"] == "" {
+		*formData["protocol"] = "tcp"
+	}
+	*formData["poolCount"] = formatOptionalInt(cfg.Transport.PoolCount)
+	*formData["dialServerTimeout"] = formatOptionalInt(cfg.Transport.DialServerTimeout)
+	*formData["proxyURL"] = cfg.Transport.ProxyURL
+	*formData["tlsEnable"] = yesNo(cfg.Transport.TLS.Enabled())
+	*formData["tlsCertFile"] = cfg.Transport.TLS.CertFile
+	*formData["tlsKeyFile"] = cfg.Transport.TLS.KeyFile
+	*formData["tlsTrustedCaFile"] = cfg.Transport.TLS.TrustedCaFile
+	*formData["tlsServerName"] = cfg.Transport.TLS.ServerName
+
+	protocolOptions := make([]huh.Option[string], 0, len(config.TransportProtocols))
+	for _, protocol := range config.TransportProtocols {
+		protocolOptions = append(protocolOptions, huh.NewOption(strings.ToUpper(protocol), protocol))
 	}
-	token = cfg.Token
-	logTo = cfg.Log.To
-	logLevel = cfg.Log.Level
 
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewInput().
-				Title("服务器地址").
-				Description("FRP 服务端的 IP 地址或域名").
-				Placeholder("如: 123.456.789.123 或 your-server.com (本地测试填 127.0.0.1)").
-				Value(&serverAddr).
+				Title(i18n.T("服务器地址")).
+				Description(i18n.T("FRP 服务端的 IP 地址或域名")).
+				Placeholder(i18n.T("如: 123.456.789.123 或 your-server.com (本地测试填 127.0.0.1)")).
+				Key("serverAddr").
+				Value(formData["serverAddr"]).
 				Validate(func(str string) error {
 					if strings.TrimSpace(str) == "" {
-						return fmt.Errorf("服务器地址不能为空")
+						return i18n.Errorf("服务器地址不能为空")
 					}
 					// 简单的IP或域名格式检查
 					str = strings.TrimSpace(str)
@@ -184,52 +328,47 @@ func NewClientConfigForm(cfg *config.Config) *ConfigFormModel {
 					}
 					// 检查是否包含非法字符
 					if strings.Contains(str, " ") {
-						return fmt.Errorf("服务器地址不能包含空格")
+						return i18n.Errorf("服务器地址不能包含空格")
 					}
 					return nil
 				}),
 
 			huh.NewInput().
-				Title("服务器端口").
-				Description("FRP 服务端监听端口 (默认: 7000)").
+				Title(i18n.T("服务器端口")).
+				Description(i18n.T("FRP 服务端监听端口 (默认: 7000)")).
 				Placeholder("7000").
-				Value(&serverPort).
+				Key("serverPort").
+				Value(formData["serverPort"]).
 				Validate(func(str string) error {
 					// 如果为空，设置默认值
 					if str == "" {
-						serverPort = "7000"
+						*formData["serverPort"] = "7000"
 						return nil
 					}
 					port, err := strconv.Atoi(str)
 					if err != nil {
-						return fmt.Errorf("端口必须是数字")
+						return i18n.Errorf("端口必须是数字")
 					}
 					if port < 1 || port > 65535 {
-						return fmt.Errorf("端口必须在 1-65535 范围内")
+						return i18n.Errorf("端口必须在 1-65535 范围内")
 					}
 					return nil
 				}),
 
 			huh.NewInput().
-				Title("认证令牌 (可选)").
-				Description("服务端设置的认证令牌，需与服务端一致。如果服务端未设置可留空").
-				Placeholder("留空表示无认证").
-				Value(&token),
-		).Title("🔧 服务器连接配置"),
+				Title(i18n.T("认证令牌 (可选)")).
+				Description(i18n.T("服务端设置的认证令牌，需与服务端一致。如果服务端未设置可留空")).
+				Placeholder(i18n.T("留空表示无认证")).
+				Key("token").
+				Value(formData["token"]),
+		).Title(i18n.T("🔧 服务器连接配置")),
 
 		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("日志输出位置").
-				Description("选择日志输出的位置").
-				Options(
-					huh.NewOption("控制台", "console"),
-					huh.NewOption("文件", "file"),
-				).
-				Value(&logTo),
+			newLogToInput(formData["logTo"]),
 
 			huh.NewSelect[string]().
-				Title("日志级别").
-				Description("选择日志记录级别").
+				Title(i18n.T("日志级别")).
+				Description(i18n.T("选择日志记录级别")).
 				Options(
 					huh.NewOption("Trace", "trace"),
 					huh.NewOption("Debug", "debug"),
@@ -237,8 +376,70 @@ func NewClientConfigForm(cfg *config.Config) *ConfigFormModel {
 					huh.NewOption("Warn", "warn"),
 					huh.NewOption("Error", "error"),
 				).
-				Value(&logLevel),
-		).Title("📄 日志配置"),
+				Value(formData["logLevel"]),
+		).Title(i18n.T("📄 日志配置")),
+
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(i18n.T("传输协议")).
+				Description(i18n.T("与服务端通信使用的协议，kcp/quic 需服务端开启对应端口")).
+				Options(protocolOptions...).
+				Key("protocol").
+				Value(formData["protocol"]),
+
+			huh.NewInput().
+				Title(i18n.T("连接池数量")).
+				Description(i18n.T("预先建立的连接数，留空或 0 表示不预建")).
+				Placeholder("0").
+				Value(formData["poolCount"]).
+				Validate(validateOptionalNumber(0)),
+
+			huh.NewInput().
+				Title(i18n.T("连接超时(秒)")).
+				Description(i18n.T("连接服务端的超时时间，留空使用默认值 10")).
+				Placeholder("10").
+				Value(formData["dialServerTimeout"]).
+				Validate(validateOptionalNumber(0)),
+
+			huh.NewInput().
+				Title(i18n.T("代理地址")).
+				Description(i18n.T("通过 http/socks5 代理连接服务端，留空表示直连")).
+				Placeholder("socks5://127.0.0.1:1080").
+				Value(formData["proxyURL"]),
+
+			huh.NewSelect[string]().
+				Title(i18n.T("启用 TLS")).
+				Description(i18n.T("frpc 默认开启，关闭后与服务端的通信不加密")).
+				Options(
+					huh.NewOption(i18n.T("开启"), "yes"),
+					huh.NewOption(i18n.T("关闭"), "no"),
+				).
+				Value(formData["tlsEnable"]),
+
+			huh.NewInput().
+				Title(i18n.T("客户端证书")).
+				Description(i18n.T("双向认证时使用的证书文件路径，需与私钥同时填写")).
+				Placeholder("/etc/frp/client.crt").
+				Value(formData["tlsCertFile"]),
+
+			huh.NewInput().
+				Title(i18n.T("客户端私钥")).
+				Description(i18n.T("与客户端证书配对的私钥文件路径")).
+				Placeholder("/etc/frp/client.key").
+				Value(formData["tlsKeyFile"]),
+
+			huh.NewInput().
+				Title(i18n.T("受信任的 CA")).
+				Description(i18n.T("用于校验服务端证书的 CA 文件，留空表示不校验")).
+				Placeholder("/etc/frp/ca.crt").
+				Value(formData["tlsTrustedCaFile"]),
+
+			huh.NewInput().
+				Title(i18n.T("TLS 服务端名称")).
+				Description(i18n.T("校验服务端证书时使用的名称，留空使用服务器地址")).
+				Placeholder("frps.example.com").
+				Value(formData["tlsServerName"]),
+		).Title(i18n.T("🔒 传输与 TLS")),
 	)
 
 	// 表单创建完成，配置更新在 Update 方法中处理
@@ -247,6 +448,7 @@ func NewClientConfigForm(cfg *config.Config) *ConfigFormModel {
 		form:     form,
 		formType: ClientConfigForm,
 		config:   cfg,
+		formData: formData,
 	}
 }
 
@@ -274,74 +476,162 @@ func NewProxyConfigForm(proxy *config.ProxyConfig) *ConfigFormModel {
 	customDomains = strings.Join(proxy.CustomDomains, ",")
 	secretKey = proxy.SecretKey
 
+	var locations, httpUser, httpPassword, hostHeaderRewrite, requestHeaders string
+	locations = strings.Join(proxy.Locations, ",")
+	httpUser = proxy.HTTPUser
+	httpPassword = proxy.HTTPPassword
+	hostHeaderRewrite = proxy.HostHeaderRewrite
+	requestHeaders = config.FormatHeaders(proxy.RequestHeaders.Set)
+
+	var group, groupKey, healthCheckType, healthCheckPath string
+	var healthCheckInterval, healthCheckTimeout, healthCheckMaxFailed string
+	group = proxy.LoadBalancer.Group
+	groupKey = proxy.LoadBalancer.GroupKey
+	healthCheckType = proxy.HealthCheck.Type
+	healthCheckPath = proxy.HealthCheck.Path
+	healthCheckInterval = formatOptionalInt(proxy.HealthCheck.IntervalS)
+	healthCheckTimeout = formatOptionalInt(proxy.HealthCheck.TimeoutS)
+	healthCheckMaxFailed = formatOptionalInt(proxy.HealthCheck.MaxFailed)
+
+	var plugin, pluginUnixPath, pluginUser, pluginPassword, pluginLocalPath, pluginStripPrefix string
+	var pluginLocalAddr, pluginCrtPath, pluginKeyPath, pluginHostHeaderRewrite string
+	plugin = proxy.Plugin.Type
+	pluginUnixPath = proxy.Plugin.UnixPath
+	pluginUser, pluginPassword = proxy.Plugin.Auth()
+	pluginLocalPath = proxy.Plugin.LocalPath
+	pluginStripPrefix = proxy.Plugin.StripPrefix
+	pluginLocalAddr = proxy.Plugin.LocalAddr
+	pluginCrtPath = proxy.Plugin.CrtPath
+	pluginKeyPath = proxy.Plugin.KeyPath
+	pluginHostHeaderRewrite = proxy.Plugin.HostHeaderRewrite
+
+	var bandwidthLimit, useEncryption, useCompression string
+	bandwidthLimit = proxy.Transport.BandwidthLimit
+	useEncryption = yesNo(proxy.Transport.UseEncryption)
+	useCompression = yesNo(proxy.Transport.UseCompression)
+
+	pluginOptions := []huh.Option[string]{huh.NewOption(i18n.T("不使用插件，转发本地端口"), "")}
+	for _, pluginType := range config.PluginTypes {
+		pluginOptions = append(pluginOptions, huh.NewOption(pluginType, pluginType))
+	}
+
+	// 表单数据绑定，提交时由 updateConfigFromForm 写回代理配置
+	formData := map[string]*string{
+		"name":                    &name,
+		"proxyType":               &proxyType,
+		"localIP":                 &localIP,
+		"localPort":               &localPort,
+		"remotePort":              &remotePort,
+		"customDomains":           &customDomains,
+		"secretKey":               &secretKey,
+		"locations":               &locations,
+		"httpUser":                &httpUser,
+		"httpPassword":            &httpPassword,
+		"hostHeaderRewrite":       &hostHeaderRewrite,
+		"requestHeaders":          &requestHeaders,
+		"group":                   &group,
+		"groupKey":                &groupKey,
+		"healthCheckType":         &healthCheckType,
+		"healthCheckPath":         &healthCheckPath,
+		"healthCheckInterval":     &healthCheckInterval,
+		"healthCheckTimeout":      &healthCheckTimeout,
+		"healthCheckMaxFailed":    &healthCheckMaxFailed,
+		"plugin":                  &plugin,
+		"pluginUnixPath":          &pluginUnixPath,
+		"pluginUser":              &pluginUser,
+		"pluginPassword":          &pluginPassword,
+		"pluginLocalPath":         &pluginLocalPath,
+		"pluginStripPrefix":       &pluginStripPrefix,
+		"pluginLocalAddr":         &pluginLocalAddr,
+		"pluginCrtPath":           &pluginCrtPath,
+		"pluginKeyPath":           &pluginKeyPath,
+		"pluginHostHeaderRewrite": &pluginHostHeaderRewrite,
+		"bandwidthLimit":          &bandwidthLimit,
+		"useEncryption":           &useEncryption,
+		"useCompression":          &useCompression,
+	}
+
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewInput().
-				Title("代理名称").
-				Description("代理的唯一标识名称 (建议使用有意义的名称，如: web-server, ssh-tunnel)").
+				Title(i18n.T("代理名称")).
+				Description(i18n.T("代理的唯一标识名称 (建议使用有意义的名称，如: web-server, ssh-tunnel)")).
 				Placeholder("web-server").
+				Key("name").
 				Value(&name).
 				Validate(func(str string) error {
 					str = strings.TrimSpace(str)
 					if str == "" {
-						return fmt.Errorf("代理名称不能为空")
+						return i18n.Errorf("代理名称不能为空")
 					}
 					// 检查名称格式
 					if strings.Contains(str, " ") {
-						return fmt.Errorf("代理名称不能包含空格，建议使用连字符")
+						return i18n.Errorf("代理名称不能包含空格，建议使用连字符")
 					}
 					if len(str) < 2 {
-						return fmt.Errorf("代理名称至少需要2个字符")
+						return i18n.Errorf("代理名称至少需要2个字符")
 					}
 					return nil
 				}),
 
 			huh.NewSelect[string]().
-				Title("代理类型").
-				Description("选择代理协议类型 (TCP最常用，HTTP用于网站)").
+				Title(i18n.T("代理类型")).
+				Description(i18n.T("选择代理协议类型 (TCP最常用，HTTP用于网站)")).
 				Options(
-					huh.NewOption("TCP - 通用端口转发 (推荐)", "tcp"),
-					huh.NewOption("HTTP - 网站代理", "http"),
-					huh.NewOption("HTTPS - 安全网站代理", "https"),
-					huh.NewOption("UDP - UDP协议转发", "udp"),
-					huh.NewOption("STCP - 安全TCP (需要密钥)", "stcp"),
-					huh.NewOption("SUDP - 安全UDP (需要密钥)", "sudp"),
-					huh.NewOption("XTCP - 点对点TCP (需要密钥)", "xtcp"),
+					huh.NewOption(i18n.T("TCP - 通用端口转发 (推荐)"), "tcp"),
+					huh.NewOption(i18n.T("HTTP - 网站代理"), "http"),
+					huh.NewOption(i18n.T("HTTPS - 安全网站代理"), "https"),
+					huh.NewOption(i18n.T("UDP - UDP协议转发"), "udp"),
+					huh.NewOption(i18n.T("STCP - 安全TCP (需要密钥)"), "stcp"),
+					huh.NewOption(i18n.T("SUDP - 安全UDP (需要密钥)"), "sudp"),
+					huh.NewOption(i18n.T("XTCP - 点对点TCP (需要密钥)"), "xtcp"),
 				).
+				Key("proxyType").
 				Value(&proxyType),
 
+			huh.NewSelect[string]().
+				Title(i18n.T("客户端插件")).
+				Description(i18n.T("使用插件时由 frpc 直接提供服务，无需填写本地地址和端口")).
+				Options(pluginOptions...).
+				Key("plugin").
+				Value(&plugin),
+
 			huh.NewInput().
-				Title("本地 IP 地址").
-				Description("要代理的本地服务的 IP 地址").
+				Title(i18n.T("本地 IP 地址")).
+				Description(i18n.T("要代理的本地服务的 IP 地址")).
 				Placeholder("127.0.0.1").
 				Value(&localIP),
 
 			huh.NewInput().
-				Title("本地端口").
-				Description("要代理的本地服务端口 (如: 22=SSH, 80=HTTP, 3389=RDP, 8080=Web服务)").
+				Title(i18n.T("本地端口")).
+				Description(i18n.T("要代理的本地服务端口 (如: 22=SSH, 80=HTTP, 3389=RDP, 8080=Web服务)")).
 				Placeholder("8080").
+				Key("localPort").
 				Value(&localPort).
 				Validate(func(str string) error {
+					if str == "" && plugin != "" {
+						return nil // 插件代理不需要本地端口
+					}
 					if str == "" {
-						return fmt.Errorf("本地端口不能为空")
+						return i18n.Errorf("本地端口不能为空")
 					}
 					port, err := strconv.Atoi(str)
 					if err != nil {
-						return fmt.Errorf("端口必须是数字")
+						return i18n.Errorf("端口必须是数字")
 					}
 					if port < 1 || port > 65535 {
-						return fmt.Errorf("端口必须在 1-65535 范围内")
+						return i18n.Errorf("端口必须在 1-65535 范围内")
 					}
 					// 提供常用端口的友好提示
 					commonPorts := map[int]string{
 						22:   "SSH",
 						80:   "HTTP",
 						443:  "HTTPS",
-						3389: "远程桌面",
+						3389: i18n.T("远程桌面"),
 						5432: "PostgreSQL",
 						3306: "MySQL",
 						6379: "Redis",
-						8080: "Web服务",
+						8080: i18n.T("Web服务"),
 					}
 					if service, exists := commonPorts[port]; exists {
 						// 这里可以添加提示，但huh库的验证函数只能返回错误
@@ -349,32 +639,33 @@ func NewProxyConfigForm(proxy *config.ProxyConfig) *ConfigFormModel {
 					}
 					return nil
 				}),
-		).Title("🔧 基本代理配置"),
+		).Title(i18n.T("🔧 基本代理配置")),
 
 		// TCP/UDP 特有配置
 		huh.NewGroup(
 			huh.NewInput().
-				Title("远程端口").
-				Description("服务端监听的公网端口 (仅TCP/UDP类型需要)").
+				Title(i18n.T("远程端口")).
+				Description(i18n.T("服务端监听的公网端口 (仅TCP/UDP类型需要)")).
 				Placeholder("6000").
+				Key("remotePort").
 				Value(&remotePort).
 				Validate(func(str string) error {
 					if proxyType != "tcp" && proxyType != "udp" {
 						return nil // 非 TCP/UDP 类型不需要验证
 					}
 					if str == "" {
-						return fmt.Errorf("TCP/UDP 代理需要设置远程端口")
+						return i18n.Errorf("TCP/UDP 代理需要设置远程端口")
 					}
 					port, err := strconv.Atoi(str)
 					if err != nil {
-						return fmt.Errorf("端口必须是数字")
+						return i18n.Errorf("端口必须是数字")
 					}
 					if port < 1 || port > 65535 {
-						return fmt.Errorf("端口必须在 1-65535 范围内")
+						return i18n.Errorf("端口必须在 1-65535 范围内")
 					}
 					return nil
 				}),
-		).Title("🌐 TCP/UDP 配置").
+		).Title(i18n.T("🌐 TCP/UDP 配置")).
 			WithHideFunc(func() bool {
 				return proxyType != "tcp" && proxyType != "udp"
 			}),
@@ -382,47 +673,311 @@ func NewProxyConfigForm(proxy *config.ProxyConfig) *ConfigFormModel {
 		// HTTP/HTTPS 特有配置
 		huh.NewGroup(
 			huh.NewInput().
-				Title("自定义域名").
-				Description("绑定的域名，多个域名用逗号分隔 (仅HTTP/HTTPS类型需要)").
+				Title(i18n.T("自定义域名")).
+				Description(i18n.T("绑定的域名，多个域名用逗号分隔 (仅HTTP/HTTPS类型需要)")).
 				Placeholder("example.com,www.example.com").
+				Key("customDomains").
 				Value(&customDomains).
 				Validate(func(str string) error {
 					if proxyType != "http" && proxyType != "https" {
 						return nil // 非 HTTP/HTTPS 类型不需要验证
 					}
 					if strings.TrimSpace(str) == "" {
-						return fmt.Errorf("HTTP/HTTPS 代理需要设置自定义域名")
+						return i18n.Errorf("HTTP/HTTPS 代理需要设置自定义域名")
 					}
 					return nil
 				}),
-		).Title("🌐 HTTP/HTTPS 配置").
+		).Title(i18n.T("🌐 HTTP/HTTPS 配置")).
 			WithHideFunc(func() bool {
 				return proxyType != "http" && proxyType != "https"
 			}),
 
+		// HTTP 高级配置，HTTPS 代理由 frps 直接透传加密流量，不支持这些选项
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("路由路径")).
+				Description(i18n.T("只转发匹配这些 URL 前缀的请求，多个用逗号分隔，留空表示全部")).
+				Placeholder("/,/api").
+				Key("locations").
+				Value(&locations).
+				Validate(func(str string) error {
+					for _, location := range splitCommaList(str) {
+						if !strings.HasPrefix(location, "/") {
+							return i18n.Errorf("路由路径 %s 必须以 / 开头", location)
+						}
+					}
+					return nil
+				}),
+
+			huh.NewInput().
+				Title(i18n.T("HTTP 认证用户名")).
+				Description(i18n.T("访问时要求 Basic 认证，留空表示不需要")).
+				Placeholder("admin").
+				Value(&httpUser),
+
+			huh.NewInput().
+				Title(i18n.T("HTTP 认证密码")).
+				Description(i18n.T("Basic 认证密码，需同时设置用户名")).
+				Value(&httpPassword).
+				EchoMode(huh.EchoModePassword).
+				Validate(func(str string) error {
+					if str != "" && strings.TrimSpace(httpUser) == "" {
+						return i18n.Errorf("设置了 HTTP 认证密码但未设置用户名")
+					}
+					return nil
+				}),
+
+			huh.NewInput().
+				Title(i18n.T("Host 头改写")).
+				Description(i18n.T("转发到本地服务前将 Host 头替换为该值，留空表示不改写")).
+				Placeholder("dev.example.com").
+				Value(&hostHeaderRewrite),
+
+			huh.NewInput().
+				Title(i18n.T("请求头")).
+				Description(i18n.T("转发前设置的请求头，格式 名称=值，多个用逗号分隔")).
+				Placeholder("X-From-Where=frp").
+				Value(&requestHeaders).
+				Validate(func(str string) error {
+					headers, err := config.ParseHeaders(str)
+					if err != nil {
+						return err
+					}
+					return config.ValidateHeaders(headers)
+				}),
+		).Title(i18n.T("🧭 HTTP 路由与认证")).
+			WithHideFunc(func() bool {
+				return proxyType != "http"
+			}),
+
 		// STCP/SUDP/XTCP 特有配置
 		huh.NewGroup(
 			huh.NewInput().
-				Title("密钥").
-				Description("用于安全连接的密钥 (仅STCP/SUDP/XTCP类型需要)").
+				Title(i18n.T("密钥")).
+				Description(i18n.T("用于安全连接的密钥 (仅STCP/SUDP/XTCP类型需要)")).
 				Placeholder("your_secret_key").
+				Key("secretKey").
 				Value(&secretKey).
 				Validate(func(str string) error {
 					if proxyType != "stcp" && proxyType != "sudp" && proxyType != "xtcp" {
 						return nil // 非加密类型不需要验证
 					}
 					if strings.TrimSpace(str) == "" {
-						return fmt.Errorf("加密代理需要设置密钥")
+						return i18n.Errorf("加密代理需要设置密钥")
 					}
-					if len(str) < 6 {
-						return fmt.Errorf("密钥长度至少6个字符")
+					if len(str) < 8 {
+						return i18n.Errorf("密钥长度不能少于8位")
 					}
 					return nil
 				}),
-		).Title("🔒 加密代理配置").
+		).Title(i18n.T("🔒 加密代理配置")).
 			WithHideFunc(func() bool {
 				return proxyType != "stcp" && proxyType != "sudp" && proxyType != "xtcp"
 			}),
+
+		// 插件参数，按所选插件显示
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("套接字路径")).
+				Description(i18n.T("要转发的 Unix 域套接字，如 Docker 的 /var/run/docker.sock")).
+				Placeholder("/var/run/docker.sock").
+				Value(&pluginUnixPath).
+				Validate(requiredWhen(func() bool { return plugin == "unix_domain_socket" }, i18n.T("unix_domain_socket 插件需要设置套接字路径"))),
+		).Title(i18n.T("🔌 Unix 域套接字")).
+			WithHideFunc(func() bool {
+				return plugin != "unix_domain_socket"
+			}),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("本地目录")).
+				Description(i18n.T("通过 HTTP 对外提供的本地目录")).
+				Placeholder("/var/www/files").
+				Value(&pluginLocalPath).
+				Validate(requiredWhen(func() bool { return plugin == "static_file" }, i18n.T("static_file 插件需要设置本地目录"))),
+
+			huh.NewInput().
+				Title(i18n.T("去除路径前缀")).
+				Description(i18n.T("访问 URL 中需要去掉的前缀，如 static")).
+				Placeholder("static").
+				Value(&pluginStripPrefix),
+		).Title(i18n.T("🔌 静态文件")).
+			WithHideFunc(func() bool {
+				return plugin != "static_file"
+			}),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("本地服务地址")).
+				Description(i18n.T("接收解密后 HTTP 请求的本地服务，host:port 形式")).
+				Placeholder("127.0.0.1:8080").
+				Value(&pluginLocalAddr).
+				Validate(requiredWhen(func() bool { return plugin == "https2http" }, i18n.T("https2http 插件需要设置本地服务地址"))),
+
+			huh.NewInput().
+				Title(i18n.T("证书文件")).
+				Description(i18n.T("对外提供 HTTPS 使用的证书，留空时 frpc 自动生成自签名证书")).
+				Placeholder("/etc/frp/server.crt").
+				Value(&pluginCrtPath),
+
+			huh.NewInput().
+				Title(i18n.T("私钥文件")).
+				Description(i18n.T("与证书配对的私钥，需与证书同时填写")).
+				Placeholder("/etc/frp/server.key").
+				Value(&pluginKeyPath).
+				Validate(func(str string) error {
+					if (strings.TrimSpace(str) == "") != (strings.TrimSpace(pluginCrtPath) == "") {
+						return i18n.Errorf("证书和私钥必须同时配置")
+					}
+					return nil
+				}),
+
+			huh.NewInput().
+				Title(i18n.T("Host 头改写")).
+				Description(i18n.T("转发到本地服务前将 Host 头替换为该值，留空表示不改写")).
+				Placeholder("127.0.0.1").
+				Value(&pluginHostHeaderRewrite),
+		).Title(i18n.T("🔌 HTTPS 转 HTTP")).
+			WithHideFunc(func() bool {
+				return plugin != "https2http"
+			}),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("插件用户名")).
+				Description(i18n.T("访问插件时需要认证，留空表示不需要")).
+				Value(&pluginUser),
+
+			huh.NewInput().
+				Title(i18n.T("插件密码")).
+				Value(&pluginPassword).
+				EchoMode(huh.EchoModePassword).
+				Validate(func(str string) error {
+					if str != "" && strings.TrimSpace(pluginUser) == "" {
+						return i18n.Errorf("设置了插件认证密码但未设置用户名")
+					}
+					return nil
+				}),
+		).Title(i18n.T("🔐 插件认证")).
+			WithHideFunc(func() bool {
+				return plugin != "http_proxy" && plugin != "socks5" && plugin != "static_file"
+			}),
+
+		// 高级配置：负载均衡与健康检查
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("负载均衡分组")).
+				Description(i18n.T("同组代理共享远程端口或域名并轮流处理请求，仅 TCP/HTTP 支持，留空表示不分组")).
+				Placeholder("web").
+				Key("group").
+				Value(&group).
+				Validate(func(str string) error {
+					if strings.TrimSpace(str) != "" && proxyType != "tcp" && proxyType != "http" {
+						return i18n.Errorf("%s 代理不支持负载均衡分组", proxyType)
+					}
+					return nil
+				}),
+
+			huh.NewInput().
+				Title(i18n.T("分组密钥")).
+				Description(i18n.T("同组代理需使用相同的密钥，设置了分组时必填")).
+				Value(&groupKey).
+				EchoMode(huh.EchoModePassword).
+				Validate(func(str string) error {
+					if str != "" && strings.TrimSpace(group) == "" {
+						return i18n.Errorf("设置了分组密钥但未设置分组名称")
+					}
+					if str == "" && strings.TrimSpace(group) != "" {
+						return i18n.Errorf("分组 %s 未设置分组密钥", strings.TrimSpace(group))
+					}
+					return nil
+				}),
+
+			huh.NewSelect[string]().
+				Title(i18n.T("健康检查")).
+				Description(i18n.T("检查失败时 frpc 会暂时下线该代理，恢复后重新注册")).
+				Options(
+					huh.NewOption(i18n.T("不启用"), ""),
+					huh.NewOption(i18n.T("TCP - 检查端口能否连接"), "tcp"),
+					huh.NewOption(i18n.T("HTTP - 请求路径并检查 2xx 响应"), "http"),
+				).
+				Key("healthCheckType").
+				Value(&healthCheckType),
+
+			huh.NewInput().
+				Title(i18n.T("检查路径")).
+				Description(i18n.T("HTTP 健康检查请求的路径")).
+				Placeholder("/health").
+				Value(&healthCheckPath).
+				Validate(func(str string) error {
+					if healthCheckType == "http" && !strings.HasPrefix(strings.TrimSpace(str), "/") {
+						return i18n.Errorf("HTTP 健康检查路径必须以 / 开头")
+					}
+					return nil
+				}),
+
+			huh.NewInput().
+				Title(i18n.T("检查间隔(秒)")).
+				Description(i18n.T("留空使用默认值 10")).
+				Placeholder("10").
+				Value(&healthCheckInterval).
+				Validate(validateOptionalNumber(1)),
+
+			huh.NewInput().
+				Title(i18n.T("检查超时(秒)")).
+				Description(i18n.T("需小于检查间隔，留空使用默认值 3")).
+				Placeholder("3").
+				Value(&healthCheckTimeout).
+				Validate(func(str string) error {
+					if err := validateOptionalNumber(1)(str); err != nil {
+						return err
+					}
+					timeout, interval := parseOptionalInt(str), parseOptionalInt(healthCheckInterval)
+					if timeout == 0 {
+						timeout = 3
+					}
+					if interval == 0 {
+						interval = 10
+					}
+					if timeout >= interval {
+						return i18n.Errorf("健康检查超时(%d 秒)必须小于检查间隔(%d 秒)", timeout, interval)
+					}
+					return nil
+				}),
+
+			huh.NewInput().
+				Title(i18n.T("最大失败次数")).
+				Description(i18n.T("连续失败达到该次数后下线代理，留空使用默认值 1")).
+				Placeholder("1").
+				Value(&healthCheckMaxFailed).
+				Validate(validateOptionalNumber(1)),
+
+			huh.NewInput().
+				Title(i18n.T("带宽限制")).
+				Description(i18n.T("单个代理的最大带宽，单位 KB 或 MB，如 1MB，留空表示不限速")).
+				Placeholder("1MB").
+				Key("bandwidthLimit").
+				Value(&bandwidthLimit).
+				Validate(validateBandwidthLimit),
+
+			huh.NewSelect[string]().
+				Title(i18n.T("加密传输")).
+				Description(i18n.T("在 frpc 与 frps 之间加密该代理的流量")).
+				Options(
+					huh.NewOption(i18n.T("关闭"), "no"),
+					huh.NewOption(i18n.T("开启"), "yes"),
+				).
+				Value(&useEncryption),
+
+			huh.NewSelect[string]().
+				Title(i18n.T("压缩传输")).
+				Description(i18n.T("压缩该代理的流量，适合文本类数据，会增加 CPU 占用")).
+				Options(
+					huh.NewOption(i18n.T("关闭"), "no"),
+					huh.NewOption(i18n.T("开启"), "yes"),
+				).
+				Value(&useCompression),
+		).Title(i18n.T("⚙️ 高级")),
 	)
 
 	// 表单创建完成，配置更新在 Update 方法中处理
@@ -431,6 +986,7 @@ func NewProxyConfigForm(proxy *config.ProxyConfig) *ConfigFormModel {
 		form:        form,
 		formType:    ProxyConfigForm,
 		proxyConfig: proxy,
+		formData:    formData,
 	}
 }
 
@@ -443,95 +999,98 @@ func NewVisitorConfigForm(visitor *config.VisitorConfig) *ConfigFormModel {
 		}
 	}
 
-	var name, visitorType, serverName, secretKey, bindAddr, bindPort string
-
-	name = visitor.Name
-	visitorType = visitor.Type
-	serverName = visitor.ServerName
-	secretKey = visitor.SecretKey
-	bindAddr = visitor.BindAddr
-	if visitor.BindPort > 0 {
-		bindPort = strconv.Itoa(visitor.BindPort)
-	}
+	// 创建表单数据绑定
+	formData := newFormData("name", "visitorType", "serverName", "secretKey", "bindAddr", "bindPort")
+	*formData["name"] = visitor.Name
+	*formData["visitorType"] = visitor.Type
+	*formData["serverName"] = visitor.ServerName
+	*formData["secretKey"] = visitor.SecretKey
+	*formData["bindAddr"] = visitor.BindAddr
+	*formData["bindPort"] = formatOptionalInt(visitor.BindPort)
 
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewInput().
-				Title("访问者名称").
-				Description("访问者的唯一标识名称").
+				Title(i18n.T("访问者名称")).
+				Description(i18n.T("访问者的唯一标识名称")).
 				Placeholder("my-visitor").
-				Value(&name).
+				Key("name").
+				Value(formData["name"]).
 				Validate(func(str string) error {
 					if strings.TrimSpace(str) == "" {
-						return fmt.Errorf("访问者名称不能为空")
+						return i18n.Errorf("访问者名称不能为空")
 					}
 					return nil
 				}),
 
 			huh.NewSelect[string]().
-				Title("访问者类型").
-				Description("选择访问者类型").
+				Title(i18n.T("访问者类型")).
+				Description(i18n.T("选择访问者类型")).
 				Options(
 					huh.NewOption("STCP", "stcp"),
 					huh.NewOption("SUDP", "sudp"),
 					huh.NewOption("XTCP", "xtcp"),
 				).
-				Value(&visitorType),
+				Key("visitorType").
+				Value(formData["visitorType"]),
 
 			huh.NewInput().
-				Title("服务器名称").
-				Description("要访问的代理服务器名称").
+				Title(i18n.T("服务器名称")).
+				Description(i18n.T("要访问的代理服务器名称")).
 				Placeholder("secret_ssh").
-				Value(&serverName).
+				Key("serverName").
+				Value(formData["serverName"]).
 				Validate(func(str string) error {
 					if strings.TrimSpace(str) == "" {
-						return fmt.Errorf("服务器名称不能为空")
+						return i18n.Errorf("服务器名称不能为空")
 					}
 					return nil
 				}),
 
 			huh.NewInput().
-				Title("密钥").
-				Description("与代理服务器相同的密钥").
+				Title(i18n.T("密钥")).
+				Description(i18n.T("与代理服务器相同的密钥")).
 				Placeholder("your_secret_key").
-				Value(&secretKey).
+				Key("secretKey").
+				Value(formData["secretKey"]).
 				Validate(func(str string) error {
 					if strings.TrimSpace(str) == "" {
-						return fmt.Errorf("密钥不能为空")
+						return i18n.Errorf("密钥不能为空")
 					}
-					if len(str) < 6 {
-						return fmt.Errorf("密钥长度至少6个字符")
+					if len(str) < 8 {
+						return i18n.Errorf("密钥长度不能少于8位")
 					}
 					return nil
 				}),
-		).Title("🔧 基本访问者配置"),
+		).Title(i18n.T("🔧 基本访问者配置")),
 
 		huh.NewGroup(
 			huh.NewInput().
-				Title("绑定地址").
-				Description("本地绑定的 IP 地址").
+				Title(i18n.T("绑定地址")).
+				Description(i18n.T("本地绑定的 IP 地址")).
 				Placeholder("127.0.0.1").
-				Value(&bindAddr),
+				Value(formData["bindAddr"]),
 
 			huh.NewInput().
-				Title("绑定端口").
-				Description("本地监听端口").
+				Title(i18n.T("绑定端口")).
+				Description(i18n.T("本地监听端口")).
 				Placeholder("9000").
-				Value(&bindPort).
+				Key("bindPort").
+				Value(formData["bindPort"]).
 				Validate(func(str string) error {
 					if str == "" {
-						return fmt.Errorf("绑定端口不能为空")
+						return i18n.Errorf("绑定端口不能为空")
 					}
 					port, err := strconv.Atoi(str)
 					if err != nil {
-						return fmt.Errorf("端口必须是数字")
+						return i18n.Errorf("端口必须是数字")
 					}
 					if port < 1 || port > 65535 {
-						return fmt.Errorf("端口必须在 1-65535 范围内")
+						return i18n.Errorf("端口必须在 1-65535 范围内")
 					}
 					return nil
 				}),
-		).Title("🌐 连接配置"),
+		).Title(i18n.T("🌐 连接配置")),
 	)
 
 	// 表单创建完成，配置更新在 Update 方法中处理
@@ -540,6 +1099,7 @@ func NewVisitorConfigForm(visitor *config.VisitorConfig) *ConfigFormModel {
 		form:          form,
 		formType:      VisitorConfigForm,
 		visitorConfig: visitor,
+		formData:      formData,
 	}
 }
 
@@ -564,6 +1124,12 @@ func (m *ConfigFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if f, ok := form.(*huh.Form); ok {
 		m.form = f
 		if m.form.State == huh.StateCompleted && !m.completed {
+			// 字段组合有误时重新打开表单并定位到出错的分组
+			if issues := m.fieldIssues(); len(issues) > 0 {
+				m.issues = issues
+				return m, m.reopen(issues[0].Field)
+			}
+			m.issues = nil
 			m.completed = true
 			// 表单完成时更新配置
 			m.updateConfigFromForm()
@@ -575,41 +1141,76 @@ func (m *ConfigFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // updateConfigFromForm 从表单更新配置
 func (m *ConfigFormModel) updateConfigFromForm() {
-	if m.config == nil || m.formData == nil {
+	if m.formData == nil {
 		return
 	}
 
 	switch m.formType {
 	case ServerConfigForm:
 		// 更新服务端配置
+		if m.config == nil {
+			return
+		}
 		if bindPort := *m.formData["bindPort"]; bindPort != "" {
 			if port, err := strconv.Atoi(bindPort); err == nil {
 				m.config.BindPort = port
 			}
 		}
-		m.config.Token = *m.formData["token"]
+		m.config.Auth.Token = *m.formData["token"]
 		m.config.WebServer.Addr = *m.formData["webAddr"]
-		if webPort := *m.formData["webPort"]; webPort != "" {
-			if port, err := strconv.Atoi(webPort); err == nil {
-				m.config.WebServer.Port = port
-			}
-		}
+		// 清空 Web 端口表示关闭管理界面
+		m.config.WebServer.Port = parseOptionalInt(*m.formData["webPort"])
 		m.config.WebServer.User = *m.formData["webUser"]
 		m.config.WebServer.Password = *m.formData["webPassword"]
-		m.config.Log.To = *m.formData["logTo"]
+		m.config.Log.To = strings.TrimSpace(*m.formData["logTo"])
 		m.config.Log.Level = *m.formData["logLevel"]
+		m.config.AllowPorts, _ = config.ParsePortRanges(*m.formData["allowPorts"])
+		m.config.MaxPortsPerClient = parseOptionalInt(*m.formData["maxPortsPerClient"])
+		m.config.VhostHTTPPort = parseOptionalInt(*m.formData["vhostHTTPPort"])
+		m.config.VhostHTTPSPort = parseOptionalInt(*m.formData["vhostHTTPSPort"])
+		m.config.VhostHTTPTimeout = parseOptionalInt(*m.formData["vhostHTTPTimeout"])
+		m.config.SubDomainHost = strings.TrimSpace(*m.formData["subDomainHost"])
+		m.config.Custom404Page = strings.TrimSpace(*m.formData["custom404Page"])
+		m.config.TCPMuxHTTPConnectPort = parseOptionalInt(*m.formData["tcpmuxHTTPConnectPort"])
+		m.config.TCPMuxPassthrough = *m.formData["tcpmuxPassthrough"] == "yes"
+		m.config.Transport.HeartbeatTimeout = parseOptionalInt(*m.formData["heartbeatTimeout"])
+		m.config.SSHTunnelGateway = config.SSHTunnelGatewayConfig{
+			BindPort:              parseOptionalInt(*m.formData["sshBindPort"]),
+			PrivateKeyFile:        strings.TrimSpace(*m.formData["sshPrivateKeyFile"]),
+			AutoGenPrivateKeyPath: strings.TrimSpace(*m.formData["sshAutoGenKeyPath"]),
+			AuthorizedKeysFile:    strings.TrimSpace(*m.formData["sshAuthorizedKeys"]),
+		}
 
 	case ClientConfigForm:
 		// 更新客户端配置
+		if m.config == nil {
+			return
+		}
 		m.config.ServerAddr = *m.formData["serverAddr"]
 		if serverPort := *m.formData["serverPort"]; serverPort != "" {
 			if port, err := strconv.Atoi(serverPort); err == nil {
 				m.config.ServerPort = port
 			}
 		}
-		m.config.Token = *m.formData["token"]
-		m.config.Log.To = *m.formData["logTo"]
+		m.config.Auth.Token = *m.formData["token"]
+		m.config.Log.To = strings.TrimSpace(*m.formData["logTo"])
 		m.config.Log.Level = *m.formData["logLevel"]
+		m.config.Transport.Protocol = *m.formData["protocol"]
+		if m.config.Transport.Protocol == "tcp" {
+			m.config.Transport.Protocol = ""
+		}
+		m.config.Transport.PoolCount = parseOptionalInt(*m.formData["poolCount"])
+		m.config.Transport.DialServerTimeout = parseOptionalInt(*m.formData["dialServerTimeout"])
+		m.config.Transport.ProxyURL = strings.TrimSpace(*m.formData["proxyURL"])
+		m.config.Transport.TLS.Enable = nil
+		if *m.formData["tlsEnable"] == "no" {
+			enable := false
+			m.config.Transport.TLS.Enable = &enable
+		}
+		m.config.Transport.TLS.CertFile = strings.TrimSpace(*m.formData["tlsCertFile"])
+		m.config.Transport.TLS.KeyFile = strings.TrimSpace(*m.formData["tlsKeyFile"])
+		m.config.Transport.TLS.TrustedCaFile = strings.TrimSpace(*m.formData["tlsTrustedCaFile"])
+		m.config.Transport.TLS.ServerName = strings.TrimSpace(*m.formData["tlsServerName"])
 
 	case ProxyConfigForm:
 		// 更新代理配置
@@ -619,20 +1220,58 @@ func (m *ConfigFormModel) updateConfigFromForm() {
 		m.proxyConfig.Name = *m.formData["name"]
 		m.proxyConfig.Type = *m.formData["proxyType"]
 		m.proxyConfig.LocalIP = *m.formData["localIP"]
-		if localPort := *m.formData["localPort"]; localPort != "" {
-			if port, err := strconv.Atoi(localPort); err == nil {
-				m.proxyConfig.LocalPort = port
-			}
+		m.proxyConfig.LocalPort = parseOptionalInt(*m.formData["localPort"])
+		// 切换类型后隐藏分组中的旧值不再适用，只保留当前类型使用的字段
+		m.proxyConfig.RemotePort = 0
+		m.proxyConfig.CustomDomains = nil
+		m.proxyConfig.SecretKey = ""
+		switch m.proxyConfig.Type {
+		case "tcp", "udp":
+			m.proxyConfig.RemotePort = parseOptionalInt(*m.formData["remotePort"])
+		case "http", "https":
+			m.proxyConfig.CustomDomains = splitCommaList(*m.formData["customDomains"])
+		case "stcp", "sudp", "xtcp":
+			m.proxyConfig.SecretKey = *m.formData["secretKey"]
 		}
-		if remotePort := *m.formData["remotePort"]; remotePort != "" {
-			if port, err := strconv.Atoi(remotePort); err == nil {
-				m.proxyConfig.RemotePort = port
-			}
+		if m.proxyConfig.Type != "http" && m.proxyConfig.Type != "https" {
+			m.proxyConfig.Subdomain = ""
+		}
+		// 路由、认证与请求头仅 HTTP 代理支持，切换为其他类型时清空
+		m.proxyConfig.Locations = nil
+		m.proxyConfig.HTTPUser = ""
+		m.proxyConfig.HTTPPassword = ""
+		m.proxyConfig.HostHeaderRewrite = ""
+		m.proxyConfig.RequestHeaders.Set = nil
+		if m.proxyConfig.Type == "http" {
+			m.proxyConfig.Locations = splitCommaList(*m.formData["locations"])
+			m.proxyConfig.HTTPUser = strings.TrimSpace(*m.formData["httpUser"])
+			m.proxyConfig.HTTPPassword = *m.formData["httpPassword"]
+			m.proxyConfig.HostHeaderRewrite = strings.TrimSpace(*m.formData["hostHeaderRewrite"])
+			m.proxyConfig.RequestHeaders.Set, _ = config.ParseHeaders(*m.formData["requestHeaders"])
 		}
-		if customDomains := *m.formData["customDomains"]; customDomains != "" {
-			m.proxyConfig.CustomDomains = strings.Split(customDomains, ",")
+		m.proxyConfig.Plugin = m.pluginFromForm(m.proxyConfig.Plugin)
+		if m.proxyConfig.Plugin.Type != "" {
+			m.proxyConfig.LocalIP = ""
+			m.proxyConfig.LocalPort = 0
+		}
+		m.proxyConfig.Transport.BandwidthLimit = strings.TrimSpace(*m.formData["bandwidthLimit"])
+		m.proxyConfig.Transport.UseEncryption = *m.formData["useEncryption"] == "yes"
+		m.proxyConfig.Transport.UseCompression = *m.formData["useCompression"] == "yes"
+		m.proxyConfig.LoadBalancer.Group = strings.TrimSpace(*m.formData["group"])
+		m.proxyConfig.LoadBalancer.GroupKey = *m.formData["groupKey"]
+		m.proxyConfig.HealthCheck.Type = *m.formData["healthCheckType"]
+		m.proxyConfig.HealthCheck.Path = ""
+		m.proxyConfig.HealthCheck.IntervalS = 0
+		m.proxyConfig.HealthCheck.TimeoutS = 0
+		m.proxyConfig.HealthCheck.MaxFailed = 0
+		if m.proxyConfig.HealthCheck.Type != "" {
+			if m.proxyConfig.HealthCheck.Type == "http" {
+				m.proxyConfig.HealthCheck.Path = strings.TrimSpace(*m.formData["healthCheckPath"])
+			}
+			m.proxyConfig.HealthCheck.IntervalS = parseOptionalInt(*m.formData["healthCheckInterval"])
+			m.proxyConfig.HealthCheck.TimeoutS = parseOptionalInt(*m.formData["healthCheckTimeout"])
+			m.proxyConfig.HealthCheck.MaxFailed = parseOptionalInt(*m.formData["healthCheckMaxFailed"])
 		}
-		m.proxyConfig.SecretKey = *m.formData["secretKey"]
 
 	case VisitorConfigForm:
 		// 更新访问者配置
@@ -644,12 +1283,36 @@ func (m *ConfigFormModel) updateConfigFromForm() {
 		m.visitorConfig.ServerName = *m.formData["serverName"]
 		m.visitorConfig.SecretKey = *m.formData["secretKey"]
 		m.visitorConfig.BindAddr = *m.formData["bindAddr"]
-		if bindPort := *m.formData["bindPort"]; bindPort != "" {
-			if port, err := strconv.Atoi(bindPort); err == nil {
-				m.visitorConfig.BindPort = port
-			}
+		m.visitorConfig.BindPort = parseOptionalInt(*m.formData["bindPort"])
+	}
+}
+
+// pluginFromForm 根据表单生成插件配置，只保留所选插件使用的字段，请求头沿用原配置
+func (m *ConfigFormModel) pluginFromForm(current config.PluginConfig) config.PluginConfig {
+	value := func(key string) string {
+		return strings.TrimSpace(*m.formData[key])
+	}
+
+	plugin := config.PluginConfig{Type: *m.formData["plugin"]}
+	switch plugin.Type {
+	case "unix_domain_socket":
+		plugin.UnixPath = value("pluginUnixPath")
+	case "static_file":
+		plugin.LocalPath = value("pluginLocalPath")
+		plugin.StripPrefix = value("pluginStripPrefix")
+	case "https2http":
+		plugin.LocalAddr = value("pluginLocalAddr")
+		plugin.CrtPath = value("pluginCrtPath")
+		plugin.KeyPath = value("pluginKeyPath")
+		plugin.HostHeaderRewrite = value("pluginHostHeaderRewrite")
+		if current.Type == plugin.Type {
+			plugin.RequestHeaders = current.RequestHeaders
 		}
 	}
+	if plugin.UsesAuth() {
+		plugin.SetAuth(value("pluginUser"), *m.formData["pluginPassword"])
+	}
+	return plugin
 }
 
 // View 渲染表单视图
@@ -658,18 +1321,54 @@ func (m *ConfigFormModel) View() string {
 		var title string
 		switch m.formType {
 		case ServerConfigForm:
-			title = "服务端配置已完成"
+			title = i18n.T("服务端配置已完成")
 		case ClientConfigForm:
-			title = "客户端配置已完成"
+			title = i18n.T("客户端配置已完成")
 		case ProxyConfigForm:
-			title = "代理配置已完成"
+			title = i18n.T("代理配置已完成")
 		case VisitorConfigForm:
-			title = "访问者配置已完成"
+			title = i18n.T("访问者配置已完成")
 		}
-		return fmt.Sprintf("\n✅ %s\n\n按 ESC 返回\n", title)
+		return i18n.Sprintf("\n✅ %s\n\n按 ESC 返回\n", title)
+	}
+
+	return m.renderIssues() + m.form.View()
+}
+
+// SetFocusedSecret 将值填入当前聚焦的 token/secretKey 输入框并返回字段名，聚焦的不是这类字段时返回空字符串
+func (m *ConfigFormModel) SetFocusedSecret(value string) string {
+	if m.completed || m.formData == nil {
+		return ""
+	}
+
+	input, ok := m.form.GetFocusedField().(*huh.Input)
+	if !ok {
+		return ""
+	}
+	key := input.GetKey()
+	ptr, ok := m.formData[key]
+	if !ok || (key != "token" && key != "secretKey") {
+		return ""
 	}
 
-	return m.form.View()
+	// 重新绑定同一指针让输入框取到新值，再空转一次 Update 刷新分组缓存的视图
+	*ptr = value
+	input.Value(ptr)
+	m.form.Update(nil)
+	return key
+}
+
+// SetTakenNames 设置代理表单中不能使用的名称，提交时检查是否重名
+func (m *ConfigFormModel) SetTakenNames(names []string) {
+	m.takenNames = names
+}
+
+// FormValue 返回表单字段的当前值，字段不存在时返回空字符串
+func (m *ConfigFormModel) FormValue(key string) string {
+	if ptr, ok := m.formData[key]; ok {
+		return *ptr
+	}
+	return ""
 }
 
 // IsCompleted 检查表单是否完成
@@ -696,3 +1395,88 @@ func (m *ConfigFormModel) GetVisitorConfig() *config.VisitorConfig {
 func (m *ConfigFormModel) GetError() error {
 	return m.err
 }
+
+// validateOptionalPort 校验可留空的端口输入
+func validateOptionalPort(str string) error {
+	if strings.TrimSpace(str) == "" {
+		return nil
+	}
+	return validatePortInput(str)
+}
+
+// validateOptionalNumber 校验可留空的整数输入，不能小于 minValue
+func validateOptionalNumber(minValue int) func(string) error {
+	return func(str string) error {
+		str = strings.TrimSpace(str)
+		if str == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(str)
+		if err != nil {
+			return i18n.Errorf("必须是整数")
+		}
+		if n < minValue {
+			return i18n.Errorf("不能小于 %d", minValue)
+		}
+		return nil
+	}
+}
+
+// newLogToInput 创建日志输出位置输入框，frp 的 log.to 为 console 或日志文件路径
+func newLogToInput(value *string) *huh.Input {
+	return huh.NewInput().
+		Title(i18n.T("日志输出位置")).
+		Description(i18n.T("console 输出到控制台，或填写日志文件路径")).
+		Placeholder("console").
+		Value(value)
+}
+
+// newFormData 为表单字段创建字符串绑定，表单提交时由 updateConfigFromForm 读取
+func newFormData(keys ...string) map[string]*string {
+	formData := make(map[string]*string, len(keys))
+	for _, key := range keys {
+		formData[key] = new(string)
+	}
+	return formData
+}
+
+// formatOptionalInt 将整数格式化为表单文本，0 显示为空
+func formatOptionalInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// parseOptionalInt 解析可留空的整数输入，留空或无效时返回 0
+func parseOptionalInt(str string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(str))
+	return n
+}
+
+// splitCommaList 拆分逗号分隔的输入，忽略空项
+func splitCommaList(str string) []string {
+	var items []string
+	for _, item := range strings.Split(str, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// validateBandwidthLimit 校验可留空的带宽限制输入
+func validateBandwidthLimit(str string) error {
+	_, err := config.ParseBandwidthLimit(str)
+	return err
+}
+
+// requiredWhen 在 cond 成立时要求输入不能为空，message 为已翻译的提示
+func requiredWhen(cond func() bool, message string) func(string) error {
+	return func(str string) error {
+		if cond() && strings.TrimSpace(str) == "" {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// formIssueKeys 校验问题中的配置字段名与表单字段 Key 不同时的对应关系，
+// 隐藏分组中的字段（如切换类型后残留的远程端口）定位到类型选择
+var formIssueKeys = map[ConfigFormType]map[string]string{
+	ServerConfigForm: {
+		"webServer.port":            "webPort",
+		"sshTunnelGateway.bindPort": "sshBindPort",
+	},
+	ClientConfigForm: {
+		"transport": "protocol",
+	},
+	ProxyConfigForm: {
+		"type":        "proxyType",
+		"role":        "proxyType",
+		"healthCheck": "healthCheckType",
+	},
+	VisitorConfigForm: {
+		"type": "visitorType",
+	},
+}
+
+// fieldIssues 用表单当前的内容更新一份配置副本并做跨字段校验，不修改原配置
+func (m *ConfigFormModel) fieldIssues() []config.FieldIssue {
+	candidate := m.candidate()
+	if candidate == nil {
+		return nil
+	}
+
+	validator := config.NewValidator()
+	switch m.formType {
+	case ServerConfigForm:
+		return validator.ServerFieldIssues(candidate.config)
+	case ClientConfigForm:
+		return validator.ClientFieldIssues(candidate.config)
+	case ProxyConfigForm:
+		issues := validator.ProxyFieldIssues(*candidate.proxyConfig)
+		if name := candidate.proxyConfig.Name; slices.Contains(m.takenNames, name) {
+			issues = append(issues, config.FieldIssue{Field: "name", Message: i18n.Sprintf("代理名称 '%s' 已存在", name)})
+		}
+		return issues
+	default:
+		return validator.VisitorFieldIssues(*candidate.visitorConfig)
+	}
+}
+
+// candidate 返回写入了表单内容的配置副本，表单没有绑定配置时返回 nil
+func (m *ConfigFormModel) candidate() *ConfigFormModel {
+	candidate := *m
+	switch m.formType {
+	case ServerConfigForm, ClientConfigForm:
+		if m.config == nil {
+			return nil
+		}
+		candidate.config = m.config.Clone()
+	case ProxyConfigForm:
+		if m.proxyConfig == nil {
+			return nil
+		}
+		proxy := m.proxyConfig.Clone()
+		candidate.proxyConfig = &proxy
+	default:
+		if m.visitorConfig == nil {
+			return nil
+		}
+		visitor := *m.visitorConfig
+		candidate.visitorConfig = &visitor
+	}
+	candidate.updateConfigFromForm()
+	return &candidate
+}
+
+// reopen 已提交的 huh 表单无法撤回，用提交的内容重新创建表单，
+// 提交时仍写回原来的配置，并把焦点移到出错字段所在的分组
+func (m *ConfigFormModel) reopen(field string) tea.Cmd {
+	candidate := m.candidate()
+	if key, ok := formIssueKeys[m.formType][field]; ok {
+		field = key
+	}
+
+	fresh := candidate.newForm()
+	m.form, m.formData = fresh.form, fresh.formData
+	cmds := []tea.Cmd{m.form.Init()}
+
+	groups, fields := formFieldPosition(candidate.newForm().form, field)
+	for range groups {
+		cmds = append(cmds, m.form.NextGroup())
+	}
+	for range fields {
+		cmds = append(cmds, m.form.NextField())
+	}
+	return tea.Batch(cmds...)
+}
+
+// newForm 按配置创建同类型的新表单
+func (m *ConfigFormModel) newForm() *ConfigFormModel {
+	switch m.formType {
+	case ServerConfigForm:
+		return NewServerConfigForm(m.config)
+	case ClientConfigForm:
+		return NewClientConfigForm(m.config)
+	case ProxyConfigForm:
+		return NewProxyConfigForm(m.proxyConfig)
+	default:
+		return NewVisitorConfigForm(m.visitorConfig)
+	}
+}
+
+// formFieldPosition 在一份用于探测的表单上逐项移动焦点，返回到达 key 字段需要切换的分组数和分组内的字段数，
+// 隐藏的分组会被跳过，找不到时返回 0, 0 停留在第一个分组
+func formFieldPosition(form *huh.Form, key string) (int, int) {
+	form.Init()
+	groups, fields := 0, 0
+	for form.State == huh.StateNormal {
+		focused := form.GetFocusedField()
+		if focused.GetKey() == key {
+			return groups, fields
+		}
+
+		form.NextField()
+		if form.GetFocusedField() != focused {
+			fields++
+			continue
+		}
+
+		// 分组内已是最后一个字段，在最后一个分组上切换会直接提交，此时说明没有找到
+		form.NextGroup()
+		groups++
+		fields = 0
+		if form.GetFocusedField() == focused {
+			break
+		}
+	}
+	return 0, 0
+}
+
+// renderIssues 渲染跨字段校验的问题摘要，显示在表单上方
+func (m *ConfigFormModel) renderIssues() string {
+	if len(m.issues) == 0 {
+		return ""
+	}
+
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	content := errorStyle.Bold(true).Render(i18n.Sprintf("❌ 有 %d 处配置需要修改，已跳转到第一处所在的分组", len(m.issues))) + "\n"
+	for _, issue := range m.issues {
+		content += errorStyle.Render("  • "+issue.Message) + hintStyle.Render(" ("+issue.Field+")") + "\n"
+	}
+	return content + "\n"
+}
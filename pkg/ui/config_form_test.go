@@ -0,0 +1,288 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"frp-cli-ui/pkg/config"
+)
+
+// setFormValues 按字段名填写表单，模拟用户输入
+func setFormValues(t *testing.T, form *ConfigFormModel, values map[string]string) {
+	t.Helper()
+	for key, value := range values {
+		ptr, ok := form.formData[key]
+		if !ok {
+			t.Fatalf("form has no field %q", key)
+		}
+		*ptr = value
+	}
+}
+
+func TestProxyFormHTTPAuthSavedAsHTTPPassword(t *testing.T) {
+	form := NewProxyConfigForm(nil)
+	setFormValues(t, form, map[string]string{
+		"name":          "web",
+		"proxyType":     "http",
+		"localPort":     "8080",
+		"customDomains": "web.example.com",
+		"httpUser":      "admin",
+		"httpPassword":  "secret",
+	})
+	form.updateConfigFromForm()
+
+	proxy := form.GetProxyConfig()
+	if proxy.HTTPUser != "admin" || proxy.HTTPPassword != "secret" {
+		t.Fatalf("HTTPUser/HTTPPassword = %q/%q, want admin/secret", proxy.HTTPUser, proxy.HTTPPassword)
+	}
+
+	cfg := &config.Config{ServerAddr: "example.com", Proxies: []config.ProxyConfig{*proxy}}
+	for _, format := range []config.ConfigFormat{config.FormatYAML, config.FormatTOML} {
+		data, err := config.MarshalConfig(cfg, format)
+		if err != nil {
+			t.Fatalf("MarshalConfig(%s): %v", format, err)
+		}
+		if !strings.Contains(string(data), "httpPassword") {
+			t.Errorf("%s: password not written as httpPassword:\n%s", format, data)
+		}
+		issues, err := config.CheckSchema(data, format, false)
+		if err != nil {
+			t.Fatalf("CheckSchema(%s): %v", format, err)
+		}
+		for _, issue := range issues {
+			t.Errorf("%s: unexpected schema issue: %s", format, issue)
+		}
+	}
+}
+
+func TestServerFormRoundTrip(t *testing.T) {
+	original := &config.Config{
+		BindPort:          7000,
+		Auth:              config.AuthConfig{Token: "secret"},
+		AllowPorts:        []config.PortRange{{Start: 2000, End: 3000}, {Single: 3001}},
+		MaxPortsPerClient: 10,
+		VhostHTTPPort:     80,
+		VhostHTTPSPort:    443,
+		VhostHTTPTimeout:  60,
+		SubDomainHost:     "frps.example.com",
+		WebServer:         config.WebServerConfig{Addr: "0.0.0.0", Port: 7500, User: "admin", Password: "admin"},
+		Log:               config.LogConfig{To: "console", Level: "info"},
+		Transport:         config.TransportConfig{HeartbeatTimeout: 90},
+		SSHTunnelGateway:  config.SSHTunnelGatewayConfig{BindPort: 2200, AuthorizedKeysFile: "/etc/frp/keys"},
+	}
+	cfg := cloneConfig(t, original)
+
+	form := NewServerConfigForm(cfg)
+	form.updateConfigFromForm()
+	if !reflect.DeepEqual(form.GetConfig(), original) {
+		t.Errorf("server config changed by round trip:\ngot  %+v\nwant %+v", form.GetConfig(), original)
+	}
+}
+
+func TestServerFormOptionalPorts(t *testing.T) {
+	tests := []struct {
+		name         string
+		bindPort     string
+		webPort      string
+		wantBindPort int
+		wantWebPort  int
+	}{
+		{"values", "7001", "7501", 7001, 7501},
+		{"surrounding spaces", "7001", " 7501 ", 7001, 7501},
+		{"empty keeps bindPort and disables web", "", "", 7000, 0},
+		{"invalid keeps bindPort and disables web", "abc", "abc", 7000, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := NewServerConfigForm(&config.Config{BindPort: 7000, WebServer: config.WebServerConfig{Port: 7500}})
+			setFormValues(t, form, map[string]string{"bindPort": tt.bindPort, "webPort": tt.webPort})
+			form.updateConfigFromForm()
+
+			cfg := form.GetConfig()
+			if cfg.BindPort != tt.wantBindPort {
+				t.Errorf("BindPort = %d, want %d", cfg.BindPort, tt.wantBindPort)
+			}
+			if cfg.WebServer.Port != tt.wantWebPort {
+				t.Errorf("WebServer.Port = %d, want %d", cfg.WebServer.Port, tt.wantWebPort)
+			}
+		})
+	}
+}
+
+func TestClientFormRoundTrip(t *testing.T) {
+	disabled := false
+	original := &config.Config{
+		ServerAddr: "example.com",
+		ServerPort: 7000,
+		Auth:       config.AuthConfig{Token: "secret"},
+		Log:        config.LogConfig{To: "/var/log/frpc.log", Level: "debug"},
+		Transport: config.TransportConfig{
+			Protocol:          "websocket",
+			PoolCount:         5,
+			DialServerTimeout: 10,
+			ProxyURL:          "socks5://127.0.0.1:1080",
+			TLS: config.TLSConfig{
+				Enable:        &disabled,
+				CertFile:      "/etc/frp/client.crt",
+				KeyFile:       "/etc/frp/client.key",
+				TrustedCaFile: "/etc/frp/ca.crt",
+				ServerName:    "frps.example.com",
+			},
+		},
+	}
+	cfg := cloneConfig(t, original)
+
+	form := NewClientConfigForm(cfg)
+	form.updateConfigFromForm()
+	if !reflect.DeepEqual(form.GetConfig(), original) {
+		t.Errorf("client config changed by round trip:\ngot  %+v\nwant %+v", form.GetConfig(), original)
+	}
+}
+
+func TestClientFormDefaults(t *testing.T) {
+	form := NewClientConfigForm(&config.Config{ServerAddr: "example.com", ServerPort: 7000})
+	setFormValues(t, form, map[string]string{"serverPort": "abc", "poolCount": "", "tlsEnable": "yes", "protocol": "tcp"})
+	form.updateConfigFromForm()
+
+	cfg := form.GetConfig()
+	if cfg.ServerPort != 7000 {
+		t.Errorf("invalid serverPort changed ServerPort to %d", cfg.ServerPort)
+	}
+	if cfg.Transport.Protocol != "" {
+		t.Errorf("tcp protocol should be left to the frpc default, got %q", cfg.Transport.Protocol)
+	}
+	if cfg.Transport.PoolCount != 0 {
+		t.Errorf("empty poolCount = %d, want 0", cfg.Transport.PoolCount)
+	}
+	if cfg.Transport.TLS.Enable != nil {
+		t.Errorf("enabled TLS should be left to the frpc default, got %v", *cfg.Transport.TLS.Enable)
+	}
+}
+
+func TestProxyFormRoundTrip(t *testing.T) {
+	tests := []config.ProxyConfig{
+		{
+			Name:       "ssh",
+			Type:       "tcp",
+			LocalIP:    "127.0.0.1",
+			LocalPort:  22,
+			RemotePort: 6000,
+			Transport: config.ProxyTransport{
+				UseEncryption:  true,
+				UseCompression: true,
+				BandwidthLimit: "1MB",
+			},
+			LoadBalancer: config.LoadBalancerConfig{Group: "ssh", GroupKey: "key"},
+			HealthCheck:  config.HealthCheckConfig{Type: "tcp", IntervalS: 10, TimeoutS: 3, MaxFailed: 3},
+		},
+		{
+			Name:              "web",
+			Type:              "http",
+			LocalIP:           "127.0.0.1",
+			LocalPort:         8080,
+			CustomDomains:     []string{"web.example.com", "www.example.com"},
+			Locations:         []string{"/", "/api"},
+			HTTPUser:          "admin",
+			HTTPPassword:      "secret",
+			HostHeaderRewrite: "dev.example.com",
+			HealthCheck:       config.HealthCheckConfig{Type: "http", Path: "/health"},
+		},
+		{
+			Name:      "private",
+			Type:      "stcp",
+			LocalIP:   "127.0.0.1",
+			LocalPort: 22,
+			SecretKey: "secret",
+		},
+	}
+
+	for _, original := range tests {
+		t.Run(original.Type, func(t *testing.T) {
+			proxy := original
+			form := NewProxyConfigForm(&proxy)
+			form.updateConfigFromForm()
+			if !reflect.DeepEqual(*form.GetProxyConfig(), original) {
+				t.Errorf("proxy changed by round trip:\ngot  %+v\nwant %+v", *form.GetProxyConfig(), original)
+			}
+		})
+	}
+}
+
+func TestProxyFormClearsFieldsOfOtherTypes(t *testing.T) {
+	form := NewProxyConfigForm(&config.ProxyConfig{
+		Name:          "web",
+		Type:          "http",
+		LocalPort:     8080,
+		CustomDomains: []string{"web.example.com"},
+		HTTPUser:      "admin",
+		HTTPPassword:  "secret",
+	})
+	setFormValues(t, form, map[string]string{"proxyType": "tcp", "remotePort": "6000"})
+	form.updateConfigFromForm()
+
+	proxy := form.GetProxyConfig()
+	if proxy.RemotePort != 6000 {
+		t.Errorf("RemotePort = %d, want 6000", proxy.RemotePort)
+	}
+	if proxy.CustomDomains != nil || proxy.HTTPUser != "" || proxy.HTTPPassword != "" {
+		t.Errorf("HTTP fields kept after switching to tcp: %+v", proxy)
+	}
+}
+
+func TestVisitorFormRoundTrip(t *testing.T) {
+	original := config.VisitorConfig{
+		Name:       "ssh-visitor",
+		Type:       "xtcp",
+		ServerName: "ssh",
+		SecretKey:  "secret",
+		BindAddr:   "127.0.0.1",
+		BindPort:   6000,
+	}
+	visitor := original
+	form := NewVisitorConfigForm(&visitor)
+	form.updateConfigFromForm()
+	if *form.GetVisitorConfig() != original {
+		t.Errorf("visitor changed by round trip:\ngot  %+v\nwant %+v", *form.GetVisitorConfig(), original)
+	}
+
+	for _, input := range []string{"", "abc"} {
+		setFormValues(t, form, map[string]string{"bindPort": input})
+		form.updateConfigFromForm()
+		if got := form.GetVisitorConfig().BindPort; got != 0 {
+			t.Errorf("bindPort %q parsed as %d, want 0", input, got)
+		}
+	}
+}
+
+func TestParseOptionalInt(t *testing.T) {
+	tests := map[string]int{
+		"":       0,
+		"   ":    0,
+		"abc":    0,
+		"12abc":  0,
+		"7000":   7000,
+		" 7000 ": 7000,
+		"-1":     -1,
+	}
+	for input, want := range tests {
+		if got := parseOptionalInt(input); got != want {
+			t.Errorf("parseOptionalInt(%q) = %d, want %d", input, got, want)
+		}
+	}
+}
+
+// cloneConfig 深拷贝配置，避免表单直接修改期望值
+func cloneConfig(t *testing.T, cfg *config.Config) *config.Config {
+	t.Helper()
+	data, err := config.MarshalConfig(cfg, config.FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clone, err := config.UnmarshalConfig(data, config.FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return clone
+}
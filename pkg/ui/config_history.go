@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// configHistoryLimit 最多保留的历史记录数
+const configHistoryLimit = 50
+
+// historyEntry 一次修改后的配置快照
+type historyEntry struct {
+	time   time.Time
+	action string
+	server *config.Config
+	client *config.Config
+}
+
+// configHistory 配置修改历史，entries[cursor] 为当前状态
+type configHistory struct {
+	entries  []historyEntry
+	cursor   int
+	selected int // 历史面板中选中的记录
+}
+
+// newConfigHistory 以当前配置作为初始状态创建历史
+func newConfigHistory(action string, server, client *config.Config) *configHistory {
+	h := &configHistory{}
+	h.reset(action, server, client)
+	return h
+}
+
+// reset 清空历史并以当前配置作为初始状态
+func (h *configHistory) reset(action string, server, client *config.Config) {
+	h.entries = []historyEntry{{
+		time:   time.Now(),
+		action: action,
+		server: server.Clone(),
+		client: client.Clone(),
+	}}
+	h.cursor = 0
+	h.selected = 0
+}
+
+// record 记录一次修改，配置未变化时忽略；撤销后再修改会丢弃可重做的记录
+func (h *configHistory) record(action string, server, client *config.Config) bool {
+	current := h.entries[h.cursor]
+	if reflect.DeepEqual(current.server, server) && reflect.DeepEqual(current.client, client) {
+		return false
+	}
+
+	h.entries = append(h.entries[:h.cursor+1], historyEntry{
+		time:   time.Now(),
+		action: action,
+		server: server.Clone(),
+		client: client.Clone(),
+	})
+	if len(h.entries) > configHistoryLimit {
+		h.entries = h.entries[len(h.entries)-configHistoryLimit:]
+	}
+	h.cursor = len(h.entries) - 1
+	h.selected = h.cursor
+	return true
+}
+
+// canUndo 是否可以撤销
+func (h *configHistory) canUndo() bool {
+	return h.cursor > 0
+}
+
+// canRedo 是否可以重做
+func (h *configHistory) canRedo() bool {
+	return h.cursor < len(h.entries)-1
+}
+
+// jump 移动到指定记录，返回该记录配置的副本
+func (h *configHistory) jump(index int) (server, client *config.Config) {
+	h.cursor = index
+	h.selected = index
+	entry := h.entries[index]
+	return entry.server.Clone(), entry.client.Clone()
+}
+
+// handleUndo 撤销上一次修改
+func (ct *ConfigTab) handleUndo() (Tab, tea.Cmd) {
+	if !ct.history.canUndo() {
+		return ct, showStatusMessage(i18n.T("没有可撤销的修改"), true)
+	}
+
+	action := ct.history.entries[ct.history.cursor].action
+	ct.applyHistory(ct.history.cursor - 1)
+	ct.events.Publish(service.UserActionEvent(service.ActionConfigUndo, i18n.T("撤销 ")+action))
+	return ct, showStatusMessage(i18n.Sprintf("↩️ 已撤销: %s（尚未保存到文件）", action), false)
+}
+
+// handleRedo 重做被撤销的修改
+func (ct *ConfigTab) handleRedo() (Tab, tea.Cmd) {
+	if !ct.history.canRedo() {
+		return ct, showStatusMessage(i18n.T("没有可重做的修改"), true)
+	}
+
+	ct.applyHistory(ct.history.cursor + 1)
+	action := ct.history.entries[ct.history.cursor].action
+	ct.events.Publish(service.UserActionEvent(service.ActionConfigUndo, i18n.T("重做 ")+action))
+	return ct, showStatusMessage(i18n.Sprintf("↪️ 已重做: %s（尚未保存到文件）", action), false)
+}
+
+// applyHistory 将配置恢复到指定历史记录，正在编辑的表单引用旧配置因此一并关闭
+func (ct *ConfigTab) applyHistory(index int) {
+	ct.serverConfig, ct.clientConfig = ct.history.jump(index)
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	if ct.state != ConfigTabHistory {
+		ct.state = ConfigTabMenu
+	}
+}
+
+// recordHistory 记录当前配置状态
+func (ct *ConfigTab) recordHistory(action string) {
+	ct.recordChange(service.ActionConfigEdit, action)
+}
+
+// recordChange 记录当前配置状态，配置有变化时发布用户操作事件
+func (ct *ConfigTab) recordChange(kind, action string) {
+	if ct.history.record(action, ct.serverConfig, ct.clientConfig) {
+		ct.events.Publish(service.UserActionEvent(kind, action))
+	}
+}
+
+// handleShowHistory 打开历史记录面板
+func (ct *ConfigTab) handleShowHistory() (Tab, tea.Cmd) {
+	ct.history.selected = ct.history.cursor
+	ct.state = ConfigTabHistory
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	return ct, nil
+}
+
+// updateHistory 处理历史记录面板中的按键
+func (ct *ConfigTab) updateHistory(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	h := ct.history
+
+	keys := ct.keys.Config
+	switch {
+	case msg.String() == "esc":
+		ct.state = ConfigTabMenu
+	case key.Matches(msg, keys.Up):
+		if h.selected > 0 {
+			h.selected--
+		}
+	case key.Matches(msg, keys.Down):
+		if h.selected < len(h.entries)-1 {
+			h.selected++
+		}
+	case key.Matches(msg, keys.Undo):
+		return ct.handleUndo()
+	case key.Matches(msg, keys.Redo):
+		return ct.handleRedo()
+	case msg.String() == "enter":
+		if h.selected == h.cursor {
+			return ct, nil
+		}
+		ct.applyHistory(h.selected)
+		entry := h.entries[h.cursor]
+		ct.events.Publish(service.UserActionEvent(service.ActionConfigUndo, i18n.T("回到历史状态 ")+entry.action))
+		return ct, showStatusMessage(i18n.Sprintf("🕘 已回到 %s 的状态: %s（尚未保存到文件）",
+			entry.time.Format("15:04:05"), entry.action), false)
+	}
+
+	return ct, nil
+}
+
+// renderHistory 渲染历史记录面板，最新的记录在最上方
+func (ct *ConfigTab) renderHistory() string {
+	h := ct.history
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color("#FAFAFA"))
+	undoneStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Strikethrough(true)
+
+	content := titleStyle.Render(i18n.T("📜 修改历史")) + "\n\n"
+
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		entry := h.entries[i]
+		marker := "  "
+		if i == h.cursor {
+			marker = "● "
+		}
+
+		line := fmt.Sprintf("%s  %s", entry.time.Format("15:04:05"), entry.action)
+		switch {
+		case i == h.selected:
+			line = selectedStyle.Render(line)
+		case i > h.cursor:
+			// 已撤销、可重做的记录
+			line = undoneStyle.Render(line)
+		}
+		content += marker + line + "\n"
+	}
+
+	content += "\n" + hintStyle.Render(i18n.Sprintf("● 当前状态 | 可撤销 %d 步，可重做 %d 步",
+		h.cursor, len(h.entries)-1-h.cursor)) + "\n"
+	keys := ct.keys.Config
+	content += hintStyle.Render(i18n.Sprintf("%s/%s 选择 | Enter 回到该状态 | %s 撤销 | %s 重做 | ESC 返回",
+		keys.Up.Help().Key, keys.Down.Help().Key, keys.Undo.Help().Key, keys.Redo.Help().Key))
+
+	return content
+}
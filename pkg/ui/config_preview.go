@@ -0,0 +1,300 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// previewStyleName 配置预览使用的 chroma 配色
+const previewStyleName = "monokai"
+
+// configPreview 配置预览面板状态，内容较长时在视口中滚动
+type configPreview struct {
+	viewport    viewport.Model
+	format      config.ConfigFormat // 为空时按各自配置文件的格式显示
+	lineNumbers bool
+
+	mark     *previewMark // 搜索跳转时标记的配置行
+	markLine int          // 标记行在预览内容中的行号，没有标记时为 -1
+}
+
+// previewMark 预览中要标记的配置字段：有 anchor 时先在 section 列表中找到该名称的代理或访问者，再找其后同时含有字段名和值的行
+type previewMark struct {
+	client  bool
+	section string // visitors 或 proxies
+	anchor  string
+	field   string
+	value   string
+}
+
+// newConfigPreview 创建配置预览面板，默认显示行号
+func newConfigPreview() *configPreview {
+	vp := viewport.New(0, 0)
+	vp.SetHorizontalStep(4)
+	return &configPreview{viewport: vp, lineNumbers: true, markLine: -1}
+}
+
+// SetSize 设置预览视口大小
+func (p *configPreview) SetSize(width, height int) {
+	if height < 3 {
+		height = 3
+	}
+	p.viewport.Width = width
+	p.viewport.Height = height
+}
+
+// formatFor 返回配置文件在预览中使用的格式，INI 配置按 YAML 显示
+func (p *configPreview) formatFor(path string) config.ConfigFormat {
+	if p.format != "" {
+		return p.format
+	}
+	if format := config.DetectFormat(path); format != config.FormatINI {
+		return format
+	}
+	return config.FormatYAML
+}
+
+// nextFormat 在跟随文件、YAML、TOML 之间切换
+func (p *configPreview) nextFormat() {
+	switch p.format {
+	case "":
+		p.format = config.FormatYAML
+	case config.FormatYAML:
+		p.format = config.FormatTOML
+	default:
+		p.format = ""
+	}
+}
+
+// handlePreviewConfig 处理预览配置，并在后台检查代理域名的解析
+func (ct *ConfigTab) handlePreviewConfig() (Tab, tea.Cmd) {
+	ct.state = ConfigTabPreview
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	ct.runValidation()
+
+	if ct.preview == nil {
+		ct.preview = newConfigPreview()
+	}
+	ct.preview.mark = nil
+	dnsCmd := ct.runDNSCheck()
+	ct.refreshPreview()
+	ct.preview.viewport.GotoTop()
+	return ct, dnsCmd
+}
+
+// refreshPreview 重新生成预览内容，保持当前滚动位置
+func (ct *ConfigTab) refreshPreview() {
+	p := ct.preview
+	p.markLine = -1
+
+	content := ct.renderValidationResult() + "\n"
+	content += lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("46")).Render(i18n.T("🎯 服务端配置文件内容:")) + "\n\n"
+	content += p.renderConfig(ct.serverConfig, p.formatFor(ct.serverConfigPath), i18n.T("服务端配置为空"), false, strings.Count(content, "\n")) + "\n\n"
+	content += lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("81")).Render(i18n.T("💻 客户端配置文件内容:")) + "\n\n"
+	content += p.renderConfig(ct.clientConfig, p.formatFor(ct.clientConfigPath), i18n.T("客户端配置为空"), true, strings.Count(content, "\n"))
+
+	p.viewport.SetContent(content)
+}
+
+// renderConfig 序列化配置并渲染为带语法高亮和行号的文本，offset 为该配置在预览内容中的起始行，用于记录标记行的位置
+func (p *configPreview) renderConfig(cfg *config.Config, format config.ConfigFormat, emptyMessage string, client bool, offset int) string {
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	if cfg == nil {
+		return hintStyle.Render(emptyMessage)
+	}
+
+	data, err := config.MarshalConfig(cfg.Flatten(), format)
+	if err != nil {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(i18n.T("错误: ") + err.Error())
+	}
+
+	lines := highlightConfig(string(data), format)
+	content := hintStyle.Render(strings.ToUpper(string(format))) + "\n"
+
+	marked := -1
+	if p.mark != nil && p.mark.client == client {
+		marked = p.mark.find(strings.Split(string(data), "\n"))
+		if marked >= 0 {
+			p.markLine = offset + 1 + marked
+		}
+	}
+	markStyle := lipgloss.NewStyle().Background(lipgloss.Color("#7D56F4")).Foreground(lipgloss.Color("#FAFAFA"))
+
+	if !p.lineNumbers {
+		if marked >= 0 && marked < len(lines) {
+			lines[marked] = markStyle.Render("▶") + " " + lines[marked]
+		}
+		return content + strings.Join(lines, "\n")
+	}
+
+	digits := len(fmt.Sprint(len(lines)))
+	for i, line := range lines {
+		gutter := fmt.Sprintf("%*d │ ", digits, i+1)
+		if i == marked {
+			lines[i] = markStyle.Render(gutter) + line
+			continue
+		}
+		lines[i] = hintStyle.Render(gutter) + line
+	}
+	return content + strings.Join(lines, "\n")
+}
+
+// find 返回标记字段所在的行，优先同时含有字段名和值的行，其次含有值的行，找不到时返回 -1
+func (m *previewMark) find(lines []string) int {
+	start := 0
+	if m.anchor != "" {
+		start = -1
+		inSection := false
+		for i, line := range lines {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "[["+m.section+"]]" || trimmed == m.section+":" {
+				inSection = true
+			}
+			if !inSection {
+				continue
+			}
+			key, value, ok := strings.Cut(strings.TrimPrefix(trimmed, "- "), "=")
+			if !ok {
+				key, value, ok = strings.Cut(strings.TrimPrefix(trimmed, "- "), ":")
+			}
+			if ok && strings.TrimSpace(key) == "name" && strings.Trim(strings.TrimSpace(value), `"'`) == m.anchor {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			return -1
+		}
+	}
+
+	field := m.field[strings.LastIndex(m.field, ".")+1:]
+	valueLine := -1
+	for i := start; i < len(lines); i++ {
+		if !strings.Contains(lines[i], m.value) {
+			continue
+		}
+		if strings.Contains(lines[i], field) {
+			return i
+		}
+		if valueLine < 0 {
+			valueLine = i
+		}
+	}
+	if valueLine < 0 && m.anchor != "" {
+		return start
+	}
+	return valueLine
+}
+
+// highlightConfig 按格式对配置文本做语法高亮，返回逐行结果。
+// 颜色交给 lipgloss 渲染，以便跟随终端的颜色能力降级
+func highlightConfig(text string, format config.ConfigFormat) []string {
+	text = strings.ReplaceAll(strings.TrimRight(text, "\n"), "\t", "    ")
+
+	lexer := lexers.Get(string(format))
+	if lexer == nil {
+		return strings.Split(text, "\n")
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, text)
+	if err != nil {
+		return strings.Split(text, "\n")
+	}
+
+	style := styles.Get(previewStyleName)
+	var lines []string
+	for _, tokens := range chroma.SplitTokensIntoLines(iterator.Tokens()) {
+		var b strings.Builder
+		for _, token := range tokens {
+			value := strings.TrimRight(token.Value, "\n")
+			if value == "" {
+				continue
+			}
+			entry := style.Get(token.Type)
+			s := lipgloss.NewStyle().Bold(entry.Bold == chroma.Yes).Italic(entry.Italic == chroma.Yes)
+			if entry.Colour.IsSet() {
+				s = s.Foreground(lipgloss.Color(entry.Colour.String()))
+			}
+			b.WriteString(s.Render(value))
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
+
+// updatePreview 处理配置预览中的按键：滚动、切换行号和格式、复制配置
+func (ct *ConfigTab) updatePreview(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	p := ct.preview
+	keys := ct.keys.Config
+
+	switch {
+	case msg.String() == "esc":
+		ct.state = ConfigTabMenu
+		return ct, nil
+	case key.Matches(msg, keys.PreviewTop):
+		p.viewport.GotoTop()
+		return ct, nil
+	case key.Matches(msg, keys.PreviewBottom):
+		p.viewport.GotoBottom()
+		return ct, nil
+	case key.Matches(msg, keys.LineNumbers):
+		p.lineNumbers = !p.lineNumbers
+		ct.refreshPreview()
+		return ct, nil
+	case key.Matches(msg, keys.PreviewFormat):
+		p.nextFormat()
+		ct.refreshPreview()
+		return ct, nil
+	case key.Matches(msg, keys.CopyClient):
+		return ct, copyConfigCmd(ct.clientConfig, p.formatFor(ct.clientConfigPath), i18n.T("客户端配置为空"))
+	case key.Matches(msg, keys.CopyServer):
+		return ct, copyConfigCmd(ct.serverConfig, p.formatFor(ct.serverConfigPath), i18n.T("服务端配置为空"))
+	case key.Matches(msg, keys.Verify):
+		return ct, ct.runFrpVerify()
+	}
+
+	var cmd tea.Cmd
+	p.viewport, cmd = p.viewport.Update(msg)
+	return ct, cmd
+}
+
+// renderConfigPreview 渲染配置预览视口和操作提示
+func (ct *ConfigTab) renderConfigPreview() string {
+	p := ct.preview
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	format := i18n.T("跟随配置文件")
+	if p.format != "" {
+		format = strings.ToUpper(string(p.format))
+	}
+
+	content := p.viewport.View() + "\n"
+	content += hintStyle.Render(i18n.Sprintf("格式: %s | 已滚动 %3.0f%%", format, p.viewport.ScrollPercent()*100)) + "\n"
+	content += hintStyle.Render(i18n.Sprintf("↑/↓ PgUp/PgDn 滚动 | Home/End 首尾 | %s 行号 | %s 切换格式 | %s 复制客户端配置 | %s 复制服务端配置 | %s frp verify | ESC 返回菜单",
+		ct.keys.Config.LineNumbers.Help().Key, ct.keys.Config.PreviewFormat.Help().Key,
+		ct.keys.Config.CopyClient.Help().Key, ct.keys.Config.CopyServer.Help().Key, ct.keys.Config.Verify.Help().Key))
+	return content
+}
+
+// copyConfigCmd 按预览中的格式复制配置，配置为空时在状态栏提示
+func copyConfigCmd(cfg *config.Config, format config.ConfigFormat, emptyMessage string) tea.Cmd {
+	if cfg == nil {
+		return showStatusMessage("❌ "+emptyMessage, true)
+	}
+	data, err := config.MarshalConfig(cfg.Flatten(), format)
+	if err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+	return copyCmd(string(data))
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// configSearchLimit 搜索面板最多同时显示的结果数
+const configSearchLimit = 15
+
+// configSearch 在已加载的服务端与客户端配置中搜索代理、访问者和端口的面板
+type configSearch struct {
+	input   textinput.Model
+	entries []config.SearchEntry
+	hits    []config.SearchHit
+	cursor  int
+}
+
+// handleConfigSearch 打开配置搜索面板，搜索范围为打开时已加载的配置
+func (ct *ConfigTab) handleConfigSearch() (Tab, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = "/ "
+	input.Placeholder = i18n.T("代理名、域名、端口、插件参数、访问者名")
+	input.CharLimit = 128
+	input.Focus()
+
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	ct.search = &configSearch{
+		input:   input,
+		entries: config.SearchEntries(ct.serverConfig, ct.clientConfig),
+	}
+	ct.state = ConfigTabSearch
+	return ct, textinput.Blink
+}
+
+// updateConfigSearch 处理搜索面板中的消息，方向键选择结果，Enter 跳转，其余按键交给输入框
+func (ct *ConfigTab) updateConfigSearch(msg tea.Msg) (Tab, tea.Cmd) {
+	s := ct.search
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case keyMsg.String() == "esc":
+			ct.search = nil
+			ct.state = ConfigTabMenu
+			return ct, nil
+		case key.Matches(keyMsg, ct.keys.Config.SearchPrev):
+			if len(s.hits) > 0 {
+				s.cursor = (s.cursor - 1 + len(s.hits)) % len(s.hits)
+			}
+			return ct, nil
+		case key.Matches(keyMsg, ct.keys.Config.SearchNext):
+			if len(s.hits) > 0 {
+				s.cursor = (s.cursor + 1) % len(s.hits)
+			}
+			return ct, nil
+		case keyMsg.String() == "enter":
+			if len(s.hits) == 0 {
+				return ct, nil
+			}
+			return ct.jumpToSearchHit(s.hits[s.cursor])
+		}
+	}
+
+	query := s.input.Value()
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	if s.input.Value() != query {
+		s.hits = config.Search(s.entries, s.input.Value())
+		s.cursor = 0
+	}
+	return ct, cmd
+}
+
+// jumpToSearchHit 跳转到匹配项：代理在代理列表中选中，访问者和服务端/客户端字段在配置预览中标记所在行
+func (ct *ConfigTab) jumpToSearchHit(hit config.SearchHit) (Tab, tea.Cmd) {
+	ct.search = nil
+	status := showStatusMessage(fmt.Sprintf("🔍 %s: %s = %s", searchHitOwner(hit), hit.Field, hit.Value), false)
+
+	if hit.Scope == config.SearchScopeProxy {
+		_, cmd := ct.handleProxyList()
+		if ct.proxyList == nil {
+			return ct, cmd
+		}
+		ct.proxyList.list.Select(hit.Index)
+		return ct, tea.Batch(cmd, status)
+	}
+
+	_, dnsCmd := ct.handlePreviewConfig()
+	p := ct.preview
+	p.mark = &previewMark{
+		client: hit.Scope != config.SearchScopeServer,
+		field:  hit.Field,
+		value:  hit.Value,
+	}
+	if hit.Scope == config.SearchScopeVisitor {
+		p.mark.section, p.mark.anchor = "visitors", hit.Owner
+	}
+	ct.refreshPreview()
+	if p.markLine >= 0 {
+		p.viewport.SetYOffset(max(0, p.markLine-3))
+	}
+	return ct, tea.Batch(status, dnsCmd)
+}
+
+// searchHitOwner 返回匹配项所属的位置，如 代理 web、服务端配置
+func searchHitOwner(hit config.SearchHit) string {
+	switch hit.Scope {
+	case config.SearchScopeProxy:
+		return i18n.T("代理 ") + hit.Owner
+	case config.SearchScopeVisitor:
+		return i18n.T("访问者 ") + hit.Owner
+	case config.SearchScopeServer:
+		return i18n.T("服务端配置")
+	default:
+		return i18n.T("客户端配置")
+	}
+}
+
+// renderConfigSearch 渲染搜索面板，结果中匹配到的字符高亮显示
+func (ct *ConfigTab) renderConfigSearch() string {
+	s := ct.search
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	matchStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color("#FAFAFA"))
+
+	content := titleStyle.Render(i18n.T("🔍 搜索配置")) + "\n"
+	content += s.input.View() + "\n\n"
+
+	switch {
+	case s.input.Value() == "":
+		content += hintStyle.Render(i18n.Sprintf("在 %d 个字段中搜索，纯数字只匹配包含该数字的端口和值", len(s.entries))) + "\n"
+	case len(s.hits) == 0:
+		content += hintStyle.Render(i18n.T("没有匹配的配置项")) + "\n"
+	}
+
+	// 结果较多时让选中项保持在可见范围内
+	start := 0
+	if s.cursor >= configSearchLimit {
+		start = s.cursor - configSearchLimit + 1
+	}
+	end := min(len(s.hits), start+configSearchLimit)
+	for i := start; i < end; i++ {
+		hit := s.hits[i]
+		prefix := runewidth.FillRight(truncateString(searchHitOwner(hit), 24), 25) + runewidth.FillRight(hit.Field, 27)
+		if i == s.cursor {
+			content += "▶ " + selectedStyle.Render(prefix+hit.Value) + "\n"
+			continue
+		}
+		content += "  " + hintStyle.Render(prefix) + highlightRunes(hit.Value, hit.Positions, matchStyle) + "\n"
+	}
+	if len(s.hits) > configSearchLimit {
+		content += hintStyle.Render(i18n.Sprintf("共 %d 条结果", len(s.hits))) + "\n"
+	}
+
+	content += "\n" + hintStyle.Render(i18n.T("输入关键字搜索 | ↑/↓ 选择结果 | Enter 跳转 | ESC 返回菜单"))
+	return content
+}
+
+// highlightRunes 用 style 渲染 text 中位于 positions 的字符
+func highlightRunes(text string, positions []int, style lipgloss.Style) string {
+	var out string
+	for i, r := range []rune(text) {
+		if slices.Contains(positions, i) {
+			out += style.Render(string(r))
+			continue
+		}
+		out += string(r)
+	}
+	return out
+}
@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"gopkg.in/yaml.v3"
 
+	"frp-cli-ui/internal/service"
 	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
 )
 
 // ConfigTabState 配置标签页状态
@@ -22,6 +25,23 @@ const (
 	ConfigTabProxyForm
 	ConfigTabVisitorForm
 	ConfigTabPreview
+	ConfigTabMigration
+	ConfigTabProxyWizard
+	ConfigTabBackups
+	ConfigTabHistory
+	ConfigTabTemplates
+	ConfigTabExport
+	ConfigTabShare
+	ConfigTabProxyList
+	ConfigTabDiagnose
+	ConfigTabPairing
+	ConfigTabServerImport
+	ConfigTabSSHTunnel
+	ConfigTabSearch
+	ConfigTabFileChoice
+	ConfigTabDiscover
+	ConfigTabNATCheck
+	ConfigTabTokenRotation
 )
 
 // ConfigTab 配置管理标签页
@@ -29,9 +49,11 @@ type ConfigTab struct {
 	BaseTab
 	state            ConfigTabState
 	currentForm      *ConfigFormModel
+	wizard           *ProxyWizard
 	serverConfig     *config.Config
 	clientConfig     *config.Config
 	currentProxy     *config.ProxyConfig
+	editingProxy     string // 代理表单正在编辑的代理名称，为空表示添加新代理
 	currentVisitor   *config.VisitorConfig
 	loader           *config.Loader
 	menuItems        []string
@@ -40,6 +62,36 @@ type ConfigTab struct {
 	filePicker       *FilePicker
 	serverConfigPath string
 	clientConfigPath string
+	serverMeta       fileMetaCache
+	clientMeta       fileMetaCache
+	appSettings      *config.AppSettings
+	keys             *KeyMap
+	migration        *iniMigration
+	backups          *backupBrowser
+	history          *configHistory
+	templates        *templateBrowser
+	bundle           *bundleExport
+	share            *shareCode
+	proxyList        *proxyList
+	diagnosis        *configDiagnosis
+	pairing          *pairingAssistant
+	serverImport     *serverImport
+	discovery        *localDiscovery
+	natCheck         *natCheck
+	rotation         *tokenRotation
+	sshTunnel        *sshTunnelHelper
+	search           *configSearch
+	fileChoice       *configFileChoice
+	events           *service.EventBus
+	preview          *configPreview
+	verify           *frpVerify
+	dnsCheck         *dnsCheck
+	notice           formNotice
+	manager          *service.Manager
+	apiClient        *service.APIClient
+	validationErrors []string
+	portWarnings     []string
+	schemaWarnings   []string
 }
 
 // NewConfigTab 创建配置管理标签页
@@ -50,14 +102,36 @@ func NewConfigTab() *ConfigTab {
 	return &ConfigTab{
 		BaseTab:          baseTab,
 		state:            ConfigTabMenu,
-		menuItems:        []string{"🎯 服务端配置", "💻 客户端配置", "🔗 添加代理", "👥 添加访问者", "📁 选择配置文件", "👀 预览配置", "💾 保存配置"},
+		menuItems:        []string{"🎯 服务端配置", "💻 客户端配置", "🔗 添加代理", "👥 添加访问者", "📁 选择配置文件", "👀 预览配置", "💾 保存配置", "📥 导入INI配置", "🔄 应用并重载客户端", "🧙 代理向导", "🕘 从备份恢复", "📜 修改历史", "📋 配置模板", "📦 导出部署包", "📱 分享/导入配置", "🧪 验证(frp verify)", "📑 代理列表", "🩺 配置诊断", "🔐 STCP/XTCP 配对", "🌐 从服务端导入代理", "🔑 SSH 隧道命令", "🔍 发现本机服务", "🕳️ XTCP 打洞诊断", "🔁 轮换认证令牌"},
 		selectedItem:     0,
 		focusOnForm:      false,
 		serverConfigPath: config.GetDefaultServerConfigPath(),
 		clientConfigPath: config.GetDefaultClientConfigPath(),
+		history:          newConfigHistory(i18n.T("初始状态"), nil, nil),
+		keys:             DefaultKeyMap(),
 	}
 }
 
+// SetKeyMap 设置快捷键
+func (ct *ConfigTab) SetKeyMap(keys *KeyMap) {
+	ct.keys = keys
+}
+
+// SetEventBus 设置事件总线，保存配置文件时发布事件
+func (ct *ConfigTab) SetEventBus(bus *service.EventBus) {
+	ct.events = bus
+}
+
+// SetManager 设置Manager实例，用于应用配置后重载客户端
+func (ct *ConfigTab) SetManager(manager *service.Manager) {
+	ct.manager = manager
+}
+
+// SetAPIClient 设置 frps API 客户端，用于从服务端导入已注册的代理
+func (ct *ConfigTab) SetAPIClient(apiClient *service.APIClient) {
+	ct.apiClient = apiClient
+}
+
 // Init 初始化
 func (ct *ConfigTab) Init() tea.Cmd {
 	return nil
@@ -68,12 +142,11 @@ func (ct *ConfigTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		ct.SetSize(msg.Width, msg.Height)
+		if ct.wizard != nil {
+			return ct, ct.wizard.Update(msg)
+		}
 		if ct.currentForm != nil {
-			form, cmd := ct.currentForm.Update(msg)
-			if f, ok := form.(*ConfigFormModel); ok {
-				ct.currentForm = f
-			}
-			return ct, cmd
+			return ct, ct.updateCurrentForm(msg)
 		}
 
 	case tea.KeyMsg:
@@ -87,6 +160,105 @@ func (ct *ConfigTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 			return ct, cmd
 		}
 
+		// INI 迁移预览有独立的按键处理
+		if ct.state == ConfigTabMigration && ct.migration != nil {
+			return ct.updateMigration(msg)
+		}
+
+		// 备份恢复浏览器有独立的按键处理
+		if ct.state == ConfigTabBackups && ct.backups != nil {
+			return ct.updateBackupBrowser(msg)
+		}
+
+		// 模板管理独占键盘，避免 s/d 等全局快捷键误触
+		if ct.state == ConfigTabTemplates && ct.templates != nil {
+			return ct.updateTemplateBrowser(msg)
+		}
+
+		// 部署包导出面板独占键盘，避免 s 等全局快捷键误触
+		if ct.state == ConfigTabExport && ct.bundle != nil {
+			return ct.updateBundleExport(msg)
+		}
+
+		// 分享码面板独占键盘，避免输入分享码时触发全局快捷键
+		if ct.state == ConfigTabShare && ct.share != nil {
+			return ct.updateShareCode(msg)
+		}
+
+		// 代理列表有独立的按键处理，空格用于启用/停用代理
+		if ct.state == ConfigTabProxyList && ct.proxyList != nil {
+			return ct.updateProxyList(msg)
+		}
+
+		// 诊断面板有独立的按键处理
+		if ct.state == ConfigTabDiagnose && ct.diagnosis != nil {
+			return ct.updateDiagnosis(msg)
+		}
+
+		// 配对助手独占键盘，表单输入时不触发全局快捷键
+		if ct.state == ConfigTabPairing && ct.pairing != nil {
+			return ct.updatePairing(msg)
+		}
+
+		// SSH 隧道命令面板独占键盘，表单输入时不触发全局快捷键
+		if ct.state == ConfigTabSSHTunnel && ct.sshTunnel != nil {
+			return ct.updateSSHTunnel(msg)
+		}
+
+		// 选择配置文件的子菜单有独立的按键处理
+		if ct.state == ConfigTabFileChoice && ct.fileChoice != nil {
+			return ct.updateFileChoice(msg)
+		}
+
+		// 搜索面板独占键盘，输入关键字时不触发全局快捷键
+		if ct.state == ConfigTabSearch && ct.search != nil {
+			return ct.updateConfigSearch(msg)
+		}
+
+		// 服务端代理导入有独立的按键处理，空格用于勾选代理
+		if ct.state == ConfigTabServerImport && ct.serverImport != nil {
+			return ct.updateServerImport(msg)
+		}
+
+		// 发现本机服务面板有独立的按键处理
+		if ct.state == ConfigTabDiscover && ct.discovery != nil {
+			return ct.updateLocalDiscovery(msg)
+		}
+
+		// XTCP 打洞诊断面板独占键盘，输入映射端口时不触发全局快捷键
+		if ct.state == ConfigTabNATCheck && ct.natCheck != nil {
+			return ct.updateNATCheck(msg)
+		}
+
+		// 令牌轮换面板有独立的按键处理，轮换进行中不能离开
+		if ct.state == ConfigTabTokenRotation && ct.rotation != nil {
+			return ct.updateTokenRotation(msg)
+		}
+
+		// 配置预览独占键盘，方向键用于滚动
+		if ct.state == ConfigTabPreview && ct.preview != nil {
+			return ct.updatePreview(msg)
+		}
+
+		// 修改历史面板有独立的按键处理
+		if ct.state == ConfigTabHistory {
+			return ct.updateHistory(msg)
+		}
+
+		// 代理向导独占键盘，ESC 取消
+		if ct.state == ConfigTabProxyWizard && ct.wizard != nil {
+			if msg.String() == "esc" {
+				ct.wizard = nil
+				ct.state = ConfigTabMenu
+				return ct, nil
+			}
+			cmd := ct.wizard.Update(msg)
+			if ct.wizard.IsCompleted() {
+				return ct.finishProxyWizard()
+			}
+			return ct, cmd
+		}
+
 		// 根据焦点位置处理键盘事件
 		if ct.focusOnForm && ct.currentForm != nil {
 			// 表单有焦点时，优先处理表单内的Tab/Shift+Tab
@@ -99,13 +271,15 @@ func (ct *ConfigTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 				// Ctrl+Tab 用于切换到菜单焦点
 				ct.focusOnForm = false
 				return ct, nil
+			}
+			switch {
+			case key.Matches(msg, ct.keys.Config.GenerateToken):
+				return ct.generateSecret()
+			case key.Matches(msg, ct.keys.Config.SyncToken):
+				return ct.syncSecret()
 			default:
 				// 其他所有键盘事件（包括tab/shift+tab）传递给表单处理
-				form, cmd := ct.currentForm.Update(msg)
-				if f, ok := form.(*ConfigFormModel); ok {
-					ct.currentForm = f
-				}
-				return ct, cmd
+				return ct, ct.updateCurrentForm(msg)
 			}
 		} else {
 			// 菜单有焦点时的全局快捷键处理
@@ -126,39 +300,197 @@ func (ct *ConfigTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 				}
 			}
 			// 菜单有焦点时，处理菜单导航
-			switch msg.String() {
-			case "up", "k":
+			keys := ct.keys.Config
+			switch {
+			case key.Matches(msg, keys.Up):
 				if ct.selectedItem > 0 {
 					ct.selectedItem--
 				}
-			case "down", "j":
+			case key.Matches(msg, keys.Down):
 				if ct.selectedItem < len(ct.menuItems)-1 {
 					ct.selectedItem++
 				}
-			case "enter", " ":
+			case key.Matches(msg, keys.Select):
 				return ct.handleMenuSelection()
+			case key.Matches(msg, keys.Apply):
+				// 一键应用客户端配置并重载
+				return ct.handleApplyClientConfig()
+			case key.Matches(msg, keys.Test):
+				// 测试客户端配置能否连接并登录服务端
+				return ct.handleTestConnection()
+			case key.Matches(msg, keys.Wizard):
+				// 打开代理向导
+				return ct.handleProxyWizard()
+			case key.Matches(msg, keys.Backups):
+				// 打开备份恢复浏览器
+				return ct.handleRestoreBackup()
+			case key.Matches(msg, keys.Undo):
+				// 撤销上一次修改
+				return ct.handleUndo()
+			case key.Matches(msg, keys.Redo):
+				// 重做被撤销的修改
+				return ct.handleRedo()
+			case key.Matches(msg, keys.History):
+				// 查看修改历史
+				return ct.handleShowHistory()
+			case key.Matches(msg, keys.Templates):
+				// 打开模板管理
+				return ct.handleTemplates()
+			case key.Matches(msg, keys.Verify):
+				// 用 frps/frpc verify 检查配置文件
+				return ct.handleFrpVerify()
+			case key.Matches(msg, keys.Proxies):
+				// 打开代理列表
+				return ct.handleProxyList()
+			case key.Matches(msg, keys.Diagnose):
+				// 交叉检查所有配置
+				return ct.handleDiagnose()
+			case key.Matches(msg, keys.Pairing):
+				// 打开 stcp/xtcp 配对助手
+				return ct.handlePairing()
+			case key.Matches(msg, keys.ImportServer):
+				// 从 frps 导入已注册的代理
+				return ct.handleServerImport()
+			case key.Matches(msg, keys.SSHTunnel):
+				// 生成通过 SSH 隧道网关创建代理的命令
+				return ct.handleSSHTunnel()
+			case key.Matches(msg, keys.Discover):
+				// 扫描本机监听的端口，为发现的服务添加代理
+				return ct.handleLocalDiscovery()
+			case key.Matches(msg, keys.NATCheck):
+				// 检测 NAT 类型，判断 xtcp 能否打洞
+				return ct.handleNATCheck()
+			case key.Matches(msg, keys.RotateToken):
+				// 轮换服务端和客户端的认证令牌
+				return ct.handleTokenRotation()
+			case key.Matches(msg, keys.Search):
+				// 在已加载的配置中搜索
+				return ct.handleConfigSearch()
 			}
 		}
 
+	case templateCatalogMsg:
+		if ct.templates != nil {
+			ct.templates.handleCatalogResult(msg)
+		}
+
+	case frpVerifyMsg:
+		ct.handleFrpVerifyResult(msg)
+
+	case dnsCheckMsg:
+		ct.handleDNSCheckResult(msg)
+
+	case serverImportMsg:
+		ct.handleServerImportResult(msg)
+
+	case localDiscoveryMsg:
+		ct.handleLocalDiscoveryResult(msg)
+
+	case natCheckMsg:
+		ct.handleNATCheckResult(msg)
+
+	case natMappingMsg:
+		ct.handleNATMappingResult(msg)
+
+	case rotationPrepareMsg:
+		ct.handleRotationPrepared(msg)
+
+	case rotationStepMsg:
+		return ct, ct.handleRotationStep(msg)
+
+	case bundleExportMsg:
+		if ct.bundle != nil {
+			ct.bundle.exporting = false
+			ct.bundle.result, ct.bundle.err = msg.result, msg.err
+		}
+
 	default:
 		// 处理文件选择器结果
 		if result, ok := GetFilePickerResult(msg); ok {
 			return ct.handleFilePickerResult(result)
 		}
 
-		// 表单模式下，将所有其他消息传递给表单处理
-		if ct.currentForm != nil {
-			form, cmd := ct.currentForm.Update(msg)
-			if f, ok := form.(*ConfigFormModel); ok {
-				ct.currentForm = f
+		// 配对助手的表单需要接收表单内部消息
+		if ct.state == ConfigTabPairing && ct.pairing != nil {
+			return ct.updatePairing(msg)
+		}
+
+		// SSH 隧道命令面板的表单需要接收表单内部消息
+		if ct.state == ConfigTabSSHTunnel && ct.sshTunnel != nil {
+			return ct.updateSSHTunnel(msg)
+		}
+
+		// 映射端口输入框需要接收光标闪烁消息
+		if ct.state == ConfigTabNATCheck && ct.natCheck != nil {
+			return ct.updateNATCheck(msg)
+		}
+
+		// 搜索输入框需要接收光标闪烁消息
+		if ct.state == ConfigTabSearch && ct.search != nil {
+			return ct.updateConfigSearch(msg)
+		}
+
+		// 代理列表中的标签表单需要接收表单内部消息
+		if ct.state == ConfigTabProxyList && ct.proxyList != nil && ct.proxyList.labelForm != nil {
+			return ct.updateProxyLabelForm(msg)
+		}
+
+		// 代理向导需要接收表单内部消息
+		if ct.wizard != nil {
+			cmd := ct.wizard.Update(msg)
+			if ct.wizard.IsCompleted() {
+				return ct.finishProxyWizard()
 			}
 			return ct, cmd
 		}
+
+		// 表单模式下，将所有其他消息传递给表单处理
+		if ct.currentForm != nil {
+			return ct, ct.updateCurrentForm(msg)
+		}
 	}
 
 	return ct, nil
 }
 
+// updateCurrentForm 将消息传递给当前表单，表单提交时记录修改历史
+func (ct *ConfigTab) updateCurrentForm(msg tea.Msg) tea.Cmd {
+	wasCompleted := ct.currentForm.IsCompleted()
+
+	form, cmd := ct.currentForm.Update(msg)
+	if f, ok := form.(*ConfigFormModel); ok {
+		ct.currentForm = f
+	}
+
+	if !wasCompleted && ct.currentForm.IsCompleted() {
+		switch ct.currentForm.formType {
+		case ServerConfigForm:
+			ct.recordHistory(i18n.T("编辑服务端配置"))
+		case ClientConfigForm:
+			ct.recordHistory(i18n.T("编辑客户端配置"))
+		case ProxyConfigForm:
+			if ct.clientConfig == nil {
+				ct.clientConfig = config.CreateDefaultClientConfig()
+			}
+			proxy := ct.currentForm.GetProxyConfig()
+			if ct.editingProxy != "" {
+				return tea.Batch(cmd, ct.finishEditProxy(proxy.Clone()))
+			}
+			ct.clientConfig.Proxies = append(ct.clientConfig.Proxies, proxy.Clone())
+			ct.recordHistory(i18n.T("编辑代理 ") + proxy.Name)
+		case VisitorConfigForm:
+			if ct.clientConfig == nil {
+				ct.clientConfig = config.CreateDefaultClientConfig()
+			}
+			visitor := ct.currentForm.GetVisitorConfig()
+			ct.clientConfig.Visitors = append(ct.clientConfig.Visitors, *visitor)
+			ct.recordHistory(i18n.T("编辑访问者 ") + visitor.Name)
+		}
+	}
+
+	return cmd
+}
+
 // handleMenuSelection 处理菜单选择
 func (ct *ConfigTab) handleMenuSelection() (Tab, tea.Cmd) {
 	switch ct.selectedItem {
@@ -182,6 +514,57 @@ func (ct *ConfigTab) handleMenuSelection() (Tab, tea.Cmd) {
 
 	case 6: // 💾 保存配置
 		return ct.handleSaveAllConfigs()
+
+	case 7: // 📥 导入INI配置
+		return ct.handleImportINI()
+
+	case 8: // 🔄 应用并重载客户端
+		return ct.handleApplyClientConfig()
+
+	case 9: // 🧙 代理向导
+		return ct.handleProxyWizard()
+
+	case 10: // 🕘 从备份恢复
+		return ct.handleRestoreBackup()
+
+	case 11: // 📜 修改历史
+		return ct.handleShowHistory()
+
+	case 12: // 📋 配置模板
+		return ct.handleTemplates()
+
+	case 13: // 📦 导出部署包
+		return ct.handleExportBundle()
+
+	case 14: // 📱 分享/导入配置
+		return ct.handleShareCode()
+
+	case 15: // 🧪 验证(frp verify)
+		return ct.handleFrpVerify()
+
+	case 16: // 📑 代理列表
+		return ct.handleProxyList()
+
+	case 17: // 🩺 配置诊断
+		return ct.handleDiagnose()
+
+	case 18: // 🔐 STCP/XTCP 配对
+		return ct.handlePairing()
+
+	case 19: // 🌐 从服务端导入代理
+		return ct.handleServerImport()
+
+	case 20: // 🔑 SSH 隧道命令
+		return ct.handleSSHTunnel()
+
+	case 21: // 🔍 发现本机服务
+		return ct.handleLocalDiscovery()
+
+	case 22: // 🕳️ XTCP 打洞诊断
+		return ct.handleNATCheck()
+
+	case 23: // 🔁 轮换认证令牌
+		return ct.handleTokenRotation()
 	}
 
 	return ct, nil
@@ -215,12 +598,42 @@ func (ct *ConfigTab) handleAddProxy() (Tab, tea.Cmd) {
 		Type:    "tcp",
 		LocalIP: "127.0.0.1",
 	}
+	ct.editingProxy = ""
 	ct.currentForm = NewProxyConfigForm(ct.currentProxy)
+	ct.currentForm.SetTakenNames(ct.proxyNames(""))
 	ct.state = ConfigTabProxyForm
 	ct.focusOnForm = true
 	return ct, ct.currentForm.Init()
 }
 
+// handleProxyWizard 打开新建代理向导
+func (ct *ConfigTab) handleProxyWizard() (Tab, tea.Cmd) {
+	if ct.clientConfig == nil {
+		ct.clientConfig = config.CreateDefaultClientConfig()
+	}
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	ct.wizard = NewProxyWizard(ct.clientConfig)
+	ct.state = ConfigTabProxyWizard
+	return ct, ct.wizard.Init()
+}
+
+// finishProxyWizard 将向导生成的代理追加到客户端配置并保存
+func (ct *ConfigTab) finishProxyWizard() (Tab, tea.Cmd) {
+	proxy := ct.wizard.Proxy()
+	ct.wizard = nil
+	ct.state = ConfigTabMenu
+
+	ct.clientConfig.Proxies = append(ct.clientConfig.Proxies, *proxy)
+	ct.recordHistory(i18n.T("向导添加代理 ") + proxy.Name)
+	if err := config.NewLoader(ct.clientConfigPath).Save(ct.clientConfig); err != nil {
+		return ct, showStatusMessage(i18n.Sprintf("代理 %s 已添加，但保存配置失败: %v", proxy.Name, err), true)
+	}
+	ct.events.Publish(service.ConfigSavedEvent("client", ct.clientConfigPath))
+
+	return ct, showStatusMessage(i18n.Sprintf("✅ 已添加代理 %s 并保存到 %s，按 r 应用并重载客户端", proxy.Name, ct.clientConfigPath), false)
+}
+
 // handleAddVisitor 处理添加访问者
 func (ct *ConfigTab) handleAddVisitor() (Tab, tea.Cmd) {
 	ct.currentVisitor = &config.VisitorConfig{
@@ -233,43 +646,143 @@ func (ct *ConfigTab) handleAddVisitor() (Tab, tea.Cmd) {
 	return ct, ct.currentForm.Init()
 }
 
-// handleChangeConfigFile 处理更换配置文件
-func (ct *ConfigTab) handleChangeConfigFile() (Tab, tea.Cmd) {
-	// 显示配置文件选择菜单
-	ct.state = ConfigTabMenu
-	// 这里可以扩展为一个子菜单，让用户选择是更换服务端还是客户端配置文件
-	ct.filePicker = NewFilePicker("选择配置文件", FilePickerModeFile)
-	ct.filePicker.SetExtensions([]string{".yaml", ".yml", ".toml", ".ini"})
-	ct.filePicker.SetStartPath(config.GetDefaultWorkDir())
-	ct.filePicker.SetSize(ct.width, ct.height)
-	return ct, ct.filePicker.Show()
+// runValidation 校验当前配置，并实时探测需要监听的端口是否已被占用
+func (ct *ConfigTab) runValidation() {
+	validator := config.NewValidator()
+	validator.SetLiveCheck(true)
+
+	ct.validationErrors = nil
+	ct.portWarnings = nil
+	ct.schemaWarnings = nil
+
+	for _, item := range []struct {
+		label  string
+		cfg    *config.Config
+		path   string
+		server bool
+	}{
+		{i18n.T("服务端"), ct.serverConfig, ct.serverConfigPath, true},
+		{i18n.T("客户端"), ct.clientConfig, ct.clientConfigPath, false},
+	} {
+		if item.cfg == nil {
+			continue
+		}
+		for _, e := range validator.ValidateConfigDetailed(item.cfg) {
+			ct.validationErrors = append(ct.validationErrors, item.label+": "+e)
+		}
+		for _, w := range validator.CheckPortAvailability(item.cfg) {
+			ct.portWarnings = append(ct.portWarnings, item.label+": "+w)
+		}
+		ct.schemaWarnings = append(ct.schemaWarnings, schemaCheckDryRun(item.label, item.cfg, item.path, item.server)...)
+	}
 }
 
-// handlePreviewConfig 处理预览配置
-func (ct *ConfigTab) handlePreviewConfig() (Tab, tea.Cmd) {
-	ct.state = ConfigTabPreview
-	ct.focusOnForm = false
-	return ct, nil
+// schemaCheckDryRun 生成保存时将写入的文件内容（不写入磁盘），按 frp 的配置结构检查字段名和类型
+func schemaCheckDryRun(label string, cfg *config.Config, path string, server bool) []string {
+	if config.DetectFormat(path) == config.FormatINI {
+		return nil
+	}
+
+	files, err := config.NewLoader(path).DryRun(cfg)
+	if err != nil {
+		return []string{label + ": " + err.Error()}
+	}
+
+	var warnings []string
+	for _, file := range files {
+		issues, err := config.CheckSchema(file.Data, config.DetectFormat(strings.TrimSuffix(file.Path, ".disabled")), server)
+		if err != nil {
+			warnings = append(warnings, label+": "+err.Error())
+			continue
+		}
+		prefix := label + ": "
+		if file.Path != path {
+			prefix = label + " " + filepath.Base(file.Path) + ": "
+		}
+		for _, issue := range issues {
+			warnings = append(warnings, prefix+issue.String())
+		}
+	}
+	return warnings
 }
 
 // handleSaveAllConfigs 处理保存所有配置
 func (ct *ConfigTab) handleSaveAllConfigs() (Tab, tea.Cmd) {
-	// 自动保存到当前设置的配置文件路径
-	if ct.serverConfig != nil {
-		loader := config.NewLoader(ct.serverConfigPath)
-		if err := loader.Save(ct.serverConfig); err == nil {
-			// 保存成功
+	// 自动保存到当前设置的配置文件路径，每个文件的结果单独通知
+	var cmds []tea.Cmd
+	for _, item := range []struct {
+		role string
+		path string
+		cfg  *config.Config
+	}{
+		{"server", ct.serverConfigPath, ct.serverConfig},
+		{"client", ct.clientConfigPath, ct.clientConfig},
+	} {
+		if item.cfg == nil {
+			continue
 		}
+		if err := config.NewLoader(item.path).Save(item.cfg); err != nil {
+			cmds = append(cmds, showStatusMessage(i18n.Sprintf("❌ 保存%s失败: %v", configRoleLabel(item.role), err), true))
+			continue
+		}
+		ct.events.Publish(service.ConfigSavedEvent(item.role, item.path))
+		cmds = append(cmds, showStatusMessage(i18n.Sprintf("✅ %s已保存到 %s", configRoleLabel(item.role), item.path), false))
 	}
 
-	if ct.clientConfig != nil {
-		loader := config.NewLoader(ct.clientConfigPath)
-		if err := loader.Save(ct.clientConfig); err == nil {
-			// 保存成功
+	if len(cmds) == 0 {
+		return ct, showStatusMessage(i18n.T("⚠️ 没有已加载的配置可保存"), false)
+	}
+	return ct, tea.Batch(cmds...)
+}
+
+// handleApplyClientConfig 校验并保存客户端配置，然后让运行中的客户端生效，进度显示在状态栏
+func (ct *ConfigTab) handleApplyClientConfig() (Tab, tea.Cmd) {
+	if ct.clientConfig == nil {
+		return ct, showStatusMessage(i18n.T("❌ 尚未编辑客户端配置"), true)
+	}
+	if ct.manager == nil {
+		return ct, showStatusMessage(i18n.T("❌ 进程管理器不可用"), true)
+	}
+
+	cfg := ct.clientConfig
+	path := ct.clientConfigPath
+	manager := ct.manager
+	events := make(chan statusMessageMsg, 8)
+
+	go func() {
+		defer close(events)
+		result, err := manager.ApplyClientConfig(cfg, path, func(step string) {
+			events <- statusMessageMsg{text: "⏳ " + step}
+		})
+		if err != nil {
+			events <- statusMessageMsg{text: "❌ " + err.Error(), isError: true}
+			return
 		}
+		events <- statusMessageMsg{text: "✅ " + result}
+	}()
+
+	return ct, waitForStatusMessage(events)
+}
+
+// handleTestConnection 使用当前客户端配置测试与服务端的连接和认证
+func (ct *ConfigTab) handleTestConnection() (Tab, tea.Cmd) {
+	if ct.clientConfig == nil {
+		return ct, showStatusMessage(i18n.T("❌ 尚未编辑客户端配置"), true)
+	}
+	if ct.manager == nil {
+		return ct, showStatusMessage(i18n.T("❌ 进程管理器不可用"), true)
 	}
 
-	return ct, nil
+	cfg := ct.clientConfig
+	manager := ct.manager
+
+	return ct, runOperation(i18n.Sprintf("正在测试连接 %s:%d", cfg.ServerAddr, cfg.ServerPort), func() tea.Msg {
+		result := manager.TestConnection(cfg, service.ConnectionTestOptionsFor(cfg))
+		if result.Success() {
+			return statusMessageMsg{text: "✅ " + result.Summary()}
+		}
+		return statusMessageMsg{text: "❌ " + result.Summary(), isError: true}
+	})
 }
 
 // handleFilePickerResult 处理文件选择器结果
@@ -278,25 +791,14 @@ func (ct *ConfigTab) handleFilePickerResult(result FilePickerResult) (Tab, tea.C
 		return ct, nil
 	}
 
-	// 根据当前选择的菜单项确定是服务端还是客户端配置文件
-	switch ct.selectedItem {
-	case 4: // 选择服务端配置文件
-		ct.serverConfigPath = result.Path
-		// 自动加载选择的服务端配置
-		if loader := config.NewLoader(result.Path); loader != nil {
-			if cfg, err := loader.Load(); err == nil {
-				ct.serverConfig = cfg
-			}
-		}
+	// 选择配置文件的子菜单中已经选择了用途
+	if ct.state == ConfigTabFileChoice && ct.fileChoice != nil {
+		return ct.handleConfigFileChosen(result)
+	}
 
-	case 5: // 选择客户端配置文件
-		ct.clientConfigPath = result.Path
-		// 自动加载选择的客户端配置
-		if loader := config.NewLoader(result.Path); loader != nil {
-			if cfg, err := loader.Load(); err == nil {
-				ct.clientConfig = cfg
-			}
-		}
+	switch ct.selectedItem {
+	case 7: // 选择待迁移的 INI 文件
+		return ct.startINIMigration(result.Path)
 	}
 
 	return ct, nil
@@ -319,6 +821,9 @@ func (ct *ConfigTab) loadConfigFile() (Tab, tea.Cmd) {
 		}
 	}
 
+	// 配置已从文件重新加载，之前的历史不再适用
+	ct.history.reset(i18n.T("加载配置文件"), ct.serverConfig, ct.clientConfig)
+
 	return ct, nil
 }
 
@@ -328,21 +833,47 @@ func (ct *ConfigTab) saveConfigFile() (Tab, tea.Cmd) {
 	os.MkdirAll(configDir, 0755)
 
 	if ct.serverConfig != nil {
-		loader := config.NewLoader(filepath.Join(configDir, "frps.yaml"))
-		loader.Save(ct.serverConfig)
+		path := filepath.Join(configDir, "frps.yaml")
+		if err := config.NewLoader(path).Save(ct.serverConfig); err == nil {
+			ct.events.Publish(service.ConfigSavedEvent("server", path))
+		}
 	}
 
 	if ct.clientConfig != nil {
-		loader := config.NewLoader(filepath.Join(configDir, "frpc.yaml"))
-		loader.Save(ct.clientConfig)
+		path := filepath.Join(configDir, "frpc.yaml")
+		if err := config.NewLoader(path).Save(ct.clientConfig); err == nil {
+			ct.events.Publish(service.ConfigSavedEvent("client", path))
+		}
 	}
 
 	return ct, nil
 }
 
+// SetAppSettings 应用设置中的配置文件路径变化时切换并重新加载配置
+func (ct *ConfigTab) SetAppSettings(settings *config.AppSettings) {
+	changed := false
+	if ct.appSettings == nil || ct.appSettings.ServerConfigPath != settings.ServerConfigPath {
+		ct.serverConfigPath = settings.ServerConfigPath
+		changed = true
+	}
+	if ct.appSettings == nil || ct.appSettings.ClientConfigPath != settings.ClientConfigPath {
+		ct.clientConfigPath = settings.ClientConfigPath
+		changed = true
+	}
+	ct.appSettings = settings
+
+	if changed {
+		ct.loadConfigFile()
+	}
+}
+
 // IsInFormMode 检查是否处于表单编辑模式
 func (ct *ConfigTab) IsInFormMode() bool {
-	return ct.focusOnForm && ct.currentForm != nil
+	return (ct.focusOnForm && ct.currentForm != nil) || ct.wizard != nil || ct.templates != nil ||
+		ct.bundle != nil || ct.share != nil || ct.pairing != nil || (ct.filePicker != nil && ct.filePicker.IsVisible()) ||
+		(ct.sshTunnel != nil && ct.sshTunnel.form != nil) ||
+		(ct.proxyList != nil && (ct.proxyList.labelForm != nil || ct.proxyList.confirmDelete)) || ct.search != nil ||
+		(ct.rotation != nil && (ct.rotation.phase == rotationReview || ct.rotation.phase == rotationRunning)) || ct.natCheck != nil
 }
 
 // View 渲染视图 - 新的左右分栏布局
@@ -402,6 +933,16 @@ func (ct *ConfigTab) View(width int, height int) string {
 	// 渲染左侧菜单
 	leftContent := ct.renderLeftMenu()
 
+	// 预览视口占满右侧剩余高度：扣除边框、内边距、标题和底部提示
+	if ct.state == ConfigTabPreview && ct.preview != nil {
+		ct.preview.SetSize(rightWidth-2, availableHeight-10)
+	}
+	// 代理列表同样扣除标题以及底部的统计和操作提示
+	if ct.state == ConfigTabProxyList && ct.proxyList != nil {
+		ct.syncProxyList()
+		ct.proxyList.setSize(rightWidth-2, availableHeight-14)
+	}
+
 	// 渲染右侧内容
 	rightContent := ct.renderRightContent(rightWidth - 2)
 
@@ -429,7 +970,7 @@ func (ct *ConfigTab) renderLeftMenu() string {
 		Padding(0, 1)
 
 	var content string
-	content += titleStyle.Render("📁 配置类型") + "\n"
+	content += titleStyle.Render(i18n.T("📁 配置类型")) + "\n"
 
 	for i, item := range ct.menuItems {
 		style := normalStyle
@@ -438,54 +979,140 @@ func (ct *ConfigTab) renderLeftMenu() string {
 			style = selectedStyle
 			prefix = "▶ "
 		}
-		content += fmt.Sprintf("%s%s\n", prefix, style.Render(item))
+		content += fmt.Sprintf("%s%s\n", prefix, style.Render(i18n.T(item)))
 	}
 
-	content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("当前配置文件:") + "\n"
+	content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.T("当前配置文件:")) + "\n"
 
-	// 显示配置文件路径（完整路径）
-	if _, err := os.Stat(ct.serverConfigPath); err == nil {
-		content += fmt.Sprintf("📄 服务端: %s\n", ct.serverConfigPath)
+	// 显示配置文件路径（完整路径）和文件头部记录的最后修改人
+	metaStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	if info, err := os.Stat(ct.serverConfigPath); err == nil {
+		content += i18n.Sprintf("📄 服务端: %s\n", ct.serverConfigPath)
+		if meta := ct.serverMeta.get(ct.serverConfigPath, info); meta != nil {
+			content += metaStyle.Render("   └ "+meta.Summary()) + "\n"
+		}
 	} else {
-		content += fmt.Sprintf("❌ 服务端: %s (不存在)\n", ct.serverConfigPath)
+		content += i18n.Sprintf("❌ 服务端: %s (不存在)\n", ct.serverConfigPath)
 	}
 
-	if _, err := os.Stat(ct.clientConfigPath); err == nil {
-		content += fmt.Sprintf("📄 客户端: %s\n", ct.clientConfigPath)
+	if info, err := os.Stat(ct.clientConfigPath); err == nil {
+		content += i18n.Sprintf("📄 客户端: %s\n", ct.clientConfigPath)
+		if meta := ct.clientMeta.get(ct.clientConfigPath, info); meta != nil {
+			content += metaStyle.Render("   └ "+meta.Summary()) + "\n"
+		}
 	} else {
-		content += fmt.Sprintf("❌ 客户端: %s (不存在)\n", ct.clientConfigPath)
+		content += i18n.Sprintf("❌ 客户端: %s (不存在)\n", ct.clientConfigPath)
 	}
 
-	content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("配置状态:") + "\n"
+	content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.T("配置状态:")) + "\n"
 
 	// 显示配置状态
 	if ct.serverConfig != nil {
-		content += fmt.Sprintf("✓ 服务端: 端口 %d\n", ct.serverConfig.BindPort)
+		content += i18n.Sprintf("✓ 服务端: 端口 %d\n", ct.serverConfig.BindPort)
 	} else {
-		content += "✗ 服务端: 未加载\n"
+		content += i18n.T("✗ 服务端: 未加载\n")
 	}
 
 	if ct.clientConfig != nil {
-		content += fmt.Sprintf("✓ 客户端: %s:%d\n", ct.clientConfig.ServerAddr, ct.clientConfig.ServerPort)
+		content += i18n.Sprintf("✓ 客户端: %s:%d\n", ct.clientConfig.ServerAddr, ct.clientConfig.ServerPort)
 		if len(ct.clientConfig.Proxies) > 0 {
-			content += fmt.Sprintf("  └ 代理: %d个\n", len(ct.clientConfig.Proxies))
+			content += i18n.Sprintf("  └ 代理: %d个\n", len(ct.clientConfig.Proxies))
 		}
 	} else {
-		content += "✗ 客户端: 未加载\n"
+		content += i18n.T("✗ 客户端: 未加载\n")
 	}
 
-	content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("操作提示:") + "\n"
-	content += "↑/↓ 选择菜单\n"
-	content += "Enter 确认选择\n"
-	content += "Tab 激活表单\n"
-	content += "ESC 退出表单"
+	content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.T("操作提示:")) + "\n"
+	content += ct.keys.Config.Up.Help().Key + "/" + ct.keys.Config.Down.Help().Key + i18n.T(" 选择菜单\n")
+	content += ct.keys.Config.Select.Help().Key + i18n.T(" 确认选择\n")
+	content += i18n.T("Tab 激活表单\n")
+	content += i18n.T("ESC 退出表单\n")
+	content += i18n.Sprintf("%s 搜索代理、端口、域名\n", ct.keys.Config.Search.Help().Key)
+	content += i18n.Sprintf("%s 撤销 (%d) | %s 重做 (%d)", ct.keys.Config.Undo.Help().Key, ct.history.cursor,
+		ct.keys.Config.Redo.Help().Key, len(ct.history.entries)-1-ct.history.cursor)
 
 	return content
 }
 
 // renderRightContent 渲染右侧内容
 func (ct *ConfigTab) renderRightContent(width int) string {
-	if ct.currentForm != nil {
+	if ct.state == ConfigTabMigration && ct.migration != nil {
+		return ct.renderMigration(width)
+	}
+
+	if ct.state == ConfigTabBackups && ct.backups != nil {
+		return ct.renderBackupBrowser(width)
+	}
+
+	if ct.state == ConfigTabHistory {
+		return ct.renderHistory()
+	}
+
+	if ct.state == ConfigTabTemplates && ct.templates != nil {
+		return ct.renderTemplateBrowser(width)
+	}
+
+	if ct.state == ConfigTabExport && ct.bundle != nil {
+		return ct.renderBundleExport(width)
+	}
+
+	if ct.state == ConfigTabShare && ct.share != nil {
+		return ct.renderShareCode(width)
+	}
+
+	if ct.state == ConfigTabProxyList && ct.proxyList != nil {
+		return ct.renderProxyList()
+	}
+
+	if ct.state == ConfigTabDiagnose && ct.diagnosis != nil {
+		return ct.renderDiagnosis(width)
+	}
+
+	if ct.state == ConfigTabPairing && ct.pairing != nil {
+		return ct.renderPairing(width)
+	}
+
+	if ct.state == ConfigTabServerImport && ct.serverImport != nil {
+		return ct.renderServerImport()
+	}
+
+	if ct.state == ConfigTabSSHTunnel && ct.sshTunnel != nil {
+		return ct.renderSSHTunnel(width)
+	}
+
+	if ct.state == ConfigTabDiscover && ct.discovery != nil {
+		return ct.renderLocalDiscovery()
+	}
+
+	if ct.state == ConfigTabNATCheck && ct.natCheck != nil {
+		return ct.renderNATCheck()
+	}
+
+	if ct.state == ConfigTabTokenRotation && ct.rotation != nil {
+		return ct.renderTokenRotation()
+	}
+
+	if ct.state == ConfigTabSearch && ct.search != nil {
+		return ct.renderConfigSearch()
+	}
+
+	if ct.state == ConfigTabFileChoice && ct.fileChoice != nil {
+		return ct.renderFileChoice()
+	}
+
+	if ct.state == ConfigTabProxyWizard && ct.wizard != nil {
+		titleStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#7D56F4")).
+			Padding(0, 0, 1, 0)
+
+		content := titleStyle.Render(i18n.T("🧙 代理向导")) + "\n\n"
+		content += ct.wizard.View()
+		content += "\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.T("Enter 下一步 | ESC 取消向导"))
+		return content
+	}
+
+	if ct.currentForm != nil || ct.state == ConfigTabPreview {
 		// 显示表单
 		titleStyle := lipgloss.NewStyle().
 			Bold(true).
@@ -495,15 +1122,15 @@ func (ct *ConfigTab) renderRightContent(width int) string {
 		var title string
 		switch ct.state {
 		case ConfigTabServerForm:
-			title = "🎯 服务端"
+			title = i18n.T("🎯 服务端")
 		case ConfigTabClientForm:
-			title = "💻 客户端"
+			title = i18n.T("💻 客户端")
 		case ConfigTabProxyForm:
-			title = "🔗 代理"
+			title = i18n.T("🔗 代理")
 		case ConfigTabVisitorForm:
-			title = "👥 访问者"
+			title = i18n.T("👥 访问者")
 		case ConfigTabPreview:
-			title = "👁️ 配置预览"
+			title = i18n.T("👁️ 配置预览")
 		}
 
 		content := titleStyle.Render(title) + "\n\n"
@@ -514,12 +1141,14 @@ func (ct *ConfigTab) renderRightContent(width int) string {
 		} else {
 			// 显示表单
 			content += ct.currentForm.View()
+			content += ct.renderFormNotice()
 
 			// 添加表单操作提示
 			if ct.focusOnForm {
-				content += "\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("表单操作: Tab/Shift+Tab 切换字段 | ESC 退出编辑 | Ctrl+Tab 回到菜单")
+				content += "\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.T("表单操作: Tab/Shift+Tab 切换字段 | ESC 退出编辑 | Ctrl+Tab 回到菜单"))
+				content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.Sprintf("%s 在令牌/密钥输入框中生成随机值", ct.keys.Config.GenerateToken.Help().Key))
 			} else {
-				content += "\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("按 Tab 键激活表单编辑")
+				content += "\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.T("按 Tab 键激活表单编辑"))
 			}
 		}
 
@@ -532,89 +1161,85 @@ func (ct *ConfigTab) renderRightContent(width int) string {
 		Foreground(lipgloss.Color("#7D56F4")).
 		Padding(0, 0, 1, 0)
 
-	content := titleStyle.Render("📋 FRP 配置管理") + "\n\n"
+	content := titleStyle.Render(i18n.T("📋 FRP 配置管理")) + "\n\n"
 
 	// 显示当前配置状态
-	content += lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render("📊 配置状态") + "\n\n"
+	content += lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render(i18n.T("📊 配置状态")) + "\n\n"
 
 	if ct.serverConfig != nil {
-		content += fmt.Sprintf("✓ 服务端: 端口 %d", ct.serverConfig.BindPort)
-		if ct.serverConfig.Token != "" {
-			content += " (已设置认证)"
+		content += i18n.Sprintf("✓ 服务端: 端口 %d", ct.serverConfig.BindPort)
+		if ct.serverConfig.Auth.Token != "" {
+			content += i18n.T(" (已设置认证)")
 		}
 		content += "\n"
 	} else {
-		content += "○ 服务端: 未配置\n"
+		content += i18n.T("○ 服务端: 未配置\n")
 	}
 
 	if ct.clientConfig != nil {
-		content += fmt.Sprintf("✓ 客户端: %s:%d", ct.clientConfig.ServerAddr, ct.clientConfig.ServerPort)
+		content += i18n.Sprintf("✓ 客户端: %s:%d", ct.clientConfig.ServerAddr, ct.clientConfig.ServerPort)
 		if len(ct.clientConfig.Proxies) > 0 {
-			content += fmt.Sprintf(" (%d个代理)", len(ct.clientConfig.Proxies))
+			content += i18n.Sprintf(" (%d个代理)", len(ct.clientConfig.Proxies))
 		}
 		content += "\n"
 	} else {
-		content += "○ 客户端: 未配置\n"
+		content += i18n.T("○ 客户端: 未配置\n")
 	}
 
-	content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render("📚 功能说明") + "\n\n"
-	content += "• 🎯 服务端配置: 配置FRP服务端参数\n"
-	content += "• 💻 客户端配置: 配置客户端连接信息\n"
-	content += "• 🔗 添加代理: 添加端口转发规则\n"
-	content += "• 👥 添加访问者: 添加P2P连接配置\n"
-	content += "• 📁 选择配置文件: 选择不同的配置文件\n"
-	content += "• 👀 预览配置: 查看当前配置的YAML内容\n"
-	content += "• 💾 保存配置: 保存当前配置到文件\n\n"
-
-	content += lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Bold(true).Render("💡 操作提示") + "\n\n"
-	content += "• 修改配置后需要手动保存\n"
-	content += "• 代理配置属于客户端配置的一部分\n"
-	content += "• 可以同时配置多个代理规则"
+	content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render(i18n.T("📚 功能说明")) + "\n\n"
+	content += i18n.T("• 🎯 服务端配置: 配置FRP服务端参数\n")
+	content += i18n.T("• 💻 客户端配置: 配置客户端连接信息\n")
+	content += i18n.T("• 🔗 添加代理: 添加端口转发规则\n")
+	content += i18n.T("• 👥 添加访问者: 添加P2P连接配置\n")
+	content += i18n.T("• 📁 选择配置文件: 选择不同的配置文件\n")
+	content += i18n.T("• 👀 预览配置: 带语法高亮和行号滚动查看配置内容，可切换 YAML/TOML\n")
+	content += i18n.T("• 💾 保存配置: 保存当前配置到文件\n")
+	content += i18n.T("• 📥 导入INI配置: 将旧版 frpc.ini/frps.ini 迁移为新格式\n")
+	content += i18n.Sprintf("• 🔄 应用并重载客户端: 校验并保存客户端配置后热重载 frpc (快捷键 %s)\n", ct.keys.Config.Apply.Help().Key)
+	content += i18n.Sprintf("• 🔌 测试连接: 按客户端配置连接服务端并验证 token (快捷键 %s)\n", ct.keys.Config.Test.Help().Key)
+	content += i18n.Sprintf("• 🧙 代理向导: 选择 SSH、网站、远程桌面、数据库等常见服务，自动填好端口 (快捷键 %s)\n", ct.keys.Config.Wizard.Help().Key)
+	content += i18n.Sprintf("• 🕘 从备份恢复: 每次保存都会自动备份旧配置，可预览差异后恢复 (快捷键 %s)\n", ct.keys.Config.Backups.Help().Key)
+	content += i18n.Sprintf("• 📜 修改历史: 查看每次修改的时间和内容，%s 撤销、%s 重做 (快捷键 %s)\n",
+		ct.keys.Config.Undo.Help().Key, ct.keys.Config.Redo.Help().Key, ct.keys.Config.History.Help().Key)
+	content += i18n.Sprintf("• 📋 配置模板: 应用或合并内置/自定义模板，可将当前配置保存为模板 (快捷键 %s)\n", ct.keys.Config.Templates.Help().Key)
+	content += i18n.T("• 📦 导出部署包: 将配置、启动脚本、系统服务定义和可选的 frp 程序打包，复制到目标机器即可部署\n")
+	content += i18n.T("• 📱 分享/导入配置: 将服务端地址、token 和一个代理编码为分享码和终端二维码，或粘贴分享码导入\n")
+	content += i18n.Sprintf("• 🧪 验证(frp verify): 用已安装的 frps/frpc 检查配置文件，发现本工具尚未校验的字段 (快捷键 %s)\n", ct.keys.Config.Verify.Help().Key)
+	content += i18n.Sprintf("• 📑 代理列表: 临时停用/重新启用代理而不丢失配置，或以已有代理为基础新建代理 (快捷键 %s)\n", ct.keys.Config.Proxies.Help().Key)
+	content += i18n.Sprintf("• 🩺 配置诊断: 交叉检查服务端和所有客户端配置，发现远程端口冲突、代理重名和 allowPorts 问题 (快捷键 %s)\n", ct.keys.Config.Diagnose.Help().Key)
+	content += i18n.Sprintf("• 🔐 STCP/XTCP 配对: 一次生成密钥相同的代理和访问者，导出访问者配置给另一台机器，导入时校验密钥 (快捷键 %s)\n", ct.keys.Config.Pairing.Help().Key)
+	content += i18n.Sprintf("• 🔍 发现本机服务: 扫描本机（可选局域网）正在监听的端口并识别常见服务，Enter 为选中的服务预填代理 (快捷键 %s)\n", ct.keys.Config.Discover.Help().Key)
+	content += i18n.Sprintf("• 🕳️ XTCP 打洞诊断: 通过 STUN 检测 NAT 类型和打洞成功的可能性，可通过 UPnP/NAT-PMP 在路由器上添加端口映射 (快捷键 %s)\n", ct.keys.Config.NATCheck.Help().Key)
+	content += i18n.Sprintf("• 🔁 轮换认证令牌: 生成新令牌，先更新并重启服务端，再更新仍使用旧令牌的客户端，清单逐台显示进度 (快捷键 %s)\n\n", ct.keys.Config.RotateToken.Help().Key)
+
+	content += lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Bold(true).Render(i18n.T("💡 操作提示")) + "\n\n"
+	content += i18n.T("• 修改配置后需要手动保存，保存前会自动备份\n")
+	content += i18n.T("• 代理配置属于客户端配置的一部分\n")
+	content += i18n.T("• 可以同时配置多个代理规则\n")
+	content += i18n.Sprintf("• 代理较多时按 %s 搜索代理名、域名、端口、插件参数和访问者名，Enter 跳转到匹配项", ct.keys.Config.Search.Help().Key)
 
 	return content
 }
 
-// renderConfigPreview 渲染配置预览
-func (ct *ConfigTab) renderConfigPreview() string {
-	var content string
+// renderValidationResult 渲染校验结果与端口占用警告
+func (ct *ConfigTab) renderValidationResult() string {
+	content := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39")).Render(i18n.T("🔍 启动前检查:")) + "\n"
 
-	// 服务端配置预览
-	content += lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("46")).Render("🎯 服务端配置文件内容:") + "\n\n"
-
-	if ct.serverConfig != nil {
-		data, err := yaml.Marshal(ct.serverConfig)
-		if err == nil {
-			content += lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("240")).
-				Padding(1).
-				Render(string(data)) + "\n\n"
-		} else {
-			content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("错误: "+err.Error()) + "\n\n"
-		}
-	} else {
-		content += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("服务端配置为空") + "\n\n"
+	if len(ct.validationErrors) == 0 && len(ct.portWarnings) == 0 && len(ct.schemaWarnings) == 0 {
+		return content + lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render(i18n.T("✅ 配置有效，端口均可用，字段均符合 frp 配置结构")) + "\n" + ct.renderFrpVerify() + ct.renderDNSCheck()
 	}
 
-	// 客户端配置预览
-	content += lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("81")).Render("💻 客户端配置文件内容:") + "\n\n"
-
-	if ct.clientConfig != nil {
-		data, err := yaml.Marshal(ct.clientConfig)
-		if err == nil {
-			content += lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("240")).
-				Padding(1).
-				Render(string(data)) + "\n\n"
-		} else {
-			content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("错误: "+err.Error()) + "\n\n"
-		}
-	} else {
-		content += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("客户端配置为空") + "\n\n"
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
+	for _, e := range ct.validationErrors {
+		content += errorStyle.Render("❌ "+e) + "\n"
+	}
+	for _, w := range ct.portWarnings {
+		content += warnStyle.Render("⚠️ "+w) + "\n"
+	}
+	for _, w := range ct.schemaWarnings {
+		content += warnStyle.Render("📐 "+w) + "\n"
 	}
 
-	content += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("按 ESC 返回菜单")
-
-	return content
+	return content + ct.renderFrpVerify() + ct.renderDNSCheck()
 }
@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// crashLogLimit 崩溃报告中保留的最近日志条数
+const crashLogLimit = 500
+
+// GetCrashDir 获取崩溃报告目录
+func GetCrashDir() string {
+	return filepath.Join(config.GetDefaultWorkDir(), "crash")
+}
+
+// crashInfo 界面崩溃时记录的 panic 值和调用栈
+type crashInfo struct {
+	value any
+	stack []byte
+	time  time.Time
+}
+
+// CrashGuard 包装主控制面板，在 Update、View 和命令中发生 panic 时记录调用栈。
+// 记录后继续向上抛出，由 Bubble Tea 恢复终端并结束程序，退出后再调用 WriteReport 生成调试报告
+type CrashGuard struct {
+	dashboard *MainDashboard
+
+	mu    sync.Mutex
+	crash *crashInfo
+}
+
+// NewCrashGuard 创建崩溃保护
+func NewCrashGuard(dashboard *MainDashboard) *CrashGuard {
+	return &CrashGuard{dashboard: dashboard}
+}
+
+// Init 初始化
+func (g *CrashGuard) Init() tea.Cmd {
+	defer g.recordPanic()
+	return g.wrapCmd(g.dashboard.Init())
+}
+
+// Update 转发消息给主控制面板
+func (g *CrashGuard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer g.recordPanic()
+	_, cmd := g.dashboard.Update(msg)
+	return g, g.wrapCmd(cmd)
+}
+
+// View 渲染主控制面板
+func (g *CrashGuard) View() string {
+	defer g.recordPanic()
+	return g.dashboard.View()
+}
+
+// wrapCmd 让命令在 Bubble Tea 的 goroutine 中 panic 时同样被记录，批量命令逐个包装
+func (g *CrashGuard) wrapCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		defer g.recordPanic()
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			wrapped := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				wrapped[i] = g.wrapCmd(c)
+			}
+			return wrapped
+		}
+		return msg
+	}
+}
+
+// recordPanic 记录第一次 panic 后重新抛出，必须直接被 defer 调用
+func (g *CrashGuard) recordPanic() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	g.mu.Lock()
+	if g.crash == nil {
+		g.crash = &crashInfo{value: r, stack: debug.Stack(), time: time.Now()}
+	}
+	g.mu.Unlock()
+	panic(r)
+}
+
+// Crashed 界面是否因 panic 退出
+func (g *CrashGuard) Crashed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.crash != nil
+}
+
+// WriteReport 将调用栈、去掉密码的应用设置、最近日志和配置摘要写入崩溃报告目录，返回报告路径
+func (g *CrashGuard) WriteReport() (string, error) {
+	g.mu.Lock()
+	crash := g.crash
+	g.mu.Unlock()
+	if crash == nil {
+		return "", i18n.Errorf("界面没有发生崩溃")
+	}
+
+	dir := filepath.Join(GetCrashDir(), "crash-"+crash.time.Format("20060102-150405"))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", i18n.Errorf("创建崩溃报告目录失败: %w", err)
+	}
+
+	// 界面状态可能已经损坏，每一部分单独收集，某一部分失败时记录原因并继续
+	sections := []struct {
+		name    string
+		collect func() ([]byte, error)
+	}{
+		{"panic.txt", func() ([]byte, error) { return crashSummary(crash), nil }},
+		{"settings.yaml", g.dashboard.crashSettings},
+		{"logs.txt", g.dashboard.crashLogs},
+		{"configs.txt", g.dashboard.crashConfigs},
+	}
+	for _, section := range sections {
+		data, err := collectCrashSection(section.collect)
+		if err != nil {
+			data = []byte(fmt.Sprintf("failed to collect %s: %v\n", section.name, err))
+		}
+		// 日志和配置摘要中包含地址和代理名称，仅允许当前用户读写
+		if err := os.WriteFile(filepath.Join(dir, section.name), data, 0600); err != nil {
+			return dir, i18n.Errorf("写入崩溃报告失败: %w", err)
+		}
+	}
+	return dir, nil
+}
+
+// collectCrashSection 收集报告的一部分，收集过程中再次 panic 时转为错误
+func collectCrashSection(collect func() ([]byte, error)) (data []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return collect()
+}
+
+// crashSummary 生成 panic 说明和运行环境
+func crashSummary(crash *crashInfo) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s\n", config.AppName, config.AppVersion)
+	fmt.Fprintf(&b, "time: %s\n", crash.time.Format(time.RFC3339))
+	fmt.Fprintf(&b, "os: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "go: %s\n", runtime.Version())
+	fmt.Fprintf(&b, "panic: %v\n\n", crash.value)
+	b.Write(crash.stack)
+	return b.Bytes()
+}
+
+// crashSettings 返回去掉密码和 Webhook 令牌的应用设置
+func (m *MainDashboard) crashSettings() ([]byte, error) {
+	return yaml.Marshal(m.appSettings.Redacted())
+}
+
+// crashLogs 返回日志页中最近的日志，包括尚未从日志通道取出的部分
+func (m *MainDashboard) crashLogs() ([]byte, error) {
+	m.pumpLogs()
+
+	for _, tab := range m.tabRegistry.GetTabs() {
+		logsTab, ok := tab.(*LogsTab)
+		if !ok {
+			continue
+		}
+		entries := logsTab.entries.Query(service.LogQuery{Limit: crashLogLimit})
+		return encodeLogExport("logs.txt", entries)
+	}
+	return nil, nil
+}
+
+// crashConfigs 返回服务端和客户端配置的摘要，不包含 token、secretKey 等敏感内容
+func (m *MainDashboard) crashConfigs() ([]byte, error) {
+	var b bytes.Buffer
+	for _, service := range []struct {
+		name string
+		key  string
+		path string
+	}{
+		{"frps", "server", m.appSettings.ServerConfigPath},
+		{"frpc", "client", m.appSettings.ClientConfigPath},
+	} {
+		paths := []string{service.path}
+		if m.manager != nil {
+			if state := m.manager.GetProcessState(service.key); state != nil {
+				fmt.Fprintf(&b, "== %s running: pid %d, config %s\n", service.name, state.PID, state.ConfigPath)
+				if state.ConfigPath != "" && state.ConfigPath != service.path {
+					paths = append(paths, state.ConfigPath)
+				}
+			}
+		}
+		for _, path := range paths {
+			b.WriteString(summarizeConfig(service.name, path))
+		}
+		b.WriteString("\n")
+	}
+	return b.Bytes(), nil
+}
+
+// summarizeConfig 生成单个配置文件的摘要
+func summarizeConfig(name, path string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "== %s config: %s\n", name, path)
+
+	cfg, err := config.NewLoader(path).Load()
+	if err != nil {
+		fmt.Fprintf(&b, "load error: %v\n", err)
+		return b.String()
+	}
+
+	if cfg.ServerAddr != "" || cfg.ServerPort > 0 {
+		fmt.Fprintf(&b, "serverAddr: %s:%d\n", cfg.ServerAddr, cfg.ServerPort)
+	}
+	if cfg.BindPort > 0 {
+		fmt.Fprintf(&b, "bindPort: %d\n", cfg.BindPort)
+	}
+	if cfg.VhostHTTPPort > 0 || cfg.VhostHTTPSPort > 0 {
+		fmt.Fprintf(&b, "vhost: http %d, https %d\n", cfg.VhostHTTPPort, cfg.VhostHTTPSPort)
+	}
+	if cfg.WebServer.Port > 0 {
+		fmt.Fprintf(&b, "webServer: %s:%d\n", cfg.WebServer.Addr, cfg.WebServer.Port)
+	}
+	fmt.Fprintf(&b, "token set: %t\n", cfg.Auth.Token != "")
+
+	if len(cfg.Proxies) > 0 {
+		fmt.Fprintf(&b, "proxies (%d):\n", len(cfg.Proxies))
+		for _, proxy := range cfg.Proxies {
+			fmt.Fprintf(&b, "  - %s [%s] local %s:%d", proxy.Name, proxy.Type, proxy.LocalIP, proxy.LocalPort)
+			if proxy.RemotePort > 0 {
+				fmt.Fprintf(&b, " remote %d", proxy.RemotePort)
+			}
+			if len(proxy.CustomDomains) > 0 {
+				fmt.Fprintf(&b, " domains %s", strings.Join(proxy.CustomDomains, ","))
+			}
+			if proxy.Subdomain != "" {
+				fmt.Fprintf(&b, " subdomain %s", proxy.Subdomain)
+			}
+			if proxy.Disabled {
+				b.WriteString(" (disabled)")
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(cfg.Visitors) > 0 {
+		fmt.Fprintf(&b, "visitors (%d):\n", len(cfg.Visitors))
+		for _, visitor := range cfg.Visitors {
+			fmt.Fprintf(&b, "  - %s [%s] server %s bind %s:%d\n",
+				visitor.Name, visitor.Type, visitor.ServerName, visitor.BindAddr, visitor.BindPort)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// scheduleEventMsg 自动启动调度事件
+type scheduleEventMsg struct {
+	event service.ScheduleEvent
+}
+
+// waitForScheduleEvent 等待下一条调度事件
+func waitForScheduleEvent(ch <-chan service.ScheduleEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return scheduleEventMsg{event: event}
+	}
+}
+
+// SetScheduler 设置调度器，用于显示自动启动配置的状态
+func (dt *DashboardTab) SetScheduler(scheduler *service.Scheduler) {
+	dt.scheduler = scheduler
+}
+
+// autostartCount 返回自动启动配置的数量
+func (dt *DashboardTab) autostartCount() int {
+	if dt.appSettings == nil {
+		return 0
+	}
+	return len(dt.appSettings.Autostart)
+}
+
+// updateAutostart 处理自动启动列表中的按键：选择配置、切换启用状态
+func (dt *DashboardTab) updateAutostart(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	keys := dt.keys.Dashboard
+	count := dt.autostartCount()
+
+	switch {
+	case key.Matches(msg, keys.CloseDetail), key.Matches(msg, keys.Autostart), count == 0:
+		dt.autostartFocus = false
+	case key.Matches(msg, keys.Up):
+		dt.autostartCursor = (dt.autostartCursor - 1 + count) % count
+	case key.Matches(msg, keys.Down):
+		dt.autostartCursor = (dt.autostartCursor + 1) % count
+	case key.Matches(msg, keys.Toggle):
+		return dt, dt.toggleAutostart()
+	}
+	return dt, nil
+}
+
+// toggleAutostart 切换选中配置的启用状态并保存到应用设置
+func (dt *DashboardTab) toggleAutostart() tea.Cmd {
+	if dt.autostartCursor >= dt.autostartCount() {
+		return nil
+	}
+
+	settings := *dt.appSettings
+	settings.Autostart = append([]config.AutostartProfile(nil), dt.appSettings.Autostart...)
+	profile := &settings.Autostart[dt.autostartCursor]
+	profile.Enabled = !profile.Enabled
+
+	if err := config.SaveAppSettings(&settings); err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+
+	notice := i18n.Sprintf("✅ 已停用自动启动 %s", profile.Name)
+	if profile.Enabled {
+		notice = i18n.Sprintf("✅ 已启用自动启动 %s", profile.Name)
+	}
+	return func() tea.Msg {
+		return appSettingsChangedMsg{settings: &settings, notice: notice}
+	}
+}
+
+// renderAutostart 渲染自动启动配置列表，没有配置时返回空字符串
+func (dt *DashboardTab) renderAutostart() string {
+	if dt.scheduler == nil || dt.autostartCount() == 0 {
+		return ""
+	}
+
+	keys := dt.keys.Dashboard
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+
+	hint := helpLine(" • ", keys.Autostart)
+	if dt.autostartFocus {
+		hint = helpLine(" • ", keys.Up, keys.Down, keys.Toggle, keys.CloseDetail)
+	}
+	content := titleStyle.Render(i18n.T("⏰ 自动启动")) + hintStyle.Render("  "+hint) + "\n"
+
+	for i, status := range dt.scheduler.Statuses(time.Now()) {
+		profile := status.Profile
+
+		cursor := "  "
+		if dt.autostartFocus && i == dt.autostartCursor {
+			cursor = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Render("▶ ")
+		}
+		check := "[ ]"
+		if profile.Enabled {
+			check = "[✓]"
+		}
+
+		target := i18n.T("客户端")
+		if profile.Service == "server" {
+			target = i18n.T("服务端")
+		}
+		windows := i18n.T("全天")
+		if len(profile.Windows) > 0 {
+			windows = strings.Join(profile.Windows, ", ")
+		}
+
+		var state string
+		switch {
+		case status.Running:
+			state = lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render(i18n.T("● 运行中"))
+		case !profile.Enabled:
+			state = hintStyle.Render(i18n.T("已停用"))
+		case status.InWindow:
+			state = lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render(i18n.T("○ 未运行"))
+		default:
+			state = hintStyle.Render(i18n.T("等待时间窗口"))
+		}
+
+		content += cursor + check + " " + lipgloss.NewStyle().Bold(true).Render(profile.Name) +
+			"  " + target + "  " + hintStyle.Render(windows) + "  " + state + "\n"
+	}
+	return content
+}
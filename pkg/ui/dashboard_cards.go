@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// dashboardOverview 仪表盘信息卡片使用的状态，由主界面在每轮轮询后更新
+type dashboardOverview struct {
+	polled     bool // 已完成第一轮轮询，之前的状态显示为未知
+	reachable  bool // frps Dashboard API 可访问
+	serverInfo *service.ServerInfo
+	server     service.ProcessStatus
+	client     service.ProcessStatus
+}
+
+// SetOverview 更新信息卡片显示的进程和 frps 状态
+func (dt *DashboardTab) SetOverview(overview dashboardOverview) {
+	dt.overview = overview
+}
+
+// 信息卡片中不同状态的样式
+var (
+	cardTitleStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	cardOKStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	cardWarnStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	cardErrorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	cardUnknownStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
+)
+
+// renderInfoCards 渲染服务端、客户端、流量和运行时间四张信息卡片
+func (dt *DashboardTab) renderInfoCards(width int) string {
+	// 计算信息卡片宽度，考虑边框、内边距和间距
+	// 每个卡片需要：边框(2) + 内边距(2) + 外边距(2) = 6个字符的额外空间
+	availableWidth := width - 8            // 为整体布局留边距
+	cardWidth := (availableWidth - 24) / 4 // 4个卡片，每个卡片6个字符额外空间
+	if cardWidth < 16 {
+		cardWidth = 16 // 确保最小宽度
+	}
+
+	infoCardStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(1).
+		Margin(0, 1, 1, 0).
+		Width(cardWidth)
+
+	card := func(title string, lines ...string) string {
+		return infoCardStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+			append([]string{cardTitleStyle.Render(title)}, lines...)...))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		card(i18n.T("🎯 服务端"), dt.serverCardLines()...),
+		card(i18n.T("💻 客户端"), dt.clientCardLines()...),
+		card(i18n.T("📈 流量"), dt.trafficCardLines()...),
+		card(i18n.T("⏰ 运行时间"), dt.uptimeCardLines()...),
+	)
+}
+
+// serverCardLines 服务端卡片：本机 frps 进程和 Dashboard API 的状态
+func (dt *DashboardTab) serverCardLines() []string {
+	ov := dt.overview
+	if !ov.polled {
+		return []string{i18n.T("状态: ") + cardUnknownStyle.Render(i18n.T("检测中")), i18n.T("端口: ") + cardUnknownStyle.Render("-")}
+	}
+
+	var status string
+	switch {
+	case ov.reachable:
+		status = cardOKStyle.Render(i18n.T("运行中"))
+	case ov.server.IsRunning:
+		// 本机 frps 在运行但 API 无响应，可能是 Dashboard 未启用或认证失败
+		status = cardErrorStyle.Render(i18n.T("API 不可达"))
+	default:
+		status = cardUnknownStyle.Render(i18n.T("未运行"))
+	}
+	lines := []string{i18n.T("状态: ") + status}
+
+	if ov.serverInfo != nil {
+		lines = append(lines, i18n.Sprintf("端口: %d", ov.serverInfo.BindPort))
+		if ov.serverInfo.Version != "" {
+			lines = append(lines, i18n.T("版本: ")+ov.serverInfo.Version)
+		}
+	} else {
+		lines = append(lines, i18n.T("端口: ")+cardUnknownStyle.Render(i18n.T("未知")))
+	}
+	return lines
+}
+
+// clientCardLines 客户端卡片：本机 frpc 进程状态和 frps 上的代理数量
+func (dt *DashboardTab) clientCardLines() []string {
+	ov := dt.overview
+	if !ov.polled {
+		return []string{i18n.T("状态: ") + cardUnknownStyle.Render(i18n.T("检测中")), i18n.T("代理: ") + cardUnknownStyle.Render("-")}
+	}
+
+	status := cardUnknownStyle.Render(i18n.T("未运行"))
+	if ov.client.IsRunning {
+		status = cardOKStyle.Render(i18n.T("运行中"))
+	}
+	lines := []string{i18n.T("状态: ") + status}
+
+	if !ov.reachable {
+		return append(lines, i18n.T("代理: ")+cardUnknownStyle.Render(i18n.T("未知")))
+	}
+	online := 0
+	for _, proxy := range dt.proxies {
+		if proxy.Status == "online" {
+			online++
+		}
+	}
+	count := i18n.Sprintf("代理: %d 个", len(dt.proxies))
+	if online < len(dt.proxies) {
+		count += " " + cardWarnStyle.Render(i18n.Sprintf("(%d 在线)", online))
+	}
+	return append(lines, count)
+}
+
+// trafficCardLines 流量卡片：frps 启动以来的累计流量
+func (dt *DashboardTab) trafficCardLines() []string {
+	ov := dt.overview
+	if ov.serverInfo == nil {
+		value := cardUnknownStyle.Render(i18n.T("未知"))
+		if ov.polled {
+			value = cardErrorStyle.Render(i18n.T("不可达"))
+		}
+		return []string{i18n.T("上行: ") + value, i18n.T("下行: ") + value}
+	}
+	return []string{
+		i18n.T("上行: ") + service.FormatTraffic(ov.serverInfo.TotalTrafficIn),
+		i18n.T("下行: ") + service.FormatTraffic(ov.serverInfo.TotalTrafficOut),
+	}
+}
+
+// uptimeCardLines 运行时间卡片：本机 frps/frpc 进程的运行时长
+func (dt *DashboardTab) uptimeCardLines() []string {
+	return []string{
+		i18n.T("服务端: ") + dt.uptimeValue(dt.overview.server),
+		i18n.T("客户端: ") + dt.uptimeValue(dt.overview.client),
+	}
+}
+
+// uptimeValue 返回进程运行时长，未运行或启动时间未知时使用灰色显示
+func (dt *DashboardTab) uptimeValue(status service.ProcessStatus) string {
+	switch {
+	case !dt.overview.polled:
+		return cardUnknownStyle.Render("-")
+	case !status.IsRunning:
+		return cardUnknownStyle.Render(i18n.T("未运行"))
+	case status.StartTime.IsZero():
+		return cardUnknownStyle.Render(i18n.T("未知"))
+	default:
+		return processUptime(status.StartTime)
+	}
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// loadProxyMeta 读取客户端配置对应的标签文件，文件修改时间未变时沿用已读取的内容
+func (dt *DashboardTab) loadProxyMeta() {
+	if dt.appSettings == nil || dt.appSettings.ClientConfigPath == "" {
+		dt.meta = nil
+		return
+	}
+
+	configPath := dt.appSettings.ClientConfigPath
+	var modTime time.Time
+	if info, err := os.Stat(config.ProxyMetaPath(configPath)); err == nil {
+		modTime = info.ModTime()
+	}
+	if dt.meta != nil && modTime.Equal(dt.metaModTime) {
+		return
+	}
+
+	// 标签文件有误时不显示标签，代理列表照常刷新
+	meta, _ := config.LoadProxyMetadata(configPath)
+	dt.meta = meta
+	dt.metaModTime = modTime
+	if dt.labelFilter != "" && !slices.Contains(meta.Labels(), dt.labelFilter) {
+		dt.labelFilter = ""
+	}
+}
+
+// visibleProxies 返回按标签筛选后的代理，按标签分组时同一标签的代理排在一起，没有标签的排在最后
+func (dt *DashboardTab) visibleProxies() []ProxyStatus {
+	var proxies []ProxyStatus
+	for _, proxy := range dt.proxies {
+		if dt.labelFilter == "" || slices.Contains(dt.meta.Get(proxy.Name).Labels, dt.labelFilter) {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	if dt.groupByLabel {
+		sort.SliceStable(proxies, func(i, j int) bool {
+			a, b := dt.groupLabel(proxies[i].Name), dt.groupLabel(proxies[j].Name)
+			if a == "" || b == "" {
+				return a != "" && b == ""
+			}
+			return a < b
+		})
+	}
+	return proxies
+}
+
+// groupLabel 返回分组使用的标签，筛选时使用筛选的标签，否则使用代理的第一个标签
+func (dt *DashboardTab) groupLabel(name string) string {
+	labels := dt.meta.Get(name).Labels
+	if len(labels) == 0 {
+		return ""
+	}
+	if dt.labelFilter != "" {
+		return dt.labelFilter
+	}
+	return labels[0]
+}
+
+// labelCell 返回标签列的内容
+func (dt *DashboardTab) labelCell(name string) string {
+	return strings.Join(dt.meta.Get(name).Labels, ",")
+}
+
+// cycleLabelFilter 依次切换到下一个标签筛选，最后一个标签之后回到显示全部
+func (dt *DashboardTab) cycleLabelFilter() tea.Cmd {
+	dt.loadProxyMeta()
+	labels := dt.meta.Labels()
+	if len(labels) == 0 {
+		dt.labelFilter = ""
+		return showStatusMessage(i18n.Sprintf("❌ 还没有代理标签，可在配置管理的代理列表中按 %s 添加",
+			dt.keys.Config.Labels.Help().Key), true)
+	}
+
+	next := 0
+	if i := slices.Index(labels, dt.labelFilter); i >= 0 {
+		next = i + 1
+	}
+	if next < len(labels) {
+		dt.labelFilter = labels[next]
+	} else {
+		dt.labelFilter = ""
+	}
+	dt.refreshRows()
+	return nil
+}
+
+// toggleGroupByLabel 切换是否按标签分组显示代理
+func (dt *DashboardTab) toggleGroupByLabel() {
+	dt.groupByLabel = !dt.groupByLabel
+	dt.loadProxyMeta()
+	dt.refreshRows()
+}
+
+// labelStatus 返回表格标题中显示的标签筛选和分组状态
+func (dt *DashboardTab) labelStatus() string {
+	var parts []string
+	if dt.labelFilter != "" {
+		parts = append(parts, fmt.Sprintf("🏷️ %s (%d/%d)", dt.labelFilter, len(dt.table.Rows()), len(dt.proxies)))
+	}
+	if dt.groupByLabel {
+		parts = append(parts, i18n.T("按标签分组"))
+	}
+	return strings.Join(parts, " · ")
+}
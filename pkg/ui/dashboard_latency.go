@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	constants "frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// applyLatencySettings 更新延迟监控选项，间隔为 0 时停止采样
+func (m *MainDashboard) applyLatencySettings(settings *constants.AppSettings) {
+	if m.latency == nil {
+		return
+	}
+
+	m.latency.SetOptions(service.LatencyOptionsFromSettings(settings))
+	if settings.LatencyInterval > 0 {
+		m.latency.Start()
+	} else {
+		m.latency.Stop()
+	}
+}
+
+// SetLatencyMonitor 设置延迟监控，用于显示到 frps 的延迟曲线
+func (dt *DashboardTab) SetLatencyMonitor(latency *service.LatencyMonitor) {
+	dt.latency = latency
+}
+
+// renderLatency 渲染到 frps 的延迟曲线，未开启采样时返回空字符串
+func (dt *DashboardTab) renderLatency(width int) string {
+	if dt.latency == nil {
+		return ""
+	}
+	samples := dt.latency.Samples()
+	if len(samples) == 0 {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	labelStyle := lipgloss.NewStyle().Width(8)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
+
+	warning := dt.latency.Warning()
+	if warning != "" {
+		lineStyle = warningStyle
+	}
+
+	columns := width - 50
+	if columns < 20 {
+		columns = 20
+	}
+	if len(samples) > columns {
+		samples = samples[len(samples)-columns:]
+	}
+
+	target := dt.latency.Target()
+	if target == "" {
+		target = i18n.T("未配置客户端")
+	}
+	options := dt.latency.Options()
+	content := titleStyle.Render(i18n.T("📶 到 frps 的延迟")) + "  " +
+		hintStyle.Render(i18n.Sprintf("%s • 每 %d 秒采样", target, int(options.Interval.Seconds()))) + "\n"
+
+	series := []struct {
+		label string
+		value func(service.LatencySample) time.Duration
+	}{
+		{i18n.T("连接"), func(s service.LatencySample) time.Duration { return s.Connect }},
+		{i18n.T("面板"), func(s service.LatencySample) time.Duration { return s.Dashboard }},
+	}
+	for _, line := range series {
+		values := make([]time.Duration, len(samples))
+		for i, sample := range samples {
+			values[i] = line.value(sample)
+		}
+		content += labelStyle.Render(line.label) + lineStyle.Render(renderLatencySparkline(values)) +
+			"  " + formatLatencyStats(values, options.Threshold) + "\n"
+	}
+
+	if warning != "" {
+		content += warningStyle.Width(width-12).Render("⚠️ "+warning) + "\n"
+	}
+	return content
+}
+
+// renderLatencySparkline 渲染延迟迷你图，测量失败的采样显示为 ×
+func renderLatencySparkline(values []time.Duration) string {
+	var peak time.Duration
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		switch {
+		case v < 0:
+			b.WriteRune('×')
+		case peak == 0:
+			b.WriteRune(sparkBlocks[0])
+		default:
+			b.WriteRune(sparkBlocks[int(v*time.Duration(len(sparkBlocks)-1)/peak)])
+		}
+	}
+	return b.String()
+}
+
+// formatLatencyStats 返回当前、平均和最高延迟，超过阈值的当前值标红
+func formatLatencyStats(values []time.Duration, threshold time.Duration) string {
+	var total, peak time.Duration
+	measured := 0
+	for _, v := range values {
+		if v < 0 {
+			continue
+		}
+		total += v
+		peak = max(peak, v)
+		measured++
+	}
+	if measured == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(i18n.T("无法连接"))
+	}
+
+	current := i18n.T("失败")
+	if last := values[len(values)-1]; last >= 0 {
+		current = formatLatency(last)
+		if threshold > 0 && last > threshold {
+			current = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(current)
+		}
+	}
+	return i18n.Sprintf("当前 %s  平均 %s  最高 %s", current, formatLatency(total/time.Duration(measured)), formatLatency(peak))
+}
+
+// formatLatency 格式化延迟，10ms 以下保留一位小数
+func formatLatency(d time.Duration) string {
+	if d < 10*time.Millisecond {
+		return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
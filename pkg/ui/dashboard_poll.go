@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// dashboardPollMsg 后台轮询 frps API 的结果
+type dashboardPollMsg struct {
+	target     string // 发起请求时的 Dashboard 目标，切换目标后丢弃旧结果
+	reachable  bool
+	proxies    []ProxyStatus
+	serverInfo *service.ServerInfo
+	server     service.ProcessStatus // 本机 frps 进程状态
+	client     service.ProcessStatus // 本机 frpc 进程状态
+}
+
+// pollDue 判断是否需要发起新一轮轮询。frps 不可达或还没有代理时每秒检查一次，
+// 以便刚启动的 frps 和刚连上的 frpc 尽快显示；上一轮尚未返回时不重复请求
+func (m *MainDashboard) pollDue(now time.Time) bool {
+	if m.apiClient == nil || m.polling {
+		return false
+	}
+	if m.lastProxyUpdate.IsZero() {
+		return true
+	}
+
+	interval := m.appSettings.APIPollDuration()
+	if m.statusInfo.ServerStatus != "运行中" || m.statusInfo.ActiveProxies == 0 {
+		interval = min(interval, time.Second)
+	}
+	return now.Sub(m.lastProxyUpdate) >= interval
+}
+
+// pollDashboard 在后台获取服务器信息和代理列表，避免慢速链路阻塞界面
+func (m *MainDashboard) pollDashboard(now time.Time) tea.Cmd {
+	m.polling = true
+	m.lastProxyUpdate = now
+
+	apiClient, manager := m.apiClient, m.manager
+	return func() tea.Msg {
+		msg := dashboardPollMsg{target: apiClient.TargetName()}
+		if manager != nil {
+			msg.server = manager.GetServerStatus()
+			msg.client = manager.GetClientStatus()
+		}
+		serverInfo, err := apiClient.GetServerInfo()
+		if err != nil {
+			return msg
+		}
+		msg.reachable = true
+		msg.serverInfo = serverInfo
+
+		if proxies, err := apiClient.GetProxyList(); err == nil {
+			msg.proxies = proxyStatuses(proxies)
+		}
+		return msg
+	}
+}
+
+// handleDashboardPoll 应用轮询结果
+func (m *MainDashboard) handleDashboardPoll(msg dashboardPollMsg) {
+	m.polling = false
+	if msg.target != m.apiClient.TargetName() {
+		return
+	}
+
+	if tab, ok := m.tabRegistry.GetTabByIndex(0).(*DashboardTab); ok {
+		tab.SetOverview(dashboardOverview{
+			polled:     true,
+			reachable:  msg.reachable,
+			serverInfo: msg.serverInfo,
+			server:     msg.server,
+			client:     msg.client,
+		})
+	}
+
+	if !msg.reachable {
+		m.statusInfo.ServerStatus = "已停止"
+		m.resetProxyInfo()
+		// 保留本轮的时间，不可达时按 pollDue 的间隔重试
+		m.lastProxyUpdate = time.Now()
+		return
+	}
+	m.statusInfo.ServerStatus = "运行中"
+	m.updateProxyInfo(msg.proxies, msg.serverInfo)
+}
+
+// proxyStatuses 将 frps 返回的代理信息转换为仪表盘显示的代理状态
+func proxyStatuses(proxies []service.ProxyInfo) []ProxyStatus {
+	result := make([]ProxyStatus, len(proxies))
+	for i, proxy := range proxies {
+		result[i] = ProxyStatus{
+			Name:            proxy.Name,
+			Type:            proxy.Conf.Type,
+			Status:          proxy.Status,
+			CurConns:        proxy.CurConns,
+			TodayTrafficIn:  proxy.TodayTrafficIn,
+			TodayTrafficOut: proxy.TodayTrafficOut,
+			ClientVersion:   proxy.ClientVersion,
+			LastStartTime:   proxy.LastStartTime,
+		}
+
+		if proxy.Conf.LocalIP != "" {
+			result[i].LocalAddr = proxy.Conf.LocalIP
+		} else {
+			result[i].LocalAddr = "N/A"
+		}
+
+		if proxy.Conf.RemotePort > 0 {
+			result[i].RemotePort = fmt.Sprintf("%d", proxy.Conf.RemotePort)
+		} else {
+			result[i].RemotePort = "N/A"
+		}
+	}
+	return result
+}
+
+// toggleRefreshPaused 暂停或恢复所有自动刷新，恢复时立即刷新日志并轮询 frps
+func (m *MainDashboard) toggleRefreshPaused() tea.Cmd {
+	m.refreshPaused = !m.refreshPaused
+	if m.refreshPaused {
+		return showStatusMessage(i18n.T("⏸ 已暂停自动刷新"), false)
+	}
+
+	m.lastLogPump = time.Now()
+	m.pumpLogs()
+	return tea.Batch(
+		showStatusMessage(i18n.T("▶ 已恢复自动刷新"), false),
+		m.updateStatus(time.Now()),
+	)
+}
@@ -2,12 +2,16 @@ package ui
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
 )
 
 // ProxyStatus 代理状态
@@ -27,27 +31,55 @@ type ProxyStatus struct {
 // DashboardTab 仪表盘标签页
 type DashboardTab struct {
 	BaseTab
-	table     table.Model
-	apiClient *service.APIClient
+	table       table.Model
+	apiClient   *service.APIClient
+	appSettings *config.AppSettings
+	keys        *KeyMap
+	detail      *proxyDetail
+	proxies     []ProxyStatus
+	probes      map[string]*proxyProbe // 按代理名称记录端到端探测结果
+
+	manager      *service.Manager
+	actions      map[string]*proxyAction // 按代理名称记录正在执行的关闭、暂停或恢复操作
+	confirmClose bool
+
+	scheduler       *service.Scheduler
+	latency         *service.LatencyMonitor
+	resources       *service.ResourceMonitor
+	overview        dashboardOverview // 最近一次轮询得到的进程和 frps 状态
+	autostartFocus  bool              // 焦点在自动启动列表上
+	autostartCursor int
+	targetFocus     bool // 正在选择 Dashboard 目标
+	targetCursor    int
+
+	meta         *config.ProxyMetadata // 客户端配置对应的代理标签
+	metaModTime  time.Time
+	labelFilter  string // 只显示带有该标签的代理，为空时显示全部
+	groupByLabel bool
+}
+
+// dashboardColumns 代理列表的表头，按当前语言显示
+func dashboardColumns() []table.Column {
+	return []table.Column{
+		{Title: i18n.T("代理名称"), Width: 12},
+		{Title: i18n.T("类型"), Width: 6},
+		{Title: i18n.T("本地地址"), Width: 16},
+		{Title: i18n.T("远程端口"), Width: 8},
+		{Title: i18n.T("状态"), Width: 8},
+		{Title: i18n.T("连接数"), Width: 6},
+		{Title: i18n.T("今日上行"), Width: 10},
+		{Title: i18n.T("今日下行"), Width: 10},
+		{Title: i18n.T("启动时间"), Width: 16},
+		{Title: i18n.T("端到端"), Width: 10},
+		{Title: i18n.T("标签"), Width: 12},
+	}
 }
 
 // NewDashboardTab 创建仪表盘标签页
 func NewDashboardTab(apiClient *service.APIClient) *DashboardTab {
 	// 初始化表格
-	columns := []table.Column{
-		{Title: "代理名称", Width: 12},
-		{Title: "类型", Width: 6},
-		{Title: "本地地址", Width: 16},
-		{Title: "远程端口", Width: 8},
-		{Title: "状态", Width: 8},
-		{Title: "连接数", Width: 6},
-		{Title: "今日上行", Width: 10},
-		{Title: "今日下行", Width: 10},
-		{Title: "启动时间", Width: 16},
-	}
-
 	t := table.New(
-		table.WithColumns(columns),
+		table.WithColumns(dashboardColumns()),
 		table.WithRows([]table.Row{}),
 		table.WithFocused(true),
 		table.WithHeight(10),
@@ -68,11 +100,13 @@ func NewDashboardTab(apiClient *service.APIClient) *DashboardTab {
 	baseTab := NewBaseTab("仪表盘")
 	baseTab.focusable = true
 
-	return &DashboardTab{
+	dt := &DashboardTab{
 		BaseTab:   baseTab,
 		table:     t,
 		apiClient: apiClient,
 	}
+	dt.SetKeyMap(DefaultKeyMap())
+	return dt
 }
 
 // Init 初始化
@@ -80,6 +114,19 @@ func (dt *DashboardTab) Init() tea.Cmd {
 	return nil
 }
 
+// SetAppSettings 设置应用配置，用于解析代理的访问地址和详情刷新间隔，并按当前语言更新表头
+func (dt *DashboardTab) SetAppSettings(settings *config.AppSettings) {
+	dt.appSettings = settings
+	dt.table.SetColumns(dashboardColumns())
+}
+
+// SetKeyMap 设置快捷键，表格的上下移动同样使用自定义按键
+func (dt *DashboardTab) SetKeyMap(keys *KeyMap) {
+	dt.keys = keys
+	dt.table.KeyMap.LineUp = keys.Dashboard.Up
+	dt.table.KeyMap.LineDown = keys.Dashboard.Down
+}
+
 // Update 更新状态
 func (dt *DashboardTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 	var cmd tea.Cmd
@@ -90,6 +137,74 @@ func (dt *DashboardTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 		if dt.width > 20 {
 			dt.table.SetWidth(dt.width - 12)
 		}
+
+	case tea.KeyMsg:
+		if dt.targetFocus {
+			return dt.updateTargetPicker(msg)
+		}
+		if dt.autostartFocus {
+			return dt.updateAutostart(msg)
+		}
+		if dt.confirmClose {
+			dt.confirmClose = false
+			if msg.String() == "y" && dt.detail != nil {
+				return dt, dt.closeProxy()
+			}
+			return dt, nil
+		}
+		if dt.detail != nil {
+			switch {
+			case key.Matches(msg, dt.keys.Dashboard.CloseDetail):
+				dt.detail = nil
+			case key.Matches(msg, dt.keys.Dashboard.Copy):
+				return dt, dt.copyRemoteAddr()
+			case key.Matches(msg, dt.keys.Dashboard.CloseProxy):
+				return dt, dt.requestCloseProxy()
+			case key.Matches(msg, dt.keys.Dashboard.PauseProxy):
+				return dt, dt.togglePauseProxy()
+			}
+			return dt, nil
+		}
+		if key.Matches(msg, dt.keys.Dashboard.Detail) {
+			return dt, dt.openDetail()
+		}
+		if key.Matches(msg, dt.keys.Dashboard.Copy) {
+			return dt, dt.copyRemoteAddr()
+		}
+		if key.Matches(msg, dt.keys.Dashboard.Probe) {
+			return dt, dt.probeSelected()
+		}
+		if key.Matches(msg, dt.keys.Dashboard.Target) {
+			return dt, dt.openTargetPicker()
+		}
+		if key.Matches(msg, dt.keys.Dashboard.LabelFilter) {
+			return dt, dt.cycleLabelFilter()
+		}
+		if key.Matches(msg, dt.keys.Dashboard.GroupByLabel) {
+			dt.toggleGroupByLabel()
+			return dt, nil
+		}
+		if key.Matches(msg, dt.keys.Dashboard.Autostart) && dt.autostartCount() > 0 {
+			dt.autostartFocus = true
+			dt.autostartCursor = min(dt.autostartCursor, dt.autostartCount()-1)
+			return dt, nil
+		}
+
+	case dashboardTickMsg:
+		if dt.detail != nil && time.Since(dt.detail.fetchedAt) >= dt.refreshInterval() {
+			return dt, dt.fetchDetail()
+		}
+		return dt, nil
+
+	case proxyDetailMsg:
+		dt.handleDetailResult(msg)
+		return dt, nil
+
+	case proxyProbeMsg:
+		return dt, dt.handleProbeResult(msg)
+
+	case proxyActionMsg:
+		return dt, dt.handleProxyAction(msg)
 	}
 
 	dt.table, cmd = dt.table.Update(msg)
@@ -105,66 +220,32 @@ func (dt *DashboardTab) View(width int, height int) string {
 	}
 	dt.table.SetWidth(tableWidth)
 
+	if dt.detail != nil {
+		return dt.renderDetail(width)
+	}
+	if dt.targetFocus {
+		return dt.renderTargetPicker(width)
+	}
+
 	// 标题样式
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("#7D56F4")).
 		Padding(0, 0, 1, 0)
 
-	// 计算信息卡片宽度，考虑边框、内边距和间距
-	// 每个卡片需要：边框(2) + 内边距(2) + 外边距(2) = 6个字符的额外空间
-	availableWidth := width - 8            // 为整体布局留边距
-	cardWidth := (availableWidth - 24) / 4 // 4个卡片，每个卡片6个字符额外空间
-	if cardWidth < 16 {
-		cardWidth = 16 // 确保最小宽度
-	}
-
-	// 信息卡片样式
-	infoCardStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
-		Padding(1).
-		Margin(0, 1, 1, 0).
-		Width(cardWidth)
-
-	// 创建信息卡片
-	serverCard := infoCardStyle.Render(
-		lipgloss.JoinVertical(lipgloss.Left,
-			lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39")).Render("🎯 服务端"),
-			"状态: 运行中",
-			"端口: 7000",
-		),
-	)
-
-	clientCard := infoCardStyle.Render(
-		lipgloss.JoinVertical(lipgloss.Left,
-			lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39")).Render("💻 客户端"),
-			"状态: 已连接",
-			fmt.Sprintf("代理: %d 个", len(dt.table.Rows())),
-		),
-	)
-
-	trafficCard := infoCardStyle.Render(
-		lipgloss.JoinVertical(lipgloss.Left,
-			lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39")).Render("📈 流量"),
-			"上行: 1.2MB",
-			"下行: 3.4MB",
-		),
-	)
-
-	uptimeCard := infoCardStyle.Render(
-		lipgloss.JoinVertical(lipgloss.Left,
-			lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39")).Render("⏰ 运行时间"),
-			"服务端: 2h 15m",
-			"客户端: 1h 45m",
-		),
-	)
-
-	// 水平排列信息卡片
-	infoCards := lipgloss.JoinHorizontal(lipgloss.Top, serverCard, clientCard, trafficCard, uptimeCard)
+	infoCards := dt.renderInfoCards(width)
 
 	// 表格标题
-	tableTitle := titleStyle.Render("📋 代理状态详情")
+	tableTitle := titleStyle.Render(i18n.T("📋 代理状态详情"))
+	if label := dt.targetLabel(); label != "" {
+		tableTitle += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(label)
+	}
+	if status := dt.labelStatus(); status != "" {
+		tableTitle += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(status)
+	}
+	tableTitle += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("  " +
+		helpLine(" • ", dt.keys.Dashboard.Detail, dt.keys.Dashboard.Copy, dt.keys.Dashboard.Probe, dt.keys.Dashboard.Target,
+			dt.keys.Dashboard.LabelFilter, dt.keys.Dashboard.GroupByLabel))
 
 	// 表格容器样式
 	tableContainerStyle := lipgloss.NewStyle().
@@ -185,23 +266,37 @@ func (dt *DashboardTab) View(width int, height int) string {
 			Width(width - 20).
 			Padding(2)
 
-		emptyMessage := emptyStyle.Render("暂无活跃代理\n\n请在配置管理中添加代理配置，或启动 FRP 客户端")
+		emptyMessage := emptyStyle.Render(i18n.T("暂无活跃代理\n\n请在配置管理中添加代理配置，或启动 FRP 客户端"))
 		tableContent = tableContainerStyle.Render(emptyMessage)
 	} else {
 		tableContent = tableContainer
 	}
 
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		infoCards,
-		"",
-		tableTitle,
-		tableContent,
-	)
+	sections := []string{infoCards}
+	if latency := dt.renderLatency(width); latency != "" {
+		sections = append(sections, latency)
+	}
+	if resources := dt.renderResources(width); resources != "" {
+		sections = append(sections, resources)
+	}
+	sections = append(sections, "", tableTitle, tableContent)
+	if autostart := dt.renderAutostart(); autostart != "" {
+		sections = append(sections, "", autostart)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
-// UpdateProxyList 更新代理列表
+// UpdateProxyList 更新代理列表，同时重新读取有变化的代理标签
 func (dt *DashboardTab) UpdateProxyList(proxies []ProxyStatus) {
+	dt.proxies = proxies
+	dt.applyPendingActions()
+	dt.loadProxyMeta()
+	dt.refreshRows()
+}
+
+// refreshRows 按代理列表、标签筛选和探测结果重建表格行
+func (dt *DashboardTab) refreshRows() {
+	proxies := dt.visibleProxies()
 	rows := make([]table.Row, len(proxies))
 
 	for i, proxy := range proxies {
@@ -222,10 +317,23 @@ func (dt *DashboardTab) UpdateProxyList(proxies []ProxyStatus) {
 			trafficIn,
 			trafficOut,
 			startTime,
+			dt.probeCell(proxy.Name),
+			dt.labelCell(proxy.Name),
 		}
 	}
 
 	dt.table.SetRows(rows)
+	if dt.table.Cursor() >= len(rows) && len(rows) > 0 {
+		dt.table.SetCursor(len(rows) - 1)
+	}
+}
+
+// refreshInterval 返回详情刷新间隔
+func (dt *DashboardTab) refreshInterval() time.Duration {
+	if dt.appSettings == nil {
+		return 3 * time.Second
+	}
+	return dt.appSettings.APIPollDuration()
 }
 
 // formatTraffic 格式化流量显示
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	constants "frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// applyDashboardTarget 切换 API 客户端到当前 Dashboard 目标，目标变化时清空旧服务器的代理列表
+func (m *MainDashboard) applyDashboardTarget(settings *constants.AppSettings) {
+	target := settings.ActiveDashboardTarget()
+	changed := m.apiClient.TargetName() != target.Name
+
+	// CA 证书在保存设置时已校验，这里失败时仍使用系统默认的证书校验
+	_ = m.apiClient.SetTarget(target)
+	if changed && m.tabRegistry != nil {
+		if tab, ok := m.tabRegistry.GetTabByIndex(0).(*DashboardTab); ok {
+			tab.probes = nil
+		}
+		m.resetProxyInfo()
+	}
+}
+
+// openTargetPicker 打开 Dashboard 目标列表，只有默认目标时提示如何添加
+func (dt *DashboardTab) openTargetPicker() tea.Cmd {
+	if dt.appSettings == nil || len(dt.appSettings.DashboardTargets) == 0 {
+		return showStatusMessage(i18n.T("❌ 没有其他 Dashboard 目标，可在 settings.yaml 的 dashboardTargets 中添加"), true)
+	}
+
+	dt.targetFocus = true
+	dt.targetCursor = 0
+	active := dt.appSettings.ActiveDashboardTarget().Name
+	for i, target := range dt.appSettings.DashboardTargetList() {
+		if target.Name == active {
+			dt.targetCursor = i
+		}
+	}
+	return nil
+}
+
+// updateTargetPicker 处理 Dashboard 目标列表中的按键
+func (dt *DashboardTab) updateTargetPicker(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	keys := dt.keys.Dashboard
+	count := len(dt.appSettings.DashboardTargetList())
+
+	switch {
+	case key.Matches(msg, keys.CloseDetail), key.Matches(msg, keys.Target):
+		dt.targetFocus = false
+	case key.Matches(msg, keys.Up):
+		dt.targetCursor = (dt.targetCursor - 1 + count) % count
+	case key.Matches(msg, keys.Down):
+		dt.targetCursor = (dt.targetCursor + 1) % count
+	case key.Matches(msg, keys.Detail):
+		dt.targetFocus = false
+		return dt, dt.switchTarget()
+	}
+	return dt, nil
+}
+
+// switchTarget 切换到选中的 Dashboard 目标并保存到应用设置
+func (dt *DashboardTab) switchTarget() tea.Cmd {
+	targets := dt.appSettings.DashboardTargetList()
+	if dt.targetCursor >= len(targets) {
+		return nil
+	}
+	target := targets[dt.targetCursor]
+	if target.Name == dt.appSettings.ActiveDashboardTarget().Name {
+		return nil
+	}
+
+	settings := *dt.appSettings
+	settings.ActiveDashboard = target.Name
+	if target.Name == constants.DefaultDashboardTarget {
+		settings.ActiveDashboard = ""
+	}
+	if err := constants.SaveAppSettings(&settings); err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+
+	notice := i18n.Sprintf("✅ 已切换到 Dashboard 目标 %s (%s)", target.Name, target.URL)
+	return func() tea.Msg {
+		return appSettingsChangedMsg{settings: &settings, notice: notice}
+	}
+}
+
+// targetLabel 返回当前 Dashboard 目标的显示名称，只有默认目标时返回空字符串
+func (dt *DashboardTab) targetLabel() string {
+	if dt.appSettings == nil || len(dt.appSettings.DashboardTargets) == 0 {
+		return ""
+	}
+	target := dt.appSettings.ActiveDashboardTarget()
+	return "🌐 " + target.Name + " (" + target.URL + ")"
+}
+
+// renderTargetPicker 渲染 Dashboard 目标列表
+func (dt *DashboardTab) renderTargetPicker(width int) string {
+	keys := dt.keys.Dashboard
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1, 2).
+		Width(min(width-8, 80))
+
+	active := dt.appSettings.ActiveDashboardTarget().Name
+	content := titleStyle.Render(i18n.T("🌐 切换 Dashboard 目标")) + "\n\n"
+	for i, target := range dt.appSettings.DashboardTargetList() {
+		cursor := "  "
+		if i == dt.targetCursor {
+			cursor = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Render("▶ ")
+		}
+		mark := "   "
+		if target.Name == active {
+			mark = lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render(" ● ")
+		}
+
+		var auth []string
+		switch {
+		case target.Token != "":
+			auth = append(auth, i18n.T("令牌"))
+		case target.User != "":
+			auth = append(auth, "Basic "+target.User)
+		}
+		if target.CAFile != "" {
+			auth = append(auth, "CA")
+		}
+		if target.InsecureSkipVerify {
+			auth = append(auth, i18n.T("不校验证书"))
+		}
+		detail := target.URL
+		for _, item := range auth {
+			detail += " · " + item
+		}
+
+		content += cursor + mark + lipgloss.NewStyle().Bold(true).Render(target.Name) + "  " + hintStyle.Render(detail) + "\n"
+	}
+	content += "\n" + hintStyle.Render(helpLine(" • ", keys.Up, keys.Down)+" • "+
+		i18n.Sprintf("%s 切换", keys.Detail.Help().Key)+" • "+helpLine(" • ", keys.CloseDetail))
+
+	return boxStyle.Render(content)
+}
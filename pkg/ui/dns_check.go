@@ -0,0 +1,102 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// dnsCheckMsg 代理域名的 DNS 检查完成
+type dnsCheckMsg struct {
+	checks []service.DomainCheck
+}
+
+// dnsCheck 代理域名 DNS 检查的状态
+type dnsCheck struct {
+	running           bool
+	checks            []service.DomainCheck
+	skippedSubdomains int // 未加载服务端 subDomainHost 而跳过的子域名代理
+}
+
+// runDNSCheck 在后台检查客户端配置中 HTTP/HTTPS 代理的域名是否解析到 frps，没有需要检查的域名时返回 nil
+func (ct *ConfigTab) runDNSCheck() tea.Cmd {
+	ct.dnsCheck = nil
+	if ct.clientConfig == nil {
+		return nil
+	}
+
+	subDomainHost := ""
+	if ct.serverConfig != nil {
+		subDomainHost = ct.serverConfig.SubDomainHost
+	}
+	skipped := 0
+	if subDomainHost == "" {
+		for _, proxy := range ct.clientConfig.Proxies {
+			if !proxy.Disabled && proxy.Subdomain != "" && (proxy.Type == "http" || proxy.Type == "https") {
+				skipped++
+			}
+		}
+	}
+	if !service.HasProxyDomains(ct.clientConfig, subDomainHost) {
+		if skipped > 0 {
+			ct.dnsCheck = &dnsCheck{skippedSubdomains: skipped}
+		}
+		return nil
+	}
+
+	// 检查期间可能继续编辑配置，使用副本
+	cfg := ct.clientConfig.Clone()
+	ct.dnsCheck = &dnsCheck{running: true, skippedSubdomains: skipped}
+	return func() tea.Msg {
+		return dnsCheckMsg{checks: service.CheckProxyDomains(cfg, subDomainHost)}
+	}
+}
+
+// handleDNSCheckResult 记录检查结果并刷新预览，预览中有标记行时保持其可见
+func (ct *ConfigTab) handleDNSCheckResult(msg dnsCheckMsg) {
+	if ct.dnsCheck == nil || !ct.dnsCheck.running {
+		return
+	}
+	ct.dnsCheck.running = false
+	ct.dnsCheck.checks = msg.checks
+	if p := ct.preview; p != nil {
+		ct.refreshPreview()
+		if p.mark != nil && p.markLine >= 0 {
+			p.viewport.SetYOffset(max(0, p.markLine-3))
+		}
+	}
+}
+
+// renderDNSCheck 渲染代理域名的解析检查结果，没有需要检查的域名时返回空字符串
+func (ct *ConfigTab) renderDNSCheck() string {
+	if ct.dnsCheck == nil {
+		return ""
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	content := "\n" + lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39")).Render(i18n.T("🌐 域名解析:")) + "\n"
+	if ct.dnsCheck.running {
+		content += hintStyle.Render(i18n.T("⏳ 正在检查 HTTP/HTTPS 代理的域名是否指向 frps...")) + "\n"
+	}
+
+	for _, check := range ct.dnsCheck.checks {
+		label := check.Proxy + ": " + check.Domain
+		switch check.Status {
+		case service.DomainOK:
+			content += lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render("✅ "+label) + hintStyle.Render("  "+check.Message) + "\n"
+			continue
+		case service.DomainUnknown:
+			content += lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render("⚠️ "+label) + "\n"
+		default:
+			content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+label) + "\n"
+		}
+		content += hintStyle.Render("   "+check.Message) + "\n"
+	}
+
+	if ct.dnsCheck.skippedSubdomains > 0 {
+		content += hintStyle.Render(i18n.Sprintf("%d 个代理只设置了子域名，服务端配置未设置 subDomainHost，无法检查", ct.dnsCheck.skippedSubdomains)) + "\n"
+	}
+	return content
+}
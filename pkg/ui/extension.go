@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"sync"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// Services 扩展标签页可以使用的服务，由主界面提供。
+// 返回的对象与内置标签页共享，扩展标签页不应替换或停止它们
+type Services interface {
+	// Manager frps/frpc 进程管理
+	Manager() *service.Manager
+
+	// APIClient 当前 frps Dashboard API 客户端
+	APIClient() *service.APIClient
+
+	// Events 生命周期事件总线，可以订阅事件，也可以发布 user.action 等事件
+	Events() *service.EventBus
+
+	// AppSettings 当前应用设置，只读，设置变更后返回新的对象
+	AppSettings() *config.AppSettings
+
+	// LoadConfig 读取应用设置中的服务端（server）或客户端（client）配置文件
+	LoadConfig(role string) (*config.Config, error)
+}
+
+// TabFactory 创建扩展标签页，返回错误时跳过该标签页并在启动后提示
+type TabFactory func(services Services) (Tab, error)
+
+// InputCapturer 需要独占键盘输入的标签页，返回 true 时全局快捷键不生效，按键全部交给标签页
+type InputCapturer interface {
+	IsInInputMode() bool
+}
+
+// TabCloser 退出时需要释放资源的标签页，如停止子进程
+type TabCloser interface {
+	Close() error
+}
+
+// extensionTab 通过 RegisterTab 注册的扩展标签页
+type extensionTab struct {
+	name    string
+	factory TabFactory
+}
+
+var (
+	extensionsMu sync.Mutex
+	extensions   []extensionTab
+)
+
+// RegisterTab 注册扩展标签页，通常在扩展包的 init 中调用，
+// 自定义构建的主程序导入该包后，扩展标签页按注册顺序排在内置标签页之后
+func RegisterTab(name string, factory TabFactory) {
+	extensionsMu.Lock()
+	defer extensionsMu.Unlock()
+	extensions = append(extensions, extensionTab{name: name, factory: factory})
+}
+
+// registeredTabs 返回已注册的扩展标签页
+func registeredTabs() []extensionTab {
+	extensionsMu.Lock()
+	defer extensionsMu.Unlock()
+	return append([]extensionTab(nil), extensions...)
+}
+
+// dashboardServices 由主界面实现的 Services
+type dashboardServices struct {
+	m *MainDashboard
+}
+
+// Manager 返回共享的进程管理器
+func (s dashboardServices) Manager() *service.Manager {
+	return s.m.manager
+}
+
+// APIClient 返回共享的 Dashboard API 客户端
+func (s dashboardServices) APIClient() *service.APIClient {
+	return s.m.apiClient
+}
+
+// Events 返回事件总线
+func (s dashboardServices) Events() *service.EventBus {
+	return s.m.events
+}
+
+// AppSettings 返回当前应用设置
+func (s dashboardServices) AppSettings() *config.AppSettings {
+	return s.m.appSettings
+}
+
+// LoadConfig 读取服务端或客户端配置文件
+func (s dashboardServices) LoadConfig(role string) (*config.Config, error) {
+	path := s.m.appSettings.ClientConfigPath
+	if role == "server" {
+		path = s.m.appSettings.ServerConfigPath
+	}
+	return config.NewLoader(path).Load()
+}
+
+// loadExtensionTabs 创建已注册的扩展标签页和应用设置中配置的插件，加载失败的跳过并返回错误
+func (m *MainDashboard) loadExtensionTabs() []error {
+	services := dashboardServices{m: m}
+	var errs []error
+
+	for _, ext := range registeredTabs() {
+		tab, err := ext.factory(services)
+		if err != nil {
+			errs = append(errs, i18n.Errorf("加载扩展标签页 %s 失败: %w", ext.name, err))
+			continue
+		}
+		m.tabRegistry.Register(tab)
+	}
+
+	for _, plugin := range m.appSettings.TabPlugins {
+		if plugin.Disabled {
+			continue
+		}
+		var tab Tab
+		var err error
+		if plugin.IsGoPlugin() {
+			tab, err = loadGoPlugin(plugin.Path, services)
+		} else {
+			tab, err = newProcessTab(plugin, services)
+		}
+		if err != nil {
+			errs = append(errs, i18n.Errorf("加载扩展标签页 %s 失败: %w", plugin.Name, err))
+			continue
+		}
+		m.tabRegistry.Register(tab)
+	}
+	return errs
+}
+
+// closeExtensionTabs 退出时释放扩展标签页的资源
+func (m *MainDashboard) closeExtensionTabs() {
+	for _, tab := range m.tabRegistry.GetTabs() {
+		if closer, ok := tab.(TabCloser); ok {
+			_ = closer.Close()
+		}
+	}
+}
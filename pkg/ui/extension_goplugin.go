@@ -0,0 +1,31 @@
+//go:build goplugin
+
+package ui
+
+import (
+	"plugin"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// loadGoPlugin 加载 Go 插件，插件需要导出 NewTab 函数，签名与 TabFactory 相同。
+// 插件必须使用与主程序相同的 Go 版本和依赖版本编译
+func loadGoPlugin(path string, services Services) (Tab, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, i18n.Errorf("打开 Go 插件失败: %w", err)
+	}
+	symbol, err := p.Lookup("NewTab")
+	if err != nil {
+		return nil, i18n.Errorf("Go 插件没有导出 NewTab: %w", err)
+	}
+
+	switch factory := symbol.(type) {
+	case func(Services) (Tab, error):
+		return factory(services)
+	case *TabFactory:
+		return (*factory)(services)
+	default:
+		return nil, i18n.Errorf("Go 插件的 NewTab 签名应为 func(ui.Services) (ui.Tab, error)")
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !goplugin
+
+package ui
+
+import "frp-cli-ui/pkg/i18n"
+
+// loadGoPlugin 未启用 Go 插件支持时返回错误，需要使用 -tags goplugin 重新编译
+func loadGoPlugin(path string, services Services) (Tab, error) {
+	return nil, i18n.Errorf("当前程序编译时未启用 Go 插件支持，请使用 go build -tags goplugin 重新编译，或改用子进程插件")
+}
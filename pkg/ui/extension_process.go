@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// processTabStderrLines 插件退出时显示的标准错误输出行数
+const processTabStderrLines = 10
+
+// processTabQueueSize 等待写给插件的消息数，插件读取太慢时丢弃新消息，避免阻塞界面
+const processTabQueueSize = 256
+
+// pluginRequest 发送给子进程插件的消息，每行一个 JSON
+type pluginRequest struct {
+	Type             string         `json:"type"` // init、resize、focus、key、event
+	Width            int            `json:"width,omitempty"`
+	Height           int            `json:"height,omitempty"`
+	Focused          bool           `json:"focused,omitempty"`
+	Key              string         `json:"key,omitempty"` // 按键名称，如 enter、ctrl+r、a
+	Event            *service.Event `json:"event,omitempty"`
+	Language         string         `json:"language,omitempty"`
+	ServerConfigPath string         `json:"serverConfigPath,omitempty"`
+	ClientConfigPath string         `json:"clientConfigPath,omitempty"`
+	DashboardURL     string         `json:"dashboardURL,omitempty"`
+}
+
+// pluginResponse 子进程插件输出的消息，每行一个 JSON
+type pluginResponse struct {
+	Type    string `json:"type"`              // view、title、status、input
+	Content string `json:"content,omitempty"` // view: 标签页内容，可以包含 ANSI 颜色
+	Title   string `json:"title,omitempty"`   // title: 新的标签页标题
+	Text    string `json:"text,omitempty"`    // status: 通知内容
+	Error   bool   `json:"error,omitempty"`   // status: 是否为错误通知
+	Input   bool   `json:"input,omitempty"`   // input: 是否独占键盘输入
+}
+
+// pluginUpdateMsg 子进程插件输出了一条消息
+type pluginUpdateMsg struct {
+	tab  *ProcessTab
+	resp pluginResponse
+}
+
+// pluginExitMsg 子进程插件已退出
+type pluginExitMsg struct {
+	tab *ProcessTab
+	err error
+}
+
+// ProcessTab 由子进程实现的扩展标签页，通过标准输入输出按行交换 JSON 消息，
+// 可以用任何语言编写。标签页只显示插件最近一次输出的 view 内容
+type ProcessTab struct {
+	BaseTab
+	name       string
+	cmd        *exec.Cmd
+	stdin      io.WriteCloser
+	requests   chan pluginRequest
+	closing    chan struct{}
+	closeOnce  sync.Once
+	updates    chan pluginResponse
+	stderr     *stderrTail
+	stopEvents func()
+	waitDone   chan struct{}
+	waitErr    error
+
+	content string
+	input   bool
+	exited  bool
+	exitErr error
+}
+
+// newProcessTab 启动子进程插件，并把生命周期事件转发给它
+func newProcessTab(plugin config.TabPluginConfig, services Services) (*ProcessTab, error) {
+	cmd := exec.Command(plugin.Path, plugin.Args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr := &stderrTail{}
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		return nil, i18n.Errorf("启动插件失败: %w", err)
+	}
+
+	base := NewBaseTab(plugin.Name)
+	base.focusable = true
+	t := &ProcessTab{
+		BaseTab:  base,
+		name:     plugin.Name,
+		cmd:      cmd,
+		stdin:    stdin,
+		requests: make(chan pluginRequest, processTabQueueSize),
+		closing:  make(chan struct{}),
+		updates:  make(chan pluginResponse, 64),
+		stderr:   stderr,
+		waitDone: make(chan struct{}),
+	}
+
+	go t.readOutput(stdout)
+	go t.writeRequests()
+
+	events, stop := services.Events().Subscribe(100)
+	t.stopEvents = stop
+	go func() {
+		for event := range events {
+			t.send(pluginRequest{Type: "event", Event: &event})
+		}
+	}()
+
+	settings := services.AppSettings()
+	t.send(pluginRequest{
+		Type:             "init",
+		Language:         i18n.Language(),
+		ServerConfigPath: settings.ServerConfigPath,
+		ClientConfigPath: settings.ClientConfigPath,
+		DashboardURL:     settings.DashboardURL,
+	})
+	return t, nil
+}
+
+// readOutput 逐行读取插件输出，无法解析的行当作纯文本内容显示；进程退出后关闭 updates
+func (t *ProcessTab) readOutput(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var resp pluginResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			resp = pluginResponse{Type: "view", Content: scanner.Text()}
+		}
+		t.updates <- resp
+	}
+	t.waitErr = t.cmd.Wait()
+	close(t.waitDone)
+	close(t.updates)
+}
+
+// send 把消息放入写入队列，队列已满或插件正在关闭时丢弃
+func (t *ProcessTab) send(req pluginRequest) {
+	select {
+	case <-t.closing:
+	case t.requests <- req:
+	default:
+	}
+}
+
+// writeRequests 在后台把队列中的消息逐行写给插件，关闭时关闭插件的标准输入
+func (t *ProcessTab) writeRequests() {
+	encoder := json.NewEncoder(t.stdin)
+	for {
+		select {
+		case req := <-t.requests:
+			// 插件已退出时写入失败，继续清空队列直到关闭
+			_ = encoder.Encode(req)
+		case <-t.closing:
+			_ = t.stdin.Close()
+			return
+		}
+	}
+}
+
+// waitForUpdate 等待插件的下一条输出
+func (t *ProcessTab) waitForUpdate() tea.Cmd {
+	return func() tea.Msg {
+		resp, ok := <-t.updates
+		if !ok {
+			<-t.waitDone
+			return pluginExitMsg{tab: t, err: t.waitErr}
+		}
+		return pluginUpdateMsg{tab: t, resp: resp}
+	}
+}
+
+// Init 开始接收插件输出
+func (t *ProcessTab) Init() tea.Cmd {
+	return t.waitForUpdate()
+}
+
+// apply 处理插件输出的一条消息，不论标签页是否为当前标签页都由主界面送达
+func (t *ProcessTab) apply(msg pluginUpdateMsg) tea.Cmd {
+	next := t.waitForUpdate()
+	switch msg.resp.Type {
+	case "view":
+		t.content = msg.resp.Content
+	case "title":
+		if msg.resp.Title != "" {
+			t.title = msg.resp.Title
+		}
+	case "input":
+		t.input = msg.resp.Input
+	case "status":
+		prefix := "🧩 "
+		if msg.resp.Error {
+			prefix = "❌ "
+		}
+		return tea.Batch(next, showStatusMessage(prefix+t.name+": "+msg.resp.Text, msg.resp.Error))
+	}
+	return next
+}
+
+// exit 记录插件退出
+func (t *ProcessTab) exit(msg pluginExitMsg) tea.Cmd {
+	t.exited, t.exitErr, t.input = true, msg.err, false
+	t.stopEvents()
+	t.closeOnce.Do(func() { close(t.closing) })
+	if msg.err != nil {
+		return showStatusMessage(i18n.Sprintf("❌ 插件 %s 已退出: %v", t.name, msg.err), true)
+	}
+	return showStatusMessage(i18n.Sprintf("⚠️ 插件 %s 已退出", t.name), false)
+}
+
+// Update 把按键转发给插件
+func (t *ProcessTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && t.focused && !t.exited {
+		t.send(pluginRequest{Type: "key", Key: msg.String()})
+	}
+	return t, nil
+}
+
+// SetSize 设置标签页大小并通知插件
+func (t *ProcessTab) SetSize(width, height int) {
+	t.BaseTab.SetSize(width, height)
+	if !t.exited {
+		t.send(pluginRequest{Type: "resize", Width: width, Height: height})
+	}
+}
+
+// Focus 设置焦点状态并通知插件
+func (t *ProcessTab) Focus(focused bool) {
+	if focused != t.focused && !t.exited {
+		t.send(pluginRequest{Type: "focus", Focused: focused})
+	}
+	t.BaseTab.Focus(focused)
+}
+
+// IsInInputMode 插件是否要求独占键盘输入
+func (t *ProcessTab) IsInInputMode() bool {
+	return t.input && t.focused
+}
+
+// View 渲染插件输出的内容，插件退出后显示退出原因和最后的错误输出
+func (t *ProcessTab) View(width int, height int) string {
+	contentWidth := max(width-12, 20)
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(1).
+		Width(contentWidth)
+
+	content := t.content
+	if content == "" && !t.exited {
+		content = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.Sprintf("正在等待插件 %s 输出...", t.name))
+	}
+	if t.exited {
+		warn := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+		text := i18n.Sprintf("⚠️ 插件 %s 已退出", t.name)
+		if t.exitErr != nil {
+			text += ": " + t.exitErr.Error()
+		}
+		content += "\n\n" + warn.Render(text)
+		if lines := t.stderr.Lines(); len(lines) > 0 {
+			content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(strings.Join(lines, "\n"))
+		}
+	}
+	return style.Render(strings.TrimLeft(content, "\n"))
+}
+
+// Close 关闭插件的标准输入让它自行退出，2 秒内未退出时强制结束
+func (t *ProcessTab) Close() error {
+	t.stopEvents()
+	t.closeOnce.Do(func() { close(t.closing) })
+
+	select {
+	case <-t.waitDone:
+		return nil
+	case <-time.After(2 * time.Second):
+		return t.cmd.Process.Kill()
+	}
+}
+
+// stderrTail 保留插件标准错误输出的最后几行
+type stderrTail struct {
+	mu    sync.Mutex
+	lines []string
+	part  string
+}
+
+// Write 实现 io.Writer
+func (s *stderrTail) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	text := s.part + string(p)
+	lines := strings.Split(text, "\n")
+	s.part = lines[len(lines)-1]
+	s.lines = append(s.lines, lines[:len(lines)-1]...)
+	if len(s.lines) > processTabStderrLines {
+		s.lines = s.lines[len(s.lines)-processTabStderrLines:]
+	}
+	return len(p), nil
+}
+
+// Lines 返回最后几行错误输出
+func (s *stderrTail) Lines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lines := append([]string(nil), s.lines...)
+	if s.part != "" {
+		lines = append(lines, s.part)
+	}
+	return lines
+}
@@ -7,8 +7,12 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/i18n"
 )
 
 // FilePickerMode 文件选择器模式
@@ -18,6 +22,7 @@ const (
 	FilePickerModeFile FilePickerMode = iota // 选择文件
 	FilePickerModeDir                        // 选择目录
 	FilePickerModeBoth                       // 文件和目录都可选择
+	FilePickerModeSave                       // 选择目录并输入要保存的文件名
 )
 
 // FileItem 文件项
@@ -34,6 +39,7 @@ type FilePickerResult struct {
 	Selected bool
 	Path     string
 	IsDir    bool
+	New      bool // 选择的文件尚不存在，由调用方创建
 }
 
 // filePickerResultMsg 文件选择结果消息
@@ -50,13 +56,33 @@ type FilePicker struct {
 	height      int
 	visible     bool
 	showHidden  bool
-	extensions  []string // 允许的文件扩展名（为空表示所有文件）
+	extensions  []string        // 允许的文件扩展名（为空表示所有文件）
+	nameInput   textinput.Model // 保存模式下的文件名
+	allowCreate bool            // 选择文件时可以按 Ctrl+N 新建文件
+	creating    bool            // 正在输入要新建的文件名
+	message     string          // 路径无效等提示
+
+	pathInput   textinput.Model // Ctrl+L 打开的路径输入框
+	editingPath bool
+	places      []filePlace // 书签目录与最近文件
+	placeIdx    int
+	showPlaces  bool
+
+	keys FilePickerKeyMap
 }
 
 // NewFilePicker 创建文件选择器
 func NewFilePicker(title string, mode FilePickerMode) *FilePicker {
 	currentDir, _ := os.Getwd()
 
+	nameInput := textinput.New()
+	nameInput.Prompt = i18n.T("文件名: ")
+	nameInput.CharLimit = 256
+
+	pathInput := textinput.New()
+	pathInput.Prompt = "📂 "
+	pathInput.CharLimit = 1024
+
 	fp := &FilePicker{
 		title:       title,
 		mode:        mode,
@@ -64,6 +90,9 @@ func NewFilePicker(title string, mode FilePickerMode) *FilePicker {
 		selectedIdx: 0,
 		visible:     false,
 		showHidden:  false,
+		nameInput:   nameInput,
+		pathInput:   pathInput,
+		keys:        DefaultKeyMap().FilePicker,
 	}
 
 	fp.loadDirectory()
@@ -76,16 +105,41 @@ func (fp *FilePicker) SetExtensions(extensions []string) {
 	fp.loadDirectory()
 }
 
+// SetKeyMap 使用自定义的快捷键
+func (fp *FilePicker) SetKeyMap(keys *KeyMap) {
+	fp.keys = keys.FilePicker
+}
+
+// SetAllowCreate 允许在选择文件时新建尚不存在的文件，结果中 New 为 true
+func (fp *FilePicker) SetAllowCreate(allow bool) {
+	fp.allowCreate = allow
+}
+
+// SetFileName 设置保存模式下的默认文件名
+func (fp *FilePicker) SetFileName(name string) {
+	fp.nameInput.SetValue(name)
+	fp.nameInput.CursorEnd()
+}
+
 // Show 显示文件选择器
 func (fp *FilePicker) Show() tea.Cmd {
 	fp.visible = true
 	fp.loadDirectory()
+	if fp.namingFile() {
+		return fp.nameInput.Focus()
+	}
 	return nil
 }
 
 // Hide 隐藏文件选择器
 func (fp *FilePicker) Hide() {
 	fp.visible = false
+	fp.creating = false
+	fp.editingPath = false
+	fp.showPlaces = false
+	fp.message = ""
+	fp.nameInput.Blur()
+	fp.pathInput.Blur()
 }
 
 // IsVisible 检查是否可见
@@ -155,18 +209,8 @@ func (fp *FilePicker) loadDirectory() {
 			dirs = append(dirs, item)
 		} else {
 			// 检查文件扩展名
-			if len(fp.extensions) > 0 {
-				ext := strings.ToLower(filepath.Ext(entry.Name()))
-				allowed := false
-				for _, allowedExt := range fp.extensions {
-					if ext == strings.ToLower(allowedExt) {
-						allowed = true
-						break
-					}
-				}
-				if !allowed {
-					continue
-				}
+			if !fp.allowedFile(entry.Name()) {
+				continue
 			}
 			files = append(files, item)
 		}
@@ -192,6 +236,25 @@ func (fp *FilePicker) loadDirectory() {
 	}
 }
 
+// allowedFile 文件扩展名是否在允许的范围内
+func (fp *FilePicker) allowedFile(name string) bool {
+	if len(fp.extensions) == 0 {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, allowedExt := range fp.extensions {
+		if ext == strings.ToLower(allowedExt) {
+			return true
+		}
+	}
+	return false
+}
+
+// namingFile 是否在输入文件名，保存模式或新建文件时为 true
+func (fp *FilePicker) namingFile() bool {
+	return fp.mode == FilePickerModeSave || fp.creating
+}
+
 // Update 更新状态
 func (fp *FilePicker) Update(msg tea.Msg) tea.Cmd {
 	if !fp.visible {
@@ -203,25 +266,54 @@ func (fp *FilePicker) Update(msg tea.Msg) tea.Cmd {
 		fp.SetSize(msg.Width, msg.Height)
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
+		switch {
+		case fp.editingPath:
+			return fp.updatePath(msg)
+		case fp.showPlaces:
+			return fp.updatePlaces(msg)
+		}
+
+		keys := fp.keys
+		switch {
+		case key.Matches(msg, keys.EditPath):
+			return fp.startPathInput()
+		case key.Matches(msg, keys.Places):
+			fp.loadPlaces()
+			fp.placeIdx = 0
+			fp.showPlaces = true
+			fp.message = ""
+			return nil
+		case key.Matches(msg, keys.NewFile):
+			if fp.allowCreate && !fp.creating {
+				fp.creating = true
+				fp.SetFileName("")
+				return fp.nameInput.Focus()
+			}
+		}
+
+		if fp.namingFile() {
+			return fp.updateSave(msg)
+		}
+
+		switch {
+		case msg.String() == "esc":
 			// 取消选择
 			fp.Hide()
 			return func() tea.Msg {
 				return filePickerResultMsg{Selected: false}
 			}
 
-		case "up", "k":
+		case key.Matches(msg, keys.Up):
 			if fp.selectedIdx > 0 {
 				fp.selectedIdx--
 			}
 
-		case "down", "j":
+		case key.Matches(msg, keys.Down):
 			if fp.selectedIdx < len(fp.items)-1 {
 				fp.selectedIdx++
 			}
 
-		case "enter", " ":
+		case key.Matches(msg, keys.Open):
 			if fp.selectedIdx < len(fp.items) {
 				item := fp.items[fp.selectedIdx]
 
@@ -237,36 +329,30 @@ func (fp *FilePicker) Update(msg tea.Msg) tea.Cmd {
 					fp.selectedIdx = 0
 				} else {
 					// 选择文件
-					fp.Hide()
-					return func() tea.Msg {
-						return filePickerResultMsg{
-							Selected: true,
-							Path:     item.Path,
-							IsDir:    false,
-						}
-					}
+					return fp.choose(item.Path, false)
 				}
 			}
 
-		case "ctrl+d":
+		case key.Matches(msg, keys.ChooseDir):
 			// 选择当前目录（仅在目录模式下）
 			if fp.mode == FilePickerModeDir || fp.mode == FilePickerModeBoth {
-				fp.Hide()
-				return func() tea.Msg {
-					return filePickerResultMsg{
-						Selected: true,
-						Path:     fp.currentPath,
-						IsDir:    true,
-					}
-				}
+				return fp.choose(fp.currentPath, true)
+			}
+
+		case key.Matches(msg, keys.CopyPath):
+			// 复制选中项的完整路径，".." 复制当前目录
+			path := fp.currentPath
+			if fp.selectedIdx < len(fp.items) && fp.items[fp.selectedIdx].Name != ".." {
+				path = fp.items[fp.selectedIdx].Path
 			}
+			return copyCmd(path)
 
-		case "ctrl+h":
+		case key.Matches(msg, keys.ToggleHidden):
 			// 切换显示隐藏文件
 			fp.showHidden = !fp.showHidden
 			fp.loadDirectory()
 
-		case "home":
+		case key.Matches(msg, keys.Home):
 			// 回到用户主目录
 			if homeDir, err := os.UserHomeDir(); err == nil {
 				fp.currentPath = homeDir
@@ -279,6 +365,98 @@ func (fp *FilePicker) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// updateSave 保存模式下的按键：输入框始终接收文字，↑/↓ 选择目录或已有文件，
+// Tab 补全已输入的文件名，未输入时进入目录或使用已有文件名，Enter 保存到当前目录。
+// 新建文件时 ESC 回到浏览文件
+func (fp *FilePicker) updateSave(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case msg.String() == "esc":
+		if fp.creating {
+			fp.creating = false
+			fp.nameInput.Blur()
+			return nil
+		}
+		fp.Hide()
+		return func() tea.Msg {
+			return filePickerResultMsg{Selected: false}
+		}
+
+	case msg.String() == "up":
+		if fp.selectedIdx > 0 {
+			fp.selectedIdx--
+		}
+		return nil
+
+	case msg.String() == "down":
+		if fp.selectedIdx < len(fp.items)-1 {
+			fp.selectedIdx++
+		}
+		return nil
+
+	case msg.String() == "tab":
+		if name := fp.nameInput.Value(); name != "" {
+			if completed := fp.completePath(name); completed != name {
+				fp.SetFileName(completed)
+				return nil
+			}
+		}
+		if fp.selectedIdx < len(fp.items) {
+			item := fp.items[fp.selectedIdx]
+			if item.IsDir {
+				fp.currentPath = item.Path
+				fp.loadDirectory()
+				fp.selectedIdx = 0
+			} else {
+				fp.SetFileName(item.Name)
+			}
+		}
+		return nil
+
+	case msg.String() == "enter":
+		path := fp.savePath()
+		if path == "" {
+			return nil
+		}
+		return fp.choose(path, false)
+
+	case key.Matches(msg, fp.keys.ToggleHidden):
+		fp.showHidden = !fp.showHidden
+		fp.loadDirectory()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	fp.nameInput, cmd = fp.nameInput.Update(msg)
+	return cmd
+}
+
+// choose 关闭选择器并返回选择结果，选择的文件会记入最近文件
+func (fp *FilePicker) choose(path string, isDir bool) tea.Cmd {
+	_, err := os.Stat(path)
+	fp.Hide()
+	result := func() tea.Msg {
+		return filePickerResultMsg{
+			Selected: true,
+			Path:     path,
+			IsDir:    isDir,
+			New:      os.IsNotExist(err),
+		}
+	}
+	if isDir {
+		return result
+	}
+	return tea.Batch(result, recordRecentFile(path))
+}
+
+// savePath 返回保存模式下的目标路径，文件名可以是绝对路径或 ~ 开头的路径
+func (fp *FilePicker) savePath() string {
+	name := strings.TrimSpace(fp.nameInput.Value())
+	if name == "" {
+		return ""
+	}
+	return fp.resolvePath(name)
+}
+
 // View 渲染视图
 func (fp *FilePicker) View() string {
 	if !fp.visible {
@@ -344,17 +522,48 @@ func (fp *FilePicker) View() string {
 	content.WriteString(titleStyle.Render(fp.title))
 	content.WriteString("\n")
 
-	// 当前路径
-	content.WriteString(pathStyle.Render("📁 " + fp.currentPath))
+	// 当前路径，Ctrl+L 时可直接输入
+	if fp.editingPath {
+		content.WriteString(pathStyle.Render(fp.pathInput.View()))
+	} else {
+		content.WriteString(pathStyle.Render("📁 " + fp.currentPath))
+	}
 	content.WriteString("\n")
+	if fp.message != "" {
+		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(fp.message))
+		content.WriteString("\n")
+	}
 
 	// 文件列表
 	listHeight := dialogHeight - 8 // 减去标题、路径、帮助等占用的行数
+
+	// 保存模式下显示文件名输入框，目标文件已存在时提示将被覆盖
+	if fp.namingFile() {
+		content.WriteString(fp.nameInput.View())
+		content.WriteString("\n")
+		if path := fp.savePath(); path != "" {
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render(i18n.T("⚠️ 文件已存在，保存时将覆盖")))
+			}
+		}
+		content.WriteString("\n")
+		listHeight -= 2
+	}
+	if fp.message != "" {
+		listHeight--
+	}
+	if fp.showPlaces {
+		content.WriteString(fp.renderPlaces(listHeight))
+	}
+
 	startIdx := 0
 	endIdx := len(fp.items)
+	if fp.showPlaces {
+		endIdx = 0
+	}
 
 	// 如果列表太长，只显示当前选择项周围的内容
-	if len(fp.items) > listHeight {
+	if endIdx > listHeight {
 		startIdx = fp.selectedIdx - listHeight/2
 		if startIdx < 0 {
 			startIdx = 0
@@ -399,7 +608,7 @@ func (fp *FilePicker) View() string {
 	}
 
 	// 添加空行填充
-	for i := endIdx - startIdx; i < listHeight; i++ {
+	for i := endIdx - startIdx; !fp.showPlaces && i < listHeight; i++ {
 		content.WriteString("\n")
 	}
 
@@ -408,16 +617,34 @@ func (fp *FilePicker) View() string {
 		Foreground(lipgloss.Color("240")).
 		Padding(1, 0, 0, 0)
 
+	keys := fp.keys
 	var helpText string
-	switch fp.mode {
-	case FilePickerModeFile:
-		helpText = "↑/↓ 导航 | Enter 选择文件/进入目录 | ESC 取消"
-	case FilePickerModeDir:
-		helpText = "↑/↓ 导航 | Enter 进入目录 | Ctrl+D 选择当前目录 | ESC 取消"
-	case FilePickerModeBoth:
-		helpText = "↑/↓ 导航 | Enter 选择/进入 | Ctrl+D 选择当前目录 | ESC 取消"
-	}
-	helpText += " | Ctrl+H 显示隐藏文件 | Home 回到主目录"
+	switch {
+	case fp.editingPath:
+		helpText = i18n.T("输入路径 | Tab 补全 | Enter 打开目录/选择文件 | ESC 返回列表")
+	case fp.showPlaces:
+		helpText = i18n.T("↑/↓ 导航 | Enter 进入书签/选择最近文件 | ESC 返回列表")
+	case fp.creating:
+		helpText = i18n.T("输入新文件名 | Tab 补全 | Enter 新建 | ESC 返回列表")
+	case fp.mode == FilePickerModeFile:
+		helpText = i18n.T("↑/↓ 导航 | Enter 选择文件/进入目录 | ESC 取消")
+	case fp.mode == FilePickerModeDir:
+		helpText = i18n.Sprintf("↑/↓ 导航 | Enter 进入目录 | %s 选择当前目录 | ESC 取消", keys.ChooseDir.Help().Key)
+	case fp.mode == FilePickerModeBoth:
+		helpText = i18n.Sprintf("↑/↓ 导航 | Enter 选择/进入 | %s 选择当前目录 | ESC 取消", keys.ChooseDir.Help().Key)
+	case fp.mode == FilePickerModeSave:
+		helpText = i18n.Sprintf("↑/↓ 导航 | Tab 补全/进入目录/使用已有文件名 | Enter 保存 | ESC 取消 | %s 隐藏文件", keys.ToggleHidden.Help().Key)
+	}
+	if !fp.namingFile() && !fp.editingPath && !fp.showPlaces {
+		helpText += i18n.Sprintf(" | %s 复制路径 | %s 显示隐藏文件 | %s 回到主目录",
+			keys.CopyPath.Help().Key, keys.ToggleHidden.Help().Key, keys.Home.Help().Key)
+	}
+	if !fp.editingPath && !fp.showPlaces {
+		helpText += " | " + i18n.Sprintf("%s 输入路径 | %s 书签/最近文件", keys.EditPath.Help().Key, keys.Places.Help().Key)
+		if fp.allowCreate && !fp.creating {
+			helpText += " | " + i18n.Sprintf("%s 新建文件", keys.NewFile.Help().Key)
+		}
+	}
 
 	content.WriteString(helpStyle.Render(helpText))
 
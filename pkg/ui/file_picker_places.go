@@ -0,0 +1,295 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// recentFilesMsg 文件选择器更新了最近文件，需要同步到内存中的应用设置
+type recentFilesMsg struct {
+	files []string
+}
+
+// filePlace 书签目录或最近文件
+type filePlace struct {
+	path   string
+	isDir  bool
+	recent bool
+}
+
+// loadPlaces 从应用设置读取书签和最近文件，只保留仍然存在的目录和符合扩展名的文件
+func (fp *FilePicker) loadPlaces() {
+	fp.places = nil
+	settings, err := config.LoadAppSettings()
+	if err != nil {
+		settings = config.DefaultAppSettings()
+	}
+
+	seen := make(map[string]bool)
+	bookmarks := append([]string{config.GetDefaultWorkDir(), "/etc/frp"}, settings.FileBookmarks...)
+	for _, dir := range bookmarks {
+		dir = fp.resolvePath(dir)
+		if info, err := os.Stat(dir); err == nil && info.IsDir() && !seen[dir] {
+			seen[dir] = true
+			fp.places = append(fp.places, filePlace{path: dir, isDir: true})
+		}
+	}
+
+	for _, file := range settings.RecentFiles {
+		info, err := os.Stat(file)
+		if err != nil || info.IsDir() || !fp.allowedFile(file) || seen[file] {
+			continue
+		}
+		seen[file] = true
+		fp.places = append(fp.places, filePlace{path: file, recent: true})
+	}
+}
+
+// updatePlaces 处理书签与最近文件列表中的按键，Enter 进入书签目录或选择最近文件
+func (fp *FilePicker) updatePlaces(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case msg.String() == "esc", key.Matches(msg, fp.keys.Places):
+		fp.showPlaces = false
+	case key.Matches(msg, fp.keys.Up):
+		if fp.placeIdx > 0 {
+			fp.placeIdx--
+		}
+	case key.Matches(msg, fp.keys.Down):
+		if fp.placeIdx < len(fp.places)-1 {
+			fp.placeIdx++
+		}
+	case msg.String() == "enter":
+		if fp.placeIdx >= len(fp.places) {
+			return nil
+		}
+		place := fp.places[fp.placeIdx]
+		fp.showPlaces = false
+		if place.isDir {
+			fp.changeDir(place.path)
+			return nil
+		}
+		return fp.openPath(place.path)
+	}
+	return nil
+}
+
+// updatePath 处理路径输入框中的按键，Tab 补全，Enter 打开输入的路径
+func (fp *FilePicker) updatePath(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		fp.editingPath = false
+		fp.pathInput.Blur()
+		return fp.refocusName()
+	case "tab":
+		fp.pathInput.SetValue(fp.completePath(fp.pathInput.Value()))
+		fp.pathInput.CursorEnd()
+		return nil
+	case "enter":
+		path := strings.TrimSpace(fp.pathInput.Value())
+		if path == "" {
+			return nil
+		}
+		fp.editingPath = false
+		fp.pathInput.Blur()
+		return tea.Batch(fp.openPath(fp.resolvePath(path)), fp.refocusName())
+	}
+
+	var cmd tea.Cmd
+	fp.pathInput, cmd = fp.pathInput.Update(msg)
+	return cmd
+}
+
+// startPathInput 打开路径输入框，预先填入当前目录
+func (fp *FilePicker) startPathInput() tea.Cmd {
+	fp.editingPath = true
+	fp.message = ""
+	fp.nameInput.Blur()
+	fp.pathInput.SetValue(strings.TrimSuffix(fp.currentPath, string(filepath.Separator)) + string(filepath.Separator))
+	fp.pathInput.CursorEnd()
+	return fp.pathInput.Focus()
+}
+
+// refocusName 保存或新建文件时把焦点还给文件名输入框
+func (fp *FilePicker) refocusName() tea.Cmd {
+	if fp.visible && fp.namingFile() {
+		return fp.nameInput.Focus()
+	}
+	return nil
+}
+
+// openPath 打开输入或选中的路径：目录进入该目录，已有文件直接选择，
+// 保存或新建文件时把路径拆成目录和文件名，不存在的路径只在可以新建文件时接受
+func (fp *FilePicker) openPath(path string) tea.Cmd {
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && info.IsDir():
+		fp.changeDir(path)
+		return nil
+	case fp.namingFile():
+		if _, err := os.Stat(filepath.Dir(path)); err != nil {
+			fp.message = i18n.Sprintf("❌ 目录不存在: %s", filepath.Dir(path))
+			return nil
+		}
+		fp.changeDir(filepath.Dir(path))
+		fp.SetFileName(filepath.Base(path))
+		return nil
+	case err == nil && fp.mode != FilePickerModeDir:
+		return fp.choose(path, false)
+	case err == nil:
+		fp.changeDir(filepath.Dir(path))
+		return nil
+	case fp.allowCreate:
+		if _, err := os.Stat(filepath.Dir(path)); err == nil {
+			return fp.choose(path, false)
+		}
+	}
+	fp.message = i18n.Sprintf("❌ 路径不存在: %s", path)
+	return nil
+}
+
+// changeDir 切换到目录并重新读取内容
+func (fp *FilePicker) changeDir(dir string) {
+	fp.currentPath = dir
+	fp.selectedIdx = 0
+	fp.message = ""
+	fp.loadDirectory()
+}
+
+// resolvePath 展开 ~ 并把相对路径解析为相对当前目录的路径，空字符串表示当前目录
+func (fp *FilePicker) resolvePath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+		}
+	}
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(fp.currentPath, path)
+}
+
+// completePath 按目录中已有的文件和目录补全路径的最后一段，
+// 有多个候选时补全到共同前缀，唯一匹配的目录补上路径分隔符
+func (fp *FilePicker) completePath(value string) string {
+	i := strings.LastIndexAny(value, "/"+string(filepath.Separator))
+	dirPart, prefix := value[:i+1], value[i+1:]
+
+	entries, err := os.ReadDir(fp.resolvePath(dirPart))
+	if err != nil {
+		return value
+	}
+
+	var matches []os.DirEntry
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if strings.HasPrefix(name, ".") && !fp.showHidden && !strings.HasPrefix(prefix, ".") {
+			continue
+		}
+		matches = append(matches, entry)
+	}
+	if len(matches) == 0 {
+		return value
+	}
+
+	common := matches[0].Name()
+	for _, entry := range matches[1:] {
+		common = commonPrefix(common, entry.Name())
+	}
+	if len(matches) == 1 && matches[0].IsDir() {
+		common += string(filepath.Separator)
+	}
+	return dirPart + common
+}
+
+// commonPrefix 返回两个字符串的共同前缀
+func commonPrefix(a, b string) string {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return a[:n]
+}
+
+// recordRecentFile 把选择的文件加入应用设置中的最近文件并保存，保存失败时不影响选择结果
+func recordRecentFile(path string) tea.Cmd {
+	return func() tea.Msg {
+		settings, err := config.LoadAppSettings()
+		if err != nil {
+			return nil
+		}
+		settings.AddRecentFile(path)
+		if err := config.SaveAppSettings(settings); err != nil {
+			return nil
+		}
+		return recentFilesMsg{files: settings.RecentFiles}
+	}
+}
+
+// renderPlaces 渲染书签与最近文件列表
+func (fp *FilePicker) renderPlaces(height int) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Padding(0, 1)
+	normalStyle := lipgloss.NewStyle().Padding(0, 1)
+
+	var content strings.Builder
+	lines := 0
+	writeLine := func(line string) {
+		if lines < height {
+			content.WriteString(line + "\n")
+			lines++
+		}
+	}
+
+	for i, place := range fp.places {
+		if i == 0 && place.isDir {
+			writeLine(headerStyle.Render(i18n.T("📌 书签")))
+		}
+		if place.recent && (i == 0 || !fp.places[i-1].recent) {
+			writeLine(headerStyle.Render(i18n.T("🕘 最近文件")))
+		}
+
+		icon := "📁"
+		if place.recent {
+			icon = "📄"
+		}
+		line := icon + " " + place.path
+		if i == fp.placeIdx {
+			writeLine(selectedStyle.Render("▶ " + line))
+		} else {
+			writeLine(normalStyle.Render("  " + line))
+		}
+	}
+	if !fp.hasRecent() {
+		writeLine(hintStyle.Render(i18n.T("还没有最近文件，选择或保存文件后会显示在这里")))
+	}
+
+	for ; lines < height; lines++ {
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// hasRecent 书签列表中是否有最近文件
+func (fp *FilePicker) hasRecent() bool {
+	for _, place := range fp.places {
+		if place.recent {
+			return true
+		}
+	}
+	return false
+}
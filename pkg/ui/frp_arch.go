@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// canReinstall 是否检测到与本机系统或架构不符的程序，可以重新下载适用于本机的版本
+func (st *SettingsTab) canReinstall() bool {
+	return st.installStatus != nil && len(st.installStatus.Mismatches) > 0 && !st.isInstalling
+}
+
+// reinstallFRP 重新下载适用于本机的 FRP，替换从其他机器拷贝来的程序
+func (st *SettingsTab) reinstallFRP() tea.Cmd {
+	st.events.Publish(service.UserActionEvent(service.ActionFrpInstall, i18n.Sprintf("重新安装适用于 %s/%s 的 FRP", runtime.GOOS, runtime.GOARCH)))
+	st.isInstalling = true
+	successMessage := i18n.Sprintf("✅ 已重新安装适用于 %s/%s 的 FRP", runtime.GOOS, runtime.GOARCH)
+	if st.installer.NeedsElevation() {
+		return st.installElevated(successMessage, false)
+	}
+	st.installProgress = i18n.Sprintf("正在下载适用于 %s/%s 的 FRP...", runtime.GOOS, runtime.GOARCH)
+
+	return st.runWithDownloadProgress(st.installer.ReinstallFRP, successMessage, false)
+}
+
+// renderArchMismatch 渲染无法在本机运行的程序和重新安装的提示
+func (st *SettingsTab) renderArchMismatch() string {
+	if st.installStatus == nil || len(st.installStatus.Mismatches) == 0 {
+		return ""
+	}
+
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	var content string
+	for _, mismatch := range st.installStatus.Mismatches {
+		content += style.Render("❌ "+mismatch.Error()) + "\n"
+	}
+	content += i18n.Sprintf("🔧 按 %s 重新下载适用于 %s/%s 的 FRP %s\n",
+		st.keys.Settings.Install.Help().Key, runtime.GOOS, runtime.GOARCH, st.installer.GetVersion())
+	return content
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// frpVerifyItem 一个配置文件的 verify 结果
+type frpVerifyItem struct {
+	label  string
+	result *service.VerifyResult
+	err    error
+}
+
+// frpVerifyMsg frps/frpc verify 检查完成
+type frpVerifyMsg struct {
+	items []frpVerifyItem
+}
+
+// frpVerify 用 frp 程序自身检查配置文件的状态
+type frpVerify struct {
+	running bool
+	items   []frpVerifyItem
+}
+
+// handleFrpVerify 打开检查结果页面，并在后台用已安装的 frps/frpc 检查磁盘上的配置文件
+func (ct *ConfigTab) handleFrpVerify() (Tab, tea.Cmd) {
+	tab, dnsCmd := ct.handlePreviewConfig()
+	return tab, tea.Batch(dnsCmd, ct.runFrpVerify())
+}
+
+// runFrpVerify 在后台依次执行 frps verify 和 frpc verify
+func (ct *ConfigTab) runFrpVerify() tea.Cmd {
+	ct.verify = &frpVerify{running: true}
+	if ct.preview != nil {
+		ct.refreshPreview()
+	}
+
+	targets := []struct {
+		label   string
+		service string
+		path    string
+	}{
+		{i18n.T("服务端"), "server", ct.serverConfigPath},
+		{i18n.T("客户端"), "client", ct.clientConfigPath},
+	}
+	return func() tea.Msg {
+		var items []frpVerifyItem
+		for _, target := range targets {
+			result, err := service.VerifyConfigFile(target.service, target.path)
+			items = append(items, frpVerifyItem{label: target.label, result: result, err: err})
+		}
+		return frpVerifyMsg{items: items}
+	}
+}
+
+// handleFrpVerifyResult 记录检查结果并刷新预览
+func (ct *ConfigTab) handleFrpVerifyResult(msg frpVerifyMsg) {
+	ct.verify = &frpVerify{items: msg.items}
+	if ct.preview != nil {
+		ct.refreshPreview()
+	}
+}
+
+// renderFrpVerify 渲染 frp verify 的输出，尚未检查时返回空字符串
+func (ct *ConfigTab) renderFrpVerify() string {
+	if ct.verify == nil {
+		return ""
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	content := "\n" + lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39")).Render(i18n.T("🧪 frp verify:")) + "\n"
+	if ct.verify.running {
+		return content + hintStyle.Render(i18n.T("⏳ 正在使用 frps/frpc 检查配置文件...")) + "\n"
+	}
+
+	for _, item := range ct.verify.items {
+		switch {
+		case item.err != nil:
+			content += lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render("⚠️ "+item.label+": "+item.err.Error()) + "\n"
+			continue
+		case item.result.Passed:
+			content += lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render("✅ "+item.label+": "+item.result.ConfigPath) + "\n"
+		default:
+			content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+item.label+": "+item.result.ConfigPath) + "\n"
+		}
+		for _, line := range strings.Split(item.result.Output, "\n") {
+			if line != "" {
+				content += hintStyle.Render("   "+line) + "\n"
+			}
+		}
+	}
+	content += hintStyle.Render(i18n.Sprintf("检查的是磁盘上的配置文件，未保存的修改请先保存；%s 重新检查", ct.keys.Config.Verify.Help().Key)) + "\n"
+	return content
+}
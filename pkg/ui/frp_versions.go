@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/installer"
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// installedVersions 返回默认安装目录中并存的版本
+func (st *SettingsTab) installedVersions() []installer.InstalledVersion {
+	if st.installStatus == nil {
+		return nil
+	}
+	return st.installStatus.Versions
+}
+
+// previousVersion 返回可以回滚到的上一个版本，没有时返回空
+func (st *SettingsTab) previousVersion() string {
+	for _, v := range st.installedVersions() {
+		if v.Previous && !v.Current {
+			return v.Version
+		}
+	}
+	return ""
+}
+
+// canRollback 是否可以回滚到上一个版本
+func (st *SettingsTab) canRollback() bool {
+	return st.previousVersion() != "" && !st.isInstalling
+}
+
+// openInstalledVersions 打开已安装版本列表，光标停在当前版本上
+func (st *SettingsTab) openInstalledVersions() {
+	st.managingVersions = true
+	st.installedCursor = 0
+	for i, v := range st.installedVersions() {
+		if v.Current {
+			st.installedCursor = i
+		}
+	}
+}
+
+// updateInstalledVersions 处理已安装版本列表的按键
+func (st *SettingsTab) updateInstalledVersions(msg tea.KeyMsg) tea.Cmd {
+	versions := st.installedVersions()
+	if st.installedCursor >= len(versions) {
+		st.installedCursor = max(len(versions)-1, 0)
+	}
+
+	keys := st.keys.Settings
+	switch {
+	case key.Matches(msg, keys.Up):
+		if st.installedCursor > 0 {
+			st.installedCursor--
+		}
+	case key.Matches(msg, keys.Down):
+		if st.installedCursor < len(versions)-1 {
+			st.installedCursor++
+		}
+	case key.Matches(msg, keys.ServiceTarget):
+		// 切换固定版本的目标服务，与系统服务操作目标共用
+		if st.serviceTarget == "frps" {
+			st.serviceTarget = "frpc"
+		} else {
+			st.serviceTarget = "frps"
+		}
+	case key.Matches(msg, keys.UseVersion):
+		if len(versions) > 0 {
+			return st.useVersion(versions[st.installedCursor].Version)
+		}
+	case key.Matches(msg, keys.PinVersion):
+		if len(versions) > 0 {
+			return st.togglePinnedVersion(versions[st.installedCursor].Version)
+		}
+	case key.Matches(msg, keys.RemoveVersion):
+		if len(versions) > 0 {
+			return st.removeVersion(versions[st.installedCursor].Version)
+		}
+	case key.Matches(msg, keys.CloseVersions):
+		st.managingVersions = false
+	}
+	return nil
+}
+
+// useVersion 把 current 切换到已安装的版本，不需要重新下载
+func (st *SettingsTab) useVersion(version string) tea.Cmd {
+	st.events.Publish(service.UserActionEvent(service.ActionFrpInstall, i18n.Sprintf("切换 FRP 版本到 %s", version)))
+	if err := st.installer.UseVersion(version); err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+	notice := i18n.Sprintf("✅ 已切换到 FRP %s", version) + st.restartHint()
+	return tea.Batch(showStatusMessage(notice, false), st.refreshInstallStatus())
+}
+
+// rollbackFRP 切换回上一个使用的版本，用于升级后出现问题时快速恢复
+func (st *SettingsTab) rollbackFRP() tea.Cmd {
+	st.events.Publish(service.UserActionEvent(service.ActionFrpInstall, i18n.Sprintf("回滚 FRP 到 %s", st.previousVersion())))
+	version, err := st.installer.Rollback()
+	if err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+	notice := i18n.Sprintf("↩ 已回滚到 FRP %s", version) + st.restartHint()
+	return tea.Batch(showStatusMessage(notice, false), st.refreshInstallStatus())
+}
+
+// removeVersion 删除一个不再使用的版本
+func (st *SettingsTab) removeVersion(version string) tea.Cmd {
+	if err := st.installer.RemoveVersion(version); err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+	return tea.Batch(showStatusMessage(i18n.Sprintf("🗑 已删除 FRP %s", version), false), st.refreshInstallStatus())
+}
+
+// togglePinnedVersion 让当前目标服务（v 切换）固定使用该版本，已固定时改回跟随 current
+func (st *SettingsTab) togglePinnedVersion(version string) tea.Cmd {
+	settings := *st.appSettings
+	pinned := &settings.ServerVersion
+	if st.serviceTarget == "frpc" {
+		pinned = &settings.ClientVersion
+	}
+	notice := i18n.Sprintf("📌 %s 固定使用 FRP %s", st.serviceTarget, version)
+	if *pinned == version {
+		version = ""
+		notice = i18n.Sprintf("📌 %s 改为使用当前版本", st.serviceTarget)
+	}
+	*pinned = version
+
+	if err := config.SaveAppSettings(&settings); err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+	st.installer.ApplySettings(&settings)
+	notice += st.restartHint()
+	return tea.Batch(
+		func() tea.Msg { return appSettingsChangedMsg{settings: &settings, notice: notice} },
+		st.refreshInstallStatus(),
+	)
+}
+
+// restartHint 有正在运行的 frps/frpc 时提示重启后才会使用新版本
+func (st *SettingsTab) restartHint() string {
+	if st.serverStatus == "运行中" || st.clientStatus == "已连接" || st.clientStatus == "连接中" {
+		return i18n.T("，重启 frps/frpc 后生效")
+	}
+	return ""
+}
+
+// renderVersionSummary 渲染并存的版本、固定版本和回滚提示
+func (st *SettingsTab) renderVersionSummary() string {
+	versions := st.installedVersions()
+	if len(versions) == 0 {
+		return ""
+	}
+
+	var names string
+	for i, v := range versions {
+		if i > 0 {
+			names += ", "
+		}
+		names += v.Version
+		if v.Current {
+			names += i18n.T(" (当前)")
+		}
+	}
+	summary := i18n.Sprintf("🗂 已安装版本: %s\n", names)
+	if st.appSettings.ServerVersion != "" {
+		summary += i18n.Sprintf("📌 frps 固定使用: %s\n", st.appSettings.ServerVersion)
+	}
+	if st.appSettings.ClientVersion != "" {
+		summary += i18n.Sprintf("📌 frpc 固定使用: %s\n", st.appSettings.ClientVersion)
+	}
+	if previous := st.previousVersion(); previous != "" {
+		summary += i18n.Sprintf("↩ 按 %s 回滚到 %s，无需重新下载\n", st.keys.Settings.Rollback.Help().Key, previous)
+	}
+	return summary
+}
+
+// renderInstalledVersions 渲染已安装版本列表
+func (st *SettingsTab) renderInstalledVersions() string {
+	content := lipgloss.NewStyle().Bold(true).Render(i18n.T("🗂 已安装版本")) + "\n"
+
+	versions := st.installedVersions()
+	if len(versions) == 0 {
+		content += i18n.T("没有按版本安装的 FRP，安装或更新后会保留在这里\n")
+	}
+	for i, v := range versions {
+		line := v.Version
+		if v.Current {
+			line += i18n.T(" ✓ 当前")
+		}
+		if v.Previous {
+			line += i18n.T(" ↩ 上一个")
+		}
+		if v.Version == st.appSettings.ServerVersion {
+			line += i18n.T(" 📌 frps")
+		}
+		if v.Version == st.appSettings.ClientVersion {
+			line += i18n.T(" 📌 frpc")
+		}
+
+		if i == st.installedCursor {
+			content += lipgloss.NewStyle().
+				Foreground(lipgloss.Color("229")).
+				Background(lipgloss.Color("57")).
+				Render("▶ "+line) + "\n"
+		} else {
+			content += "  " + line + "\n"
+		}
+	}
+
+	keys := st.keys.Settings
+	hint := i18n.Sprintf("↑/↓ 选择 • %s 设为当前 • %s 固定给 %s • %s 切换 frps/frpc • %s 删除 • ESC 关闭",
+		keys.UseVersion.Help().Key, keys.PinVersion.Help().Key, st.serviceTarget,
+		keys.ServiceTarget.Help().Key, keys.RemoveVersion.Help().Key)
+	content += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(hint)
+	return content
+}
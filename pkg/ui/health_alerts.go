@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	constants "frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// alertBannerMaxLines 告警横幅最多显示的告警条数
+const alertBannerMaxLines = 3
+
+// healthAlertMsg 健康监控发出的告警或恢复通知
+type healthAlertMsg struct {
+	alert service.Alert
+}
+
+// waitForHealthAlert 等待下一条健康告警
+func waitForHealthAlert(ch <-chan service.Alert) tea.Cmd {
+	return func() tea.Msg {
+		alert, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return healthAlertMsg{alert: alert}
+	}
+}
+
+// applyMonitorSettings 更新健康监控选项，间隔为 0 时停止监控
+func (m *MainDashboard) applyMonitorSettings(settings *constants.AppSettings) {
+	if m.monitor == nil {
+		return
+	}
+
+	m.monitor.SetOptions(service.MonitorOptionsFromSettings(settings))
+	if settings.MonitorInterval > 0 {
+		m.monitor.Start()
+	} else {
+		m.monitor.Stop()
+		m.monitor.Dismiss()
+		m.alerts = nil
+	}
+}
+
+// handleHealthAlert 刷新告警横幅，恢复通知显示在状态栏
+func (m *MainDashboard) handleHealthAlert(msg healthAlertMsg) tea.Cmd {
+	m.alerts = m.monitor.ActiveAlerts()
+
+	cmds := []tea.Cmd{waitForHealthAlert(m.monitor.Alerts())}
+	if msg.alert.Resolved {
+		cmds = append(cmds, showStatusMessage("✅ "+msg.alert.Title, false))
+	}
+	return tea.Batch(cmds...)
+}
+
+// dismissAlerts 忽略当前所有告警
+func (m *MainDashboard) dismissAlerts() {
+	if m.monitor != nil {
+		m.monitor.Dismiss()
+	}
+	m.alerts = nil
+}
+
+// renderAlertBanner 渲染告警横幅，没有告警时返回空字符串
+func (m *MainDashboard) renderAlertBanner(width int) string {
+	if len(m.alerts) == 0 {
+		return ""
+	}
+
+	bannerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Background(lipgloss.Color("160")).
+		Bold(true).
+		Padding(0, 1).
+		Width(width)
+
+	var content string
+	for i, alert := range m.alerts {
+		if i == alertBannerMaxLines {
+			content += i18n.Sprintf("... 另有 %d 条告警\n", len(m.alerts)-alertBannerMaxLines)
+			break
+		}
+		icon := "⚠️"
+		if alert.Severity == service.AlertCritical {
+			icon = "🚨"
+		}
+		line := fmt.Sprintf("%s %s %s", icon, alert.Time.Format("15:04:05"), alert.Title)
+		if alert.Message != "" {
+			line += " - " + alert.Message
+		}
+		content += truncateString(line, width-4) + "\n"
+	}
+	content += i18n.T("Ctrl+A: 忽略告警")
+
+	return bannerStyle.Render(content)
+}
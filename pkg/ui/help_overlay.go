@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// helpColumnGap 帮助页各列之间的空白
+const helpColumnGap = 4
+
+// isSettingsTabActive 当前是否为设置标签页
+func (m *MainDashboard) isSettingsTabActive() bool {
+	_, ok := m.tabRegistry.GetTabByIndex(m.activeTab).(*SettingsTab)
+	return ok
+}
+
+// renderHelpOverlay 渲染全屏快捷键帮助，内容由当前快捷键生成，当前标签页的分组排在全局之后并高亮
+func (m *MainDashboard) renderHelpOverlay() string {
+	groups := m.keys.groups()
+	activeTitle := ""
+	if tab := m.tabRegistry.GetTabByIndex(m.activeTab); tab != nil {
+		activeTitle = tab.Title()
+	}
+
+	ordered := []keyGroup{groups[0]}
+	for _, group := range groups[1:] {
+		if group.title == activeTitle {
+			ordered = append(ordered, group)
+		}
+	}
+	for _, group := range groups[1:] {
+		if group.title != activeTitle {
+			ordered = append(ordered, group)
+		}
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	activeStyle := titleStyle.Foreground(lipgloss.Color("205"))
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	// 每个分组渲染为一个块
+	var blocks []string
+	for _, group := range ordered {
+		keyWidth, descWidth := 0, 0
+		all := slices.Clone(group.bindings)
+		for _, panel := range group.panels {
+			all = append(all, panel.bindings...)
+		}
+		for _, nb := range all {
+			help := nb.binding.Help()
+			keyWidth = max(keyWidth, runewidth.StringWidth(help.Key))
+			descWidth = max(descWidth, runewidth.StringWidth(help.Desc))
+		}
+		line := func(nb namedBinding) string {
+			help := nb.binding.Help()
+			keyPadding := strings.Repeat(" ", keyWidth-runewidth.StringWidth(help.Key))
+			descPadding := strings.Repeat(" ", descWidth-runewidth.StringWidth(help.Desc))
+			return "\n  " + keyStyle.Render(help.Key) + keyPadding + "  " +
+				descStyle.Render(help.Desc) + descPadding + "  " + hintStyle.Render(nb.id)
+		}
+
+		// 标题后附分组名，每行末尾附操作名，便于在 keyBindings 中自定义
+		var b strings.Builder
+		if group.title == activeTitle {
+			b.WriteString(activeStyle.Render("▶ " + group.title + i18n.T("（当前）")))
+		} else {
+			b.WriteString(titleStyle.Render(group.title))
+		}
+		b.WriteString(hintStyle.Render(" " + group.id))
+		for _, nb := range group.bindings {
+			b.WriteString(line(nb))
+		}
+		// 子面板的快捷键列在面板名下，只有面板的分组不再重复标题
+		for _, panel := range group.panels {
+			if len(group.bindings) > 0 {
+				b.WriteString("\n" + descStyle.Bold(true).Render(panel.title))
+			}
+			for _, nb := range panel.bindings {
+				b.WriteString(line(nb))
+			}
+		}
+		blocks = append(blocks, b.String())
+	}
+
+	// 按宽度分列，每个块放入当前最短的一列
+	blockWidth := 0
+	for _, block := range blocks {
+		if w := lipgloss.Width(block); w > blockWidth {
+			blockWidth = w
+		}
+	}
+	columnCount := (m.width - 4 + helpColumnGap) / (blockWidth + helpColumnGap)
+	if columnCount < 1 {
+		columnCount = 1
+	}
+	if columnCount > len(blocks) {
+		columnCount = len(blocks)
+	}
+
+	columns := make([][]string, columnCount)
+	heights := make([]int, columnCount)
+	for _, block := range blocks {
+		shortest := 0
+		for i := range heights {
+			if heights[i] < heights[shortest] {
+				shortest = i
+			}
+		}
+		columns[shortest] = append(columns[shortest], block)
+		heights[shortest] += lipgloss.Height(block) + 1
+	}
+
+	columnStyle := lipgloss.NewStyle().Width(blockWidth).MarginRight(helpColumnGap)
+	rendered := make([]string, columnCount)
+	for i, column := range columns {
+		rendered[i] = columnStyle.Render(strings.Join(column, "\n\n"))
+	}
+
+	content := titleStyle.Render(i18n.T("⌨️  快捷键帮助")) + "\n\n" +
+		lipgloss.JoinHorizontal(lipgloss.Top, rendered...) + "\n\n" +
+		hintStyle.Render(i18n.T("表单、确认框和弹窗中使用 Enter 确认、ESC 取消、y 确认操作，输入框中 Tab/↑/↓ 切换焦点和补全，这些按键不可自定义。")) + "\n" +
+		hintStyle.Render(i18n.T("在 settings.yaml 的 keyBindings 中按 \"<分组>.<操作>\" 自定义快捷键，如 global.quit: \"q,ctrl+c\"")) + "\n\n" +
+		hintStyle.Render(m.keys.Global.Help.Help().Key+i18n.T("/ESC: 关闭帮助"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		lipgloss.NewStyle().Padding(1, 2).Render(content))
+}
@@ -0,0 +1,243 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// iniMigration INI 迁移向导状态
+type iniMigration struct {
+	sourcePath string
+	targetPath string
+	format     config.ConfigFormat
+	result     *config.INIImportResult
+	diff       []config.DiffLine
+	message    string
+	err        error
+	written    bool
+}
+
+// newINIMigration 读取 INI 文件并生成迁移预览
+func newINIMigration(sourcePath string) *iniMigration {
+	m := &iniMigration{
+		sourcePath: sourcePath,
+		format:     config.FormatYAML,
+	}
+
+	result, err := config.ImportINIFile(sourcePath)
+	if err != nil {
+		m.err = err
+		return m
+	}
+	m.result = result
+	m.refresh()
+
+	return m
+}
+
+// toggleFormat 在 YAML 与 TOML 输出格式之间切换
+func (m *iniMigration) toggleFormat() {
+	if m.format == config.FormatYAML {
+		m.format = config.FormatTOML
+	} else {
+		m.format = config.FormatYAML
+	}
+	m.refresh()
+}
+
+// refresh 重新计算目标路径和差异预览
+func (m *iniMigration) refresh() {
+	if m.result == nil {
+		return
+	}
+
+	m.targetPath = config.INITargetPath(m.sourcePath, m.format)
+
+	newData, err := config.MarshalConfig(m.result.Config, m.format)
+	if err != nil {
+		m.err = i18n.Errorf("生成配置失败: %w", err)
+		return
+	}
+
+	// 目标文件已存在时与现有内容比较，否则整份内容均为新增
+	var oldData []byte
+	if data, err := os.ReadFile(m.targetPath); err == nil {
+		oldData = data
+	}
+	m.diff = config.DiffText(string(oldData), string(newData))
+}
+
+// write 写入迁移后的配置文件
+func (m *iniMigration) write() error {
+	if m.result == nil {
+		return i18n.Errorf("没有可写入的配置")
+	}
+
+	loader := config.NewLoader(m.targetPath)
+	if err := loader.Save(m.result.Config); err != nil {
+		return err
+	}
+
+	m.written = true
+	m.message = i18n.Sprintf("✅ 已写入 %s", m.targetPath)
+	return nil
+}
+
+// handleImportINI 打开 INI 文件选择器
+func (ct *ConfigTab) handleImportINI() (Tab, tea.Cmd) {
+	ct.state = ConfigTabMenu
+	ct.filePicker = NewFilePicker(i18n.T("选择旧版 INI 配置文件"), FilePickerModeFile)
+	ct.filePicker.SetKeyMap(ct.keys)
+	ct.filePicker.SetExtensions([]string{".ini"})
+	ct.filePicker.SetStartPath(config.GetDefaultWorkDir())
+	ct.filePicker.SetSize(ct.width, ct.height)
+	return ct, ct.filePicker.Show()
+}
+
+// startINIMigration 根据选择的 INI 文件进入迁移预览
+func (ct *ConfigTab) startINIMigration(path string) (Tab, tea.Cmd) {
+	ct.migration = newINIMigration(path)
+	ct.state = ConfigTabMigration
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	return ct, nil
+}
+
+// updateMigration 处理迁移预览中的按键
+func (ct *ConfigTab) updateMigration(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	m := ct.migration
+
+	switch {
+	case msg.String() == "esc":
+		ct.migration = nil
+		ct.state = ConfigTabMenu
+	case key.Matches(msg, ct.keys.Config.MigrationFormat):
+		if m.result != nil && !m.written {
+			m.toggleFormat()
+		}
+	case msg.String() == "enter" || msg.String() == "y":
+		if m.result == nil || m.written {
+			return ct, nil
+		}
+		if err := m.write(); err != nil {
+			m.err = err
+			return ct, nil
+		}
+
+		ct.events.Publish(service.ConfigSavedEvent(m.result.ConfigType, m.targetPath))
+
+		// 写入成功后将迁移结果作为当前配置
+		switch m.result.ConfigType {
+		case "server":
+			ct.serverConfig = m.result.Config
+			ct.serverConfigPath = m.targetPath
+		case "client":
+			ct.clientConfig = m.result.Config
+			ct.clientConfigPath = m.targetPath
+		}
+		ct.history.reset(i18n.T("导入 INI 配置 ")+filepath.Base(m.sourcePath), ct.serverConfig, ct.clientConfig)
+	}
+
+	return ct, nil
+}
+
+// renderMigration 渲染迁移预览
+func (ct *ConfigTab) renderMigration(width int) string {
+	m := ct.migration
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+	content := titleStyle.Render(i18n.T("📥 INI 配置迁移")) + "\n\n"
+	content += i18n.Sprintf("源文件: %s\n", m.sourcePath)
+
+	if m.err != nil && m.result == nil {
+		content += "\n" + errorStyle.Render("❌ "+m.err.Error()) + "\n\n"
+		content += hintStyle.Render(i18n.T("按 ESC 返回菜单"))
+		return content
+	}
+
+	typeLabel := i18n.T("客户端")
+	if m.result.ConfigType == "server" {
+		typeLabel = i18n.T("服务端")
+	}
+	content += i18n.Sprintf("目标文件: %s (%s)\n", m.targetPath, strings.ToUpper(string(m.format)))
+	content += i18n.Sprintf("配置类型: %s | 代理: %d 个 | 访问者: %d 个\n\n",
+		typeLabel, len(m.result.Config.Proxies), len(m.result.Config.Visitors))
+
+	if len(m.result.Warnings) > 0 {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Bold(true).Render(i18n.T("⚠️ 迁移提示")) + "\n"
+		for _, warning := range m.result.Warnings {
+			content += "• " + warning + "\n"
+		}
+		content += "\n"
+	}
+
+	content += lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render(i18n.T("📝 变更预览")) + "\n"
+	content += renderDiff(m.diff, width)
+	content += "\n"
+
+	if m.err != nil {
+		content += errorStyle.Render("❌ "+m.err.Error()) + "\n"
+	}
+	if m.message != "" {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render(m.message) + "\n"
+	}
+
+	if m.written {
+		content += hintStyle.Render(i18n.T("按 ESC 返回菜单"))
+	} else {
+		content += hintStyle.Render(i18n.Sprintf("Enter/y 写入文件 | %s 切换 YAML/TOML | ESC 取消", ct.keys.Config.MigrationFormat.Help().Key))
+	}
+
+	return content
+}
+
+// renderDiff 渲染逐行差异
+func renderDiff(diff []config.DiffLine, width int) string {
+	addStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	delStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	equalStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+
+	var b strings.Builder
+	for _, line := range diff {
+		text := line.Text
+		if width > 4 {
+			text = truncateString(text, width-2)
+		}
+
+		switch line.Op {
+		case config.DiffInsert:
+			b.WriteString(addStyle.Render("+ " + text))
+		case config.DiffDelete:
+			b.WriteString(delStyle.Render("- " + text))
+		default:
+			b.WriteString(equalStyle.Render("  " + text))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(diff) == 0 {
+		b.WriteString(equalStyle.Render(i18n.T("  (无变更)")) + "\n")
+	}
+
+	return b.String()
+}
+
+// truncateString 按显示宽度截断字符串
+func truncateString(s string, width int) string {
+	return runewidth.Truncate(s, width, "…")
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// stagedInstallMsg FRP 已下载并解压到临时目录，等待通过 sudo 安装
+type stagedInstallMsg struct {
+	dir            string
+	successMessage string
+	initWorkspace  bool
+}
+
+// installElevated 当前用户无权写入安装目录时，先在后台下载解压到临时目录，再让出终端通过 sudo 安装
+func (st *SettingsTab) installElevated(successMessage string, initWorkspace bool) tea.Cmd {
+	st.installProgress = i18n.Sprintf("正在下载 FRP，完成后需要通过 sudo 安装到 %s...", st.installer.GetInstallDir())
+	return st.stageElevated(st.installer.StageFRP, successMessage, initWorkspace)
+}
+
+// stageElevated 在后台由 stage 准备好临时目录中的程序，完成后通过 sudo 安装
+func (st *SettingsTab) stageElevated(stage func() (string, error), successMessage string, initWorkspace bool) tea.Cmd {
+	if err := st.installer.CheckNotPackageManaged(); err != nil {
+		return func() tea.Msg { return installProgressMsg{done: true, err: err} }
+	}
+
+	var staged string
+	return st.runDownload(func() error {
+		var err error
+		staged, err = stage()
+		return err
+	}, func(err error) tea.Msg {
+		if err != nil {
+			return installProgressMsg{done: true, err: err}
+		}
+		return stagedInstallMsg{dir: staged, successMessage: successMessage, initWorkspace: initWorkspace}
+	})
+}
+
+// runElevatedInstall 暂停界面并在终端中执行 sudo，结束后删除临时目录并恢复界面
+func (st *SettingsTab) runElevatedInstall(msg stagedInstallMsg) tea.Cmd {
+	st.download = nil
+	st.installProgress = i18n.Sprintf("🔐 请在终端中输入 sudo 密码，安装到 %s", st.installer.GetInstallDir())
+
+	return tea.ExecProcess(st.installer.ElevatedInstallCommand(msg.dir), func(err error) tea.Msg {
+		os.RemoveAll(msg.dir)
+		if err != nil {
+			return installProgressMsg{done: true, err: i18n.Errorf("sudo 安装失败: %w", err)}
+		}
+		return installProgressMsg{message: msg.successMessage, done: true, initWorkspace: msg.initWorkspace}
+	})
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"frp-cli-ui/internal/installer"
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// openArchivePicker 打开文件选择器，选择从 GitHub 发布页拷贝过来的 frp 压缩包
+func (st *SettingsTab) openArchivePicker() tea.Cmd {
+	st.filePicker = NewFilePicker(i18n.T("📦 选择 FRP 安装包 (frp_<版本>_<系统>_<架构>.tar.gz/zip，校验文件放在同一目录)"), FilePickerModeFile)
+	st.filePicker.SetKeyMap(st.keys)
+	st.filePicker.SetExtensions([]string{".gz", ".zip"})
+	if home, err := os.UserHomeDir(); err == nil {
+		st.filePicker.SetStartPath(home)
+	}
+	st.filePicker.SetSize(st.width, st.height)
+	return st.filePicker.Show()
+}
+
+// installFromArchive 校验并安装选择的本地压缩包，不访问网络
+func (st *SettingsTab) installFromArchive(path string) tea.Cmd {
+	archive, err := installer.ParseArchive(path)
+	if err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+
+	st.events.Publish(service.UserActionEvent(service.ActionFrpInstall, i18n.Sprintf("从 %s 离线安装 FRP", archive.Path)))
+	st.isInstalling = true
+	initWorkspace := st.installStatus == nil || !st.installStatus.IsInstalled
+	successMessage := i18n.Sprintf("✅ 已从安装包安装 FRP %s", archive.Version)
+	if st.installer.NeedsElevation() {
+		st.installProgress = i18n.Sprintf("正在解压 %s，完成后需要通过 sudo 安装到 %s...", filepath.Base(archive.Path), st.installer.GetInstallDir())
+		return st.stageElevated(func() (string, error) {
+			return st.installer.StageArchive(archive.Path)
+		}, successMessage, initWorkspace)
+	}
+	st.installProgress = i18n.Sprintf("正在从 %s 安装 FRP %s...", filepath.Base(archive.Path), archive.Version)
+
+	return st.runWithDownloadProgress(func() error {
+		return st.installer.InstallFromArchive(archive.Path)
+	}, successMessage, initWorkspace)
+}
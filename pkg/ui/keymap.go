@@ -0,0 +1,815 @@
+package ui
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// GlobalKeyMap 全局快捷键，任意标签页都可使用
+type GlobalKeyMap struct {
+	Quit          key.Binding
+	NextTab       key.Binding
+	PrevTab       key.Binding
+	StartServer   key.Binding
+	StopServer    key.Binding
+	StartClient   key.Binding
+	StopClient    key.Binding
+	DismissAlerts key.Binding
+	PauseRefresh  key.Binding
+	Suspend       key.Binding
+	Help          key.Binding
+	PageUp        key.Binding
+	PageDown      key.Binding
+}
+
+// DashboardKeyMap 仪表盘快捷键
+type DashboardKeyMap struct {
+	Up          key.Binding
+	Down        key.Binding
+	Detail      key.Binding
+	CloseDetail key.Binding
+	Copy        key.Binding
+	Probe       key.Binding
+	Autostart   key.Binding
+	Toggle      key.Binding
+	Target      key.Binding
+
+	LabelFilter  key.Binding
+	GroupByLabel key.Binding
+	CloseProxy   key.Binding
+	PauseProxy   key.Binding
+}
+
+// TrafficKeyMap 流量标签页快捷键
+type TrafficKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	Window  key.Binding
+	Refresh key.Binding
+}
+
+// ClientsKeyMap 客户端标签页快捷键
+type ClientsKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	Refresh key.Binding
+	Kick    key.Binding
+}
+
+// ConfigKeyMap 配置管理标签页快捷键（菜单获得焦点时，生成/同步令牌在表单获得焦点时生效）
+type ConfigKeyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	Select    key.Binding
+	Apply     key.Binding
+	Test      key.Binding
+	Wizard    key.Binding
+	Backups   key.Binding
+	Undo      key.Binding
+	Redo      key.Binding
+	History   key.Binding
+	Templates key.Binding
+
+	GenerateToken key.Binding
+	SyncToken     key.Binding
+
+	CopyClient key.Binding
+	CopyServer key.Binding
+
+	LineNumbers   key.Binding
+	PreviewFormat key.Binding
+
+	Verify    key.Binding
+	Proxies   key.Binding
+	Duplicate key.Binding
+	Diagnose  key.Binding
+	Pairing   key.Binding
+	Split     key.Binding
+	SplitAll  key.Binding
+
+	ImportServer key.Binding
+	SSHTunnel    key.Binding
+	Discover     key.Binding
+	NATCheck     key.Binding
+	RotateToken  key.Binding
+	Labels       key.Binding
+	Search       key.Binding
+	DeleteProxy  key.Binding
+
+	// 代理列表和服务端代理导入面板
+	Toggle    key.Binding
+	ToggleAll key.Binding
+
+	// 模板浏览器
+	ApplyTemplate      key.Binding
+	MergeTemplate      key.Binding
+	SaveServerTemplate key.Binding
+	SaveClientTemplate key.Binding
+	RenameTemplate     key.Binding
+	DeleteTemplate     key.Binding
+	OnlineTemplates    key.Binding
+	RefreshCatalog     key.Binding
+	ImportTemplate     key.Binding
+
+	// 部署包导出
+	BundleService  key.Binding
+	BundleTarget   key.Binding
+	BundleBinary   key.Binding
+	CopyBundlePath key.Binding
+
+	// 分享码
+	PrevShareProxy  key.Binding
+	NextShareProxy  key.Binding
+	CopyShareCode   key.Binding
+	ImportShareCode key.Binding
+
+	// STCP/XTCP 配对助手
+	CopyVisitor   key.Binding
+	ExportVisitor key.Binding
+
+	// INI 配置迁移
+	MigrationFormat key.Binding
+
+	// 配置预览
+	PreviewTop    key.Binding
+	PreviewBottom key.Binding
+
+	// 发现本机服务
+	DiscoverLAN key.Binding
+
+	// XTCP 打洞诊断
+	AddMapping     key.Binding
+	DeleteMappings key.Binding
+
+	// 令牌轮换
+	CopyToken key.Binding
+
+	// SSH 隧道命令
+	CopySSHCommand key.Binding
+
+	// 搜索配置，输入关键字时选择结果
+	SearchPrev key.Binding
+	SearchNext key.Binding
+}
+
+// SettingsKeyMap 设置标签页快捷键，服务启停使用全局快捷键
+type SettingsKeyMap struct {
+	Install        key.Binding
+	Update         key.Binding
+	Uninstall      key.Binding
+	PickVersion    key.Binding
+	Mirror         key.Binding
+	AppSettings    key.Binding
+	Refresh        key.Binding
+	Test           key.Binding
+	Reload         key.Binding
+	ServiceTarget  key.Binding
+	AutoRestart    key.Binding
+	InstallService key.Binding
+	ToggleBoot     key.Binding
+	RemoveService  key.Binding
+	AuditLog       key.Binding
+	UseExisting    key.Binding
+	Versions       key.Binding
+	Rollback       key.Binding
+	SelfUpdate     key.Binding
+	InstallArchive key.Binding
+
+	// 版本选择列表
+	RefreshReleases key.Binding
+	ReleaseChannel  key.Binding
+	CloseVersions   key.Binding
+
+	// 已安装版本列表，版本选择列表也使用其中的 Up/Down
+	Up            key.Binding
+	Down          key.Binding
+	UseVersion    key.Binding
+	PinVersion    key.Binding
+	RemoveVersion key.Binding
+
+	// 操作记录浏览器，上下移动使用 Up/Down
+	CloseAudit  key.Binding
+	AuditFilter key.Binding
+	AuditReplay key.Binding
+	AuditPlay   key.Binding
+	AuditReload key.Binding
+	AuditTop    key.Binding
+	AuditBottom key.Binding
+}
+
+// RemoteKeyMap 远程服务器标签页快捷键
+type RemoteKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	Add     key.Binding
+	Edit    key.Binding
+	Delete  key.Binding
+	Check   key.Binding
+	Upload  key.Binding
+	Restart key.Binding
+	Tail    key.Binding
+}
+
+// LogsKeyMap 日志标签页快捷键
+type LogsKeyMap struct {
+	Search      key.Binding
+	Jump        key.Binding
+	ClearSearch key.Binding
+	Level       key.Binding
+	Source      key.Binding
+	Follow      key.Binding
+	Clear       key.Binding
+	Top         key.Binding
+	Bottom      key.Binding
+	Copy        key.Binding
+	Export      key.Binding
+}
+
+// FilePickerKeyMap 文件选择对话框快捷键，各标签页打开的文件选择器共用。
+// 输入文件名或路径时只响应 ↑/↓、Tab 和切换隐藏文件、书签的组合键
+type FilePickerKeyMap struct {
+	Up           key.Binding
+	Down         key.Binding
+	Open         key.Binding
+	ChooseDir    key.Binding
+	CopyPath     key.Binding
+	ToggleHidden key.Binding
+	Home         key.Binding
+	EditPath     key.Binding
+	Places       key.Binding
+	NewFile      key.Binding
+}
+
+// KeyMap 全部可自定义的快捷键，按作用范围分组，可在应用设置的 keyBindings 中覆盖
+type KeyMap struct {
+	Global    GlobalKeyMap
+	Dashboard DashboardKeyMap
+	Traffic   TrafficKeyMap
+	Clients   ClientsKeyMap
+	Config    ConfigKeyMap
+	Settings  SettingsKeyMap
+	Remote    RemoteKeyMap
+	Logs      LogsKeyMap
+
+	FilePicker FilePickerKeyMap
+}
+
+// KeyMapAware 使用快捷键的标签页，快捷键变更后由主界面重新下发
+type KeyMapAware interface {
+	SetKeyMap(keys *KeyMap)
+}
+
+// namedBinding 带设置项名称的快捷键
+type namedBinding struct {
+	id      string
+	binding *key.Binding
+}
+
+// keyGroup 一组快捷键，id 为设置项前缀，title 与标签页标题一致
+type keyGroup struct {
+	id       string
+	title    string
+	bindings []namedBinding
+	panels   []keyPanel
+}
+
+// keyPanel 标签页内的子面板，面板打开时只响应自己的快捷键，因此只在面板内检查冲突。
+// shared 为面板中同时使用的标签页快捷键，只参与冲突检查，确认选择与启用/停用共用空格，由面板先判断，不列入其中；
+// exclusive 表示面板独占键盘，不响应全局快捷键
+type keyPanel struct {
+	title     string
+	exclusive bool
+	bindings  []namedBinding
+	shared    []namedBinding
+}
+
+// newBinding 创建快捷键，帮助文本中的按键名由 keys 生成
+func newBinding(desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(displayKeys(keys), desc))
+}
+
+// DefaultKeyMap 返回默认快捷键
+func DefaultKeyMap() *KeyMap {
+	return &KeyMap{
+		Global: GlobalKeyMap{
+			Quit:          newBinding(i18n.T("退出"), "q", "ctrl+c"),
+			NextTab:       newBinding(i18n.T("下一个标签页"), "tab"),
+			PrevTab:       newBinding(i18n.T("上一个标签页"), "shift+tab"),
+			StartServer:   newBinding(i18n.T("启动服务端"), "s"),
+			StopServer:    newBinding(i18n.T("停止服务端"), "ctrl+s"),
+			StartClient:   newBinding(i18n.T("启动客户端"), "d"),
+			StopClient:    newBinding(i18n.T("停止客户端"), "ctrl+d"),
+			DismissAlerts: newBinding(i18n.T("忽略告警"), "ctrl+a"),
+			PauseRefresh:  newBinding(i18n.T("暂停/恢复刷新"), "ctrl+p"),
+			Suspend:       newBinding(i18n.T("挂起程序"), "ctrl+z"),
+			Help:          newBinding(i18n.T("快捷键帮助"), "?"),
+			PageUp:        newBinding(i18n.T("超出屏幕的内容向上翻页"), "pgup"),
+			PageDown:      newBinding(i18n.T("超出屏幕的内容向下翻页"), "pgdown"),
+		},
+		Dashboard: DashboardKeyMap{
+			Up:          newBinding(i18n.T("上移"), "up", "k"),
+			Down:        newBinding(i18n.T("下移"), "down", "j"),
+			Detail:      newBinding(i18n.T("查看详情"), "enter"),
+			CloseDetail: newBinding(i18n.T("关闭详情"), "esc"),
+			Copy:        newBinding(i18n.T("复制访问地址"), "y"),
+			Probe:       newBinding(i18n.T("端到端探测"), "p"),
+			Autostart:   newBinding(i18n.T("自动启动"), "a"),
+			Toggle:      newBinding(i18n.T("启用/停用自动启动"), " "),
+			Target:      newBinding(i18n.T("切换 Dashboard 目标"), "t"),
+
+			LabelFilter:  newBinding(i18n.T("按标签筛选"), "l"),
+			GroupByLabel: newBinding(i18n.T("按标签分组"), "g"),
+			CloseProxy:   newBinding(i18n.T("关闭代理连接"), "x"),
+			PauseProxy:   newBinding(i18n.T("暂停/恢复代理"), "z"),
+		},
+		Traffic: TrafficKeyMap{
+			Up:      newBinding(i18n.T("上一个代理"), "up", "k"),
+			Down:    newBinding(i18n.T("下一个代理"), "down", "j"),
+			Window:  newBinding(i18n.T("切换时间窗口"), "w"),
+			Refresh: newBinding(i18n.T("刷新历史"), "r"),
+		},
+		Clients: ClientsKeyMap{
+			Up:      newBinding(i18n.T("上一个客户端"), "up", "k"),
+			Down:    newBinding(i18n.T("下一个客户端"), "down", "j"),
+			Refresh: newBinding(i18n.T("刷新列表"), "r"),
+			Kick:    newBinding(i18n.T("断开客户端"), "x"),
+		},
+		Config: ConfigKeyMap{
+			Up:        newBinding(i18n.T("上移"), "up", "k"),
+			Down:      newBinding(i18n.T("下移"), "down", "j"),
+			Select:    newBinding(i18n.T("确认选择"), "enter", " "),
+			Apply:     newBinding(i18n.T("应用并重载客户端"), "r"),
+			Test:      newBinding(i18n.T("测试连接"), "t"),
+			Wizard:    newBinding(i18n.T("代理向导"), "w"),
+			Backups:   newBinding(i18n.T("从备份恢复"), "b"),
+			Undo:      newBinding(i18n.T("撤销"), "u"),
+			Redo:      newBinding(i18n.T("重做"), "ctrl+y"),
+			History:   newBinding(i18n.T("修改历史"), "h"),
+			Templates: newBinding(i18n.T("配置模板"), "m"),
+
+			GenerateToken: newBinding(i18n.T("生成令牌/密钥"), "ctrl+g"),
+			SyncToken:     newBinding(i18n.T("同步令牌到客户端"), "ctrl+t"),
+
+			CopyClient: newBinding(i18n.T("复制客户端配置"), "y"),
+			CopyServer: newBinding(i18n.T("复制服务端配置"), "Y"),
+
+			LineNumbers:   newBinding(i18n.T("显示/隐藏行号"), "n"),
+			PreviewFormat: newBinding(i18n.T("切换预览格式"), "v"),
+
+			Verify:    newBinding(i18n.T("frp verify 验证"), "f"),
+			Proxies:   newBinding(i18n.T("代理列表"), "p"),
+			Duplicate: newBinding(i18n.T("复制代理"), "c"),
+			Diagnose:  newBinding(i18n.T("配置诊断"), "i"),
+			Pairing:   newBinding(i18n.T("STCP/XTCP 配对"), "x"),
+			Split:     newBinding(i18n.T("拆分到独立文件/合并回主配置"), "o"),
+			SplitAll:  newBinding(i18n.T("全部拆分/全部合并"), "O"),
+
+			ImportServer: newBinding(i18n.T("从服务端导入代理"), "g"),
+			SSHTunnel:    newBinding(i18n.T("SSH 隧道命令"), "e"),
+			Discover:     newBinding(i18n.T("发现本机服务"), "a"),
+			NATCheck:     newBinding(i18n.T("XTCP 打洞诊断"), "N"),
+			RotateToken:  newBinding(i18n.T("轮换认证令牌"), "R"),
+			Labels:       newBinding(i18n.T("编辑标签/备注"), "l"),
+			Search:       newBinding(i18n.T("搜索配置"), "/"),
+			DeleteProxy:  newBinding(i18n.T("删除代理"), "delete", "D"),
+
+			Toggle:    newBinding(i18n.T("启用/停用或勾选"), " "),
+			ToggleAll: newBinding(i18n.T("全部启用/停用或全部勾选"), "a"),
+
+			ApplyTemplate:      newBinding(i18n.T("应用模板(替换)"), "enter"),
+			MergeTemplate:      newBinding(i18n.T("合并到当前配置"), "m"),
+			SaveServerTemplate: newBinding(i18n.T("保存服务端配置为模板"), "s"),
+			SaveClientTemplate: newBinding(i18n.T("保存客户端配置为模板"), "c"),
+			RenameTemplate:     newBinding(i18n.T("重命名模板"), "e"),
+			DeleteTemplate:     newBinding(i18n.T("删除模板"), "d", "delete"),
+			OnlineTemplates:    newBinding(i18n.T("在线模板"), "o"),
+			RefreshCatalog:     newBinding(i18n.T("刷新模板目录"), "r"),
+			ImportTemplate:     newBinding(i18n.T("导入到本地"), "enter", "i"),
+
+			BundleService:  newBinding(i18n.T("切换服务端/客户端"), "s"),
+			BundleTarget:   newBinding(i18n.T("切换目标系统"), "o"),
+			BundleBinary:   newBinding(i18n.T("是否包含程序"), "b"),
+			CopyBundlePath: newBinding(i18n.T("复制部署包路径"), "y"),
+
+			PrevShareProxy:  newBinding(i18n.T("上一个代理"), "left", "h", "up", "k"),
+			NextShareProxy:  newBinding(i18n.T("下一个代理"), "right", "l", "down", "j"),
+			CopyShareCode:   newBinding(i18n.T("复制分享码"), "y"),
+			ImportShareCode: newBinding(i18n.T("导入分享码"), "i"),
+
+			CopyVisitor:   newBinding(i18n.T("复制访问者配置"), "y"),
+			ExportVisitor: newBinding(i18n.T("写入访问者配置文件"), "w"),
+
+			MigrationFormat: newBinding(i18n.T("切换 YAML/TOML"), "t"),
+
+			PreviewTop:    newBinding(i18n.T("跳到开头"), "home", "g"),
+			PreviewBottom: newBinding(i18n.T("跳到末尾"), "end", "G"),
+
+			DiscoverLAN: newBinding(i18n.T("切换是否扫描局域网"), "l"),
+
+			AddMapping:     newBinding(i18n.T("添加端口映射"), "m"),
+			DeleteMappings: newBinding(i18n.T("删除本次添加的映射"), "d"),
+
+			CopyToken: newBinding(i18n.T("复制新令牌"), "c"),
+
+			CopySSHCommand: newBinding(i18n.T("复制 SSH 命令"), "y"),
+
+			SearchPrev: newBinding(i18n.T("上一个结果"), "up", "ctrl+p"),
+			SearchNext: newBinding(i18n.T("下一个结果"), "down", "ctrl+n"),
+		},
+		Settings: SettingsKeyMap{
+			Install:        newBinding(i18n.T("安装FRP"), "i"),
+			Update:         newBinding(i18n.T("更新FRP"), "u"),
+			Uninstall:      newBinding(i18n.T("卸载FRP"), "ctrl+u"),
+			PickVersion:    newBinding(i18n.T("选择版本"), "p"),
+			Mirror:         newBinding(i18n.T("镜像/代理"), "m"),
+			AppSettings:    newBinding(i18n.T("应用设置"), "g"),
+			Refresh:        newBinding(i18n.T("刷新状态"), "r"),
+			Test:           newBinding(i18n.T("测试连接"), "t"),
+			Reload:         newBinding(i18n.T("热重载客户端"), "h"),
+			ServiceTarget:  newBinding(i18n.T("切换服务目标"), "v"),
+			AutoRestart:    newBinding(i18n.T("切换自动重启"), "o"),
+			InstallService: newBinding(i18n.T("安装为系统服务"), "a"),
+			ToggleBoot:     newBinding(i18n.T("切换开机自启"), "e"),
+			RemoveService:  newBinding(i18n.T("移除系统服务"), "x"),
+			AuditLog:       newBinding(i18n.T("操作记录"), "l"),
+			UseExisting:    newBinding(i18n.T("使用已安装的 FRP"), "E"),
+			Versions:       newBinding(i18n.T("已安装版本"), "V"),
+			Rollback:       newBinding(i18n.T("回滚版本"), "b"),
+			SelfUpdate:     newBinding(i18n.T("更新 frp-cli-ui"), "U"),
+			InstallArchive: newBinding(i18n.T("离线安装"), "f"),
+
+			RefreshReleases: newBinding(i18n.T("刷新版本列表"), "r"),
+			ReleaseChannel:  newBinding(i18n.T("切换正式版/预发布通道"), "c"),
+			CloseVersions:   newBinding(i18n.T("关闭列表"), "esc", "q"),
+
+			Up:            newBinding(i18n.T("上移"), "up", "k"),
+			Down:          newBinding(i18n.T("下移"), "down", "j"),
+			UseVersion:    newBinding(i18n.T("设为当前版本"), "enter"),
+			PinVersion:    newBinding(i18n.T("固定/取消固定版本"), "p"),
+			RemoveVersion: newBinding(i18n.T("删除版本"), "d"),
+
+			CloseAudit:  newBinding(i18n.T("返回/退出回放"), "esc", "q"),
+			AuditFilter: newBinding(i18n.T("筛选记录"), "/"),
+			AuditReplay: newBinding(i18n.T("回放该会话"), "r"),
+			AuditPlay:   newBinding(i18n.T("自动回放/暂停"), "p", " "),
+			AuditReload: newBinding(i18n.T("重新读取"), "ctrl+r"),
+			AuditTop:    newBinding(i18n.T("跳到第一条"), "home", "g"),
+			AuditBottom: newBinding(i18n.T("跳到最后一条"), "end", "G"),
+		},
+		Remote: RemoteKeyMap{
+			Up:      newBinding(i18n.T("上移"), "up", "k"),
+			Down:    newBinding(i18n.T("下移"), "down", "j"),
+			Add:     newBinding(i18n.T("添加"), "a"),
+			Edit:    newBinding(i18n.T("编辑"), "e"),
+			Delete:  newBinding(i18n.T("删除"), "x"),
+			Check:   newBinding(i18n.T("测试连接并查看服务状态"), "c"),
+			Upload:  newBinding(i18n.T("上传配置"), "u"),
+			Restart: newBinding(i18n.T("重启远程服务"), "r"),
+			Tail:    newBinding(i18n.T("查看/停止远程日志"), "l"),
+		},
+		Logs: LogsKeyMap{
+			Search:      newBinding(i18n.T("搜索"), "/"),
+			Jump:        newBinding(i18n.T("跳转时间"), "t"),
+			ClearSearch: newBinding(i18n.T("清除搜索"), "esc"),
+			Level:       newBinding(i18n.T("级别"), "l"),
+			Source:      newBinding(i18n.T("来源"), "o"),
+			Follow:      newBinding(i18n.T("跟随/暂停"), "f"),
+			Clear:       newBinding(i18n.T("清空"), "c"),
+			Top:         newBinding(i18n.T("跳到开头"), "home"),
+			Bottom:      newBinding(i18n.T("跳到末尾"), "end"),
+			Copy:        newBinding(i18n.T("复制日志行"), "y"),
+			Export:      newBinding(i18n.T("导出日志"), "e"),
+		},
+		FilePicker: FilePickerKeyMap{
+			Up:           newBinding(i18n.T("上移"), "up", "k"),
+			Down:         newBinding(i18n.T("下移"), "down", "j"),
+			Open:         newBinding(i18n.T("选择文件/进入目录"), "enter", " "),
+			ChooseDir:    newBinding(i18n.T("选择当前目录"), "ctrl+d"),
+			CopyPath:     newBinding(i18n.T("复制路径"), "y"),
+			ToggleHidden: newBinding(i18n.T("显示/隐藏隐藏文件"), "ctrl+h"),
+			Home:         newBinding(i18n.T("回到主目录"), "home"),
+			EditPath:     newBinding(i18n.T("输入路径"), "ctrl+l"),
+			Places:       newBinding(i18n.T("书签/最近文件"), "ctrl+o"),
+			NewFile:      newBinding(i18n.T("新建文件"), "ctrl+n"),
+		},
+	}
+}
+
+// groups 按显示顺序列出全部快捷键，设置项名称为 "<分组>.<名称>"
+func (km *KeyMap) groups() []keyGroup {
+	g, d, t, cl, c, s, r, l := &km.Global, &km.Dashboard, &km.Traffic, &km.Clients, &km.Config, &km.Settings, &km.Remote, &km.Logs
+	fp := &km.FilePicker
+	return []keyGroup{
+		{"global", i18n.T("全局"), []namedBinding{
+			{"quit", &g.Quit}, {"nextTab", &g.NextTab}, {"prevTab", &g.PrevTab},
+			{"startServer", &g.StartServer}, {"stopServer", &g.StopServer},
+			{"startClient", &g.StartClient}, {"stopClient", &g.StopClient},
+			{"dismissAlerts", &g.DismissAlerts}, {"pauseRefresh", &g.PauseRefresh}, {"suspend", &g.Suspend}, {"help", &g.Help},
+			{"pageUp", &g.PageUp}, {"pageDown", &g.PageDown},
+		}, nil},
+		{"dashboard", i18n.T("仪表盘"), []namedBinding{
+			{"up", &d.Up}, {"down", &d.Down}, {"detail", &d.Detail}, {"closeDetail", &d.CloseDetail}, {"copy", &d.Copy},
+			{"probe", &d.Probe}, {"autostart", &d.Autostart}, {"toggleAutostart", &d.Toggle}, {"target", &d.Target},
+			{"labelFilter", &d.LabelFilter}, {"groupByLabel", &d.GroupByLabel}, {"closeProxy", &d.CloseProxy},
+			{"pauseProxy", &d.PauseProxy},
+		}, nil},
+		{"traffic", i18n.T("流量"), []namedBinding{
+			{"up", &t.Up}, {"down", &t.Down}, {"window", &t.Window}, {"refresh", &t.Refresh},
+		}, nil},
+		{"clients", i18n.T("客户端"), []namedBinding{
+			{"up", &cl.Up}, {"down", &cl.Down}, {"refresh", &cl.Refresh}, {"kick", &cl.Kick},
+		}, nil},
+		{"config", i18n.T("配置管理"), []namedBinding{
+			{"up", &c.Up}, {"down", &c.Down}, {"select", &c.Select}, {"apply", &c.Apply},
+			{"test", &c.Test}, {"wizard", &c.Wizard}, {"backups", &c.Backups}, {"undo", &c.Undo},
+			{"redo", &c.Redo}, {"history", &c.History}, {"templates", &c.Templates},
+			{"generateToken", &c.GenerateToken}, {"syncToken", &c.SyncToken},
+			{"copyClient", &c.CopyClient}, {"copyServer", &c.CopyServer},
+			{"lineNumbers", &c.LineNumbers}, {"previewFormat", &c.PreviewFormat},
+			{"verify", &c.Verify}, {"proxies", &c.Proxies}, {"duplicate", &c.Duplicate},
+			{"diagnose", &c.Diagnose}, {"pairing", &c.Pairing}, {"split", &c.Split},
+			{"splitAll", &c.SplitAll}, {"importServer", &c.ImportServer},
+			{"sshTunnel", &c.SSHTunnel}, {"discover", &c.Discover}, {"natCheck", &c.NATCheck},
+			{"rotateToken", &c.RotateToken}, {"labels", &c.Labels},
+			{"search", &c.Search}, {"deleteProxy", &c.DeleteProxy},
+		}, []keyPanel{
+			{i18n.T("代理列表/从服务端导入代理"), false, []namedBinding{
+				{"toggle", &c.Toggle}, {"toggleAll", &c.ToggleAll},
+			}, []namedBinding{
+				{"up", &c.Up}, {"down", &c.Down}, {"duplicate", &c.Duplicate}, {"deleteProxy", &c.DeleteProxy},
+				{"split", &c.Split}, {"splitAll", &c.SplitAll}, {"labels", &c.Labels}, {"importServer", &c.ImportServer},
+			}},
+			{i18n.T("配置模板"), true, []namedBinding{
+				{"applyTemplate", &c.ApplyTemplate}, {"mergeTemplate", &c.MergeTemplate},
+				{"saveServerTemplate", &c.SaveServerTemplate}, {"saveClientTemplate", &c.SaveClientTemplate},
+				{"renameTemplate", &c.RenameTemplate}, {"deleteTemplate", &c.DeleteTemplate},
+				{"onlineTemplates", &c.OnlineTemplates},
+			}, []namedBinding{
+				{"up", &c.Up}, {"down", &c.Down},
+			}},
+			{i18n.T("在线模板"), true, []namedBinding{
+				{"refreshCatalog", &c.RefreshCatalog}, {"importTemplate", &c.ImportTemplate},
+			}, []namedBinding{
+				{"up", &c.Up}, {"down", &c.Down},
+			}},
+			{i18n.T("导出部署包"), true, []namedBinding{
+				{"bundleService", &c.BundleService}, {"bundleTarget", &c.BundleTarget},
+				{"bundleBinary", &c.BundleBinary}, {"copyBundlePath", &c.CopyBundlePath},
+			}, nil},
+			{i18n.T("分享码"), true, []namedBinding{
+				{"prevShareProxy", &c.PrevShareProxy}, {"nextShareProxy", &c.NextShareProxy},
+				{"copyShareCode", &c.CopyShareCode}, {"importShareCode", &c.ImportShareCode},
+			}, nil},
+			{i18n.T("STCP/XTCP 配对"), true, []namedBinding{
+				{"copyVisitor", &c.CopyVisitor}, {"exportVisitor", &c.ExportVisitor},
+			}, nil},
+			{i18n.T("INI 配置迁移"), false, []namedBinding{
+				{"migrationFormat", &c.MigrationFormat},
+			}, nil},
+			{i18n.T("配置预览"), false, []namedBinding{
+				{"previewTop", &c.PreviewTop}, {"previewBottom", &c.PreviewBottom},
+			}, []namedBinding{
+				{"lineNumbers", &c.LineNumbers}, {"previewFormat", &c.PreviewFormat},
+				{"copyClient", &c.CopyClient}, {"copyServer", &c.CopyServer}, {"verify", &c.Verify},
+			}},
+			{i18n.T("发现本机服务"), false, []namedBinding{
+				{"discoverLAN", &c.DiscoverLAN},
+			}, []namedBinding{
+				{"up", &c.Up}, {"down", &c.Down}, {"select", &c.Select}, {"discover", &c.Discover},
+			}},
+			{i18n.T("XTCP 打洞诊断"), true, []namedBinding{
+				{"addMapping", &c.AddMapping}, {"deleteMappings", &c.DeleteMappings},
+			}, []namedBinding{
+				{"natCheck", &c.NATCheck},
+			}},
+			{i18n.T("轮换认证令牌"), false, []namedBinding{
+				{"copyToken", &c.CopyToken},
+			}, []namedBinding{
+				{"up", &c.Up}, {"down", &c.Down}, {"select", &c.Select},
+			}},
+			{i18n.T("SSH 隧道命令"), false, []namedBinding{
+				{"copySSHCommand", &c.CopySSHCommand},
+			}, nil},
+			{i18n.T("搜索配置"), true, []namedBinding{
+				{"searchPrev", &c.SearchPrev}, {"searchNext", &c.SearchNext},
+			}, nil},
+		}},
+		{"settings", i18n.T("设置"), []namedBinding{
+			{"install", &s.Install}, {"update", &s.Update}, {"uninstall", &s.Uninstall},
+			{"pickVersion", &s.PickVersion}, {"mirror", &s.Mirror}, {"appSettings", &s.AppSettings},
+			{"refresh", &s.Refresh}, {"test", &s.Test}, {"reload", &s.Reload},
+			{"serviceTarget", &s.ServiceTarget}, {"autoRestart", &s.AutoRestart},
+			{"installService", &s.InstallService}, {"toggleBoot", &s.ToggleBoot}, {"removeService", &s.RemoveService},
+			{"auditLog", &s.AuditLog}, {"useExisting", &s.UseExisting},
+			{"versions", &s.Versions}, {"rollback", &s.Rollback}, {"selfUpdate", &s.SelfUpdate},
+			{"installArchive", &s.InstallArchive},
+		}, []keyPanel{
+			{i18n.T("选择版本"), true, []namedBinding{
+				{"refreshReleases", &s.RefreshReleases}, {"releaseChannel", &s.ReleaseChannel},
+				{"closeVersions", &s.CloseVersions},
+			}, []namedBinding{
+				{"up", &s.Up}, {"down", &s.Down},
+			}},
+			{i18n.T("已安装版本"), true, []namedBinding{
+				{"up", &s.Up}, {"down", &s.Down}, {"useVersion", &s.UseVersion},
+				{"pinVersion", &s.PinVersion}, {"removeVersion", &s.RemoveVersion},
+			}, []namedBinding{
+				{"serviceTarget", &s.ServiceTarget}, {"closeVersions", &s.CloseVersions},
+			}},
+			{i18n.T("操作记录"), true, []namedBinding{
+				{"closeAudit", &s.CloseAudit}, {"auditFilter", &s.AuditFilter}, {"auditReplay", &s.AuditReplay},
+				{"auditPlay", &s.AuditPlay}, {"auditReload", &s.AuditReload},
+				{"auditTop", &s.AuditTop}, {"auditBottom", &s.AuditBottom},
+			}, []namedBinding{
+				{"up", &s.Up}, {"down", &s.Down},
+			}},
+		}},
+		{"remote", i18n.T("远程服务器"), []namedBinding{
+			{"up", &r.Up}, {"down", &r.Down}, {"add", &r.Add}, {"edit", &r.Edit}, {"delete", &r.Delete},
+			{"check", &r.Check}, {"upload", &r.Upload}, {"restart", &r.Restart}, {"tail", &r.Tail},
+		}, nil},
+		{"logs", i18n.T("日志"), []namedBinding{
+			{"search", &l.Search}, {"jump", &l.Jump}, {"clearSearch", &l.ClearSearch},
+			{"level", &l.Level}, {"source", &l.Source}, {"follow", &l.Follow}, {"clear", &l.Clear},
+			{"top", &l.Top}, {"bottom", &l.Bottom}, {"copy", &l.Copy}, {"export", &l.Export},
+		}, nil},
+		// 文件选择器在各标签页中都独占键盘，快捷键全部放在独占面板中
+		{"filePicker", i18n.T("文件选择"), nil, []keyPanel{
+			{i18n.T("文件选择"), true, []namedBinding{
+				{"up", &fp.Up}, {"down", &fp.Down}, {"open", &fp.Open}, {"chooseDir", &fp.ChooseDir},
+				{"copyPath", &fp.CopyPath}, {"toggleHidden", &fp.ToggleHidden}, {"home", &fp.Home},
+				{"editPath", &fp.EditPath}, {"places", &fp.Places}, {"newFile", &fp.NewFile},
+			}, nil},
+		}},
+	}
+}
+
+// NewKeyMap 在默认快捷键上应用自定义设置，值为逗号分隔的按键，如 "q,ctrl+c"。
+// 设置有误时返回默认快捷键和错误
+func NewKeyMap(overrides map[string]string) (*KeyMap, error) {
+	km := DefaultKeyMap()
+	if len(overrides) == 0 {
+		return km, nil
+	}
+
+	index := make(map[string]*key.Binding)
+	for _, group := range km.groups() {
+		for _, nb := range group.bindings {
+			index[group.id+"."+nb.id] = nb.binding
+		}
+		for _, panel := range group.panels {
+			for _, nb := range panel.bindings {
+				index[group.id+"."+nb.id] = nb.binding
+			}
+		}
+	}
+
+	// 按名称排序，保证错误信息稳定
+	ids := make([]string, 0, len(overrides))
+	for id := range overrides {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		binding, ok := index[id]
+		if !ok {
+			return DefaultKeyMap(), i18n.Errorf("未知的快捷键设置项: %s", id)
+		}
+		keys := parseKeyList(overrides[id])
+		if len(keys) == 0 {
+			return DefaultKeyMap(), i18n.Errorf("快捷键 %s 不能为空", id)
+		}
+		binding.SetKeys(keys...)
+		binding.SetHelp(displayKeys(keys), binding.Help().Desc)
+	}
+
+	if err := km.checkConflicts(); err != nil {
+		return DefaultKeyMap(), err
+	}
+	return km, nil
+}
+
+// checkConflicts 检查同一标签页内、子面板内以及它们与全局快捷键之间的按键冲突
+func (km *KeyMap) checkConflicts() error {
+	groups := km.groups()
+	global := groups[0]
+
+	for i, group := range groups {
+		scopes := []keyGroup{group}
+		if i > 0 {
+			scopes = append(scopes, global)
+		}
+		if err := checkScopeConflicts(scopes); err != nil {
+			return err
+		}
+
+		for _, panel := range group.panels {
+			scopes := []keyGroup{{id: group.id, bindings: slices.Concat(panel.bindings, panel.shared)}}
+			if !panel.exclusive {
+				scopes = append(scopes, global)
+			}
+			if err := checkScopeConflicts(scopes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkScopeConflicts 检查同时生效的几组快捷键中是否有按键重复
+func checkScopeConflicts(scopes []keyGroup) error {
+	owners := make(map[string]string)
+	for _, scope := range scopes {
+		for _, nb := range scope.bindings {
+			name := scope.id + "." + nb.id
+			for _, k := range nb.binding.Keys() {
+				if owner, exists := owners[k]; exists && owner != name {
+					return i18n.Errorf("快捷键冲突: %s 同时用于 %s 和 %s", displayKey(k), owner, name)
+				}
+				owners[k] = name
+			}
+		}
+	}
+	return nil
+}
+
+// parseKeyList 解析逗号分隔的按键列表
+func parseKeyList(value string) []string {
+	var keys []string
+	for _, k := range strings.Split(value, ",") {
+		k = strings.TrimSpace(k)
+		if k == "space" {
+			k = " "
+		}
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// displayKeys 生成帮助中显示的按键名，如 "↑/k"
+func displayKeys(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = displayKey(k)
+	}
+	return strings.Join(names, "/")
+}
+
+// displayKey 将按键名转换为界面上的写法，如 ctrl+s 显示为 Ctrl+S
+func displayKey(k string) string {
+	switch k {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case " ":
+		return "Space"
+	case "esc":
+		return "ESC"
+	case "pgup":
+		return "PgUp"
+	case "pgdown":
+		return "PgDn"
+	}
+
+	parts := strings.Split(k, "+")
+	for i, part := range parts {
+		// 单个字母保持原样，区分大小写的 E/W 等按键不受影响
+		if len([]rune(part)) > 1 || i < len(parts)-1 {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		} else if len(parts) > 1 {
+			parts[i] = strings.ToUpper(part)
+		}
+	}
+	return strings.Join(parts, "+")
+}
+
+// helpLine 生成 "按键: 说明" 形式的操作提示，跳过已禁用的快捷键
+func helpLine(sep string, bindings ...key.Binding) string {
+	items := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		if !binding.Enabled() {
+			continue
+		}
+		items = append(items, helpItem(binding))
+	}
+	return strings.Join(items, sep)
+}
+
+// helpItem 单个快捷键的提示
+func helpItem(binding key.Binding) string {
+	help := binding.Help()
+	return help.Key + ": " + help.Desc
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"frp-cli-ui/pkg/config"
+)
+
+func TestDefaultKeyMapHasNoConflicts(t *testing.T) {
+	if err := DefaultKeyMap().checkConflicts(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewKeyMapPanelBindings(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		wantErr   bool
+	}{
+		{"override panel binding", map[string]string{"config.toggleAll": "A"}, false},
+		{"panel binding may reuse a menu key", map[string]string{"config.mergeTemplate": "w"}, false},
+		{"exclusive panel may reuse a global key", map[string]string{"settings.pinVersion": "s"}, false},
+		{"conflict inside panel", map[string]string{"config.toggleAll": "D"}, true},
+		{"conflict with shared menu binding", map[string]string{"config.renameTemplate": "k"}, true},
+		{"non-exclusive panel conflicts with global key", map[string]string{"config.toggle": "s"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyMap(tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewKeyMap(%v) error = %v, wantErr %v", tt.overrides, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProxyListToggleAllUsesKeyMap(t *testing.T) {
+	keys, err := NewKeyMap(map[string]string{"config.toggleAll": "A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ct := NewConfigTab()
+	ct.SetKeyMap(keys)
+	ct.clientConfig = &config.Config{Proxies: []config.ProxyConfig{{Name: "web"}, {Name: "ssh"}}}
+	ct.proxyList = newProxyList(ct.clientConfig.Proxies, nil, 0)
+	ct.state = ConfigTabProxyList
+
+	ct.updateProxyList(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if got := ct.clientConfig.EnabledProxyCount(); got != 2 {
+		t.Fatalf("default key still toggled all proxies, %d enabled", got)
+	}
+
+	ct.updateProxyList(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if got := ct.clientConfig.EnabledProxyCount(); got != 0 {
+		t.Errorf("configured key did not disable all proxies, %d enabled", got)
+	}
+}
+
+func TestAuditViewerUsesKeyMap(t *testing.T) {
+	keys, err := NewKeyMap(map[string]string{"settings.auditFilter": "f"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, _ := newAuditViewer(t.TempDir() + "/audit.log")
+	v.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")}, keys.Settings)
+	if v.filtering {
+		t.Fatal("default key still started filtering")
+	}
+	v.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")}, keys.Settings)
+	if !v.filtering {
+		t.Error("configured key did not start filtering")
+	}
+}
+
+func TestFilePickerUsesKeyMap(t *testing.T) {
+	keys, err := NewKeyMap(map[string]string{"filePicker.toggleHidden": "ctrl+t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fp := NewFilePicker("test", FilePickerModeFile)
+	fp.SetKeyMap(keys)
+	fp.SetStartPath(t.TempDir())
+	fp.Show()
+
+	fp.Update(tea.KeyMsg{Type: tea.KeyCtrlH})
+	if fp.showHidden {
+		t.Fatal("default key still toggled hidden files")
+	}
+	fp.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if !fp.showHidden {
+		t.Error("configured key did not toggle hidden files")
+	}
+}
+
+func TestLocalDiscoveryUsesKeyMap(t *testing.T) {
+	keys, err := NewKeyMap(map[string]string{"config.discoverLAN": "L"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ct := NewConfigTab()
+	ct.SetKeyMap(keys)
+	ct.discovery = &localDiscovery{}
+	ct.state = ConfigTabDiscover
+	defer ct.closeLocalDiscovery()
+
+	ct.updateLocalDiscovery(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if ct.discovery.lan {
+		t.Fatal("default key still switched to LAN scanning")
+	}
+	ct.updateLocalDiscovery(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if !ct.discovery.lan {
+		t.Error("configured key did not switch to LAN scanning")
+	}
+}
@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// discoveryMaxLines 发现的服务每屏最多显示的行数
+const discoveryMaxLines = 16
+
+// localDiscoveryMsg 本机服务扫描完成
+type localDiscoveryMsg struct {
+	id       int
+	services []service.DiscoveredService
+	err      error
+	elapsed  time.Duration
+}
+
+// localDiscovery 发现本机服务面板的状态
+type localDiscovery struct {
+	id       int // 扫描编号，忽略已取消或过期扫描的结果
+	lan      bool
+	scanning bool
+	cancel   context.CancelFunc
+	services []service.DiscoveredService
+	err      error
+	elapsed  time.Duration
+	cursor   int
+	offset   int
+}
+
+// handleLocalDiscovery 打开发现本机服务面板并开始扫描本机端口
+func (ct *ConfigTab) handleLocalDiscovery() (Tab, tea.Cmd) {
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	ct.discovery = &localDiscovery{}
+	ct.state = ConfigTabDiscover
+	return ct, ct.startLocalDiscovery()
+}
+
+// startLocalDiscovery 取消正在进行的扫描并在后台重新扫描
+func (ct *ConfigTab) startLocalDiscovery() tea.Cmd {
+	d := ct.discovery
+	if d.cancel != nil {
+		d.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	d.id++
+	d.scanning, d.cancel, d.err = true, cancel, nil
+
+	id, opts := d.id, service.DiscoverOptions{LAN: d.lan}
+	return func() tea.Msg {
+		start := time.Now()
+		services, err := service.DiscoverServices(ctx, opts)
+		return localDiscoveryMsg{id: id, services: services, err: err, elapsed: time.Since(start)}
+	}
+}
+
+// closeLocalDiscovery 关闭面板并取消正在进行的扫描
+func (ct *ConfigTab) closeLocalDiscovery() {
+	if ct.discovery != nil && ct.discovery.cancel != nil {
+		ct.discovery.cancel()
+	}
+	ct.discovery = nil
+}
+
+// handleLocalDiscoveryResult 记录扫描结果，面板已关闭或已重新扫描时忽略
+func (ct *ConfigTab) handleLocalDiscoveryResult(msg localDiscoveryMsg) {
+	d := ct.discovery
+	if d == nil || msg.id != d.id {
+		return
+	}
+	d.cancel()
+	d.scanning, d.cancel = false, nil
+	d.services, d.err, d.elapsed = msg.services, msg.err, msg.elapsed
+	d.cursor, d.offset = 0, 0
+}
+
+// updateLocalDiscovery 处理发现本机服务面板中的按键
+func (ct *ConfigTab) updateLocalDiscovery(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	d := ct.discovery
+	keys := ct.keys.Config
+	count := len(d.services)
+
+	switch {
+	case msg.String() == "esc":
+		ct.closeLocalDiscovery()
+		ct.state = ConfigTabMenu
+	case key.Matches(msg, keys.Discover):
+		return ct, ct.startLocalDiscovery()
+	case key.Matches(msg, keys.DiscoverLAN):
+		d.lan = !d.lan
+		return ct, ct.startLocalDiscovery()
+	case count == 0:
+	case key.Matches(msg, keys.Up):
+		d.cursor = (d.cursor - 1 + count) % count
+	case key.Matches(msg, keys.Down):
+		d.cursor = (d.cursor + 1) % count
+	case key.Matches(msg, keys.Select):
+		return ct.addDiscoveredProxy(d.services[d.cursor])
+	}
+
+	// 光标始终在可见范围内
+	if d.cursor < d.offset {
+		d.offset = d.cursor
+	} else if d.cursor >= d.offset+discoveryMaxLines {
+		d.offset = d.cursor - discoveryMaxLines + 1
+	}
+	return ct, nil
+}
+
+// addDiscoveredProxy 打开添加代理表单，预填服务的地址、端口和建议的代理名，其余字段由用户补充
+func (ct *ConfigTab) addDiscoveredProxy(svc service.DiscoveredService) (Tab, tea.Cmd) {
+	ct.closeLocalDiscovery()
+	ct.currentProxy = &config.ProxyConfig{
+		Name:      config.UniqueProxyName(ct.clientConfig, svc.SuggestedName()),
+		Type:      "tcp",
+		LocalIP:   svc.Host,
+		LocalPort: svc.Port,
+	}
+	ct.editingProxy = ""
+	ct.currentForm = NewProxyConfigForm(ct.currentProxy)
+	ct.currentForm.SetTakenNames(ct.proxyNames(""))
+	ct.state = ConfigTabProxyForm
+	ct.focusOnForm = true
+	return ct, tea.Batch(ct.currentForm.Init(),
+		showStatusMessage(i18n.Sprintf("🔍 已预填 %s:%d，请填写远程端口或域名后保存", svc.Host, svc.Port), false))
+}
+
+// exposedBy 返回已经转发该服务的代理名，没有时返回空字符串
+func (ct *ConfigTab) exposedBy(svc service.DiscoveredService) string {
+	if ct.clientConfig == nil {
+		return ""
+	}
+	for _, proxy := range ct.clientConfig.Proxies {
+		if proxy.LocalPort != svc.Port {
+			continue
+		}
+		host := proxy.LocalIP
+		if host == "" || host == "localhost" {
+			host = "127.0.0.1"
+		}
+		if host == svc.Host {
+			return proxy.Name
+		}
+	}
+	return ""
+}
+
+// renderLocalDiscovery 渲染发现本机服务面板
+func (ct *ConfigTab) renderLocalDiscovery() string {
+	d := ct.discovery
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color("#FAFAFA"))
+
+	content := titleStyle.Render(i18n.T("🔍 发现本机服务")) + "\n"
+	content += hintStyle.Render(i18n.Sprintf("扫描范围: %s", service.DiscoverSummary(service.DiscoverOptions{LAN: d.lan}))) + "\n\n"
+
+	switch {
+	case d.scanning:
+		content += i18n.T("⏳ 正在扫描监听中的 TCP 端口...") + "\n"
+	case d.err != nil:
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(i18n.Sprintf("❌ 扫描失败: %v", d.err)) + "\n"
+	case len(d.services) == 0:
+		content += hintStyle.Render(i18n.T("没有发现监听中的端口")) + "\n"
+	}
+
+	end := min(d.offset+discoveryMaxLines, len(d.services))
+	for i := d.offset; i < end; i++ {
+		svc := d.services[i]
+		name := svc.Service
+		if name == "" {
+			name = "?"
+		}
+		line := fmt.Sprintf("%-21s %-14s %s", fmt.Sprintf("%s:%d", svc.Host, svc.Port), name, svc.Banner)
+		exposed := ct.exposedBy(svc)
+		if exposed != "" {
+			line += "  " + i18n.Sprintf("(已由代理 %s 转发)", exposed)
+		}
+
+		switch {
+		case i == d.cursor:
+			content += "▶ " + selectedStyle.Render(line) + "\n"
+		case exposed != "":
+			content += "  " + hintStyle.Render(line) + "\n"
+		default:
+			content += "  " + line + "\n"
+		}
+	}
+
+	if !d.scanning && len(d.services) > 0 {
+		content += hintStyle.Render(i18n.Sprintf("共 %d 个端口，用时 %s", len(d.services), d.elapsed.Round(100*time.Millisecond))) + "\n"
+	}
+	lanHint := i18n.Sprintf("%s 同时扫描局域网", ct.keys.Config.DiscoverLAN.Help().Key)
+	if d.lan {
+		lanHint = i18n.Sprintf("%s 只扫描本机", ct.keys.Config.DiscoverLAN.Help().Key)
+	}
+	content += "\n" + hintStyle.Render(i18n.Sprintf("↑/↓ 选择 | Enter 为该服务添加代理 | %s | %s 重新扫描 | ESC 返回菜单",
+		lanHint, ct.keys.Config.Discover.Help().Key))
+	return content
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// defaultLogColors 各日志级别的默认颜色，主题和应用设置中的 logColors 可以覆盖
+var defaultLogColors = map[string]string{
+	"ERROR": "196", // 红色
+	"WARN":  "226", // 黄色
+	"INFO":  "46",  // 绿色
+	"DEBUG": "240", // 暗灰色
+	"TRACE": "237", // 更暗的灰色
+}
+
+// logPlainColor 无法识别级别的日志颜色
+const logPlainColor = "250"
+
+// logPalette 日志级别到颜色的映射
+type logPalette map[string]string
+
+// newLogPalette 按主题的日志配色生成调色板，再应用设置中的 logColors。
+// 级别名可以是 frp 的短格式或完整名称；有误的项被跳过并返回第一个错误，其余颜色照常使用
+func newLogPalette(theme string, overrides map[string]string) (logPalette, error) {
+	palette := make(logPalette, len(defaultLogColors))
+	for level, color := range defaultLogColors {
+		palette[level] = color
+	}
+	for level, color := range layoutThemes[theme].logColors {
+		palette[level] = color
+	}
+
+	// 按名称排序，保证错误信息稳定
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var firstErr error
+	for _, name := range names {
+		level := service.ParseLogLevel(name)
+		color := strings.TrimSpace(overrides[name])
+		var err error
+		switch {
+		case level == "":
+			err = i18n.Errorf("未知的日志级别 %q，可选: %s", name, strings.Join(service.LogLevels, ", "))
+		case !validTermColor(color):
+			err = i18n.Errorf("日志级别 %s 的颜色 %q 无效，应为 0-255 的终端颜色编号或 #RRGGBB", name, color)
+		default:
+			palette[level] = color
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return palette, firstErr
+}
+
+// style 返回级别对应颜色的样式，未知级别使用普通颜色
+func (p logPalette) style(level string) lipgloss.Style {
+	color, ok := p[level]
+	if !ok {
+		color = logPlainColor
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+}
+
+// validTermColor 颜色是否为 0-255 的终端颜色编号或 #RGB / #RRGGBB
+func validTermColor(color string) bool {
+	if n, err := strconv.Atoi(color); err == nil {
+		return n >= 0 && n <= 255
+	}
+	if !strings.HasPrefix(color, "#") || (len(color) != 4 && len(color) != 7) {
+		return false
+	}
+	_, err := strconv.ParseUint(color[1:], 16, 32)
+	return err == nil
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// logExportEntry 导出为 JSON 时的单条日志
+type logExportEntry struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Source  string    `json:"source"`
+	Message string    `json:"message"`
+}
+
+// startExport 打开保存对话框，导出当前过滤条件下的日志
+func (lt *LogsTab) startExport() tea.Cmd {
+	if lt.matchCount == 0 {
+		return showStatusMessage(i18n.T("❌ 没有可导出的日志"), true)
+	}
+
+	lt.filePicker = NewFilePicker(i18n.T("📤 导出日志 (扩展名 .txt 或 .json，再加 .gz 可压缩)"), FilePickerModeSave)
+	lt.filePicker.SetKeyMap(lt.keys)
+	lt.filePicker.SetStartPath(config.GetDefaultWorkDir())
+	lt.filePicker.SetFileName("frp-logs-" + time.Now().Format("20060102-150405") + ".txt")
+	lt.filePicker.SetSize(lt.width, lt.height)
+	return lt.filePicker.Show()
+}
+
+// exportLogs 将当前过滤条件下的日志写入文件
+func (lt *LogsTab) exportLogs(path string) tea.Cmd {
+	entries := lt.filteredEntries()
+	if err := writeLogExport(path, entries); err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+	return showStatusMessage(i18n.Sprintf("✅ 已导出 %d 条日志到 %s", len(entries), path), false)
+}
+
+// writeLogExport 按扩展名写入日志文件
+func writeLogExport(path string, entries []service.LogMessage) error {
+	content, err := encodeLogExport(path, entries)
+	if err != nil {
+		return err
+	}
+
+	// 日志中可能包含地址和代理名称，仅允许当前用户读写
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return i18n.Errorf("写入日志文件失败: %w", err)
+	}
+	return nil
+}
+
+// encodeLogExport 按文件名的扩展名编码日志：.json 为 JSON 数组，其余为纯文本，以 .gz 结尾时再做 gzip 压缩。
+// 导出的内容用于附加到问题报告，时间包含日期和毫秒，来源不做翻译
+func encodeLogExport(path string, entries []service.LogMessage) ([]byte, error) {
+	name := strings.ToLower(path)
+	compress := strings.HasSuffix(name, ".gz")
+	name = strings.TrimSuffix(name, ".gz")
+
+	var data bytes.Buffer
+	if strings.HasSuffix(name, ".json") {
+		records := make([]logExportEntry, len(entries))
+		for i, entry := range entries {
+			records[i] = logExportEntry{
+				Time:    entry.Timestamp,
+				Level:   entry.EffectiveLevel(),
+				Source:  entry.Source,
+				Message: entry.Message,
+			}
+		}
+		encoder := json.NewEncoder(&data)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(records); err != nil {
+			return nil, i18n.Errorf("序列化日志失败: %w", err)
+		}
+	} else {
+		for _, entry := range entries {
+			fmt.Fprintf(&data, "%s [%-5s] [%s] %s\n",
+				entry.Timestamp.Format("2006-01-02 15:04:05.000"), entry.EffectiveLevel(), entry.Source, entry.Message)
+		}
+	}
+
+	content := data.Bytes()
+	if compress {
+		var compressed bytes.Buffer
+		writer := gzip.NewWriter(&compressed)
+		if _, err := writer.Write(content); err != nil {
+			return nil, i18n.Errorf("压缩日志失败: %w", err)
+		}
+		if err := writer.Close(); err != nil {
+			return nil, i18n.Errorf("压缩日志失败: %w", err)
+		}
+		content = compressed.Bytes()
+	}
+	return content, nil
+}
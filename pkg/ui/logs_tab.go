@@ -0,0 +1,442 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// LogSink 可接收服务日志的标签页
+type LogSink interface {
+	AppendLogs(entries []service.LogMessage)
+}
+
+// logsInputMode 日志标签页输入模式
+type logsInputMode int
+
+const (
+	logsInputNone logsInputMode = iota
+	logsInputSearch
+	logsInputJump
+)
+
+// LogsTab 全屏日志查看标签页
+type LogsTab struct {
+	BaseTab
+	viewport     viewport.Model
+	input        textinput.Model
+	inputMode    logsInputMode
+	entries      *service.LogStore
+	query        string
+	queryRegex   *regexp.Regexp
+	levelFilter  string // 空表示全部，否则显示该级别及更严重的日志
+	sourceFilter string // 空表示全部，"server"、"client" 或 "proxy:<名称>"
+	proxyNames   []string
+	follow       bool
+	message      string
+	matchCount   int
+	keys         *KeyMap
+	filePicker   *FilePicker // 导出日志时的保存对话框
+	palette      logPalette  // 日志级别的颜色
+}
+
+// NewLogsTab 创建日志标签页
+func NewLogsTab() *LogsTab {
+	baseTab := NewBaseTab("日志")
+	baseTab.focusable = true
+
+	vp := viewport.New(80, 20)
+	// 半页翻动与全局快捷键 d/ctrl+d 冲突，改用翻页键
+	vp.KeyMap.HalfPageDown = key.NewBinding(key.WithDisabled())
+	vp.KeyMap.HalfPageUp = key.NewBinding(key.WithDisabled())
+
+	input := textinput.New()
+	input.CharLimit = 256
+	palette, _ := newLogPalette("default", nil)
+
+	return &LogsTab{
+		BaseTab:  baseTab,
+		viewport: vp,
+		input:    input,
+		entries:  service.NewLogStore(config.DefaultAppSettings().LogCapacity),
+		follow:   true,
+		keys:     DefaultKeyMap(),
+		palette:  palette,
+	}
+}
+
+// SetKeyMap 设置快捷键
+func (lt *LogsTab) SetKeyMap(keys *KeyMap) {
+	lt.keys = keys
+}
+
+// Init 初始化
+func (lt *LogsTab) Init() tea.Cmd {
+	return nil
+}
+
+// AppendLogs 追加新日志
+func (lt *LogsTab) AppendLogs(entries []service.LogMessage) {
+	if len(entries) == 0 {
+		return
+	}
+
+	lt.entries.Append(entries...)
+	lt.refreshContent()
+}
+
+// SetAppSettings 设置应用配置，更新保留的日志条数和日志配色
+func (lt *LogsTab) SetAppSettings(settings *config.AppSettings) {
+	if lt.entries.Capacity() != settings.LogCapacity {
+		lt.entries.SetCapacity(settings.LogCapacity)
+	}
+	lt.palette, _ = newLogPalette(settings.Theme, settings.LogColors)
+	lt.refreshContent()
+}
+
+// SetProxyNames 设置可用于来源过滤的代理名称
+func (lt *LogsTab) SetProxyNames(names []string) {
+	lt.proxyNames = names
+}
+
+// IsInInputMode 是否正在输入搜索、跳转内容或导出文件名
+func (lt *LogsTab) IsInInputMode() bool {
+	return lt.inputMode != logsInputNone || (lt.filePicker != nil && lt.filePicker.IsVisible())
+}
+
+// SetSize 设置标签页大小
+func (lt *LogsTab) SetSize(width int, height int) {
+	lt.BaseTab.SetSize(width, height)
+
+	contentWidth := width - 16
+	if contentWidth < 40 {
+		contentWidth = 40
+	}
+	contentHeight := height - 18
+	if contentHeight < 5 {
+		contentHeight = 5
+	}
+
+	lt.viewport.Width = contentWidth
+	lt.viewport.Height = contentHeight
+	if lt.filePicker != nil {
+		lt.filePicker.SetSize(width, height)
+	}
+	lt.refreshContent()
+}
+
+// Update 更新状态
+func (lt *LogsTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
+	if result, ok := GetFilePickerResult(msg); ok {
+		if result.Selected {
+			return lt, lt.exportLogs(result.Path)
+		}
+		return lt, nil
+	}
+	if lt.filePicker != nil && lt.filePicker.IsVisible() {
+		return lt, lt.filePicker.Update(msg)
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !lt.focused {
+		return lt, nil
+	}
+
+	if lt.inputMode != logsInputNone {
+		return lt.updateInput(keyMsg)
+	}
+
+	keys := lt.keys.Logs
+	switch {
+	case key.Matches(keyMsg, keys.Search):
+		lt.startInput(logsInputSearch, i18n.T("搜索 (支持正则): "), lt.query)
+		return lt, textinput.Blink
+	case key.Matches(keyMsg, keys.Jump):
+		lt.startInput(logsInputJump, i18n.T("跳转到时间 (HH:MM[:SS]): "), "")
+		return lt, textinput.Blink
+	case key.Matches(keyMsg, keys.ClearSearch):
+		lt.setQuery("")
+	case key.Matches(keyMsg, keys.Level):
+		lt.levelFilter = nextOption(append([]string{""}, service.LogLevels...), lt.levelFilter)
+		lt.refreshContent()
+	case key.Matches(keyMsg, keys.Source):
+		lt.sourceFilter = nextOption(lt.sourceOptions(), lt.sourceFilter)
+		lt.refreshContent()
+	case key.Matches(keyMsg, keys.Follow):
+		lt.follow = !lt.follow
+		if lt.follow {
+			lt.viewport.GotoBottom()
+		}
+	case key.Matches(keyMsg, keys.Clear):
+		lt.entries.Clear()
+		lt.refreshContent()
+	case key.Matches(keyMsg, keys.Top):
+		lt.follow = false
+		lt.viewport.GotoTop()
+	case key.Matches(keyMsg, keys.Bottom):
+		lt.viewport.GotoBottom()
+	case key.Matches(keyMsg, keys.Copy):
+		return lt, lt.copyLine()
+	case key.Matches(keyMsg, keys.Export):
+		return lt, lt.startExport()
+	default:
+		var cmd tea.Cmd
+		lt.viewport, cmd = lt.viewport.Update(msg)
+		// 手动向上滚动时暂停跟随，回到底部后恢复
+		if !lt.viewport.AtBottom() {
+			lt.follow = false
+		}
+		return lt, cmd
+	}
+
+	return lt, nil
+}
+
+// startInput 进入输入模式
+func (lt *LogsTab) startInput(mode logsInputMode, prompt, value string) {
+	lt.inputMode = mode
+	lt.input.Prompt = prompt
+	lt.input.SetValue(value)
+	lt.input.CursorEnd()
+	lt.input.Focus()
+	lt.message = ""
+}
+
+// updateInput 处理输入模式下的按键
+func (lt *LogsTab) updateInput(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		lt.inputMode = logsInputNone
+		lt.input.Blur()
+		return lt, nil
+	case "enter":
+		value := strings.TrimSpace(lt.input.Value())
+		mode := lt.inputMode
+		lt.inputMode = logsInputNone
+		lt.input.Blur()
+
+		if mode == logsInputSearch {
+			lt.setQuery(value)
+		} else {
+			lt.jumpToTime(value)
+		}
+		return lt, nil
+	}
+
+	var cmd tea.Cmd
+	lt.input, cmd = lt.input.Update(msg)
+	return lt, cmd
+}
+
+// setQuery 设置搜索条件，能编译为正则时按正则匹配，否则按子串匹配
+func (lt *LogsTab) setQuery(query string) {
+	lt.query = query
+	lt.queryRegex = nil
+	lt.message = ""
+
+	if query != "" {
+		if re, err := regexp.Compile("(?i)" + query); err == nil {
+			lt.queryRegex = re
+		} else {
+			lt.message = i18n.T("正则无效，已按普通文本搜索")
+		}
+	}
+	lt.refreshContent()
+}
+
+// jumpToTime 跳转到指定时间之后的第一条日志
+func (lt *LogsTab) jumpToTime(value string) {
+	var target time.Time
+	var err error
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if target, err = time.Parse(layout, value); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		lt.message = i18n.Sprintf("时间格式无效: %s", value)
+		return
+	}
+
+	targetClock := target.Hour()*3600 + target.Minute()*60 + target.Second()
+	for i, entry := range lt.filteredEntries() {
+		ts := entry.Timestamp
+		if ts.Hour()*3600+ts.Minute()*60+ts.Second() >= targetClock {
+			lt.follow = false
+			lt.viewport.SetYOffset(i)
+			lt.message = i18n.Sprintf("已跳转到 %s", ts.Format("15:04:05"))
+			return
+		}
+	}
+	lt.message = i18n.Sprintf("没有 %s 之后的日志", value)
+}
+
+// sourceOptions 返回可选的来源过滤条件
+func (lt *LogsTab) sourceOptions() []string {
+	options := []string{"", "server", "client"}
+	for _, name := range lt.proxyNames {
+		options = append(options, "proxy:"+name)
+	}
+	return options
+}
+
+// nextOption 返回选项列表中的下一个值
+func nextOption(options []string, current string) string {
+	for i, option := range options {
+		if option == current {
+			return options[(i+1)%len(options)]
+		}
+	}
+	return options[0]
+}
+
+// matches 判断日志是否满足代理来源和搜索条件，级别和服务来源由日志存储过滤
+func (lt *LogsTab) matches(entry service.LogMessage) bool {
+	if name, ok := strings.CutPrefix(lt.sourceFilter, "proxy:"); ok && !strings.Contains(entry.Message, "["+name+"]") {
+		return false
+	}
+
+	if lt.query != "" {
+		if lt.queryRegex != nil {
+			return lt.queryRegex.MatchString(entry.Message)
+		}
+		return strings.Contains(strings.ToLower(entry.Message), strings.ToLower(lt.query))
+	}
+
+	return true
+}
+
+// filteredEntries 返回满足过滤条件的日志
+func (lt *LogsTab) filteredEntries() []service.LogMessage {
+	query := service.LogQuery{MinLevel: lt.levelFilter, Match: lt.matches}
+	if !strings.HasPrefix(lt.sourceFilter, "proxy:") {
+		query.Source = lt.sourceFilter
+	}
+	return lt.entries.Query(query)
+}
+
+// refreshContent 重新生成视口内容
+func (lt *LogsTab) refreshContent() {
+	entries := lt.filteredEntries()
+	lt.matchCount = len(entries)
+
+	lines := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		lines = append(lines, lt.formatEntry(entry))
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.T("暂无匹配的日志")))
+	}
+
+	lt.viewport.SetContent(strings.Join(lines, "\n"))
+	if lt.follow {
+		lt.viewport.GotoBottom()
+	}
+}
+
+// formatEntry 格式化单条日志
+func (lt *LogsTab) formatEntry(entry service.LogMessage) string {
+	line := truncateString(plainLogLine(entry), lt.viewport.Width)
+	return lt.palette.style(entry.EffectiveLevel()).Render(line)
+}
+
+// plainLogLine 返回不带颜色、不截断的单条日志
+func plainLogLine(entry service.LogMessage) string {
+	sourceLabel := i18n.T("服务端")
+	if entry.Source == "client" {
+		sourceLabel = i18n.T("客户端")
+	}
+	return fmt.Sprintf("%s [%-5s] [%s] %s",
+		entry.Timestamp.Format("15:04:05"), entry.EffectiveLevel(), sourceLabel, entry.Message)
+}
+
+// copyLine 复制一条日志：跟随时复制最新一条，暂停时复制视口顶部的一条（跳转时间后即为目标日志）
+func (lt *LogsTab) copyLine() tea.Cmd {
+	entries := lt.filteredEntries()
+	if len(entries) == 0 {
+		return showStatusMessage(i18n.T("❌ 没有可复制的日志"), true)
+	}
+
+	index := len(entries) - 1
+	if !lt.follow {
+		index = min(lt.viewport.YOffset, len(entries)-1)
+	}
+	return copyCmd(plainLogLine(entries[index]))
+}
+
+// View 渲染视图
+func (lt *LogsTab) View(width int, height int) string {
+	if lt.filePicker != nil && lt.filePicker.IsVisible() {
+		return lt.filePicker.View()
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	levelLabel := i18n.T("全部")
+	if lt.levelFilter != "" {
+		levelLabel = lt.levelFilter + i18n.T(" 及以上")
+	}
+
+	sourceLabel := i18n.T("全部")
+	switch {
+	case lt.sourceFilter == "server":
+		sourceLabel = i18n.T("服务端")
+	case lt.sourceFilter == "client":
+		sourceLabel = i18n.T("客户端")
+	case strings.HasPrefix(lt.sourceFilter, "proxy:"):
+		sourceLabel = i18n.T("代理 ") + strings.TrimPrefix(lt.sourceFilter, "proxy:")
+	}
+
+	followLabel := i18n.T("⏸ 已暂停")
+	if lt.follow {
+		followLabel = i18n.T("▶ 跟随中")
+	}
+
+	searchLabel := i18n.T("无")
+	if lt.query != "" {
+		searchLabel = lt.query
+	}
+
+	var content string
+	content += titleStyle.Render(i18n.T("📋 日志查看器")) + "\n"
+	content += fmt.Sprintf("%s %s  %s %s  %s %s  %s  %s\n",
+		labelStyle.Render(i18n.T("级别:")), levelLabel,
+		labelStyle.Render(i18n.T("来源:")), sourceLabel,
+		labelStyle.Render(i18n.T("搜索:")), searchLabel,
+		followLabel,
+		hintStyle.Render(i18n.Sprintf("(%d/%d 条)", lt.matchCount, lt.entries.Len())),
+	)
+
+	if lt.inputMode != logsInputNone {
+		content += lt.input.View() + "\n"
+	} else if lt.message != "" {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render(lt.message) + "\n"
+	} else {
+		content += "\n"
+	}
+
+	content += lt.viewport.View() + "\n\n"
+	keys := lt.keys.Logs
+	content += hintStyle.Render(helpLine(" • ", keys.Search, keys.Level, keys.Source, keys.Follow, keys.Jump, keys.Copy, keys.Export, keys.Clear) +
+		i18n.T(" • ↑/↓ PgUp/PgDn: 滚动 • ") + helpItem(keys.ClearSearch))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 1).
+		Render(content)
+}
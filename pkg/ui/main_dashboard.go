@@ -2,19 +2,104 @@ package ui
 
 import (
 	"fmt"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
 
 	"frp-cli-ui/internal/service"
 	constants "frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
 )
 
 // dashboardTickMsg 为Dashboard特定的时钟消息类型
 type dashboardTickMsg time.Time
 
+// statusMessageMsg 操作结果消息，以通知的形式显示，next 不为空时继续等待后续消息
+type statusMessageMsg struct {
+	text    string
+	isError bool
+	next    tea.Cmd
+}
+
+// showStatusMessage 显示一条操作结果通知，isError 为 true 时按错误级别显示
+func showStatusMessage(text string, isError bool) tea.Cmd {
+	return func() tea.Msg {
+		return statusMessageMsg{text: text, isError: isError}
+	}
+}
+
+// waitForStatusMessage 从通道依次读取操作结果消息，通道关闭后结束
+func waitForStatusMessage(ch <-chan statusMessageMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		msg.next = waitForStatusMessage(ch)
+		return msg
+	}
+}
+
+// restartEventMsg 进程自动重启事件
+type restartEventMsg struct {
+	event service.RestartEvent
+}
+
+// waitForRestartEvent 等待下一条自动重启事件
+func waitForRestartEvent(ch <-chan service.RestartEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return restartEventMsg{event: event}
+	}
+}
+
+// webhookErrorMsg 生命周期事件 Webhook 推送失败
+type webhookErrorMsg struct {
+	err error
+}
+
+// waitForWebhookError 等待下一条 Webhook 推送错误
+func waitForWebhookError(ch <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		err, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return webhookErrorMsg{err: err}
+	}
+}
+
+// auditErrorMsg 写入操作记录失败
+type auditErrorMsg struct {
+	err error
+}
+
+// waitForAuditError 等待下一条操作记录写入错误
+func waitForAuditError(ch <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		err, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return auditErrorMsg{err: err}
+	}
+}
+
+// appSettingsChangedMsg 应用设置已保存，需要重新下发到各模块
+type appSettingsChangedMsg struct {
+	settings *constants.AppSettings
+	notice   string // 保存后显示的提示，留空时使用默认提示
+}
+
 // MainDashboard 主控制面板
 type MainDashboard struct {
 	layout      *AppLayout
@@ -24,6 +109,21 @@ type MainDashboard struct {
 	tabRegistry *TabRegistry
 	manager     *service.Manager
 	apiClient   *service.APIClient
+	monitor     *service.HealthMonitor
+	latency     *service.LatencyMonitor
+	resources   *service.ResourceMonitor
+	logs        <-chan service.LogMessage // 订阅的 frps/frpc 日志
+	stopLogs    func()                    // 取消日志订阅
+	webhooks    *service.WebhookDispatcher
+	audit       *service.AuditRecorder
+	events      *service.EventBus
+	scheduler   *service.Scheduler
+	alerts      []service.Alert // 尚未恢复的健康告警
+	appSettings *constants.AppSettings
+	keys        *KeyMap
+	keyMapErr   error   // 自定义快捷键有误，启动后提示
+	logColorErr error   // 自定义日志颜色有误，启动后提示
+	pluginErrs  []error // 扩展标签页加载失败，启动后提示
 	statusInfo  struct {
 		ServerStatus  string
 		ClientStatus  string
@@ -31,8 +131,22 @@ type MainDashboard struct {
 		TotalTraffic  string
 		LastUpdate    time.Time
 	}
-	lastProxyUpdate time.Time // 记录上次代理状态更新时间
+	lastProxyUpdate time.Time          // 记录上次发起代理状态轮询的时间
+	lastTraffic     time.Time          // 上次记录流量统计的时间
+	lastLogPump     time.Time          // 上次将新日志分发到标签页的时间
+	polling         bool               // 后台轮询 frps API 尚未返回
+	refreshPaused   bool               // 已暂停所有自动刷新
+	operations      []pendingOperation // 正在后台执行的操作
+	spinner         spinner.Model
+	toasts          ToastQueue
 	showConfirmQuit bool
+	showHelp        bool     // 显示快捷键帮助
+	quitStops       bool     // 确认退出后需要停止本工具启动的进程
+	closing         bool     // 正在停止进程，显示关闭对话框
+	closingSteps    []string // 关闭进度
+	shutdownOnce    sync.Once
+	shutdownErr     error
+	restarting      bool // 自更新后确认重启，退出后启动新版本
 	ready           bool
 }
 
@@ -40,16 +154,48 @@ type MainDashboard struct {
 func NewMainDashboard() *MainDashboard {
 	runewidth.DefaultCondition.EastAsianWidth = false
 
+	// 读取失败时 LoadAppSettings 返回默认设置
+	appSettings, _ := constants.LoadAppSettings()
+
+	events := service.NewEventBus()
 	manager := service.NewManager()
-	apiClient := service.NewAPIClient("http://127.0.0.1:7500", "admin", "admin")
+	manager.SetEventBus(events)
+	apiClient := service.NewAPIClient(appSettings.DashboardURL, appSettings.DashboardUser, appSettings.DashboardPassword)
+	// CA 证书在保存设置时已校验，这里失败时仍使用系统默认的证书校验
+	_ = apiClient.SetTarget(appSettings.ActiveDashboardTarget())
 
 	tabRegistry := NewTabRegistry()
-	tabRegistry.Register(NewDashboardTab(apiClient))
-	tabRegistry.Register(NewConfigTab())
+	scheduler := service.NewScheduler(manager)
+	dashboardTab := NewDashboardTab(apiClient)
+	dashboardTab.SetScheduler(scheduler)
+	dashboardTab.SetManager(manager)
+	latency := service.NewLatencyMonitor(manager, apiClient, service.LatencyOptionsFromSettings(appSettings))
+	dashboardTab.SetLatencyMonitor(latency)
+	resources := service.NewResourceMonitor(manager)
+	dashboardTab.SetResourceMonitor(resources)
+	tabRegistry.Register(dashboardTab)
+	trafficTab := NewTrafficTab(apiClient)
+	trafficStore := service.NewTrafficStore(service.GetTrafficDir(), appSettings.TrafficRetention())
+	trafficTab.SetTrafficStore(trafficStore)
+	quotas := service.NewQuotaTracker(trafficStore)
+	trafficTab.SetQuotaTracker(quotas)
+	tabRegistry.Register(trafficTab)
+	tabRegistry.Register(NewClientsTab(apiClient))
+	configTab := NewConfigTab()
+	configTab.SetManager(manager)
+	configTab.SetEventBus(events)
+	configTab.SetAPIClient(apiClient)
+	tabRegistry.Register(configTab)
 
 	settingsTab := NewSettingsTab()
 	settingsTab.SetManager(manager)
+	settingsTab.SetEventBus(events)
+	audit := service.NewAuditRecorder(service.GetAuditLogPath())
+	settingsTab.SetAuditRecorder(audit)
+	settingsTab.SetResourceMonitor(resources)
 	tabRegistry.Register(settingsTab)
+	tabRegistry.Register(NewRemoteTab())
+	tabRegistry.Register(NewLogsTab())
 
 	dashboard := &MainDashboard{
 		tabRegistry: tabRegistry,
@@ -66,9 +212,27 @@ func NewMainDashboard() *MainDashboard {
 			TotalTraffic:  "0B",
 			LastUpdate:    time.Now(),
 		},
-		manager:   manager,
-		apiClient: apiClient,
+		manager:     manager,
+		apiClient:   apiClient,
+		monitor:     service.NewHealthMonitor(manager, apiClient, service.MonitorOptionsFromSettings(appSettings)),
+		latency:     latency,
+		resources:   resources,
+		webhooks:    service.NewWebhookDispatcher(),
+		audit:       audit,
+		events:      events,
+		scheduler:   scheduler,
+		appSettings: appSettings,
+		spinner:     spinner.New(spinner.WithSpinner(spinner.Dot)),
 	}
+	dashboard.logs, dashboard.stopLogs = manager.SubscribeLogs()
+	dashboard.pluginErrs = dashboard.loadExtensionTabs()
+	dashboard.monitor.SetEventBus(events)
+	dashboard.monitor.SetLatencyMonitor(latency)
+	dashboard.monitor.SetQuotaTracker(quotas)
+	dashboard.applyAppSettings(appSettings)
+	dashboard.webhooks.Start(events)
+	dashboard.audit.Start(events)
+	resources.Start()
 
 	settingsTab.SetStatusCallback(func(serverStatus, clientStatus string) {
 		dashboard.statusInfo.ServerStatus = serverStatus
@@ -88,11 +252,28 @@ func (m *MainDashboard) Init() tea.Cmd {
 			cmds = append(cmds, cmd)
 		}
 	}
+	if m.keyMapErr != nil {
+		cmds = append(cmds, showStatusMessage("❌ "+m.keyMapErr.Error()+i18n.T("，已使用默认快捷键"), true))
+	}
+	if m.logColorErr != nil {
+		cmds = append(cmds, showStatusMessage("❌ "+m.logColorErr.Error(), true))
+	}
+	for _, err := range m.pluginErrs {
+		cmds = append(cmds, showStatusMessage("❌ "+err.Error(), true))
+	}
+
+	// 按自动启动配置启动服务
+	m.scheduler.Start()
 
 	// 添加主仪表板的时钟
 	cmds = append(cmds,
 		tea.Tick(time.Second, func(t time.Time) tea.Msg { return dashboardTickMsg(t) }),
 		func() tea.Msg { return dashboardTickMsg(time.Now()) },
+		waitForHealthAlert(m.monitor.Alerts()),
+		waitForRestartEvent(m.manager.RestartEvents()),
+		waitForWebhookError(m.webhooks.Errors()),
+		waitForAuditError(m.audit.Errors()),
+		waitForScheduleEvent(m.scheduler.Events()),
 	)
 
 	return tea.Batch(cmds...)
@@ -109,6 +290,7 @@ func (m *MainDashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// 初始化或更新AppLayout
 		if m.layout == nil {
 			m.layout = NewAppLayout(m.width, m.height)
+			m.layout.ApplyTheme(m.appSettings.Theme)
 		} else {
 			m.layout.SetSize(m.width, m.height)
 		}
@@ -119,69 +301,220 @@ func (m *MainDashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.KeyMsg:
+		// 关闭过程中只响应 Ctrl+C，跳过等待直接退出
+		if m.closing {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
 		// 处理确认退出对话框
 		if m.showConfirmQuit {
 			switch msg.String() {
 			case "y", "Y", "enter":
-				return m, tea.Quit
+				m.showConfirmQuit = false
+				return m, m.beginShutdown()
 			case "n", "N", "esc":
 				m.showConfirmQuit = false
 			}
 			return m, nil
 		}
 
+		// 快捷键帮助打开时，任意帮助键或 ESC 关闭
+		if m.showHelp {
+			if key.Matches(msg, m.keys.Global.Help, m.keys.Global.Quit) || msg.String() == "esc" {
+				m.showHelp = false
+			}
+			return m, nil
+		}
+
 		// 检查当前标签页是否需要独占键盘输入
 		shouldInterceptKeys := m.shouldInterceptKeysForCurrentTab()
 
 		// 如果当前标签页不需要独占输入，处理全局快捷键
 		if !shouldInterceptKeys {
+			keys := m.keys.Global
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c"))):
+			case key.Matches(msg, keys.Quit):
 				m.showConfirmQuit = true
+				m.quitStops = m.stopsProcessesOnExit()
+				return m, nil
+
+			case key.Matches(msg, keys.Help):
+				m.showHelp = true
 				return m, nil
 
-			case key.Matches(msg, key.NewBinding(key.WithKeys("tab"))):
+			case key.Matches(msg, keys.NextTab):
 				m.activeTab = (m.activeTab + 1) % len(m.tabRegistry.GetTabs())
 				// 更新焦点状态
 				m.updateFocus()
 				return m, tea.ClearScreen
 
-			case key.Matches(msg, key.NewBinding(key.WithKeys("shift+tab"))):
+			case key.Matches(msg, keys.PrevTab):
 				m.activeTab = (m.activeTab - 1 + len(m.tabRegistry.GetTabs())) % len(m.tabRegistry.GetTabs())
 				// 更新焦点状态
 				m.updateFocus()
 				return m, tea.ClearScreen
 
-			case key.Matches(msg, key.NewBinding(key.WithKeys("s"))):
+			case m.isSettingsTabActive() && key.Matches(msg, keys.StartServer, keys.StopServer, keys.StartClient, keys.StopClient):
+				// 设置页自己处理服务启停，并显示进度和错误
+
+			case key.Matches(msg, keys.StartServer):
 				// 启动服务端
 				if m.manager != nil {
-					_ = m.manager.StartServer("examples/frps.yaml")
+					manager, path := m.manager, m.appSettings.ServerConfigPath
+					m.events.Publish(service.UserActionEvent(service.ActionProcessStart, i18n.T("启动服务端 ")+path))
+					return m, processOperation(i18n.T("正在启动服务端"), i18n.T("✅ 服务端已启动"), func() error {
+						return manager.StartServer(path)
+					})
 				}
 
-			case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+s"))):
+			case key.Matches(msg, keys.StopServer):
 				// 停止服务端
 				if m.manager != nil {
-					_ = m.manager.StopServer()
+					m.events.Publish(service.UserActionEvent(service.ActionProcessStop, i18n.T("停止服务端")))
+					return m, processOperation(i18n.T("正在停止服务端"), i18n.T("✅ 服务端已停止"), m.manager.StopServer)
 				}
 
-			case key.Matches(msg, key.NewBinding(key.WithKeys("d"))):
+			case key.Matches(msg, keys.StartClient):
 				// 启动客户端
 				if m.manager != nil {
-					_ = m.manager.StartClient("examples/frpc.yaml")
+					manager, path := m.manager, m.appSettings.ClientConfigPath
+					m.events.Publish(service.UserActionEvent(service.ActionProcessStart, i18n.T("启动客户端 ")+path))
+					return m, processOperation(i18n.T("正在启动客户端"), i18n.T("✅ 客户端已启动"), func() error {
+						return manager.StartClient(path)
+					})
 				}
 
-			case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+d"))):
+			case key.Matches(msg, keys.StopClient):
 				// 停止客户端
 				if m.manager != nil {
-					_ = m.manager.StopClient()
+					m.events.Publish(service.UserActionEvent(service.ActionProcessStop, i18n.T("停止客户端")))
+					return m, processOperation(i18n.T("正在停止客户端"), i18n.T("✅ 客户端已停止"), m.manager.StopClient)
 				}
 
-			case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+z"))):
-				// 处理 Ctrl+Z 挂起
+			case key.Matches(msg, keys.DismissAlerts):
+				// 忽略健康告警
+				if len(m.alerts) > 0 {
+					m.dismissAlerts()
+					return m, nil
+				}
+
+			case key.Matches(msg, keys.PauseRefresh):
+				return m, m.toggleRefreshPaused()
+
+			case key.Matches(msg, keys.PageUp, keys.PageDown) && m.layout != nil && m.layout.Scrollable():
+				// 标签页内容超出屏幕时由布局翻页，否则交给标签页自己的视口
+				if key.Matches(msg, keys.PageUp) {
+					m.layout.ScrollPage(-1)
+				} else {
+					m.layout.ScrollPage(1)
+				}
+				return m, nil
+
+			case key.Matches(msg, keys.Suspend):
+				// 处理 Ctrl+Z 挂起，Windows 控制台不支持挂起进程
+				if runtime.GOOS == "windows" {
+					return m, showStatusMessage(i18n.T("Windows 不支持挂起，请使用 ")+keys.Quit.Help().Key+i18n.T(" 退出"), true)
+				}
 				return m, func() tea.Msg { return tea.Suspend() }
 			}
 		}
 
+	case statusMessageMsg:
+		cmds = append(cmds, m.toasts.Push(statusToastLevel(msg), msg.text))
+		if msg.next != nil {
+			cmds = append(cmds, msg.next)
+		}
+		return m, tea.Batch(cmds...)
+
+	case toastExpireMsg:
+		m.toasts.Dismiss(msg.id)
+		return m, nil
+
+	case shutdownProgressMsg:
+		return m, m.handleShutdownProgress(msg)
+
+	case restartAppMsg:
+		m.restarting = true
+		return m, m.beginShutdown()
+
+	case healthAlertMsg:
+		return m, m.handleHealthAlert(msg)
+
+	case restartEventMsg:
+		isError := msg.event.GaveUp || msg.event.Err != nil
+		return m, tea.Batch(
+			showStatusMessage("🔁 "+msg.event.Message(), isError),
+			waitForRestartEvent(m.manager.RestartEvents()),
+		)
+
+	case scheduleEventMsg:
+		return m, tea.Batch(
+			showStatusMessage("⏰ "+msg.event.Message(), msg.event.Err != nil),
+			waitForScheduleEvent(m.scheduler.Events()),
+		)
+
+	case webhookErrorMsg:
+		return m, tea.Batch(
+			showStatusMessage("❌ "+msg.err.Error(), true),
+			waitForWebhookError(m.webhooks.Errors()),
+		)
+
+	case auditErrorMsg:
+		return m, tea.Batch(
+			showStatusMessage("❌ "+msg.err.Error(), true),
+			waitForAuditError(m.audit.Errors()),
+		)
+
+	case appSettingsChangedMsg:
+		m.applyAppSettings(msg.settings)
+		if msg.notice != "" {
+			return m, showStatusMessage(msg.notice, false)
+		}
+		return m, showStatusMessage(i18n.T("✅ 应用设置已保存"), false)
+
+	case recentFilesMsg:
+		// 同步到共享的设置，避免设置页保存时覆盖最近文件
+		if m.appSettings != nil {
+			m.appSettings.RecentFiles = msg.files
+		}
+		return m, nil
+
+	case operationStartMsg:
+		return m, m.startOperation(msg)
+
+	case operationDoneMsg:
+		return m, m.finishOperation(msg)
+
+	case spinner.TickMsg:
+		return m, m.updateSpinner(msg)
+
+	case downloadProgressMsg, installProgressMsg, stagedInstallMsg, installStatusMsg, releasesMsg,
+		serviceStatusMsg, systemServiceStatusMsg, systemServiceResultMsg, selfUpdateCheckMsg, selfUpdateDoneMsg:
+		// 设置页在后台执行的检查、下载和安装结果需要送达设置页，切换标签页后也不能丢失
+		return m, m.updateSettingsTab(msg)
+
+	case pluginUpdateMsg:
+		// 扩展标签页插件的输出在切换标签页后仍需送达
+		return m, msg.tab.apply(msg)
+
+	case pluginExitMsg:
+		return m, msg.tab.exit(msg)
+
+	case rotationPrepareMsg, rotationStepMsg:
+		// 令牌轮换需要逐台执行到结束，切换标签页后也不能中断
+		return m, m.updateConfigTab(msg)
+
+	case proxyActionMsg:
+		// 代理操作完成后需要更新或回滚仪表盘中的状态，切换标签页后也不能丢失
+		return m, m.updateDashboardTab(msg)
+
+	case remoteResultMsg, remoteLogMsg, remoteLogEndMsg:
+		// 远程操作和日志在切换标签页后仍需继续
+		return m, m.updateRemoteTab(msg)
+
 	case tea.SuspendMsg:
 		// 程序即将挂起，可以在这里做一些清理工作
 		return m, nil
@@ -190,11 +523,24 @@ func (m *MainDashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// 程序从挂起状态恢复，重新绘制屏幕
 		return m, tea.ClearScreen
 
+	case dashboardPollMsg:
+		m.handleDashboardPoll(msg)
+		return m, nil
+
 	case dashboardTickMsg:
-		m.updateStatus(time.Time(msg))
+		// 时钟每秒一次，各类数据按各自的刷新间隔判断是否到期
 		cmds = append(cmds, tea.Tick(time.Second, func(t time.Time) tea.Msg {
 			return dashboardTickMsg(t)
 		}))
+		if m.refreshPaused {
+			// 暂停时标签页也不再收到时钟，详情、客户端列表和服务状态都停止刷新
+			return m, tea.Batch(cmds...)
+		}
+		if time.Since(m.lastLogPump) >= m.appSettings.LogDuration() {
+			m.lastLogPump = time.Now()
+			m.pumpLogs()
+		}
+		cmds = append(cmds, m.updateStatus(time.Time(msg)))
 	}
 
 	// 更新当前活动的标签页
@@ -214,51 +560,89 @@ func (m *MainDashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-// 以下是真实的服务状态检查和代理获取方法
+// applyAppSettings 应用设置并下发到各标签页
+func (m *MainDashboard) applyAppSettings(settings *constants.AppSettings) {
+	m.appSettings = settings
+	// 先切换语言，之后生成的快捷键说明和标签页内容使用新语言
+	i18n.SetLanguage(settings.Language)
+	m.keys, m.keyMapErr = NewKeyMap(settings.KeyBindings)
+	_, m.logColorErr = newLogPalette(settings.Theme, settings.LogColors)
+	m.applyDashboardTarget(settings)
+	m.applyMonitorSettings(settings)
+	m.applyLatencySettings(settings)
+	m.webhooks.SetWebhooks(settings.Webhooks)
+	m.audit.SetEnabled(settings.AuditLog)
+	m.scheduler.SetSettings(settings)
+	m.manager.SetRestartPolicy("server", service.RestartPolicyFromSettings(settings, settings.AutoRestartServer))
+	m.manager.SetRestartPolicy("client", service.RestartPolicyFromSettings(settings, settings.AutoRestartClient))
+	m.manager.SetHooks("server", service.HooksFromSettings(settings.ServerHooks))
+	m.manager.SetHooks("client", service.HooksFromSettings(settings.ClientHooks))
+	m.manager.SetLogFile("server", service.LogFileFromSettings(settings.ServerLogFile, settings.ServerConfigPath))
+	m.manager.SetLogFile("client", service.LogFileFromSettings(settings.ClientLogFile, settings.ClientConfigPath))
+	if m.layout != nil {
+		m.layout.ApplyTheme(settings.Theme)
+	}
 
-// checkServerStatus 检查服务器状态
-func (m *MainDashboard) checkServerStatus() bool {
-	return m.manager != nil && m.apiClient != nil && m.apiClient.IsServerReachable()
+	for _, tab := range m.tabRegistry.GetTabs() {
+		if aware, ok := tab.(AppSettingsAware); ok {
+			aware.SetAppSettings(settings)
+		}
+		if aware, ok := tab.(KeyMapAware); ok {
+			aware.SetKeyMap(m.keys)
+		}
+	}
 }
 
-// getProxyList 获取真实的代理列表
-func (m *MainDashboard) getProxyList() []ProxyStatus {
-	if m.apiClient == nil {
-		return []ProxyStatus{}
+// updateSettingsTab 将消息直接交给设置标签页处理，不论其是否为当前标签页
+func (m *MainDashboard) updateSettingsTab(msg tea.Msg) tea.Cmd {
+	tabs := m.tabRegistry.GetTabs()
+	for i, tab := range tabs {
+		if settingsTab, ok := tab.(*SettingsTab); ok {
+			updatedTab, cmd := settingsTab.Update(msg)
+			tabs[i] = updatedTab
+			return cmd
+		}
 	}
+	return nil
+}
 
-	proxies, err := m.apiClient.GetProxyList()
-	if err != nil {
-		return []ProxyStatus{}
+// updateRemoteTab 将消息直接交给远程服务器标签页处理，不论其是否为当前标签页
+func (m *MainDashboard) updateRemoteTab(msg tea.Msg) tea.Cmd {
+	tabs := m.tabRegistry.GetTabs()
+	for i, tab := range tabs {
+		if remoteTab, ok := tab.(*RemoteTab); ok {
+			updatedTab, cmd := remoteTab.Update(msg)
+			tabs[i] = updatedTab
+			return cmd
+		}
 	}
+	return nil
+}
 
-	result := make([]ProxyStatus, len(proxies))
-	for i, proxy := range proxies {
-		result[i] = ProxyStatus{
-			Name:            proxy.Name,
-			Type:            proxy.Conf.Type,
-			Status:          proxy.Status,
-			CurConns:        proxy.CurConns,
-			TodayTrafficIn:  proxy.TodayTrafficIn,
-			TodayTrafficOut: proxy.TodayTrafficOut,
-			ClientVersion:   proxy.ClientVersion,
-			LastStartTime:   proxy.LastStartTime,
-		}
-
-		if proxy.Conf.LocalIP != "" {
-			result[i].LocalAddr = proxy.Conf.LocalIP
-		} else {
-			result[i].LocalAddr = "N/A"
+// updateConfigTab 将消息送达配置管理标签页
+func (m *MainDashboard) updateConfigTab(msg tea.Msg) tea.Cmd {
+	tabs := m.tabRegistry.GetTabs()
+	for i, tab := range tabs {
+		if configTab, ok := tab.(*ConfigTab); ok {
+			updatedTab, cmd := configTab.Update(msg)
+			tabs[i] = updatedTab
+			return cmd
 		}
+	}
+	return nil
+}
 
-		if proxy.Conf.RemotePort > 0 {
-			result[i].RemotePort = fmt.Sprintf("%d", proxy.Conf.RemotePort)
-		} else {
-			result[i].RemotePort = "N/A"
+// updateDashboardTab 将消息送达仪表盘标签页
+func (m *MainDashboard) updateDashboardTab(msg tea.Msg) tea.Cmd {
+	tabs := m.tabRegistry.GetTabs()
+	for i, tab := range tabs {
+		if dashboardTab, ok := tab.(*DashboardTab); ok {
+			updatedTab, cmd := dashboardTab.Update(msg)
+			tabs[i] = updatedTab
+			return cmd
 		}
 	}
-
-	return result
+	return nil
 }
 
 // updateFocus 更新标签页焦点状态
@@ -273,18 +657,31 @@ func (m *MainDashboard) updateFocus() {
 // View 渲染视图
 func (m *MainDashboard) View() string {
 	if !m.ready || m.layout == nil {
-		return "正在初始化...\n\n按 Ctrl+C 退出"
+		return i18n.T("正在初始化...\n\n按 Ctrl+C 退出")
+	}
+
+	if m.closing {
+		return m.renderClosingDialog()
+	}
+
+	if m.showHelp {
+		return m.renderHelpOverlay()
 	}
 
 	// 显示确认退出对话框
 	if m.showConfirmQuit {
-		dialogContent := `确认退出
+		notice := i18n.T("正在运行的 frps/frpc 将继续在后台运行")
+		if m.quitStops {
+			notice = i18n.T("退出时将停止本工具启动的 frps/frpc")
+		}
+		dialogContent := i18n.Sprintf(`确认退出
 
 您确定要退出 FRP 管理工具吗？
+%s
 
 [Y] 是的，退出  [N] 取消
 
-按 Y 或 Enter 确认退出，按 N 或 ESC 取消`
+按 Y 或 Enter 确认退出，按 N 或 ESC 取消`, notice)
 
 		return m.layout.RenderDialog(dialogContent, DefaultDialogOptions())
 	}
@@ -294,21 +691,34 @@ func (m *MainDashboard) View() string {
 		config.Title = constants.AppName + " " + constants.AppVersion
 		config.Tabs = m.tabRegistry.GetTabTitles()
 		config.ActiveTab = m.activeTab
-		config.StatusText = fmt.Sprintf(
-			"Server: %s | Client: %s | Active Proxies: %d | Total Traffic: %s | Last Update: %s",
-			m.statusInfo.ServerStatus,
-			m.statusInfo.ClientStatus,
-			m.statusInfo.ActiveProxies,
-			m.statusInfo.TotalTraffic,
-			m.statusInfo.LastUpdate.Format(time.DateTime),
-		)
-		config.HelpText = "Tab: 切换标签 | q: 退出"
+		// 窄终端放不下时先省略更新时间、流量等次要信息
+		config.StatusSegments = []StatusSegment{
+			{Text: "Server: " + i18n.T(m.statusInfo.ServerStatus), Priority: 60},
+			{Text: "Client: " + i18n.T(m.statusInfo.ClientStatus), Priority: 60},
+			{Text: fmt.Sprintf("Active Proxies: %d", m.statusInfo.ActiveProxies), Priority: 40},
+			{Text: "Total Traffic: " + m.statusInfo.TotalTraffic, Priority: 30},
+			{Text: "Last Update: " + m.statusInfo.LastUpdate.Format(time.DateTime), Priority: 10},
+		}
+		if operations := m.renderOperations(); operations != "" {
+			config.StatusSegments = append([]StatusSegment{{Text: operations, Priority: 80}}, config.StatusSegments...)
+		}
+		if m.refreshPaused {
+			paused := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).
+				Render(i18n.Sprintf("⏸ 刷新已暂停 (%s 恢复)", m.keys.Global.PauseRefresh.Help().Key))
+			config.StatusSegments = append([]StatusSegment{{Text: paused, Priority: 90}}, config.StatusSegments...)
+		}
+		config.HelpText = helpLine(" | ", m.keys.Global.NextTab, m.keys.Global.Quit, m.keys.Global.Help)
+		config.Toasts = m.toasts.Toasts()
+		config.ScrollHint = i18n.Sprintf("%s/%s 翻页", m.keys.Global.PageUp.Help().Key, m.keys.Global.PageDown.Help().Key)
 
 		// 获取当前活动标签页的内容
 		if m.activeTab < len(m.tabRegistry.GetTabs()) {
 			activeTab := m.tabRegistry.GetTabByIndex(m.activeTab)
 			config.MainContent = activeTab.View(m.width, m.height)
 		}
+		if banner := m.renderAlertBanner(m.width - 12); banner != "" {
+			config.MainContent = banner + "\n\n" + config.MainContent
+		}
 	})
 
 	return m.layout.Render()
@@ -327,57 +737,85 @@ func (m *MainDashboard) shouldInterceptKeysForCurrentTab() bool {
 		return configTab.IsInFormMode()
 	}
 
-	// 可以扩展其他需要独占键盘输入的标签页类型
-	return false
-}
+	// 设置标签页选择版本时
+	if settingsTab, ok := activeTab.(*SettingsTab); ok {
+		return settingsTab.IsInInputMode()
+	}
 
-func (m *MainDashboard) updateStatus(currentTime time.Time) {
-	m.statusInfo.LastUpdate = currentTime
+	// 远程服务器标签页编辑配置或等待确认时
+	if remoteTab, ok := activeTab.(*RemoteTab); ok {
+		return remoteTab.IsInInputMode()
+	}
 
-	previousServerStatus := m.statusInfo.ServerStatus
-	previousClientStatus := m.statusInfo.ClientStatus
+	// 仪表盘等待确认关闭代理时
+	if dashboardTab, ok := activeTab.(*DashboardTab); ok {
+		return dashboardTab.IsInInputMode()
+	}
 
-	// 更新服务器状态
-	if m.checkServerStatus() {
-		m.statusInfo.ServerStatus = "运行中"
-	} else {
-		m.statusInfo.ServerStatus = "已停止"
+	// 客户端标签页等待确认断开时
+	if clientsTab, ok := activeTab.(*ClientsTab); ok {
+		return clientsTab.IsInInputMode()
 	}
 
-	statusChanged := (previousServerStatus != m.statusInfo.ServerStatus) ||
-		(previousClientStatus != m.statusInfo.ClientStatus)
+	// 日志标签页输入搜索条件时
+	if logsTab, ok := activeTab.(*LogsTab); ok {
+		return logsTab.IsInInputMode()
+	}
 
-	shouldUpdateProxy := m.lastProxyUpdate.IsZero() ||
-		statusChanged ||
-		currentTime.Sub(m.lastProxyUpdate) >= 3*time.Second ||
-		(m.statusInfo.ServerStatus == "运行中" && m.statusInfo.ActiveProxies == 0 &&
-			currentTime.Sub(m.lastProxyUpdate) >= 1*time.Second)
+	// 扩展标签页通过 InputCapturer 声明需要独占键盘输入
+	if capturer, ok := activeTab.(InputCapturer); ok {
+		return capturer.IsInInputMode()
+	}
+	return false
+}
 
-	if m.apiClient != nil && shouldUpdateProxy {
-		m.updateProxyInfo()
-		m.lastProxyUpdate = currentTime
-	} else if m.statusInfo.ServerStatus != "运行中" {
-		m.resetProxyInfo()
+// updateStatus 更新时钟，到达轮询间隔时返回后台获取 frps 状态的命令
+func (m *MainDashboard) updateStatus(currentTime time.Time) tea.Cmd {
+	m.statusInfo.LastUpdate = currentTime
+	if !m.pollDue(currentTime) {
+		return nil
 	}
+	return m.pollDashboard(currentTime)
 }
 
-func (m *MainDashboard) updateProxyInfo() {
-	proxies := m.getProxyList()
+// updateProxyInfo 将代理列表和服务器信息同步到各标签页
+func (m *MainDashboard) updateProxyInfo(proxies []ProxyStatus, serverInfo *service.ServerInfo) {
 	m.statusInfo.ActiveProxies = len(proxies)
 
 	if tab, ok := m.tabRegistry.GetTabByIndex(0).(*DashboardTab); ok {
 		tab.UpdateProxyList(proxies)
 	}
 
-	if m.statusInfo.ServerStatus == "运行中" {
-		if serverInfo, err := m.apiClient.GetServerInfo(); err == nil {
-			totalTraffic := serverInfo.TotalTrafficIn + serverInfo.TotalTrafficOut
-			m.statusInfo.TotalTraffic = service.FormatTraffic(totalTraffic)
-		} else if m.statusInfo.TotalTraffic == "" {
-			m.statusInfo.TotalTraffic = "N/A"
+	// 同步代理名称供日志按代理过滤，并记录流量采样
+	names := make([]string, len(proxies))
+	for i, proxy := range proxies {
+		names[i] = proxy.Name
+	}
+	// 流量统计按自己的采样间隔记录，不随每次 API 轮询写入
+	now := time.Now()
+	sampleTraffic := now.Sub(m.lastTraffic) >= m.appSettings.TrafficDuration()
+	if sampleTraffic {
+		m.lastTraffic = now
+	}
+	for _, tab := range m.tabRegistry.GetTabs() {
+		switch t := tab.(type) {
+		case *LogsTab:
+			t.SetProxyNames(names)
+		case *TrafficTab:
+			if sampleTraffic {
+				t.RecordSample(proxies, now)
+			}
+		}
+	}
+
+	if serverInfo != nil {
+		totalTraffic := serverInfo.TotalTrafficIn + serverInfo.TotalTrafficOut
+		m.statusInfo.TotalTraffic = service.FormatTraffic(totalTraffic)
+		if tab, ok := m.tabRegistry.GetTabByIndex(1).(*TrafficTab); ok && sampleTraffic {
+			tab.RecordServerSample(serverInfo.TotalTrafficIn, serverInfo.TotalTrafficOut, now)
 		}
-	} else {
-		m.statusInfo.TotalTraffic = "0B"
+	} else if m.statusInfo.TotalTraffic == "" {
+		m.statusInfo.TotalTraffic = "N/A"
 	}
 }
 
@@ -391,3 +829,38 @@ func (m *MainDashboard) resetProxyInfo() {
 
 	m.lastProxyUpdate = time.Time{}
 }
+
+// pumpLogs 从日志订阅中读取新日志并分发给所有日志接收方，
+// 避免日志只被当前激活的标签页消费
+func (m *MainDashboard) pumpLogs() {
+	if m.logs == nil {
+		return
+	}
+
+	var entries []service.LogMessage
+
+	// 非阻塞读取所有可用的新日志
+	for len(entries) < cap(m.logs) {
+		select {
+		case logMsg, ok := <-m.logs:
+			if !ok {
+				m.logs = nil
+				break
+			}
+			entries = append(entries, logMsg)
+			continue
+		default:
+		}
+		break
+	}
+
+	if len(entries) == 0 {
+		return
+	}
+
+	for _, tab := range m.tabRegistry.GetTabs() {
+		if sink, ok := tab.(LogSink); ok {
+			sink.AppendLogs(entries)
+		}
+	}
+}
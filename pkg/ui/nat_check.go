@@ -0,0 +1,402 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// NAT 检测参数
+const (
+	natCheckTimeout   = 15 * time.Second
+	natMappingTimeout = 10 * time.Second
+	natMappingLease   = time.Hour // 端口映射的租期，过期后路由器自动删除
+)
+
+// natCheckMsg NAT 类型检测和路由器端口映射协议发现完成
+type natCheckMsg struct {
+	id          int
+	report      *service.NATReport
+	err         error
+	mapper      service.PortMapper
+	mapperErr   error
+	externalIP  string
+	externalErr error
+}
+
+// natMapping 本次会话添加的端口映射
+type natMapping struct {
+	protocol string
+	internal int
+	external int
+}
+
+// natMappingMsg 端口映射添加或删除完成
+type natMappingMsg struct {
+	id      int
+	added   *natMapping  // 添加成功的映射
+	deleted []natMapping // 删除成功的映射
+	err     error
+}
+
+// natCheck xtcp 打洞诊断面板的状态
+type natCheck struct {
+	id          int // 检测编号，忽略已关闭或已重新检测的结果
+	running     bool
+	cancel      context.CancelFunc
+	report      *service.NATReport
+	err         error
+	mapper      service.PortMapper
+	mapperErr   error
+	externalIP  string
+	externalErr error
+	mappings    []natMapping
+	busy        bool // 正在添加或删除映射
+	mappingErr  error
+	editing     bool
+	input       textinput.Model
+}
+
+// handleNATCheck 打开 xtcp 打洞诊断面板并开始检测
+func (ct *ConfigTab) handleNATCheck() (Tab, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "udp 40000"
+	input.CharLimit = 32
+
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	ct.natCheck = &natCheck{input: input}
+	ct.state = ConfigTabNATCheck
+	return ct, ct.startNATCheck()
+}
+
+// startNATCheck 在后台并行进行 STUN 检测和路由器端口映射协议发现
+func (ct *ConfigTab) startNATCheck() tea.Cmd {
+	n := ct.natCheck
+	if n.cancel != nil {
+		n.cancel()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), natCheckTimeout)
+	n.id++
+	n.running, n.cancel = true, cancel
+	n.report, n.err, n.mapper, n.mapperErr = nil, nil, nil, nil
+
+	id := n.id
+	return func() tea.Msg {
+		msg := natCheckMsg{id: id}
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			msg.report, msg.err = service.DetectNAT(ctx, service.DefaultSTUNServers)
+		}()
+		go func() {
+			defer wg.Done()
+			msg.mapper, msg.mapperErr = service.DiscoverPortMapper(ctx)
+			if msg.mapper != nil {
+				msg.externalIP, msg.externalErr = msg.mapper.ExternalIP(ctx)
+			}
+		}()
+		wg.Wait()
+		return msg
+	}
+}
+
+// closeNATCheck 关闭面板并取消正在进行的检测，已添加的映射在租期结束后由路由器删除
+func (ct *ConfigTab) closeNATCheck() {
+	if ct.natCheck != nil && ct.natCheck.cancel != nil {
+		ct.natCheck.cancel()
+	}
+	ct.natCheck = nil
+}
+
+// handleNATCheckResult 记录检测结果，面板已关闭或已重新检测时忽略
+func (ct *ConfigTab) handleNATCheckResult(msg natCheckMsg) {
+	n := ct.natCheck
+	if n == nil || msg.id != n.id {
+		return
+	}
+	n.cancel()
+	n.running, n.cancel = false, nil
+	n.report, n.err = msg.report, msg.err
+	n.mapper, n.mapperErr = msg.mapper, msg.mapperErr
+	n.externalIP, n.externalErr = msg.externalIP, msg.externalErr
+}
+
+// handleNATMappingResult 记录映射的添加或删除结果
+func (ct *ConfigTab) handleNATMappingResult(msg natMappingMsg) {
+	n := ct.natCheck
+	if n == nil || msg.id != n.id {
+		return
+	}
+	n.busy, n.mappingErr = false, msg.err
+	if msg.added != nil {
+		n.mappings = append(n.mappings, *msg.added)
+	}
+	for _, deleted := range msg.deleted {
+		for i, m := range n.mappings {
+			if m == deleted {
+				n.mappings = append(n.mappings[:i], n.mappings[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// updateNATCheck 处理 xtcp 打洞诊断面板中的消息，输入映射端口时按键交给输入框
+func (ct *ConfigTab) updateNATCheck(msg tea.Msg) (Tab, tea.Cmd) {
+	n := ct.natCheck
+
+	if n.editing {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			switch keyMsg.String() {
+			case "esc":
+				n.editing = false
+				n.input.Blur()
+				return ct, nil
+			case "enter":
+				protocol, internal, external, err := parseNATMapping(n.input.Value())
+				if err != nil {
+					n.mappingErr = err
+					return ct, nil
+				}
+				n.editing = false
+				n.input.Blur()
+				return ct, ct.addNATMapping(protocol, internal, external)
+			}
+		}
+		var cmd tea.Cmd
+		n.input, cmd = n.input.Update(msg)
+		return ct, cmd
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return ct, nil
+	}
+	switch {
+	case keyMsg.String() == "esc":
+		ct.closeNATCheck()
+		ct.state = ConfigTabMenu
+	case n.busy:
+		// 等待路由器响应，避免重新检测后丢失映射结果
+	case key.Matches(keyMsg, ct.keys.Config.NATCheck):
+		return ct, ct.startNATCheck()
+	case n.running || n.mapper == nil:
+	case key.Matches(keyMsg, ct.keys.Config.AddMapping):
+		n.editing, n.mappingErr = true, nil
+		n.input.SetValue("")
+		n.input.Focus()
+		return ct, textinput.Blink
+	case key.Matches(keyMsg, ct.keys.Config.DeleteMappings) && len(n.mappings) > 0:
+		return ct, ct.deleteNATMappings()
+	}
+	return ct, nil
+}
+
+// parseNATMapping 解析映射输入，格式为 "协议 端口" 或 "协议 内部端口:外部端口"，省略协议时为 udp
+func parseNATMapping(value string) (protocol string, internal, external int, err error) {
+	fields := strings.Fields(strings.ToLower(value))
+	protocol = "udp"
+	if len(fields) == 2 {
+		protocol, fields = fields[0], fields[1:]
+	}
+	if len(fields) != 1 || (protocol != "udp" && protocol != "tcp") {
+		return "", 0, 0, i18n.Errorf("格式应为 \"udp 40000\" 或 \"tcp 6000:16000\"")
+	}
+
+	internalText, externalText, found := strings.Cut(fields[0], ":")
+	if !found {
+		externalText = internalText
+	}
+	internal, err1 := strconv.Atoi(internalText)
+	external, err2 := strconv.Atoi(externalText)
+	if err1 != nil || err2 != nil || internal < 1 || internal > 65535 || external < 1 || external > 65535 {
+		return "", 0, 0, i18n.Errorf("端口必须是 1-65535 之间的数字")
+	}
+	return protocol, internal, external, nil
+}
+
+// addNATMapping 在后台请求路由器添加端口映射
+func (ct *ConfigTab) addNATMapping(protocol string, internal, external int) tea.Cmd {
+	n := ct.natCheck
+	n.busy, n.mappingErr = true, nil
+	id, mapper := n.id, n.mapper
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), natMappingTimeout)
+		defer cancel()
+		actual, err := mapper.AddMapping(ctx, protocol, internal, external, natMappingLease)
+		if err != nil {
+			return natMappingMsg{id: id, err: err}
+		}
+		return natMappingMsg{id: id, added: &natMapping{protocol: protocol, internal: internal, external: actual}}
+	}
+}
+
+// deleteNATMappings 在后台删除本次会话添加的全部映射
+func (ct *ConfigTab) deleteNATMappings() tea.Cmd {
+	n := ct.natCheck
+	n.busy, n.mappingErr = true, nil
+	id, mapper, mappings := n.id, n.mapper, append([]natMapping(nil), n.mappings...)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), natMappingTimeout)
+		defer cancel()
+		msg := natMappingMsg{id: id}
+		for _, m := range mappings {
+			if err := mapper.DeleteMapping(ctx, m.protocol, m.internal, m.external); err != nil {
+				msg.err = err
+				continue
+			}
+			msg.deleted = append(msg.deleted, m)
+		}
+		return msg
+	}
+}
+
+// xtcpNames 返回客户端配置中 xtcp 代理和访问者的名称
+func (ct *ConfigTab) xtcpNames() []string {
+	if ct.clientConfig == nil {
+		return nil
+	}
+	var names []string
+	for _, proxy := range ct.clientConfig.Proxies {
+		if proxy.Type == "xtcp" && !proxy.Disabled {
+			names = append(names, proxy.Name)
+		}
+	}
+	for _, visitor := range ct.clientConfig.Visitors {
+		if visitor.Type == "xtcp" {
+			names = append(names, visitor.Name)
+		}
+	}
+	return names
+}
+
+// natTypeText 返回 NAT 类型的显示文本
+func natTypeText(t service.NATType) string {
+	switch t {
+	case service.NATNone:
+		return i18n.T("无 NAT（公网地址）")
+	case service.NATCone:
+		return i18n.T("锥形 NAT (Cone)")
+	case service.NATSymmetric:
+		return i18n.T("对称型 NAT (Symmetric)")
+	default:
+		return i18n.T("未知")
+	}
+}
+
+// renderNATCheck 渲染 xtcp 打洞诊断面板
+func (ct *ConfigTab) renderNATCheck() string {
+	n := ct.natCheck
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	sectionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+
+	content := titleStyle.Render(i18n.T("🕳️ XTCP 打洞诊断")) + "\n"
+	if names := ct.xtcpNames(); len(names) > 0 {
+		content += hintStyle.Render(i18n.Sprintf("客户端配置中的 xtcp 代理/访问者: %s", strings.Join(names, ", "))) + "\n\n"
+	} else {
+		content += hintStyle.Render(i18n.T("当前客户端配置中没有 xtcp 代理或访问者，检测结果仍可用于判断本网络能否打洞")) + "\n\n"
+	}
+
+	if n.running {
+		content += i18n.T("⏳ 正在通过 STUN 检测 NAT 类型并查找支持 UPnP/NAT-PMP 的路由器...") + "\n"
+		return content + "\n" + hintStyle.Render(i18n.T("ESC 返回菜单"))
+	}
+
+	content += sectionStyle.Render(i18n.T("📡 NAT 类型")) + "\n"
+	if n.report != nil {
+		for _, result := range n.report.Results {
+			if result.Err != nil {
+				content += "  " + errorStyle.Render(fmt.Sprintf("✗ %s: %v", result.Server, result.Err)) + "\n"
+			} else {
+				content += "  " + i18n.Sprintf("✓ %s 看到的地址: %s", result.Server, result.Mapped) + "\n"
+			}
+		}
+		if n.report.LocalAddr != "" {
+			content += "  " + i18n.Sprintf("本机地址: %s", n.report.LocalAddr) + "\n"
+		}
+	}
+	if n.err != nil {
+		content += "  " + errorStyle.Render("❌ "+n.err.Error()) + "\n"
+	} else if n.report != nil {
+		typeStyle := okStyle
+		if n.report.Type != service.NATNone && n.report.Type != service.NATCone {
+			typeStyle = warnStyle
+		}
+		line := i18n.Sprintf("类型: %s", natTypeText(n.report.Type))
+		if n.report.Type == service.NATSymmetric {
+			line += i18n.Sprintf("，端口差 %+d", n.report.PortDelta)
+		}
+		if n.report.Preserved {
+			line += i18n.T("，保持本地端口")
+		}
+		content += "  " + typeStyle.Render(line) + "\n"
+		content += "  " + lipgloss.NewStyle().Width(80).Render(n.report.Likelihood()) + "\n"
+	}
+
+	content += "\n" + sectionStyle.Render(i18n.T("🔀 路由器端口映射")) + "\n"
+	switch {
+	case n.mapperErr != nil:
+		content += "  " + hintStyle.Render(n.mapperErr.Error()) + "\n"
+	case n.mapper != nil:
+		content += "  " + i18n.Sprintf("✓ 网关 %s 支持 %s", n.mapper.Gateway(), n.mapper.Protocol()) + "\n"
+		if n.externalErr != nil {
+			content += "  " + errorStyle.Render(i18n.Sprintf("获取 WAN 口地址失败: %v", n.externalErr)) + "\n"
+		} else if n.externalIP != "" {
+			content += "  " + i18n.Sprintf("WAN 口地址: %s", n.externalIP) + "\n"
+			publicIP := ""
+			if n.report != nil {
+				publicIP = n.report.PublicIP
+			}
+			if warning := service.DoubleNATWarning(n.externalIP, publicIP); warning != "" {
+				content += "  " + warnStyle.Render("⚠ "+warning) + "\n"
+			}
+		}
+		for _, m := range n.mappings {
+			content += "  " + okStyle.Render(i18n.Sprintf("✓ 已映射 %s %d → 本机 %d（%s 后过期）",
+				strings.ToUpper(m.protocol), m.external, m.internal, natMappingLease)) + "\n"
+		}
+		if n.busy {
+			content += "  " + i18n.T("⏳ 正在与路由器通信...") + "\n"
+		}
+	}
+	if n.mappingErr != nil {
+		content += "  " + errorStyle.Render("❌ "+n.mappingErr.Error()) + "\n"
+	}
+	if n.editing {
+		content += "\n" + i18n.T("输入要映射的端口，如 udp 40000 或 tcp 6000:16000（本机端口:外部端口）") + "\n" + n.input.View() + "\n"
+		return content + "\n" + hintStyle.Render(i18n.T("Enter 添加映射 | ESC 取消"))
+	}
+
+	content += "\n" + hintStyle.Render(lipgloss.NewStyle().Width(80).Render(
+		i18n.T("提示: frpc 打洞时使用随机端口，固定的端口映射不能保证 xtcp 成功；映射适合让对端直接连接本机的固定端口，或用于确认路由器是否开启了 UPnP/NAT-PMP"))) + "\n\n"
+
+	help := fmt.Sprintf("%s %s", ct.keys.Config.NATCheck.Help().Key, i18n.T("重新检测"))
+	if n.mapper != nil {
+		help += " | " + i18n.Sprintf("%s 添加映射", ct.keys.Config.AddMapping.Help().Key)
+		if len(n.mappings) > 0 {
+			help += " | " + i18n.Sprintf("%s 删除本次添加的映射", ct.keys.Config.DeleteMappings.Help().Key)
+		}
+	}
+	return content + hintStyle.Render(help+" | "+i18n.T("ESC 返回菜单"))
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"sync/atomic"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/i18n"
+)
+
+// operationSeq 后台操作编号
+var operationSeq atomic.Int64
+
+// operationStartMsg 请求在后台执行一个耗时操作，由主界面登记后再启动
+type operationStartMsg struct {
+	id    int64
+	label string
+	run   func() tea.Msg
+}
+
+// operationDoneMsg 后台操作结束，result 为操作自身的结果消息
+type operationDoneMsg struct {
+	id     int64
+	result tea.Msg
+}
+
+// runOperation 在后台 goroutine 中执行进程启停、网络请求等可能阻塞的操作，
+// 执行期间状态栏显示 spinner 和 label，结束后 run 返回的消息按原来的方式分发
+func runOperation(label string, run func() tea.Msg) tea.Cmd {
+	id := operationSeq.Add(1)
+	return func() tea.Msg {
+		return operationStartMsg{id: id, label: label, run: run}
+	}
+}
+
+// pendingOperation 正在执行的后台操作
+type pendingOperation struct {
+	id    int64
+	label string
+}
+
+// startOperation 登记后台操作并返回执行它的命令，第一个操作开始时启动 spinner
+func (m *MainDashboard) startOperation(msg operationStartMsg) tea.Cmd {
+	m.operations = append(m.operations, pendingOperation{id: msg.id, label: msg.label})
+
+	run := func() tea.Msg {
+		return operationDoneMsg{id: msg.id, result: msg.run()}
+	}
+	if len(m.operations) == 1 {
+		return tea.Batch(run, m.spinner.Tick)
+	}
+	return run
+}
+
+// finishOperation 移除已结束的操作，并将操作结果重新分发
+func (m *MainDashboard) finishOperation(msg operationDoneMsg) tea.Cmd {
+	for i, op := range m.operations {
+		if op.id == msg.id {
+			m.operations = append(m.operations[:i], m.operations[i+1:]...)
+			break
+		}
+	}
+	if msg.result == nil {
+		return nil
+	}
+	return func() tea.Msg { return msg.result }
+}
+
+// updateSpinner 推进 spinner 动画，没有进行中的操作时停止
+func (m *MainDashboard) updateSpinner(msg spinner.TickMsg) tea.Cmd {
+	if len(m.operations) == 0 {
+		return nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return cmd
+}
+
+// renderOperations 渲染进行中的操作，多个操作时只显示最早的一个和数量
+func (m *MainDashboard) renderOperations() string {
+	if len(m.operations) == 0 {
+		return ""
+	}
+	text := m.operations[0].label
+	if len(m.operations) > 1 {
+		text += i18n.Sprintf(" 等 %d 个操作", len(m.operations))
+	}
+	return m.spinner.View() + lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(text)
+}
+
+// processOperation 在后台启停 frps/frpc，结束后通知 done 或错误
+func processOperation(label, done string, run func() error) tea.Cmd {
+	return runOperation(label, func() tea.Msg {
+		if err := run(); err != nil {
+			return statusMessageMsg{text: "❌ " + err.Error(), isError: true}
+		}
+		return statusMessageMsg{text: done}
+	})
+}
@@ -0,0 +1,328 @@
+package ui
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 配对助手阶段
+const (
+	pairingStageChoose = iota
+	pairingStageGenerate
+	pairingStageImport
+	pairingStageResult
+)
+
+// 配对助手的操作
+const (
+	pairingActionGenerate = "generate"
+	pairingActionImport   = "import"
+)
+
+// pairingAssistant stcp/xtcp/sudp 配对助手：一次生成代理和访问者两端，或导入另一台机器导出的访问者
+type pairingAssistant struct {
+	stage int
+	form  *huh.Form
+
+	// 表单绑定字段
+	action     string
+	proxyType  string
+	name       string
+	localPort  string
+	bindPort   string
+	importPath string
+
+	// 生成结果
+	pairing    *config.Pairing
+	snippet    string
+	format     config.ConfigFormat
+	exportPath string
+
+	// 导入结果
+	imported *config.VisitorImport
+
+	err error
+}
+
+// handlePairing 打开配对助手
+func (ct *ConfigTab) handlePairing() (Tab, tea.Cmd) {
+	p := &pairingAssistant{stage: pairingStageChoose}
+	p.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(i18n.T("要做什么？")).
+				Description(i18n.T("stcp/xtcp/sudp 需要两台机器上的代理和访问者使用相同的 secretKey 和 serverName")).
+				Options(
+					huh.NewOption(i18n.T("生成代理和访问者 (本机提供服务)"), pairingActionGenerate),
+					huh.NewOption(i18n.T("导入访问者配置 (本机访问另一台机器的服务)"), pairingActionImport),
+				).
+				Value(&p.action),
+		).Title(i18n.T("🔐 STCP/XTCP 配对")),
+	)
+
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	ct.pairing = p
+	ct.state = ConfigTabPairing
+	return ct, p.form.Init()
+}
+
+// updatePairing 处理配对助手中的消息，表单阶段把消息交给表单
+func (ct *ConfigTab) updatePairing(msg tea.Msg) (Tab, tea.Cmd) {
+	p := ct.pairing
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			ct.pairing = nil
+			ct.state = ConfigTabMenu
+			return ct, nil
+		}
+
+		if p.stage == pairingStageResult {
+			switch {
+			case key.Matches(keyMsg, ct.keys.Config.CopyVisitor):
+				if p.snippet != "" {
+					return ct, copyCmd(p.snippet)
+				}
+			case key.Matches(keyMsg, ct.keys.Config.ExportVisitor):
+				if p.pairing != nil {
+					return ct, ct.exportPairing()
+				}
+			}
+			return ct, nil
+		}
+	}
+
+	if p.form == nil {
+		return ct, nil
+	}
+	form, cmd := p.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		p.form = f
+	}
+	if p.form.State != huh.StateCompleted {
+		return ct, cmd
+	}
+
+	switch p.stage {
+	case pairingStageChoose:
+		if p.action == pairingActionImport {
+			p.stage = pairingStageImport
+			p.form = ct.newPairingImportForm()
+		} else {
+			p.stage = pairingStageGenerate
+			p.form = ct.newPairingGenerateForm()
+		}
+		return ct, p.form.Init()
+	case pairingStageGenerate:
+		ct.finishPairing()
+	case pairingStageImport:
+		ct.importPairing()
+	}
+	return ct, cmd
+}
+
+// newPairingGenerateForm 创建生成代理和访问者的表单
+func (ct *ConfigTab) newPairingGenerateForm() *huh.Form {
+	p := ct.pairing
+	p.proxyType = "stcp"
+	p.name = config.UniqueProxyName(ct.clientConfig, "secret-ssh")
+	p.localPort = "22"
+	p.bindPort = "6000"
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(i18n.T("代理类型")).
+				Options(
+					huh.NewOption(i18n.T("stcp - 流量经 frps 中转，最稳定"), "stcp"),
+					huh.NewOption(i18n.T("xtcp - 点对点直连，打洞失败时无法连接"), "xtcp"),
+					huh.NewOption(i18n.T("sudp - UDP 服务，流量经 frps 中转"), "sudp"),
+				).
+				Value(&p.proxyType),
+
+			huh.NewInput().
+				Title(i18n.T("代理名称")).
+				Description(i18n.T("访问者的 serverName 会设置为这个名称")).
+				Value(&p.name).
+				Validate(ct.validatePairingName),
+
+			huh.NewInput().
+				Title(i18n.T("本地端口")).
+				Description(i18n.T("本机上该服务监听的端口")).
+				Value(&p.localPort).
+				Validate(validatePortInput),
+
+			huh.NewInput().
+				Title(i18n.T("访问者端口")).
+				Description(i18n.T("另一台机器上访问者监听的端口，通过 127.0.0.1:端口 访问本机服务")).
+				Value(&p.bindPort).
+				Validate(validatePortInput),
+		).Title(i18n.T("🔐 生成代理和访问者")),
+	)
+}
+
+// newPairingImportForm 创建导入访问者配置的表单
+func (ct *ConfigTab) newPairingImportForm() *huh.Form {
+	p := ct.pairing
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("访问者配置文件")).
+				Description(i18n.T("另一台机器上配对助手导出的 .toml/.yaml 文件，导入时会校验密钥")).
+				Placeholder("secret-ssh-visitor.toml").
+				Value(&p.importPath).
+				Validate(func(str string) error {
+					if strings.TrimSpace(str) == "" {
+						return i18n.Errorf("文件路径不能为空")
+					}
+					return nil
+				}),
+		).Title(i18n.T("📥 导入访问者配置")),
+	)
+}
+
+// validatePairingName 校验代理名称不为空、不含空格且未被使用
+func (ct *ConfigTab) validatePairingName(str string) error {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return i18n.Errorf("代理名称不能为空")
+	}
+	if strings.Contains(str, " ") {
+		return i18n.Errorf("代理名称不能包含空格，建议使用连字符")
+	}
+	if ct.clientConfig != nil {
+		for _, proxy := range ct.clientConfig.Proxies {
+			if proxy.Name == str {
+				return i18n.Errorf("代理名称 %s 已存在", str)
+			}
+		}
+	}
+	return nil
+}
+
+// finishPairing 生成代理和访问者，代理加入本机客户端配置，访问者生成片段供另一台机器使用
+func (ct *ConfigTab) finishPairing() {
+	p := ct.pairing
+	p.stage = pairingStageResult
+	p.form = nil
+
+	length := config.DefaultTokenLength
+	if ct.appSettings != nil {
+		length = ct.appSettings.TokenLength
+	}
+	localPort, _ := strconv.Atoi(strings.TrimSpace(p.localPort))
+	bindPort, _ := strconv.Atoi(strings.TrimSpace(p.bindPort))
+
+	p.pairing, p.err = config.NewPairing(p.proxyType, strings.TrimSpace(p.name), localPort, bindPort, length)
+	if p.err != nil {
+		return
+	}
+
+	if ct.clientConfig == nil {
+		ct.clientConfig = config.CreateDefaultClientConfig()
+	}
+	ct.clientConfig.Proxies = append(ct.clientConfig.Proxies, p.pairing.Proxy)
+	ct.recordHistory(i18n.T("添加配对代理 ") + p.pairing.Proxy.Name)
+
+	p.format = config.DetectFormat(ct.clientConfigPath)
+	if p.format == config.FormatINI {
+		p.format = config.FormatTOML
+	}
+	data, err := p.pairing.VisitorSnippet(ct.clientConfig, p.format)
+	if err != nil {
+		p.err = err
+		return
+	}
+	p.snippet = string(data)
+	p.exportPath = filepath.Join(filepath.Dir(ct.clientConfigPath), p.pairing.Visitor.Name+p.format.Extension())
+}
+
+// exportPairing 将访问者配置写入客户端配置所在目录
+func (ct *ConfigTab) exportPairing() tea.Cmd {
+	p := ct.pairing
+	if err := p.pairing.ExportVisitorSnippet(ct.clientConfig, p.exportPath); err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+	return showStatusMessage(i18n.Sprintf("✅ 已写入访问者配置 %s，复制到另一台机器后用配对助手导入", p.exportPath), false)
+}
+
+// importPairing 读取访问者配置，校验密钥后合并到本机客户端配置
+func (ct *ConfigTab) importPairing() {
+	p := ct.pairing
+	p.stage = pairingStageResult
+	p.form = nil
+
+	if ct.clientConfig == nil {
+		ct.clientConfig = config.CreateDefaultClientConfig()
+	}
+	p.imported, p.err = config.ImportVisitorFile(ct.clientConfig, strings.TrimSpace(p.importPath))
+	if p.err != nil {
+		return
+	}
+	names := append(append([]string(nil), p.imported.Added...), p.imported.Replaced...)
+	ct.recordHistory(i18n.T("导入访问者 ") + strings.Join(names, ", "))
+}
+
+// renderPairing 渲染配对助手
+func (ct *ConfigTab) renderPairing(width int) string {
+	p := ct.pairing
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
+
+	content := titleStyle.Render(i18n.T("🔐 STCP/XTCP 配对")) + "\n\n"
+
+	if p.stage != pairingStageResult {
+		content += p.form.View()
+		content += "\n\n" + hintStyle.Render(i18n.T("Enter 下一步 | ESC 返回菜单"))
+		return content
+	}
+
+	if p.err != nil {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Width(width-4).Render("❌ "+p.err.Error()) + "\n\n"
+		content += hintStyle.Render(i18n.T("ESC 返回菜单"))
+		return content
+	}
+
+	if p.imported != nil {
+		if len(p.imported.Added) > 0 {
+			content += successStyle.Render(i18n.Sprintf("✅ 已添加访问者: %s", strings.Join(p.imported.Added, ", "))) + "\n"
+		}
+		if len(p.imported.Replaced) > 0 {
+			content += successStyle.Render(i18n.Sprintf("✅ 已替换同名访问者: %s", strings.Join(p.imported.Replaced, ", "))) + "\n"
+		}
+		for _, warning := range p.imported.Warnings {
+			content += warningStyle.Width(width-4).Render("⚠️ "+warning) + "\n"
+		}
+		content += "\n" + hintStyle.Render(i18n.T("密钥校验通过；导入的内容尚未保存，请使用 💾 保存配置 写入文件")) + "\n\n"
+		content += hintStyle.Render(i18n.T("ESC 返回菜单"))
+		return content
+	}
+
+	proxy, visitor := p.pairing.Proxy, p.pairing.Visitor
+	content += successStyle.Render(i18n.Sprintf("✅ 已添加代理 %s (%s)，保存配置后生效", proxy.Name, proxy.Type)) + "\n"
+	content += i18n.Sprintf("另一台机器使用下面的访问者配置，访问 %s:%d 即可连接本机的 %d 端口", visitor.BindAddr, visitor.BindPort, proxy.LocalPort) + "\n"
+	if proxy.Type == "xtcp" {
+		content += warningStyle.Render(i18n.T("⚠️ xtcp 依赖 NAT 打洞，两端网络不支持时无法连接，可改用 stcp")) + "\n"
+	}
+	content += "\n" + strings.Join(highlightConfig(p.snippet, p.format), "\n") + "\n\n"
+	content += warningStyle.Render(i18n.T("⚠️ 访问者配置包含 secretKey 和 token，请通过可信渠道发送")) + "\n\n"
+	content += hintStyle.Render(i18n.Sprintf("%s 复制访问者配置 | %s 写入 %s | ESC 返回菜单",
+		ct.keys.Config.CopyVisitor.Help().Key, ct.keys.Config.ExportVisitor.Help().Key, p.exportPath))
+	return content
+}
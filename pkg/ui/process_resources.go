@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// SetResourceMonitor 设置进程资源监控，用于显示 frps/frpc 的 CPU 和内存曲线
+func (dt *DashboardTab) SetResourceMonitor(resources *service.ResourceMonitor) {
+	dt.resources = resources
+}
+
+// SetResourceMonitor 设置进程资源监控，用于在服务控制中显示 CPU、内存和运行时长
+func (st *SettingsTab) SetResourceMonitor(resources *service.ResourceMonitor) {
+	st.resources = resources
+}
+
+// resourceServices 显示资源占用的服务及其进程名
+var resourceServices = []struct {
+	key  string
+	name string
+}{
+	{"server", "frps"},
+	{"client", "frpc"},
+}
+
+// renderResources 渲染运行中的 frps/frpc 的 CPU、内存曲线和运行时长，都未运行时返回空字符串
+func (dt *DashboardTab) renderResources(width int) string {
+	if dt.resources == nil {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	labelStyle := lipgloss.NewStyle().Width(8)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	cpuStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	memoryStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+
+	// 两条曲线并排显示，其余宽度留给 PID、运行时长和当前值
+	columns := (width - 90) / 2
+	columns = max(columns, 10)
+
+	var lines string
+	for _, svc := range resourceServices {
+		samples := dt.resources.Samples(svc.key)
+		if len(samples) == 0 {
+			continue
+		}
+		if len(samples) > columns {
+			samples = samples[len(samples)-columns:]
+		}
+		cpu, memory := resourceSeries(samples)
+		last := samples[len(samples)-1]
+
+		lines += labelStyle.Render(svc.name) +
+			hintStyle.Render(i18n.Sprintf("PID %d • 运行 %s", last.PID, processUptime(last.StartTime))) + "  " +
+			"CPU " + cpuStyle.Render(renderSparkline(cpu)) + " " + fmt.Sprintf("%5.1f%%", last.CPU) + "  " +
+			i18n.T("内存") + " " + memoryStyle.Render(renderSparkline(memory)) + " " + service.FormatTraffic(int64(last.Memory)) + "\n"
+	}
+	if lines == "" {
+		return ""
+	}
+	return titleStyle.Render(i18n.T("🧮 进程资源")) + "  " + hintStyle.Render(i18n.T("CPU 以单核为 100%")) + "\n" + lines
+}
+
+// renderResourceLine 返回服务控制中单个进程的资源占用，未运行或尚无采样时返回空字符串
+func (st *SettingsTab) renderResourceLine(serviceKey string) string {
+	if st.resources == nil {
+		return ""
+	}
+	samples := st.resources.Samples(serviceKey)
+	if len(samples) == 0 {
+		return ""
+	}
+	if len(samples) > 16 {
+		samples = samples[len(samples)-16:]
+	}
+	cpu, memory := resourceSeries(samples)
+	last := samples[len(samples)-1]
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	return "   " + hintStyle.Render(i18n.Sprintf("CPU %s %.1f%% • 内存 %s %s • 运行 %s",
+		renderSparkline(cpu), last.CPU, renderSparkline(memory),
+		service.FormatTraffic(int64(last.Memory)), processUptime(last.StartTime))) + "\n"
+}
+
+// resourceSeries 将采样转换为迷你图数据，CPU 保留两位小数精度
+func resourceSeries(samples []service.ResourceSample) (cpu, memory []int64) {
+	cpu = make([]int64, len(samples))
+	memory = make([]int64, len(samples))
+	for i, sample := range samples {
+		cpu[i] = int64(sample.CPU * 100)
+		memory[i] = int64(sample.Memory)
+	}
+	return cpu, memory
+}
+
+// processUptime 返回进程运行时长，启动时间未知时显示 -
+func processUptime(start time.Time) string {
+	if start.IsZero() {
+		return "-"
+	}
+	return formatUptime(time.Since(start))
+}
+
+// formatUptime 格式化运行时长，只保留最大的两个单位
+func formatUptime(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := int(d / (24 * time.Hour))
+	hours := int(d/time.Hour) % 24
+	minutes := int(d/time.Minute) % 60
+	seconds := int(d/time.Second) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%02dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm%02ds", minutes, seconds)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
@@ -0,0 +1,206 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 代理详情中可执行的写操作
+const (
+	proxyActionClose  = "close"
+	proxyActionPause  = "pause"
+	proxyActionResume = "resume"
+)
+
+// proxyActionMsg 代理操作的结果
+type proxyActionMsg struct {
+	name   string
+	action string
+	result string
+	err    error
+}
+
+// proxyAction 正在执行的代理操作，记录操作前的状态，失败时回滚
+type proxyAction struct {
+	action   string
+	status   string // 预期的状态，轮询结果在操作完成前沿用该状态
+	curConns int
+	previous ProxyStatus
+	detail   *service.ProxyInfo
+}
+
+// SetManager 设置进程管理器，用于暂停和恢复本机客户端配置中的代理
+func (dt *DashboardTab) SetManager(manager *service.Manager) {
+	dt.manager = manager
+}
+
+// IsInInputMode 等待确认关闭代理时独占键盘
+func (dt *DashboardTab) IsInInputMode() bool {
+	return dt.confirmClose
+}
+
+// requestCloseProxy 请求关闭详情中的代理，需要确认
+func (dt *DashboardTab) requestCloseProxy() tea.Cmd {
+	if dt.apiClient == nil {
+		return nil
+	}
+	if dt.actions[dt.detail.name] != nil {
+		return showStatusMessage(i18n.Sprintf("❌ 代理 %s 正在执行其他操作", dt.detail.name), true)
+	}
+	dt.confirmClose = true
+	return nil
+}
+
+// closeProxy 让 frps 关闭代理并断开其连接，立即将代理显示为离线
+func (dt *DashboardTab) closeProxy() tea.Cmd {
+	name, apiClient := dt.detail.name, dt.apiClient
+	dt.beginAction(name, proxyActionClose, "offline")
+	return runOperation(i18n.Sprintf("正在关闭代理 %s", name), func() tea.Msg {
+		return proxyActionMsg{name: name, action: proxyActionClose, err: apiClient.CloseProxy(name)}
+	})
+}
+
+// togglePauseProxy 暂停在线的代理或恢复离线的代理：修改本机客户端配置中的 disabled 并重载 frpc
+func (dt *DashboardTab) togglePauseProxy() tea.Cmd {
+	d := dt.detail
+	if d.proxy == nil {
+		return showStatusMessage(i18n.T("❌ 代理详情尚未加载"), true)
+	}
+	if dt.manager == nil || dt.appSettings == nil || dt.appSettings.ClientConfigPath == "" {
+		return showStatusMessage(i18n.T("❌ 未设置客户端配置，无法暂停或恢复代理"), true)
+	}
+	if dt.actions[d.name] != nil {
+		return showStatusMessage(i18n.Sprintf("❌ 代理 %s 正在执行其他操作", d.name), true)
+	}
+
+	configPath := dt.appSettings.ClientConfigPath
+	if state := dt.manager.GetProcessState("client"); state != nil && state.ConfigPath != "" {
+		configPath = state.ConfigPath
+	}
+
+	name, manager := d.name, dt.manager
+	action, status, label := proxyActionPause, "offline", i18n.Sprintf("正在暂停代理 %s", name)
+	if d.proxy.Status != "online" {
+		action, status, label = proxyActionResume, "online", i18n.Sprintf("正在恢复代理 %s", name)
+	}
+	dt.beginAction(name, action, status)
+	return runOperation(label, func() tea.Msg {
+		result, err := manager.SetProxyDisabled(configPath, name, action == proxyActionPause)
+		return proxyActionMsg{name: name, action: action, result: result, err: err}
+	})
+}
+
+// beginAction 记录操作前的状态并立即显示预期的结果
+func (dt *DashboardTab) beginAction(name, action, status string) {
+	pending := &proxyAction{action: action, status: status}
+	for _, proxy := range dt.proxies {
+		if proxy.Name == name {
+			pending.previous = proxy
+			break
+		}
+	}
+	if action == proxyActionResume {
+		pending.curConns = pending.previous.CurConns
+	}
+	if dt.detail != nil && dt.detail.name == name && dt.detail.proxy != nil {
+		previous := *dt.detail.proxy
+		pending.detail = &previous
+		dt.detail.proxy.Status = status
+		dt.detail.proxy.CurConns = pending.curConns
+	}
+
+	if dt.actions == nil {
+		dt.actions = make(map[string]*proxyAction)
+	}
+	dt.actions[name] = pending
+	dt.applyPendingActions()
+	dt.refreshRows()
+}
+
+// applyPendingActions 让正在执行操作的代理沿用预期状态，避免操作完成前的轮询结果覆盖
+func (dt *DashboardTab) applyPendingActions() {
+	for i, proxy := range dt.proxies {
+		if pending := dt.actions[proxy.Name]; pending != nil {
+			dt.proxies[i].Status = pending.status
+			dt.proxies[i].CurConns = pending.curConns
+		}
+	}
+}
+
+// handleProxyAction 处理代理操作的结果，失败时回滚到操作前的状态
+func (dt *DashboardTab) handleProxyAction(msg proxyActionMsg) tea.Cmd {
+	pending := dt.actions[msg.name]
+	delete(dt.actions, msg.name)
+
+	if msg.err != nil {
+		if pending != nil {
+			dt.rollbackAction(msg.name, pending)
+		}
+		text := msg.err.Error()
+		if msg.action == proxyActionClose {
+			text = clientsErrorText(msg.err, i18n.T("当前 frps 不支持关闭代理"))
+		}
+		return showStatusMessage("❌ "+text, true)
+	}
+
+	var text string
+	switch msg.action {
+	case proxyActionClose:
+		text = i18n.Sprintf("✅ 已关闭代理 %s，frpc 会重新注册代理", msg.name)
+	case proxyActionPause:
+		text = i18n.Sprintf("✅ 已暂停代理 %s", msg.name)
+	default:
+		text = i18n.Sprintf("✅ 已恢复代理 %s", msg.name)
+	}
+	if msg.result != "" {
+		text += " (" + msg.result + ")"
+	}
+
+	var refresh tea.Cmd
+	if dt.detail != nil && dt.detail.name == msg.name {
+		refresh = dt.fetchDetail()
+	}
+	return tea.Batch(showStatusMessage(text, false), refresh)
+}
+
+// rollbackAction 恢复操作前的状态，期间轮询到的流量等数据保持不变
+func (dt *DashboardTab) rollbackAction(name string, pending *proxyAction) {
+	for i, proxy := range dt.proxies {
+		if proxy.Name == name {
+			dt.proxies[i].Status = pending.previous.Status
+			dt.proxies[i].CurConns = pending.previous.CurConns
+		}
+	}
+	if dt.detail != nil && dt.detail.name == name && dt.detail.proxy != nil && pending.detail != nil {
+		dt.detail.proxy.Status = pending.detail.Status
+		dt.detail.proxy.CurConns = pending.detail.CurConns
+	}
+	dt.refreshRows()
+}
+
+// renderProxyActions 渲染详情中的操作提示、进行中的操作和关闭确认
+func (dt *DashboardTab) renderProxyActions() string {
+	name := dt.detail.name
+	if dt.confirmClose {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("226")).
+			Render(i18n.Sprintf("确定关闭代理 %s 吗？当前连接会被断开，frpc 会重新注册代理 (y/N)", name)) + "\n\n"
+	}
+
+	pending := dt.actions[name]
+	if pending == nil {
+		return ""
+	}
+	var text string
+	switch pending.action {
+	case proxyActionClose:
+		text = i18n.T("⏳ 正在关闭代理...")
+	case proxyActionPause:
+		text = i18n.T("⏳ 正在暂停代理...")
+	default:
+		text = i18n.T("⏳ 正在恢复代理...")
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(text) + "\n\n"
+}
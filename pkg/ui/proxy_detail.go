@@ -0,0 +1,244 @@
+package ui
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// proxyDetailMsg 代理详情查询结果
+type proxyDetailMsg struct {
+	name   string
+	proxy  *service.ProxyInfo
+	server *service.ServerInfo
+	err    error
+}
+
+// proxyDetail 仪表盘中打开的代理详情
+type proxyDetail struct {
+	name      string
+	proxyType string
+	proxy     *service.ProxyInfo
+	server    *service.ServerInfo
+	err       error
+	fetchedAt time.Time
+	loading   bool
+}
+
+// openDetail 打开表格当前选中代理的详情
+func (dt *DashboardTab) openDetail() tea.Cmd {
+	row := dt.table.SelectedRow()
+	if len(row) < 2 {
+		return nil
+	}
+
+	dt.detail = &proxyDetail{name: row[0], proxyType: row[1]}
+	return dt.fetchDetail()
+}
+
+// fetchDetail 从 frps API 获取代理详情和服务端信息
+func (dt *DashboardTab) fetchDetail() tea.Cmd {
+	if dt.detail == nil || dt.apiClient == nil || dt.detail.loading {
+		return nil
+	}
+
+	dt.detail.loading = true
+	dt.detail.fetchedAt = time.Now()
+	name, proxyType, apiClient := dt.detail.name, dt.detail.proxyType, dt.apiClient
+
+	return func() tea.Msg {
+		proxy, err := apiClient.GetProxyInfo(proxyType, name)
+		if err != nil {
+			return proxyDetailMsg{name: name, err: err}
+		}
+		// 服务端信息只用于解析访问地址，失败时不影响详情展示
+		server, _ := apiClient.GetServerInfo()
+		return proxyDetailMsg{name: name, proxy: proxy, server: server}
+	}
+}
+
+// handleDetailResult 处理详情查询结果，已关闭或切换的详情忽略
+func (dt *DashboardTab) handleDetailResult(msg proxyDetailMsg) {
+	if dt.detail == nil || dt.detail.name != msg.name {
+		return
+	}
+
+	dt.detail.loading = false
+	dt.detail.err = msg.err
+	if msg.err != nil {
+		return
+	}
+	dt.detail.proxy = msg.proxy
+	if pending := dt.actions[msg.name]; pending != nil {
+		msg.proxy.Status = pending.status
+		msg.proxy.CurConns = pending.curConns
+	}
+	if msg.server != nil {
+		dt.detail.server = msg.server
+	}
+}
+
+// resolveRemoteAddr 根据代理类型解析外部访问地址
+func (dt *DashboardTab) resolveRemoteAddr(proxy *service.ProxyInfo, server *service.ServerInfo) string {
+	host := dt.serverHost()
+
+	switch proxy.Conf.Type {
+	case "tcp", "udp":
+		if proxy.Conf.RemotePort > 0 {
+			return net.JoinHostPort(host, fmt.Sprintf("%d", proxy.Conf.RemotePort))
+		}
+
+	case "http", "https":
+		var domains []string
+		domains = append(domains, proxy.Conf.CustomDomains...)
+		if proxy.Conf.SubDomain != "" && server != nil && server.SubdomainHost != "" {
+			domains = append(domains, proxy.Conf.SubDomain+"."+server.SubdomainHost)
+		}
+		if len(domains) == 0 {
+			break
+		}
+
+		port := 0
+		if server != nil {
+			port = server.VhostHTTPPort
+			if proxy.Conf.Type == "https" {
+				port = server.VhostHTTPSPort
+			}
+		}
+
+		addrs := make([]string, len(domains))
+		for i, domain := range domains {
+			addrs[i] = proxy.Conf.Type + "://" + domain
+			if port > 0 && !(proxy.Conf.Type == "http" && port == 80) && !(proxy.Conf.Type == "https" && port == 443) {
+				addrs[i] += fmt.Sprintf(":%d", port)
+			}
+		}
+		return strings.Join(addrs, ", ")
+
+	case "stcp", "sudp", "xtcp":
+		return i18n.T("仅限访问者连接")
+	}
+
+	return "N/A"
+}
+
+// copyRemoteAddr 复制代理的访问地址。详情中使用解析后的地址，列表中只有 TCP/UDP 代理能直接拼出 主机:端口
+func (dt *DashboardTab) copyRemoteAddr() tea.Cmd {
+	if dt.detail != nil {
+		p := dt.detail.proxy
+		if p == nil {
+			return showStatusMessage(i18n.T("❌ 代理详情尚未加载"), true)
+		}
+		switch p.Conf.Type {
+		case "stcp", "sudp", "xtcp":
+			return showStatusMessage(i18n.T("❌ 该代理仅限访问者连接，没有访问地址"), true)
+		}
+		addr := dt.resolveRemoteAddr(p, dt.detail.server)
+		if addr == "N/A" {
+			return showStatusMessage(i18n.T("❌ 该代理没有可复制的访问地址"), true)
+		}
+		return copyCmd(addr)
+	}
+
+	row := dt.table.SelectedRow()
+	if len(row) < 4 {
+		return nil
+	}
+	if port, err := strconv.Atoi(row[3]); err == nil && port > 0 && (row[1] == "tcp" || row[1] == "udp") {
+		return copyCmd(net.JoinHostPort(dt.serverHost(), row[3]))
+	}
+	return showStatusMessage(i18n.Sprintf("❌ 请按 %s 打开详情后复制 %s 代理的访问地址", dt.keys.Dashboard.Detail.Help().Key, row[1]), true)
+}
+
+// serverHost 从当前 Dashboard 目标的地址推断服务端主机
+func (dt *DashboardTab) serverHost() string {
+	if dt.apiClient != nil {
+		if parsed, err := url.Parse(dt.apiClient.BaseURL()); err == nil && parsed.Hostname() != "" {
+			return parsed.Hostname()
+		}
+	}
+	return "127.0.0.1"
+}
+
+// renderDetail 渲染代理详情
+func (dt *DashboardTab) renderDetail(width int) string {
+	d := dt.detail
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Width(12)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(1).
+		Width(width - 12)
+
+	content := titleStyle.Render(i18n.Sprintf("🔍 代理详情: %s", d.name)) + "\n\n"
+
+	if d.err != nil {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+d.err.Error()) + "\n\n"
+	}
+
+	if d.proxy == nil {
+		if d.err == nil {
+			content += hintStyle.Render(i18n.T("正在加载...")) + "\n\n"
+		}
+	} else {
+		p := d.proxy
+		localAddr := "N/A"
+		if p.Conf.LocalIP != "" {
+			localAddr = p.Conf.LocalIP
+			if p.Conf.LocalPort > 0 {
+				localAddr = net.JoinHostPort(p.Conf.LocalIP, fmt.Sprintf("%d", p.Conf.LocalPort))
+			}
+		}
+
+		rows := []struct {
+			label string
+			value string
+		}{
+			{i18n.T("类型"), p.Conf.Type},
+			{i18n.T("状态"), p.Status},
+			{i18n.T("本地地址"), localAddr},
+			{i18n.T("访问地址"), dt.resolveRemoteAddr(p, d.server)},
+			{i18n.T("当前连接"), fmt.Sprintf("%d", p.CurConns)},
+			{i18n.T("今日上行"), formatTraffic(p.TodayTrafficIn)},
+			{i18n.T("今日下行"), formatTraffic(p.TodayTrafficOut)},
+			{i18n.T("客户端版本"), valueOrDash(p.ClientVersion)},
+			{i18n.T("最近启动"), valueOrDash(p.LastStartTime)},
+			{i18n.T("最近关闭"), valueOrDash(p.LastCloseTime)},
+		}
+		for _, row := range rows {
+			content += labelStyle.Render(row.label) + row.value + "\n"
+		}
+		content += "\n"
+	}
+
+	content += dt.renderProxyActions()
+
+	updated := "-"
+	if !d.fetchedAt.IsZero() {
+		updated = d.fetchedAt.Format("15:04:05")
+	}
+	content += hintStyle.Render(i18n.Sprintf("更新于 %s • Esc: 返回列表", updated) + " • " +
+		helpLine(" • ", dt.keys.Dashboard.Copy, dt.keys.Dashboard.CloseProxy, dt.keys.Dashboard.PauseProxy))
+
+	return boxStyle.Render(content)
+}
+
+// valueOrDash 空值显示为 -
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// proxyLabelForm 在代理列表中编辑代理标签和备注的表单
+type proxyLabelForm struct {
+	form   *huh.Form
+	proxy  string
+	labels string
+	note   string
+}
+
+// editProxyLabels 为代理列表中选中的代理打开标签编辑表单
+func (ct *ConfigTab) editProxyLabels(index int) tea.Cmd {
+	meta, err := config.LoadProxyMetadata(ct.clientConfigPath)
+	if err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+	ct.proxyList.meta = meta
+
+	name := ct.clientConfig.Proxies[index].Name
+	current := meta.Get(name)
+	f := &proxyLabelForm{
+		proxy:  name,
+		labels: strings.Join(current.Labels, ", "),
+		note:   current.Note,
+	}
+	f.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("标签")).
+				Description(i18n.T("逗号分隔，如 prod, homelab；仪表盘可按标签筛选和分组")).
+				Value(&f.labels),
+
+			huh.NewInput().
+				Title(i18n.T("备注")).
+				Value(&f.note),
+		).Title(i18n.Sprintf("🏷️ 代理 %s 的标签", name)),
+	)
+	ct.proxyList.labelForm = f
+	return f.form.Init()
+}
+
+// updateProxyLabelForm 把消息交给标签表单，提交后写入标签文件
+func (ct *ConfigTab) updateProxyLabelForm(msg tea.Msg) (Tab, tea.Cmd) {
+	f := ct.proxyList.labelForm
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "esc" {
+		ct.proxyList.labelForm = nil
+		return ct, nil
+	}
+
+	form, cmd := f.form.Update(msg)
+	if updated, ok := form.(*huh.Form); ok {
+		f.form = updated
+	}
+	if f.form.State != huh.StateCompleted {
+		return ct, cmd
+	}
+
+	ct.proxyList.labelForm = nil
+	meta := ct.proxyList.meta
+	meta.Set(f.proxy, config.ProxyMeta{
+		Labels: config.ParseLabels(f.labels),
+		Note:   strings.TrimSpace(f.note),
+	})
+	if err := meta.Save(ct.clientConfigPath); err != nil {
+		return ct, showStatusMessage("❌ "+err.Error(), true)
+	}
+	return ct, showStatusMessage(i18n.Sprintf("🏷️ 已更新代理 %s 的标签，保存在 %s", f.proxy, config.ProxyMetaPath(ct.clientConfigPath)), false)
+}
@@ -0,0 +1,450 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// proxyList 客户端代理列表，可编辑、删除、启用/停用、复制代理或将代理拆分到 confd 下的独立文件。
+// 光标和分页由 list.Model 维护，按键仍由配置标签页按自定义快捷键处理
+type proxyList struct {
+	list          list.Model
+	confirmDelete bool
+
+	meta      *config.ProxyMetadata
+	labelForm *proxyLabelForm
+}
+
+// proxyListItem 代理列表中的一行
+type proxyListItem struct {
+	proxy config.ProxyConfig
+	meta  config.ProxyMeta
+}
+
+// FilterValue 实现 list.Item，代理列表不启用过滤
+func (i proxyListItem) FilterValue() string { return i.proxy.Name }
+
+// proxyListDelegate 渲染代理行：选中行高亮，停用的代理置灰，超出宽度时截断
+type proxyListDelegate struct{}
+
+func (proxyListDelegate) Height() int                         { return 1 }
+func (proxyListDelegate) Spacing() int                        { return 0 }
+func (proxyListDelegate) Update(tea.Msg, *list.Model) tea.Cmd { return nil }
+
+func (proxyListDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	row, ok := item.(proxyListItem)
+	if !ok {
+		return
+	}
+	proxy := row.proxy
+
+	check := "[✓]"
+	if proxy.Disabled {
+		check = "[ ]"
+	}
+	line := fmt.Sprintf("%s %-20s %-6s %s:%d", check, proxy.Name, proxy.Type, proxy.LocalIP, proxy.LocalPort)
+	if proxy.RemotePort > 0 {
+		line += fmt.Sprintf(" → :%d", proxy.RemotePort)
+	}
+	if proxy.Source != "" {
+		line += "  📄 " + filepath.Base(proxy.Source)
+	}
+	if !row.meta.IsEmpty() {
+		line += "  🏷️ " + strings.Join(row.meta.Labels, ",")
+		if row.meta.Note != "" {
+			line += " · " + row.meta.Note
+		}
+	}
+	if m.Width() > 2 {
+		line = ansi.Truncate(line, m.Width()-2, "…")
+	}
+
+	switch {
+	case index == m.Index():
+		line = "▶ " + lipgloss.NewStyle().
+			Background(lipgloss.Color("#7D56F4")).
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Render(line)
+	case proxy.Disabled:
+		line = "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(line)
+	default:
+		line = "  " + line
+	}
+	fmt.Fprint(w, line)
+}
+
+// newProxyList 创建代理列表并选中指定的代理
+func newProxyList(proxies []config.ProxyConfig, meta *config.ProxyMetadata, selected int) *proxyList {
+	l := list.New(nil, proxyListDelegate{}, 0, 0)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.SetShowPagination(false)
+	l.SetFilteringEnabled(false)
+	l.InfiniteScrolling = true
+
+	pl := &proxyList{list: l, meta: meta}
+	pl.setProxies(proxies)
+	pl.list.Select(max(min(selected, len(proxies)-1), 0))
+	return pl
+}
+
+// setProxies 用客户端配置中的代理和标签刷新列表，光标超出时停在最后一个代理上
+func (pl *proxyList) setProxies(proxies []config.ProxyConfig) {
+	items := make([]list.Item, len(proxies))
+	for i, proxy := range proxies {
+		items[i] = proxyListItem{proxy: proxy, meta: pl.meta.Get(proxy.Name)}
+	}
+	index := pl.list.Index()
+	pl.list.SetItems(items)
+	pl.list.Select(max(min(index, len(items)-1), 0))
+}
+
+// setSize 设置列表区域大小，代理较少时只占用需要的行数；删除确认提示占用两行，分页时留一行显示页码
+func (pl *proxyList) setSize(width, maxHeight int) {
+	if pl.confirmDelete {
+		maxHeight -= 2
+	}
+	height := len(pl.list.Items())
+	if height > maxHeight {
+		height = maxHeight - 1
+	}
+	pl.list.SetSize(width, max(height, 1))
+}
+
+// view 渲染列表，有多页时在下方显示页码
+func (pl *proxyList) view() string {
+	content := pl.list.View() + "\n"
+	if pl.list.Paginator.TotalPages > 1 {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).
+			Render(i18n.Sprintf("第 %d/%d 页", pl.list.Paginator.Page+1, pl.list.Paginator.TotalPages)) + "\n"
+	}
+	return content
+}
+
+// syncProxyList 代理列表打开时用客户端配置刷新列表
+func (ct *ConfigTab) syncProxyList() {
+	if ct.proxyList != nil && ct.clientConfig != nil {
+		ct.proxyList.setProxies(ct.clientConfig.Proxies)
+	}
+}
+
+// selected 返回选中代理在客户端配置中的下标
+func (pl *proxyList) selected() int {
+	return pl.list.Index()
+}
+
+// handleProxyList 打开客户端代理列表
+func (ct *ConfigTab) handleProxyList() (Tab, tea.Cmd) {
+	if ct.clientConfig == nil || len(ct.clientConfig.Proxies) == 0 {
+		return ct, showStatusMessage(i18n.T("❌ 客户端配置中还没有代理，请先添加代理"), true)
+	}
+
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	// 默认选中最后一个代理，通常是刚添加的那个
+	meta, err := config.LoadProxyMetadata(ct.clientConfigPath)
+	ct.proxyList = newProxyList(ct.clientConfig.Proxies, meta, len(ct.clientConfig.Proxies)-1)
+	ct.state = ConfigTabProxyList
+	if err != nil {
+		return ct, showStatusMessage("❌ "+err.Error(), true)
+	}
+	return ct, nil
+}
+
+// updateProxyList 处理代理列表中的按键，编辑标签时把按键交给标签表单
+func (ct *ConfigTab) updateProxyList(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	// 按键可能启用/停用、拆分或删除代理，处理后让列表跟随客户端配置
+	defer ct.syncProxyList()
+
+	if ct.proxyList.labelForm != nil {
+		return ct.updateProxyLabelForm(msg)
+	}
+
+	if ct.proxyList.confirmDelete {
+		ct.proxyList.confirmDelete = false
+		if msg.String() == "y" {
+			return ct, ct.deleteProxy(ct.proxyList.selected())
+		}
+		return ct, nil
+	}
+
+	keys := ct.keys.Config
+	count := len(ct.clientConfig.Proxies)
+	cursor := ct.proxyList.selected()
+
+	// 空格与确认选择共用按键，需要先于 Select 判断
+	switch {
+	case msg.String() == "esc", count == 0:
+		ct.proxyList = nil
+		ct.state = ConfigTabMenu
+	case key.Matches(msg, keys.Toggle):
+		return ct, ct.toggleProxy(cursor)
+	case key.Matches(msg, keys.ToggleAll):
+		return ct, ct.toggleAllProxies()
+	case key.Matches(msg, keys.Up):
+		ct.proxyList.list.CursorUp()
+	case key.Matches(msg, keys.Down):
+		ct.proxyList.list.CursorDown()
+	case key.Matches(msg, keys.Select):
+		return ct.editProxy(cursor)
+	case key.Matches(msg, keys.Duplicate):
+		return ct.duplicateProxy(cursor)
+	case key.Matches(msg, keys.DeleteProxy):
+		ct.proxyList.confirmDelete = true
+	case key.Matches(msg, keys.Split):
+		return ct, ct.splitProxy(cursor)
+	case key.Matches(msg, keys.SplitAll):
+		return ct, ct.splitAllProxies()
+	case key.Matches(msg, keys.Labels):
+		return ct, ct.editProxyLabels(cursor)
+	}
+	return ct, nil
+}
+
+// toggleProxy 切换代理的启用状态，保存配置后生效
+func (ct *ConfigTab) toggleProxy(index int) tea.Cmd {
+	proxy := &ct.clientConfig.Proxies[index]
+	proxy.Disabled = !proxy.Disabled
+
+	if proxy.Disabled {
+		ct.recordHistory(i18n.T("停用代理 ") + proxy.Name)
+		return showStatusMessage(i18n.Sprintf("⏸️ 已停用代理 %s，保存配置后生效", proxy.Name), false)
+	}
+	ct.recordHistory(i18n.T("启用代理 ") + proxy.Name)
+	return showStatusMessage(i18n.Sprintf("▶️ 已启用代理 %s，保存配置后生效", proxy.Name), false)
+}
+
+// toggleAllProxies 有启用的代理时全部停用，否则全部启用
+func (ct *ConfigTab) toggleAllProxies() tea.Cmd {
+	disable := ct.clientConfig.EnabledProxyCount() > 0
+	for i := range ct.clientConfig.Proxies {
+		ct.clientConfig.Proxies[i].Disabled = disable
+	}
+
+	if disable {
+		ct.recordHistory(i18n.T("停用全部代理"))
+		return showStatusMessage(i18n.T("⏸️ 已停用全部代理，保存配置后生效"), false)
+	}
+	ct.recordHistory(i18n.T("启用全部代理"))
+	return showStatusMessage(i18n.T("▶️ 已启用全部代理，保存配置后生效"), false)
+}
+
+// splitProxy 将写在主配置中的代理拆分到 confd 下的独立文件，已拆分的代理则合并回主配置，保存配置后生效
+func (ct *ConfigTab) splitProxy(index int) tea.Cmd {
+	if config.DetectFormat(ct.clientConfigPath) == config.FormatINI {
+		return showStatusMessage(i18n.T("❌ INI 配置不支持 includes，请先迁移到 TOML/YAML"), true)
+	}
+
+	proxy := &ct.clientConfig.Proxies[index]
+	if proxy.Source != "" {
+		proxy.Source = ""
+		ct.recordHistory(i18n.T("合并代理 ") + proxy.Name)
+		return showStatusMessage(i18n.Sprintf("📥 代理 %s 将合并回主配置，保存配置后生效", proxy.Name), false)
+	}
+
+	proxy.Source = config.IncludeFilePath(ct.clientConfig, ct.clientConfigPath, proxy.Name)
+	ct.recordHistory(i18n.T("拆分代理 ") + proxy.Name)
+	return showStatusMessage(i18n.Sprintf("📤 代理 %s 将保存到 %s，保存配置后生效",
+		proxy.Name, filepath.Join(config.IncludeDir, filepath.Base(proxy.Source))), false)
+}
+
+// splitAllProxies 有写在主配置中的代理时全部拆分到独立文件，否则全部合并回主配置
+func (ct *ConfigTab) splitAllProxies() tea.Cmd {
+	if config.DetectFormat(ct.clientConfigPath) == config.FormatINI {
+		return showStatusMessage(i18n.T("❌ INI 配置不支持 includes，请先迁移到 TOML/YAML"), true)
+	}
+
+	cfg := ct.clientConfig
+	if cfg.IncludedProxyCount() < len(cfg.Proxies) {
+		for i := range cfg.Proxies {
+			if cfg.Proxies[i].Source == "" {
+				cfg.Proxies[i].Source = config.IncludeFilePath(cfg, ct.clientConfigPath, cfg.Proxies[i].Name)
+			}
+		}
+		ct.recordHistory(i18n.T("拆分全部代理"))
+		return showStatusMessage(i18n.Sprintf("📤 全部代理将分别保存到 %s 目录，主配置中生成 includes，保存配置后生效",
+			config.IncludeDir), false)
+	}
+
+	for i := range cfg.Proxies {
+		cfg.Proxies[i].Source = ""
+	}
+	ct.recordHistory(i18n.T("合并全部代理"))
+	return showStatusMessage(i18n.T("📥 全部代理将合并回主配置，保存配置后生效"), false)
+}
+
+// duplicateProxy 复制代理并在代理表单中打开副本，表单提交后才会加入客户端配置
+func (ct *ConfigTab) duplicateProxy(index int) (Tab, tea.Cmd) {
+	clone, err := config.DuplicateProxy(ct.clientConfig, index)
+	if err != nil {
+		return ct, showStatusMessage("❌ "+err.Error(), true)
+	}
+
+	ct.proxyList = nil
+	ct.currentProxy = &clone
+	ct.editingProxy = ""
+	ct.currentForm = NewProxyConfigForm(ct.currentProxy)
+	ct.currentForm.SetTakenNames(ct.proxyNames(""))
+	ct.state = ConfigTabProxyForm
+	ct.focusOnForm = true
+	return ct, tea.Batch(
+		ct.currentForm.Init(),
+		showStatusMessage(i18n.Sprintf("📑 已复制代理 %s 为 %s，修改后提交表单即可添加",
+			ct.clientConfig.Proxies[index].Name, clone.Name), false),
+	)
+}
+
+// editProxy 在代理表单中编辑代理的副本，提交后替换原代理并写入配置文件
+func (ct *ConfigTab) editProxy(index int) (Tab, tea.Cmd) {
+	proxy := ct.clientConfig.Proxies[index].Clone()
+
+	ct.proxyList = nil
+	ct.currentProxy = &proxy
+	ct.editingProxy = proxy.Name
+	ct.currentForm = NewProxyConfigForm(ct.currentProxy)
+	ct.currentForm.SetTakenNames(ct.proxyNames(proxy.Name))
+	ct.state = ConfigTabProxyForm
+	ct.focusOnForm = true
+	return ct, ct.currentForm.Init()
+}
+
+// finishEditProxy 用提交的表单替换正在编辑的代理，改名时标签随之迁移
+func (ct *ConfigTab) finishEditProxy(proxy config.ProxyConfig) tea.Cmd {
+	name := ct.editingProxy
+	ct.editingProxy = ""
+	index := slices.IndexFunc(ct.clientConfig.Proxies, func(p config.ProxyConfig) bool { return p.Name == name })
+	if index < 0 {
+		return showStatusMessage(i18n.Sprintf("❌ 代理 %s 已不在客户端配置中", name), true)
+	}
+
+	ct.clientConfig.Proxies[index] = proxy
+	ct.recordHistory(i18n.T("编辑代理 ") + proxy.Name)
+	if proxy.Name != name {
+		ct.moveProxyMeta(name, proxy.Name)
+	}
+
+	saved, err := ct.saveProxyChange(func(l *config.Loader) error { return l.UpdateProxy(name, proxy) })
+	switch {
+	case err != nil:
+		return showStatusMessage(i18n.Sprintf("代理 %s 已更新，但保存配置失败: %v", proxy.Name, err), true)
+	case saved:
+		return showStatusMessage(i18n.Sprintf("✅ 已更新代理 %s 并保存到 %s", proxy.Name, ct.clientConfigPath), false)
+	default:
+		return showStatusMessage(i18n.Sprintf("✏️ 已更新代理 %s，保存配置后生效", proxy.Name), false)
+	}
+}
+
+// deleteProxy 从客户端配置中删除代理，代理已写入配置文件时同时从文件中移除
+func (ct *ConfigTab) deleteProxy(index int) tea.Cmd {
+	name := ct.clientConfig.Proxies[index].Name
+	ct.clientConfig.Proxies = slices.Delete(ct.clientConfig.Proxies, index, index+1)
+	ct.recordHistory(i18n.T("删除代理 ") + name)
+	ct.moveProxyMeta(name, "")
+
+	if len(ct.clientConfig.Proxies) == 0 {
+		ct.proxyList = nil
+		ct.state = ConfigTabMenu
+	}
+
+	saved, err := ct.saveProxyChange(func(l *config.Loader) error { return l.RemoveProxy(name) })
+	switch {
+	case err != nil:
+		return showStatusMessage(i18n.Sprintf("代理 %s 已删除，但保存配置失败: %v", name, err), true)
+	case saved:
+		return showStatusMessage(i18n.Sprintf("🗑️ 已删除代理 %s 并保存到 %s", name, ct.clientConfigPath), false)
+	default:
+		return showStatusMessage(i18n.Sprintf("🗑️ 已删除代理 %s，保存配置后生效", name), false)
+	}
+}
+
+// saveProxyChange 在磁盘上的客户端配置中单独应用一次代理修改并保存，不会带上内存中其他尚未保存的修改。
+// 配置文件不存在或代理尚未写入文件时返回 false，修改在保存配置后生效
+func (ct *ConfigTab) saveProxyChange(change func(*config.Loader) error) (bool, error) {
+	loader := config.NewLoader(ct.clientConfigPath)
+	if _, err := loader.Load(); err != nil {
+		return false, nil
+	}
+	if err := change(loader); err != nil {
+		return false, nil
+	}
+	if err := loader.Save(loader.GetConfig()); err != nil {
+		return false, err
+	}
+	ct.events.Publish(service.ConfigSavedEvent("client", ct.clientConfigPath))
+	return true, nil
+}
+
+// moveProxyMeta 代理改名时迁移标签和备注，to 为空表示代理已删除
+func (ct *ConfigTab) moveProxyMeta(from, to string) {
+	meta, err := config.LoadProxyMetadata(ct.clientConfigPath)
+	if err != nil || meta.Get(from).IsEmpty() {
+		return
+	}
+	if to != "" {
+		meta.Set(to, meta.Get(from))
+	}
+	meta.Set(from, config.ProxyMeta{})
+	_ = meta.Save(ct.clientConfigPath)
+}
+
+// proxyNames 返回客户端配置中除 except 以外的代理名称
+func (ct *ConfigTab) proxyNames(except string) []string {
+	if ct.clientConfig == nil {
+		return nil
+	}
+	var names []string
+	for _, proxy := range ct.clientConfig.Proxies {
+		if proxy.Name != except {
+			names = append(names, proxy.Name)
+		}
+	}
+	return names
+}
+
+// renderProxyList 渲染客户端代理列表，超出右侧面板高度时分页显示
+func (ct *ConfigTab) renderProxyList() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	content := titleStyle.Render(i18n.T("📑 代理列表")) + "\n\n"
+	if f := ct.proxyList.labelForm; f != nil {
+		content += f.form.View()
+		content += "\n\n" + hintStyle.Render(i18n.T("Enter 保存 | ESC 取消"))
+		return content
+	}
+	if ct.proxyList.confirmDelete {
+		warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+		content += warnStyle.Render(i18n.Sprintf("确定删除代理 %s 吗？已写入配置文件的代理会同时从文件中删除 (y/N)",
+			ct.clientConfig.Proxies[ct.proxyList.selected()].Name)) + "\n\n"
+	}
+	content += ct.proxyList.view()
+
+	total := len(ct.clientConfig.Proxies)
+	content += hintStyle.Render(i18n.Sprintf("共 %d 个代理，%d 个已停用；停用的代理保存在配置文件末尾的注释中",
+		total, total-ct.clientConfig.EnabledProxyCount())) + "\n"
+	if included := ct.clientConfig.IncludedProxyCount(); included > 0 {
+		content += hintStyle.Render(i18n.Sprintf("%d 个代理保存在独立文件中，文件内代理全部停用时重命名为 .disabled", included)) + "\n"
+	}
+	keys := ct.keys.Config
+	content += "\n" + hintStyle.Render(i18n.Sprintf("↑/↓ 选择代理 | Enter 编辑 | %s 删除 | %s 启用/停用 | %s 全部启用/停用 | %s 复制并编辑 | %s 拆分/合并 | %s 全部拆分/合并 | %s 标签/备注 | ESC 返回菜单",
+		keys.DeleteProxy.Help().Key, keys.Toggle.Help().Key, keys.ToggleAll.Help().Key, keys.Duplicate.Help().Key,
+		keys.Split.Help().Key, keys.SplitAll.Help().Key, keys.Labels.Help().Key))
+	return content
+}
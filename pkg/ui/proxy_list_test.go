@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"frp-cli-ui/pkg/config"
+)
+
+// newProxyListTab 打开包含 count 个代理的代理列表
+func newProxyListTab(t *testing.T, count int) *ConfigTab {
+	t.Helper()
+	proxies := make([]config.ProxyConfig, count)
+	for i := range proxies {
+		proxies[i] = config.ProxyConfig{Name: fmt.Sprintf("proxy-%02d", i), Type: "tcp", LocalIP: "127.0.0.1", LocalPort: 8000 + i}
+	}
+
+	ct := NewConfigTab()
+	ct.clientConfigPath = t.TempDir() + "/frpc.toml"
+	ct.clientConfig = &config.Config{ServerAddr: "example.com", Proxies: proxies}
+	if _, cmd := ct.handleProxyList(); ct.proxyList == nil {
+		t.Fatalf("proxy list not opened: %v", cmd)
+	}
+	return ct
+}
+
+// pressProxyListKey 在代理列表中按下特殊键
+func pressProxyListKey(ct *ConfigTab, k tea.KeyType) {
+	ct.updateProxyList(tea.KeyMsg{Type: k})
+}
+
+func TestProxyListSelectsLastProxyAndWraps(t *testing.T) {
+	ct := newProxyListTab(t, 3)
+	if got := ct.proxyList.selected(); got != 2 {
+		t.Fatalf("selected %d after opening, want the last proxy", got)
+	}
+
+	pressProxyListKey(ct, tea.KeyDown)
+	if got := ct.proxyList.selected(); got != 0 {
+		t.Errorf("Down on the last proxy selected %d, want 0", got)
+	}
+	pressProxyListKey(ct, tea.KeyUp)
+	if got := ct.proxyList.selected(); got != 2 {
+		t.Errorf("Up on the first proxy selected %d, want 2", got)
+	}
+}
+
+func TestProxyListPaginates(t *testing.T) {
+	ct := newProxyListTab(t, 30)
+	ct.View(100, 30)
+
+	pl := ct.proxyList
+	if pl.list.Paginator.TotalPages < 2 {
+		t.Fatalf("30 proxies fit on one page of a 30 line screen")
+	}
+	if pl.list.Paginator.Page != pl.list.Paginator.TotalPages-1 {
+		t.Errorf("page %d, want the last page where the selected proxy is", pl.list.Paginator.Page)
+	}
+
+	view := ansi.Strip(ct.renderProxyList())
+	if !strings.Contains(view, "▶ [✓] proxy-29") {
+		t.Errorf("selected proxy not shown:\n%s", view)
+	}
+	if strings.Contains(view, "proxy-00") {
+		t.Errorf("proxy on the first page shown on the last page:\n%s", view)
+	}
+	if !strings.Contains(view, fmt.Sprintf("%d/%d", pl.list.Paginator.TotalPages, pl.list.Paginator.TotalPages)) {
+		t.Errorf("page indicator missing:\n%s", view)
+	}
+
+	// 翻到第一页
+	pressProxyListKey(ct, tea.KeyDown)
+	if pl.list.Paginator.Page != 0 || pl.selected() != 0 {
+		t.Errorf("Down on the last proxy went to page %d, index %d", pl.list.Paginator.Page, pl.selected())
+	}
+}
+
+func TestProxyListTruncatesLongLines(t *testing.T) {
+	ct := newProxyListTab(t, 1)
+	ct.clientConfig.Proxies[0].Name = strings.Repeat("long-name-", 20)
+	ct.proxyList.setProxies(ct.clientConfig.Proxies)
+	ct.proxyList.setSize(60, 10)
+
+	for _, line := range strings.Split(ct.proxyList.list.View(), "\n") {
+		if w := lipgloss.Width(line); w > 60 {
+			t.Errorf("line is %d columns wide, want at most 60: %q", w, ansi.Strip(line))
+		}
+	}
+}
+
+func TestProxyListFollowsConfigChanges(t *testing.T) {
+	ct := newProxyListTab(t, 3)
+
+	pressProxyListKey(ct, tea.KeySpace)
+	if !ct.clientConfig.Proxies[2].Disabled {
+		t.Fatalf("Space did not disable the selected proxy")
+	}
+	if !strings.Contains(ansi.Strip(ct.renderProxyList()), "[ ] proxy-02") {
+		t.Errorf("disabled proxy not shown as unchecked")
+	}
+
+	ct.proxyList.confirmDelete = true
+	ct.updateProxyList(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if len(ct.clientConfig.Proxies) != 2 {
+		t.Fatalf("%d proxies left after delete, want 2", len(ct.clientConfig.Proxies))
+	}
+	if got := ct.proxyList.selected(); got != 1 {
+		t.Errorf("selected %d after deleting the last proxy, want 1", got)
+	}
+	if len(ct.proxyList.list.Items()) != 2 {
+		t.Errorf("list has %d items after delete, want 2", len(ct.proxyList.list.Items()))
+	}
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"net"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// proxyProbeMsg 代理端到端探测结果
+type proxyProbeMsg struct {
+	name   string
+	result *service.ProxyProbeResult
+}
+
+// proxyProbe 一个代理最近一次的探测状态
+type proxyProbe struct {
+	running bool
+	result  *service.ProxyProbeResult
+}
+
+// probeSelected 探测表格当前选中的代理，结果显示在 端到端 列
+func (dt *DashboardTab) probeSelected() tea.Cmd {
+	row := dt.table.SelectedRow()
+	if len(row) < 2 || dt.apiClient == nil {
+		return nil
+	}
+	name, proxyType := row[0], row[1]
+	if probe := dt.probes[name]; probe != nil && probe.running {
+		return nil
+	}
+
+	switch proxyType {
+	case "stcp", "sudp", "xtcp":
+		return showStatusMessage(i18n.T("❌ 该代理仅限访问者连接，无法从外部探测"), true)
+	case "udp":
+		return showStatusMessage(i18n.T("❌ UDP 没有连接握手，无法判断隧道是否可用"), true)
+	}
+
+	if dt.probes == nil {
+		dt.probes = make(map[string]*proxyProbe)
+	}
+	dt.probes[name] = &proxyProbe{running: true}
+	dt.refreshRows()
+
+	apiClient, host := dt.apiClient, dt.serverHost()
+	return runOperation(i18n.Sprintf("正在探测代理 %s", name), func() tea.Msg {
+		proxy, err := apiClient.GetProxyInfo(proxyType, name)
+		if err != nil {
+			return proxyProbeMsg{name: name, result: &service.ProxyProbeResult{Err: err}}
+		}
+		server, _ := apiClient.GetServerInfo()
+		target, err := probeTarget(proxy, server, host)
+		if err != nil {
+			return proxyProbeMsg{name: name, result: &service.ProxyProbeResult{Err: err}}
+		}
+		return proxyProbeMsg{name: name, result: service.ProbeProxy(target, 0)}
+	})
+}
+
+// handleProbeResult 记录探测结果并在状态栏显示
+func (dt *DashboardTab) handleProbeResult(msg proxyProbeMsg) tea.Cmd {
+	dt.probes[msg.name] = &proxyProbe{result: msg.result}
+	dt.refreshRows()
+
+	if msg.result.Success() {
+		return showStatusMessage("✅ "+msg.name+": "+msg.result.Summary(), false)
+	}
+	if msg.result.Target.Addr == "" {
+		return showStatusMessage("❌ "+msg.name+": "+msg.result.Err.Error(), true)
+	}
+	return showStatusMessage("❌ "+msg.name+": "+msg.result.Summary(), true)
+}
+
+// probeTarget 按代理类型确定探测地址：TCP 使用远程端口，HTTP/HTTPS 使用虚拟主机端口和第一个域名
+func probeTarget(proxy *service.ProxyInfo, server *service.ServerInfo, host string) (service.ProxyProbeTarget, error) {
+	target := service.ProxyProbeTarget{Type: proxy.Conf.Type}
+
+	switch proxy.Conf.Type {
+	case "tcp":
+		if proxy.Conf.RemotePort <= 0 {
+			return target, i18n.Errorf("代理没有远程端口")
+		}
+		target.Addr = net.JoinHostPort(host, strconv.Itoa(proxy.Conf.RemotePort))
+
+	case "http", "https":
+		switch {
+		case len(proxy.Conf.CustomDomains) > 0:
+			target.Host = proxy.Conf.CustomDomains[0]
+		case proxy.Conf.SubDomain != "" && server != nil && server.SubdomainHost != "":
+			target.Host = proxy.Conf.SubDomain + "." + server.SubdomainHost
+		default:
+			return target, i18n.Errorf("代理没有可用的域名")
+		}
+
+		port := 0
+		if server != nil {
+			port = server.VhostHTTPPort
+			if proxy.Conf.Type == "https" {
+				port = server.VhostHTTPSPort
+			}
+		}
+		if port <= 0 {
+			return target, i18n.Errorf("frps 未开启 %s 虚拟主机端口", proxy.Conf.Type)
+		}
+		// 直接连接 frps，而不是解析域名，避免受 DNS 或 CDN 影响
+		target.Addr = net.JoinHostPort(host, strconv.Itoa(port))
+
+	default:
+		return target, i18n.Errorf("暂不支持探测 %s 代理", proxy.Conf.Type)
+	}
+	return target, nil
+}
+
+// probeCell 返回代理在 端到端 列中的显示内容
+func (dt *DashboardTab) probeCell(name string) string {
+	probe := dt.probes[name]
+	switch {
+	case probe == nil:
+		return "-"
+	case probe.running:
+		return i18n.T("⏳ 探测中")
+	case probe.result.Success():
+		return "✅ " + strconv.FormatInt(probe.result.Latency.Milliseconds(), 10) + "ms"
+	default:
+		return i18n.T("❌ 不通")
+	}
+}
@@ -0,0 +1,317 @@
+package ui
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 向导阶段
+const (
+	wizardStagePreset = iota
+	wizardStageDetails
+)
+
+// ProxyWizard 新建代理向导：先选择服务预设，再只询问必要的几项
+type ProxyWizard struct {
+	form      *huh.Form
+	stage     int
+	cfg       *config.Config
+	presetKey string
+	preset    config.ProxyPreset
+	completed bool
+	proxy     *config.ProxyConfig
+
+	// 表单绑定字段
+	name       string
+	localPort  string
+	remotePort string
+	domain     string
+	secretKey  string
+
+	bandwidthLimit string
+	useEncryption  bool
+	useCompression bool
+}
+
+// NewProxyWizard 创建代理向导，cfg 为要追加代理的客户端配置
+func NewProxyWizard(cfg *config.Config) *ProxyWizard {
+	w := &ProxyWizard{
+		stage: wizardStagePreset,
+		cfg:   cfg,
+	}
+
+	var options []huh.Option[string]
+	for _, preset := range config.ProxyPresets() {
+		label := fmt.Sprintf("%s - %s", preset.Name, preset.Description)
+		options = append(options, huh.NewOption(label, preset.Key))
+	}
+
+	w.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(i18n.T("要共享什么服务？")).
+				Description(i18n.T("选择后会自动填好端口和推荐的代理类型")).
+				Options(options...).
+				Value(&w.presetKey),
+		).Title(i18n.T("🧙 新建代理向导")),
+	)
+
+	return w
+}
+
+// Init 初始化向导
+func (w *ProxyWizard) Init() tea.Cmd {
+	return w.form.Init()
+}
+
+// Update 更新向导状态
+func (w *ProxyWizard) Update(msg tea.Msg) tea.Cmd {
+	if w.completed {
+		return nil
+	}
+
+	form, cmd := w.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		w.form = f
+	}
+
+	if w.form.State != huh.StateCompleted {
+		return cmd
+	}
+
+	switch w.stage {
+	case wizardStagePreset:
+		preset, err := config.GetProxyPreset(w.presetKey)
+		if err != nil {
+			return cmd
+		}
+		w.preset = preset
+		w.stage = wizardStageDetails
+		w.form = w.newDetailsForm()
+		return w.form.Init()
+
+	case wizardStageDetails:
+		w.completed = true
+		w.proxy = w.buildProxy()
+	}
+
+	return cmd
+}
+
+// newDetailsForm 根据预设创建详细信息表单，只包含该类型必须填写的字段
+func (w *ProxyWizard) newDetailsForm() *huh.Form {
+	preset := w.preset
+	w.name = config.UniqueProxyName(w.cfg, preset.Key)
+	w.localPort = strconv.Itoa(preset.LocalPort)
+	if preset.RemotePort > 0 {
+		w.remotePort = strconv.Itoa(w.suggestRemotePort(preset.RemotePort))
+	}
+	if preset.Type == "stcp" {
+		w.secretKey = randomSecretKey()
+	}
+
+	description := i18n.Sprintf("类型: %s，本地端口: %d", strings.ToUpper(preset.Type), preset.LocalPort)
+	if preset.Warning != "" {
+		description += "\n⚠️ " + preset.Warning
+	}
+
+	fields := []huh.Field{
+		huh.NewNote().
+			Title(preset.Name).
+			Description(description),
+
+		huh.NewInput().
+			Title(i18n.T("代理名称")).
+			Value(&w.name).
+			Validate(w.validateName),
+
+		huh.NewInput().
+			Title(i18n.T("本地端口")).
+			Description(i18n.T("本机上该服务监听的端口")).
+			Value(&w.localPort).
+			Validate(validatePortInput),
+	}
+
+	switch preset.Type {
+	case "tcp", "udp":
+		fields = append(fields, huh.NewInput().
+			Title(i18n.T("公网端口")).
+			Description(i18n.T("通过 服务器地址:公网端口 访问，需在 frps 允许的端口范围内")).
+			Value(&w.remotePort).
+			Validate(w.validateRemotePort))
+	case "http", "https":
+		fields = append(fields, huh.NewInput().
+			Title(i18n.T("访问域名")).
+			Description(i18n.T("需要将域名解析到 frps 服务器")).
+			Placeholder("app.example.com").
+			Value(&w.domain).
+			Validate(func(str string) error {
+				if strings.TrimSpace(str) == "" {
+					return i18n.Errorf("域名不能为空")
+				}
+				return nil
+			}))
+	case "stcp":
+		fields = append(fields, huh.NewInput().
+			Title(i18n.T("访问密钥")).
+			Description(i18n.T("访问者需要使用相同的密钥，已自动生成")).
+			Value(&w.secretKey).
+			Validate(func(str string) error {
+				if len(strings.TrimSpace(str)) < 6 {
+					return i18n.Errorf("密钥长度至少6个字符")
+				}
+				return nil
+			}))
+	}
+
+	options := huh.NewGroup(
+		huh.NewInput().
+			Title(i18n.T("带宽限制")).
+			Description(i18n.T("单个代理的最大带宽，单位 KB 或 MB，如 1MB，留空表示不限速")).
+			Placeholder("1MB").
+			Value(&w.bandwidthLimit).
+			Validate(validateBandwidthLimit),
+
+		huh.NewConfirm().
+			Title(i18n.T("加密传输")).
+			Description(i18n.T("在 frpc 与 frps 之间加密该代理的流量")).
+			Affirmative(i18n.T("开启")).
+			Negative(i18n.T("关闭")).
+			Value(&w.useEncryption),
+
+		huh.NewConfirm().
+			Title(i18n.T("压缩传输")).
+			Description(i18n.T("压缩该代理的流量，适合文本类数据，会增加 CPU 占用")).
+			Affirmative(i18n.T("开启")).
+			Negative(i18n.T("关闭")).
+			Value(&w.useCompression),
+	).Title(i18n.T("⚙️ 可选设置"))
+
+	return huh.NewForm(huh.NewGroup(fields...).Title(i18n.T("🔧 确认代理信息")), options)
+}
+
+// validateName 校验代理名称不为空且不重复
+func (w *ProxyWizard) validateName(str string) error {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return i18n.Errorf("代理名称不能为空")
+	}
+	if strings.Contains(str, " ") {
+		return i18n.Errorf("代理名称不能包含空格，建议使用连字符")
+	}
+	if w.cfg != nil {
+		for _, proxy := range w.cfg.Proxies {
+			if proxy.Name == str {
+				return i18n.Errorf("代理名称 %s 已存在", str)
+			}
+		}
+	}
+	return nil
+}
+
+// validateRemotePort 校验公网端口合法且未被其他代理使用
+func (w *ProxyWizard) validateRemotePort(str string) error {
+	if err := validatePortInput(str); err != nil {
+		return err
+	}
+	port, _ := strconv.Atoi(strings.TrimSpace(str))
+	if w.remotePortUsed(port) {
+		return i18n.Errorf("公网端口 %d 已被其他代理使用", port)
+	}
+	return nil
+}
+
+// remotePortUsed 检查同协议的公网端口是否已被占用
+func (w *ProxyWizard) remotePortUsed(port int) bool {
+	if w.cfg == nil {
+		return false
+	}
+	for _, proxy := range w.cfg.Proxies {
+		if proxy.RemotePort == port && proxy.Type == w.preset.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestRemotePort 从建议端口开始寻找未被占用的公网端口
+func (w *ProxyWizard) suggestRemotePort(port int) int {
+	for port < 65535 && w.remotePortUsed(port) {
+		port++
+	}
+	return port
+}
+
+// buildProxy 根据表单内容生成代理配置
+func (w *ProxyWizard) buildProxy() *config.ProxyConfig {
+	proxy := &config.ProxyConfig{
+		Name:    strings.TrimSpace(w.name),
+		Type:    w.preset.Type,
+		LocalIP: "127.0.0.1",
+	}
+	proxy.LocalPort, _ = strconv.Atoi(strings.TrimSpace(w.localPort))
+
+	switch w.preset.Type {
+	case "tcp", "udp":
+		proxy.RemotePort, _ = strconv.Atoi(strings.TrimSpace(w.remotePort))
+	case "http", "https":
+		proxy.CustomDomains = []string{strings.TrimSpace(w.domain)}
+	case "stcp":
+		proxy.SecretKey = strings.TrimSpace(w.secretKey)
+	}
+	proxy.Transport = config.ProxyTransport{
+		UseEncryption:  w.useEncryption,
+		UseCompression: w.useCompression,
+		BandwidthLimit: strings.TrimSpace(w.bandwidthLimit),
+	}
+
+	return proxy
+}
+
+// View 渲染向导
+func (w *ProxyWizard) View() string {
+	if w.completed {
+		return i18n.Sprintf("\n✅ 已添加代理 %s\n", w.proxy.Name)
+	}
+	return w.form.View()
+}
+
+// IsCompleted 向导是否完成
+func (w *ProxyWizard) IsCompleted() bool {
+	return w.completed
+}
+
+// Proxy 返回向导生成的代理配置，未完成时为空
+func (w *ProxyWizard) Proxy() *config.ProxyConfig {
+	return w.proxy
+}
+
+// validatePortInput 校验端口输入
+func validatePortInput(str string) error {
+	port, err := strconv.Atoi(strings.TrimSpace(str))
+	if err != nil {
+		return i18n.Errorf("端口必须是数字")
+	}
+	if port < 1 || port > 65535 {
+		return i18n.Errorf("端口必须在 1-65535 范围内")
+	}
+	return nil
+}
+
+// randomSecretKey 生成随机密钥
+func randomSecretKey() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 远程服务器表单字段顺序
+const (
+	remoteFieldName = iota
+	remoteFieldRole
+	remoteFieldHost
+	remoteFieldPort
+	remoteFieldUser
+	remoteFieldAuth
+	remoteFieldKeyPath
+	remoteFieldPassword
+	remoteFieldRemoteConfig
+	remoteFieldService
+	remoteFieldLogPath
+	remoteFieldSudo
+	remoteFieldLocalConfig
+)
+
+// remoteProfileForm 远程服务器编辑表单
+type remoteProfileForm struct {
+	inputs []textinput.Model
+	focus  int
+	err    error
+	title  string
+}
+
+// newRemoteProfileForm 创建远程服务器表单
+func newRemoteProfileForm(profile config.RemoteProfile, title string) *remoteProfileForm {
+	fields := []struct {
+		prompt      string
+		placeholder string
+		value       string
+	}{
+		{i18n.T("名称:         "), "vps-tokyo", profile.Name},
+		{i18n.T("服务类型:     "), "frps / frpc", profile.Role},
+		{i18n.T("主机:         "), i18n.T("1.2.3.4 或 frp.example.com"), profile.Host},
+		{i18n.T("SSH 端口:     "), "22", strconv.Itoa(profile.Port)},
+		{i18n.T("用户:         "), "root", profile.User},
+		{i18n.T("认证方式:     "), "key / password / agent", profile.AuthMethod},
+		{i18n.T("私钥路径:     "), i18n.T("留空自动查找 ~/.ssh/id_ed25519、id_rsa"), profile.KeyPath},
+		{i18n.T("密码/口令:    "), i18n.T("密码认证的密码或私钥口令"), profile.Password},
+		{i18n.T("远程配置:     "), "/etc/frp/frps.toml", profile.RemoteConfigPath},
+		{i18n.T("服务名:       "), "frps", profile.ServiceName},
+		{i18n.T("日志文件:     "), i18n.T("留空使用 journalctl"), profile.LogPath},
+		{i18n.T("使用 sudo:    "), "yes / no", yesNo(profile.UseSudo)},
+		{i18n.T("本地配置:     "), i18n.T("留空使用应用设置中的服务端或客户端配置"), profile.LocalConfigPath},
+	}
+
+	form := &remoteProfileForm{title: title}
+	for i, field := range fields {
+		input := textinput.New()
+		input.Prompt = field.prompt
+		input.Placeholder = field.placeholder
+		input.CharLimit = 256
+		input.Width = 50
+		input.SetValue(field.value)
+		if i == remoteFieldPassword {
+			input.EchoMode = textinput.EchoPassword
+		}
+		form.inputs = append(form.inputs, input)
+	}
+	form.inputs[form.focus].Focus()
+
+	return form
+}
+
+// Update 处理按键，保存成功时返回新的配置，取消时 closed 为 true
+func (f *remoteProfileForm) Update(msg tea.KeyMsg) (cmd tea.Cmd, saved *config.RemoteProfile, closed bool) {
+	switch msg.String() {
+	case "esc":
+		return nil, nil, true
+	case "tab", "down":
+		return f.moveFocus(1), nil, false
+	case "shift+tab", "up":
+		return f.moveFocus(-1), nil, false
+	case "enter":
+		profile, err := f.profile()
+		f.err = err
+		if err != nil {
+			return nil, nil, false
+		}
+		return nil, profile, true
+	}
+
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	return cmd, nil, false
+}
+
+// moveFocus 移动输入焦点
+func (f *remoteProfileForm) moveFocus(delta int) tea.Cmd {
+	f.inputs[f.focus].Blur()
+	f.focus = (f.focus + delta + len(f.inputs)) % len(f.inputs)
+	return f.inputs[f.focus].Focus()
+}
+
+// profile 根据表单内容生成并校验配置
+func (f *remoteProfileForm) profile() (*config.RemoteProfile, error) {
+	value := func(field int) string {
+		return strings.TrimSpace(f.inputs[field].Value())
+	}
+
+	port, err := strconv.Atoi(value(remoteFieldPort))
+	if err != nil {
+		return nil, i18n.Errorf("SSH 端口必须是数字")
+	}
+
+	useSudo, err := parseYesNo(value(remoteFieldSudo))
+	if err != nil {
+		return nil, i18n.Errorf("使用 sudo %w", err)
+	}
+
+	// 切换服务类型时，仍为另一种类型默认值的远程配置和服务名改用当前类型的默认值
+	role := strings.ToLower(value(remoteFieldRole))
+	remoteConfig, service := value(remoteFieldRemoteConfig), value(remoteFieldService)
+	for _, other := range []string{config.RemoteRoleServer, config.RemoteRoleClient} {
+		if other == role {
+			continue
+		}
+		if remoteConfig == "/etc/frp/"+other+".toml" {
+			remoteConfig = ""
+		}
+		if service == other {
+			service = ""
+		}
+	}
+
+	profile := config.RemoteProfile{
+		Name:             value(remoteFieldName),
+		Role:             role,
+		Host:             value(remoteFieldHost),
+		Port:             port,
+		User:             value(remoteFieldUser),
+		AuthMethod:       strings.ToLower(value(remoteFieldAuth)),
+		KeyPath:          value(remoteFieldKeyPath),
+		Password:         f.inputs[remoteFieldPassword].Value(),
+		RemoteConfigPath: remoteConfig,
+		ServiceName:      service,
+		LogPath:          value(remoteFieldLogPath),
+		UseSudo:          useSudo,
+		LocalConfigPath:  value(remoteFieldLocalConfig),
+	}
+
+	profile.Normalize()
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// View 渲染表单
+func (f *remoteProfileForm) View() string {
+	content := lipgloss.NewStyle().Bold(true).Render(f.title) + "\n"
+	for _, input := range f.inputs {
+		content += input.View() + "\n"
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	if f.err != nil {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+f.err.Error()) + "\n"
+	}
+	content += hintStyle.Render(i18n.Sprintf("保存到 %s • Tab/↑↓: 切换 • Enter: 保存 • Esc: 取消", config.GetRemoteProfilesPath()))
+	return content
+}
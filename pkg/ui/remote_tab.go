@@ -0,0 +1,597 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/remote"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// remoteLogLimit 保留的远程日志行数
+const remoteLogLimit = 500
+
+// remoteResultMsg 远程操作结果
+type remoteResultMsg struct {
+	text  string
+	err   error
+	retry func() tea.Cmd // 主机未被信任时，信任后重试的操作
+}
+
+// remoteLogMsg 远程日志行
+type remoteLogMsg struct {
+	line string
+	ch   <-chan string
+}
+
+// remoteLogEndMsg 远程日志流结束
+type remoteLogEndMsg struct {
+	ch  <-chan string
+	err error
+}
+
+// RemoteTab 远程服务器管理标签页：上传 frps/frpc 配置、重启服务、查看日志
+type RemoteTab struct {
+	BaseTab
+	profiles    []config.RemoteProfile
+	selected    int
+	form        *remoteProfileForm
+	editIndex   int // 正在编辑的配置索引，-1 表示新建
+	appSettings *config.AppSettings
+	keys        *KeyMap
+	loadErr     error
+
+	busy          bool
+	results       []string
+	confirmUpload bool
+	confirmDelete bool
+	pendingTrust  *remote.UnknownHostError
+	pendingRetry  func() tea.Cmd
+
+	tailCancel  context.CancelFunc
+	tailCh      <-chan string
+	tailProfile string
+	logLines    []string
+}
+
+// NewRemoteTab 创建远程服务器标签页
+func NewRemoteTab() *RemoteTab {
+	baseTab := NewBaseTab("远程服务器")
+	baseTab.focusable = true
+
+	rt := &RemoteTab{
+		BaseTab:   baseTab,
+		editIndex: -1,
+		keys:      DefaultKeyMap(),
+	}
+	rt.profiles, rt.loadErr = config.LoadRemoteProfiles()
+	return rt
+}
+
+// SetKeyMap 设置快捷键
+func (rt *RemoteTab) SetKeyMap(keys *KeyMap) {
+	rt.keys = keys
+}
+
+// SetAppSettings 更新应用设置，用于确定默认上传的本地配置
+func (rt *RemoteTab) SetAppSettings(settings *config.AppSettings) {
+	rt.appSettings = settings
+}
+
+// Init 初始化
+func (rt *RemoteTab) Init() tea.Cmd {
+	return nil
+}
+
+// IsInInputMode 编辑配置或等待确认时独占键盘
+func (rt *RemoteTab) IsInInputMode() bool {
+	return rt.form != nil || rt.confirmUpload || rt.confirmDelete || rt.pendingTrust != nil
+}
+
+// current 返回当前选中的远程服务器
+func (rt *RemoteTab) current() *config.RemoteProfile {
+	if rt.selected < 0 || rt.selected >= len(rt.profiles) {
+		return nil
+	}
+	return &rt.profiles[rt.selected]
+}
+
+// localConfigPath 返回要上传的本地配置文件
+func (rt *RemoteTab) localConfigPath(profile *config.RemoteProfile) string {
+	if profile.LocalConfigPath != "" {
+		return profile.LocalConfigPath
+	}
+	if profile.IsClient() {
+		if rt.appSettings != nil {
+			return rt.appSettings.ClientConfigPath
+		}
+		return config.GetDefaultClientConfigPath()
+	}
+	if rt.appSettings != nil {
+		return rt.appSettings.ServerConfigPath
+	}
+	return config.GetDefaultServerConfigPath()
+}
+
+// Update 更新状态
+func (rt *RemoteTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !rt.focused {
+			return rt, nil
+		}
+		return rt.handleKey(msg)
+
+	case remoteResultMsg:
+		rt.busy = false
+		var unknown *remote.UnknownHostError
+		if errors.As(msg.err, &unknown) {
+			rt.pendingTrust = unknown
+			rt.pendingRetry = msg.retry
+			return rt, nil
+		}
+		if msg.err != nil {
+			rt.addResult("❌ " + msg.err.Error())
+			return rt, showStatusMessage("❌ "+msg.err.Error(), true)
+		}
+		rt.addResult("✅ " + msg.text)
+		return rt, showStatusMessage("✅ "+msg.text, false)
+
+	case remoteLogMsg:
+		// 忽略已停止的旧日志流
+		if msg.ch != rt.tailCh {
+			return rt, nil
+		}
+		rt.logLines = append(rt.logLines, msg.line)
+		if len(rt.logLines) > remoteLogLimit {
+			rt.logLines = rt.logLines[len(rt.logLines)-remoteLogLimit:]
+		}
+		return rt, waitForRemoteLog(msg.ch)
+
+	case remoteLogEndMsg:
+		if msg.ch != rt.tailCh {
+			return rt, nil
+		}
+		rt.tailCh = nil
+		if rt.tailCancel != nil {
+			rt.tailCancel()
+			rt.tailCancel = nil
+		}
+		var unknown *remote.UnknownHostError
+		if errors.As(msg.err, &unknown) {
+			rt.pendingTrust = unknown
+			rt.pendingRetry = rt.startTail
+			return rt, nil
+		}
+		if msg.err != nil {
+			rt.addResult("❌ " + msg.err.Error())
+		} else {
+			rt.addResult(i18n.Sprintf("⏹️ 已停止查看 %s 的日志", rt.tailProfile))
+		}
+	}
+
+	return rt, nil
+}
+
+// handleKey 处理按键
+func (rt *RemoteTab) handleKey(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	if rt.form != nil {
+		cmd, saved, closed := rt.form.Update(msg)
+		if saved != nil {
+			return rt, rt.saveProfile(*saved)
+		}
+		if closed {
+			rt.form = nil
+		}
+		return rt, cmd
+	}
+
+	if rt.pendingTrust != nil {
+		trust := rt.pendingTrust
+		retry := rt.pendingRetry
+		rt.pendingTrust = nil
+		rt.pendingRetry = nil
+		if msg.String() != "y" {
+			rt.addResult(i18n.T("已取消连接 ") + trust.Host)
+			return rt, nil
+		}
+		if err := remote.TrustHost(trust); err != nil {
+			rt.addResult("❌ " + err.Error())
+			return rt, nil
+		}
+		rt.addResult(i18n.Sprintf("🔑 已信任 %s (%s)", trust.Host, trust.Fingerprint))
+		if retry != nil {
+			return rt, retry()
+		}
+		return rt, nil
+	}
+
+	if rt.confirmUpload {
+		rt.confirmUpload = false
+		if msg.String() == "y" {
+			return rt, rt.upload()
+		}
+		return rt, nil
+	}
+
+	if rt.confirmDelete {
+		rt.confirmDelete = false
+		if msg.String() == "y" {
+			return rt, rt.deleteProfile()
+		}
+		return rt, nil
+	}
+
+	keys := rt.keys.Remote
+	switch {
+	case key.Matches(msg, keys.Up):
+		if rt.selected > 0 {
+			rt.selected--
+		}
+	case key.Matches(msg, keys.Down):
+		if rt.selected < len(rt.profiles)-1 {
+			rt.selected++
+		}
+	case key.Matches(msg, keys.Add):
+		rt.editIndex = -1
+		rt.form = newRemoteProfileForm(config.NewRemoteProfile(), i18n.T("➕ 添加远程服务器"))
+	case key.Matches(msg, keys.Edit):
+		if profile := rt.current(); profile != nil {
+			rt.editIndex = rt.selected
+			rt.form = newRemoteProfileForm(*profile, i18n.T("✏️ 编辑远程服务器"))
+		}
+	case key.Matches(msg, keys.Delete):
+		if rt.current() != nil {
+			rt.confirmDelete = true
+		}
+	case key.Matches(msg, keys.Check):
+		return rt, rt.runOperation(rt.checkStatus)
+	case key.Matches(msg, keys.Upload):
+		if rt.current() != nil && !rt.busy {
+			rt.confirmUpload = true
+		}
+	case key.Matches(msg, keys.Restart):
+		return rt, rt.runOperation(rt.restart)
+	case key.Matches(msg, keys.Tail):
+		if rt.tailCancel != nil {
+			rt.tailCancel()
+			rt.tailCancel = nil
+			return rt, nil
+		}
+		return rt, rt.startTail()
+	}
+
+	return rt, nil
+}
+
+// saveProfile 保存新建或编辑后的远程服务器
+func (rt *RemoteTab) saveProfile(profile config.RemoteProfile) tea.Cmd {
+	profiles := append([]config.RemoteProfile(nil), rt.profiles...)
+	for i, existing := range profiles {
+		if existing.Name == profile.Name && i != rt.editIndex {
+			rt.form.err = i18n.Errorf("名称 %s 已存在", profile.Name)
+			return nil
+		}
+	}
+
+	if rt.editIndex >= 0 && rt.editIndex < len(profiles) {
+		profiles[rt.editIndex] = profile
+	} else {
+		profiles = append(profiles, profile)
+	}
+
+	if err := config.SaveRemoteProfiles(profiles); err != nil {
+		rt.form.err = err
+		return nil
+	}
+
+	rt.profiles = profiles
+	if rt.editIndex < 0 {
+		rt.selected = len(profiles) - 1
+	}
+	rt.form = nil
+	return showStatusMessage(i18n.Sprintf("✅ 已保存远程服务器 %s", profile.Name), false)
+}
+
+// deleteProfile 删除选中的远程服务器
+func (rt *RemoteTab) deleteProfile() tea.Cmd {
+	profile := rt.current()
+	if profile == nil {
+		return nil
+	}
+	name := profile.Name
+
+	profiles := append([]config.RemoteProfile(nil), rt.profiles[:rt.selected]...)
+	profiles = append(profiles, rt.profiles[rt.selected+1:]...)
+	if err := config.SaveRemoteProfiles(profiles); err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+
+	rt.profiles = profiles
+	if rt.selected >= len(profiles) && rt.selected > 0 {
+		rt.selected--
+	}
+	return showStatusMessage(i18n.Sprintf("🗑️ 已删除远程服务器 %s", name), false)
+}
+
+// runOperation 在后台连接选中的服务器并执行操作
+func (rt *RemoteTab) runOperation(op func(*remote.Client, config.RemoteProfile) (string, error)) tea.Cmd {
+	profile := rt.current()
+	if profile == nil || rt.busy {
+		return nil
+	}
+	rt.busy = true
+	p := *profile
+
+	var run func() tea.Cmd
+	run = func() tea.Cmd {
+		rt.busy = true
+		return func() tea.Msg {
+			client, err := remote.Dial(p)
+			if err != nil {
+				return remoteResultMsg{err: err, retry: run}
+			}
+			defer client.Close()
+
+			text, err := op(client, p)
+			return remoteResultMsg{text: text, err: err}
+		}
+	}
+	return run()
+}
+
+// checkStatus 测试连接并获取远程服务状态
+func (rt *RemoteTab) checkStatus(client *remote.Client, profile config.RemoteProfile) (string, error) {
+	status, err := client.ServiceStatus()
+	if err != nil {
+		return "", err
+	}
+	return i18n.Sprintf("%s 连接成功，%s 服务状态: %s", profile.Name, profile.ServiceName, status), nil
+}
+
+// restart 重启远程 frps/frpc 服务
+func (rt *RemoteTab) restart(client *remote.Client, profile config.RemoteProfile) (string, error) {
+	if _, err := client.RestartService(); err != nil {
+		return "", err
+	}
+	return i18n.Sprintf("%s 上的 %s 已重启", profile.Name, profile.ServiceName), nil
+}
+
+// upload 校验本地配置后上传到远程服务器
+func (rt *RemoteTab) upload() tea.Cmd {
+	profile := rt.current()
+	if profile == nil {
+		return nil
+	}
+	localPath := rt.localConfigPath(profile)
+
+	cfg, err := config.NewLoader(localPath).Load()
+	if err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+	if err := config.NewValidator().ValidateConfig(cfg); err != nil {
+		return showStatusMessage(i18n.Sprintf("❌ 本地配置校验失败: %v", err), true)
+	}
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return showStatusMessage(i18n.Sprintf("❌ 读取本地配置失败: %v", err), true)
+	}
+
+	return rt.runOperation(func(client *remote.Client, p config.RemoteProfile) (string, error) {
+		if err := client.UploadConfig(data); err != nil {
+			return "", err
+		}
+		return i18n.Sprintf("已上传 %s 到 %s:%s，按 %s 重启服务生效", localPath, p.Name, p.RemoteConfigPath, rt.keys.Remote.Restart.Help().Key), nil
+	})
+}
+
+// startTail 开始持续读取选中服务器的日志
+func (rt *RemoteTab) startTail() tea.Cmd {
+	profile := rt.current()
+	if profile == nil {
+		return nil
+	}
+	p := *profile
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rt.tailCancel = cancel
+	rt.tailProfile = p.Name
+	rt.logLines = nil
+
+	lines := make(chan string, 64)
+	rt.tailCh = lines
+	done := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		client, err := remote.Dial(p)
+		if err != nil {
+			done <- err
+			return
+		}
+		defer client.Close()
+		done <- client.TailLogs(ctx, lines)
+	}()
+
+	return tea.Batch(
+		waitForRemoteLog(lines),
+		func() tea.Msg {
+			return remoteLogEndMsg{ch: lines, err: <-done}
+		},
+	)
+}
+
+// waitForRemoteLog 等待下一行远程日志，通道关闭后结束
+func waitForRemoteLog(ch <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return remoteLogMsg{line: line, ch: ch}
+	}
+}
+
+// addResult 记录操作结果
+func (rt *RemoteTab) addResult(text string) {
+	rt.results = append(rt.results, fmt.Sprintf("[%s] %s", time.Now().Format("15:04:05"), text))
+	if len(rt.results) > 20 {
+		rt.results = rt.results[len(rt.results)-20:]
+	}
+}
+
+// View 渲染视图
+func (rt *RemoteTab) View(width int, height int) string {
+	contentWidth := width - 12
+	if contentWidth < 60 {
+		contentWidth = 60
+	}
+	leftWidth := contentWidth / 3
+	if leftWidth < 30 {
+		leftWidth = 30
+	}
+	rightWidth := contentWidth - leftWidth - 4
+
+	availableHeight := height - 6
+	if availableHeight < 10 {
+		availableHeight = 10
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(1).
+		MaxHeight(availableHeight)
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		boxStyle.Width(leftWidth).Render(rt.renderProfiles()),
+		boxStyle.Width(rightWidth).Render(rt.renderDetail(rightWidth-2, availableHeight-4)),
+	)
+}
+
+// renderProfiles 渲染远程服务器列表
+func (rt *RemoteTab) renderProfiles() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Padding(0, 1)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	content := titleStyle.Render(i18n.T("🖥️ 远程服务器")) + "\n"
+
+	if rt.loadErr != nil {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+rt.loadErr.Error()) + "\n"
+	}
+	if len(rt.profiles) == 0 {
+		content += i18n.T("暂无远程服务器，按 ") + rt.keys.Remote.Add.Help().Key + i18n.T(" 添加\n")
+	}
+
+	for i, profile := range rt.profiles {
+		line := fmt.Sprintf("%s [%s] (%s@%s)", profile.Name, profile.Role, profile.User, profile.Address())
+		if i == rt.selected {
+			content += "▶ " + selectedStyle.Render(line) + "\n"
+		} else {
+			content += "  " + line + "\n"
+		}
+	}
+
+	content += "\n" + hintStyle.Render(i18n.T("操作提示:")) + "\n"
+	keys := rt.keys.Remote
+	content += helpLine(" | ", keys.Add, keys.Edit, keys.Delete) + "\n"
+	for _, binding := range []key.Binding{keys.Check, keys.Upload, keys.Restart, keys.Tail} {
+		content += helpItem(binding) + "\n"
+	}
+
+	return content
+}
+
+// renderDetail 渲染表单、确认提示、操作结果和远程日志
+func (rt *RemoteTab) renderDetail(width, height int) string {
+	if rt.form != nil {
+		return rt.form.View()
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
+	var content string
+
+	if profile := rt.current(); profile != nil {
+		content += lipgloss.NewStyle().Bold(true).Render(profile.Name) + "\n"
+		content += i18n.Sprintf("主机: %s@%s (%s 认证)\n", profile.User, profile.Address(), profile.AuthMethod)
+		content += i18n.Sprintf("本地配置: %s\n", rt.localConfigPath(profile))
+		content += i18n.Sprintf("远程配置: %s\n", profile.RemoteConfigPath)
+		content += i18n.Sprintf("服务: %s", profile.ServiceName)
+		if profile.UseSudo {
+			content += " (sudo)"
+		}
+		content += "\n"
+		if profile.LogPath != "" {
+			content += i18n.Sprintf("日志: %s\n", profile.LogPath)
+		}
+		content += "\n"
+	}
+
+	switch {
+	case rt.pendingTrust != nil:
+		content += warnStyle.Render(i18n.Sprintf("⚠️ 首次连接 %s\n公钥指纹: %s\n请确认指纹与服务器一致，按 y 信任并继续，其他键取消",
+			rt.pendingTrust.Host, rt.pendingTrust.Fingerprint)) + "\n\n"
+	case rt.confirmUpload:
+		profile := rt.current()
+		content += warnStyle.Render(i18n.Sprintf("将 %s 上传到 %s:%s，远程旧配置会备份为 .bak，确认上传？(y/N)",
+			rt.localConfigPath(profile), profile.Name, profile.RemoteConfigPath)) + "\n\n"
+	case rt.confirmDelete:
+		content += warnStyle.Render(i18n.Sprintf("确定删除远程服务器 %s 吗？(y/N)", rt.current().Name)) + "\n\n"
+	case rt.busy:
+		content += i18n.T("⏳ 正在执行远程操作...\n\n")
+	}
+
+	if len(rt.results) > 0 {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render(i18n.T("📋 操作记录")) + "\n"
+		results := rt.results
+		if len(results) > 5 {
+			results = results[len(results)-5:]
+		}
+		for _, result := range results {
+			content += truncateString(result, width) + "\n"
+		}
+		content += "\n"
+	}
+
+	if rt.tailCancel != nil || len(rt.logLines) > 0 {
+		title := i18n.T("📜 远程日志: ") + rt.tailProfile
+		if rt.tailCancel != nil {
+			title += i18n.T(" (实时，按 ") + rt.keys.Remote.Tail.Help().Key + i18n.T(" 停止)")
+		}
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render(title) + "\n"
+
+		// 只显示剩余高度能容纳的最新日志
+		maxLines := height - strings.Count(content, "\n") - 1
+		if maxLines < 5 {
+			maxLines = 5
+		}
+		lines := rt.logLines
+		if len(lines) > maxLines {
+			lines = lines[len(lines)-maxLines:]
+		}
+		for _, line := range lines {
+			content += truncateString(line, width) + "\n"
+		}
+	} else if rt.current() != nil {
+		content += hintStyle.Render(i18n.T("按 ") + rt.keys.Remote.Tail.Help().Key + i18n.T(" 查看远程 frps 日志"))
+	}
+
+	return content
+}
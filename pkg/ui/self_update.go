@@ -0,0 +1,108 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/installer"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// selfUpdateCheckMsg frp-cli-ui 自身的更新检查结果，manual 表示由用户手动检查
+type selfUpdateCheckMsg struct {
+	update *installer.SelfUpdate
+	err    error
+	manual bool
+}
+
+// selfUpdateDoneMsg frp-cli-ui 新版本安装结果
+type selfUpdateDoneMsg struct {
+	version string
+	err     error
+}
+
+// restartAppMsg 自更新后确认重启，由主界面退出并启动新版本
+type restartAppMsg struct{}
+
+// checkSelfUpdate 在后台检查 frp-cli-ui 的新版本，启动时使用缓存，手动检查时重新获取
+func (st *SettingsTab) checkSelfUpdate(manual bool) tea.Cmd {
+	updater := st.selfUpdater
+	return func() tea.Msg {
+		update, err := updater.Check(manual)
+		return selfUpdateCheckMsg{update: update, err: err, manual: manual}
+	}
+}
+
+// handleSelfUpdateCheck 记录检查结果，手动检查时提示结果
+func (st *SettingsTab) handleSelfUpdateCheck(msg selfUpdateCheckMsg) tea.Cmd {
+	if msg.err != nil {
+		if msg.manual {
+			return showStatusMessage(i18n.Sprintf("❌ 检查 frp-cli-ui 更新失败: %v", msg.err), true)
+		}
+		return nil
+	}
+	st.selfUpdate = msg.update
+	if !msg.manual {
+		return nil
+	}
+	if msg.update.Available() {
+		return showStatusMessage(i18n.Sprintf("🆕 frp-cli-ui 有新版本 %s，再按 %s 安装", msg.update.Version(), st.keys.Settings.SelfUpdate.Help().Key), false)
+	}
+	return showStatusMessage(i18n.Sprintf("✨ frp-cli-ui %s 已是最新版本", config.AppVersion), false)
+}
+
+// selfUpdateApp 有新版本时下载安装，否则重新检查
+func (st *SettingsTab) selfUpdateApp() tea.Cmd {
+	if !st.selfUpdate.Available() {
+		return st.checkSelfUpdate(true)
+	}
+
+	update := st.selfUpdate
+	st.isInstalling = true
+	st.installProgress = i18n.Sprintf("正在下载 frp-cli-ui %s...", update.Version())
+	return st.runDownload(func() error {
+		_, err := st.selfUpdater.Apply(update)
+		return err
+	}, func(err error) tea.Msg {
+		return selfUpdateDoneMsg{version: update.Version(), err: err}
+	})
+}
+
+// handleSelfUpdateDone 安装成功后询问是否立即重启
+func (st *SettingsTab) handleSelfUpdateDone(msg selfUpdateDoneMsg) tea.Cmd {
+	st.isInstalling = false
+	st.download = nil
+	if msg.err != nil {
+		st.installProgress = i18n.Sprintf("操作失败: %v", msg.err)
+		return showStatusMessage("❌ "+st.installProgress, true)
+	}
+	st.installProgress = ""
+	st.selfUpdate = nil
+	st.restartVersion = msg.version
+	return showStatusMessage(i18n.Sprintf("✅ frp-cli-ui 已更新到 %s", msg.version), false)
+}
+
+// updateRestartPrompt 处理重启确认，y 立即重启，其他键稍后手动重启
+func (st *SettingsTab) updateRestartPrompt(msg tea.KeyMsg) tea.Cmd {
+	version := st.restartVersion
+	st.restartVersion = ""
+	switch msg.String() {
+	case "y", "Y":
+		return func() tea.Msg { return restartAppMsg{} }
+	}
+	return showStatusMessage(i18n.Sprintf("frp-cli-ui %s 将在下次启动时生效", version), false)
+}
+
+// renderSelfUpdate 渲染 frp-cli-ui 的新版本提示和重启确认
+func (st *SettingsTab) renderSelfUpdate() string {
+	if st.restartVersion != "" {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("226")).
+			Render(i18n.Sprintf("🔁 frp-cli-ui 已更新到 %s，立即重启以使用新版本？(y/N)", st.restartVersion)) + "\n"
+	}
+	if st.selfUpdate.Available() {
+		return i18n.Sprintf("🆕 frp-cli-ui 有新版本 %s（当前 %s），按 %s 更新\n",
+			st.selfUpdate.Version(), st.selfUpdate.Current, st.keys.Settings.SelfUpdate.Help().Key)
+	}
+	return ""
+}
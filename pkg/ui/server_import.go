@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// serverImportMsg 从 frps 查询已注册代理完成
+type serverImportMsg struct {
+	target  string
+	proxies []service.ProxyInfo
+	err     error
+}
+
+// serverImport 从 frps 导入代理的面板状态，勾选的代理以停用状态写入客户端配置
+type serverImport struct {
+	target   string
+	loading  bool
+	err      error
+	items    []service.ProxyImport
+	selected map[int]bool
+	cursor   int
+}
+
+// handleServerImport 打开服务端代理导入面板并查询当前 Dashboard 目标上已注册的代理
+func (ct *ConfigTab) handleServerImport() (Tab, tea.Cmd) {
+	if ct.apiClient == nil {
+		return ct, showStatusMessage(i18n.T("❌ 未配置 Dashboard 地址，无法查询服务端代理"), true)
+	}
+	if ct.clientConfig == nil {
+		ct.clientConfig = config.CreateDefaultClientConfig()
+	}
+
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	ct.serverImport = &serverImport{selected: make(map[int]bool)}
+	ct.state = ConfigTabServerImport
+	return ct, ct.fetchServerProxies()
+}
+
+// fetchServerProxies 后台查询 frps 上已注册的代理
+func (ct *ConfigTab) fetchServerProxies() tea.Cmd {
+	s := ct.serverImport
+	s.loading = true
+	s.err = nil
+	s.target = ct.apiClient.TargetName()
+
+	apiClient, target := ct.apiClient, s.target
+	return func() tea.Msg {
+		if _, err := apiClient.Ping(); err != nil {
+			return serverImportMsg{target: target, err: err}
+		}
+		proxies, err := apiClient.GetProxyList()
+		return serverImportMsg{target: target, proxies: proxies, err: err}
+	}
+}
+
+// handleServerImportResult 处理查询结果，面板已关闭或 Dashboard 目标已切换时忽略
+func (ct *ConfigTab) handleServerImportResult(msg serverImportMsg) {
+	s := ct.serverImport
+	if s == nil || msg.target != s.target {
+		return
+	}
+	s.loading = false
+	s.err = msg.err
+	s.items = service.ProxyImportsFromServer(msg.proxies, ct.clientConfig)
+	s.selected = make(map[int]bool)
+	s.cursor = 0
+}
+
+// updateServerImport 处理服务端代理导入面板中的按键
+func (ct *ConfigTab) updateServerImport(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	s := ct.serverImport
+	keys := ct.keys.Config
+	count := len(s.items)
+
+	// 空格与确认选择共用按键，需要先于 Select 判断
+	switch {
+	case msg.String() == "esc":
+		ct.serverImport = nil
+		ct.state = ConfigTabMenu
+	case key.Matches(msg, keys.ImportServer):
+		if !s.loading {
+			return ct, ct.fetchServerProxies()
+		}
+	case s.loading || count == 0:
+	case key.Matches(msg, keys.Toggle):
+		switch {
+		case s.selected[s.cursor]:
+			delete(s.selected, s.cursor)
+		case s.items[s.cursor].Importable():
+			s.selected[s.cursor] = true
+		}
+	case key.Matches(msg, keys.ToggleAll):
+		s.toggleAll()
+	case key.Matches(msg, keys.Up):
+		s.cursor = (s.cursor - 1 + count) % count
+	case key.Matches(msg, keys.Down):
+		s.cursor = (s.cursor + 1) % count
+	case key.Matches(msg, keys.Select):
+		return ct.importServerProxies()
+	}
+	return ct, nil
+}
+
+// toggleAll 有可导入的代理未勾选时全部勾选，否则全部取消
+func (s *serverImport) toggleAll() {
+	selectAll := false
+	for i, item := range s.items {
+		if item.Importable() && !s.selected[i] {
+			selectAll = true
+			break
+		}
+	}
+	s.selected = make(map[int]bool)
+	if !selectAll {
+		return
+	}
+	for i, item := range s.items {
+		if item.Importable() {
+			s.selected[i] = true
+		}
+	}
+}
+
+// importServerProxies 把勾选的代理以停用状态追加到客户端配置并保存，
+// 停用是为了避免与仍在服务端注册的同名代理冲突，确认后在代理列表中启用
+func (ct *ConfigTab) importServerProxies() (Tab, tea.Cmd) {
+	s := ct.serverImport
+	var names []string
+	needsSecret := 0
+	for i, item := range s.items {
+		if !s.selected[i] || !item.Importable() {
+			continue
+		}
+		proxy := item.Proxy.Clone()
+		proxy.Disabled = true
+		ct.clientConfig.Proxies = append(ct.clientConfig.Proxies, proxy)
+		names = append(names, proxy.Name)
+		if item.NeedsSecret {
+			needsSecret++
+		}
+	}
+	if len(names) == 0 {
+		return ct, showStatusMessage(i18n.Sprintf("❌ 请先按 %s 勾选要导入的代理", ct.keys.Config.Toggle.Help().Key), true)
+	}
+
+	ct.serverImport = nil
+	ct.state = ConfigTabMenu
+	ct.recordHistory(i18n.Sprintf("从服务端导入 %d 个代理", len(names)))
+	if err := config.NewLoader(ct.clientConfigPath).Save(ct.clientConfig); err != nil {
+		return ct, showStatusMessage(i18n.Sprintf("已导入 %d 个代理，但保存配置失败: %v", len(names), err), true)
+	}
+	ct.events.Publish(service.ConfigSavedEvent("client", ct.clientConfigPath))
+
+	text := i18n.Sprintf("✅ 已从服务端导入 %s 并保存到 %s，代理处于停用状态，确认后在代理列表中启用",
+		strings.Join(names, ", "), ct.clientConfigPath)
+	if needsSecret > 0 {
+		text += i18n.Sprintf("；%d 个 stcp/xtcp/sudp 代理需要补填 secretKey", needsSecret)
+	}
+	return ct, showStatusMessage(text, false)
+}
+
+// renderServerImport 渲染服务端代理导入面板
+func (ct *ConfigTab) renderServerImport() string {
+	s := ct.serverImport
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color("#FAFAFA"))
+
+	content := titleStyle.Render(i18n.T("🌐 从服务端导入代理")) + "\n"
+	if s.target != "" {
+		content += hintStyle.Render(i18n.Sprintf("Dashboard 目标: %s", s.target)) + "\n"
+	}
+	content += "\n"
+
+	switch {
+	case s.loading:
+		content += i18n.T("⏳ 正在查询服务端已注册的代理...") + "\n"
+	case s.err != nil:
+		content += errorStyle.Render(i18n.Sprintf("❌ 查询服务端代理失败: %v", s.err)) + "\n"
+	case len(s.items) == 0:
+		content += hintStyle.Render(i18n.T("服务端上没有已注册的代理")) + "\n"
+	}
+
+	importable := 0
+	for i, item := range s.items {
+		proxy := item.Proxy
+		check := "[ ]"
+		if s.selected[i] {
+			check = "[✓]"
+		}
+		line := fmt.Sprintf("%s %-20s %-6s", check, proxy.Name, proxy.Type)
+		if proxy.LocalPort > 0 {
+			line += fmt.Sprintf(" %s:%d", proxy.LocalIP, proxy.LocalPort)
+		} else if proxy.Plugin.Type != "" {
+			line += " " + proxy.Plugin.Type
+		}
+		if proxy.RemotePort > 0 {
+			line += fmt.Sprintf(" → :%d", proxy.RemotePort)
+		}
+		for _, domain := range proxy.CustomDomains {
+			line += " " + domain
+		}
+
+		switch {
+		case item.Exists:
+			line += "  " + i18n.T("(本地已有同名代理)")
+		case !item.Importable():
+			line += "  " + i18n.T("(离线，服务端没有配置内容)")
+		case item.NeedsSecret:
+			line += "  " + i18n.T("(需补填 secretKey)")
+		}
+		if item.Importable() {
+			importable++
+		}
+
+		switch {
+		case i == s.cursor:
+			content += "▶ " + selectedStyle.Render(line) + "\n"
+		case !item.Importable():
+			content += "  " + hintStyle.Render(line) + "\n"
+		default:
+			content += "  " + line + "\n"
+		}
+	}
+
+	if len(s.items) > 0 {
+		content += hintStyle.Render(i18n.Sprintf("共 %d 个代理，%d 个可导入，已勾选 %d 个；密钥和密码不会通过 API 返回，导入后需要补填",
+			len(s.items), importable, len(s.selected))) + "\n"
+	}
+	keys := ct.keys.Config
+	content += "\n" + hintStyle.Render(i18n.Sprintf("↑/↓ 选择代理 | %s 勾选 | %s 全选/取消 | Enter 导入 | %s 重新查询 | ESC 返回菜单",
+		keys.Toggle.Help().Key, keys.ToggleAll.Help().Key, keys.ImportServer.Help().Key))
+	return content
+}
@@ -1,24 +1,23 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/progress"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"frp-cli-ui/internal/installer"
 	"frp-cli-ui/internal/service"
 	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
 )
 
-// settingsTickMsg 设置标签页时钟消息类型
-type settingsTickMsg time.Time
-
-// logTickMsg 日志更新专用时钟消息类型
-type logTickMsg time.Time
-
 // installStatusMsg 安装状态消息
 type installStatusMsg struct {
 	status *installer.InstallStatus
@@ -30,18 +29,41 @@ type installProgressMsg struct {
 	message string
 	done    bool
 	err     error
+	// checkStatus 启动失败后需要立即检查服务状态
+	checkStatus bool
+	// initWorkspace 安装成功后需要初始化工作空间
+	initWorkspace bool
 }
 
-// serviceStatusMsg 服务状态消息
+// downloadProgressMsg 下载进度消息
+type downloadProgressMsg struct {
+	progress installer.DownloadProgress
+	ch       <-chan installer.DownloadProgress
+}
+
+// serviceStatusMsg 服务状态消息，notice 不为空时显示为操作成功的通知
 type serviceStatusMsg struct {
 	serverStatus string
 	clientStatus string
+	notice       string
+}
+
+// systemServiceStatusMsg 系统服务状态消息
+type systemServiceStatusMsg struct {
+	statuses map[string]*service.SystemServiceStatus
+	err      error
+}
+
+// systemServiceResultMsg 系统服务操作结果消息
+type systemServiceResultMsg struct {
+	message string
+	err     error
 }
 
-// logUpdateMsg 日志更新消息
-type logUpdateMsg struct {
-	serverLogs []string
-	clientLogs []string
+// releasesMsg 版本列表消息
+type releasesMsg struct {
+	releases []installer.Release
+	err      error
 }
 
 // StatusUpdateCallback 状态更新回调函数类型
@@ -50,17 +72,42 @@ type StatusUpdateCallback func(serverStatus, clientStatus string)
 // SettingsTab 设置标签页 - 简化版本
 type SettingsTab struct {
 	BaseTab
-	installer       *installer.Installer
-	manager         *service.Manager
-	installStatus   *installer.InstallStatus
-	isInstalling    bool
-	installProgress string
-	serverStatus    string
-	clientStatus    string
-	statusCallback  StatusUpdateCallback
-	serverLogs      []string
-	clientLogs      []string
-	maxLogLines     int
+	installer        *installer.Installer
+	manager          *service.Manager
+	resources        *service.ResourceMonitor
+	installStatus    *installer.InstallStatus
+	isInstalling     bool
+	installProgress  string
+	serverStatus     string
+	clientStatus     string
+	statusCheckedAt  time.Time // 上次检查 frps/frpc 状态的时间
+	statusCallback   StatusUpdateCallback
+	logs             *service.LogStore // 服务端和客户端的最近日志
+	maxLogLines      int               // 每个进程显示的日志行数
+	daemonizer       *service.Daemonizer
+	serviceTarget    string // 系统服务操作目标: "frps" 或 "frpc"
+	systemServices   map[string]*service.SystemServiceStatus
+	serviceMessage   string
+	releases         []installer.Release
+	releaseErr       error
+	pickingVersion   bool
+	versionCursor    int
+	managingVersions bool // 正在浏览已安装的版本
+	installedCursor  int
+	progressBar      progress.Model
+	download         *installer.DownloadProgress
+	appSettings      *config.AppSettings
+	settingsForm     *appSettingsForm
+	keys             *KeyMap
+	events           *service.EventBus
+	audit            *service.AuditRecorder
+	auditViewer      *auditViewer
+	notifiedVersion  string // 已发布过 update.available 事件的版本
+	selfUpdater      *installer.SelfUpdater
+	selfUpdate       *installer.SelfUpdate // frp-cli-ui 自身的更新检查结果
+	restartVersion   string                // 已安装的 frp-cli-ui 新版本，不为空时询问是否重启
+	filePicker       *FilePicker           // 选择离线安装包
+	logPalette       logPalette            // 日志级别的颜色
 }
 
 // NewSettingsTab 创建设置标签页 - 简化版本
@@ -69,16 +116,24 @@ func NewSettingsTab() *SettingsTab {
 	baseTab.focusable = true
 
 	st := &SettingsTab{
-		BaseTab:      baseTab,
-		installer:    installer.NewInstaller(""),
-		manager:      service.NewManager(),
-		serverStatus: "已停止",
-		clientStatus: "未连接",
-		serverLogs:   []string{"[15:04:05] [INFO] 日志系统已初始化"},
-		clientLogs:   []string{"[15:04:05] [INFO] 等待客户端启动..."},
-		maxLogLines:  20,
+		BaseTab:       baseTab,
+		installer:     installer.NewInstaller(""),
+		manager:       service.NewManager(),
+		serverStatus:  "已停止",
+		clientStatus:  "未连接",
+		logs:          service.NewLogStore(200),
+		maxLogLines:   20,
+		daemonizer:    service.NewDaemonizer(),
+		serviceTarget: "frps",
+		progressBar:   progress.New(progress.WithDefaultGradient(), progress.WithWidth(30)),
+		keys:          DefaultKeyMap(),
 	}
 
+	// 读取失败时 LoadAppSettings 返回默认设置
+	st.appSettings, _ = config.LoadAppSettings()
+	st.logPalette, _ = newLogPalette(st.appSettings.Theme, st.appSettings.LogColors)
+	st.selfUpdater = installer.NewSelfUpdater(st.installer)
+
 	return st
 }
 
@@ -87,44 +142,52 @@ func (st *SettingsTab) SetStatusCallback(callback StatusUpdateCallback) {
 	st.statusCallback = callback
 }
 
+// SetKeyMap 设置快捷键
+func (st *SettingsTab) SetKeyMap(keys *KeyMap) {
+	st.keys = keys
+}
+
 // SetManager 设置Manager实例（用于共享Manager）
 func (st *SettingsTab) SetManager(manager *service.Manager) {
 	st.manager = manager
 }
 
-// Init 初始化 - 简化日志系统
-func (st *SettingsTab) Init() tea.Cmd {
-	status, err := st.installer.CheckInstallation()
-	if err == nil {
-		st.installStatus = status
-	} else {
-		st.installProgress = fmt.Sprintf("检查安装状态失败: %v", err)
-	}
+// SetEventBus 设置事件总线，发现新版本和用户操作时发布事件
+func (st *SettingsTab) SetEventBus(bus *service.EventBus) {
+	st.events = bus
+}
 
-	return tea.Batch(
-		st.checkServiceStatus(),
-		tea.Tick(500*time.Millisecond, func(t time.Time) tea.Msg {
-			return settingsTickMsg(t)
-		}),
-		// 独立的日志更新计时器，更频繁的更新
-		tea.Tick(1*time.Second, func(t time.Time) tea.Msg {
-			return logTickMsg(t)
-		}),
-	)
+// SetAuditRecorder 设置操作记录器，浏览操作记录时读取它的审计文件
+func (st *SettingsTab) SetAuditRecorder(recorder *service.AuditRecorder) {
+	st.audit = recorder
 }
 
-// startAutoRefresh 启动自动刷新
-func (st *SettingsTab) startAutoRefresh() tea.Cmd {
-	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
-		return settingsTickMsg(t)
+// publishUpdateAvailable 发现新版本时发布事件，同一版本只发布一次
+func (st *SettingsTab) publishUpdateAvailable() {
+	status := st.installStatus
+	if status == nil || !status.NeedsUpdate || status.LatestVersion == st.notifiedVersion {
+		return
+	}
+	st.notifiedVersion = status.LatestVersion
+	st.events.Publish(service.Event{
+		Type:    service.EventUpdateAvailable,
+		Source:  "frp",
+		Title:   i18n.Sprintf("FRP 有新版本可用: %s", status.LatestVersion),
+		Message: i18n.Sprintf("当前版本: %s", status.Version),
+		Data:    map[string]string{"current": status.Version, "latest": status.LatestVersion},
 	})
 }
 
-// startLogAutoRefresh 启动独立的日志自动刷新
-func (st *SettingsTab) startLogAutoRefresh() tea.Cmd {
-	return tea.Tick(1*time.Second, func(t time.Time) tea.Msg {
-		return logTickMsg(t)
-	})
+// Init 初始化 - 简化日志系统
+func (st *SettingsTab) Init() tea.Cmd {
+	// 检查安装状态需要运行 frps/frpc 获取版本，放到后台执行
+	return tea.Batch(
+		st.refreshInstallStatus(),
+		st.checkServiceStatus(),
+		st.refreshSystemServices(),
+		st.loadReleases(false),
+		st.checkSelfUpdate(false),
+	)
 }
 
 // Update 更新状态 - 清理版本
@@ -134,89 +197,227 @@ func (st *SettingsTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		st.SetSize(msg.Width, msg.Height)
+		if st.filePicker != nil {
+			st.filePicker.SetSize(msg.Width, msg.Height)
+		}
+
+	case filePickerResultMsg:
+		if msg.Selected {
+			return st, st.installFromArchive(msg.Path)
+		}
 
 	case tea.KeyMsg:
+		if st.focused && st.filePicker != nil && st.filePicker.IsVisible() {
+			return st, st.filePicker.Update(msg)
+		}
+		if st.focused && st.restartVersion != "" {
+			return st, st.updateRestartPrompt(msg)
+		}
+		if st.focused && st.pickingVersion {
+			return st, st.updateVersionPicker(msg)
+		}
+		if st.focused && st.managingVersions {
+			return st, st.updateInstalledVersions(msg)
+		}
+		if st.focused && st.settingsForm != nil {
+			return st, st.updateSettingsForm(msg)
+		}
+		if st.focused && st.auditViewer != nil {
+			cmd, closed := st.auditViewer.update(msg, st.keys.Settings)
+			if closed {
+				st.auditViewer = nil
+			}
+			return st, cmd
+		}
 		if st.focused {
-			switch msg.String() {
-			case "i":
-				// 安装 FRP
+			keys, global := st.keys.Settings, st.keys.Global
+			switch {
+			case key.Matches(msg, keys.Install):
+				// 安装 FRP，已安装的程序与本机架构不符时重新安装
 				if st.installStatus != nil && !st.installStatus.IsInstalled && !st.isInstalling {
 					return st, st.installFRP()
 				}
-			case "u":
+				if st.canReinstall() {
+					return st, st.reinstallFRP()
+				}
+			case key.Matches(msg, keys.Update):
 				// 更新 FRP
-				if st.installStatus != nil && st.installStatus.IsInstalled && st.installStatus.NeedsUpdate && !st.isInstalling {
+				if st.canUpdate() {
 					return st, st.updateFRP()
 				}
-			case "ctrl+u":
-				// 卸载 FRP
-				if st.installStatus != nil && st.installStatus.IsInstalled && !st.isInstalling {
+			case key.Matches(msg, keys.Uninstall):
+				// 卸载 FRP，PATH 中和包管理器安装的程序不由本工具管理
+				if st.canUninstall() {
 					return st, st.uninstallFRP()
 				}
-			case "s":
+			case key.Matches(msg, keys.InstallArchive):
+				// 从本地压缩包离线安装
+				if !st.isInstalling {
+					return st, st.openArchivePicker()
+				}
+			case key.Matches(msg, keys.UseExisting):
+				// 使用包管理器或手动安装的 FRP，不再重复下载
+				if st.installStatus != nil && st.installStatus.CanAdopt() && !st.isInstalling {
+					return st, st.useExistingFRP()
+				}
+			case key.Matches(msg, global.StartServer):
 				// 启动服务端 - 简化条件，优先检查服务状态
 				if st.serverStatus == "已停止" {
 					return st, st.startServer()
 				}
-			case "ctrl+s":
+			case key.Matches(msg, global.StopServer):
 				// 停止服务端 - 不管是否是自己启动的都尝试停止
 				if st.serverStatus == "运行中" {
 					return st, st.stopServer()
 				}
-			case "c":
+			case key.Matches(msg, global.StartClient):
 				// 启动客户端 - 简化条件，优先检查服务状态
 				if st.clientStatus == "未连接" {
 					return st, st.startClient()
 				}
-			case "ctrl+x":
+			case key.Matches(msg, global.StopClient):
 				// 停止客户端 - 不管是否是自己启动的都尝试停止
 				if st.clientStatus == "已连接" || st.clientStatus == "连接中" {
 					return st, st.stopClient()
 				}
-			case "r":
+			case key.Matches(msg, keys.Test):
+				// 测试客户端与服务端的连接和认证
+				return st, st.testConnection()
+			case key.Matches(msg, keys.Reload):
+				// 热重载客户端配置
+				if st.clientStatus == "已连接" || st.clientStatus == "连接中" {
+					return st, st.reloadClient()
+				}
+			case key.Matches(msg, keys.PickVersion):
+				// 选择要安装的版本
+				if !st.isInstalling {
+					st.pickingVersion = true
+					st.versionCursor = st.currentVersionIndex()
+					if len(st.releases) == 0 {
+						return st, st.loadReleases(true)
+					}
+				}
+			case key.Matches(msg, keys.Versions):
+				// 浏览已安装的版本，切换当前版本或为 frps/frpc 固定版本
+				if st.installer.ManagesVersions() && !st.isInstalling {
+					st.openInstalledVersions()
+				}
+			case key.Matches(msg, keys.Rollback):
+				// 回滚到上一个版本
+				if st.canRollback() {
+					return st, st.rollbackFRP()
+				}
+			case key.Matches(msg, keys.SelfUpdate):
+				// 检查或安装 frp-cli-ui 自身的新版本
+				if !st.isInstalling {
+					return st, st.selfUpdateApp()
+				}
+			case key.Matches(msg, keys.AppSettings):
+				// 编辑应用设置
+				st.settingsForm = newAppSettingsForm(st.appSettings, settingsFieldDashboardURL)
+			case key.Matches(msg, keys.AuditLog):
+				// 浏览操作记录
+				if st.audit != nil {
+					viewer, cmd := newAuditViewer(st.audit.Path())
+					st.auditViewer = viewer
+					return st, cmd
+				}
+			case key.Matches(msg, keys.Mirror):
+				// 设置下载镜像和代理
+				st.settingsForm = newAppSettingsForm(st.appSettings, settingsFieldMirror)
+			case key.Matches(msg, keys.Refresh):
 				// 手动刷新安装状态
-				return st, st.refreshInstallStatus()
+				return st, tea.Batch(st.refreshInstallStatus(), st.refreshSystemServices())
+			case key.Matches(msg, keys.ServiceTarget):
+				// 切换系统服务操作目标
+				if st.serviceTarget == "frps" {
+					st.serviceTarget = "frpc"
+				} else {
+					st.serviceTarget = "frps"
+				}
+			case key.Matches(msg, keys.AutoRestart):
+				// 切换当前目标服务的崩溃自动重启
+				return st, st.toggleAutoRestart()
+			case key.Matches(msg, keys.InstallService):
+				// 安装为系统服务（开机自启）
+				if st.installStatus != nil && st.installStatus.IsInstalled && !st.isTargetServiceInstalled() {
+					return st, st.installSystemService()
+				}
+			case key.Matches(msg, keys.ToggleBoot):
+				// 切换开机自启
+				if st.isTargetServiceInstalled() {
+					return st, st.toggleSystemServiceBoot()
+				}
+			case key.Matches(msg, keys.RemoveService):
+				// 移除系统服务
+				if st.isTargetServiceInstalled() {
+					return st, st.uninstallSystemService()
+				}
 			}
 		}
 
-	case settingsTickMsg:
-		// 自动刷新状态
-		cmds = append(cmds,
-			st.checkServiceStatus(),
-			st.startAutoRefresh(), // 继续下一次自动刷新
-		)
-
-	case logTickMsg:
-		// 独立的日志更新
-		cmds = append(cmds,
-			st.updateLogs(),
-			st.startLogAutoRefresh(), // 继续下一次日志刷新
-		)
-
 	case installStatusMsg:
 		st.isInstalling = false // 检查完成
 		st.installStatus = msg.status
 		if msg.err != nil {
-			st.installProgress = fmt.Sprintf("检查安装状态失败: %v", msg.err)
+			st.installProgress = i18n.Sprintf("检查安装状态失败: %v", msg.err)
 		} else {
 			st.installProgress = "" // 清除之前的错误信息
+			st.publishUpdateAvailable()
+		}
+
+	case auditLogMsg:
+		if st.auditViewer != nil {
+			st.auditViewer.setEntries(msg)
+		}
+
+	case auditReplayTickMsg:
+		if st.auditViewer != nil {
+			cmds = append(cmds, st.auditViewer.advance(msg))
 		}
 
+	case downloadProgressMsg:
+		st.download = &msg.progress
+		cmds = append(cmds, waitForDownloadProgress(msg.ch))
+
+	case stagedInstallMsg:
+		cmds = append(cmds, st.runElevatedInstall(msg))
+
+	case selfUpdateCheckMsg:
+		cmds = append(cmds, st.handleSelfUpdateCheck(msg))
+
+	case selfUpdateDoneMsg:
+		cmds = append(cmds, st.handleSelfUpdateDone(msg))
+
 	case installProgressMsg:
 		if msg.done {
 			st.isInstalling = false
+			st.download = nil
 			if msg.err != nil {
-				st.installProgress = fmt.Sprintf("操作失败: %v", msg.err)
+				var checksumErr *installer.ChecksumError
+				if errors.As(msg.err, &checksumErr) && checksumErr.Removed {
+					st.installProgress = i18n.Sprintf("❌ 安装已中止: %s 未通过 SHA256 校验，文件可能已损坏或被篡改，已删除下载文件\n期望: %s\n实际: %s",
+						checksumErr.File, checksumErr.Expected, checksumErr.Actual)
+				} else if errors.As(msg.err, &checksumErr) {
+					st.installProgress = i18n.Sprintf("❌ 安装已中止: %s 未通过 SHA256 校验，文件可能已损坏或被篡改\n期望: %s\n实际: %s",
+						checksumErr.File, checksumErr.Expected, checksumErr.Actual)
+				} else {
+					st.installProgress = i18n.Sprintf("操作失败: %v", msg.err)
+				}
+				cmds = append(cmds, showStatusMessage("❌ "+strings.SplitN(st.installProgress, "\n", 2)[0], true))
 				// 如果是启动失败，立即检查服务状态
-				if strings.Contains(msg.message, "启动") {
+				if msg.checkStatus {
 					cmds = append(cmds, st.checkServiceStatus())
 				}
 			} else {
 				st.installProgress = msg.message
+				if msg.message != "" {
+					cmds = append(cmds, showStatusMessage(msg.message, false))
+				}
 				// 安装完成后同步检查状态并触发初始化
 				cmds = append(cmds, st.refreshInstallStatus())
 				// 如果是安装成功，触发初始化逻辑
-				if strings.Contains(msg.message, "安装成功") {
+				if msg.initWorkspace {
 					cmds = append(cmds, func() tea.Msg {
 						// 触发工作空间初始化
 						config.InitializeWorkspace()
@@ -235,16 +436,41 @@ func (st *SettingsTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
 		if st.statusCallback != nil {
 			st.statusCallback(st.serverStatus, st.clientStatus)
 		}
-		// 服务状态变化时立即触发一次日志更新
-		cmds = append(cmds, st.updateLogs())
+		if msg.notice != "" {
+			cmds = append(cmds, showStatusMessage(msg.notice, false))
+		}
+
+	case releasesMsg:
+		st.releaseErr = msg.err
+		if msg.err == nil {
+			st.releases = msg.releases
+			if st.versionCursor >= len(st.channelReleases()) {
+				st.versionCursor = 0
+			}
+		}
+		// 版本列表更新后重新计算是否需要更新
+		cmds = append(cmds, st.refreshInstallStatus())
+
+	case systemServiceStatusMsg:
+		if msg.err != nil {
+			st.serviceMessage = i18n.Sprintf("查询系统服务失败: %v", msg.err)
+		} else {
+			st.systemServices = msg.statuses
+		}
 
-	case logUpdateMsg:
-		st.serverLogs = msg.serverLogs
-		st.clientLogs = msg.clientLogs
+	case systemServiceResultMsg:
+		if msg.err != nil {
+			st.serviceMessage = i18n.Sprintf("操作失败: %v", msg.err)
+			cmds = append(cmds, showStatusMessage("❌ "+st.serviceMessage, true))
+		} else {
+			st.serviceMessage = msg.message
+			cmds = append(cmds, showStatusMessage(msg.message, false))
+		}
+		cmds = append(cmds, st.refreshSystemServices())
 
 	case dashboardTickMsg:
-		// 处理来自主仪表板的时钟消息
-		if st.focused {
+		// 按进程状态刷新间隔检查 frps/frpc 状态
+		if st.focused && time.Since(st.statusCheckedAt) >= st.appSettings.RefreshDuration() {
 			cmds = append(cmds, st.checkServiceStatus())
 		}
 	}
@@ -259,6 +485,21 @@ func (st *SettingsTab) View(width int, height int) string {
 		contentWidth = 40
 	}
 
+	// 选择离线安装包时占满整个标签页
+	if st.filePicker != nil && st.filePicker.IsVisible() {
+		return st.filePicker.View()
+	}
+
+	// 浏览操作记录时占满整个标签页
+	if st.auditViewer != nil {
+		return lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("240")).
+			Padding(1).
+			Width(contentWidth).
+			Render(st.auditViewer.view(contentWidth-4, height, st.appSettings.AuditLog, st.keys.Settings))
+	}
+
 	// 计算左右分屏的宽度，确保总宽度匹配
 	leftWidth := (contentWidth - 4) / 2
 	rightWidth := contentWidth - leftWidth - 4
@@ -303,6 +544,10 @@ func (st *SettingsTab) renderLeftContent() string {
 	content += st.renderServiceControl()
 	content += "\n\n"
 
+	// 系统服务部分
+	content += st.renderSystemServices()
+	content += "\n\n"
+
 	// 操作提示部分（放在左侧内容底部）
 	content += st.renderHorizontalHelp()
 
@@ -314,29 +559,11 @@ func (st *SettingsTab) renderRightLogs(width int) string {
 	var content string
 
 	// 标题
-	content += lipgloss.NewStyle().Bold(true).Render("📋 实时日志") + "\n\n"
+	content += lipgloss.NewStyle().Bold(true).Render(i18n.T("📋 实时日志")) + "\n\n"
 
 	// 服务端日志区域
-	content += lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render("🎯 服务端日志:") + "\n" // 使用🎯替代🖥️
-	if len(st.serverLogs) == 0 {
-		content += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("暂无日志 (状态: "+st.serverStatus+")") + "\n"
-	} else {
-		// 显示最新的日志
-		for _, log := range st.serverLogs {
-			// 根据日志级别设置颜色
-			logColor := "250"
-			if strings.Contains(log, "[ERROR]") {
-				logColor = "196" // 红色
-			} else if strings.Contains(log, "[WARN]") {
-				logColor = "226" // 黄色
-			} else if strings.Contains(log, "[INFO]") {
-				logColor = "46" // 绿色
-			} else if strings.Contains(log, "[DEBUG]") {
-				logColor = "240" // 暗灰色
-			}
-			content += lipgloss.NewStyle().Foreground(lipgloss.Color(logColor)).Render("• "+log) + "\n"
-		}
-	}
+	content += lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render(i18n.T("🎯 服务端日志:")) + "\n" // 使用🎯替代🖥️
+	content += st.renderRecentLogs("server", st.serverStatus)
 
 	// 添加空行撑满上半部分
 	for i := 0; i < 3; i++ {
@@ -348,26 +575,8 @@ func (st *SettingsTab) renderRightLogs(width int) string {
 	content += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(separator) + "\n\n"
 
 	// 客户端日志区域
-	content += lipgloss.NewStyle().Foreground(lipgloss.Color("81")).Render("💻 客户端日志:") + "\n"
-	if len(st.clientLogs) == 0 {
-		content += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("暂无日志 (状态: "+st.clientStatus+")") + "\n"
-	} else {
-		// 显示最新的日志
-		for _, log := range st.clientLogs {
-			// 根据日志级别设置颜色
-			logColor := "250"
-			if strings.Contains(log, "[ERROR]") {
-				logColor = "196" // 红色
-			} else if strings.Contains(log, "[WARN]") {
-				logColor = "226" // 黄色
-			} else if strings.Contains(log, "[INFO]") {
-				logColor = "81" // 蓝色
-			} else if strings.Contains(log, "[DEBUG]") {
-				logColor = "240" // 暗灰色
-			}
-			content += lipgloss.NewStyle().Foreground(lipgloss.Color(logColor)).Render("• "+log) + "\n"
-		}
-	}
+	content += lipgloss.NewStyle().Foreground(lipgloss.Color("81")).Render(i18n.T("💻 客户端日志:")) + "\n"
+	content += st.renderRecentLogs("client", st.clientStatus)
 
 	// 添加空行撑满下半部分
 	for i := 0; i < 3; i++ {
@@ -382,33 +591,80 @@ func (st *SettingsTab) renderFRPStatus() string {
 	statusStyle := lipgloss.NewStyle().Bold(true)
 
 	var status string
-	status += statusStyle.Render("🔧 FRP 安装状态") + "\n\n"
+	status += statusStyle.Render(i18n.T("🔧 FRP 安装状态")) + "\n\n"
 
 	if st.installStatus == nil {
-		status += "正在检查安装状态..."
+		status += i18n.T("正在检查安装状态...")
 		return status
 	}
 
 	if st.installStatus.IsInstalled {
-		status += fmt.Sprintf("✅ 已安装 (版本: %s)\n", st.installStatus.Version)
-		status += fmt.Sprintf("📁 安装目录: %s\n", st.installStatus.InstallDir)
-		status += fmt.Sprintf("🎯 服务端: %s\n", st.installStatus.FrpsPath) // 使用🎯替代🖥️避免宽度问题
-		status += fmt.Sprintf("💻 客户端: %s\n", st.installStatus.FrpcPath)
+		status += i18n.Sprintf("✅ 已安装 (版本: %s)\n", st.installStatus.Version)
+		if installer.IsPrerelease(st.installStatus.Version) {
+			status += prereleaseStyle.Render(i18n.T("⚠️ 当前使用的是预发布版本，可能不稳定")) + "\n"
+		}
+		origin := installer.OriginLabel(st.installStatus.Origin)
+		switch {
+		case st.installStatus.CanAdopt():
+			status += i18n.Sprintf("📦 检测到 %s 安装的程序，按 %s 直接使用，无需重复下载到 %s\n",
+				origin, st.keys.Settings.UseExisting.Help().Key, st.installStatus.InstallDir)
+		case st.installStatus.External:
+			status += i18n.Sprintf("📍 使用 %s 中的程序（未由本工具安装）\n", origin)
+		case st.installStatus.PackageManaged():
+			status += i18n.Sprintf("📁 安装目录: %s（由 %s 管理，请通过包管理器更新或卸载）\n", st.installStatus.InstallDir, origin)
+		default:
+			status += i18n.Sprintf("📁 安装目录: %s\n", st.installStatus.InstallDir)
+		}
+		status += i18n.Sprintf("🎯 服务端: %s (%s)\n", st.installStatus.FrpsPath, versionOrUnknown(st.installStatus.FrpsVersion)) // 使用🎯替代🖥️避免宽度问题
+		status += i18n.Sprintf("💻 客户端: %s (%s)\n", st.installStatus.FrpcPath, versionOrUnknown(st.installStatus.FrpcVersion))
+		if st.installStatus.FrpsVersion != "" && st.installStatus.FrpcVersion != "" &&
+			st.installStatus.FrpsVersion != st.installStatus.FrpcVersion {
+			status += lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render(i18n.T("⚠️ frps 与 frpc 版本不一致")) + "\n"
+		}
+		status += st.renderArchMismatch()
 
 		if st.installStatus.NeedsUpdate {
-			status += fmt.Sprintf("🔄 有新版本可用: %s\n", st.installStatus.LatestVersion)
+			status += i18n.Sprintf("🔄 有新版本可用: %s\n", st.installStatus.LatestVersion+prereleaseTag(st.installStatus.LatestVersion))
 		} else {
-			status += "✨ 已是最新版本\n"
+			status += i18n.T("✨ 已是最新版本\n")
 		}
+		if target := st.installer.GetVersion(); target != st.installStatus.Version && target != st.installStatus.LatestVersion {
+			status += i18n.Sprintf("🎯 已选择版本: %s\n", target+prereleaseTag(target))
+		}
+		status += st.renderVersionSummary()
 	} else {
-		status += "❌ 未安装\n"
-		status += fmt.Sprintf("📁 将安装到: %s\n", st.installer.GetInstallDir())
-		status += fmt.Sprintf("📦 最新版本: %s\n", st.installer.GetVersion())
+		status += i18n.T("❌ 未安装\n")
+		status += i18n.Sprintf("📁 将安装到: %s\n", st.installer.GetInstallDir())
+		status += i18n.Sprintf("📦 将安装版本: %s\n", st.installer.GetVersion()+prereleaseTag(st.installer.GetVersion()))
+	}
+	if st.appSettings.IncludesPrereleases() {
+		status += prereleaseStyle.Render(i18n.T("📡 更新通道: 预发布（包含 RC 等测试版本）")) + "\n"
+	}
+	status += st.renderSelfUpdate()
+
+	if st.appSettings.DownloadMirror != "" {
+		status += i18n.Sprintf("🌐 下载镜像: %s\n", st.appSettings.DownloadMirror)
+	}
+	if st.appSettings.DownloadProxy != "" {
+		status += i18n.Sprintf("🔌 下载代理: %s\n", st.appSettings.DownloadProxy)
+	}
+
+	if st.pickingVersion {
+		status += "\n" + st.renderVersionPicker()
+	}
+	if st.managingVersions {
+		status += "\n" + st.renderInstalledVersions()
+	}
+	if st.settingsForm != nil {
+		status += "\n" + st.settingsForm.View()
 	}
 
 	// 显示安装进度或状态
 	if st.isInstalling {
 		status += "\n🔄 " + st.installProgress
+		if st.download != nil {
+			status += "\n" + st.renderDownloadProgress()
+		}
 	} else if st.installProgress != "" {
 		status += "\n" + st.installProgress
 	}
@@ -421,7 +677,7 @@ func (st *SettingsTab) renderServiceControl() string {
 	controlStyle := lipgloss.NewStyle().Bold(true)
 
 	var control string
-	control += controlStyle.Render("🚀 FRP 服务控制") + "\n\n"
+	control += controlStyle.Render(i18n.T("🚀 FRP 服务控制")) + "\n\n"
 
 	// 服务端状态
 	serverStatusColor := "240"
@@ -431,7 +687,8 @@ func (st *SettingsTab) renderServiceControl() string {
 		serverStatusColor = "226" // 黄色
 	}
 	serverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(serverStatusColor))
-	control += fmt.Sprintf("🎯 服务端状态: %s\n", serverStyle.Render(st.serverStatus)) // 使用🎯替代🖥️
+	control += i18n.Sprintf("🎯 服务端状态: %s\n", serverStyle.Render(i18n.T(st.serverStatus))) // 使用🎯替代🖥️
+	control += st.renderResourceLine("server")
 
 	// 客户端状态
 	clientStatusColor := "240"
@@ -441,7 +698,21 @@ func (st *SettingsTab) renderServiceControl() string {
 		clientStatusColor = "226" // 黄色
 	}
 	clientStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(clientStatusColor))
-	control += fmt.Sprintf("💻 客户端状态: %s\n", clientStyle.Render(st.clientStatus))
+	control += i18n.Sprintf("💻 客户端状态: %s\n", clientStyle.Render(i18n.T(st.clientStatus)))
+	control += st.renderResourceLine("client")
+
+	onOff := func(enabled bool) string {
+		if enabled {
+			return lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render(i18n.T("开"))
+		}
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.T("关"))
+	}
+	limit := i18n.T("不限次数")
+	if st.appSettings.RestartMaxRetries > 0 {
+		limit = i18n.Sprintf("%d 秒内最多 %d 次", st.appSettings.RestartWindow, st.appSettings.RestartMaxRetries)
+	}
+	control += i18n.Sprintf("🔁 自动重启: 服务端 %s / 客户端 %s (%s)\n",
+		onOff(st.appSettings.AutoRestartServer), onOff(st.appSettings.AutoRestartClient), limit)
 
 	return control
 }
@@ -452,87 +723,116 @@ func (st *SettingsTab) renderHorizontalHelp() string {
 		Foreground(lipgloss.Color("240")).
 		Padding(0, 1)
 
-	var helpItems []string
+	keys, global := st.keys.Settings, st.keys.Global
+	var helpItems []key.Binding
 
 	// 根据状态动态显示可用操作
 	if st.installStatus == nil {
-		helpItems = append(helpItems, "r: 刷新状态")
+		helpItems = append(helpItems, keys.Refresh)
 	} else if !st.installStatus.IsInstalled {
-		helpItems = append(helpItems, "i: 安装FRP", "r: 刷新状态")
+		helpItems = append(helpItems, keys.Install, keys.InstallArchive, keys.PickVersion, keys.Mirror, keys.Refresh)
 	} else {
-		if st.installStatus.NeedsUpdate {
-			helpItems = append(helpItems, "u: 更新FRP")
+		if st.canReinstall() {
+			helpItems = append(helpItems, keys.Install)
+		}
+		if st.canUpdate() {
+			helpItems = append(helpItems, keys.Update)
+		}
+		helpItems = append(helpItems, keys.PickVersion, keys.InstallArchive, keys.Mirror)
+		if len(st.installStatus.Versions) > 0 {
+			helpItems = append(helpItems, keys.Versions)
 		}
-		helpItems = append(helpItems, "Ctrl+U: 卸载FRP", "r: 刷新状态")
+		if st.canRollback() {
+			helpItems = append(helpItems, keys.Rollback)
+		}
+		if st.installStatus.CanAdopt() {
+			helpItems = append(helpItems, keys.UseExisting)
+		}
+		if st.canUninstall() {
+			helpItems = append(helpItems, keys.Uninstall)
+		}
+		helpItems = append(helpItems, keys.Refresh, keys.Test)
 
 		// 服务控制操作
 		if st.serverStatus == "已停止" {
-			helpItems = append(helpItems, "s: 启动服务端")
+			helpItems = append(helpItems, global.StartServer)
 		} else if st.serverStatus == "运行中" {
-			helpItems = append(helpItems, "Ctrl+S: 停止服务端")
+			helpItems = append(helpItems, global.StopServer)
 		}
 
 		if st.clientStatus == "未连接" {
-			helpItems = append(helpItems, "c: 启动客户端")
+			helpItems = append(helpItems, global.StartClient)
 		} else if st.clientStatus == "已连接" || st.clientStatus == "连接中" {
-			helpItems = append(helpItems, "Ctrl+X: 停止客户端")
+			helpItems = append(helpItems, keys.Reload, global.StopClient)
+		}
+
+		// 系统服务操作
+		helpItems = append(helpItems, keys.ServiceTarget, keys.AutoRestart)
+		if st.isTargetServiceInstalled() {
+			helpItems = append(helpItems, keys.ToggleBoot, keys.RemoveService)
+		} else {
+			helpItems = append(helpItems, keys.InstallService)
 		}
 	}
 
 	// 添加自动刷新提示
-	helpItems = append(helpItems, "⚡ 自动刷新: 2秒")
+	helpItems = append(helpItems, keys.SelfUpdate, keys.AppSettings, keys.AuditLog)
+	refresh := i18n.Sprintf("⚡ 状态刷新: %d秒", st.appSettings.RefreshInterval)
 
-	return helpStyle.Render("💡 " + strings.Join(helpItems, " • "))
+	return helpStyle.Render("💡 " + helpLine(" • ", helpItems...) + " • " + refresh)
 }
 
 // checkServiceStatus 检查服务状态 - 优化避免频繁切换
 func (st *SettingsTab) checkServiceStatus() tea.Cmd {
+	st.statusCheckedAt = time.Now()
+	// 当前状态在发出命令时读取，后台 goroutine 不访问标签页字段
+	manager, previousServer, previousClient := st.manager, st.serverStatus, st.clientStatus
 	return func() tea.Msg {
 		var serverStatus, clientStatus string
 
 		// 检查服务端状态 - 需要加入防抖动逻辑
-		serverProcessStatus := st.manager.GetServerStatus()
+		serverProcessStatus := manager.GetServerStatus()
 		currentServerRunning := serverProcessStatus.IsRunning
 
 		// 对于服务端，使用更保守的状态更新策略
 		if currentServerRunning {
 			// 如果检测到进程运行，立即更新为运行中
-			if st.serverStatus != "运行中" {
+			if previousServer != "运行中" {
 				serverStatus = "运行中"
 			} else {
-				serverStatus = st.serverStatus
+				serverStatus = previousServer
 			}
 		} else {
 			// 如果检测到进程不运行，且当前不是"已停止"状态，则更新
-			if st.serverStatus != "已停止" {
+			if previousServer != "已停止" {
 				serverStatus = "已停止"
 			} else {
-				serverStatus = st.serverStatus
+				serverStatus = previousServer
 			}
 		}
 
 		// 检查客户端状态 - 类似的保守策略
-		clientProcessStatus := st.manager.GetClientStatus()
+		clientProcessStatus := manager.GetClientStatus()
 		currentClientRunning := clientProcessStatus.IsRunning
 
 		if currentClientRunning {
 			// 如果检测到进程运行，立即更新为已连接
-			if st.clientStatus != "已连接" {
+			if previousClient != "已连接" {
 				clientStatus = "已连接"
 			} else {
-				clientStatus = st.clientStatus
+				clientStatus = previousClient
 			}
 		} else {
 			// 如果进程不运行，根据当前状态决定
-			if st.clientStatus == "连接中" || st.clientStatus == "已连接" {
+			if previousClient == "连接中" || previousClient == "已连接" {
 				clientStatus = "未连接"
 			} else {
-				clientStatus = st.clientStatus
+				clientStatus = previousClient
 			}
 		}
 
 		// 只有状态真正改变时才发送更新消息
-		if serverStatus != st.serverStatus || clientStatus != st.clientStatus {
+		if serverStatus != previousServer || clientStatus != previousClient {
 			return serviceStatusMsg{
 				serverStatus: serverStatus,
 				clientStatus: clientStatus,
@@ -546,30 +846,36 @@ func (st *SettingsTab) checkServiceStatus() tea.Cmd {
 
 // startServer 启动服务端
 func (st *SettingsTab) startServer() tea.Cmd {
-	return func() tea.Msg {
-		err := st.manager.StartServer("examples/frps.yaml")
+	st.events.Publish(service.UserActionEvent(service.ActionProcessStart, i18n.T("启动服务端 ")+st.appSettings.ServerConfigPath))
+	manager, path, clientStatus := st.manager, st.appSettings.ServerConfigPath, st.clientStatus
+	return runOperation(i18n.T("正在启动服务端"), func() tea.Msg {
+		err := manager.StartServer(path)
 		if err != nil {
 			return installProgressMsg{
-				message: fmt.Sprintf("启动服务端失败: %v", err),
-				done:    true,
-				err:     err,
+				message:     i18n.Sprintf("启动服务端失败: %v", err),
+				done:        true,
+				err:         err,
+				checkStatus: true,
 			}
 		}
 		// 先更新状态
 		return serviceStatusMsg{
 			serverStatus: "启动中",
-			clientStatus: st.clientStatus,
+			clientStatus: clientStatus,
+			notice:       i18n.T("✅ 服务端已启动"),
 		}
-	}
+	})
 }
 
 // stopServer 停止服务端
 func (st *SettingsTab) stopServer() tea.Cmd {
-	return func() tea.Msg {
-		err := st.manager.StopServer()
+	st.events.Publish(service.UserActionEvent(service.ActionProcessStop, i18n.T("停止服务端")))
+	manager, clientStatus := st.manager, st.clientStatus
+	return runOperation(i18n.T("正在停止服务端"), func() tea.Msg {
+		err := manager.StopServer()
 		if err != nil {
 			return installProgressMsg{
-				message: fmt.Sprintf("停止服务端失败: %v", err),
+				message: i18n.Sprintf("停止服务端失败: %v", err),
 				done:    true,
 				err:     err,
 			}
@@ -577,78 +883,129 @@ func (st *SettingsTab) stopServer() tea.Cmd {
 		// 先更新状态
 		return serviceStatusMsg{
 			serverStatus: "已停止",
-			clientStatus: st.clientStatus,
+			clientStatus: clientStatus,
+			notice:       i18n.T("✅ 服务端已停止"),
 		}
-	}
+	})
 }
 
 // startClient 启动客户端
 func (st *SettingsTab) startClient() tea.Cmd {
-	return func() tea.Msg {
-		err := st.manager.StartClient("examples/frpc.yaml")
+	st.events.Publish(service.UserActionEvent(service.ActionProcessStart, i18n.T("启动客户端 ")+st.appSettings.ClientConfigPath))
+	manager, path, serverStatus := st.manager, st.appSettings.ClientConfigPath, st.serverStatus
+	return runOperation(i18n.T("正在启动客户端"), func() tea.Msg {
+		err := manager.StartClient(path)
 		if err != nil {
 			return installProgressMsg{
-				message: fmt.Sprintf("启动客户端失败: %v", err),
-				done:    true,
-				err:     err,
+				message:     i18n.Sprintf("启动客户端失败: %v", err),
+				done:        true,
+				err:         err,
+				checkStatus: true,
 			}
 		}
 		// 先更新状态为连接中
 		return serviceStatusMsg{
-			serverStatus: st.serverStatus,
+			serverStatus: serverStatus,
 			clientStatus: "连接中",
+			notice:       i18n.T("✅ 客户端已启动"),
 		}
-	}
+	})
 }
 
 // stopClient 停止客户端
 func (st *SettingsTab) stopClient() tea.Cmd {
-	return func() tea.Msg {
-		err := st.manager.StopClient()
+	st.events.Publish(service.UserActionEvent(service.ActionProcessStop, i18n.T("停止客户端")))
+	manager, serverStatus := st.manager, st.serverStatus
+	return runOperation(i18n.T("正在停止客户端"), func() tea.Msg {
+		err := manager.StopClient()
 		if err != nil {
 			return installProgressMsg{
-				message: fmt.Sprintf("停止客户端失败: %v", err),
+				message: i18n.Sprintf("停止客户端失败: %v", err),
 				done:    true,
 				err:     err,
 			}
 		}
 		// 先更新状态
 		return serviceStatusMsg{
-			serverStatus: st.serverStatus,
+			serverStatus: serverStatus,
 			clientStatus: "未连接",
+			notice:       i18n.T("✅ 客户端已停止"),
 		}
-	}
+	})
 }
 
-// installFRP 安装FRP
-func (st *SettingsTab) installFRP() tea.Cmd {
-	st.isInstalling = true
-	st.installProgress = "正在下载 FRP..."
+// reloadClient 通过 frpc 管理接口热重载客户端配置，无需重启进程
+func (st *SettingsTab) reloadClient() tea.Cmd {
+	st.events.Publish(service.UserActionEvent(service.ActionProcessRestart, i18n.T("热重载客户端配置 ")+st.appSettings.ClientConfigPath))
+	path := st.appSettings.ClientConfigPath
+	return runOperation(i18n.T("正在热重载客户端配置"), func() tea.Msg {
+		cfg, err := config.NewLoader(path).Load()
+		if err != nil {
+			return installProgressMsg{done: true, err: err}
+		}
 
-	return func() tea.Msg {
-		err := st.installer.InstallFRP()
+		client, err := service.NewClientAPIClientFromConfig(cfg)
 		if err != nil {
-			return installProgressMsg{
-				message: "",
-				done:    true,
-				err:     err,
-			}
+			return installProgressMsg{done: true, err: err}
+		}
+
+		if err := client.Reload(); err != nil {
+			return installProgressMsg{done: true, err: err}
 		}
+
 		return installProgressMsg{
-			message: "✅ FRP 安装成功！",
+			message: i18n.T("✅ 客户端配置已热重载"),
 			done:    true,
-			err:     nil,
 		}
+	})
+}
+
+// testConnection 按客户端配置测试与服务端的连接和认证
+func (st *SettingsTab) testConnection() tea.Cmd {
+	st.installProgress = i18n.T("正在测试连接...")
+
+	manager, path := st.manager, st.appSettings.ClientConfigPath
+	return runOperation(i18n.T("正在测试连接"), func() tea.Msg {
+		cfg, err := config.NewLoader(path).Load()
+		if err != nil {
+			return installProgressMsg{done: true, err: err}
+		}
+
+		result := manager.TestConnection(cfg, service.ConnectionTestOptionsFor(cfg))
+		if !result.Success() {
+			return installProgressMsg{done: true, err: fmt.Errorf("%s", result.Summary())}
+		}
+		return installProgressMsg{message: "✅ " + result.Summary(), done: true}
+	})
+}
+
+// installFRP 安装FRP
+func (st *SettingsTab) installFRP() tea.Cmd {
+	st.events.Publish(service.UserActionEvent(service.ActionFrpInstall, i18n.T("安装 FRP")))
+	st.isInstalling = true
+	if st.installer.NeedsElevation() {
+		return st.installElevated(i18n.T("✅ FRP 安装成功！"), true)
 	}
+	st.installProgress = i18n.T("正在下载 FRP...")
+
+	return st.runWithDownloadProgress(st.installer.InstallFRP, i18n.T("✅ FRP 安装成功！"), true)
 }
 
 // updateFRP 更新FRP
 func (st *SettingsTab) updateFRP() tea.Cmd {
+	st.events.Publish(service.UserActionEvent(service.ActionFrpInstall, i18n.T("更新 FRP")))
 	st.isInstalling = true
-	st.installProgress = "正在更新 FRP..."
+	if st.installer.NeedsElevation() {
+		return st.installElevated(i18n.T("✅ FRP 更新成功！"), false)
+	}
+	st.installProgress = i18n.T("正在更新 FRP...")
 
-	return func() tea.Msg {
-		err := st.installer.UpdateFRP()
+	return st.runWithDownloadProgress(st.installer.UpdateFRP, i18n.T("✅ FRP 更新成功！"), false)
+}
+
+// runWithDownloadProgress 执行下载相关操作，同时监听下载进度，initWorkspace 表示成功后初始化工作空间
+func (st *SettingsTab) runWithDownloadProgress(action func() error, successMessage string, initWorkspace bool) tea.Cmd {
+	return st.runDownload(action, func(err error) tea.Msg {
 		if err != nil {
 			return installProgressMsg{
 				message: "",
@@ -657,17 +1014,71 @@ func (st *SettingsTab) updateFRP() tea.Cmd {
 			}
 		}
 		return installProgressMsg{
-			message: "✅ FRP 更新成功！",
-			done:    true,
-			err:     nil,
+			message:       successMessage,
+			done:          true,
+			err:           nil,
+			initWorkspace: initWorkspace,
+		}
+	})
+}
+
+// runDownload 执行下载相关操作并监听下载进度，结束后由 done 根据结果生成消息
+func (st *SettingsTab) runDownload(action func() error, done func(err error) tea.Msg) tea.Cmd {
+	st.download = nil
+	ch := make(chan installer.DownloadProgress, 16)
+	st.installer.SetProgressChannel(ch)
+
+	run := func() tea.Msg {
+		err := action()
+		st.installer.SetProgressChannel(nil)
+		close(ch)
+		return done(err)
+	}
+
+	return tea.Batch(run, waitForDownloadProgress(ch))
+}
+
+// waitForDownloadProgress 等待下一条下载进度，通道关闭后结束
+func waitForDownloadProgress(ch <-chan installer.DownloadProgress) tea.Cmd {
+	return func() tea.Msg {
+		p, ok := <-ch
+		if !ok {
+			return nil
 		}
+		return downloadProgressMsg{progress: p, ch: ch}
+	}
+}
+
+// renderDownloadProgress 渲染下载进度条、速度与剩余时间
+func (st *SettingsTab) renderDownloadProgress() string {
+	p := st.download
+	if p.Done {
+		return i18n.T("下载完成，正在校验并解压...")
+	}
+
+	var line string
+	if p.Total > 0 {
+		line = st.progressBar.ViewAs(p.Percent()) + "\n"
+		line += fmt.Sprintf("%s / %s", service.FormatTraffic(p.Downloaded), service.FormatTraffic(p.Total))
+	} else {
+		line = i18n.Sprintf("已下载 %s", service.FormatTraffic(p.Downloaded))
+	}
+
+	line += fmt.Sprintf("  %s/s", service.FormatTraffic(int64(p.Speed)))
+	if p.ETA > 0 {
+		line += i18n.Sprintf("  剩余 %s", p.ETA.Round(time.Second))
+	}
+	if p.Resumed {
+		line += i18n.T("  (断点续传)")
 	}
+	return line
 }
 
 // uninstallFRP 卸载FRP
 func (st *SettingsTab) uninstallFRP() tea.Cmd {
+	st.events.Publish(service.UserActionEvent(service.ActionFrpInstall, i18n.T("卸载 FRP")))
 	st.isInstalling = true
-	st.installProgress = "正在卸载 FRP..."
+	st.installProgress = i18n.T("正在卸载 FRP...")
 
 	return func() tea.Msg {
 		err := st.installer.Uninstall()
@@ -679,7 +1090,7 @@ func (st *SettingsTab) uninstallFRP() tea.Cmd {
 			}
 		}
 		return installProgressMsg{
-			message: "✅ FRP 卸载成功！",
+			message: i18n.T("✅ FRP 卸载成功！"),
 			done:    true,
 			err:     nil,
 		}
@@ -703,53 +1114,413 @@ func (st *SettingsTab) refreshInstallStatus() tea.Cmd {
 	}
 }
 
-// updateLogs 更新日志 - 从manager日志通道收集
-func (st *SettingsTab) updateLogs() tea.Cmd {
+// AppendLogs 追加新日志，由主界面统一从 manager 日志订阅分发
+func (st *SettingsTab) AppendLogs(entries []service.LogMessage) {
+	st.logs.Append(entries...)
+}
+
+// renderRecentLogs 渲染某个进程最近的日志，按 frp 输出中的级别着色
+func (st *SettingsTab) renderRecentLogs(source, status string) string {
+	entries := st.logs.Query(service.LogQuery{Source: source, Limit: st.maxLogLines})
+	if len(entries) == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.T("暂无日志 (状态: ")+i18n.T(status)+")") + "\n"
+	}
+
+	var content string
+	for _, entry := range entries {
+		level := entry.EffectiveLevel()
+		line := fmt.Sprintf("• [%s] [%s] %s", entry.Timestamp.Format("15:04:05"), level, entry.Message)
+		content += st.logPalette.style(level).Render(line) + "\n"
+	}
+	return content
+}
+
+// serviceConfigPath 返回系统服务使用的配置文件路径，与手动启动使用同一份配置
+func serviceConfigPath(name string) string {
+	path := filepath.Join("examples", name+".yaml")
+	if absPath, err := filepath.Abs(path); err == nil {
+		return absPath
+	}
+	return path
+}
+
+// isTargetServiceInstalled 当前目标是否已安装为系统服务
+func (st *SettingsTab) isTargetServiceInstalled() bool {
+	status := st.systemServices[st.serviceTarget]
+	return status != nil && status.Installed
+}
+
+// refreshSystemServices 刷新系统服务状态
+func (st *SettingsTab) refreshSystemServices() tea.Cmd {
 	return func() tea.Msg {
-		// 从service manager获取日志通道
-		logChan := st.manager.GetLogChannel()
-
-		var newServerLogs, newClientLogs []string
-
-		// 非阻塞读取所有可用的新日志
-		for {
-			select {
-			case logMsg := <-logChan:
-				// 格式化日志消息，包含日志级别信息
-				formattedLog := fmt.Sprintf("[%s] [%s] %s",
-					logMsg.Timestamp.Format("15:04:05"),
-					logMsg.Level,
-					logMsg.Message)
-
-				// 根据来源分类
-				if logMsg.Source == "server" {
-					newServerLogs = append(newServerLogs, formattedLog)
-				} else if logMsg.Source == "client" {
-					newClientLogs = append(newClientLogs, formattedLog)
-				}
-			default:
-				// 没有更多日志时退出
-				goto done
+		statuses := make(map[string]*service.SystemServiceStatus)
+		for _, name := range []string{"frps", "frpc"} {
+			status, err := st.daemonizer.Status(name)
+			if err != nil {
+				return systemServiceStatusMsg{err: err}
 			}
+			statuses[name] = status
+		}
+		return systemServiceStatusMsg{statuses: statuses}
+	}
+}
+
+// installSystemService 将当前目标安装为开机自启的系统服务
+func (st *SettingsTab) installSystemService() tea.Cmd {
+	name := st.serviceTarget
+	st.serviceMessage = i18n.Sprintf("正在安装 %s 系统服务...", name)
+	st.events.Publish(service.UserActionEvent(service.ActionSystemService, i18n.Sprintf("安装 %s 系统服务", name)))
+
+	return func() tea.Msg {
+		err := st.daemonizer.Install(service.SystemServiceSpec{
+			Name:         name,
+			ConfigPath:   serviceConfigPath(name),
+			EnableOnBoot: true,
+		})
+		if err != nil {
+			return systemServiceResultMsg{err: err}
+		}
+		return systemServiceResultMsg{message: i18n.Sprintf("✅ %s 已注册为系统服务", name)}
+	}
+}
+
+// toggleSystemServiceBoot 切换当前目标的开机自启
+func (st *SettingsTab) toggleSystemServiceBoot() tea.Cmd {
+	name := st.serviceTarget
+	enabled := !st.systemServices[name].Enabled
+	if enabled {
+		st.events.Publish(service.UserActionEvent(service.ActionSystemService, i18n.Sprintf("开启 %s 开机自启", name)))
+	} else {
+		st.events.Publish(service.UserActionEvent(service.ActionSystemService, i18n.Sprintf("关闭 %s 开机自启", name)))
+	}
+
+	return func() tea.Msg {
+		if err := st.daemonizer.SetEnabled(name, enabled); err != nil {
+			return systemServiceResultMsg{err: err}
+		}
+		if enabled {
+			return systemServiceResultMsg{message: i18n.Sprintf("✅ %s 已开启开机自启", name)}
+		}
+		return systemServiceResultMsg{message: i18n.Sprintf("✅ %s 已关闭开机自启", name)}
+	}
+}
+
+// uninstallSystemService 移除当前目标的系统服务
+func (st *SettingsTab) uninstallSystemService() tea.Cmd {
+	name := st.serviceTarget
+	st.serviceMessage = i18n.Sprintf("正在移除 %s 系统服务...", name)
+	st.events.Publish(service.UserActionEvent(service.ActionSystemService, i18n.Sprintf("移除 %s 系统服务", name)))
+
+	return func() tea.Msg {
+		if err := st.daemonizer.Uninstall(name); err != nil {
+			return systemServiceResultMsg{err: err}
 		}
+		return systemServiceResultMsg{message: i18n.Sprintf("✅ %s 系统服务已移除", name)}
+	}
+}
 
-	done:
+// renderSystemServices 渲染系统服务部分
+func (st *SettingsTab) renderSystemServices() string {
+	var content string
+	content += lipgloss.NewStyle().Bold(true).Render(i18n.T("🛠 系统服务")) + "\n\n"
 
-		// 合并新日志到现有日志
-		allServerLogs := append(st.serverLogs, newServerLogs...)
-		allClientLogs := append(st.clientLogs, newClientLogs...)
+	backend := st.daemonizer.Backend()
+	if backend == "" {
+		return content + i18n.T("当前系统不支持安装系统服务")
+	}
 
-		// 限制日志行数，保留最新的日志
-		if len(allServerLogs) > st.maxLogLines {
-			allServerLogs = allServerLogs[len(allServerLogs)-st.maxLogLines:]
+	for _, name := range []string{"frps", "frpc"} {
+		marker := "  "
+		if name == st.serviceTarget {
+			marker = "▶ "
 		}
-		if len(allClientLogs) > st.maxLogLines {
-			allClientLogs = allClientLogs[len(allClientLogs)-st.maxLogLines:]
+
+		status := st.systemServices[name]
+		var state string
+		switch {
+		case status == nil:
+			state = i18n.T("检查中...")
+		case !status.Installed:
+			state = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.T("未安装"))
+		default:
+			running := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("已停止")
+			if status.Active {
+				running = lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render("运行中")
+			}
+			boot := i18n.T("开机自启: 关")
+			if status.Enabled {
+				boot = i18n.T("开机自启: 开")
+			}
+			state = running + " | " + boot
 		}
 
-		return logUpdateMsg{
-			serverLogs: allServerLogs,
-			clientLogs: allClientLogs,
+		content += fmt.Sprintf("%s%s (%s): %s\n", marker, name, backend, state)
+	}
+
+	if st.serviceMessage != "" {
+		content += "\n" + st.serviceMessage
+	}
+
+	return content
+}
+
+// IsInInputMode 是否正在选择版本、浏览已安装版本、编辑应用设置、选择安装包或确认重启，需要独占键盘输入
+func (st *SettingsTab) IsInInputMode() bool {
+	return st.pickingVersion || st.managingVersions || st.settingsForm != nil || st.auditViewer != nil || st.restartVersion != "" ||
+		(st.filePicker != nil && st.filePicker.IsVisible())
+}
+
+// versionOrUnknown 版本为空时显示未知
+func versionOrUnknown(version string) string {
+	if version == "" {
+		return i18n.T("版本未知")
+	}
+	return "v" + version
+}
+
+// canUninstall 是否可以卸载：只卸载本工具安装的程序
+func (st *SettingsTab) canUninstall() bool {
+	return st.installStatus != nil && st.installStatus.IsInstalled && !st.installStatus.External &&
+		!st.installStatus.PackageManaged() && !st.isInstalling
+}
+
+// useExistingFRP 把检测到的程序所在目录记为安装目录，之后启动 frps/frpc 直接使用这些程序
+func (st *SettingsTab) useExistingFRP() tea.Cmd {
+	status := st.installStatus
+	settings := *st.appSettings
+	settings.InstallDir = filepath.Dir(status.FrpsPath)
+	if err := config.SaveAppSettings(&settings); err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+
+	st.installer.ApplySettings(&settings)
+	notice := i18n.Sprintf("✅ 已改用 %s 安装的 FRP: %s", installer.OriginLabel(status.Origin), settings.InstallDir)
+	return tea.Batch(
+		func() tea.Msg { return appSettingsChangedMsg{settings: &settings, notice: notice} },
+		st.refreshInstallStatus(),
+	)
+}
+
+// canUpdate 是否可以更新：有新版本，或者选择了与当前不同的版本
+func (st *SettingsTab) canUpdate() bool {
+	if st.installStatus == nil || !st.installStatus.IsInstalled || st.isInstalling {
+		return false
+	}
+	// 已改用包管理器安装的程序时由包管理器负责更新
+	if st.installStatus.PackageManaged() && !st.installStatus.External {
+		return false
+	}
+	return st.installStatus.NeedsUpdate || st.installer.GetVersion() != st.installStatus.Version
+}
+
+// loadReleases 获取可安装的版本列表
+func (st *SettingsTab) loadReleases(forceRefresh bool) tea.Cmd {
+	return func() tea.Msg {
+		releases, err := st.installer.ListReleases(forceRefresh)
+		return releasesMsg{releases: releases, err: err}
+	}
+}
+
+// channelReleases 返回当前更新通道中的版本，正式版通道不显示预发布版本
+func (st *SettingsTab) channelReleases() []installer.Release {
+	return installer.FilterChannel(st.releases, st.appSettings.IncludesPrereleases())
+}
+
+// toggleReleaseChannel 在正式版和预发布通道之间切换并保存设置
+func (st *SettingsTab) toggleReleaseChannel() tea.Cmd {
+	settings := *st.appSettings
+	notice := i18n.T("📡 已切换到预发布通道，版本列表包含 RC 等测试版本")
+	if settings.IncludesPrereleases() {
+		settings.ReleaseChannel = ""
+		notice = i18n.T("📡 已切换到正式版通道")
+	} else {
+		settings.ReleaseChannel = config.ReleaseChannelPrerelease
+	}
+	if err := config.SaveAppSettings(&settings); err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+
+	st.appSettings = &settings
+	st.installer.ApplySettings(&settings)
+	st.versionCursor = st.currentVersionIndex()
+	return tea.Batch(
+		func() tea.Msg { return appSettingsChangedMsg{settings: &settings, notice: notice} },
+		st.refreshInstallStatus(),
+	)
+}
+
+// prereleaseStyle 预发布版本和预发布通道的提示颜色
+var prereleaseStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+
+// prereleaseTag 预发布版本在版本号后显示的标记
+func prereleaseTag(version string) string {
+	if installer.IsPrerelease(version) {
+		return i18n.T(" (预发布)")
+	}
+	return ""
+}
+
+// currentVersionIndex 返回当前目标版本在列表中的位置
+func (st *SettingsTab) currentVersionIndex() int {
+	for i, release := range st.channelReleases() {
+		if release.Version() == st.installer.GetVersion() {
+			return i
+		}
+	}
+	return 0
+}
+
+// updateVersionPicker 处理版本选择列表的按键
+func (st *SettingsTab) updateVersionPicker(msg tea.KeyMsg) tea.Cmd {
+	keys := st.keys.Settings
+	switch {
+	case key.Matches(msg, keys.Up):
+		if st.versionCursor > 0 {
+			st.versionCursor--
+		}
+	case key.Matches(msg, keys.Down):
+		if st.versionCursor < len(st.channelReleases())-1 {
+			st.versionCursor++
+		}
+	case key.Matches(msg, keys.RefreshReleases):
+		return st.loadReleases(true)
+	case key.Matches(msg, keys.ReleaseChannel):
+		return st.toggleReleaseChannel()
+	case msg.String() == "enter":
+		if releases := st.channelReleases(); st.versionCursor < len(releases) {
+			st.installer.SetVersion(releases[st.versionCursor].Version())
+		}
+		st.pickingVersion = false
+		return st.refreshInstallStatus()
+	case key.Matches(msg, keys.CloseVersions):
+		st.pickingVersion = false
+	}
+	return nil
+}
+
+// renderVersionPicker 渲染版本选择列表
+func (st *SettingsTab) renderVersionPicker() string {
+	title := i18n.T("📦 选择版本（正式版通道）")
+	if st.appSettings.IncludesPrereleases() {
+		title = i18n.T("📦 选择版本（预发布通道）")
+	}
+	content := lipgloss.NewStyle().Bold(true).Render(title) + "\n"
+
+	releases := st.channelReleases()
+	if len(releases) == 0 {
+		if st.releaseErr != nil {
+			return content + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(i18n.T("获取版本列表失败: ")+st.releaseErr.Error()) + "\n"
+		}
+		if len(st.releases) > 0 {
+			return content + i18n.Sprintf("当前通道没有可用的版本，按 %s 切换通道\n", st.keys.Settings.ReleaseChannel.Help().Key)
+		}
+		return content + i18n.T("正在获取版本列表...\n")
+	}
+
+	// 只显示光标附近的版本
+	const visible = 8
+	start := st.versionCursor - visible/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + visible
+	if end > len(releases) {
+		end = len(releases)
+		start = end - visible
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	installed := ""
+	if st.installStatus != nil {
+		installed = st.installStatus.Version
+	}
+	downloaded := make(map[string]bool)
+	for _, v := range st.installedVersions() {
+		downloaded[v.Version] = true
+	}
+
+	for i := start; i < end; i++ {
+		release := releases[i]
+		line := release.Version()
+		if !release.PublishedAt.IsZero() {
+			line += "  " + release.PublishedAt.Format("2006-01-02")
+		}
+		if release.Prerelease {
+			line += i18n.T(" ⚠ 预发布")
+		}
+		if release.Version() == installed {
+			line += i18n.T(" ✓ 已安装")
+		} else if downloaded[release.Version()] {
+			line += i18n.T(" (已下载，切换无需重新下载)")
+		}
+
+		if i == st.versionCursor {
+			content += lipgloss.NewStyle().
+				Foreground(lipgloss.Color("229")).
+				Background(lipgloss.Color("57")).
+				Render("▶ "+line) + "\n"
+		} else if release.Prerelease {
+			content += prereleaseStyle.Render("  "+line) + "\n"
+		} else {
+			content += "  " + line + "\n"
+		}
+	}
+
+	content += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.Sprintf("↑/↓ 选择 • Enter 确认 • %s 切换正式版/预发布通道 • %s 刷新 • %s 取消",
+		st.keys.Settings.ReleaseChannel.Help().Key, st.keys.Settings.RefreshReleases.Help().Key, st.keys.Settings.CloseVersions.Help().Key))
+	return content
+}
+
+// SetAppSettings 更新应用设置，同步到安装器和日志配色
+func (st *SettingsTab) SetAppSettings(settings *config.AppSettings) {
+	st.appSettings = settings
+	st.installer.ApplySettings(settings)
+	st.logPalette, _ = newLogPalette(settings.Theme, settings.LogColors)
+}
+
+// toggleAutoRestart 切换当前目标服务（v 选择）的自动重启并保存设置
+func (st *SettingsTab) toggleAutoRestart() tea.Cmd {
+	settings := *st.appSettings
+	name := i18n.T("服务端")
+	if st.serviceTarget == "frps" {
+		settings.AutoRestartServer = !settings.AutoRestartServer
+	} else {
+		settings.AutoRestartClient = !settings.AutoRestartClient
+		name = i18n.T("客户端")
+	}
+
+	if err := config.SaveAppSettings(&settings); err != nil {
+		return showStatusMessage("❌ "+err.Error(), true)
+	}
+
+	enabled := settings.AutoRestartServer
+	if st.serviceTarget == "frpc" {
+		enabled = settings.AutoRestartClient
+	}
+	state := i18n.T("已关闭")
+	if enabled {
+		state = i18n.T("已开启")
+	}
+	notice := i18n.Sprintf("🔁 %s自动重启%s", name, state)
+	return func() tea.Msg { return appSettingsChangedMsg{settings: &settings, notice: notice} }
+}
+
+// updateSettingsForm 处理应用设置表单的按键，保存后通知主界面下发新设置
+func (st *SettingsTab) updateSettingsForm(msg tea.KeyMsg) tea.Cmd {
+	cmd, saved, closed := st.settingsForm.Update(msg, st.appSettings)
+	if closed {
+		st.settingsForm = nil
+	}
+	if saved != nil {
+		changed := func() tea.Msg { return appSettingsChangedMsg{settings: saved} }
+		if saved.InstallDir == st.appSettings.InstallDir && saved.ReleaseChannel == st.appSettings.ReleaseChannel {
+			return changed
 		}
+		// 安装目录或更新通道变化后立即重新检查安装状态和可用的更新
+		st.installer.ApplySettings(saved)
+		return tea.Batch(changed, st.refreshInstallStatus())
 	}
+	return cmd
 }
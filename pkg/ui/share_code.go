@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/skip2/go-qrcode"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// shareCode 分享码面板状态，导出时展示分享码和二维码，导入时粘贴分享码
+type shareCode struct {
+	proxyIndex int
+	code       string
+	qr         string
+	importing  bool
+	input      textinput.Model
+	message    string
+	err        error
+}
+
+// handleShareCode 打开分享码面板，没有可分享的代理时直接进入导入
+func (ct *ConfigTab) handleShareCode() (Tab, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = i18n.T("分享码: ")
+	input.Placeholder = config.ShareCodePrefix + "..."
+	input.CharLimit = 4096
+	input.Width = 50
+
+	ct.share = &shareCode{input: input}
+	ct.state = ConfigTabShare
+	ct.currentForm = nil
+	ct.focusOnForm = false
+
+	if ct.clientConfig == nil || len(ct.clientConfig.Proxies) == 0 {
+		ct.share.importing = true
+		return ct, ct.share.input.Focus()
+	}
+	ct.refreshShareCode()
+	return ct, nil
+}
+
+// refreshShareCode 按当前选中的代理重新生成分享码和二维码
+func (ct *ConfigTab) refreshShareCode() {
+	s := ct.share
+	s.code, s.qr, s.err = "", "", nil
+
+	proxy := ct.clientConfig.Proxies[s.proxyIndex]
+	shared, err := config.NewSharedConfig(ct.clientConfig, proxy.Name)
+	if err != nil {
+		s.err = err
+		return
+	}
+	if s.code, err = shared.Encode(); err != nil {
+		s.err = i18n.Errorf("生成分享码失败: %w", err)
+		return
+	}
+
+	qr, err := qrcode.New(s.code, qrcode.Low)
+	if err != nil {
+		s.err = i18n.Errorf("生成二维码失败: %w", err)
+		return
+	}
+	s.qr = qr.ToSmallString(false)
+}
+
+// updateShareCode 处理分享码面板中的按键
+func (ct *ConfigTab) updateShareCode(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	s := ct.share
+	canExport := ct.clientConfig != nil && len(ct.clientConfig.Proxies) > 0
+
+	if s.importing {
+		switch msg.String() {
+		case "esc":
+			if !canExport {
+				ct.share = nil
+				ct.state = ConfigTabMenu
+				return ct, nil
+			}
+			s.importing = false
+			s.input.Blur()
+			s.message, s.err = "", nil
+			if s.proxyIndex >= len(ct.clientConfig.Proxies) {
+				s.proxyIndex = 0
+			}
+			ct.refreshShareCode()
+			return ct, nil
+		case "enter":
+			ct.importShareCode()
+			return ct, nil
+		}
+
+		var cmd tea.Cmd
+		s.input, cmd = s.input.Update(msg)
+		return ct, cmd
+	}
+
+	keys := ct.keys.Config
+	switch {
+	case msg.String() == "esc":
+		ct.share = nil
+		ct.state = ConfigTabMenu
+	case key.Matches(msg, keys.PrevShareProxy):
+		s.proxyIndex = (s.proxyIndex - 1 + len(ct.clientConfig.Proxies)) % len(ct.clientConfig.Proxies)
+		ct.refreshShareCode()
+	case key.Matches(msg, keys.NextShareProxy):
+		s.proxyIndex = (s.proxyIndex + 1) % len(ct.clientConfig.Proxies)
+		ct.refreshShareCode()
+	case key.Matches(msg, keys.CopyShareCode):
+		if s.code != "" {
+			return ct, copyCmd(s.code)
+		}
+	case key.Matches(msg, keys.ImportShareCode):
+		s.importing = true
+		s.message, s.err = "", nil
+		s.input.SetValue("")
+		return ct, s.input.Focus()
+	}
+
+	return ct, nil
+}
+
+// importShareCode 解析输入的分享码并写入客户端配置
+func (ct *ConfigTab) importShareCode() {
+	s := ct.share
+	s.message, s.err = "", nil
+
+	shared, err := config.DecodeShareCode(s.input.Value())
+	if err != nil {
+		s.err = err
+		return
+	}
+
+	if ct.clientConfig == nil {
+		ct.clientConfig = config.CreateDefaultClientConfig()
+		ct.clientConfig.Proxies = nil
+	}
+	if shared.ApplyTo(ct.clientConfig) {
+		s.message = i18n.Sprintf("✅ 已导入 %s:%d，替换了同名代理 '%s'", shared.ServerAddr, shared.ServerPort, shared.Proxy.Name)
+	} else {
+		s.message = i18n.Sprintf("✅ 已导入 %s:%d 和代理 '%s'", shared.ServerAddr, shared.ServerPort, shared.Proxy.Name)
+	}
+	s.input.SetValue("")
+	ct.recordHistory(i18n.T("导入分享码 ") + shared.Proxy.Name)
+}
+
+// renderShareCode 渲染分享码面板
+func (ct *ConfigTab) renderShareCode(width int) string {
+	s := ct.share
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+	content := titleStyle.Render(i18n.T("📱 分享/导入客户端配置")) + "\n\n"
+
+	if s.importing {
+		content += i18n.T("粘贴同事发来的分享码，服务端地址、端口以及分享码中的 token 将覆盖当前客户端配置，代理按名称添加或替换") + "\n\n"
+		content += s.input.View() + "\n\n"
+		if s.err != nil {
+			content += errorStyle.Render("❌ "+s.err.Error()) + "\n\n"
+		}
+		if s.message != "" {
+			content += lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render(s.message) + "\n"
+			content += hintStyle.Render(i18n.T("导入的内容尚未保存，请使用 💾 保存配置 写入文件")) + "\n\n"
+		}
+		content += hintStyle.Render(i18n.T("Enter 导入 | ESC 返回"))
+		return content
+	}
+
+	proxy := ct.clientConfig.Proxies[s.proxyIndex]
+	content += i18n.Sprintf("代理: %s (%s)  [%d/%d]\n", proxy.Name, proxy.Type, s.proxyIndex+1, len(ct.clientConfig.Proxies))
+	content += i18n.Sprintf("服务端: %s:%d\n\n", ct.clientConfig.ServerAddr, ct.clientConfig.ServerPort)
+
+	if s.err != nil {
+		content += errorStyle.Render("❌ "+s.err.Error()) + "\n\n"
+	} else {
+		content += s.qr + "\n"
+		content += wrapText(s.code, width) + "\n\n"
+		if ct.clientConfig.Auth.Token != "" {
+			content += lipgloss.NewStyle().Foreground(lipgloss.Color("226")).
+				Render(i18n.T("⚠️ 分享码包含 token，请只发给可信的人")) + "\n\n"
+		}
+	}
+
+	content += hintStyle.Render(i18n.Sprintf("←/→ 切换代理 | %s 复制分享码 | %s 导入分享码 | ESC 返回",
+		ct.keys.Config.CopyShareCode.Help().Key, ct.keys.Config.ImportShareCode.Help().Key))
+	return content
+}
+
+// wrapText 按显示宽度硬换行，便于完整复制长字符串
+func wrapText(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+
+	var b strings.Builder
+	lineWidth := 0
+	for _, r := range s {
+		w := runewidth.RuneWidth(r)
+		if lineWidth+w > width {
+			b.WriteString("\n")
+			lineWidth = 0
+		}
+		b.WriteRune(r)
+		lineWidth += w
+	}
+	return b.String()
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// shutdownResultDelay 关闭完成后保留关闭对话框的时间，便于看清结果
+const shutdownResultDelay = 800 * time.Millisecond
+
+// shutdownProgressMsg 退出时停止进程的进度，done 为 true 表示已全部处理完
+type shutdownProgressMsg struct {
+	step string
+	done bool
+	err  error
+	next tea.Cmd
+}
+
+// waitForShutdownProgress 从通道依次读取关闭进度
+func waitForShutdownProgress(ch <-chan shutdownProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		msg.next = waitForShutdownProgress(ch)
+		return msg
+	}
+}
+
+// stopsProcessesOnExit 退出时是否需要停止本工具启动的进程
+func (m *MainDashboard) stopsProcessesOnExit() bool {
+	return m.manager != nil && m.appSettings.StopOnExit && m.manager.HasManagedProcesses()
+}
+
+// RestartRequested 是否在自更新后选择了立即重启，终端界面结束后由调用方启动新版本
+func (m *MainDashboard) RestartRequested() bool {
+	return m.restarting
+}
+
+// beginShutdown 确认退出后调用，需要停止进程时显示关闭对话框并在后台停止，否则直接退出
+func (m *MainDashboard) beginShutdown() tea.Cmd {
+	if m.monitor != nil {
+		// 避免把主动停止的进程当成故障告警
+		m.monitor.Stop()
+	}
+	if m.scheduler != nil {
+		m.scheduler.Stop()
+	}
+	if m.latency != nil {
+		m.latency.Stop()
+	}
+	if m.resources != nil {
+		m.resources.Stop()
+	}
+	if m.stopLogs != nil {
+		m.stopLogs()
+	}
+	m.closeExtensionTabs()
+	if !m.stopsProcessesOnExit() {
+		return tea.Quit
+	}
+
+	m.closing = true
+	m.closingSteps = nil
+
+	ch := make(chan shutdownProgressMsg, 16)
+	go func() {
+		err := m.stopProcesses(func(step string) {
+			ch <- shutdownProgressMsg{step: step}
+		})
+		ch <- shutdownProgressMsg{done: true, err: err}
+		close(ch)
+	}()
+
+	return waitForShutdownProgress(ch)
+}
+
+// handleShutdownProgress 记录关闭进度，全部完成后退出程序
+func (m *MainDashboard) handleShutdownProgress(msg shutdownProgressMsg) tea.Cmd {
+	if !msg.done {
+		m.closingSteps = append(m.closingSteps, msg.step)
+		return msg.next
+	}
+
+	if msg.err != nil {
+		m.closingSteps = append(m.closingSteps, i18n.T("部分进程未能停止，请手动检查"))
+	}
+	m.closingSteps = append(m.closingSteps, i18n.T("关闭完成，正在退出..."))
+	return tea.Tick(shutdownResultDelay, func(time.Time) tea.Msg { return tea.Quit() })
+}
+
+// Shutdown 在终端界面结束后调用，停止健康监控和自动启动调度，并按设置停止本工具启动的 frps/frpc。
+// 界面中已执行过关闭流程时会等待其完成并返回同样的结果，界面被信号中断时作为兜底。最后关闭进程管理器
+func (m *MainDashboard) Shutdown(progress service.ShutdownProgressFunc) error {
+	if m.monitor != nil {
+		m.monitor.Stop()
+	}
+	if m.scheduler != nil {
+		m.scheduler.Stop()
+	}
+	if m.latency != nil {
+		m.latency.Stop()
+	}
+	err := m.stopProcesses(progress)
+	if m.manager != nil {
+		// 进程停止后等待剩余日志读完，并取消尚未执行的自动重启
+		m.manager.Close()
+	}
+	return err
+}
+
+// stopProcesses 只执行一次的进程停止，未开启退出时停止进程则不做处理
+func (m *MainDashboard) stopProcesses(progress service.ShutdownProgressFunc) error {
+	m.shutdownOnce.Do(func() {
+		if m.manager == nil || !m.appSettings.StopOnExit {
+			return
+		}
+		m.shutdownErr = m.manager.Shutdown(m.appSettings.ShutdownDuration(), progress)
+	})
+	return m.shutdownErr
+}
+
+// renderClosingDialog 渲染关闭进度对话框
+func (m *MainDashboard) renderClosingDialog() string {
+	var b strings.Builder
+	b.WriteString(i18n.T("正在关闭\n\n正在停止本工具启动的 FRP 进程，请稍候...\n"))
+	for _, step := range m.closingSteps {
+		b.WriteString("\n" + step)
+	}
+	b.WriteString(i18n.T("\n\n按 Ctrl+C 跳过等待，剩余进程将在退出后继续停止"))
+
+	return m.layout.RenderDialog(b.String(), DefaultDialogOptions())
+}
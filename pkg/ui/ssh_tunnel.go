@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// sshTunnelHelper 生成 ssh -R 命令的面板，供未运行 frpc 的机器通过 frps 的 SSH 隧道网关创建代理
+type sshTunnelHelper struct {
+	form *huh.Form
+
+	// 表单绑定字段
+	serverAddr string
+	sshPort    string
+	proxyType  string
+	name       string
+	localIP    string
+	localPort  string
+	remotePort string
+	domain     string
+	subdomain  string
+	secretKey  string
+
+	tunnel  config.SSHTunnel
+	command string
+	err     error
+}
+
+// handleSSHTunnel 打开 SSH 隧道命令生成面板，服务器地址和网关端口取自当前配置
+func (ct *ConfigTab) handleSSHTunnel() (Tab, tea.Cmd) {
+	tunnel := config.NewSSHTunnel(ct.serverConfig, ct.clientConfig)
+	h := &sshTunnelHelper{
+		serverAddr: tunnel.ServerAddr,
+		sshPort:    strconv.Itoa(tunnel.SSHPort),
+		proxyType:  tunnel.Type,
+		name:       config.UniqueProxyName(ct.clientConfig, "ssh-tunnel"),
+		localIP:    tunnel.LocalIP,
+		localPort:  "8080",
+	}
+	h.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("服务器地址")).
+				Description(i18n.T("frps 所在机器的地址")).
+				Placeholder("frps.example.com").
+				Value(&h.serverAddr).
+				Validate(func(str string) error {
+					if strings.TrimSpace(str) == "" {
+						return i18n.Errorf("服务器地址不能为空")
+					}
+					return nil
+				}),
+
+			huh.NewInput().
+				Title(i18n.T("SSH 隧道网关端口")).
+				Description(i18n.T("服务端配置中 sshTunnelGateway.bindPort 的值")).
+				Value(&h.sshPort).
+				Validate(validatePortInput),
+
+			huh.NewSelect[string]().
+				Title(i18n.T("代理类型")).
+				Options(huh.NewOptions(config.SSHTunnelTypes...)...).
+				Value(&h.proxyType),
+
+			huh.NewInput().
+				Title(i18n.T("代理名称")).
+				Value(&h.name).
+				Validate(func(str string) error {
+					if strings.TrimSpace(str) == "" {
+						return i18n.Errorf("代理名称不能为空")
+					}
+					return nil
+				}),
+
+			huh.NewInput().
+				Title(i18n.T("本地 IP")).
+				Description(i18n.T("运行 ssh 命令的机器上要暴露的服务地址")).
+				Value(&h.localIP),
+
+			huh.NewInput().
+				Title(i18n.T("本地端口")).
+				Value(&h.localPort).
+				Validate(validatePortInput),
+		).Title(i18n.T("🔑 SSH 隧道命令")),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("远程端口 (tcp)")).
+				Description(i18n.T("frps 上对外监听的端口，留空由 frps 分配")).
+				Value(&h.remotePort).
+				Validate(validateOptionalPort),
+		).WithHideFunc(func() bool { return h.proxyType != "tcp" }),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("自定义域名")).
+				Description(i18n.T("与子域名至少填写一项")).
+				Placeholder("www.example.com").
+				Value(&h.domain),
+
+			huh.NewInput().
+				Title(i18n.T("子域名")).
+				Description(i18n.T("需要服务端配置 subDomainHost")).
+				Value(&h.subdomain),
+		).WithHideFunc(func() bool {
+			return h.proxyType != "http" && h.proxyType != "https" && h.proxyType != "tcpmux"
+		}),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("访问密钥 (secretKey)")).
+				Description(i18n.T("访问者需要使用相同的 secretKey")).
+				Value(&h.secretKey),
+		).WithHideFunc(func() bool { return h.proxyType != "stcp" }),
+	)
+
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	ct.sshTunnel = h
+	ct.state = ConfigTabSSHTunnel
+	return ct, h.form.Init()
+}
+
+// updateSSHTunnel 处理 SSH 隧道命令面板中的消息，表单阶段把消息交给表单
+func (ct *ConfigTab) updateSSHTunnel(msg tea.Msg) (Tab, tea.Cmd) {
+	h := ct.sshTunnel
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case keyMsg.String() == "esc":
+			ct.sshTunnel = nil
+			ct.state = ConfigTabMenu
+			return ct, nil
+		case key.Matches(keyMsg, ct.keys.Config.CopySSHCommand):
+			if h.form == nil && h.command != "" {
+				return ct, copyCmd(h.command)
+			}
+		}
+	}
+
+	if h.form == nil {
+		return ct, nil
+	}
+	form, cmd := h.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		h.form = f
+	}
+	if h.form.State == huh.StateCompleted {
+		h.form = nil
+		h.generate()
+	}
+	return ct, cmd
+}
+
+// generate 按表单内容生成 ssh 命令
+func (h *sshTunnelHelper) generate() {
+	localPort, _ := strconv.Atoi(strings.TrimSpace(h.localPort))
+	sshPort, _ := strconv.Atoi(strings.TrimSpace(h.sshPort))
+	remotePort, _ := strconv.Atoi(strings.TrimSpace(h.remotePort))
+
+	h.tunnel = config.SSHTunnel{
+		ServerAddr:   strings.TrimSpace(h.serverAddr),
+		SSHPort:      sshPort,
+		Type:         h.proxyType,
+		Name:         strings.TrimSpace(h.name),
+		LocalIP:      strings.TrimSpace(h.localIP),
+		LocalPort:    localPort,
+		RemotePort:   remotePort,
+		CustomDomain: strings.TrimSpace(h.domain),
+		Subdomain:    strings.TrimSpace(h.subdomain),
+		SecretKey:    strings.TrimSpace(h.secretKey),
+	}
+	h.command, h.err = h.tunnel.Command()
+}
+
+// renderSSHTunnel 渲染 SSH 隧道命令面板
+func (ct *ConfigTab) renderSSHTunnel(width int) string {
+	h := ct.sshTunnel
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Width(width - 4)
+
+	content := titleStyle.Render(i18n.T("🔑 SSH 隧道命令")) + "\n\n"
+
+	if h.form != nil {
+		content += h.form.View()
+		content += "\n\n" + hintStyle.Render(i18n.T("Enter 下一步 | ESC 返回菜单"))
+		return content
+	}
+
+	if h.err != nil {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Width(width-4).Render("❌ "+h.err.Error()) + "\n\n"
+		content += hintStyle.Render(i18n.T("ESC 返回菜单"))
+		return content
+	}
+
+	content += i18n.T("在要暴露服务的机器上运行下面的命令，连接保持期间代理一直有效，不需要安装 frpc：") + "\n\n"
+	content += lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Width(width-4).Render(h.command) + "\n\n"
+
+	if ct.serverConfig != nil && ct.serverConfig.SSHTunnelGateway.BindPort == 0 {
+		content += warningStyle.Render(i18n.T("⚠️ 当前服务端配置未启用 SSH 隧道网关，请在服务端配置中填写 SSH 隧道网关端口")) + "\n"
+	} else if ct.serverConfig != nil && ct.serverConfig.SSHTunnelGateway.AuthorizedKeysFile == "" {
+		content += warningStyle.Render(i18n.T("⚠️ 服务端未配置授权公钥文件，任何能连接该端口的人都可以创建代理")) + "\n"
+	}
+	if h.tunnel.Type == "tcp" && h.tunnel.RemotePort == 0 {
+		content += hintStyle.Render(i18n.T("远程端口由 frps 分配，连接成功后 ssh 会输出实际端口")) + "\n"
+	}
+	content += hintStyle.Render(i18n.T("SSH 隧道网关需要 frps v0.53 及以上版本；用户名 v0 为固定写法")) + "\n\n"
+	content += hintStyle.Render(i18n.Sprintf("%s 复制命令 | ESC 返回菜单", ct.keys.Config.CopySSHCommand.Help().Key))
+	return content
+}
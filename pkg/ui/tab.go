@@ -2,6 +2,9 @@ package ui
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
 )
 
 // Tab 定义标签页接口
@@ -31,6 +34,11 @@ type Tab interface {
 	Focus(focused bool)
 }
 
+// AppSettingsAware 需要读取应用设置的标签页，设置变更后由主界面重新下发
+type AppSettingsAware interface {
+	SetAppSettings(settings *config.AppSettings)
+}
+
 // BaseTab 提供Tab接口的基本实现
 type BaseTab struct {
 	title     string
@@ -49,9 +57,9 @@ func NewBaseTab(title string) BaseTab {
 	}
 }
 
-// Title 获取标签页标题
+// Title 获取标签页标题，按当前语言显示
 func (b BaseTab) Title() string {
-	return b.title
+	return i18n.T(b.title)
 }
 
 // SetSize 设置标签页大小
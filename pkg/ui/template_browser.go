@@ -0,0 +1,528 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// templatePreviewMaxLines 模板预览最多显示的行数
+const templatePreviewMaxLines = 20
+
+// 模板浏览器中的输入操作
+const (
+	templateInputNone = iota
+	templateInputSaveServer
+	templateInputSaveClient
+	templateInputRename
+	templateInputConfirmDelete
+)
+
+// templateBrowser 模板管理浏览器状态
+type templateBrowser struct {
+	manager   *config.TemplateManager
+	templates []*config.ConfigTemplate
+	selected  int
+	inputMode int
+	input     textinput.Model
+	err       error
+
+	// 在线模板目录
+	catalogMode     bool
+	catalogURL      string
+	catalog         *config.CatalogResult
+	catalogSelected int
+	loading         bool
+}
+
+// templateCatalogMsg 在线模板目录获取结果
+type templateCatalogMsg struct {
+	result *config.CatalogResult
+	err    error
+}
+
+// fetchTemplateCatalog 在后台获取在线模板目录
+func fetchTemplateCatalog(catalogURL string, forceRefresh bool) tea.Cmd {
+	return func() tea.Msg {
+		result, err := config.FetchTemplateCatalog(catalogURL, forceRefresh)
+		return templateCatalogMsg{result: result, err: err}
+	}
+}
+
+// newTemplateBrowser 加载内置模板和用户模板
+func newTemplateBrowser() *templateBrowser {
+	input := textinput.New()
+	input.CharLimit = 64
+	input.Width = 40
+
+	b := &templateBrowser{
+		manager: config.NewTemplateManager(),
+		input:   input,
+	}
+	b.err = b.manager.Reload()
+	b.refresh("")
+	return b
+}
+
+// refresh 重新读取模板列表，并尽量选中指定名称的模板
+func (b *templateBrowser) refresh(selectName string) {
+	b.templates = b.manager.GetTemplates()
+	if selectName != "" {
+		for i, template := range b.templates {
+			if template.Name == selectName {
+				b.selected = i
+				return
+			}
+		}
+	}
+	if b.selected >= len(b.templates) {
+		b.selected = len(b.templates) - 1
+	}
+	if b.selected < 0 {
+		b.selected = 0
+	}
+}
+
+// current 返回当前选中的模板
+func (b *templateBrowser) current() *config.ConfigTemplate {
+	if b.selected < 0 || b.selected >= len(b.templates) {
+		return nil
+	}
+	return b.templates[b.selected]
+}
+
+// startInput 进入名称输入或删除确认
+func (b *templateBrowser) startInput(mode int, value string) tea.Cmd {
+	b.inputMode = mode
+	b.err = nil
+	b.input.SetValue(value)
+	b.input.CursorEnd()
+	return b.input.Focus()
+}
+
+// stopInput 退出输入
+func (b *templateBrowser) stopInput() {
+	b.inputMode = templateInputNone
+	b.input.Blur()
+}
+
+// currentCatalogTemplate 返回在线目录中选中的模板
+func (b *templateBrowser) currentCatalogTemplate() *config.ConfigTemplate {
+	if b.catalog == nil || b.catalogSelected < 0 || b.catalogSelected >= len(b.catalog.Catalog.Templates) {
+		return nil
+	}
+	return b.catalog.Catalog.Templates[b.catalogSelected]
+}
+
+// openCatalog 切换到在线模板目录并开始获取
+func (b *templateBrowser) openCatalog(forceRefresh bool) tea.Cmd {
+	b.catalogMode = true
+	b.err = nil
+	if b.catalogURL == "" {
+		b.err = i18n.Errorf("未设置模板目录地址，请在设置页按 g 填写「模板目录地址」")
+		return nil
+	}
+	b.loading = true
+	return fetchTemplateCatalog(b.catalogURL, forceRefresh)
+}
+
+// handleCatalogResult 处理在线模板目录获取结果
+func (b *templateBrowser) handleCatalogResult(msg templateCatalogMsg) {
+	b.loading = false
+	if msg.err != nil {
+		b.err = msg.err
+		return
+	}
+	b.catalog = msg.result
+	if b.catalogSelected >= len(b.catalog.Catalog.Templates) {
+		b.catalogSelected = 0
+	}
+}
+
+// updateCatalog 处理在线模板目录中的按键
+func (ct *ConfigTab) updateCatalog(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	b := ct.templates
+	keys := ct.keys.Config
+
+	switch {
+	case msg.String() == "esc":
+		b.catalogMode = false
+		b.err = nil
+	case key.Matches(msg, keys.Up):
+		if b.catalogSelected > 0 {
+			b.catalogSelected--
+		}
+	case key.Matches(msg, keys.Down):
+		if b.catalog != nil && b.catalogSelected < len(b.catalog.Catalog.Templates)-1 {
+			b.catalogSelected++
+		}
+	case key.Matches(msg, keys.RefreshCatalog):
+		if !b.loading {
+			return ct, b.openCatalog(true)
+		}
+	case key.Matches(msg, keys.ImportTemplate):
+		template := b.currentCatalogTemplate()
+		if template == nil {
+			return ct, nil
+		}
+		name, err := b.manager.ImportCatalogTemplate(template)
+		if err != nil {
+			b.err = err
+			return ct, nil
+		}
+		b.refresh(name)
+		return ct, showStatusMessage(i18n.Sprintf("📥 已导入模板 %s", name), false)
+	}
+
+	return ct, nil
+}
+
+// handleTemplates 打开模板管理浏览器
+func (ct *ConfigTab) handleTemplates() (Tab, tea.Cmd) {
+	ct.templates = newTemplateBrowser()
+	if ct.appSettings != nil {
+		ct.templates.catalogURL = ct.appSettings.TemplateCatalogURL
+	}
+	ct.state = ConfigTabTemplates
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	return ct, nil
+}
+
+// updateTemplateBrowser 处理模板浏览器中的按键
+func (ct *ConfigTab) updateTemplateBrowser(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	b := ct.templates
+
+	if b.inputMode != templateInputNone {
+		return ct.updateTemplateInput(msg)
+	}
+	if b.catalogMode {
+		return ct.updateCatalog(msg)
+	}
+
+	keys := ct.keys.Config
+	switch {
+	case msg.String() == "esc":
+		ct.templates = nil
+		ct.state = ConfigTabMenu
+	case key.Matches(msg, keys.Up):
+		if b.selected > 0 {
+			b.selected--
+		}
+	case key.Matches(msg, keys.Down):
+		if b.selected < len(b.templates)-1 {
+			b.selected++
+		}
+	case key.Matches(msg, keys.ApplyTemplate):
+		return ct.applyTemplate(false)
+	case key.Matches(msg, keys.MergeTemplate):
+		return ct.applyTemplate(true)
+	case key.Matches(msg, keys.OnlineTemplates):
+		return ct, b.openCatalog(false)
+	case key.Matches(msg, keys.SaveServerTemplate):
+		if ct.serverConfig == nil {
+			b.err = i18n.Errorf("尚未编辑服务端配置")
+			return ct, nil
+		}
+		return ct, b.startInput(templateInputSaveServer, "")
+	case key.Matches(msg, keys.SaveClientTemplate):
+		if ct.clientConfig == nil {
+			b.err = i18n.Errorf("尚未编辑客户端配置")
+			return ct, nil
+		}
+		return ct, b.startInput(templateInputSaveClient, "")
+	case key.Matches(msg, keys.RenameTemplate):
+		if template := b.current(); template != nil {
+			if template.Builtin {
+				b.err = i18n.Errorf("内置模板不能重命名")
+				return ct, nil
+			}
+			return ct, b.startInput(templateInputRename, template.Name)
+		}
+	case key.Matches(msg, keys.DeleteTemplate):
+		if template := b.current(); template != nil {
+			if template.Builtin {
+				b.err = i18n.Errorf("内置模板不能删除")
+				return ct, nil
+			}
+			b.inputMode = templateInputConfirmDelete
+			b.err = nil
+		}
+	}
+
+	return ct, nil
+}
+
+// updateTemplateInput 处理模板名称输入和删除确认
+func (ct *ConfigTab) updateTemplateInput(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	b := ct.templates
+
+	if b.inputMode == templateInputConfirmDelete {
+		template := b.current()
+		if msg.String() == "y" && template != nil {
+			b.stopInput()
+			if err := b.manager.DeleteTemplate(template.Name); err != nil {
+				b.err = err
+				return ct, nil
+			}
+			b.refresh("")
+			return ct, showStatusMessage(i18n.Sprintf("🗑️ 已删除模板 %s", template.Name), false)
+		}
+		b.stopInput()
+		return ct, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		b.stopInput()
+		return ct, nil
+	case "enter":
+		name := strings.TrimSpace(b.input.Value())
+		var err error
+		var status string
+
+		switch b.inputMode {
+		case templateInputSaveServer:
+			err = b.manager.SaveTemplate(name, i18n.T("从当前服务端配置创建"), "server", ct.serverConfig)
+			status = i18n.Sprintf("✅ 已将当前服务端配置保存为模板 %s", name)
+		case templateInputSaveClient:
+			err = b.manager.SaveTemplate(name, i18n.T("从当前客户端配置创建"), "client", ct.clientConfig)
+			status = i18n.Sprintf("✅ 已将当前客户端配置保存为模板 %s", name)
+		case templateInputRename:
+			err = b.manager.RenameTemplate(b.current().Name, name)
+			status = i18n.Sprintf("✅ 模板已重命名为 %s", name)
+		}
+
+		if err != nil {
+			b.err = err
+			return ct, nil
+		}
+		b.stopInput()
+		b.refresh(name)
+		return ct, showStatusMessage(status, false)
+	}
+
+	var cmd tea.Cmd
+	b.input, cmd = b.input.Update(msg)
+	return ct, cmd
+}
+
+// applyTemplate 用选中的模板替换或合并到同类型的当前配置
+func (ct *ConfigTab) applyTemplate(merge bool) (Tab, tea.Cmd) {
+	b := ct.templates
+	template := b.current()
+	if template == nil {
+		return ct, nil
+	}
+
+	target := &ct.clientConfig
+	if template.Type == "server" {
+		target = &ct.serverConfig
+	}
+
+	var cfg *config.Config
+	var err error
+	action := i18n.T("应用模板 ") + template.Name
+	if merge {
+		cfg, err = b.manager.MergeTemplate(*target, template.Name)
+		action = i18n.T("合并模板 ") + template.Name
+	} else {
+		cfg, err = b.manager.ApplyTemplate(template.Name)
+	}
+	if err != nil {
+		b.err = err
+		return ct, nil
+	}
+
+	*target = cfg
+	ct.recordChange(service.ActionTemplateApply, action)
+	ct.templates = nil
+	ct.state = ConfigTabMenu
+	return ct, showStatusMessage(i18n.Sprintf("✅ 已%s，可按 %s 撤销，确认后请保存配置", action, ct.keys.Config.Undo.Help().Key), false)
+}
+
+// renderTemplateBrowser 渲染模板列表和预览
+func (ct *ConfigTab) renderTemplateBrowser(width int) string {
+	b := ct.templates
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color("#FAFAFA"))
+
+	if b.catalogMode {
+		return ct.renderCatalog(width)
+	}
+
+	content := titleStyle.Render(i18n.T("📋 配置模板")) + "\n\n"
+	content += hintStyle.Render(i18n.T("用户模板目录: ")+config.GetTemplateDir()) + "\n\n"
+
+	for i, template := range b.templates {
+		typeLabel := i18n.T("客户端")
+		if template.Type == "server" {
+			typeLabel = i18n.T("服务端")
+		}
+		source := i18n.T("自定义")
+		if template.Builtin {
+			source = i18n.T("内置")
+		}
+
+		line := fmt.Sprintf("[%s|%s] %s", typeLabel, source, template.Name)
+		if i == b.selected {
+			content += "▶ " + selectedStyle.Render(line) + "\n"
+		} else {
+			content += "  " + line + "\n"
+		}
+	}
+	content += "\n"
+
+	if template := b.current(); template != nil {
+		if template.Description != "" {
+			content += hintStyle.Render(template.Description) + "\n"
+		}
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render(i18n.T("👀 模板内容")) + "\n"
+		content += renderTemplatePreview(template, width) + "\n"
+	}
+
+	switch b.inputMode {
+	case templateInputSaveServer, templateInputSaveClient:
+		content += i18n.T("新模板名称: ") + b.input.View() + "\n"
+		content += hintStyle.Render(i18n.T("Enter 保存 | ESC 取消")) + "\n"
+	case templateInputRename:
+		content += i18n.T("重命名为: ") + b.input.View() + "\n"
+		content += hintStyle.Render(i18n.T("Enter 确认 | ESC 取消")) + "\n"
+	case templateInputConfirmDelete:
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("226")).
+			Render(i18n.Sprintf("确定删除模板 %s 吗？(y/N)", b.current().Name)) + "\n"
+	}
+
+	if b.err != nil {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+b.err.Error()) + "\n"
+	}
+
+	if b.inputMode == templateInputNone {
+		keys := ct.keys.Config
+		content += hintStyle.Render(i18n.Sprintf("%s 应用(替换) | %s 合并到当前配置 | %s/%s 保存当前服务端/客户端配置为模板 | %s 重命名 | %s 删除 | %s 在线模板 | ESC 返回",
+			keys.ApplyTemplate.Help().Key, keys.MergeTemplate.Help().Key, keys.SaveServerTemplate.Help().Key,
+			keys.SaveClientTemplate.Help().Key, keys.RenameTemplate.Help().Key, keys.DeleteTemplate.Help().Key,
+			keys.OnlineTemplates.Help().Key))
+	}
+
+	return content
+}
+
+// renderCatalog 渲染在线模板目录
+func (ct *ConfigTab) renderCatalog(width int) string {
+	b := ct.templates
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color("#FAFAFA"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
+
+	content := titleStyle.Render(i18n.T("🌐 在线模板")) + "\n\n"
+	if b.catalogURL != "" {
+		content += hintStyle.Render(i18n.T("目录地址: ")+b.catalogURL) + "\n"
+	}
+
+	if b.loading {
+		content += i18n.T("\n⏳ 正在获取模板目录...\n")
+	}
+
+	if b.catalog != nil {
+		catalog := b.catalog.Catalog
+		if catalog.Name != "" {
+			content += lipgloss.NewStyle().Bold(true).Render(catalog.Name) + "\n"
+		}
+		if catalog.Description != "" {
+			content += hintStyle.Render(catalog.Description) + "\n"
+		}
+		if b.catalog.FromCache {
+			note := i18n.Sprintf("使用 %s 的缓存", b.catalog.CachedAt.Format("2006-01-02 15:04"))
+			if b.catalog.FetchErr != nil {
+				content += warnStyle.Render(i18n.T("📴 离线模式，")+note+": "+b.catalog.FetchErr.Error()) + "\n"
+			} else {
+				content += hintStyle.Render(note+i18n.Sprintf("，按 %s 刷新", ct.keys.Config.RefreshCatalog.Help().Key)) + "\n"
+			}
+		}
+		if b.catalog.Skipped > 0 {
+			content += warnStyle.Render(i18n.Sprintf("⚠️ 已忽略 %d 个格式不完整的模板", b.catalog.Skipped)) + "\n"
+		}
+		content += "\n"
+
+		for i, template := range catalog.Templates {
+			typeLabel := i18n.T("客户端")
+			if template.Type == "server" {
+				typeLabel = i18n.T("服务端")
+			}
+			line := fmt.Sprintf("[%s] %s", typeLabel, template.Name)
+			if _, err := b.manager.GetTemplate(template.Name); err == nil {
+				line += i18n.T(" (本地已有同名模板)")
+			}
+			if i == b.catalogSelected {
+				content += "▶ " + selectedStyle.Render(line) + "\n"
+			} else {
+				content += "  " + line + "\n"
+			}
+		}
+		content += "\n"
+
+		if template := b.currentCatalogTemplate(); template != nil {
+			if template.Description != "" {
+				content += hintStyle.Render(template.Description) + "\n"
+			}
+			content += lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true).Render(i18n.T("👀 模板内容")) + "\n"
+			content += renderTemplatePreview(template, width) + "\n"
+		}
+	}
+
+	if b.err != nil {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+b.err.Error()) + "\n"
+	}
+	content += hintStyle.Render(i18n.Sprintf("↑/↓ 选择 | %s 导入到本地 | %s 刷新 | ESC 返回本地模板",
+		ct.keys.Config.ImportTemplate.Help().Key, ct.keys.Config.RefreshCatalog.Help().Key))
+
+	return content
+}
+
+// renderTemplatePreview 以 YAML 形式预览模板内容
+func renderTemplatePreview(template *config.ConfigTemplate, width int) string {
+	data, err := config.MarshalConfig(template.Config, config.FormatYAML)
+	if err != nil {
+		return i18n.T("生成预览失败: ") + err.Error()
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	truncated := len(lines) > templatePreviewMaxLines
+	if truncated {
+		lines = lines[:templatePreviewMaxLines]
+	}
+
+	previewStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+	var b strings.Builder
+	for _, line := range lines {
+		if width > 4 {
+			line = truncateString(line, width-2)
+		}
+		b.WriteString(previewStyle.Render("  "+line) + "\n")
+	}
+	if truncated {
+		b.WriteString(previewStyle.Render("  …") + "\n")
+	}
+	return b.String()
+}
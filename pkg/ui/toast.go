@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ToastLevel 通知级别
+type ToastLevel int
+
+const (
+	ToastInfo    ToastLevel = iota // 普通提示
+	ToastSuccess                   // 操作成功
+	ToastWarning                   // 警告
+	ToastError                     // 操作失败
+	ToastPending                   // 进行中的步骤，下一条进行中或结果通知会替换它
+)
+
+// maxToasts 同时显示的通知数，超出时丢弃最早的
+const maxToasts = 4
+
+// duration 通知自动消失前的显示时长，错误停留更久
+func (l ToastLevel) duration() time.Duration {
+	switch l {
+	case ToastError:
+		return 15 * time.Second
+	case ToastWarning:
+		return 10 * time.Second
+	case ToastPending:
+		return 60 * time.Second
+	default:
+		return 6 * time.Second
+	}
+}
+
+// color 通知级别对应的颜色
+func (l ToastLevel) color() string {
+	switch l {
+	case ToastSuccess:
+		return "46"
+	case ToastWarning:
+		return "214"
+	case ToastError:
+		return "196"
+	case ToastPending:
+		return "245"
+	default:
+		return "39"
+	}
+}
+
+// Toast 一条临时通知
+type Toast struct {
+	ID    int64
+	Level ToastLevel
+	Text  string
+	At    time.Time
+}
+
+// toastExpireMsg 通知到期，需要从队列中移除
+type toastExpireMsg struct {
+	id int64
+}
+
+// ToastQueue 操作结果通知队列，每条通知按级别自动消失，互不覆盖
+type ToastQueue struct {
+	toasts []Toast
+	nextID int64
+}
+
+// Push 添加一条通知并返回到期后移除它的命令。
+// 最新一条是进行中的通知时，新通知替换它，让一个操作的各个步骤只占一行
+func (q *ToastQueue) Push(level ToastLevel, text string) tea.Cmd {
+	if n := len(q.toasts); n > 0 && q.toasts[n-1].Level == ToastPending {
+		q.toasts = q.toasts[:n-1]
+	}
+
+	q.nextID++
+	toast := Toast{ID: q.nextID, Level: level, Text: text, At: time.Now()}
+	q.toasts = append(q.toasts, toast)
+	if len(q.toasts) > maxToasts {
+		q.toasts = q.toasts[len(q.toasts)-maxToasts:]
+	}
+
+	return tea.Tick(level.duration(), func(time.Time) tea.Msg {
+		return toastExpireMsg{id: toast.ID}
+	})
+}
+
+// Dismiss 移除指定通知，已被替换或丢弃的通知忽略
+func (q *ToastQueue) Dismiss(id int64) {
+	for i, toast := range q.toasts {
+		if toast.ID == id {
+			q.toasts = append(q.toasts[:i], q.toasts[i+1:]...)
+			return
+		}
+	}
+}
+
+// Toasts 返回当前显示的通知，最早的在前
+func (q *ToastQueue) Toasts() []Toast {
+	return q.toasts
+}
+
+// statusToastLevel 根据状态消息的错误标记和开头的图标推断通知级别
+func statusToastLevel(msg statusMessageMsg) ToastLevel {
+	switch {
+	case msg.isError:
+		return ToastError
+	case strings.HasPrefix(msg.text, "⏳"):
+		return ToastPending
+	case strings.HasPrefix(msg.text, "⚠"):
+		return ToastWarning
+	case strings.HasPrefix(msg.text, "✅"):
+		return ToastSuccess
+	default:
+		return ToastInfo
+	}
+}
+
+// renderToasts 渲染通知列表，每条通知右对齐并带有时间和级别颜色的左边框
+func renderToasts(toasts []Toast, width int) string {
+	timeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	lines := make([]string, 0, len(toasts))
+	for _, toast := range toasts {
+		color := lipgloss.Color(toast.Level.color())
+		border := lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(color).
+			PaddingLeft(1)
+		text := truncateString(strings.ReplaceAll(toast.Text, "\n", " "), max(10, width-12))
+		line := timeStyle.Render(toast.At.Format("15:04:05")) + " " + lipgloss.NewStyle().Foreground(color).Render(text)
+		lines = append(lines, border.Render(line))
+	}
+	return lipgloss.NewStyle().Width(width).Align(lipgloss.Right).Render(lipgloss.JoinVertical(lipgloss.Right, lines...))
+}
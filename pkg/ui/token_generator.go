@@ -0,0 +1,106 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// formNotice 表单下方的提示，只在生成它的表单上显示
+type formNotice struct {
+	form *ConfigFormModel
+	text string
+	err  error
+}
+
+// generateSecret 为当前聚焦的 token/secretKey 输入框生成随机值并复制到剪贴板
+func (ct *ConfigTab) generateSecret() (Tab, tea.Cmd) {
+	ct.notice = formNotice{form: ct.currentForm}
+
+	length := config.DefaultTokenLength
+	if ct.appSettings != nil {
+		length = ct.appSettings.TokenLength
+	}
+	token, err := config.GenerateToken(length)
+	if err != nil {
+		ct.notice.err = err
+		return ct, nil
+	}
+
+	field := ct.currentForm.SetFocusedSecret(token)
+	if field == "" {
+		ct.notice.err = i18n.Errorf("请先将焦点移到认证令牌或密钥输入框")
+		return ct, nil
+	}
+
+	method := copyToClipboard(token)
+	if field == "secretKey" {
+		ct.notice.text = i18n.Sprintf("🔑 已生成 %d 位密钥并复制到剪贴板 (%s)", length, method)
+	} else {
+		ct.notice.text = i18n.Sprintf("🔑 已生成 %d 位认证令牌并复制到剪贴板 (%s)", length, method)
+	}
+	if ct.currentForm.formType == ServerConfigForm || ct.currentForm.formType == ProxyConfigForm {
+		ct.notice.text += i18n.Sprintf("，按 %s 同步到客户端配置", ct.keys.Config.SyncToken.Help().Key)
+	}
+	return ct, nil
+}
+
+// syncSecret 将服务端表单的 token 同步到客户端配置，或将代理表单的密钥同步到对应的访问者
+func (ct *ConfigTab) syncSecret() (Tab, tea.Cmd) {
+	ct.notice = formNotice{form: ct.currentForm}
+
+	if ct.clientConfig == nil {
+		ct.notice.err = i18n.Errorf("尚未加载或创建客户端配置")
+		return ct, nil
+	}
+
+	switch ct.currentForm.formType {
+	case ServerConfigForm:
+		token := ct.currentForm.FormValue("token")
+		if token == "" {
+			ct.notice.err = i18n.Errorf("认证令牌为空")
+			return ct, nil
+		}
+		ct.clientConfig.Auth.Token = token
+		ct.recordHistory(i18n.T("同步认证令牌到客户端配置"))
+		ct.notice.text = i18n.T("✅ 已同步认证令牌到客户端配置，保存后生效")
+
+	case ProxyConfigForm:
+		name, secretKey := ct.currentForm.FormValue("name"), ct.currentForm.FormValue("secretKey")
+		if secretKey == "" {
+			ct.notice.err = i18n.Errorf("密钥为空")
+			return ct, nil
+		}
+		synced := 0
+		for i := range ct.clientConfig.Visitors {
+			if ct.clientConfig.Visitors[i].ServerName == name {
+				ct.clientConfig.Visitors[i].SecretKey = secretKey
+				synced++
+			}
+		}
+		if synced == 0 {
+			ct.notice.err = i18n.Errorf("客户端配置中没有 serverName 为 '%s' 的访问者", name)
+			return ct, nil
+		}
+		ct.recordHistory(i18n.T("同步密钥到访问者 ") + name)
+		ct.notice.text = i18n.Sprintf("✅ 已同步密钥到 %d 个访问者，保存后生效", synced)
+
+	default:
+		ct.notice.err = i18n.Errorf("当前表单没有可同步的令牌")
+	}
+
+	return ct, nil
+}
+
+// renderFormNotice 渲染当前表单的提示
+func (ct *ConfigTab) renderFormNotice() string {
+	if ct.notice.form == nil || ct.notice.form != ct.currentForm {
+		return ""
+	}
+	if ct.notice.err != nil {
+		return "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+ct.notice.err.Error())
+	}
+	return "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render(ct.notice.text)
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"frp-cli-ui/pkg/config"
+)
+
+func TestSyncTokenRoundTrip(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	token, err := config.GenerateToken(config.DefaultTokenLength)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	ct := NewConfigTab()
+	ct.clientConfig = &config.Config{ServerAddr: "example.com", ServerPort: 7000}
+	ct.currentForm = NewServerConfigForm(&config.Config{BindPort: 7000})
+	*ct.currentForm.formData["token"] = token
+	ct.currentForm.updateConfigFromForm()
+
+	ct.syncSecret()
+	if ct.notice.err != nil {
+		t.Fatalf("syncSecret: %v", ct.notice.err)
+	}
+
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		name   string
+		cfg    *config.Config
+		server bool
+	}{
+		{"frps.toml", ct.currentForm.GetConfig(), true},
+		{"frpc.yaml", ct.clientConfig, false},
+	} {
+		path := filepath.Join(dir, tt.name)
+		if err := config.NewLoader(path).Save(tt.cfg); err != nil {
+			t.Fatalf("Save %s: %v", tt.name, err)
+		}
+
+		loaded, err := config.NewLoader(path).Load()
+		if err != nil {
+			t.Fatalf("Load %s: %v", tt.name, err)
+		}
+		if loaded.Auth.Token != token {
+			t.Errorf("%s: Auth.Token = %q, want %q", tt.name, loaded.Auth.Token, token)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		issues, err := config.CheckSchema(config.StripFileMeta(data), config.DetectFormat(path), tt.server)
+		if err != nil {
+			t.Fatalf("CheckSchema %s: %v", tt.name, err)
+		}
+		for _, issue := range issues {
+			t.Errorf("%s: unexpected schema issue: %s", tt.name, issue)
+		}
+	}
+}
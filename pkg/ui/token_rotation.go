@@ -0,0 +1,394 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/remote"
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// 令牌轮换的阶段
+const (
+	rotationChoose    = iota // 选择服务端
+	rotationPreparing        // 读取各配置当前的令牌
+	rotationReview           // 确认清单
+	rotationRunning
+	rotationDone
+)
+
+// 清单中每份配置的状态
+const (
+	rotationItemPending = iota
+	rotationItemRunning
+	rotationItemDone
+	rotationItemFailed
+	rotationItemSkipped
+)
+
+// rotationPrepareMsg 读取服务端和各客户端当前令牌的结果
+type rotationPrepareMsg struct {
+	id       int
+	oldToken string
+	err      error   // 读取服务端配置失败
+	linked   []bool  // 客户端是否使用与服务端相同的令牌
+	errs     []error // 读取客户端配置失败的原因
+}
+
+// rotationStepMsg 一份配置更新并重启完成
+type rotationStepMsg struct {
+	id     int
+	index  int
+	result string
+	err    error
+}
+
+// rotationItem 清单中的一份配置
+type rotationItem struct {
+	target service.RotationTarget
+	status int
+	result string
+	err    error
+}
+
+// tokenRotation 认证令牌轮换面板的状态：先更新并重启服务端，再依次更新并重启使用旧令牌的客户端
+type tokenRotation struct {
+	id      int // 读取和执行的编号，忽略面板关闭后返回的结果
+	phase   int
+	servers []service.RotationTarget
+	clients []service.RotationTarget
+	cursor  int
+	token   string
+	items   []rotationItem // 第一项是服务端
+	err     error
+}
+
+// handleTokenRotation 打开令牌轮换面板，列出本机和远程服务器上的 frps/frpc 配置
+func (ct *ConfigTab) handleTokenRotation() (Tab, tea.Cmd) {
+	if ct.manager == nil {
+		return ct, showStatusMessage(i18n.T("❌ 进程管理器不可用"), true)
+	}
+
+	r := &tokenRotation{}
+	if fileExists(ct.serverConfigPath) {
+		r.servers = append(r.servers, service.LocalRotationTarget(true, ct.serverConfigPath))
+	}
+	if fileExists(ct.clientConfigPath) {
+		r.clients = append(r.clients, service.LocalRotationTarget(false, ct.clientConfigPath))
+	}
+
+	profiles, err := config.LoadRemoteProfiles()
+	r.err = err
+	for _, profile := range profiles {
+		if profile.IsClient() {
+			r.clients = append(r.clients, service.RemoteRotationTarget(profile))
+		} else {
+			r.servers = append(r.servers, service.RemoteRotationTarget(profile))
+		}
+	}
+
+	ct.currentForm = nil
+	ct.focusOnForm = false
+	ct.rotation = r
+	ct.state = ConfigTabTokenRotation
+	return ct, nil
+}
+
+// fileExists 判断文件是否存在
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// prepareRotation 读取选中服务端的令牌，找出仍在使用该令牌的客户端
+func (ct *ConfigTab) prepareRotation() tea.Cmd {
+	r := ct.rotation
+	if len(r.servers) == 0 {
+		return nil
+	}
+
+	length := config.DefaultTokenLength
+	if ct.appSettings != nil {
+		length = ct.appSettings.TokenLength
+	}
+	token, err := config.GenerateToken(length)
+	if err != nil {
+		r.err = err
+		return nil
+	}
+
+	r.id++
+	r.phase, r.token, r.err = rotationPreparing, token, nil
+	r.items = []rotationItem{{target: r.servers[r.cursor]}}
+	for _, client := range r.clients {
+		r.items = append(r.items, rotationItem{target: client})
+	}
+
+	id, server, clients := r.id, r.servers[r.cursor], r.clients
+	return func() tea.Msg {
+		msg := rotationPrepareMsg{id: id, linked: make([]bool, len(clients)), errs: make([]error, len(clients))}
+		msg.oldToken, msg.err = server.ReadToken()
+		if msg.err != nil {
+			return msg
+		}
+		for i, client := range clients {
+			token, err := client.ReadToken()
+			msg.linked[i], msg.errs[i] = err == nil && token == msg.oldToken, err
+		}
+		return msg
+	}
+}
+
+// handleRotationPrepared 根据读取结果生成清单，令牌不同或读取失败的客户端跳过
+func (ct *ConfigTab) handleRotationPrepared(msg rotationPrepareMsg) {
+	r := ct.rotation
+	if r == nil || msg.id != r.id {
+		return
+	}
+	if msg.err != nil {
+		r.phase, r.err = rotationChoose, msg.err
+		return
+	}
+
+	r.phase = rotationReview
+	for i := range msg.linked {
+		item := &r.items[i+1]
+		switch {
+		case msg.errs[i] != nil:
+			item.status, item.err = rotationItemSkipped, msg.errs[i]
+		case !msg.linked[i]:
+			item.status, item.result = rotationItemSkipped, i18n.T("令牌与服务端不同，未关联")
+		}
+	}
+}
+
+// startRotation 按清单顺序开始轮换，服务端最先更新
+func (ct *ConfigTab) startRotation() tea.Cmd {
+	r := ct.rotation
+	r.phase = rotationRunning
+	ct.events.Publish(service.UserActionEvent(service.ActionTokenRotate,
+		i18n.Sprintf("轮换认证令牌: %s", r.items[0].target.Name)))
+	return ct.runRotationStep(0)
+}
+
+// runRotationStep 更新并重启清单中的第 index 项
+func (ct *ConfigTab) runRotationStep(index int) tea.Cmd {
+	r := ct.rotation
+	r.items[index].status = rotationItemRunning
+
+	id, target, token, manager := r.id, r.items[index].target, r.token, ct.manager
+	return func() tea.Msg {
+		result, err := manager.RotateToken(target, token)
+		return rotationStepMsg{id: id, index: index, result: result, err: err}
+	}
+}
+
+// handleRotationStep 记录一项的结果并继续下一项。服务端失败时停止，客户端之间互不影响
+func (ct *ConfigTab) handleRotationStep(msg rotationStepMsg) tea.Cmd {
+	r := ct.rotation
+	if r == nil || msg.id != r.id {
+		return nil
+	}
+
+	item := &r.items[msg.index]
+	item.result, item.err = msg.result, msg.err
+	item.status = rotationItemDone
+	if msg.err != nil {
+		item.status = rotationItemFailed
+	} else if item.target.Remote == nil {
+		ct.syncRotatedToken(item.target)
+	}
+
+	if msg.index == 0 && msg.err != nil {
+		for i := range r.items[1:] {
+			if r.items[i+1].status == rotationItemPending {
+				r.items[i+1].status, r.items[i+1].result = rotationItemSkipped, i18n.T("服务端未完成轮换，未修改")
+			}
+		}
+	}
+	for i := msg.index + 1; i < len(r.items); i++ {
+		if r.items[i].status == rotationItemPending {
+			return ct.runRotationStep(i)
+		}
+	}
+
+	r.phase = rotationDone
+	failed := 0
+	for _, item := range r.items {
+		if item.status == rotationItemFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return showStatusMessage(i18n.Sprintf("❌ 令牌轮换完成，%d 项失败", failed), true)
+	}
+	return showStatusMessage(i18n.T("✅ 令牌轮换完成"), false)
+}
+
+// syncRotatedToken 本机配置已写入新令牌，同步到已加载的配置，避免之后保存时写回旧令牌
+func (ct *ConfigTab) syncRotatedToken(target service.RotationTarget) {
+	if target.Server && ct.serverConfig != nil && target.ConfigPath == ct.serverConfigPath {
+		ct.serverConfig.Auth.Token = ct.rotation.token
+	}
+	if !target.Server && ct.clientConfig != nil && target.ConfigPath == ct.clientConfigPath {
+		ct.clientConfig.Auth.Token = ct.rotation.token
+	}
+}
+
+// updateTokenRotation 处理令牌轮换面板中的按键
+func (ct *ConfigTab) updateTokenRotation(msg tea.KeyMsg) (Tab, tea.Cmd) {
+	r := ct.rotation
+	keys := ct.keys.Config
+
+	if r.phase == rotationRunning {
+		// 轮换进行中不能关闭，否则服务端和客户端的令牌可能不一致
+		return ct, nil
+	}
+	if msg.String() == "esc" {
+		if r.phase == rotationPreparing || r.phase == rotationReview {
+			// 尚未修改任何配置，丢弃读取结果后重新选择
+			r.id++
+			r.phase = rotationChoose
+			return ct, nil
+		}
+		ct.rotation = nil
+		ct.state = ConfigTabMenu
+		return ct, nil
+	}
+
+	switch r.phase {
+	case rotationChoose:
+		switch {
+		case key.Matches(msg, keys.Up):
+			if r.cursor > 0 {
+				r.cursor--
+			}
+		case key.Matches(msg, keys.Down):
+			if r.cursor < len(r.servers)-1 {
+				r.cursor++
+			}
+		case key.Matches(msg, keys.Select):
+			return ct, ct.prepareRotation()
+		}
+	case rotationReview:
+		if msg.String() == "y" {
+			return ct, ct.startRotation()
+		}
+	case rotationDone:
+		if key.Matches(msg, keys.CopyToken) {
+			method := copyToClipboard(r.token)
+			return ct, showStatusMessage(i18n.Sprintf("📋 已复制新令牌到剪贴板 (%s)", method), false)
+		}
+	}
+	return ct, nil
+}
+
+// renderTokenRotation 渲染令牌轮换面板
+func (ct *ConfigTab) renderTokenRotation() string {
+	r := ct.rotation
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Padding(0, 0, 1, 0)
+	selectedStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color("#7D56F4")).
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Padding(0, 1)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
+
+	content := titleStyle.Render(i18n.T("🔁 轮换认证令牌")) + "\n"
+	if r.err != nil {
+		content += errorStyle.Render("❌ "+rotationErrorText(r.err)) + "\n\n"
+	}
+
+	if r.phase == rotationChoose {
+		if len(r.servers) == 0 {
+			content += i18n.T("没有可轮换的服务端：本机服务端配置不存在，也没有添加 frps 远程服务器") + "\n\n"
+			return content + hintStyle.Render(i18n.T("ESC 返回菜单"))
+		}
+		content += i18n.T("选择要轮换令牌的服务端，使用相同令牌的本机客户端和 frpc 远程服务器会一并更新:") + "\n\n"
+		for i, server := range r.servers {
+			line := fmt.Sprintf("%s (%s)", server.Name, server.Location())
+			if i == r.cursor {
+				content += "▶ " + selectedStyle.Render(line) + "\n"
+			} else {
+				content += "  " + line + "\n"
+			}
+		}
+		return content + "\n" + hintStyle.Render(i18n.T("↑↓: 选择 • Enter: 读取令牌 • ESC: 返回菜单"))
+	}
+
+	if r.phase == rotationPreparing {
+		return content + i18n.T("⏳ 正在读取服务端和客户端当前的令牌...") + "\n\n" + hintStyle.Render(i18n.T("ESC: 重新选择服务端"))
+	}
+
+	content += i18n.Sprintf("新令牌: %s", maskToken(r.token)) + "\n\n"
+	for i, item := range r.items {
+		role := "frpc"
+		if i == 0 {
+			role = "frps"
+		}
+		line := fmt.Sprintf("%s %s [%s] %s", rotationIcon(item.status), item.target.Name, role, hintStyle.Render(item.target.Location()))
+		switch {
+		case item.err != nil:
+			line += "\n    " + errorStyle.Render(rotationErrorText(item.err))
+		case item.result != "":
+			line += "\n    " + hintStyle.Render(item.result)
+		}
+		content += line + "\n"
+	}
+	content += "\n"
+
+	switch r.phase {
+	case rotationReview:
+		content += warnStyle.Render(i18n.T("将依次写入新令牌并重启：先服务端，再各客户端。确认开始？(y/N)")) + "\n"
+		content += hintStyle.Render(i18n.T("ESC: 重新选择服务端"))
+	case rotationRunning:
+		content += hintStyle.Render(i18n.T("⏳ 正在轮换，完成前无法关闭"))
+	case rotationDone:
+		content += hintStyle.Render(i18n.Sprintf("%s: 复制新令牌 • ESC: 返回菜单", ct.keys.Config.CopyToken.Help().Key))
+	}
+	return content
+}
+
+// rotationIcon 返回清单项状态的图标
+func rotationIcon(status int) string {
+	switch status {
+	case rotationItemRunning:
+		return "🔄"
+	case rotationItemDone:
+		return "✅"
+	case rotationItemFailed:
+		return "❌"
+	case rotationItemSkipped:
+		return "⏭️"
+	default:
+		return "⬜"
+	}
+}
+
+// rotationErrorText 返回错误说明，远程主机未被信任时提示先到远程服务器页确认指纹
+func rotationErrorText(err error) string {
+	var unknown *remote.UnknownHostError
+	if errors.As(err, &unknown) {
+		return i18n.Sprintf("%v，请先在远程服务器页测试连接并信任该主机", err)
+	}
+	return err.Error()
+}
+
+// maskToken 只显示令牌的首尾几位
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return token
+	}
+	return token[:4] + "…" + token[len(token)-4:]
+}
@@ -0,0 +1,561 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"frp-cli-ui/internal/service"
+	"frp-cli-ui/pkg/config"
+	"frp-cli-ui/pkg/i18n"
+)
+
+// sparkBlocks 迷你图使用的块字符，从低到高
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// trafficWindows 可选的时间窗口
+var trafficWindows = []time.Duration{5 * time.Minute, 10 * time.Minute, 30 * time.Minute, time.Hour, 6 * time.Hour, 24 * time.Hour}
+
+// trafficHistoryMsg 代理流量历史消息
+type trafficHistoryMsg struct {
+	name    string
+	history *service.ProxyTrafficHistory
+	err     error
+}
+
+// trafficSample 一次采样的流量增量
+type trafficSample struct {
+	at  time.Time
+	in  int64
+	out int64
+}
+
+// trafficSeries 单个代理的采样序列
+type trafficSeries struct {
+	lastIn  int64
+	lastOut int64
+	lastAt  time.Time
+	samples []trafficSample
+}
+
+// TrafficTab 代理流量图表标签页
+type TrafficTab struct {
+	BaseTab
+	apiClient    *service.APIClient
+	keys         *KeyMap
+	series       map[string]*trafficSeries
+	names        []string
+	selected     int
+	windowIndex  int
+	history      *service.ProxyTrafficHistory
+	historyErr   error
+	historyFetch time.Time
+	store        *service.TrafficStore
+	storeErr     error
+	quotas       *service.QuotaTracker
+	quotaUsages  []service.QuotaUsage
+	quotaErr     error
+}
+
+// NewTrafficTab 创建流量标签页
+func NewTrafficTab(apiClient *service.APIClient) *TrafficTab {
+	baseTab := NewBaseTab("流量")
+	baseTab.focusable = true
+
+	return &TrafficTab{
+		BaseTab:     baseTab,
+		apiClient:   apiClient,
+		keys:        DefaultKeyMap(),
+		series:      make(map[string]*trafficSeries),
+		windowIndex: 1,
+	}
+}
+
+// Init 初始化
+func (tt *TrafficTab) Init() tea.Cmd {
+	return nil
+}
+
+// SetKeyMap 设置快捷键
+func (tt *TrafficTab) SetKeyMap(keys *KeyMap) {
+	tt.keys = keys
+}
+
+// SetTrafficStore 设置流量历史存储，并载入最大时间窗口内的历史采样
+func (tt *TrafficTab) SetTrafficStore(store *service.TrafficStore) {
+	tt.store = store
+	if store == nil {
+		return
+	}
+
+	maxWindow := trafficWindows[len(trafficWindows)-1]
+	records, err := store.Load(time.Now().Add(-maxWindow))
+	if err != nil {
+		tt.storeErr = err
+		return
+	}
+
+	for _, record := range records {
+		s, ok := tt.series[record.Name]
+		if !ok {
+			s = &trafficSeries{}
+			tt.series[record.Name] = s
+		}
+		// 最后一条记录的计数器值作为基线，应用重启后继续计算增量
+		s.lastIn, s.lastOut, s.lastAt = record.TotalIn, record.TotalOut, record.Time
+		if record.In > 0 || record.Out > 0 {
+			s.samples = append(s.samples, trafficSample{at: record.Time, in: record.In, out: record.Out})
+		}
+	}
+	tt.refreshNames()
+}
+
+// SetQuotaTracker 设置流量配额统计，新的采样同时计入配额用量
+func (tt *TrafficTab) SetQuotaTracker(tracker *service.QuotaTracker) {
+	tt.quotas = tracker
+}
+
+// SetAppSettings 设置应用配置，更新流量历史保留天数和流量配额
+func (tt *TrafficTab) SetAppSettings(settings *config.AppSettings) {
+	if tt.store != nil {
+		tt.store.SetRetention(settings.TrafficRetention())
+	}
+	if tt.quotas != nil {
+		tt.quotas.SetQuotas(settings.TrafficQuotas)
+		tt.refreshQuotas()
+	}
+}
+
+// refreshQuotas 重新计算配额用量
+func (tt *TrafficTab) refreshQuotas() {
+	if tt.quotas != nil {
+		tt.quotaUsages, tt.quotaErr = tt.quotas.Usage(time.Now())
+	}
+}
+
+// RecordSample 根据代理今日累计流量记录一次增量采样
+func (tt *TrafficTab) RecordSample(proxies []ProxyStatus, at time.Time) {
+	var records []service.TrafficRecord
+	for _, proxy := range proxies {
+		if record, ok := tt.record(proxy.Name, proxy.TodayTrafficIn, proxy.TodayTrafficOut, at); ok {
+			records = append(records, record)
+		}
+	}
+	tt.persist(records)
+	tt.refreshNames()
+	tt.refreshQuotas()
+}
+
+// RecordServerSample 根据服务端总流量计数器记录一次增量采样
+func (tt *TrafficTab) RecordServerSample(totalIn, totalOut int64, at time.Time) {
+	if record, ok := tt.record(service.ServerTrafficSeries, totalIn, totalOut, at); ok {
+		tt.persist([]service.TrafficRecord{record})
+	}
+	tt.refreshNames()
+	tt.refreshQuotas()
+}
+
+// record 计算一个序列的增量并追加采样，返回需要持久化的记录
+func (tt *TrafficTab) record(name string, totalIn, totalOut int64, at time.Time) (service.TrafficRecord, bool) {
+	maxWindow := trafficWindows[len(trafficWindows)-1]
+	record := service.TrafficRecord{Time: at, Name: name, TotalIn: totalIn, TotalOut: totalOut}
+
+	s, ok := tt.series[name]
+	if !ok {
+		// 第一次见到的代理只记录基线，避免把今日累计量当成一次增量
+		tt.series[name] = &trafficSeries{lastIn: totalIn, lastOut: totalOut, lastAt: at}
+		return record, true
+	}
+
+	deltaIn := totalIn - s.lastIn
+	deltaOut := totalOut - s.lastOut
+	// 代理计数器只统计当天流量，应用关闭期间跨天时基线已失效，整个计数器都是新增量
+	if name != service.ServerTrafficSeries && at.Sub(s.lastAt) > time.Hour &&
+		s.lastAt.Format("20060102") != at.Format("20060102") {
+		deltaIn, deltaOut = totalIn, totalOut
+	}
+	// 跨天、代理或 frps 重启时累计值会归零
+	if deltaIn < 0 {
+		deltaIn = totalIn
+	}
+	if deltaOut < 0 {
+		deltaOut = totalOut
+	}
+
+	s.lastIn, s.lastOut, s.lastAt = totalIn, totalOut, at
+	s.samples = append(s.samples, trafficSample{at: at, in: deltaIn, out: deltaOut})
+
+	// 只保留最大时间窗口内的采样
+	cutoff := at.Add(-maxWindow)
+	for len(s.samples) > 0 && s.samples[0].at.Before(cutoff) {
+		s.samples = s.samples[1:]
+	}
+
+	// 没有流量时不写入，基线仍保留在上一条记录中
+	if deltaIn == 0 && deltaOut == 0 {
+		return record, false
+	}
+	record.In, record.Out = deltaIn, deltaOut
+	return record, true
+}
+
+// persist 将采样写入流量历史存储
+func (tt *TrafficTab) persist(records []service.TrafficRecord) {
+	if tt.store != nil {
+		tt.storeErr = tt.store.Append(records)
+	}
+	if tt.quotas != nil && tt.storeErr == nil {
+		tt.quotas.Add(records)
+	}
+}
+
+// refreshNames 刷新代理列表，服务端总计排在最前
+func (tt *TrafficTab) refreshNames() {
+	names := make([]string, 0, len(tt.series))
+	for name := range tt.series {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == service.ServerTrafficSeries || names[j] == service.ServerTrafficSeries {
+			return names[i] == service.ServerTrafficSeries
+		}
+		return names[i] < names[j]
+	})
+	tt.names = names
+
+	if tt.selected >= len(tt.names) {
+		tt.selected = 0
+	}
+}
+
+// seriesLabel 返回序列的显示名称
+func seriesLabel(name string) string {
+	if name == service.ServerTrafficSeries {
+		return i18n.T("🖥 服务端总计")
+	}
+	return name
+}
+
+// formatWindow 格式化时间窗口
+func formatWindow(window time.Duration) string {
+	if window >= time.Hour {
+		return i18n.Sprintf("%d 小时", int(window.Hours()))
+	}
+	return i18n.Sprintf("%d 分钟", int(window.Minutes()))
+}
+
+// selectedName 当前选中的代理名称
+func (tt *TrafficTab) selectedName() string {
+	if tt.selected < len(tt.names) {
+		return tt.names[tt.selected]
+	}
+	return ""
+}
+
+// Update 更新状态
+func (tt *TrafficTab) Update(msg tea.Msg) (Tab, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !tt.focused {
+			return tt, nil
+		}
+
+		switch {
+		case key.Matches(msg, tt.keys.Traffic.Up):
+			if tt.selected > 0 {
+				tt.selected--
+				return tt, tt.fetchHistory()
+			}
+		case key.Matches(msg, tt.keys.Traffic.Down):
+			if tt.selected < len(tt.names)-1 {
+				tt.selected++
+				return tt, tt.fetchHistory()
+			}
+		case key.Matches(msg, tt.keys.Traffic.Window):
+			tt.windowIndex = (tt.windowIndex + 1) % len(trafficWindows)
+		case key.Matches(msg, tt.keys.Traffic.Refresh):
+			return tt, tt.fetchHistory()
+		}
+
+	case dashboardTickMsg:
+		// 按天统计的历史变化很慢，每分钟刷新一次即可
+		if time.Since(tt.historyFetch) >= time.Minute || (tt.history == nil && tt.selectedName() != "") {
+			return tt, tt.fetchHistory()
+		}
+
+	case trafficHistoryMsg:
+		if msg.name == tt.selectedName() {
+			tt.history = msg.history
+			tt.historyErr = msg.err
+		}
+	}
+
+	return tt, nil
+}
+
+// fetchHistory 获取当前代理的每日流量历史
+func (tt *TrafficTab) fetchHistory() tea.Cmd {
+	name := tt.selectedName()
+	if name == "" {
+		return nil
+	}
+
+	// frps 只按代理统计每日流量，服务端总计从本地流量历史中汇总
+	fromStore := name == service.ServerTrafficSeries
+	if (fromStore && tt.store == nil) || (!fromStore && tt.apiClient == nil) {
+		return nil
+	}
+
+	tt.historyFetch = time.Now()
+	if tt.history != nil && tt.history.Name != name {
+		tt.history = nil
+	}
+
+	if fromStore {
+		store := tt.store
+		return func() tea.Msg {
+			history, err := store.DailyTotals(name, 7, time.Now())
+			return trafficHistoryMsg{name: name, history: history, err: err}
+		}
+	}
+
+	return func() tea.Msg {
+		history, err := tt.apiClient.GetProxyTraffic(name)
+		return trafficHistoryMsg{name: name, history: history, err: err}
+	}
+}
+
+// View 渲染视图
+func (tt *TrafficTab) View(width int, height int) string {
+	contentWidth := width - 12
+	if contentWidth < 60 {
+		contentWidth = 60
+	}
+
+	leftWidth := 24
+	rightWidth := contentWidth - leftWidth - 4
+
+	leftStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(1).
+		Width(leftWidth)
+
+	rightStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(1).
+		Width(rightWidth)
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		leftStyle.Render(tt.renderProxyList(leftWidth-2)),
+		rightStyle.Render(tt.renderCharts(rightWidth-2)),
+	)
+}
+
+// renderProxyList 渲染代理列表
+func (tt *TrafficTab) renderProxyList(width int) string {
+	content := lipgloss.NewStyle().Bold(true).Render(i18n.T("📡 代理")) + "\n\n"
+
+	if len(tt.names) == 0 {
+		return content + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i18n.T("暂无代理数据\n请确认服务端已运行"))
+	}
+
+	for i, name := range tt.names {
+		line := truncateString(seriesLabel(name), width-2)
+		if i == tt.selected {
+			content += lipgloss.NewStyle().
+				Foreground(lipgloss.Color("229")).
+				Background(lipgloss.Color("57")).
+				Render("▶ "+line) + "\n"
+		} else {
+			content += "  " + line + "\n"
+		}
+	}
+
+	return content
+}
+
+// renderCharts 渲染选中代理的流量图表
+func (tt *TrafficTab) renderCharts(width int) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	inStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	outStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
+
+	name := tt.selectedName()
+	if name == "" {
+		return titleStyle.Render(i18n.T("📈 流量图表")) + "\n\n" + hintStyle.Render(i18n.T("暂无数据"))
+	}
+
+	window := trafficWindows[tt.windowIndex]
+	chartWidth := width - 14
+	if chartWidth < 10 {
+		chartWidth = 10
+	}
+
+	inValues, outValues := tt.bucketSamples(name, window, chartWidth)
+
+	var content string
+	content += titleStyle.Render(i18n.Sprintf("📈 %s - 最近 %s", seriesLabel(name), formatWindow(window))) + "\n\n"
+	content += fmt.Sprintf("%s %s\n", inStyle.Render(i18n.T("入站 ↓")), inStyle.Render(renderSparkline(inValues)))
+	content += hintStyle.Render(i18n.Sprintf("       峰值 %s / 合计 %s", service.FormatTraffic(maxInt64(inValues)), service.FormatTraffic(sumInt64(inValues)))) + "\n\n"
+	content += fmt.Sprintf("%s %s\n", outStyle.Render(i18n.T("出站 ↑")), outStyle.Render(renderSparkline(outValues)))
+	content += hintStyle.Render(i18n.Sprintf("       峰值 %s / 合计 %s", service.FormatTraffic(maxInt64(outValues)), service.FormatTraffic(sumInt64(outValues)))) + "\n\n"
+
+	content += lipgloss.NewStyle().Bold(true).Render(i18n.T("📊 近 7 天流量")) + "\n"
+	switch {
+	case tt.historyErr != nil:
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+tt.historyErr.Error()) + "\n"
+	case tt.history == nil:
+		content += hintStyle.Render(i18n.T("加载中...")) + "\n"
+	default:
+		content += renderDailyBars(tt.history, chartWidth-10, inStyle, outStyle)
+	}
+
+	content += tt.renderQuotas(chartWidth - 10)
+
+	if tt.storeErr != nil {
+		content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(i18n.T("⚠️ 流量历史: ")+tt.storeErr.Error()) + "\n"
+	}
+	content += "\n" + hintStyle.Render(helpLine(" • ", tt.keys.Traffic.Up, tt.keys.Traffic.Down, tt.keys.Traffic.Window, tt.keys.Traffic.Refresh))
+	return content
+}
+
+// renderQuotas 渲染流量配额的用量，没有设置配额时返回空字符串
+func (tt *TrafficTab) renderQuotas(width int) string {
+	if tt.quotaErr == nil && len(tt.quotaUsages) == 0 {
+		return ""
+	}
+
+	content := "\n" + lipgloss.NewStyle().Bold(true).Render(i18n.T("📏 流量配额")) + "\n"
+	if tt.quotaErr != nil {
+		return content + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ "+tt.quotaErr.Error()) + "\n"
+	}
+
+	barWidth := max(width/2, 10)
+	for _, usage := range tt.quotaUsages {
+		color := lipgloss.Color("46")
+		switch {
+		case usage.Exceeded():
+			color = lipgloss.Color("196")
+		case usage.Warning():
+			color = lipgloss.Color("214")
+		}
+		filled := min(int(usage.Ratio()*float64(barWidth)), barWidth)
+		bar := lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+			lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Render(strings.Repeat("░", barWidth-filled))
+
+		line := usage.Quota.DisplayName()
+		if usage.Quota.Direction == config.QuotaIn || usage.Quota.Direction == config.QuotaOut {
+			line += " (" + usage.Quota.Direction + ")"
+		}
+		content += line + "\n"
+		content += fmt.Sprintf("  %s %3.0f%%  %s / %s", bar, usage.Ratio()*100,
+			service.FormatTraffic(usage.Used), service.FormatTraffic(usage.Limit))
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(
+			"  "+i18n.Sprintf("%s 重置", usage.End.Format("01-02"))) + "\n"
+	}
+	return content
+}
+
+// bucketSamples 将时间窗口内的采样按列聚合
+func (tt *TrafficTab) bucketSamples(name string, window time.Duration, columns int) ([]int64, []int64) {
+	inValues := make([]int64, columns)
+	outValues := make([]int64, columns)
+
+	s, ok := tt.series[name]
+	if !ok {
+		return inValues, outValues
+	}
+
+	now := time.Now()
+	start := now.Add(-window)
+	bucket := window / time.Duration(columns)
+
+	for _, sample := range s.samples {
+		if sample.at.Before(start) {
+			continue
+		}
+		index := int(sample.at.Sub(start) / bucket)
+		if index >= columns {
+			index = columns - 1
+		}
+		inValues[index] += sample.in
+		outValues[index] += sample.out
+	}
+
+	return inValues, outValues
+}
+
+// renderSparkline 使用块字符渲染迷你图
+func renderSparkline(values []int64) string {
+	peak := maxInt64(values)
+
+	var b strings.Builder
+	for _, v := range values {
+		if peak == 0 || v == 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		level := int(v * int64(len(sparkBlocks)-1) / peak)
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// renderDailyBars 渲染每日流量横向柱状图
+func renderDailyBars(history *service.ProxyTrafficHistory, width int, inStyle, outStyle lipgloss.Style) string {
+	if width < 10 {
+		width = 10
+	}
+
+	var peak int64
+	for _, v := range append(append([]int64{}, history.TrafficIn...), history.TrafficOut...) {
+		if v > peak {
+			peak = v
+		}
+	}
+
+	bar := func(v int64) string {
+		if peak == 0 {
+			return ""
+		}
+		return strings.Repeat("█", int(v*int64(width)/peak))
+	}
+
+	var content string
+	today := time.Now()
+	for i := 0; i < len(history.TrafficIn) && i < len(history.TrafficOut); i++ {
+		day := today.AddDate(0, 0, -i).Format("01-02")
+		content += fmt.Sprintf("%s ↓ %s %s\n", day, inStyle.Render(bar(history.TrafficIn[i])), service.FormatTraffic(history.TrafficIn[i]))
+		content += fmt.Sprintf("      ↑ %s %s\n", outStyle.Render(bar(history.TrafficOut[i])), service.FormatTraffic(history.TrafficOut[i]))
+	}
+	return content
+}
+
+// maxInt64 返回最大值
+func maxInt64(values []int64) int64 {
+	var peak int64
+	for _, v := range values {
+		if v > peak {
+			peak = v
+		}
+	}
+	return peak
+}
+
+// sumInt64 返回合计
+func sumInt64(values []int64) int64 {
+	var total int64
+	for _, v := range values {
+		total += v
+	}
+	return total
+}